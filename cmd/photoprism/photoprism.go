@@ -64,17 +64,30 @@ func main() {
 		commands.PlacesCommand,
 		commands.PurgeCommand,
 		commands.CleanUpCommand,
+		commands.BrokenCommand,
+		commands.DuplicatesCommand,
+		commands.CheckCommand,
+		commands.RenameCommand,
+		commands.AliasesCommand,
+		commands.AlbumsCommand,
 		commands.OptimizeCommand,
 		commands.MomentsCommand,
 		commands.ConvertCommand,
 		commands.ThumbsCommand,
+		commands.WarmupCommand,
 		commands.MigrateCommand,
+		commands.MigrationsCommand,
 		commands.BackupCommand,
 		commands.RestoreCommand,
+		commands.RestoreMetaCommand,
 		commands.ResetCommand,
 		commands.PasswdCommand,
 		commands.UsersCommand,
+		commands.DLNACommand,
 		commands.ConfigCommand,
+		commands.ConsoleCommand,
+		commands.CompletionCommand,
+		commands.DoctorCommand,
 		commands.VersionCommand,
 	}
 
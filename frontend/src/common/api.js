@@ -89,6 +89,15 @@ Api.interceptors.response.use(
       }
     }
 
+    // Update download token.
+    if (resp.headers && resp.headers["x-download-token"]) {
+      const downloadToken = resp.headers["x-download-token"];
+      if (config.downloadToken !== downloadToken) {
+        config.downloadToken = downloadToken;
+        Event.publish("config.updated", { config: { downloadToken } });
+      }
+    }
+
     return resp;
   },
   function (error) {
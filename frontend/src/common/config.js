@@ -129,6 +129,11 @@ export default class Config {
 
     for (let key in values) {
       if (values.hasOwnProperty(key)) {
+        // Keep session tokens if the update does not include them.
+        if (!values[key] && ["downloadToken", "previewToken", "mapTiles"].includes(key)) {
+          continue;
+        }
+
         this.set(key, values[key]);
       }
     }
@@ -242,6 +247,9 @@ export default class Config {
       case "people":
         this.values.count.people += data.count;
         break;
+      case "pets":
+        this.values.count.pets += data.count;
+        break;
       case "places":
         this.values.count.places += data.count;
         break;
@@ -61,6 +61,8 @@ export class Album extends RestModel {
       Month: -1,
       Favorite: false,
       Private: false,
+      Guests: false,
+      Locked: false,
       PhotoCount: 0,
       LinkCount: 0,
       CreatedAt: "",
@@ -213,6 +215,28 @@ export class Album extends RestModel {
     return Api.delete(this.getEntityResource() + "/like");
   }
 
+  lock() {
+    this.Locked = true;
+    return Api.post(this.getEntityResource() + "/lock");
+  }
+
+  unlock() {
+    this.Locked = false;
+    return Api.delete(this.getEntityResource() + "/lock");
+  }
+
+  setCover(photoUID) {
+    return Api.put(this.getEntityResource() + "/cover/" + photoUID).then((r) =>
+      Promise.resolve(this.setValues(r.data))
+    );
+  }
+
+  resetCover() {
+    return Api.delete(this.getEntityResource() + "/cover").then((r) =>
+      Promise.resolve(this.setValues(r.data))
+    );
+  }
+
   static batchSize() {
     return 24;
   }
@@ -37,10 +37,12 @@ export class ConfigOptions extends Model {
     return {
       Debug: config.values.debug,
       ReadOnly: config.values.readonly,
+      StrictReadOnly: false,
       Experimental: config.values.experimental,
       OriginalsLimit: 0,
       Workers: 0,
       WakeupInterval: 0,
+      TokenLifetime: 0,
       DisableBackups: config.values.disable.backups,
       DisableWebDAV: config.values.disable.webdav,
       DisableSettings: config.values.disable.settings,
@@ -50,12 +52,14 @@ export class ConfigOptions extends Model {
       DisableRawtherapee: config.values.disable.rawtherapee,
       DisableSips: config.values.disable.sips,
       DisableHeifConvert: config.values.disable.heifconvert,
+      DisableDcmtk: config.values.disable.dcmtk,
       DisableFFmpeg: config.values.disable.ffmpeg,
       DisableTensorFlow: config.values.disable.tensorflow,
       DetectNSFW: false,
       UploadNSFW: config.values.uploadNSFW,
       RawPresets: false,
       ThumbUncached: true,
+      ThumbLibrary: "",
       ThumbFilter: "",
       ThumbSize: 0,
       ThumbSizeUncached: 0,
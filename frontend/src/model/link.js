@@ -48,6 +48,8 @@ export default class Link extends Model {
       HasPassword: false,
       CanComment: false,
       CanEdit: false,
+      CanUpload: false,
+      Review: false,
       CreatedAt: "",
       ModifiedAt: "",
     };
@@ -91,7 +91,10 @@ export class Photo extends RestModel {
       TypeSrc: "",
       Stack: 0,
       Favorite: false,
+      Rating: 0,
+      RatingSrc: "",
       Private: false,
+      Locked: false,
       Scan: false,
       Panorama: false,
       Portrait: false,
@@ -113,6 +116,8 @@ export class Photo extends RestModel {
       Lat: 0.0,
       Lng: 0.0,
       Altitude: 0,
+      Heading: 0.0,
+      Speed: 0.0,
       Iso: 0,
       FocalLength: 0,
       FNumber: 0.0,
@@ -142,6 +147,7 @@ export class Photo extends RestModel {
         License: "",
         LicenseSrc: "",
       },
+      Locales: [],
       Files: [],
       Labels: [],
       Keywords: [],
@@ -778,6 +784,16 @@ export class Photo extends RestModel {
     return Api.delete(this.getEntityResource() + "/like");
   }
 
+  lock() {
+    this.Locked = true;
+    return Api.post(this.getEntityResource() + "/lock");
+  }
+
+  unlock() {
+    this.Locked = false;
+    return Api.delete(this.getEntityResource() + "/lock");
+  }
+
   addLabel(name) {
     return Api.post(this.getEntityResource() + "/label", { Name: name, Priority: 10 }).then((r) =>
       Promise.resolve(this.setValues(r.data))
@@ -802,6 +818,28 @@ export class Photo extends RestModel {
     );
   }
 
+  localized(locale) {
+    const l = this.Locales ? this.Locales.find((l) => l.Locale === locale) : null;
+
+    return {
+      Title: l && l.Title ? l.Title : this.Title,
+      Description: l && l.Description ? l.Description : this.Description,
+    };
+  }
+
+  updateLocale(locale, title, description) {
+    return Api.put(this.getEntityResource() + "/locales/" + locale, {
+      Title: title,
+      Description: description,
+    }).then((r) => Promise.resolve(this.setValues(r.data)));
+  }
+
+  removeLocale(locale) {
+    return Api.delete(this.getEntityResource() + "/locales/" + locale).then((r) =>
+      Promise.resolve(this.setValues(r.data))
+    );
+  }
+
   getMarkers(valid) {
     let result = [];
 
@@ -837,6 +875,10 @@ export class Photo extends RestModel {
       values.DescriptionSrc = src.Manual;
     }
 
+    if (values.Rating !== undefined) {
+      values.RatingSrc = src.Manual;
+    }
+
     if (values.Lat || values.Lng || values.Country) {
       values.PlaceSrc = src.Manual;
     }
@@ -353,6 +353,7 @@ const clientConfig = {
     rawtherapee: false,
     sips: true,
     heifconvert: false,
+    dcmtk: false,
     ffmpeg: false,
     tensorflow: false,
   },
@@ -62,11 +62,10 @@ func (a Actions) Allow(action Action) bool {
 	return false
 }
 
+// Allow tests if the role may perform the action, roles without permissions are denied.
 func (r Roles) Allow(role Role, action Action) bool {
 	if a, ok := r[role]; ok {
 		return a.Allow(action)
-	} else if a, ok := r[RoleDefault]; ok {
-		return a.Allow(action)
 	}
 
 	return false
@@ -6,6 +6,7 @@ type Actions map[Action]bool
 const (
 	ActionDefault    Action = "*"
 	ActionSearch     Action = "search"
+	ActionSearchAll  Action = "search-all"
 	ActionCreate     Action = "create"
 	ActionRead       Action = "read"
 	ActionUpdate     Action = "update"
@@ -19,4 +20,6 @@ const (
 	ActionComment    Action = "comment"
 	ActionExport     Action = "export"
 	ActionImport     Action = "import"
+	ActionLock       Action = "lock"
+	ActionSimilar    Action = "similar"
 )
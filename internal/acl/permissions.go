@@ -1,28 +1,295 @@
 package acl
 
+// Common action sets of the permission table.
+var (
+	allActions = Actions{ActionDefault: true}
+	noActions  = Actions{}
+)
+
+// Permissions maps resources and roles to the allowed actions, API handlers must not grant access otherwise.
+//
+// Each resource has a row for every user role, so that the intent is documented: admins may do everything,
+// and guests may only see what has been shared with them. Family members, friends, children, and other
+// registered users may read the config, change their own account, get the counts of the albums they have
+// been invited to, and manage files with WebDAV. Their access to albums depends on the album member roles
+// owner, contributor, and viewer, see entity.AlbumMember. Roles without a row are denied.
 var Permissions = ACL{
 	ResourceDefault: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
 	},
 	ResourceConfig: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionRead: true},
+		RoleAdmin:   allActions,
+		RoleFamily:  Actions{ActionRead: true},
+		RoleFriend:  Actions{ActionRead: true},
+		RoleChild:   Actions{ActionRead: true},
+		RoleGuest:   Actions{ActionRead: true},
+		RoleDefault: Actions{ActionRead: true},
 	},
 	ResourceConfigOptions: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceSettings: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceLogs: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceAccounts: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
 	},
 	ResourceSubjects: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
 	},
 	ResourceAlbums: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionSearch: true, ActionRead: true},
+		RoleAdmin:       allActions,
+		RoleFamily:      noActions,
+		RoleFriend:      noActions,
+		RoleChild:       noActions,
+		RoleGuest:       Actions{ActionSearch: true, ActionRead: true},
+		RoleDefault:     noActions,
+		RoleOwner:       allActions,
+		RoleContributor: Actions{ActionRead: true, ActionDownload: true, ActionUpdate: true, ActionUpload: true},
+		RoleViewer:      Actions{ActionRead: true, ActionDownload: true},
 	},
-	ResourcePhotos: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionSearch: true, ActionRead: true, ActionDownload: true},
+	ResourceCameras: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceCategories: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceCountries: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceFiles: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceFolders: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceLabels: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceLenses: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceLinks: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceMembers: Roles{
+		RoleAdmin:       allActions,
+		RoleFamily:      noActions,
+		RoleFriend:      noActions,
+		RoleChild:       noActions,
+		RoleGuest:       noActions,
+		RoleDefault:     noActions,
+		RoleOwner:       allActions,
+		RoleContributor: Actions{ActionRead: true},
+		RoleViewer:      Actions{ActionRead: true},
+	},
+	ResourceGeo: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourcePasswords: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
 	},
 	ResourceUsers: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  Actions{ActionUpdateSelf: true},
+		RoleFriend:  Actions{ActionUpdateSelf: true},
+		RoleChild:   Actions{ActionUpdateSelf: true},
+		RoleGuest:   Actions{ActionUpdateSelf: true},
 		RoleDefault: Actions{ActionUpdateSelf: true},
 	},
+	ResourcePhotos: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   Actions{ActionSearch: true, ActionRead: true, ActionDownload: true},
+		RoleDefault: noActions,
+	},
+	ResourcePhotoOfDay: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourcePlaces: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourcePrint: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceFeedback: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceActivity: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceCounts: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  Actions{ActionRead: true},
+		RoleFriend:  Actions{ActionRead: true},
+		RoleChild:   Actions{ActionRead: true},
+		RoleGuest:   noActions,
+		RoleDefault: Actions{ActionRead: true},
+	},
+	ResourceDownloads: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourcePush: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   Actions{ActionRead: true, ActionCreate: true, ActionDelete: true},
+		RoleDefault: noActions,
+	},
+	ResourceSearches: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   Actions{ActionSearch: true, ActionRead: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true},
+		RoleDefault: noActions,
+	},
+	ResourceSuggestions: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceTimelapses: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceTags: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  noActions,
+		RoleFriend:  noActions,
+		RoleChild:   noActions,
+		RoleGuest:   noActions,
+		RoleDefault: noActions,
+	},
+	ResourceWebDAV: Roles{
+		RoleAdmin:   allActions,
+		RoleFamily:  allActions,
+		RoleFriend:  allActions,
+		RoleChild:   allActions,
+		RoleGuest:   noActions,
+		RoleDefault: allActions,
+	},
 }
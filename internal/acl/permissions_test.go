@@ -50,3 +50,144 @@ func TestACL_Deny(t *testing.T) {
 		assert.True(t, Permissions.Deny(ResourceAlbums, RoleGuest, ActionDefault))
 	})
 }
+
+func TestPermissions(t *testing.T) {
+	tests := []struct {
+		Resource Resource
+		Role     Role
+		Action   Action
+		Allow    bool
+	}{
+		{ResourceCounts, RoleAdmin, ActionRead, true},
+		{ResourceCounts, RoleGuest, ActionRead, false},
+		{ResourceCounts, RoleDefault, ActionRead, true},
+		{ResourceCounts, RoleDefault, ActionUpdate, false},
+		{ResourceDownloads, RoleAdmin, ActionCreate, true},
+		{ResourceDownloads, RoleGuest, ActionSearch, false},
+		{ResourceDownloads, RoleDefault, ActionSearch, false},
+		{ResourcePush, RoleGuest, ActionRead, true},
+		{ResourcePush, RoleGuest, ActionCreate, true},
+		{ResourcePush, RoleGuest, ActionUpdate, false},
+		{ResourceSearches, RoleGuest, ActionUpdate, true},
+		{ResourcePhotos, RoleAdmin, ActionSearchAll, true},
+		{ResourcePhotos, RoleGuest, ActionSearchAll, false},
+		{ResourceAlbums, RoleGuest, ActionSearchAll, false},
+		{ResourcePhotoOfDay, RoleAdmin, ActionRead, true},
+		{ResourcePhotoOfDay, RoleGuest, ActionRead, false},
+		{ResourceMembers, RoleAdmin, ActionRead, true},
+		{ResourceMembers, RoleGuest, ActionRead, false},
+		{ResourcePrint, RoleAdmin, ActionCreate, true},
+		{ResourcePrint, RoleGuest, ActionCreate, false},
+		{ResourceAlbums, RoleOwner, ActionShare, true},
+		{ResourceAlbums, RoleContributor, ActionUpload, true},
+		{ResourceAlbums, RoleContributor, ActionDelete, false},
+		{ResourceAlbums, RoleViewer, ActionUpload, false},
+		{ResourceMembers, RoleContributor, ActionRead, true},
+		{ResourceMembers, RoleContributor, ActionShare, false},
+		{ResourceWebDAV, RoleAdmin, ActionUpload, true},
+		{ResourceWebDAV, RoleFamily, ActionDefault, true},
+		{ResourceWebDAV, RoleGuest, ActionDefault, false},
+		{ResourceWebDAV, RoleGuest, ActionRead, false},
+		{ResourceSuggestions, RoleAdmin, ActionSearch, true},
+		{ResourceSuggestions, RoleGuest, ActionSearch, false},
+		{ResourceTimelapses, RoleAdmin, ActionCreate, true},
+		{ResourceTimelapses, RoleGuest, ActionSearch, false},
+		{ResourceTimelapses, RoleGuest, ActionRead, false},
+		{ResourceTags, RoleAdmin, ActionSearch, true},
+		{ResourceTags, RoleGuest, ActionSearch, false},
+		{ResourceSearches, RoleFamily, ActionSearch, false},
+		{ResourcePhotos, RoleAdmin, ActionSimilar, true},
+		{ResourcePhotos, RoleGuest, ActionSimilar, false},
+		{ResourceLinks, RoleGuest, ActionSearch, false},
+		{ResourceUsers, RoleGuest, ActionUpdateSelf, true},
+		{ResourceUsers, RoleGuest, ActionUpdate, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.Resource)+"/"+string(tt.Role)+"/"+string(tt.Action), func(t *testing.T) {
+			assert.Equal(t, tt.Allow, Permissions.Allow(tt.Resource, tt.Role, tt.Action))
+		})
+	}
+}
+
+func TestPermissions_UserRoles(t *testing.T) {
+	for resource, roles := range Permissions {
+		for _, role := range UserRoles {
+			_, ok := roles[role]
+			assert.True(t, ok, "%s has no row for %s", resource, role)
+		}
+	}
+}
+
+func TestPermissions_Matrix(t *testing.T) {
+	resources := []Resource{
+		ResourceDefault, ResourceConfig, ResourceConfigOptions, ResourceSettings, ResourceLogs, ResourceAccounts,
+		ResourceSubjects, ResourceAlbums, ResourceCameras, ResourceCategories, ResourceCountries, ResourceFiles,
+		ResourceFolders, ResourceLabels, ResourceLenses, ResourceLinks, ResourceMembers, ResourceGeo,
+		ResourcePasswords, ResourceUsers, ResourcePhotos, ResourcePhotoOfDay, ResourcePlaces, ResourcePrint,
+		ResourceFeedback, ResourceActivity, ResourceCounts, ResourceDownloads, ResourcePush, ResourceSearches,
+		ResourceSuggestions, ResourceTimelapses, ResourceTags, ResourceWebDAV,
+	}
+
+	actions := []Action{
+		ActionSearch, ActionSearchAll, ActionCreate, ActionRead, ActionUpdate, ActionUpdateSelf, ActionDelete,
+		ActionPrivate, ActionUpload, ActionDownload, ActionShare, ActionLike, ActionComment, ActionExport,
+		ActionImport, ActionLock, ActionSimilar,
+	}
+
+	registered := []Role{RoleFamily, RoleFriend, RoleChild, RoleDefault}
+
+	// Actions that are allowed in addition to admins, who may do everything, all others must be denied.
+	allowed := map[Resource]map[Role][]Action{
+		ResourceConfig: {RoleGuest: {ActionRead}},
+		ResourceAlbums: {
+			RoleGuest:       {ActionSearch, ActionRead},
+			RoleOwner:       actions,
+			RoleContributor: {ActionRead, ActionDownload, ActionUpdate, ActionUpload},
+			RoleViewer:      {ActionRead, ActionDownload},
+		},
+		ResourceMembers: {
+			RoleOwner:       actions,
+			RoleContributor: {ActionRead},
+			RoleViewer:      {ActionRead},
+		},
+		ResourceUsers:    {RoleGuest: {ActionUpdateSelf}},
+		ResourcePhotos:   {RoleGuest: {ActionSearch, ActionRead, ActionDownload}},
+		ResourcePush:     {RoleGuest: {ActionRead, ActionCreate, ActionDelete}},
+		ResourceSearches: {RoleGuest: {ActionSearch, ActionRead, ActionCreate, ActionUpdate, ActionDelete}},
+	}
+
+	for _, role := range registered {
+		for resource, a := range map[Resource][]Action{
+			ResourceConfig: {ActionRead},
+			ResourceUsers:  {ActionUpdateSelf},
+			ResourceCounts: {ActionRead},
+			ResourceWebDAV: actions,
+		} {
+			if allowed[resource] == nil {
+				allowed[resource] = make(map[Role][]Action)
+			}
+
+			allowed[resource][role] = a
+		}
+	}
+
+	for _, resource := range resources {
+		_, ok := Permissions[resource]
+		assert.True(t, ok, "%s has no permissions", resource)
+
+		for _, role := range append(append([]Role{}, UserRoles...), MemberRoles...) {
+			t.Run(string(resource)+"/"+string(role), func(t *testing.T) {
+				for _, action := range actions {
+					expected := role == RoleAdmin
+
+					for _, a := range allowed[resource][role] {
+						expected = expected || a == action
+					}
+
+					assert.Equal(t, expected, Permissions.Allow(resource, role, action), string(action))
+				}
+			})
+		}
+	}
+}
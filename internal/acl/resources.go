@@ -19,10 +19,22 @@ const (
 	ResourceLabels        Resource = "labels"
 	ResourceLenses        Resource = "lenses"
 	ResourceLinks         Resource = "links"
+	ResourceMembers       Resource = "members"
 	ResourceGeo           Resource = "geo"
 	ResourcePasswords     Resource = "passwords"
 	ResourceUsers         Resource = "users"
 	ResourcePhotos        Resource = "photos"
+	ResourcePhotoOfDay    Resource = "photo_of_day"
 	ResourcePlaces        Resource = "places"
+	ResourcePrint         Resource = "print"
 	ResourceFeedback      Resource = "feedback"
+	ResourceActivity      Resource = "activity"
+	ResourceCounts        Resource = "counts"
+	ResourceDownloads     Resource = "downloads"
+	ResourcePush          Resource = "push"
+	ResourceSearches      Resource = "searches"
+	ResourceSuggestions   Resource = "suggestions"
+	ResourceTimelapses    Resource = "timelapses"
+	ResourceTags          Resource = "tags"
+	ResourceWebDAV        Resource = "webdav"
 )
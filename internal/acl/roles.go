@@ -4,7 +4,7 @@ type Role string
 type Roles map[Role]Actions
 
 const (
-	RoleDefault     Role = "*"
+	RoleDefault     Role = "*" // Registered users without a special role.
 	RoleAdmin       Role = "admin"
 	RolePartner     Role = "partner"
 	RoleFamily      Role = "family"
@@ -18,3 +18,16 @@ const (
 	RoleWorkmate    Role = "workmate"
 	RoleGuest       Role = "guest"
 )
+
+// Album member roles, see entity.AlbumMember.
+const (
+	RoleOwner       Role = "owner"
+	RoleContributor Role = "contributor"
+	RoleViewer      Role = "viewer"
+)
+
+// UserRoles lists the roles that users can have, see entity.User.
+var UserRoles = []Role{RoleAdmin, RoleFamily, RoleFriend, RoleChild, RoleGuest, RoleDefault}
+
+// MemberRoles lists the roles that album members can have, see entity.AlbumMember.
+var MemberRoles = []Role{RoleOwner, RoleContributor, RoleViewer}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetActivities returns the activity feed, e.g. completed imports and newly created albums.
+//
+// GET /api/v1/activity
+//
+// Query:
+//   count:   int    Max result count (required)
+//   offset:  int    Result offset
+//   type:    string Activity type, e.g. import, album, share, or person
+func GetActivities(router *gin.RouterGroup) {
+	router.GET("/activity", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceActivity, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		if resp, err := query.Activities(limit, offset, c.Query("type")); err != nil {
+			AbortError(c, 400, err)
+			return
+		} else {
+			AddCountHeader(c, len(resp))
+			AddLimitHeader(c, limit)
+			AddOffsetHeader(c, offset)
+
+			c.JSON(http.StatusOK, resp)
+		}
+	})
+}
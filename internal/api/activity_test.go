@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetActivities(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetActivities(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/activity?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.GreaterOrEqual(t, int(gjson.Get(r.Body.String(), "#").Int()), 2)
+		assert.Equal(t, "10", r.Header().Get("X-Limit"))
+	})
+	t.Run("Type", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetActivities(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/activity?count=10&type=album")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "#").Int())
+
+		for _, val := range gjson.Get(r.Body.String(), "#.Type").Array() {
+			assert.Equal(t, "album", val.String())
+		}
+	})
+}
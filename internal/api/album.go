@@ -1,7 +1,6 @@
 package api
 
 import (
-	"archive/zip"
 	"net/http"
 	"path/filepath"
 	"strings"
@@ -19,7 +18,6 @@ import (
 	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/internal/service"
 
-	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
@@ -46,14 +44,15 @@ func SaveAlbumAsYaml(a entity.Album) {
 // GET /api/v1/albums/:uid
 func GetAlbum(router *gin.RouterGroup) {
 	router.GET("/albums/:uid", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionRead)
+		id := sanitize.IdString(c.Param("uid"))
+		s, member := AuthAlbum(SessionID(c), id, acl.ResourceAlbums, acl.ActionRead)
 
-		if s.Invalid() {
+		// Guests may only see shared albums.
+		if s.Invalid() || member == nil && SharedOnly(s, acl.ResourceAlbums) && !s.HasShare(id) {
 			AbortUnauthorized(c)
 			return
 		}
 
-		id := sanitize.IdString(c.Param("uid"))
 		a, err := query.AlbumByUID(id)
 
 		if err != nil {
@@ -61,6 +60,13 @@ func GetAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Remove location details as configured for the share link.
+		if SharedOnly(s, acl.ResourceAlbums) && s.GeoPrivacy(a.AlbumUID) == entity.GeoStrip {
+			a.AlbumLocation = ""
+			a.AlbumState = ""
+			a.AlbumCountry = entity.UnknownID
+		}
+
 		c.JSON(http.StatusOK, a)
 	})
 }
@@ -93,6 +99,7 @@ func CreateAlbum(router *gin.RouterGroup) {
 		}
 
 		event.SuccessMsg(i18n.MsgAlbumCreated)
+		event.Publish("activity.album", event.Data{"uid": a.AlbumUID, "title": a.AlbumTitle, "user": s.User.UserUID})
 
 		UpdateClientConfig()
 
@@ -122,6 +129,9 @@ func UpdateAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
+		} else if a.AlbumLocked {
+			AbortLocked(c)
+			return
 		}
 
 		f, err := form.NewAlbum(a)
@@ -138,12 +148,19 @@ func UpdateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		guests := a.AlbumGuests
+
 		if err := a.SaveForm(f); err != nil {
 			log.Error(err)
 			AbortSaveFailed(c)
 			return
 		}
 
+		// Update the albums visible to registered guests.
+		if guests != a.AlbumGuests {
+			service.ShareCache().Flush()
+		}
+
 		UpdateClientConfig()
 
 		event.SuccessMsg(i18n.MsgAlbumSaved)
@@ -156,6 +173,91 @@ func UpdateAlbum(router *gin.RouterGroup) {
 	})
 }
 
+// SetAlbumCover uses a photo of the album as cover, so that the cover is no longer selected automatically.
+//
+// PUT /api/v1/albums/:uid/cover/:photo
+func SetAlbumCover(router *gin.RouterGroup) {
+	router.PUT("/albums/:uid/cover/:photo", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := sanitize.IdString(c.Param("uid"))
+		a, err := query.AlbumByUID(uid)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		} else if a.AlbumLocked {
+			AbortLocked(c)
+			return
+		}
+
+		if err := a.SetCover(sanitize.IdString(c.Param("photo"))); err != nil {
+			log.Errorf("album: %s", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		RemoveFromAlbumCoverCache(a.AlbumUID)
+
+		PublishAlbumEvent(EntityUpdated, uid, c)
+
+		SaveAlbumAsYaml(a)
+
+		c.JSON(http.StatusOK, a)
+	})
+}
+
+// ResetAlbumCover allows the album cover to be selected automatically again, based on quality,
+// people, and recency.
+//
+// DELETE /api/v1/albums/:uid/cover
+func ResetAlbumCover(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/cover", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := sanitize.IdString(c.Param("uid"))
+		a, err := query.AlbumByUID(uid)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		} else if a.AlbumLocked {
+			AbortLocked(c)
+			return
+		}
+
+		if err := a.ResetCover(); err != nil {
+			log.Errorf("album: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		RemoveFromAlbumCoverCache(a.AlbumUID)
+
+		// Return the automatically selected cover.
+		if a, err = query.AlbumByUID(uid); err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		PublishAlbumEvent(EntityUpdated, uid, c)
+
+		SaveAlbumAsYaml(a)
+
+		c.JSON(http.StatusOK, a)
+	})
+}
+
 // DeleteAlbum deletes an existing album.
 //
 // DELETE /api/v1/albums/:uid
@@ -175,6 +277,9 @@ func DeleteAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
+		} else if a.AlbumLocked {
+			AbortLocked(c)
+			return
 		}
 
 		// Regular, manually created album?
@@ -194,6 +299,13 @@ func DeleteAlbum(router *gin.RouterGroup) {
 
 		PublishAlbumEvent(EntityDeleted, id, c)
 
+		// Update precalculated photo counts in the background.
+		entity.UpdateCountsAsync(UpdateClientConfig)
+
+		if a.AlbumGuests {
+			service.ShareCache().Flush()
+		}
+
 		UpdateClientConfig()
 
 		SaveAlbumAsYaml(a)
@@ -280,6 +392,65 @@ func DislikeAlbum(router *gin.RouterGroup) {
 	})
 }
 
+// LockAlbum protects an album from changes and deletion.
+//
+// POST /api/v1/albums/:uid/lock
+//
+// Parameters:
+//   uid: string Album UID
+func LockAlbum(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/lock", func(c *gin.Context) {
+		setAlbumLocked(c, true)
+	})
+}
+
+// UnlockAlbum removes the lock from an album.
+//
+// DELETE /api/v1/albums/:uid/lock
+//
+// Parameters:
+//   uid: string Album UID
+func UnlockAlbum(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/lock", func(c *gin.Context) {
+		setAlbumLocked(c, false)
+	})
+}
+
+// setAlbumLocked updates the lock flag of the album in the request.
+func setAlbumLocked(c *gin.Context, locked bool) {
+	s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionLock)
+
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return
+	}
+
+	id := sanitize.IdString(c.Param("uid"))
+	a, err := query.AlbumByUID(id)
+
+	if err != nil {
+		Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+		return
+	}
+
+	if err := a.SetLocked(locked); err != nil {
+		Abort(c, http.StatusInternalServerError, i18n.ErrSaveFailed)
+		return
+	}
+
+	UpdateClientConfig()
+
+	PublishAlbumEvent(EntityUpdated, id, c)
+
+	SaveAlbumAsYaml(a)
+
+	if locked {
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgLocked))
+	} else {
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgUnlocked))
+	}
+}
+
 // CloneAlbums creates a new album containing pictures from other albums.
 //
 // POST /api/v1/albums/:uid/clone
@@ -297,6 +468,9 @@ func CloneAlbums(router *gin.RouterGroup) {
 		if err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
+		} else if a.AlbumLocked {
+			AbortLocked(c)
+			return
 		}
 
 		var f form.Selection
@@ -343,7 +517,10 @@ func CloneAlbums(router *gin.RouterGroup) {
 // POST /api/v1/albums/:uid/photos
 func AddPhotosToAlbum(router *gin.RouterGroup) {
 	router.POST("/albums/:uid/photos", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionUpdate)
+		uid := sanitize.IdString(c.Param("uid"))
+
+		// Album contributors may add photos, too.
+		s, member := AuthAlbum(SessionID(c), uid, acl.ResourceAlbums, acl.ActionUpdate)
 
 		if s.Invalid() {
 			AbortUnauthorized(c)
@@ -357,12 +534,14 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 			return
 		}
 
-		uid := sanitize.IdString(c.Param("uid"))
 		a, err := query.AlbumByUID(uid)
 
 		if err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
+		} else if a.AlbumLocked {
+			AbortLocked(c)
+			return
 		}
 
 		photos, err := query.PhotoSelection(f)
@@ -373,7 +552,21 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 			return
 		}
 
-		added := a.AddPhotos(photos.UIDs())
+		uids := photos.UIDs()
+
+		// Contributors may only add photos they can see in albums they are a member of.
+		if member != nil {
+			if uids, err = query.MemberPhotoUIDs(s.User.UserUID, uids); err != nil {
+				log.Errorf("album: %s", err)
+				AbortUnexpected(c)
+				return
+			} else if len(uids) == 0 {
+				AbortEntityNotFound(c)
+				return
+			}
+		}
+
+		added := a.AddPhotos(uids)
 
 		if len(added) > 0 {
 			if len(added) == 1 {
@@ -384,12 +577,33 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 
 			RemoveFromAlbumCoverCache(a.AlbumUID)
 
+			// Update precalculated photo counts in the background.
+			entity.UpdateCountsAsync(UpdateClientConfig)
+
 			PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
 
+			// Notify about new activity in shared albums.
+			if len(a.Links()) > 0 || a.HasMembers() {
+				event.Publish("notify.share", event.Data{
+					"uid":   a.AlbumUID,
+					"slug":  a.AlbumSlug,
+					"album": a.Title(),
+					"user":  s.User.UserUID,
+					"count": len(added),
+				})
+			}
+
 			SaveAlbumAsYaml(a)
+
+			// Track contributor activity.
+			if member != nil {
+				if err := member.AddedPhotos(len(added)); err != nil {
+					log.Errorf("album: %s (update member)", err)
+				}
+			}
 		}
 
-		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "album": a, "photos": photos.UIDs(), "added": added})
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "album": a, "photos": uids, "added": added})
 	})
 }
 
@@ -422,6 +636,9 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
+		} else if a.AlbumLocked {
+			AbortLocked(c)
+			return
 		}
 
 		removed := a.RemovePhotos(f.Photos)
@@ -435,6 +652,9 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 
 			RemoveFromAlbumCoverCache(a.AlbumUID)
 
+			// Update precalculated photo counts in the background.
+			entity.UpdateCountsAsync(UpdateClientConfig)
+
 			PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
 
 			SaveAlbumAsYaml(a)
@@ -470,11 +690,9 @@ func DownloadAlbum(router *gin.RouterGroup) {
 		}
 
 		zipFileName := a.ZipName()
-
-		AddDownloadHeader(c, zipFileName)
-
-		zipWriter := zip.NewWriter(c.Writer)
-		defer func() { _ = zipWriter.Close() }()
+		download := &zipDownload{}
+		limit := service.Config().DownloadLimit()
+		redact := shareRedact(c, a.AlbumUID)
 
 		var aliases = make(map[string]int)
 
@@ -499,18 +717,89 @@ func DownloadAlbum(router *gin.RouterGroup) {
 
 			aliases[key] += 1
 
-			if fs.FileExists(fileName) {
-				if err := addFileToZip(zipWriter, fileName, alias); err != nil {
-					log.Error(err)
-					Abort(c, http.StatusInternalServerError, i18n.ErrZipFailed)
+			if entry, err := newZipEntry(fileName, alias); err != nil {
+				log.Errorf("download: failed finding %s", sanitize.Log(file.FileName))
+			} else {
+				download.Add(entry)
+				log.Infof("download: added %s as %s", sanitize.Log(file.FileName), sanitize.Log(alias))
+			}
+
+			// Check the size before shared files are redacted, so that no time is wasted.
+			if limit > 0 && download.FileSize > limit {
+				Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrZipTooLarge)
+				return
+			}
+		}
+
+		// Shared files are added without the metadata that must not be shared.
+		if redact != nil && redact.Enabled() {
+			defer download.Remove()
+
+			for i, e := range download.Entries {
+				fileName, err := redact.File(e.FileName)
+
+				if err != nil {
+					log.Errorf("download: %s", err)
+					AbortUnexpected(c)
 					return
 				}
-				log.Infof("download: added %s as %s", sanitize.Log(file.FileName), sanitize.Log(alias))
-			} else {
-				log.Errorf("download: failed finding %s", sanitize.Log(file.FileName))
+
+				entry, err := newZipEntry(fileName, e.Alias)
+
+				if err != nil {
+					removeRedactedFile(fileName)
+					log.Errorf("download: %s", err)
+					AbortUnexpected(c)
+					return
+				}
+
+				entry.Temp = true
+				download.Entries[i] = entry
 			}
 		}
 
+		if err := download.Finish(); err != nil {
+			log.Errorf("download: %s", err)
+			Abort(c, http.StatusInternalServerError, i18n.ErrZipFailed)
+			return
+		}
+
 		log.Infof("download: created %s [%s]", sanitize.Log(zipFileName), time.Since(start))
+
+		serveZip(c, zipFileName, download)
+	})
+}
+
+// RestoreAlbums restores albums from the YAML files in the albums path, including their photos,
+// members, and share links. Existing albums are not changed.
+//
+// POST /api/v1/albums/restore
+func RestoreAlbums(router *gin.RouterGroup) {
+	router.POST("/albums/restore", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionImport)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if conf.ReadOnly() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		count, err := photoprism.RestoreAlbums(conf.AlbumsPath(), true)
+
+		if err != nil {
+			log.Errorf("restore: %s", err)
+		}
+
+		if count > 0 {
+			UpdateClientConfig()
+		}
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgAlbumsRestored, count), "count": count})
 	})
 }
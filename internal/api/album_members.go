@@ -0,0 +1,186 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// AuthAlbum returns the session if the user is authorized for the resource action, either
+// because of their role or because they have been invited to the album.
+func AuthAlbum(id, albumUID string, resource acl.Resource, action acl.Action) (s session.Data, m *entity.AlbumMember) {
+	if s = Auth(id, resource, action); s.Valid() {
+		return s, nil
+	}
+
+	s = Session(id)
+
+	if !s.User.Registered() {
+		return session.Data{}, nil
+	}
+
+	if m = entity.FindAlbumMember(albumUID, s.User.UserUID); m == nil || !m.Allow(resource, action) {
+		return session.Data{}, nil
+	}
+
+	return s, m
+}
+
+// GetAlbumMembers returns the members of an album and their activity.
+//
+// GET /api/v1/albums/:uid/members
+func GetAlbumMembers(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/members", func(c *gin.Context) {
+		uid := sanitize.IdString(c.Param("uid"))
+
+		if s, _ := AuthAlbum(SessionID(c), uid, acl.ResourceMembers, acl.ActionRead); s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if _, err := query.AlbumByUID(uid); err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		results, err := query.AlbumMembers(uid)
+
+		if err != nil {
+			log.Errorf("album: %s (find members)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// AddAlbumMember invites a user to an album, or changes the role of an existing member.
+//
+// POST /api/v1/albums/:uid/members
+func AddAlbumMember(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/members", func(c *gin.Context) {
+		uid := sanitize.IdString(c.Param("uid"))
+		s, _ := AuthAlbum(SessionID(c), uid, acl.ResourceMembers, acl.ActionShare)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		a, err := query.AlbumByUID(uid)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		var f form.AlbumMember
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		var u *entity.User
+
+		if f.UserUID != "" {
+			u = entity.FindUserByUID(sanitize.IdString(f.UserUID))
+		} else {
+			u = entity.FindUserByName(f.UserName)
+		}
+
+		if u == nil || !u.Registered() || u.Guest() {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		if f.Role == "" {
+			f.Role = entity.MemberContributor
+		} else if !entity.MemberRoles[f.Role] {
+			Abort(c, http.StatusBadRequest, i18n.ErrInvalidRole)
+			return
+		}
+
+		m := entity.FindAlbumMember(a.AlbumUID, u.UserUID)
+
+		if m == nil {
+			m = entity.NewAlbumMember(a.AlbumUID, u.UserUID, f.Role)
+			m.InvitedBy = s.User.UserUID
+		} else {
+			m.MemberRole = f.Role
+		}
+
+		if err := m.Save(); err != nil {
+			log.Errorf("album: %s (save member)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("album: %s is now %s of %s", sanitize.Log(u.Username()), m.MemberRole, sanitize.Log(a.Title()))
+
+		event.SuccessMsg(i18n.MsgMemberAddedTo, sanitize.Log(u.Username()), sanitize.Log(a.Title()))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// RemoveAlbumMember removes a user from an album.
+//
+// DELETE /api/v1/albums/:uid/members/:user
+func RemoveAlbumMember(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/members/:user", func(c *gin.Context) {
+		uid := sanitize.IdString(c.Param("uid"))
+		userUID := sanitize.IdString(c.Param("user"))
+		s, _ := AuthAlbum(SessionID(c), uid, acl.ResourceMembers, acl.ActionShare)
+
+		// Members may always leave an album.
+		if s.Invalid() && Session(SessionID(c)).User.UserUID == userUID {
+			s = Session(SessionID(c))
+		}
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		a, err := query.AlbumByUID(uid)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		m := entity.FindAlbumMember(a.AlbumUID, userUID)
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("album: %s (remove member)", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		name := userUID
+
+		if u := entity.FindUserByUID(userUID); u != nil {
+			name = u.Username()
+		}
+
+		event.SuccessMsg(i18n.MsgMemberRemovedFrom, sanitize.Log(name), sanitize.Log(a.Title()))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
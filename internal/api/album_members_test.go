@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetAlbumMembers(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumMembers(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/members")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "bob", gjson.Get(r.Body.String(), "0.UserName").String())
+		assert.Equal(t, "contributor", gjson.Get(r.Body.String(), "0.Role").String())
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumMembers(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/xxx/members")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("Guest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetAlbumMembers(router)
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba8"}})
+		r := AuthenticatedRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/members", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestAddAlbumMember(t *testing.T) {
+	t.Run("AddAndRemove", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddAlbumMember(router)
+		RemoveAlbumMember(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/members", `{"UserName": "alice", "Role": "viewer"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "viewer", gjson.Get(r.Body.String(), "Role").String())
+		assert.Equal(t, "uqxetse3cy5eo9z2", gjson.Get(r.Body.String(), "UserUID").String())
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/members", `{"UserUID": "uqxetse3cy5eo9z2", "Role": "owner"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "owner", gjson.Get(r.Body.String(), "Role").String())
+
+		r = PerformRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba8/members/uqxetse3cy5eo9z2")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba8/members/uqxetse3cy5eo9z2")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidRole", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddAlbumMember(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/members", `{"UserName": "alice", "Role": "admin"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("UserNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddAlbumMember(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/members", `{"UserName": "nobody"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddAlbumMember(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/xxx/members", `{"UserName": "alice"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
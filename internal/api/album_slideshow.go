@@ -0,0 +1,107 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// CreateAlbumSlideshow starts rendering the album as slideshow video in the background.
+//
+// POST /api/v1/albums/:uid/slideshow
+func CreateAlbumSlideshow(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/slideshow", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if conf.DisableFFmpeg() || !conf.Settings().Features.Download {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.Slideshow
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		a, err := query.AlbumByUID(sanitize.IdString(c.Param("uid")))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		opt := photoprism.NewSlideshowOptions(f.Duration, f.Transition, f.Music)
+
+		if _, err := photoprism.SlideshowMusic(conf.OriginalsPath(), opt.Music); err != nil {
+			log.Warn(err)
+			AbortBadRequest(c)
+			return
+		}
+
+		// The worker is acquired before responding, so that concurrent requests are rejected as busy.
+		err = photoprism.NewSlideshow(conf).Start(a, opt, func(fileName string, err error) {
+			if err != nil {
+				log.Errorf("slideshow: %s", err)
+				event.Error("Slideshow could not be created")
+				return
+			}
+
+			event.Publish("slideshow.completed", event.Data{"uid": a.AlbumUID, "user": s.User.UserUID})
+		})
+
+		if err != nil {
+			log.Infof("slideshow: %s", err)
+			AbortBusy(c)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"code": http.StatusAccepted, "uid": a.AlbumUID, "duration": opt.Duration, "transition": opt.Transition})
+	})
+}
+
+// DownloadAlbumSlideshow sends the rendered slideshow video of an album.
+//
+// GET /api/v1/albums/:uid/slideshow
+func DownloadAlbumSlideshow(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/slideshow", func(c *gin.Context) {
+		if InvalidDownloadToken(c) {
+			AbortUnauthorized(c)
+			return
+		}
+
+		a, err := query.AlbumByUID(sanitize.IdString(c.Param("uid")))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		fileName := photoprism.SlideshowPath(service.Config().VideoPath(), a.AlbumUID)
+
+		if !fs.FileExists(fileName) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.FileAttachment(fileName, a.AlbumSlug+".mp4")
+	})
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+func TestCreateAlbumSlideshow(t *testing.T) {
+	t.Run("FeatureDisabled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		if !conf.DisableFFmpeg() {
+			t.Skip("ffmpeg is enabled")
+		}
+
+		CreateAlbumSlideshow(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/slideshow", `{"duration": 3, "transition": "fade"}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		if conf.DisableFFmpeg() {
+			t.Skip("ffmpeg is disabled")
+		}
+
+		CreateAlbumSlideshow(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/xxx/slideshow", `{"duration": 3, "transition": "fade"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidMusic", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		if conf.DisableFFmpeg() {
+			t.Skip("ffmpeg is disabled")
+		}
+
+		CreateAlbumSlideshow(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/slideshow", `{"music": "../../etc/passwd"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Busy", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		if conf.DisableFFmpeg() {
+			t.Skip("ffmpeg is disabled")
+		}
+
+		if err := mutex.SlideshowWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.SlideshowWorker.Stop()
+
+		CreateAlbumSlideshow(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/slideshow", `{"duration": 3, "transition": "fade"}`)
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+	})
+}
+
+func TestDownloadAlbumSlideshow(t *testing.T) {
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		DownloadAlbumSlideshow(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/xxx/slideshow?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NotRendered", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		DownloadAlbumSlideshow(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/slideshow?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		DownloadAlbumSlideshow(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/slideshow?t=xxx")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -81,6 +83,43 @@ func TestUpdateAlbum(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 }
+
+func TestSetAlbumCover(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateAlbum(router)
+	AddPhotosToAlbum(router)
+	SetAlbumCover(router)
+	ResetAlbumCover(router)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Cover", "Description": "", "Notes": "", "Favorite": false}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+	r = PerformRequestWithBody(app, "POST", "/api/v1/albums/"+uid+"/photos", `{"photos": ["pt9jtdre2lvl0y11"]}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	t.Run("successful request", func(t *testing.T) {
+		r := PerformRequest(app, "PUT", "/api/v1/albums/"+uid+"/cover/pt9jtdre2lvl0y11")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "manual", gjson.Get(r.Body.String(), "ThumbSrc").String())
+		assert.Equal(t, "pcad9168fa6acc5c5c2965ddf6ec465ca42fd818", gjson.Get(r.Body.String(), "Thumb").String())
+	})
+	t.Run("photo not in album", func(t *testing.T) {
+		r := PerformRequest(app, "PUT", "/api/v1/albums/"+uid+"/cover/pt9jtdre2lvl0yh0")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("reset", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", "/api/v1/albums/"+uid+"/cover")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "ThumbSrc").String())
+		assert.Equal(t, "pcad9168fa6acc5c5c2965ddf6ec465ca42fd818", gjson.Get(r.Body.String(), "Thumb").String())
+	})
+	t.Run("not found", func(t *testing.T) {
+		r := PerformRequest(app, "PUT", "/api/v1/albums/xxx/cover/pt9jtdre2lvl0y11")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		r = PerformRequest(app, "DELETE", "/api/v1/albums/xxx/cover")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
 func TestDeleteAlbum(t *testing.T) {
 	app, router, _ := NewApiTest()
 	CreateAlbum(router)
@@ -189,6 +228,21 @@ func TestAddPhotosToAlbum(t *testing.T) {
 		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/xxx/photos", `{"photos": ["pt9jtdre2lvl0yxx"]}`)
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
+	t.Run("Contributor", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		AddPhotosToAlbum(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+
+		// Photos that are not in any album the contributor is a member of cannot be added.
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/photos", `{"photos": ["pt9jtdre2lvl0y12"]}`, sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/photos", `{"photos": ["pt9jtdre2lvl0y12", "pt9jtdre2lvl0yh8"]}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, []interface{}{"pt9jtdre2lvl0yh8"}, gjson.Get(r.Body.String(), "photos").Value())
+	})
 }
 
 func TestRemovePhotosFromAlbum(t *testing.T) {
@@ -252,6 +306,25 @@ func TestDownloadAlbum(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl?t="+conf.DownloadToken())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("redaction failed", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer redactDownloadTest(t, conf)()
+
+		binName := filepath.Join(conf.TempPath(), "exiftool-failed")
+
+		if err := os.WriteFile(binName, []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(binName)
+
+		conf.Options().ExifToolBin = binName
+
+		DownloadAlbum(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba7/dl?t="+conf.ShareDownloadToken())
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+	})
 }
 
 func TestCloneAlbums(t *testing.T) {
@@ -286,3 +359,52 @@ func TestCloneAlbums(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 }
+
+func TestRestoreAlbums(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CloneAlbums(router)
+		RestoreAlbums(router)
+		r := PerformRequest(app, "POST", "/api/v1/albums/restore")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "count").Exists())
+		assert.Contains(t, gjson.Get(r.Body.String(), "message").String(), "albums restored")
+	})
+}
+
+func TestLockAlbum(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateAlbum(router)
+	LockAlbum(router)
+	UnlockAlbum(router)
+	DeleteAlbum(router)
+	BatchAlbumsDelete(router)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Locked Album"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("Lock", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/albums/"+uid+"/lock")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Locked", gjson.Get(r.Body.String(), "message").String())
+	})
+	t.Run("DeleteLocked", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", "/api/v1/albums/"+uid)
+		assert.Equal(t, http.StatusLocked, r.Code)
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/batch/albums/delete", `{"albums": ["`+uid+`"]}`)
+		assert.Equal(t, http.StatusLocked, r.Code)
+	})
+	t.Run("Unlock", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", "/api/v1/albums/"+uid+"/lock")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/albums/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/albums/xxx/lock")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
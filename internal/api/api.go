@@ -32,14 +32,20 @@ https://docs.photoprism.app/developer-guide/
 package api
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 var log = event.Log
@@ -58,29 +64,94 @@ func logWarn(prefix string, err error) {
 
 func UpdateClientConfig() {
 	conf := service.Config()
+	clientConfig := conf.UserConfig()
 
-	event.Publish("config.updated", event.Data{"config": conf.UserConfig()})
+	// Session tokens must not be replaced with the static tokens.
+	if conf.TokenLifetime() > 0 {
+		clientConfig.DownloadToken = ""
+		clientConfig.PreviewToken = ""
+		clientConfig.MapTiles = ""
+	}
+
+	event.Publish("config.updated", event.Data{"config": clientConfig})
 }
 
+// Abort aborts the request with an error response based on the message id.
 func Abort(c *gin.Context, code int, id i18n.Message, params ...interface{}) {
-	resp := i18n.NewResponse(code, id, params...)
+	resp := errorResponse(c, i18n.NewResponse(code, id, params...))
+
+	log.Debugf("api: abort %s with code %d (%s, trace %s)", sanitize.Log(c.FullPath()), code, resp.String(), resp.Trace)
+
+	c.AbortWithStatusJSON(code, resp)
+}
 
-	log.Debugf("api: abort %s with code %d (%s)", sanitize.Log(c.FullPath()), code, resp.String())
+// AbortError aborts the request with an error response based on the error, e.g. if there is no matching message.
+func AbortError(c *gin.Context, code int, err error) {
+	resp := errorResponse(c, i18n.NewErrorResponse(code, err))
+	resp.Err = txt.UcFirst(resp.Err)
+
+	log.Debugf("api: abort %s with code %d (%s, trace %s)", sanitize.Log(c.FullPath()), code, sanitize.Log(resp.String()), resp.Trace)
 
 	c.AbortWithStatusJSON(code, resp)
 }
 
+// Error aborts the request with an error response based on the message id and logs the error details.
 func Error(c *gin.Context, code int, err error, id i18n.Message, params ...interface{}) {
-	resp := i18n.NewResponse(code, id, params...)
+	resp := errorResponse(c, i18n.NewResponse(code, id, params...))
 
 	if err != nil {
 		resp.Details = err.Error()
-		log.Errorf("api: error %s with code %d in %s (%s)", sanitize.Log(err.Error()), code, sanitize.Log(c.FullPath()), resp.String())
+		log.Errorf("api: error %s with code %d in %s (%s, trace %s)", sanitize.Log(err.Error()), code, sanitize.Log(c.FullPath()), resp.String(), resp.Trace)
 	}
 
 	c.AbortWithStatusJSON(code, resp)
 }
 
+// errorResponse adds field validation errors and a trace id to the error response.
+func errorResponse(c *gin.Context, resp i18n.Response) i18n.Response {
+	resp.Fields = fieldErrors(c)
+	resp.Trace = traceID(c)
+
+	return resp
+}
+
+// fieldErrors returns the reasons why form fields could not be bound to the request, if any.
+func fieldErrors(c *gin.Context) map[string]string {
+	result := make(map[string]string)
+
+	for _, e := range c.Errors.ByType(gin.ErrorTypeBind) {
+		var validationErrs validator.ValidationErrors
+		var typeErr *json.UnmarshalTypeError
+
+		if errors.As(e.Err, &validationErrs) {
+			for _, fieldErr := range validationErrs {
+				result[fieldErr.Field()] = fieldErr.Tag()
+			}
+		} else if errors.As(e.Err, &typeErr) && typeErr.Field != "" {
+			result[typeErr.Field] = "invalid"
+		}
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// traceID returns the id of the current trace or request, so that error reports can be matched with logs.
+func traceID(c *gin.Context) string {
+	if c.Request == nil {
+		return rnd.UUID()
+	} else if ctx := trace.SpanContextFromContext(c.Request.Context()); ctx.HasTraceID() {
+		return ctx.TraceID().String()
+	} else if id := sanitize.Token(c.GetHeader("X-Request-Id")); id != "" {
+		return id
+	}
+
+	return rnd.UUID()
+}
+
 func AbortUnauthorized(c *gin.Context) {
 	Abort(c, http.StatusUnauthorized, i18n.ErrUnauthorized)
 }
@@ -116,3 +187,11 @@ func AbortFeatureDisabled(c *gin.Context) {
 func AbortBusy(c *gin.Context) {
 	Abort(c, http.StatusTooManyRequests, i18n.ErrBusy)
 }
+
+func AbortLocked(c *gin.Context) {
+	Abort(c, http.StatusLocked, i18n.ErrLocked)
+}
+
+func AbortStorageFull(c *gin.Context) {
+	Abort(c, http.StatusInsufficientStorage, i18n.ErrStorageFull)
+}
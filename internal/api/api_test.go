@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -9,11 +10,15 @@ import (
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/rnd"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
 )
 
 // NewApiTest returns new API test helper.
@@ -97,3 +102,65 @@ func TestMain(m *testing.M) {
 
 	os.Exit(code)
 }
+
+func TestAbort(t *testing.T) {
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, _, _ := NewApiTest()
+		app.GET("/abort", func(c *gin.Context) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+		})
+		r := PerformRequest(app, "GET", "/abort")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		assert.Equal(t, int64(http.StatusNotFound), gjson.Get(r.Body.String(), "code").Int())
+		assert.Equal(t, "album_not_found", gjson.Get(r.Body.String(), "id").String())
+		assert.Equal(t, i18n.Msg(i18n.ErrAlbumNotFound), gjson.Get(r.Body.String(), "error").String())
+		assert.True(t, rnd.IsUUID(gjson.Get(r.Body.String(), "trace").String()))
+		assert.False(t, gjson.Get(r.Body.String(), "fields").Exists())
+	})
+	t.Run("RequestID", func(t *testing.T) {
+		app, _, _ := NewApiTest()
+		app.GET("/abort", func(c *gin.Context) {
+			AbortUnexpected(c)
+		})
+		req, _ := http.NewRequest("GET", "/abort", nil)
+		req.Header.Add("X-Request-Id", "c0ffee-123")
+		r := httptest.NewRecorder()
+		app.ServeHTTP(r, req)
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+		assert.Equal(t, "unexpected", gjson.Get(r.Body.String(), "id").String())
+		assert.Equal(t, "c0ffee-123", gjson.Get(r.Body.String(), "trace").String())
+	})
+	t.Run("FieldErrors", func(t *testing.T) {
+		app, _, _ := NewApiTest()
+		app.POST("/abort", func(c *gin.Context) {
+			var f struct {
+				Title string `json:"Title" binding:"required"`
+				Count int    `json:"Count"`
+			}
+
+			if err := c.BindJSON(&f); err != nil {
+				AbortBadRequest(c)
+			}
+		})
+		r := PerformRequestWithBody(app, "POST", "/abort", `{"Count": 5}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "bad_request", gjson.Get(r.Body.String(), "id").String())
+		assert.Equal(t, "required", gjson.Get(r.Body.String(), "fields.Title").String())
+
+		r = PerformRequestWithBody(app, "POST", "/abort", `{"Title": "Cat", "Count": "five"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "invalid", gjson.Get(r.Body.String(), "fields.Count").String())
+	})
+}
+
+func TestAbortError(t *testing.T) {
+	app, _, _ := NewApiTest()
+	app.GET("/abort", func(c *gin.Context) {
+		AbortError(c, http.StatusConflict, errors.New("link already exists"))
+	})
+	r := PerformRequest(app, "GET", "/abort")
+	assert.Equal(t, http.StatusConflict, r.Code)
+	assert.Equal(t, "conflict", gjson.Get(r.Body.String(), "id").String())
+	assert.Equal(t, "Link already exists", gjson.Get(r.Body.String(), "error").String())
+	assert.NotEmpty(t, gjson.Get(r.Body.String(), "trace").String())
+}
@@ -39,6 +39,8 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
+		} else if !unlockedPhotos(c, &f) {
+			return
 		}
 
 		log.Infof("photos: archiving %s", sanitize.Log(f.String()))
@@ -52,7 +54,9 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 			}
 
 			for _, p := range photos {
-				if err := p.Archive(); err != nil {
+				if p.PhotoLocked {
+					continue
+				} else if err := p.Archive(); err != nil {
 					log.Errorf("archive: %s", err)
 				} else {
 					SavePhotoAsYaml(p)
@@ -67,7 +71,7 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 		}
 
 		// Update precalculated photo and file counts.
-		logWarn("index", entity.UpdateCounts())
+		entity.UpdateCountsAsync(UpdateClientConfig)
 
 		// Update album, subject, and label cover thumbs.
 		logWarn("index", query.UpdateCovers())
@@ -102,6 +106,8 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
+		} else if !unlockedPhotos(c, &f) {
+			return
 		}
 
 		log.Infof("photos: restoring %s", sanitize.Log(f.String()))
@@ -129,7 +135,7 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 		}
 
 		// Update precalculated photo and file counts.
-		logWarn("index", entity.UpdateCounts())
+		entity.UpdateCountsAsync(UpdateClientConfig)
 
 		// Update album, subject, and label cover thumbs.
 		logWarn("index", query.UpdateCovers())
@@ -164,6 +170,8 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
+		} else if !unlockedPhotos(c, &f) {
+			return
 		}
 
 		log.Infof("photos: approving %s", sanitize.Log(f.String()))
@@ -194,6 +202,89 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 	})
 }
 
+// BatchPhotosStack manually stacks multiple photos, the first selected photo becomes the stack.
+//
+// POST /api/v1/batch/photos/stack
+func BatchPhotosStack(router *gin.RouterGroup) {
+	router.POST("/batch/photos/stack", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Photos) < 2 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		log.Infof("photos: stacking %s", sanitize.Log(f.String()))
+
+		stack, err := query.PhotoByUID(sanitize.IdString(f.Photos[0]))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		photos, err := query.PhotoSelection(form.Selection{Photos: f.Photos[1:]})
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		} else if stack.PhotoLocked || photos.Locked() {
+			AbortLocked(c)
+			return
+		}
+
+		stacked, err := stack.Stack(photos)
+
+		if err != nil {
+			log.Errorf("stack: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		// Stacked photos are flagged as deleted in their sidecar files.
+		for _, p := range stacked {
+			SavePhotoAsYaml(p)
+		}
+
+		// Update precalculated photo and file counts.
+		entity.UpdateCountsAsync(UpdateClientConfig)
+
+		// Update album, subject, and label cover thumbs.
+		logWarn("index", query.UpdateCovers())
+
+		UpdateClientConfig()
+
+		event.EntitiesDeleted("photos", stacked.UIDs())
+
+		PublishPhotoEvent(EntityUpdated, stack.PhotoUID, c)
+
+		p, err := query.PhotoPreloadByUID(stack.PhotoUID)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		SavePhotoAsYaml(p)
+
+		event.SuccessMsg(i18n.MsgPhotosStacked, len(stacked)+1)
+
+		c.JSON(http.StatusOK, p)
+	})
+}
+
 // BatchAlbumsDelete permanently removes multiple albums.
 //
 // POST /api/v1/batch/albums/delete
@@ -218,6 +309,17 @@ func BatchAlbumsDelete(router *gin.RouterGroup) {
 			return
 		}
 
+		// Locked albums can't be deleted.
+		if uids, err := query.UnlockedAlbumUIDs(f.Albums); err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		} else if len(uids) == 0 {
+			AbortLocked(c)
+			return
+		} else {
+			f.Albums = uids
+		}
+
 		log.Infof("albums: deleting %s", sanitize.Log(f.String()))
 
 		entity.Db().Where("album_uid IN (?)", f.Albums).Delete(&entity.Album{})
@@ -253,6 +355,8 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
+		} else if !unlockedPhotos(c, &f) {
+			return
 		}
 
 		log.Infof("photos: updating private flag for %s", sanitize.Log(f.String()))
@@ -265,7 +369,7 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 		}
 
 		// Update precalculated photo and file counts.
-		logWarn("index", entity.UpdateCounts())
+		entity.UpdateCountsAsync(UpdateClientConfig)
 
 		if photos, err := query.PhotoSelection(f); err == nil {
 			for _, p := range photos {
@@ -358,6 +462,8 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 		if len(f.Photos) == 0 {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
+		} else if !unlockedPhotos(c, &f) {
+			return
 		}
 
 		log.Infof("photos: deleting %s", sanitize.Log(f.String()))
@@ -373,7 +479,9 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 
 		// Delete photos.
 		for _, p := range photos {
-			if err := photoprism.Delete(p); err != nil {
+			if p.PhotoLocked {
+				continue
+			} else if err := photoprism.Delete(p); err != nil {
 				log.Errorf("delete: %s", err)
 			} else {
 				deleted = append(deleted, p)
@@ -383,7 +491,7 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 		// Any photos deleted?
 		if len(deleted) > 0 {
 			// Update precalculated photo and file counts.
-			logWarn("index", entity.UpdateCounts())
+			entity.UpdateCountsAsync(UpdateClientConfig)
 
 			UpdateClientConfig()
 
@@ -393,3 +501,272 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgPermanentlyDeleted))
 	})
 }
+
+// unlockedPhotos removes locked photos from the selection, and aborts the request if none are left.
+func unlockedPhotos(c *gin.Context, f *form.Selection) bool {
+	uids, err := query.UnlockedPhotoUIDs(f.Photos)
+
+	if err != nil {
+		AbortEntityNotFound(c)
+		return false
+	} else if len(uids) == 0 {
+		AbortLocked(c)
+		return false
+	}
+
+	if skipped := len(f.Photos) - len(uids); skipped > 0 {
+		log.Infof("photos: skipped %d locked or missing photos", skipped)
+	}
+
+	f.Photos = uids
+
+	return true
+}
+
+// batchAlbums returns the selected albums, or an error if one of them doesn't exist.
+func batchAlbums(uids []string) (albums entity.Albums, err error) {
+	for _, uid := range uids {
+		a, err := query.AlbumByUID(sanitize.IdString(uid))
+
+		if err != nil {
+			return albums, err
+		}
+
+		albums = append(albums, a)
+	}
+
+	return albums, nil
+}
+
+// BatchAlbumsAdd adds multiple photos to multiple albums, either all or none are added.
+//
+// POST /api/v1/batch/albums/add
+func BatchAlbumsAdd(router *gin.RouterGroup) {
+	router.POST("/batch/albums/add", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Albums) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoAlbumsSelected)
+			return
+		} else if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		albums, err := batchAlbums(f.Albums)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		} else if albums.Locked() {
+			AbortLocked(c)
+			return
+		}
+
+		photos, err := query.PhotoSelection(form.Selection{Photos: f.Photos})
+
+		if err != nil {
+			log.Errorf("albums: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		added, err := entity.AddPhotosToAlbums(albums.UIDs(), photos.UIDs())
+
+		if err != nil {
+			log.Errorf("albums: %s (add photos)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		for _, a := range albums {
+			RemoveFromAlbumCoverCache(a.AlbumUID)
+			PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+			SaveAlbumAsYaml(a)
+		}
+
+		entity.UpdateCountsAsync(UpdateClientConfig)
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "albums": albums.UIDs(), "photos": photos.UIDs(), "added": added})
+	})
+}
+
+// BatchAlbumsRemove removes multiple photos from multiple albums, either all or none are removed.
+//
+// POST /api/v1/batch/albums/remove
+func BatchAlbumsRemove(router *gin.RouterGroup) {
+	router.POST("/batch/albums/remove", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Albums) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoAlbumsSelected)
+			return
+		} else if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		albums, err := batchAlbums(f.Albums)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		} else if albums.Locked() {
+			AbortLocked(c)
+			return
+		}
+
+		removed, err := entity.RemovePhotosFromAlbums(albums.UIDs(), f.Photos)
+
+		if err != nil {
+			log.Errorf("albums: %s (remove photos)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		for _, a := range albums {
+			RemoveFromAlbumCoverCache(a.AlbumUID)
+			PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+			SaveAlbumAsYaml(a)
+		}
+
+		entity.UpdateCountsAsync(UpdateClientConfig)
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "albums": albums.UIDs(), "photos": f.Photos, "removed": removed})
+	})
+}
+
+// BatchAlbumsClone creates copies of multiple albums with the same pictures.
+//
+// POST /api/v1/batch/albums/clone
+func BatchAlbumsClone(router *gin.RouterGroup) {
+	router.POST("/batch/albums/clone", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionCreate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Albums) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoAlbumsSelected)
+			return
+		}
+
+		albums, err := batchAlbums(f.Albums)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		clones, err := entity.CloneAlbums(albums)
+
+		if err != nil {
+			log.Errorf("albums: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		for _, clone := range clones {
+			PublishAlbumEvent(EntityCreated, clone.AlbumUID, c)
+			SaveAlbumAsYaml(clone)
+		}
+
+		entity.UpdateCountsAsync(UpdateClientConfig)
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgAlbumCloned), "albums": clones})
+	})
+}
+
+// BatchAlbumsMerge moves the pictures of the selected albums to the first album and deletes the others.
+//
+// POST /api/v1/batch/albums/merge
+func BatchAlbumsMerge(router *gin.RouterGroup) {
+	router.POST("/batch/albums/merge", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionDelete)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Albums) < 2 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoAlbumsSelected)
+			return
+		}
+
+		albums, err := batchAlbums(f.Albums)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		} else if albums.Locked() {
+			AbortLocked(c)
+			return
+		}
+
+		a, others := albums[0], albums[1:]
+
+		added, err := a.Merge(others)
+
+		if err != nil {
+			log.Errorf("albums: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		RemoveFromAlbumCoverCache(a.AlbumUID)
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+		SaveAlbumAsYaml(a)
+
+		// Keep backups of the merged albums, so that they can be restored.
+		for _, other := range others {
+			SaveAlbumAsYaml(other)
+		}
+
+		event.EntitiesDeleted("albums", others.UIDs())
+
+		entity.UpdateCountsAsync(UpdateClientConfig)
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgAlbumsMerged, len(others), sanitize.Log(a.Title())), "album": a, "added": added})
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
@@ -264,6 +265,52 @@ func TestBatchPhotosApprove(t *testing.T) {
 	})
 }
 
+func TestBatchPhotosStack(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosStack(router)
+
+		stack := entity.NewPhoto(true)
+		stack.PhotoName = "BatchStack1"
+
+		if err := stack.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		other := entity.NewPhoto(true)
+		other.PhotoName = "BatchStack2"
+
+		if err := other.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/stack", fmt.Sprintf(`{"photos": ["%s", "%s"]}`, stack.PhotoUID, other.PhotoUID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, stack.PhotoUID, gjson.Get(r.Body.String(), "UID").String())
+		assert.Equal(t, "1", gjson.Get(r.Body.String(), "Stack").String())
+	})
+	t.Run("one photo selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosStack(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/stack", `{"photos": ["pt9jtdre2lvl0y50"]}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("photo not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosStack(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/stack", `{"photos": ["pt9jtdre2lvl0xxx", "pt9jtdre2lvl0y50"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosStack(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/stack", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
 func TestBatchPhotosDelete(t *testing.T) {
 	t.Run("feature disabled", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -274,3 +321,99 @@ func TestBatchPhotosDelete(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, r.Code)
 	})
 }
+
+func TestBatchAlbumsAdd(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateAlbum(router)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "BatchAddFirst"}`)
+	first := gjson.Get(r.Body.String(), "UID").String()
+	r = PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "BatchAddSecond"}`)
+	second := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchAlbumsAdd(router)
+		BatchAlbumsRemove(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/albums/add", fmt.Sprintf(`{"albums": ["%s", "%s"], "photos": ["pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"]}`, first, second))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Len(t, gjson.Get(r.Body.String(), "added").Array(), 4)
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/batch/albums/remove", fmt.Sprintf(`{"albums": ["%s", "%s"], "photos": ["pt9jtdre2lvl0yh8"]}`, first, second))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Len(t, gjson.Get(r.Body.String(), "removed").Array(), 2)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchAlbumsAdd(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/albums/add", fmt.Sprintf(`{"albums": ["%s", "at9lxuqxpoxxxxxx"], "photos": ["pt9jtdre2lvl0yh7"]}`, first))
+		assert.Equal(t, i18n.Msg(i18n.ErrAlbumNotFound), gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("no photos selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchAlbumsRemove(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/albums/remove", fmt.Sprintf(`{"albums": ["%s"], "photos": []}`, first))
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestBatchAlbumsClone(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateAlbum(router)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "BatchClone"}`)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchAlbumsClone(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/albums/clone", fmt.Sprintf(`{"albums": ["%s"]}`, uid))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "BatchClone (Copy)", gjson.Get(r.Body.String(), "albums.0.Title").String())
+		assert.NotEqual(t, uid, gjson.Get(r.Body.String(), "albums.0.UID").String())
+	})
+	t.Run("no albums selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchAlbumsClone(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/albums/clone", `{"albums": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestBatchAlbumsMerge(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateAlbum(router)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "BatchMergeTarget"}`)
+	target := gjson.Get(r.Body.String(), "UID").String()
+	r = PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "BatchMergeSource"}`)
+	source := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbum(router)
+		BatchAlbumsAdd(router)
+		BatchAlbumsMerge(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/albums/add", fmt.Sprintf(`{"albums": ["%s"], "photos": ["pt9jtdre2lvl0yh7"]}`, source))
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/batch/albums/merge", fmt.Sprintf(`{"albums": ["%s", "%s"]}`, target, source))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(r.Body.String(), "added.0.PhotoUID").String())
+
+		r = PerformRequest(app, "GET", "/api/v1/albums/"+source)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("only one album", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchAlbumsMerge(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/albums/merge", fmt.Sprintf(`{"albums": ["%s"]}`, target))
+		assert.Equal(t, i18n.Msg(i18n.ErrNoAlbumsSelected), gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("same album", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchAlbumsMerge(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/albums/merge", fmt.Sprintf(`{"albums": ["%s", "%s"]}`, target, target))
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -65,7 +65,7 @@ func RemoveFromAlbumCoverCache(uid string) {
 		log.Debugf("removed %s from cache", cacheKey)
 	}
 
-	if err := query.UpdateAlbumCovers(); err != nil {
+	if err := query.UpdateAlbumCover(uid); err != nil {
 		log.Error(err)
 	}
 }
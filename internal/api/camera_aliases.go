@@ -0,0 +1,125 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// aliasResource returns the ACL resource for a camera alias type.
+func aliasResource(aliasType string) acl.Resource {
+	if aliasType == entity.AliasLens {
+		return acl.ResourceLenses
+	}
+
+	return acl.ResourceCameras
+}
+
+// GetCameraAliases returns the camera or lens aliases.
+//
+// GET /api/v1/aliases/:type
+func GetCameraAliases(router *gin.RouterGroup) {
+	router.GET("/aliases/:type", func(c *gin.Context) {
+		aliasType := sanitize.Token(c.Param("type"))
+		s := Auth(SessionID(c), aliasResource(aliasType), acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if !entity.AliasTypes[aliasType] {
+			AbortBadRequest(c)
+			return
+		}
+
+		results, err := query.CameraAliases(aliasType)
+
+		if err != nil {
+			log.Errorf("aliases: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// SaveCameraAlias adds or updates a camera or lens alias, the target must exist.
+//
+// POST /api/v1/aliases/:type
+func SaveCameraAlias(router *gin.RouterGroup) {
+	router.POST("/aliases/:type", func(c *gin.Context) {
+		aliasType := sanitize.Token(c.Param("type"))
+		s := Auth(SessionID(c), aliasResource(aliasType), acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.CameraAlias
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.NewCameraAlias(aliasType, f.Name, f.Target)
+
+		if err := m.Validate(); err != nil {
+			log.Errorf("aliases: %s", err)
+			AbortBadRequest(c)
+			return
+		} else if !m.TargetExists() {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Save(); err != nil {
+			log.Errorf("aliases: %s (save)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("aliases: %s is now an alias of %s", sanitize.Log(m.AliasName), sanitize.Log(m.TargetSlug))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteCameraAlias removes a camera or lens alias.
+//
+// DELETE /api/v1/aliases/:type/:slug
+func DeleteCameraAlias(router *gin.RouterGroup) {
+	router.DELETE("/aliases/:type/:slug", func(c *gin.Context) {
+		aliasType := sanitize.Token(c.Param("type"))
+		s := Auth(SessionID(c), aliasResource(aliasType), acl.ActionDelete)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m := entity.FindCameraAlias(aliasType, sanitize.Token(c.Param("slug")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("aliases: %s (delete)", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetCameraAliases(t *testing.T) {
+	t.Run("Camera", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetCameraAliases(router)
+		r := PerformRequest(app, "GET", "/api/v1/aliases/camera")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, gjson.Get(r.Body.String(), "#.Slug").String(), "canon-canon-eos-6d")
+	})
+	t.Run("InvalidType", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetCameraAliases(router)
+		r := PerformRequest(app, "GET", "/api/v1/aliases/flash")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestSaveCameraAlias(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SaveCameraAlias(router)
+		DeleteCameraAlias(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/aliases/camera", `{"Name": "Canon EOS 6D Digital", "Target": "Canon EOS 6D"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "canon-eos-6d-digital", gjson.Get(r.Body.String(), "Slug").String())
+		assert.Equal(t, "canon-eos-6d", gjson.Get(r.Body.String(), "Target").String())
+
+		r = PerformRequest(app, "DELETE", "/api/v1/aliases/camera/canon-eos-6d-digital")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("TargetNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SaveCameraAlias(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/aliases/lens", `{"Name": "Apple F380", "Target": "Lens Does Not Exist"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("SameName", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SaveCameraAlias(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/aliases/camera", `{"Name": "Canon EOS 6D", "Target": "Canon EOS 6D"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestDeleteCameraAlias(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteCameraAlias(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/aliases/camera/nikon-nikon-d750")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
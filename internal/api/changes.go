@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// GetChanges returns the UIDs of photos and albums that have changed since a sync token,
+// so that clients can update cached search results incrementally.
+//
+// GET /api/v1/changes?since=<token>
+func GetChanges(router *gin.RouterGroup) {
+	router.GET("/changes", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		since, err := query.ParseSyncToken(c.Query("since"))
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		var shares []string
+
+		// Guests may only see changes of public content in shared albums.
+		if SharedOnly(s, acl.ResourcePhotos) {
+			shares = append([]string{}, s.Shares...)
+		}
+
+		result, err := query.ChangesSince(since, shares)
+
+		if err != nil {
+			AbortError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+func TestGetChanges(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetChanges(router)
+		r := PerformRequest(app, "GET", "/api/v1/changes")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "Token").String())
+		assert.Greater(t, gjson.Get(r.Body.String(), "Photos.Created.#").Int(), int64(0))
+		assert.Greater(t, gjson.Get(r.Body.String(), "Albums.Created.#").Int(), int64(0))
+	})
+	t.Run("Since", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetChanges(router)
+		r := PerformRequest(app, "GET", "/api/v1/changes?since=32503680000")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "Photos.Created.#").Int())
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "Photos.Deleted.#").Int())
+	})
+	t.Run("Guest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetChanges(router)
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Tokens: []string{"4jxf3jfn2k"}, Shares: session.UIDs{"at9lxuqxpogaaba9"}})
+		r := AuthenticatedRequest(app, "GET", "/api/v1/changes", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "at9lxuqxpogaaba9", gjson.Get(r.Body.String(), "Albums.Created.0").String())
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "Albums.Created.#").Int())
+		assert.NotContains(t, gjson.Get(r.Body.String(), "Photos.Created").String(), "pt9jtdre2lvl0yh7")
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetChanges(router)
+		r := PerformRequest(app, "GET", "/api/v1/changes?since=foo")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -30,9 +30,9 @@ func GetConfig(router *gin.RouterGroup) {
 		conf := service.Config()
 
 		if s.User.Guest() {
-			c.JSON(http.StatusOK, conf.GuestConfig())
+			c.JSON(http.StatusOK, AccessConfig(SessionID(c), conf.GuestConfig()))
 		} else if s.User.Registered() {
-			c.JSON(http.StatusOK, conf.UserConfig())
+			c.JSON(http.StatusOK, AccessConfig(SessionID(c), conf.UserConfig()))
 		} else {
 			c.JSON(http.StatusOK, conf.PublicConfig())
 		}
@@ -86,13 +86,13 @@ func SaveConfigOptions(router *gin.RouterGroup) {
 
 			if err != nil {
 				log.Errorf("options: %s", err)
-				c.AbortWithStatusJSON(http.StatusInternalServerError, err)
+				AbortError(c, http.StatusInternalServerError, err)
 				return
 			}
 
 			if err := yaml.Unmarshal(yamlData, v); err != nil {
 				log.Errorf("options: %s", err)
-				c.AbortWithStatusJSON(http.StatusInternalServerError, err)
+				AbortError(c, http.StatusInternalServerError, err)
 				return
 			}
 		}
@@ -107,27 +107,27 @@ func SaveConfigOptions(router *gin.RouterGroup) {
 
 		if err != nil {
 			log.Errorf("options: %s", err)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, err)
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
 		// Make sure directory exists.
 		if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
 			log.Errorf("options: %s", err)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, err)
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
 		// Write YAML data to file.
 		if err := os.WriteFile(fileName, yamlData, os.ModePerm); err != nil {
 			log.Errorf("options: %s", err)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, err)
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
 		if err := conf.Options().Load(fileName); err != nil {
 			log.Errorf("options: %s", err)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, err)
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -140,3 +140,31 @@ func SaveConfigOptions(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, conf.Options())
 	})
 }
+
+// RotateTokens replaces the download and preview tokens, so that previously shared thumbnail and download URLs
+// stop working. Expiring session tokens are revoked, static tokens are replaced until the next restart.
+//
+// POST /api/v1/config/tokens
+func RotateTokens(router *gin.RouterGroup) {
+	router.POST("/config/tokens", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceConfigOptions, acl.ActionUpdate)
+		conf := service.Config()
+
+		if s.Invalid() || conf.Public() || conf.DisableSettings() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if conf.TokenLifetime() > 0 {
+			service.Session().RevokeAccess()
+		} else {
+			conf.RotateTokens()
+		}
+
+		log.Infof("config: rotated download and preview tokens")
+
+		UpdateClientConfig()
+
+		c.JSON(http.StatusOK, AccessConfig(SessionID(c), conf.UserConfig()))
+	})
+}
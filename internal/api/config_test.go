@@ -37,3 +37,12 @@ func TestSaveConfigOptions(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, r.Code)
 	})
 }
+
+func TestRotateTokens(t *testing.T) {
+	t.Run("unauthorised", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RotateTokens(router)
+		r := PerformRequest(app, "POST", "/api/v1/config/tokens")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
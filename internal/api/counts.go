@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// GetCounts returns precalculated photo counts by type and key, e.g. the number of photos per album,
+// label, person, country, and year. Use the type parameter to limit the results, e.g. ?type=album,year.
+// Users without admin rights only get the counts of albums they are a member of.
+//
+// GET /api/v1/counts
+func GetCounts(router *gin.RouterGroup) {
+	router.GET("/counts", func(c *gin.Context) {
+		s := AuthUser(SessionID(c), acl.ResourceCounts, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		// Users who may not search all pictures only get the counts of their albums.
+		all := !SharedOnly(s, acl.ResourcePhotos)
+		types := append([]string{entity.CountTotal}, entity.PhotoCountTypes...)
+
+		if !all {
+			types = []string{entity.CountAlbum}
+		}
+
+		if param := strings.TrimSpace(c.Query("type")); param != "" {
+			types = strings.Split(strings.ToLower(param), ",")
+		}
+
+		results := make(map[string]map[string]int, len(types))
+
+		for _, t := range types {
+			t = strings.TrimSpace(t)
+
+			if t != entity.CountTotal && !validCountType(t) {
+				AbortBadRequest(c)
+				return
+			}
+
+			var counts map[string]int
+			var err error
+
+			if all {
+				counts, err = query.PhotoCounts(t)
+			} else if t == entity.CountAlbum {
+				counts, err = query.MemberAlbumCounts(s.User.UserUID)
+			} else {
+				AbortUnauthorized(c)
+				return
+			}
+
+			if err != nil {
+				log.Errorf("counts: %s", err)
+				AbortUnexpected(c)
+				return
+			}
+
+			results[t] = counts
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// validCountType tests if the photo count type is known.
+func validCountType(t string) bool {
+	for _, countType := range entity.PhotoCountTypes {
+		if t == countType {
+			return true
+		}
+	}
+
+	return false
+}
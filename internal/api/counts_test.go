@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+func TestGetCounts(t *testing.T) {
+	if err := entity.UpdateCounts(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("All", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetCounts(router)
+		r := PerformRequest(app, "GET", "/api/v1/counts")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Greater(t, gjson.Get(r.Body.String(), "total.photos").Int(), int64(0))
+		assert.True(t, gjson.Get(r.Body.String(), "album").IsObject())
+		assert.True(t, gjson.Get(r.Body.String(), "year").IsObject())
+	})
+	t.Run("Type", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetCounts(router)
+		r := PerformRequest(app, "GET", "/api/v1/counts?type=album,country")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "album").IsObject())
+		assert.True(t, gjson.Get(r.Body.String(), "country").IsObject())
+		assert.False(t, gjson.Get(r.Body.String(), "total").Exists())
+	})
+	t.Run("UnknownType", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetCounts(router)
+		r := PerformRequest(app, "GET", "/api/v1/counts?type=xxx")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Member", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetCounts(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/counts", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "album").Exists())
+		assert.False(t, gjson.Get(r.Body.String(), "album.at9lxuqxpogaaba7").Exists())
+		assert.False(t, gjson.Get(r.Body.String(), "total").Exists())
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/counts?type=year", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("Guest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetCounts(router)
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Tokens: []string{"4jxf3jfn2k"}, Shares: session.UIDs{"at9lxuqxpogaaba8"}})
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/counts", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -2,10 +2,13 @@ package api
 
 import (
 	"net/http"
+	"os"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
@@ -20,6 +23,11 @@ import (
 
 // DownloadName returns the download file name type.
 func DownloadName(c *gin.Context) entity.DownloadName {
+	// Shared files must not reveal their names if they are redacted.
+	if ShareDownload(c) && service.Config().ShareRedacted(entity.RedactFilenames) {
+		return entity.DownloadNameShare
+	}
+
 	switch c.Query("name") {
 	case "file":
 		return entity.DownloadNameFile
@@ -32,6 +40,60 @@ func DownloadName(c *gin.Context) entity.DownloadName {
 	}
 }
 
+// shareRedact returns the worker that redacts downloads of the shared entities, or nil if the download is
+// not shared. GPS coordinates are removed unless the links allow exact locations, using the strictest
+// level of all links to the entities if the session is unknown, e.g. with static download tokens.
+func shareRedact(c *gin.Context, shareUIDs ...string) *photoprism.Redact {
+	conf := service.Config()
+	token := sanitize.Token(c.Query("t"))
+	level := ""
+
+	if s, ok := service.Session().DownloadToken(token); ok {
+		if !SharedOnly(s, acl.ResourcePhotos) {
+			return nil
+		}
+
+		for _, uid := range shareUIDs {
+			level = entity.GeoStricter(level, s.GeoPrivacy(uid))
+		}
+	} else if conf.ShareDownload(token) {
+		for _, uid := range shareUIDs {
+			for _, link := range entity.FindValidLinks("", uid) {
+				level = entity.GeoStricter(level, link.LinkGeo)
+			}
+		}
+	} else {
+		return nil
+	}
+
+	redact := photoprism.NewRedact(conf)
+
+	if level != entity.GeoExact {
+		redact.Add(entity.RedactGps)
+	}
+
+	return redact
+}
+
+// photoRedact returns the worker that redacts downloads of a shared photo based on the links of its albums,
+// or nil if the download is not shared.
+func photoRedact(c *gin.Context, photoUID string) *photoprism.Redact {
+	albums, err := query.PhotoAlbumUIDs(photoUID)
+
+	if err != nil {
+		log.Errorf("download: %s", err)
+	}
+
+	return shareRedact(c, albums...)
+}
+
+// removeRedactedFile removes a copy created by Redact.File once it has been served.
+func removeRedactedFile(fileName string) {
+	if err := os.Remove(fileName); err != nil {
+		log.Warnf("download: %s", err)
+	}
+}
+
 // GET /api/v1/dl/:hash
 //
 // Parameters:
@@ -48,7 +110,7 @@ func GetDownload(router *gin.RouterGroup) {
 		f, err := query.FileByHash(fileHash)
 
 		if err != nil {
-			c.AbortWithStatusJSON(404, gin.H{"error": err.Error()})
+			Abort(c, http.StatusNotFound, i18n.ErrFileNotFound)
 			return
 		}
 
@@ -64,6 +126,16 @@ func GetDownload(router *gin.RouterGroup) {
 			return
 		}
 
+		if redact := photoRedact(c, f.PhotoUID); redact != nil && redact.Enabled() {
+			if fileName, err = redact.File(fileName); err != nil {
+				log.Errorf("download: %s", err)
+				c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+				return
+			}
+
+			defer removeRedactedFile(fileName)
+		}
+
 		c.FileAttachment(fileName, f.DownloadName(DownloadName(c), 0))
 	})
 }
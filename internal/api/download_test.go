@@ -2,13 +2,55 @@ package api
 
 import (
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/tidwall/gjson"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
+// redactDownloadTest adds the photo to an album shared with rounded locations, creates its original, and uses a fake
+// ExifTool command that writes its arguments to the output file, so that the downloaded file shows what was removed.
+func redactDownloadTest(t *testing.T, conf *config.Config) func() {
+	fileName := photoprism.FileName(entity.RootOriginals, "2790/07/27900704_070228_D6D51B6C.jpg")
+	binName := filepath.Join(conf.TempPath(), "exiftool-download")
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		t.Fatal(err)
+	} else if err := fs.Copy(filepath.Join(conf.ExamplesPath(), "tree_white.jpg"), fileName); err != nil {
+		t.Fatal(err)
+	} else if err := os.WriteFile(binName, []byte("#!/bin/sh\necho \"$@\" > \"$4\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := entity.NewPhotoAlbum("pt9jtdre2lvl0yh7", "at9lxuqxpogaaba7")
+
+	if err := entry.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	conf.Options().ShareRedact = "serial"
+	conf.Options().ExifToolBin = binName
+
+	return func() {
+		conf.Options().ShareRedact = ""
+		conf.Options().ExifToolBin = ""
+		_ = entity.Db().Delete(entry).Error
+		_ = os.Remove(binName)
+		_ = os.Remove(fileName)
+	}
+}
+
 func TestGetDownload(t *testing.T) {
 	t.Run("download not existing file", func(t *testing.T) {
 		app, router, conf := NewApiTest()
@@ -17,7 +59,8 @@ func TestGetDownload(t *testing.T) {
 
 		r := PerformRequest(app, "GET", "/api/v1/dl/123xxx?t="+conf.DownloadToken())
 		val := gjson.Get(r.Body.String(), "error")
-		assert.Equal(t, "record not found", val.String())
+		assert.Equal(t, "File not found", val.String())
+		assert.Equal(t, "file_not_found", gjson.Get(r.Body.String(), "id").String())
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 	t.Run("could not find original", func(t *testing.T) {
@@ -32,4 +75,47 @@ func TestGetDownload(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/dl/3cad9168fa6acc5c5c2965ddf6ec465ca42fd818?t=xxx")
 		assert.Equal(t, http.StatusForbidden, r.Code)
 	})
+	t.Run("share token removes gps", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer redactDownloadTest(t, conf)()
+		GetDownload(router)
+		r := PerformRequest(app, "GET", "/api/v1/dl/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818?t="+conf.ShareDownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "-SerialNumber=")
+		assert.Contains(t, r.Body.String(), "-gps:all=")
+	})
+}
+
+func TestShareRedact(t *testing.T) {
+	conf := service.Config()
+	conf.Options().ShareRedact = "serial"
+	defer func() { conf.Options().ShareRedact = "" }()
+
+	request := func(token string) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/albums/at9lxuqxpogaaba8/dl?t="+token, nil)
+		return c
+	}
+
+	t.Run("NotShared", func(t *testing.T) {
+		assert.Nil(t, shareRedact(request(conf.DownloadToken()), "at9lxuqxpogaaba8"))
+	})
+	t.Run("ExactLinks", func(t *testing.T) {
+		redact := shareRedact(request(conf.ShareDownloadToken()), "at9lxuqxpogaaba8")
+
+		if redact == nil {
+			t.Fatal("redact must not be nil")
+		}
+
+		assert.Equal(t, []string{entity.RedactSerial}, redact.Fields())
+	})
+	t.Run("RoundedLinks", func(t *testing.T) {
+		redact := shareRedact(request(conf.ShareDownloadToken()), "at9lxuqxpogaaba7")
+
+		if redact == nil {
+			t.Fatal("redact must not be nil")
+		}
+
+		assert.Equal(t, []string{entity.RedactSerial, entity.RedactGps}, redact.Fields())
+	})
 }
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// DownloadsLimit is the maximum number of urls that can be submitted at once.
+const DownloadsLimit = 1000
+
+// GetDownloads returns the remote downloads and feeds of the current user, newest first.
+//
+// GET /api/v1/downloads
+func GetDownloads(router *gin.RouterGroup) {
+	router.GET("/downloads", func(c *gin.Context) {
+		s := AuthUser(SessionID(c), acl.ResourceDownloads, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		if limit <= 0 || limit > DownloadsLimit {
+			limit = 100
+		}
+
+		results, err := query.Downloads(s.User.UserUID, limit, offset)
+
+		if err != nil {
+			log.Errorf("download: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(results))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// AddDownloads adds remote files and RSS / Atom feeds to the download queue. Files are downloaded and
+// imported in the background, duplicate urls are ignored.
+//
+// POST /api/v1/downloads
+func AddDownloads(router *gin.RouterGroup) {
+	router.POST("/downloads", func(c *gin.Context) {
+		s := AuthUser(SessionID(c), acl.ResourceDownloads, acl.ActionCreate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if conf.ReadOnly() || !conf.Settings().Features.Import {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.Download
+
+		if err := c.BindJSON(&f); err != nil || f.Empty() || len(f.URLs)+len(f.Feeds) > DownloadsLimit {
+			AbortBadRequest(c)
+			return
+		}
+
+		albumUID := sanitize.IdString(f.Album)
+
+		if albumUID != "" {
+			if _, err := query.AlbumByUID(albumUID); err != nil {
+				Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+				return
+			}
+		}
+
+		var downloads []*entity.Download
+
+		for _, u := range f.URLs {
+			downloads = append(downloads, entity.NewDownload(u, entity.DownloadFile, albumUID, s.User.UserUID))
+		}
+
+		for _, u := range f.Feeds {
+			downloads = append(downloads, entity.NewDownload(u, entity.DownloadFeed, albumUID, s.User.UserUID))
+		}
+
+		// Make sure all urls are valid before adding any of them.
+		for _, d := range downloads {
+			if err := d.Validate(); err != nil {
+				log.Debugf("download: %s", err)
+				AbortBadRequest(c)
+				return
+			}
+		}
+
+		results := make(entity.Downloads, 0, len(downloads))
+		added := 0
+
+		for _, d := range downloads {
+			m, created, err := d.FirstOrCreate()
+
+			if err != nil {
+				log.Errorf("download: %s (create)", err)
+				AbortSaveFailed(c)
+				return
+			} else if created {
+				added++
+			}
+
+			results = append(results, *m)
+		}
+
+		log.Infof("download: added %d urls to the download queue", added)
+
+		if added > 0 {
+			workers.StartDownloads(conf)
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// DeleteDownload removes a remote download or feed from the queue.
+//
+// DELETE /api/v1/downloads/:id
+func DeleteDownload(router *gin.RouterGroup) {
+	router.DELETE("/downloads/:id", func(c *gin.Context) {
+		s := AuthUser(SessionID(c), acl.ResourceDownloads, acl.ActionDelete)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m := entity.FindDownload(txt.UInt(c.Param("id")))
+
+		if m == nil || !s.Owner(m.UserUID) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("download: %s (delete)", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestAddDownloads(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddDownloads(router)
+		GetDownloads(router)
+		DeleteDownload(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/downloads", `{"Feeds": ["https://feeds.example.com/feed.xml"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "feed", gjson.Get(r.Body.String(), "0.Type").String())
+		assert.Equal(t, "pending", gjson.Get(r.Body.String(), "0.Status").String())
+
+		id := gjson.Get(r.Body.String(), "0.ID").String()
+
+		r = PerformRequest(app, "GET", "/api/v1/downloads")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, gjson.Get(r.Body.String(), "#.ID").String(), id)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/downloads/"+id)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/downloads/"+id)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("Duplicate", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddDownloads(router)
+		DeleteDownload(router)
+
+		// The same url added by another user is a different download.
+		r := PerformRequestWithBody(app, "POST", "/api/v1/downloads", `{"URLs": ["https://images.example.com/2020/sunset.jpg"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		id := gjson.Get(r.Body.String(), "0.ID").Int()
+		assert.NotEqual(t, int64(1000000), id)
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/downloads", `{"URLs": ["https://images.example.com/2020/sunset.jpg"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, id, gjson.Get(r.Body.String(), "0.ID").Int())
+
+		r = PerformRequest(app, "DELETE", "/api/v1/downloads/"+gjson.Get(r.Body.String(), "0.ID").String())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddDownloads(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/downloads", `{"URLs": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidURL", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddDownloads(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/downloads", `{"URLs": ["file:///etc/passwd"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("LocalURL", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddDownloads(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/downloads", `{"URLs": ["http://127.0.0.1:2342/api/v1/config"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddDownloads(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/downloads", `{"URLs": ["https://example.com/a.jpg"], "Album": "at9lxuqxpogaaxxx"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -22,7 +22,7 @@ func GetErrors(router *gin.RouterGroup) {
 		offset := txt.Int(c.Query("offset"))
 
 		if resp, err := query.Errors(limit, offset, c.Query("q")); err != nil {
-			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, 400, err)
 			return
 		} else {
 			AddCountHeader(c, len(resp))
@@ -13,7 +13,6 @@ import (
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/search"
-	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // GetFace returns a face as JSON.
@@ -70,7 +69,7 @@ func UpdateFace(router *gin.RouterGroup) {
 		if !f.FaceHidden && f.FaceHidden == m.FaceHidden {
 			// Do nothing.
 		} else if err := m.Update("FaceHidden", f.FaceHidden); err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -78,7 +77,7 @@ func UpdateFace(router *gin.RouterGroup) {
 		if f.SubjUID == "" {
 			// Do nothing.
 		} else if err := m.SetSubjectUID(f.SubjUID); err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// FaceReviewLimit is the maximum number of faces returned for review at once.
+const FaceReviewLimit = 100
+
+// GetFaceReview returns the next unnamed faces ordered by cluster confidence, with suggested names.
+//
+// GET /api/v1/review/faces
+//
+// Parameters:
+//   count: int Max number of faces (optional, default 20)
+func GetFaceReview(router *gin.RouterGroup) {
+	router.GET("/review/faces", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceSubjects, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if !service.Config().Settings().Features.People {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		count := 20
+
+		if v := c.Query("count"); v == "" {
+			// Use default.
+		} else if n, err := strconv.Atoi(v); err != nil || n < 1 {
+			AbortBadRequest(c)
+			return
+		} else if n > FaceReviewLimit {
+			count = FaceReviewLimit
+		} else {
+			count = n
+		}
+
+		results, err := service.Faces().Review(count)
+
+		if err != nil {
+			log.Errorf("faces: %s (review)", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// ConfirmFaceReview names or rejects a batch of reviewed faces.
+//
+// POST /api/v1/review/faces
+func ConfirmFaceReview(router *gin.RouterGroup) {
+	router.POST("/review/faces", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceSubjects, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if !service.Config().Settings().Features.People {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.FaceReview
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if f.Empty() {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		} else if !unlockedFaces(c, &f) {
+			return
+		}
+
+		if err := mutex.People.Start(); err != nil {
+			AbortBusy(c)
+			return
+		}
+
+		defer mutex.People.Stop()
+
+		result, err := service.Faces().Confirm(f)
+
+		if err != nil {
+			log.Errorf("faces: %s (confirm)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		if result.Named > 0 {
+			if res, err := service.Faces().Optimize(); err != nil {
+				log.Errorf("faces: %s (optimize)", err)
+			} else if res.Merged > 0 {
+				log.Infof("faces: merged %s", english.Plural(res.Merged, "cluster", "clusters"))
+			}
+		}
+
+		if result.Named > 0 || result.Rejected > 0 {
+			if err := query.UpdateSubjectCovers(); err != nil {
+				log.Errorf("faces: %s (update covers)", err)
+			}
+
+			if err := entity.UpdateSubjectCounts(); err != nil {
+				log.Errorf("faces: %s (update counts)", err)
+			}
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// unlockedFaces removes faces of locked photos from the review, and aborts the request if none are left.
+func unlockedFaces(c *gin.Context, f *form.FaceReview) bool {
+	var faces []form.FaceConfirmation
+
+	for _, face := range f.Faces {
+		// Faces that don't exist are skipped when confirming the review.
+		if m, err := query.MarkerByUID(face.MarkerUID); err != nil || m.FileUID == "" {
+			faces = append(faces, face)
+		} else if file, err := query.FileByUID(m.FileUID); err == nil && photoLocked(file.PhotoUID) {
+			continue
+		} else {
+			faces = append(faces, face)
+		}
+	}
+
+	if len(faces) == 0 {
+		AbortLocked(c)
+		return false
+	}
+
+	if skipped := len(f.Faces) - len(faces); skipped > 0 {
+		log.Infof("faces: skipped %d faces of locked photos", skipped)
+	}
+
+	f.Faces = faces
+
+	return true
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+func TestGetFaceReview(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetFaceReview(router)
+		r := PerformRequest(app, "GET", "/api/v1/review/faces?count=3")
+		assert.Equal(t, http.StatusOK, r.Code)
+		count := gjson.Get(r.Body.String(), "#").Int()
+		assert.LessOrEqual(t, count, int64(3))
+		assert.Greater(t, count, int64(0))
+		assert.True(t, gjson.Get(r.Body.String(), "0.Suggestions").IsArray())
+	})
+	t.Run("InvalidCount", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetFaceReview(router)
+		r := PerformRequest(app, "GET", "/api/v1/review/faces?count=abc")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestConfirmFaceReview(t *testing.T) {
+	t.Run("NothingSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ConfirmFaceReview(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/review/faces", `{"Faces": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Skipped", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ConfirmFaceReview(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/review/faces", `{"Faces": [{"UID": "mt9k3pw1wowuy000", "Name": "Jane Doe"}]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "Skipped").Int())
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "Named").Int())
+	})
+	t.Run("Locked", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ConfirmFaceReview(router)
+
+		m, err := query.PhotoByUID("pt9jtdre2lvl0y12")
+
+		if err != nil {
+			t.Fatal(err)
+		} else if err := m.SetLocked(true); err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.SetLocked(false)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/review/faces", `{"Faces": [{"UID": "mt9k3pw1wowu1002", "Name": "Jane Doe"}]}`)
+		assert.Equal(t, http.StatusLocked, r.Code)
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
@@ -51,6 +52,9 @@ func DeleteFile(router *gin.RouterGroup) {
 			log.Errorf("photo: cannot delete primary file")
 			AbortDeleteFailed(c)
 			return
+		} else if photoLocked(file.PhotoUID) {
+			AbortLocked(c)
+			return
 		}
 
 		fileName := photoprism.FileName(file.FileRoot, file.FileName)
@@ -74,6 +78,9 @@ func DeleteFile(router *gin.RouterGroup) {
 			return
 		}
 
+		// Update precalculated photo and file counts.
+		entity.UpdateCountsAsync(UpdateClientConfig)
+
 		// Notify clients by publishing events.
 		PublishPhotoEvent(EntityUpdated, photoUID, c)
 
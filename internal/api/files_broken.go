@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetBrokenFiles returns originals that could not be decoded while indexing.
+//
+// GET /api/v1/files/broken
+func GetBrokenFiles(router *gin.RouterGroup) {
+	router.GET("/files/broken", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceFiles, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		if limit <= 0 {
+			limit = 1000
+		}
+
+		resp, err := query.BrokenFiles(limit, offset)
+
+		if err != nil {
+			log.Errorf("files: %s (find broken)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(resp))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, resp)
+	})
+}
+
+// DismissBrokenFile removes a file from the list of broken files, e.g. after it has been replaced.
+//
+// DELETE /api/v1/files/broken?name=:name&root=:root
+func DismissBrokenFile(router *gin.RouterGroup) {
+	router.DELETE("/files/broken", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceFiles, acl.ActionDelete)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		fileName := c.Query("name")
+		fileRoot := sanitize.Token(c.Query("root"))
+
+		if fileRoot == "" {
+			fileRoot = entity.RootOriginals
+		}
+
+		m := entity.BrokenFile{FileName: fileName, FileRoot: fileRoot}
+
+		if fileName == "" || m.Find() != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Purge(); err != nil {
+			AbortDeleteFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
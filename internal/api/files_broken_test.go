@@ -0,0 +1,55 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestGetBrokenFiles(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		if err := entity.AddBrokenFile("2022/01/api-broken.jpg", entity.RootOriginals, 42, errors.New("invalid JPEG format")); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.PurgeBrokenFile("2022/01/api-broken.jpg", entity.RootOriginals)
+
+		GetBrokenFiles(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/files/broken?count=100")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, gjson.Get(r.Body.String(), "#.Name").String(), "2022/01/api-broken.jpg")
+	})
+}
+
+func TestDismissBrokenFile(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		if err := entity.AddBrokenFile("2022/01/api-dismiss.jpg", entity.RootOriginals, 42, errors.New("invalid JPEG format")); err != nil {
+			t.Fatal(err)
+		}
+
+		DismissBrokenFile(router)
+
+		r := PerformRequest(app, "DELETE", "/api/v1/files/broken?name=2022/01/api-dismiss.jpg")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "2022/01/api-dismiss.jpg", gjson.Get(r.Body.String(), "Name").String())
+
+		m := entity.BrokenFile{FileName: "2022/01/api-dismiss.jpg", FileRoot: entity.RootOriginals}
+		assert.Error(t, m.Find())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DismissBrokenFile(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/files/broken?name=2022/01/missing.jpg")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
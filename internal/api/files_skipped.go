@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+// GetSkippedFiles returns the hidden, ignored, and junk files that were skipped by the last index or import run,
+// as well as files exceeding the configured size, folder depth, or video duration limits.
+//
+// GET /api/v1/files/skipped
+//
+// Parameters:
+//   worker: string Worker name, either "index" (default), "import", or "transcode"
+func GetSkippedFiles(router *gin.RouterGroup) {
+	router.GET("/files/skipped", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceFiles, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		worker := c.Query("worker")
+
+		switch worker {
+		case "":
+			worker = "index"
+		case "index", "import", "transcode":
+		default:
+			AbortBadRequest(c)
+			return
+		}
+
+		resp := photoprism.Skipped(worker)
+
+		AddCountHeader(c, len(resp))
+
+		c.JSON(http.StatusOK, resp)
+	})
+}
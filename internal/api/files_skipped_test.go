@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSkippedFiles(t *testing.T) {
+	t.Run("Index", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSkippedFiles(router)
+		r := PerformRequest(app, "GET", "/api/v1/files/skipped")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("Import", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSkippedFiles(router)
+		r := PerformRequest(app, "GET", "/api/v1/files/skipped?worker=import")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("Transcode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSkippedFiles(router)
+		r := PerformRequest(app, "GET", "/api/v1/files/skipped?worker=transcode")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("InvalidWorker", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSkippedFiles(router)
+		r := PerformRequest(app, "GET", "/api/v1/files/skipped?worker=convert")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
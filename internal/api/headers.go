@@ -64,8 +64,28 @@ func AddFileCountHeaders(c *gin.Context, filesCount, foldersCount int) {
 	c.Header("X-Folders", strconv.Itoa(foldersCount))
 }
 
+// AccessTokens returns the preview and download tokens of the session if tokens expire, or the static tokens otherwise.
+func AccessTokens(id string) (previewToken, downloadToken string) {
+	conf := service.Config()
+
+	if lifetime := conf.TokenLifetime(); lifetime > 0 {
+		a, err := service.Session().Access(id, lifetime)
+
+		if err != nil {
+			log.Debugf("session: %s", err)
+		}
+
+		return a.PreviewToken, a.DownloadToken
+	}
+
+	return conf.PreviewToken(), conf.DownloadToken()
+}
+
 // AddTokenHeaders adds preview token headers to the response.
 func AddTokenHeaders(c *gin.Context) {
-	c.Header("X-Preview-Token", service.Config().PreviewToken())
-	c.Header("X-Download-Token", service.Config().DownloadToken())
+	// Add session tokens, so that clients get new tokens before the current ones expire.
+	if previewToken, downloadToken := AccessTokens(SessionID(c)); previewToken != "" {
+		c.Header("X-Preview-Token", previewToken)
+		c.Header("X-Download-Token", downloadToken)
+	}
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
@@ -82,6 +84,11 @@ func StartImport(router *gin.RouterGroup) {
 			opt.Albums = f.Albums
 		}
 
+		if err := imp.CheckStorage(opt); errors.Is(err, config.ErrStorageFull) {
+			AbortStorageFull(c)
+			return
+		}
+
 		imp.Start(opt)
 
 		if subPath != "" && path != conf.ImportPath() && fs.IsEmpty(path) {
@@ -103,7 +110,7 @@ func StartImport(router *gin.RouterGroup) {
 		msg := i18n.Msg(i18n.MsgImportCompletedIn, elapsed)
 
 		event.Success(msg)
-		event.Publish("import.completed", event.Data{"path": path, "seconds": elapsed})
+		event.Publish("import.completed", event.Data{"path": path, "seconds": elapsed, "user": s.User.UserUID})
 		event.Publish("index.completed", event.Data{"path": path, "seconds": elapsed})
 
 		for _, uid := range f.Albums {
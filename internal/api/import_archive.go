@@ -0,0 +1,142 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// ImportArchives imports uploaded ZIP and TAR archives, keeping their folder structure, and
+// returns the import status of each extracted file.
+//
+// POST /api/v1/archives
+func ImportArchives(router *gin.RouterGroup) {
+	router.POST("/archives", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionImport)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if conf.ReadOnly() || !conf.Settings().Features.Import {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		// Refuse uploads that would use up the storage reserve.
+		if err := conf.CheckStorage(conf.TempPath(), c.Request.ContentLength); err != nil {
+			AbortStorageFull(c)
+			return
+		}
+
+		start := time.Now()
+
+		f, err := c.MultipartForm()
+
+		if err != nil {
+			log.Errorf("import: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		files := f.File["files"]
+		albums := f.Value["albums"]
+
+		if len(files) == 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		uploadPath := filepath.Join(conf.TempPath(), "uploads", rnd.UUID())
+
+		if err := os.MkdirAll(uploadPath, os.ModePerm); err != nil {
+			log.Errorf("import: failed creating folder for archives (%s)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		defer os.RemoveAll(uploadPath)
+
+		imp := service.Import()
+
+		var results photoprism.ArchiveResults
+
+		for _, file := range files {
+			baseName := filepath.Base(file.Filename)
+
+			if !fs.IsArchive(baseName) {
+				log.Errorf("import: %s is not a supported archive", sanitize.Log(baseName))
+				AbortBadRequest(c)
+				return
+			}
+
+			archiveName := filepath.Join(uploadPath, baseName)
+
+			if err := c.SaveUploadedFile(file, archiveName); err != nil {
+				log.Errorf("import: failed saving archive %s", sanitize.Log(baseName))
+				AbortBadRequest(c)
+				return
+			}
+
+			event.InfoMsg(i18n.MsgCopyingFilesFrom, sanitize.Log(baseName))
+
+			r, err := imp.Archive(archiveName, albums)
+
+			if errors.Is(err, config.ErrStorageFull) {
+				AbortStorageFull(c)
+				return
+			} else if err != nil {
+				log.Errorf("import: %s in %s", err, sanitize.Log(baseName))
+				Abort(c, http.StatusBadRequest, i18n.ErrInvalidArchive)
+				return
+			}
+
+			results = append(results, r...)
+		}
+
+		moments := service.Moments()
+
+		if err := moments.Start(); err != nil {
+			log.Warnf("moments: %s", err)
+		}
+
+		elapsed := int(time.Since(start).Seconds())
+
+		msg := i18n.Msg(i18n.MsgImportCompletedIn, elapsed)
+
+		event.Success(msg)
+		event.Publish("import.completed", event.Data{"path": uploadPath, "seconds": elapsed, "user": s.User.UserUID})
+		event.Publish("index.completed", event.Data{"path": uploadPath, "seconds": elapsed})
+
+		for _, uid := range albums {
+			PublishAlbumEvent(EntityUpdated, uid, c)
+		}
+
+		UpdateClientConfig()
+
+		// Update album, label, and subject cover thumbs.
+		if err := query.UpdateCovers(); err != nil {
+			log.Warnf("index: %s (update covers)", err)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": msg, "files": results})
+	})
+}
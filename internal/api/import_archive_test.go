@@ -0,0 +1,45 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportArchives(t *testing.T) {
+	t.Run("NoForm", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ImportArchives(router)
+		r := PerformRequest(app, "POST", "/api/v1/archives")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotAnArchive", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ImportArchives(router)
+
+		body := &bytes.Buffer{}
+		w := multipart.NewWriter(body)
+
+		if fw, err := w.CreateFormFile("files", "photo.jpg"); err != nil {
+			t.Fatal(err)
+		} else if _, err := fw.Write([]byte("not an archive")); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		req, _ := http.NewRequest("POST", "/api/v1/archives", body)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+
+		r := httptest.NewRecorder()
+		app.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
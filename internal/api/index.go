@@ -3,6 +3,7 @@ package api
 import (
 	"net/http"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -14,7 +15,6 @@ import (
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/sanitize"
-	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // StartIndexing indexes media files in the "originals" folder.
@@ -45,6 +45,13 @@ func StartIndexing(router *gin.RouterGroup) {
 			return
 		}
 
+		stages, err := photoprism.ParseIndexStages(strings.Join(f.Stages, ","))
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
 		path := conf.OriginalsPath()
 
 		ind := service.Index()
@@ -53,10 +60,14 @@ func StartIndexing(router *gin.RouterGroup) {
 			Rescan:  f.Rescan,
 			Convert: conf.Settings().Index.Convert && conf.SidecarWritable(),
 			Path:    filepath.Clean(f.Path),
+			Filter:  strings.TrimSpace(f.Filter),
+			Stages:  stages,
 			Stack:   true,
 		}
 
-		if len(indOpt.Path) > 1 {
+		if indOpt.Filter != "" {
+			event.InfoMsg(i18n.MsgIndexingFilesMatching, sanitize.Log(indOpt.Filter))
+		} else if len(indOpt.Path) > 1 {
 			event.InfoMsg(i18n.MsgIndexingFiles, sanitize.Log(indOpt.Path))
 		} else {
 			event.InfoMsg(i18n.MsgIndexingOriginals)
@@ -74,7 +85,7 @@ func StartIndexing(router *gin.RouterGroup) {
 		}
 
 		if files, photos, err := prg.Start(prgOpt); err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		} else if len(files) > 0 || len(photos) > 0 {
 			event.InfoMsg(i18n.MsgRemovedFilesAndPhotos, len(files), len(photos))
@@ -13,7 +13,6 @@ import (
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // UpdateLabel updates label properties.
@@ -73,12 +72,12 @@ func LikeLabel(router *gin.RouterGroup) {
 		label, err := query.LabelByUID(id)
 
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusNotFound, err)
 			return
 		}
 
 		if err := label.Update("LabelFavorite", true); err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -113,12 +112,12 @@ func DislikeLabel(router *gin.RouterGroup) {
 		label, err := query.LabelByUID(id)
 
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusNotFound, err)
 			return
 		}
 
 		if err := label.Update("LabelFavorite", false); err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -14,7 +14,6 @@ import (
 	"github.com/photoprism/photoprism/internal/query"
 
 	"github.com/photoprism/photoprism/pkg/sanitize"
-	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // PUT /api/v1/:entity/:uid/links/:link
@@ -38,6 +37,9 @@ func UpdateLink(c *gin.Context) {
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
 	link.LinkExpires = f.LinkExpires
+	link.SetGeo(f.LinkGeo)
+	link.CanUpload = f.CanUpload
+	link.LinkReview = f.LinkReview
 
 	if f.LinkToken != "" {
 		link.LinkToken = strings.TrimSpace(strings.ToLower(f.LinkToken))
@@ -45,13 +47,13 @@ func UpdateLink(c *gin.Context) {
 
 	if f.Password != "" {
 		if err := link.SetPassword(f.Password); err != nil {
-			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusConflict, err)
 			return
 		}
 	}
 
 	if err := link.Save(); err != nil {
-		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
+		AbortError(c, http.StatusConflict, err)
 		return
 	}
 
@@ -76,7 +78,7 @@ func DeleteLink(c *gin.Context) {
 	link := entity.FindLink(sanitize.Token(c.Param("link")))
 
 	if err := link.Delete(); err != nil {
-		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
+		AbortError(c, http.StatusConflict, err)
 		return
 	}
 
@@ -110,16 +112,19 @@ func CreateLink(c *gin.Context) {
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
 	link.LinkExpires = f.LinkExpires
+	link.SetGeo(f.LinkGeo)
+	link.CanUpload = f.CanUpload
+	link.LinkReview = f.LinkReview
 
 	if f.Password != "" {
 		if err := link.SetPassword(f.Password); err != nil {
-			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusConflict, err)
 			return
 		}
 	}
 
 	if err := link.Save(); err != nil {
-		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
+		AbortError(c, http.StatusConflict, err)
 		return
 	}
 
@@ -161,6 +166,13 @@ func DeleteAlbumLink(router *gin.RouterGroup) {
 // GET /api/v1/albums/:uid/links
 func GetAlbumLinks(router *gin.RouterGroup) {
 	router.GET("/albums/:uid/links", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
 		m, err := query.AlbumByUID(sanitize.IdString(c.Param("uid")))
 
 		if err != nil {
@@ -201,6 +213,13 @@ func DeletePhotoLink(router *gin.RouterGroup) {
 // GET /api/v1/photos/:uid/links
 func GetPhotoLinks(router *gin.RouterGroup) {
 	router.GET("/photos/:uid/links", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
 		m, err := query.PhotoByUID(sanitize.IdString(c.Param("uid")))
 
 		if err != nil {
@@ -241,6 +260,13 @@ func DeleteLabelLink(router *gin.RouterGroup) {
 // GET /api/v1/labels/:uid/links
 func GetLabelLinks(router *gin.RouterGroup) {
 	router.GET("/labels/:uid/links", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
 		m, err := query.LabelByUID(sanitize.IdString(c.Param("uid")))
 
 		if err != nil {
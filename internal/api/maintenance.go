@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+// MaintenanceStatus returns the maintenance mode status, "paused" is true once all busy
+// workers have stopped at a safe checkpoint.
+func MaintenanceStatus() gin.H {
+	return gin.H{
+		"enabled": mutex.Maintenance.Enabled(),
+		"paused":  mutex.Maintenance.Idle(),
+		"busy":    mutex.BusyWorkers(),
+		"jobs":    mutex.Maintenance.Jobs(),
+	}
+}
+
+// EnableMaintenance puts the instance into maintenance mode, so that all background workers pause.
+//
+// POST /api/v1/maintenance
+func EnableMaintenance(router *gin.RouterGroup) {
+	router.POST("/maintenance", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceConfig, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		mutex.Maintenance.Enable()
+
+		log.Infof("maintenance: enabled, workers will pause at the next checkpoint")
+		event.InfoMsg(i18n.MsgMaintenanceEnabled)
+
+		c.JSON(http.StatusOK, MaintenanceStatus())
+	})
+}
+
+// DisableMaintenance ends maintenance mode, so that paused background workers resume.
+//
+// DELETE /api/v1/maintenance
+func DisableMaintenance(router *gin.RouterGroup) {
+	router.DELETE("/maintenance", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceConfig, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		mutex.Maintenance.Disable()
+
+		log.Infof("maintenance: disabled, workers resume")
+		event.InfoMsg(i18n.MsgMaintenanceDisabled)
+
+		c.JSON(http.StatusOK, MaintenanceStatus())
+	})
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestMaintenance(t *testing.T) {
+	app, router, _ := NewApiTest()
+	EnableMaintenance(router)
+	DisableMaintenance(router)
+	GetStatus(router)
+
+	r := PerformRequest(app, "POST", "/api/v1/maintenance")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.True(t, gjson.Get(r.Body.String(), "enabled").Bool())
+
+	r = PerformRequest(app, "GET", "/api/v1/status")
+	assert.Equal(t, "maintenance", gjson.Get(r.Body.String(), "status").String())
+	assert.True(t, gjson.Get(r.Body.String(), "maintenance.enabled").Bool())
+	assert.True(t, gjson.Get(r.Body.String(), "maintenance.paused").Bool())
+
+	r = PerformRequest(app, "DELETE", "/api/v1/maintenance")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.False(t, gjson.Get(r.Body.String(), "enabled").Bool())
+
+	r = PerformRequest(app, "GET", "/api/v1/status")
+	assert.Equal(t, "operational", gjson.Get(r.Body.String(), "status").String())
+}
@@ -50,6 +50,9 @@ func findFileMarker(c *gin.Context) (file *entity.File, marker *entity.Marker, e
 	if f, err := query.FileByUID(marker.FileUID); err != nil {
 		AbortEntityNotFound(c)
 		return nil, marker, err
+	} else if photoLocked(f.PhotoUID) {
+		AbortLocked(c)
+		return nil, marker, fmt.Errorf("photo locked")
 	} else {
 		file = &f
 	}
@@ -81,6 +84,8 @@ func UpdateMarker(router *gin.RouterGroup) {
 			return
 		}
 
+		prevName := marker.MarkerName
+
 		// Initialize form.
 		f, err := form.NewMarker(*marker)
 
@@ -115,6 +120,18 @@ func UpdateMarker(router *gin.RouterGroup) {
 			if err := entity.UpdateSubjectCounts(); err != nil {
 				log.Errorf("faces: %s (update counts)", err)
 			}
+
+			// Update precalculated photo counts per person.
+			entity.UpdateCountsAsync(UpdateClientConfig)
+
+			// Record people named by users in the activity feed.
+			if marker.SubjUID != "" && marker.MarkerName != "" && marker.MarkerName != prevName {
+				event.Publish("activity.person", event.Data{
+					"uid":  marker.SubjUID,
+					"name": marker.MarkerName,
+					"user": Session(SessionID(c)).User.UserUID,
+				})
+			}
 		}
 
 		// Update photo metadata.
@@ -169,6 +186,9 @@ func ClearMarkerSubject(router *gin.RouterGroup) {
 			log.Errorf("faces: %s (update counts)", err)
 		}
 
+		// Update precalculated photo counts per person.
+		entity.UpdateCountsAsync(UpdateClientConfig)
+
 		// Update photo metadata.
 		if !file.FilePrimary {
 			log.Infof("faces: skipped updating photo for non-primary file")
@@ -5,7 +5,6 @@ import (
 
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,7 +22,7 @@ func GetMomentsTime(router *gin.RouterGroup) {
 		result, err := query.MomentsTime(1)
 
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
@@ -37,6 +38,26 @@ func SavePhotoAsYaml(p entity.Photo) {
 	}
 }
 
+// WritebackPhotoMetadata writes manually edited metadata back to the original file, if enabled.
+func WritebackPhotoMetadata(p entity.Photo) {
+	w := photoprism.NewWriteback(service.Config())
+
+	if w.Disabled() {
+		return
+	}
+
+	if err := w.Start(p); err != nil {
+		log.Errorf("photo: %s (writeback)", err)
+	}
+}
+
+// photoLocked tests if the photo with the UID is locked.
+func photoLocked(uid string) bool {
+	m, err := query.PhotoByUID(uid)
+
+	return err == nil && m.PhotoLocked
+}
+
 // GetPhoto returns photo details as JSON.
 //
 // Route : GET /api/v1/photos/:uid
@@ -58,6 +79,19 @@ func GetPhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		// Remove metadata and round or remove coordinates as configured for the share links.
+		if SharedOnly(s, acl.ResourcePhotos) {
+			p.SetGeoPrivacy(SharedGeoPrivacy(s))
+			p.Redact(service.Config().ShareRedact())
+		}
+
+		// Show label names in the user interface language, if enabled.
+		for _, l := range p.Labels {
+			if l.Label != nil {
+				l.Label.LabelName = classify.Translate(l.Label.LabelName)
+			}
+		}
+
 		c.IndentedJSON(http.StatusOK, p)
 	})
 }
@@ -80,6 +114,9 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if m.PhotoLocked {
+			AbortLocked(c)
+			return
 		}
 
 		// TODO: Proof-of-concept for form handling - might need refactoring
@@ -117,6 +154,7 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		}
 
 		SavePhotoAsYaml(p)
+		WritebackPhotoMetadata(p)
 
 		UpdateClientConfig()
 
@@ -155,6 +193,16 @@ func GetPhotoDownload(router *gin.RouterGroup) {
 			return
 		}
 
+		if redact := photoRedact(c, f.PhotoUID); redact != nil && redact.Enabled() {
+			if fileName, err = redact.File(fileName); err != nil {
+				log.Errorf("photo: %s", err)
+				c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+				return
+			}
+
+			defer removeRedactedFile(fileName)
+		}
+
 		c.FileAttachment(fileName, f.DownloadName(DownloadName(c), 0))
 	})
 }
@@ -213,6 +261,9 @@ func ApprovePhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if m.PhotoLocked {
+			AbortLocked(c)
+			return
 		}
 
 		if err := m.Approve(); err != nil {
@@ -248,6 +299,9 @@ func LikePhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if m.PhotoLocked {
+			AbortLocked(c)
+			return
 		}
 
 		if err := m.SetFavorite(true); err != nil {
@@ -283,6 +337,9 @@ func DislikePhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if m.PhotoLocked {
+			AbortLocked(c)
+			return
 		}
 
 		if err := m.SetFavorite(false); err != nil {
@@ -299,6 +356,66 @@ func DislikePhoto(router *gin.RouterGroup) {
 	})
 }
 
+// LockPhoto protects a photo from changes, archiving, and deletion.
+//
+// POST /api/v1/photos/:uid/lock
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+func LockPhoto(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/lock", func(c *gin.Context) {
+		setPhotoLocked(c, true)
+	})
+}
+
+// UnlockPhoto removes the lock from a photo.
+//
+// DELETE /api/v1/photos/:uid/lock
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+func UnlockPhoto(router *gin.RouterGroup) {
+	router.DELETE("/photos/:uid/lock", func(c *gin.Context) {
+		setPhotoLocked(c, false)
+	})
+}
+
+// setPhotoLocked updates the lock flag of the photo in the request.
+func setPhotoLocked(c *gin.Context, locked bool) {
+	s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionLock)
+
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return
+	}
+
+	id := sanitize.IdString(c.Param("uid"))
+	m, err := query.PhotoByUID(id)
+
+	if err != nil {
+		AbortEntityNotFound(c)
+		return
+	}
+
+	if err := m.SetLocked(locked); err != nil {
+		log.Errorf("photo: %s", err.Error())
+		AbortSaveFailed(c)
+		return
+	}
+
+	SavePhotoAsYaml(m)
+
+	PublishPhotoEvent(EntityUpdated, id, c)
+
+	if locked {
+		event.SuccessMsg(i18n.MsgLocked)
+	} else {
+		event.SuccessMsg(i18n.MsgUnlocked)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"photo": m})
+}
+
 // POST /api/v1/photos/:uid/files/:file_uid/primary
 //
 // Parameters:
@@ -315,6 +432,12 @@ func PhotoPrimary(router *gin.RouterGroup) {
 
 		uid := sanitize.IdString(c.Param("uid"))
 		fileUID := sanitize.IdString(c.Param("file_uid"))
+
+		if photoLocked(uid) {
+			AbortLocked(c)
+			return
+		}
+
 		err := query.SetPhotoPrimary(uid, fileUID)
 
 		if err != nil {
@@ -333,6 +456,8 @@ func PhotoPrimary(router *gin.RouterGroup) {
 			return
 		}
 
+		SavePhotoAsYaml(p)
+
 		c.JSON(http.StatusOK, p)
 	})
 }
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/disintegration/imaging"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// GetPhotoEdit returns the editing operations of the primary photo file.
+//
+// GET /api/v1/photos/:uid/edit
+func GetPhotoEdit(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/edit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		f, err := query.FileByPhotoUID(sanitize.IdString(c.Param("uid")))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		edit, err := thumb.ParseEdit(f.FileEdits)
+
+		if err != nil {
+			log.Warnf("photo: %s in %s (parse edits)", err, sanitize.Log(f.FileName))
+		}
+
+		c.JSON(http.StatusOK, edit)
+	})
+}
+
+// UpdatePhotoEdit saves crop, rotate, and straighten operations without modifying the original file.
+//
+// PUT /api/v1/photos/:uid/edit
+func UpdatePhotoEdit(router *gin.RouterGroup) {
+	router.PUT("/photos/:uid/edit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var edit thumb.Edit
+
+		if err := c.BindJSON(&edit); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		edit.Normalize()
+
+		if err := edit.Validate(); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
+		savePhotoEdit(c, edit)
+	})
+}
+
+// DeletePhotoEdit removes all editing operations so that the original is shown again.
+//
+// DELETE /api/v1/photos/:uid/edit
+func DeletePhotoEdit(router *gin.RouterGroup) {
+	router.DELETE("/photos/:uid/edit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		savePhotoEdit(c, thumb.Edit{})
+	})
+}
+
+// savePhotoEdit stores the editing operations of the primary photo file and returns the updated file.
+func savePhotoEdit(c *gin.Context, edit thumb.Edit) {
+	uid := sanitize.IdString(c.Param("uid"))
+
+	if photoLocked(uid) {
+		AbortLocked(c)
+		return
+	}
+
+	f, err := query.FileByPhotoUID(uid)
+
+	if err != nil {
+		AbortEntityNotFound(c)
+		return
+	}
+
+	editHash := ""
+
+	if !edit.Empty() {
+		editHash = edit.Hash(f.FileHash)
+	}
+
+	if err := f.Updates(entity.Values{"FileEdits": edit.JSON(), "FileEditHash": editHash}); err != nil {
+		Error(c, http.StatusInternalServerError, err, i18n.ErrSaveFailed)
+		return
+	}
+
+	PublishPhotoEvent(EntityUpdated, uid, c)
+
+	event.SuccessMsg(i18n.MsgChangesSaved)
+
+	c.JSON(http.StatusOK, f)
+}
+
+// GetPhotoEditDownload exports the edited version of a photo as JPEG.
+//
+// GET /api/v1/photos/:uid/edit/dl
+func GetPhotoEditDownload(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/edit/dl", func(c *gin.Context) {
+		if InvalidDownloadToken(c) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		f, err := query.FileByPhotoUID(sanitize.IdString(c.Param("uid")))
+
+		if err != nil {
+			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+			return
+		}
+
+		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+		if !fs.FileExists(fileName) {
+			log.Errorf("photo: file %s is missing", sanitize.Log(f.FileName))
+			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+			return
+		}
+
+		edit, err := thumb.ParseEdit(f.FileEdits)
+
+		if err != nil {
+			log.Warnf("photo: %s in %s (parse edits)", err, sanitize.Log(f.FileName))
+		}
+
+		img, err := thumb.Open(fileName, f.FileOrientation)
+
+		if err != nil {
+			log.Errorf("photo: %s in %s (export edited)", err, sanitize.Log(f.FileName))
+			c.Data(http.StatusInternalServerError, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		downloadName := fs.StripKnownExt(f.DownloadName(DownloadName(c), 0)) + fs.EditSuffixes[0] + fs.JpegExt
+
+		AddDownloadHeader(c, downloadName)
+		AddContentTypeHeader(c, "image/jpeg")
+
+		if err := imaging.Encode(c.Writer, edit.Apply(img), imaging.JPEG, imaging.JPEGQuality(thumb.JpegQuality)); err != nil {
+			log.Errorf("photo: %s in %s (export edited)", err, sanitize.Log(f.FileName))
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUpdatePhotoEdit(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoEdit(router)
+		GetPhotoEdit(router)
+		DeletePhotoEdit(router)
+
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0yh7/edit", `{"Rotate": -90, "Straighten": 1.5, "CropX": 0.1, "CropY": 0.1, "CropW": 0.8, "CropH": 0.8}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, gjson.Get(r.Body.String(), "EditHash").String(), "_e")
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/edit")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(270), gjson.Get(r.Body.String(), "Rotate").Int())
+		assert.Equal(t, 1.5, gjson.Get(r.Body.String(), "Straighten").Float())
+
+		r = PerformRequest(app, "DELETE", "/api/v1/photos/pt9jtdre2lvl0yh7/edit")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "EditHash").String())
+	})
+	t.Run("InvalidEdit", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoEdit(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0yh7/edit", `{"Rotate": 45}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoEdit(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/xxx/edit", `{"Rotate": 90}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetPhotoEditDownload(t *testing.T) {
+	t.Run("OriginalMissing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPhotoEditDownload(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/edit/dl?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoEditDownload(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/edit/dl?t=xxx")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -12,8 +12,8 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // POST /api/v1/photos/:uid/label
@@ -34,6 +34,9 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if m.PhotoLocked {
+			AbortLocked(c)
+			return
 		}
 
 		var f form.Label
@@ -46,18 +49,19 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 		labelEntity := entity.FirstOrCreateLabel(entity.NewLabel(f.LabelName, f.LabelPriority))
 
 		if labelEntity == nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed creating label"})
+			AbortSaveFailed(c)
 			return
 		}
 
 		if err := labelEntity.Restore(); err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "could not restore label"})
+			Error(c, http.StatusInternalServerError, err, i18n.ErrSaveFailed)
+			return
 		}
 
 		photoLabel := entity.FirstOrCreatePhotoLabel(entity.NewPhotoLabel(m.ID, labelEntity.ID, f.Uncertainty, "manual"))
 
 		if photoLabel == nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed updating photo label"})
+			AbortSaveFailed(c)
 			return
 		}
 
@@ -78,7 +82,7 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 		}
 
 		if err := p.SaveLabels(); err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -109,19 +113,22 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if m.PhotoLocked {
+			AbortLocked(c)
+			return
 		}
 
 		labelId, err := strconv.Atoi(sanitize.Token(c.Param("id")))
 
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusNotFound, err)
 			return
 		}
 
 		label, err := query.PhotoLabel(m.ID, uint(labelId))
 
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusNotFound, err)
 			return
 		}
 
@@ -142,7 +149,7 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 		logError("label", p.RemoveKeyword(label.Label.LabelName))
 
 		if err := p.SaveLabels(); err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -175,19 +182,22 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if m.PhotoLocked {
+			AbortLocked(c)
+			return
 		}
 
 		labelId, err := strconv.Atoi(sanitize.Token(c.Param("id")))
 
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusNotFound, err)
 			return
 		}
 
 		label, err := query.PhotoLabel(m.ID, uint(labelId))
 
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusNotFound, err)
 			return
 		}
 
@@ -197,7 +207,7 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 		}
 
 		if err := label.Save(); err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -209,7 +219,7 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 		}
 
 		if err := p.SaveLabels(); err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// savePhotoLocale updates or removes the title and description of a photo in a language and returns the updated
+// photo. The photo title and description are changed directly if the locale is the configured default locale.
+func savePhotoLocale(c *gin.Context, remove bool) {
+	s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return
+	}
+
+	uid := sanitize.IdString(c.Param("uid"))
+	m, err := query.PhotoByUID(uid)
+
+	if err != nil {
+		AbortEntityNotFound(c)
+		return
+	} else if m.PhotoLocked {
+		AbortLocked(c)
+		return
+	}
+
+	locale := i18n.ParseLocale(c.Param("locale"))
+	isDefault := locale == i18n.ParseLocale(service.Config().DefaultLocale())
+
+	// The default locale cannot be removed.
+	if locale == "" || remove && isDefault {
+		AbortBadRequest(c)
+		return
+	}
+
+	// An empty title and description remove the locale.
+	var f form.PhotoLocale
+
+	if !remove {
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+	}
+
+	if isDefault {
+		m.SetTitle(f.Title, entity.SrcManual)
+		m.SetDescription(f.Description, entity.SrcManual)
+
+		if err := m.Save(); err != nil {
+			log.Errorf("photo: %s (update title)", err)
+			AbortSaveFailed(c)
+			return
+		}
+	} else if _, err := m.SetLocale(string(locale), f.Title, f.Description); err != nil {
+		log.Errorf("photo: %s (update locale)", err)
+		AbortSaveFailed(c)
+		return
+	}
+
+	PublishPhotoEvent(EntityUpdated, uid, c)
+
+	event.SuccessMsg(i18n.MsgChangesSaved)
+
+	p, err := query.PhotoPreloadByUID(uid)
+
+	if err != nil {
+		AbortEntityNotFound(c)
+		return
+	}
+
+	SavePhotoAsYaml(p)
+
+	c.JSON(http.StatusOK, p)
+}
+
+// UpdatePhotoLocale updates the title and description of a photo in another language.
+//
+// PUT /api/v1/photos/:uid/locales/:locale
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+//   locale: string Locale such as "de" or "pt_BR"
+func UpdatePhotoLocale(router *gin.RouterGroup) {
+	router.PUT("/photos/:uid/locales/:locale", func(c *gin.Context) {
+		savePhotoLocale(c, false)
+	})
+}
+
+// DeletePhotoLocale removes the title and description of a photo in another language.
+//
+// DELETE /api/v1/photos/:uid/locales/:locale
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+//   locale: string Locale such as "de" or "pt_BR"
+func DeletePhotoLocale(router *gin.RouterGroup) {
+	router.DELETE("/photos/:uid/locales/:locale", func(c *gin.Context) {
+		savePhotoLocale(c, true)
+	})
+}
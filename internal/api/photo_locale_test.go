@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUpdatePhotoLocale(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoLocale(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y21/locales/de", `{"Title": "Titel", "Description": "Beschreibung"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Titel", gjson.Get(r.Body.String(), `Locales.#(Locale=="de").Title`).String())
+		assert.Equal(t, "Beschreibung", gjson.Get(r.Body.String(), `Locales.#(Locale=="de").Description`).String())
+	})
+	t.Run("DefaultLocale", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoLocale(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y23/locales/en", `{"Title": "English Title"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "English Title", gjson.Get(r.Body.String(), "Title").String())
+		assert.Equal(t, "manual", gjson.Get(r.Body.String(), "TitleSrc").String())
+		assert.False(t, gjson.Get(r.Body.String(), `Locales.#(Locale=="en")`).Exists())
+	})
+	t.Run("InvalidLocale", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoLocale(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y21/locales/xxx", `{"Title": "Titel"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoLocale(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y21/locales/de", `{"Title": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoLocale(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/xxx/locales/de", `{"Title": "Titel"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestDeletePhotoLocale(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoLocale(router)
+		DeletePhotoLocale(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y24/locales/fr", `{"Title": "Titre"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), `Locales.#(Locale=="fr")`).Exists())
+		r = PerformRequest(app, "DELETE", "/api/v1/photos/pt9jtdre2lvl0y24/locales/fr")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), `Locales.#(Locale=="fr")`).Exists())
+	})
+	t.Run("DefaultLocale", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeletePhotoLocale(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/photos/pt9jtdre2lvl0y24/locales/en")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+const photoOfDay = "photo-of-the-day"
+
+// PhotoOfDayResult represents the featured photo of a day with absolute image and page URLs.
+type PhotoOfDayResult struct {
+	Date        string    `json:"Date"`
+	UID         string    `json:"UID"`
+	Title       string    `json:"Title"`
+	Description string    `json:"Description"`
+	TakenAt     time.Time `json:"TakenAt"`
+	Hash        string    `json:"Hash"`
+	Width       int       `json:"Width"`
+	Height      int       `json:"Height"`
+	ImageUrl    string    `json:"ImageUrl"`
+	ThumbUrl    string    `json:"ThumbUrl"`
+	PageUrl     string    `json:"PageUrl"`
+}
+
+// PhotoOfDayEmbed represents the featured photo as oEmbed photo response, see https://oembed.com/.
+type PhotoOfDayEmbed struct {
+	Version         string `json:"version"`
+	Type            string `json:"type"`
+	Title           string `json:"title"`
+	Url             string `json:"url"`
+	Width           int    `json:"width"`
+	Height          int    `json:"height"`
+	ProviderName    string `json:"provider_name"`
+	ProviderUrl     string `json:"provider_url"`
+	ThumbnailUrl    string `json:"thumbnail_url"`
+	ThumbnailWidth  int    `json:"thumbnail_width"`
+	ThumbnailHeight int    `json:"thumbnail_height"`
+}
+
+// thumbSize returns the resolution of a thumbnail created from an image with the given resolution.
+func thumbSize(size thumb.Size, width, height int) (w, h int) {
+	if width < 1 || height < 1 {
+		return size.Width, size.Height
+	}
+
+	for _, opt := range size.Options {
+		if opt != thumb.ResampleFit {
+			continue
+		}
+
+		// Images are never scaled up.
+		scale := math.Min(1, math.Min(float64(size.Width)/float64(width), float64(size.Height)/float64(height)))
+
+		return int(math.Round(float64(width) * scale)), int(math.Round(float64(height) * scale))
+	}
+
+	return size.Width, size.Height
+}
+
+// NewPhotoOfDayResult returns the response for a featured photo with image urls that contain the preview token.
+func NewPhotoOfDayResult(conf *config.Config, p search.Photo, day time.Time, thumbName thumb.Name, previewToken string) PhotoOfDayResult {
+	contentUrl := conf.SiteUrl() + strings.TrimPrefix(config.ApiUri, "/")
+	width, height := thumbSize(thumb.Sizes[thumbName], p.FileWidth, p.FileHeight)
+
+	return PhotoOfDayResult{
+		Date:        day.Format(form.PhotoOfDayDate),
+		UID:         p.PhotoUID,
+		Title:       p.PhotoTitle,
+		Description: p.PhotoDescription,
+		TakenAt:     p.TakenAt,
+		Hash:        p.FileHash,
+		Width:       width,
+		Height:      height,
+		ImageUrl:    fmt.Sprintf("%s/t/%s/%s/%s", contentUrl, p.FileHash, previewToken, thumbName),
+		ThumbUrl:    fmt.Sprintf("%s/t/%s/%s/%s", contentUrl, p.FileHash, previewToken, thumb.Tile500),
+		PageUrl:     fmt.Sprintf("%sbrowse?q=uid:%s", conf.SiteUrl(), p.PhotoUID),
+	}
+}
+
+// Embed returns the featured photo as oEmbed response.
+func (r PhotoOfDayResult) Embed(conf *config.Config) PhotoOfDayEmbed {
+	tile := thumb.Sizes[thumb.Tile500]
+
+	return PhotoOfDayEmbed{
+		Version:         "1.0",
+		Type:            "photo",
+		Title:           r.Title,
+		Url:             r.ImageUrl,
+		Width:           r.Width,
+		Height:          r.Height,
+		ProviderName:    conf.SiteTitle(),
+		ProviderUrl:     conf.SiteUrl(),
+		ThumbnailUrl:    r.ThumbUrl,
+		ThumbnailWidth:  tile.Width,
+		ThumbnailHeight: tile.Height,
+	}
+}
+
+// GetPhotoOfDay returns a featured photo per day, selected from all photos matching an optional filter.
+// The same photo is returned for the same day, filter, and seed.
+//
+// GET /api/v1/photo-of-the-day
+//
+// Query:
+//   q:      string Search filter, e.g. "favorite:true"
+//   date:   string Day in the format 2006-01-02, default is today
+//   seed:   string Selects a different photo per day
+//   size:   string Thumbnail size of the image url, default is fit_1920
+//   format: string Response format: json (default), oembed, or image for a redirect to the image url
+func GetPhotoOfDay(router *gin.RouterGroup) {
+	router.GET("/"+photoOfDay, func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotoOfDay, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.PhotoOfDay
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		conf := service.Config()
+
+		day, err := f.Day(time.Now())
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		thumbName := thumb.Fit1920
+
+		if f.Size != "" {
+			thumbName = thumb.Name(sanitize.Token(f.Size))
+		}
+
+		size, ok := thumb.Sizes[thumbName]
+
+		if !ok {
+			log.Errorf("%s: invalid size %s", photoOfDay, sanitize.Log(thumbName.String()))
+			AbortBadRequest(c)
+			return
+		}
+
+		// Use the same size as the thumbnail api if the requested size isn't cached.
+		if size.Uncached() && !conf.ThumbUncached() {
+			if thumbName, _ = thumb.Find(conf.ThumbSizePrecached()); thumbName == "" {
+				AbortBadRequest(c)
+				return
+			}
+		}
+
+		// Keep the selection stable while the library changes.
+		cache := service.CoverCache()
+		cacheKey := CacheKey(photoOfDay, day.Format(form.PhotoOfDayDate), f.Query+"/"+f.Seed)
+
+		var p search.Photo
+
+		if cacheData, ok := cache.Get(cacheKey); ok {
+			p = cacheData.(search.Photo)
+		} else if p, err = search.PhotoOfDay(form.SearchPhotos{Query: f.Query}, day, f.Seed); err == search.ErrNoPhotoOfDay {
+			AbortEntityNotFound(c)
+			return
+		} else if err != nil {
+			log.Warnf("%s: %s", photoOfDay, err)
+			AbortBadRequest(c)
+			return
+		} else {
+			cache.SetDefault(cacheKey, p)
+		}
+
+		// The image urls contain the preview token of the session if tokens expire.
+		previewToken, _ := AccessTokens(SessionID(c))
+		result := NewPhotoOfDayResult(conf, p, day, thumbName, previewToken)
+
+		c.Header("Cache-Control", "no-cache")
+
+		switch f.Format {
+		case "", "json":
+			c.JSON(http.StatusOK, result)
+		case "oembed":
+			c.JSON(http.StatusOK, result.Embed(conf))
+		case "image":
+			c.Redirect(http.StatusTemporaryRedirect, result.ImageUrl)
+		default:
+			AbortBadRequest(c)
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+func TestGetPhotoOfDay(t *testing.T) {
+	t.Run("Json", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoOfDay(router)
+		r := PerformRequest(app, "GET", "/api/v1/photo-of-the-day?date=2026-10-14")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		body := r.Body.String()
+
+		assert.Equal(t, "2026-10-14", gjson.Get(body, "Date").String())
+		assert.NotEmpty(t, gjson.Get(body, "UID").String())
+		assert.Contains(t, gjson.Get(body, "ImageUrl").String(), "/api/v1/t/"+gjson.Get(body, "Hash").String()+"/")
+		assert.Contains(t, gjson.Get(body, "ImageUrl").String(), "/fit_")
+		assert.Contains(t, gjson.Get(body, "PageUrl").String(), "browse?q=uid:")
+
+		again := PerformRequest(app, "GET", "/api/v1/photo-of-the-day?date=2026-10-14")
+		assert.Equal(t, gjson.Get(body, "UID").String(), gjson.Get(again.Body.String(), "UID").String())
+	})
+	t.Run("OEmbed", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoOfDay(router)
+		r := PerformRequest(app, "GET", "/api/v1/photo-of-the-day?format=oembed&size=fit_720&seed=kitchen")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		body := r.Body.String()
+
+		assert.Equal(t, "1.0", gjson.Get(body, "version").String())
+		assert.Equal(t, "photo", gjson.Get(body, "type").String())
+		assert.True(t, strings.HasSuffix(gjson.Get(body, "url").String(), "/fit_720"))
+		assert.LessOrEqual(t, gjson.Get(body, "width").Int(), int64(720))
+		assert.Equal(t, int64(500), gjson.Get(body, "thumbnail_width").Int())
+	})
+	t.Run("Image", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoOfDay(router)
+		r := PerformRequest(app, "GET", "/api/v1/photo-of-the-day?format=image")
+		assert.Equal(t, http.StatusTemporaryRedirect, r.Code)
+		assert.Contains(t, r.Header().Get("Location"), "/api/v1/t/")
+	})
+	t.Run("SessionToken", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		conf.Options().TokenLifetime = 3600
+		defer func() {
+			conf.SetPublic(true)
+			conf.Options().TokenLifetime = 0
+		}()
+		GetPhotoOfDay(router)
+		sessId := AuthenticateAdmin(app, router)
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/photo-of-the-day", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		// Static tokens are no longer valid, so the image urls must contain the session token.
+		previewToken, _ := AccessTokens(sessId)
+		assert.NotEmpty(t, previewToken)
+		assert.NotContains(t, gjson.Get(r.Body.String(), "ImageUrl").String(), "/"+conf.PreviewToken()+"/")
+		assert.Contains(t, gjson.Get(r.Body.String(), "ImageUrl").String(), "/"+previewToken+"/")
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoOfDay(router)
+		r := PerformRequest(app, "GET", "/api/v1/photo-of-the-day?q=title:xxxnotfoundxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidDate", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoOfDay(router)
+		r := PerformRequest(app, "GET", "/api/v1/photo-of-the-day?date=14.10.2026")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoOfDay(router)
+		r := PerformRequest(app, "GET", "/api/v1/photo-of-the-day?size=xxx")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidFormat", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoOfDay(router)
+		r := PerformRequest(app, "GET", "/api/v1/photo-of-the-day?format=xml")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Unauthorized", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetPhotoOfDay(router)
+		r := PerformRequest(app, "GET", "/api/v1/photo-of-the-day")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("Guest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetPhotoOfDay(router)
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba8"}})
+		r := AuthenticatedRequest(app, "GET", "/api/v1/photo-of-the-day", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestThumbSize(t *testing.T) {
+	fit := thumb.Sizes[thumb.Fit1920]
+	tile := thumb.Sizes[thumb.Tile500]
+
+	w, h := thumbSize(fit, 3840, 2160)
+	assert.Equal(t, 1920, w)
+	assert.Equal(t, 1080, h)
+
+	w, h = thumbSize(fit, 1000, 3000)
+	assert.Equal(t, 400, w)
+	assert.Equal(t, 1200, h)
+
+	w, h = thumbSize(fit, 800, 600)
+	assert.Equal(t, 800, w)
+	assert.Equal(t, 600, h)
+
+	w, h = thumbSize(tile, 3840, 2160)
+	assert.Equal(t, 500, w)
+	assert.Equal(t, 500, h)
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SimilarPhotosCount is the default number of similar photos returned.
+const SimilarPhotosCount = 24
+
+// GetSimilarPhotos returns photos that look similar to the photo with the UID as JSON.
+//
+// GET /api/v1/photos/:uid/similar
+//
+// Parameters:
+//   uid: string Photo UID as returned by the API
+//
+// Query:
+//   count: int Max result count
+func GetSimilarPhotos(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/similar", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSimilar)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := sanitize.IdString(c.Param("uid"))
+
+		if _, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		count := txt.Int(c.Query("count"))
+
+		if count <= 0 {
+			count = SimilarPhotosCount
+		}
+
+		// Exclude private photos if the feature is enabled.
+		public := service.Config().Settings().Features.Private
+
+		results, err := search.Similar(uid, count, public)
+
+		if err != nil {
+			log.Debugf("photo: %s (find similar)", err)
+			c.JSON(http.StatusOK, search.PhotoResults{})
+			return
+		}
+
+		AddCountHeader(c, len(results))
+		AddLimitHeader(c, count)
+
+		c.JSON(http.StatusOK, results)
+	})
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestGetSimilarPhotos(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		photo := entity.PhotoFixtures.Get("19800101_000002_D640C559")
+		near := entity.PhotoFixtures.Get("Photo04")
+		e := classify.NewEmbedding([]float32{0.7, 0.2, 0.1, 0})
+
+		for _, p := range []entity.Photo{photo, near} {
+			if err := entity.SavePhotoEmbedding(p.ID, p.PhotoUID, e); err != nil {
+				t.Fatal(err)
+			}
+
+			defer entity.DeletePhotoEmbedding(p.ID)
+		}
+
+		app, router, _ := NewApiTest()
+		GetSimilarPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/"+photo.PhotoUID+"/similar?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, near.PhotoUID, gjson.Get(r.Body.String(), "0.UID").String())
+	})
+	t.Run("NoEmbedding", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSimilarPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh8/similar")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSimilarPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/similar")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
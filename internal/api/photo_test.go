@@ -4,7 +4,10 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 )
@@ -19,6 +22,18 @@ func TestGetPhoto(t *testing.T) {
 		assert.Equal(t, "200", val.String())
 	})
 
+	t.Run("GuestRoundedLocation", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetPhoto(router)
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Tokens: []string{"4jxf3jfn2k"}, Shares: session.UIDs{"at9lxuqxpogaaba7"}})
+		r := AuthenticatedRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh8", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.InDelta(t, 48.5, gjson.Get(r.Body.String(), "Lat").Float(), 0.0001)
+		assert.Equal(t, entity.UnknownLocation.ID, gjson.Get(r.Body.String(), "CellID").String())
+	})
+
 	t.Run("search for not existing photo", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		GetPhoto(router)
@@ -39,6 +54,15 @@ func TestUpdatePhoto(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 
+	t.Run("rating", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhoto(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y13", `{"Rating": 9}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(5), gjson.Get(r.Body.String(), "Rating").Int())
+		assert.Equal(t, "manual", gjson.Get(r.Body.String(), "RatingSrc").String())
+	})
+
 	t.Run("invalid request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		UpdatePhoto(router)
@@ -77,6 +101,33 @@ func TestGetPhotoDownload(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/dl?t=xxx")
 		assert.Equal(t, http.StatusForbidden, r.Code)
 	})
+
+	t.Run("share token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.Options().ShareRedact = "gps"
+		defer func() { conf.Options().ShareRedact = "" }()
+		GetPhotoDownload(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/dl?t="+conf.ShareDownloadToken())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+
+	t.Run("share token removes gps", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer redactDownloadTest(t, conf)()
+		GetPhotoDownload(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/dl?t="+conf.ShareDownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "-gps:all=")
+	})
+
+	t.Run("download token keeps gps", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer redactDownloadTest(t, conf)()
+		GetPhotoDownload(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/dl?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotContains(t, r.Body.String(), "-gps:all=")
+	})
 }
 
 func TestLikePhoto(t *testing.T) {
@@ -182,3 +233,69 @@ func TestApprovePhoto(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 }
+
+func TestLockPhoto(t *testing.T) {
+	app, router, _ := NewApiTest()
+	LockPhoto(router)
+	UnlockPhoto(router)
+	UpdatePhoto(router)
+	BatchPhotosArchive(router)
+	BatchPhotosRestore(router)
+	LikePhoto(router)
+	ApprovePhoto(router)
+	PhotoPrimary(router)
+	BatchPhotosApprove(router)
+	BatchTagsAdd(router)
+	BatchTagsRemove(router)
+
+	t.Run("Lock", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y11/lock")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "photo.Locked").Bool())
+	})
+	t.Run("UpdateLocked", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y11", `{"Title": "Locked"}`)
+		assert.Equal(t, http.StatusLocked, r.Code)
+	})
+	t.Run("ArchiveLocked", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/archive", `{"photos": ["pt9jtdre2lvl0y11"]}`)
+		assert.Equal(t, http.StatusLocked, r.Code)
+	})
+	t.Run("RestoreLocked", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/restore", `{"photos": ["pt9jtdre2lvl0y11"]}`)
+		assert.Equal(t, http.StatusLocked, r.Code)
+	})
+	t.Run("LikeLocked", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y11/like")
+		assert.Equal(t, http.StatusLocked, r.Code)
+	})
+	t.Run("ApproveLocked", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y11/approve")
+		assert.Equal(t, http.StatusLocked, r.Code)
+	})
+	t.Run("PrimaryLocked", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y11/files/ft9es39w45bnlqdw/primary")
+		assert.Equal(t, http.StatusLocked, r.Code)
+	})
+	t.Run("BatchApproveLocked", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/approve", `{"photos": ["pt9jtdre2lvl0y11"]}`)
+		assert.Equal(t, http.StatusLocked, r.Code)
+	})
+	t.Run("TagsAddLocked", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/tags/add", `{"photos": ["pt9jtdre2lvl0y11"], "tags": ["Locked"]}`)
+		assert.Equal(t, http.StatusLocked, r.Code)
+	})
+	t.Run("TagsRemoveLocked", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/tags/remove", `{"photos": ["pt9jtdre2lvl0y11"], "tags": ["Locked"]}`)
+		assert.Equal(t, http.StatusLocked, r.Code)
+	})
+	t.Run("Unlock", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", "/api/v1/photos/pt9jtdre2lvl0y11/lock")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), "photo.Locked").Bool())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/xxx/lock")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
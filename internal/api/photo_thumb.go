@@ -1,6 +1,7 @@
 package api
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"path/filepath"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
@@ -74,6 +76,10 @@ func GetThumb(router *gin.RouterGroup) {
 			return
 		}
 
+		// Is edited thumbnail?
+		thumbHash := fileHash
+		fileHash, editKey := thumb.ParseEditHash(fileHash)
+
 		thumbName := thumb.Name(sanitize.Token(c.Param("size")))
 
 		size, ok := thumb.Sizes[thumbName]
@@ -95,7 +101,7 @@ func GetThumb(router *gin.RouterGroup) {
 		}
 
 		cache := service.ThumbCache()
-		cacheKey := CacheKey("thumbs", fileHash, string(thumbName))
+		cacheKey := CacheKey("thumbs", thumbHash, string(thumbName))
 
 		if cacheData, ok := cache.Get(cacheKey); ok {
 			log.Tracef("api: cache hit for %s [%s]", cacheKey, time.Since(start))
@@ -121,7 +127,7 @@ func GetThumb(router *gin.RouterGroup) {
 
 		// Return existing thumbs straight away.
 		if !download {
-			if fileName, err := thumb.FileName(fileHash, conf.ThumbPath(), size.Width, size.Height, size.Options...); err == nil && fs.FileExists(fileName) {
+			if fileName, err := thumb.FileName(thumbHash, conf.ThumbPath(), size.Width, size.Height, size.Options...); err == nil && fs.FileExists(fileName) {
 				c.File(fileName)
 				return
 			}
@@ -151,6 +157,12 @@ func GetThumb(router *gin.RouterGroup) {
 			return
 		}
 
+		// Edited versions may only be requested with the current edit hash.
+		if editKey != "" && subtle.ConstantTimeCompare([]byte(thumbHash), []byte(f.FileEditHash)) != 1 {
+			c.Data(http.StatusOK, "image/svg+xml", photoIconSvg)
+			return
+		}
+
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
 		if !fs.FileExists(fileName) {
@@ -165,6 +177,8 @@ func GetThumb(router *gin.RouterGroup) {
 
 				if _, err := f.RelatedPhoto().Delete(false); err != nil {
 					log.Errorf("%s: %s while deleting %s", logPrefix, err, sanitize.Log(f.FileName))
+				} else {
+					entity.UpdateCountsAsync(UpdateClientConfig)
 				}
 			}
 
@@ -172,9 +186,20 @@ func GetThumb(router *gin.RouterGroup) {
 		}
 
 		// Use original file if thumb size exceeds limit, see https://github.com/photoprism/photoprism/issues/157
-		if size.ExceedsLimit() && c.Query("download") == "" {
+		if size.ExceedsLimit() && c.Query("download") == "" && editKey == "" {
 			log.Debugf("%s: using original, size exceeds limit (width %d, height %d)", logPrefix, size.Width, size.Height)
 
+			// Originals may only be viewed by guests without the metadata that must not be shared.
+			if GuestPreview(c) {
+				if fileName, err = photoprism.NewRedact(conf).Add(entity.RedactGps).File(fileName); err != nil {
+					log.Errorf("%s: %s", logPrefix, err)
+					c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+					return
+				}
+
+				defer removeRedactedFile(fileName)
+			}
+
 			AddThumbCacheHeader(c)
 			c.File(fileName)
 
@@ -183,7 +208,15 @@ func GetThumb(router *gin.RouterGroup) {
 
 		var thumbnail string
 
-		if conf.ThumbUncached() || size.Uncached() {
+		if editKey != "" {
+			edit, editErr := thumb.ParseEdit(f.FileEdits)
+
+			if editErr != nil {
+				log.Warnf("%s: %s in %s (parse edits)", logPrefix, editErr, sanitize.Log(f.FileName))
+			}
+
+			thumbnail, err = thumb.EditFromFile(fileName, f.FileHash, conf.ThumbPath(), edit, size.Width, size.Height, f.FileOrientation, size.Options...)
+		} else if conf.ThumbUncached() || size.Uncached() {
 			thumbnail, err = thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, f.FileOrientation, size.Options...)
 		} else {
 			thumbnail, err = thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), size.Width, size.Height, size.Options...)
@@ -28,6 +28,13 @@ func TestGetThumb(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"+conf.PreviewToken()+"/fit_7680")
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("invalid edit key", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetThumb(router)
+		r := PerformRequest(app, "GET", "/api/v1/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818_e0000abcd/"+conf.PreviewToken()+"/tile_500")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, photoIconSvg, r.Body.Bytes())
+	})
 	t.Run("invalid token", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		GetThumb(router)
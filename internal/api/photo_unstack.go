@@ -70,6 +70,9 @@ func PhotoUnstack(router *gin.RouterGroup) {
 			log.Errorf("photo: cannot find photo for file uid %s (unstack)", fileUID)
 			AbortEntityNotFound(c)
 			return
+		} else if file.Photo.PhotoLocked {
+			AbortLocked(c)
+			return
 		}
 
 		stackPhoto := *file.Photo
@@ -144,7 +147,7 @@ func PhotoUnstack(router *gin.RouterGroup) {
 				relRoot = file.FileRoot
 			}
 
-			if err := entity.UnscopedDb().Exec(`UPDATE files 
+			if err := entity.Exec(`UPDATE files 
 				SET photo_id = ?, photo_uid = ?, file_name = ?, file_missing = 0
 				WHERE file_name = ? AND file_root = ?`,
 				newPhoto.ID, newPhoto.PhotoUID, r.RootRelName(),
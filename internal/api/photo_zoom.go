@@ -0,0 +1,149 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/thumb"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// zoomPath returns the tile pyramid cache folder for a file hash, and creates the pyramid in the
+// background if needed, in which case thumb.ErrZoomPending is returned.
+func zoomPath(fileHash string) (string, error) {
+	conf := service.Config()
+
+	if zoomDir, ok := thumb.ZoomCached(fileHash, conf.ThumbPath()); ok {
+		return zoomDir, nil
+	}
+
+	f, err := query.FileByHash(fileHash)
+
+	if err != nil {
+		return "", err
+	}
+
+	// Find fallback if file is not a JPEG image.
+	if f.NoJPEG() {
+		if f, err = query.FileByPhotoUID(f.PhotoUID); err != nil {
+			return "", err
+		}
+	}
+
+	// Tile pyramids are created on demand, like uncached thumbnails, and only for images that
+	// are larger than the largest thumbnail size.
+	if !conf.ThumbUncached() {
+		return "", fmt.Errorf("on-demand rendering is disabled")
+	} else if f.FileError != "" {
+		return "", fmt.Errorf("%s has errors", sanitize.Log(f.FileName))
+	} else if f.FileWidth <= thumb.MaxSize() && f.FileHeight <= thumb.MaxSize() {
+		return "", fmt.Errorf("%s is not larger than thumbnails", sanitize.Log(f.FileName))
+	} else if limit := conf.OriginalsLimit(); limit > 0 && f.FileSize > limit {
+		return "", fmt.Errorf("%s exceeds the file size limit", sanitize.Log(f.FileName))
+	}
+
+	fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+	if !fs.FileExists(fileName) {
+		return "", fmt.Errorf("file %s is missing", sanitize.Log(f.FileName))
+	}
+
+	if err = thumb.ZoomStart(fileName, f.FileHash, conf.ThumbPath(), f.FileOrientation); err != nil {
+		return "", err
+	}
+
+	return thumb.ZoomPath(f.FileHash, conf.ThumbPath())
+}
+
+// GetZoomImage returns the Deep Zoom descriptor of an image matching the file hash,
+// so that very large images can be displayed without loading the original.
+//
+// GET /api/v1/zoom/:hash/:token/image.dzi
+//
+// Parameters:
+//   hash: string sha1 file hash
+//   token: string url security token, see config
+func GetZoomImage(router *gin.RouterGroup) {
+	router.GET("/zoom/:hash/:token/"+thumb.ZoomDescriptor, func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			AbortUnauthorized(c)
+			return
+		}
+
+		zoomDir, err := zoomPath(sanitize.Token(c.Param("hash")))
+
+		if err == thumb.ErrZoomPending {
+			log.Debugf("zoom: %s", err)
+			c.Header("Retry-After", "5")
+			AbortBusy(c)
+			return
+		} else if err != nil {
+			log.Errorf("zoom: %s", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		AddThumbCacheHeader(c)
+
+		c.Header("Content-Type", "application/xml")
+		c.File(filepath.Join(zoomDir, thumb.ZoomDescriptor))
+	})
+}
+
+// GetZoomTile returns a Deep Zoom image tile matching the file hash, pyramid level, column, and row.
+//
+// GET /api/v1/zoom/:hash/:token/image_files/:level/:tile
+//
+// Parameters:
+//   hash: string sha1 file hash
+//   token: string url security token, see config
+//   level: int pyramid level, 0 is a single pixel
+//   tile: string column and row with file extension like 3_5.jpg
+func GetZoomTile(router *gin.RouterGroup) {
+	router.GET("/zoom/:hash/:token/image_files/:level/:tile", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		var col, row int
+
+		level, err := strconv.Atoi(c.Param("level"))
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		} else if _, err = fmt.Sscanf(c.Param("tile"), "%d_%d."+string(thumb.ZoomFormat), &col, &row); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Tiles are only requested after the descriptor, so the pyramid must already exist.
+		zoomDir, ok := thumb.ZoomCached(sanitize.Token(c.Param("hash")), service.Config().ThumbPath())
+
+		if !ok {
+			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		fileName := thumb.ZoomTileName(zoomDir, level, col, row)
+
+		if !fs.FileExists(fileName) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		AddThumbCacheHeader(c)
+
+		c.File(fileName)
+	})
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetZoomImage(t *testing.T) {
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetZoomImage(router)
+		r := PerformRequest(app, "GET", "/api/v1/zoom/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/xxx/image.dzi")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetZoomImage(router)
+		r := PerformRequest(app, "GET", "/api/v1/zoom/1/"+conf.PreviewToken()+"/image.dzi")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("OriginalMissing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetZoomImage(router)
+		r := PerformRequest(app, "GET", "/api/v1/zoom/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"+conf.PreviewToken()+"/image.dzi")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetZoomTile(t *testing.T) {
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetZoomTile(router)
+		r := PerformRequest(app, "GET", "/api/v1/zoom/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/xxx/image_files/0/0_0.jpg")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("InvalidLevel", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetZoomTile(router)
+		r := PerformRequest(app, "GET", "/api/v1/zoom/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"+conf.PreviewToken()+"/image_files/x/0_0.jpg")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidTile", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetZoomTile(router)
+		r := PerformRequest(app, "GET", "/api/v1/zoom/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"+conf.PreviewToken()+"/image_files/0/foo.jpg")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetZoomTile(router)
+		r := PerformRequest(app, "GET", "/api/v1/zoom/1/"+conf.PreviewToken()+"/image_files/0/0_0.jpg")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/svg+xml", r.Header().Get("Content-Type"))
+	})
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// PrintCopiesLimit is the maximum number of copies per photo in a single print order.
+const PrintCopiesLimit = 100
+
+// GetPrintProducts returns the available print products, or an empty list if no print service is configured.
+//
+// GET /api/v1/print/products
+func GetPrintProducts(router *gin.RouterGroup) {
+	router.GET("/print/products", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePrint, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		results := make([]photoprism.PrintProduct, 0, len(photoprism.PrintProducts))
+
+		if service.Config().PrintEnabled() {
+			for _, name := range photoprism.PrintProductNames() {
+				results = append(results, photoprism.PrintProducts[name])
+			}
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// SendPrintOrder exports the selected photos in the size of the print product, and sends them to the print service.
+//
+// POST /api/v1/print
+func SendPrintOrder(router *gin.RouterGroup) {
+	router.POST("/print", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePrint, acl.ActionCreate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if !conf.PrintEnabled() || !conf.Settings().Features.Download {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.PrintOrder
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		product, ok := photoprism.FindPrintProduct(f.Product)
+
+		if !ok {
+			AbortBadRequest(c)
+			return
+		}
+
+		if f.Copies < 1 {
+			f.Copies = 1
+		} else if f.Copies > PrintCopiesLimit {
+			f.Copies = PrintCopiesLimit
+		}
+
+		photos, err := query.PhotoSelection(form.Selection{Photos: f.Photos})
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Deleted photos cannot be printed, even if their UIDs were sent.
+		if photos = printablePhotos(photos); len(photos) == 0 {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		dir := filepath.Join(conf.TempPath(), "print", rnd.Token(8))
+
+		defer func() {
+			if err := os.RemoveAll(dir); err != nil {
+				log.Warnf("print: %s (remove temp folder)", err)
+			}
+		}()
+
+		files, err := photoprism.PrintExports(photos, product, dir)
+
+		if err != nil {
+			log.Errorf("print: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		order := photoprism.PrintOrder{Product: product.Name, Copies: f.Copies, Photos: files}
+
+		resp, err := photoprism.NewPrintClient(conf.PrintUrl(), conf.PrintToken()).Send(order)
+
+		if err != nil {
+			log.Errorf("print: %s", sanitize.Log(err.Error()))
+			Abort(c, http.StatusBadGateway, i18n.ErrUnexpected)
+			return
+		}
+
+		log.Infof("print: sent %d photos as %s", len(files), sanitize.Log(product.Name))
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "product": product.Name, "copies": f.Copies, "photos": photos.UIDs(), "response": resp})
+	})
+}
+
+// printablePhotos returns the photos in the selection that have not been deleted.
+func printablePhotos(photos entity.Photos) (results entity.Photos) {
+	results = make(entity.Photos, 0, len(photos))
+
+	for _, p := range photos {
+		if p.DeletedAt == nil {
+			results = append(results, p)
+		}
+	}
+
+	return results
+}
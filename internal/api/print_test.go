@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPrintProducts(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetPrintProducts(router)
+	r := PerformRequest(app, "GET", "/api/v1/print/products")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "[]", r.Body.String())
+}
+
+func TestSendPrintOrder(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SendPrintOrder(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/print", `{"photos": ["pt9jtdre2lvl0y11"], "product": "4x6"}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Guest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		SendPrintOrder(router)
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba8"}})
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/print", `{"photos": ["pt9jtdre2lvl0y11"], "product": "4x6"}`, sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestPrintablePhotos(t *testing.T) {
+	deleted := time.Now()
+	photos := entity.Photos{{PhotoUID: "pt9jtdre2lvl0y11"}, {PhotoUID: "pt9jtdre2lvl0y12", DeletedAt: &deleted}}
+
+	assert.Equal(t, []string{"pt9jtdre2lvl0y11"}, printablePhotos(photos).UIDs())
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// GetPushKey returns the public key that web apps need for subscribing to push notifications.
+//
+// GET /api/v1/push/key
+func GetPushKey(router *gin.RouterGroup) {
+	router.GET("/push/key", func(c *gin.Context) {
+		s := AuthUser(SessionID(c), acl.ResourcePush, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if !conf.PushEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"key": conf.PushPublicKey()})
+	})
+}
+
+// SubscribePush registers a browser of the current user for push notifications.
+//
+// POST /api/v1/push/subscriptions
+func SubscribePush(router *gin.RouterGroup) {
+	router.POST("/push/subscriptions", func(c *gin.Context) {
+		s := AuthUser(SessionID(c), acl.ResourcePush, acl.ActionCreate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if !service.Config().PushEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.PushSubscription
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.NewPushSubscription(s.User.UserUID, f.Endpoint, f.Keys.P256dh, f.Keys.Auth, c.Request.UserAgent())
+
+		if err := m.Validate(); err != nil {
+			log.Errorf("push: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := m.Save(); err != nil {
+			log.Errorf("push: %s (subscribe)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("push: added subscription for %s", s.User.UserName)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// UnsubscribePush removes a push subscription of the current user.
+//
+// DELETE /api/v1/push/subscriptions
+func UnsubscribePush(router *gin.RouterGroup) {
+	router.DELETE("/push/subscriptions", func(c *gin.Context) {
+		s := AuthUser(SessionID(c), acl.ResourcePush, acl.ActionDelete)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.PushSubscription
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.FindPushSubscription(f.Endpoint)
+
+		if m == nil || !s.Owner(m.UserUID) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("push: %s (unsubscribe)", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
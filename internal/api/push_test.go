@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPushKey(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetPushKey(router)
+
+	r := PerformRequest(app, "GET", "/api/v1/push/key")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Len(t, gjson.Get(r.Body.String(), "key").String(), 87)
+}
+
+func TestSubscribePush(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SubscribePush(router)
+		UnsubscribePush(router)
+
+		body := `{"endpoint": "https://push.example.com/send/api-test", "keys": {"p256dh": "BNcRdreALRFXTkOOUHK1EtK2wtaz5Ry4YfYCA_0QTpQtUbVlUls0VJXg7A8u-Ts1XbjhazAkj7I99e8QcYP7DkM", "auth": "tBHItJI5svbpez7KI4CCXg"}}`
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/push/subscriptions", body)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "https://push.example.com/send/api-test", gjson.Get(r.Body.String(), "Endpoint").String())
+		assert.False(t, gjson.Get(r.Body.String(), "Auth").Exists())
+
+		r = PerformRequestWithBody(app, "DELETE", "/api/v1/push/subscriptions", body)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequestWithBody(app, "DELETE", "/api/v1/push/subscriptions", body)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidEndpoint", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SubscribePush(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/push/subscriptions", `{"endpoint": "http://push.example.com/send/1", "keys": {"p256dh": "abc", "auth": "abc"}}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
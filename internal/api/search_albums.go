@@ -9,6 +9,7 @@ import (
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/tracing"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -33,18 +34,34 @@ func SearchAlbums(router *gin.RouterGroup) {
 			return
 		}
 
+		shared := SharedOnly(s, acl.ResourceAlbums)
+
 		// Guest permissions are limited to shared albums.
-		if s.Guest() {
+		if shared {
+			if s.NoShares() {
+				c.JSON(http.StatusOK, search.AlbumResults{})
+				return
+			}
+
 			f.UID = s.Shares.Join(txt.Or)
 		}
 
-		result, err := search.Albums(f)
+		ctx, span := tracing.Start(c.Request.Context(), "search.albums")
+		result, err := search.AlbumsContext(ctx, f)
+		tracing.End(span, err)
 
 		if err != nil {
-			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, 400, err)
 			return
 		}
 
+		// Remove location details as configured for the share links.
+		if shared {
+			for i := range result {
+				result[i].SetGeoPrivacy(s.GeoPrivacy(result[i].AlbumUID))
+			}
+		}
+
 		AddCountHeader(c, len(result))
 		AddLimitHeader(c, f.Count)
 		AddOffsetHeader(c, f.Offset)
@@ -7,6 +7,10 @@ import (
 	"github.com/tidwall/gjson"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 )
 
 func TestSearchAlbums(t *testing.T) {
@@ -25,3 +29,42 @@ func TestSearchAlbums(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 }
+
+func TestSearchAlbums_Guest(t *testing.T) {
+	app, router, conf := NewApiTest()
+	conf.SetPublic(false)
+	defer conf.SetPublic(true)
+	SearchAlbums(router)
+	SearchPhotos(router)
+	GetAlbum(router)
+
+	sessId := service.Session().Create(session.Data{User: entity.User{
+		ID:        99,
+		UserUID:   "uqxqg7i1kperxvu9",
+		UserName:  "grandma",
+		RoleGuest: true,
+	}})
+
+	t.Run("Albums", func(t *testing.T) {
+		r := AuthenticatedRequest(app, "GET", "/api/v1/albums?count=100", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		for _, uid := range gjson.Get(r.Body.String(), "#.UID").Array() {
+			assert.Equal(t, "at9lxuqxpogaaba8", uid.String())
+		}
+	})
+	t.Run("Album", func(t *testing.T) {
+		r := AuthenticatedRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba9", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("Photos", func(t *testing.T) {
+		r := AuthenticatedRequest(app, "GET", "/api/v1/photos?count=10&album=at9lxuqxpogaaba8", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/photos?count=10", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/search"
-	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/photoprism/photoprism/internal/tracing"
 )
 
 // SearchFaces finds and returns faces as JSON.
@@ -33,10 +33,12 @@ func SearchFaces(router *gin.RouterGroup) {
 			return
 		}
 
-		result, err := search.Faces(f)
+		ctx, span := tracing.Start(c.Request.Context(), "search.faces")
+		result, err := search.FacesContext(ctx, f)
+		tracing.End(span, err)
 
 		if err != nil {
-			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, 400, err)
 			return
 		}
 
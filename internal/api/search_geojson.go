@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/photoprism/photoprism/pkg/sanitize"
@@ -12,7 +13,7 @@ import (
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/internal/service"
-	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/photoprism/photoprism/internal/tracing"
 )
 
 // SearchGeo finds photos and returns results as JSON, so they can be displayed on a map or in a viewer.
@@ -36,41 +37,60 @@ func SearchGeo(router *gin.RouterGroup) {
 			return
 		}
 
+		shared := SharedOnly(s, acl.ResourcePhotos)
+
 		// Guests may only see public content.
-		if s.Guest() {
+		if shared {
+			// Parse the query string first, so that the album it may select is checked as well.
+			if err := f.ParseQueryString(); err != nil {
+				AbortBadRequest(c)
+				return
+			}
+
+			f.Filter = ""
+
 			if f.Album == "" || !s.HasShare(f.Album) {
 				AbortUnauthorized(c)
 				return
 			}
 
-			f.Public = true
-			f.Private = false
-			f.Archived = false
-			f.Review = false
+			// Limits the results to public pictures without location filters, see search.Geo.
+			f.SetShared(s.Shares)
 		}
 
 		// Find matching pictures.
-		photos, err := search.Geo(f)
+		ctx, span := tracing.Start(c.Request.Context(), "search.geo")
+		photos, err := search.GeoContext(ctx, f)
+		tracing.End(span, err)
 
-		if err != nil {
+		if errors.Is(err, search.ErrNotShared) {
+			AbortUnauthorized(c)
+			return
+		} else if err != nil {
 			log.Warnf("search: %s", err)
 			AbortBadRequest(c)
 			return
 		}
 
+		// Round or remove coordinates as configured for the share link.
+		if shared {
+			photos = photos.SetGeoPrivacy(s.GeoPrivacy(f.Album)).Redact(service.Config().ShareRedact())
+		}
+
 		var resp []byte
 
 		// Render JSON response.
 		switch sanitize.Token(c.Param("format")) {
 		case "view":
 			conf := service.Config()
-			resp, err = photos.ViewerJSON(conf.ContentUri(), conf.ApiUri(), conf.PreviewToken(), conf.DownloadToken())
+			previewToken, downloadToken := AccessTokens(SessionID(c))
+			resp, err = photos.ViewerJSON(conf.ContentUri(), conf.ApiUri(), previewToken, downloadToken)
 		default:
 			resp, err = photos.GeoJSON()
 		}
 
 		if err != nil {
-			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, 400, err)
 			return
 		}
 
@@ -5,6 +5,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 )
 
 func TestSearchGeo(t *testing.T) {
@@ -26,4 +31,29 @@ func TestSearchGeo(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 		t.Logf("response: %s", r.Body.String())
 	})
+	t.Run("GuestQueryOverride", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+
+		SearchGeo(router)
+
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba9"}})
+
+		all := AuthenticatedRequest(app, "GET", "/api/v1/geo?album=at9lxuqxpogaaba9", sessId)
+		assert.Equal(t, http.StatusOK, all.Code)
+
+		// Albums that are not shared with the guest cannot be selected with the query string or filter.
+		for _, query := range []string{"q=album:at9lxuqxpogaaba8", "filter=album:at9lxuqxpogaaba8"} {
+			r := AuthenticatedRequest(app, "GET", "/api/v1/geo?album=at9lxuqxpogaaba9&"+query, sessId)
+			assert.Equal(t, http.StatusUnauthorized, r.Code, query)
+		}
+
+		// Private and archived pictures remain excluded, and so do location filters.
+		for _, query := range []string{"q=private:true+public:false+archived:true", "filter=private:true+public:false", "near=pt9jtdre2lvl0y11", "q=near:pt9jtdre2lvl0y11"} {
+			r := AuthenticatedRequest(app, "GET", "/api/v1/geo?album=at9lxuqxpogaaba9&"+query, sessId)
+			assert.Equal(t, http.StatusOK, r.Code, query)
+			assert.Equal(t, gjson.Get(all.Body.String(), "features.#.properties.UID").String(), gjson.Get(r.Body.String(), "features.#.properties.UID").String(), query)
+		}
+	})
 }
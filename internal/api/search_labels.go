@@ -8,7 +8,7 @@ import (
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/search"
-	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/photoprism/photoprism/internal/tracing"
 )
 
 // SearchLabels finds and returns labels as JSON.
@@ -32,10 +32,12 @@ func SearchLabels(router *gin.RouterGroup) {
 			return
 		}
 
-		result, err := search.Labels(f)
+		ctx, span := tracing.Start(c.Request.Context(), "search.labels")
+		result, err := search.LabelsContext(ctx, f)
+		tracing.End(span, err)
 
 		if err != nil {
-			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, 400, err)
 			return
 		}
 
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -8,6 +9,8 @@ import (
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/tracing"
 )
 
 // SearchPhotos searches the pictures index and returns the result as JSON.
@@ -44,29 +47,46 @@ func SearchPhotos(router *gin.RouterGroup) {
 			return
 		}
 
+		shared := SharedOnly(s, acl.ResourcePhotos)
+
 		// Guests may only see public content in shared albums.
-		if s.Guest() {
+		if shared {
+			// Parse the query string first, so that the album it may select is checked as well.
+			if err := f.ParseQueryString(); err != nil {
+				AbortBadRequest(c)
+				return
+			}
+
+			f.Filter = ""
+
 			if f.Album == "" || !s.HasShare(f.Album) {
 				AbortUnauthorized(c)
 				return
 			}
 
-			f.UID = ""
-			f.Public = true
-			f.Private = false
-			f.Hidden = false
-			f.Archived = false
-			f.Review = false
+			// Limits the results to public pictures without location filters, see search.Photos.
+			f.SetShared(s.Shares)
 		}
 
-		result, count, err := search.Photos(f)
+		ctx, span := tracing.Start(c.Request.Context(), "search.photos")
+		result, count, err := search.PhotosContext(ctx, f)
+		tracing.End(span, err)
 
-		if err != nil {
+		if errors.Is(err, search.ErrNotShared) {
+			AbortUnauthorized(c)
+			return
+		} else if err != nil {
 			log.Warnf("search: %s", err)
 			AbortBadRequest(c)
 			return
 		}
 
+		// Round or remove coordinates as configured for the share link.
+		if shared {
+			result.SetGeoPrivacy(s.GeoPrivacy(f.Album))
+			result.Redact(service.Config().ShareRedact())
+		}
+
 		AddCountHeader(c, count)
 		AddLimitHeader(c, f.Count)
 		AddOffsetHeader(c, f.Offset)
@@ -7,6 +7,10 @@ import (
 	"github.com/tidwall/gjson"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 )
 
 func TestSearchPhotos(t *testing.T) {
@@ -24,5 +28,53 @@ func TestSearchPhotos(t *testing.T) {
 		SearchPhotos(router)
 		result := PerformRequest(app, "GET", "/api/v1/photos?xxx=10")
 		assert.Equal(t, http.StatusBadRequest, result.Code)
+		assert.Equal(t, "bad_request", gjson.Get(result.Body.String(), "id").String())
+		assert.Equal(t, "required", gjson.Get(result.Body.String(), "fields.Count").String())
+	})
+
+	t.Run("GuestGeoFilter", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+
+		SearchPhotos(router)
+
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba9"}})
+
+		all := AuthenticatedRequest(app, "GET", "/api/v1/photos?count=10&album=at9lxuqxpogaaba9", sessId)
+		assert.Equal(t, http.StatusOK, all.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(all.Body.String(), "#").Int())
+
+		// Location filters are ignored, so that guests cannot probe hidden coordinates.
+		for _, query := range []string{"latlng=1,1,0,0", "polygon=1,0,1,1,0,1", "lat=1&lng=1&radius=1", "q=lat:1+lng:1+radius:1", "geo=no", "country=zz", "state=Nowhere", "alt=>100000", "heading=1..2", "speed=>100000", "q=geo:no+country:zz"} {
+			r := AuthenticatedRequest(app, "GET", "/api/v1/photos?count=10&album=at9lxuqxpogaaba9&"+query, sessId)
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, gjson.Get(all.Body.String(), "#").Int(), gjson.Get(r.Body.String(), "#").Int(), query)
+		}
+	})
+	t.Run("GuestQueryOverride", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+
+		SearchPhotos(router)
+
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba9"}})
+
+		all := AuthenticatedRequest(app, "GET", "/api/v1/photos?count=10&album=at9lxuqxpogaaba9", sessId)
+		assert.Equal(t, http.StatusOK, all.Code)
+
+		// Albums that are not shared with the guest cannot be selected with the query string or filter.
+		for _, query := range []string{"q=album:at9lxuqxpogaaba8", "filter=album:at9lxuqxpogaaba8"} {
+			r := AuthenticatedRequest(app, "GET", "/api/v1/photos?count=10&album=at9lxuqxpogaaba9&"+query, sessId)
+			assert.Equal(t, http.StatusUnauthorized, r.Code, query)
+		}
+
+		// Private, archived, and hidden pictures remain excluded.
+		for _, query := range []string{"q=private:true+public:false+archived:true+hidden:true+review:true", "filter=private:true+public:false", "q=albums:at9lxuqxpogaaba8"} {
+			r := AuthenticatedRequest(app, "GET", "/api/v1/photos?count=10&album=at9lxuqxpogaaba9&"+query, sessId)
+			assert.Equal(t, http.StatusOK, r.Code, query)
+			assert.Equal(t, gjson.Get(all.Body.String(), "#.UID").String(), gjson.Get(r.Body.String(), "#.UID").String(), query)
+		}
 	})
 }
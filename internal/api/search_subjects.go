@@ -7,9 +7,10 @@ import (
 	"github.com/gin-gonic/gin/binding"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/search"
-	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/photoprism/photoprism/internal/tracing"
 )
 
 // SearchSubjects finds and returns subjects as JSON.
@@ -17,34 +18,54 @@ import (
 // GET /api/v1/subjects
 func SearchSubjects(router *gin.RouterGroup) {
 	router.GET("/subjects", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceSubjects, acl.ActionSearch)
+		searchSubjects(c, "")
+	})
+}
 
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+// SearchPets finds and returns pets as JSON.
+//
+// GET /api/v1/pets
+func SearchPets(router *gin.RouterGroup) {
+	router.GET("/pets", func(c *gin.Context) {
+		searchSubjects(c, entity.SubjPet)
+	})
+}
 
-		var f form.SearchSubjects
+// searchSubjects finds subjects and renders them as JSON, optionally limited to a subject type.
+func searchSubjects(c *gin.Context, subjType string) {
+	s := Auth(SessionID(c), acl.ResourceSubjects, acl.ActionSearch)
 
-		err := c.MustBindWith(&f, binding.Form)
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return
+	}
 
-		if err != nil {
-			AbortBadRequest(c)
-			return
-		}
+	var f form.SearchSubjects
 
-		result, err := search.Subjects(f)
+	err := c.MustBindWith(&f, binding.Form)
 
-		if err != nil {
-			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
-			return
-		}
+	if err != nil {
+		AbortBadRequest(c)
+		return
+	}
 
-		AddCountHeader(c, len(result))
-		AddLimitHeader(c, f.Count)
-		AddOffsetHeader(c, f.Offset)
-		AddTokenHeaders(c)
+	if subjType != "" {
+		f.Type = subjType
+	}
 
-		c.JSON(http.StatusOK, result)
-	})
+	ctx, span := tracing.Start(c.Request.Context(), "search.subjects")
+	result, err := search.SubjectsContext(ctx, f)
+	tracing.End(span, err)
+
+	if err != nil {
+		AbortError(c, 400, err)
+		return
+	}
+
+	AddCountHeader(c, len(result))
+	AddLimitHeader(c, f.Count)
+	AddOffsetHeader(c, f.Offset)
+	AddTokenHeaders(c)
+
+	c.JSON(http.StatusOK, result)
 }
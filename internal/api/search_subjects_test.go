@@ -25,3 +25,22 @@ func TestSearchSubjects(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 }
+
+func TestSearchPets(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchPets(router)
+		r := PerformRequest(app, "GET", "/api/v1/pets?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		gjson.Get(r.Body.String(), "#.Type").ForEach(func(key, value gjson.Result) bool {
+			assert.Equal(t, "pet", value.String())
+			return true
+		})
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchPets(router)
+		r := PerformRequest(app, "GET", "/api/v1/pets?xxx=10")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
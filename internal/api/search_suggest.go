@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SuggestLimit is the default number of search suggestions.
+const SuggestLimit = 10
+
+// SearchSuggestions returns labels, people, albums, places, and cameras with a name starting with a prefix.
+// Suggestions are based on the complete index, so they are not available to guests.
+//
+// GET /api/v1/suggest
+//
+// Parameters:
+//   q: string Prefix to complete
+//   type: string Comma-separated suggestion types, e.g. "label,person" (optional)
+//   count: int Max number of results (optional)
+func SearchSuggestions(router *gin.RouterGroup) {
+	router.GET("/suggest", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceSuggestions, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+
+		if limit <= 0 || limit > search.MaxResults {
+			limit = SuggestLimit
+		}
+
+		requested := search.SuggestTypes
+
+		if t := strings.TrimSpace(strings.ToLower(c.Query("type"))); t != "" {
+			requested = strings.Split(t, ",")
+		}
+
+		types := make([]string, 0, len(requested))
+
+		// Only suggest people if the feature is enabled.
+		for _, t := range requested {
+			if t = strings.TrimSpace(t); t == search.SuggestPerson && !service.Config().Settings().Features.People {
+				continue
+			} else if t != "" {
+				types = append(types, t)
+			}
+		}
+
+		if len(types) == 0 {
+			c.JSON(http.StatusOK, search.Suggestions{})
+			return
+		}
+
+		result, err := search.Suggest(c.Query("q"), limit, types...)
+
+		if err != nil {
+			AbortError(c, 400, err)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, limit)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
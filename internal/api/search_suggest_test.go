@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+func TestSearchSuggestions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchSuggestions(router)
+		r := PerformRequest(app, "GET", "/api/v1/suggest?q=berlin&count=5")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Berlin 2019", gjson.Get(r.Body.String(), `#(Type=="album").Name`).String())
+	})
+	t.Run("Type", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchSuggestions(router)
+		r := PerformRequest(app, "GET", "/api/v1/suggest?q=c&type=camera")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		for _, t2 := range gjson.Get(r.Body.String(), "#.Type").Array() {
+			assert.Equal(t, "camera", t2.String())
+		}
+	})
+	t.Run("Guest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+
+		SearchSuggestions(router)
+
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba8"}})
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/suggest?q=berlin", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchSuggestions(router)
+		r := PerformRequest(app, "GET", "/api/v1/suggest")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+}
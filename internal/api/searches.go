@@ -0,0 +1,178 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// authSearch returns the session and saved search if the user is allowed to access it.
+func authSearch(c *gin.Context, action acl.Action) (s session.Data, m *entity.Search) {
+	s = AuthUser(SessionID(c), acl.ResourceSearches, action)
+
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return s, nil
+	}
+
+	m = entity.FindSearch(sanitize.IdString(c.Param("uid")))
+
+	if m == nil {
+		AbortEntityNotFound(c)
+		return s, nil
+	} else if !s.Owner(m.UserUID) {
+		AbortEntityNotFound(c)
+		return s, nil
+	}
+
+	return s, m
+}
+
+// GetSearches returns the saved searches of the current user.
+//
+// GET /api/v1/searches
+func GetSearches(router *gin.RouterGroup) {
+	router.GET("/searches", func(c *gin.Context) {
+		s := AuthUser(SessionID(c), acl.ResourceSearches, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		results, err := query.Searches(s.User.UserUID)
+
+		if err != nil {
+			log.Errorf("searches: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// GetSearch returns a saved search by UID.
+//
+// GET /api/v1/searches/:uid
+func GetSearch(router *gin.RouterGroup) {
+	router.GET("/searches/:uid", func(c *gin.Context) {
+		if _, m := authSearch(c, acl.ActionRead); m != nil {
+			c.JSON(http.StatusOK, m)
+		}
+	})
+}
+
+// CreateSearch saves a search query for the current user.
+//
+// POST /api/v1/searches
+func CreateSearch(router *gin.RouterGroup) {
+	router.POST("/searches", func(c *gin.Context) {
+		s := AuthUser(SessionID(c), acl.ResourceSearches, acl.ActionCreate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.SavedSearch
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.NewSearch(s.User.UserUID, f.Name, f.Query, f.Notify)
+
+		if err := m.Validate(); err != nil {
+			log.Errorf("searches: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := m.Create(); err != nil {
+			log.Errorf("searches: %s (create)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("searches: saved %s", sanitize.Log(m.SearchName))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// UpdateSearch changes the name, query, or notification setting of a saved search.
+//
+// PUT /api/v1/searches/:uid
+func UpdateSearch(router *gin.RouterGroup) {
+	router.PUT("/searches/:uid", func(c *gin.Context) {
+		_, m := authSearch(c, acl.ActionUpdate)
+
+		if m == nil {
+			return
+		}
+
+		f := form.SavedSearch{Name: m.SearchName, Query: m.SearchQuery, Notify: m.SearchNotify}
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		updated := entity.NewSearch(m.UserUID, f.Name, f.Query, f.Notify)
+
+		if err := updated.Validate(); err != nil {
+			log.Errorf("searches: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		m.SearchName = updated.SearchName
+		m.SearchNotify = updated.SearchNotify
+
+		// Only photos added after the query has changed are new matches.
+		if m.SearchQuery != updated.SearchQuery {
+			checkedAt := entity.TimeStamp()
+			m.SearchQuery = updated.SearchQuery
+			m.CheckedAt = &checkedAt
+			m.MatchCount = 0
+		}
+
+		if err := m.Save(); err != nil {
+			log.Errorf("searches: %s (update)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteSearch removes a saved search.
+//
+// DELETE /api/v1/searches/:uid
+func DeleteSearch(router *gin.RouterGroup) {
+	router.DELETE("/searches/:uid", func(c *gin.Context) {
+		_, m := authSearch(c, acl.ActionDelete)
+
+		if m == nil {
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("searches: %s (delete)", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
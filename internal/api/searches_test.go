@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCreateSearch(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateSearch(router)
+		GetSearches(router)
+		GetSearch(router)
+		UpdateSearch(router)
+		DeleteSearch(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/searches", `{"Name": "Beach", "Query": "label:beach", "Notify": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Beach", gjson.Get(r.Body.String(), "Name").String())
+		assert.True(t, gjson.Get(r.Body.String(), "Notify").Bool())
+
+		uid := gjson.Get(r.Body.String(), "UID").String()
+
+		r = PerformRequest(app, "GET", "/api/v1/searches")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, gjson.Get(r.Body.String(), "#.UID").String(), uid)
+
+		r = PerformRequest(app, "GET", "/api/v1/searches/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "label:beach", gjson.Get(r.Body.String(), "Query").String())
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/searches/"+uid, `{"Query": "label:sea", "Notify": false}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Beach", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, "label:sea", gjson.Get(r.Body.String(), "Query").String())
+		assert.False(t, gjson.Get(r.Body.String(), "Notify").Bool())
+
+		r = PerformRequest(app, "DELETE", "/api/v1/searches/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/searches/"+uid)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("EmptyQuery", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateSearch(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/searches", `{"Name": "Beach", "Query": ""}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGetSearch(t *testing.T) {
+	t.Run("Fixture", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSearch(router)
+		r := PerformRequest(app, "GET", "/api/v1/searches/qt9lxuqxpogaaba1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Kids", gjson.Get(r.Body.String(), "Name").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSearch(router)
+		r := PerformRequest(app, "GET", "/api/v1/searches/qt9lxuqxpogaaxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestDeleteSearch(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteSearch(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/searches/qt9lxuqxpogaaxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -2,14 +2,17 @@ package api
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/photoprism/photoprism/pkg/sanitize"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/internal/session"
 )
@@ -41,7 +44,7 @@ func CreateSession(router *gin.RouterGroup) {
 			links := entity.FindValidLinks(f.Token, "")
 
 			if len(links) == 0 {
-				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidLink)})
+				Abort(c, http.StatusBadRequest, i18n.ErrInvalidLink)
 			}
 
 			data.Tokens = []string{f.Token}
@@ -59,18 +62,18 @@ func CreateSession(router *gin.RouterGroup) {
 			user := entity.FindUserByName(f.UserName)
 
 			if user == nil {
-				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidCredentials)})
+				Abort(c, http.StatusBadRequest, i18n.ErrInvalidCredentials)
 				return
 			}
 
 			if user.InvalidPassword(f.Password) {
-				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidCredentials)})
+				Abort(c, http.StatusBadRequest, i18n.ErrInvalidCredentials)
 				return
 			}
 
 			data.User = *user
 		} else {
-			c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidPassword)})
+			Abort(c, http.StatusBadRequest, i18n.ErrInvalidPassword)
 			return
 		}
 
@@ -80,11 +83,18 @@ func CreateSession(router *gin.RouterGroup) {
 
 		AddSessionHeader(c, id)
 
-		if data.User.Anonymous() {
-			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": conf.GuestConfig()})
+		var clientConfig config.ClientConfig
+
+		if data.User.Anonymous() || data.User.Guest() {
+			clientConfig = AccessConfig(id, conf.GuestConfig())
 		} else {
-			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": conf.UserConfig()})
+			clientConfig = AccessConfig(id, conf.UserConfig())
 		}
+
+		// Include the access tokens that may have been added to the session.
+		data = service.Session().Get(id)
+
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": clientConfig})
 	})
 }
 
@@ -94,11 +104,67 @@ func DeleteSession(router *gin.RouterGroup) {
 		id := sanitize.Token(c.Param("id"))
 
 		service.Session().Delete(id)
+		service.ShareCache().Delete(id)
 
 		c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id})
 	})
 }
 
+// RotateSessionTokens replaces the download and preview tokens of the current session,
+// so that previously shared thumbnail and download URLs stop working immediately.
+//
+// POST /api/v1/session/tokens
+func RotateSessionTokens(router *gin.RouterGroup) {
+	router.POST("/session/tokens", func(c *gin.Context) {
+		id := SessionID(c)
+
+		if s := Session(id); s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+		lifetime := conf.TokenLifetime()
+
+		if lifetime <= 0 {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		a, err := service.Session().RotateAccess(id, lifetime)
+
+		if err != nil {
+			log.Errorf("session: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"downloadToken": a.DownloadToken, "previewToken": a.PreviewToken, "expires": a.Expires})
+	})
+}
+
+// AccessConfig returns the client config with the download and preview tokens of the session, if tokens expire.
+func AccessConfig(id string, cfg config.ClientConfig) config.ClientConfig {
+	conf := service.Config()
+	lifetime := conf.TokenLifetime()
+
+	if lifetime <= 0 {
+		return cfg
+	}
+
+	a, err := service.Session().Access(id, lifetime)
+
+	if err != nil {
+		log.Debugf("session: %s", err)
+	}
+
+	cfg.MapTiles = strings.Replace(cfg.MapTiles, "/tiles/"+conf.PreviewToken()+"/", "/tiles/"+a.PreviewToken+"/", 1)
+	cfg.DownloadToken = a.DownloadToken
+	cfg.PreviewToken = a.PreviewToken
+
+	return cfg
+}
+
 // Gets session id from HTTP header.
 func SessionID(c *gin.Context) string {
 	return c.GetHeader("X-Session-ID")
@@ -112,7 +178,70 @@ func Session(id string) session.Data {
 	}
 
 	// Check if session id is valid.
-	return service.Session().Get(id)
+	s := service.Session().Get(id)
+
+	// Delete the session if the user has been disabled or removed in the meantime, e.g. with the CLI.
+	if s.User.Registered() && !ActiveUser(s.User.UserUID) {
+		service.Session().DeleteUser(s.User.UserUID)
+		return session.Data{}
+	}
+
+	// Registered guests may also see albums that are visible to guests.
+	if s.Guest() && s.User.Registered() {
+		s.Shares = append(append(session.UIDs{}, s.Shares...), GuestAlbumUIDs(id)...)
+	}
+
+	return s
+}
+
+// ActiveUser tests if the registered user may still log in, which is cached for a minute so that the
+// database is not queried for each request.
+func ActiveUser(uid string) bool {
+	cache := service.UserCache()
+
+	if hit, ok := cache.Get(uid); ok {
+		return hit.(bool)
+	}
+
+	active := entity.UserActive(uid)
+
+	cache.SetDefault(uid, active)
+
+	return active
+}
+
+// GuestAlbumUIDs returns the albums visible to registered guests, which are cached for each session.
+func GuestAlbumUIDs(id string) session.UIDs {
+	cache := service.ShareCache()
+
+	if hit, ok := cache.Get(id); ok {
+		return hit.(session.UIDs)
+	}
+
+	uids, err := query.GuestAlbumUIDs()
+
+	if err != nil {
+		log.Errorf("session: %s (find guest albums)", err)
+		return session.UIDs{}
+	}
+
+	cache.SetDefault(id, session.UIDs(uids))
+
+	return uids
+}
+
+// SharedGeoPrivacy returns the strictest geo privacy level of the albums shared with a guest session.
+func SharedGeoPrivacy(s session.Data) (level string) {
+	for _, uid := range s.Shares {
+		level = entity.GeoStricter(level, s.GeoPrivacy(uid))
+	}
+
+	return level
+}
+
+// SharedOnly tests if the session role may only search content that has been shared with it, e.g. for guests.
+func SharedOnly(s session.Data, resource acl.Resource) bool {
+	return acl.Permissions.Deny(resource, s.User.Role(), acl.ActionSearchAll)
 }
 
 // Auth returns the session if user is authorized for the current action.
@@ -126,6 +255,19 @@ func Auth(id string, resource acl.Resource, action acl.Action) session.Data {
 	return sess
 }
 
+// AuthUser returns the session if the user is registered and authorized for the current action,
+// e.g. for resources that belong to a user account.
+func AuthUser(id string, resource acl.Resource, action acl.Action) session.Data {
+	if sess := Auth(id, resource, action); sess.User.Registered() {
+		return sess
+	}
+
+	return session.Data{}
+}
+
+// DLNAKey is the context key of requests received by the DLNA media server, which only accepts the DLNA token.
+const DLNAKey = "dlna"
+
 // InvalidPreviewToken returns true if the token is invalid.
 func InvalidPreviewToken(c *gin.Context) bool {
 	token := sanitize.Token(c.Param("token"))
@@ -134,10 +276,55 @@ func InvalidPreviewToken(c *gin.Context) bool {
 		token = sanitize.Token(c.Query("t"))
 	}
 
+	// Smart TVs use a dedicated token that is not valid for other requests.
+	if c.GetBool(DLNAKey) {
+		return service.Config().InvalidDLNAToken(token)
+	}
+
+	// Only session tokens are valid if they expire.
+	if service.Config().TokenLifetime() > 0 {
+		return !service.Session().ValidPreviewToken(token)
+	}
+
 	return service.Config().InvalidPreviewToken(token)
 }
 
 // InvalidDownloadToken returns true if the token is invalid.
 func InvalidDownloadToken(c *gin.Context) bool {
-	return service.Config().InvalidDownloadToken(sanitize.Token(c.Query("t")))
+	token := sanitize.Token(c.Query("t"))
+
+	if service.Config().TokenLifetime() > 0 {
+		_, ok := service.Session().DownloadToken(token)
+		return !ok
+	}
+
+	return service.Config().InvalidDownloadToken(token)
+}
+
+// GuestPreview tests if the preview token may belong to a guest, so that originals must be redacted. This
+// is always the case with static tokens, as they are the same for all sessions.
+func GuestPreview(c *gin.Context) bool {
+	if service.Config().Public() {
+		return false
+	}
+
+	if s, ok := service.Session().PreviewToken(sanitize.Token(c.Param("token"))); ok {
+		return SharedOnly(s, acl.ResourcePhotos)
+	}
+
+	return true
+}
+
+// ShareDownload tests if the download token belongs to shared content with redacted metadata.
+func ShareDownload(c *gin.Context) bool {
+	conf := service.Config()
+	token := sanitize.Token(c.Query("t"))
+
+	// Downloads of guest sessions are redacted if tokens expire.
+	if conf.TokenLifetime() > 0 {
+		s, ok := service.Session().DownloadToken(token)
+		return ok && SharedOnly(s, acl.ResourcePhotos) && len(conf.ShareRedact()) > 0
+	}
+
+	return conf.ShareDownload(token)
 }
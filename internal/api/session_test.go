@@ -4,7 +4,11 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 )
@@ -44,6 +48,27 @@ func TestCreateSession(t *testing.T) {
 		assert.Equal(t, i18n.Msg(i18n.ErrInvalidCredentials), val.String())
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("registered guest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateSession(router)
+
+		user := entity.User{UserName: "sessionguest", RoleGuest: true}
+
+		if err := user.Create(); err != nil {
+			t.Fatal(err)
+		} else if err = user.SetPassword("Guest123!"); err != nil {
+			t.Fatal(err)
+		}
+
+		defer user.Delete()
+
+		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", `{"username": "sessionguest", "password": "Guest123!"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "guest", gjson.Get(r.Body.String(), "config.mode").String())
+
+		r = PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", `{"username": "admin", "password": "photoprism"}`)
+		assert.NotEqual(t, "guest", gjson.Get(r.Body.String(), "config.mode").String())
+	})
 	t.Run("alice - successful request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		CreateSession(router)
@@ -72,6 +97,14 @@ func TestCreateSession(t *testing.T) {
 		assert.Equal(t, i18n.Msg(i18n.ErrInvalidCredentials), val.String())
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("friend - disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateSession(router)
+		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", `{"username": "friend", "password": "!Friend321"}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrInvalidCredentials), val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
 }
 
 func TestDeleteSession(t *testing.T) {
@@ -101,3 +134,211 @@ func TestDeleteSession(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestRotateSessionTokens(t *testing.T) {
+	t.Run("Unlimited", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		RotateSessionTokens(router)
+		sessId := AuthenticateAdmin(app, router)
+
+		r := AuthenticatedRequest(app, http.MethodPost, "/api/v1/session/tokens", sessId)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Expiring", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		conf.Options().TokenLifetime = 3600
+		defer func() {
+			conf.SetPublic(true)
+			conf.Options().TokenLifetime = 0
+		}()
+		RotateSessionTokens(router)
+		DownloadAlbum(router)
+		sessId := AuthenticateAdmin(app, router)
+
+		// Static tokens are no longer valid.
+		r := PerformRequest(app, http.MethodGet, "/api/v1/albums/at9lxuqxpogaaba8/dl?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		r = AuthenticatedRequest(app, http.MethodPost, "/api/v1/session/tokens", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		downloadToken := gjson.Get(r.Body.String(), "downloadToken").String()
+		assert.NotEmpty(t, downloadToken)
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "previewToken").String())
+		assert.Greater(t, gjson.Get(r.Body.String(), "expires").Int(), int64(0))
+
+		r = PerformRequest(app, http.MethodGet, "/api/v1/albums/at9lxuqxpogaaba8/dl?t="+downloadToken)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		// Previous tokens are invalid after rotation.
+		r = AuthenticatedRequest(app, http.MethodPost, "/api/v1/session/tokens", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEqual(t, downloadToken, gjson.Get(r.Body.String(), "downloadToken").String())
+
+		r = PerformRequest(app, http.MethodGet, "/api/v1/albums/at9lxuqxpogaaba8/dl?t="+downloadToken)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("Unauthorized", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		RotateSessionTokens(router)
+
+		r := AuthenticatedRequest(app, http.MethodPost, "/api/v1/session/tokens", "xxx")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestAuth(t *testing.T) {
+	_, _, conf := NewApiTest()
+	conf.SetPublic(false)
+	defer conf.SetPublic(true)
+
+	sessId := service.Session().Create(session.Data{User: entity.User{
+		ID:        99,
+		UserUID:   "uqxqg7i1kperxvu9",
+		UserName:  "grandma",
+		RoleGuest: true,
+	}})
+
+	t.Run("Guest", func(t *testing.T) {
+		assert.True(t, Auth(sessId, acl.ResourcePhotos, acl.ActionSearch).Valid())
+		assert.True(t, Auth(sessId, acl.ResourceCounts, acl.ActionRead).Invalid())
+		assert.True(t, Auth(sessId, acl.ResourcePhotos, acl.ActionSimilar).Invalid())
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		assert.True(t, Auth("xxx", acl.ResourcePhotos, acl.ActionSearch).Invalid())
+	})
+}
+
+func TestAuthUser(t *testing.T) {
+	_, _, conf := NewApiTest()
+	conf.SetPublic(false)
+	defer conf.SetPublic(true)
+
+	t.Run("RegisteredGuest", func(t *testing.T) {
+		sessId := service.Session().Create(session.Data{User: entity.User{
+			ID:        99,
+			UserUID:   "uqxqg7i1kperxvu9",
+			UserName:  "grandma",
+			RoleGuest: true,
+		}})
+
+		assert.True(t, AuthUser(sessId, acl.ResourceSearches, acl.ActionCreate).Valid())
+		assert.True(t, AuthUser(sessId, acl.ResourceDownloads, acl.ActionCreate).Invalid())
+	})
+	t.Run("LinkGuest", func(t *testing.T) {
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba8"}})
+
+		assert.True(t, Auth(sessId, acl.ResourceSearches, acl.ActionCreate).Valid())
+		assert.True(t, AuthUser(sessId, acl.ResourceSearches, acl.ActionCreate).Invalid())
+	})
+}
+
+func TestSession(t *testing.T) {
+	t.Run("DisabledUser", func(t *testing.T) {
+		conf := service.Config()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+
+		friend := entity.UserFixtures.Get("friend")
+		id := service.Session().Create(session.Data{User: friend})
+
+		assert.True(t, Session(id).Invalid())
+		assert.False(t, service.Session().Exists(id))
+	})
+	t.Run("DeletedUser", func(t *testing.T) {
+		conf := service.Config()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+
+		deleted := entity.UserFixtures.Get("deleted")
+		id := service.Session().Create(session.Data{User: deleted})
+
+		assert.True(t, Session(id).Invalid())
+		assert.False(t, service.Session().Exists(id))
+	})
+}
+
+func TestGuestAlbumUIDs(t *testing.T) {
+	sessId := service.Session().Create(session.Data{User: entity.User{
+		ID:        99,
+		UserUID:   "uqxqg7i1kperxvu9",
+		UserName:  "grandma",
+		RoleGuest: true,
+	}})
+
+	uids := GuestAlbumUIDs(sessId)
+
+	hit, ok := service.ShareCache().Get(sessId)
+
+	if assert.True(t, ok) {
+		assert.Equal(t, uids, hit.(session.UIDs))
+	}
+
+	// Cached albums are returned for the same session.
+	service.ShareCache().SetDefault(sessId, session.UIDs{"at9lxuqxpogaaxxx"})
+	assert.Equal(t, session.UIDs{"at9lxuqxpogaaxxx"}, GuestAlbumUIDs(sessId))
+	service.ShareCache().Delete(sessId)
+}
+
+func TestActiveUser(t *testing.T) {
+	assert.True(t, ActiveUser("uqxqg7i1kperxvu9"))
+	assert.False(t, ActiveUser("uqxqg7i1kperxvu7"))
+
+	hit, ok := service.UserCache().Get("uqxqg7i1kperxvu9")
+
+	if assert.True(t, ok) {
+		assert.True(t, hit.(bool))
+	}
+
+	// The cached status is returned until it expires.
+	service.UserCache().SetDefault("uqxqg7i1kperxvu9", false)
+	assert.False(t, ActiveUser("uqxqg7i1kperxvu9"))
+	service.UserCache().Delete("uqxqg7i1kperxvu9")
+}
+
+// TestAuth_Endpoints verifies that guests cannot access endpoints which are not shared with them.
+func TestAuth_Endpoints(t *testing.T) {
+	app, router, conf := NewApiTest()
+	conf.SetPublic(false)
+	defer conf.SetPublic(true)
+
+	GetCounts(router)
+	GetSimilarPhotos(router)
+	GetDownloads(router)
+	GetSearches(router)
+	GetAlbumLinks(router)
+	GetPhotoLinks(router)
+	GetLabelLinks(router)
+
+	sessId := service.Session().Create(session.Data{User: entity.User{
+		ID:        99,
+		UserUID:   "uqxqg7i1kperxvu9",
+		UserName:  "grandma",
+		RoleGuest: true,
+	}})
+
+	tests := []struct {
+		Path string
+		Code int
+	}{
+		{"/api/v1/counts", http.StatusUnauthorized},
+		{"/api/v1/photos/pt9jtdre2lvl0yh7/similar", http.StatusUnauthorized},
+		{"/api/v1/downloads", http.StatusUnauthorized},
+		{"/api/v1/searches", http.StatusOK},
+		{"/api/v1/albums/at9lxuqxpogaaba8/links", http.StatusUnauthorized},
+		{"/api/v1/photos/pt9jtdre2lvl0yh7/links", http.StatusUnauthorized},
+		{"/api/v1/labels/lt9k3pw1wowuy3c2/links", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Path, func(t *testing.T) {
+			r := AuthenticatedRequest(app, http.MethodGet, tt.Path, sessId)
+			assert.Equal(t, tt.Code, r.Code)
+		})
+	}
+}
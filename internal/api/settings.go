@@ -21,7 +21,7 @@ func GetSettings(router *gin.RouterGroup) {
 		}
 
 		if settings := service.Config().Settings(); settings != nil {
-			c.JSON(http.StatusOK, settings)
+			c.JSON(http.StatusOK, settings.Masked())
 		} else {
 			Abort(c, http.StatusNotFound, i18n.ErrNotFound)
 		}
@@ -46,14 +46,18 @@ func SaveSettings(router *gin.RouterGroup) {
 		}
 
 		settings := conf.Settings()
+		saved := settings.Notify
 
 		if err := c.BindJSON(settings); err != nil {
 			AbortBadRequest(c)
 			return
 		}
 
+		// Secrets are masked in responses, so keep them if they were not changed.
+		settings.KeepSecrets(saved)
+
 		if err := settings.Save(conf.SettingsFile()); err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, err)
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -61,6 +65,6 @@ func SaveSettings(router *gin.RouterGroup) {
 
 		event.InfoMsg(i18n.MsgSettingsSaved)
 
-		c.JSON(http.StatusOK, settings)
+		c.JSON(http.StatusOK, settings.Masked())
 	})
 }
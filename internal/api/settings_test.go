@@ -39,6 +39,27 @@ func TestSaveSettings(t *testing.T) {
 		r3 := PerformRequestWithBody(app, "POST", "/api/v1/settings", `{"ui":{"language": "en"}}`)
 		assert.Equal(t, http.StatusOK, r3.Code)
 	})
+	t.Run("masked secrets", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetSettings(router)
+		SaveSettings(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/settings", `{"notify":{"telegram":{"token":"123:abc"}}}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "********", gjson.Get(r.Body.String(), "notify.telegram.token").String())
+
+		r = PerformRequest(app, "GET", "/api/v1/settings")
+		assert.Equal(t, "********", gjson.Get(r.Body.String(), "notify.telegram.token").String())
+
+		// Sending back the placeholder keeps the saved token.
+		r = PerformRequestWithBody(app, "POST", "/api/v1/settings", `{"notify":{"telegram":{"token":"********"}}}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "123:abc", conf.Settings().Notify.Telegram.Token)
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/settings", `{"notify":{"telegram":{"token":""}}}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", conf.Settings().Notify.Telegram.Token)
+	})
 	t.Run("bad request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		SaveSettings(router)
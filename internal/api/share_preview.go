@@ -20,6 +20,7 @@ import (
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
 // SharePreview returns a link share preview image.
@@ -34,7 +35,7 @@ func SharePreview(router *gin.RouterGroup) {
 		share := sanitize.Token(c.Param("share"))
 		links := entity.FindLinks(token, share)
 
-		if len(links) != 1 {
+		if len(links) != 1 || links[0].Expired() {
 			log.Warn("share: invalid token (preview)")
 			c.Redirect(http.StatusTemporaryRedirect, conf.SitePreview())
 			return
@@ -65,8 +66,13 @@ func SharePreview(router *gin.RouterGroup) {
 
 		var f form.SearchPhotos
 
-		// Covers may only contain public content in shared albums.
-		f.Album = share
+		// Covers may only contain public content in shared albums, or the shared photo itself.
+		if rnd.IsPPID(share, 'p') {
+			f.UID = share
+		} else {
+			f.Album = share
+		}
+
 		f.Public = true
 		f.Private = false
 		f.Hidden = false
@@ -0,0 +1,458 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// ShareUploadFile represents a guest upload that is waiting for review.
+type ShareUploadFile struct {
+	Name       string    `json:"Name"`
+	Size       int64     `json:"Size"`
+	UploadedAt time.Time `json:"UploadedAt"`
+}
+
+// shareUploadRequests counts the guest upload requests per link, so that they can be limited.
+var shareUploadRequests = gc.New(time.Hour, 10*time.Minute)
+
+// shareUploadRateExceeded tests if the upload requests of the link exceed the configured rate.
+func shareUploadRateExceeded(linkUID string, rate int) bool {
+	if n, err := shareUploadRequests.IncrementInt(linkUID, 1); err == nil {
+		return n > rate
+	}
+
+	shareUploadRequests.SetDefault(linkUID, 1)
+
+	return rate < 1
+}
+
+// shareUploadLink returns the first valid link that allows guests to upload to the share.
+func shareUploadLink(token, share string) *entity.Link {
+	for _, link := range entity.FindValidLinks(token, share) {
+		if link.Uploads() {
+			return &link
+		}
+	}
+
+	return nil
+}
+
+// shareUploadFiles returns the absolute file names of pending uploads, all if names is empty.
+func shareUploadFiles(dir string, names []string) (result []string) {
+	if len(names) == 0 {
+		matches, _ := filepath.Glob(filepath.Join(dir, "*", "*"))
+
+		for _, fileName := range matches {
+			if fs.FileExists(fileName) {
+				result = append(result, fileName)
+			}
+		}
+
+		return result
+	}
+
+	for _, name := range names {
+		fileName := filepath.Join(dir, filepath.Clean("/"+name))
+
+		if strings.HasPrefix(fileName, dir+string(os.PathSeparator)) && fs.FileExists(fileName) {
+			result = append(result, fileName)
+		}
+	}
+
+	return result
+}
+
+// approveShareUploads moves uploaded files to the approved folder of the album, so that they are imported
+// in the background. Files stay there until they have been imported successfully.
+func approveShareUploads(conf *config.Config, dir, albumUID string, fileNames []string) (approved int) {
+	approvedPath := filepath.Join(conf.ShareApprovedPath(albumUID), rnd.Token(8))
+
+	if err := os.MkdirAll(approvedPath, os.ModePerm); err != nil {
+		log.Errorf("share: %s (create approved folder)", err)
+		return 0
+	}
+
+	for _, fileName := range fileNames {
+		if err := os.Rename(fileName, filepath.Join(approvedPath, filepath.Base(fileName))); err != nil {
+			log.Errorf("share: %s (move upload)", err)
+		} else {
+			approved++
+		}
+	}
+
+	// Remove empty upload folders.
+	if dirs, err := filepath.Glob(filepath.Join(dir, "*")); err == nil {
+		for _, d := range dirs {
+			if fs.IsEmpty(d) {
+				_ = os.Remove(d)
+			}
+		}
+	}
+
+	if approved == 0 {
+		_ = os.Remove(approvedPath)
+		return 0
+	}
+
+	workers.StartShareUploads(conf)
+
+	return approved
+}
+
+// ShareUpload lets guests upload files to a shared album if the link permits it.
+// Uploads are imported in the background, or kept for review if moderation is enabled.
+//
+// POST /api/v1/shares/:token/:share/upload
+func ShareUpload(router *gin.RouterGroup) {
+	router.POST("/shares/:token/:share/upload", func(c *gin.Context) {
+		conf := service.Config()
+
+		if conf.ReadOnly() || !conf.Settings().Features.Upload {
+			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+			return
+		}
+
+		link := shareUploadLink(sanitize.Token(c.Param("token")), sanitize.Token(c.Param("share")))
+
+		if link == nil {
+			Abort(c, http.StatusForbidden, i18n.ErrInvalidLink)
+			return
+		} else if _, err := query.AlbumByUID(link.ShareUID); err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		// Failed password attempts count as well, so that passwords can't be guessed.
+		if shareUploadRateExceeded(link.LinkUID, conf.ShareUploadRate()) {
+			Abort(c, http.StatusTooManyRequests, i18n.ErrTooManyRequests)
+			return
+		}
+
+		// The password is checked before the request body is read.
+		if link.InvalidPassword(c.GetHeader("X-Link-Password")) {
+			Abort(c, http.StatusUnauthorized, i18n.ErrInvalidPassword)
+			return
+		}
+
+		limit := conf.ShareUploadLimit()
+
+		if c.Request.ContentLength > limit {
+			Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrUploadTooLarge)
+			return
+		} else if err := conf.CheckStorage(conf.StoragePath(), c.Request.ContentLength); err != nil {
+			AbortStorageFull(c)
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+
+		f, err := c.MultipartForm()
+
+		if err != nil {
+			log.Errorf("share: %s (upload)", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		files := f.File["files"]
+		dir := conf.ShareUploadPath(link.ShareUID)
+
+		if len(files) == 0 {
+			AbortBadRequest(c)
+			return
+		} else if max := conf.ShareUploadFiles(); len(files) > max || len(shareUploadFiles(dir, nil))+len(files) > 10*max {
+			// Limit the number of files per upload and the number of files waiting for review.
+			Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrUploadTooLarge)
+			return
+		}
+
+		// Only media files are accepted.
+		for _, file := range files {
+			if !fs.IsMedia(file.Filename) {
+				Abort(c, http.StatusUnsupportedMediaType, i18n.ErrUnsupportedType)
+				return
+			}
+		}
+
+		start := time.Now()
+		p := filepath.Join(dir, start.Format("20060102-150405")+"-"+rnd.Token(4))
+
+		if err := os.MkdirAll(p, os.ModePerm); err != nil {
+			log.Errorf("share: %s (create upload folder)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		var uploads []string
+
+		for _, file := range files {
+			fileName := filepath.Join(p, filepath.Base(file.Filename))
+
+			if err := c.SaveUploadedFile(file, fileName); err != nil {
+				log.Errorf("share: failed saving file %s", sanitize.Log(filepath.Base(file.Filename)))
+				_ = os.RemoveAll(p)
+				AbortBadRequest(c)
+				return
+			}
+
+			uploads = append(uploads, fileName)
+		}
+
+		if !conf.UploadNSFW() && ContainsNSFW(uploads) {
+			_ = os.RemoveAll(p)
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
+		}
+
+		log.Infof("share: %d files uploaded by guest to %s", len(uploads), sanitize.Log(link.ShareUID))
+
+		if link.LinkReview {
+			event.Publish("notify.upload", event.Data{"uid": link.ShareUID, "count": len(uploads)})
+			c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgUploadsPending, len(uploads)))
+			return
+		}
+
+		approveShareUploads(conf, dir, link.ShareUID, uploads)
+
+		elapsed := int(time.Since(start).Seconds())
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgFilesUploadedIn, len(uploads), elapsed))
+	})
+}
+
+// UploadToAlbum lets album contributors upload their own files, which are imported into the album
+// in the background without review.
+//
+// POST /api/v1/albums/:uid/upload
+func UploadToAlbum(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/upload", func(c *gin.Context) {
+		conf := service.Config()
+
+		if conf.ReadOnly() || !conf.Settings().Features.Upload {
+			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+			return
+		}
+
+		uid := sanitize.IdString(c.Param("uid"))
+
+		s, _ := AuthAlbum(SessionID(c), uid, acl.ResourceAlbums, acl.ActionUpload)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if a, err := query.AlbumByUID(uid); err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		} else if a.AlbumLocked {
+			AbortLocked(c)
+			return
+		}
+
+		// Refuse uploads that would use up the storage reserve.
+		if err := conf.CheckStorage(conf.StoragePath(), c.Request.ContentLength); err != nil {
+			AbortStorageFull(c)
+			return
+		}
+
+		f, err := c.MultipartForm()
+
+		if err != nil {
+			log.Errorf("album: %s (upload)", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		files := f.File["files"]
+
+		if len(files) == 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Only media files are accepted.
+		for _, file := range files {
+			if !fs.IsMedia(file.Filename) {
+				Abort(c, http.StatusUnsupportedMediaType, i18n.ErrUnsupportedType)
+				return
+			}
+		}
+
+		start := time.Now()
+		p := filepath.Join(conf.ShareApprovedPath(uid), rnd.Token(8))
+
+		if err := os.MkdirAll(p, os.ModePerm); err != nil {
+			log.Errorf("album: %s (create upload folder)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		var uploads []string
+
+		for _, file := range files {
+			fileName := filepath.Join(p, filepath.Base(file.Filename))
+
+			if err := c.SaveUploadedFile(file, fileName); err != nil {
+				log.Errorf("album: failed saving file %s", sanitize.Log(filepath.Base(file.Filename)))
+				_ = os.RemoveAll(p)
+				AbortBadRequest(c)
+				return
+			}
+
+			uploads = append(uploads, fileName)
+		}
+
+		if !conf.UploadNSFW() && ContainsNSFW(uploads) {
+			_ = os.RemoveAll(p)
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
+		}
+
+		log.Infof("album: %d files uploaded by %s to %s", len(uploads), sanitize.Log(s.User.Username()), sanitize.Log(uid))
+
+		workers.StartShareUploads(conf)
+
+		elapsed := int(time.Since(start).Seconds())
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgFilesUploadedIn, len(uploads), elapsed))
+	})
+}
+
+// GetShareUploads returns the guest uploads to an album that are waiting for review.
+//
+// GET /api/v1/albums/:uid/uploads
+func GetShareUploads(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/uploads", func(c *gin.Context) {
+		uid := sanitize.IdString(c.Param("uid"))
+
+		if s, _ := AuthAlbum(SessionID(c), uid, acl.ResourceAlbums, acl.ActionUpdate); s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if _, err := query.AlbumByUID(uid); err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		dir := service.Config().ShareUploadPath(uid)
+		results := make([]ShareUploadFile, 0)
+
+		for _, fileName := range shareUploadFiles(dir, nil) {
+			if info, err := os.Stat(fileName); err == nil {
+				results = append(results, ShareUploadFile{
+					Name:       filepath.ToSlash(fs.RelName(fileName, dir)),
+					Size:       info.Size(),
+					UploadedAt: info.ModTime().UTC(),
+				})
+			}
+		}
+
+		sort.Slice(results, func(i, j int) bool {
+			return results[i].UploadedAt.Before(results[j].UploadedAt)
+		})
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// ApproveShareUploads imports guest uploads into the album, all if no files are selected.
+//
+// POST /api/v1/albums/:uid/uploads
+func ApproveShareUploads(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/uploads", func(c *gin.Context) {
+		uid := sanitize.IdString(c.Param("uid"))
+
+		if s, _ := AuthAlbum(SessionID(c), uid, acl.ResourceAlbums, acl.ActionUpdate); s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		a, err := query.AlbumByUID(uid)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		conf := service.Config()
+		dir := conf.ShareUploadPath(uid)
+		fileNames := shareUploadFiles(dir, f.Files)
+
+		if len(fileNames) == 0 {
+			Abort(c, http.StatusNotFound, i18n.ErrFileNotFound)
+			return
+		}
+
+		approved := approveShareUploads(conf, dir, uid, fileNames)
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgUploadsApproved, approved, a.AlbumTitle))
+	})
+}
+
+// RejectShareUploads deletes guest uploads to the album, all if no files are selected.
+//
+// DELETE /api/v1/albums/:uid/uploads
+func RejectShareUploads(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/uploads", func(c *gin.Context) {
+		uid := sanitize.IdString(c.Param("uid"))
+
+		if s, _ := AuthAlbum(SessionID(c), uid, acl.ResourceAlbums, acl.ActionUpdate); s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		dir := service.Config().ShareUploadPath(uid)
+		rejected := 0
+
+		for _, fileName := range shareUploadFiles(dir, f.Files) {
+			if err := os.Remove(fileName); err != nil {
+				log.Errorf("share: %s (reject upload)", err)
+				continue
+			}
+
+			rejected++
+
+			if d := filepath.Dir(fileName); fs.IsEmpty(d) {
+				_ = os.Remove(d)
+			}
+		}
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgUploadsRejected, rejected))
+	})
+}
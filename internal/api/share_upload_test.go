@@ -0,0 +1,177 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+func TestShareUpload(t *testing.T) {
+	t.Run("InvalidLink", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareUpload(router)
+		r := PerformRequest(app, "POST", "/api/v1/shares/xxx/at9lxuqxpogaaba8/upload")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("UploadsNotAllowed", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareUpload(router)
+		r := PerformRequest(app, "POST", "/api/v1/shares/1jxf3jfn2k/at9lxuqxpogaaba8/upload")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("InvalidPassword", func(t *testing.T) {
+		link := entity.NewLink("at9lxuqxpogaaba8", false, false)
+		link.CanUpload = true
+
+		if err := link.SetPassword("secret"); err != nil {
+			t.Fatal(err)
+		} else if err = link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		app, router, _ := NewApiTest()
+		ShareUpload(router)
+
+		// The password must be checked before the body is parsed, so an empty body doesn't matter.
+		r := PerformRequest(app, "POST", "/api/v1/shares/"+link.LinkToken+"/at9lxuqxpogaaba8/upload")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestShareUploadRateExceeded(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		assert.False(t, shareUploadRateExceeded("sqn2xpryd1ob7xxx", 3))
+	}
+
+	assert.True(t, shareUploadRateExceeded("sqn2xpryd1ob7xxx", 3))
+	assert.False(t, shareUploadRateExceeded("sqn2xpryd1ob8xxx", 3))
+	assert.True(t, shareUploadRateExceeded("sqn2xpryd1ob9xxx", 0))
+}
+
+func TestUploadToAlbum(t *testing.T) {
+	upload := func(app http.Handler, uid, fileName, sessId string) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		w := multipart.NewWriter(body)
+
+		if fw, err := w.CreateFormFile("files", fileName); err != nil {
+			t.Fatal(err)
+		} else if _, err := fw.Write([]byte("photo")); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		req, _ := http.NewRequest("POST", "/api/v1/albums/"+uid+"/upload", body)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		req.Header.Set("X-Session-ID", sessId)
+
+		r := httptest.NewRecorder()
+		app.ServeHTTP(r, req)
+
+		return r
+	}
+
+	t.Run("Contributor", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		conf.Options().UploadNSFW = true
+		defer func() { conf.Options().UploadNSFW = false }()
+		UploadToAlbum(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+
+		// Keep the upload waiting for import.
+		if err := mutex.MainWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.MainWorker.Stop()
+		defer os.RemoveAll(conf.ShareApprovedPath("at9lxuqxpogaaba8"))
+
+		r := upload(app, "at9lxuqxpogaaba8", "bob.jpg", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		// The upload is imported into the album in the background.
+		matches, _ := filepath.Glob(filepath.Join(conf.ShareApprovedPath("at9lxuqxpogaaba8"), "*", "bob.jpg"))
+		assert.Len(t, matches, 1)
+	})
+	t.Run("Viewer", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		UploadToAlbum(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+
+		r := upload(app, "at9lxuqxpogaaba9", "bob.jpg", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("UnsupportedType", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UploadToAlbum(router)
+
+		r := upload(app, "at9lxuqxpogaaba8", "bob.txt", "")
+		assert.Equal(t, http.StatusUnsupportedMediaType, r.Code)
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UploadToAlbum(router)
+
+		r := upload(app, "at9lxuqxpogaabxxx", "bob.jpg", "")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetShareUploads(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetShareUploads(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/uploads")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetShareUploads(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/xxx/uploads")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestApproveShareUploads(t *testing.T) {
+	t.Run("NothingToApprove", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ApproveShareUploads(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/uploads", `{"files": ["../../originals/foo.jpg"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ApproveShareUploads(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/uploads", `{"files": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestRejectShareUploads(t *testing.T) {
+	t.Run("NothingToReject", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RejectShareUploads(router)
+		r := PerformRequestWithBody(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba8/uploads", `{"files": []}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "0 uploads rejected")
+	})
+}
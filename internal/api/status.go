@@ -4,11 +4,31 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/service"
 )
 
+// GetStatus returns the server status, including the disk usage of storage volumes for admins.
+//
 // GET /api/v1/status
 func GetStatus(router *gin.RouterGroup) {
 	router.GET("/status", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "operational"})
+		result := gin.H{"status": "operational"}
+
+		if mutex.Maintenance.Enabled() {
+			result["status"] = "maintenance"
+		}
+
+		// Don't expose details to anonymous clients like health checks, or to guests.
+		if s := Auth(SessionID(c), acl.ResourceConfigOptions, acl.ActionRead); !s.Invalid() {
+			conf := service.Config()
+			result["storage"] = conf.StorageVolumes()
+			result["storageReserve"] = conf.StorageReserve()
+			result["maintenance"] = MaintenanceStatus()
+		}
+
+		c.JSON(http.StatusOK, result)
 	})
 }
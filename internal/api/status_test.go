@@ -6,6 +6,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 )
 
 func TestGetStatus(t *testing.T) {
@@ -17,4 +21,25 @@ func TestGetStatus(t *testing.T) {
 		assert.Equal(t, "operational", val.String())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("Storage", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetStatus(router)
+		r := PerformRequest(app, "GET", "/api/v1/status")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "originals", gjson.Get(r.Body.String(), "storage.0.name").String())
+		assert.Greater(t, gjson.Get(r.Body.String(), "storage.0.total").Int(), int64(0))
+	})
+	t.Run("Guest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetStatus(router)
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba8"}})
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/status", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "operational", gjson.Get(r.Body.String(), "status").String())
+		assert.False(t, gjson.Get(r.Body.String(), "storage").Exists())
+		assert.False(t, gjson.Get(r.Body.String(), "maintenance").Exists())
+	})
 }
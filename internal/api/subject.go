@@ -13,7 +13,6 @@ import (
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/mutex"
-	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // GetSubject returns a subject as JSON.
@@ -64,6 +63,8 @@ func UpdateSubject(router *gin.RouterGroup) {
 			return
 		}
 
+		prevName := m.SubjName
+
 		// Initialize form.
 		f, err := form.NewSubject(*m)
 
@@ -90,6 +91,11 @@ func UpdateSubject(router *gin.RouterGroup) {
 			}
 		}
 
+		// Record renamed people in the activity feed.
+		if m.IsPerson() && m.SubjName != prevName {
+			event.Publish("activity.person", event.Data{"uid": m.SubjUID, "name": m.SubjName, "user": s.User.UserUID})
+		}
+
 		c.JSON(http.StatusOK, m)
 	})
 }
@@ -118,7 +124,7 @@ func LikeSubject(router *gin.RouterGroup) {
 		}
 
 		if err := subj.Update("SubjFavorite", true); err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -152,7 +158,7 @@ func DislikeSubject(router *gin.RouterGroup) {
 		}
 
 		if err := subj.Update("SubjFavorite", false); err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			AbortError(c, http.StatusInternalServerError, err)
 			return
 		}
 
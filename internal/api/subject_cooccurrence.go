@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetSubjectCooccurrence returns the people who appear in the same photos as a subject, and how often.
+//
+// GET /api/v1/subjects/:uid/cooccurrence
+//
+// Parameters:
+//   count: int Max number of results (optional)
+func GetSubjectCooccurrence(router *gin.RouterGroup) {
+	router.GET("/subjects/:uid/cooccurrence", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceSubjects, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		subj := entity.FindSubject(sanitize.IdString(c.Param("uid")))
+
+		if subj == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+
+		if resp, err := query.SubjectCooccurrences(subj.SubjUID, limit); err != nil {
+			AbortError(c, http.StatusBadRequest, err)
+			return
+		} else {
+			AddCountHeader(c, len(resp))
+			AddLimitHeader(c, limit)
+
+			c.JSON(http.StatusOK, resp)
+		}
+	})
+}
+
+// GetPeopleCooccurrence returns each pair of people who appear in the same photos, and how often,
+// e.g. for drawing relationship graphs.
+//
+// GET /api/v1/people/cooccurrence
+//
+// Parameters:
+//   count: int Max number of results (optional)
+func GetPeopleCooccurrence(router *gin.RouterGroup) {
+	router.GET("/people/cooccurrence", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceSubjects, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+
+		if resp, err := query.SubjectCooccurrences("", limit); err != nil {
+			AbortError(c, http.StatusBadRequest, err)
+			return
+		} else {
+			AddCountHeader(c, len(resp))
+			AddLimitHeader(c, limit)
+
+			c.JSON(http.StatusOK, resp)
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetSubjectCooccurrence(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSubjectCooccurrence(router)
+		r := PerformRequest(app, "GET", "/api/v1/subjects/jqy1y111h1njaaac/cooccurrence?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Parse(r.Body.String()).IsArray())
+
+		// Other tests may change face markers, so only the subject UIDs are checked.
+		for _, uid := range gjson.Get(r.Body.String(), "#.SubjUID").Array() {
+			assert.Equal(t, "jqy1y111h1njaaac", uid.String())
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSubjectCooccurrence(router)
+		r := PerformRequest(app, "GET", "/api/v1/subjects/xxx1y111h1njaaaa/cooccurrence")
+		assert.Equal(t, "Subject not found", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetPeopleCooccurrence(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetPeopleCooccurrence(router)
+	r := PerformRequest(app, "GET", "/api/v1/people/cooccurrence")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.True(t, gjson.Parse(r.Body.String()).IsArray())
+}
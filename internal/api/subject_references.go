@@ -0,0 +1,200 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// findPeopleSubject returns the subject matching the api request if the user may update it.
+func findPeopleSubject(c *gin.Context) *entity.Subject {
+	s := Auth(SessionID(c), acl.ResourceSubjects, acl.ActionUpdate)
+
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return nil
+	}
+
+	if !service.Config().Settings().Features.People {
+		AbortFeatureDisabled(c)
+		return nil
+	}
+
+	subj := entity.FindSubject(sanitize.IdString(c.Param("uid")))
+
+	if subj == nil {
+		Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+		return nil
+	}
+
+	return subj
+}
+
+// ProposeSubjectFaces finds unassigned faces that match uploaded reference portraits of a subject.
+//
+// POST /api/v1/subjects/:uid/references
+//
+// Parameters:
+//   uid: string Subject UID
+//   dist: float Max distance between reference and proposed faces (optional)
+func ProposeSubjectFaces(router *gin.RouterGroup) {
+	router.POST("/subjects/:uid/references", func(c *gin.Context) {
+		subj := findPeopleSubject(c)
+
+		if subj == nil {
+			return
+		}
+
+		dist := face.ClusterDist
+
+		if s := c.Query("dist"); s == "" {
+			// Use default.
+		} else if d, err := strconv.ParseFloat(s, 64); err != nil || d <= 0 {
+			AbortBadRequest(c)
+			return
+		} else {
+			dist = d
+		}
+
+		f, err := c.MultipartForm()
+
+		if err != nil {
+			log.Errorf("faces: %s (references)", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		files := f.File["files"]
+
+		if len(files) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		conf := service.Config()
+		p := filepath.Join(conf.TempPath(), "references", rnd.Token(8))
+
+		if err := os.MkdirAll(p, os.ModePerm); err != nil {
+			log.Errorf("faces: failed creating folder for references")
+			AbortSaveFailed(c)
+			return
+		}
+
+		defer func() {
+			if err := os.RemoveAll(p); err != nil {
+				log.Warnf("faces: %s (remove references)", err)
+			}
+		}()
+
+		var fileNames []string
+
+		for _, file := range files {
+			fileName := filepath.Join(p, sanitize.FileName(filepath.Base(file.Filename)))
+
+			if err := c.SaveUploadedFile(file, fileName); err != nil {
+				log.Errorf("faces: failed saving reference %s", sanitize.Log(filepath.Base(file.Filename)))
+				AbortBadRequest(c)
+				return
+			}
+
+			fileNames = append(fileNames, fileName)
+		}
+
+		references, err := service.Faces().References(service.FaceNet(), fileNames)
+
+		if err != nil {
+			log.Errorf("faces: %s (references)", err)
+			AbortFeatureDisabled(c)
+			return
+		} else if references.Empty() {
+			Abort(c, http.StatusNotFound, i18n.ErrNoFacesFound)
+			return
+		}
+
+		proposals, err := service.Faces().Propose(references, dist)
+
+		if err != nil {
+			log.Errorf("faces: %s (propose)", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		log.Infof("faces: found %d matching faces for %s", len(proposals), sanitize.Log(subj.SubjName))
+
+		c.JSON(http.StatusOK, gin.H{"UID": subj.SubjUID, "References": len(references), "Dist": dist, "Markers": proposals})
+	})
+}
+
+// AssignSubjectFaces assigns the reviewed face proposals to a subject.
+//
+// POST /api/v1/subjects/:uid/references/confirm
+//
+// Parameters:
+//   uid: string Subject UID
+func AssignSubjectFaces(router *gin.RouterGroup) {
+	router.POST("/subjects/:uid/references/confirm", func(c *gin.Context) {
+		subj := findPeopleSubject(c)
+
+		if subj == nil {
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Markers) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		if err := mutex.People.Start(); err != nil {
+			AbortBusy(c)
+			return
+		}
+
+		defer mutex.People.Stop()
+
+		assigned, err := service.Faces().Assign(subj, f.Markers)
+
+		if err != nil {
+			log.Errorf("faces: %s (assign)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		if assigned > 0 {
+			if err := query.UpdateSubjectCovers(); err != nil {
+				log.Errorf("faces: %s (update covers)", err)
+			}
+
+			if err := entity.UpdateSubjectCounts(); err != nil {
+				log.Errorf("faces: %s (update counts)", err)
+			}
+
+			PublishSubjectEvent(EntityUpdated, subj.SubjUID, c)
+		}
+
+		event.SuccessMsg(i18n.MsgFacesAssignedTo, assigned, subj.SubjName)
+
+		c.JSON(http.StatusOK, gin.H{"UID": subj.SubjUID, "Assigned": assigned})
+	})
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+func TestProposeSubjectFaces(t *testing.T) {
+	t.Run("InvalidSubject", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ProposeSubjectFaces(router)
+		r := PerformRequest(app, "POST", "/api/v1/subjects/xxx1y111h1njaaaa/references")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, "Subject not found", val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidDist", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ProposeSubjectFaces(router)
+		r := PerformRequest(app, "POST", "/api/v1/subjects/jqu0xs11qekk9jx8/references?dist=foo")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NoFiles", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ProposeSubjectFaces(router)
+		r := PerformRequest(app, "POST", "/api/v1/subjects/jqu0xs11qekk9jx8/references")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestAssignSubjectFaces(t *testing.T) {
+	t.Run("InvalidSubject", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AssignSubjectFaces(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/subjects/xxx1y111h1njaaaa/references/confirm", `{"markers": ["mt9k3pw1wowuy222"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NoMarkers", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AssignSubjectFaces(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/subjects/jqu0xs11qekk9jx8/references/confirm", `{"markers": []}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, "No items selected", val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("SkippedMarkers", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AssignSubjectFaces(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/subjects/jqu0xs11qekk9jx8/references/confirm", `{"markers": ["mt9k3pw1wowuy111"]}`)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "Assigned").Int())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("GuestWhileBusy", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		AssignSubjectFaces(router)
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba8"}})
+
+		if err := mutex.People.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.People.Stop()
+
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/subjects/jqu0xs11qekk9jx8/references/confirm", `{"markers": ["mt9k3pw1wowuy111"]}`, sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("Busy", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AssignSubjectFaces(router)
+
+		if err := mutex.People.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.People.Stop()
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/subjects/jqu0xs11qekk9jx8/references/confirm", `{"markers": ["mt9k3pw1wowuy111"]}`)
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+	})
+}
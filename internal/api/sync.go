@@ -0,0 +1,356 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// SyncHashLimit is the maximum number of file hashes per sync request.
+const SyncHashLimit = 1000
+
+// Sync clients such as mobile auto-upload apps use the following steps to upload new files:
+//
+// 1. POST /api/v1/sync/check with the SHA1 hashes of local files returns the hashes that are missing.
+// 2. POST /api/v1/sync/upload/:hash uploads each missing file as multipart form field "file".
+// 3. POST /api/v1/sync/confirm with the hashes of the uploaded files imports them into originals.
+//
+// Uploaded files that are not confirmed within workers.SyncUploadExpires are removed by the garbage collection worker.
+
+// syncPath returns the path where uploaded files are kept until the user confirms them.
+func syncPath(conf *config.Config, userUID string) string {
+	return filepath.Join(conf.SyncUploadPath(), sanitize.Token(userUID))
+}
+
+// syncResult returns the found and missing hashes in the same order as requested.
+func syncResult(hashes []string) (found, missing []string, err error) {
+	found, missing = []string{}, []string{}
+
+	known, err := query.KnownFileHashes(hashes)
+
+	if err != nil {
+		return found, missing, err
+	}
+
+	exists := make(map[string]bool, len(known))
+
+	for _, h := range known {
+		exists[h] = true
+	}
+
+	for _, h := range hashes {
+		if exists[h] {
+			found = append(found, h)
+		} else {
+			missing = append(missing, h)
+		}
+	}
+
+	return found, missing, nil
+}
+
+// SyncCheck returns which of the file hashes sent by the client already exist,
+// so that only missing files need to be uploaded.
+//
+// POST /api/v1/sync/check
+func SyncCheck(router *gin.RouterGroup) {
+	router.POST("/sync/check", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpload)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if conf.ReadOnly() || !conf.Settings().Features.Upload {
+			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+			return
+		}
+
+		var f form.SyncFiles
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		hashes := f.FileHashes()
+
+		if len(hashes) > SyncHashLimit {
+			AbortBadRequest(c)
+			return
+		}
+
+		found, missing, err := syncResult(hashes)
+
+		if err != nil {
+			log.Errorf("sync: %s (check hashes)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "found": found, "missing": missing})
+	})
+}
+
+// SyncUpload saves an uploaded file until it is confirmed by the client, provided that
+// its SHA1 hash matches the hash in the request path.
+//
+// POST /api/v1/sync/upload/:hash
+func SyncUpload(router *gin.RouterGroup) {
+	router.POST("/sync/upload/:hash", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpload)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if conf.ReadOnly() || !conf.Settings().Features.Upload {
+			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+			return
+		}
+
+		fileHash := sanitize.Hex(c.Param("hash"))
+
+		if len(fileHash) != form.SyncHashLength {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Skip files that already exist.
+		if found, _, err := syncResult([]string{fileHash}); err != nil {
+			log.Errorf("sync: %s (check hash)", err)
+			AbortUnexpected(c)
+			return
+		} else if len(found) > 0 {
+			c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "hash": fileHash, "status": "exists"})
+			return
+		}
+
+		// Refuse uploads that would use up the storage reserve.
+		if err := conf.CheckStorage(conf.TempPath(), c.Request.ContentLength); err != nil {
+			AbortStorageFull(c)
+			return
+		}
+
+		file, err := c.FormFile("file")
+
+		if err != nil {
+			log.Errorf("sync: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		baseName := sanitize.FileName(filepath.Base(file.Filename))
+
+		if baseName == "" || baseName == "." {
+			AbortBadRequest(c)
+			return
+		}
+
+		uploadPath := filepath.Join(syncPath(conf, s.User.UserUID), fileHash)
+
+		if err := os.MkdirAll(uploadPath, os.ModePerm); err != nil {
+			log.Errorf("sync: failed creating folder for %s (%s)", sanitize.Log(baseName), err)
+			AbortUnexpected(c)
+			return
+		}
+
+		fileName := filepath.Join(uploadPath, baseName)
+
+		if err := c.SaveUploadedFile(file, fileName); err != nil {
+			log.Errorf("sync: failed saving file %s", sanitize.Log(baseName))
+			AbortBadRequest(c)
+			return
+		}
+
+		if h := fs.Hash(fileName); h != fileHash {
+			log.Errorf("sync: %s has hash %s instead of %s", sanitize.Log(baseName), h, fileHash)
+			logWarn("sync", os.RemoveAll(uploadPath))
+			AbortBadRequest(c)
+			return
+		}
+
+		if !conf.UploadNSFW() && ContainsNSFW([]string{fileName}) {
+			logWarn("sync", os.RemoveAll(uploadPath))
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
+		}
+
+		log.Debugf("sync: saved %s", sanitize.Log(baseName))
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "hash": fileHash, "status": "uploaded"})
+	})
+}
+
+// syncImport imports confirmed files in the background. Files that could not be imported, e.g. because
+// another import was started in the meantime, are moved back so that they can be confirmed again.
+func syncImport(userPath, importPath string, albums []string, userUID string) {
+	start := time.Now()
+
+	opt := photoprism.ImportOptionsMove(importPath)
+	opt.Albums = albums
+
+	service.Import().Start(opt)
+
+	restored := 0
+
+	if dirs, err := filepath.Glob(filepath.Join(importPath, "*")); err == nil {
+		for _, dir := range dirs {
+			if fs.IsEmpty(dir) {
+				continue
+			} else if err := os.Rename(dir, filepath.Join(userPath, filepath.Base(dir))); err != nil {
+				log.Errorf("sync: failed moving %s back to upload folder (%s)", filepath.Base(dir), err)
+			} else {
+				restored++
+			}
+		}
+	}
+
+	if restored > 0 {
+		log.Warnf("sync: %d confirmed files were not imported and must be confirmed again", restored)
+	}
+
+	logWarn("sync", os.RemoveAll(importPath))
+
+	if err := service.Moments().Start(); err != nil {
+		log.Warnf("moments: %s", err)
+	}
+
+	elapsed := int(time.Since(start).Seconds())
+
+	event.Publish("import.completed", event.Data{"path": importPath, "seconds": elapsed, "user": userUID})
+	event.Publish("index.completed", event.Data{"path": importPath, "seconds": elapsed})
+
+	for _, uid := range albums {
+		if result, err := search.Albums(form.SearchAlbums{UID: uid}); err != nil {
+			log.Warnf("sync: %s (update album)", err)
+		} else {
+			event.PublishEntities("albums", string(EntityUpdated), result)
+		}
+	}
+
+	UpdateClientConfig()
+
+	// Update album, label, and subject cover thumbs.
+	if err := query.UpdateCovers(); err != nil {
+		log.Warnf("index: %s (update covers)", err)
+	}
+}
+
+// SyncConfirm starts importing uploaded files in the background and returns which of the confirmed
+// hashes exist, and which are pending. It fails with status 423 if another import is running.
+//
+// POST /api/v1/sync/confirm
+func SyncConfirm(router *gin.RouterGroup) {
+	router.POST("/sync/confirm", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpload)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if conf.ReadOnly() || !conf.Settings().Features.Upload {
+			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+			return
+		}
+
+		var f form.SyncFiles
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		hashes := f.FileHashes()
+
+		if len(hashes) == 0 || len(hashes) > SyncHashLimit {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Keep the uploaded files and let the client try again if another import or index operation is running.
+		if mutex.MainWorker.Busy() {
+			AbortBusy(c)
+			return
+		}
+
+		imp := service.Import()
+
+		if err := imp.CheckStorage(photoprism.ImportOptionsMove(conf.OriginalsPath())); errors.Is(err, config.ErrStorageFull) {
+			AbortStorageFull(c)
+			return
+		}
+
+		userPath := syncPath(conf, s.User.UserUID)
+		importPath := filepath.Join(userPath, rnd.UUID())
+
+		// Move confirmed files to a separate folder, so that they are imported only once.
+		var pending []string
+
+		for _, h := range hashes {
+			src := filepath.Join(userPath, h)
+
+			if !fs.PathExists(src) {
+				continue
+			} else if err := os.MkdirAll(importPath, os.ModePerm); err != nil {
+				log.Errorf("sync: failed creating import folder (%s)", err)
+				AbortUnexpected(c)
+				return
+			} else if err := os.Rename(src, filepath.Join(importPath, h)); err != nil {
+				log.Errorf("sync: failed moving %s to import folder (%s)", h, err)
+				continue
+			}
+
+			pending = append(pending, h)
+		}
+
+		if len(pending) > 0 {
+			go syncImport(userPath, importPath, f.Albums, s.User.UserUID)
+		}
+
+		found, missing, err := syncResult(hashes)
+
+		if err != nil {
+			log.Errorf("sync: %s (check hashes)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		log.Infof("sync: found %d of %d confirmed files, importing %d", len(found), len(hashes), len(pending))
+
+		if len(pending) == 0 {
+			c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "found": found, "missing": missing, "pending": []string{}})
+			return
+		}
+
+		// Pending files are still missing until they have been imported in the background.
+		c.JSON(http.StatusAccepted, gin.H{"code": http.StatusAccepted, "found": found, "missing": missing, "pending": pending})
+	})
+}
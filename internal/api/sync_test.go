@@ -0,0 +1,121 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+func TestSyncCheck(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SyncCheck(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/sync/check", `{"hashes": ["ACAD9168FA6ACC5C5C2965DDF6EC465CA42FD818", "0000000000000000000000000000000000000000", "xyz"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, `["acad9168fa6acc5c5c2965ddf6ec465ca42fd818"]`, gjson.Get(r.Body.String(), "found").Raw)
+		assert.Equal(t, `["0000000000000000000000000000000000000000"]`, gjson.Get(r.Body.String(), "missing").Raw)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SyncCheck(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/sync/check", `{"hashes": []}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, `[]`, gjson.Get(r.Body.String(), "found").Raw)
+		assert.Equal(t, `[]`, gjson.Get(r.Body.String(), "missing").Raw)
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SyncCheck(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/sync/check", `{"hashes": "xyz"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestSyncUpload(t *testing.T) {
+	upload := func(app http.Handler, hash string, data []byte) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		w := multipart.NewWriter(body)
+
+		if fw, err := w.CreateFormFile("file", "photo.jpg"); err != nil {
+			t.Fatal(err)
+		} else if _, err := fw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		req, _ := http.NewRequest("POST", "/api/v1/sync/upload/"+hash, body)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+
+		r := httptest.NewRecorder()
+		app.ServeHTTP(r, req)
+
+		return r
+	}
+
+	t.Run("Exists", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SyncUpload(router)
+		r := upload(app, "acad9168fa6acc5c5c2965ddf6ec465ca42fd818", []byte("photo"))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "exists", gjson.Get(r.Body.String(), "status").String())
+	})
+	t.Run("HashMismatch", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SyncUpload(router)
+		r := upload(app, "0000000000000000000000000000000000000000", []byte("photo"))
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SyncUpload(router)
+		r := upload(app, "xyz", []byte("photo"))
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NoFile", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SyncUpload(router)
+		r := PerformRequest(app, "POST", "/api/v1/sync/upload/0000000000000000000000000000000000000000")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestSyncConfirm(t *testing.T) {
+	t.Run("NotUploaded", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SyncConfirm(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/sync/confirm", `{"hashes": ["acad9168fa6acc5c5c2965ddf6ec465ca42fd818", "0000000000000000000000000000000000000000"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, `["acad9168fa6acc5c5c2965ddf6ec465ca42fd818"]`, gjson.Get(r.Body.String(), "found").Raw)
+		assert.Equal(t, `["0000000000000000000000000000000000000000"]`, gjson.Get(r.Body.String(), "missing").Raw)
+		assert.Equal(t, `[]`, gjson.Get(r.Body.String(), "pending").Raw)
+	})
+	t.Run("Busy", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SyncConfirm(router)
+
+		if err := mutex.MainWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.MainWorker.Stop()
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/sync/confirm", `{"hashes": ["0000000000000000000000000000000000000000"]}`)
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+	})
+	t.Run("NoHashes", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SyncConfirm(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/sync/confirm", `{"hashes": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
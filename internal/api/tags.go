@@ -0,0 +1,266 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// GetTags returns all personal tags sorted by name, including their photo count.
+//
+// GET /api/v1/tags
+func GetTags(router *gin.RouterGroup) {
+	router.GET("/tags", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceTags, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		results, err := query.Tags()
+
+		if err != nil {
+			log.Errorf("tags: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(results))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// CreateTag adds a new personal tag, or returns the existing tag with the same name.
+//
+// POST /api/v1/tags
+func CreateTag(router *gin.RouterGroup) {
+	router.POST("/tags", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceTags, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Tag
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.NewTag(f.TagName)
+
+		if err := m.Validate(); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		result := entity.FirstOrCreateTag(m)
+
+		if result == nil {
+			AbortSaveFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// UpdateTag renames a personal tag.
+//
+// PUT /api/v1/tags/:uid
+func UpdateTag(router *gin.RouterGroup) {
+	router.PUT("/tags/:uid", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceTags, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Tag
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.FindTag(sanitize.IdString(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Rename(f.TagName); err != nil {
+			log.Errorf("tags: %s (rename)", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteTag removes a personal tag from all photos and deletes it.
+//
+// DELETE /api/v1/tags/:uid
+func DeleteTag(router *gin.RouterGroup) {
+	router.DELETE("/tags/:uid", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceTags, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m := entity.FindTag(sanitize.IdString(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("tags: %s (delete)", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// batchTags returns the selected tags, names of tags that don't exist yet are created if create is true.
+func batchTags(names []string, create bool) (tags entity.Tags) {
+	found := make(map[string]bool)
+
+	for _, name := range names {
+		var m *entity.Tag
+
+		if create {
+			if m = entity.FindTag(name); m == nil {
+				m = entity.FirstOrCreateTag(entity.NewTag(name))
+			}
+		} else {
+			m = entity.FindTag(name)
+		}
+
+		if m != nil && !found[m.TagUID] {
+			found[m.TagUID] = true
+			tags = append(tags, *m)
+		}
+	}
+
+	return tags
+}
+
+// BatchTagsAdd adds personal tags to multiple photos, tags are created if they don't exist yet.
+//
+// POST /api/v1/batch/tags/add
+func BatchTagsAdd(router *gin.RouterGroup) {
+	router.POST("/batch/tags/add", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Tags) == 0 || len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		} else if !unlockedPhotos(c, &f) {
+			return
+		}
+
+		log.Infof("tags: adding %s", sanitize.Log(f.String()))
+
+		tags := batchTags(f.Tags, true)
+
+		if len(tags) == 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		photos, err := query.PhotoSelection(form.Selection{Photos: f.Photos})
+
+		if err != nil {
+			log.Errorf("tags: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		added, err := entity.AddPhotoTags(tags.UIDs(), photos.UIDs())
+
+		if err != nil {
+			log.Errorf("tags: %s (add to photos)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "tags": tags.UIDs(), "photos": photos.UIDs(), "added": len(added)})
+	})
+}
+
+// BatchTagsRemove removes personal tags from multiple photos.
+//
+// POST /api/v1/batch/tags/remove
+func BatchTagsRemove(router *gin.RouterGroup) {
+	router.POST("/batch/tags/remove", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Tags) == 0 || len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		} else if !unlockedPhotos(c, &f) {
+			return
+		}
+
+		tags := batchTags(f.Tags, false)
+
+		if len(tags) == 0 {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		removed, err := entity.RemovePhotoTags(tags.UIDs(), f.Photos)
+
+		if err != nil {
+			log.Errorf("tags: %s (remove from photos)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "tags": tags.UIDs(), "photos": f.Photos, "removed": removed})
+	})
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+func TestGetTags(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetTags(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/tags")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, gjson.Get(r.Body.String(), "#.Name").String(), "To Print")
+	})
+	t.Run("Guest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetTags(router)
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba8"}})
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/tags", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestTags(t *testing.T) {
+	t.Run("CreateRenameDelete", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateTag(router)
+		UpdateTag(router)
+		DeleteTag(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/tags", `{"Name": "Gift Ideas"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "gift-ideas", gjson.Get(r.Body.String(), "Slug").String())
+
+		uid := gjson.Get(r.Body.String(), "UID").String()
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/tags/"+uid, `{"Name": "Gifts"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Gifts", gjson.Get(r.Body.String(), "Name").String())
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/tags/"+uid, `{"Name": "To Print"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/tags/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/tags/"+uid)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("EmptyName", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateTag(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/tags", `{"Name": "  "}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestBatchTagsAdd(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchTagsAdd(router)
+		BatchTagsRemove(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/tags/add", `{"photos": ["pt9jtdre2lvl0yh7"], "tags": ["Needs Edit", "Wallpaper"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "added").Int())
+		assert.Contains(t, gjson.Get(r.Body.String(), "tags").String(), "tt9lxuqxpogaaba3")
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/batch/tags/remove", `{"photos": ["pt9jtdre2lvl0yh7"], "tags": ["needs-edit", "wallpaper"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "removed").Int())
+	})
+	t.Run("NoPhotos", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchTagsAdd(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/tags/add", `{"photos": [], "tags": ["To Print"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestBatchTagsRemove(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchTagsRemove(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/tags/remove", `{"photos": ["pt9jtdre2lvl0yh7"], "tags": ["unknown-tag"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/tiles"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// TilesCacheTTL is the cache TTL of offline map tiles.
+var TilesCacheTTL MaxAge = 3600 * 24 * 7 // 1 week
+
+// GetTile returns a map tile from an MBTiles file in the tiles path, so that maps work offline.
+//
+// GET /api/v1/tiles/:token/:style/:z/:x/:y
+//
+// Parameters:
+//   style: string Offline map style, i.e. the name of the MBTiles file without extension
+//   z: int Zoom level
+//   x: int Tile column
+//   y: int Tile row, optionally with file extension like 12.png
+func GetTile(router *gin.RouterGroup) {
+	router.GET("/tiles/:token/:style/:z/:x/:y", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			AbortUnauthorized(c)
+			return
+		}
+
+		fileName := service.Config().TilesFile(c.Param("style"))
+
+		if fileName == "" {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		y := strings.TrimSuffix(c.Param("y"), filepath.Ext(c.Param("y")))
+
+		zoom, errZ := strconv.Atoi(c.Param("z"))
+		col, errX := strconv.Atoi(c.Param("x"))
+		row, errY := strconv.Atoi(y)
+
+		if errZ != nil || errX != nil || errY != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m, err := tiles.Get(fileName)
+
+		if err != nil {
+			log.Errorf("tiles: %s", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		data, err := m.Tile(zoom, col, row)
+
+		if err != nil {
+			log.Debugf("tiles: %s in %s", err, sanitize.Log(filepath.Base(fileName)))
+			AbortBadRequest(c)
+			return
+		}
+
+		AddCacheHeader(c, TilesCacheTTL)
+
+		// Empty tiles are not stored in MBTiles files.
+		if len(data) == 0 {
+			c.Status(http.StatusNoContent)
+			return
+		}
+
+		if tiles.Gzipped(data) {
+			c.Header("Content-Encoding", "gzip")
+		}
+
+		c.Data(http.StatusOK, m.ContentType(), data)
+	})
+}
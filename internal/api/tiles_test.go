@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/tiles"
+)
+
+func TestGetTile(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetTile(router)
+
+	dir := t.TempDir()
+	db, err := gorm.Open("sqlite3", filepath.Join(dir, "osm.mbtiles"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, stmt := range []string{
+		"CREATE TABLE metadata (name TEXT, value TEXT)",
+		"CREATE TABLE tiles (zoom_level INTEGER, tile_column INTEGER, tile_row INTEGER, tile_data BLOB)",
+		"INSERT INTO metadata VALUES ('format', 'png')",
+		"INSERT INTO tiles VALUES (0, 0, 0, X'89504E47')",
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_ = db.Close()
+
+	conf.Options().TilesPath = dir
+	defer func() {
+		tiles.CloseAll()
+		conf.Options().TilesPath = ""
+	}()
+
+	t.Run("Found", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/tiles/"+conf.PreviewToken()+"/osm/0/0/0.png")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/png", r.Header().Get("Content-Type"))
+		assert.Equal(t, "\x89PNG", r.Body.String())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/tiles/"+conf.PreviewToken()+"/osm/1/0/0")
+		assert.Equal(t, http.StatusNoContent, r.Code)
+	})
+	t.Run("InvalidCoordinates", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/tiles/"+conf.PreviewToken()+"/osm/0/1/0")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/tiles/"+conf.PreviewToken()+"/osm/a/0/0")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("StyleNotFound", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/tiles/"+conf.PreviewToken()+"/topo/0/0/0")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/tiles/xxx/osm/0/0/0")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// GetTimelapseSequences returns sequences of photos taken at fixed intervals that can be assembled into time-lapse videos.
+//
+// GET /api/v1/timelapses
+//
+// Query:
+//   min:    int    Minimum number of photos per sequence
+//   after:  string Start of the time window as date or RFC 3339 timestamp, 90 days before the end by default
+//   before: string End of the time window as date or RFC 3339 timestamp, now by default
+func GetTimelapseSequences(router *gin.RouterGroup) {
+	router.GET("/timelapses", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceTimelapses, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		minFrames := photoprism.TimelapseMinFrames
+
+		if v := c.Query("min"); v != "" {
+			if n, err := strconv.Atoi(v); err != nil || n < 2 {
+				AbortBadRequest(c)
+				return
+			} else {
+				minFrames = n
+			}
+		}
+
+		before, err := timelapseTime(c.Query("before"), time.Now())
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		after, err := timelapseTime(c.Query("after"), before.Add(-1*photoprism.TimelapseWindow))
+
+		if err != nil || !before.After(after) || before.Sub(after) > photoprism.TimelapseMaxWindow {
+			AbortBadRequest(c)
+			return
+		}
+
+		sequences, err := photoprism.NewTimelapse(service.Config(), service.Index()).Sequences(minFrames, after, before)
+
+		if err != nil {
+			log.Errorf("timelapse: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, sequences)
+	})
+}
+
+// timelapseTime parses a date or RFC 3339 timestamp, and returns the default value if s is empty.
+func timelapseTime(s string, defaultTime time.Time) (time.Time, error) {
+	if s == "" {
+		return defaultTime, nil
+	} else if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
+
+// CreateTimelapse starts assembling photos into a time-lapse video in the background.
+//
+// POST /api/v1/timelapses
+func CreateTimelapse(router *gin.RouterGroup) {
+	router.POST("/timelapses", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceTimelapses, acl.ActionCreate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if conf.DisableFFmpeg() {
+			AbortFeatureDisabled(c)
+			return
+		} else if conf.ReadOnly() {
+			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+			return
+		}
+
+		var f form.Timelapse
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		var photoUIDs []string
+
+		for _, uid := range f.Photos {
+			if uid = sanitize.IdString(uid); uid != "" {
+				photoUIDs = append(photoUIDs, uid)
+			}
+		}
+
+		if len(photoUIDs) < 2 || len(photoUIDs) > photoprism.TimelapseMaxFrames {
+			AbortBadRequest(c)
+			return
+		}
+
+		opt := photoprism.NewTimelapseOptions(f.Fps)
+
+		// The worker is acquired before responding, so that concurrent requests are rejected as busy.
+		err := photoprism.NewTimelapse(conf, service.Index()).Start(photoUIDs, opt, func(uid string, err error) {
+			if err != nil {
+				log.Errorf("timelapse: %s", err)
+				event.Error("Time-lapse could not be created")
+				return
+			}
+
+			event.Publish("timelapse.completed", event.Data{"uid": uid, "user": s.User.UserUID})
+
+			UpdateClientConfig()
+		})
+
+		if err != nil {
+			log.Infof("timelapse: %s", err)
+			AbortBusy(c)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"code": http.StatusAccepted, "photos": len(photoUIDs), "fps": opt.Fps})
+	})
+}
+
+// GetTimelapsePhotos returns the UIDs of the photos a time-lapse video was assembled from in frame order.
+//
+// GET /api/v1/timelapses/:uid
+func GetTimelapsePhotos(router *gin.RouterGroup) {
+	router.GET("/timelapses/:uid", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceTimelapses, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := sanitize.IdString(c.Param("uid"))
+
+		photoUIDs, err := query.TimelapsePhotoUIDs(uid)
+
+		if err != nil {
+			log.Errorf("timelapse: %s", err)
+			AbortUnexpected(c)
+			return
+		} else if len(photoUIDs) == 0 {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"uid": uid, "photos": photoUIDs})
+	})
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+func TestGetTimelapseSequences(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetTimelapseSequences(router)
+		r := PerformRequest(app, "GET", "/api/v1/timelapses?min=2")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("Window", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetTimelapseSequences(router)
+		r := PerformRequest(app, "GET", "/api/v1/timelapses?min=2&after=2020-01-01&before=2020-12-31T23:59:59Z")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("InvalidWindow", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetTimelapseSequences(router)
+		r := PerformRequest(app, "GET", "/api/v1/timelapses?after=2010-01-01&before=2020-01-01")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		r = PerformRequest(app, "GET", "/api/v1/timelapses?after=2020-01-01&before=2019-01-01")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		r = PerformRequest(app, "GET", "/api/v1/timelapses?before=yesterday")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Guest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+
+		GetTimelapseSequences(router)
+		GetTimelapsePhotos(router)
+
+		sessId := service.Session().Create(session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba8"}})
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/timelapses", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		r = AuthenticatedRequest(app, "GET", "/api/v1/timelapses/pt9jtdre2lvl0y90", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("InvalidMin", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetTimelapseSequences(router)
+		r := PerformRequest(app, "GET", "/api/v1/timelapses?min=1")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestCreateTimelapse(t *testing.T) {
+	t.Run("NotEnoughPhotos", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		if conf.DisableFFmpeg() {
+			t.Skip("ffmpeg is disabled")
+		}
+
+		CreateTimelapse(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/timelapses", `{"photos": ["pt9jtdre2lvl0yh7"], "fps": 25}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		if conf.DisableFFmpeg() {
+			t.Skip("ffmpeg is disabled")
+		}
+
+		CreateTimelapse(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/timelapses", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Busy", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		if conf.DisableFFmpeg() {
+			t.Skip("ffmpeg is disabled")
+		}
+
+		if err := mutex.TimelapseWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.TimelapseWorker.Stop()
+
+		CreateTimelapse(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/timelapses", `{"photos": ["pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"], "fps": 25}`)
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+	})
+}
+
+func TestGetTimelapsePhotos(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetTimelapsePhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/timelapses/pt9jtdre2lvl0y99")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -31,6 +31,12 @@ func Upload(router *gin.RouterGroup) {
 			return
 		}
 
+		// Refuse uploads that would use up the storage reserve.
+		if err := conf.CheckStorage(conf.ImportPath(), c.Request.ContentLength); err != nil {
+			AbortStorageFull(c)
+			return
+		}
+
 		start := time.Now()
 		subPath := sanitize.Path(c.Param("path"))
 
@@ -70,38 +76,15 @@ func Upload(router *gin.RouterGroup) {
 			uploads = append(uploads, filename)
 		}
 
-		if !conf.UploadNSFW() {
-			nd := service.NsfwDetector()
-
-			containsNSFW := false
-
+		if !conf.UploadNSFW() && ContainsNSFW(uploads) {
 			for _, filename := range uploads {
-				labels, err := nd.File(filename)
-
-				if err != nil {
-					log.Debug(err)
-					continue
-				}
-
-				if labels.IsSafe() {
-					continue
+				if err := os.Remove(filename); err != nil {
+					log.Errorf("nsfw: could not delete %s", sanitize.Log(filename))
 				}
-
-				log.Infof("nsfw: %s might be offensive", sanitize.Log(filename))
-
-				containsNSFW = true
 			}
 
-			if containsNSFW {
-				for _, filename := range uploads {
-					if err := os.Remove(filename); err != nil {
-						log.Errorf("nsfw: could not delete %s", sanitize.Log(filename))
-					}
-				}
-
-				Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
-				return
-			}
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
 		}
 
 		elapsed := int(time.Since(start).Seconds())
@@ -113,3 +96,29 @@ func Upload(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
 	})
 }
+
+// ContainsNSFW returns true if any of the uploaded files might be offensive.
+func ContainsNSFW(fileNames []string) bool {
+	nd := service.NsfwDetector()
+
+	containsNSFW := false
+
+	for _, filename := range fileNames {
+		labels, err := nd.File(filename)
+
+		if err != nil {
+			log.Debug(err)
+			continue
+		}
+
+		if labels.IsSafe() {
+			continue
+		}
+
+		log.Infof("nsfw: %s might be offensive", sanitize.Log(filename))
+
+		containsNSFW = true
+	}
+
+	return containsNSFW
+}
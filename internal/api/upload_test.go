@@ -14,4 +14,14 @@ func TestUpload(t *testing.T) {
 		r := PerformRequest(app, "POST", "/api/v1/upload/xxx")
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("StorageFull", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		Upload(router)
+
+		conf.Options().StorageReserve = 1 << 40
+		defer func() { conf.Options().StorageReserve = 0 }()
+
+		r := PerformRequest(app, "POST", "/api/v1/upload/xxx")
+		assert.Equal(t, http.StatusInsufficientStorage, r.Code)
+	})
 }
@@ -33,13 +33,13 @@ func ChangePassword(router *gin.RouterGroup) {
 		uid := sanitize.IdString(c.Param("uid"))
 		m := entity.FindUserByUID(uid)
 
-		if s.User.UserUID != m.UserUID {
-			AbortUnauthorized(c)
+		if m == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
 			return
 		}
 
-		if m == nil {
-			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+		if s.User.UserUID != m.UserUID {
+			AbortUnauthorized(c)
 			return
 		}
 
@@ -97,7 +97,7 @@ func TestChangeUserPasswords(t *testing.T) {
 		}
 	})
 
-	t.Run("friend: change password to same", func(t *testing.T) {
+	t.Run("friend: disabled", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		conf.SetPublic(false)
 		defer conf.SetPublic(true)
@@ -113,7 +113,7 @@ func TestChangeUserPasswords(t *testing.T) {
 		} else {
 			r := AuthenticatedRequestWithBody(app, "PUT", "/api/v1/users/uqxqg7i1kperxvu7/password",
 				string(pwStr), sessId)
-			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, http.StatusUnauthorized, r.Code)
 		}
 	})
 
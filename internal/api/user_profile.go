@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// profileUser returns the user whose profile is requested, if it belongs to the current session.
+func profileUser(c *gin.Context) *entity.User {
+	conf := service.Config()
+
+	if conf.Public() || conf.DisableSettings() {
+		Abort(c, http.StatusForbidden, i18n.ErrPublic)
+		return nil
+	}
+
+	s := Auth(SessionID(c), acl.ResourceUsers, acl.ActionUpdateSelf)
+
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return nil
+	}
+
+	m := entity.FindUserByUID(sanitize.IdString(c.Param("uid")))
+
+	if m == nil {
+		Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+		return nil
+	}
+
+	// Users can only view and change their own profile.
+	if s.User.UserUID != m.UserUID || !m.Registered() {
+		AbortUnauthorized(c)
+		return nil
+	}
+
+	return m
+}
+
+// GetUserProfile returns the profile of the current user.
+//
+// GET /api/v1/users/:uid/profile
+func GetUserProfile(router *gin.RouterGroup) {
+	router.GET("/users/:uid/profile", func(c *gin.Context) {
+		m := profileUser(c)
+
+		if m == nil {
+			return
+		}
+
+		c.JSON(http.StatusOK, m.Profile())
+	})
+}
+
+// UpdateUserProfile changes the display name, avatar, and preferred language and theme of the current user.
+//
+// PUT /api/v1/users/:uid/profile
+func UpdateUserProfile(router *gin.RouterGroup) {
+	router.PUT("/users/:uid/profile", func(c *gin.Context) {
+		m := profileUser(c)
+
+		if m == nil {
+			return
+		}
+
+		f, err := form.NewUserProfile(m.Profile())
+
+		if err != nil {
+			log.Error(err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := m.SaveProfile(f); err != nil {
+			log.Errorf("profile: %s", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrSaveFailed)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgProfileSaved)
+
+		c.JSON(http.StatusOK, m.Profile())
+	})
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetUserProfile(t *testing.T) {
+	t.Run("public mode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUserProfile(router)
+		r := PerformRequest(app, "GET", "/api/v1/users/uqxc08w3d0ej2283/profile")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("bob: own profile", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserProfile(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/users/uqxc08w3d0ej2283/profile", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "bob", gjson.Get(r.Body.String(), "UserName").String())
+	})
+	t.Run("bob: alice's profile", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserProfile(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/profile", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("not existing user", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserProfile(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/users/uqxc08w3d0ej2xxx/profile", sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestUpdateUserProfile(t *testing.T) {
+	t.Run("bob: change profile", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		UpdateUserProfile(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequestWithBody(app, "PUT", "/api/v1/users/uqxc08w3d0ej2283/profile",
+			`{"DisplayName": "Bob", "AvatarUID": "pt9jtdre2lvl0yh7", "Language": "fr", "Theme": "lavender"}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Bob", gjson.Get(r.Body.String(), "DisplayName").String())
+		assert.Equal(t, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", gjson.Get(r.Body.String(), "Avatar").String())
+		assert.Equal(t, "fr", gjson.Get(r.Body.String(), "Language").String())
+		assert.Equal(t, "lavender", gjson.Get(r.Body.String(), "Theme").String())
+
+		// Unchanged values are kept.
+		r = AuthenticatedRequestWithBody(app, "PUT", "/api/v1/users/uqxc08w3d0ej2283/profile", `{"Theme": ""}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", gjson.Get(r.Body.String(), "Avatar").String())
+		assert.Equal(t, "fr", gjson.Get(r.Body.String(), "Language").String())
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "Theme").String())
+	})
+	t.Run("bob: invalid language", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		UpdateUserProfile(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequestWithBody(app, "PUT", "/api/v1/users/uqxc08w3d0ej2283/profile", `{"Language": "xxx"}`, sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("bob: change alice's profile", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		UpdateUserProfile(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequestWithBody(app, "PUT", "/api/v1/users/uqxetse3cy5eo9z2/profile", `{"DisplayName": "Bob"}`, sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+const (
+	ContentTypeHls     = "application/vnd.apple.mpegurl"
+	ContentTypeSegment = "video/mp2t"
+)
+
+// hlsNameRegexp matches the names of playlists and segments in the HLS cache.
+var hlsNameRegexp = regexp.MustCompile(`^([0-9]+/)?(index\.m3u8|segment_[0-9]+\.ts)$`)
+
+// GetVideoHls streams segmented videos for adaptive streaming, if they have been created.
+//
+// GET /api/v1/videos/:hash/:token/hls/*name
+//
+// Parameters:
+//   hash: string The photo or video file hash as returned by the search API
+//   name: string Playlist or segment name, e.g. index.m3u8 or 720/segment_0001.ts
+func GetVideoHls(router *gin.RouterGroup) {
+	router.GET("/videos/:hash/:token/hls/*name", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			AbortUnauthorized(c)
+			return
+		}
+
+		fileHash := sanitize.Token(c.Param("hash"))
+		name := strings.TrimPrefix(c.Param("name"), "/")
+
+		if !hlsNameRegexp.MatchString(name) {
+			AbortBadRequest(c)
+			return
+		}
+
+		f, err := query.FileByHash(fileHash)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if !f.FileVideo {
+			if f, err = query.VideoByPhotoUID(f.PhotoUID); err != nil {
+				AbortEntityNotFound(c)
+				return
+			}
+		}
+
+		fileName := filepath.Join(photoprism.HlsPath(service.Config().VideoPath(), f.FileHash), filepath.FromSlash(name))
+
+		if !fs.FileExists(fileName) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if strings.HasSuffix(name, ".ts") {
+			AddContentTypeHeader(c, ContentTypeSegment)
+		} else {
+			AddContentTypeHeader(c, ContentTypeHls)
+
+			// Mark the segments as recently used so that they are trimmed last.
+			if err := photoprism.TouchVideoCache(photoprism.HlsPath(service.Config().VideoPath(), f.FileHash)); err != nil {
+				log.Debugf("video: %s (touch cache)", err)
+			}
+		}
+
+		c.File(fileName)
+	})
+}
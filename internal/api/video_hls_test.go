@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+func TestGetVideoHls(t *testing.T) {
+	t.Run("Playlist", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideo(router)
+		GetVideoHls(router)
+
+		dir := photoprism.HlsPath(conf.VideoPath(), "acad9168fa6acc5c5c2965ddf6ec465ca42fd832")
+
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		if err := os.WriteFile(filepath.Join(dir, photoprism.HlsPlaylist), []byte("#EXTM3U\n"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/"+conf.PreviewToken()+"/hls/index.m3u8")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, ContentTypeHls, r.Header().Get("Content-Type"))
+		assert.Equal(t, "#EXTM3U\n", r.Body.String())
+	})
+	t.Run("NotSegmented", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoHls(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/"+conf.PreviewToken()+"/hls/720/segment_0001.ts")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidName", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoHls(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/"+conf.PreviewToken()+"/hls/../../secret.m3u8")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetVideoHls(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/xxx/hls/index.m3u8")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// GetVideoSprite returns the sprite sheet of a video for preview scrubbing, if it has been created.
+//
+// GET /api/v1/videos/:hash/:token/sprite/:name
+//
+// Parameters:
+//   hash: string The photo or video file hash as returned by the search API
+//   name: string Either sprite.jpg for the image or sprite.json for its layout
+func GetVideoSprite(router *gin.RouterGroup) {
+	router.GET("/videos/:hash/:token/sprite/:name", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			AbortUnauthorized(c)
+			return
+		}
+
+		fileHash := sanitize.Token(c.Param("hash"))
+		name := c.Param("name")
+
+		if name != photoprism.SpriteImage && name != photoprism.SpriteInfo {
+			AbortBadRequest(c)
+			return
+		}
+
+		f, err := query.FileByHash(fileHash)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if !f.FileVideo {
+			if f, err = query.VideoByPhotoUID(f.PhotoUID); err != nil {
+				AbortEntityNotFound(c)
+				return
+			}
+		}
+
+		fileName := filepath.Join(photoprism.SpritePath(service.Config().VideoPath(), f.FileHash), name)
+
+		if !fs.FileExists(fileName) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Mark the sprite sheet as recently used so that it is trimmed last.
+		if err := photoprism.TouchVideoCache(filepath.Dir(fileName)); err != nil {
+			log.Debugf("video: %s (touch cache)", err)
+		}
+
+		c.File(fileName)
+	})
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+func TestGetVideoSprite(t *testing.T) {
+	t.Run("Info", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideo(router)
+		GetVideoHls(router)
+		GetVideoSprite(router)
+
+		dir := photoprism.SpritePath(conf.VideoPath(), "acad9168fa6acc5c5c2965ddf6ec465ca42fd832")
+
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		if err := os.WriteFile(filepath.Join(dir, photoprism.SpriteInfo), []byte(`{"Columns":10}`), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/"+conf.PreviewToken()+"/sprite/sprite.json")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, `{"Columns":10}`, r.Body.String())
+	})
+	t.Run("NotCreated", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoSprite(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/"+conf.PreviewToken()+"/sprite/sprite.jpg")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidName", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoSprite(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/"+conf.PreviewToken()+"/sprite/secret.jpg")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetVideoSprite(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/xxx/sprite/sprite.jpg")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
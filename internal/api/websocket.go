@@ -23,22 +23,26 @@ var wsConnection = websocket.Upgrader{
 var wsTimeout = 90 * time.Second
 
 type clientInfo struct {
-	SessionToken string `json:"session"`
-	CssUri       string `json:"css"`
-	JsUri        string `json:"js"`
-	Version      string `json:"version"`
+	SessionToken string   `json:"session"`
+	CssUri       string   `json:"css"`
+	JsUri        string   `json:"js"`
+	Version      string   `json:"version"`
+	Topics       []string `json:"topics"`
+	UIDs         []string `json:"uids"`
 }
 
 var wsAuth = struct {
-	user  map[string]entity.User
-	mutex sync.RWMutex
-}{user: make(map[string]entity.User)}
+	user    map[string]entity.User
+	session map[string]string
+	mutex   sync.RWMutex
+}{user: make(map[string]entity.User), session: make(map[string]string)}
 
 // wsReader initializes a websocket reader for receiving messages.
 func wsReader(ws *websocket.Conn, writeMutex *sync.Mutex, connId string, conf *config.Config) {
 	defer ws.Close()
 
-	ws.SetReadLimit(512)
+	// Topic subscriptions may include a list of entity UIDs.
+	ws.SetReadLimit(4096)
 
 	if err := ws.SetReadDeadline(time.Now().Add(wsTimeout)); err != nil {
 		return
@@ -58,17 +62,25 @@ func wsReader(ws *websocket.Conn, writeMutex *sync.Mutex, connId string, conf *c
 		if err := json.Unmarshal(m, &info); err != nil {
 			// Do nothing.
 		} else {
+			// Limit events to the subscribed topics and entities, if any.
+			if info.Topics != nil || info.UIDs != nil {
+				wsFilters.mutex.Lock()
+				wsFilters.filter[connId] = newWsFilter(info.Topics, info.UIDs)
+				wsFilters.mutex.Unlock()
+			}
+
 			if sess := Session(info.SessionToken); sess.Valid() {
 				wsAuth.mutex.Lock()
 				wsAuth.user[connId] = sess.User
+				wsAuth.session[connId] = info.SessionToken
 				wsAuth.mutex.Unlock()
 
 				var clientConfig config.ClientConfig
 
 				if sess.User.Guest() {
-					clientConfig = conf.GuestConfig()
+					clientConfig = AccessConfig(info.SessionToken, conf.GuestConfig())
 				} else if sess.User.Registered() {
-					clientConfig = conf.UserConfig()
+					clientConfig = AccessConfig(info.SessionToken, conf.UserConfig())
 				} else {
 					clientConfig = conf.PublicConfig()
 				}
@@ -109,6 +121,8 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 		"subjects.*",
 		"people.*",
 		"sync.*",
+		"download.*",
+		"slideshow.*",
 	)
 
 	defer func() {
@@ -118,7 +132,12 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 
 		wsAuth.mutex.Lock()
 		wsAuth.user[connId] = entity.UnknownUser
+		delete(wsAuth.session, connId)
 		wsAuth.mutex.Unlock()
+
+		wsFilters.mutex.Lock()
+		delete(wsFilters.filter, connId)
+		wsFilters.mutex.Unlock()
 	}()
 
 	for {
@@ -144,15 +163,21 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 				user = hit
 			}
 
+			sessionID := wsAuth.session[connId]
+
 			wsAuth.mutex.RUnlock()
 
-			if user.Registered() {
+			wsFilters.mutex.RLock()
+			filter := wsFilters.filter[connId]
+			wsFilters.mutex.RUnlock()
+
+			if user.Registered() && wsRecipient(user, sessionID, msg) && filter.Match(msg) {
 				writeMutex.Lock()
 
 				if err := ws.SetWriteDeadline(time.Now().Add(30 * time.Second)); err != nil {
 					writeMutex.Unlock()
 					return
-				} else if err := ws.WriteJSON(gin.H{"event": msg.Name, "data": msg.Fields}); err != nil {
+				} else if err := ws.WriteJSON(gin.H{"event": msg.Name, "data": wsData(msg)}); err != nil {
 					writeMutex.Unlock()
 					return
 				}
@@ -163,7 +188,8 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 	}
 }
 
-// Websocket registers websocket request handler.
+// Websocket registers websocket request handler. Clients may send a list of topics and entity UIDs
+// to receive only matching events, e.g. {"topics": ["index.*", "albums.updated"], "uids": ["at9lxuqxpogaaba8"]}.
 //
 // GET /api/v1/ws
 func Websocket(router *gin.RouterGroup) {
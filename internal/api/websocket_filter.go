@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+// wsFilter limits the events sent to a websocket client, see clientInfo.
+type wsFilter struct {
+	Topics []string
+	UIDs   map[string]bool
+}
+
+// wsPrivateEvents are only sent to the user specified in the "user" field, e.g. saved search notifications.
+var wsPrivateEvents = map[string]bool{
+	"notify.search": true,
+}
+
+// wsSessionEvents are only sent to the session specified in the "session" field, e.g. download progress.
+var wsSessionEvents = map[string]bool{
+	"download.progress": true,
+}
+
+// wsRecipient tests if the user may receive the message, private events are only sent to their owner.
+func wsRecipient(user entity.User, sessionID string, msg event.Message) bool {
+	if wsSessionEvents[msg.Name] {
+		id, _ := msg.Fields["session"].(string)
+
+		return id != "" && id == sessionID
+	} else if !wsPrivateEvents[msg.Name] {
+		return true
+	}
+
+	uid, _ := msg.Fields["user"].(string)
+
+	return uid != "" && uid == user.UserUID
+}
+
+// wsData returns the message data sent to clients, without the id of the recipient session.
+func wsData(msg event.Message) event.Data {
+	if _, ok := msg.Fields["session"]; !ok {
+		return msg.Fields
+	}
+
+	data := make(event.Data, len(msg.Fields))
+
+	for k, v := range msg.Fields {
+		if k != "session" {
+			data[k] = v
+		}
+	}
+
+	return data
+}
+
+var wsFilters = struct {
+	filter map[string]wsFilter
+	mutex  sync.RWMutex
+}{filter: make(map[string]wsFilter)}
+
+// newWsFilter returns a new event filter for the topics and entity UIDs. Topics may end with a
+// wildcard, e.g. "index.*". All events are matched if no topics are specified.
+func newWsFilter(topics, uids []string) wsFilter {
+	f := wsFilter{}
+
+	for _, topic := range topics {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			f.Topics = append(f.Topics, strings.ToLower(topic))
+		}
+	}
+
+	for _, uid := range uids {
+		if uid = strings.TrimSpace(uid); uid == "" {
+			continue
+		} else if f.UIDs == nil {
+			f.UIDs = make(map[string]bool, len(uids))
+		}
+
+		f.UIDs[uid] = true
+	}
+
+	return f
+}
+
+// MatchTopic tests if the event name matches the subscribed topics.
+func (f wsFilter) MatchTopic(name string) bool {
+	if len(f.Topics) == 0 {
+		return true
+	}
+
+	name = strings.ToLower(name)
+
+	for _, topic := range f.Topics {
+		if topic == "*" || topic == name {
+			return true
+		} else if strings.HasSuffix(topic, ".*") && strings.HasPrefix(name, topic[:len(topic)-1]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchEntities tests if the message refers to one of the subscribed entity UIDs. Messages
+// without entities are not filtered by UID.
+func (f wsFilter) MatchEntities(msg event.Message) bool {
+	if len(f.UIDs) == 0 {
+		return true
+	}
+
+	entities, ok := msg.Fields["entities"]
+
+	if !ok || entities == nil {
+		return true
+	}
+
+	data, err := json.Marshal(entities)
+
+	if err != nil {
+		return false
+	}
+
+	var values []interface{}
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		return false
+	}
+
+	// Deleted entities are published as a list of UIDs.
+	for _, v := range values {
+		switch e := v.(type) {
+		case string:
+			if f.UIDs[e] {
+				return true
+			}
+		case map[string]interface{}:
+			if uid, ok := e["UID"].(string); ok && f.UIDs[uid] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Match tests if the message should be sent to the client.
+func (f wsFilter) Match(msg event.Message) bool {
+	return f.MatchTopic(msg.Name) && f.MatchEntities(msg)
+}
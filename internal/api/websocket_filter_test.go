@@ -0,0 +1,78 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+func TestWsFilter_MatchTopic(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		f := newWsFilter(nil, nil)
+		assert.True(t, f.MatchTopic("index.folder"))
+		assert.True(t, newWsFilter([]string{"*"}, nil).MatchTopic("albums.updated"))
+	})
+	t.Run("Wildcard", func(t *testing.T) {
+		f := newWsFilter([]string{" Index.* ", ""}, nil)
+		assert.Equal(t, []string{"index.*"}, f.Topics)
+		assert.True(t, f.MatchTopic("index.folder"))
+		assert.False(t, f.MatchTopic("index"))
+		assert.False(t, f.MatchTopic("indexing.folder"))
+		assert.False(t, f.MatchTopic("albums.updated"))
+	})
+	t.Run("Exact", func(t *testing.T) {
+		f := newWsFilter([]string{"albums.updated"}, nil)
+		assert.True(t, f.MatchTopic("albums.updated"))
+		assert.False(t, f.MatchTopic("albums.deleted"))
+	})
+}
+
+func TestWsFilter_Match(t *testing.T) {
+	f := newWsFilter([]string{"albums.*", "index.*"}, []string{"at9lxuqxpogaaba8"})
+
+	t.Run("Entity", func(t *testing.T) {
+		msg := event.Message{Name: "albums.updated", Fields: event.Data{"entities": []map[string]string{{"UID": "at9lxuqxpogaaba8"}}}}
+		assert.True(t, f.Match(msg))
+	})
+	t.Run("OtherEntity", func(t *testing.T) {
+		msg := event.Message{Name: "albums.updated", Fields: event.Data{"entities": []map[string]string{{"UID": "at9lxuqxpogaaba7"}}}}
+		assert.False(t, f.Match(msg))
+	})
+	t.Run("Deleted", func(t *testing.T) {
+		msg := event.Message{Name: "albums.deleted", Fields: event.Data{"entities": []string{"at9lxuqxpogaaba8"}}}
+		assert.True(t, f.Match(msg))
+	})
+	t.Run("NoEntities", func(t *testing.T) {
+		msg := event.Message{Name: "index.folder", Fields: event.Data{"filePath": "2021"}}
+		assert.True(t, f.Match(msg))
+	})
+	t.Run("OtherTopic", func(t *testing.T) {
+		msg := event.Message{Name: "photos.updated", Fields: event.Data{"entities": []string{"at9lxuqxpogaaba8"}}}
+		assert.False(t, f.Match(msg))
+	})
+}
+
+func TestWsRecipient(t *testing.T) {
+	msg := event.Message{Name: "notify.search", Fields: event.Data{"uid": "qt9lxuqxpogaaba1", "user": entity.Admin.UserUID}}
+
+	t.Run("Owner", func(t *testing.T) {
+		assert.True(t, wsRecipient(entity.Admin, "", msg))
+	})
+	t.Run("OtherUser", func(t *testing.T) {
+		assert.False(t, wsRecipient(entity.UserFixtures.Get("bob"), "", msg))
+	})
+	t.Run("PublicEvent", func(t *testing.T) {
+		assert.True(t, wsRecipient(entity.UserFixtures.Get("bob"), "", event.Message{Name: "albums.updated"}))
+	})
+	t.Run("Session", func(t *testing.T) {
+		progress := event.Message{Name: "download.progress", Fields: event.Data{"session": "abc", "filename": "test.zip"}}
+
+		assert.True(t, wsRecipient(entity.Admin, "abc", progress))
+		assert.False(t, wsRecipient(entity.Admin, "xyz", progress))
+		assert.False(t, wsRecipient(entity.Admin, "", progress))
+		assert.Equal(t, event.Data{"filename": "test.zip"}, wsData(progress))
+	})
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// GetWorkers returns the number of workers for each task type.
+//
+// GET /api/v1/workers
+func GetWorkers(router *gin.RouterGroup) {
+	router.GET("/workers", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceConfigOptions, acl.ActionRead)
+		conf := service.Config()
+
+		if s.Invalid() || conf.Public() || conf.DisableSettings() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, conf.AllTaskWorkers())
+	})
+}
+
+// UpdateWorkers changes the number of workers for one or more task types until the server is restarted.
+//
+// PUT /api/v1/workers
+func UpdateWorkers(router *gin.RouterGroup) {
+	router.PUT("/workers", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceConfigOptions, acl.ActionUpdate)
+		conf := service.Config()
+
+		if s.Invalid() || conf.Public() || conf.DisableSettings() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f config.TaskWorkersMap
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		for task, n := range f {
+			if err := conf.SetTaskWorkers(task, n); err != nil {
+				Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+				return
+			}
+
+			log.Infof("config: changed number of %s workers to %d", sanitize.Log(task), conf.TaskWorkers(task))
+		}
+
+		event.InfoMsg(i18n.MsgSettingsSaved)
+
+		c.JSON(http.StatusOK, conf.AllTaskWorkers())
+	})
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWorkers(t *testing.T) {
+	t.Run("unauthorised", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetWorkers(router)
+		r := PerformRequest(app, "GET", "/api/v1/workers")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestUpdateWorkers(t *testing.T) {
+	t.Run("unauthorised", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateWorkers(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/workers", `{"thumbs": 1}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -2,18 +2,23 @@ package api
 
 import (
 	"archive/zip"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"net/http"
 	"os"
-	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
@@ -25,6 +30,80 @@ import (
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
+// ZipExpires specifies how long a created zip download can be started or resumed.
+const ZipExpires = 24 * time.Hour
+
+// zipEntry represents a file in a zip download.
+type zipEntry struct {
+	FileName string
+	Alias    string
+	Size     int64
+	Modified time.Time
+	Temp     bool
+}
+
+// newZipEntry returns a zip entry for the file with the given name and alias.
+func newZipEntry(fileName, alias string) (zipEntry, error) {
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		return zipEntry{}, err
+	} else if info.IsDir() {
+		return zipEntry{}, fmt.Errorf("%s is a folder", sanitize.Log(filepath.Base(fileName)))
+	}
+
+	return zipEntry{FileName: fileName, Alias: alias, Size: info.Size(), Modified: info.ModTime()}, nil
+}
+
+// zipDownload represents a zip archive that is generated on the fly when it is downloaded.
+// Downloads belong to the session that created them, so that other users cannot fetch them.
+type zipDownload struct {
+	SessionID string
+	Entries   []zipEntry
+	FileSize  int64
+	Size      int64
+	ETag      string
+	Created   time.Time
+}
+
+// Add adds a file to the download.
+func (d *zipDownload) Add(e zipEntry) {
+	d.Entries = append(d.Entries, e)
+	d.FileSize += e.Size
+}
+
+// Remove deletes the temporary copies of redacted files.
+func (d *zipDownload) Remove() {
+	for _, e := range d.Entries {
+		if e.Temp {
+			removeRedactedFile(e.FileName)
+		}
+	}
+}
+
+// Finish calculates the archive size and entity tag once all files have been added.
+func (d *zipDownload) Finish() (err error) {
+	if d.Size, err = zipSize(d.Entries); err != nil {
+		return err
+	}
+
+	h := fnv.New64a()
+
+	for _, e := range d.Entries {
+		_, _ = fmt.Fprintf(h, "%s:%d:%d;", e.Alias, e.Size, e.Modified.UnixNano())
+	}
+
+	d.ETag = fmt.Sprintf("\"%x\"", h.Sum64())
+
+	return nil
+}
+
+// zipDownloads contains the zip downloads that have not expired yet.
+var zipDownloads = struct {
+	items map[string]*zipDownload
+	mutex sync.Mutex
+}{items: make(map[string]*zipDownload)}
+
 // POST /api/v1/zip
 func CreateZip(router *gin.RouterGroup) {
 	router.POST("/zip", func(c *gin.Context) {
@@ -65,29 +144,28 @@ func CreateZip(router *gin.RouterGroup) {
 			return
 		}
 
-		zipPath := path.Join(conf.TempPath(), "zip")
 		zipToken := rnd.Token(8)
 		zipBaseName := fmt.Sprintf("photoprism-download-%s-%s.zip", time.Now().Format("20060102-150405"), zipToken)
-		zipFileName := path.Join(zipPath, zipBaseName)
 
-		if err := os.MkdirAll(zipPath, 0700); err != nil {
-			Error(c, http.StatusInternalServerError, err, i18n.ErrZipFailed)
-			return
-		}
+		dlName := DownloadName(c)
+		limit := conf.DownloadLimit()
+		download := &zipDownload{SessionID: SessionID(c), Created: time.Now()}
 
-		newZipFile, err := os.Create(zipFileName)
+		// Files shared with guests are redacted like single downloads, and GPS coordinates
+		// are removed unless all links of the session allow exact locations.
+		var redact *photoprism.Redact
 
-		if err != nil {
-			Error(c, http.StatusInternalServerError, err, i18n.ErrZipFailed)
-			return
-		}
+		if SharedOnly(s, acl.ResourcePhotos) {
+			redact = photoprism.NewRedact(conf)
 
-		defer newZipFile.Close()
-
-		zipWriter := zip.NewWriter(newZipFile)
-		defer zipWriter.Close()
+			if SharedGeoPrivacy(s) != entity.GeoExact {
+				redact.Add(entity.RedactGps)
+			}
 
-		dlName := DownloadName(c)
+			if conf.ShareRedacted(entity.RedactFilenames) {
+				dlName = entity.DownloadNameShare
+			}
+		}
 
 		var aliases = make(map[string]int)
 
@@ -112,23 +190,58 @@ func CreateZip(router *gin.RouterGroup) {
 
 			aliases[key] += 1
 
-			if fs.FileExists(fileName) {
-				if err := addFileToZip(zipWriter, fileName, alias); err != nil {
+			if !fs.FileExists(fileName) {
+				log.Warnf("download: file %s is missing", sanitize.Log(file.FileName))
+				logError("download", file.Update("FileMissing", true))
+				continue
+			}
+
+			temp := false
+
+			if redact != nil && redact.Enabled() {
+				if fileName, err = redact.File(fileName); err != nil {
+					log.Errorf("download: %s", err)
+					download.Remove()
 					Error(c, http.StatusInternalServerError, err, i18n.ErrZipFailed)
 					return
 				}
-				log.Infof("download: added %s as %s", sanitize.Log(file.FileName), sanitize.Log(alias))
-			} else {
-				log.Warnf("download: file %s is missing", sanitize.Log(file.FileName))
-				logError("download", file.Update("FileMissing", true))
+
+				temp = true
+			}
+
+			entry, err := newZipEntry(fileName, alias)
+
+			if err != nil {
+				log.Warnf("download: %s", err)
+				continue
 			}
+
+			entry.Temp = temp
+			download.Add(entry)
+
+			if limit > 0 && download.FileSize > limit {
+				log.Warnf("download: selection exceeds limit of %s", humanize.Bytes(uint64(limit)))
+				download.Remove()
+				Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrZipTooLarge)
+				return
+			}
+
+			log.Infof("download: added %s as %s", sanitize.Log(file.FileName), sanitize.Log(alias))
+		}
+
+		if err := download.Finish(); err != nil {
+			download.Remove()
+			Error(c, http.StatusInternalServerError, err, i18n.ErrZipFailed)
+			return
 		}
 
+		addZipDownload(zipBaseName, download)
+
 		elapsed := int(time.Since(start).Seconds())
 
 		log.Infof("download: created %s [%s]", sanitize.Log(zipBaseName), time.Since(start))
 
-		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgZipCreatedIn, elapsed), "filename": zipBaseName})
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgZipCreatedIn, elapsed), "filename": zipBaseName, "size": download.Size})
 	})
 }
 
@@ -140,53 +253,284 @@ func DownloadZip(router *gin.RouterGroup) {
 			return
 		}
 
-		conf := service.Config()
 		zipBaseName := sanitize.FileName(filepath.Base(c.Param("filename")))
-		zipPath := path.Join(conf.TempPath(), "zip")
-		zipFileName := path.Join(zipPath, zipBaseName)
+		download := findZipDownload(zipBaseName)
 
-		if !fs.FileExists(zipFileName) {
-			log.Errorf("could not find zip file: %s", sanitize.Log(zipFileName))
+		if download == nil {
+			log.Errorf("could not find zip file: %s", sanitize.Log(zipBaseName))
 			c.Data(404, "image/svg+xml", photoIconSvg)
 			return
+		} else if !zipSession(c, download) {
+			log.Errorf("download: %s belongs to another session", sanitize.Log(zipBaseName))
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
 		}
 
-		c.FileAttachment(zipFileName, zipBaseName)
+		serveZip(c, zipBaseName, download)
+	})
+}
+
+// serveZip streams a zip download to the client, supporting range requests to resume interrupted downloads.
+func serveZip(c *gin.Context, name string, download *zipDownload) {
+	stream := &zipStream{name: name, session: download.SessionID, entries: download.Entries, size: download.Size, percent: -1}
+	defer stream.Close()
+
+	AddDownloadHeader(c, name)
+	AddContentTypeHeader(c, "application/zip")
+	c.Header("ETag", download.ETag)
+
+	http.ServeContent(c.Writer, c.Request, name, download.Created, stream)
+}
+
+// zipSession tests if the zip download belongs to the session of the request. Session-scoped download
+// tokens must have been issued to the same session, and downloads of sessions that have been closed expire.
+func zipSession(c *gin.Context, download *zipDownload) bool {
+	if service.Config().Public() {
+		return true
+	} else if download.SessionID == "" || service.Session().Get(download.SessionID).Invalid() {
+		return false
+	}
 
-		if err := os.Remove(zipFileName); err != nil {
-			log.Errorf("download: failed removing %s (%s)", sanitize.Log(zipFileName), err.Error())
+	if service.Config().TokenLifetime() > 0 {
+		if id, ok := service.Session().DownloadSessionID(sanitize.Token(c.Query("t"))); !ok || id != download.SessionID {
+			return false
 		}
+	}
+
+	if id := SessionID(c); id != "" && id != download.SessionID {
+		return false
+	}
+
+	return true
+}
+
+// addZipDownload registers a new zip download and removes expired downloads.
+func addZipDownload(name string, download *zipDownload) {
+	zipDownloads.mutex.Lock()
+	defer zipDownloads.mutex.Unlock()
+
+	pruneZipDownloads()
+
+	zipDownloads.items[name] = download
+}
+
+// findZipDownload returns the zip download with the given file name, or nil if it doesn't exist.
+func findZipDownload(name string) *zipDownload {
+	zipDownloads.mutex.Lock()
+	defer zipDownloads.mutex.Unlock()
+
+	pruneZipDownloads()
+
+	if download, ok := zipDownloads.items[name]; ok {
+		return download
+	}
+
+	return nil
+}
+
+// pruneZipDownloads removes expired downloads and their temporary files, the mutex must be locked.
+func pruneZipDownloads() {
+	for key, item := range zipDownloads.items {
+		if time.Since(item.Created) > ZipExpires {
+			item.Remove()
+			delete(zipDownloads.items, key)
+		}
+	}
+}
+
+// writeZip writes an uncompressed zip archive containing the entries to w.
+//
+// Files are stored without compression so that the archive size is known in advance
+// and each download generates exactly the same bytes, which is required for resuming.
+func writeZip(w io.Writer, entries []zipEntry, open func(e zipEntry) (io.ReadCloser, error)) error {
+	zipWriter := zip.NewWriter(w)
+
+	for _, e := range entries {
+		header := &zip.FileHeader{
+			Name:     e.Alias,
+			Method:   zip.Store,
+			Modified: e.Modified,
+		}
+
+		header.SetMode(0644)
+
+		writer, err := zipWriter.CreateHeader(header)
+
+		if err != nil {
+			return err
+		}
+
+		r, err := open(e)
+
+		if err != nil {
+			return err
+		}
+
+		n, err := io.CopyN(writer, r, e.Size)
+		r.Close()
+
+		if err != nil {
+			return fmt.Errorf("%s has changed (%d of %d bytes)", sanitize.Log(e.Alias), n, e.Size)
+		}
+	}
+
+	return zipWriter.Close()
+}
+
+// openZipEntry opens the original file of a zip entry for reading.
+func openZipEntry(e zipEntry) (io.ReadCloser, error) {
+	return os.Open(e.FileName)
+}
+
+// zipSize returns the size of the zip archive in bytes without reading the files.
+func zipSize(entries []zipEntry) (int64, error) {
+	w := &zipCounter{}
+
+	err := writeZip(w, entries, func(e zipEntry) (io.ReadCloser, error) {
+		return io.NopCloser(io.LimitReader(zipZeros{}, e.Size)), nil
 	})
+
+	return w.n, err
 }
 
-func addFileToZip(zipWriter *zip.Writer, fileName, fileAlias string) error {
-	fileToZip, err := os.Open(fileName)
-	if err != nil {
-		return err
+// zipCounter counts the bytes written to it.
+type zipCounter struct {
+	n int64
+}
+
+func (w *zipCounter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// zipZeros is an endless source of zero bytes.
+type zipZeros struct{}
+
+func (zipZeros) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
 	}
-	defer fileToZip.Close()
 
-	// Get the file information
-	info, err := fileToZip.Stat()
-	if err != nil {
-		return err
+	return len(p), nil
+}
+
+// zipSkipper discards the first bytes written to it.
+type zipSkipper struct {
+	w    io.Writer
+	skip int64
+}
+
+func (w *zipSkipper) Write(p []byte) (int, error) {
+	n := len(p)
+
+	if w.skip >= int64(n) {
+		w.skip -= int64(n)
+		return n, nil
+	} else if w.skip > 0 {
+		p = p[w.skip:]
+		w.skip = 0
 	}
 
-	header, err := zip.FileInfoHeader(info)
-	if err != nil {
-		return err
+	if _, err := w.w.Write(p); err != nil {
+		return 0, err
 	}
 
-	header.Name = fileAlias
+	return n, nil
+}
 
-	// Change to deflate to gain better compression
-	// see http://golang.org/pkg/archive/zip/#pkg-constants
-	header.Method = zip.Deflate
+// zipStream implements io.ReadSeeker for a zip archive that is generated on the fly.
+type zipStream struct {
+	name    string
+	session string
+	entries []zipEntry
+	size    int64
+	offset  int64
+	percent int64
+	pipe    *io.PipeReader
+}
 
-	writer, err := zipWriter.CreateHeader(header)
-	if err != nil {
-		return err
+// Read reads the archive starting at the current offset.
+func (s *zipStream) Read(p []byte) (int, error) {
+	if s.offset >= s.size {
+		return 0, io.EOF
 	}
-	_, err = io.Copy(writer, fileToZip)
+
+	if s.pipe == nil {
+		r, w := io.Pipe()
+		s.pipe = r
+
+		go func(entries []zipEntry, offset int64) {
+			w.CloseWithError(writeZip(&zipSkipper{w: w, skip: offset}, entries, openZipEntry))
+		}(s.entries, s.offset)
+	}
+
+	n, err := s.pipe.Read(p)
+	s.offset += int64(n)
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		log.Errorf("download: %s in %s", err, sanitize.Log(s.name))
+	}
+
+	s.publishProgress()
+
+	return n, err
+}
+
+// Seek sets the offset for the next Read.
+func (s *zipStream) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += s.offset
+	case io.SeekEnd:
+		offset += s.size
+	default:
+		return 0, errors.New("zip: invalid whence")
+	}
+
+	if offset < 0 {
+		return 0, errors.New("zip: negative position")
+	}
+
+	if offset != s.offset {
+		_ = s.Close()
+		s.offset = offset
+	}
+
+	return offset, nil
+}
+
+// Close stops generating the archive.
+func (s *zipStream) Close() error {
+	if s.pipe == nil {
+		return nil
+	}
+
+	err := s.pipe.Close()
+	s.pipe = nil
+
 	return err
 }
+
+// publishProgress sends a download progress event to the session of the download whenever the percentage changes.
+func (s *zipStream) publishProgress() {
+	if s.size <= 0 {
+		return
+	}
+
+	percent := s.offset * 100 / s.size
+
+	if percent == s.percent {
+		return
+	}
+
+	s.percent = percent
+
+	event.Publish("download.progress", event.Data{
+		"session":  s.session,
+		"filename": s.name,
+		"offset":   s.offset,
+		"size":     s.size,
+		"percent":  percent,
+	})
+}
@@ -1,11 +1,22 @@
 package api
 
 import (
+	"archive/zip"
+	"bytes"
+	"io"
 	"net/http"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 func TestCreateZip(t *testing.T) {
@@ -56,4 +67,112 @@ func TestDownloadZip(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/zip/xxx?t="+conf.DownloadToken())
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
+	t.Run("other session", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+
+		DownloadZip(router)
+		admin := service.Session().Create(session.Data{User: entity.Admin})
+		bob := service.Session().Create(session.Data{User: entity.UserFixtures.Get("bob")})
+
+		defer service.Session().Delete(admin)
+		defer service.Session().Delete(bob)
+
+		addZipDownload("zip-other-session.zip", &zipDownload{SessionID: admin, Created: time.Now()})
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/zip/zip-other-session.zip?t="+conf.DownloadToken(), bob)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/zip/zip-other-session.zip?t="+conf.DownloadToken(), admin)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("expired", func(t *testing.T) {
+		addZipDownload("zip-expired.zip", &zipDownload{Created: time.Now().Add(-2 * ZipExpires)})
+		assert.Nil(t, findZipDownload("zip-expired.zip"))
+	})
+}
+
+func TestZipDownload(t *testing.T) {
+	download := &zipDownload{}
+
+	for _, name := range []string{"beach_sand.jpg", "IMG_4120.JPG", "IMG_4120 copy.JPG"} {
+		entry, err := newZipEntry(filepath.Join(service.Config().ExamplesPath(), name), name)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		download.Add(entry)
+	}
+
+	if err := download.Finish(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+
+	if err := writeZip(&buf, download.Entries, openZipEntry); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("size", func(t *testing.T) {
+		assert.Equal(t, int64(buf.Len()), download.Size)
+		assert.Greater(t, download.Size, download.FileSize)
+		assert.NotEmpty(t, download.ETag)
+
+		r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, r.File, 3)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		stream := &zipStream{name: "test.zip", entries: download.Entries, size: download.Size, percent: -1}
+		defer stream.Close()
+
+		offset, err := stream.Seek(1000, io.SeekStart)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := io.ReadAll(stream)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(1000), offset)
+		assert.Equal(t, buf.Bytes()[1000:], result)
+	})
+
+	t.Run("remove temp", func(t *testing.T) {
+		tempName := filepath.Join(service.Config().TempPath(), "zip-remove-temp.jpg")
+
+		if err := fs.Copy(filepath.Join(service.Config().ExamplesPath(), "beach_sand.jpg"), tempName); err != nil {
+			t.Fatal(err)
+		}
+
+		entry, err := newZipEntry(tempName, "beach_sand.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry.Temp = true
+		temp := &zipDownload{Entries: append([]zipEntry{entry}, download.Entries...)}
+		temp.Remove()
+
+		assert.False(t, fs.FileExists(tempName))
+		assert.True(t, fs.FileExists(download.Entries[0].FileName))
+	})
+
+	t.Run("not existing", func(t *testing.T) {
+		_, err := newZipEntry(filepath.Join(service.Config().ExamplesPath(), "xxx.jpg"), "xxx.jpg")
+		assert.Error(t, err)
+	})
 }
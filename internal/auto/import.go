@@ -1,6 +1,7 @@
 package auto
 
 import (
+	"os"
 	"path/filepath"
 	"sync"
 	"time"
@@ -43,7 +44,7 @@ func mustImport(delay time.Duration) bool {
 	importMutex.Lock()
 	defer importMutex.Unlock()
 
-	return !autoImport.IsZero() && autoImport.Sub(time.Now()) < -1*delay && !mutex.MainWorker.Busy()
+	return !autoImport.IsZero() && autoImport.Sub(time.Now()) < -1*delay && !mutex.MainWorker.Busy() && !mutex.Maintenance.Enabled()
 }
 
 // Import starts importing originals e.g. after WebDAV uploads.
@@ -76,9 +77,20 @@ func Import() error {
 		opt = photoprism.ImportOptionsCopy(path)
 	}
 
-	imported := imp.Start(opt)
+	imported := len(imp.Start(opt))
 
-	if len(imported) == 0 {
+	// Import files from the private WebDAV upload folders of users.
+	if dirs, err := os.ReadDir(conf.UploadPath()); err == nil {
+		for _, dir := range dirs {
+			if !dir.IsDir() {
+				continue
+			}
+
+			imported += len(imp.Start(photoprism.ImportOptionsMove(filepath.Join(conf.UploadPath(), dir.Name()))))
+		}
+	}
+
+	if imported == 0 {
 		return nil
 	}
 
@@ -42,7 +42,7 @@ func mustIndex(delay time.Duration) bool {
 	indexMutex.Lock()
 	defer indexMutex.Unlock()
 
-	return !autoIndex.IsZero() && autoIndex.Sub(time.Now()) < -1*delay && !mutex.MainWorker.Busy()
+	return !autoIndex.IsZero() && autoIndex.Sub(time.Now()) < -1*delay && !mutex.MainWorker.Busy() && !mutex.Maintenance.Enabled()
 }
 
 // Index starts indexing originals e.g. after WebDAV uploads.
@@ -0,0 +1,124 @@
+/*
+
+Package caption generates natural-language image descriptions with an external captioning model.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.app/developer-guide/
+
+*/
+package caption
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+var log = event.Log
+
+// Timeout is the max time to wait for a caption, as models running on a CPU may be slow.
+var Timeout = 2 * time.Minute
+
+// Result represents a generated image caption.
+type Result struct {
+	Caption    string  `json:"caption"`
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// Model sends images to a local or remote captioning service, e.g. "http://localhost:5000/caption".
+//
+// The service must accept a JPEG image as request body and respond with a JSON object
+// like {"caption": "a dog running on the beach", "confidence": 0.82}.
+type Model struct {
+	uri    string
+	client *http.Client
+}
+
+// NewModel returns a new captioning model that uses the service with the given uri.
+func NewModel(uri string) *Model {
+	return &Model{uri: strings.TrimSpace(uri), client: &http.Client{Timeout: Timeout}}
+}
+
+// Disabled tests if no captioning service is configured.
+func (m *Model) Disabled() bool {
+	return m == nil || m.uri == ""
+}
+
+// File returns the caption generated for a JPEG image file.
+func (m *Model) File(fileName string) (result Result, err error) {
+	if m.Disabled() {
+		return result, errors.New("caption: service uri missing")
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, m.uri, bytes.NewReader(data))
+
+	if err != nil {
+		return result, err
+	}
+
+	req.Header.Set("Content-Type", "image/jpeg")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := m.client.Do(req)
+
+	if err != nil {
+		return result, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("caption: unexpected response (%s)", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return result, fmt.Errorf("caption: %s", err)
+	}
+
+	result.Caption = txt.Clip(strings.TrimSpace(result.Caption), txt.ClipDescription)
+
+	if result.Caption == "" {
+		return result, errors.New("caption: empty result")
+	}
+
+	log.Tracef("caption: generated %q", result.Caption)
+
+	return result, nil
+}
@@ -0,0 +1,53 @@
+package caption
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var examplesPath, _ = filepath.Abs("../../assets/examples")
+
+func TestModel_File(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method != http.MethodPost || r.Header.Get("Content-Type") != "image/jpeg":
+			w.WriteHeader(http.StatusBadRequest)
+		case r.URL.Path == "/empty":
+			_, _ = w.Write([]byte(`{"caption":"  "}`))
+		default:
+			_, _ = w.Write([]byte(`{"caption":" a cat sitting on a sofa ","confidence":0.82}`))
+		}
+	}))
+
+	defer server.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := NewModel(server.URL + "/caption").File(examplesPath + "/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "a cat sitting on a sofa", result.Caption)
+		assert.Equal(t, 0.82, result.Confidence)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := NewModel(server.URL + "/empty").File(examplesPath + "/cat_brown.jpg")
+		assert.Error(t, err)
+	})
+	t.Run("FileNotFound", func(t *testing.T) {
+		_, err := NewModel(server.URL).File(examplesPath + "/missing.jpg")
+		assert.Error(t, err)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		m := NewModel(" ")
+		assert.True(t, m.Disabled())
+
+		_, err := m.File(examplesPath + "/cat_brown.jpg")
+		assert.Error(t, err)
+	})
+}
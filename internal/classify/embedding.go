@@ -0,0 +1,196 @@
+package classify
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// EmbeddingBands is the number of locality-sensitive hash bands used to find similar embeddings.
+const EmbeddingBands = 8
+
+// EmbeddingBandBits is the number of random hyperplanes per hash band.
+const EmbeddingBandBits = 8
+
+// Embedding represents a normalized image embedding vector based on the classification result.
+type Embedding []float32
+
+// NewEmbedding returns an embedding based on the model output probabilities. The square roots of
+// the probabilities form a unit vector, so that the cosine similarity is the Bhattacharyya coefficient.
+func NewEmbedding(probabilities []float32) Embedding {
+	result := make(Embedding, len(probabilities))
+
+	for i, p := range probabilities {
+		if p > 0 {
+			result[i] = float32(math.Sqrt(float64(p)))
+		}
+	}
+
+	return result.Normalized()
+}
+
+// EmbeddingFromBytes returns the embedding encoded by Bytes.
+func EmbeddingFromBytes(data []byte) Embedding {
+	result := make(Embedding, len(data))
+
+	for i, b := range data {
+		result[i] = float32(b) / math.MaxUint8
+	}
+
+	return result.Normalized()
+}
+
+// Empty tests if the embedding has no dimensions or only zero values.
+func (m Embedding) Empty() bool {
+	for _, v := range m {
+		if v != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Normalized returns the embedding scaled to unit length.
+func (m Embedding) Normalized() Embedding {
+	var sum float64
+
+	for _, v := range m {
+		sum += float64(v) * float64(v)
+	}
+
+	if sum == 0 {
+		return m
+	}
+
+	norm := float32(math.Sqrt(sum))
+	result := make(Embedding, len(m))
+
+	for i, v := range m {
+		result[i] = v / norm
+	}
+
+	return result
+}
+
+// Add returns the normalized sum of both embeddings, e.g. to combine the results of multiple image crops.
+func (m Embedding) Add(other Embedding) Embedding {
+	if len(m) == 0 {
+		return other
+	} else if len(other) != len(m) {
+		return m
+	}
+
+	result := make(Embedding, len(m))
+
+	for i := range m {
+		result[i] = m[i] + other[i]
+	}
+
+	return result.Normalized()
+}
+
+// Similarity returns the cosine similarity with another embedding, from 0 (different) to 1 (same).
+func (m Embedding) Similarity(other Embedding) float64 {
+	if len(other) != len(m) {
+		return 0
+	}
+
+	var dot float64
+
+	for i := range m {
+		dot += float64(m[i]) * float64(other[i])
+	}
+
+	return dot
+}
+
+// Bytes returns the embedding with one byte per dimension, as stored in the database.
+func (m Embedding) Bytes() []byte {
+	result := make([]byte, len(m))
+
+	for i, v := range m {
+		if v <= 0 {
+			continue
+		} else if v >= 1 {
+			result[i] = math.MaxUint8
+		} else {
+			result[i] = byte(math.Round(float64(v) * math.MaxUint8))
+		}
+	}
+
+	return result
+}
+
+// Buckets returns the locality-sensitive hash bucket of each band. Similar embeddings are likely
+// to share at least one bucket, so that candidates can be found without comparing all embeddings.
+func (m Embedding) Buckets() (result []uint32) {
+	if m.Empty() {
+		return result
+	}
+
+	planes := hyperplanes(len(m))
+	result = make([]uint32, EmbeddingBands)
+
+	for band := 0; band < EmbeddingBands; band++ {
+		bits := uint32(0)
+
+		for bit := 0; bit < EmbeddingBandBits; bit++ {
+			if m.dot(planes[band*EmbeddingBandBits+bit]) >= 0 {
+				bits |= 1 << bit
+			}
+		}
+
+		result[band] = uint32(band)<<EmbeddingBandBits | bits
+	}
+
+	return result
+}
+
+// dot returns the dot product with a vector of the same length.
+func (m Embedding) dot(v []float32) (result float32) {
+	for i := range m {
+		result += m[i] * v[i]
+	}
+
+	return result
+}
+
+var hyperplaneMutex = sync.Mutex{}
+var hyperplaneCache = make(map[int][][]float32)
+
+// hyperplanes returns random hyperplanes for the number of dimensions. A fixed seed is used, so that
+// the bucket values remain the same between restarts. Embedding values are positive, so the planes
+// contain the diagonal of the positive orthant.
+func hyperplanes(dim int) [][]float32 {
+	hyperplaneMutex.Lock()
+	defer hyperplaneMutex.Unlock()
+
+	if planes, ok := hyperplaneCache[dim]; ok {
+		return planes
+	}
+
+	r := rand.New(rand.NewSource(int64(dim)))
+	center := float32(1 / math.Sqrt(float64(dim)))
+	planes := make([][]float32, EmbeddingBands*EmbeddingBandBits)
+
+	for i := range planes {
+		planes[i] = make([]float32, dim)
+
+		var offset float32
+
+		for j := range planes[i] {
+			planes[i][j] = float32(r.NormFloat64())
+			offset += planes[i][j] * center
+		}
+
+		// Remove the diagonal component, so that the plane divides the positive embeddings.
+		for j := range planes[i] {
+			planes[i][j] -= offset * center
+		}
+	}
+
+	hyperplaneCache[dim] = planes
+
+	return planes
+}
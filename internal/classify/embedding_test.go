@@ -0,0 +1,67 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEmbedding(t *testing.T) {
+	t.Run("Probabilities", func(t *testing.T) {
+		e := NewEmbedding([]float32{0.64, 0.36, 0})
+
+		assert.Len(t, e, 3)
+		assert.InDelta(t, 0.8, e[0], 0.0001)
+		assert.InDelta(t, 0.6, e[1], 0.0001)
+		assert.Equal(t, float32(0), e[2])
+		assert.InDelta(t, 1, e.Similarity(e), 0.0001)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.True(t, NewEmbedding([]float32{0, 0}).Empty())
+		assert.True(t, NewEmbedding(nil).Empty())
+		assert.Empty(t, Embedding{}.Buckets())
+	})
+}
+
+func TestEmbedding_Bytes(t *testing.T) {
+	e := NewEmbedding([]float32{0.5, 0.3, 0.2, 0})
+	b := e.Bytes()
+
+	assert.Len(t, b, 4)
+	assert.Equal(t, byte(0), b[3])
+	assert.InDelta(t, 1, e.Similarity(EmbeddingFromBytes(b)), 0.001)
+}
+
+func TestEmbedding_Add(t *testing.T) {
+	a := NewEmbedding([]float32{1, 0})
+	b := NewEmbedding([]float32{0, 1})
+
+	assert.Equal(t, a, Embedding{}.Add(a))
+	assert.Equal(t, a, a.Add(Embedding{1, 2, 3}))
+	assert.InDelta(t, 0.7071, a.Add(b).Similarity(a), 0.0001)
+}
+
+func TestEmbedding_Similarity(t *testing.T) {
+	a := NewEmbedding([]float32{0.7, 0.2, 0.1, 0})
+	b := NewEmbedding([]float32{0.6, 0.3, 0.1, 0})
+	c := NewEmbedding([]float32{0, 0.1, 0.2, 0.7})
+
+	assert.Greater(t, a.Similarity(b), a.Similarity(c))
+	assert.Equal(t, float64(0), a.Similarity(Embedding{1}))
+}
+
+func TestEmbedding_Buckets(t *testing.T) {
+	probabilities := make([]float32, 100)
+	probabilities[3], probabilities[7] = 0.8, 0.2
+
+	e := NewEmbedding(probabilities)
+	buckets := e.Buckets()
+
+	assert.Len(t, buckets, EmbeddingBands)
+	assert.Equal(t, buckets, e.Buckets())
+	assert.Equal(t, buckets, EmbeddingFromBytes(e.Bytes()).Buckets())
+
+	for band, bucket := range buckets {
+		assert.Equal(t, uint32(band), bucket>>EmbeddingBandBits)
+	}
+}
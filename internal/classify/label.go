@@ -6,6 +6,13 @@ import (
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// PetLabels contains the names of labels that refer to pets, which can be named and searched like people.
+var PetLabels = map[string]bool{
+	"cat":    true,
+	"dog":    true,
+	"rabbit": true,
+}
+
 // Label represents a MediaFile label (automatically created).
 type Label struct {
 	Name        string   `json:"label"`       // Label name
@@ -47,3 +54,8 @@ func LocationLabel(name string, uncertainty int) Label {
 func (l Label) Title() string {
 	return txt.Title(txt.Clip(l.Name, txt.ClipDefault))
 }
+
+// Pet tests if the label refers to a pet.
+func (l Label) Pet() bool {
+	return PetLabels[strings.ToLower(l.Name)]
+}
@@ -51,3 +51,15 @@ func TestLabel_Title(t *testing.T) {
 		assert.Equal(t, "Berlin / Neukölln Hasenheide", LocLabel.Title())
 	})
 }
+
+func TestLabel_Pet(t *testing.T) {
+	t.Run("Dog", func(t *testing.T) {
+		assert.True(t, Label{Name: "dog"}.Pet())
+	})
+	t.Run("Cat", func(t *testing.T) {
+		assert.True(t, Label{Name: "Cat"}.Pet())
+	})
+	t.Run("Bear", func(t *testing.T) {
+		assert.False(t, Label{Name: "bear"}.Pet())
+	})
+}
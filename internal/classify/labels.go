@@ -85,3 +85,17 @@ func (l Labels) Title(fallback string) string {
 
 	return fallback
 }
+
+// Pet returns the pet label with the lowest uncertainty, if any.
+func (l Labels) Pet() (result Label, ok bool) {
+	for _, label := range l {
+		if label.Uncertainty >= 100 || !label.Pet() {
+			continue
+		} else if !ok || label.Uncertainty < result.Uncertainty {
+			result = label
+			ok = true
+		}
+	}
+
+	return result, ok
+}
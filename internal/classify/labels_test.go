@@ -120,3 +120,33 @@ func TestLabel_Sort(t *testing.T) {
 	assert.Equal(t, "label 1", labels[7].Name)
 	assert.Equal(t, "label 8", labels[8].Name)
 }
+
+func TestLabels_Pet(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		labels := Labels{
+			{Name: "dog", Uncertainty: 40},
+			{Name: "cat", Uncertainty: 20},
+			{Name: "animal", Uncertainty: 10},
+		}
+
+		result, ok := labels.Pet()
+
+		assert.True(t, ok)
+		assert.Equal(t, "cat", result.Name)
+		assert.Equal(t, 20, result.Uncertainty)
+	})
+	t.Run("Uncertain", func(t *testing.T) {
+		labels := Labels{{Name: "dog", Uncertainty: 100}}
+
+		_, ok := labels.Pet()
+
+		assert.False(t, ok)
+	})
+	t.Run("None", func(t *testing.T) {
+		labels := Labels{{Name: "snow", Uncertainty: 10}}
+
+		_, ok := labels.Pet()
+
+		assert.False(t, ok)
+	})
+}
@@ -57,8 +57,30 @@ func (t *TensorFlow) File(filename string) (result Labels, err error) {
 	return t.Labels(imageBuffer)
 }
 
+// ClassifyFile returns matching labels and the embedding vector for a jpeg media file.
+func (t *TensorFlow) ClassifyFile(filename string) (result Labels, embedding Embedding, err error) {
+	if t.disabled {
+		return result, embedding, nil
+	}
+
+	imageBuffer, err := os.ReadFile(filename)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return t.Classify(imageBuffer)
+}
+
 // Labels returns matching labels for a jpeg media string.
 func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
+	result, _, err = t.Classify(img)
+
+	return result, err
+}
+
+// Classify returns matching labels and the embedding vector for a jpeg media string.
+func (t *TensorFlow) Classify(img []byte) (result Labels, embedding Embedding, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("classify: %s (inference panic)\nstack: %s", r, debug.Stack())
@@ -66,18 +88,18 @@ func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
 	}()
 
 	if t.disabled {
-		return result, nil
+		return result, embedding, nil
 	}
 
 	if err := t.loadModel(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Create tensor from image.
 	tensor, err := t.createTensor(img, "jpeg")
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Run inference.
@@ -91,21 +113,24 @@ func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
 		nil)
 
 	if err != nil {
-		return result, fmt.Errorf("classify: %s (run inference)", err.Error())
+		return result, embedding, fmt.Errorf("classify: %s (run inference)", err.Error())
 	}
 
 	if len(output) < 1 {
-		return result, fmt.Errorf("classify: inference failed, no output")
+		return result, embedding, fmt.Errorf("classify: inference failed, no output")
 	}
 
-	// Return best labels
-	result = t.bestLabels(output[0].Value().([][]float32)[0])
+	probabilities := output[0].Value().([][]float32)[0]
+
+	// Return best labels and the embedding vector.
+	result = t.bestLabels(probabilities)
+	embedding = NewEmbedding(probabilities)
 
 	if len(result) > 0 {
 		log.Tracef("classify: image classified as %+v", result)
 	}
 
-	return result, nil
+	return result, embedding, nil
 }
 
 func (t *TensorFlow) loadLabels(path string) error {
@@ -0,0 +1,303 @@
+package classify
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gosimple/slug"
+)
+
+// LabelTranslations maps canonical English label names to their translation.
+type LabelTranslations map[string]string
+
+// Translations contains the label translations with the locale as index, e.g. "de".
+var Translations = map[string]LabelTranslations{
+	"de": {
+		"animal":       "Tier",
+		"architecture": "Architektur",
+		"baby":         "Baby",
+		"beach":        "Strand",
+		"bear":         "Bär",
+		"beverage":     "Getränk",
+		"bike":         "Fahrrad",
+		"bird":         "Vogel",
+		"boat":         "Boot",
+		"book":         "Buch",
+		"bridge":       "Brücke",
+		"building":     "Gebäude",
+		"bus":          "Bus",
+		"butterfly":    "Schmetterling",
+		"camera":       "Kamera",
+		"camping":      "Camping",
+		"car":          "Auto",
+		"cat":          "Katze",
+		"church":       "Kirche",
+		"coffee":       "Kaffee",
+		"computer":     "Computer",
+		"cow":          "Kuh",
+		"dessert":      "Dessert",
+		"dog":          "Hund",
+		"drinks":       "Getränke",
+		"duck":         "Ente",
+		"elephant":     "Elefant",
+		"farm":         "Bauernhof",
+		"field":        "Feld",
+		"fish":         "Fisch",
+		"flower":       "Blume",
+		"food":         "Essen",
+		"fruit":        "Obst",
+		"furniture":    "Möbel",
+		"horse":        "Pferd",
+		"insect":       "Insekt",
+		"kitchen":      "Küche",
+		"lakeside":     "Seeufer",
+		"landscape":    "Landschaft",
+		"monument":     "Denkmal",
+		"mountain":     "Berg",
+		"nature":       "Natur",
+		"people":       "Menschen",
+		"plant":        "Pflanze",
+		"portrait":     "Porträt",
+		"seashore":     "Küste",
+		"ship":         "Schiff",
+		"snow":         "Schnee",
+		"tower":        "Turm",
+		"toy":          "Spielzeug",
+		"train":        "Zug",
+		"vegetables":   "Gemüse",
+		"vehicle":      "Fahrzeug",
+		"water":        "Wasser",
+		"wildlife":     "Wildtiere",
+		"window":       "Fenster",
+		"wine":         "Wein",
+		"wood":         "Holz",
+	},
+	"es": {
+		"animal":       "Animal",
+		"architecture": "Arquitectura",
+		"baby":         "Bebé",
+		"beach":        "Playa",
+		"bear":         "Oso",
+		"beverage":     "Bebida",
+		"bike":         "Bicicleta",
+		"bird":         "Pájaro",
+		"boat":         "Barco",
+		"book":         "Libro",
+		"bridge":       "Puente",
+		"building":     "Edificio",
+		"bus":          "Autobús",
+		"butterfly":    "Mariposa",
+		"camera":       "Cámara",
+		"car":          "Coche",
+		"cat":          "Gato",
+		"church":       "Iglesia",
+		"coffee":       "Café",
+		"cow":          "Vaca",
+		"dog":          "Perro",
+		"drinks":       "Bebidas",
+		"duck":         "Pato",
+		"elephant":     "Elefante",
+		"farm":         "Granja",
+		"field":        "Campo",
+		"fish":         "Pez",
+		"flower":       "Flor",
+		"food":         "Comida",
+		"fruit":        "Fruta",
+		"furniture":    "Muebles",
+		"insect":       "Insecto",
+		"kitchen":      "Cocina",
+		"landscape":    "Paisaje",
+		"monument":     "Monumento",
+		"mountain":     "Montaña",
+		"nature":       "Naturaleza",
+		"people":       "Personas",
+		"plant":        "Planta",
+		"portrait":     "Retrato",
+		"seashore":     "Costa",
+		"ship":         "Buque",
+		"snow":         "Nieve",
+		"tower":        "Torre",
+		"toy":          "Juguete",
+		"train":        "Tren",
+		"vegetables":   "Verduras",
+		"vehicle":      "Vehículo",
+		"water":        "Agua",
+		"window":       "Ventana",
+		"wine":         "Vino",
+		"wood":         "Madera",
+	},
+	"fr": {
+		"animal":       "Animal",
+		"architecture": "Architecture",
+		"baby":         "Bébé",
+		"beach":        "Plage",
+		"bear":         "Ours",
+		"beverage":     "Boisson",
+		"bike":         "Vélo",
+		"bird":         "Oiseau",
+		"boat":         "Bateau",
+		"book":         "Livre",
+		"bridge":       "Pont",
+		"building":     "Bâtiment",
+		"butterfly":    "Papillon",
+		"camera":       "Appareil Photo",
+		"car":          "Voiture",
+		"cat":          "Chat",
+		"church":       "Église",
+		"coffee":       "Café",
+		"cow":          "Vache",
+		"dog":          "Chien",
+		"drinks":       "Boissons",
+		"duck":         "Canard",
+		"elephant":     "Éléphant",
+		"farm":         "Ferme",
+		"field":        "Champ",
+		"fish":         "Poisson",
+		"flower":       "Fleur",
+		"food":         "Nourriture",
+		"fruit":        "Fruit",
+		"furniture":    "Meubles",
+		"insect":       "Insecte",
+		"kitchen":      "Cuisine",
+		"landscape":    "Paysage",
+		"monument":     "Monument",
+		"mountain":     "Montagne",
+		"nature":       "Nature",
+		"people":       "Personnes",
+		"plant":        "Plante",
+		"portrait":     "Portrait",
+		"seashore":     "Bord de Mer",
+		"ship":         "Navire",
+		"snow":         "Neige",
+		"tower":        "Tour",
+		"toy":          "Jouet",
+		"train":        "Train",
+		"vegetables":   "Légumes",
+		"vehicle":      "Véhicule",
+		"water":        "Eau",
+		"window":       "Fenêtre",
+		"wine":         "Vin",
+		"wood":         "Bois",
+	},
+	"nl": {
+		"animal":       "Dier",
+		"architecture": "Architectuur",
+		"baby":         "Baby",
+		"beach":        "Strand",
+		"bear":         "Beer",
+		"beverage":     "Drank",
+		"bike":         "Fiets",
+		"bird":         "Vogel",
+		"boat":         "Boot",
+		"book":         "Boek",
+		"bridge":       "Brug",
+		"building":     "Gebouw",
+		"butterfly":    "Vlinder",
+		"car":          "Auto",
+		"cat":          "Kat",
+		"church":       "Kerk",
+		"coffee":       "Koffie",
+		"cow":          "Koe",
+		"dog":          "Hond",
+		"duck":         "Eend",
+		"elephant":     "Olifant",
+		"farm":         "Boerderij",
+		"field":        "Veld",
+		"fish":         "Vis",
+		"flower":       "Bloem",
+		"food":         "Eten",
+		"fruit":        "Fruit",
+		"furniture":    "Meubels",
+		"insect":       "Insect",
+		"kitchen":      "Keuken",
+		"landscape":    "Landschap",
+		"mountain":     "Berg",
+		"nature":       "Natuur",
+		"people":       "Mensen",
+		"plant":        "Plant",
+		"portrait":     "Portret",
+		"seashore":     "Kust",
+		"ship":         "Schip",
+		"snow":         "Sneeuw",
+		"tower":        "Toren",
+		"train":        "Trein",
+		"vegetables":   "Groenten",
+		"vehicle":      "Voertuig",
+		"water":        "Water",
+		"window":       "Raam",
+		"wine":         "Wijn",
+		"wood":         "Hout",
+	},
+}
+
+var translateMutex = sync.RWMutex{}
+var translateLocale = ""
+var canonicalNames = make(map[string]string)
+
+// SetLocale enables label translation for the locale, e.g. "de" or "pt_BR". An empty
+// string or a locale without translations disables it.
+func SetLocale(locale string) {
+	translateMutex.Lock()
+	defer translateMutex.Unlock()
+
+	// Fall back to the language if there are no translations for the region.
+	if _, ok := Translations[locale]; !ok && len(locale) > 2 {
+		locale = locale[:2]
+	}
+
+	canonicalNames = make(map[string]string)
+
+	if translations, ok := Translations[locale]; !ok {
+		translateLocale = ""
+		return
+	} else {
+		translateLocale = locale
+
+		for name, translation := range translations {
+			canonicalNames[slug.Make(translation)] = name
+		}
+	}
+}
+
+// Translate returns the label name in the current locale, or the canonical name if there is no translation.
+func Translate(name string) string {
+	translateMutex.RLock()
+	defer translateMutex.RUnlock()
+
+	if translateLocale == "" {
+		return name
+	} else if translation, ok := Translations[translateLocale][strings.ToLower(name)]; ok {
+		return translation
+	}
+
+	return name
+}
+
+// Canonical returns the canonical English label name of a translation, or the name itself if it is unknown.
+func Canonical(name string) string {
+	translateMutex.RLock()
+	defer translateMutex.RUnlock()
+
+	if translateLocale == "" {
+		return name
+	} else if canonical, ok := canonicalNames[slug.Make(name)]; ok {
+		return canonical
+	}
+
+	return name
+}
+
+// CanonicalList returns the canonical label names of a list separated by sep, e.g. "katze|hund".
+func CanonicalList(names, sep string) string {
+	if names == "" {
+		return names
+	}
+
+	list := strings.Split(names, sep)
+
+	for i := range list {
+		list[i] = Canonical(list[i])
+	}
+
+	return strings.Join(list, sep)
+}
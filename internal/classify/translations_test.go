@@ -0,0 +1,63 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		SetLocale("")
+		assert.Equal(t, "Cat", Translate("Cat"))
+	})
+	t.Run("German", func(t *testing.T) {
+		SetLocale("de")
+		defer SetLocale("")
+
+		assert.Equal(t, "Katze", Translate("Cat"))
+		assert.Equal(t, "Katze", Translate("cat"))
+		assert.Equal(t, "Unknown", Translate("Unknown"))
+	})
+	t.Run("Region", func(t *testing.T) {
+		SetLocale("fr_CA")
+		defer SetLocale("")
+
+		assert.Equal(t, "Chien", Translate("dog"))
+	})
+	t.Run("NoTranslations", func(t *testing.T) {
+		SetLocale("zh")
+		defer SetLocale("")
+
+		assert.Equal(t, "Dog", Translate("Dog"))
+	})
+}
+
+func TestCanonical(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		SetLocale("")
+		assert.Equal(t, "katze", Canonical("katze"))
+	})
+	t.Run("German", func(t *testing.T) {
+		SetLocale("de")
+		defer SetLocale("")
+
+		assert.Equal(t, "cat", Canonical("Katze"))
+		assert.Equal(t, "bear", Canonical("bar"))
+		assert.Equal(t, "cat", Canonical("cat"))
+	})
+	t.Run("MultipleWords", func(t *testing.T) {
+		SetLocale("fr")
+		defer SetLocale("")
+
+		assert.Equal(t, "seashore", Canonical("Bord de Mer"))
+	})
+}
+
+func TestCanonicalList(t *testing.T) {
+	SetLocale("de")
+	defer SetLocale("")
+
+	assert.Equal(t, "cat|dog|unknown", CanonicalList("katze|hund|unknown", "|"))
+	assert.Equal(t, "", CanonicalList("", "|"))
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// AlbumsCommand registers album management subcommands.
+var AlbumsCommand = cli.Command{
+	Name:  "albums",
+	Usage: "Album management subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:      "restore",
+			Usage:     "Restores albums from YAML files, including photos, members, and share links",
+			ArgsUsage: "[PATH]",
+			Action:    albumsRestoreAction,
+		},
+	},
+}
+
+// albumsRestoreAction restores albums from YAML files.
+func albumsRestoreAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		service.SetConfig(conf)
+
+		albumsPath := ctx.Args().First()
+
+		if albumsPath == "" {
+			albumsPath = conf.AlbumsPath()
+		}
+
+		if !fs.PathExists(albumsPath) {
+			return fmt.Errorf("album files path %s not found", sanitize.Log(albumsPath))
+		}
+
+		log.Infof("restoring albums from %s", sanitize.Log(albumsPath))
+
+		count, err := photoprism.RestoreAlbums(albumsPath, true)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("restored %s from YAML files", english.Plural(count, "album", "albums"))
+
+		return nil
+	})
+}
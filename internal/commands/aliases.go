@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// AliasesCommand registers camera and lens alias subcommands.
+var AliasesCommand = cli.Command{
+	Name:  "aliases",
+	Usage: "Camera and lens alias subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "Lists camera and lens aliases",
+			Action: aliasesListAction,
+		},
+		{
+			Name:      "add",
+			Usage:     "Adds an alias, so that a camera or lens name is merged into another one",
+			ArgsUsage: "[camera|lens] [NAME] [TARGET]",
+			Action:    aliasesAddAction,
+		},
+		{
+			Name:      "remove",
+			Usage:     "Removes an alias",
+			ArgsUsage: "[camera|lens] [NAME]",
+			Action:    aliasesRemoveAction,
+		},
+		{
+			Name:   "apply",
+			Usage:  "Merges already indexed photos into the cameras and lenses they are an alias of",
+			Action: aliasesApplyAction,
+		},
+	},
+}
+
+// aliasesListAction lists camera and lens aliases.
+func aliasesListAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		results, err := query.CameraAliases("")
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%-8s %-40s %s\n", "TYPE", "NAME", "TARGET")
+
+		for _, m := range results {
+			fmt.Printf("%-8s %-40s %s\n", m.AliasType, m.AliasName, m.TargetSlug)
+		}
+
+		return nil
+	})
+}
+
+// aliasesAddAction adds a camera or lens alias.
+func aliasesAddAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		if ctx.NArg() != 3 {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m := entity.NewCameraAlias(ctx.Args().Get(0), ctx.Args().Get(1), ctx.Args().Get(2))
+
+		if err := m.Validate(); err != nil {
+			return err
+		} else if !m.TargetExists() {
+			return fmt.Errorf("%s %s not found", m.AliasType, sanitize.Log(m.TargetSlug))
+		}
+
+		if err := m.Save(); err != nil {
+			return err
+		}
+
+		log.Infof("%s is now an alias of %s, run 'photoprism aliases apply' to update indexed photos", sanitize.Log(m.AliasName), sanitize.Log(m.TargetSlug))
+
+		return nil
+	})
+}
+
+// aliasesRemoveAction removes a camera or lens alias.
+func aliasesRemoveAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		if ctx.NArg() != 2 {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m := entity.NewCameraAlias(ctx.Args().Get(0), ctx.Args().Get(1), "")
+
+		if entity.FindCameraAlias(m.AliasType, m.AliasSlug) == nil {
+			return fmt.Errorf("alias %s not found", sanitize.Log(m.AliasName))
+		}
+
+		if err := m.Delete(); err != nil {
+			return err
+		}
+
+		log.Infof("removed alias %s", sanitize.Log(m.AliasName))
+
+		return nil
+	})
+}
+
+// aliasesApplyAction merges indexed photos into the cameras and lenses they are an alias of.
+func aliasesApplyAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		results, err := query.CameraAliases("")
+
+		if err != nil {
+			return err
+		}
+
+		var updated int64
+
+		for _, m := range results {
+			if n, err := m.Apply(); err != nil {
+				log.Warnf("aliases: %s", err)
+			} else if n > 0 {
+				log.Infof("aliases: merged %s from %s into %s", english.Plural(int(n), "photo", "photos"), sanitize.Log(m.AliasName), sanitize.Log(m.TargetSlug))
+				updated += n
+			}
+		}
+
+		log.Infof("aliases: updated %s", english.Plural(int(updated), "photo", "photos"))
+
+		return nil
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
@@ -56,6 +57,10 @@ var backupFlags = []cli.Flag{
 		Name:  "index-path",
 		Usage: "custom index backup `PATH`",
 	},
+	cli.BoolFlag{
+		Name:  "unencrypted",
+		Usage: "allow unencrypted SQL dumps of an encrypted SQLite index",
+	},
 }
 
 // backupAction creates a database backup.
@@ -85,6 +90,11 @@ func backupAction(ctx *cli.Context) error {
 	}
 
 	if backupIndex {
+		// SQL dumps are plain text, so they must not silently expose the contents of an encrypted index.
+		if conf.SqliteEncrypted() && !ctx.Bool("unencrypted") {
+			return fmt.Errorf("SQL dumps are not encrypted, use --unencrypted to back up the encrypted index anyway")
+		}
+
 		// If empty, use default backup file name.
 		if indexFileName == "" {
 			if !fs.PathWritable(indexPath) {
@@ -116,50 +126,8 @@ func backupAction(ctx *cli.Context) error {
 			log.Infof("writing SQL dump to %s", sanitize.Log(indexFileName))
 		}
 
-		var cmd *exec.Cmd
-
-		switch conf.DatabaseDriver() {
-		case config.MySQL, config.MariaDB:
-			cmd = exec.Command(
-				conf.MysqldumpBin(),
-				"--protocol", "tcp",
-				"-h", conf.DatabaseHost(),
-				"-P", conf.DatabasePortString(),
-				"-u", conf.DatabaseUser(),
-				"-p"+conf.DatabasePassword(),
-				conf.DatabaseName(),
-			)
-		case config.SQLite3:
-			cmd = exec.Command(
-				conf.SqliteBin(),
-				conf.DatabaseDsn(),
-				".dump",
-			)
-		default:
-			return fmt.Errorf("unsupported database type: %s", conf.DatabaseDriver())
-		}
-
-		// Fetch command output.
-		var out bytes.Buffer
-		var stderr bytes.Buffer
-		cmd.Stdout = &out
-		cmd.Stderr = &stderr
-
-		// Run backup command.
-		if err := cmd.Run(); err != nil {
-			if stderr.String() != "" {
-				return errors.New(stderr.String())
-			}
-		}
-
-		if indexFileName == "-" {
-			// Return output via stdout.
-			fmt.Println(out.String())
-		} else {
-			// Write output to file.
-			if err := os.WriteFile(indexFileName, []byte(out.String()), os.ModePerm); err != nil {
-				return err
-			}
+		if err := dumpIndex(conf, indexFileName); err != nil {
+			return err
 		}
 	}
 
@@ -192,3 +160,71 @@ func backupAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// sqliteInput returns the sqlite shell input, setting the key first if the index is encrypted. The key is
+// passed on stdin, so that it is not visible in the process list, and the pragma output is discarded.
+func sqliteInput(conf *config.Config, input string) string {
+	if key := conf.SqliteKey(); key != "" {
+		return ".output /dev/null\n" + config.SqliteKeyPragma(key) + ";\n.output\n" + input
+	}
+
+	return input
+}
+
+// dumpIndex writes an SQL dump of the index database to the file, or to stdout if the file name is "-".
+func dumpIndex(conf *config.Config, indexFileName string) error {
+	var cmd *exec.Cmd
+
+	switch conf.DatabaseDriver() {
+	case config.MySQL, config.MariaDB:
+		cmd = exec.Command(
+			conf.MysqldumpBin(),
+			"--protocol", "tcp",
+			"-h", conf.DatabaseHost(),
+			"-P", conf.DatabasePortString(),
+			"-u", conf.DatabaseUser(),
+			"-p"+conf.DatabasePassword(),
+			conf.DatabaseName(),
+		)
+	case config.SQLite3:
+		cmd = exec.Command(
+			conf.SqliteBin(),
+			conf.DatabaseDsn(),
+		)
+		cmd.Stdin = strings.NewReader(sqliteInput(conf, ".dump\n"))
+	default:
+		return fmt.Errorf("unsupported database type: %s", conf.DatabaseDriver())
+	}
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	// Run backup command.
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return errors.New(stderr.String())
+		}
+	}
+
+	if indexFileName == "-" {
+		// Return output via stdout.
+		fmt.Println(out.String())
+	} else {
+		mode := os.ModePerm
+
+		// Restrict access to unencrypted dumps of an encrypted index.
+		if conf.SqliteEncrypted() {
+			mode = 0600
+		}
+
+		// Write output to file.
+		if err := os.WriteFile(indexFileName, []byte(out.String()), mode); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
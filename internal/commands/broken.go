@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// BrokenCommand registers subcommands for reviewing originals that could not be decoded.
+var BrokenCommand = cli.Command{
+	Name:  "broken",
+	Usage: "Broken file management subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "Lists originals that could not be decoded while indexing",
+			Action: brokenListAction,
+		},
+		{
+			Name:      "move",
+			Usage:     "Moves broken originals to a quarantine folder and removes them from the index",
+			ArgsUsage: "[PATH]",
+			Action:    brokenMoveAction,
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "dry-run, n",
+					Usage: "show what would be moved without changing any files",
+				},
+			},
+		},
+	},
+}
+
+// brokenListAction lists originals that could not be decoded.
+func brokenListAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		files, err := query.BrokenFiles(10000, 0)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("found %s", english.Plural(len(files), "broken file", "broken files"))
+
+		fmt.Printf("%-40s %-8s %-20s %s\n", "NAME", "ATTEMPTS", "UPDATED", "ERROR")
+
+		for _, f := range files {
+			fmt.Printf("%-40s %-8d %-20s %s\n", f.FileName, f.Attempts, f.UpdatedAt.Format("2006-01-02 15:04:05"), f.FileError)
+		}
+
+		return nil
+	})
+}
+
+// brokenMoveAction moves broken originals to a quarantine folder.
+func brokenMoveAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		destPath := ctx.Args().First()
+
+		if destPath == "" {
+			destPath = filepath.Join(conf.StoragePath(), "quarantine")
+		}
+
+		dryRun := ctx.Bool("dry-run")
+
+		moved, err := photoprism.MoveBrokenFiles(destPath, dryRun)
+
+		if err != nil {
+			return err
+		}
+
+		for _, fileName := range moved {
+			fmt.Println(fileName)
+		}
+
+		if dryRun {
+			log.Infof("broken: would move %s to %s", english.Plural(len(moved), "file", "files"), sanitize.Log(destPath))
+		} else {
+			log.Infof("broken: moved %s to %s", english.Plural(len(moved), "file", "files"), sanitize.Log(destPath))
+		}
+
+		return nil
+	})
+}
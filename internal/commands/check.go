@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// CheckCommand registers the check cli command.
+var CheckCommand = cli.Command{
+	Name:      "check",
+	Usage:     "Validates the index against originals and sidecar files",
+	ArgsUsage: "[PATH]",
+	Action:    checkAction,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "hashes",
+			Usage: "also compare file hashes, which requires reading all files",
+		},
+		cli.BoolFlag{
+			Name:  "fix, f",
+			Usage: "repair the issues found, e.g. by indexing changed files and removing orphan entries",
+		},
+	},
+}
+
+// checkAction validates the index and optionally repairs it.
+func checkAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	opt := photoprism.CheckOptions{
+		Path:   strings.TrimSpace(ctx.Args().First()),
+		Hashes: ctx.Bool("hashes"),
+		Fix:    ctx.Bool("fix"),
+	}
+
+	w := photoprism.NewCheck(conf, service.Index())
+
+	issues, err := w.Start(opt)
+
+	if err != nil {
+		return err
+	}
+
+	if len(issues) > 0 {
+		fmt.Printf("%-10s %-50s %-18s %s\n", "ISSUE", "FILE", "PHOTO", "DETAILS")
+
+		for _, i := range issues {
+			fmt.Printf("%-10s %-50s %-18s %s\n", i.Type, i.Name, i.PhotoUID, i.Details)
+		}
+	}
+
+	if opt.Fix {
+		log.Infof("check: repaired %s [%s]", english.Plural(len(issues), "issue", "issues"), time.Since(start))
+	} else {
+		log.Infof("check: found %s [%s]", english.Plural(len(issues), "issue", "issues"), time.Since(start))
+	}
+
+	return nil
+}
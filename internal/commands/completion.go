@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// CompletionCommand registers the shell completion subcommands.
+var CompletionCommand = cli.Command{
+	Name:  "completion",
+	Usage: "Shell completion subcommands",
+	Description: "Shows a completion script for the shell, which can be loaded in your shell profile, e.g.\n" +
+		"   source <(photoprism completion bash)",
+	Subcommands: []cli.Command{
+		{
+			Name:   "bash",
+			Usage:  "Shows the bash completion script",
+			Action: completionBashAction,
+		},
+		{
+			Name:   "zsh",
+			Usage:  "Shows the zsh completion script",
+			Action: completionZshAction,
+		},
+		{
+			Name:   "fish",
+			Usage:  "Shows the fish completion script",
+			Action: completionFishAction,
+		},
+	},
+}
+
+// bashCompletion is the bash completion script template, see completionScript().
+const bashCompletion = `# %[1]s bash completion
+
+_%[2]s_bash_autocomplete() {
+  if [[ "${COMP_WORDS[0]}" != "source" ]]; then
+    local cur opts
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    if [[ "$cur" == "-"* ]]; then
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} ${cur} --generate-bash-completion )
+    else
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} --generate-bash-completion )
+    fi
+    COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+    return 0
+  fi
+}
+
+complete -o bashdefault -o default -o nospace -F _%[2]s_bash_autocomplete %[1]s
+`
+
+// zshCompletion is the zsh completion script template, see completionScript().
+const zshCompletion = `#compdef %[1]s
+
+_%[2]s_zsh_autocomplete() {
+  local -a opts
+  local cur
+  cur=${words[-1]}
+  if [[ "$cur" == "-"* ]]; then
+    opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 ${words[@]:0:#words[@]-1} ${cur} --generate-bash-completion)}")
+  else
+    opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 ${words[@]:0:#words[@]-1} --generate-bash-completion)}")
+  fi
+
+  if [[ "${opts[1]}" != "" ]]; then
+    _describe 'values' opts
+  else
+    _files
+  fi
+
+  return
+}
+
+compdef _%[2]s_zsh_autocomplete %[1]s
+`
+
+// rootApp returns the main application, as subcommands are executed by a separate app instance.
+func rootApp(ctx *cli.Context) *cli.App {
+	for ctx.Parent() != nil {
+		ctx = ctx.Parent()
+	}
+
+	return ctx.App
+}
+
+// completionName returns the program name for use in completion scripts.
+func completionName(app *cli.App) string {
+	if name := strings.TrimSpace(app.HelpName); name != "" {
+		return name
+	}
+
+	return strings.ToLower(app.Name)
+}
+
+// completionScript returns the completion script for the program name, which is also used
+// in function names after replacing characters that are not allowed.
+func completionScript(script, name string) string {
+	return fmt.Sprintf(script, name, strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+// completionBashAction shows the bash completion script.
+func completionBashAction(ctx *cli.Context) error {
+	fmt.Print(completionScript(bashCompletion, completionName(rootApp(ctx))))
+
+	return nil
+}
+
+// completionZshAction shows the zsh completion script.
+func completionZshAction(ctx *cli.Context) error {
+	fmt.Print(completionScript(zshCompletion, completionName(rootApp(ctx))))
+
+	return nil
+}
+
+// completionFishAction shows the fish completion script.
+func completionFishAction(ctx *cli.Context) error {
+	// Fish completions are generated for the app name, so use the program name instead.
+	app := *rootApp(ctx)
+	app.Name = completionName(&app)
+
+	if script, err := app.ToFishCompletion(); err != nil {
+		return err
+	} else {
+		fmt.Print(script)
+	}
+
+	return nil
+}
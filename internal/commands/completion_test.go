@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/pkg/capture"
+)
+
+func TestCompletionCommand(t *testing.T) {
+	app := cli.NewApp()
+	app.Name = "PhotoPrism"
+	app.HelpName = "photoprism"
+	app.Commands = []cli.Command{IndexCommand, CompletionCommand}
+
+	t.Run("Fish", func(t *testing.T) {
+		var err error
+
+		output := capture.Output(func() {
+			err = app.Run([]string{"photoprism", "completion", "fish"})
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, output, "complete -c photoprism")
+		assert.Contains(t, output, "index")
+		assert.NotContains(t, output, "complete -c PhotoPrism")
+	})
+	t.Run("Bash", func(t *testing.T) {
+		var err error
+
+		output := capture.Output(func() {
+			err = app.Run([]string{"photoprism", "completion", "bash"})
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, output, "_photoprism_bash_autocomplete photoprism")
+	})
+}
+
+func TestCompletionScript(t *testing.T) {
+	t.Run("Bash", func(t *testing.T) {
+		script := completionScript(bashCompletion, "photoprism")
+
+		assert.Contains(t, script, "_photoprism_bash_autocomplete()")
+		assert.Contains(t, script, "-F _photoprism_bash_autocomplete photoprism\n")
+	})
+	t.Run("Zsh", func(t *testing.T) {
+		script := completionScript(zshCompletion, "photoprism")
+
+		assert.Contains(t, script, "#compdef photoprism\n")
+		assert.Contains(t, script, "compdef _photoprism_zsh_autocomplete photoprism\n")
+	})
+	t.Run("FunctionName", func(t *testing.T) {
+		script := completionScript(bashCompletion, "photoprism-dev")
+
+		assert.Contains(t, script, "-F _photoprism_dev_bash_autocomplete photoprism-dev\n")
+	})
+}
+
+func TestCompletionName(t *testing.T) {
+	assert.Equal(t, "photoprism", completionName(&cli.App{Name: "PhotoPrism"}))
+	assert.Equal(t, "pp", completionName(&cli.App{Name: "PhotoPrism", HelpName: "pp"}))
+}
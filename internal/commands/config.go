@@ -1,11 +1,13 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 	"unicode/utf8"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/config"
@@ -16,6 +18,50 @@ var ConfigCommand = cli.Command{
 	Name:   "config",
 	Usage:  "Displays global configuration values",
 	Action: configAction,
+	Subcommands: []cli.Command{
+		{
+			Name:   "check",
+			Usage:  "Validates paths, database connection, and external tools; exits non-zero on errors",
+			Action: configCheckAction,
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "json, j",
+					Usage: "print report as JSON",
+				},
+			},
+		},
+	},
+}
+
+// configCheckAction validates the configuration and prints a report.
+func configCheckAction(ctx *cli.Context) error {
+	conf := config.NewConfig(ctx)
+
+	results := conf.Check()
+
+	if ctx.Bool("json") {
+		if data, err := json.MarshalIndent(results, "", "  "); err != nil {
+			return err
+		} else {
+			fmt.Println(string(data))
+		}
+	} else {
+		fmt.Printf("%-18s %-8s %s\n", "NAME", "STATUS", "VALUE")
+
+		for _, r := range results {
+			if r.Message == "" {
+				fmt.Printf("%-18s %-8s %s\n", r.Name, r.Status, r.Value)
+			} else {
+				fmt.Printf("%-18s %-8s %s (%s)\n", r.Name, r.Status, r.Value, r.Message)
+			}
+		}
+	}
+
+	if results.Fatal() {
+		return cli.NewExitError(fmt.Sprintf("config: found %s", english.Plural(results.Count(config.CheckError), "error", "errors")), 1)
+	}
+
+	return nil
 }
 
 // configAction lists configuration options and their values.
@@ -29,9 +75,12 @@ func configAction(ctx *cli.Context) error {
 	// Flags.
 	fmt.Printf("%-25s %t\n", "debug", conf.Debug())
 	fmt.Printf("%-25s %s\n", "log-level", conf.LogLevel())
+	fmt.Printf("%-25s %s\n", "log-format", conf.LogFormat())
 	fmt.Printf("%-25s %t\n", "public", conf.Public())
 	fmt.Printf("%-25s %s\n", "admin-password", strings.Repeat("*", utf8.RuneCountInString(conf.AdminPassword())))
 	fmt.Printf("%-25s %t\n", "read-only", conf.ReadOnly())
+	fmt.Printf("%-25s %t\n", "strict-read-only", conf.StrictReadOnly())
+	fmt.Printf("%-25s %t\n", "maintenance", conf.Maintenance())
 	fmt.Printf("%-25s %t\n", "experimental", conf.Experimental())
 
 	// Config.
@@ -41,15 +90,25 @@ func configAction(ctx *cli.Context) error {
 
 	// Paths.
 	fmt.Printf("%-25s %s\n", "originals-path", conf.OriginalsPath())
+	fmt.Printf("%-25s %s\n", "originals-roots", conf.OriginalsRoots())
+	fmt.Printf("%-25s %s\n", "ignore-symlinks", strings.Join(conf.IgnoreSymlinks(), ","))
 	fmt.Printf("%-25s %d\n", "originals-limit", conf.OriginalsLimit())
+	fmt.Printf("%-25s %d\n", "originals-depth", conf.OriginalsDepth())
+	fmt.Printf("%-25s %s\n", "junk-files", strings.Join(conf.JunkFiles(), ","))
 	fmt.Printf("%-25s %s\n", "storage-path", conf.StoragePath())
 	fmt.Printf("%-25s %s\n", "import-path", conf.ImportPath())
+	fmt.Printf("%-25s %s\n", "import-name", conf.ImportName())
 	fmt.Printf("%-25s %s\n", "cache-path", conf.CachePath())
 	fmt.Printf("%-25s %s\n", "sidecar-path", conf.SidecarPath())
+	fmt.Printf("%-25s %s\n", "sidecar-layout", conf.SidecarLayout())
+	fmt.Printf("%-25s %t\n", "sidecar-gzip", conf.SidecarGzip())
+	fmt.Printf("%-25s %t\n", "sidecar-only", conf.SidecarOnly())
 	fmt.Printf("%-25s %s\n", "albums-path", conf.AlbumsPath())
 	fmt.Printf("%-25s %s\n", "temp-path", conf.TempPath())
 	fmt.Printf("%-25s %s\n", "backup-path", conf.BackupPath())
 	fmt.Printf("%-25s %s\n", "assets-path", conf.AssetsPath())
+	fmt.Printf("%-25s %s\n", "tiles-path", conf.TilesPath())
+	fmt.Printf("%-25s %s\n", "tiles-style", conf.TilesStyle())
 
 	// Assets.
 	fmt.Printf("%-25s %s\n", "static-path", conf.StaticPath())
@@ -59,9 +118,19 @@ func configAction(ctx *cli.Context) error {
 
 	// Workers.
 	fmt.Printf("%-25s %d\n", "workers", conf.Workers())
+	fmt.Printf("%-25s %d\n", "index-workers", conf.TaskWorkers(config.TaskIndex))
+	fmt.Printf("%-25s %d\n", "thumb-workers", conf.TaskWorkers(config.TaskThumbs))
+	fmt.Printf("%-25s %d\n", "convert-workers", conf.TaskWorkers(config.TaskConvert))
+	fmt.Printf("%-25s %d\n", "face-workers", conf.TaskWorkers(config.TaskFaces))
+	fmt.Printf("%-25s %d\n", "meta-workers", conf.TaskWorkers(config.TaskMeta))
 	fmt.Printf("%-25s %d\n", "wakeup-interval", conf.WakeupInterval()/time.Second)
 	fmt.Printf("%-25s %d\n", "auto-index", conf.AutoIndex()/time.Second)
 	fmt.Printf("%-25s %d\n", "auto-import", conf.AutoImport()/time.Second)
+	fmt.Printf("%-25s %t\n", "import-review", conf.ImportReview())
+	fmt.Printf("%-25s %d\n", "upload-quota", conf.UploadQuota())
+	fmt.Printf("%-25s %d\n", "download-limit", conf.DownloadLimit())
+	fmt.Printf("%-25s %d\n", "archive-limit", conf.ArchiveLimit())
+	fmt.Printf("%-25s %d\n", "storage-reserve", conf.StorageReserve())
 
 	// Features.
 	fmt.Printf("%-25s %t\n", "disable-backups", conf.DisableBackups())
@@ -75,6 +144,7 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %t\n", "disable-rawtherapee", conf.DisableRawtherapee())
 	fmt.Printf("%-25s %t\n", "disable-sips", conf.DisableSips())
 	fmt.Printf("%-25s %t\n", "disable-heifconvert", conf.DisableHeifConvert())
+	fmt.Printf("%-25s %t\n", "disable-dcmtk", conf.DisableDcmtk())
 	fmt.Printf("%-25s %t\n", "disable-ffmpeg", conf.DisableFFmpeg())
 
 	// TensorFlow.
@@ -82,6 +152,9 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "tensorflow-model-path", conf.TensorFlowModelPath())
 	fmt.Printf("%-25s %t\n", "detect-nsfw", conf.DetectNSFW())
 	fmt.Printf("%-25s %t\n", "upload-nsfw", conf.UploadNSFW())
+	fmt.Printf("%-25s %d\n", "share-upload-limit", conf.ShareUploadLimit())
+	fmt.Printf("%-25s %d\n", "share-upload-files", conf.ShareUploadFiles())
+	fmt.Printf("%-25s %d\n", "share-upload-rate", conf.ShareUploadRate())
 
 	// UI Defaults.
 	fmt.Printf("%-25s %s\n", "default-locale", conf.DefaultLocale())
@@ -114,6 +187,9 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "http-host", conf.HttpHost())
 	fmt.Printf("%-25s %d\n", "http-port", conf.HttpPort())
 	fmt.Printf("%-25s %s\n", "http-mode", conf.HttpMode())
+	fmt.Printf("%-25s %t\n", "dlna", conf.DLNA())
+	fmt.Printf("%-25s %s\n", "dlna-name", conf.DLNAName())
+	fmt.Printf("%-25s %d\n", "dlna-port", conf.DLNAPort())
 
 	// Database.
 	fmt.Printf("%-25s %s\n", "database-driver", dbDriver)
@@ -125,24 +201,53 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "database-password", strings.Repeat("*", utf8.RuneCountInString(conf.DatabasePassword())))
 	fmt.Printf("%-25s %d\n", "database-conns", conf.DatabaseConns())
 	fmt.Printf("%-25s %d\n", "database-conns-idle", conf.DatabaseConnsIdle())
+	fmt.Printf("%-25s %s\n", "sqlite-journal-mode", conf.SqliteJournalMode())
+	fmt.Printf("%-25s %d\n", "sqlite-busy-timeout", conf.SqliteBusyTimeout())
+	fmt.Printf("%-25s %d\n", "sqlite-cache-size", conf.SqliteCacheSize())
+	fmt.Printf("%-25s %d\n", "sqlite-mmap-size", conf.SqliteMmapSize())
+	fmt.Printf("%-25s %s\n", "sqlite-key", strings.Repeat("*", utf8.RuneCountInString(conf.SqliteKey())))
+	fmt.Printf("%-25s %s\n", "sqlite-key-file", conf.SqliteKeyFile())
+	fmt.Printf("%-25s %t\n", "explain", conf.Explain())
+	fmt.Printf("%-25s %s\n", "trace-endpoint", conf.TraceEndpoint())
+	fmt.Printf("%-25s %t\n", "trace-insecure", conf.TraceInsecure())
+	fmt.Printf("%-25s %g\n", "trace-sample-rate", conf.TraceSampleRate())
 
 	// External Tools.
 	fmt.Printf("%-25s %t\n", "raw-presets", conf.RawPresets())
+	fmt.Printf("%-25s %t\n", "raw-previews", conf.RawPreviews())
 	fmt.Printf("%-25s %s\n", "darktable-bin", conf.DarktableBin())
 	fmt.Printf("%-25s %s\n", "darktable-blacklist", conf.DarktableBlacklist())
 	fmt.Printf("%-25s %s\n", "rawtherapee-bin", conf.RawtherapeeBin())
 	fmt.Printf("%-25s %s\n", "rawtherapee-blacklist", conf.RawtherapeeBlacklist())
 	fmt.Printf("%-25s %s\n", "sips-bin", conf.SipsBin())
 	fmt.Printf("%-25s %s\n", "heifconvert-bin", conf.HeifConvertBin())
+	fmt.Printf("%-25s %s\n", "dcmtk-bin", conf.DcmtkBin())
 	fmt.Printf("%-25s %s\n", "ffmpeg-bin", conf.FFmpegBin())
 	fmt.Printf("%-25s %s\n", "ffmpeg-encoder", conf.FFmpegEncoder())
 	fmt.Printf("%-25s %d\n", "ffmpeg-bitrate", conf.FFmpegBitrate())
+	fmt.Printf("%-25s %s\n", "ffmpeg-max-duration", conf.FFmpegMaxDuration())
 	fmt.Printf("%-25s %d\n", "ffmpeg-buffers", conf.FFmpegBuffers())
+	fmt.Printf("%-25s %t\n", "ffmpeg-hls", conf.FFmpegHls())
+	fmt.Printf("%-25s %t\n", "ffmpeg-sprite", conf.FFmpegSprite())
+	fmt.Printf("%-25s %d\n", "video-cache-limit", conf.VideoCacheLimit())
 	fmt.Printf("%-25s %s\n", "exiftool-bin", conf.ExifToolBin())
+	fmt.Printf("%-25s %s\n", "metadata-cmd", conf.MetadataCmd())
+	fmt.Printf("%-25s %s\n", "metadata-ext", conf.MetadataExt())
+	fmt.Printf("%-25s %s\n", "exif-writeback", strings.Join(conf.ExifWriteback(), ","))
+	fmt.Printf("%-25s %s\n", "metadata-priority", conf.MetadataPriorityString())
 
 	// Thumbnails.
+	fmt.Printf("%-25s %s\n", "share-redact", strings.Join(conf.ShareRedact(), ","))
 	fmt.Printf("%-25s %s\n", "download-token", conf.DownloadToken())
 	fmt.Printf("%-25s %s\n", "preview-token", conf.PreviewToken())
+	fmt.Printf("%-25s %s\n", "token-lifetime", conf.TokenLifetime())
+	fmt.Printf("%-25s %s\n", "push-public-key", conf.PushPublicKey())
+	fmt.Printf("%-25s %s\n", "push-private-key", strings.Repeat("*", utf8.RuneCountInString(conf.PushPrivateKey())))
+	fmt.Printf("%-25s %s\n", "push-subject", conf.PushSubject())
+	fmt.Printf("%-25s %s\n", "print-url", conf.PrintUrl())
+	fmt.Printf("%-25s %s\n", "print-token", strings.Repeat("*", utf8.RuneCountInString(conf.PrintToken())))
+	fmt.Printf("%-25s %s\n", "thumb-library", conf.ThumbLibrary())
+	fmt.Printf("%-25s %s\n", "vips-bin", conf.VipsBin())
 	fmt.Printf("%-25s %s\n", "thumb-filter", conf.ThumbFilter())
 	fmt.Printf("%-25s %t\n", "thumb-uncached", conf.ThumbUncached())
 	fmt.Printf("%-25s %d\n", "thumb-size", conf.ThumbSizePrecached())
@@ -160,6 +265,8 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %d\n", "face-cluster-core", conf.FaceClusterCore())
 	fmt.Printf("%-25s %f\n", "face-cluster-dist", conf.FaceClusterDist())
 	fmt.Printf("%-25s %f\n", "face-match-dist", conf.FaceMatchDist())
+	fmt.Printf("%-25s %d\n", "face-keyframes", conf.FaceKeyframes())
+	fmt.Printf("%-25s %s\n", "caption-uri", conf.CaptionUri())
 
 	// Daemon Mode.
 	fmt.Printf("%-25s %s\n", "pid-filename", conf.PIDFilename())
@@ -0,0 +1,268 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/manifoldco/promptui"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	pfs "github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// ConsoleCommand registers the interactive console cli command.
+var ConsoleCommand = cli.Command{
+	Name:        "console",
+	Usage:       "Starts an interactive console for common maintenance tasks",
+	Description: "Index, purge, faces, and backup tasks can be selected from a menu and are started after confirmation.",
+	Action:      consoleAction,
+}
+
+// consoleTask represents a maintenance task that can be started from the console.
+type consoleTask struct {
+	Name    string
+	Details string
+	Confirm string
+	Run     func(conf *config.Config) (string, error)
+}
+
+// consoleTasks contains the tasks shown in the console menu, an empty Run function quits the console.
+var consoleTasks = []consoleTask{
+	{
+		Name:    "Index",
+		Details: "Indexes new and changed files in the originals folder",
+		Confirm: "Index originals",
+		Run:     consoleIndex,
+	},
+	{
+		Name:    "Purge",
+		Details: "Updates missing files, photo counts, and album covers",
+		Confirm: "Purge missing files",
+		Run:     consolePurge,
+	},
+	{
+		Name:    "Faces",
+		Details: "Performs face clustering and matching",
+		Confirm: "Update faces",
+		Run:     consoleFaces,
+	},
+	{
+		Name:    "Backup",
+		Details: "Creates an index SQL dump and album YAML files",
+		Confirm: "Create backup",
+		Run:     consoleBackup,
+	},
+	{
+		Name:    "Quit",
+		Details: "Leaves the console",
+	},
+}
+
+// consoleAction starts the interactive console.
+func consoleAction(ctx *cli.Context) error {
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	menu := promptui.Select{
+		Label: "Select a task",
+		Items: consoleTasks,
+		Size:  len(consoleTasks),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}",
+			Active:   "> {{ .Name | cyan }}",
+			Inactive: "  {{ .Name }}",
+			Selected: "{{ .Name | green }}",
+			Details:  "{{ .Details | faint }}",
+		},
+	}
+
+	for {
+		i, _, err := menu.Run()
+
+		// Quit if interrupted, e.g. with Ctrl+C.
+		if err != nil || consoleTasks[i].Run == nil {
+			return nil
+		}
+
+		task := consoleTasks[i]
+
+		confirm := promptui.Prompt{
+			Label:     task.Confirm,
+			IsConfirm: true,
+		}
+
+		if _, err := confirm.Run(); err != nil {
+			continue
+		}
+
+		start := time.Now()
+
+		// Log messages would interfere with the progress bar.
+		level := log.GetLevel()
+		log.SetLevel(logrus.WarnLevel)
+
+		result, err := task.Run(conf)
+
+		log.SetLevel(level)
+
+		if err != nil {
+			fmt.Printf("%s failed: %s\n\n", task.Name, err)
+		} else {
+			fmt.Printf("%s in %s\n\n", result, time.Since(start).Truncate(time.Millisecond))
+		}
+	}
+}
+
+// countMedia returns the number of media files in a folder, skipping hidden files and folders.
+func countMedia(dir string) (count int) {
+	_ = filepath.WalkDir(dir, func(fileName string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if strings.HasPrefix(d.Name(), ".") && fileName != dir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !d.IsDir() && pfs.IsMedia(fileName) {
+			count++
+		}
+
+		return nil
+	})
+
+	return count
+}
+
+// consoleIndex indexes new and changed originals.
+func consoleIndex(conf *config.Config) (string, error) {
+	bar := newProgressBar(os.Stdout, "Indexing", countMedia(conf.OriginalsPath()))
+
+	s := event.Subscribe("index.indexing")
+	received := make(chan struct{})
+
+	go func() {
+		for range s.Receiver {
+			bar.Add(1)
+		}
+
+		close(received)
+	}()
+
+	indexed := service.Index().Start(photoprism.IndexOptions{
+		Path:    "/",
+		Rescan:  false,
+		Convert: conf.Settings().Index.Convert && conf.SidecarWritable(),
+		Stack:   true,
+	})
+
+	// Wait until all events have been received before completing the progress bar.
+	event.Unsubscribe(s)
+	<-received
+
+	bar.Finish()
+
+	return fmt.Sprintf("indexed %s", english.Plural(len(indexed), "file", "files")), nil
+}
+
+// consolePurge updates missing files, photo counts, and album covers.
+func consolePurge(conf *config.Config) (result string, err error) {
+	bar := newProgressBar(os.Stdout, "Purging", 0)
+
+	err = bar.Spin(func() error {
+		files, photos, err := service.Purge().Start(photoprism.PurgeOptions{})
+
+		result = fmt.Sprintf("purged %s and %s", english.Plural(len(files), "file", "files"), english.Plural(len(photos), "photo", "photos"))
+
+		return err
+	})
+
+	return result, err
+}
+
+// consoleFaces performs face clustering and matching.
+func consoleFaces(conf *config.Config) (string, error) {
+	if conf.DisableFaces() {
+		return "", fmt.Errorf("facial recognition is disabled")
+	}
+
+	bar := newProgressBar(os.Stdout, "Updating faces", 0)
+
+	err := bar.Spin(func() error {
+		return service.Faces().Start(photoprism.FacesOptionsDefault())
+	})
+
+	return "faces updated", err
+}
+
+// consoleBackup creates an index SQL dump and album YAML files.
+func consoleBackup(conf *config.Config) (result string, err error) {
+	indexFileName := filepath.Join(conf.BackupPath(), conf.DatabaseDriver(), time.Now().UTC().Format("2006-01-02")+".sql")
+
+	// SQL dumps are plain text, even if the index is encrypted.
+	if conf.SqliteEncrypted() {
+		unencrypted := promptui.Prompt{
+			Label:     "Create an unencrypted SQL dump of the encrypted index",
+			IsConfirm: true,
+		}
+
+		if _, err := unencrypted.Run(); err != nil {
+			return "", fmt.Errorf("backup canceled")
+		}
+	}
+
+	if pfs.FileExists(indexFileName) {
+		replace := promptui.Prompt{
+			Label:     fmt.Sprintf("Replace existing %s", sanitize.Log(filepath.Base(indexFileName))),
+			IsConfirm: true,
+		}
+
+		if _, err := replace.Run(); err != nil {
+			return "", fmt.Errorf("SQL dump already exists")
+		}
+	}
+
+	bar := newProgressBar(os.Stdout, "Creating backup", 0)
+
+	err = bar.Spin(func() error {
+		if err := os.MkdirAll(filepath.Dir(indexFileName), os.ModePerm); err != nil {
+			return err
+		} else if err := dumpIndex(conf, indexFileName); err != nil {
+			return err
+		}
+
+		count, err := photoprism.BackupAlbums(conf.AlbumsPath(), true)
+
+		result = fmt.Sprintf("saved SQL dump and %s", english.Plural(count, "album file", "album files"))
+
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+// DLNACommand registers the dlna cli command.
+var DLNACommand = cli.Command{
+	Name:  "dlna",
+	Usage: "DLNA media server subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:   "reset",
+			Usage:  "Replaces the media server token, so that previously loaded media URLs stop working",
+			Action: dlnaResetAction,
+		},
+	},
+}
+
+// dlnaResetAction replaces the DLNA media server token.
+func dlnaResetAction(ctx *cli.Context) error {
+	conf := config.NewConfig(ctx)
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	if err := conf.ResetDLNAToken(); err != nil {
+		return err
+	}
+
+	log.Infof("dlna: replaced media server token, restart the server and browse on your smart tv again")
+
+	return nil
+}
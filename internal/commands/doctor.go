@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+const doctorDescription = "Collects version info, the configuration, database statistics, recent errors, and environment details " +
+	"in a ZIP archive that can be attached to bug reports.\n" +
+	"   Passwords, tokens, host names, email and IP addresses as well as paths are removed or replaced with placeholders."
+
+// DoctorCommand registers the doctor cli command.
+var DoctorCommand = cli.Command{
+	Name:        "doctor",
+	Description: doctorDescription,
+	Usage:       "Creates a redacted diagnostics archive for bug reports",
+	ArgsUsage:   "[FILENAME]",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "errors, e",
+			Usage: "maximum `NUMBER` of recent errors to include",
+			Value: 100,
+		},
+		cli.BoolFlag{
+			Name:  "force, f",
+			Usage: "replace existing file",
+		},
+	},
+	Action: doctorAction,
+}
+
+// doctorAction creates a diagnostics archive.
+func doctorAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	fileName := strings.TrimSpace(ctx.Args().First())
+
+	if fileName == "" {
+		fileName = fmt.Sprintf("photoprism-doctor-%s.zip", start.UTC().Format("20060102-150405"))
+	}
+
+	fileName = fs.Abs(fileName)
+
+	if !ctx.Bool("force") && fs.FileExists(fileName) {
+		return fmt.Errorf("%s already exists", filepath.Base(fileName))
+	}
+
+	// The archive should still be created if the configuration is broken.
+	initErr := conf.Init()
+
+	if initErr == nil {
+		conf.InitDb()
+		defer conf.Shutdown()
+	}
+
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	z := zip.NewWriter(f)
+
+	// Version and environment details.
+	version := fmt.Sprintf("%s %s\n%s %s/%s\n", conf.Name(), conf.Version(), runtime.Version(), runtime.GOOS, runtime.GOARCH)
+
+	if err := doctorAdd(z, "version.txt", []byte(version)); err != nil {
+		return err
+	}
+
+	env := map[string]interface{}{
+		"Runtime":  config.NewRuntimeInfo(),
+		"Docker":   fs.FileExists("/.dockerenv"),
+		"Driver":   conf.DatabaseDriver(),
+		"ReadOnly": conf.ReadOnly(),
+		"Created":  start.UTC(),
+	}
+
+	if initErr != nil {
+		env["InitError"] = conf.Redact(initErr.Error())
+	}
+
+	if err := doctorAddJSON(z, "environment.json", env); err != nil {
+		return err
+	}
+
+	// Configuration options and check results.
+	if data, err := yaml.Marshal(conf.RedactedOptions()); err != nil {
+		return err
+	} else if err := doctorAdd(z, "config.yml", data); err != nil {
+		return err
+	}
+
+	results := conf.Check()
+
+	for i := range results {
+		results[i].Value = conf.Redact(results[i].Value)
+		results[i].Message = conf.Redact(results[i].Message)
+	}
+
+	if err := doctorAddJSON(z, "check.json", results); err != nil {
+		return err
+	}
+
+	// Database statistics and recent errors require a working database connection.
+	if initErr == nil {
+		if err := doctorAddJSON(z, "stats.json", conf.UserConfig().Count); err != nil {
+			return err
+		}
+
+		var b strings.Builder
+
+		if entries, err := query.Errors(ctx.Int("errors"), 0, ""); err != nil {
+			log.Warnf("doctor: %s (errors)", err)
+		} else {
+			for _, e := range entries {
+				b.WriteString(fmt.Sprintf("%s %-7s %s\n", e.ErrorTime.UTC().Format(time.RFC3339), e.ErrorLevel, conf.Redact(e.ErrorMessage)))
+			}
+		}
+
+		if err := doctorAdd(z, "errors.txt", []byte(b.String())); err != nil {
+			return err
+		}
+	}
+
+	if err := z.Close(); err != nil {
+		return err
+	}
+
+	log.Infof("doctor: created %s [%s]", fileName, time.Since(start))
+
+	return nil
+}
+
+// doctorAdd adds a file with the given content to the archive.
+func doctorAdd(z *zip.Writer, name string, data []byte) error {
+	w, err := z.Create(name)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
+
+// doctorAddJSON adds a JSON file to the archive.
+func doctorAddJSON(z *zip.Writer, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return doctorAdd(z, name, data)
+}
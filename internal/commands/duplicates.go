@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// DuplicatesCommand registers the duplicates cli command.
+var DuplicatesCommand = cli.Command{
+	Name:      "duplicates",
+	Usage:     "Detects folders whose files all have identical copies elsewhere in originals",
+	ArgsUsage: "[PATH]",
+	Action:    duplicatesAction,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "merge, m",
+			Usage: "remove duplicate folders and update the index so that it refers to the copies",
+		},
+		cli.BoolFlag{
+			Name:  "symlinks, s",
+			Usage: "replace duplicate files with symbolic links when merging instead of removing them",
+		},
+	},
+}
+
+// duplicatesAction reports duplicate folders and optionally merges them.
+func duplicatesAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		pathName := strings.Trim(ctx.Args().First(), "/")
+		merge := ctx.Bool("merge")
+		symlinks := ctx.Bool("symlinks")
+
+		if conf.ReadOnly() && merge {
+			return config.ErrReadOnly
+		}
+
+		folders, err := photoprism.FindDuplicateFolders(pathName)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("duplicates: found %s, the index should be up to date", english.Plural(len(folders), "duplicate folder", "duplicate folders"))
+
+		if len(folders) == 0 {
+			return nil
+		}
+
+		fmt.Printf("%-40s %-6s %-10s %s\n", "FOLDER", "FILES", "SIZE", "COPIES IN")
+
+		for _, f := range folders {
+			fmt.Printf("%-40s %-6d %-10s %s\n", "/"+f.Path, len(f.Files), humanize.Bytes(uint64(f.Size)), "/"+strings.Join(f.CopyPaths(), ", /"))
+		}
+
+		if !merge {
+			return nil
+		}
+
+		merged := 0
+
+		for _, f := range folders {
+			if err := photoprism.MergeDuplicateFolder(f, symlinks); err != nil {
+				log.Errorf("duplicates: %s while merging %s", err, sanitize.Log(f.Path))
+				continue
+			}
+
+			merged++
+		}
+
+		log.Infof("duplicates: merged %s", english.Plural(merged, "folder", "folders"))
+
+		return nil
+	})
+}
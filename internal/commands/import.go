@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"errors"
+	"fmt"
 	"path/filepath"
 	"strings"
 	"time"
@@ -12,14 +13,15 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 // ImportCommand registers the import cli command.
 var ImportCommand = cli.Command{
 	Name:      "mv",
 	Aliases:   []string{"import"},
-	Usage:     "Moves media files to originals",
-	ArgsUsage: "[PATH]",
+	Usage:     "Moves media files to originals, ZIP and TAR archives are extracted",
+	ArgsUsage: "[PATH|ARCHIVE]",
 	Action:    importAction,
 }
 
@@ -63,9 +65,31 @@ func importAction(ctx *cli.Context) error {
 		return errors.New("import path is identical with originals")
 	}
 
+	w := service.Import()
+
+	// Extract archives and import the files, keeping their folder structure.
+	if fs.IsArchive(sourcePath) && fs.FileExists(sourcePath) {
+		log.Infof("importing media files from %s to %s", sourcePath, conf.OriginalsPath())
+
+		results, err := w.Archive(sourcePath, nil)
+
+		if err != nil {
+			return err
+		}
+
+		for _, r := range results {
+			fmt.Printf("%-10s %-40s %s\n", r.Status, r.FileName, r.Original)
+		}
+
+		log.Infof("import completed in %s", time.Since(start))
+
+		conf.Shutdown()
+
+		return nil
+	}
+
 	log.Infof("moving media files from %s to %s", sourcePath, conf.OriginalsPath())
 
-	w := service.Import()
 	opt := photoprism.ImportOptionsMove(sourcePath)
 
 	w.Start(opt)
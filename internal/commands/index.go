@@ -31,10 +31,22 @@ var indexFlags = []cli.Flag{
 		Name:  "force, f",
 		Usage: "re-index all originals, including unchanged files",
 	},
+	cli.StringFlag{
+		Name:  "filter",
+		Usage: "re-index files of photos matching a search `FILTER` only, e.g. \"camera:2\" or \"missing-location\"",
+	},
+	cli.StringFlag{
+		Name:  "stages",
+		Usage: "re-run the comma-separated pipeline `STAGES` only for files that have been indexed before: meta, labels, faces",
+	},
 	cli.BoolFlag{
 		Name:  "cleanup, c",
 		Usage: "remove orphan index entries and thumbnails",
 	},
+	cli.BoolFlag{
+		Name:  "full",
+		Usage: "check all files for removal during cleanup, including those in unchanged folders",
+	},
 }
 
 // indexAction indexes all photos in originals directory (photo library)
@@ -56,7 +68,17 @@ func indexAction(ctx *cli.Context) error {
 	// Use first argument to limit scope if set.
 	subPath := strings.TrimSpace(ctx.Args().First())
 
-	if subPath == "" {
+	filter := strings.TrimSpace(ctx.String("filter"))
+
+	stages, err := photoprism.ParseIndexStages(ctx.String("stages"))
+
+	if err != nil {
+		return err
+	}
+
+	if filter != "" {
+		log.Infof("indexing originals matching %s", sanitize.Log(filter))
+	} else if subPath == "" {
 		log.Infof("indexing originals in %s", sanitize.Log(conf.OriginalsPath()))
 	} else {
 		log.Infof("indexing originals in %s", sanitize.Log(filepath.Join(conf.OriginalsPath(), subPath)))
@@ -71,6 +93,8 @@ func indexAction(ctx *cli.Context) error {
 	if w := service.Index(); w != nil {
 		opt := photoprism.IndexOptions{
 			Path:    subPath,
+			Filter:  filter,
+			Stages:  stages,
 			Rescan:  ctx.Bool("force"),
 			Convert: conf.Settings().Index.Convert && conf.SidecarWritable(),
 			Stack:   true,
@@ -84,6 +108,7 @@ func indexAction(ctx *cli.Context) error {
 		opt := photoprism.PurgeOptions{
 			Path:   subPath,
 			Ignore: indexed,
+			Full:   ctx.Bool("full"),
 		}
 
 		if files, photos, err := w.Start(opt); err != nil {
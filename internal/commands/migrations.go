@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/migrate"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// MigrationsCommand registers the database schema migration subcommands.
+var MigrationsCommand = cli.Command{
+	Name:  "migrations",
+	Usage: "Database schema migration subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:    "status",
+			Aliases: []string{"ls"},
+			Usage:   "Lists migrations and their status",
+			Action:  migrationsStatusAction,
+		},
+		{
+			Name:      "rollback",
+			Usage:     "Reverts the most recent or the specified migration, unless AutoMigrate has changed the schema since",
+			ArgsUsage: "[ID]",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "force, f",
+					Usage: "don't ask for confirmation",
+				},
+			},
+			Action: migrationsRollbackAction,
+		},
+		{
+			Name:   "encrypt",
+			Usage:  "Encrypts an existing SQLite index with the configured sqlite key",
+			Action: migrationsEncryptAction,
+		},
+		{
+			Name:   "sidecars",
+			Usage:  "Moves existing YAML sidecar files to the configured layout and compression",
+			Action: migrationsSidecarsAction,
+		},
+	},
+}
+
+// migrationsStatusAction lists migrations and their status.
+func migrationsStatusAction(ctx *cli.Context) error {
+	conf := config.NewConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	db := conf.Db()
+	migrations, ok := migrate.Dialects[db.Dialect().GetName()]
+
+	if !ok {
+		return fmt.Errorf("unsupported database dialect %s", db.Dialect().GetName())
+	}
+
+	newer := make(map[string]bool)
+
+	for _, id := range migrate.Newer(db) {
+		newer[id] = true
+	}
+
+	fmt.Printf("%-16s %-8s %-20s %-4s %s\n", "ID", "STATUS", "FINISHED", "DOWN", "ERROR")
+
+	for _, m := range migrations.Status(db) {
+		status, finished, down := m.Status(), "", "no"
+
+		if m.FinishedAt != nil {
+			finished = m.FinishedAt.Format("2006-01-02 15:04:05")
+		}
+
+		if m.Reversible() {
+			down = "yes"
+		}
+
+		// Migrations added by a newer version can only be rolled back with that version.
+		if newer[m.ID] {
+			status, down = "newer", "?"
+		} else if m.Auto() {
+			status = "auto"
+		}
+
+		fmt.Printf("%-16s %-8s %-20s %-4s %s\n", m.ID, status, finished, down, m.Error)
+	}
+
+	return nil
+}
+
+// migrationsRollbackAction reverts a migration. This is refused if the schema has been changed by
+// AutoMigrate since the migration was executed, as these changes are not versioned.
+func migrationsRollbackAction(ctx *cli.Context) error {
+	id := ctx.Args().First()
+
+	if !ctx.Bool("force") {
+		label := "Revert the most recent migration?"
+
+		if id != "" {
+			label = fmt.Sprintf("Revert migration %s?", id)
+		}
+
+		actionPrompt := promptui.Prompt{
+			Label:     label,
+			IsConfirm: true,
+		}
+
+		if _, err := actionPrompt.Run(); err != nil {
+			return nil
+		}
+	}
+
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	db := conf.Db()
+	migrations, ok := migrate.Dialects[db.Dialect().GetName()]
+
+	if !ok {
+		return fmt.Errorf("unsupported database dialect %s", db.Dialect().GetName())
+	}
+
+	m, err := migrations.Rollback(db, id)
+
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	log.Infof("migration %s rolled back in %s", m.ID, time.Since(start))
+
+	return nil
+}
+
+// migrationsEncryptAction encrypts an existing unencrypted SQLite index database.
+func migrationsEncryptAction(ctx *cli.Context) error {
+	conf := config.NewConfig(ctx)
+
+	// The index must not be opened, as the key cannot be used until it is encrypted.
+	if !config.SqlitePlaintext(conf.SqliteFile()) {
+		return fmt.Errorf("%s is not an unencrypted SQLite database", sanitize.Log(conf.SqliteFile()))
+	}
+
+	start := time.Now()
+
+	if err := conf.SqliteEncrypt(); err != nil {
+		return err
+	}
+
+	log.Infof("migrate: encrypted index database in %s", time.Since(start))
+
+	return nil
+}
+
+// migrationsSidecarsAction moves existing YAML sidecar files to the configured layout.
+func migrationsSidecarsAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	log.Infof("migrate: moving sidecar files in %s to the %s layout", sanitize.Log(conf.SidecarPath()), conf.SidecarLayout())
+
+	res, err := photoprism.MigrateYaml(conf.OriginalsPath(), conf.SidecarPath())
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("migrate: %d sidecar files moved, %d outdated removed, %d not found, %d failed [%s]",
+		res.Moved, res.Removed, res.NotFound, res.Failed, time.Since(start))
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// progressWidth is the width of progress bars in characters.
+const progressWidth = 30
+
+// progressFrames are shown in turn if the total amount of work is unknown.
+var progressFrames = []string{"|", "/", "-", "\\"}
+
+// progressBar shows the progress of a console task on a single line.
+type progressBar struct {
+	mu    sync.Mutex
+	out   io.Writer
+	label string
+	total int
+	done  int
+	frame int
+	start time.Time
+}
+
+// newProgressBar returns a new progress bar, a total of 0 shows a spinner instead.
+func newProgressBar(out io.Writer, label string, total int) *progressBar {
+	return &progressBar{out: out, label: label, total: total, start: time.Now()}
+}
+
+// String returns the progress bar as string.
+func (p *progressBar) String() string {
+	elapsed := time.Since(p.start).Truncate(time.Second)
+
+	if p.total <= 0 {
+		return fmt.Sprintf("%s %s %s", p.label, progressFrames[p.frame%len(progressFrames)], elapsed)
+	}
+
+	done := p.done
+
+	// Related files may be found in addition to the files counted beforehand.
+	if done > p.total {
+		done = p.total
+	}
+
+	filled := done * progressWidth / p.total
+
+	return fmt.Sprintf("%s [%s%s] %3d%% (%d/%d) %s", p.label,
+		strings.Repeat("=", filled), strings.Repeat(" ", progressWidth-filled),
+		done*100/p.total, done, p.total, elapsed)
+}
+
+// render redraws the progress bar, the lock must be held.
+func (p *progressBar) render() {
+	_, _ = fmt.Fprintf(p.out, "\r\033[K%s", p.String())
+}
+
+// Add adds to the amount of work done.
+func (p *progressBar) Add(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done += n
+	p.render()
+}
+
+// Tick shows the next spinner frame.
+func (p *progressBar) Tick() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.frame++
+	p.render()
+}
+
+// Finish shows the progress bar as complete and ends the line.
+func (p *progressBar) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.done < p.total {
+		p.done = p.total
+	}
+
+	p.render()
+
+	_, _ = fmt.Fprintln(p.out)
+}
+
+// Spin shows the spinner until the function returns.
+func (p *progressBar) Spin(run func() error) error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- run()
+	}()
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-done:
+			p.Finish()
+			return err
+		case <-ticker.C:
+			p.Tick()
+		}
+	}
+}
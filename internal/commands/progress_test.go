@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressBar(t *testing.T) {
+	t.Run("Total", func(t *testing.T) {
+		var out bytes.Buffer
+		bar := newProgressBar(&out, "Indexing", 4)
+
+		assert.Contains(t, bar.String(), "Indexing [                              ]   0% (0/4)")
+
+		bar.Add(1)
+
+		assert.Contains(t, bar.String(), "Indexing [=======                       ]  25% (1/4)")
+		assert.Contains(t, out.String(), "25% (1/4)")
+
+		bar.Add(4)
+
+		assert.Contains(t, bar.String(), " 100% (4/4)")
+
+		bar.Finish()
+
+		assert.True(t, bytes.HasSuffix(out.Bytes(), []byte("\n")))
+	})
+	t.Run("Spinner", func(t *testing.T) {
+		var out bytes.Buffer
+		bar := newProgressBar(&out, "Purging", 0)
+
+		assert.Contains(t, bar.String(), "Purging |")
+
+		bar.Tick()
+
+		assert.Contains(t, bar.String(), "Purging /")
+	})
+	t.Run("Spin", func(t *testing.T) {
+		var out bytes.Buffer
+		bar := newProgressBar(&out, "Purging", 0)
+
+		err := bar.Spin(func() error {
+			return errors.New("failed")
+		})
+
+		assert.EqualError(t, err, "failed")
+		assert.Contains(t, out.String(), "Purging")
+	})
+}
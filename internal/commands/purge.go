@@ -34,6 +34,10 @@ var purgeFlags = []cli.Flag{
 		Name:  "dry",
 		Usage: "dry run, don't actually remove anything",
 	},
+	cli.BoolFlag{
+		Name:  "full",
+		Usage: "check all files, including those in unchanged folders",
+	},
 }
 
 // purgeAction removes missing files from search results
@@ -71,6 +75,7 @@ func purgeAction(ctx *cli.Context) error {
 		Path: subPath,
 		Dry:  ctx.Bool("dry"),
 		Hard: ctx.Bool("hard"),
+		Full: ctx.Bool("full"),
 	}
 
 	if files, photos, err := w.Start(opt); err != nil {
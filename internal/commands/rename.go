@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// RenameCommand registers the rename cli command.
+var RenameCommand = cli.Command{
+	Name:      "rename",
+	Usage:     "Renames indexed originals based on the import file name template",
+	ArgsUsage: "[PATH]",
+	Action:    renameAction,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only show the new file names without renaming any files",
+		},
+	},
+}
+
+// renameAction renames indexed originals and updates the index.
+func renameAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	if conf.ImportName() == "" {
+		log.Infof("rename: using canonical file names, see import-name option")
+	} else {
+		log.Infof("rename: using file name template %s", conf.ImportName())
+	}
+
+	opt := photoprism.RenameOptions{
+		Path:   strings.TrimSpace(ctx.Args().First()),
+		DryRun: ctx.Bool("dry-run"),
+	}
+
+	renamed, err := photoprism.NewRename(conf).Start(opt)
+
+	if err != nil {
+		return err
+	}
+
+	if len(renamed) > 0 {
+		fmt.Printf("%-50s %s\n", "FROM", "TO")
+
+		for _, f := range renamed {
+			fmt.Printf("%-50s %s\n", f.From, f.To)
+		}
+	}
+
+	if opt.DryRun {
+		log.Infof("rename: %s would be renamed [%s]", english.Plural(len(renamed), "file", "files"), time.Since(start))
+	} else {
+		log.Infof("rename: renamed %s [%s]", english.Plural(len(renamed), "file", "files"), time.Since(start))
+	}
+
+	return nil
+}
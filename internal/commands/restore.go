@@ -174,7 +174,7 @@ func restoreAction(ctx *cli.Context) error {
 
 		go func() {
 			defer stdin.Close()
-			if _, err := io.WriteString(stdin, string(sqlBackup)); err != nil {
+			if _, err := io.WriteString(stdin, sqliteInput(conf, string(sqlBackup))); err != nil {
 				log.Errorf(err.Error())
 			}
 		}()
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// RestoreMetaCommand configures the command name, flags, and action.
+var RestoreMetaCommand = cli.Command{
+	Name:      "restore-meta",
+	Usage:     "Applies metadata from YAML and JSON sidecar files to the index",
+	ArgsUsage: "[PATH]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "force, f",
+			Usage: "overwrite photos that have been edited after the sidecar file was saved",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run, n",
+			Usage: "show what would be restored without changing the index",
+		},
+	},
+	Action: restoreMetaAction,
+}
+
+// restoreMetaAction applies metadata from sidecar files to matching photos in the index.
+func restoreMetaAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	sidecarPath := ctx.Args().First()
+
+	if sidecarPath == "" {
+		sidecarPath = conf.SidecarPath()
+	}
+
+	dryRun := ctx.Bool("dry-run")
+
+	if dryRun {
+		log.Infof("restore: checking sidecar files in %s (dry run)", sanitize.Log(sidecarPath))
+	} else {
+		log.Infof("restore: applying metadata from sidecar files in %s", sanitize.Log(sidecarPath))
+	}
+
+	res, err := photoprism.RestoreMeta(sidecarPath, ctx.Bool("force"), dryRun)
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("restore: %s restored, %d skipped, %d not found, %d failed [%s]",
+		english.Plural(res.Restored, "photo", "photos"), res.Skipped, res.NotFound, res.Failed, time.Since(start))
+
+	return nil
+}
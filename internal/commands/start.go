@@ -9,14 +9,18 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/sevlyar/go-daemon"
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/auto"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/dlna"
+	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/server"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/tiles"
 	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/sanitize"
@@ -107,10 +111,24 @@ func startAction(ctx *cli.Context) error {
 		log.Infof("config: read-only mode enabled")
 	}
 
+	if conf.Maintenance() {
+		mutex.Maintenance.Enable()
+		log.Infof("config: maintenance mode enabled, workers are paused")
+	}
+
 	// start web server
 	go server.Start(cctx, conf)
 
-	if count, err := photoprism.RestoreAlbums(conf.AlbumsPath(), false); err != nil {
+	// Start and announce the DLNA media server so that smart TVs can find it.
+	if conf.DLNA() {
+		go server.StartDLNA(cctx, conf)
+		go dlna.Start(cctx, conf)
+	}
+
+	// Rebuild the index from originals and sidecar files in sidecar-only mode.
+	if conf.SidecarOnly() {
+		go rebuildIndex(conf)
+	} else if count, err := photoprism.RestoreAlbums(conf.AlbumsPath(), false); err != nil {
 		log.Errorf("restore: %s", err)
 	} else if count > 0 {
 		log.Infof("%d albums restored", count)
@@ -131,6 +149,7 @@ func startAction(ctx *cli.Context) error {
 	auto.Stop()
 
 	log.Info("shutting down...")
+	tiles.CloseAll()
 	conf.Shutdown()
 	cancel()
 	err := dctx.Release()
@@ -143,3 +162,29 @@ func startAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// rebuildIndex indexes all originals, restoring photo metadata from YAML sidecar files, and
+// then restores the albums, so that their photos can be found.
+func rebuildIndex(conf *config.Config) {
+	start := time.Now()
+
+	log.Infof("index: rebuilding from originals and sidecar files")
+
+	var indexed fs.Done
+
+	if w := service.Index(); w != nil {
+		indexed = w.Start(photoprism.IndexOptions{
+			Convert: conf.Settings().Index.Convert && conf.SidecarWritable(),
+			Stack:   true,
+		})
+	}
+
+	// Existing albums are skipped, e.g. folders that have been created while indexing.
+	if count, err := photoprism.RestoreAlbums(conf.AlbumsPath(), true); err != nil {
+		log.Errorf("restore: %s", err)
+	} else if count > 0 {
+		log.Infof("%d albums restored", count)
+	}
+
+	log.Infof("index: rebuilt from %s [%s]", english.Plural(len(indexed), "file", "files"), time.Since(start))
+}
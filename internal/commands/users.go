@@ -5,15 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize/english"
 	"github.com/manifoldco/promptui"
 	"github.com/urfave/cli"
 
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
@@ -48,12 +52,19 @@ var UsersCommand = cli.Command{
 					Name:  "email, m",
 					Usage: "sets the users email",
 				},
+				cli.StringFlag{
+					Name:  "role, r",
+					Usage: fmt.Sprintf("user `ROLE` (%s)", strings.Join(entity.UserRoles, ", ")),
+					Value: string(acl.RoleAdmin),
+				},
 			},
 		},
 		{
-			Name:   "update",
-			Usage:  "Updates user information",
-			Action: usersUpdateAction,
+			Name:      "modify",
+			Aliases:   []string{"update"},
+			Usage:     "Modifies user information",
+			Action:    usersModifyAction,
+			ArgsUsage: "[USERNAME]",
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  "fullname, n",
@@ -67,13 +78,44 @@ var UsersCommand = cli.Command{
 					Name:  "email, m",
 					Usage: "sets the users email",
 				},
+				cli.StringFlag{
+					Name:  "role, r",
+					Usage: fmt.Sprintf("user `ROLE` (%s)", strings.Join(entity.UserRoles, ", ")),
+				},
+				cli.BoolFlag{
+					Name:  "disable",
+					Usage: "disables login for the user",
+				},
+				cli.BoolFlag{
+					Name:  "enable",
+					Usage: "enables login for the user",
+				},
 			},
 		},
 		{
-			Name:      "delete",
+			Name:      "remove",
+			Aliases:   []string{"delete"},
 			Usage:     "Removes an existing user",
-			Action:    usersDeleteAction,
+			Action:    usersRemoveAction,
 			ArgsUsage: "[USERNAME]",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "force, f",
+					Usage: "removes the user without confirmation",
+				},
+			},
+		},
+		{
+			Name:      "reset-password",
+			Usage:     "Sets a new password, a random password is generated and displayed if none is given",
+			Action:    usersResetPasswordAction,
+			ArgsUsage: "[USERNAME]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "password, p",
+					Usage: "new password",
+				},
+			},
 		},
 	},
 }
@@ -86,6 +128,7 @@ func usersAddAction(ctx *cli.Context) error {
 			FullName: strings.TrimSpace(ctx.String("fullname")),
 			Email:    strings.TrimSpace(ctx.String("email")),
 			Password: strings.TrimSpace(ctx.String("password")),
+			Role:     strings.TrimSpace(ctx.String("role")),
 		}
 
 		interactive := true
@@ -174,7 +217,7 @@ func usersAddAction(ctx *cli.Context) error {
 	})
 }
 
-func usersDeleteAction(ctx *cli.Context) error {
+func usersRemoveAction(ctx *cli.Context) error {
 	return callWithDependencies(ctx, func(conf *config.Config) error {
 		userName := strings.TrimSpace(ctx.Args().First())
 
@@ -182,18 +225,33 @@ func usersDeleteAction(ctx *cli.Context) error {
 			return errors.New("please provide a username")
 		}
 
-		actionPrompt := promptui.Prompt{
-			Label:     fmt.Sprintf("Delete %s?", sanitize.Log(userName)),
-			IsConfirm: true,
+		confirmed := ctx.Bool("force")
+
+		if !confirmed {
+			actionPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Delete %s?", sanitize.Log(userName)),
+				IsConfirm: true,
+			}
+
+			_, err := actionPrompt.Run()
+			confirmed = err == nil
 		}
 
-		if _, err := actionPrompt.Run(); err == nil {
+		if confirmed {
+			// Load the stored sessions before the user is removed, so that they can be deleted.
+			sessions := session.New(168*time.Hour, conf.CachePath())
+
 			if m := entity.FindUserByName(userName); m == nil {
 				return errors.New("user not found")
 			} else if err := m.Delete(); err != nil {
 				return err
 			} else {
 				log.Infof("%s deleted", sanitize.Log(userName))
+
+				// Log out removed users, running servers will also delete their sessions within a minute.
+				if n := sessions.DeleteUser(m.UserUID); n > 0 {
+					log.Infof("%d sessions deleted: %s", n, sanitize.Log(userName))
+				}
 			}
 		} else {
 			log.Infof("keeping user")
@@ -208,10 +266,22 @@ func usersListAction(ctx *cli.Context) error {
 		users := query.RegisteredUsers()
 		log.Infof("found %s", english.Plural(len(users), "user", "users"))
 
-		fmt.Printf("%-4s %-16s %-16s %-16s\n", "ID", "LOGIN", "NAME", "EMAIL")
+		fmt.Printf("%-4s %-16s %-16s %-24s %-8s %-8s\n", "ID", "LOGIN", "NAME", "EMAIL", "ROLE", "STATUS")
 
 		for _, user := range users {
-			fmt.Printf("%-4d %-16s %-16s %-16s", user.ID, user.Username(), user.FullName, user.PrimaryEmail)
+			status := "active"
+
+			if user.UserDisabled {
+				status = "disabled"
+			}
+
+			role := string(user.Role())
+
+			if user.Role() == acl.RoleDefault {
+				role = "user"
+			}
+
+			fmt.Printf("%-4d %-16s %-16s %-24s %-8s %-8s", user.ID, user.Username(), user.FullName, user.PrimaryEmail, role, status)
 			fmt.Printf("\n")
 		}
 
@@ -219,7 +289,7 @@ func usersListAction(ctx *cli.Context) error {
 	})
 }
 
-func usersUpdateAction(ctx *cli.Context) error {
+func usersModifyAction(ctx *cli.Context) error {
 	return callWithDependencies(ctx, func(conf *config.Config) error {
 		username := ctx.Args().First()
 		if username == "" {
@@ -253,20 +323,79 @@ func usersUpdateAction(ctx *cli.Context) error {
 			u.PrimaryEmail = uc.Email
 		}
 
+		if ctx.IsSet("role") {
+			if err := u.SetRole(ctx.String("role")); err != nil {
+				return err
+			}
+		}
+
+		if ctx.Bool("disable") && ctx.Bool("enable") {
+			return errors.New("user cannot be disabled and enabled at the same time")
+		} else if ctx.Bool("disable") {
+			u.UserDisabled = true
+		} else if ctx.Bool("enable") {
+			u.UserDisabled = false
+		}
+
 		if err := u.Validate(); err != nil {
 			return err
 		}
 
+		// Load the stored sessions before the user is disabled, so that they can be deleted.
+		sessions := session.New(168*time.Hour, conf.CachePath())
+
 		if err := u.Save(); err != nil {
 			return err
 		}
 
+		// Log out disabled users, running servers will also delete their sessions within a minute.
+		if u.UserDisabled {
+			if n := sessions.DeleteUser(u.UserUID); n > 0 {
+				fmt.Printf("%d sessions deleted: %s\n", n, sanitize.Log(u.Username()))
+			}
+		}
+
 		fmt.Printf("user successfully updated: %s\n", sanitize.Log(u.Username()))
 
 		return nil
 	})
 }
 
+func usersResetPasswordAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		userName := strings.TrimSpace(ctx.Args().First())
+
+		if userName == "" {
+			return errors.New("please provide a username")
+		}
+
+		u := entity.FindUserByName(userName)
+
+		if u == nil {
+			return errors.New("user not found")
+		}
+
+		password := strings.TrimSpace(ctx.String("password"))
+		generated := password == ""
+
+		if generated {
+			password = rnd.Password()
+		}
+
+		if err := u.SetPassword(password); err != nil {
+			return err
+		}
+
+		if generated {
+			fmt.Printf("new password for %s: %s\n", sanitize.Log(u.Username()), password)
+		} else {
+			fmt.Printf("password successfully changed: %s\n", sanitize.Log(u.Username()))
+		}
+
+		return nil
+	})
+}
+
 func callWithDependencies(ctx *cli.Context, f func(conf *config.Config) error) error {
 	conf := config.NewConfig(ctx)
 
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// WarmupCommand registers the warmup cli command.
+var WarmupCommand = cli.Command{
+	Name:  "warmup",
+	Usage: "Pre-generates thumbnails for photos matching a search filter, e.g. after a cache reset",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "sizes, s",
+			Usage: "comma-separated list of thumbnail `SIZES`, e.g. \"tile_224,fit_1280\" (default: pre-cached sizes)",
+		},
+		cli.StringFlag{
+			Name:  "filter",
+			Usage: "create thumbnails for photos matching a search `FILTER` only, e.g. \"favorite:true\" or \"year:2021\"",
+		},
+		cli.IntFlag{
+			Name:  "workers, w",
+			Usage: "number of concurrent `WORKERS` (default: thumbnail workers)",
+		},
+		cli.BoolFlag{
+			Name:  "force, f",
+			Usage: "replace existing thumbnails",
+		},
+	},
+	Action: warmupAction,
+}
+
+// warmupAction pre-generates thumbnails for photos matching a search filter.
+func warmupAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	sizes, err := thumb.ParseNames(ctx.String("sizes"))
+
+	if err != nil {
+		return err
+	}
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	filter := strings.TrimSpace(ctx.String("filter"))
+
+	if filter != "" {
+		log.Infof("creating thumbnails for photos matching %s", sanitize.Log(filter))
+	} else {
+		log.Infof("creating thumbnails for all photos in %s", sanitize.Log(conf.ThumbPath()))
+	}
+
+	opt := photoprism.WarmupOptions{
+		Filter:  filter,
+		Sizes:   sizes,
+		Workers: ctx.Int("workers"),
+		Force:   ctx.Bool("force"),
+	}
+
+	files, err := service.Warmup().Start(opt)
+
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	log.Infof("thumbnails created for %d files in %s", files, time.Since(start))
+
+	return nil
+}
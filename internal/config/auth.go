@@ -1,12 +1,22 @@
 package config
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"regexp"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// tokenMutex synchronizes access to the static download and preview tokens, as they can be rotated at runtime.
+var tokenMutex sync.Mutex
+
 func isBcrypt(s string) bool {
 	b, err := regexp.MatchString(`^\$2[ayb]\$.{56}$`, s)
 	if err != nil {
@@ -29,11 +39,14 @@ func (c *Config) CheckPassword(p string) bool {
 
 // InvalidDownloadToken tests if the token is invalid.
 func (c *Config) InvalidDownloadToken(t string) bool {
-	return c.DownloadToken() != t
+	return c.DownloadToken() != t && !c.ShareDownload(t)
 }
 
 // DownloadToken returns the DOWNLOAD api token (you can optionally use a static value for permanent caching).
 func (c *Config) DownloadToken() string {
+	tokenMutex.Lock()
+	defer tokenMutex.Unlock()
+
 	if c.options.DownloadToken == "" {
 		c.options.DownloadToken = rnd.Token(8)
 	}
@@ -41,6 +54,53 @@ func (c *Config) DownloadToken() string {
 	return c.options.DownloadToken
 }
 
+// ShareRedact returns the metadata that is removed from shared content, if any.
+func (c *Config) ShareRedact() (fields []string) {
+	for _, s := range strings.Split(c.options.ShareRedact, ",") {
+		s = strings.ToLower(strings.TrimSpace(s))
+
+		switch s {
+		case "":
+			continue
+		case entity.RedactSerial, entity.RedactOwner, entity.RedactGps, entity.RedactFilenames:
+			fields = append(fields, s)
+		default:
+			log.Warnf("config: unknown share redact field %s", sanitize.Log(s))
+		}
+	}
+
+	return fields
+}
+
+// ShareRedacted tests if the metadata field is removed from shared content.
+func (c *Config) ShareRedacted(field string) bool {
+	for _, f := range c.ShareRedact() {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ShareDownloadToken returns the download token for shared content, which is only different from
+// the regular download token if metadata must be redacted. It is derived from the download token,
+// so that the regular token can't be guessed from it.
+func (c *Config) ShareDownloadToken() string {
+	if len(c.ShareRedact()) == 0 {
+		return c.DownloadToken()
+	}
+
+	h := sha1.Sum([]byte("share:" + c.DownloadToken()))
+
+	return hex.EncodeToString(h[:])[:10]
+}
+
+// ShareDownload tests if the token is a download token for shared content with redacted metadata.
+func (c *Config) ShareDownload(t string) bool {
+	return t != "" && len(c.ShareRedact()) > 0 && c.ShareDownloadToken() == t
+}
+
 // InvalidPreviewToken tests if the preview token is invalid.
 func (c *Config) InvalidPreviewToken(t string) bool {
 	return c.PreviewToken() != t && c.DownloadToken() != t
@@ -48,6 +108,9 @@ func (c *Config) InvalidPreviewToken(t string) bool {
 
 // PreviewToken returns the preview image api token (based on the unique storage serial by default).
 func (c *Config) PreviewToken() string {
+	tokenMutex.Lock()
+	defer tokenMutex.Unlock()
+
 	if c.options.PreviewToken == "" {
 		if c.Public() {
 			c.options.PreviewToken = "public"
@@ -58,3 +121,32 @@ func (c *Config) PreviewToken() string {
 
 	return c.options.PreviewToken
 }
+
+// MinTokenLifetime is the minimum lifetime of expiring download and preview tokens.
+const MinTokenLifetime = 10 * time.Minute
+
+// TokenLifetime returns the lifetime of download and preview tokens, which are created per session
+// and rotated if it is greater than zero. Tokens do not expire in public mode.
+func (c *Config) TokenLifetime() time.Duration {
+	if c.Public() || c.options.TokenLifetime <= 0 {
+		return 0
+	}
+
+	if lifetime := time.Duration(c.options.TokenLifetime) * time.Second; lifetime > MinTokenLifetime {
+		return lifetime
+	}
+
+	return MinTokenLifetime
+}
+
+// RotateTokens replaces the static download and preview tokens with random values until the next restart.
+func (c *Config) RotateTokens() {
+	tokenMutex.Lock()
+	defer tokenMutex.Unlock()
+
+	c.options.DownloadToken = rnd.Token(8)
+
+	if !c.Public() {
+		c.options.PreviewToken = rnd.Token(8)
+	}
+}
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -53,3 +55,84 @@ func TestConfig_InvalidPreviewToken(t *testing.T) {
 
 	assert.True(t, c.InvalidPreviewToken("xxx"))
 }
+
+func TestConfig_ShareRedact(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.ShareRedact())
+	assert.False(t, c.ShareRedacted("gps"))
+
+	c.options.ShareRedact = "Serial, gps,filenames,lens"
+
+	assert.Equal(t, []string{"serial", "gps", "filenames"}, c.ShareRedact())
+	assert.True(t, c.ShareRedacted("gps"))
+	assert.False(t, c.ShareRedacted("owner"))
+}
+
+func TestConfig_ShareDownloadToken(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, c.DownloadToken(), c.ShareDownloadToken())
+	assert.False(t, c.ShareDownload(c.ShareDownloadToken()))
+
+	c.options.ShareRedact = "gps"
+	token := c.ShareDownloadToken()
+
+	assert.NotEqual(t, c.DownloadToken(), token)
+	assert.Len(t, token, 10)
+	assert.True(t, c.ShareDownload(token))
+	assert.False(t, c.ShareDownload(c.DownloadToken()))
+	assert.False(t, c.InvalidDownloadToken(token))
+	assert.False(t, c.InvalidDownloadToken(c.DownloadToken()))
+	assert.True(t, c.InvalidDownloadToken("xxx"))
+}
+
+func TestConfig_TokenLifetime(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, time.Duration(0), c.TokenLifetime())
+
+	c.options.TokenLifetime = 60
+	assert.Equal(t, MinTokenLifetime, c.TokenLifetime())
+
+	c.options.TokenLifetime = 3600
+	assert.Equal(t, time.Hour, c.TokenLifetime())
+
+	c.options.Public = true
+	assert.Equal(t, time.Duration(0), c.TokenLifetime())
+}
+
+func TestConfig_RotateTokens(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	download := c.DownloadToken()
+	preview := c.PreviewToken()
+
+	c.RotateTokens()
+
+	assert.NotEqual(t, download, c.DownloadToken())
+	assert.NotEqual(t, preview, c.PreviewToken())
+	assert.True(t, c.InvalidDownloadToken(download))
+	assert.False(t, c.InvalidDownloadToken(c.DownloadToken()))
+
+	t.Run("Concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+				c.RotateTokens()
+			}()
+
+			go func() {
+				defer wg.Done()
+				assert.NotEmpty(t, c.PreviewToken())
+				assert.NotEmpty(t, c.DownloadToken())
+			}()
+		}
+
+		wg.Wait()
+	})
+}
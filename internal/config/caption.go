@@ -0,0 +1,13 @@
+package config
+
+import "strings"
+
+// CaptionUri returns the captioning service URI, or an empty string if captions are disabled.
+func (c *Config) CaptionUri() string {
+	return strings.TrimSpace(c.options.CaptionUri)
+}
+
+// DisableCaptions tests if generating image captions is disabled.
+func (c *Config) DisableCaptions() bool {
+	return c.CaptionUri() == ""
+}
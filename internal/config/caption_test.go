@@ -0,0 +1,21 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_CaptionUri(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.CaptionUri())
+	assert.True(t, c.DisableCaptions())
+
+	c.options.CaptionUri = " http://localhost:5000/caption "
+
+	assert.Equal(t, "http://localhost:5000/caption", c.CaptionUri())
+	assert.False(t, c.DisableCaptions())
+
+	c.options.CaptionUri = ""
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Check status values.
+const (
+	CheckOK      = "ok"
+	CheckWarning = "warning"
+	CheckError   = "error"
+)
+
+// CheckResult represents the outcome of a single configuration check.
+type CheckResult struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// CheckResults represents a configuration check report.
+type CheckResults []CheckResult
+
+// Fatal tests if the report contains at least one error.
+func (r CheckResults) Fatal() bool {
+	for _, result := range r {
+		if result.Status == CheckError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Count returns the number of results with the given status.
+func (r CheckResults) Count(status string) (n int) {
+	for _, result := range r {
+		if result.Status == status {
+			n++
+		}
+	}
+
+	return n
+}
+
+// add appends a new check result.
+func (r *CheckResults) add(name, value, status, msg string) {
+	*r = append(*r, CheckResult{Name: name, Value: value, Status: status, Message: msg})
+}
+
+// Check validates the configuration without modifying it, e.g. to be used in health checks.
+func (c *Config) Check() (results CheckResults) {
+	results.checkPath("originals-path", c.OriginalsPath(), true, !c.ReadOnly())
+	results.checkPath("import-path", c.ImportPath(), false, !c.ReadOnly())
+	results.checkPath("storage-path", c.StoragePath(), true, true)
+	results.checkPath("cache-path", c.CachePath(), true, true)
+	results.checkPath("sidecar-path", c.SidecarPath(), c.SidecarPathIsAbs(), c.SidecarWritable())
+	results.checkPath("backup-path", c.BackupPath(), false, true)
+	results.checkPath("assets-path", c.AssetsPath(), true, false)
+	results.checkPath("temp-path", c.TempPath(), false, true)
+
+	// Opening a SQLite database that does not exist would create an empty file.
+	if c.db == nil && c.DatabaseDriver() == SQLite3 && !fs.FileExists(c.SqliteFile()) {
+		results.add("database", c.DatabaseDriver(), CheckWarning, "missing")
+	} else if err := c.checkDb(); err != nil {
+		results.add("database", c.DatabaseDriver(), CheckError, err.Error())
+	} else {
+		results.add("database", c.DatabaseDriver(), CheckOK, "")
+	}
+
+	results.checkBin("exiftool-bin", c.ExifToolBin(), c.DisableExifTool())
+	results.checkBin("ffmpeg-bin", c.FFmpegBin(), c.DisableFFmpeg())
+	results.checkBin("darktable-bin", c.DarktableBin(), c.DisableDarktable())
+	results.checkBin("rawtherapee-bin", c.RawtherapeeBin(), c.DisableRawtherapee())
+	results.checkBin("heifconvert-bin", c.HeifConvertBin(), c.DisableHeifConvert())
+	results.checkBin("dcmtk-bin", c.DcmtkBin(), c.DisableDcmtk())
+	results.checkBin("vips-bin", c.VipsBin(), strings.ToLower(c.options.ThumbLibrary) != thumb.LibVips)
+
+	return results
+}
+
+// checkPath adds the result of checking a storage path.
+func (r *CheckResults) checkPath(name, path string, required, writable bool) {
+	status := CheckWarning
+
+	if required {
+		status = CheckError
+	}
+
+	switch {
+	case path == "":
+		r.add(name, path, status, "not configured")
+	case fs.FileExists(path):
+		r.add(name, path, CheckError, "is a file, not a folder")
+	case !fs.PathExists(path):
+		r.add(name, path, status, "does not exist")
+	case writable && !fs.PathWritable(path):
+		r.add(name, path, status, "is not writable")
+	default:
+		r.add(name, path, CheckOK, "")
+	}
+}
+
+// checkBin adds the result of checking an external command.
+func (r *CheckResults) checkBin(name, bin string, disabled bool) {
+	switch {
+	case disabled:
+		r.add(name, bin, CheckOK, "disabled")
+	case bin == "":
+		r.add(name, bin, CheckWarning, "not found")
+	default:
+		if info, err := os.Stat(bin); err != nil {
+			r.add(name, bin, CheckWarning, err.Error())
+		} else if info.Mode()&0111 == 0 {
+			r.add(name, bin, CheckWarning, "is not executable")
+		} else {
+			r.add(name, bin, CheckOK, "")
+		}
+	}
+}
+
+// checkDb tests if the database is reachable without retrying or failing fatally.
+func (c *Config) checkDb() error {
+	if c.db != nil {
+		return c.db.DB().Ping()
+	}
+
+	dbDriver := c.DatabaseDriver()
+	dbDsn := c.DatabaseDsn()
+
+	if dbDriver == "" {
+		return fmt.Errorf("driver not specified")
+	} else if dbDsn == "" {
+		return fmt.Errorf("dsn not specified")
+	}
+
+	db, err := gorm.Open(dbDriver, dbDsn)
+
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	return db.DB().Ping()
+}
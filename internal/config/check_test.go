@@ -0,0 +1,72 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestConfig_Check(t *testing.T) {
+	c := TestConfig()
+
+	results := c.Check()
+
+	assert.NotEmpty(t, results)
+
+	for _, r := range results {
+		if r.Name == "database" {
+			assert.Equal(t, CheckOK, r.Status)
+		}
+	}
+}
+
+func TestConfig_Check_SqliteMissing(t *testing.T) {
+	c := &Config{options: NewTestOptions()}
+	c.options.DatabaseDriver = SQLite3
+	c.options.DatabaseDsn = filepath.Join(t.TempDir(), "index.db")
+
+	for _, r := range c.Check() {
+		if r.Name == "database" {
+			assert.Equal(t, CheckWarning, r.Status)
+			assert.Equal(t, "missing", r.Message)
+		}
+	}
+
+	assert.False(t, fs.FileExists(c.options.DatabaseDsn))
+}
+
+func TestCheckResults_Fatal(t *testing.T) {
+	t.Run("Errors", func(t *testing.T) {
+		var r CheckResults
+
+		r.add("originals-path", "", CheckError, "not configured")
+		r.add("ffmpeg-bin", "", CheckWarning, "not found")
+
+		assert.True(t, r.Fatal())
+		assert.Equal(t, 1, r.Count(CheckError))
+		assert.Equal(t, 1, r.Count(CheckWarning))
+	})
+	t.Run("Warnings", func(t *testing.T) {
+		var r CheckResults
+
+		r.checkBin("ffmpeg-bin", "", false)
+		r.checkBin("darktable-bin", "", true)
+
+		assert.False(t, r.Fatal())
+		assert.Equal(t, 1, r.Count(CheckOK))
+	})
+	t.Run("Paths", func(t *testing.T) {
+		var r CheckResults
+
+		r.checkPath("originals-path", "/xxx/yyy", true, true)
+		r.checkPath("import-path", "/xxx/yyy", false, true)
+		r.checkPath("temp-path", t.TempDir(), true, true)
+
+		assert.Equal(t, CheckError, r[0].Status)
+		assert.Equal(t, CheckWarning, r[1].Status)
+		assert.Equal(t, CheckOK, r[2].Status)
+	})
+}
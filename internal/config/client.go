@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"strings"
 	"time"
 
@@ -53,6 +54,7 @@ type ClientConfig struct {
 	Thumbs          ThumbSizes          `json:"thumbs"`
 	Status          string              `json:"status"`
 	MapKey          string              `json:"mapKey"`
+	MapTiles        string              `json:"mapTiles"`
 	DownloadToken   string              `json:"downloadToken"`
 	PreviewToken    string              `json:"previewToken"`
 	Settings        Settings            `json:"settings"`
@@ -81,6 +83,7 @@ type ClientDisable struct {
 	Rawtherapee    bool `json:"rawtherapee"`
 	Sips           bool `json:"sips"`
 	HeifConvert    bool `json:"heifconvert"`
+	Dcmtk          bool `json:"dcmtk"`
 	TensorFlow     bool `json:"tensorflow"`
 	Faces          bool `json:"faces"`
 	Classification bool `json:"classification"`
@@ -106,12 +109,35 @@ type ClientCounts struct {
 	Folders        int `json:"folders"`
 	Files          int `json:"files"`
 	People         int `json:"people"`
+	Pets           int `json:"pets"`
 	Places         int `json:"places"`
 	States         int `json:"states"`
 	Labels         int `json:"labels"`
 	LabelMaxPhotos int `json:"labelMaxPhotos"`
 }
 
+// completeTotals tests if the precalculated totals contain all counts, so that they can be used.
+func completeTotals(totals map[string]int) bool {
+	for _, key := range entity.TotalCountKeys {
+		if _, ok := totals[key]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// setTotals sets the counts from a map with their json field names as keys.
+func (c *ClientCounts) setTotals(totals map[string]int) error {
+	data, err := json.Marshal(totals)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, c)
+}
+
 type CategoryLabels []CategoryLabel
 
 type CategoryLabel struct {
@@ -188,6 +214,7 @@ func (c *Config) PublicConfig() ClientConfig {
 			Rawtherapee:    true,
 			Sips:           true,
 			HeifConvert:    true,
+			Dcmtk:          true,
 			TensorFlow:     true,
 			Faces:          true,
 			Classification: true,
@@ -258,6 +285,7 @@ func (c *Config) GuestConfig() ClientConfig {
 			Rawtherapee:    true,
 			Sips:           true,
 			HeifConvert:    true,
+			Dcmtk:          true,
 			TensorFlow:     true,
 			Faces:          true,
 			Classification: true,
@@ -297,7 +325,8 @@ func (c *Config) GuestConfig() ClientConfig {
 		Thumbs:          Thumbs,
 		Status:          c.Hub().Status,
 		MapKey:          c.Hub().MapKey(),
-		DownloadToken:   c.DownloadToken(),
+		MapTiles:        c.MapTilesUri(),
+		DownloadToken:   c.ShareDownloadToken(),
 		PreviewToken:    c.PreviewToken(),
 		ManifestUri:     c.ClientManifestUri(),
 		Clip:            txt.ClipDefault,
@@ -311,7 +340,7 @@ func (c *Config) UserConfig() ClientConfig {
 	assets := c.ClientAssets()
 
 	result := ClientConfig{
-		Settings: *c.Settings(),
+		Settings: c.Settings().Masked(),
 		Disable: ClientDisable{
 			Backups:        c.DisableBackups(),
 			WebDAV:         c.DisableWebDAV(),
@@ -323,6 +352,7 @@ func (c *Config) UserConfig() ClientConfig {
 			Rawtherapee:    c.DisableRawtherapee(),
 			Sips:           c.DisableSips(),
 			HeifConvert:    c.DisableHeifConvert(),
+			Dcmtk:          c.DisableDcmtk(),
 			TensorFlow:     c.DisableTensorFlow(),
 			Faces:          c.DisableFaces(),
 			Classification: c.DisableClassification(),
@@ -362,6 +392,7 @@ func (c *Config) UserConfig() ClientConfig {
 		Thumbs:          Thumbs,
 		Status:          c.Hub().Status,
 		MapKey:          c.Hub().MapKey(),
+		MapTiles:        c.MapTilesUri(),
 		DownloadToken:   c.DownloadToken(),
 		PreviewToken:    c.PreviewToken(),
 		ManifestUri:     c.ClientManifestUri(),
@@ -377,70 +408,75 @@ func (c *Config) UserConfig() ClientConfig {
 		Limit(1).Offset(0).
 		Take(&result.Pos)
 
-	c.Db().
-		Table("cameras").
-		Where("camera_slug <> 'zz' AND camera_slug <> ''").
-		Select("COUNT(*) AS cameras").
-		Take(&result.Count)
-
-	c.Db().
-		Table("lenses").
-		Where("lens_slug <> 'zz' AND lens_slug <> ''").
-		Select("COUNT(*) AS lenses").
-		Take(&result.Count)
-
-	c.Db().
-		Table("photos").
-		Select("SUM(photo_type = 'video' AND photo_quality >= 0 AND photo_private = 0) AS videos, " +
-			"SUM(photo_type = 'live' AND photo_quality >= 0 AND photo_private = 0) AS live, " +
-			"SUM(photo_quality = -1) AS hidden, SUM(photo_type IN ('image','raw') AND photo_private = 0 AND photo_quality >= 0) AS photos, " +
-			"SUM(photo_type IN ('image','raw','live') AND photo_quality < 3 AND photo_quality >= 0 AND photo_private = 0) AS review, " +
-			"SUM(photo_favorite = 1 AND photo_private = 0 AND photo_quality >= 0) AS favorites, " +
-			"SUM(photo_private = 1 AND photo_quality >= 0) AS private").
-		Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND (file_missing = 1 OR file_error <> ''))").
-		Where("deleted_at IS NULL").
-		Take(&result.Count)
-
-	result.Count.All = result.Count.Photos + result.Count.Live + result.Count.Videos
-
-	c.Db().
-		Table("labels").
-		Select("MAX(photo_count) AS label_max_photos, COUNT(*) AS labels").
-		Where("photo_count > 0").
-		Where("deleted_at IS NULL").
-		Where("(label_priority >= 0 OR label_favorite = 1)").
-		Take(&result.Count)
-
-	c.Db().
-		Table("albums").
-		Select("SUM(album_type = ?) AS albums, SUM(album_type = ?) AS moments, SUM(album_type = ?) AS months, SUM(album_type = ?) AS states, SUM(album_type = ?) AS folders", entity.AlbumDefault, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
-		Where("deleted_at IS NULL AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photos.photo_path FROM photos WHERE photos.deleted_at IS NULL))").
-		Take(&result.Count)
-
-	c.Db().
-		Table("files").
-		Select("COUNT(*) AS files").
-		Where("file_missing = 0").
-		Where("deleted_at IS NULL").
-		Take(&result.Count)
-
-	c.Db().
-		Table("countries").
-		Select("(COUNT(*) - 1) AS countries").
-		Take(&result.Count)
-
-	c.Db().
-		Table("places").
-		Select("SUM(photo_count > 0) AS places").
-		Where("id <> 'zz'").
-		Take(&result.Count)
+	// Use precalculated totals if available, see entity.UpdateCounts().
+	if totals, err := query.PhotoCounts(entity.CountTotal); err != nil || !completeTotals(totals) || result.Count.setTotals(totals) != nil {
+		c.Db().
+			Table("cameras").
+			Where("camera_slug <> 'zz' AND camera_slug <> ''").
+			Select("COUNT(*) AS cameras").
+			Take(&result.Count)
+
+		c.Db().
+			Table("lenses").
+			Where("lens_slug <> 'zz' AND lens_slug <> ''").
+			Select("COUNT(*) AS lenses").
+			Take(&result.Count)
+
+		c.Db().
+			Table("labels").
+			Select("MAX(photo_count) AS label_max_photos, COUNT(*) AS labels").
+			Where("photo_count > 0").
+			Where("deleted_at IS NULL").
+			Where("(label_priority >= 0 OR label_favorite = 1)").
+			Take(&result.Count)
+
+		c.Db().
+			Table("photos").
+			Select("SUM(photo_type = 'video' AND photo_quality >= 0 AND photo_private = 0 AND photo_pending = 0) AS videos, " +
+				"SUM(photo_type = 'live' AND photo_quality >= 0 AND photo_private = 0 AND photo_pending = 0) AS live, " +
+				"SUM(photo_quality = -1) AS hidden, SUM(photo_type IN ('image','raw') AND photo_private = 0 AND photo_pending = 0 AND photo_quality >= 0) AS photos, " +
+				"SUM(photo_type IN ('image','raw','live') AND (photo_quality < 3 OR photo_pending = 1) AND photo_quality >= 0 AND photo_private = 0) AS review, " +
+				"SUM(photo_favorite = 1 AND photo_private = 0 AND photo_pending = 0 AND photo_quality >= 0) AS favorites, " +
+				"SUM(photo_private = 1 AND photo_quality >= 0) AS private").
+			Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND (file_missing = 1 OR file_error <> ''))").
+			Where("deleted_at IS NULL").
+			Take(&result.Count)
+
+		result.Count.All = result.Count.Photos + result.Count.Live + result.Count.Videos
+
+		c.Db().
+			Table("albums").
+			Select("SUM(album_type = ?) AS albums, SUM(album_type = ?) AS moments, SUM(album_type = ?) AS months, SUM(album_type = ?) AS states, SUM(album_type = ?) AS folders", entity.AlbumDefault, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
+			Where("deleted_at IS NULL AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photos.photo_path FROM photos WHERE photos.deleted_at IS NULL))").
+			Take(&result.Count)
+
+		c.Db().
+			Table("files").
+			Select("COUNT(*) AS files").
+			Where("file_missing = 0").
+			Where("deleted_at IS NULL").
+			Take(&result.Count)
+
+		c.Db().
+			Table("countries").
+			Select("(COUNT(*) - 1) AS countries").
+			Take(&result.Count)
+
+		c.Db().
+			Table("places").
+			Select("SUM(photo_count > 0) AS places").
+			Where("id <> 'zz'").
+			Take(&result.Count)
+
+		result.Count.People, _ = query.PeopleCount()
+		result.Count.Pets, _ = query.PetsCount()
+	}
 
 	c.Db().
 		Order("country_slug").
 		Find(&result.Countries)
 
 	// People are subjects with type person.
-	result.Count.People, _ = query.PeopleCount()
 	result.People, _ = query.People()
 
 	c.Db().
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestConfig_PublicConfig(t *testing.T) {
@@ -38,3 +40,30 @@ func TestConfig_Flags(t *testing.T) {
 	config.options.Experimental = false
 	config.options.ReadOnly = false
 }
+
+func TestClientCounts_SetTotals(t *testing.T) {
+	counts := ClientCounts{Cameras: 3}
+
+	assert.NoError(t, counts.setTotals(map[string]int{"all": 12, "photos": 10, "videos": 2, "folders": 4}))
+	assert.Equal(t, 12, counts.All)
+	assert.Equal(t, 10, counts.Photos)
+	assert.Equal(t, 2, counts.Videos)
+	assert.Equal(t, 4, counts.Folders)
+	assert.Equal(t, 3, counts.Cameras)
+}
+
+func TestCompleteTotals(t *testing.T) {
+	totals := make(map[string]int)
+
+	assert.False(t, completeTotals(totals))
+
+	for _, key := range entity.TotalCountKeys {
+		totals[key] = 1
+	}
+
+	assert.True(t, completeTotals(totals))
+
+	delete(totals, "pets")
+
+	assert.False(t, completeTotals(totals))
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/photoprism/photoprism/internal/hub/places"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/tracing"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/sanitize"
@@ -74,6 +75,7 @@ type Config struct {
 	hub      *hub.Config
 	token    string
 	serial   string
+	roots    originalsRoots
 }
 
 func init() {
@@ -96,12 +98,9 @@ func init() {
 	}
 }
 
-func initLogger(debug bool) {
+func initLogger(debug bool, format string) {
 	once.Do(func() {
-		log.SetFormatter(&logrus.TextFormatter{
-			DisableColors: false,
-			FullTimestamp: true,
-		})
+		log.SetFormatter(logFormatter(format))
 
 		if debug {
 			log.SetLevel(logrus.DebugLevel)
@@ -113,7 +112,7 @@ func initLogger(debug bool) {
 
 // NewConfig initialises a new configuration file
 func NewConfig(ctx *cli.Context) *Config {
-	initLogger(ctx.GlobalBool("debug"))
+	initLogger(ctx.GlobalBool("debug"), ctx.GlobalString("log-format"))
 
 	c := &Config{
 		options: NewOptions(ctx),
@@ -144,17 +143,30 @@ func (c *Config) Options() *Options {
 // Propagate updates config options in other packages as needed.
 func (c *Config) Propagate() {
 	log.SetLevel(c.LogLevel())
+	log.SetFormatter(logFormatter(c.LogFormat()))
 
 	// Set thumbnail generation parameters.
 	thumb.SizePrecached = c.ThumbSizePrecached()
 	thumb.SizeUncached = c.ThumbSizeUncached()
 	thumb.Filter = c.ThumbFilter()
+	thumb.Library = c.ThumbLibrary()
+	thumb.VipsBin = c.VipsBin()
+
+	// Set junk file name patterns.
+	fs.JunkPatterns = c.JunkFiles()
 	thumb.JpegQuality = c.JpegQuality()
 
 	// Set geocoding parameters.
 	places.UserAgent = c.UserAgent()
 	entity.GeoApi = c.GeoApi()
 
+	// Set custom metadata source priorities.
+	entity.SetFieldPriorities(c.MetadataPriority())
+
+	// Set YAML sidecar file layout.
+	entity.SidecarLayout = c.SidecarLayout()
+	entity.SidecarGzip = c.SidecarGzip()
+
 	// Set facial recognition parameters.
 	face.ScoreThreshold = c.FaceScore()
 	face.OverlapThreshold = c.FaceOverlap()
@@ -180,6 +192,13 @@ func (c *Config) Init() error {
 		return err
 	}
 
+	c.initOriginalsRoots()
+
+	// Make sure that the originals are not changed in strict read-only mode.
+	if err := c.initProtectedPaths(); err != nil {
+		return err
+	}
+
 	// Show funding info?
 	if !c.Sponsor() {
 		log.Info(MsgSponsor)
@@ -214,6 +233,8 @@ func (c *Config) Init() error {
 
 	c.initSettings()
 	c.initHub()
+	c.initPush()
+	c.initTracing()
 
 	c.Propagate()
 
@@ -445,7 +466,35 @@ func (c *Config) Experimental() bool {
 
 // ReadOnly tests if photo directories are write protected.
 func (c *Config) ReadOnly() bool {
-	return c.options.ReadOnly
+	return c.options.ReadOnly || c.options.StrictReadOnly
+}
+
+// StrictReadOnly tests if no files may be created, changed, or deleted in the originals folder,
+// including sidecar files, which are then kept in the storage folder.
+func (c *Config) StrictReadOnly() bool {
+	return c.options.StrictReadOnly
+}
+
+// Maintenance tests if the instance should start in maintenance mode with paused background workers.
+func (c *Config) Maintenance() bool {
+	return c.options.Maintenance
+}
+
+// ProtectedPaths returns the folders that must not be changed in strict read-only mode.
+func (c *Config) ProtectedPaths() (paths []string) {
+	if !c.StrictReadOnly() {
+		return paths
+	}
+
+	if c.OriginalsPath() != "" {
+		paths = append(paths, c.OriginalsPath())
+	}
+
+	for _, root := range c.OriginalsRoots() {
+		paths = append(paths, root.Path)
+	}
+
+	return paths
 }
 
 // DetectNSFW tests if NSFW photos should be detected and flagged.
@@ -458,11 +507,81 @@ func (c *Config) UploadNSFW() bool {
 	return c.options.UploadNSFW
 }
 
+// ShareUploadLimit returns the maximum size of guest uploads to shared albums in bytes.
+func (c *Config) ShareUploadLimit() int64 {
+	if c.options.ShareUploadLimit <= 0 {
+		return 100 * 1024 * 1024
+	}
+
+	return int64(c.options.ShareUploadLimit) * 1024 * 1024
+}
+
+// ShareUploadFiles returns the maximum number of files guests may upload to a shared album at once.
+func (c *Config) ShareUploadFiles() int {
+	if c.options.ShareUploadFiles <= 0 {
+		return 100
+	}
+
+	return c.options.ShareUploadFiles
+}
+
+// ShareUploadRate returns the maximum number of guest upload requests per link and hour.
+func (c *Config) ShareUploadRate() int {
+	if c.options.ShareUploadRate <= 0 {
+		return 20
+	}
+
+	return c.options.ShareUploadRate
+}
+
+// ShareUploadPath returns the path of pending guest uploads to a shared album. It is not located in the
+// import path, so that regular imports don't add files that have not been approved yet.
+func (c *Config) ShareUploadPath(albumUID string) string {
+	return filepath.Join(c.StoragePath(), "shares", albumUID)
+}
+
+// ShareApprovedPath returns the path of approved guest uploads that are waiting to be imported into a shared album.
+func (c *Config) ShareApprovedPath(albumUID string) string {
+	return filepath.Join(c.StoragePath(), "shares", ".approved", albumUID)
+}
+
+// SyncUploadPath returns the path where files uploaded by sync clients are kept until they are confirmed.
+func (c *Config) SyncUploadPath() string {
+	return filepath.Join(c.TempPath(), "sync")
+}
+
 // AdminPassword returns the initial admin password.
 func (c *Config) AdminPassword() string {
 	return c.options.AdminPassword
 }
 
+// Supported log output formats.
+const (
+	LogFormatText = "text"
+	LogFormatJson = "json"
+)
+
+// logFormatter returns the Logrus formatter for the log output format.
+func logFormatter(format string) logrus.Formatter {
+	if strings.ToLower(strings.TrimSpace(format)) == LogFormatJson {
+		return event.NewJsonFormatter()
+	}
+
+	return &logrus.TextFormatter{
+		DisableColors: false,
+		FullTimestamp: true,
+	}
+}
+
+// LogFormat returns the log output format, either text or json.
+func (c *Config) LogFormat() string {
+	if strings.ToLower(strings.TrimSpace(c.options.LogFormat)) == LogFormatJson {
+		return LogFormatJson
+	}
+
+	return LogFormatText
+}
+
 // LogLevel returns the Logrus log level.
 func (c *Config) LogLevel() logrus.Level {
 	// Normalize string.
@@ -493,6 +612,8 @@ func (c *Config) Shutdown() {
 	} else {
 		log.Info("closed database connection")
 	}
+
+	tracing.Shutdown()
 }
 
 // Workers returns the number of workers e.g. for indexing files.
@@ -565,6 +686,11 @@ func (c *Config) AutoImport() time.Duration {
 	return time.Duration(c.options.AutoImport) * time.Second
 }
 
+// ImportReview checks if imported photos must be approved before they appear in search results and albums.
+func (c *Config) ImportReview() bool {
+	return c.options.ImportReview
+}
+
 // GeoApi returns the preferred geocoding api (none or places).
 func (c *Config) GeoApi() string {
 	if c.options.DisablePlaces {
@@ -584,6 +710,47 @@ func (c *Config) OriginalsLimit() int64 {
 	return c.options.OriginalsLimit * 1024 * 1024
 }
 
+// OriginalsDepth returns the maximum folder depth below an originals root that is indexed, or 0 if unlimited.
+func (c *Config) OriginalsDepth() int {
+	if c.options.OriginalsDepth <= 0 {
+		return 0
+	} else if c.options.OriginalsDepth > 1000 {
+		return 1000
+	}
+
+	return c.options.OriginalsDepth
+}
+
+// UploadQuota returns the maximum size of pending WebDAV uploads per user in bytes, or -1 if unlimited.
+func (c *Config) UploadQuota() int64 {
+	if c.options.UploadQuota <= 0 || c.options.UploadQuota > 100000 {
+		return -1
+	}
+
+	// Megabyte.
+	return c.options.UploadQuota * 1024 * 1024
+}
+
+// DownloadLimit returns the maximum size of ZIP downloads in bytes, or -1 if unlimited.
+func (c *Config) DownloadLimit() int64 {
+	if c.options.DownloadLimit <= 0 || c.options.DownloadLimit > 1000000 {
+		return -1
+	}
+
+	// Megabyte.
+	return c.options.DownloadLimit * 1024 * 1024
+}
+
+// ArchiveLimit returns the maximum extracted size of imported archives in bytes, or -1 if unlimited.
+func (c *Config) ArchiveLimit() int64 {
+	if c.options.ArchiveLimit <= 0 || c.options.ArchiveLimit > 1000000 {
+		return -1
+	}
+
+	// Megabyte.
+	return c.options.ArchiveLimit * 1024 * 1024
+}
+
 // UpdateHub updates backend api credentials for maps & places.
 func (c *Config) UpdateHub() {
 	if err := c.hub.Refresh(); err != nil {
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -148,6 +149,15 @@ func TestConfig_ImportPath(t *testing.T) {
 	assert.True(t, strings.HasSuffix(result, "/storage/testdata/import"))
 }
 
+func TestConfig_UserUploadPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.True(t, strings.HasSuffix(c.UserUploadPath("uqxetse3cy5eo9z2"), "/storage/testdata/upload/uqxetse3cy5eo9z2"))
+	assert.False(t, strings.HasPrefix(c.UserUploadPath("uqxetse3cy5eo9z2"), c.ImportPath()))
+	assert.Equal(t, "", c.UserUploadPath(""))
+	assert.Equal(t, "", c.UserUploadPath("../import"))
+}
+
 func TestConfig_ExifToolBin(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -155,6 +165,65 @@ func TestConfig_ExifToolBin(t *testing.T) {
 	assert.Equal(t, "/usr/bin/exiftool", bin)
 }
 
+func TestConfig_MetadataCmd(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.MetadataCmd())
+	assert.Equal(t, "", c.MetadataExt())
+
+	c.options.MetadataCmd = " exiftool -config custom.cfg -j "
+	c.options.MetadataExt = "fits, fit"
+
+	assert.Equal(t, "exiftool -config custom.cfg -j", c.MetadataCmd())
+	assert.Equal(t, "fits, fit", c.MetadataExt())
+}
+
+func TestConfig_MetadataPriority(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.MetadataPriority())
+	assert.Equal(t, "", c.MetadataPriorityString())
+
+	c.options.MetadataPriority = "Title: xmp > meta > xmp, taken:meta>foo>manual, camera, foo:meta, rating:"
+
+	assert.Equal(t, map[string][]string{
+		"title": {"xmp", "meta"},
+		"taken": {"meta", "manual"},
+	}, c.MetadataPriority())
+	assert.Equal(t, "title:xmp>meta,taken:meta>manual", c.MetadataPriorityString())
+}
+
+func TestConfig_LogFormat(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, LogFormatText, c.LogFormat())
+	assert.IsType(t, &logrus.TextFormatter{}, logFormatter(c.LogFormat()))
+
+	c.options.LogFormat = " JSON"
+
+	assert.Equal(t, LogFormatJson, c.LogFormat())
+	assert.IsType(t, &event.JsonFormatter{}, logFormatter(c.LogFormat()))
+
+	c.options.LogFormat = "xml"
+
+	assert.Equal(t, LogFormatText, c.LogFormat())
+}
+
+func TestConfig_ExifWriteback(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.ExifWriteback())
+
+	c.options.ExifWriteback = "Title, keywords,gps,camera"
+	c.options.ExifToolBin = "/bin/sh"
+
+	assert.Equal(t, []string{"title", "keywords", "gps"}, c.ExifWriteback())
+
+	c.options.ReadOnly = true
+
+	assert.Empty(t, c.ExifWriteback())
+}
+
 func TestConfig_CachePath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -295,6 +364,14 @@ func TestConfig_AutoImport(t *testing.T) {
 	assert.Equal(t, 2*time.Hour, c.AutoImport())
 }
 
+func TestConfig_ImportReview(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.False(t, c.ImportReview())
+	c.options.ImportReview = true
+	assert.True(t, c.ImportReview())
+	c.options.ImportReview = false
+}
+
 func TestConfig_GeoApi(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -311,6 +388,48 @@ func TestConfig_OriginalsLimit(t *testing.T) {
 	assert.Equal(t, int64(838860800), c.OriginalsLimit())
 }
 
+func TestConfig_OriginalsDepth(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 0, c.OriginalsDepth())
+	c.options.OriginalsDepth = 3
+	assert.Equal(t, 3, c.OriginalsDepth())
+	c.options.OriginalsDepth = -1
+	assert.Equal(t, 0, c.OriginalsDepth())
+	c.options.OriginalsDepth = 5000
+	assert.Equal(t, 1000, c.OriginalsDepth())
+}
+
+func TestConfig_UploadQuota(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, int64(-1), c.UploadQuota())
+	c.options.UploadQuota = 100
+	assert.Equal(t, int64(104857600), c.UploadQuota())
+	c.options.UploadQuota = 200000
+	assert.Equal(t, int64(-1), c.UploadQuota())
+}
+
+func TestConfig_DownloadLimit(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, int64(-1), c.DownloadLimit())
+	c.options.DownloadLimit = 1000
+	assert.Equal(t, int64(1048576000), c.DownloadLimit())
+	c.options.DownloadLimit = 2000000
+	assert.Equal(t, int64(-1), c.DownloadLimit())
+}
+
+func TestConfig_ArchiveLimit(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, int64(-1), c.ArchiveLimit())
+	c.options.ArchiveLimit = 1000
+	assert.Equal(t, int64(1048576000), c.ArchiveLimit())
+	c.options.ArchiveLimit = 2000000
+	assert.Equal(t, int64(-1), c.ArchiveLimit())
+}
+
 func TestConfig_BaseUri(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -440,3 +559,44 @@ func TestConfigOptions(t *testing.T) {
 	assert.Equal(t, r2.AutoImport, 0)
 	assert.Equal(t, r2.AutoIndex, 0)
 }
+
+func TestConfig_ShareUploadLimit(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, int64(100*1024*1024), c.ShareUploadLimit())
+
+	c.options.ShareUploadLimit = 5
+	assert.Equal(t, int64(5*1024*1024), c.ShareUploadLimit())
+}
+
+func TestConfig_ShareUploadFiles(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, 100, c.ShareUploadFiles())
+
+	c.options.ShareUploadFiles = 5
+	assert.Equal(t, 5, c.ShareUploadFiles())
+}
+
+func TestConfig_ShareUploadRate(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, 20, c.ShareUploadRate())
+
+	c.options.ShareUploadRate = 5
+	assert.Equal(t, 5, c.ShareUploadRate())
+}
+
+func TestConfig_ShareUploadPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, c.StoragePath()+"/shares/as6sg6bxpogaaba7", c.ShareUploadPath("as6sg6bxpogaaba7"))
+	assert.False(t, strings.HasPrefix(c.ShareUploadPath("as6sg6bxpogaaba7"), c.ImportPath()))
+}
+
+func TestConfig_SyncUploadPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, c.TempPath()+"/sync", c.SyncUploadPath())
+}
+
+func TestConfig_ShareApprovedPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, c.StoragePath()+"/shares/.approved/as6sg6bxpogaaba7", c.ShareApprovedPath("as6sg6bxpogaaba7"))
+	assert.Equal(t, c.StoragePath()+"/shares/.approved", c.ShareApprovedPath(""))
+}
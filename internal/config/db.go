@@ -26,6 +26,11 @@ var dsnPattern = regexp.MustCompile(
 
 // DatabaseDriver returns the database driver name.
 func (c *Config) DatabaseDriver() string {
+	// The index is rebuilt in an ephemeral SQLite database in sidecar-only mode.
+	if c.SidecarOnly() {
+		return SQLite3
+	}
+
 	switch strings.ToLower(c.options.DatabaseDriver) {
 	case MySQL, MariaDB:
 		c.options.DatabaseDriver = MySQL
@@ -46,7 +51,9 @@ func (c *Config) DatabaseDriver() string {
 
 // DatabaseDsn returns the database data source name (DSN).
 func (c *Config) DatabaseDsn() string {
-	if c.options.DatabaseDsn == "" {
+	if c.SidecarOnly() {
+		return c.EphemeralDbFile()
+	} else if c.options.DatabaseDsn == "" {
 		switch c.DatabaseDriver() {
 		case MySQL, MariaDB:
 			return fmt.Sprintf(
@@ -76,6 +83,12 @@ func (c *Config) DatabaseDsn() string {
 	return c.options.DatabaseDsn
 }
 
+// EphemeralDbFile returns the name of the temporary SQLite database file used in sidecar-only mode.
+// It is unique for each process and removed when the database is closed.
+func (c *Config) EphemeralDbFile() string {
+	return filepath.Join(c.TempPath(), fmt.Sprintf("index-%d.db", os.Getpid()))
+}
+
 // ParseDatabaseDsn parses the database dsn and extracts user, password, database server, and name.
 func (c *Config) ParseDatabaseDsn() {
 	if c.options.DatabaseDsn == "" || c.options.DatabaseServer != "" {
@@ -198,6 +211,11 @@ func (c *Config) DatabaseConnsIdle() int {
 	return limit
 }
 
+// Explain tests if slow database queries should be logged with their query plans.
+func (c *Config) Explain() bool {
+	return c.options.Explain
+}
+
 // Db returns the db connection.
 func (c *Config) Db() *gorm.DB {
 	if c.db == nil {
@@ -217,9 +235,24 @@ func (c *Config) CloseDb() error {
 		}
 	}
 
+	if c.SidecarOnly() {
+		c.removeEphemeralDb()
+	}
+
 	return nil
 }
 
+// removeEphemeralDb deletes the temporary SQLite database files used in sidecar-only mode.
+func (c *Config) removeEphemeralDb() {
+	fileName := c.EphemeralDbFile()
+
+	for _, name := range []string{fileName, fileName + "-wal", fileName + "-shm", fileName + "-journal"} {
+		if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+			log.Warnf("config: %s (remove ephemeral database)", err)
+		}
+	}
+}
+
 // SetDbOptions sets the database collation to unicode if supported.
 func (c *Config) SetDbOptions() {
 	switch c.DatabaseDriver() {
@@ -245,7 +278,8 @@ func (c *Config) MigrateDb(runFailed bool) {
 
 	entity.Admin.InitPassword(c.AdminPassword())
 
-	go entity.SaveErrorMessages()
+	go entity.SaveErrorMessages(c.OriginalsPath(), c.SidecarPath())
+	go entity.SaveActivities()
 }
 
 // InitTestDb drops all tables in the currently configured database and re-creates them.
@@ -256,7 +290,8 @@ func (c *Config) InitTestDb() {
 
 	entity.Admin.InitPassword(c.AdminPassword())
 
-	go entity.SaveErrorMessages()
+	go entity.SaveErrorMessages(c.OriginalsPath(), c.SidecarPath())
+	go entity.SaveActivities()
 }
 
 // connectDb establishes a database connection.
@@ -275,10 +310,40 @@ func (c *Config) connectDb() error {
 		return errors.New("config: database DSN not specified")
 	}
 
-	db, err := gorm.Open(dbDriver, dbDsn)
+	// Start with an empty index in sidecar-only mode.
+	if c.SidecarOnly() {
+		c.removeEphemeralDb()
+		log.Infof("config: sidecar-only mode enabled, using ephemeral database")
+	}
+
+	open := func() (*gorm.DB, error) {
+		return gorm.Open(dbDriver, dbDsn)
+	}
+
+	// An existing unencrypted index must be migrated before the key can be used.
+	if dbDriver == SQLite3 && c.SqliteEncrypted() && SqlitePlaintext(c.SqliteFile()) {
+		log.Fatal(ErrSqliteNotEncrypted)
+	}
+
+	// Set SQLite pragmas for each new connection.
+	if dbDriver == SQLite3 {
+		registerSqliteDriver(c.SqliteKey(), c.SqlitePragmas())
+
+		open = func() (*gorm.DB, error) {
+			return gorm.Open(SQLite3, sqliteDriver, c.SqliteDsn())
+		}
+	}
+
+	db, err := open()
+
+	// Retrying does not help if the database cannot be decrypted.
+	if err == ErrSqliteEncryptionUnsupported || err == ErrSqliteEncryptionKey {
+		log.Fatal(err)
+	}
+
 	if err != nil || db == nil {
 		for i := 1; i <= 12; i++ {
-			db, err = gorm.Open(dbDriver, dbDsn)
+			db, err = open()
 
 			if db != nil && err == nil {
 				break
@@ -295,6 +360,19 @@ func (c *Config) connectDb() error {
 	db.LogMode(false)
 	db.SetLogger(log)
 
+	if c.Explain() {
+		entity.RegisterExplain(db)
+	}
+
+	if c.Tracing() {
+		entity.RegisterTracing(db)
+	}
+
+	// Serialize writes, as SQLite only supports a single writer at a time.
+	if dbDriver == SQLite3 {
+		entity.RegisterWriteQueue(db)
+	}
+
 	db.DB().SetMaxOpenConns(c.DatabaseConns())
 	db.DB().SetMaxIdleConns(c.DatabaseConnsIdle())
 	db.DB().SetConnMaxLifetime(10 * time.Minute)
@@ -0,0 +1,325 @@
+package config
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// sqliteDriver is the name of the SQLite3 driver that sets the configured pragmas on new connections.
+const sqliteDriver = "sqlite3_photoprism"
+
+// Errors that are returned if an encrypted database cannot be opened, retrying does not help.
+var (
+	ErrSqliteEncryptionUnsupported = errors.New("sqlite: encryption not supported, sqlcipher is required")
+	ErrSqliteEncryptionKey         = errors.New("sqlite: invalid encryption key or database not encrypted")
+	ErrSqliteNotEncrypted          = errors.New("sqlite: index database is not encrypted yet, run 'photoprism migrations encrypt' first")
+)
+
+// sqliteHeader is the header of unencrypted SQLite database files.
+const sqliteHeader = "SQLite format 3\x00"
+
+var sqliteOnce sync.Once
+var sqlitePragmas = struct {
+	key   string
+	list  []string
+	mutex sync.RWMutex
+}{}
+
+// registerSqliteDriver registers the SQLite3 driver with a hook that sets the encryption key if not empty
+// and executes the pragmas for each new connection, as they are not persisted in the database file.
+func registerSqliteDriver(key string, pragmas []string) {
+	sqlitePragmas.mutex.Lock()
+	sqlitePragmas.key = key
+	sqlitePragmas.list = pragmas
+	sqlitePragmas.mutex.Unlock()
+
+	sqliteOnce.Do(func() {
+		sql.Register(sqliteDriver, &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				sqlitePragmas.mutex.RLock()
+				defer sqlitePragmas.mutex.RUnlock()
+
+				// The key must be set before the database is accessed.
+				if sqlitePragmas.key != "" {
+					if err := sqliteUnlock(conn, sqlitePragmas.key); err != nil {
+						return err
+					}
+				}
+
+				for _, pragma := range sqlitePragmas.list {
+					if _, err := conn.Exec(pragma, nil); err != nil {
+						return fmt.Errorf("%s (%s)", err, strings.ToLower(pragma))
+					}
+				}
+
+				return nil
+			},
+		})
+	})
+}
+
+// sqliteUnlock sets the encryption key of an SQLCipher database connection and checks that it is valid.
+func sqliteUnlock(conn *sqlite3.SQLiteConn, key string) error {
+	if _, err := conn.Exec(SqliteKeyPragma(key), nil); err != nil {
+		return ErrSqliteEncryptionUnsupported
+	}
+
+	// Standard SQLite ignores unknown pragmas, so check that the library was built with SQLCipher.
+	if version, err := sqliteQueryString(conn, "PRAGMA cipher_version"); err != nil || version == "" {
+		return ErrSqliteEncryptionUnsupported
+	}
+
+	// Reading the schema fails if the key is wrong or the database is not encrypted.
+	if _, err := sqliteQueryString(conn, "SELECT count(*) FROM sqlite_master"); err != nil {
+		return ErrSqliteEncryptionKey
+	}
+
+	return nil
+}
+
+// sqliteQueryString returns the first column of the first result row as string.
+func sqliteQueryString(conn *sqlite3.SQLiteConn, query string) (string, error) {
+	rows, err := conn.Query(query, nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer rows.Close()
+
+	dest := make([]driver.Value, len(rows.Columns()))
+
+	if len(dest) == 0 {
+		return "", nil
+	} else if err := rows.Next(dest); err != nil {
+		return "", nil
+	}
+
+	switch v := dest[0].(type) {
+	case nil:
+		return "", nil
+	case []byte:
+		return string(v), nil
+	default:
+		return fmt.Sprintf("%v", v), nil
+	}
+}
+
+// SqliteKeyPragma returns the pragma that sets the SQLCipher encryption key.
+func SqliteKeyPragma(key string) string {
+	return fmt.Sprintf("PRAGMA key = '%s'", strings.ReplaceAll(key, "'", "''"))
+}
+
+// SqliteKeyFile returns the name of the file that contains the SQLite encryption key, if any.
+func (c *Config) SqliteKeyFile() string {
+	if c.options.SqliteKeyFile == "" {
+		return ""
+	}
+
+	return fs.Abs(c.options.SqliteKeyFile)
+}
+
+// SqliteKey returns the SQLCipher key for encrypting the SQLite index database, or an empty string
+// if encryption is disabled. A key set with the config or an environment variable takes precedence.
+func (c *Config) SqliteKey() string {
+	if c.options.SqliteKey != "" {
+		return c.options.SqliteKey
+	}
+
+	fileName := c.SqliteKeyFile()
+
+	if fileName == "" {
+		return ""
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		log.Errorf("config: %s (read sqlite key file %s)", err, sanitize.Log(fileName))
+		return ""
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+// SqliteEncrypted tests if the SQLite index database is encrypted with SQLCipher.
+func (c *Config) SqliteEncrypted() bool {
+	return c.DatabaseDriver() == SQLite3 && c.SqliteKey() != ""
+}
+
+// SqliteFile returns the file name of the SQLite index database without DSN prefix and parameters.
+func (c *Config) SqliteFile() string {
+	fileName := strings.TrimPrefix(c.DatabaseDsn(), "file:")
+
+	if i := strings.Index(fileName, "?"); i >= 0 {
+		fileName = fileName[:i]
+	}
+
+	return fileName
+}
+
+// SqlitePlaintext tests if the file is an existing SQLite database that is not encrypted.
+func SqlitePlaintext(fileName string) bool {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return false
+	}
+
+	defer f.Close()
+
+	header := make([]byte, len(sqliteHeader))
+
+	if _, err := io.ReadFull(f, header); err != nil {
+		return false
+	}
+
+	return string(header) == sqliteHeader
+}
+
+// SqliteEncrypt encrypts an existing unencrypted SQLite index database with the configured key,
+// using sqlcipher_export() to copy it to a new file that replaces the original.
+func (c *Config) SqliteEncrypt() error {
+	key := c.SqliteKey()
+	fileName := c.SqliteFile()
+
+	if c.DatabaseDriver() != SQLite3 {
+		return fmt.Errorf("sqlite: index database driver is %s", c.DatabaseDriver())
+	} else if key == "" {
+		return errors.New("sqlite: encryption key not configured")
+	} else if !SqlitePlaintext(fileName) {
+		return fmt.Errorf("sqlite: %s is not an unencrypted database", sanitize.Log(fileName))
+	}
+
+	db, err := sql.Open(SQLite3, fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	encrypted := fileName + ".encrypted"
+
+	_ = os.Remove(encrypted)
+
+	// Changes in the write-ahead log must be included.
+	if _, err = db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return err
+	} else if _, err = db.Exec("ATTACH DATABASE ? AS encrypted KEY ?", encrypted, key); err != nil {
+		return err
+	}
+
+	if _, err = db.Exec("SELECT sqlcipher_export('encrypted')"); err != nil {
+		_ = os.Remove(encrypted)
+
+		if strings.Contains(err.Error(), "no such function") {
+			return ErrSqliteEncryptionUnsupported
+		}
+
+		return err
+	}
+
+	if _, err = db.Exec("DETACH DATABASE encrypted"); err != nil {
+		_ = os.Remove(encrypted)
+		return err
+	} else if err = db.Close(); err != nil {
+		_ = os.Remove(encrypted)
+		return err
+	}
+
+	// Remove the unencrypted write-ahead log and shared memory files.
+	for _, ext := range []string{"-wal", "-shm"} {
+		_ = os.Remove(fileName + ext)
+	}
+
+	return os.Rename(encrypted, fileName)
+}
+
+// SqliteJournalMode returns the SQLite journal mode, e.g. WAL or DELETE.
+func (c *Config) SqliteJournalMode() string {
+	switch mode := strings.ToUpper(strings.TrimSpace(c.options.SqliteJournalMode)); mode {
+	case "DELETE", "TRUNCATE", "PERSIST", "MEMORY", "WAL", "OFF":
+		return mode
+	case "":
+		return "WAL"
+	default:
+		log.Warnf("config: unsupported sqlite journal mode %s, using wal", mode)
+		return "WAL"
+	}
+}
+
+// SqliteBusyTimeout returns the number of milliseconds SQLite waits for a database lock.
+func (c *Config) SqliteBusyTimeout() int {
+	if c.options.SqliteBusyTimeout <= 0 {
+		return 5000
+	} else if c.options.SqliteBusyTimeout > 600000 {
+		return 600000
+	}
+
+	return c.options.SqliteBusyTimeout
+}
+
+// SqliteCacheSize returns the SQLite page cache size per connection in MB.
+func (c *Config) SqliteCacheSize() int {
+	if c.options.SqliteCacheSize <= 0 {
+		return 32
+	} else if c.options.SqliteCacheSize > 4096 {
+		return 4096
+	}
+
+	return c.options.SqliteCacheSize
+}
+
+// SqliteMmapSize returns the maximum size of memory-mapped SQLite database files in MB, or 0 if disabled.
+func (c *Config) SqliteMmapSize() int {
+	if c.options.SqliteMmapSize <= 0 {
+		return 0
+	} else if c.options.SqliteMmapSize > 65536 {
+		return 65536
+	}
+
+	return c.options.SqliteMmapSize
+}
+
+// SqlitePragmas returns the pragmas executed for new SQLite connections.
+func (c *Config) SqlitePragmas() []string {
+	pragmas := []string{
+		fmt.Sprintf("PRAGMA busy_timeout = %d", c.SqliteBusyTimeout()),
+		fmt.Sprintf("PRAGMA journal_mode = %s", c.SqliteJournalMode()),
+		fmt.Sprintf("PRAGMA cache_size = -%d", c.SqliteCacheSize()*1024),
+		fmt.Sprintf("PRAGMA mmap_size = %d", int64(c.SqliteMmapSize())*1024*1024),
+	}
+
+	// Syncing less often is safe in WAL mode, see https://www.sqlite.org/pragma.html#pragma_synchronous.
+	if c.SqliteJournalMode() == "WAL" {
+		pragmas = append(pragmas, "PRAGMA synchronous = NORMAL")
+	}
+
+	return pragmas
+}
+
+// SqliteDsn returns the SQLite data source name, with transactions acquiring the write lock immediately
+// so that waiting for a busy database does not fail with "database is locked" when upgrading read locks.
+func (c *Config) SqliteDsn() string {
+	dsn := c.DatabaseDsn()
+
+	if strings.Contains(dsn, "_txlock=") {
+		return dsn
+	} else if strings.Contains(dsn, "?") {
+		return dsn + "&_txlock=immediate"
+	}
+
+	return dsn + "?_txlock=immediate"
+}
@@ -0,0 +1,215 @@
+package config
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SqliteJournalMode(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "WAL", c.SqliteJournalMode())
+	c.options.SqliteJournalMode = "delete"
+	assert.Equal(t, "DELETE", c.SqliteJournalMode())
+	c.options.SqliteJournalMode = "xxx"
+	assert.Equal(t, "WAL", c.SqliteJournalMode())
+}
+
+func TestConfig_SqliteBusyTimeout(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 5000, c.SqliteBusyTimeout())
+	c.options.SqliteBusyTimeout = 1000
+	assert.Equal(t, 1000, c.SqliteBusyTimeout())
+	c.options.SqliteBusyTimeout = -1
+	assert.Equal(t, 5000, c.SqliteBusyTimeout())
+	c.options.SqliteBusyTimeout = 1000000
+	assert.Equal(t, 600000, c.SqliteBusyTimeout())
+}
+
+func TestConfig_SqliteCacheSize(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 32, c.SqliteCacheSize())
+	c.options.SqliteCacheSize = 100
+	assert.Equal(t, 100, c.SqliteCacheSize())
+	c.options.SqliteCacheSize = 10000
+	assert.Equal(t, 4096, c.SqliteCacheSize())
+}
+
+func TestConfig_SqliteMmapSize(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 0, c.SqliteMmapSize())
+	c.options.SqliteMmapSize = 256
+	assert.Equal(t, 256, c.SqliteMmapSize())
+	c.options.SqliteMmapSize = -1
+	assert.Equal(t, 0, c.SqliteMmapSize())
+}
+
+func TestConfig_SqlitePragmas(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, []string{
+		"PRAGMA busy_timeout = 5000",
+		"PRAGMA journal_mode = WAL",
+		"PRAGMA cache_size = -32768",
+		"PRAGMA mmap_size = 0",
+		"PRAGMA synchronous = NORMAL",
+	}, c.SqlitePragmas())
+
+	c.options.SqliteJournalMode = "delete"
+	c.options.SqliteMmapSize = 256
+
+	assert.Equal(t, []string{
+		"PRAGMA busy_timeout = 5000",
+		"PRAGMA journal_mode = DELETE",
+		"PRAGMA cache_size = -32768",
+		"PRAGMA mmap_size = 268435456",
+	}, c.SqlitePragmas())
+}
+
+func TestConfig_SqliteDsn(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.DatabaseDsn = "index.db"
+	assert.Equal(t, "index.db?_txlock=immediate", c.SqliteDsn())
+	c.options.DatabaseDsn = "index.db?_foreign_keys=1"
+	assert.Equal(t, "index.db?_foreign_keys=1&_txlock=immediate", c.SqliteDsn())
+	c.options.DatabaseDsn = "index.db?_txlock=deferred"
+	assert.Equal(t, "index.db?_txlock=deferred", c.SqliteDsn())
+}
+
+func TestSqliteKeyPragma(t *testing.T) {
+	assert.Equal(t, "PRAGMA key = 'secret'", SqliteKeyPragma("secret"))
+	assert.Equal(t, "PRAGMA key = 'it''s'", SqliteKeyPragma("it's"))
+}
+
+func TestConfig_SqliteKey(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.SqliteKey())
+	assert.Equal(t, "", c.SqliteKeyFile())
+	assert.False(t, c.SqliteEncrypted())
+
+	t.Run("File", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "sqlite.key")
+
+		if err := os.WriteFile(fileName, []byte("from-file\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		c.options.SqliteKeyFile = fileName
+		assert.Equal(t, fileName, c.SqliteKeyFile())
+		assert.Equal(t, "from-file", c.SqliteKey())
+		assert.True(t, c.SqliteEncrypted())
+	})
+	t.Run("Option", func(t *testing.T) {
+		c.options.SqliteKey = "secret"
+		assert.Equal(t, "secret", c.SqliteKey())
+		assert.True(t, c.SqliteEncrypted())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		c.options.SqliteKey = ""
+		c.options.SqliteKeyFile = "/xxx/sqlite.key"
+		assert.Equal(t, "", c.SqliteKey())
+		assert.False(t, c.SqliteEncrypted())
+	})
+}
+
+func TestConfig_SqliteFile(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.DatabaseDsn = "file:/photoprism/index.db?_busy_timeout=5000"
+	assert.Equal(t, "/photoprism/index.db", c.SqliteFile())
+
+	c.options.DatabaseDsn = "/photoprism/index.db"
+	assert.Equal(t, "/photoprism/index.db", c.SqliteFile())
+}
+
+func TestSqlitePlaintext(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "index.db")
+
+	db, err := sql.Open(SQLite3, fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	} else if _, err := db.Exec("CREATE TABLE test (id INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = db.Close()
+
+	assert.True(t, SqlitePlaintext(fileName))
+	assert.False(t, SqlitePlaintext(filepath.Join(dir, "xxx.db")))
+
+	t.Run("Encrypted", func(t *testing.T) {
+		encrypted := filepath.Join(dir, "encrypted.db")
+
+		if err := os.WriteFile(encrypted, []byte("0123456789abcdef0123456789abcdef"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, SqlitePlaintext(encrypted))
+	})
+}
+
+func TestConfig_SqliteEncrypt(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "index.db")
+
+	db, err := sql.Open(SQLite3, fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	} else if _, err := db.Exec("CREATE TABLE test (id INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = db.Close()
+
+	c.options.DatabaseDsn = fileName
+
+	t.Run("NoKey", func(t *testing.T) {
+		assert.Error(t, c.SqliteEncrypt())
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		c.options.SqliteKey = "secret"
+
+		if err := c.SqliteEncrypt(); err == nil {
+			t.Skip("sqlcipher available")
+		} else {
+			assert.Equal(t, ErrSqliteEncryptionUnsupported, err)
+		}
+
+		// The original database must be kept if encryption fails.
+		assert.True(t, SqlitePlaintext(fileName))
+		assert.NoFileExists(t, fileName+".encrypted")
+	})
+}
+
+func TestSqliteUnlock(t *testing.T) {
+	d := &sqlite3.SQLiteDriver{}
+
+	conn, err := d.Open(":memory:")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer conn.Close()
+
+	// The bundled SQLite library does not support encryption.
+	if version, _ := sqliteQueryString(conn.(*sqlite3.SQLiteConn), "PRAGMA cipher_version"); version != "" {
+		t.Skip("sqlcipher available")
+	}
+
+	assert.Equal(t, ErrSqliteEncryptionUnsupported, sqliteUnlock(conn.(*sqlite3.SQLiteConn), "secret"))
+}
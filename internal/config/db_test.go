@@ -1,6 +1,9 @@
 package config
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -108,3 +111,51 @@ func TestConfig_DatabaseConnsIdle(t *testing.T) {
 	c.options.DatabaseConnsIdle = 35
 	assert.Equal(t, 28, c.DatabaseConnsIdle())
 }
+
+func TestConfig_Explain(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.False(t, c.Explain())
+
+	c.options.Explain = true
+	assert.True(t, c.Explain())
+
+	c.options.Explain = false
+}
+
+func TestConfig_EphemeralDbFile(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, filepath.Join(c.TempPath(), fmt.Sprintf("index-%d.db", os.Getpid())), c.EphemeralDbFile())
+}
+
+func TestConfig_SidecarOnly(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.SidecarOnly())
+	assert.NotEqual(t, c.EphemeralDbFile(), c.DatabaseDsn())
+
+	c.options.SidecarOnly = true
+	c.options.DatabaseDriver = MySQL
+	c.options.DisableBackups = true
+
+	assert.True(t, c.SidecarOnly())
+	assert.Equal(t, SQLite3, c.DatabaseDriver())
+	assert.Equal(t, c.EphemeralDbFile(), c.DatabaseDsn())
+	assert.True(t, c.BackupYaml())
+
+	if err := os.MkdirAll(c.TempPath(), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	fileName := c.EphemeralDbFile()
+
+	if err := os.WriteFile(fileName, []byte("test"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(fileName+"-wal", []byte("test"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, c.CloseDb())
+	assert.NoFileExists(t, fileName)
+	assert.NoFileExists(t, fileName+"-wal")
+}
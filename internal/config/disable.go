@@ -99,3 +99,8 @@ func (c *Config) DisableSips() bool {
 func (c *Config) DisableHeifConvert() bool {
 	return c.options.DisableHeifConvert || c.HeifConvertBin() == ""
 }
+
+// DisableDcmtk tests if DCMTK is disabled for DICOM conversion.
+func (c *Config) DisableDcmtk() bool {
+	return c.options.DisableDcmtk || c.DcmtkBin() == ""
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	uuid "github.com/satori/go.uuid"
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// DefaultDLNAPort is the default port of the DLNA media server.
+const DefaultDLNAPort = 8200
+
+// DLNAKeys represents the token that smart TVs use to load photos and videos from the DLNA media server.
+type DLNAKeys struct {
+	Token string `yaml:"Token"`
+}
+
+// DLNA tests if the DLNA media server is enabled.
+func (c *Config) DLNA() bool {
+	return c.options.DLNA
+}
+
+// DLNAName returns the media server name displayed on smart TVs.
+func (c *Config) DLNAName() string {
+	if c.options.DLNAName == "" {
+		return c.SiteTitle()
+	}
+
+	return c.options.DLNAName
+}
+
+// DLNAPort returns the port of the DLNA media server, which only accepts requests from the local network.
+func (c *Config) DLNAPort() int {
+	if c.options.DLNAPort <= 0 || c.options.DLNAPort > 65535 {
+		return DefaultDLNAPort
+	}
+
+	return c.options.DLNAPort
+}
+
+// DLNAUUID returns the unique device name of the media server. It is based on the storage serial,
+// so that smart TVs recognize the server again after a restart.
+func (c *Config) DLNAUUID() string {
+	return uuid.NewV5(uuid.NamespaceOID, c.Serial()).String()
+}
+
+// DLNAKeysFile returns the file name of the generated DLNA media server token.
+func (c *Config) DLNAKeysFile() string {
+	return filepath.Join(c.ConfigPath(), "dlna.yml")
+}
+
+// DLNAToken returns the token for loading photos and videos from the DLNA media server. Unlike the
+// preview token, it is only accepted by the media server and can be revoked with ResetDLNAToken.
+func (c *Config) DLNAToken() string {
+	if c.options.DLNAToken != "" {
+		return c.options.DLNAToken
+	}
+
+	fileName := c.DLNAKeysFile()
+	keys := DLNAKeys{}
+
+	if fs.FileExists(fileName) {
+		if data, err := os.ReadFile(fileName); err != nil {
+			log.Errorf("config: %s (read dlna token)", err)
+		} else if err := yaml.Unmarshal(data, &keys); err != nil {
+			log.Errorf("config: %s (parse dlna token)", err)
+		}
+	}
+
+	if keys.Token == "" {
+		if err := c.ResetDLNAToken(); err != nil {
+			log.Errorf("config: %s (create dlna token)", err)
+		}
+
+		return c.options.DLNAToken
+	}
+
+	c.options.DLNAToken = keys.Token
+
+	return c.options.DLNAToken
+}
+
+// ResetDLNAToken replaces the DLNA media server token, so that previously shared media URLs stop working.
+func (c *Config) ResetDLNAToken() error {
+	keys := DLNAKeys{Token: rnd.Token(10) + rnd.Token(10)}
+
+	if data, err := yaml.Marshal(keys); err != nil {
+		return err
+	} else if err := os.WriteFile(c.DLNAKeysFile(), data, 0600); err != nil {
+		return err
+	}
+
+	c.options.DLNAToken = keys.Token
+
+	return nil
+}
+
+// InvalidDLNAToken tests if the token is not the DLNA media server token.
+func (c *Config) InvalidDLNAToken(t string) bool {
+	return t == "" || c.DLNAToken() != t
+}
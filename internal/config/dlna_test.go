@@ -0,0 +1,69 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestConfig_DLNA(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.DLNA())
+	c.options.DLNA = true
+	assert.True(t, c.DLNA())
+}
+
+func TestConfig_DLNAName(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, c.SiteTitle(), c.DLNAName())
+	c.options.DLNAName = "Living Room"
+	assert.Equal(t, "Living Room", c.DLNAName())
+}
+
+func TestConfig_DLNAUUID(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.True(t, rnd.IsUUID(c.DLNAUUID()))
+	assert.Equal(t, c.DLNAUUID(), c.DLNAUUID())
+}
+
+func TestConfig_DLNAPort(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, DefaultDLNAPort, c.DLNAPort())
+	c.options.DLNAPort = 9000
+	assert.Equal(t, 9000, c.DLNAPort())
+	c.options.DLNAPort = 70000
+	assert.Equal(t, DefaultDLNAPort, c.DLNAPort())
+}
+
+func TestConfig_DLNAToken(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.ConfigPath = t.TempDir()
+
+	token := c.DLNAToken()
+
+	assert.NotEmpty(t, token)
+	assert.NotEqual(t, c.PreviewToken(), token)
+	assert.FileExists(t, c.DLNAKeysFile())
+	assert.False(t, c.InvalidDLNAToken(token))
+	assert.True(t, c.InvalidDLNAToken(c.PreviewToken()))
+	assert.True(t, c.InvalidDLNAToken(""))
+
+	// The saved token is loaded after a restart.
+	c.options.DLNAToken = ""
+	assert.Equal(t, token, c.DLNAToken())
+
+	t.Run("Reset", func(t *testing.T) {
+		if err := c.ResetDLNAToken(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, token, c.DLNAToken())
+		assert.True(t, c.InvalidDLNAToken(token))
+	})
+}
@@ -9,6 +9,7 @@ var (
 	ErrReadOnly     = errors.New("not available in read-only mode")
 	ErrUnauthorized = errors.New("please log in and try again")
 	ErrUploadNSFW   = errors.New("upload might be offensive")
+	ErrStorageFull  = errors.New("not enough storage space available")
 )
 
 func LogError(err error) {
@@ -73,3 +73,12 @@ func (c *Config) FaceMatchDist() float64 {
 
 	return c.options.FaceMatchDist
 }
+
+// FaceKeyframes returns the number of video keyframes to search for faces, 0 if disabled.
+func (c *Config) FaceKeyframes() int {
+	if c.options.FaceKeyframes < 0 || c.options.FaceKeyframes > 20 {
+		return face.Keyframes
+	}
+
+	return c.options.FaceKeyframes
+}
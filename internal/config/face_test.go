@@ -77,3 +77,12 @@ func TestConfig_FaceMatchDist(t *testing.T) {
 	c.options.FaceMatchDist = 0.01
 	assert.Equal(t, 0.46, c.FaceMatchDist())
 }
+
+func TestConfig_FaceKeyframes(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, 0, c.FaceKeyframes())
+	c.options.FaceKeyframes = 5
+	assert.Equal(t, 5, c.FaceKeyframes())
+	c.options.FaceKeyframes = 50
+	assert.Equal(t, 3, c.FaceKeyframes())
+}
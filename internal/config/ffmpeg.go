@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // FFmpegBin returns the ffmpeg executable file name.
 func (c *Config) FFmpegBin() string {
 	return findExecutable(c.options.FFmpegBin, "ffmpeg")
@@ -43,3 +45,36 @@ func (c *Config) FFmpegBitrate() int {
 		return c.options.FFmpegBitrate
 	}
 }
+
+// FFmpegMaxDuration returns the maximum duration of videos that are transcoded, or 0 if unlimited.
+func (c *Config) FFmpegMaxDuration() time.Duration {
+	if c.options.FFmpegMaxDuration <= 0 {
+		return 0
+	}
+
+	return time.Duration(c.options.FFmpegMaxDuration) * time.Minute
+}
+
+// FFmpegHls tests if long videos should be segmented for adaptive HLS streaming.
+func (c *Config) FFmpegHls() bool {
+	return c.options.FFmpegHls && c.FFmpegEnabled()
+}
+
+// FFmpegSprite tests if sprite sheets of videos should be created for preview scrubbing.
+func (c *Config) FFmpegSprite() bool {
+	return c.options.FFmpegSprite && c.FFmpegEnabled()
+}
+
+// VideoPath returns the cache path for video segments.
+func (c *Config) VideoPath() string {
+	return c.CachePath() + "/videos"
+}
+
+// VideoCacheLimit returns the maximum size of the video cache in bytes, 0 for unlimited.
+func (c *Config) VideoCacheLimit() int64 {
+	if c.options.VideoCacheLimit <= 0 {
+		return 0
+	}
+
+	return int64(c.options.VideoCacheLimit) * 1024 * 1024
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -46,3 +47,52 @@ func TestConfig_FFmpegBitrate(t *testing.T) {
 	c.options.FFmpegBitrate = 800
 	assert.Equal(t, 800, c.FFmpegBitrate())
 }
+
+func TestConfig_FFmpegMaxDuration(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, time.Duration(0), c.FFmpegMaxDuration())
+
+	c.options.FFmpegMaxDuration = 30
+	assert.Equal(t, 30*time.Minute, c.FFmpegMaxDuration())
+
+	c.options.FFmpegMaxDuration = -5
+	assert.Equal(t, time.Duration(0), c.FFmpegMaxDuration())
+}
+
+func TestConfig_FFmpegHls(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.False(t, c.FFmpegHls())
+
+	c.options.FFmpegHls = true
+	assert.Equal(t, c.FFmpegEnabled(), c.FFmpegHls())
+
+	c.options.DisableFFmpeg = true
+	assert.False(t, c.FFmpegHls())
+}
+
+func TestConfig_VideoPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, c.CachePath()+"/videos", c.VideoPath())
+}
+
+func TestConfig_FFmpegSprite(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.False(t, c.FFmpegSprite())
+
+	c.options.FFmpegSprite = true
+	assert.Equal(t, c.FFmpegEnabled(), c.FFmpegSprite())
+
+	c.options.DisableFFmpeg = true
+	assert.False(t, c.FFmpegSprite())
+}
+
+func TestConfig_VideoCacheLimit(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, int64(0), c.VideoCacheLimit())
+
+	c.options.VideoCacheLimit = 512
+	assert.Equal(t, int64(512*1024*1024), c.VideoCacheLimit())
+
+	c.options.VideoCacheLimit = -1
+	assert.Equal(t, int64(0), c.VideoCacheLimit())
+}
@@ -21,6 +21,12 @@ var GlobalFlags = []cli.Flag{
 		Value:  "info",
 		EnvVar: "PHOTOPRISM_LOG_LEVEL",
 	},
+	cli.StringFlag{
+		Name:   "log-format",
+		Usage:  "log output `FORMAT`, text or json for structured logs",
+		Value:  "text",
+		EnvVar: "PHOTOPRISM_LOG_FORMAT",
+	},
 	cli.BoolFlag{
 		Name:   "debug",
 		Usage:  "enable debug mode, show additional log messages",
@@ -59,6 +65,16 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "disable import, upload, delete, and all other operations that require write permissions",
 		EnvVar: "PHOTOPRISM_READONLY",
 	},
+	cli.BoolFlag{
+		Name:   "strict-read-only",
+		Usage:  "never create, change, or delete files in the originals folder, and keep sidecar files in the storage folder",
+		EnvVar: "PHOTOPRISM_STRICT_READONLY",
+	},
+	cli.BoolFlag{
+		Name:   "maintenance",
+		Usage:  "start in maintenance mode, background workers are paused until it is disabled via the API",
+		EnvVar: "PHOTOPRISM_MAINTENANCE",
+	},
 	cli.BoolFlag{
 		Name:   "experimental, e",
 		Usage:  "enable experimental features",
@@ -85,12 +101,32 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "storage `PATH` of your original media files (photos and videos)",
 		EnvVar: "PHOTOPRISM_ORIGINALS_PATH",
 	},
+	cli.StringFlag{
+		Name:   "originals-roots",
+		Usage:  "additional originals `NAME:PATH[:SIDECAR],...` e.g. on other mounts, each with an optional sidecar path",
+		EnvVar: "PHOTOPRISM_ORIGINALS_ROOTS",
+	},
+	cli.StringFlag{
+		Name:   "ignore-symlinks",
+		Usage:  "originals root `NAMES` in which symbolic links are not followed, separated by commas, e.g. originals,nas",
+		EnvVar: "PHOTOPRISM_IGNORE_SYMLINKS",
+	},
 	cli.IntFlag{
 		Name:   "originals-limit",
 		Value:  1000,
 		Usage:  "file size limit in `MB`",
 		EnvVar: "PHOTOPRISM_ORIGINALS_LIMIT",
 	},
+	cli.IntFlag{
+		Name:   "originals-depth",
+		Usage:  "maximum folder `DEPTH` to index below an originals root (0 for unlimited)",
+		EnvVar: "PHOTOPRISM_ORIGINALS_DEPTH",
+	},
+	cli.StringFlag{
+		Name:   "junk-files",
+		Usage:  "junk file and folder name `PATTERNS` to skip, separated by commas (default: .DS_Store, ._*, Thumbs.db, @eaDir, trash folders, and more)",
+		EnvVar: "PHOTOPRISM_JUNK_FILES",
+	},
 	cli.StringFlag{
 		Name:   "storage-path",
 		Usage:  "writable storage `PATH` for cache, database, and sidecar files",
@@ -101,6 +137,11 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "base `PATH` from which files can be imported to originals (optional)",
 		EnvVar: "PHOTOPRISM_IMPORT_PATH",
 	},
+	cli.StringFlag{
+		Name:   "import-name",
+		Usage:  "file name `TEMPLATE` for imported originals, e.g. {date}_{time}_{camera}_{counter} (optional)",
+		EnvVar: "PHOTOPRISM_IMPORT_NAME",
+	},
 	cli.StringFlag{
 		Name:   "cache-path",
 		Usage:  "custom cache `PATH` for sessions and thumbnail files (optional)",
@@ -111,6 +152,22 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "custom relative or absolute sidecar `PATH` (optional)",
 		EnvVar: "PHOTOPRISM_SIDECAR_PATH",
 	},
+	cli.StringFlag{
+		Name:   "sidecar-layout",
+		Usage:  "YAML sidecar file `LAYOUT`: mirror, hash (stores files in hash-prefixed folders instead of mirroring originals, run \"photoprism migrations sidecars\" to move existing files)",
+		Value:  "mirror",
+		EnvVar: "PHOTOPRISM_SIDECAR_LAYOUT",
+	},
+	cli.BoolFlag{
+		Name:   "sidecar-gzip",
+		Usage:  "compress YAML sidecar files with gzip",
+		EnvVar: "PHOTOPRISM_SIDECAR_GZIP",
+	},
+	cli.BoolFlag{
+		Name:   "sidecar-only",
+		Usage:  "rebuild the index from originals and sidecar files at startup, using an ephemeral SQLite database",
+		EnvVar: "PHOTOPRISM_SIDECAR_ONLY",
+	},
 	cli.StringFlag{
 		Name:   "temp-path",
 		Usage:  "custom temporary file `PATH` (optional)",
@@ -126,12 +183,47 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "assets `PATH` containing static resources like icons, models, and translations",
 		EnvVar: "PHOTOPRISM_ASSETS_PATH",
 	},
+	cli.StringFlag{
+		Name:   "tiles-path",
+		Usage:  "optional `PATH` to MBTiles files for serving map tiles in offline environments",
+		EnvVar: "PHOTOPRISM_TILES_PATH",
+	},
+	cli.StringFlag{
+		Name:   "tiles-style",
+		Usage:  "offline map `STYLE`, i.e. the name of an MBTiles file in the tiles path without extension",
+		EnvVar: "PHOTOPRISM_TILES_STYLE",
+	},
 	cli.IntFlag{
 		Name:   "workers, w",
 		Usage:  "maximum `NUMBER` of indexing workers, default depends on the number of physical cores",
 		EnvVar: "PHOTOPRISM_WORKERS",
 		Value:  cpuid.CPU.PhysicalCores / 2,
 	},
+	cli.IntFlag{
+		Name:   "index-workers",
+		Usage:  "maximum `NUMBER` of workers for indexing and importing files (0 uses workers)",
+		EnvVar: "PHOTOPRISM_INDEX_WORKERS",
+	},
+	cli.IntFlag{
+		Name:   "thumb-workers",
+		Usage:  "maximum `NUMBER` of workers for generating thumbnails (0 uses workers)",
+		EnvVar: "PHOTOPRISM_THUMB_WORKERS",
+	},
+	cli.IntFlag{
+		Name:   "convert-workers",
+		Usage:  "maximum `NUMBER` of workers for converting files (0 uses workers)",
+		EnvVar: "PHOTOPRISM_CONVERT_WORKERS",
+	},
+	cli.IntFlag{
+		Name:   "face-workers",
+		Usage:  "maximum `NUMBER` of workers for clustering and matching faces (0 uses workers)",
+		EnvVar: "PHOTOPRISM_FACE_WORKERS",
+	},
+	cli.IntFlag{
+		Name:   "meta-workers",
+		Usage:  "maximum `NUMBER` of workers for optimizing metadata (0 for one worker)",
+		EnvVar: "PHOTOPRISM_META_WORKERS",
+	},
 	cli.IntFlag{
 		Name:   "wakeup-interval",
 		Usage:  "metadata, share & sync background worker wakeup interval in `SECONDS` (1-604800)",
@@ -150,6 +242,35 @@ var GlobalFlags = []cli.Flag{
 		Value:  DefaultAutoImportDelay,
 		EnvVar: "PHOTOPRISM_AUTO_IMPORT",
 	},
+	cli.BoolFlag{
+		Name:   "import-review",
+		Usage:  "imported photos must be approved in review before they appear in search results and albums",
+		EnvVar: "PHOTOPRISM_IMPORT_REVIEW",
+	},
+	cli.Int64Flag{
+		Name:   "upload-quota",
+		Usage:  "maximum size of pending WebDAV uploads per user in `MB` (1-100000), disable with -1",
+		Value:  -1,
+		EnvVar: "PHOTOPRISM_UPLOAD_QUOTA",
+	},
+	cli.Int64Flag{
+		Name:   "download-limit",
+		Usage:  "maximum size of ZIP downloads in `MB` (1-1000000), disable with -1",
+		Value:  -1,
+		EnvVar: "PHOTOPRISM_DOWNLOAD_LIMIT",
+	},
+	cli.Int64Flag{
+		Name:   "archive-limit",
+		Usage:  "maximum extracted size of imported ZIP and TAR archives in `MB` (1-1000000), disable with -1",
+		Value:  10000,
+		EnvVar: "PHOTOPRISM_ARCHIVE_LIMIT",
+	},
+	cli.Int64Flag{
+		Name:   "storage-reserve",
+		Usage:  "free disk space in `MB` to keep available, imports and uploads that need more are refused, disable with -1",
+		Value:  1024,
+		EnvVar: "PHOTOPRISM_STORAGE_RESERVE",
+	},
 	cli.BoolFlag{
 		Name:   "disable-webdav",
 		Usage:  "disable built-in WebDAV server",
@@ -200,6 +321,11 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "disable converting HEIC/HEIF files",
 		EnvVar: "PHOTOPRISM_DISABLE_HEIFCONVERT",
 	},
+	cli.BoolFlag{
+		Name:   "disable-dcmtk",
+		Usage:  "disable converting DICOM files with DCMTK",
+		EnvVar: "PHOTOPRISM_DISABLE_DCMTK",
+	},
 	cli.BoolFlag{
 		Name:   "disable-tensorflow",
 		Usage:  "disable all features depending on TensorFlow",
@@ -225,6 +351,24 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "allow uploads that may be offensive",
 		EnvVar: "PHOTOPRISM_UPLOAD_NSFW",
 	},
+	cli.IntFlag{
+		Name:   "share-upload-limit",
+		Usage:  "maximum size of guest uploads to shared albums in `MB`",
+		Value:  100,
+		EnvVar: "PHOTOPRISM_SHARE_UPLOAD_LIMIT",
+	},
+	cli.IntFlag{
+		Name:   "share-upload-files",
+		Usage:  "maximum `NUMBER` of files guests may upload to shared albums at once",
+		Value:  100,
+		EnvVar: "PHOTOPRISM_SHARE_UPLOAD_FILES",
+	},
+	cli.IntFlag{
+		Name:   "share-upload-rate",
+		Usage:  "maximum `NUMBER` of guest uploads per share link and hour",
+		Value:  20,
+		EnvVar: "PHOTOPRISM_SHARE_UPLOAD_RATE",
+	},
 	cli.StringFlag{
 		Name:   "default-theme",
 		Usage:  "standard user interface theme `NAME`",
@@ -325,6 +469,22 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "http server compression `METHOD` (none or gzip)",
 		EnvVar: "PHOTOPRISM_HTTP_COMPRESSION",
 	},
+	cli.BoolFlag{
+		Name:   "dlna",
+		Usage:  "enable DLNA media server on a separate port so that smart TVs in the local network can browse public albums",
+		EnvVar: "PHOTOPRISM_DLNA",
+	},
+	cli.StringFlag{
+		Name:   "dlna-name",
+		Usage:  "DLNA media server `NAME` displayed on smart TVs (default: site title)",
+		EnvVar: "PHOTOPRISM_DLNA_NAME",
+	},
+	cli.IntFlag{
+		Name:   "dlna-port",
+		Value:  DefaultDLNAPort,
+		Usage:  "DLNA media server port `NUMBER`, only accepts requests from the local network",
+		EnvVar: "PHOTOPRISM_DLNA_PORT",
+	},
 	cli.StringFlag{
 		Name:   "database-driver",
 		Usage:  "database `DRIVER` (sqlite or mysql)",
@@ -368,11 +528,70 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "maximum `NUMBER` of idle database connections",
 		EnvVar: "PHOTOPRISM_DATABASE_CONNS_IDLE",
 	},
+	cli.StringFlag{
+		Name:   "sqlite-journal-mode",
+		Usage:  "sqlite journal `MODE` (wal, delete, truncate, persist, memory, off)",
+		Value:  "wal",
+		EnvVar: "PHOTOPRISM_SQLITE_JOURNAL_MODE",
+	},
+	cli.IntFlag{
+		Name:   "sqlite-busy-timeout",
+		Usage:  "`MILLISECONDS` sqlite waits for a locked database",
+		Value:  5000,
+		EnvVar: "PHOTOPRISM_SQLITE_BUSY_TIMEOUT",
+	},
+	cli.IntFlag{
+		Name:   "sqlite-cache-size",
+		Usage:  "sqlite page cache size per connection in `MB`",
+		Value:  32,
+		EnvVar: "PHOTOPRISM_SQLITE_CACHE_SIZE",
+	},
+	cli.IntFlag{
+		Name:   "sqlite-mmap-size",
+		Usage:  "maximum size of memory-mapped sqlite files in `MB` (0 to disable)",
+		EnvVar: "PHOTOPRISM_SQLITE_MMAP_SIZE",
+	},
+	cli.StringFlag{
+		Name:   "sqlite-key",
+		Usage:  "sqlite encryption `KEY`, requires a build with sqlcipher (run \"photoprism migrations encrypt\" for an existing index)",
+		EnvVar: "PHOTOPRISM_SQLITE_KEY",
+	},
+	cli.StringFlag{
+		Name:   "sqlite-key-file",
+		Usage:  "`FILENAME` containing the sqlite encryption key",
+		EnvVar: "PHOTOPRISM_SQLITE_KEY_FILE",
+	},
+	cli.BoolFlag{
+		Name:   "explain",
+		Usage:  "log slow database queries with their query plans",
+		EnvVar: "PHOTOPRISM_EXPLAIN",
+	},
+	cli.StringFlag{
+		Name:   "trace-endpoint",
+		Usage:  "OpenTelemetry OTLP/HTTP collector `HOST:PORT` or URL for tracing, e.g. localhost:4318",
+		EnvVar: "PHOTOPRISM_TRACE_ENDPOINT",
+	},
+	cli.BoolFlag{
+		Name:   "trace-insecure",
+		Usage:  "send traces to the collector without TLS",
+		EnvVar: "PHOTOPRISM_TRACE_INSECURE",
+	},
+	cli.Float64Flag{
+		Name:   "trace-sample-rate",
+		Usage:  "`FRACTION` of traces that are recorded, from 0 to 1",
+		Value:  1,
+		EnvVar: "PHOTOPRISM_TRACE_SAMPLE_RATE",
+	},
 	cli.BoolFlag{
 		Name:   "raw-presets",
 		Usage:  "enable RAW file converter presets (may reduce performance)",
 		EnvVar: "PHOTOPRISM_RAW_PRESETS",
 	},
+	cli.BoolFlag{
+		Name:   "raw-previews",
+		Usage:  "use embedded JPEG previews of RAW files instead of converting them if large enough",
+		EnvVar: "PHOTOPRISM_RAW_PREVIEWS",
+	},
 	cli.StringFlag{
 		Name:   "darktable-bin",
 		Usage:  "Darktable CLI `COMMAND` for RAW image conversion",
@@ -409,6 +628,12 @@ var GlobalFlags = []cli.Flag{
 		Value:  "heif-convert",
 		EnvVar: "PHOTOPRISM_HEIFCONVERT_BIN",
 	},
+	cli.StringFlag{
+		Name:   "dcmtk-bin",
+		Usage:  "DCMTK `COMMAND` for DICOM image conversion",
+		Value:  "dcmj2pnm",
+		EnvVar: "PHOTOPRISM_DCMTK_BIN",
+	},
 	cli.StringFlag{
 		Name:   "ffmpeg-bin",
 		Usage:  "FFmpeg `COMMAND` for video transcoding and thumbnail extraction",
@@ -427,18 +652,63 @@ var GlobalFlags = []cli.Flag{
 		Value:  50,
 		EnvVar: "PHOTOPRISM_FFMPEG_BITRATE",
 	},
+	cli.IntFlag{
+		Name:   "ffmpeg-max-duration",
+		Usage:  "maximum video duration in `MINUTES` to transcode (0 for unlimited)",
+		EnvVar: "PHOTOPRISM_FFMPEG_MAX_DURATION",
+	},
 	cli.IntFlag{
 		Name:   "ffmpeg-buffers",
 		Usage:  "`NUMBER` of FFmpeg capture buffers",
 		Value:  32,
 		EnvVar: "PHOTOPRISM_FFMPEG_BUFFERS",
 	},
+	cli.BoolFlag{
+		Name:   "ffmpeg-hls",
+		Usage:  "segment long videos for adaptive HLS streaming",
+		EnvVar: "PHOTOPRISM_FFMPEG_HLS",
+	},
+	cli.BoolFlag{
+		Name:   "ffmpeg-sprite",
+		Usage:  "create sprite sheets of videos for preview scrubbing",
+		EnvVar: "PHOTOPRISM_FFMPEG_SPRITE",
+	},
+	cli.IntFlag{
+		Name:   "video-cache-limit",
+		Usage:  "maximum size of cached video segments and sprite sheets in `MB` (0 for unlimited)",
+		EnvVar: "PHOTOPRISM_VIDEO_CACHE_LIMIT",
+	},
 	cli.StringFlag{
 		Name:   "exiftool-bin",
 		Usage:  "ExifTool `COMMAND` for extracting metadata",
 		Value:  "exiftool",
 		EnvVar: "PHOTOPRISM_EXIFTOOL_BIN",
 	},
+	cli.StringFlag{
+		Name:   "metadata-cmd",
+		Usage:  "external `COMMAND` that writes additional metadata as JSON to stdout, the file name is appended",
+		EnvVar: "PHOTOPRISM_METADATA_CMD",
+	},
+	cli.StringFlag{
+		Name:   "metadata-ext",
+		Usage:  "comma-separated file `EXTENSIONS` for the metadata command (default: all)",
+		EnvVar: "PHOTOPRISM_METADATA_EXT",
+	},
+	cli.StringFlag{
+		Name:   "exif-writeback",
+		Usage:  "write manually edited metadata `FIELDS` back to originals with ExifTool, e.g. title,keywords,gps,rating",
+		EnvVar: "PHOTOPRISM_EXIF_WRITEBACK",
+	},
+	cli.StringFlag{
+		Name:   "metadata-priority",
+		Usage:  "metadata source `PRIORITIES` by field in descending order, e.g. taken:meta>xmp>yaml,title:manual>xmp>meta",
+		EnvVar: "PHOTOPRISM_METADATA_PRIORITY",
+	},
+	cli.StringFlag{
+		Name:   "share-redact",
+		Usage:  "metadata `FIELDS` removed from shared content, e.g. serial,owner,gps,filenames",
+		EnvVar: "PHOTOPRISM_SHARE_REDACT",
+	},
 	cli.StringFlag{
 		Name:   "download-token",
 		Usage:  "`SECRET` download URL token for originals (default: random)",
@@ -449,6 +719,48 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "`SECRET` thumbnail and video streaming URL token (default: random)",
 		EnvVar: "PHOTOPRISM_PREVIEW_TOKEN",
 	},
+	cli.IntFlag{
+		Name:   "token-lifetime",
+		Usage:  "download and preview URL token lifetime in `SECONDS`, tokens are created per session and rotated if set (0 for unlimited)",
+		EnvVar: "PHOTOPRISM_TOKEN_LIFETIME",
+	},
+	cli.StringFlag{
+		Name:   "push-public-key",
+		Usage:  "VAPID public `KEY` for sending push notifications to web apps (default: generated)",
+		EnvVar: "PHOTOPRISM_PUSH_PUBLIC_KEY",
+	},
+	cli.StringFlag{
+		Name:   "push-private-key",
+		Usage:  "VAPID private `KEY` for sending push notifications to web apps (default: generated)",
+		EnvVar: "PHOTOPRISM_PUSH_PRIVATE_KEY",
+	},
+	cli.StringFlag{
+		Name:   "push-subject",
+		Usage:  "contact `URL` or mailto address sent to push services (default: site url)",
+		EnvVar: "PHOTOPRISM_PUSH_SUBJECT",
+	},
+	cli.StringFlag{
+		Name:   "print-url",
+		Usage:  "print service endpoint `URL` to which selected photos can be sent for ordering prints (optional)",
+		EnvVar: "PHOTOPRISM_PRINT_URL",
+	},
+	cli.StringFlag{
+		Name:   "print-token",
+		Usage:  "access `TOKEN` sent to the print service as bearer authorization (optional)",
+		EnvVar: "PHOTOPRISM_PRINT_TOKEN",
+	},
+	cli.StringFlag{
+		Name:   "thumb-library",
+		Usage:  "image processing `LIBRARY` for creating thumbnails (imaging, vips)",
+		Value:  "imaging",
+		EnvVar: "PHOTOPRISM_THUMB_LIBRARY",
+	},
+	cli.StringFlag{
+		Name:   "vips-bin",
+		Usage:  "libvips vipsthumbnail `COMMAND` for fast thumbnail generation",
+		Value:  "vipsthumbnail",
+		EnvVar: "PHOTOPRISM_VIPS_BIN",
+	},
 	cli.StringFlag{
 		Name:   "thumb-filter",
 		Usage:  "thumbnail downscaling `FILTER` (best to worst: blackman, lanczos, cubic, linear)",
@@ -532,6 +844,17 @@ var GlobalFlags = []cli.Flag{
 		Value:  face.MatchDist,
 		EnvVar: "PHOTOPRISM_FACE_MATCH_DIST",
 	},
+	cli.IntFlag{
+		Name:   "face-keyframes",
+		Usage:  "`NUMBER` of video keyframes to search for faces (0-20)",
+		Value:  face.Keyframes,
+		EnvVar: "PHOTOPRISM_FACE_KEYFRAMES",
+	},
+	cli.StringFlag{
+		Name:   "caption-uri",
+		Usage:  "captioning service `URI` for generating image descriptions (disabled if empty)",
+		EnvVar: "PHOTOPRISM_CAPTION_URI",
+	},
 	cli.StringFlag{
 		Name:   "pid-filename",
 		Usage:  "process id `FILENAME` (daemon mode only)",
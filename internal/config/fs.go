@@ -6,8 +6,11 @@ import (
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"strings"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
@@ -216,6 +219,40 @@ func (c *Config) ImportPath() string {
 	return fs.Abs(c.options.ImportPath)
 }
 
+// ImportName returns the file name template for imported originals, or an empty string for canonical names.
+func (c *Config) ImportName() string {
+	return strings.TrimSpace(c.options.ImportName)
+}
+
+// JunkFiles returns the name patterns of junk files and folders to skip, e.g. .DS_Store or Thumbs.db.
+func (c *Config) JunkFiles() (patterns []string) {
+	if c.options.JunkFiles == "" {
+		return fs.DefaultJunkPatterns
+	}
+
+	for _, s := range strings.Split(c.options.JunkFiles, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			patterns = append(patterns, s)
+		}
+	}
+
+	return patterns
+}
+
+// UploadPath returns the path to the private WebDAV upload folders, which are not part of the shared import folder.
+func (c *Config) UploadPath() string {
+	return filepath.Join(c.StoragePath(), "upload")
+}
+
+// UserUploadPath returns the private WebDAV upload folder of a user, or an empty string if the user uid is invalid.
+func (c *Config) UserUploadPath(userUID string) string {
+	if !rnd.IsUID(userUID, 'u') {
+		return ""
+	}
+
+	return filepath.Join(c.UploadPath(), userUID)
+}
+
 // ExifToolBin returns the exiftool executable file name.
 func (c *Config) ExifToolBin() string {
 	return findExecutable(c.options.ExifToolBin, "exiftool")
@@ -226,20 +263,181 @@ func (c *Config) ExifToolJson() bool {
 	return !c.DisableExifTool()
 }
 
-// BackupYaml tests if creating YAML files is enabled.
+// Metadata fields that can be written back to originals.
+const (
+	ExifWritebackTitle    = "title"
+	ExifWritebackKeywords = "keywords"
+	ExifWritebackGps      = "gps"
+	ExifWritebackRating   = "rating"
+)
+
+// ExifWriteback returns the metadata fields that are written back to originals with ExifTool, if any.
+func (c *Config) ExifWriteback() (fields []string) {
+	if c.ReadOnly() || c.ExifToolBin() == "" {
+		return fields
+	}
+
+	for _, s := range strings.Split(c.options.ExifWriteback, ",") {
+		s = strings.ToLower(strings.TrimSpace(s))
+
+		switch s {
+		case "":
+			continue
+		case ExifWritebackTitle, ExifWritebackKeywords, ExifWritebackGps, ExifWritebackRating:
+			fields = append(fields, s)
+		default:
+			log.Warnf("config: unknown exif writeback field %s", sanitize.Log(s))
+		}
+	}
+
+	return fields
+}
+
+// MetadataCmd returns the external command for extracting additional metadata, if any.
+func (c *Config) MetadataCmd() string {
+	return strings.TrimSpace(c.options.MetadataCmd)
+}
+
+// MetadataExt returns the comma-separated file extensions the metadata command is used for.
+func (c *Config) MetadataExt() string {
+	return strings.TrimSpace(c.options.MetadataExt)
+}
+
+// MetadataPriority returns the custom metadata source priorities by field, with the sources in
+// descending order of precedence, e.g. "taken:meta>xmp>yaml,title:manual>xmp>meta".
+func (c *Config) MetadataPriority() map[string][]string {
+	result := make(map[string][]string)
+
+	for _, s := range strings.Split(c.options.MetadataPriority, ",") {
+		s = strings.ToLower(strings.TrimSpace(s))
+
+		if s == "" {
+			continue
+		}
+
+		parts := strings.SplitN(s, ":", 2)
+		field := strings.TrimSpace(parts[0])
+
+		if !entity.PriorityField(field) {
+			log.Warnf("config: unknown metadata priority field %s", sanitize.Log(field))
+			continue
+		} else if len(parts) < 2 {
+			log.Warnf("config: missing metadata sources for %s", sanitize.Log(field))
+			continue
+		}
+
+		var sources []string
+		found := make(map[string]bool)
+
+		for _, src := range strings.Split(parts[1], ">") {
+			src = strings.TrimSpace(src)
+
+			if _, ok := entity.SrcPriority[src]; !ok || src == entity.SrcAuto {
+				log.Warnf("config: unknown metadata source %s for %s", sanitize.Log(src), sanitize.Log(field))
+			} else if !found[src] {
+				found[src] = true
+				sources = append(sources, src)
+			}
+		}
+
+		if len(sources) > 0 {
+			result[field] = sources
+		}
+	}
+
+	return result
+}
+
+// MetadataPriorityString returns the custom metadata source priorities as string, see MetadataPriority.
+func (c *Config) MetadataPriorityString() string {
+	priority := c.MetadataPriority()
+
+	var fields []string
+
+	for _, field := range entity.PriorityFields {
+		if sources, ok := priority[field]; ok {
+			fields = append(fields, field+":"+strings.Join(sources, ">"))
+		}
+	}
+
+	return strings.Join(fields, ",")
+}
+
+// BackupYaml tests if creating YAML files is enabled. This is always the case in sidecar-only mode,
+// as changes would otherwise be lost on restart.
 func (c *Config) BackupYaml() bool {
-	return !c.DisableBackups()
+	return c.SidecarOnly() || !c.DisableBackups()
 }
 
 // SidecarPath returns the storage path for generated sidecar files (relative or absolute).
 func (c *Config) SidecarPath() string {
 	if c.options.SidecarPath == "" {
 		c.options.SidecarPath = filepath.Join(c.StoragePath(), "sidecar")
+	} else if c.StrictReadOnly() && (!filepath.IsAbs(c.options.SidecarPath) || c.InsideOriginals(c.options.SidecarPath)) {
+		log.Warnf("config: sidecar files cannot be stored in originals in strict read-only mode")
+		c.options.SidecarPath = filepath.Join(c.StoragePath(), "sidecar")
+	} else if c.SidecarLayout() == entity.SidecarLayoutHash && !filepath.IsAbs(c.options.SidecarPath) {
+		log.Warnf("config: hash-prefixed sidecar folders must be stored outside originals")
+		c.options.SidecarPath = filepath.Join(c.StoragePath(), "sidecar")
 	}
 
 	return c.options.SidecarPath
 }
 
+// SidecarLayout returns the YAML sidecar file layout, either "mirror" or "hash".
+func (c *Config) SidecarLayout() string {
+	switch strings.ToLower(strings.TrimSpace(c.options.SidecarLayout)) {
+	case entity.SidecarLayoutHash:
+		return entity.SidecarLayoutHash
+	default:
+		return entity.SidecarLayoutMirror
+	}
+}
+
+// SidecarGzip tests if YAML sidecar files should be compressed with gzip.
+func (c *Config) SidecarGzip() bool {
+	return c.options.SidecarGzip
+}
+
+// SidecarOnly tests if the index should be rebuilt from originals and sidecar files at startup, so that
+// only these need to be persisted, e.g. for stateless container deployments.
+func (c *Config) SidecarOnly() bool {
+	return c.options.SidecarOnly
+}
+
+// InsideOriginals tests if the path is inside the originals folder.
+func (c *Config) InsideOriginals(path string) bool {
+	originalsPath := c.OriginalsPath()
+
+	if originalsPath == "" || path == "" {
+		return false
+	}
+
+	path = fs.Abs(path)
+
+	return path == originalsPath || strings.HasPrefix(path, originalsPath+string(os.PathSeparator))
+}
+
+// initProtectedPaths protects the originals from changes in strict read-only mode.
+func (c *Config) initProtectedPaths() error {
+	paths := c.ProtectedPaths()
+
+	if len(paths) == 0 {
+		fs.SetProtected()
+		return nil
+	}
+
+	if c.InsideOriginals(c.StoragePath()) {
+		return fmt.Errorf("config: storage folder must not be inside originals in strict read-only mode")
+	}
+
+	fs.SetProtected(paths...)
+
+	log.Infof("config: strict read-only mode enabled, originals will not be changed")
+
+	return nil
+}
+
 // SidecarPathIsAbs tests if sidecar path is absolute.
 func (c *Config) SidecarPathIsAbs() bool {
 	return filepath.IsAbs(c.SidecarPath())
@@ -278,7 +476,7 @@ func (c *Config) StoragePath() string {
 		storageDir := fs.Abs(dirName)
 
 		// Find existing directories.
-		if fs.PathWritable(originalsDir) && !c.ReadOnly() {
+		if !c.ReadOnly() && fs.PathWritable(originalsDir) {
 			return originalsDir
 		} else if fs.PathWritable(storageDir) && c.ReadOnly() {
 			return storageDir
@@ -354,8 +552,12 @@ func (c *Config) MysqldumpBin() string {
 	return findExecutable("", "mysqldump")
 }
 
-// SqliteBin returns the sqlite executable file name.
+// SqliteBin returns the sqlite executable file name, or the sqlcipher executable if the index is encrypted.
 func (c *Config) SqliteBin() string {
+	if c.SqliteEncrypted() {
+		return findExecutable("", "sqlcipher")
+	}
+
 	return findExecutable("", "sqlite3")
 }
 
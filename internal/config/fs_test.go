@@ -1,8 +1,10 @@
 package config
 
 import (
+	"path/filepath"
 	"testing"
 
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/stretchr/testify/assert"
 )
@@ -45,6 +47,61 @@ func TestConfig_SidecarPath(t *testing.T) {
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/sidecar", c.SidecarPath())
 }
 
+func TestConfig_SidecarPathStrictReadOnly(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.StrictReadOnly = true
+	c.options.SidecarPath = ".photoprism"
+	assert.Equal(t, filepath.Join(c.StoragePath(), "sidecar"), c.SidecarPath())
+	c.options.SidecarPath = filepath.Join(c.OriginalsPath(), ".photoprism")
+	assert.Equal(t, filepath.Join(c.StoragePath(), "sidecar"), c.SidecarPath())
+	c.options.SidecarPath = "/tmp/photoprism/sidecar"
+	assert.Equal(t, "/tmp/photoprism/sidecar", c.SidecarPath())
+}
+
+func TestConfig_SidecarLayout(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "mirror", c.SidecarLayout())
+	assert.False(t, c.SidecarGzip())
+	c.options.SidecarLayout = " Hash "
+	assert.Equal(t, "hash", c.SidecarLayout())
+	c.options.SidecarPath = ".photoprism"
+	assert.Equal(t, filepath.Join(c.StoragePath(), "sidecar"), c.SidecarPath())
+	c.options.SidecarLayout = "xxx"
+	assert.Equal(t, "mirror", c.SidecarLayout())
+}
+
+func TestConfig_InsideOriginals(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.True(t, c.InsideOriginals(c.OriginalsPath()))
+	assert.True(t, c.InsideOriginals(filepath.Join(c.OriginalsPath(), "2021/photo.jpg")))
+	assert.False(t, c.InsideOriginals(c.OriginalsPath()+"-sidecar"))
+	assert.False(t, c.InsideOriginals(c.StoragePath()))
+	assert.False(t, c.InsideOriginals(""))
+}
+
+func TestConfig_ProtectedPaths(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.ProtectedPaths())
+	assert.NoError(t, c.initProtectedPaths())
+	assert.False(t, fs.Protected(c.OriginalsPath()))
+
+	c.options.StrictReadOnly = true
+	defer fs.SetProtected()
+
+	assert.True(t, c.ReadOnly())
+	assert.Equal(t, []string{c.OriginalsPath()}, c.ProtectedPaths())
+	assert.NoError(t, c.initProtectedPaths())
+	assert.True(t, fs.Protected(filepath.Join(c.OriginalsPath(), "photo.jpg")))
+	assert.False(t, fs.Protected(filepath.Join(c.SidecarPath(), "photo.yml")))
+
+	c.options.StoragePath = filepath.Join(c.OriginalsPath(), ".photoprism")
+	assert.Error(t, c.initProtectedPaths())
+}
+
 func TestConfig_SidecarPathIsAbs(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -321,6 +378,14 @@ func TestConfig_ImportPath2(t *testing.T) {
 	assert.Equal(t, "", c.ImportPath())
 }
 
+func TestConfig_ImportName(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, "", c.ImportName())
+	c.options.ImportName = " {date}_{counter} "
+	assert.Equal(t, "{date}_{counter}", c.ImportName())
+	c.options.ImportName = ""
+}
+
 func TestConfig_AssetsPath2(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/assets", c.AssetsPath())
@@ -342,3 +407,12 @@ func TestConfig_SqliteBin(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Contains(t, c.SqliteBin(), "sqlite")
 }
+
+func TestConfig_JunkFiles(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, fs.DefaultJunkPatterns, c.JunkFiles())
+	c.options.JunkFiles = " .DS_Store, Thumbs.db,,*.ini "
+	assert.Equal(t, []string{".DS_Store", "Thumbs.db", "*.ini"}, c.JunkFiles())
+	c.options.JunkFiles = ""
+}
@@ -38,6 +38,7 @@ type Options struct {
 	PartnerID             string  `yaml:"-" json:"-" flag:"partner-id"`
 	AdminPassword         string  `yaml:"AdminPassword" json:"-" flag:"admin-password"`
 	LogLevel              string  `yaml:"LogLevel" json:"-" flag:"log-level"`
+	LogFormat             string  `yaml:"LogFormat" json:"-" flag:"log-format"`
 	Debug                 bool    `yaml:"Debug" json:"Debug" flag:"debug"`
 	Test                  bool    `yaml:"-" json:"Test,omitempty" flag:"test"`
 	Unsafe                bool    `yaml:"-" json:"-" flag:"unsafe"`
@@ -45,22 +46,44 @@ type Options struct {
 	Sponsor               bool    `yaml:"-" json:"-" flag:"sponsor"`
 	Public                bool    `yaml:"Public" json:"-" flag:"public"`
 	ReadOnly              bool    `yaml:"ReadOnly" json:"ReadOnly" flag:"read-only"`
+	StrictReadOnly        bool    `yaml:"StrictReadOnly" json:"StrictReadOnly" flag:"strict-read-only"`
+	Maintenance           bool    `yaml:"Maintenance" json:"-" flag:"maintenance"`
 	Experimental          bool    `yaml:"Experimental" json:"Experimental" flag:"experimental"`
 	ConfigPath            string  `yaml:"ConfigPath" json:"-" flag:"config-path"`
 	ConfigFile            string  `json:"-"`
 	OriginalsPath         string  `yaml:"OriginalsPath" json:"-" flag:"originals-path"`
+	OriginalsRoots        string  `yaml:"OriginalsRoots" json:"-" flag:"originals-roots"`
+	IgnoreSymlinks        string  `yaml:"IgnoreSymlinks" json:"-" flag:"ignore-symlinks"`
 	OriginalsLimit        int64   `yaml:"OriginalsLimit" json:"OriginalsLimit" flag:"originals-limit"`
+	OriginalsDepth        int     `yaml:"OriginalsDepth" json:"OriginalsDepth" flag:"originals-depth"`
+	JunkFiles             string  `yaml:"JunkFiles" json:"-" flag:"junk-files"`
 	StoragePath           string  `yaml:"StoragePath" json:"-" flag:"storage-path"`
 	ImportPath            string  `yaml:"ImportPath" json:"-" flag:"import-path"`
+	ImportName            string  `yaml:"ImportName" json:"ImportName" flag:"import-name"`
 	CachePath             string  `yaml:"CachePath" json:"-" flag:"cache-path"`
 	SidecarPath           string  `yaml:"SidecarPath" json:"-" flag:"sidecar-path"`
+	SidecarLayout         string  `yaml:"SidecarLayout" json:"SidecarLayout" flag:"sidecar-layout"`
+	SidecarGzip           bool    `yaml:"SidecarGzip" json:"SidecarGzip" flag:"sidecar-gzip"`
+	SidecarOnly           bool    `yaml:"SidecarOnly" json:"SidecarOnly" flag:"sidecar-only"`
 	TempPath              string  `yaml:"TempPath" json:"-" flag:"temp-path"`
 	BackupPath            string  `yaml:"BackupPath" json:"-" flag:"backup-path"`
 	AssetsPath            string  `yaml:"AssetsPath" json:"-" flag:"assets-path"`
+	TilesPath             string  `yaml:"TilesPath" json:"-" flag:"tiles-path"`
+	TilesStyle            string  `yaml:"TilesStyle" json:"TilesStyle" flag:"tiles-style"`
 	Workers               int     `yaml:"Workers" json:"Workers" flag:"workers"`
+	IndexWorkers          int     `yaml:"IndexWorkers" json:"IndexWorkers" flag:"index-workers"`
+	ThumbWorkers          int     `yaml:"ThumbWorkers" json:"ThumbWorkers" flag:"thumb-workers"`
+	ConvertWorkers        int     `yaml:"ConvertWorkers" json:"ConvertWorkers" flag:"convert-workers"`
+	FaceWorkers           int     `yaml:"FaceWorkers" json:"FaceWorkers" flag:"face-workers"`
+	MetaWorkers           int     `yaml:"MetaWorkers" json:"MetaWorkers" flag:"meta-workers"`
 	WakeupInterval        int     `yaml:"WakeupInterval" json:"WakeupInterval" flag:"wakeup-interval"`
 	AutoIndex             int     `yaml:"AutoIndex" json:"AutoIndex" flag:"auto-index"`
 	AutoImport            int     `yaml:"AutoImport" json:"AutoImport" flag:"auto-import"`
+	ImportReview          bool    `yaml:"ImportReview" json:"ImportReview" flag:"import-review"`
+	UploadQuota           int64   `yaml:"UploadQuota" json:"UploadQuota" flag:"upload-quota"`
+	DownloadLimit         int64   `yaml:"DownloadLimit" json:"DownloadLimit" flag:"download-limit"`
+	ArchiveLimit          int64   `yaml:"ArchiveLimit" json:"ArchiveLimit" flag:"archive-limit"`
+	StorageReserve        int64   `yaml:"StorageReserve" json:"StorageReserve" flag:"storage-reserve"`
 	DisableWebDAV         bool    `yaml:"DisableWebDAV" json:"DisableWebDAV" flag:"disable-webdav"`
 	DisableBackups        bool    `yaml:"DisableBackups" json:"DisableBackups" flag:"disable-backups"`
 	DisableSettings       bool    `yaml:"DisableSettings" json:"-" flag:"disable-settings"`
@@ -71,11 +94,15 @@ type Options struct {
 	DisableRawtherapee    bool    `yaml:"DisableRawtherapee" json:"DisableRawtherapee" flag:"disable-rawtherapee"`
 	DisableSips           bool    `yaml:"DisableSips" json:"DisableSips" flag:"disable-sips"`
 	DisableHeifConvert    bool    `yaml:"DisableHeifConvert" json:"DisableHeifConvert" flag:"disable-heifconvert"`
+	DisableDcmtk          bool    `yaml:"DisableDcmtk" json:"DisableDcmtk" flag:"disable-dcmtk"`
 	DisableTensorFlow     bool    `yaml:"DisableTensorFlow" json:"DisableTensorFlow" flag:"disable-tensorflow"`
 	DisableFaces          bool    `yaml:"DisableFaces" json:"DisableFaces" flag:"disable-faces"`
 	DisableClassification bool    `yaml:"DisableClassification" json:"DisableClassification" flag:"disable-classification"`
 	DetectNSFW            bool    `yaml:"DetectNSFW" json:"DetectNSFW" flag:"detect-nsfw"`
 	UploadNSFW            bool    `yaml:"UploadNSFW" json:"-" flag:"upload-nsfw"`
+	ShareUploadLimit      int     `yaml:"ShareUploadLimit" json:"ShareUploadLimit" flag:"share-upload-limit"`
+	ShareUploadFiles      int     `yaml:"ShareUploadFiles" json:"ShareUploadFiles" flag:"share-upload-files"`
+	ShareUploadRate       int     `yaml:"ShareUploadRate" json:"ShareUploadRate" flag:"share-upload-rate"`
 	DefaultTheme          string  `yaml:"DefaultTheme" json:"DefaultTheme" flag:"default-theme"`
 	DefaultLocale         string  `yaml:"DefaultLocale" json:"DefaultLocale" flag:"default-locale"`
 	AppIcon               string  `yaml:"AppIcon" json:"AppIcon" flag:"app-icon"`
@@ -98,25 +125,58 @@ type Options struct {
 	DatabasePassword      string  `yaml:"DatabasePassword" json:"-" flag:"database-password"`
 	DatabaseConns         int     `yaml:"DatabaseConns" json:"-" flag:"database-conns"`
 	DatabaseConnsIdle     int     `yaml:"DatabaseConnsIdle" json:"-" flag:"database-conns-idle"`
+	SqliteJournalMode     string  `yaml:"SqliteJournalMode" json:"-" flag:"sqlite-journal-mode"`
+	SqliteBusyTimeout     int     `yaml:"SqliteBusyTimeout" json:"-" flag:"sqlite-busy-timeout"`
+	SqliteCacheSize       int     `yaml:"SqliteCacheSize" json:"-" flag:"sqlite-cache-size"`
+	SqliteMmapSize        int     `yaml:"SqliteMmapSize" json:"-" flag:"sqlite-mmap-size"`
+	SqliteKey             string  `yaml:"SqliteKey" json:"-" flag:"sqlite-key"`
+	SqliteKeyFile         string  `yaml:"SqliteKeyFile" json:"-" flag:"sqlite-key-file"`
+	Explain               bool    `yaml:"Explain" json:"Explain" flag:"explain"`
+	TraceEndpoint         string  `yaml:"TraceEndpoint" json:"-" flag:"trace-endpoint"`
+	TraceInsecure         bool    `yaml:"TraceInsecure" json:"-" flag:"trace-insecure"`
+	TraceSampleRate       float64 `yaml:"TraceSampleRate" json:"-" flag:"trace-sample-rate"`
 	HttpHost              string  `yaml:"HttpHost" json:"-" flag:"http-host"`
 	HttpPort              int     `yaml:"HttpPort" json:"-" flag:"http-port"`
 	HttpMode              string  `yaml:"HttpMode" json:"-" flag:"http-mode"`
 	HttpCompression       string  `yaml:"HttpCompression" json:"-" flag:"http-compression"`
+	DLNA                  bool    `yaml:"DLNA" json:"-" flag:"dlna"`
+	DLNAName              string  `yaml:"DLNAName" json:"-" flag:"dlna-name"`
+	DLNAPort              int     `yaml:"DLNAPort" json:"-" flag:"dlna-port"`
+	DLNAToken             string  `yaml:"-" json:"-"`
 	RawPresets            bool    `yaml:"RawPresets" json:"RawPresets" flag:"raw-presets"`
+	RawPreviews           bool    `yaml:"RawPreviews" json:"RawPreviews" flag:"raw-previews"`
 	DarktableBin          string  `yaml:"DarktableBin" json:"-" flag:"darktable-bin"`
 	DarktableBlacklist    string  `yaml:"DarktableBlacklist" json:"-" flag:"darktable-blacklist"`
 	RawtherapeeBin        string  `yaml:"RawtherapeeBin" json:"-" flag:"rawtherapee-bin"`
 	RawtherapeeBlacklist  string  `yaml:"RawtherapeeBlacklist" json:"-" flag:"rawtherapee-blacklist"`
 	SipsBin               string  `yaml:"SipsBin" json:"-" flag:"sips-bin"`
 	HeifConvertBin        string  `yaml:"HeifConvertBin" json:"-" flag:"heifconvert-bin"`
+	DcmtkBin              string  `yaml:"DcmtkBin" json:"-" flag:"dcmtk-bin"`
 	FFmpegBin             string  `yaml:"FFmpegBin" json:"-" flag:"ffmpeg-bin"`
 	FFmpegEncoder         string  `yaml:"FFmpegEncoder" json:"FFmpegEncoder" flag:"ffmpeg-encoder"`
 	FFmpegBitrate         int     `yaml:"FFmpegBitrate" json:"FFmpegBitrate" flag:"ffmpeg-bitrate"`
+	FFmpegMaxDuration     int     `yaml:"FFmpegMaxDuration" json:"FFmpegMaxDuration" flag:"ffmpeg-max-duration"`
 	FFmpegBuffers         int     `yaml:"FFmpegBuffers" json:"FFmpegBuffers" flag:"ffmpeg-buffers"`
+	FFmpegHls             bool    `yaml:"FFmpegHls" json:"FFmpegHls" flag:"ffmpeg-hls"`
+	FFmpegSprite          bool    `yaml:"FFmpegSprite" json:"FFmpegSprite" flag:"ffmpeg-sprite"`
+	VideoCacheLimit       int     `yaml:"VideoCacheLimit" json:"VideoCacheLimit" flag:"video-cache-limit"`
 	ExifToolBin           string  `yaml:"ExifToolBin" json:"-" flag:"exiftool-bin"`
+	MetadataCmd           string  `yaml:"MetadataCmd" json:"-" flag:"metadata-cmd"`
+	MetadataExt           string  `yaml:"MetadataExt" json:"-" flag:"metadata-ext"`
+	ExifWriteback         string  `yaml:"ExifWriteback" json:"-" flag:"exif-writeback"`
+	MetadataPriority      string  `yaml:"MetadataPriority" json:"-" flag:"metadata-priority"`
 	DetachServer          bool    `yaml:"DetachServer" json:"-" flag:"detach-server"`
+	ShareRedact           string  `yaml:"ShareRedact" json:"-" flag:"share-redact"`
 	DownloadToken         string  `yaml:"DownloadToken" json:"-" flag:"download-token"`
 	PreviewToken          string  `yaml:"PreviewToken" json:"-" flag:"preview-token"`
+	TokenLifetime         int     `yaml:"TokenLifetime" json:"TokenLifetime" flag:"token-lifetime"`
+	PushPublicKey         string  `yaml:"PushPublicKey" json:"-" flag:"push-public-key"`
+	PushPrivateKey        string  `yaml:"PushPrivateKey" json:"-" flag:"push-private-key"`
+	PushSubject           string  `yaml:"PushSubject" json:"-" flag:"push-subject"`
+	PrintUrl              string  `yaml:"PrintUrl" json:"-" flag:"print-url"`
+	PrintToken            string  `yaml:"PrintToken" json:"-" flag:"print-token"`
+	ThumbLibrary          string  `yaml:"ThumbLibrary" json:"ThumbLibrary" flag:"thumb-library"`
+	VipsBin               string  `yaml:"VipsBin" json:"-" flag:"vips-bin"`
 	ThumbFilter           string  `yaml:"ThumbFilter" json:"ThumbFilter" flag:"thumb-filter"`
 	ThumbUncached         bool    `yaml:"ThumbUncached" json:"ThumbUncached" flag:"thumb-uncached"`
 	ThumbSize             int     `yaml:"ThumbSize" json:"ThumbSize" flag:"thumb-size"`
@@ -131,6 +191,8 @@ type Options struct {
 	FaceClusterCore       int     `yaml:"-" json:"-" flag:"face-cluster-core"`
 	FaceClusterDist       float64 `yaml:"-" json:"-" flag:"face-cluster-dist"`
 	FaceMatchDist         float64 `yaml:"-" json:"-" flag:"face-match-dist"`
+	FaceKeyframes         int     `yaml:"-" json:"-" flag:"face-keyframes"`
+	CaptionUri            string  `yaml:"CaptionUri" json:"-" flag:"caption-uri"`
 	PIDFilename           string  `yaml:"PIDFilename" json:"-" flag:"pid-filename"`
 	LogFilename           string  `yaml:"LogFilename" json:"-" flag:"log-filename"`
 }
@@ -170,6 +232,7 @@ func (c *Options) expandFilenames() {
 	c.StoragePath = fs.Abs(c.StoragePath)
 	c.BackupPath = fs.Abs(c.BackupPath)
 	c.AssetsPath = fs.Abs(c.AssetsPath)
+	c.TilesPath = fs.Abs(c.TilesPath)
 	c.CachePath = fs.Abs(c.CachePath)
 	c.OriginalsPath = fs.Abs(c.OriginalsPath)
 	c.ImportPath = fs.Abs(c.ImportPath)
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"strings"
+)
+
+// PrintUrl returns the endpoint URL of an external print service that receives print orders.
+func (c *Config) PrintUrl() string {
+	return strings.TrimSpace(c.options.PrintUrl)
+}
+
+// PrintToken returns the access token that is sent to the print service, if any.
+func (c *Config) PrintToken() string {
+	return strings.TrimSpace(c.options.PrintToken)
+}
+
+// PrintEnabled tests if photos can be sent to an external print service.
+func (c *Config) PrintEnabled() bool {
+	return strings.HasPrefix(c.PrintUrl(), "http://") || strings.HasPrefix(c.PrintUrl(), "https://")
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Print(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.PrintUrl())
+	assert.Equal(t, "", c.PrintToken())
+	assert.False(t, c.PrintEnabled())
+
+	c.options.PrintUrl = " https://print.example.com/orders "
+	c.options.PrintToken = "secret"
+
+	assert.Equal(t, "https://print.example.com/orders", c.PrintUrl())
+	assert.Equal(t, "secret", c.PrintToken())
+	assert.True(t, c.PrintEnabled())
+
+	c.options.PrintUrl = "ftp://print.example.com/"
+	assert.False(t, c.PrintEnabled())
+
+	c.options.PrintUrl = ""
+	c.options.PrintToken = ""
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/internal/notify"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// PushKeys represents the VAPID key pair that identifies this server to browser push services.
+type PushKeys struct {
+	PublicKey  string `yaml:"PublicKey"`
+	PrivateKey string `yaml:"PrivateKey"`
+}
+
+// PushKeysFile returns the file name of the generated push notification keys.
+func (c *Config) PushKeysFile() string {
+	return filepath.Join(c.ConfigPath(), "push.yml")
+}
+
+// initPush loads the push notification keys, or generates and saves them if they have not been configured.
+// Keys must not change afterwards, as existing browser subscriptions would become invalid.
+func (c *Config) initPush() {
+	if c.options.PushPublicKey != "" && c.options.PushPrivateKey != "" {
+		return
+	}
+
+	fileName := c.PushKeysFile()
+	keys := PushKeys{}
+
+	if fs.FileExists(fileName) {
+		if data, err := os.ReadFile(fileName); err != nil {
+			log.Errorf("config: %s (read push keys)", err)
+		} else if err := yaml.Unmarshal(data, &keys); err != nil {
+			log.Errorf("config: %s (parse push keys)", err)
+		}
+	}
+
+	if keys.PublicKey == "" || keys.PrivateKey == "" {
+		var err error
+
+		if keys.PublicKey, keys.PrivateKey, err = notify.GenerateVapidKeys(); err != nil {
+			log.Errorf("config: %s (generate push keys)", err)
+			return
+		} else if data, err := yaml.Marshal(keys); err != nil {
+			log.Errorf("config: %s (push keys)", err)
+		} else if err := os.WriteFile(fileName, data, 0600); err != nil {
+			log.Errorf("config: failed creating %s (%s)", filepath.Base(fileName), err)
+		} else {
+			log.Debugf("config: created %s", filepath.Base(fileName))
+		}
+	}
+
+	c.options.PushPublicKey = keys.PublicKey
+	c.options.PushPrivateKey = keys.PrivateKey
+}
+
+// PushPublicKey returns the VAPID public key that web apps need for subscribing to push notifications.
+func (c *Config) PushPublicKey() string {
+	return c.options.PushPublicKey
+}
+
+// PushPrivateKey returns the VAPID private key for signing push messages.
+func (c *Config) PushPrivateKey() string {
+	return c.options.PushPrivateKey
+}
+
+// PushSubject returns the contact URL or mailto address sent to push services.
+func (c *Config) PushSubject() string {
+	if s := strings.TrimSpace(c.options.PushSubject); s != "" {
+		return s
+	}
+
+	return c.SiteUrl()
+}
+
+// PushEnabled tests if push notifications can be sent to web apps.
+func (c *Config) PushEnabled() bool {
+	return c.PushPublicKey() != "" && c.PushPrivateKey() != ""
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_InitPush(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	_ = os.MkdirAll(c.ConfigPath(), os.ModePerm)
+	_ = os.Remove(c.PushKeysFile())
+
+	defer os.Remove(c.PushKeysFile())
+
+	assert.False(t, c.PushEnabled())
+
+	c.initPush()
+
+	assert.True(t, c.PushEnabled())
+	assert.FileExists(t, c.PushKeysFile())
+
+	publicKey := c.PushPublicKey()
+
+	// Keys must not change once they have been generated.
+	c = NewConfig(CliTestContext())
+	c.initPush()
+
+	assert.Equal(t, publicKey, c.PushPublicKey())
+	assert.NotEmpty(t, c.PushPrivateKey())
+}
+
+func TestConfig_PushSubject(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, c.SiteUrl(), c.PushSubject())
+
+	c.options.PushSubject = "mailto:admin@example.com"
+
+	assert.Equal(t, "mailto:admin@example.com", c.PushSubject())
+}
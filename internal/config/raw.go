@@ -5,6 +5,11 @@ func (c *Config) RawPresets() bool {
 	return c.options.RawPresets
 }
 
+// RawPreviews tests if embedded JPEG previews of RAW files should be used instead of converting them.
+func (c *Config) RawPreviews() bool {
+	return c.options.RawPreviews
+}
+
 // DarktableBin returns the darktable-cli executable file name.
 func (c *Config) DarktableBin() string {
 	return findExecutable(c.options.DarktableBin, "darktable-cli")
@@ -54,3 +59,13 @@ func (c *Config) HeifConvertBin() string {
 func (c *Config) HeifConvertEnabled() bool {
 	return !c.DisableHeifConvert()
 }
+
+// DcmtkBin returns the dcmj2pnm executable file name.
+func (c *Config) DcmtkBin() string {
+	return findExecutable(c.options.DcmtkBin, "dcmj2pnm")
+}
+
+// DcmtkEnabled tests if DCMTK is enabled for DICOM conversion.
+func (c *Config) DcmtkEnabled() bool {
+	return !c.DisableDcmtk()
+}
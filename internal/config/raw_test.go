@@ -47,6 +47,16 @@ func TestConfig_DarktablePresets(t *testing.T) {
 	assert.False(t, c.RawPresets())
 }
 
+func TestConfig_RawPreviews(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.RawPreviews())
+
+	c.options.RawPreviews = true
+
+	assert.True(t, c.RawPreviews())
+}
+
 func TestConfig_DarktableEnabled(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.True(t, c.DarktableEnabled())
@@ -82,3 +92,11 @@ func TestConfig_HeifConvertEnabled(t *testing.T) {
 	c.options.DisableHeifConvert = true
 	assert.False(t, c.HeifConvertEnabled())
 }
+
+func TestConfig_DcmtkEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, c.DcmtkBin() != "", c.DcmtkEnabled())
+
+	c.options.DisableDcmtk = true
+	assert.False(t, c.DcmtkEnabled())
+}
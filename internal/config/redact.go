@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Redacted is the placeholder for values that have been removed from reports.
+const Redacted = "[redacted]"
+
+// redactKeys contains substrings of option names whose values must never be included in reports.
+var redactKeys = []string{"Password", "Token", "Key", "Secret", "Dsn", "Site", "Imprint", "CdnUrl", "DatabaseServer", "DatabaseName", "DatabaseUser", "HttpHost", "TraceEndpoint", "PrintUrl"}
+
+// Regular expressions that match personal data in log messages.
+var (
+	redactEmail = regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.-]+`)
+	redactIPv4  = regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`)
+	redactUrl   = regexp.MustCompile(`(https?|wss?)://[^\s/"']+`)
+)
+
+// redactKey tests if the value of the option with the given name must be removed.
+func redactKey(name string) bool {
+	for _, key := range redactKeys {
+		if strings.Contains(name, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RedactedOptions returns the configuration options as a map without secrets and personal data.
+func (c *Config) RedactedOptions() map[string]interface{} {
+	result := make(map[string]interface{})
+
+	v := reflect.ValueOf(c.options).Elem()
+
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Tag.Get("yaml")
+
+		if name == "" || name == "-" {
+			continue
+		}
+
+		value := v.Field(i).Interface()
+
+		if s, ok := value.(string); ok {
+			if s != "" && redactKey(name) {
+				value = Redacted
+			} else {
+				value = c.Redact(s)
+			}
+		}
+
+		result[name] = value
+	}
+
+	return result
+}
+
+// redactPaths returns the configured paths and their placeholders, longest path first.
+func (c *Config) redactPaths() (paths [][2]string) {
+	paths = [][2]string{
+		{c.OriginalsPath(), "[originals]"},
+		{c.ImportPath(), "[import]"},
+		{c.StoragePath(), "[storage]"},
+		{c.CachePath(), "[cache]"},
+		{c.SidecarPath(), "[sidecar]"},
+		{c.TempPath(), "[temp]"},
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, [2]string{home, "~"})
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		return len(paths[i][0]) > len(paths[j][0])
+	})
+
+	return paths
+}
+
+// Redact replaces secrets, configured paths, URLs, email and IP addresses in a string
+// so that it can be attached to a bug report.
+func (c *Config) Redact(s string) string {
+	if s == "" {
+		return s
+	}
+
+	// Tokens can be rotated at runtime.
+	tokenMutex.Lock()
+	downloadToken, previewToken := c.options.DownloadToken, c.options.PreviewToken
+	tokenMutex.Unlock()
+
+	secrets := []string{
+		c.options.AdminPassword,
+		c.options.DatabasePassword,
+		c.options.DatabaseDsn,
+		downloadToken,
+		previewToken,
+		c.options.PushPrivateKey,
+		c.SqliteKey(),
+		c.options.DLNAToken,
+		c.options.SiteUrl,
+	}
+
+	for _, secret := range secrets {
+		if len(secret) > 2 {
+			s = strings.ReplaceAll(s, secret, Redacted)
+		}
+	}
+
+	for _, p := range c.redactPaths() {
+		if len(p[0]) > 1 {
+			s = strings.ReplaceAll(s, p[0], p[1])
+		}
+	}
+
+	s = redactUrl.ReplaceAllString(s, "$1://"+Redacted)
+	s = redactEmail.ReplaceAllString(s, Redacted)
+	s = redactIPv4.ReplaceAllString(s, Redacted)
+
+	return s
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_RedactedOptions(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.AdminPassword = "secret123"
+	c.options.DatabaseDsn = "photoprism:insecure@tcp(mariadb:3306)/photoprism"
+	c.options.SiteUrl = "https://photos.example.com/"
+
+	result := c.RedactedOptions()
+
+	assert.Equal(t, Redacted, result["AdminPassword"])
+	assert.Equal(t, Redacted, result["DatabaseDsn"])
+	assert.Equal(t, Redacted, result["SiteUrl"])
+	assert.Equal(t, "", result["DatabasePassword"])
+	assert.Equal(t, c.options.LogLevel, result["LogLevel"])
+	assert.Equal(t, c.options.HttpPort, result["HttpPort"])
+	assert.NotContains(t, result, "PartnerID")
+}
+
+func TestConfig_Redact(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.AdminPassword = "secret123"
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", c.Redact(""))
+	})
+	t.Run("Password", func(t *testing.T) {
+		assert.Equal(t, "password is [redacted]", c.Redact("password is secret123"))
+	})
+	t.Run("Originals", func(t *testing.T) {
+		assert.Equal(t, "index: [originals]/2020/IMG_1234.jpg not found", c.Redact("index: "+c.OriginalsPath()+"/2020/IMG_1234.jpg not found"))
+	})
+	t.Run("Email", func(t *testing.T) {
+		assert.Equal(t, "mail from [redacted] failed", c.Redact("mail from john.doe@example.com failed"))
+	})
+	t.Run("IP", func(t *testing.T) {
+		assert.Equal(t, "connection from [redacted] refused", c.Redact("connection from 192.168.1.10 refused"))
+	})
+	t.Run("Url", func(t *testing.T) {
+		assert.Equal(t, "GET https://[redacted]/api/v1/status", c.Redact("GET https://photos.example.com/api/v1/status"))
+	})
+	t.Run("SqliteKeyFile", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "sqlite.key")
+
+		if err := os.WriteFile(fileName, []byte("key-from-file\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		c.options.SqliteKeyFile = fileName
+		defer func() { c.options.SqliteKeyFile = "" }()
+
+		assert.Equal(t, "key is [redacted]", c.Redact("key is key-from-file"))
+	})
+}
@@ -30,6 +30,21 @@ func (c *Config) JpegQuality() int {
 	return c.options.JpegQuality
 }
 
+// ThumbLibrary returns the image processing library for creating thumbnails, libvips is only used
+// if the vipsthumbnail command was found.
+func (c *Config) ThumbLibrary() string {
+	if strings.ToLower(c.options.ThumbLibrary) == thumb.LibVips && c.VipsBin() != "" {
+		return thumb.LibVips
+	}
+
+	return thumb.LibImaging
+}
+
+// VipsBin returns the vipsthumbnail executable file name.
+func (c *Config) VipsBin() string {
+	return findExecutable(c.options.VipsBin, "vipsthumbnail")
+}
+
 // ThumbFilter returns the thumbnail resample filter (best to worst: blackman, lanczos, cubic or linear).
 func (c *Config) ThumbFilter() thumb.ResampleFilter {
 	switch strings.ToLower(c.options.ThumbFilter) {
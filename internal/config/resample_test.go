@@ -27,6 +27,28 @@ func TestConfig_JpegQuality(t *testing.T) {
 	assert.Equal(t, int(98), c.JpegQuality())
 }
 
+func TestConfig_ThumbLibrary(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, thumb.LibImaging, c.ThumbLibrary())
+	c.options.ThumbLibrary = "vips"
+	c.options.VipsBin = "/bin/true"
+	assert.Equal(t, thumb.LibVips, c.ThumbLibrary())
+	c.options.VipsBin = "/invalid/vipsthumbnail"
+	assert.Equal(t, thumb.LibImaging, c.ThumbLibrary())
+	c.options.ThumbLibrary = "imaging"
+	assert.Equal(t, thumb.LibImaging, c.ThumbLibrary())
+}
+
+func TestConfig_VipsBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.VipsBin = "/bin/true"
+	assert.Equal(t, "/bin/true", c.VipsBin())
+	c.options.VipsBin = "/invalid/vipsthumbnail"
+	assert.Equal(t, "", c.VipsBin())
+}
+
 func TestConfig_ThumbFilter(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
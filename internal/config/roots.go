@@ -0,0 +1,211 @@
+package config
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// rootNameRegexp matches valid names of additional originals roots, which must fit into the
+// file_root database column together with the sidecar root prefix.
+var rootNameRegexp = regexp.MustCompile(`^[a-z0-9]{1,8}$`)
+
+// OriginalsRoot represents an additional originals folder, e.g. on another mount.
+type OriginalsRoot struct {
+	Name        string
+	Path        string
+	SidecarPath string
+}
+
+// SidecarRoot returns the file root name of the sidecar folder.
+func (r OriginalsRoot) SidecarRoot() string {
+	return SidecarRoot(r.Name)
+}
+
+// String returns the root as NAME:PATH:SIDECAR string.
+func (r OriginalsRoot) String() string {
+	return r.Name + ":" + r.Path + ":" + r.SidecarPath
+}
+
+// OriginalsRoots represents a list of additional originals folders.
+type OriginalsRoots []OriginalsRoot
+
+// String returns the roots as comma-separated string.
+func (r OriginalsRoots) String() string {
+	names := make([]string, len(r))
+
+	for i, root := range r {
+		names[i] = root.String()
+	}
+
+	return strings.Join(names, ",")
+}
+
+// Find returns the root with the given file root name, which may also be the name of its sidecar folder.
+func (r OriginalsRoots) Find(name string) (root OriginalsRoot, sidecar bool, ok bool) {
+	for _, root = range r {
+		if root.Name == name {
+			return root, false, true
+		} else if root.SidecarRoot() == name {
+			return root, true, true
+		}
+	}
+
+	return OriginalsRoot{}, false, false
+}
+
+// contains tests if the file is located in the given folder.
+func (r OriginalsRoot) contains(dir, fileName string) bool {
+	return dir != "" && (fileName == dir || strings.HasPrefix(fileName, dir+string(filepath.Separator)))
+}
+
+// Match returns the root that contains the file, and if it is located in the sidecar folder of the root.
+func (r OriginalsRoots) Match(fileName string) (root OriginalsRoot, sidecar bool, ok bool) {
+	for _, root = range r {
+		if root.contains(root.SidecarPath, fileName) {
+			return root, true, true
+		} else if root.contains(root.Path, fileName) {
+			return root, false, true
+		}
+	}
+
+	return OriginalsRoot{}, false, false
+}
+
+// SidecarRoot returns the file root name of the sidecar folder that belongs to an additional originals root.
+func SidecarRoot(name string) string {
+	return entity.RootSidecar + "-" + name
+}
+
+// originalsRoots caches the parsed originals-roots config option, as it is needed for every file name.
+type originalsRoots struct {
+	mutex  sync.Mutex
+	option string
+	strict bool
+	roots  OriginalsRoots
+	parsed bool
+}
+
+// initOriginalsRoots parses the additional originals folders once, so that invalid entries are reported on startup.
+func (c *Config) initOriginalsRoots() {
+	if roots := c.OriginalsRoots(); len(roots) > 0 {
+		log.Debugf("config: using originals roots %s", roots)
+	}
+}
+
+// OriginalsRoots returns the additional originals folders, see the originals-roots config option.
+// The option is parsed again only if it has changed since the last call.
+func (c *Config) OriginalsRoots() OriginalsRoots {
+	c.roots.mutex.Lock()
+	defer c.roots.mutex.Unlock()
+
+	if c.roots.parsed && c.roots.option == c.options.OriginalsRoots && c.roots.strict == c.StrictReadOnly() {
+		return c.roots.roots
+	}
+
+	c.roots.option = c.options.OriginalsRoots
+	c.roots.strict = c.StrictReadOnly()
+	c.roots.roots = c.parseOriginalsRoots()
+	c.roots.parsed = true
+
+	return c.roots.roots
+}
+
+// parseOriginalsRoots parses the originals-roots config option. Invalid entries are skipped,
+// as well as folders within the main originals, import, or sidecar folders.
+func (c *Config) parseOriginalsRoots() (result OriginalsRoots) {
+	if c.options.OriginalsRoots == "" {
+		return result
+	}
+
+	reserved := []string{c.OriginalsPath(), c.ImportPath(), c.SidecarPath()}
+	names := make(map[string]bool)
+
+	for _, s := range strings.Split(c.options.OriginalsRoots, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+
+		values := strings.SplitN(s, ":", 3)
+
+		if len(values) < 2 || strings.TrimSpace(values[1]) == "" {
+			log.Warnf("config: originals root %s must be specified as NAME:PATH[:SIDECAR]", sanitize.Log(s))
+			continue
+		}
+
+		root := OriginalsRoot{
+			Name: strings.ToLower(strings.TrimSpace(values[0])),
+			Path: fs.Abs(strings.TrimSpace(values[1])),
+		}
+
+		if !rootNameRegexp.MatchString(root.Name) || root.Name == entity.RootImport {
+			log.Warnf("config: invalid originals root name %s, use up to 8 lowercase letters and numbers", sanitize.Log(root.Name))
+			continue
+		} else if names[root.Name] {
+			log.Warnf("config: originals root name %s is used more than once", sanitize.Log(root.Name))
+			continue
+		}
+
+		if len(values) == 3 && strings.TrimSpace(values[2]) != "" {
+			root.SidecarPath = fs.Abs(strings.TrimSpace(values[2]))
+		} else {
+			root.SidecarPath = filepath.Join(c.SidecarPath(), root.Name)
+		}
+
+		// Sidecar files must not be stored in originals in strict read-only mode.
+		if c.StrictReadOnly() && (root.contains(root.Path, root.SidecarPath) || c.InsideOriginals(root.SidecarPath)) {
+			root.SidecarPath = filepath.Join(c.SidecarPath(), root.Name)
+		}
+
+		nested := false
+
+		for _, dir := range reserved {
+			if root.contains(dir, root.Path) || root.contains(root.Path, dir) {
+				nested = true
+			}
+		}
+
+		if nested {
+			log.Warnf("config: originals root %s must not overlap with other storage folders", sanitize.Log(root.Name))
+			continue
+		}
+
+		names[root.Name] = true
+		reserved = append(reserved, root.Path)
+		result = append(result, root)
+	}
+
+	return result
+}
+
+// IgnoreSymlinks returns the names of the originals roots in which symbolic links are not followed,
+// the main originals folder is specified as "originals".
+func (c *Config) IgnoreSymlinks() (names []string) {
+	if c.options.IgnoreSymlinks == "" {
+		return names
+	}
+
+	for _, s := range strings.Split(c.options.IgnoreSymlinks, ",") {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			names = append(names, s)
+		}
+	}
+
+	return names
+}
+
+// FollowSymlinks tests if symbolic links should be followed when indexing the originals root with the given name.
+func (c *Config) FollowSymlinks(root string) bool {
+	for _, name := range c.IgnoreSymlinks() {
+		if name == root || name == "originals" && root == entity.RootOriginals {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_OriginalsRoots(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.OriginalsRoots())
+
+	t.Run("Valid", func(t *testing.T) {
+		c.options.OriginalsRoots = "nas:/mnt/nas/photos, usb:/media/usb:/media/usb-sidecar"
+
+		roots := c.OriginalsRoots()
+
+		assert.Len(t, roots, 2)
+		assert.Equal(t, OriginalsRoot{Name: "nas", Path: "/mnt/nas/photos", SidecarPath: filepath.Join(c.SidecarPath(), "nas")}, roots[0])
+		assert.Equal(t, OriginalsRoot{Name: "usb", Path: "/media/usb", SidecarPath: "/media/usb-sidecar"}, roots[1])
+		assert.Equal(t, "sidecar-usb", roots[1].SidecarRoot())
+		assert.Equal(t, "nas:/mnt/nas/photos:"+filepath.Join(c.SidecarPath(), "nas")+",usb:/media/usb:/media/usb-sidecar", roots.String())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		c.options.OriginalsRoots = "nas,:/mnt/a,toolongname:/mnt/b,import:/mnt/c,a-b:/mnt/d,x:/mnt/e,x:/mnt/f,y:" + c.OriginalsPath() + "/sub"
+
+		roots := c.OriginalsRoots()
+
+		assert.Len(t, roots, 1)
+		assert.Equal(t, "x", roots[0].Name)
+		assert.Equal(t, "/mnt/e", roots[0].Path)
+	})
+	t.Run("Find", func(t *testing.T) {
+		c.options.OriginalsRoots = "nas:/mnt/nas/photos"
+
+		roots := c.OriginalsRoots()
+
+		root, sidecar, ok := roots.Find("nas")
+		assert.True(t, ok)
+		assert.False(t, sidecar)
+		assert.Equal(t, "/mnt/nas/photos", root.Path)
+
+		root, sidecar, ok = roots.Find("sidecar-nas")
+		assert.True(t, ok)
+		assert.True(t, sidecar)
+		assert.Equal(t, "nas", root.Name)
+
+		_, _, ok = roots.Find("/")
+		assert.False(t, ok)
+	})
+	t.Run("Match", func(t *testing.T) {
+		c.options.OriginalsRoots = "nas:/mnt/nas/photos"
+
+		roots := c.OriginalsRoots()
+
+		root, sidecar, ok := roots.Match("/mnt/nas/photos/2021/test.jpg")
+		assert.True(t, ok)
+		assert.False(t, sidecar)
+		assert.Equal(t, "nas", root.Name)
+
+		_, sidecar, ok = roots.Match(filepath.Join(c.SidecarPath(), "nas", "2021", "test.jpg.json"))
+		assert.True(t, ok)
+		assert.True(t, sidecar)
+
+		_, _, ok = roots.Match("/mnt/nas/photos2/test.jpg")
+		assert.False(t, ok)
+
+		_, _, ok = roots.Match(filepath.Join(c.SidecarPath(), "2021", "test.jpg.json"))
+		assert.False(t, ok)
+	})
+
+	t.Run("Cached", func(t *testing.T) {
+		c.options.OriginalsRoots = "nas:/mnt/nas/photos"
+
+		roots := c.OriginalsRoots()
+
+		if assert.Len(t, roots, 1) {
+			assert.Same(t, &roots[0], &c.OriginalsRoots()[0])
+		}
+
+		c.options.OriginalsRoots = "usb:/media/usb"
+
+		if roots = c.OriginalsRoots(); assert.Len(t, roots, 1) {
+			assert.Equal(t, "usb", roots[0].Name)
+		}
+	})
+
+	c.options.OriginalsRoots = ""
+}
+
+func TestConfig_IgnoreSymlinks(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.IgnoreSymlinks())
+	assert.True(t, c.FollowSymlinks("/"))
+	assert.True(t, c.FollowSymlinks("nas"))
+
+	c.options.IgnoreSymlinks = " Originals, nas,,"
+
+	assert.Equal(t, []string{"originals", "nas"}, c.IgnoreSymlinks())
+	assert.False(t, c.FollowSymlinks("/"))
+	assert.False(t, c.FollowSymlinks("nas"))
+	assert.True(t, c.FollowSymlinks("usb"))
+
+	c.options.IgnoreSymlinks = ""
+}
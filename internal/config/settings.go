@@ -3,10 +3,14 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/entity"
 
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/notify"
+	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 	"gopkg.in/yaml.v2"
@@ -18,6 +22,7 @@ type UISettings struct {
 	Zoom      bool   `json:"zoom" yaml:"Zoom"`
 	Theme     string `json:"theme" yaml:"Theme"`
 	Language  string `json:"language" yaml:"Language"`
+	Translate bool   `json:"translate" yaml:"Translate"`
 }
 
 // TemplateSettings represents template settings for the UI and messaging.
@@ -85,6 +90,133 @@ type DownloadSettings struct {
 	Name entity.DownloadName `json:"name" yaml:"Name"`
 }
 
+// ThumbSettings represents thumbnail settings.
+type ThumbSettings struct {
+	Quality thumb.Quality `json:"quality" yaml:"Quality"`
+}
+
+// EmailNotifySettings represents SMTP notification settings.
+type EmailNotifySettings struct {
+	Enabled  bool   `json:"enabled" yaml:"Enabled"`
+	Host     string `json:"host" yaml:"Host"`
+	Port     int    `json:"port" yaml:"Port"`
+	User     string `json:"user" yaml:"User"`
+	Password string `json:"password" yaml:"Password"`
+	From     string `json:"from" yaml:"From"`
+	To       string `json:"to" yaml:"To"`
+}
+
+// TelegramNotifySettings represents Telegram bot notification settings.
+type TelegramNotifySettings struct {
+	Enabled bool   `json:"enabled" yaml:"Enabled"`
+	Token   string `json:"token" yaml:"Token"`
+	ChatID  string `json:"chatId" yaml:"ChatID"`
+}
+
+// MatrixNotifySettings represents Matrix notification settings.
+type MatrixNotifySettings struct {
+	Enabled bool   `json:"enabled" yaml:"Enabled"`
+	Server  string `json:"server" yaml:"Server"`
+	Token   string `json:"token" yaml:"Token"`
+	RoomID  string `json:"roomId" yaml:"RoomID"`
+}
+
+// DigestNotifySettings represents monthly summary email settings.
+type DigestNotifySettings struct {
+	Enabled bool   `json:"enabled" yaml:"Enabled"`
+	To      string `json:"to" yaml:"To"`
+}
+
+// NotifySettings represents notification events and channels.
+type NotifySettings struct {
+	Import   bool                   `json:"import" yaml:"Import"`
+	Share    bool                   `json:"share" yaml:"Share"`
+	Storage  bool                   `json:"storage" yaml:"Storage"`
+	Search   bool                   `json:"search" yaml:"Search"`
+	Digest   DigestNotifySettings   `json:"digest" yaml:"Digest"`
+	Email    EmailNotifySettings    `json:"email" yaml:"Email"`
+	Telegram TelegramNotifySettings `json:"telegram" yaml:"Telegram"`
+	Matrix   MatrixNotifySettings   `json:"matrix" yaml:"Matrix"`
+}
+
+// Channels returns the enabled notification channels.
+func (s NotifySettings) Channels() (result notify.Channels) {
+	if s.Email.Enabled {
+		result = append(result, s.email(s.Email.To))
+	}
+
+	if s.Telegram.Enabled {
+		result = append(result, notify.Telegram{Token: s.Telegram.Token, ChatID: s.Telegram.ChatID})
+	}
+
+	if s.Matrix.Enabled {
+		result = append(result, notify.Matrix{Server: s.Matrix.Server, Token: s.Matrix.Token, RoomID: s.Matrix.RoomID})
+	}
+
+	return result
+}
+
+// DigestChannel returns the email channel for monthly summaries, or nil if they are disabled.
+// The SMTP settings of the email channel are used, and its recipients if none are configured.
+func (s NotifySettings) DigestChannel() notify.Channel {
+	if !s.Digest.Enabled || s.Email.Host == "" {
+		return nil
+	}
+
+	to := s.Digest.To
+
+	if strings.TrimSpace(to) == "" {
+		to = s.Email.To
+	}
+
+	if ch := s.email(to); len(ch.To) > 0 {
+		return ch
+	}
+
+	return nil
+}
+
+// email returns an email channel for the comma-separated recipient addresses.
+func (s NotifySettings) email(recipients string) notify.Email {
+	var to []string
+
+	for _, addr := range strings.Split(recipients, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			to = append(to, addr)
+		}
+	}
+
+	return notify.Email{
+		Host:     s.Email.Host,
+		Port:     s.Email.Port,
+		User:     s.Email.User,
+		Password: s.Email.Password,
+		From:     s.Email.From,
+		To:       to,
+	}
+}
+
+// SecretPlaceholder replaces notification passwords and tokens in settings sent to clients.
+const SecretPlaceholder = "********"
+
+// maskSecret returns the placeholder if the secret is not empty.
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	return SecretPlaceholder
+}
+
+// keepSecret returns the saved secret if the placeholder was sent back by a client.
+func keepSecret(secret, saved string) string {
+	if secret == SecretPlaceholder {
+		return saved
+	}
+
+	return secret
+}
+
 // Settings represents user settings for Web UI, indexing, and import.
 type Settings struct {
 	UI        UISettings       `json:"ui" yaml:"UI"`
@@ -96,6 +228,8 @@ type Settings struct {
 	Stack     StackSettings    `json:"stack" yaml:"Stack"`
 	Share     ShareSettings    `json:"share" yaml:"Share"`
 	Download  DownloadSettings `json:"download" yaml:"Download"`
+	Thumbs    ThumbSettings    `json:"thumbs" yaml:"Thumbs"`
+	Notify    NotifySettings   `json:"notify" yaml:"Notify"`
 }
 
 // NewSettings creates a new Settings instance.
@@ -155,12 +289,50 @@ func NewSettings(c *Config) *Settings {
 		Download: DownloadSettings{
 			Name: entity.DownloadNameDefault,
 		},
+		Thumbs: ThumbSettings{
+			Quality: thumb.Quality{},
+		},
+		Notify: NotifySettings{
+			Import:  true,
+			Share:   true,
+			Storage: true,
+			Search:  true,
+			Email: EmailNotifySettings{
+				Port: 587,
+			},
+		},
 	}
 }
 
+// Masked returns a copy of the settings with notification secrets replaced by a placeholder,
+// so that they are never sent to clients.
+func (s Settings) Masked() Settings {
+	s.Notify.Email.Password = maskSecret(s.Notify.Email.Password)
+	s.Notify.Telegram.Token = maskSecret(s.Notify.Telegram.Token)
+	s.Notify.Matrix.Token = maskSecret(s.Notify.Matrix.Token)
+
+	return s
+}
+
+// KeepSecrets keeps the saved notification secrets if a client sent back the placeholder instead.
+func (s *Settings) KeepSecrets(saved NotifySettings) {
+	s.Notify.Email.Password = keepSecret(s.Notify.Email.Password, saved.Email.Password)
+	s.Notify.Telegram.Token = keepSecret(s.Notify.Telegram.Token, saved.Telegram.Token)
+	s.Notify.Matrix.Token = keepSecret(s.Notify.Matrix.Token, saved.Matrix.Token)
+}
+
 // Propagate updates settings in other packages as needed.
 func (s *Settings) Propagate() {
 	i18n.SetLocale(s.UI.Language)
+
+	// Display and search auto-generated labels in the user interface language.
+	if s.UI.Translate {
+		classify.SetLocale(s.UI.Language)
+	} else {
+		classify.SetLocale("")
+	}
+
+	thumb.SizeQuality = s.Thumbs.Quality.Valid()
 }
 
 // StackSequences tests if files should be stacked based on their file name prefix (sequential names).
@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/notify"
+	"github.com/photoprism/photoprism/internal/thumb"
 )
 
 func TestNewSettings(t *testing.T) {
@@ -70,6 +73,18 @@ func TestSettings_Save(t *testing.T) {
 	})
 }
 
+func TestSettings_Propagate(t *testing.T) {
+	c := NewSettings(TestConfig())
+	c.Thumbs.Quality = thumb.Quality{"tile": 70, "fit_2048": 85, "fit_9999": 80}
+
+	defer func() { thumb.SizeQuality = thumb.Quality{} }()
+
+	c.Propagate()
+
+	assert.Equal(t, thumb.Quality{"tile": 70, "fit_2048": 85}, thumb.SizeQuality)
+	assert.Equal(t, 70, thumb.Sizes[thumb.Tile500].Quality())
+}
+
 func TestSettings_Stacks(t *testing.T) {
 	c := NewSettings(TestConfig())
 	assert.False(t, c.StackSequences())
@@ -83,3 +98,106 @@ func TestConfig_Settings(t *testing.T) {
 	r := c.Settings()
 	assert.False(t, r.Features.Places)
 }
+
+func TestNotifySettings_Channels(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		s := NewSettings(TestConfig())
+
+		assert.True(t, s.Notify.Import)
+		assert.Empty(t, s.Notify.Channels())
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		s := NotifySettings{
+			Email:    EmailNotifySettings{Enabled: true, Host: "smtp.example.com", To: "jane@example.com, john@example.com,"},
+			Telegram: TelegramNotifySettings{Enabled: true, Token: "123:abc", ChatID: "42"},
+			Matrix:   MatrixNotifySettings{Enabled: false},
+		}
+
+		channels := s.Channels()
+
+		assert.Len(t, channels, 2)
+		assert.Equal(t, "email", channels[0].Name())
+		assert.Equal(t, []string{"jane@example.com", "john@example.com"}, channels[0].(notify.Email).To)
+		assert.Equal(t, "telegram", channels[1].Name())
+	})
+}
+
+func TestNotifySettings_DigestChannel(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		s := NewSettings(TestConfig())
+
+		assert.False(t, s.Notify.Digest.Enabled)
+		assert.Nil(t, s.Notify.DigestChannel())
+	})
+	t.Run("EmailRecipients", func(t *testing.T) {
+		s := NotifySettings{
+			Digest: DigestNotifySettings{Enabled: true},
+			Email:  EmailNotifySettings{Host: "smtp.example.com", To: "jane@example.com"},
+		}
+
+		if ch := s.DigestChannel(); ch == nil {
+			t.Fatal("channel must not be nil")
+		} else {
+			assert.Equal(t, []string{"jane@example.com"}, ch.(notify.Email).To)
+		}
+	})
+	t.Run("DigestRecipients", func(t *testing.T) {
+		s := NotifySettings{
+			Digest: DigestNotifySettings{Enabled: true, To: "john@example.com, "},
+			Email:  EmailNotifySettings{Host: "smtp.example.com", To: "jane@example.com"},
+		}
+
+		if ch := s.DigestChannel(); ch == nil {
+			t.Fatal("channel must not be nil")
+		} else {
+			assert.Equal(t, []string{"john@example.com"}, ch.(notify.Email).To)
+		}
+	})
+	t.Run("NoRecipients", func(t *testing.T) {
+		s := NotifySettings{
+			Digest: DigestNotifySettings{Enabled: true},
+			Email:  EmailNotifySettings{Host: "smtp.example.com"},
+		}
+
+		assert.Nil(t, s.DigestChannel())
+	})
+	t.Run("NoHost", func(t *testing.T) {
+		s := NotifySettings{
+			Digest: DigestNotifySettings{Enabled: true, To: "john@example.com"},
+		}
+
+		assert.Nil(t, s.DigestChannel())
+	})
+}
+
+func TestSettings_Masked(t *testing.T) {
+	s := NewSettings(NewConfig(CliTestContext()))
+	s.Notify.Email.Password = "secret"
+	s.Notify.Telegram.Token = "123:abc"
+
+	m := s.Masked()
+
+	assert.Equal(t, SecretPlaceholder, m.Notify.Email.Password)
+	assert.Equal(t, SecretPlaceholder, m.Notify.Telegram.Token)
+	assert.Equal(t, "", m.Notify.Matrix.Token)
+	assert.Equal(t, "secret", s.Notify.Email.Password)
+}
+
+func TestSettings_KeepSecrets(t *testing.T) {
+	s := NewSettings(NewConfig(CliTestContext()))
+	saved := NotifySettings{
+		Email:    EmailNotifySettings{Password: "secret"},
+		Telegram: TelegramNotifySettings{Token: "123:abc"},
+		Matrix:   MatrixNotifySettings{Token: "syt_abc"},
+	}
+
+	s.Notify.Email.Password = SecretPlaceholder
+	s.Notify.Telegram.Token = "456:def"
+	s.Notify.Matrix.Token = ""
+
+	s.KeepSecrets(saved)
+
+	assert.Equal(t, "secret", s.Notify.Email.Password)
+	assert.Equal(t, "456:def", s.Notify.Telegram.Token)
+	assert.Equal(t, "", s.Notify.Matrix.Token)
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// StorageVolume represents the disk usage of a storage folder.
+type StorageVolume struct {
+	Name    string `json:"name"`
+	Path    string `json:"-"`
+	Free    uint64 `json:"free"`
+	Used    uint64 `json:"used"`
+	Total   uint64 `json:"total"`
+	Percent int    `json:"percent"`
+}
+
+// StorageVolumes represents the disk usage of all storage folders.
+type StorageVolumes []StorageVolume
+
+// NewStorageVolume returns the current disk usage of the file system that contains path.
+func NewStorageVolume(name, path string) (result StorageVolume, err error) {
+	result = StorageVolume{Name: name, Path: path}
+
+	used, total, err := fs.DiskUsage(path)
+
+	if err != nil {
+		return result, err
+	} else if total == 0 {
+		return result, fmt.Errorf("unknown size of %s", name)
+	}
+
+	result.Used = used
+	result.Total = total
+	result.Free = total - used
+	result.Percent = int(used * 100 / total)
+
+	return result, nil
+}
+
+// StorageReserve returns the free disk space in bytes to keep available, or -1 if disabled.
+func (c *Config) StorageReserve() int64 {
+	if c.options.StorageReserve < 0 {
+		return -1
+	}
+
+	// Megabyte.
+	return c.options.StorageReserve * 1024 * 1024
+}
+
+// StorageVolumes returns the disk usage of the originals, sidecar, and cache folders.
+func (c *Config) StorageVolumes() (result StorageVolumes) {
+	paths := []struct{ name, path string }{
+		{"originals", c.OriginalsPath()},
+		{"sidecar", c.SidecarPath()},
+		{"cache", c.CachePath()},
+	}
+
+	for _, p := range paths {
+		if v, err := NewStorageVolume(p.name, p.path); err != nil {
+			log.Debugf("config: %s (%s disk usage)", err, p.name)
+		} else {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// CheckStorage returns ErrStorageFull if writing the number of bytes to path would use up the storage reserve.
+func (c *Config) CheckStorage(path string, size int64) error {
+	reserve := c.StorageReserve()
+
+	if reserve < 0 {
+		return nil
+	}
+
+	v, err := NewStorageVolume("", path)
+
+	// Don't refuse writes if the disk usage can't be determined.
+	if err != nil {
+		return nil
+	}
+
+	if size < 0 {
+		size = 0
+	}
+
+	if uint64(size+reserve) > v.Free {
+		log.Warnf("config: %s needed, but only %s free with %s reserved", humanize.Bytes(uint64(size)), humanize.Bytes(v.Free), humanize.Bytes(uint64(reserve)))
+		return ErrStorageFull
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStorageVolume(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		v, err := NewStorageVolume("testdata", "testdata")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "testdata", v.Name)
+		assert.Greater(t, v.Total, uint64(0))
+		assert.Equal(t, v.Total, v.Free+v.Used)
+		assert.LessOrEqual(t, v.Percent, 100)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewStorageVolume("missing", "testdata/foo/bar")
+		assert.Error(t, err)
+	})
+}
+
+func TestConfig_StorageReserve(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, int64(0), c.StorageReserve())
+	c.options.StorageReserve = 1024
+	assert.Equal(t, int64(1073741824), c.StorageReserve())
+	c.options.StorageReserve = -1
+	assert.Equal(t, int64(-1), c.StorageReserve())
+}
+
+func TestConfig_StorageVolumes(t *testing.T) {
+	c := TestConfig()
+
+	volumes := c.StorageVolumes()
+
+	assert.Len(t, volumes, 3)
+	assert.Equal(t, "originals", volumes[0].Name)
+	assert.Equal(t, "sidecar", volumes[1].Name)
+	assert.Equal(t, "cache", volumes[2].Name)
+}
+
+func TestConfig_CheckStorage(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	t.Run("Available", func(t *testing.T) {
+		assert.NoError(t, c.CheckStorage("testdata", 1024))
+	})
+	t.Run("Full", func(t *testing.T) {
+		assert.Equal(t, ErrStorageFull, c.CheckStorage("testdata", 1<<62))
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		c.options.StorageReserve = -1
+		defer func() { c.options.StorageReserve = 0 }()
+
+		assert.NoError(t, c.CheckStorage("testdata", 1<<62))
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		assert.NoError(t, c.CheckStorage("testdata/foo/bar", 1<<62))
+	})
+}
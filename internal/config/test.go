@@ -143,6 +143,11 @@ func NewTestConfig() *Config {
 	thumb.SizePrecached = c.ThumbSizePrecached()
 	thumb.SizeUncached = c.ThumbSizeUncached()
 	thumb.Filter = c.ThumbFilter()
+	thumb.Library = c.ThumbLibrary()
+	thumb.VipsBin = c.VipsBin()
+
+	// Set junk file name patterns.
+	fs.JunkPatterns = c.JunkFiles()
 	thumb.JpegQuality = c.JpegQuality()
 
 	return c
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// TilesExt is the file extension of MBTiles map tile databases.
+const TilesExt = ".mbtiles"
+
+// TilesPath returns the path to MBTiles files for serving map tiles offline, if any.
+func (c *Config) TilesPath() string {
+	if c.options.TilesPath == "" {
+		return ""
+	}
+
+	return fs.Abs(c.options.TilesPath)
+}
+
+// TilesStyles returns the names of the offline map styles found in the tiles path.
+func (c *Config) TilesStyles() (styles []string) {
+	if c.TilesPath() == "" {
+		return styles
+	}
+
+	matches, err := filepath.Glob(filepath.Join(c.TilesPath(), "*"+TilesExt))
+
+	if err != nil {
+		log.Warnf("config: %s (find map tiles)", err)
+		return styles
+	}
+
+	// Style names must be valid URL path segments.
+	for _, fileName := range matches {
+		if style := strings.TrimSuffix(filepath.Base(fileName), TilesExt); style == sanitize.Token(style) {
+			styles = append(styles, style)
+		}
+	}
+
+	sort.Strings(styles)
+
+	return styles
+}
+
+// TilesStyle returns the offline map style, or an empty string if offline maps are disabled.
+func (c *Config) TilesStyle() string {
+	styles := c.TilesStyles()
+
+	if len(styles) == 0 {
+		return ""
+	} else if c.options.TilesStyle == "" {
+		return styles[0]
+	}
+
+	style := sanitize.Token(c.options.TilesStyle)
+
+	for _, s := range styles {
+		if s == style {
+			return style
+		}
+	}
+
+	log.Warnf("config: map tiles %s not found", sanitize.Log(c.options.TilesStyle))
+
+	return ""
+}
+
+// TilesFile returns the MBTiles file name of an offline map style, or an empty string if it doesn't exist.
+func (c *Config) TilesFile(style string) string {
+	if style == "" || style != sanitize.Token(style) || c.TilesPath() == "" {
+		return ""
+	}
+
+	fileName := filepath.Join(c.TilesPath(), style+TilesExt)
+
+	if !fs.FileExists(fileName) {
+		return ""
+	}
+
+	return fileName
+}
+
+// OfflineMaps tests if map tiles are served from MBTiles files, so that maps work without internet access.
+func (c *Config) OfflineMaps() bool {
+	return c.TilesStyle() != ""
+}
+
+// MapTilesUri returns the URI template for offline map tiles, or an empty string if offline maps are disabled.
+func (c *Config) MapTilesUri() string {
+	style := c.TilesStyle()
+
+	if style == "" {
+		return ""
+	}
+
+	return c.ApiUri() + "/tiles/" + c.PreviewToken() + "/" + style + "/{z}/{x}/{y}"
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_TilesStyle(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.TilesPath())
+	assert.Empty(t, c.TilesStyles())
+	assert.Equal(t, "", c.TilesStyle())
+	assert.False(t, c.OfflineMaps())
+	assert.Equal(t, "", c.MapTilesUri())
+
+	dir := t.TempDir()
+
+	for _, name := range []string{"topo.mbtiles", "osm.mbtiles", "invalid name.mbtiles", "readme.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte{}, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c.options.TilesPath = dir
+
+	assert.Equal(t, dir, c.TilesPath())
+	assert.Equal(t, []string{"osm", "topo"}, c.TilesStyles())
+	assert.Equal(t, "osm", c.TilesStyle())
+	assert.True(t, c.OfflineMaps())
+	assert.Equal(t, "/api/v1/tiles/"+c.PreviewToken()+"/osm/{z}/{x}/{y}", c.MapTilesUri())
+
+	c.options.TilesStyle = "topo"
+
+	assert.Equal(t, "topo", c.TilesStyle())
+	assert.Equal(t, filepath.Join(dir, "topo.mbtiles"), c.TilesFile("topo"))
+	assert.Equal(t, "", c.TilesFile("../topo"))
+	assert.Equal(t, "", c.TilesFile("missing"))
+
+	c.options.TilesStyle = "missing"
+
+	assert.Equal(t, "", c.TilesStyle())
+	assert.False(t, c.OfflineMaps())
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/tracing"
+)
+
+// TraceEndpoint returns the OTLP/HTTP collector endpoint, e.g. localhost:4318, tracing is disabled if empty.
+func (c *Config) TraceEndpoint() string {
+	return strings.TrimSpace(c.options.TraceEndpoint)
+}
+
+// TraceInsecure tests if spans should be sent to the collector without TLS.
+func (c *Config) TraceInsecure() bool {
+	return c.options.TraceInsecure
+}
+
+// TraceSampleRate returns the fraction of traces that are recorded, from 0 to 1.
+func (c *Config) TraceSampleRate() float64 {
+	if c.options.TraceSampleRate <= 0 || c.options.TraceSampleRate > 1 {
+		return 1
+	}
+
+	return c.options.TraceSampleRate
+}
+
+// Tracing tests if request, database, and worker spans are exported.
+func (c *Config) Tracing() bool {
+	return c.TraceEndpoint() != ""
+}
+
+// initTracing sets up the span exporter if a collector endpoint is configured.
+func (c *Config) initTracing() {
+	if !c.Tracing() {
+		return
+	}
+
+	if err := tracing.Init(tracing.Options{
+		Endpoint:   c.TraceEndpoint(),
+		Insecure:   c.TraceInsecure(),
+		SampleRate: c.TraceSampleRate(),
+		Service:    strings.ToLower(c.Name()),
+		Version:    c.Version(),
+	}); err != nil {
+		log.Errorf("config: %s", err)
+	}
+}
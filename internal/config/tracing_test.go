@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_TraceEndpoint(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.TraceEndpoint())
+	assert.False(t, c.Tracing())
+	c.options.TraceEndpoint = " localhost:4318 "
+	assert.Equal(t, "localhost:4318", c.TraceEndpoint())
+	assert.True(t, c.Tracing())
+	c.options.TraceEndpoint = ""
+}
+
+func TestConfig_TraceInsecure(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.TraceInsecure())
+	c.options.TraceInsecure = true
+	assert.True(t, c.TraceInsecure())
+	c.options.TraceInsecure = false
+}
+
+func TestConfig_TraceSampleRate(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 1.0, c.TraceSampleRate())
+	c.options.TraceSampleRate = 0.25
+	assert.Equal(t, 0.25, c.TraceSampleRate())
+	c.options.TraceSampleRate = 5
+	assert.Equal(t, 1.0, c.TraceSampleRate())
+	c.options.TraceSampleRate = -1
+	assert.Equal(t, 1.0, c.TraceSampleRate())
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Task types with separate worker concurrency settings.
+const (
+	TaskIndex   = "index"
+	TaskThumbs  = "thumbs"
+	TaskConvert = "convert"
+	TaskFaces   = "faces"
+	TaskMeta    = "meta"
+)
+
+// Tasks lists all task types with separate worker concurrency settings.
+var Tasks = []string{TaskIndex, TaskThumbs, TaskConvert, TaskFaces, TaskMeta}
+
+// TaskWorkersMap maps task types to the number of workers.
+type TaskWorkersMap map[string]int
+
+var taskWorkersMutex = sync.RWMutex{}
+
+// taskOption returns a pointer to the worker option of a task type, or nil if the type is unknown.
+func (c *Config) taskOption(task string) *int {
+	switch task {
+	case TaskIndex:
+		return &c.options.IndexWorkers
+	case TaskThumbs:
+		return &c.options.ThumbWorkers
+	case TaskConvert:
+		return &c.options.ConvertWorkers
+	case TaskFaces:
+		return &c.options.FaceWorkers
+	case TaskMeta:
+		return &c.options.MetaWorkers
+	default:
+		return nil
+	}
+}
+
+// TaskWorkers returns the number of workers for a task type, e.g. TaskIndex.
+func (c *Config) TaskWorkers(task string) int {
+	taskWorkersMutex.RLock()
+	defer taskWorkersMutex.RUnlock()
+
+	opt := c.taskOption(task)
+
+	// Use the default number of workers if not set.
+	if opt == nil || *opt <= 0 {
+		if task == TaskMeta {
+			return 1
+		}
+
+		return c.Workers()
+	}
+
+	n := *opt
+
+	// Limit number of workers writing to SQLite3 to avoid database locking issues.
+	if c.DatabaseDriver() == SQLite3 && n > 4 && task != TaskThumbs && task != TaskConvert {
+		return 4
+	}
+
+	// Don't start more workers than there are CPU cores.
+	if n > runtime.NumCPU() {
+		return runtime.NumCPU()
+	}
+
+	return n
+}
+
+// SetTaskWorkers changes the number of workers for a task type at runtime, 0 restores the default.
+func (c *Config) SetTaskWorkers(task string, n int) error {
+	if n < 0 || n > runtime.NumCPU() {
+		return fmt.Errorf("number of %s workers must be between 0 and %d", sanitize.Log(task), runtime.NumCPU())
+	}
+
+	taskWorkersMutex.Lock()
+	defer taskWorkersMutex.Unlock()
+
+	opt := c.taskOption(task)
+
+	if opt == nil {
+		return fmt.Errorf("unknown task type %s", sanitize.Log(task))
+	}
+
+	*opt = n
+
+	return nil
+}
+
+// AllTaskWorkers returns the number of workers for all task types.
+func (c *Config) AllTaskWorkers() TaskWorkersMap {
+	result := make(TaskWorkersMap, len(Tasks))
+
+	for _, task := range Tasks {
+		result[task] = c.TaskWorkers(task)
+	}
+
+	return result
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_TaskWorkers(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	t.Run("Default", func(t *testing.T) {
+		assert.Equal(t, c.Workers(), c.TaskWorkers(TaskIndex))
+		assert.Equal(t, c.Workers(), c.TaskWorkers(TaskThumbs))
+		assert.Equal(t, 1, c.TaskWorkers(TaskMeta))
+		assert.Equal(t, c.Workers(), c.TaskWorkers("unknown"))
+	})
+	t.Run("Explicit", func(t *testing.T) {
+		c.options.ThumbWorkers = 1
+		assert.Equal(t, 1, c.TaskWorkers(TaskThumbs))
+		c.options.ThumbWorkers = runtime.NumCPU() + 1
+		assert.Equal(t, runtime.NumCPU(), c.TaskWorkers(TaskThumbs))
+		c.options.ThumbWorkers = 0
+	})
+}
+
+func TestConfig_SetTaskWorkers(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.NoError(t, c.SetTaskWorkers(TaskConvert, 1))
+	assert.Equal(t, 1, c.TaskWorkers(TaskConvert))
+	assert.NoError(t, c.SetTaskWorkers(TaskConvert, 0))
+	assert.Equal(t, c.Workers(), c.TaskWorkers(TaskConvert))
+
+	assert.Error(t, c.SetTaskWorkers(TaskConvert, -1))
+	assert.Error(t, c.SetTaskWorkers(TaskConvert, runtime.NumCPU()+1))
+	assert.Error(t, c.SetTaskWorkers("unknown", 1))
+}
+
+func TestConfig_AllTaskWorkers(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	result := c.AllTaskWorkers()
+
+	assert.Len(t, result, len(Tasks))
+	assert.Equal(t, 1, result[TaskMeta])
+}
@@ -0,0 +1,334 @@
+package dlna
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// Content directory object IDs.
+const (
+	RootID      = "0"
+	AlbumsID    = "albums"
+	RecentID    = "recent"
+	FavoritesID = "favorites"
+	AlbumPrefix = "album:"
+	PhotoPrefix = "photo:"
+)
+
+// Browse flags, see ContentDirectory:1 service template.
+const (
+	BrowseMetadata       = "BrowseMetadata"
+	BrowseDirectChildren = "BrowseDirectChildren"
+)
+
+// MaxCount is the maximum number of objects returned by a single browse request.
+const MaxCount = 500
+
+// RecentCount is the number of photos and videos in the recent container.
+const RecentCount = 100
+
+// Res represents a media resource of an item.
+type Res struct {
+	ProtocolInfo string `xml:"protocolInfo,attr"`
+	Resolution   string `xml:"resolution,attr,omitempty"`
+	Duration     string `xml:"duration,attr,omitempty"`
+	URL          string `xml:",chardata"`
+}
+
+// Object represents a container or item in a DIDL-Lite document.
+type Object struct {
+	ID          string `xml:"id,attr"`
+	ParentID    string `xml:"parentID,attr"`
+	Restricted  int    `xml:"restricted,attr"`
+	ChildCount  *int   `xml:"childCount,attr,omitempty"`
+	Title       string `xml:"dc:title"`
+	Class       string `xml:"upnp:class"`
+	Date        string `xml:"dc:date,omitempty"`
+	AlbumArtURI string `xml:"upnp:albumArtURI,omitempty"`
+	Res         []Res  `xml:"res,omitempty"`
+}
+
+// DIDL represents a DIDL-Lite document with containers and items.
+type DIDL struct {
+	XMLName    xml.Name `xml:"DIDL-Lite"`
+	Xmlns      string   `xml:"xmlns,attr"`
+	XmlnsDC    string   `xml:"xmlns:dc,attr"`
+	XmlnsUPnP  string   `xml:"xmlns:upnp,attr"`
+	Containers []Object `xml:"container"`
+	Items      []Object `xml:"item"`
+}
+
+// Len returns the number of objects in the document.
+func (d DIDL) Len() int {
+	return len(d.Containers) + len(d.Items)
+}
+
+// String returns the document as XML string.
+func (d DIDL) String() string {
+	d.Xmlns = "urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/"
+	d.XmlnsDC = "http://purl.org/dc/elements/1.1/"
+	d.XmlnsUPnP = "urn:schemas-upnp-org:metadata-1-0/upnp/"
+
+	data, err := xml.Marshal(d)
+
+	if err != nil {
+		log.Errorf("dlna: %s", err)
+		return ""
+	}
+
+	return string(data)
+}
+
+// BrowseResult represents the result of a browse request.
+type BrowseResult struct {
+	DIDL         DIDL
+	TotalMatches int
+}
+
+// Content represents the content directory of the media server.
+type Content struct {
+	ContentUri   string
+	PreviewToken string
+}
+
+// NewContent returns a content directory with absolute media URLs, e.g. "http://192.168.1.2:2342/api/v1".
+func NewContent(contentUri, previewToken string) *Content {
+	return &Content{ContentUri: strings.TrimRight(contentUri, "/"), PreviewToken: previewToken}
+}
+
+// Browse returns the metadata or the children of an object.
+func (c *Content) Browse(id, flag string, offset, count int) (result BrowseResult, err error) {
+	if count <= 0 || count > MaxCount {
+		count = MaxCount
+	}
+
+	switch flag {
+	case BrowseMetadata:
+		return c.metadata(id)
+	case BrowseDirectChildren:
+		return c.children(id, offset, count)
+	default:
+		return result, Fault{Code: ErrInvalidArgs, Description: fmt.Sprintf("invalid browse flag %s", flag)}
+	}
+}
+
+// metadata returns the metadata of a single object.
+func (c *Content) metadata(id string) (result BrowseResult, err error) {
+	result.TotalMatches = 1
+
+	switch {
+	case id == RootID:
+		result.DIDL.Containers = []Object{container(RootID, "-1", "PhotoPrism", 3)}
+	case id == AlbumsID || id == RecentID || id == FavoritesID:
+		result.DIDL.Containers = []Object{c.rootContainers()[id]}
+	case strings.HasPrefix(id, AlbumPrefix):
+		albums, err := search.Albums(form.SearchAlbums{UID: strings.TrimPrefix(id, AlbumPrefix), Type: entity.AlbumDefault, Count: 1})
+
+		if err != nil {
+			return result, err
+		} else if len(albums) == 0 {
+			return result, Fault{Code: ErrNoSuchObject, Description: "no such object"}
+		}
+
+		result.DIDL.Containers = []Object{c.album(albums[0])}
+	case strings.HasPrefix(id, PhotoPrefix):
+		photos, _, err := search.Photos(form.SearchPhotos{UID: strings.TrimPrefix(id, PhotoPrefix), Primary: true, Public: true, Count: 1})
+
+		if err != nil {
+			return result, err
+		} else if len(photos) == 0 {
+			return result, Fault{Code: ErrNoSuchObject, Description: "no such object"}
+		}
+
+		result.DIDL.Items = []Object{c.item(photos[0], RecentID)}
+	default:
+		return result, Fault{Code: ErrNoSuchObject, Description: "no such object"}
+	}
+
+	return result, nil
+}
+
+// children returns the direct children of a container.
+func (c *Content) children(id string, offset, count int) (result BrowseResult, err error) {
+	switch {
+	case id == RootID:
+		all := c.rootContainers()
+
+		for _, cid := range []string{AlbumsID, RecentID, FavoritesID} {
+			result.DIDL.Containers = append(result.DIDL.Containers, all[cid])
+		}
+
+		result.DIDL.Containers = page(result.DIDL.Containers, offset, count)
+		result.TotalMatches = 3
+
+		return result, nil
+	case id == AlbumsID:
+		albums, err := search.Albums(form.SearchAlbums{Type: entity.AlbumDefault, Order: entity.SortOrderName, Count: count, Offset: offset})
+
+		if err != nil {
+			return result, err
+		}
+
+		for _, a := range albums {
+			result.DIDL.Containers = append(result.DIDL.Containers, c.album(a))
+		}
+	case id == RecentID:
+		if offset >= RecentCount {
+			result.TotalMatches = RecentCount
+			return result, nil
+		} else if offset+count > RecentCount {
+			count = RecentCount - offset
+		}
+
+		err = c.photos(&result, id, form.SearchPhotos{Order: entity.SortOrderAdded, Count: count, Offset: offset})
+	case id == FavoritesID:
+		err = c.photos(&result, id, form.SearchPhotos{Favorite: true, Order: entity.SortOrderNewest, Count: count, Offset: offset})
+	case strings.HasPrefix(id, AlbumPrefix):
+		err = c.photos(&result, id, form.SearchPhotos{Album: strings.TrimPrefix(id, AlbumPrefix), Order: entity.SortOrderOldest, Count: count, Offset: offset})
+	default:
+		return result, Fault{Code: ErrNoSuchObject, Description: "no such object"}
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	// The exact total is unknown, so one more object is reported if the page is full.
+	result.TotalMatches = offset + result.DIDL.Len()
+
+	if result.DIDL.Len() == count {
+		result.TotalMatches++
+	}
+
+	if id == RecentID && result.TotalMatches > RecentCount {
+		result.TotalMatches = RecentCount
+	}
+
+	return result, nil
+}
+
+// photos adds the photos and videos matching the search form as items.
+func (c *Content) photos(result *BrowseResult, parentID string, f form.SearchPhotos) error {
+	f.Primary = true
+	f.Public = true
+
+	photos, _, err := search.Photos(f)
+
+	if err != nil {
+		return err
+	}
+
+	for _, p := range photos {
+		result.DIDL.Items = append(result.DIDL.Items, c.item(p, parentID))
+	}
+
+	return nil
+}
+
+// rootContainers returns the top-level containers by ID.
+func (c *Content) rootContainers() map[string]Object {
+	return map[string]Object{
+		AlbumsID:    container(AlbumsID, RootID, "Albums", -1),
+		RecentID:    container(RecentID, RootID, "Recent", -1),
+		FavoritesID: container(FavoritesID, RootID, "Favorites", -1),
+	}
+}
+
+// album returns an album container.
+func (c *Content) album(a search.Album) Object {
+	obj := container(AlbumPrefix+a.AlbumUID, AlbumsID, a.AlbumTitle, a.PhotoCount)
+	obj.Class = "object.container.album.photoAlbum"
+
+	if a.Thumb != "" {
+		obj.AlbumArtURI = c.thumbUrl(a.Thumb, "tile_224")
+	}
+
+	return obj
+}
+
+// item returns a photo or video item.
+func (c *Content) item(p search.Photo, parentID string) Object {
+	obj := Object{
+		ID:          PhotoPrefix + p.PhotoUID,
+		ParentID:    parentID,
+		Restricted:  1,
+		Title:       p.PhotoTitle,
+		Date:        p.TakenAt.Format("2006-01-02"),
+		AlbumArtURI: c.thumbUrl(p.FileHash, "tile_224"),
+	}
+
+	if obj.Title == "" {
+		obj.Title = p.PhotoUID
+	}
+
+	if p.PhotoType == entity.TypeVideo {
+		obj.Class = "object.item.videoItem"
+		obj.Res = []Res{{
+			ProtocolInfo: "http-get:*:video/mp4:*",
+			Duration:     duration(p.FileDuration),
+			URL:          fmt.Sprintf("%s/videos/%s/%s/avc", c.ContentUri, p.FileHash, c.PreviewToken),
+		}}
+	} else {
+		obj.Class = "object.item.imageItem.photo"
+		obj.Res = []Res{{
+			ProtocolInfo: "http-get:*:image/jpeg:DLNA.ORG_PN=JPEG_LRG",
+			URL:          c.thumbUrl(p.FileHash, "fit_1920"),
+		}}
+	}
+
+	return obj
+}
+
+// thumbUrl returns the absolute URL of a thumbnail.
+func (c *Content) thumbUrl(hash, size string) string {
+	return fmt.Sprintf("%s/t/%s/%s/%s", c.ContentUri, hash, c.PreviewToken, size)
+}
+
+// container returns a container object, a negative child count is omitted.
+func container(id, parentID, title string, childCount int) Object {
+	obj := Object{
+		ID:         id,
+		ParentID:   parentID,
+		Restricted: 1,
+		Title:      title,
+		Class:      "object.container.storageFolder",
+	}
+
+	if childCount >= 0 {
+		obj.ChildCount = &childCount
+	}
+
+	return obj
+}
+
+// page returns the objects in the requested range.
+func page(objects []Object, offset, count int) []Object {
+	if offset >= len(objects) {
+		return nil
+	}
+
+	objects = objects[offset:]
+
+	if count < len(objects) {
+		objects = objects[:count]
+	}
+
+	return objects
+}
+
+// duration formats a duration as H:MM:SS.
+func duration(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+
+	s := int(d.Seconds())
+
+	return fmt.Sprintf("%d:%02d:%02d", s/3600, (s/60)%60, s%60)
+}
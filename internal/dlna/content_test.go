@@ -0,0 +1,163 @@
+package dlna
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func testContent() *Content {
+	return NewContent("http://192.168.1.2:2342/api/v1/", "public")
+}
+
+func TestContent_Browse(t *testing.T) {
+	t.Run("RootMetadata", func(t *testing.T) {
+		result, err := testContent().Browse(RootID, BrowseMetadata, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, result.TotalMatches)
+		assert.Equal(t, "-1", result.DIDL.Containers[0].ParentID)
+	})
+	t.Run("RootChildren", func(t *testing.T) {
+		result, err := testContent().Browse(RootID, BrowseDirectChildren, 1, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 3, result.TotalMatches)
+		assert.Len(t, result.DIDL.Containers, 2)
+		assert.Equal(t, RecentID, result.DIDL.Containers[0].ID)
+	})
+	t.Run("Albums", func(t *testing.T) {
+		result, err := testContent().Browse(AlbumsID, BrowseDirectChildren, 0, 2)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result.DIDL.Containers, 2)
+		assert.Equal(t, 3, result.TotalMatches)
+		assert.Equal(t, AlbumsID, result.DIDL.Containers[0].ParentID)
+		assert.Equal(t, "object.container.album.photoAlbum", result.DIDL.Containers[0].Class)
+	})
+	t.Run("Album", func(t *testing.T) {
+		uid := entity.AlbumFixtures.Get("holiday-2030").AlbumUID
+
+		meta, err := testContent().Browse(AlbumPrefix+uid, BrowseMetadata, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, AlbumPrefix+uid, meta.DIDL.Containers[0].ID)
+
+		result, err := testContent().Browse(AlbumPrefix+uid, BrowseDirectChildren, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, result.DIDL.Items)
+
+		for _, item := range result.DIDL.Items {
+			assert.Equal(t, AlbumPrefix+uid, item.ParentID)
+			assert.Len(t, item.Res, 1)
+		}
+	})
+	t.Run("Recent", func(t *testing.T) {
+		result, err := testContent().Browse(RecentID, BrowseDirectChildren, 0, 5)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result.DIDL.Items, 5)
+		assert.Equal(t, 6, result.TotalMatches)
+
+		item, err := testContent().Browse(result.DIDL.Items[0].ID, BrowseMetadata, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, result.DIDL.Items[0].ID, item.DIDL.Items[0].ID)
+	})
+	t.Run("RecentLimit", func(t *testing.T) {
+		result, err := testContent().Browse(RecentID, BrowseDirectChildren, RecentCount, 5)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result.DIDL.Items)
+		assert.Equal(t, RecentCount, result.TotalMatches)
+	})
+	t.Run("Favorites", func(t *testing.T) {
+		result, err := testContent().Browse(FavoritesID, BrowseDirectChildren, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, result.DIDL.Items)
+	})
+	t.Run("NoSuchObject", func(t *testing.T) {
+		_, err := testContent().Browse("foo", BrowseDirectChildren, 0, 0)
+
+		assert.Equal(t, Fault{Code: ErrNoSuchObject, Description: "no such object"}, err)
+	})
+	t.Run("InvalidFlag", func(t *testing.T) {
+		_, err := testContent().Browse(RootID, "foo", 0, 0)
+
+		assert.IsType(t, Fault{}, err)
+	})
+}
+
+func TestDIDL_String(t *testing.T) {
+	result, err := testContent().Browse(RootID, BrowseDirectChildren, 0, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := result.DIDL.String()
+
+	assert.Contains(t, s, `<DIDL-Lite xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/">`)
+	assert.Contains(t, s, `<container id="albums" parentID="0" restricted="1"><dc:title>Albums</dc:title><upnp:class>object.container.storageFolder</upnp:class></container>`)
+}
+
+func TestContent_item(t *testing.T) {
+	content := testContent()
+
+	result, err := content.Browse(RecentID, BrowseDirectChildren, 0, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range result.DIDL.Items {
+		switch item.Class {
+		case "object.item.videoItem":
+			assert.Contains(t, item.Res[0].URL, "http://192.168.1.2:2342/api/v1/videos/")
+			assert.Contains(t, item.Res[0].URL, "/public/avc")
+		case "object.item.imageItem.photo":
+			assert.Contains(t, item.Res[0].URL, "http://192.168.1.2:2342/api/v1/t/")
+			assert.Contains(t, item.Res[0].URL, "/public/fit_1920")
+		default:
+			t.Errorf("unexpected class %s", item.Class)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	assert.Equal(t, "", duration(0))
+	assert.Equal(t, "0:01:05", duration(65*time.Second))
+	assert.Equal(t, "1:00:00", duration(time.Hour))
+}
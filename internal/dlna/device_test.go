@@ -0,0 +1,42 @@
+package dlna
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRoot(t *testing.T) {
+	r := NewRoot("Living Room", "9d4b2a3c-0000-5000-8000-000000000000", "1.0.0", "/dlna")
+
+	assert.Equal(t, DeviceType, r.Device.DeviceType)
+	assert.Equal(t, "uuid:9d4b2a3c-0000-5000-8000-000000000000", r.Device.UDN)
+	assert.Len(t, r.Device.Services, 2)
+	assert.Equal(t, "/dlna/control/ContentDirectory", r.Device.Services[0].ControlURL)
+	assert.Equal(t, "/dlna/ConnectionManager.xml", r.Device.Services[1].SCPDURL)
+}
+
+func TestRoot_Bytes(t *testing.T) {
+	data, err := NewRoot("Living Room", "9d4b2a3c", "1.0.0", "/dlna").Bytes()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := string(data)
+
+	assert.True(t, strings.HasPrefix(s, xml.Header))
+	assert.Contains(t, s, `<root xmlns="urn:schemas-upnp-org:device-1-0">`)
+	assert.Contains(t, s, "<friendlyName>Living Room</friendlyName>")
+	assert.Contains(t, s, "<serviceType>urn:schemas-upnp-org:service:ContentDirectory:1</serviceType>")
+	assert.Contains(t, s, "DMS-1.50")
+}
+
+func TestSCPD(t *testing.T) {
+	var v struct{}
+
+	assert.NoError(t, xml.Unmarshal([]byte(ContentDirectorySCPD), &v))
+	assert.NoError(t, xml.Unmarshal([]byte(ConnectionManagerSCPD), &v))
+}
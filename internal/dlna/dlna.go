@@ -0,0 +1,51 @@
+/*
+
+Package dlna provides a DLNA media server, so that smart TVs can browse albums and recent photos natively.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.app/developer-guide/
+
+*/
+package dlna
+
+import (
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// Uri is the base URI of the device description, service descriptions, and control endpoints.
+const Uri = "/dlna"
+
+// UPnP device and service types, see http://upnp.org/specs/av/UPnP-av-MediaServer-v1-Device.pdf.
+const (
+	DeviceType            = "urn:schemas-upnp-org:device:MediaServer:1"
+	ContentDirectoryType  = "urn:schemas-upnp-org:service:ContentDirectory:1"
+	ConnectionManagerType = "urn:schemas-upnp-org:service:ConnectionManager:1"
+)
+
+// ServiceTypes lists the services of the media server.
+var ServiceTypes = []string{ContentDirectoryType, ConnectionManagerType}
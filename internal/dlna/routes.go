@@ -0,0 +1,181 @@
+package dlna
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+// contentType is the content type of device descriptions and SOAP responses.
+const contentType = `text/xml; charset="utf-8"`
+
+// SystemUpdateID is reported to clients as content directory version. It does not change,
+// so that clients reload the library when browsing.
+const SystemUpdateID = "1"
+
+// SourceProtocolInfo lists the media formats the server provides.
+const SourceProtocolInfo = "http-get:*:image/jpeg:*,http-get:*:video/mp4:*"
+
+// Register registers the device description, service descriptions, and control endpoints.
+func Register(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/device.xml", func(c *gin.Context) {
+		data, err := NewRoot(conf.DLNAName(), conf.DLNAUUID(), conf.Version(), Uri).Bytes()
+
+		if err != nil {
+			log.Errorf("dlna: %s", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.Data(http.StatusOK, contentType, data)
+	})
+
+	router.GET("/ContentDirectory.xml", func(c *gin.Context) {
+		c.Data(http.StatusOK, contentType, []byte(ContentDirectorySCPD))
+	})
+
+	router.GET("/ConnectionManager.xml", func(c *gin.Context) {
+		c.Data(http.StatusOK, contentType, []byte(ConnectionManagerSCPD))
+	})
+
+	router.POST("/control/ContentDirectory", func(c *gin.Context) {
+		control(c, ContentDirectoryType, func(action string, args Args) ([]Arg, error) {
+			return contentDirectory(conf, c, action, args)
+		})
+	})
+
+	router.POST("/control/ConnectionManager", func(c *gin.Context) {
+		control(c, ConnectionManagerType, connectionManager)
+	})
+
+	// Events are not sent, but clients expect subscriptions to succeed.
+	subscribe := func(c *gin.Context) {
+		sid := c.GetHeader("SID")
+
+		if sid == "" {
+			sid = "uuid:" + uuid.NewV4().String()
+		}
+
+		c.Header("SID", sid)
+		c.Header("TIMEOUT", "Second-1800")
+		c.Status(http.StatusOK)
+	}
+
+	unsubscribe := func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	}
+
+	for _, service := range []string{"ContentDirectory", "ConnectionManager"} {
+		router.Handle("SUBSCRIBE", "/event/"+service, subscribe)
+		router.Handle("UNSUBSCRIBE", "/event/"+service, unsubscribe)
+	}
+}
+
+// LocalClient tests if the remote address of a request belongs to the local network.
+func LocalClient(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+
+	if err != nil {
+		host = remoteAddr
+	}
+
+	ip := net.ParseIP(host)
+
+	if ip == nil {
+		return false
+	}
+
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast()
+}
+
+// control parses a SOAP action request and writes the response returned by the handler.
+func control(c *gin.Context, serviceType string, handler func(action string, args Args) ([]Arg, error)) {
+	action := ParseAction(c.GetHeader("SOAPACTION"))
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, 1<<20))
+
+	if err != nil {
+		fault(c, Fault{Code: ErrInvalidArgs, Description: "invalid request"})
+		return
+	}
+
+	args, err := ParseArgs(body)
+
+	if err != nil {
+		log.Debugf("dlna: %s (parse %s request)", err, action)
+		fault(c, Fault{Code: ErrInvalidArgs, Description: "invalid request"})
+		return
+	}
+
+	result, err := handler(action, args)
+
+	if f, ok := err.(Fault); ok {
+		fault(c, f)
+		return
+	} else if err != nil {
+		log.Errorf("dlna: %s (%s)", err, action)
+		fault(c, Fault{Code: ErrInvalidAction, Description: "action failed"})
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, Response(serviceType, action, result))
+}
+
+// fault writes a UPnP error response.
+func fault(c *gin.Context, f Fault) {
+	c.Data(http.StatusInternalServerError, contentType, FaultResponse(f))
+}
+
+// contentDirectory handles ContentDirectory:1 actions.
+func contentDirectory(conf *config.Config, c *gin.Context, action string, args Args) ([]Arg, error) {
+	switch action {
+	case "Browse":
+		// Media URLs must be absolute and reachable by the client, so they are based on the local address
+		// the request was received on rather than the Host header sent by the client.
+		addr, ok := c.Request.Context().Value(http.LocalAddrContextKey).(net.Addr)
+
+		if !ok || addr == nil {
+			return nil, fmt.Errorf("unknown local address")
+		}
+
+		content := NewContent(fmt.Sprintf("http://%s%s", addr.String(), config.ApiUri), conf.DLNAToken())
+
+		result, err := content.Browse(args["ObjectID"], args["BrowseFlag"], args.Int("StartingIndex"), args.Int("RequestedCount"))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []Arg{
+			{Name: "Result", Value: result.DIDL.String()},
+			{Name: "NumberReturned", Value: fmt.Sprintf("%d", result.DIDL.Len())},
+			{Name: "TotalMatches", Value: fmt.Sprintf("%d", result.TotalMatches)},
+			{Name: "UpdateID", Value: SystemUpdateID},
+		}, nil
+	case "GetSystemUpdateID":
+		return []Arg{{Name: "Id", Value: SystemUpdateID}}, nil
+	case "GetSearchCapabilities":
+		return []Arg{{Name: "SearchCaps", Value: ""}}, nil
+	case "GetSortCapabilities":
+		return []Arg{{Name: "SortCaps", Value: ""}}, nil
+	default:
+		return nil, Fault{Code: ErrInvalidAction, Description: "invalid action"}
+	}
+}
+
+// connectionManager handles ConnectionManager:1 actions.
+func connectionManager(action string, args Args) ([]Arg, error) {
+	switch action {
+	case "GetProtocolInfo":
+		return []Arg{{Name: "Source", Value: SourceProtocolInfo}, {Name: "Sink", Value: ""}}, nil
+	case "GetCurrentConnectionIDs":
+		return []Arg{{Name: "ConnectionIDs", Value: "0"}}, nil
+	default:
+		return nil, Fault{Code: ErrInvalidAction, Description: "invalid action"}
+	}
+}
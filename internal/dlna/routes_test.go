@@ -0,0 +1,103 @@
+package dlna
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func testRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	app := gin.New()
+	Register(app.Group(Uri), config.TestConfig())
+	return app
+}
+
+func TestRegister(t *testing.T) {
+	app := testRouter()
+
+	t.Run("Device", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/dlna/device.xml", nil)
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "<deviceType>urn:schemas-upnp-org:device:MediaServer:1</deviceType>")
+		assert.Contains(t, w.Body.String(), "<controlURL>/dlna/control/ContentDirectory</controlURL>")
+	})
+	t.Run("ServiceDescription", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/dlna/ContentDirectory.xml", nil)
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "<name>Browse</name>")
+	})
+	t.Run("Browse", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/dlna/control/ContentDirectory", strings.NewReader(browseRequest))
+		req.Header.Set("SOAPACTION", `"urn:schemas-upnp-org:service:ContentDirectory:1#Browse"`)
+		req.Host = "attacker.example.com"
+		req = req.WithContext(context.WithValue(req.Context(), http.LocalAddrContextKey, &net.TCPAddr{IP: net.ParseIP("192.168.1.2"), Port: 8200}))
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "<u:BrowseResponse")
+		assert.Contains(t, w.Body.String(), "&lt;DIDL-Lite")
+		assert.Contains(t, w.Body.String(), "<UpdateID>1</UpdateID>")
+	})
+	t.Run("UnknownLocalAddr", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/dlna/control/ContentDirectory", strings.NewReader(browseRequest))
+		req.Header.Set("SOAPACTION", `"urn:schemas-upnp-org:service:ContentDirectory:1#Browse"`)
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+	t.Run("InvalidAction", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/dlna/control/ContentDirectory", strings.NewReader(browseRequest))
+		req.Header.Set("SOAPACTION", `"urn:schemas-upnp-org:service:ContentDirectory:1#Foo"`)
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "<errorCode>401</errorCode>")
+	})
+	t.Run("ProtocolInfo", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/dlna/control/ConnectionManager", strings.NewReader(browseRequest))
+		req.Header.Set("SOAPACTION", `"urn:schemas-upnp-org:service:ConnectionManager:1#GetProtocolInfo"`)
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "<Source>http-get:*:image/jpeg:*,http-get:*:video/mp4:*</Source>")
+	})
+	t.Run("Subscribe", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("SUBSCRIBE", "/dlna/event/ContentDirectory", nil)
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, strings.HasPrefix(w.Header().Get("SID"), "uuid:"))
+		assert.Equal(t, "Second-1800", w.Header().Get("TIMEOUT"))
+	})
+}
+
+func TestLocalClient(t *testing.T) {
+	assert.True(t, LocalClient("192.168.1.20:52000"))
+	assert.True(t, LocalClient("10.0.0.5:52000"))
+	assert.True(t, LocalClient("127.0.0.1:52000"))
+	assert.True(t, LocalClient("[fe80::1]:52000"))
+	assert.True(t, LocalClient("[fd00::1]:52000"))
+	assert.False(t, LocalClient("8.8.8.8:52000"))
+	assert.False(t, LocalClient("[2001:4860:4860::8888]:52000"))
+	assert.False(t, LocalClient("invalid"))
+}
@@ -0,0 +1,116 @@
+package dlna
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// UPnP error codes returned in SOAP faults.
+const (
+	ErrInvalidAction = 401
+	ErrInvalidArgs   = 402
+	ErrNoSuchObject  = 701
+)
+
+// Fault represents a UPnP error that is returned as SOAP fault.
+type Fault struct {
+	Code        int
+	Description string
+}
+
+// Error returns the error description.
+func (f Fault) Error() string {
+	return fmt.Sprintf("upnp error %d: %s", f.Code, f.Description)
+}
+
+// Arg represents an action argument.
+type Arg struct {
+	Name  string
+	Value string
+}
+
+// Args represents the arguments of an action request.
+type Args map[string]string
+
+// Int returns an argument as integer, or 0 if it is missing or invalid.
+func (a Args) Int(name string) (result int) {
+	if _, err := fmt.Sscanf(strings.TrimSpace(a[name]), "%d", &result); err != nil || result < 0 {
+		return 0
+	}
+
+	return result
+}
+
+// ParseAction returns the action name from a SOAPACTION header like
+// "urn:schemas-upnp-org:service:ContentDirectory:1#Browse".
+func ParseAction(header string) string {
+	header = strings.Trim(strings.TrimSpace(header), `"`)
+
+	if i := strings.LastIndex(header, "#"); i >= 0 {
+		return header[i+1:]
+	}
+
+	return ""
+}
+
+// ParseArgs returns the arguments of a SOAP action request.
+func ParseArgs(body []byte) (Args, error) {
+	var env struct {
+		Body struct {
+			Action struct {
+				Args []struct {
+					XMLName xml.Name
+					Value   string `xml:",chardata"`
+				} `xml:",any"`
+			} `xml:",any"`
+		} `xml:"Body"`
+	}
+
+	if err := xml.Unmarshal(body, &env); err != nil {
+		return nil, err
+	}
+
+	args := make(Args, len(env.Body.Action.Args))
+
+	for _, arg := range env.Body.Action.Args {
+		args[arg.XMLName.Local] = arg.Value
+	}
+
+	return args, nil
+}
+
+// Response returns the SOAP envelope for an action response.
+func Response(serviceType, action string, args []Arg) []byte {
+	var b bytes.Buffer
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>`)
+	fmt.Fprintf(&b, `<u:%sResponse xmlns:u="%s">`, action, serviceType)
+
+	for _, arg := range args {
+		fmt.Fprintf(&b, "<%s>", arg.Name)
+		_ = xml.EscapeText(&b, []byte(arg.Value))
+		fmt.Fprintf(&b, "</%s>", arg.Name)
+	}
+
+	fmt.Fprintf(&b, `</u:%sResponse>`, action)
+	b.WriteString(`</s:Body></s:Envelope>`)
+
+	return b.Bytes()
+}
+
+// FaultResponse returns the SOAP envelope for a UPnP error.
+func FaultResponse(f Fault) []byte {
+	var b bytes.Buffer
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>`)
+	b.WriteString(`<s:Fault><faultcode>s:Client</faultcode><faultstring>UPnPError</faultstring><detail>`)
+	fmt.Fprintf(&b, `<UPnPError xmlns="urn:schemas-upnp-org:control-1-0"><errorCode>%d</errorCode><errorDescription>`, f.Code)
+	_ = xml.EscapeText(&b, []byte(f.Description))
+	b.WriteString(`</errorDescription></UPnPError></detail></s:Fault></s:Body></s:Envelope>`)
+
+	return b.Bytes()
+}
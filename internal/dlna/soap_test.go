@@ -0,0 +1,65 @@
+package dlna
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const browseRequest = `<?xml version="1.0" encoding="utf-8"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">
+  <s:Body>
+    <u:Browse xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1">
+      <ObjectID>albums</ObjectID>
+      <BrowseFlag>BrowseDirectChildren</BrowseFlag>
+      <Filter>*</Filter>
+      <StartingIndex>5</StartingIndex>
+      <RequestedCount>20</RequestedCount>
+      <SortCriteria></SortCriteria>
+    </u:Browse>
+  </s:Body>
+</s:Envelope>`
+
+func TestParseAction(t *testing.T) {
+	assert.Equal(t, "Browse", ParseAction(`"urn:schemas-upnp-org:service:ContentDirectory:1#Browse"`))
+	assert.Equal(t, "GetProtocolInfo", ParseAction("urn:schemas-upnp-org:service:ConnectionManager:1#GetProtocolInfo"))
+	assert.Equal(t, "", ParseAction(""))
+}
+
+func TestParseArgs(t *testing.T) {
+	t.Run("Browse", func(t *testing.T) {
+		args, err := ParseArgs([]byte(browseRequest))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "albums", args["ObjectID"])
+		assert.Equal(t, BrowseDirectChildren, args["BrowseFlag"])
+		assert.Equal(t, 5, args.Int("StartingIndex"))
+		assert.Equal(t, 20, args.Int("RequestedCount"))
+		assert.Equal(t, 0, args.Int("SortCriteria"))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseArgs([]byte("<s:Envelope"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestResponse(t *testing.T) {
+	s := string(Response(ContentDirectoryType, "Browse", []Arg{{Name: "Result", Value: "<DIDL-Lite/>"}}))
+
+	assert.Contains(t, s, `<u:BrowseResponse xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1">`)
+	assert.Contains(t, s, "<Result>&lt;DIDL-Lite/&gt;</Result>")
+	assert.True(t, strings.HasSuffix(s, "</u:BrowseResponse></s:Body></s:Envelope>"))
+}
+
+func TestFaultResponse(t *testing.T) {
+	s := string(FaultResponse(Fault{Code: ErrNoSuchObject, Description: "no such object"}))
+
+	assert.Contains(t, s, "<errorCode>701</errorCode>")
+	assert.Contains(t, s, "<errorDescription>no such object</errorDescription>")
+	assert.Equal(t, "upnp error 701: no such object", Fault{Code: ErrNoSuchObject, Description: "no such object"}.Error())
+}
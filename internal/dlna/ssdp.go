@@ -0,0 +1,267 @@
+package dlna
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+// SSDP discovery settings, see http://upnp.org/specs/arch/UPnP-arch-DeviceArchitecture-v1.1.pdf.
+const (
+	SsdpAddr     = "239.255.255.250:1900"
+	SsdpMaxAge   = 1800
+	SsdpInterval = 5 * time.Minute
+	SsdpAll      = "ssdp:all"
+	RootDevice   = "upnp:rootdevice"
+)
+
+// Ssdp announces the media server on the local network and answers search requests.
+type Ssdp struct {
+	UUID     string
+	Location string
+	Server   string
+}
+
+// NewSsdp returns a new SSDP announcer for the config.
+func NewSsdp(conf *config.Config) *Ssdp {
+	return &Ssdp{
+		UUID:     conf.DLNAUUID(),
+		Location: fmt.Sprintf(":%d%s", conf.DLNAPort(), Uri+"/device.xml"),
+		Server:   fmt.Sprintf("Linux/1.0 UPnP/1.0 %s", conf.UserAgent()),
+	}
+}
+
+// Start announces the media server until the context is canceled.
+func Start(ctx context.Context, conf *config.Config) {
+	s := NewSsdp(conf)
+
+	addr, err := net.ResolveUDPAddr("udp4", SsdpAddr)
+
+	if err != nil {
+		log.Errorf("dlna: %s", err)
+		return
+	}
+
+	conn, err := net.ListenMulticastUDP("udp4", nil, addr)
+
+	if err != nil {
+		log.Errorf("dlna: %s (listen for discovery requests)", err)
+		return
+	}
+
+	log.Infof("dlna: media server %s announced on %s", conf.DLNAName(), SsdpAddr)
+
+	go func() {
+		<-ctx.Done()
+		s.Notify(addr, "ssdp:byebye")
+		_ = conn.Close()
+	}()
+
+	go func() {
+		ticker := time.NewTicker(SsdpInterval)
+		defer ticker.Stop()
+
+		for {
+			s.Notify(addr, "ssdp:alive")
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	buf := make([]byte, 2048)
+
+	for {
+		n, remote, err := conn.ReadFromUDP(buf)
+
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Errorf("dlna: %s (read discovery request)", err)
+			}
+
+			return
+		}
+
+		st, ok := ParseSearch(buf[:n])
+
+		if !ok {
+			continue
+		}
+
+		for _, target := range s.Match(st) {
+			if err = s.Reply(remote, target); err != nil {
+				log.Debugf("dlna: %s (reply to %s)", err, remote)
+			}
+		}
+	}
+}
+
+// Targets returns all search targets of the media server.
+func (s *Ssdp) Targets() []string {
+	return append([]string{RootDevice, "uuid:" + s.UUID, DeviceType}, ServiceTypes...)
+}
+
+// Match returns the targets matching the search target of a request.
+func (s *Ssdp) Match(st string) []string {
+	if st == SsdpAll {
+		return s.Targets()
+	}
+
+	for _, target := range s.Targets() {
+		if target == st {
+			return []string{target}
+		}
+	}
+
+	return nil
+}
+
+// USN returns the unique service name of a target.
+func (s *Ssdp) USN(target string) string {
+	if target == "uuid:"+s.UUID {
+		return target
+	}
+
+	return fmt.Sprintf("uuid:%s::%s", s.UUID, target)
+}
+
+// Reply sends a unicast response to a search request.
+func (s *Ssdp) Reply(remote *net.UDPAddr, target string) error {
+	conn, err := net.DialUDP("udp4", nil, remote)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	// The local address of the connection is the one reachable by the client.
+	host := conn.LocalAddr().(*net.UDPAddr).IP.String()
+
+	_, err = conn.Write(s.Response(host, target))
+
+	return err
+}
+
+// Response returns the response to a search request.
+func (s *Ssdp) Response(host, target string) []byte {
+	return message("HTTP/1.1 200 OK", [][2]string{
+		{"CACHE-CONTROL", fmt.Sprintf("max-age=%d", SsdpMaxAge)},
+		{"DATE", time.Now().UTC().Format(http.TimeFormat)},
+		{"EXT", ""},
+		{"LOCATION", "http://" + host + s.Location},
+		{"SERVER", s.Server},
+		{"ST", target},
+		{"USN", s.USN(target)},
+	})
+}
+
+// Notify sends alive or byebye notifications for all targets on each multicast interface.
+func (s *Ssdp) Notify(addr *net.UDPAddr, nts string) {
+	for _, ip := range multicastIPs() {
+		conn, err := net.DialUDP("udp4", &net.UDPAddr{IP: ip}, addr)
+
+		if err != nil {
+			log.Debugf("dlna: %s (notify)", err)
+			continue
+		}
+
+		for _, target := range s.Targets() {
+			if _, err = conn.Write(s.Notification(ip.String(), target, nts)); err != nil {
+				log.Debugf("dlna: %s (notify)", err)
+			}
+		}
+
+		_ = conn.Close()
+	}
+}
+
+// Notification returns an alive or byebye notification for a target.
+func (s *Ssdp) Notification(host, target, nts string) []byte {
+	headers := [][2]string{
+		{"HOST", SsdpAddr},
+		{"NT", target},
+		{"NTS", nts},
+		{"USN", s.USN(target)},
+	}
+
+	if nts == "ssdp:alive" {
+		headers = append(headers,
+			[2]string{"CACHE-CONTROL", fmt.Sprintf("max-age=%d", SsdpMaxAge)},
+			[2]string{"LOCATION", "http://" + host + s.Location},
+			[2]string{"SERVER", s.Server},
+		)
+	}
+
+	return message("NOTIFY * HTTP/1.1", headers)
+}
+
+// ParseSearch returns the search target if the message is a discovery request.
+func ParseSearch(data []byte) (st string, ok bool) {
+	req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(data)))
+
+	if err != nil || req.Method != "M-SEARCH" {
+		return "", false
+	}
+
+	if strings.Trim(req.Header.Get("MAN"), `"`) != "ssdp:discover" {
+		return "", false
+	}
+
+	return req.Header.Get("ST"), true
+}
+
+// message returns an SSDP message with the given start line and headers.
+func message(start string, headers [][2]string) []byte {
+	var b bytes.Buffer
+
+	b.WriteString(start + "\r\n")
+
+	for _, h := range headers {
+		fmt.Fprintf(&b, "%s: %s\r\n", h[0], h[1])
+	}
+
+	b.WriteString("\r\n")
+
+	return b.Bytes()
+}
+
+// multicastIPs returns the IPv4 addresses of all active multicast interfaces.
+func multicastIPs() (result []net.IP) {
+	ifaces, err := net.Interfaces()
+
+	if err != nil {
+		log.Debugf("dlna: %s (interfaces)", err)
+		return result
+	}
+
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagMulticast == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+
+		if err != nil {
+			continue
+		}
+
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil {
+				result = append(result, ipNet.IP.To4())
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,78 @@
+package dlna
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSsdp() *Ssdp {
+	return &Ssdp{UUID: "9d4b2a3c", Location: ":2342/dlna/device.xml", Server: "Linux/1.0 UPnP/1.0 PhotoPrism/test"}
+}
+
+func TestParseSearch(t *testing.T) {
+	t.Run("Discover", func(t *testing.T) {
+		msg := "M-SEARCH * HTTP/1.1\r\nHOST: 239.255.255.250:1900\r\nMAN: \"ssdp:discover\"\r\nMX: 2\r\nST: urn:schemas-upnp-org:device:MediaServer:1\r\n\r\n"
+
+		st, ok := ParseSearch([]byte(msg))
+
+		assert.True(t, ok)
+		assert.Equal(t, DeviceType, st)
+	})
+	t.Run("Notify", func(t *testing.T) {
+		msg := "NOTIFY * HTTP/1.1\r\nHOST: 239.255.255.250:1900\r\nNT: upnp:rootdevice\r\nNTS: ssdp:alive\r\n\r\n"
+
+		_, ok := ParseSearch([]byte(msg))
+
+		assert.False(t, ok)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, ok := ParseSearch([]byte("foo"))
+
+		assert.False(t, ok)
+	})
+}
+
+func TestSsdp_Match(t *testing.T) {
+	s := testSsdp()
+
+	assert.Len(t, s.Match(SsdpAll), 5)
+	assert.Equal(t, []string{RootDevice}, s.Match(RootDevice))
+	assert.Equal(t, []string{"uuid:9d4b2a3c"}, s.Match("uuid:9d4b2a3c"))
+	assert.Equal(t, []string{ContentDirectoryType}, s.Match(ContentDirectoryType))
+	assert.Empty(t, s.Match("urn:schemas-upnp-org:device:MediaRenderer:1"))
+}
+
+func TestSsdp_USN(t *testing.T) {
+	s := testSsdp()
+
+	assert.Equal(t, "uuid:9d4b2a3c", s.USN("uuid:9d4b2a3c"))
+	assert.Equal(t, "uuid:9d4b2a3c::upnp:rootdevice", s.USN(RootDevice))
+}
+
+func TestSsdp_Response(t *testing.T) {
+	s := string(testSsdp().Response("192.168.1.2", DeviceType))
+
+	assert.True(t, strings.HasPrefix(s, "HTTP/1.1 200 OK\r\n"))
+	assert.Contains(t, s, "LOCATION: http://192.168.1.2:2342/dlna/device.xml\r\n")
+	assert.Contains(t, s, "ST: urn:schemas-upnp-org:device:MediaServer:1\r\n")
+	assert.Contains(t, s, "USN: uuid:9d4b2a3c::urn:schemas-upnp-org:device:MediaServer:1\r\n")
+	assert.True(t, strings.HasSuffix(s, "\r\n\r\n"))
+}
+
+func TestSsdp_Notification(t *testing.T) {
+	t.Run("Alive", func(t *testing.T) {
+		s := string(testSsdp().Notification("192.168.1.2", RootDevice, "ssdp:alive"))
+
+		assert.True(t, strings.HasPrefix(s, "NOTIFY * HTTP/1.1\r\n"))
+		assert.Contains(t, s, "NTS: ssdp:alive\r\n")
+		assert.Contains(t, s, "LOCATION: http://192.168.1.2:2342/dlna/device.xml\r\n")
+	})
+	t.Run("ByeBye", func(t *testing.T) {
+		s := string(testSsdp().Notification("192.168.1.2", RootDevice, "ssdp:byebye"))
+
+		assert.Contains(t, s, "NTS: ssdp:byebye\r\n")
+		assert.NotContains(t, s, "LOCATION")
+	})
+}
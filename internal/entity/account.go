@@ -78,9 +78,16 @@ func (m *Account) SaveForm(form form.Account) error {
 	if m.AccType != string(remote.ServiceWebDAV) {
 		// TODO: Only WebDAV supported at the moment
 		m.AccShare = false
+	}
+
+	if !remote.CanSync(m.AccType) {
 		m.AccSync = false
 	}
 
+	if !remote.CanUpload(m.AccType) {
+		m.SyncUpload = false
+	}
+
 	// Set defaults
 	if m.SharePath == "" {
 		m.SharePath = "/"
@@ -108,8 +115,15 @@ func (m *Account) Delete() error {
 
 // Directories returns a list of directories or albums in an account.
 func (m *Account) Directories() (result fs.FileInfos, err error) {
-	if m.AccType == remote.ServiceWebDAV {
-		c := webdav.New(m.AccURL, m.AccUser, m.AccPass)
+	if remote.CanSync(m.AccType) {
+		var c remote.Client
+
+		if c, err = remote.NewClient(m.AccType, m.AccURL, m.AccUser, m.AccPass); err != nil {
+			return result, err
+		}
+
+		defer c.Close()
+
 		result, err = c.Directories("/", true, webdav.SyncTimeout)
 	}
 
@@ -85,6 +85,27 @@ func TestAccount_SaveForm(t *testing.T) {
 		assert.Equal(t, "new.com", model.AccURL)
 
 	})
+	t.Run("sftp", func(t *testing.T) {
+		account := Account{AccName: "SFTP", AccURL: "sftp://example.com/", AccType: "sftp", AccShare: true, AccSync: true,
+			SyncPath: "/DCIM", SyncUpload: true, SyncDownload: true}
+
+		accountForm, err := form.NewAccount(account)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		model, err := CreateAccount(accountForm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, model.AccShare)
+		assert.True(t, model.AccSync)
+		assert.True(t, model.SyncDownload)
+		assert.False(t, model.SyncUpload)
+	})
 }
 
 func TestAccount_Delete(t *testing.T) {
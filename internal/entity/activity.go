@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Activity types.
+const (
+	ActivityImport = "import"
+	ActivityAlbum  = "album"
+	ActivityShare  = "share"
+	ActivityPerson = "person"
+)
+
+// activityTopics contains the events that are recorded as activity.
+var activityTopics = []string{"import.completed", "notify.share", "activity.*"}
+
+type Activities []Activity
+
+// Activity represents a high-level event such as a completed import or a newly created album.
+type Activity struct {
+	ID              uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	ActivityType    string    `gorm:"type:VARBINARY(32);index;" json:"Type" yaml:"Type"`
+	UserUID         string    `gorm:"type:VARBINARY(42);index;" json:"UserUID" yaml:"UserUID,omitempty"`
+	EntityUID       string    `gorm:"type:VARBINARY(42);" json:"EntityUID" yaml:"EntityUID,omitempty"`
+	ActivityMessage string    `gorm:"type:VARCHAR(512);" json:"Message" yaml:"Message"`
+	CreatedAt       time.Time `sql:"index" json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// TableName returns the entity database table name.
+func (Activity) TableName() string {
+	return "activities"
+}
+
+// NewActivity returns a new activity entry.
+func NewActivity(activityType, userUID, entityUID, message string) *Activity {
+	return &Activity{
+		ActivityType:    activityType,
+		UserUID:         userUID,
+		EntityUID:       entityUID,
+		ActivityMessage: txt.Clip(message, 512),
+		CreatedAt:       TimeStamp(),
+	}
+}
+
+// Create inserts a new activity entry into the database.
+func (m *Activity) Create() error {
+	return Db().Create(m).Error
+}
+
+// DeleteActivities removes activity entries created before the given time and returns the number of deleted entries.
+func DeleteActivities(before time.Time) (int, error) {
+	res := UnscopedDb().Where("created_at < ?", before).Delete(Activity{})
+
+	return int(res.RowsAffected), res.Error
+}
+
+// ActivityFromEvent returns the activity entry for an event, if it should be recorded.
+func ActivityFromEvent(msg event.Message) (m *Activity, ok bool) {
+	str := func(name string) string {
+		if val, ok := msg.Fields[name]; ok && val != nil {
+			return fmt.Sprint(val)
+		}
+
+		return ""
+	}
+
+	switch msg.Name {
+	case "import.completed":
+		m = NewActivity(ActivityImport, str("user"), "", fmt.Sprintf("Import completed in %s s", str("seconds")))
+	case "notify.share":
+		m = NewActivity(ActivityShare, str("user"), str("uid"), fmt.Sprintf("%s entries added to %s", str("count"), str("album")))
+	case "activity.album":
+		m = NewActivity(ActivityAlbum, str("user"), str("uid"), fmt.Sprintf("Album %s created", str("title")))
+	case "activity.person":
+		m = NewActivity(ActivityPerson, str("user"), str("uid"), fmt.Sprintf("%s named", str("name")))
+	default:
+		return nil, false
+	}
+
+	return m, true
+}
+
+// SaveActivities subscribes to high-level events and stores them in the activities table.
+func SaveActivities() {
+	s := event.Subscribe(activityTopics...)
+
+	defer func() {
+		event.Unsubscribe(s)
+	}()
+
+	for msg := range s.Receiver {
+		if m, ok := ActivityFromEvent(msg); !ok {
+			continue
+		} else if err := m.Create(); err != nil {
+			log.Errorf("activity: %s (create)", err)
+		}
+	}
+}
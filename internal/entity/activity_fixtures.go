@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+type ActivityMap map[string]Activity
+
+func (m ActivityMap) Get(name string) Activity {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return Activity{}
+}
+
+func (m ActivityMap) Pointer(name string) *Activity {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &Activity{}
+}
+
+var ActivityFixtures = ActivityMap{
+	"import": {
+		ID:              1000000,
+		ActivityType:    ActivityImport,
+		UserUID:         "uqxetse3cy5eo9z2",
+		ActivityMessage: "Import completed in 12 s",
+		CreatedAt:       time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+	"album": {
+		ID:              1000001,
+		ActivityType:    ActivityAlbum,
+		UserUID:         "uqxetse3cy5eo9z2",
+		EntityUID:       "at9lxuqxpogaaba7",
+		ActivityMessage: "Album Christmas 2030 created",
+		CreatedAt:       time.Date(2020, 3, 7, 8, 16, 20, 0, time.UTC),
+	},
+}
+
+// CreateActivityFixtures inserts known entities into the database for testing.
+func CreateActivityFixtures() {
+	for _, entity := range ActivityFixtures {
+		Db().Create(&entity)
+	}
+}
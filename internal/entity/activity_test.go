@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+func TestNewActivity(t *testing.T) {
+	m := NewActivity(ActivityAlbum, "uqxetse3cy5eo9z2", "at9lxuqxpogaaba7", "Album Holiday created")
+
+	assert.Equal(t, ActivityAlbum, m.ActivityType)
+	assert.Equal(t, "uqxetse3cy5eo9z2", m.UserUID)
+	assert.Equal(t, "at9lxuqxpogaaba7", m.EntityUID)
+	assert.Equal(t, "Album Holiday created", m.ActivityMessage)
+	assert.False(t, m.CreatedAt.IsZero())
+}
+
+func TestActivity_Create(t *testing.T) {
+	m := NewActivity(ActivityPerson, "uqxetse3cy5eo9z2", "jqy1y111h1njaaac", "Jane named")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, m.ID)
+}
+
+func TestDeleteActivities(t *testing.T) {
+	expired := NewActivity(ActivityAlbum, "uqxetse3cy5eo9z2", "at9lxuqxpogaaba7", "Album Expired created")
+	expired.CreatedAt = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := NewActivity(ActivityAlbum, "uqxetse3cy5eo9z2", "at9lxuqxpogaaba7", "Album Recent created")
+	recent.CreatedAt = time.Date(2001, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, m := range []*Activity{expired, recent} {
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	n, err := DeleteActivities(time.Date(2001, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Error(t, Db().First(&Activity{}, expired.ID).Error)
+	assert.NoError(t, Db().First(&Activity{}, recent.ID).Error)
+
+	assert.NoError(t, Db().Delete(recent).Error)
+}
+
+func TestActivityFromEvent(t *testing.T) {
+	t.Run("Import", func(t *testing.T) {
+		m, ok := ActivityFromEvent(event.Message{Name: "import.completed", Fields: event.Data{"seconds": 5, "user": "uqxetse3cy5eo9z2"}})
+		assert.True(t, ok)
+		assert.Equal(t, ActivityImport, m.ActivityType)
+		assert.Equal(t, "uqxetse3cy5eo9z2", m.UserUID)
+		assert.Equal(t, "Import completed in 5 s", m.ActivityMessage)
+	})
+	t.Run("Share", func(t *testing.T) {
+		m, ok := ActivityFromEvent(event.Message{Name: "notify.share", Fields: event.Data{"uid": "at9lxuqxpogaaba7", "album": "Holiday", "count": 3}})
+		assert.True(t, ok)
+		assert.Equal(t, ActivityShare, m.ActivityType)
+		assert.Equal(t, "at9lxuqxpogaaba7", m.EntityUID)
+		assert.Equal(t, "3 entries added to Holiday", m.ActivityMessage)
+	})
+	t.Run("Album", func(t *testing.T) {
+		m, ok := ActivityFromEvent(event.Message{Name: "activity.album", Fields: event.Data{"uid": "at9lxuqxpogaaba7", "title": "Holiday"}})
+		assert.True(t, ok)
+		assert.Equal(t, ActivityAlbum, m.ActivityType)
+		assert.Equal(t, "Album Holiday created", m.ActivityMessage)
+	})
+	t.Run("Person", func(t *testing.T) {
+		m, ok := ActivityFromEvent(event.Message{Name: "activity.person", Fields: event.Data{"uid": "jqy1y111h1njaaac", "name": "Jane"}})
+		assert.True(t, ok)
+		assert.Equal(t, ActivityPerson, m.ActivityType)
+		assert.Equal(t, "", m.UserUID)
+		assert.Equal(t, "Jane named", m.ActivityMessage)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, ok := ActivityFromEvent(event.Message{Name: "index.completed"})
+		assert.False(t, ok)
+	})
+}
@@ -29,6 +29,26 @@ const (
 
 type Albums []Album
 
+// UIDs returns the album UIDs.
+func (m Albums) UIDs() (result []string) {
+	for _, a := range m {
+		result = append(result, a.AlbumUID)
+	}
+
+	return result
+}
+
+// Locked tests if at least one of the albums is locked.
+func (m Albums) Locked() bool {
+	for _, a := range m {
+		if a.AlbumLocked {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Album represents a photo album
 type Album struct {
 	ID               uint        `gorm:"primary_key" json:"ID" yaml:"-"`
@@ -53,12 +73,18 @@ type Album struct {
 	AlbumDay         int         `gorm:"index:idx_albums_ymd;" json:"Day" yaml:"Day,omitempty"`
 	AlbumFavorite    bool        `json:"Favorite" yaml:"Favorite,omitempty"`
 	AlbumPrivate     bool        `json:"Private" yaml:"Private,omitempty"`
+	AlbumGuests      bool        `json:"Guests" yaml:"Guests,omitempty"`
+	AlbumLocked      bool        `json:"Locked" yaml:"Locked,omitempty"`
 	Thumb            string      `gorm:"type:VARBINARY(128);index;default:'';" json:"Thumb" yaml:"Thumb,omitempty"`
 	ThumbSrc         string      `gorm:"type:VARBINARY(8);default:'';" json:"ThumbSrc,omitempty" yaml:"ThumbSrc,omitempty"`
 	CreatedAt        time.Time   `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
 	UpdatedAt        time.Time   `json:"UpdatedAt" yaml:"UpdatedAt,omitempty"`
 	DeletedAt        *time.Time  `sql:"index" json:"DeletedAt" yaml:"DeletedAt,omitempty"`
 	Photos           PhotoAlbums `gorm:"foreignkey:AlbumUID;association_foreignkey:AlbumUID;" json:"-" yaml:"Photos,omitempty"`
+
+	// Members and share links are only included in YAML backups.
+	Members AlbumMembers `gorm:"-" json:"-" yaml:"Members,omitempty"`
+	Shares  Links        `gorm:"-" json:"-" yaml:"Links,omitempty"`
 }
 
 // TableName returns the entity database table name.
@@ -474,11 +500,46 @@ func (m *Album) Update(attr string, value interface{}) error {
 	return UnscopedDb().Model(m).UpdateColumn(attr, value).Error
 }
 
+// SetLocked updates the lock flag of an album, locked albums can't be changed or deleted.
+func (m *Album) SetLocked(locked bool) error {
+	m.AlbumLocked = locked
+
+	return m.Update("AlbumLocked", m.AlbumLocked)
+}
+
 // Updates multiple columns in the database.
 func (m *Album) Updates(values interface{}) error {
 	return UnscopedDb().Model(m).UpdateColumns(values).Error
 }
 
+// SetCover uses a photo of the album as cover, so that the cover is no longer selected automatically.
+func (m *Album) SetCover(photoUID string) error {
+	var entry PhotoAlbum
+
+	if err := Db().Where("album_uid = ? AND photo_uid = ? AND hidden = 0 AND missing = 0", m.AlbumUID, photoUID).
+		First(&entry).Error; err != nil {
+		return fmt.Errorf("photo %s is not in album %s", sanitize.Log(photoUID), m.AlbumUID)
+	}
+
+	file, err := PrimaryFile(photoUID)
+
+	if err != nil || file.FileHash == "" {
+		return fmt.Errorf("photo %s has no primary file", sanitize.Log(photoUID))
+	}
+
+	m.Thumb = file.FileHash
+	m.ThumbSrc = SrcManual
+
+	return m.Updates(Values{"Thumb": m.Thumb, "ThumbSrc": m.ThumbSrc})
+}
+
+// ResetCover allows the album cover to be selected automatically again.
+func (m *Album) ResetCover() error {
+	m.ThumbSrc = SrcAuto
+
+	return m.Update("ThumbSrc", m.ThumbSrc)
+}
+
 // UpdateFolder updates the path, filter and slug for a folder album.
 func (m *Album) UpdateFolder(albumPath, albumFilter string) error {
 	albumPath = strings.Trim(albumPath, string(os.PathSeparator))
@@ -494,7 +555,7 @@ func (m *Album) UpdateFolder(albumPath, albumFilter string) error {
 		"AlbumSlug":   albumSlug,
 	}).Error; err != nil {
 		return err
-	} else if err := UnscopedDb().Exec("UPDATE albums SET album_path = NULL WHERE album_path = ? AND id <> ?", albumPath, m.ID).Error; err != nil {
+	} else if err := Exec("UPDATE albums SET album_path = NULL WHERE album_path = ? AND id <> ?", albumPath, m.ID).Error; err != nil {
 		return err
 	}
 
@@ -556,6 +617,10 @@ func (m *Album) DeletePermanently() error {
 		return err
 	}
 
+	if err := AddTombstones(TombAlbum, m.AlbumUID, []string{m.AlbumUID}); err != nil {
+		log.Errorf("album: %s (add tombstone)", err)
+	}
+
 	if !wasDeleted {
 		m.PublishCountChange(-1)
 	}
@@ -635,3 +700,14 @@ func (m *Album) RemovePhotos(UIDs []string) (removed PhotoAlbums) {
 func (m *Album) Links() Links {
 	return FindLinks("", m.AlbumUID)
 }
+
+// HasMembers tests if users have been invited to the album.
+func (m *Album) HasMembers() bool {
+	var count int
+
+	if err := Db().Model(&AlbumMember{}).Where("album_uid = ?", m.AlbumUID).Count(&count).Error; err != nil {
+		return false
+	}
+
+	return count > 0
+}
@@ -0,0 +1,223 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+)
+
+// AddPhotosToAlbums adds photos to multiple albums in a single transaction, so that either all
+// or none of the albums are changed.
+func AddPhotosToAlbums(albumUIDs, photoUIDs []string) (added PhotoAlbums, err error) {
+	err = Db().Transaction(func(tx *gorm.DB) error {
+		added = PhotoAlbums{}
+
+		for _, albumUID := range albumUIDs {
+			for _, photoUID := range photoUIDs {
+				entry := PhotoAlbum{AlbumUID: albumUID, PhotoUID: photoUID, Hidden: false}
+
+				if err := tx.Save(&entry).Error; err != nil {
+					return err
+				}
+
+				added = append(added, entry)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return PhotoAlbums{}, err
+	}
+
+	return added, nil
+}
+
+// RemovePhotosFromAlbums removes photos from multiple albums in a single transaction, so that
+// either all or none of the albums are changed.
+func RemovePhotosFromAlbums(albumUIDs, photoUIDs []string) (removed PhotoAlbums, err error) {
+	err = Db().Transaction(func(tx *gorm.DB) error {
+		removed = PhotoAlbums{}
+
+		for _, albumUID := range albumUIDs {
+			for _, photoUID := range photoUIDs {
+				entry := PhotoAlbum{AlbumUID: albumUID, PhotoUID: photoUID, Hidden: true}
+
+				if err := tx.Save(&entry).Error; err != nil {
+					return err
+				}
+
+				removed = append(removed, entry)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return PhotoAlbums{}, err
+	}
+
+	return removed, nil
+}
+
+// albumEntries returns the visible entries of a manually created album.
+func albumEntries(tx *gorm.DB, albumUID string) (entries PhotoAlbums, err error) {
+	err = tx.Where("album_uid = ? AND hidden = 0", albumUID).Order("created_at, photo_uid").Find(&entries).Error
+
+	return entries, err
+}
+
+// Clone creates a copy of a manually created album with the same pictures in a single transaction.
+func (m *Album) Clone(title string) (clone *Album, err error) {
+	if !m.IsDefault() {
+		return nil, fmt.Errorf("album: only manually created albums can be cloned")
+	}
+
+	err = Db().Transaction(func(tx *gorm.DB) error {
+		clone, err = m.clone(tx, title)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	clone.PublishCountChange(1)
+
+	return clone, nil
+}
+
+// CloneAlbums creates copies of multiple manually created albums in a single transaction, so that
+// either all or none of the copies are created.
+func CloneAlbums(albums Albums) (clones Albums, err error) {
+	for _, a := range albums {
+		if !a.IsDefault() {
+			return Albums{}, fmt.Errorf("album: only manually created albums can be cloned")
+		}
+	}
+
+	err = Db().Transaction(func(tx *gorm.DB) error {
+		clones = Albums{}
+
+		for i := range albums {
+			clone, err := albums[i].clone(tx, "")
+
+			if err != nil {
+				return err
+			}
+
+			clones = append(clones, *clone)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return Albums{}, err
+	}
+
+	for i := range clones {
+		clones[i].PublishCountChange(1)
+	}
+
+	return clones, nil
+}
+
+// clone creates a copy of the album with the same pictures using the transaction provided.
+func (m *Album) clone(tx *gorm.DB, title string) (clone *Album, err error) {
+	if title == "" {
+		title = fmt.Sprintf("%s (Copy)", m.AlbumTitle)
+	}
+
+	clone = NewAlbum(title, AlbumDefault)
+	clone.AlbumLocation = m.AlbumLocation
+	clone.AlbumCategory = m.AlbumCategory
+	clone.AlbumCaption = m.AlbumCaption
+	clone.AlbumDescription = m.AlbumDescription
+	clone.AlbumNotes = m.AlbumNotes
+	clone.AlbumOrder = m.AlbumOrder
+	clone.AlbumTemplate = m.AlbumTemplate
+	clone.AlbumPrivate = m.AlbumPrivate
+	clone.Thumb = m.Thumb
+	clone.ThumbSrc = m.ThumbSrc
+
+	entries, err := albumEntries(tx, m.AlbumUID)
+
+	if err != nil {
+		return nil, err
+	} else if err = tx.Create(clone).Error; err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		entry.AlbumUID = clone.AlbumUID
+
+		if err = tx.Create(&entry).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return clone, nil
+}
+
+// Merge moves the pictures of other manually created albums to this album and deletes them
+// afterwards in a single transaction. Share links of the merged albums are removed as well.
+func (m *Album) Merge(others Albums) (added PhotoAlbums, err error) {
+	if !m.IsDefault() {
+		return PhotoAlbums{}, fmt.Errorf("album: only manually created albums can be merged")
+	}
+
+	for _, other := range others {
+		if !other.IsDefault() {
+			return PhotoAlbums{}, fmt.Errorf("album: only manually created albums can be merged")
+		} else if other.AlbumUID == m.AlbumUID {
+			return PhotoAlbums{}, fmt.Errorf("album: cannot merge %s with itself", m)
+		}
+	}
+
+	err = Db().Transaction(func(tx *gorm.DB) error {
+		added = PhotoAlbums{}
+
+		for i := range others {
+			entries, err := albumEntries(tx, others[i].AlbumUID)
+
+			if err != nil {
+				return err
+			}
+
+			for _, entry := range entries {
+				entry.AlbumUID = m.AlbumUID
+				entry.Hidden = false
+
+				if err := tx.Save(&entry).Error; err != nil {
+					return err
+				}
+
+				added = append(added, entry)
+			}
+
+			if err := tx.Delete(&others[i]).Error; err != nil {
+				return err
+			} else if err := tx.Delete(&Link{}, "share_uid = ?", others[i].AlbumUID).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return PhotoAlbums{}, err
+	}
+
+	deletedAt := TimeStamp()
+
+	for i := range others {
+		others[i].DeletedAt = &deletedAt
+		others[i].PublishCountChange(-1)
+	}
+
+	return added, nil
+}
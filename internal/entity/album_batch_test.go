@@ -0,0 +1,172 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddPhotosToAlbums(t *testing.T) {
+	first := NewAlbum("Batch Add First", AlbumDefault)
+	second := NewAlbum("Batch Add Second", AlbumDefault)
+
+	for _, a := range []*Album{first, second} {
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	photos := []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}
+
+	added, err := AddPhotosToAlbums([]string{first.AlbumUID, second.AlbumUID}, photos)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, added, 4)
+
+	removed, err := RemovePhotosFromAlbums([]string{first.AlbumUID, second.AlbumUID}, photos[:1])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, removed, 2)
+
+	var count int
+
+	Db().Model(&PhotoAlbum{}).Where("album_uid = ? AND hidden = 0", second.AlbumUID).Count(&count)
+
+	assert.Equal(t, 1, count)
+}
+
+func TestAlbum_Clone(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		a := NewAlbum("Clone Source", AlbumDefault)
+		a.AlbumDescription = "To be cloned"
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		a.AddPhotos([]string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"})
+		a.RemovePhotos([]string{"pt9jtdre2lvl0yh8"})
+
+		clone, err := a.Clone("")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, a.AlbumUID, clone.AlbumUID)
+		assert.Equal(t, "Clone Source (Copy)", clone.AlbumTitle)
+		assert.Equal(t, "To be cloned", clone.AlbumDescription)
+
+		var entries PhotoAlbums
+
+		Db().Where("album_uid = ?", clone.AlbumUID).Find(&entries)
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh7"}, entries.UIDs())
+	})
+	t.Run("Moment", func(t *testing.T) {
+		a := NewMomentsAlbum("Clone Moment", "clone-moment", "public:true")
+
+		_, err := a.Clone("")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCloneAlbums(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		a := NewAlbum("Clone Batch A", AlbumDefault)
+		b := NewAlbum("Clone Batch B", AlbumDefault)
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		} else if err = b.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		a.AddPhotos([]string{"pt9jtdre2lvl0yh7"})
+
+		clones, err := CloneAlbums(Albums{*a, *b})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, clones, 2)
+		assert.Equal(t, "Clone Batch A (Copy)", clones[0].AlbumTitle)
+		assert.Equal(t, "Clone Batch B (Copy)", clones[1].AlbumTitle)
+
+		var entries PhotoAlbums
+
+		Db().Where("album_uid = ?", clones[0].AlbumUID).Find(&entries)
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh7"}, entries.UIDs())
+	})
+	t.Run("Moment", func(t *testing.T) {
+		a := NewAlbum("Clone Batch Default", AlbumDefault)
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := CloneAlbums(Albums{*a, *NewMomentsAlbum("Clone Batch Moment", "clone-batch-moment", "public:true")})
+
+		assert.Error(t, err)
+
+		var count int
+
+		Db().Model(Album{}).Where("album_title = ?", "Clone Batch Default (Copy)").Count(&count)
+
+		assert.Equal(t, 0, count)
+	})
+}
+
+func TestAlbum_Merge(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		target := NewAlbum("Merge Target", AlbumDefault)
+		source := NewAlbum("Merge Source", AlbumDefault)
+
+		for _, a := range []*Album{target, source} {
+			if err := a.Create(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		target.AddPhotos([]string{"pt9jtdre2lvl0yh7"})
+		source.AddPhotos([]string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"})
+
+		link := NewLink(source.AlbumUID, false, false)
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		added, err := target.Merge(Albums{*source})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, added, 2)
+
+		var count int
+
+		Db().Model(&PhotoAlbum{}).Where("album_uid = ? AND hidden = 0", target.AlbumUID).Count(&count)
+		assert.Equal(t, 2, count)
+
+		assert.Error(t, Db().First(&Album{}, "album_uid = ?", source.AlbumUID).Error)
+		assert.Empty(t, FindLinks("", source.AlbumUID))
+	})
+	t.Run("Self", func(t *testing.T) {
+		target := AlbumFixtures.Get("berlin-2019")
+
+		_, err := target.Merge(Albums{target})
+
+		assert.Error(t, err)
+	})
+}
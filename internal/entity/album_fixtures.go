@@ -69,6 +69,7 @@ var AlbumFixtures = AlbumMap{
 		AlbumDay:         0,
 		AlbumFavorite:    true,
 		AlbumPrivate:     false,
+		AlbumGuests:      true,
 		CreatedAt:        time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC),
 		UpdatedAt:        time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
 		DeletedAt:        nil,
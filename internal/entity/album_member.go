@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/acl"
+)
+
+// Album member roles.
+const (
+	MemberOwner       = "owner"
+	MemberContributor = "contributor"
+	MemberViewer      = "viewer"
+)
+
+// MemberRoles maps valid album member roles.
+var MemberRoles = map[string]bool{
+	MemberOwner:       true,
+	MemberContributor: true,
+	MemberViewer:      true,
+}
+
+type AlbumMembers []AlbumMember
+
+// AlbumMember represents a user who has been invited to an album, and tracks the photos they added.
+type AlbumMember struct {
+	AlbumUID   string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"AlbumUID" yaml:"-"`
+	UserUID    string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index" json:"UserUID" yaml:"UserUID"`
+	MemberRole string     `gorm:"type:VARBINARY(32);" json:"Role" yaml:"Role"`
+	InvitedBy  string     `gorm:"type:VARBINARY(42);" json:"InvitedBy" yaml:"InvitedBy,omitempty"`
+	PhotoCount int        `json:"PhotoCount" yaml:"PhotoCount,omitempty"`
+	AddedAt    *time.Time `json:"AddedAt" yaml:"AddedAt,omitempty"`
+	CreatedAt  time.Time  `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
+	UpdatedAt  time.Time  `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (AlbumMember) TableName() string {
+	return "albums_members"
+}
+
+// NewAlbumMember returns a new album member with the given role.
+func NewAlbumMember(albumUID, userUID, role string) *AlbumMember {
+	return &AlbumMember{
+		AlbumUID:   albumUID,
+		UserUID:    userUID,
+		MemberRole: role,
+	}
+}
+
+// FindAlbumMember returns the membership of a user in an album, or nil if the user is not a member.
+func FindAlbumMember(albumUID, userUID string) *AlbumMember {
+	if albumUID == "" || userUID == "" {
+		return nil
+	}
+
+	result := AlbumMember{}
+
+	if err := Db().Where("album_uid = ? AND user_uid = ?", albumUID, userUID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Validate checks the album, user and role of the member.
+func (m *AlbumMember) Validate() error {
+	if m.AlbumUID == "" {
+		return fmt.Errorf("album uid must not be empty")
+	} else if m.UserUID == "" {
+		return fmt.Errorf("user uid must not be empty")
+	} else if !MemberRoles[m.MemberRole] {
+		return fmt.Errorf("invalid member role %s", m.MemberRole)
+	}
+
+	return nil
+}
+
+// Create inserts a new row to the database.
+func (m *AlbumMember) Create() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	return Db().Create(m).Error
+}
+
+// Save updates or inserts a row.
+func (m *AlbumMember) Save() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	return Db().Save(m).Error
+}
+
+// Delete removes the member from the album.
+func (m *AlbumMember) Delete() error {
+	return Db().Where("album_uid = ? AND user_uid = ?", m.AlbumUID, m.UserUID).Delete(&AlbumMember{}).Error
+}
+
+// Owner tests if the member may manage the album and its members.
+func (m *AlbumMember) Owner() bool {
+	return m.MemberRole == MemberOwner
+}
+
+// CanAdd tests if the member may add photos to the album.
+func (m *AlbumMember) CanAdd() bool {
+	return m.MemberRole == MemberOwner || m.MemberRole == MemberContributor
+}
+
+// Role returns the member role for ACL permission checks.
+func (m *AlbumMember) Role() acl.Role {
+	switch m.MemberRole {
+	case MemberOwner:
+		return acl.RoleOwner
+	case MemberContributor:
+		return acl.RoleContributor
+	case MemberViewer:
+		return acl.RoleViewer
+	default:
+		return ""
+	}
+}
+
+// Allow tests if the member role permits an action on the album resource.
+func (m *AlbumMember) Allow(resource acl.Resource, action acl.Action) bool {
+	role := m.Role()
+
+	if role == "" {
+		return false
+	}
+
+	return acl.Permissions.Allow(resource, role, action)
+}
+
+// AddedPhotos records that the member added photos to the album.
+func (m *AlbumMember) AddedPhotos(count int) error {
+	if count <= 0 {
+		return nil
+	}
+
+	addedAt := TimeStamp()
+
+	m.PhotoCount += count
+	m.AddedAt = &addedAt
+
+	return Db().Model(m).Updates(Values{"PhotoCount": m.PhotoCount, "AddedAt": m.AddedAt}).Error
+}
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+type AlbumMemberMap map[string]AlbumMember
+
+func (m AlbumMemberMap) Get(name string) AlbumMember {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return AlbumMember{}
+}
+
+func (m AlbumMemberMap) Pointer(name string) *AlbumMember {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &AlbumMember{}
+}
+
+var AlbumMemberFixtures = AlbumMemberMap{
+	"holiday-2030-bob": {
+		AlbumUID:   "at9lxuqxpogaaba8",
+		UserUID:    "uqxc08w3d0ej2283",
+		MemberRole: MemberContributor,
+		InvitedBy:  "uqxetse3cy5eo9z2",
+		PhotoCount: 1,
+		CreatedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:  time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+	},
+	"berlin-2019-bob": {
+		AlbumUID:   "at9lxuqxpogaaba9",
+		UserUID:    "uqxc08w3d0ej2283",
+		MemberRole: MemberViewer,
+		InvitedBy:  "uqxetse3cy5eo9z2",
+		CreatedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:  time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+	},
+}
+
+// CreateAlbumMemberFixtures inserts known entities into the database for testing.
+func CreateAlbumMemberFixtures() {
+	for _, entity := range AlbumMemberFixtures {
+		Db().Create(&entity)
+	}
+}
@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+)
+
+func TestAlbumMember_TableName(t *testing.T) {
+	assert.Equal(t, "albums_members", AlbumMember{}.TableName())
+}
+
+func TestFindAlbumMember(t *testing.T) {
+	t.Run("Contributor", func(t *testing.T) {
+		m := FindAlbumMember("at9lxuqxpogaaba8", "uqxc08w3d0ej2283")
+
+		if m == nil {
+			t.Fatal("member should not be nil")
+		}
+
+		assert.Equal(t, MemberContributor, m.MemberRole)
+		assert.True(t, m.CanAdd())
+		assert.False(t, m.Owner())
+	})
+	t.Run("Viewer", func(t *testing.T) {
+		m := FindAlbumMember("at9lxuqxpogaaba9", "uqxc08w3d0ej2283")
+
+		if m == nil {
+			t.Fatal("member should not be nil")
+		}
+
+		assert.False(t, m.CanAdd())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindAlbumMember("at9lxuqxpogaaba8", "uqxetse3cy5eo9z2"))
+		assert.Nil(t, FindAlbumMember("", "uqxc08w3d0ej2283"))
+	})
+}
+
+func TestAlbumMember_Create(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := NewAlbumMember("at9lxuqxpogaaba8", "uqxqg7i1kperxvu7", MemberOwner)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, FindAlbumMember("at9lxuqxpogaaba8", "uqxqg7i1kperxvu7").Owner())
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindAlbumMember("at9lxuqxpogaaba8", "uqxqg7i1kperxvu7"))
+	})
+	t.Run("InvalidRole", func(t *testing.T) {
+		assert.Error(t, NewAlbumMember("at9lxuqxpogaaba8", "uqxqg7i1kperxvu7", "admin").Create())
+	})
+	t.Run("NoUser", func(t *testing.T) {
+		assert.Error(t, NewAlbumMember("at9lxuqxpogaaba8", "", MemberViewer).Save())
+	})
+}
+
+func TestAlbumMember_AddedPhotos(t *testing.T) {
+	m := NewAlbumMember("at9lxuqxpogaaba9", "uqxetse3cy5eo9z2", MemberContributor)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.AddedPhotos(3); err != nil {
+		t.Fatal(err)
+	}
+
+	result := FindAlbumMember("at9lxuqxpogaaba9", "uqxetse3cy5eo9z2")
+
+	assert.Equal(t, 3, result.PhotoCount)
+	assert.NotNil(t, result.AddedAt)
+
+	assert.NoError(t, m.AddedPhotos(0))
+	assert.NoError(t, m.Delete())
+}
+
+func TestAlbumMember_Allow(t *testing.T) {
+	owner := NewAlbumMember("at9lxuqxpogaaba8", "uqxc08w3d0ej2283", MemberOwner)
+	contributor := NewAlbumMember("at9lxuqxpogaaba8", "uqxc08w3d0ej2283", MemberContributor)
+	viewer := NewAlbumMember("at9lxuqxpogaaba8", "uqxc08w3d0ej2283", MemberViewer)
+
+	assert.True(t, viewer.Allow(acl.ResourceAlbums, acl.ActionRead))
+	assert.False(t, viewer.Allow(acl.ResourceAlbums, acl.ActionUpdate))
+	assert.False(t, viewer.Allow(acl.ResourceAlbums, acl.ActionUpload))
+	assert.True(t, viewer.Allow(acl.ResourceMembers, acl.ActionRead))
+	assert.True(t, contributor.Allow(acl.ResourceAlbums, acl.ActionUpdate))
+	assert.True(t, contributor.Allow(acl.ResourceAlbums, acl.ActionUpload))
+	assert.False(t, contributor.Allow(acl.ResourceAlbums, acl.ActionDelete))
+	assert.False(t, contributor.Allow(acl.ResourceMembers, acl.ActionShare))
+	assert.True(t, owner.Allow(acl.ResourceMembers, acl.ActionShare))
+	assert.False(t, owner.Allow(acl.ResourcePhotos, acl.ActionRead))
+	assert.False(t, NewAlbumMember("at9lxuqxpogaaba8", "uqxc08w3d0ej2283", "").Allow(acl.ResourceAlbums, acl.ActionRead))
+}
@@ -391,6 +391,40 @@ func TestAlbum_Update(t *testing.T) {
 	})
 }
 
+func TestAlbum_SetCover(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		album := AlbumFixtures.Get("berlin-2019")
+
+		if err := album.SetCover("pt9jtdre2lvl0y11"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, SrcManual, album.ThumbSrc)
+		assert.Equal(t, "pcad9168fa6acc5c5c2965ddf6ec465ca42fd818", album.Thumb)
+
+		var found Album
+
+		if err := UnscopedDb().Where("album_uid = ?", album.AlbumUID).First(&found).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, album.Thumb, found.Thumb)
+		assert.Equal(t, SrcManual, found.ThumbSrc)
+
+		if err := album.ResetCover(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, SrcAuto, album.ThumbSrc)
+	})
+	t.Run("NotInAlbum", func(t *testing.T) {
+		album := AlbumFixtures.Get("berlin-2019")
+
+		assert.Error(t, album.SetCover("pt9jtdre2lvl0yh7"))
+		assert.Equal(t, SrcAuto, album.ThumbSrc)
+	})
+}
+
 func TestAlbum_Save(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		album := NewStateAlbum("Dogs", "dogs", "label:dog")
@@ -477,6 +511,17 @@ func TestAlbum_Links(t *testing.T) {
 	})
 }
 
+func TestAlbum_HasMembers(t *testing.T) {
+	t.Run("Shared", func(t *testing.T) {
+		album := AlbumFixtures.Get("berlin-2019")
+		assert.True(t, album.HasMembers())
+	})
+	t.Run("Private", func(t *testing.T) {
+		album := AlbumFixtures.Get("christmas2030")
+		assert.False(t, album.HasMembers())
+	})
+}
+
 func TestAlbum_AddPhotos(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		album := Album{
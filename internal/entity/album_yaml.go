@@ -1,29 +1,166 @@
 package entity
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
 
-	"github.com/photoprism/photoprism/pkg/fs"
 	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
 var albumYamlMutex = sync.Mutex{}
 
-// Yaml returns album data as YAML string.
+// Yaml returns album data as YAML string, see Backup for adding photos, members, and share links.
 func (m *Album) Yaml() (out []byte, err error) {
-	if err := Db().Model(m).Association("Photos").Find(&m.Photos).Error; err != nil {
-		log.Errorf("album: %s (yaml)", err)
-		return out, err
+	return yaml.Marshal(m)
+}
+
+// Backup returns a copy of the album with the photos, members, and share links that are saved in YAML backups.
+func (m *Album) Backup() (result *Album, err error) {
+	backup := *m
+	backup.Photos = nil
+	backup.Members = nil
+
+	if err = Db().Model(m).Association("Photos").Find(&backup.Photos).Error; err != nil {
+		return nil, fmt.Errorf("%s (backup photos)", err)
 	}
 
-	return yaml.Marshal(m)
+	// Add file hashes, so that photos can be found after their UID has changed.
+	if len(backup.Photos) > 0 {
+		hashes, err := PhotoHashes(backup.Photos.UIDs())
+
+		if err != nil {
+			return nil, fmt.Errorf("%s (backup hashes)", err)
+		}
+
+		for i := range backup.Photos {
+			backup.Photos[i].Hash = hashes[backup.Photos[i].PhotoUID]
+		}
+	}
+
+	if err = Db().Where("album_uid = ?", m.AlbumUID).Order("user_uid").Find(&backup.Members).Error; err != nil {
+		return nil, fmt.Errorf("%s (backup members)", err)
+	}
+
+	// Passwords are included as hash, so that protected links stay protected when restored.
+	backup.Shares = m.Links()
+
+	for i := range backup.Shares {
+		if !backup.Shares[i].HasPassword {
+			continue
+		} else if pw := FindPassword(backup.Shares[i].LinkUID); pw != nil {
+			backup.Shares[i].PasswordHash = pw.Hash
+		}
+	}
+
+	return &backup, nil
+}
+
+// PhotoHashes returns the primary file hashes of the photos with the given UIDs.
+func PhotoHashes(photoUIDs []string) (result map[string]string, err error) {
+	result = make(map[string]string, len(photoUIDs))
+
+	if len(photoUIDs) == 0 {
+		return result, nil
+	}
+
+	var files Files
+
+	if err = Db().Where("photo_uid IN (?) AND file_primary = 1", photoUIDs).Find(&files).Error; err != nil {
+		return result, err
+	}
+
+	for _, f := range files {
+		result[f.PhotoUID] = f.FileHash
+	}
+
+	return result, nil
+}
+
+// RelinkPhotos updates the photos of an album loaded from a YAML file, so that they refer to existing
+// photos. Photos that are not found by UID are searched by file hash, otherwise the UID is kept, as it
+// may be restored from a sidecar file when indexing. Returns the number of photos not found.
+func (m *Album) RelinkPhotos() (missing int) {
+	photos := make(PhotoAlbums, 0, len(m.Photos))
+	found := make(map[string]bool, len(m.Photos))
+
+	for _, p := range m.Photos {
+		p.AlbumUID = m.AlbumUID
+
+		if p.PhotoUID != "" && Db().Where("photo_uid = ?", p.PhotoUID).First(&Photo{}).Error == nil {
+			// Found by UID.
+		} else if p.Hash == "" {
+			missing++
+		} else if f, err := FirstFileByHash(p.Hash); err == nil && f.PhotoUID != "" {
+			log.Debugf("album: found photo %s by hash", sanitize.Log(f.PhotoUID))
+			p.PhotoUID = f.PhotoUID
+		} else {
+			missing++
+		}
+
+		if p.PhotoUID != "" && !found[p.PhotoUID] {
+			found[p.PhotoUID] = true
+			photos = append(photos, p)
+		}
+	}
+
+	m.Photos = photos
+
+	return missing
+}
+
+// RestoreShares adds the members and share links of an album loaded from a YAML file, if they don't exist yet.
+func (m *Album) RestoreShares() (err error) {
+	for _, member := range m.Members {
+		member.AlbumUID = m.AlbumUID
+
+		if FindAlbumMember(member.AlbumUID, member.UserUID) != nil {
+			continue
+		} else if FindUserByUID(member.UserUID) == nil {
+			log.Debugf("album: member %s not found", sanitize.Log(member.UserUID))
+			continue
+		} else if err = member.Create(); err != nil {
+			return err
+		}
+	}
+
+	for _, link := range m.Shares {
+		link.ShareUID = m.AlbumUID
+
+		if len(FindLinks(link.LinkToken, link.ShareUID)) > 0 {
+			continue
+		} else if link.HasPassword && link.PasswordHash == "" {
+			// Protected links must not be restored without their password.
+			log.Warnf("album: skipped link without password hash in %s", sanitize.Log(m.AlbumUID))
+			continue
+		} else if err = Db().Create(&link).Error; err != nil {
+			return err
+		} else if link.HasPassword {
+			pw := Password{UID: link.LinkUID, Hash: link.PasswordHash}
+
+			if err = pw.Save(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
 // SaveAsYaml saves album data as YAML file.
 func (m *Album) SaveAsYaml(fileName string) error {
-	data, err := m.Yaml()
+	backup, err := m.Backup()
+
+	if err != nil {
+		log.Errorf("album: %s", err)
+		return err
+	}
+
+	data, err := backup.Yaml()
 
 	if err != nil {
 		return err
@@ -86,7 +86,9 @@ func TestAlbum_LoadFromYaml(t *testing.T) {
 
 		if existingYaml, err := os.ReadFile(fileName); err != nil {
 			t.Fatal(err)
-		} else if newYaml, err := a.Yaml(); err != nil {
+		} else if backup, err := a.Backup(); err != nil {
+			t.Fatal(err)
+		} else if newYaml, err := backup.Yaml(); err != nil {
 			t.Fatal(err)
 		} else {
 			assert.Equal(t, existingYaml[:50], newYaml[:50])
@@ -99,11 +101,33 @@ func TestAlbum_LoadFromYaml(t *testing.T) {
 			assert.Equal(t, a.AlbumCountry, m.AlbumCountry)
 			assert.Equal(t, a.CreatedAt, m.CreatedAt)
 			assert.Equal(t, a.UpdatedAt, m.UpdatedAt)
-			assert.Equal(t, len(a.Photos), len(m.Photos))
+			assert.Equal(t, len(backup.Photos), len(m.Photos))
 		}
 	})
 }
 
+func TestAlbum_Backup(t *testing.T) {
+	t.Run("berlin-2019", func(t *testing.T) {
+		m := AlbumFixtures.Get("berlin-2019")
+
+		if err := m.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		m.Photos = nil
+
+		backup, err := m.Backup()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.AlbumUID, backup.AlbumUID)
+		assert.NotEmpty(t, backup.Photos)
+		assert.Empty(t, m.Photos)
+	})
+}
+
 func TestAlbum_YamlFileName(t *testing.T) {
 	t.Run("berlin-2019", func(t *testing.T) {
 		m := AlbumFixtures.Get("berlin-2019")
@@ -117,3 +141,103 @@ func TestAlbum_YamlFileName(t *testing.T) {
 		assert.Equal(t, "/foo/bar/album/at9lxuqxpogaaba9.yml", fileName)
 	})
 }
+
+func TestPhotoHashes(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		result, err := PhotoHashes([]string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0xxx"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", result["pt9jtdre2lvl0yh7"])
+		assert.Equal(t, "", result["pt9jtdre2lvl0xxx"])
+	})
+	t.Run("Empty", func(t *testing.T) {
+		result, err := PhotoHashes(nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
+
+func TestAlbum_RelinkPhotos(t *testing.T) {
+	m := Album{
+		AlbumUID: "at9lxuqxpoaaaxxx",
+		Photos: PhotoAlbums{
+			{PhotoUID: "pt9jtdre2lvl0yh7", Order: 1},
+			{PhotoUID: "pt9jtdre2lvl0xx1", Hash: "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", Order: 2},
+			{PhotoUID: "pt9jtdre2lvl0xx2", Hash: "ocad9168fa6acc5c5c2965ddf6ec465ca42fd818", Order: 3},
+			{PhotoUID: "pt9jtdre2lvl0xx3", Order: 4},
+		},
+	}
+
+	assert.Equal(t, 1, m.RelinkPhotos())
+
+	if assert.Len(t, m.Photos, 3) {
+		assert.Equal(t, "pt9jtdre2lvl0yh7", m.Photos[0].PhotoUID)
+		assert.Equal(t, PhotoFixtures.Pointer("Photo01").PhotoUID, m.Photos[1].PhotoUID)
+		assert.Equal(t, 3, m.Photos[1].Order)
+		assert.Equal(t, "pt9jtdre2lvl0xx3", m.Photos[2].PhotoUID)
+		assert.Equal(t, "at9lxuqxpoaaaxxx", m.Photos[2].AlbumUID)
+	}
+}
+
+func TestAlbum_RestoreShares(t *testing.T) {
+	m := AlbumFixtures.Get("christmas2030")
+
+	m.Members = AlbumMembers{
+		{UserUID: "uqxc08w3d0ej2283", MemberRole: MemberViewer},
+		{UserUID: "uqxc08w3d0ejxxxx", MemberRole: MemberViewer},
+	}
+
+	pw := NewPassword("sqxc08w3d0ejrst1", "restored")
+
+	m.Shares = Links{
+		{LinkToken: "restoredtoken1", LinkExpires: 0},
+		{LinkUID: "sqxc08w3d0ejrst1", LinkToken: "restoredtoken2", MaxViews: 5, HasPassword: true, PasswordHash: pw.Hash},
+		{LinkToken: "restoredtoken3", HasPassword: true},
+	}
+
+	if err := m.RestoreShares(); err != nil {
+		t.Fatal(err)
+	}
+
+	member := FindAlbumMember(m.AlbumUID, "uqxc08w3d0ej2283")
+
+	if member == nil {
+		t.Fatal("member should not be nil")
+	}
+
+	assert.Equal(t, MemberViewer, member.MemberRole)
+	assert.Nil(t, FindAlbumMember(m.AlbumUID, "uqxc08w3d0ejxxxx"))
+
+	links := FindLinks("restoredtoken1", m.AlbumUID)
+
+	assert.Len(t, links, 1)
+
+	protected := FindLinks("restoredtoken2", m.AlbumUID)
+
+	if assert.Len(t, protected, 1) {
+		assert.Equal(t, uint(5), protected[0].MaxViews)
+		assert.False(t, protected[0].InvalidPassword("restored"))
+		assert.True(t, protected[0].InvalidPassword("wrong"))
+	}
+
+	// Links without password hash must not be restored unprotected.
+	assert.Empty(t, FindLinks("restoredtoken3", m.AlbumUID))
+
+	// Restoring again must not fail or create duplicates.
+	if err := m.RestoreShares(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, FindLinks("restoredtoken1", m.AlbumUID), 1)
+
+	assert.NoError(t, member.Delete())
+	assert.NoError(t, links[0].Delete())
+	assert.NoError(t, protected[0].Delete())
+}
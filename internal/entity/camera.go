@@ -105,6 +105,14 @@ func FirstOrCreateCamera(m *Camera) *Camera {
 
 	result := Camera{}
 
+	// Merge alternative names into the camera they are an alias of.
+	if alias := FindCameraAlias(AliasCamera, m.CameraSlug); alias != nil {
+		if res := Db().Where("camera_slug = ?", alias.TargetSlug).First(&result); res.Error == nil {
+			cameraCache.SetDefault(m.CameraSlug, &result)
+			return &result
+		}
+	}
+
 	if res := Db().Where("camera_slug = ?", m.CameraSlug).First(&result); res.Error == nil {
 		cameraCache.SetDefault(m.CameraSlug, &result)
 		return &result
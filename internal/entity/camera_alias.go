@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Camera alias types.
+const (
+	AliasCamera = "camera"
+	AliasLens   = "lens"
+)
+
+// AliasTypes maps valid camera alias types.
+var AliasTypes = map[string]bool{
+	AliasCamera: true,
+	AliasLens:   true,
+}
+
+type CameraAliases []CameraAlias
+
+// CameraAlias maps an alternative camera or lens name to the camera or lens it should be merged into,
+// e.g. "Nikon NIKON D750" to "Nikon D750".
+type CameraAlias struct {
+	AliasType  string    `gorm:"type:VARBINARY(16);primary_key;auto_increment:false" json:"Type" yaml:"Type"`
+	AliasSlug  string    `gorm:"type:VARBINARY(160);primary_key;auto_increment:false" json:"Slug" yaml:"Slug"`
+	AliasName  string    `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name"`
+	TargetSlug string    `gorm:"type:VARBINARY(160);index;" json:"Target" yaml:"Target"`
+	CreatedAt  time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (CameraAlias) TableName() string {
+	return "cameras_aliases"
+}
+
+// NewCameraAlias returns a new alias for a camera or lens name.
+func NewCameraAlias(aliasType, aliasName, targetName string) *CameraAlias {
+	return &CameraAlias{
+		AliasType:  aliasType,
+		AliasSlug:  txt.Slug(aliasName),
+		AliasName:  txt.Clip(aliasName, txt.ClipName),
+		TargetSlug: txt.Slug(targetName),
+	}
+}
+
+// FindCameraAlias returns the alias for a camera or lens slug, or nil if it does not exist.
+func FindCameraAlias(aliasType, slug string) *CameraAlias {
+	if aliasType == "" || slug == "" || slug == UnknownID {
+		return nil
+	}
+
+	result := CameraAlias{}
+
+	if err := UnscopedDb().Where("alias_type = ? AND alias_slug = ?", aliasType, slug).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Validate checks the type and slugs of the alias.
+func (m *CameraAlias) Validate() error {
+	if !AliasTypes[m.AliasType] {
+		return fmt.Errorf("invalid alias type %s", sanitize.Log(m.AliasType))
+	} else if m.AliasSlug == "" {
+		return fmt.Errorf("alias name must not be empty")
+	} else if m.TargetSlug == "" {
+		return fmt.Errorf("alias target must not be empty")
+	} else if m.AliasSlug == m.TargetSlug {
+		return fmt.Errorf("alias and target must not be the same")
+	}
+
+	return nil
+}
+
+// TargetExists tests if the camera or lens the alias refers to exists.
+func (m *CameraAlias) TargetExists() bool {
+	switch m.AliasType {
+	case AliasCamera:
+		return Db().Where("camera_slug = ?", m.TargetSlug).First(&Camera{}).Error == nil
+	case AliasLens:
+		return Db().Where("lens_slug = ?", m.TargetSlug).First(&Lens{}).Error == nil
+	default:
+		return false
+	}
+}
+
+// Save updates or inserts a row.
+func (m *CameraAlias) Save() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	defer m.flushCache()
+
+	return UnscopedDb().Save(m).Error
+}
+
+// Delete removes the alias from the database.
+func (m *CameraAlias) Delete() error {
+	defer m.flushCache()
+
+	return UnscopedDb().Delete(CameraAlias{}, "alias_type = ? AND alias_slug = ?", m.AliasType, m.AliasSlug).Error
+}
+
+// flushCache removes the cached cameras or lenses, so that changed aliases apply immediately.
+func (m *CameraAlias) flushCache() {
+	switch m.AliasType {
+	case AliasCamera:
+		FlushCameraCache()
+	case AliasLens:
+		FlushLensCache()
+	}
+}
+
+// Apply merges photos of the aliased camera or lens into the target and returns the number of updated photos.
+func (m *CameraAlias) Apply() (updated int64, err error) {
+	switch m.AliasType {
+	case AliasCamera:
+		src, dest := Camera{}, Camera{}
+
+		if err := Db().Where("camera_slug = ?", m.AliasSlug).First(&src).Error; err != nil {
+			return 0, nil
+		} else if err := Db().Where("camera_slug = ?", m.TargetSlug).First(&dest).Error; err != nil {
+			return 0, fmt.Errorf("camera %s not found", sanitize.Log(m.TargetSlug))
+		}
+
+		res := UnscopedDb().Model(Photo{}).Where("camera_id = ?", src.ID).UpdateColumn("camera_id", dest.ID)
+
+		return res.RowsAffected, res.Error
+	case AliasLens:
+		src, dest := Lens{}, Lens{}
+
+		if err := Db().Where("lens_slug = ?", m.AliasSlug).First(&src).Error; err != nil {
+			return 0, nil
+		} else if err := Db().Where("lens_slug = ?", m.TargetSlug).First(&dest).Error; err != nil {
+			return 0, fmt.Errorf("lens %s not found", sanitize.Log(m.TargetSlug))
+		}
+
+		res := UnscopedDb().Model(Photo{}).Where("lens_id = ?", src.ID).UpdateColumn("lens_id", dest.ID)
+
+		return res.RowsAffected, res.Error
+	default:
+		return 0, fmt.Errorf("invalid alias type %s", sanitize.Log(m.AliasType))
+	}
+}
@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+type CameraAliasMap map[string]CameraAlias
+
+func (m CameraAliasMap) Get(name string) CameraAlias {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return CameraAlias{}
+}
+
+func (m CameraAliasMap) Pointer(name string) *CameraAlias {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &CameraAlias{}
+}
+
+var CameraAliasFixtures = CameraAliasMap{
+	"canon-canon-eos-6d": {
+		AliasType:  AliasCamera,
+		AliasSlug:  "canon-canon-eos-6d",
+		AliasName:  "Canon Canon EOS 6D",
+		TargetSlug: "canon-eos-6d",
+		CreatedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+	"apple-f-380": {
+		AliasType:  AliasLens,
+		AliasSlug:  "apple-f-380",
+		AliasName:  "Apple F380",
+		TargetSlug: "lens-f-380",
+		CreatedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+}
+
+// CreateCameraAliasFixtures inserts known entities into the database for testing.
+func CreateCameraAliasFixtures() {
+	for _, entity := range CameraAliasFixtures {
+		Db().Create(&entity)
+	}
+}
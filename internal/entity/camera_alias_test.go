@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCameraAlias(t *testing.T) {
+	m := NewCameraAlias(AliasCamera, "Nikon NIKON D750", "Nikon D750")
+
+	assert.Equal(t, AliasCamera, m.AliasType)
+	assert.Equal(t, "nikon-nikon-d750", m.AliasSlug)
+	assert.Equal(t, "Nikon NIKON D750", m.AliasName)
+	assert.Equal(t, "nikon-d750", m.TargetSlug)
+}
+
+func TestFindCameraAlias(t *testing.T) {
+	t.Run("Camera", func(t *testing.T) {
+		m := FindCameraAlias(AliasCamera, "canon-canon-eos-6d")
+
+		if m == nil {
+			t.Fatal("alias should not be nil")
+		}
+
+		assert.Equal(t, "canon-eos-6d", m.TargetSlug)
+	})
+	t.Run("WrongType", func(t *testing.T) {
+		assert.Nil(t, FindCameraAlias(AliasLens, "canon-canon-eos-6d"))
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		assert.Nil(t, FindCameraAlias(AliasCamera, UnknownID))
+	})
+}
+
+func TestCameraAlias_Validate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, NewCameraAlias(AliasLens, "Apple F380", "F380").Validate())
+	})
+	t.Run("InvalidType", func(t *testing.T) {
+		assert.Error(t, NewCameraAlias("flash", "Apple F380", "F380").Validate())
+	})
+	t.Run("EmptyTarget", func(t *testing.T) {
+		assert.Error(t, NewCameraAlias(AliasCamera, "Nikon NIKON D750", "").Validate())
+	})
+	t.Run("SameSlug", func(t *testing.T) {
+		assert.Error(t, NewCameraAlias(AliasCamera, "Nikon D750", "NIKON D750").Validate())
+	})
+}
+
+func TestCameraAlias_TargetExists(t *testing.T) {
+	assert.True(t, CameraAliasFixtures.Pointer("canon-canon-eos-6d").TargetExists())
+	assert.True(t, CameraAliasFixtures.Pointer("apple-f-380").TargetExists())
+	assert.False(t, NewCameraAlias(AliasLens, "Apple F380", "Lens Does Not Exist").TargetExists())
+}
+
+func TestCameraAlias_Apply(t *testing.T) {
+	t.Run("Camera", func(t *testing.T) {
+		src := FirstOrCreateCamera(NewCamera("EOS 6D Alias", "Canon"))
+		dest := CameraFixtures.Pointer("canon-eos-6d")
+
+		photo := &Photo{PhotoUID: "pt9jtdre2lvlalia", CameraID: src.ID}
+
+		if err := photo.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer UnscopedDb().Delete(photo)
+
+		m := NewCameraAlias(AliasCamera, src.CameraName, dest.CameraSlug)
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.Delete()
+
+		updated, err := m.Apply()
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), updated)
+
+		found := &Photo{}
+
+		if err := UnscopedDb().Where("photo_uid = ?", photo.PhotoUID).First(found).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, dest.ID, found.CameraID)
+
+		// New files with the alias name are assigned to the target camera.
+		assert.Equal(t, dest.ID, FirstOrCreateCamera(NewCamera("EOS 6D Alias", "Canon")).ID)
+	})
+	t.Run("Lens", func(t *testing.T) {
+		m := CameraAliasFixtures.Pointer("apple-f-380")
+
+		updated, err := m.Apply()
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), updated)
+	})
+	t.Run("TargetNotFound", func(t *testing.T) {
+		FirstOrCreateLens(NewLens("F999 Alias", "Apple"))
+
+		m := NewCameraAlias(AliasLens, "Apple F999 Alias", "Lens Does Not Exist")
+
+		_, err := m.Apply()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCameraAlias_Delete(t *testing.T) {
+	m := NewCameraAlias(AliasLens, "Apple F123 Alias", "F380")
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, FindCameraAlias(AliasLens, "apple-f123-alias"))
+	assert.NoError(t, m.Delete())
+	assert.Nil(t, FindCameraAlias(AliasLens, "apple-f123-alias"))
+}
@@ -3,6 +3,7 @@ package entity
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
@@ -84,11 +85,11 @@ func UpdateSubjectCounts() (err error) {
 	filesTable := File{}.TableName()
 	markerTable := Marker{}.TableName()
 
-	condition := gorm.Expr("subj_type = ?", SubjPerson)
+	condition := gorm.Expr("subj_type IN (?, ?)", SubjPerson, SubjPet)
 
 	switch DbDialect() {
 	case MySQL:
-		res = Db().Exec(`UPDATE ? LEFT JOIN (
+		res = Exec(`UPDATE ? LEFT JOIN (
 		SELECT m.subj_uid, COUNT(DISTINCT f.id) AS subj_files, COUNT(DISTINCT f.photo_id) AS subj_photos FROM ? f
 			JOIN ? m ON f.file_uid = m.file_uid AND m.subj_uid IS NOT NULL AND m.subj_uid <> '' AND m.subj_uid IS NOT NULL
 			WHERE m.marker_invalid = 0 AND f.deleted_at IS NULL GROUP BY m.subj_uid
@@ -134,7 +135,7 @@ func UpdateLabelCounts() (err error) {
 	start := time.Now()
 	var res *gorm.DB
 	if IsDialect(MySQL) {
-		res = Db().Exec(`UPDATE labels LEFT JOIN (
+		res = Exec(`UPDATE labels LEFT JOIN (
 		SELECT p2.label_id, COUNT(DISTINCT photo_id) AS label_photos FROM (
 			SELECT pl.label_id as label_id, p.id AS photo_id FROM photos p
 				JOIN photos_labels pl ON pl.photo_id = p.id AND pl.uncertainty < 100
@@ -181,6 +182,48 @@ func UpdateLabelCounts() (err error) {
 	return nil
 }
 
+// CountsDelay is the time to wait for further changes before counts are updated in the background.
+var CountsDelay = 3 * time.Second
+
+// countsUpdate is the timer that updates counts in the background once no further changes are made.
+var countsUpdate = struct {
+	sync.Mutex
+	timer *time.Timer
+	done  func()
+}{}
+
+// UpdateCountsAsync updates the precalculated photo and file counts in the background, so that edits don't
+// have to wait for the update and many changes in a row, e.g. batch edits, only cause a single update.
+// The optional done function is called after the update, e.g. to notify clients.
+func UpdateCountsAsync(done func()) {
+	countsUpdate.Lock()
+	defer countsUpdate.Unlock()
+
+	if done != nil {
+		countsUpdate.done = done
+	}
+
+	if countsUpdate.timer != nil {
+		countsUpdate.timer.Reset(CountsDelay)
+		return
+	}
+
+	countsUpdate.timer = time.AfterFunc(CountsDelay, func() {
+		if err := UpdateCounts(); err != nil {
+			log.Warnf("index: %s (update counts)", err)
+		}
+
+		countsUpdate.Lock()
+		done := countsUpdate.done
+		countsUpdate.done = nil
+		countsUpdate.Unlock()
+
+		if done != nil {
+			done()
+		}
+	})
+}
+
 // UpdateCounts updates precalculated photo and file counts.
 func UpdateCounts() (err error) {
 	log.Info("index: updating counts")
@@ -209,19 +252,30 @@ func UpdateCounts() (err error) {
 		return err
 	}
 
+	// Update precalculated counts, so that they don't need to be queried each time they are displayed.
+	for _, countType := range PhotoCountTypes {
+		if err = UpdatePhotoCounts(countType); err != nil {
+			return err
+		}
+	}
+
+	if err = UpdateTotalCounts(); err != nil {
+		return err
+	}
+
 	/* TODO: Slow with many photos due to missing index.
 	start = time.Now()
 
 	// Update calendar album visibility.
 	switch DbDialect() {
 	default:
-		if err = UnscopedDb().Exec(`UPDATE albums SET deleted_at = ? WHERE album_type=? AND id NOT IN (
+		if err = Exec(`UPDATE albums SET deleted_at = ? WHERE album_type=? AND id NOT IN (
 		SELECT a.id FROM albums a JOIN photos p ON a.album_month = MONTH(p.taken_at) AND a.album_year = YEAR(p.taken_at)
 		AND p.deleted_at IS NULL AND p.photo_quality > -1 AND p.photo_private = 0 WHERE album_type=? GROUP BY a.id)`,
 			TimeStamp(), AlbumMonth, AlbumMonth).Error; err != nil {
 			return err
 		}
-		if err = UnscopedDb().Exec(`UPDATE albums SET deleted_at = NULL WHERE album_type=? AND id IN (
+		if err = Exec(`UPDATE albums SET deleted_at = NULL WHERE album_type=? AND id IN (
 		SELECT a.id FROM albums a JOIN photos p ON a.album_month = MONTH(p.taken_at) AND a.album_year = YEAR(p.taken_at)
 		AND p.deleted_at IS NULL AND p.photo_quality > -1 AND p.photo_private = 0 WHERE album_type=? GROUP BY a.id)`,
 			AlbumMonth, AlbumMonth).Error; err != nil {
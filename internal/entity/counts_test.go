@@ -2,6 +2,9 @@ package entity
 
 import (
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestLabelCounts(t *testing.T) {
@@ -23,3 +26,23 @@ func TestUpdatePhotoCounts(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestUpdateCountsAsync(t *testing.T) {
+	CountsDelay = 10 * time.Millisecond
+	defer func() { CountsDelay = 3 * time.Second }()
+
+	done := make(chan bool, 2)
+	notify := func() { done <- true }
+
+	// Changes in a row only cause a single update.
+	UpdateCountsAsync(notify)
+	UpdateCountsAsync(notify)
+
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("counts have not been updated")
+	}
+
+	assert.Len(t, done, 0)
+}
@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// SlowQuery is the duration after which a query is logged with its plan in explain mode.
+var SlowQuery = 250 * time.Millisecond
+
+const explainStartKey = "photoprism:explain_start"
+
+// RegisterExplain adds callbacks that log slow queries together with their query plans.
+//
+// Row queries are not explained, as their result set may still hold the connection.
+func RegisterExplain(db *gorm.DB) {
+	db.Callback().Query().Before("gorm:query").Register("photoprism:explain_start", explainStart)
+	db.Callback().Query().After("gorm:query").Register("photoprism:explain", explainQuery)
+}
+
+// explainStart remembers when the query was started.
+func explainStart(scope *gorm.Scope) {
+	scope.Set(explainStartKey, time.Now())
+}
+
+// explainQuery logs the query plan if the query took longer than SlowQuery.
+func explainQuery(scope *gorm.Scope) {
+	val, ok := scope.Get(explainStartKey)
+
+	if !ok {
+		return
+	}
+
+	start, ok := val.(time.Time)
+
+	if !ok {
+		return
+	}
+
+	elapsed := time.Since(start)
+
+	if elapsed < SlowQuery {
+		return
+	}
+
+	// Only select statements can be explained without side effects.
+	if !strings.HasPrefix(strings.ToUpper(strings.TrimSpace(scope.SQL)), "SELECT") {
+		return
+	}
+
+	plan, err := Explain(scope.NewDB(), scope.SQL, scope.SQLVars...)
+
+	if err != nil {
+		log.Warnf("explain: %s", err)
+	}
+
+	log.Warnf("explain: slow query [%s]\n%s\n%s", elapsed, scope.SQL, strings.Join(plan, "\n"))
+}
+
+// Explain returns the query plan of a select statement, one line per row.
+func Explain(db *gorm.DB, query string, values ...interface{}) (plan []string, err error) {
+	switch db.Dialect().GetName() {
+	case SQLite3:
+		query = "EXPLAIN QUERY PLAN " + query
+	default:
+		query = "EXPLAIN " + query
+	}
+
+	rows, err := db.Raw(query, values...).Rows()
+
+	if err != nil {
+		return plan, err
+	}
+
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+
+	if err != nil {
+		return plan, err
+	}
+
+	values = make([]interface{}, len(cols))
+
+	for rows.Next() {
+		row := make([]sql.NullString, len(cols))
+
+		for i := range row {
+			values[i] = &row[i]
+		}
+
+		if err := rows.Scan(values...); err != nil {
+			return plan, err
+		}
+
+		fields := make([]string, 0, len(cols))
+
+		for i, col := range cols {
+			if row[i].Valid && row[i].String != "" {
+				fields = append(fields, fmt.Sprintf("%s=%s", col, row[i].String))
+			}
+		}
+
+		plan = append(plan, strings.Join(fields, " "))
+	}
+
+	return plan, rows.Err()
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain(t *testing.T) {
+	t.Run("Photos", func(t *testing.T) {
+		plan, err := Explain(Db(), "SELECT photo_uid FROM photos WHERE photo_year = ? AND photo_month = ?", 2020, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, plan)
+		t.Log(plan)
+	})
+	t.Run("InvalidQuery", func(t *testing.T) {
+		_, err := Explain(Db(), "SELECT foo FROM unknown_table")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRegisterExplain(t *testing.T) {
+	db, err := gorm.Open(SQLite3, ":memory:")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	RegisterExplain(db)
+
+	slowQuery := SlowQuery
+	SlowQuery = 0
+	defer func() { SlowQuery = slowQuery }()
+
+	if err := db.AutoMigrate(&TestEntity{}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var result []TestEntity
+
+	assert.NoError(t, db.Where("test_label = ?", "foo").Find(&result).Error)
+	assert.Empty(t, result)
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"database/sql"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jinzhu/gorm"
+)
+
+// writeQueue serializes write operations, so that concurrent workers wait for each other
+// instead of failing with "database is locked" errors.
+var writeQueue = sync.Mutex{}
+
+// writeQueueEnabled is set to 1 once the write queue has been registered.
+var writeQueueEnabled int32
+
+// RegisterWriteQueue wraps the callbacks that execute create, update, and delete statements,
+// e.g. for SQLite, which only supports a single writer at a time.
+func RegisterWriteQueue(db *gorm.DB) {
+	cb := db.Callback()
+
+	cb.Create().Replace("gorm:create", queued(cb.Create().Get("gorm:create")))
+	cb.Update().Replace("gorm:update", queued(cb.Update().Get("gorm:update")))
+	cb.Delete().Replace("gorm:delete", queued(cb.Delete().Get("gorm:delete")))
+
+	atomic.StoreInt32(&writeQueueEnabled, 1)
+}
+
+// Exec executes a raw SQL statement that changes data, and waits for other write operations
+// to complete if the write queue is enabled.
+func Exec(sql string, values ...interface{}) *gorm.DB {
+	if atomic.LoadInt32(&writeQueueEnabled) == 1 {
+		writeQueue.Lock()
+		defer writeQueue.Unlock()
+	}
+
+	return UnscopedDb().Exec(sql, values...)
+}
+
+// queued returns a callback that waits for other write operations to complete before running.
+func queued(callback func(scope *gorm.Scope)) func(scope *gorm.Scope) {
+	return func(scope *gorm.Scope) {
+		if !writeQueued(scope) {
+			callback(scope)
+			return
+		}
+
+		writeQueue.Lock()
+		defer writeQueue.Unlock()
+
+		callback(scope)
+	}
+}
+
+// writeQueued tests if the operation must wait in the write queue. Operations within a transaction
+// that was not started for them, e.g. when saving associations, are not queued to prevent deadlocks.
+func writeQueued(scope *gorm.Scope) bool {
+	if _, ok := scope.SQLDB().(*sql.Tx); !ok {
+		return true
+	}
+
+	_, started := scope.InstanceGet("gorm:started_transaction")
+
+	return started
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterWriteQueue(t *testing.T) {
+	db, err := gorm.Open("sqlite3", filepath.Join(t.TempDir(), "queue.db"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	RegisterWriteQueue(db)
+
+	if err := db.AutoMigrate(&Keyword{}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, 20)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+			errs <- db.Create(&Keyword{Keyword: fmt.Sprintf("queue%d", i)}).Error
+		}(i)
+
+		go func(i int) {
+			defer wg.Done()
+			errs <- db.Transaction(func(tx *gorm.DB) error {
+				return tx.Create(&Keyword{Keyword: fmt.Sprintf("tx%d", i)}).Error
+			})
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	var count int
+
+	db.Model(&Keyword{}).Count(&count)
+
+	assert.Equal(t, 20, count)
+}
+
+func TestExec(t *testing.T) {
+	var wg sync.WaitGroup
+
+	errs := make(chan error, 10)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			errs <- Exec("UPDATE files SET file_missing = file_missing WHERE id = ?", FileFixtures.Get("exampleFileName.jpg").ID).Error
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
@@ -1,7 +1,11 @@
 package entity
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -14,35 +18,50 @@ type Tables map[string]interface{}
 
 // Entities contains database entities and their table names.
 var Entities = Tables{
-	migrate.Migration{}.TableName(): &migrate.Migration{},
-	"errors":                        &Error{},
-	"addresses":                     &Address{},
-	"users":                         &User{},
-	"accounts":                      &Account{},
-	"folders":                       &Folder{},
-	"duplicates":                    &Duplicate{},
-	File{}.TableName():              &File{},
-	"files_share":                   &FileShare{},
-	"files_sync":                    &FileSync{},
-	Photo{}.TableName():             &Photo{},
-	"details":                       &Details{},
-	Place{}.TableName():             &Place{},
-	Cell{}.TableName():              &Cell{},
-	"cameras":                       &Camera{},
-	"lenses":                        &Lens{},
-	"countries":                     &Country{},
-	"albums":                        &Album{},
-	"photos_albums":                 &PhotoAlbum{},
-	"labels":                        &Label{},
-	"categories":                    &Category{},
-	"photos_labels":                 &PhotoLabel{},
-	"keywords":                      &Keyword{},
-	"photos_keywords":               &PhotoKeyword{},
-	"passwords":                     &Password{},
-	"links":                         &Link{},
-	Subject{}.TableName():           &Subject{},
-	Face{}.TableName():              &Face{},
-	Marker{}.TableName():            &Marker{},
+	migrate.Migration{}.TableName():    &migrate.Migration{},
+	"errors":                           &Error{},
+	Activity{}.TableName():             &Activity{},
+	"addresses":                        &Address{},
+	"users":                            &User{},
+	"accounts":                         &Account{},
+	"folders":                          &Folder{},
+	"duplicates":                       &Duplicate{},
+	File{}.TableName():                 &File{},
+	"files_share":                      &FileShare{},
+	"files_sync":                       &FileSync{},
+	BrokenFile{}.TableName():           &BrokenFile{},
+	Photo{}.TableName():                &Photo{},
+	"details":                          &Details{},
+	PhotoLocale{}.TableName():          &PhotoLocale{},
+	Place{}.TableName():                &Place{},
+	Cell{}.TableName():                 &Cell{},
+	"cameras":                          &Camera{},
+	"lenses":                           &Lens{},
+	"cameras_aliases":                  &CameraAlias{},
+	"searches":                         &Search{},
+	Download{}.TableName():             &Download{},
+	PushSubscription{}.TableName():     &PushSubscription{},
+	"countries":                        &Country{},
+	"albums":                           &Album{},
+	"photos_albums":                    &PhotoAlbum{},
+	AlbumMember{}.TableName():          &AlbumMember{},
+	"labels":                           &Label{},
+	"categories":                       &Category{},
+	"photos_labels":                    &PhotoLabel{},
+	"keywords":                         &Keyword{},
+	"photos_keywords":                  &PhotoKeyword{},
+	Tag{}.TableName():                  &Tag{},
+	PhotoTag{}.TableName():             &PhotoTag{},
+	PhotoTimelapse{}.TableName():       &PhotoTimelapse{},
+	PhotoEmbedding{}.TableName():       &PhotoEmbedding{},
+	PhotoEmbeddingBucket{}.TableName(): &PhotoEmbeddingBucket{},
+	"passwords":                        &Password{},
+	"links":                            &Link{},
+	Subject{}.TableName():              &Subject{},
+	Face{}.TableName():                 &Face{},
+	Marker{}.TableName():               &Marker{},
+	PhotoCount{}.TableName():           &PhotoCount{},
+	Tombstone{}.TableName():            &Tombstone{},
 }
 
 // WaitForMigration waits for the database migration to be successful.
@@ -85,6 +104,12 @@ func (list Tables) Truncate(db *gorm.DB) {
 
 // Migrate migrates all database tables of registered entities.
 func (list Tables) Migrate(db *gorm.DB, runFailed bool) {
+	// Don't modify a schema that has been migrated by a newer version.
+	if newer := migrate.Newer(db); len(newer) > 0 {
+		log.Errorf("entity: schema has been migrated by a newer version (%s), run \"photoprism migrations rollback\" with that version before downgrading", strings.Join(newer, ", "))
+		return
+	}
+
 	for name, entity := range list {
 		if err := db.AutoMigrate(entity).Error; err != nil {
 			log.Debugf("entity: %s (waiting 1s)", err.Error())
@@ -98,11 +123,38 @@ func (list Tables) Migrate(db *gorm.DB, runFailed bool) {
 		}
 	}
 
-	if err := migrate.Auto(db, runFailed); err != nil {
+	if err := migrate.Auto(db, runFailed, list.Checksum(db)); err != nil {
 		log.Error(err)
 	}
 }
 
+// Checksum returns a checksum of the schema created by AutoMigrate for the registered entities.
+func (list Tables) Checksum(db *gorm.DB) string {
+	names := make([]string, 0, len(list))
+
+	for name := range list {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	h := sha1.New()
+
+	for _, name := range names {
+		_, _ = fmt.Fprintf(h, "%s\n", name)
+
+		for _, field := range db.NewScope(list[name]).GetModelStruct().StructFields {
+			if field.IsIgnored {
+				continue
+			}
+
+			_, _ = fmt.Fprintf(h, "%s %s %s\n", field.DBName, field.Struct.Type, field.Tag.Get("gorm"))
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // Drop drops all database tables of registered entities.
 func (list Tables) Drop(db *gorm.DB) {
 	for _, entity := range list {
@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTables_Checksum(t *testing.T) {
+	checksum := Entities.Checksum(Db())
+
+	assert.Len(t, checksum, 40)
+	assert.Equal(t, checksum, Entities.Checksum(Db()))
+	assert.NotEqual(t, checksum, Tables{"test_ignore": &TestEntity{}}.Checksum(Db()))
+}
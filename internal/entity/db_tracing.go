@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/photoprism/photoprism/internal/tracing"
+)
+
+// TracingStatementLength is the maximum length of SQL statements added to spans.
+var TracingStatementLength = 2000
+
+const (
+	tracingSpanKey    = "photoprism:tracing_span"
+	tracingContextKey = "photoprism:tracing_context"
+)
+
+// DbContext returns a database connection that adds query spans to the trace in the context,
+// e.g. of an API request or a background job.
+func DbContext(ctx context.Context) *gorm.DB {
+	return Db().Set(tracingContextKey, ctx)
+}
+
+// RegisterTracing adds callbacks that record database operations as spans.
+//
+// Values are not included in spans, as they may contain personal data.
+func RegisterTracing(db *gorm.DB) {
+	cb := db.Callback()
+
+	cb.Create().Before("gorm:create").Register("photoprism:tracing_start", tracingStart("db.create"))
+	cb.Create().After("gorm:create").Register("photoprism:tracing_end", tracingEnd)
+	cb.Query().Before("gorm:query").Register("photoprism:tracing_start", tracingStart("db.query"))
+	cb.Query().After("gorm:query").Register("photoprism:tracing_end", tracingEnd)
+	cb.Update().Before("gorm:update").Register("photoprism:tracing_start", tracingStart("db.update"))
+	cb.Update().After("gorm:update").Register("photoprism:tracing_end", tracingEnd)
+	cb.Delete().Before("gorm:delete").Register("photoprism:tracing_start", tracingStart("db.delete"))
+	cb.Delete().After("gorm:delete").Register("photoprism:tracing_end", tracingEnd)
+	cb.RowQuery().Before("gorm:row_query").Register("photoprism:tracing_start", tracingStart("db.row_query"))
+	cb.RowQuery().After("gorm:row_query").Register("photoprism:tracing_end", tracingEnd)
+}
+
+// tracingStart returns a callback that starts a new span with the given name,
+// as child of the span in the context set with DbContext, if any.
+func tracingStart(name string) func(scope *gorm.Scope) {
+	return func(scope *gorm.Scope) {
+		_, span := tracing.Start(tracingContext(scope), name,
+			semconv.DBSystemKey.String(scope.Dialect().GetName()),
+			semconv.DBSQLTableKey.String(scope.TableName()),
+		)
+
+		scope.Set(tracingSpanKey, span)
+	}
+}
+
+// tracingContext returns the context set with DbContext, or an empty context.
+func tracingContext(scope *gorm.Scope) context.Context {
+	if val, ok := scope.Get(tracingContextKey); !ok {
+		return context.Background()
+	} else if ctx, ok := val.(context.Context); ok && ctx != nil {
+		return ctx
+	}
+
+	return context.Background()
+}
+
+// tracingEnd adds the statement and result to the span and ends it.
+func tracingEnd(scope *gorm.Scope) {
+	val, ok := scope.Get(tracingSpanKey)
+
+	if !ok {
+		return
+	}
+
+	span, ok := val.(trace.Span)
+
+	if !ok {
+		return
+	}
+
+	statement := scope.SQL
+
+	if len(statement) > TracingStatementLength {
+		statement = statement[:TracingStatementLength]
+	}
+
+	span.SetAttributes(
+		semconv.DBStatementKey.String(statement),
+		attribute.Int64("db.rows_affected", scope.DB().RowsAffected),
+	)
+
+	if err := scope.DB().Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		tracing.End(span, err)
+	} else {
+		span.End()
+	}
+}
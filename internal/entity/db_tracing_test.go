@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRegisterTracing(t *testing.T) {
+	db, err := gorm.Open(SQLite3, ":memory:")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(provider)
+
+	if err := db.AutoMigrate(&TestEntity{}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	RegisterTracing(db)
+
+	m := TestEntity{ID: "foo", TestLabel: "Foo"}
+
+	assert.NoError(t, db.Create(&m).Error)
+
+	var result []TestEntity
+
+	assert.NoError(t, db.Where("test_label = ?", "Foo").Find(&result).Error)
+	assert.Len(t, result, 1)
+
+	spans := recorder.Ended()
+
+	if assert.GreaterOrEqual(t, len(spans), 2) {
+		last := spans[len(spans)-1]
+
+		assert.Equal(t, "db.create", spans[0].Name())
+		assert.Equal(t, "db.query", last.Name())
+
+		attrs := make(map[string]string)
+
+		for _, a := range last.Attributes() {
+			attrs[string(a.Key)] = a.Value.Emit()
+		}
+
+		assert.Equal(t, "sqlite3", attrs["db.system"])
+		assert.Equal(t, "test_ignore", attrs["db.sql.table"])
+		assert.Contains(t, attrs["db.statement"], "test_label = ?")
+		assert.NotContains(t, attrs["db.statement"], "Foo")
+		assert.False(t, last.Parent().IsValid())
+	}
+
+	t.Run("Context", func(t *testing.T) {
+		ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+
+		assert.NoError(t, db.Set(tracingContextKey, ctx).Where("test_label = ?", "Foo").Find(&result).Error)
+
+		parent.End()
+
+		spans := recorder.Ended()
+
+		if assert.GreaterOrEqual(t, len(spans), 2) {
+			query := spans[len(spans)-2]
+
+			assert.Equal(t, "db.query", query.Name())
+			assert.Equal(t, parent.SpanContext().SpanID(), query.Parent().SpanID())
+			assert.Equal(t, parent.SpanContext().TraceID(), query.SpanContext().TraceID())
+		}
+	})
+}
@@ -28,6 +28,8 @@ type Details struct {
 	CopyrightSrc string    `gorm:"type:VARBINARY(8);" json:"CopyrightSrc" yaml:"CopyrightSrc,omitempty"`
 	License      string    `gorm:"type:VARCHAR(250);" json:"License" yaml:"License,omitempty"`
 	LicenseSrc   string    `gorm:"type:VARBINARY(8);" json:"LicenseSrc" yaml:"LicenseSrc,omitempty"`
+	Caption      string    `gorm:"type:TEXT;" json:"Caption" yaml:"Caption,omitempty"`
+	CaptionSrc   string    `gorm:"type:VARBINARY(8);" json:"CaptionSrc" yaml:"CaptionSrc,omitempty"`
 	CreatedAt    time.Time `yaml:"-"`
 	UpdatedAt    time.Time `yaml:"-"`
 }
@@ -107,6 +109,11 @@ func (m *Details) NoLicense() bool {
 	return m.License == ""
 }
 
+// NoCaption tests if the photo has no generated Caption.
+func (m *Details) NoCaption() bool {
+	return m.Caption == ""
+}
+
 // HasKeywords tests if the photo has a Keywords.
 func (m *Details) HasKeywords() bool {
 	return !m.NoKeywords()
@@ -137,6 +144,11 @@ func (m *Details) HasLicense() bool {
 	return !m.NoLicense()
 }
 
+// HasCaption tests if the photo has a generated Caption.
+func (m *Details) HasCaption() bool {
+	return !m.NoCaption()
+}
+
 // SetKeywords updates the photo details field.
 func (m *Details) SetKeywords(data, src string) {
 	val := txt.Clip(data, txt.ClipDescription)
@@ -145,12 +157,12 @@ func (m *Details) SetKeywords(data, src string) {
 		return
 	}
 
-	if (SrcPriority[src] < SrcPriority[m.KeywordsSrc]) && m.HasKeywords() {
+	if (FieldPriority(FieldKeywords, src) < FieldPriority(FieldKeywords, m.KeywordsSrc)) && m.HasKeywords() {
 		// Ignore if priority is lower and keywords already exist.
 		return
 	}
 
-	if SrcPriority[src] > SrcPriority[m.KeywordsSrc] {
+	if FieldPriority(FieldKeywords, src) > FieldPriority(FieldKeywords, m.KeywordsSrc) {
 		// Overwrite existing keywords if priority is higher.
 		m.Keywords = val
 	} else {
@@ -169,7 +181,7 @@ func (m *Details) SetSubject(data, src string) {
 		return
 	}
 
-	if (SrcPriority[src] < SrcPriority[m.SubjectSrc]) && m.HasSubject() {
+	if (FieldPriority(FieldSubject, src) < FieldPriority(FieldSubject, m.SubjectSrc)) && m.HasSubject() {
 		return
 	}
 
@@ -185,7 +197,7 @@ func (m *Details) SetNotes(data, src string) {
 		return
 	}
 
-	if (SrcPriority[src] < SrcPriority[m.NotesSrc]) && m.HasNotes() {
+	if (FieldPriority(FieldNotes, src) < FieldPriority(FieldNotes, m.NotesSrc)) && m.HasNotes() {
 		return
 	}
 
@@ -201,7 +213,7 @@ func (m *Details) SetArtist(data, src string) {
 		return
 	}
 
-	if (SrcPriority[src] < SrcPriority[m.ArtistSrc]) && m.HasArtist() {
+	if (FieldPriority(FieldArtist, src) < FieldPriority(FieldArtist, m.ArtistSrc)) && m.HasArtist() {
 		return
 	}
 
@@ -217,7 +229,7 @@ func (m *Details) SetCopyright(data, src string) {
 		return
 	}
 
-	if (SrcPriority[src] < SrcPriority[m.CopyrightSrc]) && m.HasCopyright() {
+	if (FieldPriority(FieldCopyright, src) < FieldPriority(FieldCopyright, m.CopyrightSrc)) && m.HasCopyright() {
 		return
 	}
 
@@ -233,10 +245,26 @@ func (m *Details) SetLicense(data, src string) {
 		return
 	}
 
-	if (SrcPriority[src] < SrcPriority[m.LicenseSrc]) && m.HasLicense() {
+	if (FieldPriority(FieldLicense, src) < FieldPriority(FieldLicense, m.LicenseSrc)) && m.HasLicense() {
 		return
 	}
 
 	m.License = val
 	m.LicenseSrc = src
 }
+
+// SetCaption updates the generated photo description, which never replaces the photo description.
+func (m *Details) SetCaption(data, src string) {
+	val := txt.Clip(data, txt.ClipDescription)
+
+	if val == "" {
+		return
+	}
+
+	if (FieldPriority(FieldCaption, src) < FieldPriority(FieldCaption, m.CaptionSrc)) && m.HasCaption() {
+		return
+	}
+
+	m.Caption = val
+	m.CaptionSrc = src
+}
@@ -332,3 +332,26 @@ func TestDetails_SetLicense(t *testing.T) {
 		assert.Equal(t, "new", description.License)
 	})
 }
+
+func TestDetails_SetCaption(t *testing.T) {
+	t.Run("no caption", func(t *testing.T) {
+		details := &Details{PhotoID: 123, Caption: ""}
+		assert.False(t, details.HasCaption())
+
+		details.SetCaption("", SrcImage)
+		assert.False(t, details.HasCaption())
+	})
+	t.Run("new caption has no priority", func(t *testing.T) {
+		details := &Details{PhotoID: 123, Caption: "old", CaptionSrc: SrcManual}
+
+		details.SetCaption("new", SrcImage)
+		assert.Equal(t, "old", details.Caption)
+	})
+	t.Run("new caption set", func(t *testing.T) {
+		details := &Details{PhotoID: 123, Caption: "old", CaptionSrc: SrcImage}
+
+		details.SetCaption("a dog on the beach", SrcImage)
+		assert.Equal(t, "a dog on the beach", details.Caption)
+		assert.True(t, details.HasCaption())
+	})
+}
@@ -0,0 +1,179 @@
+package entity
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/dial"
+)
+
+// Download types.
+const (
+	DownloadFile = "file"
+	DownloadFeed = "feed"
+)
+
+// Download status values.
+const (
+	DownloadPending = "pending"
+	DownloadDone    = "done"
+	DownloadFailed  = "failed"
+)
+
+type Downloads []Download
+
+// Download represents a remote file or RSS / Atom feed that is downloaded and imported in the background.
+type Download struct {
+	ID             uint       `gorm:"primary_key" json:"ID" yaml:"-"`
+	DownloadURL    string     `gorm:"type:VARBINARY(1024);" json:"URL" yaml:"URL"`
+	DownloadHash   string     `gorm:"type:VARBINARY(40);unique_index;" json:"Hash" yaml:"-"`
+	DownloadType   string     `gorm:"type:VARBINARY(8);" json:"Type" yaml:"Type"`
+	DownloadStatus string     `gorm:"type:VARBINARY(8);index;" json:"Status" yaml:"Status"`
+	DownloadError  string     `gorm:"type:VARBINARY(512);" json:"Error" yaml:"Error,omitempty"`
+	FeedID         uint       `gorm:"index;" json:"FeedID" yaml:"-"`
+	AlbumUID       string     `gorm:"type:VARBINARY(42);" json:"AlbumUID" yaml:"AlbumUID,omitempty"`
+	UserUID        string     `gorm:"type:VARBINARY(42);index;" json:"UserUID" yaml:"UserUID,omitempty"`
+	CheckedAt      *time.Time `json:"CheckedAt" yaml:"-"`
+	CreatedAt      time.Time  `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
+	UpdatedAt      time.Time  `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (Download) TableName() string {
+	return "downloads"
+}
+
+// DownloadHash returns the hash used to detect duplicate download URLs of the same user.
+func DownloadHash(userUID, rawUrl string) string {
+	h := sha1.Sum([]byte(userUID + ":" + strings.TrimSpace(rawUrl)))
+
+	return hex.EncodeToString(h[:])
+}
+
+// NewDownload returns a new pending download.
+func NewDownload(rawUrl, downloadType, albumUID, userUID string) *Download {
+	rawUrl = strings.TrimSpace(rawUrl)
+
+	if downloadType != DownloadFeed {
+		downloadType = DownloadFile
+	}
+
+	return &Download{
+		DownloadURL:    rawUrl,
+		DownloadHash:   DownloadHash(userUID, rawUrl),
+		DownloadType:   downloadType,
+		DownloadStatus: DownloadPending,
+		AlbumUID:       albumUID,
+		UserUID:        userUID,
+	}
+}
+
+// FindDownload returns a download by ID, or nil if it does not exist.
+func FindDownload(id uint) *Download {
+	if id == 0 {
+		return nil
+	}
+
+	result := Download{}
+
+	if err := Db().Where("id = ?", id).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// BeforeCreate sets the URL hash before inserting a new row to the database.
+func (m *Download) BeforeCreate(scope *gorm.Scope) error {
+	return scope.SetColumn("DownloadHash", DownloadHash(m.UserUID, m.DownloadURL))
+}
+
+// Validate checks the URL and type of the download.
+func (m *Download) Validate() error {
+	if m.DownloadURL == "" {
+		return fmt.Errorf("download url must not be empty")
+	} else if len(m.DownloadURL) > 1024 {
+		return fmt.Errorf("download url is too long")
+	} else if u, err := url.Parse(m.DownloadURL); err != nil || u.Host == "" {
+		return fmt.Errorf("invalid download url")
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported download url scheme %s", u.Scheme)
+	} else if err = dial.CheckHost(u.Hostname()); err != nil {
+		return fmt.Errorf("invalid download url (%s)", err)
+	} else if m.DownloadType != DownloadFile && m.DownloadType != DownloadFeed {
+		return fmt.Errorf("unknown download type %s", m.DownloadType)
+	}
+
+	return nil
+}
+
+// Feed tests if the download is an RSS or Atom feed.
+func (m *Download) Feed() bool {
+	return m.DownloadType == DownloadFeed
+}
+
+// FirstOrCreate returns the existing download with the same URL, or inserts a new row.
+// The second return value is true if a new row has been created.
+func (m *Download) FirstOrCreate() (result *Download, created bool, err error) {
+	if err := m.Validate(); err != nil {
+		return nil, false, err
+	}
+
+	found := Download{}
+
+	// Other users may add the same url, e.g. to a different album.
+	if err := Db().Where("download_hash = ?", DownloadHash(m.UserUID, m.DownloadURL)).First(&found).Error; err == nil {
+		return &found, false, nil
+	}
+
+	if err := Db().Create(m).Error; err != nil {
+		return nil, false, err
+	}
+
+	return m, true, nil
+}
+
+// Delete removes the download.
+func (m *Download) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// Done marks the download as complete, feeds remain pending so that new items are downloaded later.
+func (m *Download) Done() error {
+	checkedAt := TimeStamp()
+
+	m.CheckedAt = &checkedAt
+	m.DownloadError = ""
+
+	if m.Feed() {
+		m.DownloadStatus = DownloadPending
+	} else {
+		m.DownloadStatus = DownloadDone
+	}
+
+	return Db().Model(m).UpdateColumns(Values{"DownloadStatus": m.DownloadStatus, "DownloadError": m.DownloadError, "CheckedAt": m.CheckedAt}).Error
+}
+
+// Failed marks the download as failed and stores the error message.
+func (m *Download) Failed(err error) error {
+	checkedAt := TimeStamp()
+
+	m.CheckedAt = &checkedAt
+	m.DownloadStatus = DownloadFailed
+
+	if err != nil {
+		m.DownloadError = err.Error()
+
+		if len(m.DownloadError) > 512 {
+			m.DownloadError = m.DownloadError[:512]
+		}
+	}
+
+	return Db().Model(m).UpdateColumns(Values{"DownloadStatus": m.DownloadStatus, "DownloadError": m.DownloadError, "CheckedAt": m.CheckedAt}).Error
+}
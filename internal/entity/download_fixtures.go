@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+type DownloadMap map[string]Download
+
+func (m DownloadMap) Get(name string) Download {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return Download{}
+}
+
+func (m DownloadMap) Pointer(name string) *Download {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &Download{}
+}
+
+var DownloadFixtures = DownloadMap{
+	"done": {
+		ID:             1000000,
+		DownloadURL:    "https://images.example.com/2020/sunset.jpg",
+		DownloadHash:   DownloadHash("uqxetse3cy5eo9z2", "https://images.example.com/2020/sunset.jpg"),
+		DownloadType:   DownloadFile,
+		DownloadStatus: DownloadDone,
+		UserUID:        "uqxetse3cy5eo9z2",
+		CreatedAt:      time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:      time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+	"failed": {
+		ID:             1000001,
+		DownloadURL:    "https://images.example.com/2020/missing.jpg",
+		DownloadHash:   DownloadHash("uqxetse3cy5eo9z2", "https://images.example.com/2020/missing.jpg"),
+		DownloadType:   DownloadFile,
+		DownloadStatus: DownloadFailed,
+		DownloadError:  "download failed with status 404",
+		UserUID:        "uqxetse3cy5eo9z2",
+		CreatedAt:      time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:      time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+}
+
+// CreateDownloadFixtures inserts known entities into the database for testing.
+func CreateDownloadFixtures() {
+	for _, entity := range DownloadFixtures {
+		Db().Create(&entity)
+	}
+}
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDownload(t *testing.T) {
+	m := NewDownload(" https://example.com/feed.xml ", DownloadFeed, "at9lxuqxpogaaba7", "uqxetse3cy5eo9z2")
+
+	assert.Equal(t, "https://example.com/feed.xml", m.DownloadURL)
+	assert.Equal(t, DownloadHash("uqxetse3cy5eo9z2", "https://example.com/feed.xml"), m.DownloadHash)
+	assert.Equal(t, DownloadFeed, m.DownloadType)
+	assert.Equal(t, DownloadPending, m.DownloadStatus)
+	assert.True(t, m.Feed())
+	assert.Equal(t, DownloadFile, NewDownload("https://example.com/a.jpg", "", "", "").DownloadType)
+}
+
+func TestDownload_Validate(t *testing.T) {
+	assert.NoError(t, NewDownload("https://example.com/a.jpg", DownloadFile, "", "").Validate())
+	assert.Error(t, NewDownload("", DownloadFile, "", "").Validate())
+	assert.Error(t, NewDownload("file:///etc/passwd", DownloadFile, "", "").Validate())
+	assert.Error(t, NewDownload("example.com/a.jpg", DownloadFile, "", "").Validate())
+	assert.Error(t, NewDownload("http://localhost:2342/api/v1/config", DownloadFile, "", "").Validate())
+	assert.Error(t, NewDownload("http://169.254.169.254/latest/meta-data/", DownloadFile, "", "").Validate())
+	assert.Error(t, NewDownload("http://[::1]/a.jpg", DownloadFile, "", "").Validate())
+}
+
+func TestDownload_FirstOrCreate(t *testing.T) {
+	t.Run("Existing", func(t *testing.T) {
+		m := NewDownload(DownloadFixtures.Get("done").DownloadURL, DownloadFile, "", "uqxetse3cy5eo9z2")
+
+		result, created, err := m.FirstOrCreate()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, created)
+		assert.Equal(t, DownloadFixtures.Get("done").ID, result.ID)
+	})
+	t.Run("OtherUser", func(t *testing.T) {
+		m := NewDownload(DownloadFixtures.Get("done").DownloadURL, DownloadFile, "", "uqxc08w3d0ej2283")
+
+		result, created, err := m.FirstOrCreate()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, created)
+		assert.NotEqual(t, DownloadFixtures.Get("done").ID, result.ID)
+		assert.Equal(t, "uqxc08w3d0ej2283", result.UserUID)
+		assert.NoError(t, result.Delete())
+	})
+	t.Run("New", func(t *testing.T) {
+		m := NewDownload("https://images.example.com/2021/new.jpg", DownloadFile, "", "uqxetse3cy5eo9z2")
+
+		result, created, err := m.FirstOrCreate()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, created)
+		assert.NotEmpty(t, result.ID)
+
+		if err := result.Failed(errors.New("not found")); err != nil {
+			t.Fatal(err)
+		}
+
+		found := FindDownload(result.ID)
+
+		if found == nil {
+			t.Fatal("download not found")
+		}
+
+		assert.Equal(t, DownloadFailed, found.DownloadStatus)
+		assert.Equal(t, "not found", found.DownloadError)
+
+		if err := found.Done(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, DownloadDone, FindDownload(result.ID).DownloadStatus)
+		assert.NoError(t, found.Delete())
+		assert.Nil(t, FindDownload(result.ID))
+	})
+}
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/event"
@@ -17,8 +19,10 @@ type Error struct {
 
 type Errors []Error
 
-// SaveErrorMessages subscribes to error logs and stored them in the errors table.
-func SaveErrorMessages() {
+// SaveErrorMessages subscribes to error logs and stored them in the errors table. Absolute file names
+// in the root paths, e.g. originals and sidecar, are stored relative to them so that entries can be
+// linked back to the indexed file.
+func SaveErrorMessages(rootPaths ...string) {
 	s := event.Subscribe("log.*")
 
 	defer func() {
@@ -41,7 +45,7 @@ func SaveErrorMessages() {
 		newError := Error{ErrorLevel: logLevel.String()}
 
 		if val, ok := msg.Fields["message"]; ok {
-			newError.ErrorMessage = val.(string)
+			newError.ErrorMessage = RelErrorMessage(val.(string), rootPaths)
 		}
 
 		if val, ok := msg.Fields["time"]; ok {
@@ -51,3 +55,29 @@ func SaveErrorMessages() {
 		Db().Create(&newError)
 	}
 }
+
+// RelErrorMessage replaces absolute file names in the root paths with originals-relative names.
+func RelErrorMessage(msg string, rootPaths []string) string {
+	if msg == "" || len(rootPaths) == 0 {
+		return msg
+	}
+
+	prefixes := make([]string, 0, len(rootPaths))
+
+	for _, p := range rootPaths {
+		if p = strings.TrimRight(p, "/"); p != "" {
+			prefixes = append(prefixes, p+"/")
+		}
+	}
+
+	// Replace the longest path first in case root paths are nested.
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+
+	for _, p := range prefixes {
+		msg = strings.ReplaceAll(msg, p, "")
+	}
+
+	return msg
+}
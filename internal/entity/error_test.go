@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelErrorMessage(t *testing.T) {
+	roots := []string{"/photoprism/originals", "/photoprism/storage/sidecar/"}
+
+	t.Run("Originals", func(t *testing.T) {
+		assert.Equal(t, "index: failed converting '2020/IMG_1234.heic' to jpeg", RelErrorMessage("index: failed converting '/photoprism/originals/2020/IMG_1234.heic' to jpeg", roots))
+	})
+	t.Run("Sidecar", func(t *testing.T) {
+		assert.Equal(t, "meta: invalid json in 2020/IMG_1234.heic.json", RelErrorMessage("meta: invalid json in /photoprism/storage/sidecar/2020/IMG_1234.heic.json", roots))
+	})
+	t.Run("Relative", func(t *testing.T) {
+		assert.Equal(t, "index: 2020/IMG_1234.heic not found", RelErrorMessage("index: 2020/IMG_1234.heic not found", roots))
+	})
+	t.Run("NoRoots", func(t *testing.T) {
+		assert.Equal(t, "index: /photoprism/originals/a.jpg not found", RelErrorMessage("index: /photoprism/originals/a.jpg not found", nil))
+	})
+}
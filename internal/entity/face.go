@@ -88,6 +88,42 @@ func (m *Face) SetEmbeddings(embeddings face.Embeddings) (err error) {
 	return nil
 }
 
+// UpdateEmbeddings recalculates the cluster midpoint and radius from the given embeddings
+// without changing the face id, so that existing marker references remain valid.
+func (m *Face) UpdateEmbeddings(embeddings face.Embeddings) (err error) {
+	if m.ID == "" {
+		return fmt.Errorf("empty face id")
+	} else if embeddings.Empty() {
+		return fmt.Errorf("no embeddings")
+	}
+
+	radius := m.SampleRadius
+
+	m.embedding, m.SampleRadius, m.Samples = face.EmbeddingsMidpoint(embeddings)
+
+	// Limit sample radius to reduce false positives, and don't let it grow with updates.
+	if m.SampleRadius > 0.35 {
+		m.SampleRadius = 0.35
+	}
+
+	if radius > 0 && m.SampleRadius > radius {
+		m.SampleRadius = radius
+	}
+
+	if m.EmbeddingJSON, err = json.Marshal(m.embedding); err != nil {
+		return err
+	}
+
+	m.UpdatedAt = TimeStamp()
+
+	return m.Updates(Values{
+		"EmbeddingJSON": m.EmbeddingJSON,
+		"SampleRadius":  m.SampleRadius,
+		"Samples":       m.Samples,
+		"UpdatedAt":     m.UpdatedAt,
+	})
+}
+
 // Matched updates the match timestamp.
 func (m *Face) Matched() error {
 	m.MatchedAt = TimePointer()
@@ -278,11 +314,11 @@ func (m *Face) RefreshPhotos() error {
 	case MySQL:
 		update := fmt.Sprintf(`UPDATE photos p JOIN files f ON f.photo_id = p.id JOIN %s m ON m.file_uid = f.file_uid
 			SET p.checked_at = NULL WHERE m.face_id = ?`, Marker{}.TableName())
-		err = UnscopedDb().Exec(update, m.ID).Error
+		err = Exec(update, m.ID).Error
 	default:
 		update := fmt.Sprintf(`UPDATE photos SET checked_at = NULL WHERE id IN (SELECT f.photo_id FROM files f
 			JOIN %s m ON m.file_uid = f.file_uid WHERE m.face_id = ?)`, Marker{}.TableName())
-		err = UnscopedDb().Exec(update, m.ID).Error
+		err = Exec(update, m.ID).Error
 	}
 
 	return err
@@ -165,6 +165,68 @@ func TestFace_SetEmbeddings(t *testing.T) {
 	})
 }
 
+func TestFace_UpdateEmbeddings(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := NewFace("", SrcAuto, MarkerFixtures.Pointer("1000003-4").Embeddings())
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		id := m.ID
+		e := append(MarkerFixtures.Pointer("1000003-4").Embeddings(), MarkerFixtures.Pointer("1000003-6").Embeddings()...)
+
+		if err := m.UpdateEmbeddings(e); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, id, m.ID)
+		assert.Equal(t, 2, m.Samples)
+
+		if found := FindFace(id); found == nil {
+			t.Fatal("face should not be nil")
+		} else {
+			assert.Equal(t, 2, found.Samples)
+			assert.Equal(t, m.Embedding()[0], found.Embedding()[0])
+		}
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("radius", func(t *testing.T) {
+		m := NewFace("", SrcAuto, MarkerFixtures.Pointer("1000003-4").Embeddings())
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		m.SampleRadius = 0.01
+		e := append(MarkerFixtures.Pointer("1000003-4").Embeddings(), MarkerFixtures.Pointer("1000003-6").Embeddings()...)
+
+		if err := m.UpdateEmbeddings(e); err != nil {
+			t.Fatal(err)
+		}
+
+		// The sample radius must not grow with updates.
+		assert.Equal(t, 0.01, m.SampleRadius)
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("empty id", func(t *testing.T) {
+		m := Face{}
+
+		assert.Error(t, m.UpdateEmbeddings(MarkerFixtures.Pointer("1000003-4").Embeddings()))
+	})
+	t.Run("no embeddings", func(t *testing.T) {
+		m := FaceFixtures.Get("joe-biden")
+
+		assert.Error(t, m.UpdateEmbeddings(face.Embeddings{}))
+	})
+}
+
 func TestFace_Embedding(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		m := FaceFixtures.Get("joe-biden")
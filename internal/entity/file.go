@@ -13,6 +13,8 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/ulule/deepcopier"
 
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/crop"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/pkg/colors"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -34,6 +36,8 @@ type Files []File
 var primaryFileMutex = sync.Mutex{}
 
 // File represents an image or sidecar file that belongs to a photo.
+// On MariaDB, the files table is partitioned by a copy of the photo year that triggers keep in sync, and the
+// files_keys table keeps the ID, FileUID, and FileName unique across all partitions, see migration 20220405-102000.
 type File struct {
 	ID               uint          `gorm:"primary_key" json:"-" yaml:"-"`
 	Photo            *Photo        `json:"-" yaml:"-"`
@@ -45,16 +49,21 @@ type File struct {
 	FileRoot         string        `gorm:"type:VARBINARY(16);default:'/';unique_index:idx_files_name_root;" json:"Root" yaml:"Root,omitempty"`
 	OriginalName     string        `gorm:"type:VARBINARY(755);" json:"OriginalName" yaml:"OriginalName,omitempty"`
 	FileHash         string        `gorm:"type:VARBINARY(128);index" json:"Hash" yaml:"Hash,omitempty"`
+	FileInode        string        `gorm:"type:VARBINARY(755);index" json:"-" yaml:"-"`
 	FileSize         int64         `json:"Size" yaml:"Size,omitempty"`
 	FileCodec        string        `gorm:"type:VARBINARY(32)" json:"Codec" yaml:"Codec,omitempty"`
 	FileType         string        `gorm:"type:VARBINARY(32)" json:"Type" yaml:"Type,omitempty"`
 	FileMime         string        `gorm:"type:VARBINARY(64)" json:"Mime" yaml:"Mime,omitempty"`
 	FilePrimary      bool          `json:"Primary" yaml:"Primary,omitempty"`
 	FileSidecar      bool          `json:"Sidecar" yaml:"Sidecar,omitempty"`
+	FileEdited       bool          `json:"Edited" yaml:"Edited,omitempty"`
+	FileEdits        string        `gorm:"type:VARBINARY(512);" json:"Edits,omitempty" yaml:"Edits,omitempty"`
+	FileEditHash     string        `gorm:"type:VARBINARY(128);" json:"EditHash,omitempty" yaml:"-"`
 	FileMissing      bool          `json:"Missing" yaml:"Missing,omitempty"`
 	FilePortrait     bool          `json:"Portrait" yaml:"Portrait,omitempty"`
 	FileVideo        bool          `json:"Video" yaml:"Video,omitempty"`
 	FileDuration     time.Duration `json:"Duration" yaml:"Duration,omitempty"`
+	FilePages        int           `gorm:"default:1;" json:"Pages" yaml:"Pages,omitempty"`
 	FileWidth        int           `json:"Width" yaml:"Width,omitempty"`
 	FileHeight       int           `json:"Height" yaml:"Height,omitempty"`
 	FileOrientation  int           `json:"Orientation" yaml:"Orientation,omitempty"`
@@ -295,14 +304,14 @@ func (m *File) Purge() error {
 	m.FileMissing = true
 	m.FilePrimary = false
 	m.DeletedAt = &deletedAt
-	return UnscopedDb().Exec("UPDATE files SET file_missing = 1, file_primary = 0, deleted_at = ? WHERE id = ?", &deletedAt, m.ID).Error
+	return Exec("UPDATE files SET file_missing = 1, file_primary = 0, deleted_at = ? WHERE id = ?", &deletedAt, m.ID).Error
 }
 
 // Found restores a previously purged file.
 func (m *File) Found() error {
 	m.FileMissing = false
 	m.DeletedAt = nil
-	return UnscopedDb().Exec("UPDATE files SET file_missing = 0, deleted_at = NULL WHERE id = ?", m.ID).Error
+	return Exec("UPDATE files SET file_missing = 0, deleted_at = NULL WHERE id = ?", m.ID).Error
 }
 
 // AllFilesMissing returns true, if all files for the photo of this file are missing.
@@ -343,7 +352,7 @@ func (m *File) ResolvePrimary() error {
 	defer primaryFileMutex.Unlock()
 
 	if m.FilePrimary {
-		return UnscopedDb().Exec("UPDATE `files` SET file_primary = (id = ?) WHERE photo_id = ?", m.ID, m.PhotoID).Error
+		return Exec("UPDATE `files` SET file_primary = (id = ?) WHERE photo_id = ?", m.ID, m.PhotoID).Error
 	}
 
 	return nil
@@ -556,6 +565,87 @@ func (m *File) AddFace(f face.Face, subjUID string) {
 	}
 }
 
+// AddPet adds a pet marker covering the whole image if the labels contain a pet and the file
+// does not have a pet marker yet, so that pets can be named and searched like people. The subject
+// is optional, e.g. if the pet was recognized in a similar photo.
+func (m *File) AddPet(labels classify.Labels, subj *Subject) {
+	label, ok := labels.Pet()
+
+	if !ok {
+		return
+	}
+
+	markers := m.Markers()
+
+	if markers.Pet() != nil {
+		return
+	}
+
+	area := crop.NewArea(label.Name, 0, 0, 1, 1)
+	size := m.FileWidth
+
+	if m.FileHeight < size {
+		size = m.FileHeight
+	}
+
+	marker := NewMarker(*m, area, "", SrcImage, MarkerPet, size, 100-label.Uncertainty)
+
+	// Failed creating new marker?
+	if marker == nil {
+		return
+	}
+
+	if subj != nil {
+		marker.SubjUID = subj.SubjUID
+		marker.SubjSrc = SrcAuto
+		marker.MarkerName = subj.SubjName
+	}
+
+	markers.Append(*marker)
+}
+
+// AddRegion adds a face marker for a region that was tagged in another application, e.g. in Lightroom
+// or Picasa. If a face marker already exists at the same position, only its missing name is added.
+func (m *File) AddRegion(area crop.Area, name, src string, size int) (err error) {
+	marker := NewMarker(*m, area, "", src, MarkerFace, size, 100)
+
+	// Failed creating new marker?
+	if marker == nil {
+		return nil
+	}
+
+	markers := m.Markers()
+
+	for i := range *markers {
+		existing := &(*markers)[i]
+
+		if existing.OverlapPercent(*marker) <= face.OverlapThreshold {
+			continue
+		} else if name == "" || existing.MarkerInvalid || existing.SubjUID != "" {
+			return nil
+		} else if existing.Unsaved() {
+			existing.MarkerName = sanitize.Name(name)
+			existing.SubjSrc = src
+			existing.Subject()
+			return nil
+		} else if changed, err := existing.SetName(name, src); err != nil || !changed {
+			return err
+		}
+
+		return existing.Save()
+	}
+
+	if name = sanitize.Name(name); name != "" {
+		marker.MarkerName = name
+		marker.SubjSrc = src
+		marker.Subject()
+	}
+
+	markers.Append(*marker)
+
+	return nil
+}
+
 // ValidFaceCount returns the number of valid face markers.
 func (m *File) ValidFaceCount() (c int) {
 	return ValidFaceCount(m.FileUID)
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+type BrokenFiles []BrokenFile
+
+// BrokenFile represents an original file that could not be decoded while indexing.
+type BrokenFile struct {
+	FileName  string    `gorm:"type:VARBINARY(755);primary_key;" json:"Name" yaml:"Name"`
+	FileRoot  string    `gorm:"type:VARBINARY(16);primary_key;default:'/';" json:"Root" yaml:"Root,omitempty"`
+	FileSize  int64     `json:"Size" yaml:"Size,omitempty"`
+	FileError string    `gorm:"type:VARBINARY(512);" json:"Error" yaml:"Error,omitempty"`
+	Attempts  int       `json:"Attempts" yaml:"Attempts,omitempty"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (BrokenFile) TableName() string {
+	return "files_broken"
+}
+
+// AddBrokenFile records a file that could not be decoded, or updates the error if it already exists.
+func AddBrokenFile(fileName, fileRoot string, fileSize int64, fileErr error) error {
+	if fileName == "" {
+		return fmt.Errorf("broken file name must not be empty (add)")
+	} else if fileRoot == "" {
+		return fmt.Errorf("broken file root must not be empty (add)")
+	} else if fileErr == nil {
+		return fmt.Errorf("broken file error must not be empty (add)")
+	}
+
+	m := &BrokenFile{FileName: fileName, FileRoot: fileRoot}
+
+	// Keep the number of previous attempts if the file has been recorded before.
+	_ = m.Find()
+
+	m.FileSize = fileSize
+	m.FileError = txt.Clip(fileErr.Error(), 512)
+	m.Attempts++
+
+	return m.Save()
+}
+
+// PurgeBrokenFile removes a file from the list of broken files.
+func PurgeBrokenFile(fileName, fileRoot string) error {
+	if fileName == "" {
+		return fmt.Errorf("broken file name must not be empty (purge)")
+	} else if fileRoot == "" {
+		return fmt.Errorf("broken file root must not be empty (purge)")
+	}
+
+	if err := UnscopedDb().Delete(BrokenFile{}, "file_name = ? AND file_root = ?", fileName, fileRoot).Error; err != nil {
+		log.Errorf("broken file: %s in %s (purge)", err, sanitize.Log(fileName))
+		return err
+	}
+
+	return nil
+}
+
+// Purge removes the file from the list of broken files.
+func (m *BrokenFile) Purge() error {
+	return PurgeBrokenFile(m.FileName, m.FileRoot)
+}
+
+// Find returns a broken file from the database.
+func (m *BrokenFile) Find() error {
+	return UnscopedDb().First(m, "file_name = ? AND file_root = ?", m.FileName, m.FileRoot).Error
+}
+
+// Save updates or inserts a row.
+func (m *BrokenFile) Save() error {
+	if m.FileName == "" {
+		return fmt.Errorf("broken file name must not be empty (save)")
+	}
+
+	return UnscopedDb().Save(m).Error
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrokenFile_TableName(t *testing.T) {
+	assert.Equal(t, "files_broken", BrokenFile{}.TableName())
+}
+
+func TestAddBrokenFile(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		if err := AddBrokenFile("2022/01/broken.jpg", RootOriginals, 1024, errors.New("invalid JPEG format")); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := AddBrokenFile("2022/01/broken.jpg", RootOriginals, 2048, errors.New("unexpected EOF")); err != nil {
+			t.Fatal(err)
+		}
+
+		m := &BrokenFile{FileName: "2022/01/broken.jpg", FileRoot: RootOriginals}
+
+		if err := m.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2, m.Attempts)
+		assert.Equal(t, int64(2048), m.FileSize)
+		assert.Equal(t, "unexpected EOF", m.FileError)
+
+		if err := m.Purge(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, m.Find())
+	})
+	t.Run("NoName", func(t *testing.T) {
+		assert.Error(t, AddBrokenFile("", RootOriginals, 0, errors.New("invalid")))
+	})
+	t.Run("NoRoot", func(t *testing.T) {
+		assert.Error(t, AddBrokenFile("broken.jpg", "", 0, errors.New("invalid")))
+	})
+	t.Run("NoError", func(t *testing.T) {
+		assert.Error(t, AddBrokenFile("broken.jpg", RootOriginals, 0, nil))
+	})
+}
+
+func TestPurgeBrokenFile(t *testing.T) {
+	assert.NoError(t, PurgeBrokenFile("missing.jpg", RootOriginals))
+	assert.Error(t, PurgeBrokenFile("", RootOriginals))
+	assert.Error(t, PurgeBrokenFile("missing.jpg", ""))
+}
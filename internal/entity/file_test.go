@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/crop"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/pkg/colors"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -464,6 +466,54 @@ func TestFile_Undelete(t *testing.T) {
 	})
 }
 
+func TestFile_AddPet(t *testing.T) {
+	t.Run("Dog", func(t *testing.T) {
+		file := &File{FileUID: "fqzuh65p4sjk3pet", FileHash: "446b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileHeight: 480, FileName: "PetTest", PhotoID: 1000003, FilePrimary: true}
+
+		file.AddPet(classify.Labels{{Name: "dog", Source: classify.SrcImage, Uncertainty: 20}}, nil)
+
+		if assert.Equal(t, 1, len(*file.Markers())) {
+			marker := (*file.Markers())[0]
+			assert.Equal(t, MarkerPet, marker.MarkerType)
+			assert.Equal(t, SrcImage, marker.MarkerSrc)
+			assert.Equal(t, float32(1), marker.W)
+			assert.Equal(t, 480, marker.Size)
+			assert.Equal(t, 80, marker.Score)
+			assert.Equal(t, SubjPet, marker.SubjType())
+		}
+
+		// Only one pet marker is added per file.
+		file.AddPet(classify.Labels{{Name: "cat", Source: classify.SrcImage, Uncertainty: 10}}, nil)
+		assert.Equal(t, 1, len(*file.Markers()))
+
+		if err := file.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, file.UnsavedMarkers())
+	})
+	t.Run("NoPet", func(t *testing.T) {
+		file := &File{FileUID: "fqzuh65p4sjk3pe1", FileHash: "546b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileHeight: 480, FileName: "PetTest", PhotoID: 1000003, FilePrimary: true}
+
+		file.AddPet(classify.Labels{{Name: "snow", Source: classify.SrcImage, Uncertainty: 20}}, nil)
+
+		assert.Equal(t, 0, len(*file.Markers()))
+	})
+	t.Run("Subject", func(t *testing.T) {
+		file := &File{FileUID: "fqzuh65p4sjk3pe2", FileHash: "646b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileHeight: 480, FileName: "PetTest", PhotoID: 1000003, FilePrimary: true}
+		subj := &Subject{SubjUID: "jqzuh65p4sjk3pet", SubjName: "Rex", SubjType: SubjPet}
+
+		file.AddPet(classify.Labels{{Name: "dog", Source: classify.SrcImage, Uncertainty: 20}}, subj)
+
+		if assert.Equal(t, 1, len(*file.Markers())) {
+			marker := (*file.Markers())[0]
+			assert.Equal(t, "jqzuh65p4sjk3pet", marker.SubjUID)
+			assert.Equal(t, SrcAuto, marker.SubjSrc)
+			assert.Equal(t, "Rex", marker.MarkerName)
+		}
+	})
+}
+
 func TestFile_AddFaces(t *testing.T) {
 	t.Run("Primary", func(t *testing.T) {
 		file := &File{FileUID: "fqzuh65p4sjk3kdn", FileHash: "346b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileName: "FacesTest", PhotoID: 1000003, FilePrimary: true}
@@ -508,6 +558,64 @@ func TestFile_AddFaces(t *testing.T) {
 	})
 }
 
+func TestFile_AddRegion(t *testing.T) {
+	t.Run("Named", func(t *testing.T) {
+		file := &File{FileUID: "fqzuh65p4sjk3kr1", FileHash: "246b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileName: "RegionsTest", PhotoID: 1000003, FilePrimary: false}
+
+		if err := file.AddRegion(crop.NewArea("face", 0.2, 0.25, 0.2, 0.3), "Jane Region", SrcXmp, 144); err != nil {
+			t.Fatal(err)
+		}
+
+		// Overlapping regions are skipped.
+		if err := file.AddRegion(crop.NewArea("face", 0.21, 0.25, 0.2, 0.3), "John Region", SrcMeta, 144); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := file.AddRegion(crop.NewArea("face", 0.6, 0.4, 0.1, 0.2), "", SrcMeta, 72); err != nil {
+			t.Fatal(err)
+		}
+
+		markers := *file.Markers()
+
+		if len(markers) != 2 {
+			t.Fatalf("expected 2 markers, found %d", len(markers))
+		}
+
+		assert.Equal(t, "Jane Region", markers[0].MarkerName)
+		assert.Equal(t, SrcXmp, markers[0].SubjSrc)
+		assert.Equal(t, SrcXmp, markers[0].MarkerSrc)
+		assert.Equal(t, MarkerFace, markers[0].MarkerType)
+		assert.False(t, markers[0].MarkerReview)
+		assert.NotEmpty(t, markers[0].SubjUID)
+		assert.Equal(t, "", markers[1].MarkerName)
+		assert.Equal(t, "", markers[1].SubjUID)
+
+		if subj := FindSubject(markers[0].SubjUID); subj == nil {
+			t.Fatal("subject not found")
+		} else {
+			assert.Equal(t, "Jane Region", subj.SubjName)
+		}
+	})
+	t.Run("NameDetectedFace", func(t *testing.T) {
+		file := &File{FileUID: "fqzuh65p4sjk3kr2", FileHash: "346b3897eec9ef75e35fbf0bbc4c83c55ca41e32", FileType: "jpg", FileWidth: 720, FileName: "RegionsTest", PhotoID: 1000003, FilePrimary: false}
+
+		area := crop.NewArea("face", 0.3, 0.3, 0.2, 0.3)
+		file.Markers().Append(*NewMarker(*file, area, "", SrcImage, MarkerFace, 144, 50))
+
+		if err := file.AddRegion(area, "Detected Region", SrcXmp, 144); err != nil {
+			t.Fatal(err)
+		}
+
+		markers := *file.Markers()
+
+		assert.Len(t, markers, 1)
+		assert.Equal(t, SrcImage, markers[0].MarkerSrc)
+		assert.Equal(t, "Detected Region", markers[0].MarkerName)
+		assert.Equal(t, SrcXmp, markers[0].SubjSrc)
+		assert.NotEmpty(t, markers[0].SubjUID)
+	})
+}
+
 func TestFile_ValidFaceCount(t *testing.T) {
 	t.Run("FileFixturesExampleBridge", func(t *testing.T) {
 		file := FileFixturesExampleBridge
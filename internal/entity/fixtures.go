@@ -8,12 +8,19 @@ func CreateTestFixtures() {
 
 	CreateLabelFixtures()
 	CreateCameraFixtures()
+	CreateCameraAliasFixtures()
 	CreateCountryFixtures()
 	CreatePhotoFixtures()
 	CreateAlbumFixtures()
 	CreateAccountFixtures()
 	CreateLinkFixtures()
 	CreatePhotoAlbumFixtures()
+	CreateAlbumMemberFixtures()
+	CreateSearchFixtures()
+	CreateDownloadFixtures()
+	CreateTagFixtures()
+	CreatePushSubscriptionFixtures()
+	CreateActivityFixtures()
 	CreateFolderFixtures()
 	CreateFileFixtures()
 	CreateKeywordFixtures()
@@ -41,6 +41,7 @@ type Folder struct {
 	CreatedAt         time.Time  `json:"-" yaml:"-"`
 	UpdatedAt         time.Time  `json:"-" yaml:"-"`
 	ModifiedAt        time.Time  `json:"ModifiedAt,omitempty" yaml:"-"`
+	ModTime           int64      `json:"-" yaml:"-"`
 	DeletedAt         *time.Time `sql:"index" json:"-"`
 }
 
@@ -221,6 +222,17 @@ func FirstOrCreateFolder(m *Folder) *Folder {
 	return nil
 }
 
+// UpdateFolderModTime stores the directory modification time found during the last complete scan.
+func UpdateFolderModTime(root, pathName string, modTime int64) error {
+	pathName = strings.Trim(pathName, string(os.PathSeparator))
+
+	if pathName == RootPath {
+		pathName = ""
+	}
+
+	return Db().Model(&Folder{}).Where("path = ? AND root = ?", pathName, root).UpdateColumn("mod_time", modTime).Error
+}
+
 // Updates selected properties in the database.
 func (m *Folder) Updates(values interface{}) error {
 	return Db().Model(m).Updates(values).Error
@@ -207,3 +207,26 @@ func TestFolder_Create(t *testing.T) {
 		assert.Equal(t, "Holiday 2020", result.Title())
 	})
 }
+
+func TestUpdateFolderModTime(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		if err := UpdateFolderModTime(RootOriginals, "/2007/12/", 1608926400); err != nil {
+			t.Fatal(err)
+		}
+
+		var m Folder
+
+		if err := Db().Where("path = ? AND root = ?", "2007/12", RootOriginals).First(&m).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(1608926400), m.ModTime)
+
+		if err := UpdateFolderModTime(RootOriginals, "2007/12", 0); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.NoError(t, UpdateFolderModTime(RootOriginals, "xxx/yyy", 1608926400))
+	})
+}
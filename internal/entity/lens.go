@@ -106,6 +106,14 @@ func FirstOrCreateLens(m *Lens) *Lens {
 
 	result := Lens{}
 
+	// Merge alternative names into the lens they are an alias of.
+	if alias := FindCameraAlias(AliasLens, m.LensSlug); alias != nil {
+		if res := Db().Where("lens_slug = ?", alias.TargetSlug).First(&result); res.Error == nil {
+			lensCache.SetDefault(m.LensSlug, &result)
+			return &result
+		}
+	}
+
 	if res := Db().Where("lens_slug = ?", m.LensSlug).First(&result); res.Error == nil {
 		lensCache.SetDefault(m.LensSlug, &result)
 		return &result
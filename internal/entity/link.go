@@ -13,20 +13,51 @@ import (
 
 type Links []Link
 
+// Geo privacy levels of sharing links.
+const (
+	GeoExact = ""
+	GeoRound = "round"
+	GeoStrip = "strip"
+)
+
+// Metadata that can be redacted in shared content.
+const (
+	RedactSerial    = "serial"
+	RedactOwner     = "owner"
+	RedactGps       = "gps"
+	RedactFilenames = "filenames"
+)
+
+// GeoStricter returns the stricter of two geo privacy levels.
+func GeoStricter(a, b string) string {
+	switch {
+	case a == GeoStrip || b == GeoStrip:
+		return GeoStrip
+	case a == GeoRound || b == GeoRound:
+		return GeoRound
+	default:
+		return GeoExact
+	}
+}
+
 // Link represents a sharing link.
 type Link struct {
-	LinkUID     string    `gorm:"type:VARBINARY(42);primary_key;" json:"UID,omitempty" yaml:"UID,omitempty"`
-	ShareUID    string    `gorm:"type:VARBINARY(42);unique_index:idx_links_uid_token;" json:"Share" yaml:"Share"`
-	ShareSlug   string    `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"Slug,omitempty"`
-	LinkToken   string    `gorm:"type:VARBINARY(160);unique_index:idx_links_uid_token;" json:"Token" yaml:"Token,omitempty"`
-	LinkExpires int       `json:"Expires" yaml:"Expires,omitempty"`
-	LinkViews   uint      `json:"Views" yaml:"-"`
-	MaxViews    uint      `json:"MaxViews" yaml:"-"`
-	HasPassword bool      `json:"HasPassword" yaml:"HasPassword,omitempty"`
-	CanComment  bool      `json:"CanComment" yaml:"CanComment,omitempty"`
-	CanEdit     bool      `json:"CanEdit" yaml:"CanEdit,omitempty"`
-	CreatedAt   time.Time `deepcopier:"skip" json:"CreatedAt" yaml:"CreatedAt"`
-	ModifiedAt  time.Time `deepcopier:"skip" json:"ModifiedAt" yaml:"ModifiedAt"`
+	LinkUID      string    `gorm:"type:VARBINARY(42);primary_key;" json:"UID,omitempty" yaml:"UID,omitempty"`
+	ShareUID     string    `gorm:"type:VARBINARY(42);unique_index:idx_links_uid_token;" json:"Share" yaml:"Share"`
+	ShareSlug    string    `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"Slug,omitempty"`
+	LinkToken    string    `gorm:"type:VARBINARY(160);unique_index:idx_links_uid_token;" json:"Token" yaml:"Token,omitempty"`
+	LinkExpires  int       `json:"Expires" yaml:"Expires,omitempty"`
+	LinkViews    uint      `json:"Views" yaml:"Views,omitempty"`
+	MaxViews     uint      `json:"MaxViews" yaml:"MaxViews,omitempty"`
+	HasPassword  bool      `json:"HasPassword" yaml:"HasPassword,omitempty"`
+	PasswordHash string    `gorm:"-" json:"-" yaml:"PasswordHash,omitempty"`
+	CanComment   bool      `json:"CanComment" yaml:"CanComment,omitempty"`
+	CanEdit      bool      `json:"CanEdit" yaml:"CanEdit,omitempty"`
+	CanUpload    bool      `json:"CanUpload" yaml:"CanUpload,omitempty"`
+	LinkReview   bool      `json:"Review" yaml:"Review,omitempty"`
+	LinkGeo      string    `gorm:"type:VARBINARY(16);" json:"Geo" yaml:"Geo,omitempty"`
+	CreatedAt    time.Time `deepcopier:"skip" json:"CreatedAt" yaml:"CreatedAt"`
+	ModifiedAt   time.Time `deepcopier:"skip" json:"ModifiedAt" yaml:"ModifiedAt"`
 }
 
 // BeforeCreate creates a random UID if needed before inserting a new row to the database.
@@ -191,6 +222,21 @@ func FindValidLinks(token, share string) (result Links) {
 	return result
 }
 
+// Uploads tests if guests may upload files to the shared album.
+func (m *Link) Uploads() bool {
+	return m.CanUpload && rnd.IsPPID(m.ShareUID, 'a')
+}
+
+// SetGeo sets the geo privacy level, unknown values default to exact coordinates.
+func (m *Link) SetGeo(level string) {
+	switch level {
+	case GeoRound, GeoStrip:
+		m.LinkGeo = level
+	default:
+		m.LinkGeo = GeoExact
+	}
+}
+
 // String returns an human readable identifier for logging.
 func (m *Link) String() string {
 	return sanitize.Log(m.LinkUID)
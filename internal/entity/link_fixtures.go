@@ -32,6 +32,7 @@ var LinkFixtures = LinkMap{
 		HasPassword: false,
 		CanComment:  true,
 		CanEdit:     false,
+		LinkGeo:     GeoRound,
 		CreatedAt:   time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
 		ModifiedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
 	},
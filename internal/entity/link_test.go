@@ -45,6 +45,21 @@ func TestLink_Expired(t *testing.T) {
 	assert.True(t, link.Expired())
 }
 
+func TestLink_Uploads(t *testing.T) {
+	album := NewLink("at9lxuqxpogaaba8", false, false)
+
+	assert.False(t, album.Uploads())
+
+	album.CanUpload = true
+
+	assert.True(t, album.Uploads())
+
+	photo := NewLink("pt9k3pw1wowuy3c3", false, false)
+	photo.CanUpload = true
+
+	assert.False(t, photo.Uploads())
+}
+
 func TestLink_Redeem(t *testing.T) {
 	link := NewLink(rnd.PPID('a'), false, false)
 
@@ -195,3 +210,19 @@ func TestLink_String(t *testing.T) {
 		assert.Equal(t, uid, link.String())
 	})
 }
+
+func TestLink_SetGeo(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba9", false, false)
+	assert.Equal(t, GeoExact, link.LinkGeo)
+	link.SetGeo(GeoStrip)
+	assert.Equal(t, GeoStrip, link.LinkGeo)
+	link.SetGeo("foo")
+	assert.Equal(t, GeoExact, link.LinkGeo)
+}
+
+func TestGeoStricter(t *testing.T) {
+	assert.Equal(t, GeoExact, GeoStricter(GeoExact, GeoExact))
+	assert.Equal(t, GeoRound, GeoStricter(GeoExact, GeoRound))
+	assert.Equal(t, GeoStrip, GeoStricter(GeoStrip, GeoRound))
+	assert.Equal(t, GeoStrip, GeoStricter(GeoExact, GeoStrip))
+}
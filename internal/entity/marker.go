@@ -23,6 +23,7 @@ const (
 	MarkerUnknown = ""
 	MarkerFace    = "face"  // MarkerType for faces (implemented).
 	MarkerLabel   = "label" // MarkerType for labels (todo).
+	MarkerPet     = "pet"   // MarkerType for pets detected by the image classifier.
 )
 
 // Marker represents an image marker point.
@@ -310,8 +311,8 @@ func (m *Marker) SetFace(f *Face, dist float64) (updated bool, err error) {
 
 // SyncSubject maintains the marker subject relationship.
 func (m *Marker) SyncSubject(updateRelated bool) (err error) {
-	// Face marker? If not, return.
-	if m.MarkerType != MarkerFace {
+	// Face or pet marker? If not, return.
+	if m.MarkerType != MarkerFace && m.MarkerType != MarkerPet {
 		return nil
 	}
 
@@ -333,6 +334,11 @@ func (m *Marker) SyncSubject(updateRelated bool) (err error) {
 		m.MarkerName = subj.SubjName
 	}
 
+	// Pets don't have face embeddings.
+	if m.MarkerType != MarkerFace {
+		return nil
+	}
+
 	// Create known face for subject?
 	if m.FaceID != "" {
 		// Do nothing.
@@ -418,6 +424,15 @@ func (m *Marker) SubjectName() string {
 	return ""
 }
 
+// SubjType returns the type of subject the marker refers to.
+func (m *Marker) SubjType() string {
+	if m.MarkerType == MarkerPet {
+		return SubjPet
+	}
+
+	return SubjPerson
+}
+
 // Subject returns the matching subject or nil.
 func (m *Marker) Subject() (subj *Subject) {
 	if m.subject != nil {
@@ -428,7 +443,7 @@ func (m *Marker) Subject() (subj *Subject) {
 
 	// Create subject?
 	if m.SubjSrc != SrcAuto && m.MarkerName != "" && m.SubjUID == "" {
-		if subj = NewSubject(m.MarkerName, SubjPerson, m.SubjSrc); subj == nil {
+		if subj = NewSubject(m.MarkerName, m.SubjType(), m.SubjSrc); subj == nil {
 			log.Errorf("marker %s: invalid subject %s", sanitize.Log(m.MarkerUID), sanitize.Log(m.MarkerName))
 			return nil
 		} else if subj = FirstOrCreateSubject(subj); subj == nil {
@@ -559,12 +574,12 @@ func (m *Marker) RefreshPhotos() error {
 	var err error
 	switch DbDialect() {
 	case MySQL:
-		err = UnscopedDb().Exec(`UPDATE photos p JOIN files f ON f.photo_id = p.id
+		err = Exec(`UPDATE photos p JOIN files f ON f.photo_id = p.id
 			JOIN ? m ON m.file_uid = f.file_uid SET p.checked_at = NULL
 			WHERE m.marker_uid = ?`,
 			gorm.Expr(Marker{}.TableName()), m.MarkerUID).Error
 	default:
-		err = UnscopedDb().Exec(`UPDATE photos SET checked_at = NULL WHERE id IN
+		err = Exec(`UPDATE photos SET checked_at = NULL WHERE id IN
 			(SELECT f.photo_id FROM files f JOIN ? m ON m.file_uid = f.file_uid
 			WHERE m.marker_uid = ? GROUP BY f.photo_id)`,
 			gorm.Expr(Marker{}.TableName()), m.MarkerUID).Error
@@ -514,6 +514,23 @@ func TestMarker_Subject(t *testing.T) {
 			assert.NotEmpty(t, s.SubjUID)
 		}
 	})
+	t.Run("Pet", func(t *testing.T) {
+		m := Marker{MarkerType: MarkerPet, SubjSrc: SrcManual, SubjUID: "", MarkerName: "Bello"}
+
+		if s := m.Subject(); s == nil {
+			t.Fatal("return value must not be nil")
+		} else {
+			assert.Equal(t, "Bello", s.SubjName)
+			assert.Equal(t, SubjPet, s.SubjType)
+			assert.True(t, s.IsPet())
+			assert.NotEmpty(t, s.SubjUID)
+		}
+	})
+}
+
+func TestMarker_SubjType(t *testing.T) {
+	assert.Equal(t, SubjPerson, (&Marker{MarkerType: MarkerFace}).SubjType())
+	assert.Equal(t, SubjPet, (&Marker{MarkerType: MarkerPet}).SubjType())
 }
 
 func TestMarker_GetFace(t *testing.T) {
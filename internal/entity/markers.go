@@ -79,7 +79,7 @@ func (m Markers) ValidFaceCount() (count int) {
 // SubjectNames returns known subject names.
 func (m Markers) SubjectNames() (names []string) {
 	for i := range m {
-		if m[i].MarkerInvalid || m[i].MarkerType != MarkerFace {
+		if m[i].MarkerInvalid || m[i].MarkerType != MarkerFace && m[i].MarkerType != MarkerPet {
 			continue
 		} else if n := m[i].SubjectName(); n != "" {
 			names = append(names, n)
@@ -89,6 +89,17 @@ func (m Markers) SubjectNames() (names []string) {
 	return txt.UniqueNames(names)
 }
 
+// Pet returns the first pet marker, or nil if there is none.
+func (m Markers) Pet() *Marker {
+	for i := range m {
+		if m[i].MarkerType == MarkerPet {
+			return &m[i]
+		}
+	}
+
+	return nil
+}
+
 // Labels returns matching labels.
 func (m Markers) Labels() (result classify.Labels) {
 	faceCount := 0
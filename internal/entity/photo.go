@@ -39,12 +39,25 @@ func (m Photos) UIDs() []string {
 	return result
 }
 
+// Locked tests if at least one of the photos is locked.
+func (m Photos) Locked() bool {
+	for _, p := range m {
+		if p.PhotoLocked {
+			return true
+		}
+	}
+
+	return false
+}
+
 // MapKey returns a key referencing time and location for indexing.
 func MapKey(takenAt time.Time, cellId string) string {
 	return path.Join(strconv.FormatInt(takenAt.Unix(), 36), cellId)
 }
 
 // Photo represents a photo, all its properties, and link to all its images and sidecar files.
+// On MariaDB, the photos table is partitioned by year, so that the photos_keys table and its triggers keep
+// the ID and PhotoUID unique across all partitions, see migration 20220405-101500.
 type Photo struct {
 	ID               uint         `gorm:"primary_key" yaml:"-"`
 	UUID             string       `gorm:"type:VARBINARY(42);index;" json:"DocumentID,omitempty" yaml:"DocumentID,omitempty"`
@@ -63,20 +76,27 @@ type Photo struct {
 	OriginalName     string       `gorm:"type:VARBINARY(755);" json:"OriginalName" yaml:"OriginalName,omitempty"`
 	PhotoStack       int8         `json:"Stack" yaml:"Stack,omitempty"`
 	PhotoFavorite    bool         `json:"Favorite" yaml:"Favorite,omitempty"`
+	PhotoRating      int          `gorm:"type:SMALLINT" json:"Rating" yaml:"Rating,omitempty"`
+	RatingSrc        string       `gorm:"type:VARBINARY(8);" json:"RatingSrc" yaml:"RatingSrc,omitempty"`
 	PhotoPrivate     bool         `json:"Private" yaml:"Private,omitempty"`
+	PhotoLocked      bool         `json:"Locked" yaml:"Locked,omitempty"`
+	PhotoPending     bool         `json:"Pending" yaml:"Pending,omitempty"`
 	PhotoScan        bool         `json:"Scan" yaml:"Scan,omitempty"`
 	PhotoPanorama    bool         `json:"Panorama" yaml:"Panorama,omitempty"`
+	PhotoProjection  string       `gorm:"type:VARBINARY(40);" json:"Projection,omitempty" yaml:"Projection,omitempty"`
 	TimeZone         string       `gorm:"type:VARBINARY(64);" json:"TimeZone" yaml:"TimeZone,omitempty"`
 	PlaceID          string       `gorm:"type:VARBINARY(42);index;default:'zz'" json:"PlaceID" yaml:"-"`
 	PlaceSrc         string       `gorm:"type:VARBINARY(8);" json:"PlaceSrc" yaml:"PlaceSrc,omitempty"`
 	CellID           string       `gorm:"type:VARBINARY(42);index;default:'zz'" json:"CellID" yaml:"-"`
 	CellAccuracy     int          `json:"CellAccuracy" yaml:"CellAccuracy,omitempty"`
 	PhotoAltitude    int          `json:"Altitude" yaml:"Altitude,omitempty"`
-	PhotoLat         float32      `gorm:"type:FLOAT;index;" json:"Lat" yaml:"Lat,omitempty"`
-	PhotoLng         float32      `gorm:"type:FLOAT;index;" json:"Lng" yaml:"Lng,omitempty"`
+	PhotoHeading     float32      `gorm:"type:FLOAT;" json:"Heading" yaml:"Heading,omitempty"`
+	PhotoSpeed       float32      `gorm:"type:FLOAT;" json:"Speed" yaml:"Speed,omitempty"`
+	PhotoLat         float32      `gorm:"type:FLOAT;index:idx_photos_photo_lat,idx_photos_lat_lng;" json:"Lat" yaml:"Lat,omitempty"`
+	PhotoLng         float32      `gorm:"type:FLOAT;index:idx_photos_photo_lng,idx_photos_lat_lng;" json:"Lng" yaml:"Lng,omitempty"`
 	PhotoCountry     string       `gorm:"type:VARBINARY(2);index:idx_photos_country_year_month;default:'zz'" json:"Country" yaml:"-"`
-	PhotoYear        int          `gorm:"index:idx_photos_ymd;index:idx_photos_country_year_month;" json:"Year" yaml:"Year"`
-	PhotoMonth       int          `gorm:"index:idx_photos_ymd;index:idx_photos_country_year_month;" json:"Month" yaml:"Month"`
+	PhotoYear        int          `gorm:"index:idx_photos_ymd,idx_photos_country_year_month;" json:"Year" yaml:"Year"`
+	PhotoMonth       int          `gorm:"index:idx_photos_ymd,idx_photos_country_year_month;" json:"Month" yaml:"Month"`
 	PhotoDay         int          `gorm:"index:idx_photos_ymd" json:"Day" yaml:"Day"`
 	PhotoIso         int          `json:"Iso" yaml:"ISO,omitempty"`
 	PhotoExposure    string       `gorm:"type:VARBINARY(64);" json:"Exposure" yaml:"Exposure,omitempty"`
@@ -91,12 +111,14 @@ type Photo struct {
 	CameraSrc        string       `gorm:"type:VARBINARY(8);" json:"CameraSrc" yaml:"-"`
 	LensID           uint         `gorm:"index:idx_photos_camera_lens;default:1" json:"LensID" yaml:"-"`
 	Details          *Details     `gorm:"association_autoupdate:false;association_autocreate:false;association_save_reference:false" json:"Details" yaml:"Details"`
+	Locales          PhotoLocales `gorm:"-" json:"Locales" yaml:"Locales,omitempty"`
 	Camera           *Camera      `gorm:"association_autoupdate:false;association_autocreate:false;association_save_reference:false" json:"Camera" yaml:"-"`
 	Lens             *Lens        `gorm:"association_autoupdate:false;association_autocreate:false;association_save_reference:false" json:"Lens" yaml:"-"`
 	Cell             *Cell        `gorm:"association_autoupdate:false;association_autocreate:false;association_save_reference:false" json:"Cell" yaml:"-"`
 	Place            *Place       `gorm:"association_autoupdate:false;association_autocreate:false;association_save_reference:false" json:"Place" yaml:"-"`
 	Keywords         []Keyword    `json:"-" yaml:"-"`
 	Albums           []Album      `json:"-" yaml:"-"`
+	Tags             []Tag        `json:"Tags" yaml:"-"`
 	Files            []File       `yaml:"-"`
 	Labels           []PhotoLabel `yaml:"-"`
 	CreatedAt        time.Time    `yaml:"CreatedAt,omitempty"`
@@ -140,6 +162,7 @@ func NewPhoto(stackable bool) Photo {
 // SavePhotoForm saves a model in the database using form data.
 func SavePhotoForm(model Photo, form form.Photo) error {
 	locChanged := model.PhotoLat != form.PhotoLat || model.PhotoLng != form.PhotoLng || model.PhotoCountry != form.PhotoCountry
+	ratingChanged := model.PhotoRating != form.PhotoRating
 
 	if err := deepcopier.Copy(&model).From(form); err != nil {
 		return err
@@ -158,6 +181,12 @@ func SavePhotoForm(model Photo, form form.Photo) error {
 
 	model.UpdateDateFields()
 
+	// Manually changed ratings take precedence over ratings found in metadata.
+	if ratingChanged {
+		model.PhotoRating = ClampRating(model.PhotoRating)
+		model.RatingSrc = SrcManual
+	}
+
 	details := model.GetDetails()
 
 	if form.Details.PhotoID == model.ID {
@@ -199,10 +228,8 @@ func SavePhotoForm(model Photo, form form.Photo) error {
 		return err
 	}
 
-	// Update precalculated photo and file counts.
-	if err := UpdateCounts(); err != nil {
-		log.Warnf("index: %s (update counts)", err)
-	}
+	// Update precalculated photo and file counts in the background.
+	UpdateCountsAsync(nil)
 
 	return nil
 }
@@ -249,6 +276,10 @@ func (m *Photo) Create() error {
 		return err
 	}
 
+	if err := m.SaveLocales(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -265,6 +296,10 @@ func (m *Photo) Save() error {
 		return err
 	}
 
+	if err := m.SaveLocales(); err != nil {
+		return err
+	}
+
 	return m.ResolvePrimary()
 }
 
@@ -327,10 +362,8 @@ func (m *Photo) SaveLabels() error {
 		return err
 	}
 
-	// Update precalculated photo and file counts.
-	if err := UpdateCounts(); err != nil {
-		log.Warnf("index: %s (update counts)", err)
-	}
+	// Update precalculated photo and file counts in the background.
+	UpdateCountsAsync(nil)
 
 	return nil
 }
@@ -435,6 +468,7 @@ func (m *Photo) IndexKeywords() error {
 	keywords = append(keywords, txt.Words(details.Keywords)...)
 	keywords = append(keywords, txt.Keywords(details.Subject)...)
 	keywords = append(keywords, txt.Keywords(details.Artist)...)
+	keywords = append(keywords, txt.Keywords(details.Caption)...)
 
 	keywords = txt.UniqueWords(keywords)
 
@@ -492,11 +526,24 @@ func (m *Photo) PreloadAlbums() {
 	Log("photo", "preload albums", q.Scan(&m.Albums).Error)
 }
 
-// PreloadMany prepares gorm scope to retrieve photo file, albums and keywords
+// PreloadTags prepares gorm scope to retrieve personal photo tags
+func (m *Photo) PreloadTags() {
+	q := Db().NewScope(nil).DB().
+		Table("tags").
+		Select(`tags.*`).
+		Joins("JOIN photos_tags ON photos_tags.tag_uid = tags.tag_uid AND photos_tags.photo_uid = ?", m.PhotoUID).
+		Order("tags.tag_name ASC")
+
+	Log("photo", "preload tags", q.Scan(&m.Tags).Error)
+}
+
+// PreloadMany prepares gorm scope to retrieve photo file, albums, keywords and tags
 func (m *Photo) PreloadMany() {
 	m.PreloadFiles()
 	m.PreloadKeywords()
 	m.PreloadAlbums()
+	m.PreloadTags()
+	m.PreloadLocales()
 }
 
 // HasID tests if the photo has a database id and uid.
@@ -603,7 +650,7 @@ func (m *Photo) SetDescription(desc, source string) {
 		return
 	}
 
-	if (SrcPriority[source] < SrcPriority[m.DescriptionSrc]) && m.HasDescription() {
+	if (FieldPriority(FieldDescription, source) < FieldPriority(FieldDescription, m.DescriptionSrc)) && m.HasDescription() {
 		return
 	}
 
@@ -622,7 +669,7 @@ func (m *Photo) SetCamera(camera *Camera, source string) {
 		return
 	}
 
-	if SrcPriority[source] < SrcPriority[m.CameraSrc] && !m.UnknownCamera() {
+	if FieldPriority(FieldCamera, source) < FieldPriority(FieldCamera, m.CameraSrc) && !m.UnknownCamera() {
 		return
 	}
 
@@ -642,7 +689,7 @@ func (m *Photo) SetLens(lens *Lens, source string) {
 		return
 	}
 
-	if SrcPriority[source] < SrcPriority[m.CameraSrc] && !m.UnknownLens() {
+	if FieldPriority(FieldCamera, source) < FieldPriority(FieldCamera, m.CameraSrc) && !m.UnknownLens() {
 		return
 	}
 
@@ -652,7 +699,7 @@ func (m *Photo) SetLens(lens *Lens, source string) {
 
 // SetExposure updates the photo exposure details.
 func (m *Photo) SetExposure(focalLength int, fNumber float32, iso int, exposure, source string) {
-	hasPriority := SrcPriority[source] >= SrcPriority[m.CameraSrc]
+	hasPriority := FieldPriority(FieldCamera, source) >= FieldPriority(FieldCamera, m.CameraSrc)
 
 	if focalLength > 0 && (hasPriority || m.PhotoFocalLength <= 0) {
 		m.PhotoFocalLength = focalLength
@@ -766,11 +813,42 @@ func (m *Photo) DeletePermanently() (files Files, err error) {
 		log.Errorf("photo: %s (remove labels)", err)
 	}
 
+	// Remember the albums the photo was in, so that the deletion can be reported to guests.
+	var albumUIDs []string
+
+	if err := UnscopedDb().Model(PhotoAlbum{}).Where("photo_uid = ? AND hidden = 0", m.PhotoUID).Pluck("album_uid", &albumUIDs).Error; err != nil {
+		log.Errorf("photo: %s (find albums)", err)
+	}
+
 	if err := UnscopedDb().Delete(PhotoAlbum{}, "photo_uid = ?", m.PhotoUID).Error; err != nil {
 		log.Errorf("photo: %s (remove albums)", err)
 	}
 
-	return files, UnscopedDb().Delete(m).Error
+	if err := UnscopedDb().Delete(PhotoTag{}, "photo_uid = ?", m.PhotoUID).Error; err != nil {
+		log.Errorf("photo: %s (remove tags)", err)
+	}
+
+	if err := UnscopedDb().Delete(PhotoTimelapse{}, "video_uid = ? OR photo_uid = ?", m.PhotoUID, m.PhotoUID).Error; err != nil {
+		log.Errorf("photo: %s (remove time-lapse links)", err)
+	}
+
+	if err := UnscopedDb().Delete(PhotoLocale{}, "photo_id = ?", m.ID).Error; err != nil {
+		log.Errorf("photo: %s (remove locales)", err)
+	}
+
+	if err := DeletePhotoEmbedding(m.ID); err != nil {
+		log.Errorf("photo: %s (remove embedding)", err)
+	}
+
+	if err := UnscopedDb().Delete(m).Error; err != nil {
+		return files, err
+	}
+
+	if err := AddTombstones(TombPhoto, m.PhotoUID, albumUIDs); err != nil {
+		log.Errorf("photo: %s (add tombstone)", err)
+	}
+
+	return files, nil
 }
 
 // NoDescription returns true if the photo has no description.
@@ -814,6 +892,13 @@ func (m *Photo) SetFavorite(favorite bool) error {
 	return nil
 }
 
+// SetLocked updates the lock flag of a photo, locked photos can't be changed, archived or deleted.
+func (m *Photo) SetLocked(locked bool) error {
+	m.PhotoLocked = locked
+
+	return m.Update("PhotoLocked", m.PhotoLocked)
+}
+
 // SetStack updates the stack flag of a photo.
 func (m *Photo) SetStack(stack int8) {
 	if m.PhotoStack != stack {
@@ -824,7 +909,7 @@ func (m *Photo) SetStack(stack int8) {
 
 // Approve approves a photo in review.
 func (m *Photo) Approve() error {
-	if m.PhotoQuality >= 3 {
+	if m.PhotoQuality >= 3 && !m.PhotoPending {
 		// Nothing to do.
 		return nil
 	}
@@ -832,15 +917,19 @@ func (m *Photo) Approve() error {
 	edited := TimeStamp()
 	m.EditedAt = &edited
 	m.PhotoQuality = m.QualityScore()
-
-	if err := Db().Model(m).Updates(Photo{EditedAt: m.EditedAt, PhotoQuality: m.PhotoQuality}).Error; err != nil {
+	m.PhotoPending = false
+
+	// Use a map, so that the pending flag is also updated if false.
+	if err := Db().Model(m).Updates(Values{
+		"EditedAt":     m.EditedAt,
+		"PhotoQuality": m.PhotoQuality,
+		"PhotoPending": m.PhotoPending,
+	}).Error; err != nil {
 		return err
 	}
 
-	// Update precalculated photo and file counts.
-	if err := UpdateCounts(); err != nil {
-		log.Warnf("index: %s (update counts)", err)
-	}
+	// Update precalculated photo and file counts in the background.
+	UpdateCountsAsync(nil)
 
 	event.Publish("count.review", event.Data{
 		"count": -1,
@@ -901,6 +990,16 @@ func (m *Photo) MapKey() string {
 	return MapKey(m.TakenAt, m.CellID)
 }
 
+// SetProjection flags the photo as panorama and sets the projection type, if known.
+func (m *Photo) SetProjection(name string) {
+	if name = SanitizeTypeString(name); name == ProjDefault {
+		return
+	}
+
+	m.PhotoPanorama = true
+	m.PhotoProjection = name
+}
+
 // SetCameraSerial updates the camera serial number.
 func (m *Photo) SetCameraSerial(s string) {
 	if s = txt.Clip(s, txt.ClipDefault); m.NoCameraSerial() && s != "" {
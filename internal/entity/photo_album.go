@@ -13,12 +13,22 @@ type PhotoAlbum struct {
 	Order     int       `json:"Order" yaml:"Order,omitempty"`
 	Hidden    bool      `json:"Hidden" yaml:"Hidden,omitempty"`
 	Missing   bool      `json:"Missing" yaml:"Missing,omitempty"`
+	Hash      string    `gorm:"-" json:"-" yaml:"Hash,omitempty"`
 	CreatedAt time.Time `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
 	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
 	Photo     *Photo    `gorm:"PRELOAD:false" yaml:"-"`
 	Album     *Album    `gorm:"PRELOAD:true" yaml:"-"`
 }
 
+// UIDs returns the photo UIDs.
+func (m PhotoAlbums) UIDs() (result []string) {
+	for _, p := range m {
+		result = append(result, p.PhotoUID)
+	}
+
+	return result
+}
+
 // TableName returns the entity database table name.
 func (PhotoAlbum) TableName() string {
 	return "photos_albums"
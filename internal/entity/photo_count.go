@@ -0,0 +1,245 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+// Photo count types.
+const (
+	CountTotal   = "total"
+	CountAlbum   = "album"
+	CountLabel   = "label"
+	CountSubject = "subject"
+	CountCountry = "country"
+	CountYear    = "year"
+)
+
+// PhotoCountTypes lists the photo count types, totals are updated separately.
+var PhotoCountTypes = []string{CountAlbum, CountLabel, CountSubject, CountCountry, CountYear}
+
+type PhotoCounts []PhotoCount
+
+// PhotoCount represents a precalculated number of photos, e.g. per album, label, person, country, or year,
+// so that counts don't need to be queried each time they are displayed.
+type PhotoCount struct {
+	CountType  string    `gorm:"type:VARBINARY(16);primary_key;auto_increment:false" json:"Type" yaml:"Type"`
+	CountKey   string    `gorm:"type:VARBINARY(64);primary_key;auto_increment:false" json:"Key" yaml:"Key"`
+	PhotoCount int       `json:"Count" yaml:"Count"`
+	UpdatedAt  time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (PhotoCount) TableName() string {
+	return "photo_counts"
+}
+
+// photoCountsSql contains the queries for each count type, which must return the count_key and photo_count columns.
+// Only photos that are visible in search results are counted.
+var photoCountsSql = map[string]string{
+	CountAlbum: `SELECT pa.album_uid AS count_key, COUNT(*) AS photo_count FROM photos_albums pa
+		JOIN photos p ON p.photo_uid = pa.photo_uid
+		WHERE pa.hidden = 0 AND p.photo_quality >= 0 AND p.photo_private = 0 AND p.photo_pending = 0 AND p.deleted_at IS NULL
+		GROUP BY pa.album_uid`,
+	CountLabel: `SELECT label_uid AS count_key, photo_count FROM labels
+		WHERE photo_count > 0 AND deleted_at IS NULL`,
+	CountSubject: `SELECT m.subj_uid AS count_key, COUNT(DISTINCT p.id) AS photo_count FROM markers m
+		JOIN files f ON f.file_uid = m.file_uid AND f.deleted_at IS NULL
+		JOIN photos p ON p.id = f.photo_id
+		WHERE m.subj_uid <> '' AND m.marker_invalid = 0
+		AND p.photo_quality >= 0 AND p.photo_private = 0 AND p.photo_pending = 0 AND p.deleted_at IS NULL
+		GROUP BY m.subj_uid`,
+	CountCountry: `SELECT photo_country AS count_key, COUNT(*) AS photo_count FROM photos
+		WHERE photo_quality >= 0 AND photo_private = 0 AND photo_pending = 0 AND deleted_at IS NULL
+		GROUP BY photo_country`,
+	CountYear: `SELECT CAST(photo_year AS CHAR) AS count_key, COUNT(*) AS photo_count FROM photos
+		WHERE photo_year > 0 AND photo_quality >= 0 AND photo_private = 0 AND photo_pending = 0 AND deleted_at IS NULL
+		GROUP BY photo_year`,
+}
+
+// photoTotals represents the total numbers of photos, videos, albums, files, and other entities.
+type photoTotals struct {
+	Photos         int
+	Live           int
+	Videos         int
+	Hidden         int
+	Favorites      int
+	Private        int
+	Review         int
+	Albums         int
+	Moments        int
+	Months         int
+	States         int
+	Folders        int
+	Files          int
+	Cameras        int
+	Lenses         int
+	Labels         int
+	LabelMaxPhotos int
+	Countries      int
+	Places         int
+	People         int
+	Pets           int
+}
+
+// TotalCountKeys lists the keys of the precalculated totals, which match the json field names of the client config counts.
+var TotalCountKeys = []string{"all", "photos", "live", "videos", "hidden", "favorites", "private", "review",
+	"albums", "moments", "months", "states", "folders", "files", "cameras", "lenses", "labels", "labelMaxPhotos",
+	"countries", "places", "people", "pets"}
+
+// replacePhotoCounts replaces the counts of the given type in a single transaction, so that
+// clients never see incomplete results.
+func replacePhotoCounts(countType string, insert func(tx *gorm.DB) *gorm.DB) (rows int64, err error) {
+	err = Db().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("count_type = ?", countType).Delete(&PhotoCount{}).Error; err != nil {
+			return err
+		}
+
+		res := insert(tx)
+		rows = res.RowsAffected
+
+		return res.Error
+	})
+
+	return rows, err
+}
+
+// UpdatePhotoCounts updates the precalculated photo counts of the given type.
+func UpdatePhotoCounts(countType string) (err error) {
+	query, ok := photoCountsSql[countType]
+
+	if !ok {
+		return fmt.Errorf("counts: unknown type %s", countType)
+	}
+
+	mutex.Index.Lock()
+	defer mutex.Index.Unlock()
+
+	start := time.Now()
+
+	rows, err := replacePhotoCounts(countType, func(tx *gorm.DB) *gorm.DB {
+		return tx.Exec(fmt.Sprintf("INSERT INTO %s (count_type, count_key, photo_count, updated_at) "+
+			"SELECT ?, c.count_key, c.photo_count, ? FROM (%s) c WHERE c.count_key IS NOT NULL",
+			PhotoCount{}.TableName(), query), countType, TimeStamp())
+	})
+
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("counts: updated %s %s [%s]", countType, english.Plural(int(rows), "count", "counts"), time.Since(start))
+
+	return nil
+}
+
+// UpdateTotalCounts updates the precalculated total numbers of photos, videos, albums, and files.
+func UpdateTotalCounts() (err error) {
+	mutex.Index.Lock()
+	defer mutex.Index.Unlock()
+
+	start := time.Now()
+	t := photoTotals{}
+
+	if err = Db().Table("photos").
+		Select("COALESCE(SUM(photo_type = 'video' AND photo_quality >= 0 AND photo_private = 0 AND photo_pending = 0), 0) AS videos, " +
+			"COALESCE(SUM(photo_type = 'live' AND photo_quality >= 0 AND photo_private = 0 AND photo_pending = 0), 0) AS live, " +
+			"COALESCE(SUM(photo_quality = -1), 0) AS hidden, " +
+			"COALESCE(SUM(photo_type IN ('image','raw') AND photo_private = 0 AND photo_pending = 0 AND photo_quality >= 0), 0) AS photos, " +
+			"COALESCE(SUM(photo_type IN ('image','raw','live') AND (photo_quality < 3 OR photo_pending = 1) AND photo_quality >= 0 AND photo_private = 0), 0) AS review, " +
+			"COALESCE(SUM(photo_favorite = 1 AND photo_private = 0 AND photo_pending = 0 AND photo_quality >= 0), 0) AS favorites, " +
+			"COALESCE(SUM(photo_private = 1 AND photo_quality >= 0), 0) AS private").
+		Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND (file_missing = 1 OR file_error <> ''))").
+		Where("deleted_at IS NULL").
+		Take(&t).Error; err != nil {
+		return err
+	}
+
+	if err = Db().Table("albums").
+		Select("COALESCE(SUM(album_type = ?), 0) AS albums, COALESCE(SUM(album_type = ?), 0) AS moments, "+
+			"COALESCE(SUM(album_type = ?), 0) AS months, COALESCE(SUM(album_type = ?), 0) AS states, "+
+			"COALESCE(SUM(album_type = ?), 0) AS folders", AlbumDefault, AlbumMoment, AlbumMonth, AlbumState, AlbumFolder).
+		Where("deleted_at IS NULL AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photos.photo_path FROM photos WHERE photos.deleted_at IS NULL))").
+		Take(&t).Error; err != nil {
+		return err
+	}
+
+	if err = Db().Table("files").
+		Select("COUNT(*) AS files").
+		Where("file_missing = 0").
+		Where("deleted_at IS NULL").
+		Take(&t).Error; err != nil {
+		return err
+	}
+
+	// Counts that are displayed in the sidebar and other places are precalculated as well.
+	if err = Db().Table("cameras").Select("COUNT(*) AS cameras").
+		Where("camera_slug <> 'zz' AND camera_slug <> ''").Take(&t).Error; err != nil {
+		return err
+	} else if err = Db().Table("lenses").Select("COUNT(*) AS lenses").
+		Where("lens_slug <> 'zz' AND lens_slug <> ''").Take(&t).Error; err != nil {
+		return err
+	} else if err = Db().Table("labels").Select("COALESCE(MAX(photo_count), 0) AS label_max_photos, COUNT(*) AS labels").
+		Where("photo_count > 0 AND deleted_at IS NULL AND (label_priority >= 0 OR label_favorite = 1)").Take(&t).Error; err != nil {
+		return err
+	} else if err = Db().Table("countries").Select("(COUNT(*) - 1) AS countries").Take(&t).Error; err != nil {
+		return err
+	} else if err = Db().Table("places").Select("COALESCE(SUM(photo_count > 0), 0) AS places").
+		Where("id <> 'zz'").Take(&t).Error; err != nil {
+		return err
+	} else if err = Db().Table(Subject{}.TableName()).
+		Select("COALESCE(SUM(subj_type = ?), 0) AS people, COALESCE(SUM(subj_type = ?), 0) AS pets", SubjPerson, SubjPet).
+		Where("deleted_at IS NULL AND subj_hidden = 0").Take(&t).Error; err != nil {
+		return err
+	}
+
+	// Keys match the json field names of the client config counts, see TotalCountKeys.
+	totals := map[string]int{
+		"all":            t.Photos + t.Live + t.Videos,
+		"photos":         t.Photos,
+		"live":           t.Live,
+		"videos":         t.Videos,
+		"hidden":         t.Hidden,
+		"favorites":      t.Favorites,
+		"private":        t.Private,
+		"review":         t.Review,
+		"albums":         t.Albums,
+		"moments":        t.Moments,
+		"months":         t.Months,
+		"states":         t.States,
+		"folders":        t.Folders,
+		"files":          t.Files,
+		"cameras":        t.Cameras,
+		"lenses":         t.Lenses,
+		"labels":         t.Labels,
+		"labelMaxPhotos": t.LabelMaxPhotos,
+		"countries":      t.Countries,
+		"places":         t.Places,
+		"people":         t.People,
+		"pets":           t.Pets,
+	}
+
+	updatedAt := TimeStamp()
+
+	_, err = replacePhotoCounts(CountTotal, func(tx *gorm.DB) *gorm.DB {
+		for key, n := range totals {
+			if res := tx.Create(&PhotoCount{CountType: CountTotal, CountKey: key, PhotoCount: n, UpdatedAt: updatedAt}); res.Error != nil {
+				return res
+			}
+		}
+
+		return tx
+	})
+
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("counts: updated totals [%s]", time.Since(start))
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatePhotoCountTypes(t *testing.T) {
+	for _, countType := range PhotoCountTypes {
+		t.Run(countType, func(t *testing.T) {
+			assert.NoError(t, UpdatePhotoCounts(countType))
+		})
+	}
+
+	t.Run("Album", func(t *testing.T) {
+		var count PhotoCount
+
+		if err := Db().Where("count_type = ? AND count_key = ?", CountAlbum, "at9lxuqxpogaaba8").First(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, count.PhotoCount, 0)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		assert.Error(t, UpdatePhotoCounts("xxx"))
+	})
+}
+
+func TestUpdateTotalCounts(t *testing.T) {
+	if err := UpdateTotalCounts(); err != nil {
+		t.Fatal(err)
+	}
+
+	var counts PhotoCounts
+
+	if err := Db().Where("count_type = ?", CountTotal).Find(&counts).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, counts, len(TotalCountKeys))
+
+	// Totals are replaced, not added.
+	assert.NoError(t, UpdateTotalCounts())
+	assert.NoError(t, Db().Where("count_type = ?", CountTotal).Find(&counts).Error)
+	assert.Len(t, counts, len(TotalCountKeys))
+}
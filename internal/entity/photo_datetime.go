@@ -26,7 +26,7 @@ func (m *Photo) SetTakenAt(taken, local time.Time, zone, source string) {
 		return
 	}
 
-	if SrcPriority[source] < SrcPriority[m.TakenSrc] && !m.TakenAt.IsZero() {
+	if FieldPriority(FieldTaken, source) < FieldPriority(FieldTaken, m.TakenSrc) && !m.TakenAt.IsZero() {
 		return
 	}
 
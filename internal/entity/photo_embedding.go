@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/classify"
+)
+
+// PhotoEmbedding represents the image embedding of a photo, used to find visually similar pictures.
+type PhotoEmbedding struct {
+	PhotoID   uint      `gorm:"primary_key;auto_increment:false"`
+	PhotoUID  string    `gorm:"type:VARBINARY(42);index;"`
+	Embedding []byte    `gorm:"type:BLOB;"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (PhotoEmbedding) TableName() string {
+	return "photos_embeddings"
+}
+
+// PhotoEmbeddingBucket represents a locality-sensitive hash bucket of a photo embedding.
+type PhotoEmbeddingBucket struct {
+	PhotoID uint   `gorm:"primary_key;auto_increment:false"`
+	Bucket  uint32 `gorm:"primary_key;auto_increment:false;index"`
+}
+
+// TableName returns the entity database table name.
+func (PhotoEmbeddingBucket) TableName() string {
+	return "photos_embeddings_buckets"
+}
+
+// Vector returns the decoded embedding vector.
+func (m *PhotoEmbedding) Vector() classify.Embedding {
+	return classify.EmbeddingFromBytes(m.Embedding)
+}
+
+// SavePhotoEmbedding replaces the embedding of a photo and its hash buckets.
+func SavePhotoEmbedding(photoID uint, photoUID string, e classify.Embedding) error {
+	if photoID == 0 || e.Empty() {
+		return nil
+	}
+
+	m := PhotoEmbedding{PhotoID: photoID, PhotoUID: photoUID, Embedding: e.Bytes()}
+
+	if err := Db().Save(&m).Error; err != nil {
+		return err
+	}
+
+	if err := UnscopedDb().Delete(PhotoEmbeddingBucket{}, "photo_id = ?", photoID).Error; err != nil {
+		return err
+	}
+
+	for _, bucket := range e.Buckets() {
+		if err := Db().Create(&PhotoEmbeddingBucket{PhotoID: photoID, Bucket: bucket}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeletePhotoEmbedding removes the embedding of a photo and its hash buckets.
+func DeletePhotoEmbedding(photoID uint) error {
+	if err := UnscopedDb().Delete(PhotoEmbeddingBucket{}, "photo_id = ?", photoID).Error; err != nil {
+		return err
+	}
+
+	return UnscopedDb().Delete(PhotoEmbedding{}, "photo_id = ?", photoID).Error
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+)
+
+func TestSavePhotoEmbedding(t *testing.T) {
+	photo := PhotoFixtures.Get("Photo01")
+	e := classify.NewEmbedding([]float32{0.5, 0.3, 0.2, 0})
+
+	t.Run("Success", func(t *testing.T) {
+		if err := SavePhotoEmbedding(photo.ID, photo.PhotoUID, e); err != nil {
+			t.Fatal(err)
+		}
+
+		// Saving again should replace the existing embedding.
+		if err := SavePhotoEmbedding(photo.ID, photo.PhotoUID, e); err != nil {
+			t.Fatal(err)
+		}
+
+		var m PhotoEmbedding
+
+		if err := Db().Where("photo_id = ?", photo.ID).First(&m).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, photo.PhotoUID, m.PhotoUID)
+		assert.InDelta(t, 1, e.Similarity(m.Vector()), 0.001)
+
+		var count int
+
+		Db().Model(&PhotoEmbeddingBucket{}).Where("photo_id = ?", photo.ID).Count(&count)
+		assert.Equal(t, classify.EmbeddingBands, count)
+
+		if err := DeletePhotoEmbedding(photo.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		Db().Model(&PhotoEmbeddingBucket{}).Where("photo_id = ?", photo.ID).Count(&count)
+		assert.Equal(t, 0, count)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.NoError(t, SavePhotoEmbedding(photo.ID, photo.PhotoUID, classify.Embedding{}))
+		assert.NoError(t, SavePhotoEmbedding(0, "", e))
+	})
+}
@@ -111,6 +111,8 @@ var PhotoFixtures = PhotoMap{
 		PhotoName:        "Photo01",
 		OriginalName:     "",
 		PhotoFavorite:    true,
+		PhotoRating:      5,
+		RatingSrc:        SrcXmp,
 		PhotoPrivate:     false,
 		PhotoScan:        false,
 		PhotoPanorama:    false,
@@ -294,6 +296,8 @@ var PhotoFixtures = PhotoMap{
 		PhotoName:        "bridge",
 		OriginalName:     "",
 		PhotoFavorite:    false,
+		PhotoRating:      3,
+		RatingSrc:        SrcManual,
 		PhotoPrivate:     false,
 		PhotoScan:        false,
 		PhotoPanorama:    false,
@@ -1018,6 +1022,8 @@ var PhotoFixtures = PhotoMap{
 		CellID:           UnknownLocation.ID,
 		CellAccuracy:     0,
 		PhotoAltitude:    3,
+		PhotoHeading:     235.3,
+		PhotoSpeed:       1.4,
 		PhotoLat:         1.234,
 		PhotoLng:         4.321,
 		PhotoCountry:     UnknownCountry.ID,
@@ -1550,6 +1556,7 @@ var PhotoFixtures = PhotoMap{
 		PhotoPrivate:     false,
 		PhotoScan:        false,
 		PhotoPanorama:    true,
+		PhotoProjection:  ProjEquirectangular,
 		TimeZone:         "America/Mexico_City",
 		Place:            PlaceFixtures.Pointer("mexico"),
 		PlaceID:          PlaceFixtures.Pointer("mexico").ID,
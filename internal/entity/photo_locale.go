@@ -0,0 +1,160 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+type PhotoLocales []PhotoLocale
+
+// PhotoLocale represents a photo title and description in another language, the photo title and
+// description itself are in the default locale.
+type PhotoLocale struct {
+	PhotoID     uint      `gorm:"primary_key;auto_increment:false" json:"-" yaml:"-"`
+	Locale      string    `gorm:"type:VARBINARY(16);primary_key;auto_increment:false" json:"Locale" yaml:"Locale"`
+	Title       string    `gorm:"type:VARCHAR(200);" json:"Title" yaml:"Title,omitempty"`
+	Description string    `gorm:"type:TEXT;" json:"Description" yaml:"Description,omitempty"`
+	CreatedAt   time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (PhotoLocale) TableName() string {
+	return "photos_locales"
+}
+
+// NewPhotoLocale returns a new localized photo title and description.
+func NewPhotoLocale(photoID uint, locale, title, description string) PhotoLocale {
+	return PhotoLocale{
+		PhotoID:     photoID,
+		Locale:      string(i18n.ParseLocale(locale)),
+		Title:       txt.Shorten(strings.TrimSpace(title), txt.ClipTitle, txt.Ellipsis),
+		Description: txt.Clip(description, txt.ClipDescription),
+	}
+}
+
+// Empty tests if neither title nor description are set.
+func (m *PhotoLocale) Empty() bool {
+	return m.Title == "" && m.Description == ""
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *PhotoLocale) Save() error {
+	if m.PhotoID == 0 {
+		return fmt.Errorf("photo locale: photo id must not be empty (save)")
+	} else if m.Locale == "" {
+		return fmt.Errorf("photo locale: invalid locale (save)")
+	}
+
+	return UnscopedDb().Save(m).Error
+}
+
+// Delete removes the record from the database.
+func (m *PhotoLocale) Delete() error {
+	if m.PhotoID == 0 || m.Locale == "" {
+		return fmt.Errorf("photo locale: photo id and locale must not be empty (delete)")
+	}
+
+	return UnscopedDb().Delete(PhotoLocale{}, "photo_id = ? AND locale = ?", m.PhotoID, m.Locale).Error
+}
+
+// PreloadLocales loads the localized photo titles and descriptions.
+func (m *Photo) PreloadLocales() {
+	m.Locales = PhotoLocales{}
+
+	Log("photo", "preload locales", UnscopedDb().Where("photo_id = ?", m.ID).Order("locale").Find(&m.Locales).Error)
+}
+
+// SetLocale updates the title and description in another language, both empty removes the locale.
+func (m *Photo) SetLocale(locale, title, description string) (*PhotoLocale, error) {
+	if !m.HasID() {
+		return nil, fmt.Errorf("photo: cannot set locale without id")
+	}
+
+	l := NewPhotoLocale(m.ID, locale, title, description)
+
+	if l.Locale == "" {
+		return nil, fmt.Errorf("photo: invalid locale %s", txt.Quote(locale))
+	}
+
+	if m.Locales == nil {
+		m.PreloadLocales()
+	}
+
+	locales := make(PhotoLocales, 0, len(m.Locales)+1)
+
+	for _, existing := range m.Locales {
+		if existing.Locale != l.Locale {
+			locales = append(locales, existing)
+		}
+	}
+
+	if l.Empty() {
+		if err := l.Delete(); err != nil {
+			return nil, err
+		}
+
+		m.Locales = locales
+
+		return nil, nil
+	}
+
+	if err := l.Save(); err != nil {
+		return nil, err
+	}
+
+	locales = append(locales, l)
+
+	sort.Slice(locales, func(i, j int) bool { return locales[i].Locale < locales[j].Locale })
+
+	m.Locales = locales
+
+	return &l, nil
+}
+
+// SaveLocales saves the localized titles and descriptions, e.g. after restoring them from a YAML sidecar file.
+func (m *Photo) SaveLocales() error {
+	for i := range m.Locales {
+		l := &m.Locales[i]
+		l.PhotoID = m.ID
+
+		if l.Locale = string(i18n.ParseLocale(l.Locale)); l.Locale == "" || l.Empty() {
+			continue
+		}
+
+		if err := l.Save(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Localized returns the title and description in the locale, or in the default locale if not found.
+func (m *Photo) Localized(locale string) (title, description string) {
+	if loc := string(i18n.ParseLocale(locale)); loc != "" {
+		for _, l := range m.Locales {
+			if l.Locale != loc {
+				continue
+			}
+
+			title, description = l.Title, l.Description
+			break
+		}
+	}
+
+	if title == "" {
+		title = m.PhotoTitle
+	}
+
+	if description == "" {
+		description = m.PhotoDescription
+	}
+
+	return title, description
+}
@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPhotoLocale(t *testing.T) {
+	m := NewPhotoLocale(1, "pt-br", " Título ", "Descrição")
+
+	assert.Equal(t, uint(1), m.PhotoID)
+	assert.Equal(t, "pt_BR", m.Locale)
+	assert.Equal(t, "Título", m.Title)
+	assert.Equal(t, "Descrição", m.Description)
+	assert.False(t, m.Empty())
+
+	empty := NewPhotoLocale(1, "de", "", "")
+	assert.True(t, empty.Empty())
+	assert.Equal(t, "", NewPhotoLocale(1, "xxx", "", "").Locale)
+}
+
+func TestPhotoLocale_Save(t *testing.T) {
+	t.Run("NoPhotoID", func(t *testing.T) {
+		m := NewPhotoLocale(0, "de", "Titel", "")
+		assert.Error(t, m.Save())
+	})
+	t.Run("InvalidLocale", func(t *testing.T) {
+		m := NewPhotoLocale(1000000, "xxx", "Titel", "")
+		assert.Error(t, m.Save())
+	})
+}
+
+func TestPhoto_SetLocale(t *testing.T) {
+	photo := PhotoFixtures.Get("19800101_000002_D640C559")
+
+	t.Run("AddAndRemove", func(t *testing.T) {
+		l, err := photo.SetLocale("de", "Titel", "Beschreibung")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "de", l.Locale)
+		assert.Len(t, photo.Locales, 1)
+
+		_, err = photo.SetLocale("nl", "Titel NL", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := Photo{ID: photo.ID, PhotoUID: photo.PhotoUID}
+		found.PreloadLocales()
+
+		assert.Len(t, found.Locales, 2)
+		assert.Equal(t, "de", found.Locales[0].Locale)
+		assert.Equal(t, "nl", found.Locales[1].Locale)
+
+		l, err = photo.SetLocale("de", "", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, l)
+		assert.Len(t, photo.Locales, 1)
+
+		found.PreloadLocales()
+
+		assert.Len(t, found.Locales, 1)
+		assert.Equal(t, "nl", found.Locales[0].Locale)
+	})
+	t.Run("InvalidLocale", func(t *testing.T) {
+		_, err := photo.SetLocale("xxx", "Titel", "")
+		assert.Error(t, err)
+	})
+	t.Run("NoID", func(t *testing.T) {
+		m := Photo{}
+		_, err := m.SetLocale("de", "Titel", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestPhoto_Localized(t *testing.T) {
+	m := Photo{
+		PhotoTitle:       "Title",
+		PhotoDescription: "Description",
+		Locales:          PhotoLocales{{Locale: "de", Title: "Titel"}},
+	}
+
+	title, desc := m.Localized("de")
+	assert.Equal(t, "Titel", title)
+	assert.Equal(t, "Description", desc)
+
+	title, desc = m.Localized("fr")
+	assert.Equal(t, "Title", title)
+	assert.Equal(t, "Description", desc)
+
+	title, _ = m.Localized("")
+	assert.Equal(t, "Title", title)
+}
@@ -23,7 +23,7 @@ func (m *Photo) SetCoordinates(lat, lng float32, altitude int, source string) {
 		return
 	}
 
-	if SrcPriority[source] < SrcPriority[m.PlaceSrc] && m.HasLatLng() {
+	if FieldPriority(FieldLocation, source) < FieldPriority(FieldLocation, m.PlaceSrc) && m.HasLatLng() {
 		return
 	}
 
@@ -38,13 +38,29 @@ func (m *Photo) SetAltitude(altitude int, source string) {
 		return
 	}
 
-	if SrcPriority[source] < SrcPriority[m.PlaceSrc] {
+	if FieldPriority(FieldLocation, source) < FieldPriority(FieldLocation, m.PlaceSrc) {
 		return
 	}
 
 	m.PhotoAltitude = altitude
 }
 
+// SetMotion sets the camera direction in degrees and the speed in km/h if not empty and from an acceptable source.
+// Since a direction of 0 degrees is due north, hasHeading must be true if the direction is known.
+func (m *Photo) SetMotion(heading, speed float32, hasHeading bool, source string) {
+	if FieldPriority(FieldLocation, source) < FieldPriority(FieldLocation, m.PlaceSrc) {
+		return
+	}
+
+	if hasHeading || source == SrcManual {
+		m.PhotoHeading = heading
+	}
+
+	if speed > 0 || source == SrcManual {
+		m.PhotoSpeed = speed
+	}
+}
+
 // UnknownLocation tests if the photo has an unknown location.
 func (m *Photo) UnknownLocation() bool {
 	return m.CellID == "" || m.CellID == UnknownLocation.ID || m.NoLatLng()
@@ -52,7 +68,7 @@ func (m *Photo) UnknownLocation() bool {
 
 // SetPosition sets a position estimate.
 func (m *Photo) SetPosition(pos geo.Position, source string, force bool) {
-	if SrcPriority[m.PlaceSrc] > SrcPriority[source] && !force {
+	if FieldPriority(FieldLocation, m.PlaceSrc) > FieldPriority(FieldLocation, source) && !force {
 		return
 	} else if pos.Lat == 0 && pos.Lng == 0 {
 		return
@@ -85,7 +101,7 @@ func (m *Photo) SetPosition(pos geo.Position, source string, force bool) {
 
 // AdoptPlace sets the place based on another photo.
 func (m *Photo) AdoptPlace(other Photo, source string, force bool) {
-	if SrcPriority[m.PlaceSrc] > SrcPriority[source] && !force {
+	if FieldPriority(FieldLocation, m.PlaceSrc) > FieldPriority(FieldLocation, source) && !force {
 		return
 	} else if other.Place == nil {
 		return
@@ -112,7 +128,7 @@ func (m *Photo) AdoptPlace(other Photo, source string, force bool) {
 
 // RemoveLocation removes the current location.
 func (m *Photo) RemoveLocation(source string, force bool) {
-	if SrcPriority[m.PlaceSrc] > SrcPriority[source] && !force {
+	if FieldPriority(FieldLocation, m.PlaceSrc) > FieldPriority(FieldLocation, source) && !force {
 		return
 	}
 
@@ -436,3 +436,31 @@ func TestUpdateLocation(t *testing.T) {
 		assert.Equal(t, SrcManual, m.PlaceSrc)
 	})
 }
+
+func TestPhoto_SetMotion(t *testing.T) {
+	t.Run("Meta", func(t *testing.T) {
+		m := Photo{PlaceSrc: SrcMeta}
+		m.SetMotion(235.3, 1.4, true, SrcMeta)
+		assert.Equal(t, float32(235.3), m.PhotoHeading)
+		assert.Equal(t, float32(1.4), m.PhotoSpeed)
+
+		m.SetMotion(0, 0, false, SrcMeta)
+		assert.Equal(t, float32(235.3), m.PhotoHeading)
+		assert.Equal(t, float32(1.4), m.PhotoSpeed)
+	})
+	t.Run("North", func(t *testing.T) {
+		m := Photo{PlaceSrc: SrcMeta, PhotoHeading: 235.3}
+		m.SetMotion(0, 0, true, SrcMeta)
+		assert.Equal(t, float32(0), m.PhotoHeading)
+	})
+	t.Run("Manual", func(t *testing.T) {
+		m := Photo{PlaceSrc: SrcManual, PhotoHeading: 90, PhotoSpeed: 5}
+		m.SetMotion(235.3, 1.4, true, SrcMeta)
+		assert.Equal(t, float32(90), m.PhotoHeading)
+		assert.Equal(t, float32(5), m.PhotoSpeed)
+
+		m.SetMotion(0, 0, false, SrcManual)
+		assert.Equal(t, float32(0), m.PhotoHeading)
+		assert.Equal(t, float32(0), m.PhotoSpeed)
+	})
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/jinzhu/gorm"
@@ -85,13 +86,6 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 		return Photo{}, merged, err
 	}
 
-	logResult := func(res *gorm.DB) {
-		if res.Error != nil {
-			log.Errorf("merge: %s", res.Error.Error())
-			err = res.Error
-		}
-	}
-
 	for i, merge := range identical {
 		if i == 0 {
 			original = merge
@@ -99,27 +93,11 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 			continue
 		}
 
-		deleted := TimeStamp()
-
-		logResult(UnscopedDb().Exec("UPDATE `files` SET photo_id = ?, photo_uid = ?, file_primary = 0 WHERE photo_id = ?", original.ID, original.PhotoUID, merge.ID))
-		logResult(UnscopedDb().Exec("UPDATE `photos` SET photo_quality = -1, deleted_at = ? WHERE id = ?", TimeStamp(), merge.ID))
-
-		switch DbDialect() {
-		case MySQL:
-			logResult(UnscopedDb().Exec("UPDATE IGNORE `photos_keywords` SET `photo_id` = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE IGNORE `photos_labels` SET `photo_id` = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE IGNORE `photos_albums` SET `photo_uid` = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
-		case SQLite3:
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE `photos_keywords` SET `photo_id` = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE `photos_labels` SET `photo_id` = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE `photos_albums` SET `photo_uid` = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
-		default:
-			log.Warnf("sql: unsupported dialect %s", DbDialect())
+		if mergeErr := original.absorb(&merge); mergeErr != nil {
+			log.Errorf("merge: %s", mergeErr.Error())
+			err = mergeErr
 		}
 
-		merge.DeletedAt = &deleted
-		merge.PhotoQuality = -1
-
 		merged = append(merged, merge)
 	}
 
@@ -131,3 +109,66 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 
 	return original, merged, err
 }
+
+// absorb moves the files, keywords, labels, and albums of another photo to this photo and flags it as deleted.
+func (m *Photo) absorb(merge *Photo) (err error) {
+	logResult := func(res *gorm.DB) {
+		if res.Error != nil {
+			err = res.Error
+		}
+	}
+
+	deleted := TimeStamp()
+
+	logResult(Exec("UPDATE `files` SET photo_id = ?, photo_uid = ?, file_primary = 0 WHERE photo_id = ?", m.ID, m.PhotoUID, merge.ID))
+	logResult(Exec("UPDATE `photos` SET photo_quality = -1, deleted_at = ? WHERE id = ?", deleted, merge.ID))
+
+	switch DbDialect() {
+	case MySQL:
+		logResult(Exec("UPDATE IGNORE `photos_keywords` SET `photo_id` = ? WHERE photo_id = ?", m.ID, merge.ID))
+		logResult(Exec("UPDATE IGNORE `photos_labels` SET `photo_id` = ? WHERE photo_id = ?", m.ID, merge.ID))
+		logResult(Exec("UPDATE IGNORE `photos_albums` SET `photo_uid` = ? WHERE photo_uid = ?", m.PhotoUID, merge.PhotoUID))
+	case SQLite3:
+		logResult(Exec("UPDATE OR IGNORE `photos_keywords` SET `photo_id` = ? WHERE photo_id = ?", m.ID, merge.ID))
+		logResult(Exec("UPDATE OR IGNORE `photos_labels` SET `photo_id` = ? WHERE photo_id = ?", m.ID, merge.ID))
+		logResult(Exec("UPDATE OR IGNORE `photos_albums` SET `photo_uid` = ? WHERE photo_uid = ?", m.PhotoUID, merge.PhotoUID))
+	default:
+		log.Warnf("sql: unsupported dialect %s", DbDialect())
+	}
+
+	merge.DeletedAt = &deleted
+	merge.PhotoQuality = -1
+
+	return err
+}
+
+// Stack manually adds other photos to this photo stack, regardless of their names and metadata.
+func (m *Photo) Stack(photos Photos) (stacked Photos, err error) {
+	if !m.HasID() {
+		return stacked, fmt.Errorf("photo: cannot stack without id")
+	}
+
+	photoMergeMutex.Lock()
+	defer photoMergeMutex.Unlock()
+
+	for _, p := range photos {
+		if !p.HasID() || p.ID == m.ID {
+			continue
+		} else if err := m.absorb(&p); err != nil {
+			return stacked, err
+		}
+
+		log.Debugf("photo: stacked id %d with id %d", p.ID, m.ID)
+
+		stacked = append(stacked, p)
+	}
+
+	if len(stacked) == 0 {
+		return stacked, nil
+	}
+
+	// Flag photo as stacked so that it is not split up automatically.
+	m.SetStack(IsStacked)
+
+	return stacked, m.ResolvePrimary()
+}
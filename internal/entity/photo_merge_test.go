@@ -95,3 +95,72 @@ func TestPhoto_Merge(t *testing.T) {
 		assert.Equal(t, 1000024, int(merged[0].ID))
 	})
 }
+
+func TestPhoto_Stack(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		stack := NewPhoto(true)
+		stack.PhotoName = "StackTest1"
+
+		if err := stack.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		other := NewPhoto(true)
+		other.PhotoName = "StackTest2"
+
+		if err := other.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := &File{PhotoID: other.ID, PhotoUID: other.PhotoUID, FileName: "StackTest2.jpg", FileRoot: RootOriginals, FileType: "jpg", FilePrimary: true}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		stacked, err := stack.Stack(Photos{stack, other})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, stacked, 1)
+		assert.Equal(t, IsStacked, stack.PhotoStack)
+		assert.NotNil(t, stacked[0].DeletedAt)
+
+		var deleted Photo
+
+		if err := UnscopedDb().Where("id = ?", other.ID).First(&deleted).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotNil(t, deleted.DeletedAt)
+		assert.Equal(t, -1, deleted.PhotoQuality)
+
+		var result File
+
+		if err := UnscopedDb().Where("id = ?", file.ID).First(&result).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, stack.ID, result.PhotoID)
+		assert.Equal(t, stack.PhotoUID, result.PhotoUID)
+		assert.False(t, result.FilePrimary)
+	})
+	t.Run("NoID", func(t *testing.T) {
+		stack := NewPhoto(true)
+
+		_, err := stack.Stack(Photos{PhotoFixtures.Get("Photo01")})
+
+		assert.Error(t, err)
+	})
+	t.Run("NothingToStack", func(t *testing.T) {
+		stack := PhotoFixtures.Get("Photo01")
+
+		stacked, err := stack.Stack(Photos{stack})
+
+		assert.NoError(t, err)
+		assert.Empty(t, stacked)
+		assert.Equal(t, PhotoFixtures.Get("Photo01").PhotoStack, stack.PhotoStack)
+	})
+}
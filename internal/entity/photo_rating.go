@@ -0,0 +1,38 @@
+package entity
+
+// Star rating range, see https://developer.adobe.com/xmp/docs/XMPNamespaces/xmp/.
+const (
+	RatingMin = 0
+	RatingMax = 5
+)
+
+// ClampRating returns the rating limited to the range from RatingMin to RatingMax.
+func ClampRating(rating int) int {
+	if rating < RatingMin {
+		return RatingMin
+	} else if rating > RatingMax {
+		return RatingMax
+	}
+
+	return rating
+}
+
+// HasRating tests if the photo has a star rating.
+func (m *Photo) HasRating() bool {
+	return m.PhotoRating > RatingMin
+}
+
+// SetRating changes the star rating if it is valid and the source has priority.
+// Empty ratings in metadata are ignored, so that existing ratings are not removed.
+func (m *Photo) SetRating(rating int, source string) {
+	if rating <= RatingMin || rating > RatingMax {
+		return
+	}
+
+	if (FieldPriority(FieldRating, source) < FieldPriority(FieldRating, m.RatingSrc)) && m.HasRating() {
+		return
+	}
+
+	m.PhotoRating = rating
+	m.RatingSrc = source
+}
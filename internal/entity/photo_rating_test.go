@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClampRating(t *testing.T) {
+	assert.Equal(t, 0, ClampRating(-1))
+	assert.Equal(t, 0, ClampRating(0))
+	assert.Equal(t, 3, ClampRating(3))
+	assert.Equal(t, 5, ClampRating(7))
+}
+
+func TestPhoto_HasRating(t *testing.T) {
+	t.Run("true", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		assert.True(t, m.HasRating())
+	})
+	t.Run("false", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo02")
+		assert.False(t, m.HasRating())
+	})
+}
+
+func TestPhoto_SetRating(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo04")
+		m.SetRating(0, SrcXmp)
+		assert.Equal(t, 3, m.PhotoRating)
+		assert.Equal(t, SrcManual, m.RatingSrc)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo02")
+		m.SetRating(6, SrcXmp)
+		assert.Equal(t, 0, m.PhotoRating)
+	})
+	t.Run("lower priority", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo04")
+		m.SetRating(5, SrcXmp)
+		assert.Equal(t, 3, m.PhotoRating)
+		assert.Equal(t, SrcManual, m.RatingSrc)
+	})
+	t.Run("success", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo02")
+		m.SetRating(4, SrcMeta)
+		assert.Equal(t, 4, m.PhotoRating)
+		assert.Equal(t, SrcMeta, m.RatingSrc)
+		m.SetRating(2, SrcXmp)
+		assert.Equal(t, 2, m.PhotoRating)
+		assert.Equal(t, SrcXmp, m.RatingSrc)
+	})
+}
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+)
+
+// GeoRoundPrecision is the number of decimal places of rounded coordinates, about 11 km.
+const GeoRoundPrecision = 1
+
+// GeoRoundCoordinate rounds a coordinate to GeoRoundPrecision decimal places.
+func GeoRoundCoordinate(v float32) float32 {
+	p := math.Pow(10, GeoRoundPrecision)
+	return float32(math.Round(float64(v)*p) / p)
+}
+
+// Redact removes the given metadata from a file before it is shared.
+func (m *File) Redact(fields []string) {
+	for _, field := range fields {
+		if field == RedactFilenames {
+			m.FileName = fmt.Sprintf("%s.%s", m.FileHash, m.FileType)
+			m.OriginalName = ""
+		}
+	}
+}
+
+// Redact removes the given metadata from a photo before it is shared, changes are never saved.
+func (m *Photo) Redact(fields []string) {
+	for _, field := range fields {
+		switch field {
+		case RedactSerial:
+			m.CameraSerial = ""
+		case RedactOwner:
+			if m.Details != nil {
+				m.Details.Artist = ""
+				m.Details.Copyright = ""
+			}
+		case RedactGps:
+			cell, place := UnknownLocation, UnknownPlace
+
+			m.PhotoLat = 0
+			m.PhotoLng = 0
+			m.PhotoAltitude = 0
+			m.PhotoHeading = 0
+			m.PhotoSpeed = 0
+			m.PhotoCountry = UnknownID
+			m.CellID = cell.ID
+			m.CellAccuracy = 0
+			m.Cell = &cell
+			m.PlaceID = place.ID
+			m.PlaceSrc = ""
+			m.Place = &place
+		case RedactFilenames:
+			m.PhotoPath = ""
+			m.PhotoName = ""
+			m.OriginalName = ""
+		}
+	}
+
+	for i := range m.Files {
+		m.Files[i].Redact(fields)
+	}
+}
+
+// SetGeoPrivacy rounds or removes coordinates and location details depending on the privacy level of
+// the sharing link, changes are never saved.
+func (m *Photo) SetGeoPrivacy(level string) {
+	switch level {
+	case GeoRound:
+		cell := UnknownLocation
+
+		m.PhotoLat = GeoRoundCoordinate(m.PhotoLat)
+		m.PhotoLng = GeoRoundCoordinate(m.PhotoLng)
+		m.PhotoAltitude = 0
+		m.PhotoHeading = 0
+		m.PhotoSpeed = 0
+		m.CellID = cell.ID
+		m.CellAccuracy = 0
+		m.Cell = &cell
+	case GeoStrip:
+		m.Redact([]string{RedactGps})
+	}
+}
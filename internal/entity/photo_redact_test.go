@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_Redact(t *testing.T) {
+	t.Run("AllFields", func(t *testing.T) {
+		m := Photo{
+			PhotoPath:    "2020/vacation",
+			PhotoName:    "IMG_1234",
+			OriginalName: "Vacation/IMG_1234.jpg",
+			CameraSerial: "123456",
+			PhotoLat:     48.519234,
+			PhotoLng:     9.057997,
+			CellID:       "s2:479a03fda123",
+			Details:      &Details{Artist: "Jane Doe", Copyright: "Jane Doe", Keywords: "beach"},
+			Files:        []File{{FileName: "2020/vacation/IMG_1234.jpg", OriginalName: "IMG_1234.jpg", FileHash: "abc123", FileType: "jpg"}},
+		}
+
+		m.Redact([]string{RedactSerial, RedactOwner, RedactGps, RedactFilenames})
+
+		assert.Equal(t, "", m.PhotoPath)
+		assert.Equal(t, "", m.PhotoName)
+		assert.Equal(t, "", m.OriginalName)
+		assert.Equal(t, "", m.CameraSerial)
+		assert.Equal(t, float32(0), m.PhotoLat)
+		assert.Equal(t, float32(0), m.PhotoLng)
+		assert.Equal(t, UnknownLocation.ID, m.CellID)
+		assert.Equal(t, "", m.Details.Artist)
+		assert.Equal(t, "", m.Details.Copyright)
+		assert.Equal(t, "beach", m.Details.Keywords)
+		assert.Equal(t, "abc123.jpg", m.Files[0].FileName)
+		assert.Equal(t, "", m.Files[0].OriginalName)
+	})
+	t.Run("NoFields", func(t *testing.T) {
+		m := Photo{CameraSerial: "123456", PhotoLat: 48.519234}
+
+		m.Redact(nil)
+
+		assert.Equal(t, "123456", m.CameraSerial)
+		assert.Equal(t, float32(48.519234), m.PhotoLat)
+	})
+}
+
+func TestPhoto_SetGeoPrivacy(t *testing.T) {
+	t.Run("Round", func(t *testing.T) {
+		m := Photo{PhotoLat: 48.519234, PhotoLng: 9.057997, PhotoAltitude: 350, CellID: "s2:479a03fda123"}
+
+		m.SetGeoPrivacy(GeoRound)
+
+		assert.InDelta(t, 48.5, m.PhotoLat, 0.0001)
+		assert.InDelta(t, 9.1, m.PhotoLng, 0.0001)
+		assert.Equal(t, 0, m.PhotoAltitude)
+		assert.Equal(t, UnknownLocation.ID, m.CellID)
+	})
+	t.Run("Strip", func(t *testing.T) {
+		m := Photo{PhotoLat: 48.519234, PhotoLng: 9.057997, CellID: "s2:479a03fda123"}
+
+		m.SetGeoPrivacy(GeoStrip)
+
+		assert.Equal(t, float32(0), m.PhotoLat)
+		assert.Equal(t, float32(0), m.PhotoLng)
+		assert.Equal(t, UnknownLocation.ID, m.CellID)
+	})
+	t.Run("Exact", func(t *testing.T) {
+		m := Photo{PhotoLat: 48.519234, PhotoLng: 9.057997}
+
+		m.SetGeoPrivacy(GeoExact)
+
+		assert.Equal(t, float32(48.519234), m.PhotoLat)
+		assert.Equal(t, float32(9.057997), m.PhotoLng)
+	})
+}
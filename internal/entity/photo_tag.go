@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+type PhotoTags []PhotoTag
+
+// PhotoTag represents the many-to-many relation between Photo and Tag.
+type PhotoTag struct {
+	PhotoUID  string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"PhotoUID" yaml:"PhotoUID"`
+	TagUID    string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index" json:"TagUID" yaml:"TagUID"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
+}
+
+// TableName returns the entity database table name.
+func (PhotoTag) TableName() string {
+	return "photos_tags"
+}
+
+// AddPhotoTags adds tags to photos in a single transaction, existing relations are kept.
+func AddPhotoTags(tagUIDs, photoUIDs []string) (added PhotoTags, err error) {
+	err = Db().Transaction(func(tx *gorm.DB) error {
+		added = PhotoTags{}
+
+		for _, tagUID := range tagUIDs {
+			for _, photoUID := range photoUIDs {
+				entry := PhotoTag{PhotoUID: photoUID, TagUID: tagUID}
+
+				if err := tx.Where(entry).FirstOrCreate(&entry).Error; err != nil {
+					return err
+				}
+
+				added = append(added, entry)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return PhotoTags{}, err
+	}
+
+	return added, nil
+}
+
+// RemovePhotoTags removes tags from photos and returns the number of removed relations.
+func RemovePhotoTags(tagUIDs, photoUIDs []string) (removed int64, err error) {
+	if len(tagUIDs) == 0 || len(photoUIDs) == 0 {
+		return 0, nil
+	}
+
+	res := Db().Where("tag_uid IN (?) AND photo_uid IN (?)", tagUIDs, photoUIDs).Delete(&PhotoTag{})
+
+	return res.RowsAffected, res.Error
+}
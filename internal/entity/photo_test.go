@@ -589,6 +589,28 @@ func TestPhoto_Approve(t *testing.T) {
 
 		assert.Equal(t, 3, photo.PhotoQuality)
 	})
+	t.Run("pending", func(t *testing.T) {
+		photo := Photo{PhotoQuality: 4, PhotoPending: true}
+
+		if err := photo.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := photo.Approve(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, photo.PhotoPending)
+		assert.NotNil(t, photo.EditedAt)
+
+		found := Photo{ID: photo.ID}
+
+		if err := found.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, found.PhotoPending)
+	})
 }
 
 func TestPhoto_Links(t *testing.T) {
@@ -800,6 +822,21 @@ func TestPhoto_Archive(t *testing.T) {
 	})
 }
 
+func TestPhoto_SetProjection(t *testing.T) {
+	t.Run("Equirectangular", func(t *testing.T) {
+		m := Photo{}
+		m.SetProjection("Equirectangular")
+		assert.True(t, m.PhotoPanorama)
+		assert.Equal(t, ProjEquirectangular, m.PhotoProjection)
+	})
+	t.Run("Default", func(t *testing.T) {
+		m := Photo{PhotoPanorama: true}
+		m.SetProjection(ProjDefault)
+		assert.True(t, m.PhotoPanorama)
+		assert.Equal(t, ProjDefault, m.PhotoProjection)
+	})
+}
+
 func TestPhoto_SetCameraSerial(t *testing.T) {
 	m := &Photo{}
 	assert.Empty(t, m.CameraSerial)
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+type PhotoTimelapses []PhotoTimelapse
+
+// PhotoTimelapse links a generated time-lapse video to the photos it was assembled from.
+type PhotoTimelapse struct {
+	VideoUID  string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"VideoUID" yaml:"VideoUID"`
+	PhotoUID  string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index" json:"PhotoUID" yaml:"PhotoUID"`
+	Frame     int       `json:"Frame" yaml:"Frame"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
+}
+
+// TableName returns the entity database table name.
+func (PhotoTimelapse) TableName() string {
+	return "photos_timelapses"
+}
+
+// AddPhotoTimelapse links a time-lapse video to its source photos in frame order,
+// replacing existing links of the video.
+func AddPhotoTimelapse(videoUID string, photoUIDs []string) (added PhotoTimelapses, err error) {
+	err = Db().Transaction(func(tx *gorm.DB) error {
+		added = PhotoTimelapses{}
+
+		if err := tx.Where("video_uid = ?", videoUID).Delete(&PhotoTimelapse{}).Error; err != nil {
+			return err
+		}
+
+		for i, photoUID := range photoUIDs {
+			entry := PhotoTimelapse{VideoUID: videoUID, PhotoUID: photoUID, Frame: i + 1}
+
+			if err := tx.Create(&entry).Error; err != nil {
+				return err
+			}
+
+			added = append(added, entry)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return PhotoTimelapses{}, err
+	}
+
+	return added, nil
+}
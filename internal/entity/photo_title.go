@@ -32,7 +32,7 @@ func (m *Photo) SetTitle(title, source string) {
 		return
 	}
 
-	if (SrcPriority[source] < SrcPriority[m.TitleSrc]) && m.HasTitle() {
+	if (FieldPriority(FieldTitle, source) < FieldPriority(FieldTitle, m.TitleSrc)) && m.HasTitle() {
 		return
 	}
 
@@ -1,8 +1,12 @@
 package entity
 
 import (
+	"bytes"
+	"compress/gzip"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -11,11 +15,28 @@ import (
 
 var photoYamlMutex = sync.Mutex{}
 
+const (
+	SidecarLayoutMirror = "mirror"
+	SidecarLayoutHash   = "hash"
+	GzipExt             = ".gz"
+)
+
+// SidecarLayout specifies if YAML sidecar files mirror the originals folder structure,
+// or are stored in hash-prefixed folders based on the primary file hash.
+var SidecarLayout = SidecarLayoutMirror
+
+// SidecarGzip specifies if YAML sidecar files are compressed with gzip.
+var SidecarGzip = false
+
 // Yaml returns photo data as YAML string.
 func (m *Photo) Yaml() ([]byte, error) {
-	// Load details if not done yet.
+	// Load details and locales if not done yet.
 	m.GetDetails()
 
+	if m.Locales == nil && m.ID > 0 {
+		m.PreloadLocales()
+	}
+
 	out, err := yaml.Marshal(m)
 
 	if err != nil {
@@ -33,25 +54,12 @@ func (m *Photo) SaveAsYaml(fileName string) error {
 		return err
 	}
 
-	// Make sure directory exists.
-	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
-		return err
-	}
-
-	photoYamlMutex.Lock()
-	defer photoYamlMutex.Unlock()
-
-	// Write YAML data to file.
-	if err := os.WriteFile(fileName, data, os.ModePerm); err != nil {
-		return err
-	}
-
-	return nil
+	return writeYaml(fileName, data)
 }
 
 // LoadFromYaml photo data from a YAML file.
 func (m *Photo) LoadFromYaml(fileName string) error {
-	data, err := os.ReadFile(fileName)
+	data, err := readYaml(fileName)
 
 	if err != nil {
 		return err
@@ -66,5 +74,149 @@ func (m *Photo) LoadFromYaml(fileName string) error {
 
 // YamlFileName returns the YAML file name.
 func (m *Photo) YamlFileName(originalsPath, sidecarPath string) string {
-	return fs.FileName(filepath.Join(originalsPath, m.PhotoPath, m.PhotoName), sidecarPath, originalsPath, fs.YamlExt)
+	if SidecarLayout == SidecarLayoutHash {
+		if f, err := m.PrimaryFile(); err != nil {
+			log.Debugf("photo: %s (find yaml file)", err)
+		} else if fileName := YamlHashName(f.FileHash, sidecarPath); fileName != "" {
+			return fileName
+		}
+	}
+
+	fileName := fs.FileName(filepath.Join(originalsPath, m.PhotoPath, m.PhotoName), sidecarPath, originalsPath, fs.YamlExt)
+
+	if SidecarGzip {
+		return fileName + GzipExt
+	}
+
+	return fileName
+}
+
+// YamlHashName returns the YAML file name in hash-prefixed sidecar folders, e.g. "sidecar/a/b/c/abc123.yml".
+func YamlHashName(fileHash, sidecarPath string) string {
+	fileName := yamlHashName(fileHash, sidecarPath)
+
+	if fileName != "" && SidecarGzip {
+		return fileName + GzipExt
+	}
+
+	return fileName
+}
+
+// yamlHashName returns the uncompressed YAML file name in hash-prefixed sidecar folders.
+func yamlHashName(fileHash, sidecarPath string) string {
+	if len(fileHash) < 4 {
+		return ""
+	}
+
+	return filepath.Join(sidecarPath, fileHash[0:1], fileHash[1:2], fileHash[2:3], fileHash+fs.YamlExt)
+}
+
+// YamlFileNames returns the possible YAML file names of the photo in all layouts, with and without compression,
+// so that existing files can be found and migrated when the configured layout changes.
+func (m *Photo) YamlFileNames(originalsPath, sidecarPath string) (result []string) {
+	var mirrorName string
+
+	if filepath.IsAbs(sidecarPath) {
+		mirrorName = filepath.Join(sidecarPath, m.PhotoPath, m.PhotoName)
+	} else {
+		mirrorName = filepath.Join(originalsPath, m.PhotoPath, sidecarPath, m.PhotoName)
+	}
+
+	for _, ext := range fs.TypeExt[fs.FormatYaml] {
+		result = append(result, mirrorName+ext, mirrorName+ext+GzipExt)
+	}
+
+	if f, err := m.PrimaryFile(); err == nil {
+		result = append(result, YamlHashNames(f.FileHash, sidecarPath)...)
+	}
+
+	return result
+}
+
+// YamlHashNames returns the possible YAML file names in hash-prefixed sidecar folders, with and without compression.
+func YamlHashNames(fileHash, sidecarPath string) []string {
+	fileName := yamlHashName(fileHash, sidecarPath)
+
+	if fileName == "" {
+		return []string{}
+	}
+
+	return []string{fileName, fileName + GzipExt}
+}
+
+// MoveYaml moves a YAML file and compresses or decompresses its data depending on the new file extension.
+func MoveYaml(fileName, destName string) error {
+	if strings.HasSuffix(fileName, GzipExt) == strings.HasSuffix(destName, GzipExt) {
+		if err := os.MkdirAll(filepath.Dir(destName), os.ModePerm); err != nil {
+			return err
+		}
+
+		photoYamlMutex.Lock()
+		defer photoYamlMutex.Unlock()
+
+		return os.Rename(fileName, destName)
+	}
+
+	data, err := readYaml(fileName)
+
+	if err != nil {
+		return err
+	} else if err = writeYaml(destName, data); err != nil {
+		return err
+	}
+
+	return os.Remove(fileName)
+}
+
+// readYaml reads data from a YAML file and decompresses it if needed.
+func readYaml(fileName string) ([]byte, error) {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return data, err
+	}
+
+	// Decompress YAML data?
+	if strings.HasSuffix(fileName, GzipExt) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+
+		if err != nil {
+			return data, err
+		}
+
+		defer zr.Close()
+
+		return io.ReadAll(zr)
+	}
+
+	return data, nil
+}
+
+// writeYaml writes data to a YAML file and compresses it if needed.
+func writeYaml(fileName string, data []byte) error {
+	// Make sure directory exists.
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return err
+	}
+
+	// Compress YAML data?
+	if strings.HasSuffix(fileName, GzipExt) {
+		var buf bytes.Buffer
+
+		zw := gzip.NewWriter(&buf)
+
+		if _, err := zw.Write(data); err != nil {
+			return err
+		} else if err := zw.Close(); err != nil {
+			return err
+		}
+
+		data = buf.Bytes()
+	}
+
+	photoYamlMutex.Lock()
+	defer photoYamlMutex.Unlock()
+
+	// Write YAML data to file.
+	return os.WriteFile(fileName, data, os.ModePerm)
 }
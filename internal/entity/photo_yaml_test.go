@@ -41,6 +41,51 @@ func TestPhoto_SaveAsYaml(t *testing.T) {
 			t.Fatal(err)
 		}
 	})
+	t.Run("with locales", func(t *testing.T) {
+		m := Photo{PhotoTitle: "Title", Locales: PhotoLocales{{Locale: "de", Title: "Titel", Description: "Beschreibung"}}}
+
+		fileName := filepath.Join(os.TempDir(), ".photoprism_test_locales.yml")
+
+		if err := m.SaveAsYaml(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		restored := Photo{}
+
+		if err := restored.LoadFromYaml(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Title", restored.PhotoTitle)
+		assert.Equal(t, m.Locales, restored.Locales)
+	})
+	t.Run("gzip", func(t *testing.T) {
+		m := Photo{PhotoTitle: "Compressed"}
+
+		fileName := filepath.Join(os.TempDir(), ".photoprism_test.yml"+GzipExt)
+
+		if err := m.SaveAsYaml(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		if data, err := os.ReadFile(fileName); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, []byte{0x1f, 0x8b}, data[:2])
+		}
+
+		restored := Photo{}
+
+		if err := restored.LoadFromYaml(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Compressed", restored.PhotoTitle)
+	})
 }
 
 func TestPhoto_YamlFileName(t *testing.T) {
@@ -53,4 +98,73 @@ func TestPhoto_YamlFileName(t *testing.T) {
 			t.Fatal(err)
 		}
 	})
+	t.Run("hash layout", func(t *testing.T) {
+		SidecarLayout = SidecarLayoutHash
+
+		defer func() { SidecarLayout = SidecarLayoutMirror }()
+
+		m := PhotoFixtures.Get("Photo04")
+		assert.Equal(t, "yyy/p/c/a/pcad9168fa6acc5c5c2965ddf6ec465ca42fd818.yml", m.YamlFileName("xxx", "yyy"))
+	})
+	t.Run("gzip", func(t *testing.T) {
+		SidecarGzip = true
+
+		defer func() { SidecarGzip = false }()
+
+		m := PhotoFixtures.Get("Photo01")
+		assert.Equal(t, "xxx/2790/02/yyy/Photo01.yml.gz", m.YamlFileName("xxx", "yyy"))
+	})
+}
+
+func TestYamlHashName(t *testing.T) {
+	assert.Equal(t, "yyy/3/c/a/3cad9168fa6acc5c5c2965ddf6ec465ca42fd818.yml", YamlHashName("3cad9168fa6acc5c5c2965ddf6ec465ca42fd818", "yyy"))
+	assert.Equal(t, "", YamlHashName("abc", "yyy"))
+
+	SidecarGzip = true
+
+	defer func() { SidecarGzip = false }()
+
+	assert.Equal(t, "yyy/3/c/a/3cad9168fa6acc5c5c2965ddf6ec465ca42fd818.yml.gz", YamlHashName("3cad9168fa6acc5c5c2965ddf6ec465ca42fd818", "yyy"))
+}
+
+func TestPhoto_YamlFileNames(t *testing.T) {
+	m := PhotoFixtures.Get("Photo04")
+	names := m.YamlFileNames("xxx", "yyy")
+
+	assert.Contains(t, names, "xxx/Germany/yyy/bridge.yml")
+	assert.Contains(t, names, "xxx/Germany/yyy/bridge.yaml.gz")
+	assert.Contains(t, names, "yyy/p/c/a/pcad9168fa6acc5c5c2965ddf6ec465ca42fd818.yml")
+	assert.Contains(t, names, "yyy/p/c/a/pcad9168fa6acc5c5c2965ddf6ec465ca42fd818.yml.gz")
+	assert.Contains(t, m.YamlFileNames("xxx", "/yyy"), "/yyy/Germany/bridge.yml.gz")
+}
+
+func TestMoveYaml(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "Photo04.yml")
+	destName := filepath.Join(dir, "a", "b", "c", "abc.yml.gz")
+
+	if err := os.WriteFile(fileName, []byte("Title: Moved\n"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := MoveYaml(fileName, destName); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoFileExists(t, fileName)
+
+	restored := Photo{}
+
+	if err := restored.LoadFromYaml(destName); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Moved", restored.PhotoTitle)
+
+	if err := MoveYaml(destName, fileName+GzipExt); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoFileExists(t, destName)
+	assert.FileExists(t, fileName+GzipExt)
 }
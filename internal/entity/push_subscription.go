@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/dial"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+type PushSubscriptions []PushSubscription
+
+// PushSubscription represents a browser or web app of a user that receives push notifications.
+type PushSubscription struct {
+	ID        uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	UserUID   string    `gorm:"type:VARBINARY(42);index;" json:"UserUID" yaml:"UserUID"`
+	Endpoint  string    `gorm:"type:VARBINARY(512);unique_index;" json:"Endpoint" yaml:"Endpoint"`
+	P256dh    string    `gorm:"type:VARBINARY(128);" json:"-" yaml:"P256dh"`
+	Auth      string    `gorm:"type:VARBINARY(64);" json:"-" yaml:"Auth"`
+	UserAgent string    `gorm:"type:VARCHAR(512);" json:"UserAgent" yaml:"UserAgent,omitempty"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
+	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (PushSubscription) TableName() string {
+	return "push_subscriptions"
+}
+
+// NewPushSubscription returns a new push subscription for the user.
+func NewPushSubscription(userUID, endpoint, p256dh, auth, userAgent string) *PushSubscription {
+	return &PushSubscription{
+		UserUID:   userUID,
+		Endpoint:  endpoint,
+		P256dh:    p256dh,
+		Auth:      auth,
+		UserAgent: txt.Clip(userAgent, 512),
+	}
+}
+
+// FindPushSubscription returns the subscription with the given endpoint, or nil if it does not exist.
+func FindPushSubscription(endpoint string) *PushSubscription {
+	if endpoint == "" {
+		return nil
+	}
+
+	result := PushSubscription{}
+
+	if err := Db().Where("endpoint = ?", endpoint).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// DeletePushSubscription removes the subscription with the given endpoint, e.g. after it has expired.
+func DeletePushSubscription(endpoint string) error {
+	if endpoint == "" {
+		return fmt.Errorf("push endpoint must not be empty")
+	}
+
+	return Db().Where("endpoint = ?", endpoint).Delete(&PushSubscription{}).Error
+}
+
+// Validate checks the user, endpoint, and keys of the subscription.
+func (m *PushSubscription) Validate() error {
+	if m.UserUID == "" {
+		return fmt.Errorf("push user must not be empty")
+	} else if m.P256dh == "" || m.Auth == "" {
+		return fmt.Errorf("push keys must not be empty")
+	} else if len(m.Endpoint) > 512 {
+		return fmt.Errorf("push endpoint is too long")
+	}
+
+	// Push services must be reached through https and must not be on a local network.
+	if u, err := url.Parse(m.Endpoint); err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid push endpoint")
+	} else if err = dial.CheckHost(u.Hostname()); err != nil {
+		return fmt.Errorf("invalid push endpoint (%s)", err)
+	}
+
+	return nil
+}
+
+// Save updates or inserts the subscription. Browsers keep the endpoint when a subscription
+// is renewed, so an existing subscription with the same endpoint is replaced.
+func (m *PushSubscription) Save() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	if existing := FindPushSubscription(m.Endpoint); existing != nil {
+		m.ID = existing.ID
+		m.CreatedAt = existing.CreatedAt
+	}
+
+	return Db().Save(m).Error
+}
+
+// Delete removes the subscription.
+func (m *PushSubscription) Delete() error {
+	return DeletePushSubscription(m.Endpoint)
+}
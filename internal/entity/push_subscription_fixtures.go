@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+type PushSubscriptionMap map[string]PushSubscription
+
+func (m PushSubscriptionMap) Get(name string) PushSubscription {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return PushSubscription{}
+}
+
+func (m PushSubscriptionMap) Pointer(name string) *PushSubscription {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &PushSubscription{}
+}
+
+var PushSubscriptionFixtures = PushSubscriptionMap{
+	"bob-phone": {
+		ID:        1000000,
+		UserUID:   "uqxc08w3d0ej2283",
+		Endpoint:  "https://push.example.com/send/bob-phone",
+		P256dh:    "BNcRdreALRFXTkOOUHK1EtK2wtaz5Ry4YfYCA_0QTpQtUbVlUls0VJXg7A8u-Ts1XbjhazAkj7I99e8QcYP7DkM",
+		Auth:      "tBHItJI5svbpez7KI4CCXg",
+		UserAgent: "Mozilla/5.0 (Android 12; Mobile)",
+		CreatedAt: time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt: time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+}
+
+// CreatePushSubscriptionFixtures inserts known entities into the database for testing.
+func CreatePushSubscriptionFixtures() {
+	for _, entity := range PushSubscriptionFixtures {
+		Db().Create(&entity)
+	}
+}
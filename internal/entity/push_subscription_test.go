@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPushSubscription(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		m := FindPushSubscription("https://push.example.com/send/bob-phone")
+
+		if m == nil {
+			t.Fatal("subscription must not be nil")
+		}
+
+		assert.Equal(t, "uqxc08w3d0ej2283", m.UserUID)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindPushSubscription("https://push.example.com/send/missing"))
+		assert.Nil(t, FindPushSubscription(""))
+	})
+}
+
+func TestPushSubscription_Validate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		m := NewPushSubscription("uqxetse3cy5eo9z2", "https://push.example.com/send/1", "key", "auth", "")
+		assert.NoError(t, m.Validate())
+	})
+	t.Run("NoUser", func(t *testing.T) {
+		m := NewPushSubscription("", "https://push.example.com/send/1", "key", "auth", "")
+		assert.Error(t, m.Validate())
+	})
+	t.Run("NoKeys", func(t *testing.T) {
+		m := NewPushSubscription("uqxetse3cy5eo9z2", "https://push.example.com/send/1", "", "", "")
+		assert.Error(t, m.Validate())
+	})
+	t.Run("InsecureEndpoint", func(t *testing.T) {
+		m := NewPushSubscription("uqxetse3cy5eo9z2", "http://push.example.com/send/1", "key", "auth", "")
+		assert.Error(t, m.Validate())
+	})
+	t.Run("PrivateEndpoint", func(t *testing.T) {
+		for _, endpoint := range []string{"https://localhost/send/1", "https://127.0.0.1:8443/send/1", "https://169.254.169.254/latest", "https://[::1]/send/1", "https://192.168.1.1/send/1"} {
+			m := NewPushSubscription("uqxetse3cy5eo9z2", endpoint, "key", "auth", "")
+			assert.Error(t, m.Validate(), endpoint)
+		}
+	})
+}
+
+func TestPushSubscription_Save(t *testing.T) {
+	endpoint := "https://push.example.com/send/alice-save"
+
+	m := NewPushSubscription("uqxetse3cy5eo9z2", endpoint, "key", "auth", "Firefox")
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Renewed subscriptions keep their endpoint and replace the existing row.
+	renewed := NewPushSubscription("uqxetse3cy5eo9z2", endpoint, "key2", "auth2", "Firefox")
+
+	if err := renewed.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, m.ID, renewed.ID)
+
+	found := FindPushSubscription(endpoint)
+
+	if found == nil {
+		t.Fatal("subscription must not be nil")
+	}
+
+	assert.Equal(t, "key2", found.P256dh)
+
+	assert.NoError(t, found.Delete())
+	assert.Nil(t, FindPushSubscription(endpoint))
+	assert.Error(t, DeletePushSubscription(""))
+}
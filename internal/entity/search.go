@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+type Searches []Search
+
+// Search represents a saved search query of a user, e.g. to get notified about new photos of their kids.
+type Search struct {
+	SearchUID    string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"UID" yaml:"UID"`
+	UserUID      string     `gorm:"type:VARBINARY(42);index;" json:"UserUID" yaml:"UserUID"`
+	SearchName   string     `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name"`
+	SearchQuery  string     `gorm:"type:VARCHAR(1024);" json:"Query" yaml:"Query"`
+	SearchNotify bool       `json:"Notify" yaml:"Notify,omitempty"`
+	MatchCount   int        `json:"MatchCount" yaml:"-"`
+	CheckedAt    *time.Time `json:"CheckedAt" yaml:"-"`
+	CreatedAt    time.Time  `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
+	UpdatedAt    time.Time  `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (Search) TableName() string {
+	return "searches"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Search) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.SearchUID, 'q') {
+		return nil
+	}
+
+	return scope.SetColumn("SearchUID", rnd.PPID('q'))
+}
+
+// NewSearch returns a new saved search.
+func NewSearch(userUID, name, query string, notify bool) *Search {
+	return &Search{
+		UserUID:      userUID,
+		SearchName:   txt.Clip(name, txt.ClipName),
+		SearchQuery:  txt.Clip(query, 1024),
+		SearchNotify: notify,
+	}
+}
+
+// FindSearch returns a saved search by UID, or nil if it does not exist.
+func FindSearch(uid string) *Search {
+	if uid == "" {
+		return nil
+	}
+
+	result := Search{}
+
+	if err := Db().Where("search_uid = ?", uid).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Validate checks the user, name and query of the search.
+func (m *Search) Validate() error {
+	if m.UserUID == "" {
+		return fmt.Errorf("search user must not be empty")
+	} else if m.SearchName == "" {
+		return fmt.Errorf("search name must not be empty")
+	} else if m.SearchQuery == "" {
+		return fmt.Errorf("search query must not be empty")
+	}
+
+	return nil
+}
+
+// Create inserts a new row to the database.
+func (m *Search) Create() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	return Db().Create(m).Error
+}
+
+// Save updates or inserts a row.
+func (m *Search) Save() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	return Db().Save(m).Error
+}
+
+// Delete removes the saved search.
+func (m *Search) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// Since returns the time after which added photos are new matches.
+func (m *Search) Since() time.Time {
+	if m.CheckedAt != nil {
+		return *m.CheckedAt
+	}
+
+	return m.CreatedAt
+}
+
+// Checked records the number of new matches found at the given time.
+func (m *Search) Checked(at time.Time, count int) error {
+	m.CheckedAt = &at
+	m.MatchCount = count
+
+	return Db().Model(m).UpdateColumns(Values{"CheckedAt": m.CheckedAt, "MatchCount": m.MatchCount}).Error
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+type SearchMap map[string]Search
+
+func (m SearchMap) Get(name string) Search {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return Search{}
+}
+
+func (m SearchMap) Pointer(name string) *Search {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &Search{}
+}
+
+var SearchFixtures = SearchMap{
+	"kids": {
+		SearchUID:    "qt9lxuqxpogaaba1",
+		UserUID:      "uqxetse3cy5eo9z2",
+		SearchName:   "Kids",
+		SearchQuery:  "people:john",
+		SearchNotify: true,
+		CreatedAt:    time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:    time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+	"bob-cats": {
+		SearchUID:    "qt9lxuqxpogaaba2",
+		UserUID:      "uqxc08w3d0ej2283",
+		SearchName:   "Cats",
+		SearchQuery:  "label:cat",
+		SearchNotify: false,
+		CreatedAt:    time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:    time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+}
+
+// CreateSearchFixtures inserts known entities into the database for testing.
+func CreateSearchFixtures() {
+	for _, entity := range SearchFixtures {
+		Db().Create(&entity)
+	}
+}
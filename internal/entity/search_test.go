@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSearch(t *testing.T) {
+	m := NewSearch("uqxetse3cy5eo9z2", "Beach", "label:beach", true)
+
+	assert.Equal(t, "uqxetse3cy5eo9z2", m.UserUID)
+	assert.Equal(t, "Beach", m.SearchName)
+	assert.Equal(t, "label:beach", m.SearchQuery)
+	assert.True(t, m.SearchNotify)
+}
+
+func TestFindSearch(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := FindSearch("qt9lxuqxpogaaba1")
+
+		if m == nil {
+			t.Fatal("search should not be nil")
+		}
+
+		assert.Equal(t, "Kids", m.SearchName)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindSearch("qt9lxuqxpogaaxxx"))
+		assert.Nil(t, FindSearch(""))
+	})
+}
+
+func TestSearch_Validate(t *testing.T) {
+	assert.NoError(t, NewSearch("uqxetse3cy5eo9z2", "Beach", "label:beach", false).Validate())
+	assert.Error(t, NewSearch("", "Beach", "label:beach", false).Validate())
+	assert.Error(t, NewSearch("uqxetse3cy5eo9z2", "", "label:beach", false).Validate())
+	assert.Error(t, NewSearch("uqxetse3cy5eo9z2", "Beach", "", false).Validate())
+}
+
+func TestSearch_Create(t *testing.T) {
+	m := NewSearch("uqxetse3cy5eo9z2", "Mountains", "label:mountain", true)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, byte('q'), m.SearchUID[0])
+	assert.Len(t, m.SearchUID, 16)
+
+	assert.NoError(t, m.Delete())
+	assert.Nil(t, FindSearch(m.SearchUID))
+}
+
+func TestSearch_Checked(t *testing.T) {
+	m := NewSearch("uqxetse3cy5eo9z2", "Flowers", "label:flower", true)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Delete()
+
+	assert.Equal(t, m.CreatedAt, m.Since())
+
+	checkedAt := time.Now().UTC().Truncate(time.Second)
+
+	assert.NoError(t, m.Checked(checkedAt, 3))
+
+	found := FindSearch(m.SearchUID)
+
+	if found == nil {
+		t.Fatal("search should not be nil")
+	}
+
+	assert.Equal(t, 3, found.MatchCount)
+	assert.Equal(t, checkedAt, found.Since().UTC())
+}
@@ -1,6 +1,10 @@
 package entity
 
-import "github.com/photoprism/photoprism/internal/classify"
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/classify"
+)
 
 type Priorities map[string]int
 
@@ -44,3 +48,92 @@ var SrcPriority = Priorities{
 	SrcXmp:      32,
 	SrcManual:   64,
 }
+
+// Metadata fields with configurable source priorities.
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldTaken       = "taken"
+	FieldLocation    = "location"
+	FieldCamera      = "camera"
+	FieldRating      = "rating"
+	FieldKeywords    = "keywords"
+	FieldSubject     = "subject"
+	FieldNotes       = "notes"
+	FieldArtist      = "artist"
+	FieldCopyright   = "copyright"
+	FieldLicense     = "license"
+	FieldCaption     = "caption"
+)
+
+// PriorityFields lists the metadata fields with configurable source priorities.
+var PriorityFields = []string{
+	FieldTitle,
+	FieldDescription,
+	FieldTaken,
+	FieldLocation,
+	FieldCamera,
+	FieldRating,
+	FieldKeywords,
+	FieldSubject,
+	FieldNotes,
+	FieldArtist,
+	FieldCopyright,
+	FieldLicense,
+	FieldCaption,
+}
+
+// PriorityField tests if the metadata field has configurable source priorities.
+func PriorityField(field string) bool {
+	for _, f := range PriorityFields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fieldPriority maps custom source priorities by metadata field.
+var fieldPriority = make(map[string]Priorities)
+var fieldPriorityMutex = sync.RWMutex{}
+
+// SetFieldPriorities replaces the custom source priorities, with the sources of each field in
+// descending order of precedence. Listed sources take precedence over all other sources,
+// except manual changes, which keep the highest priority unless they are listed themselves.
+func SetFieldPriorities(fields map[string][]string) {
+	fieldPriorityMutex.Lock()
+	defer fieldPriorityMutex.Unlock()
+
+	fieldPriority = make(map[string]Priorities, len(fields))
+
+	for field, sources := range fields {
+		if len(sources) == 0 {
+			continue
+		}
+
+		p := make(Priorities, len(sources)+1)
+
+		for i, src := range sources {
+			p[src] = 2*SrcPriority[SrcManual] + len(sources) - i
+		}
+
+		if _, ok := p[SrcManual]; !ok {
+			p[SrcManual] = 4 * SrcPriority[SrcManual]
+		}
+
+		fieldPriority[field] = p
+	}
+}
+
+// FieldPriority returns the priority of a data source for the metadata field.
+func FieldPriority(field, src string) int {
+	fieldPriorityMutex.RLock()
+	defer fieldPriorityMutex.RUnlock()
+
+	if prio, ok := fieldPriority[field][src]; ok {
+		return prio
+	}
+
+	return SrcPriority[src]
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSrcString(t *testing.T) {
+	assert.Equal(t, "auto", SrcString(SrcAuto))
+	assert.Equal(t, "xmp", SrcString(SrcXmp))
+}
+
+func TestPriorityField(t *testing.T) {
+	assert.True(t, PriorityField(FieldTaken))
+	assert.False(t, PriorityField("foo"))
+}
+
+func TestFieldPriority(t *testing.T) {
+	SetFieldPriorities(map[string][]string{
+		FieldTitle: {SrcMeta, SrcXmp},
+		FieldTaken: {SrcMeta, SrcManual, SrcXmp},
+	})
+
+	defer SetFieldPriorities(nil)
+
+	t.Run("Default", func(t *testing.T) {
+		assert.Equal(t, SrcPriority[SrcXmp], FieldPriority(FieldKeywords, SrcXmp))
+		assert.Equal(t, SrcPriority[SrcName], FieldPriority(FieldTitle, SrcName))
+	})
+	t.Run("Custom", func(t *testing.T) {
+		assert.Greater(t, FieldPriority(FieldTitle, SrcMeta), FieldPriority(FieldTitle, SrcXmp))
+		assert.Greater(t, FieldPriority(FieldTitle, SrcXmp), FieldPriority(FieldTitle, SrcYaml))
+		assert.Greater(t, FieldPriority(FieldTitle, SrcManual), FieldPriority(FieldTitle, SrcMeta))
+		assert.Greater(t, FieldPriority(FieldTaken, SrcMeta), FieldPriority(FieldTaken, SrcManual))
+	})
+	t.Run("SetTitle", func(t *testing.T) {
+		m := Photo{PhotoTitle: "From XMP", TitleSrc: SrcXmp}
+
+		m.SetTitle("From Exif", SrcMeta)
+		assert.Equal(t, "From Exif", m.PhotoTitle)
+		assert.Equal(t, SrcMeta, m.TitleSrc)
+
+		m.SetTitle("From XMP", SrcXmp)
+		assert.Equal(t, "From Exif", m.PhotoTitle)
+		assert.Equal(t, SrcMeta, m.TitleSrc)
+	})
+	t.Run("SetTakenAt", func(t *testing.T) {
+		manual := time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+		meta := time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)
+		m := Photo{TakenAt: manual, TakenAtLocal: manual, TakenSrc: SrcManual}
+
+		m.SetTakenAt(meta, meta, "", SrcMeta)
+		assert.Equal(t, meta, m.TakenAt)
+		assert.Equal(t, SrcMeta, m.TakenSrc)
+	})
+}
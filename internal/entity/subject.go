@@ -22,10 +22,10 @@ var subjectMutex = sync.Mutex{}
 // Subject represents a named photo subject, typically a person.
 type Subject struct {
 	SubjUID      string          `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
-	SubjType     string          `gorm:"type:VARBINARY(8);default:'';" json:"Type,omitempty" yaml:"Type,omitempty"`
+	SubjType     string          `gorm:"type:VARBINARY(8);unique_index:idx_subjects_name_type;default:'';" json:"Type,omitempty" yaml:"Type,omitempty"`
 	SubjSrc      string          `gorm:"type:VARBINARY(8);default:'';" json:"Src,omitempty" yaml:"Src,omitempty"`
 	SubjSlug     string          `gorm:"type:VARBINARY(160);index;default:'';" json:"Slug" yaml:"-"`
-	SubjName     string          `gorm:"type:VARCHAR(160);unique_index;default:'';" json:"Name" yaml:"Name"`
+	SubjName     string          `gorm:"type:VARCHAR(160);unique_index:idx_subjects_name_type;default:'';" json:"Name" yaml:"Name"`
 	SubjAlias    string          `gorm:"type:VARCHAR(160);default:'';" json:"Alias" yaml:"Alias"`
 	SubjBio      string          `gorm:"type:TEXT;" json:"Bio" yaml:"Bio,omitempty"`
 	SubjNotes    string          `gorm:"type:TEXT;" json:"Notes,omitempty" yaml:"Notes,omitempty"`
@@ -115,6 +115,11 @@ func (m *Subject) Delete() error {
 		event.Publish("count.people", event.Data{
 			"count": -1,
 		})
+	} else if m.IsPet() {
+		event.EntitiesDeleted("pets", []string{m.SubjUID})
+		event.Publish("count.pets", event.Data{
+			"count": -1,
+		})
 	}
 
 	if err := Db().Model(&Face{}).Where("subj_uid = ?", m.SubjUID).Update("subj_uid", "").Error; err != nil {
@@ -152,6 +157,11 @@ func (m *Subject) Restore() error {
 			event.Publish("count.people", event.Data{
 				"count": 1,
 			})
+		} else if m.IsPet() {
+			event.EntitiesCreated("pets", []*Person{m.Person()})
+			event.Publish("count.pets", event.Data{
+				"count": 1,
+			})
 		}
 
 		return UnscopedDb().Model(m).UpdateColumn("DeletedAt", nil).Error
@@ -178,7 +188,7 @@ func FirstOrCreateSubject(m *Subject) *Subject {
 		return nil
 	}
 
-	if found := FindSubjectByName(m.SubjName); found != nil {
+	if found := FindSubjectByName(m.SubjName, m.SubjType); found != nil {
 		return found
 	} else if createErr := m.Create(); createErr == nil {
 		log.Infof("subject: added %s %s", TypeString(m.SubjType), sanitize.Log(m.SubjName))
@@ -190,10 +200,15 @@ func FirstOrCreateSubject(m *Subject) *Subject {
 			event.Publish("count.people", event.Data{
 				"count": 1,
 			})
+		} else if m.IsPet() {
+			event.EntitiesCreated("pets", []*Person{m.Person()})
+			event.Publish("count.pets", event.Data{
+				"count": 1,
+			})
 		}
 
 		return m
-	} else if found = FindSubjectByName(m.SubjName); found != nil {
+	} else if found = FindSubjectByName(m.SubjName, m.SubjType); found != nil {
 		return found
 	} else {
 		log.Errorf("subject: %s while creating %s", createErr, sanitize.Log(m.SubjName))
@@ -217,8 +232,8 @@ func FindSubject(s string) *Subject {
 	return &result
 }
 
-// FindSubjectByName find an existing subject by name.
-func FindSubjectByName(name string) *Subject {
+// FindSubjectByName find an existing subject by name and type, so that a pet can have the same name as a person.
+func FindSubjectByName(name, subjType string) *Subject {
 	name = sanitize.Name(name)
 
 	if name == "" {
@@ -228,7 +243,7 @@ func FindSubjectByName(name string) *Subject {
 	result := Subject{}
 
 	// Search database.
-	if err := UnscopedDb().Where("subj_name LIKE ?", name).First(&result).Error; err != nil {
+	if err := UnscopedDb().Where("subj_name LIKE ? AND subj_type = ?", name, subjType).First(&result).Error; err != nil {
 		return nil
 	}
 
@@ -247,6 +262,23 @@ func (m *Subject) IsPerson() bool {
 	return m.SubjType == SubjPerson
 }
 
+// IsPet tests if the subject is a pet.
+func (m *Subject) IsPet() bool {
+	return m.SubjType == SubjPet
+}
+
+// CounterName returns the name of the event that updates the client counter for the subject type, if any.
+func (m *Subject) CounterName() string {
+	switch m.SubjType {
+	case SubjPerson:
+		return "count.people"
+	case SubjPet:
+		return "count.pets"
+	default:
+		return ""
+	}
+}
+
 // Person creates and returns a Person based on this subject.
 func (m *Subject) Person() *Person {
 	return NewPerson(*m)
@@ -304,14 +336,14 @@ func (m *Subject) SaveForm(f form.Subject) (changed bool, err error) {
 		m.SubjExcluded = f.SubjExcluded
 
 		// Update counter.
-		if !m.IsPerson() {
+		if counter := m.CounterName(); counter == "" {
 			// Ignore.
 		} else if m.Visible() {
-			event.Publish("count.people", event.Data{
+			event.Publish(counter, event.Data{
 				"count": 1,
 			})
 		} else {
-			event.Publish("count.people", event.Data{
+			event.Publish(counter, event.Data{
 				"count": -1,
 			})
 		}
@@ -333,6 +365,8 @@ func (m *Subject) SaveForm(f form.Subject) (changed bool, err error) {
 
 			if m.IsPerson() {
 				event.EntitiesUpdated("people", []*Person{m.Person()})
+			} else if m.IsPet() {
+				event.EntitiesUpdated("pets", []*Person{m.Person()})
 			}
 
 			return true, nil
@@ -355,10 +389,12 @@ func (m *Subject) UpdateName(name string) (*Subject, error) {
 
 		if m.IsPerson() {
 			event.EntitiesUpdated("people", []*Person{m.Person()})
+		} else if m.IsPet() {
+			event.EntitiesUpdated("pets", []*Person{m.Person()})
 		}
 
 		return m, m.UpdateMarkerNames()
-	} else if existing := FindSubjectByName(m.SubjName); existing == nil {
+	} else if existing := FindSubjectByName(m.SubjName, m.SubjType); existing == nil {
 		return m, err
 	} else {
 		return existing, m.MergeWith(existing)
@@ -394,11 +430,11 @@ func (m *Subject) RefreshPhotos() error {
 	case MySQL:
 		update := fmt.Sprintf(`UPDATE photos p JOIN files f ON f.photo_id = p.id JOIN %s m ON m.file_uid = f.file_uid
 			SET p.checked_at = NULL WHERE m.subj_uid = ?`, Marker{}.TableName())
-		err = UnscopedDb().Exec(update, m.SubjUID).Error
+		err = Exec(update, m.SubjUID).Error
 	default:
 		update := fmt.Sprintf(`UPDATE photos SET checked_at = NULL WHERE id IN (SELECT f.photo_id FROM files f
 			JOIN %s m ON m.file_uid = f.file_uid WHERE m.subj_uid = ?)`, Marker{}.TableName())
-		err = UnscopedDb().Exec(update, m.SubjUID).Error
+		err = Exec(update, m.SubjUID).Error
 	}
 
 	return err
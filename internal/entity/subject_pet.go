@@ -0,0 +1,8 @@
+package entity
+
+const (
+	SubjPet = "pet" // SubjType for pets.
+)
+
+// SubjTypesNamed contains the subject types that can be named and searched like people.
+var SubjTypesNamed = []string{SubjPerson, SubjPet}
@@ -189,6 +189,32 @@ func TestFindSubject(t *testing.T) {
 	})
 }
 
+func TestFindSubjectByName(t *testing.T) {
+	t.Run("Person", func(t *testing.T) {
+		if s := FindSubjectByName("John Doe", SubjPerson); s == nil {
+			t.Fatal("result must not be nil")
+		} else {
+			assert.Equal(t, SubjPerson, s.SubjType)
+		}
+	})
+	t.Run("PetWithSameName", func(t *testing.T) {
+		assert.Nil(t, FindSubjectByName("John Doe", SubjPet))
+
+		pet := FirstOrCreateSubject(NewSubject("John Doe", SubjPet, SrcManual))
+
+		if pet == nil {
+			t.Fatal("result must not be nil")
+		}
+
+		assert.Equal(t, SubjPet, pet.SubjType)
+		assert.Equal(t, pet.SubjUID, FindSubjectByName("John Doe", SubjPet).SubjUID)
+		assert.NotEqual(t, pet.SubjUID, FindSubjectByName("John Doe", SubjPerson).SubjUID)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Nil(t, FindSubjectByName("", SubjPerson))
+	})
+}
+
 func TestSubject_Links(t *testing.T) {
 	t.Run("no-result", func(t *testing.T) {
 		m := SubjectFixtures.Pointer("john-doe")
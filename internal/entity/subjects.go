@@ -23,14 +23,14 @@ func OrphanPeople() (Subjects, error) {
 	orphans := Subjects{}
 
 	err := Db().
-		Where("subj_type = ?", SubjPerson).
+		Where("subj_type IN (?)", SubjTypesNamed).
 		Where(fmt.Sprintf("subj_uid NOT IN (SELECT DISTINCT subj_uid FROM %s)", Marker{}.TableName())).
 		Find(&orphans).Error
 
 	return orphans, err
 }
 
-// DeleteOrphanPeople finds and (soft) deletes all unused people.
+// DeleteOrphanPeople finds and (soft) deletes all unused people and pets.
 func DeleteOrphanPeople() (count int, err error) {
 	subj, err := OrphanPeople()
 
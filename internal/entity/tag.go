@@ -0,0 +1,154 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+type Tags []Tag
+
+// Tag represents a personal tag like "to print" that can be added to photos, separate from classification labels.
+type Tag struct {
+	TagUID    string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"UID" yaml:"UID"`
+	TagSlug   string    `gorm:"type:VARBINARY(160);unique_index;" json:"Slug" yaml:"-"`
+	TagName   string    `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
+	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// UIDs returns the tag UIDs.
+func (m Tags) UIDs() (result []string) {
+	for _, t := range m {
+		result = append(result, t.TagUID)
+	}
+
+	return result
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Tag) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.TagUID, 't') {
+		return nil
+	}
+
+	return scope.SetColumn("TagUID", rnd.PPID('t'))
+}
+
+// NewTag returns a new tag with the given name.
+func NewTag(name string) *Tag {
+	name = txt.Clip(strings.TrimSpace(name), txt.ClipName)
+
+	return &Tag{
+		TagSlug: txt.Slug(name),
+		TagName: name,
+	}
+}
+
+// FindTag returns a tag by UID or name, or nil if it does not exist.
+func FindTag(uidOrName string) *Tag {
+	if uidOrName == "" {
+		return nil
+	}
+
+	result := Tag{}
+
+	if rnd.IsUID(uidOrName, 't') {
+		if err := Db().Where("tag_uid = ?", uidOrName).First(&result).Error; err == nil {
+			return &result
+		}
+	}
+
+	if slug := txt.Slug(uidOrName); slug == "" {
+		return nil
+	} else if err := Db().Where("tag_slug = ?", slug).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Validate checks the tag name.
+func (m *Tag) Validate() error {
+	if m.TagName == "" || m.TagSlug == "" {
+		return fmt.Errorf("tag name must not be empty")
+	}
+
+	return nil
+}
+
+// Create inserts a new row to the database.
+func (m *Tag) Create() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	return Db().Create(m).Error
+}
+
+// FirstOrCreateTag returns the existing tag with the same name, inserts a new row or nil in case of errors.
+func FirstOrCreateTag(m *Tag) *Tag {
+	if m.TagSlug == "" {
+		return nil
+	}
+
+	result := Tag{}
+
+	if err := Db().Where("tag_slug = ?", m.TagSlug).First(&result).Error; err == nil {
+		return &result
+	} else if createErr := m.Create(); createErr == nil {
+		return m
+	} else if err := Db().Where("tag_slug = ?", m.TagSlug).First(&result).Error; err == nil {
+		return &result
+	} else {
+		log.Errorf("tag: %s (find or create %s)", createErr, txt.LogParam(m.TagSlug))
+	}
+
+	return nil
+}
+
+// Rename changes the name and slug of the tag.
+func (m *Tag) Rename(name string) error {
+	name = txt.Clip(strings.TrimSpace(name), txt.ClipName)
+	slug := txt.Slug(name)
+
+	if name == "" || slug == "" {
+		return fmt.Errorf("tag name must not be empty")
+	}
+
+	if slug != m.TagSlug {
+		if existing := FindTag(slug); existing != nil && existing.TagUID != m.TagUID {
+			return fmt.Errorf("tag %s already exists", txt.LogParam(name))
+		}
+	}
+
+	m.TagName = name
+	m.TagSlug = slug
+
+	return Db().Model(m).Updates(Values{"TagName": m.TagName, "TagSlug": m.TagSlug}).Error
+}
+
+// Delete removes the tag and its photo relations.
+func (m *Tag) Delete() error {
+	if m.TagUID == "" {
+		return fmt.Errorf("tag uid must not be empty")
+	}
+
+	return Db().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tag_uid = ?", m.TagUID).Delete(&PhotoTag{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(m).Error
+	})
+}
@@ -0,0 +1,62 @@
+package entity
+
+import "time"
+
+type TagMap map[string]Tag
+
+func (m TagMap) Get(name string) Tag {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return Tag{}
+}
+
+func (m TagMap) Pointer(name string) *Tag {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &Tag{}
+}
+
+var TagFixtures = TagMap{
+	"to-print": {
+		TagUID:    "tt9lxuqxpogaaba1",
+		TagSlug:   "to-print",
+		TagName:   "To Print",
+		CreatedAt: time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt: time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+	"for-blog": {
+		TagUID:    "tt9lxuqxpogaaba2",
+		TagSlug:   "for-blog",
+		TagName:   "For Blog",
+		CreatedAt: time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt: time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+	"needs-edit": {
+		TagUID:    "tt9lxuqxpogaaba3",
+		TagSlug:   "needs-edit",
+		TagName:   "Needs Edit",
+		CreatedAt: time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt: time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+}
+
+var PhotoTagFixtures = []PhotoTag{
+	{PhotoUID: "pt9jtdre2lvl0y11", TagUID: "tt9lxuqxpogaaba1", CreatedAt: time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC)},
+	{PhotoUID: "pt9jtdre2lvl0y11", TagUID: "tt9lxuqxpogaaba2", CreatedAt: time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC)},
+	{PhotoUID: "pt9jtdre2lvl0y12", TagUID: "tt9lxuqxpogaaba1", CreatedAt: time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC)},
+}
+
+// CreateTagFixtures inserts known entities into the database for testing.
+func CreateTagFixtures() {
+	for _, entity := range TagFixtures {
+		Db().Create(&entity)
+	}
+
+	for _, entity := range PhotoTagFixtures {
+		Db().Create(&entity)
+	}
+}
@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTag(t *testing.T) {
+	m := NewTag("  Needs Edit ")
+
+	assert.Equal(t, "Needs Edit", m.TagName)
+	assert.Equal(t, "needs-edit", m.TagSlug)
+	assert.Equal(t, "", m.TagUID)
+}
+
+func TestFindTag(t *testing.T) {
+	t.Run("UID", func(t *testing.T) {
+		m := FindTag("tt9lxuqxpogaaba1")
+
+		if m == nil {
+			t.Fatal("tag must not be nil")
+		}
+
+		assert.Equal(t, "To Print", m.TagName)
+	})
+	t.Run("Name", func(t *testing.T) {
+		m := FindTag("for blog")
+
+		if m == nil {
+			t.Fatal("tag must not be nil")
+		}
+
+		assert.Equal(t, "tt9lxuqxpogaaba2", m.TagUID)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindTag("tt9lxuqxpogaaxxx"))
+		assert.Nil(t, FindTag(""))
+	})
+}
+
+func TestFirstOrCreateTag(t *testing.T) {
+	t.Run("Existing", func(t *testing.T) {
+		m := FirstOrCreateTag(NewTag("to print"))
+
+		if m == nil {
+			t.Fatal("tag must not be nil")
+		}
+
+		assert.Equal(t, "tt9lxuqxpogaaba1", m.TagUID)
+	})
+	t.Run("New", func(t *testing.T) {
+		m := FirstOrCreateTag(NewTag("Gift Ideas"))
+
+		if m == nil {
+			t.Fatal("tag must not be nil")
+		}
+
+		assert.Len(t, m.TagUID, 16)
+		assert.Equal(t, "gift-ideas", m.TagSlug)
+		assert.NoError(t, m.Delete())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Nil(t, FirstOrCreateTag(NewTag("  ")))
+	})
+}
+
+func TestTag_Rename(t *testing.T) {
+	m := FirstOrCreateTag(NewTag("Rename Me"))
+
+	if m == nil {
+		t.Fatal("tag must not be nil")
+	}
+
+	assert.NoError(t, m.Rename("Renamed"))
+	assert.Equal(t, "renamed", m.TagSlug)
+	assert.Equal(t, "Renamed", FindTag(m.TagUID).TagName)
+	assert.Error(t, m.Rename("To Print"))
+	assert.Error(t, m.Rename(""))
+	assert.NoError(t, m.Delete())
+}
+
+func TestPhotoTags(t *testing.T) {
+	tag := FirstOrCreateTag(NewTag("Batch Tag"))
+
+	if tag == nil {
+		t.Fatal("tag must not be nil")
+	}
+
+	photos := []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}
+
+	added, err := AddPhotoTags([]string{tag.TagUID}, photos)
+
+	assert.NoError(t, err)
+	assert.Len(t, added, 2)
+
+	// Adding the same tag again must not fail.
+	added, err = AddPhotoTags([]string{tag.TagUID}, photos)
+
+	assert.NoError(t, err)
+	assert.Len(t, added, 2)
+
+	photo := Photo{PhotoUID: "pt9jtdre2lvl0yh7"}
+	photo.PreloadTags()
+
+	if assert.Len(t, photo.Tags, 1) {
+		assert.Equal(t, "Batch Tag", photo.Tags[0].TagName)
+	}
+
+	removed, err := RemovePhotoTags([]string{tag.TagUID}, photos[:1])
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+	assert.NoError(t, tag.Delete())
+
+	var count int
+	Db().Model(&PhotoTag{}).Where("tag_uid = ?", tag.TagUID).Count(&count)
+	assert.Equal(t, 0, count)
+}
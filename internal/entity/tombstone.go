@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// Tombstone types.
+const (
+	TombPhoto = "photos"
+	TombAlbum = "albums"
+)
+
+// Tombstone represents a permanently deleted photo or album, so that clients can remove it from
+// their cache when syncing changes. Photos have a tombstone for each album they were in, so that
+// deletions can be reported to guests.
+type Tombstone struct {
+	ID        uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	TombType  string    `gorm:"type:VARBINARY(16);index:idx_tombstones_type_created;" json:"Type" yaml:"Type"`
+	TombUID   string    `gorm:"type:VARBINARY(42);" json:"UID" yaml:"UID"`
+	AlbumUID  string    `gorm:"type:VARBINARY(42);index;" json:"AlbumUID,omitempty" yaml:"AlbumUID,omitempty"`
+	CreatedAt time.Time `gorm:"index:idx_tombstones_type_created;" json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// TableName returns the entity database table name.
+func (Tombstone) TableName() string {
+	return "tombstones"
+}
+
+// AddTombstones records the permanent deletion of a photo or album, optionally with the albums it was in.
+func AddTombstones(tombType, tombUID string, albumUIDs []string) error {
+	if tombType == "" || tombUID == "" {
+		return fmt.Errorf("tombstone type and uid must not be empty")
+	}
+
+	now := TimeStamp()
+
+	if err := Db().Create(&Tombstone{TombType: tombType, TombUID: tombUID, CreatedAt: now}).Error; err != nil {
+		return err
+	}
+
+	for _, albumUID := range albumUIDs {
+		if err := Db().Create(&Tombstone{TombType: tombType, TombUID: tombUID, AlbumUID: albumUID, CreatedAt: now}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddTombstones(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		if err := AddTombstones(TombAlbum, "at9lxuqxpotomb01", []string{"at9lxuqxpotomb01"}); err != nil {
+			t.Fatal(err)
+		}
+
+		var result []Tombstone
+
+		if err := Db().Where("tomb_uid = ?", "at9lxuqxpotomb01").Order("id").Find(&result).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+		assert.Equal(t, "", result[0].AlbumUID)
+		assert.Equal(t, "at9lxuqxpotomb01", result[1].AlbumUID)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Error(t, AddTombstones(TombPhoto, "", nil))
+	})
+}
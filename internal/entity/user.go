@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/mail"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -198,6 +199,38 @@ func FindUserByUID(uid string) *User {
 	}
 }
 
+// UserDisabled tests if the user with the specified uid has been disabled.
+func UserDisabled(uid string) bool {
+	if uid == "" {
+		return false
+	}
+
+	var count int
+
+	if err := Db().Model(&User{}).Where("user_uid = ? AND user_disabled = ?", uid, true).Count(&count).Error; err != nil {
+		log.Debugf("user: %s", err)
+		return false
+	}
+
+	return count > 0
+}
+
+// UserActive tests if the user with the specified uid exists and has neither been disabled nor deleted.
+func UserActive(uid string) bool {
+	if uid == "" {
+		return false
+	}
+
+	var count int
+
+	if err := Db().Model(&User{}).Where("user_uid = ? AND user_disabled = ?", uid, false).Count(&count).Error; err != nil {
+		log.Debugf("user: %s", err)
+		return false
+	}
+
+	return count > 0
+}
+
 // Delete marks the entity as deleted.
 func (m *User) Delete() error {
 	if m.ID <= 1 {
@@ -296,6 +329,11 @@ func (m *User) InvalidPassword(password string) bool {
 		return true
 	}
 
+	if m.UserDisabled {
+		log.Warnf("login for %s is disabled", sanitize.Log(m.Username()))
+		return true
+	}
+
 	if password == "" {
 		return true
 	}
@@ -348,6 +386,36 @@ func (m *User) Role() acl.Role {
 	return acl.RoleDefault
 }
 
+// UserRoles lists the roles that can be assigned to users, "user" has no special permissions.
+var UserRoles = []string{string(acl.RoleAdmin), string(acl.RoleFamily), string(acl.RoleFriend), string(acl.RoleChild), string(acl.RoleGuest), "user"}
+
+// SetRole replaces the current user role, see UserRoles.
+func (m *User) SetRole(role string) error {
+	m.RoleAdmin = false
+	m.RoleChild = false
+	m.RoleFamily = false
+	m.RoleFriend = false
+	m.RoleGuest = false
+
+	switch acl.Role(strings.ToLower(strings.TrimSpace(role))) {
+	case acl.RoleAdmin:
+		m.RoleAdmin = true
+	case acl.RoleChild:
+		m.RoleChild = true
+	case acl.RoleFamily:
+		m.RoleFamily = true
+	case acl.RoleFriend:
+		m.RoleFriend = true
+	case acl.RoleGuest:
+		m.RoleGuest = true
+	case "user", acl.RoleDefault:
+	default:
+		return fmt.Errorf("unknown role %s", sanitize.Log(role))
+	}
+
+	return nil
+}
+
 // Validate Makes sure username and email are unique and meet requirements. Returns error if any property is invalid
 func (m *User) Validate() error {
 	if m.Username() == "" {
@@ -398,6 +466,11 @@ func CreateWithPassword(uc form.UserCreate) error {
 		PrimaryEmail: uc.Email,
 		RoleAdmin:    true,
 	}
+	if uc.Role != "" {
+		if err := u.SetRole(uc.Role); err != nil {
+			return err
+		}
+	}
 	if len(uc.Password) < 4 {
 		return fmt.Errorf("new password for %s must be at least 4 characters", sanitize.Log(u.Username()))
 	}
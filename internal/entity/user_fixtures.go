@@ -66,6 +66,18 @@ var UserFixtures = UserMap{
 		PrimaryEmail: "",
 		DeletedAt:    &deleteTime,
 	},
+	"guest": {
+		ID:           10000009,
+		AddressID:    1,
+		UserUID:      "uqxqg7i1kperxvu9",
+		UserName:     "grandma",
+		FullName:     "Grandma",
+		RoleAdmin:    false,
+		RoleGuest:    true,
+		RoleFriend:   false,
+		UserDisabled: false,
+		PrimaryEmail: "grandma@example.com",
+	},
 }
 
 // CreateUserFixtures inserts known entities into the database for testing.
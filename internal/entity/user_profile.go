@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// userLanguageRegexp matches supported language codes like "en" or "pt_BR".
+var userLanguageRegexp = regexp.MustCompile(`^[a-z]{2}(_[A-Z]{2})?$`)
+
+// userThemeRegexp matches user interface theme names like "default" or "mint-dark".
+var userThemeRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// UserPreferences represents personal user interface preferences, stored as JSON in UserSettings.
+type UserPreferences struct {
+	Language string `json:"Language,omitempty" yaml:"Language,omitempty"`
+	Theme    string `json:"Theme,omitempty" yaml:"Theme,omitempty"`
+}
+
+// UserProfile represents the public profile of a user, which users may change themselves.
+type UserProfile struct {
+	UID         string `json:"UID"`
+	UserName    string `json:"UserName"`
+	DisplayName string `json:"DisplayName"`
+	Avatar      string `json:"Avatar"`
+	Language    string `json:"Language"`
+	Theme       string `json:"Theme"`
+}
+
+// Preferences returns the personal user interface preferences.
+func (m *User) Preferences() (result UserPreferences) {
+	if m.UserSettings == "" {
+		return result
+	}
+
+	if err := json.Unmarshal([]byte(m.UserSettings), &result); err != nil {
+		log.Warnf("user: %s (parse settings of %s)", err, m.String())
+	}
+
+	return result
+}
+
+// SetPreferences validates and sets the personal user interface preferences, empty values
+// reset them to the global defaults.
+func (m *User) SetPreferences(prefs UserPreferences) error {
+	prefs.Language = strings.TrimSpace(prefs.Language)
+	prefs.Theme = strings.ToLower(strings.TrimSpace(prefs.Theme))
+
+	if prefs.Language != "" && !userLanguageRegexp.MatchString(prefs.Language) {
+		return fmt.Errorf("user: invalid language %s", sanitize.Log(prefs.Language))
+	} else if prefs.Theme != "" && !userThemeRegexp.MatchString(prefs.Theme) {
+		return fmt.Errorf("user: invalid theme %s", sanitize.Log(prefs.Theme))
+	}
+
+	if prefs == (UserPreferences{}) {
+		m.UserSettings = ""
+		return nil
+	}
+
+	data, err := json.Marshal(prefs)
+
+	if err != nil {
+		return err
+	}
+
+	m.UserSettings = string(data)
+
+	return nil
+}
+
+// SetAvatar uses the primary file of a photo as avatar, see PersonAvatar.
+func (m *User) SetAvatar(photoUID string) error {
+	if !rnd.IsPPID(photoUID, 'p') {
+		return fmt.Errorf("user: invalid photo uid %s", sanitize.Log(photoUID))
+	}
+
+	file, err := PrimaryFile(photoUID)
+
+	if err != nil {
+		return fmt.Errorf("user: photo %s not found", sanitize.Log(photoUID))
+	}
+
+	m.PersonAvatar = file.FileHash
+
+	return nil
+}
+
+// Profile returns the public user profile.
+func (m *User) Profile() UserProfile {
+	prefs := m.Preferences()
+
+	return UserProfile{
+		UID:         m.UserUID,
+		UserName:    m.Username(),
+		DisplayName: m.FullName,
+		Avatar:      m.PersonAvatar,
+		Language:    prefs.Language,
+		Theme:       prefs.Theme,
+	}
+}
+
+// SaveProfile updates the user profile from a form. A new avatar can be picked by photo
+// uid, and an empty avatar removes the current one.
+func (m *User) SaveProfile(f form.UserProfile) error {
+	if !m.Registered() {
+		return fmt.Errorf("user: only registered users can change their profile")
+	}
+
+	if name := strings.TrimSpace(f.DisplayName); name == "" {
+		return fmt.Errorf("user: display name must not be empty")
+	} else if len(name) > 128 {
+		return fmt.Errorf("user: display name is too long")
+	} else {
+		m.FullName = name
+	}
+
+	if f.AvatarUID != "" {
+		if err := m.SetAvatar(f.AvatarUID); err != nil {
+			return err
+		}
+	} else if f.Avatar == "" {
+		m.PersonAvatar = ""
+	}
+
+	if err := m.SetPreferences(UserPreferences{Language: f.Language, Theme: f.Theme}); err != nil {
+		return err
+	}
+
+	return Db().Model(m).Updates(map[string]interface{}{
+		"full_name":     m.FullName,
+		"person_avatar": m.PersonAvatar,
+		"user_settings": m.UserSettings,
+	}).Error
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestUser_SetPreferences(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		m := User{}
+
+		if err := m.SetPreferences(UserPreferences{Language: "pt_BR", Theme: " Mint "}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, `{"Language":"pt_BR","Theme":"mint"}`, m.UserSettings)
+		assert.Equal(t, UserPreferences{Language: "pt_BR", Theme: "mint"}, m.Preferences())
+	})
+	t.Run("Reset", func(t *testing.T) {
+		m := User{UserSettings: `{"Language":"de"}`}
+
+		if err := m.SetPreferences(UserPreferences{}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", m.UserSettings)
+		assert.Equal(t, UserPreferences{}, m.Preferences())
+	})
+	t.Run("InvalidLanguage", func(t *testing.T) {
+		m := User{}
+		assert.Error(t, m.SetPreferences(UserPreferences{Language: "<script>"}))
+		assert.Equal(t, "", m.UserSettings)
+	})
+	t.Run("InvalidTheme", func(t *testing.T) {
+		m := User{}
+		assert.Error(t, m.SetPreferences(UserPreferences{Theme: "../default"}))
+	})
+}
+
+func TestUser_Preferences(t *testing.T) {
+	t.Run("Invalid", func(t *testing.T) {
+		m := User{UserSettings: "{"}
+		assert.Equal(t, UserPreferences{}, m.Preferences())
+	})
+}
+
+func TestUser_SetAvatar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := User{}
+
+		if err := m.SetAvatar("pt9jtdre2lvl0yh7"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", m.PersonAvatar)
+	})
+	t.Run("InvalidUID", func(t *testing.T) {
+		m := User{}
+		assert.Error(t, m.SetAvatar("xxx"))
+		assert.Equal(t, "", m.PersonAvatar)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		m := User{}
+		assert.Error(t, m.SetAvatar("pt9jtdre2lvl0yxx"))
+	})
+}
+
+func TestUser_SaveProfile(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := &User{UserName: "profile", FullName: "Profile"}
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := form.NewUserProfile(m.Profile())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f.DisplayName = " Jane Doe "
+		f.AvatarUID = "pt9jtdre2lvl0yh7"
+		f.Language = "de"
+
+		if err := m.SaveProfile(f); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FindUserByUID(m.UserUID)
+
+		if result == nil {
+			t.Fatal("user not found")
+		}
+
+		profile := result.Profile()
+
+		assert.Equal(t, "profile", profile.UserName)
+		assert.Equal(t, "Jane Doe", profile.DisplayName)
+		assert.Equal(t, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", profile.Avatar)
+		assert.Equal(t, "de", profile.Language)
+		assert.Equal(t, "", profile.Theme)
+
+		// Keeps the current avatar.
+		f, _ = form.NewUserProfile(profile)
+
+		if err := result.SaveProfile(f); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", result.PersonAvatar)
+
+		// Removes the avatar.
+		f.Avatar = ""
+
+		if err := result.SaveProfile(f); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", FindUserByUID(m.UserUID).PersonAvatar)
+	})
+	t.Run("EmptyName", func(t *testing.T) {
+		m := UserFixtures.Pointer("bob")
+		assert.Error(t, m.SaveProfile(form.UserProfile{DisplayName: " "}))
+	})
+	t.Run("Unregistered", func(t *testing.T) {
+		m := Guest
+		assert.Error(t, m.SaveProfile(form.UserProfile{DisplayName: "Guest"}))
+	})
+}
@@ -115,6 +115,15 @@ func TestUser_InvalidPassword(t *testing.T) {
 		assert.True(t, p.InvalidPassword("abcdef"))
 
 	})
+	t.Run("disabled", func(t *testing.T) {
+		m := FindUserByName("friend")
+
+		if m == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.True(t, m.InvalidPassword("!Friend321"))
+	})
 	t.Run("not registered", func(t *testing.T) {
 		p := User{UserUID: "u12", UserName: "", FullName: ""}
 		assert.True(t, p.InvalidPassword("abcdef"))
@@ -391,6 +400,24 @@ func TestUser_Role(t *testing.T) {
 	})
 }
 
+func TestUser_SetRole(t *testing.T) {
+	t.Run("Guest", func(t *testing.T) {
+		p := User{UserUID: "u000000000000008", UserName: "Hanna", RoleAdmin: true}
+		assert.NoError(t, p.SetRole("Guest"))
+		assert.False(t, p.RoleAdmin)
+		assert.Equal(t, acl.RoleGuest, p.Role())
+	})
+	t.Run("User", func(t *testing.T) {
+		p := User{UserUID: "u000000000000008", UserName: "Hanna", RoleFriend: true}
+		assert.NoError(t, p.SetRole("user"))
+		assert.Equal(t, acl.RoleDefault, p.Role())
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		p := User{UserUID: "u000000000000008", UserName: "Hanna"}
+		assert.Error(t, p.SetRole("superuser"))
+	})
+}
+
 func TestUser_Validate(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
 		u := &User{
@@ -500,6 +527,32 @@ func TestCreateWithPassword(t *testing.T) {
 		err := CreateWithPassword(u)
 		assert.Nil(t, err)
 	})
+	t.Run("guest", func(t *testing.T) {
+		u := form.UserCreate{
+			UserName: "thomas3",
+			FullName: "Thomas Three",
+			Email:    "thomas3@example.com",
+			Password: "helloworld",
+			Role:     "guest",
+		}
+		if err := CreateWithPassword(u); err != nil {
+			t.Fatal(err)
+		}
+		m := FindUserByName("thomas3")
+		if m == nil {
+			t.Fatal("user should not be nil")
+		}
+		assert.True(t, m.Guest())
+		assert.False(t, m.Admin())
+	})
+	t.Run("invalid role", func(t *testing.T) {
+		u := form.UserCreate{
+			UserName: "thomas4",
+			Password: "helloworld",
+			Role:     "superuser",
+		}
+		assert.Error(t, CreateWithPassword(u))
+	})
 }
 
 func TestDeleteUser(t *testing.T) {
@@ -530,3 +583,18 @@ func TestUser_Deleted(t *testing.T) {
 	assert.False(t, UserFixtures.Pointer("alice").Deleted())
 	assert.True(t, UserFixtures.Pointer("deleted").Deleted())
 }
+
+func TestUserDisabled(t *testing.T) {
+	assert.True(t, UserDisabled("uqxqg7i1kperxvu7"))
+	assert.False(t, UserDisabled("uqxc08w3d0ej2283"))
+	assert.False(t, UserDisabled(""))
+}
+
+func TestUserActive(t *testing.T) {
+	assert.True(t, UserActive("uqxc08w3d0ej2283"))
+	assert.True(t, UserActive("uqxqg7i1kperxvu9"))
+	assert.False(t, UserActive("uqxqg7i1kperxvu7"))
+	assert.False(t, UserActive("uqxqg7i1kperxvu8"))
+	assert.False(t, UserActive("uqxxxxxxxxxxxxxx"))
+	assert.False(t, UserActive(""))
+}
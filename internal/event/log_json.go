@@ -0,0 +1,86 @@
+package event
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Structured log fields.
+const (
+	FieldComponent = "component"
+	FieldPhotoUID  = "photo_uid"
+	FieldDuration  = "duration"
+)
+
+// logComponentRegexp matches the component prefix of log messages like "index: ...".
+var logComponentRegexp = regexp.MustCompile(`^([a-z][a-z0-9-]*): `)
+
+// logDurationRegexp matches the duration at the end of log messages like "... [1.25s]".
+var logDurationRegexp = regexp.MustCompile(`\[([0-9.]+[a-zµ]+)]$`)
+
+// JsonFormatter formats log entries as JSON with consistent fields for log aggregation.
+type JsonFormatter struct {
+	logrus.JSONFormatter
+}
+
+// NewJsonFormatter returns a new JSON log formatter.
+func NewJsonFormatter() *JsonFormatter {
+	return &JsonFormatter{
+		JSONFormatter: logrus.JSONFormatter{TimestampFormat: time.RFC3339Nano},
+	}
+}
+
+// LogFields returns the structured fields found in a log message.
+func LogFields(msg string) logrus.Fields {
+	fields := logrus.Fields{}
+
+	if m := logComponentRegexp.FindStringSubmatch(msg); len(m) == 2 {
+		fields[FieldComponent] = m[1]
+	}
+
+	if m := logDurationRegexp.FindStringSubmatch(msg); len(m) == 2 {
+		if d, err := time.ParseDuration(m[1]); err == nil {
+			fields[FieldDuration] = d.Seconds()
+		}
+	}
+
+	for _, w := range strings.FieldsFunc(msg, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) {
+		if rnd.IsPPID(w, 'p') {
+			fields[FieldPhotoUID] = w
+			break
+		}
+	}
+
+	return fields
+}
+
+// Format renders a log entry as JSON, adding fields found in the message unless they were set explicitly.
+func (f *JsonFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	fields := LogFields(entry.Message)
+
+	for k, v := range entry.Data {
+		fields[k] = v
+	}
+
+	e := entry.WithFields(fields)
+	e.Time = entry.Time
+	e.Level = entry.Level
+	e.Message = entry.Message
+	e.Caller = entry.Caller
+	e.Buffer = entry.Buffer
+
+	return f.JSONFormatter.Format(e)
+}
+
+// JsonLog tests if logs are formatted as JSON.
+func JsonLog() bool {
+	_, ok := Log.Formatter.(*JsonFormatter)
+	return ok
+}
@@ -0,0 +1,63 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogFields(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		fields := LogFields("index: updated photo pt9jtdre2lvl0yh7 in 2019/img.jpg [1.5s]")
+
+		assert.Equal(t, "index", fields[FieldComponent])
+		assert.Equal(t, "pt9jtdre2lvl0yh7", fields[FieldPhotoUID])
+		assert.Equal(t, 1.5, fields[FieldDuration])
+	})
+	t.Run("Path", func(t *testing.T) {
+		fields := LogFields("http: GET /api/v1/photos/pt9jtdre2lvl0yh7/dl (200) [250µs]")
+
+		assert.Equal(t, "http", fields[FieldComponent])
+		assert.Equal(t, "pt9jtdre2lvl0yh7", fields[FieldPhotoUID])
+		assert.Equal(t, 0.00025, fields[FieldDuration])
+	})
+	t.Run("None", func(t *testing.T) {
+		assert.Empty(t, LogFields("Starting PhotoPrism"))
+	})
+}
+
+func TestJsonFormatter_Format(t *testing.T) {
+	f := NewJsonFormatter()
+
+	entry := logrus.NewEntry(logrus.New())
+	entry.Time = time.Date(2021, 10, 14, 9, 30, 0, 0, time.UTC)
+	entry.Level = logrus.InfoLevel
+	entry.Message = "faces: found 3 new faces [12ms]"
+	entry.Data = logrus.Fields{FieldComponent: "workers"}
+
+	b, err := f.Format(entry)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result map[string]interface{}
+
+	if err := json.Unmarshal(b, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "faces: found 3 new faces [12ms]", result["msg"])
+	assert.Equal(t, "info", result["level"])
+	assert.Equal(t, "2021-10-14T09:30:00Z", result["time"])
+	assert.Equal(t, "workers", result[FieldComponent])
+	assert.Equal(t, 0.012, result[FieldDuration])
+	assert.NotContains(t, result, FieldPhotoUID)
+}
+
+func TestJsonLog(t *testing.T) {
+	assert.False(t, JsonLog())
+}
@@ -16,6 +16,23 @@ func (faces Faces) Contains(other Face) bool {
 	return false
 }
 
+// Similar returns true if a face with a similar embedding already exists.
+func (faces Faces) Similar(other Face, dist float64) bool {
+	if !other.Embeddings.One() {
+		return false
+	}
+
+	embedding := other.Embeddings.First()
+
+	for _, f := range faces {
+		if f.Embeddings.One() && f.Embeddings.First().Distance(embedding) < dist {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Append adds a face.
 func (faces *Faces) Append(f Face) {
 	*faces = append(*faces, f)
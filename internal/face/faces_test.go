@@ -146,3 +146,20 @@ func TestFaces_Contains(t *testing.T) {
 		assert.False(t, faces.Contains(c))
 	})
 }
+
+func TestFaces_Similar(t *testing.T) {
+	a := Face{Embeddings: Embeddings{make(Embedding, 512)}}
+	b := Face{Embeddings: Embeddings{make(Embedding, 512)}}
+	c := Face{Embeddings: Embeddings{make(Embedding, 512)}}
+	c.Embeddings[0][0] = 1
+
+	t.Run("Similar", func(t *testing.T) {
+		assert.True(t, Faces{a}.Similar(b, MatchDist))
+	})
+	t.Run("Different", func(t *testing.T) {
+		assert.False(t, Faces{a}.Similar(c, MatchDist))
+	})
+	t.Run("NoEmbedding", func(t *testing.T) {
+		assert.False(t, Faces{a}.Similar(Face{}, MatchDist))
+	})
+}
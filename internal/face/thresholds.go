@@ -15,6 +15,7 @@ var ClusterDist = 0.64                           // Similarity distance threshol
 var MatchDist = 0.46                             // Distance offset threshold for matching new faces with clusters.
 var ClusterCore = 4                              // Min number of faces forming a cluster core.
 var SampleThreshold = 2 * ClusterCore            // Threshold for automatic clustering to start.
+var Keyframes = 3                                // Number of video keyframes to search for faces.
 
 // QualityThreshold returns the scale adjusted quality score threshold.
 func QualityThreshold(scale int) (score float32) {
@@ -19,6 +19,7 @@ type Album struct {
 	AlbumCountry     string `json:"Country"`
 	AlbumFavorite    bool   `json:"Favorite"`
 	AlbumPrivate     bool   `json:"Private"`
+	AlbumGuests      bool   `json:"Guests"`
 }
 
 func NewAlbum(m interface{}) (f Album, err error) {
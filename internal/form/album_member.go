@@ -0,0 +1,8 @@
+package form
+
+// AlbumMember represents an album membership form.
+type AlbumMember struct {
+	UserUID  string `json:"UserUID"`
+	UserName string `json:"UserName"`
+	Role     string `json:"Role"`
+}
@@ -0,0 +1,7 @@
+package form
+
+// CameraAlias represents a camera or lens alias form.
+type CameraAlias struct {
+	Name   string `json:"Name"`
+	Target string `json:"Target"`
+}
@@ -0,0 +1,13 @@
+package form
+
+// Download represents a form for adding remote files and RSS / Atom feeds to the download queue.
+type Download struct {
+	URLs  []string `json:"URLs"`
+	Feeds []string `json:"Feeds"`
+	Album string   `json:"Album"`
+}
+
+// Empty tests if no urls have been submitted.
+func (f Download) Empty() bool {
+	return len(f.URLs) == 0 && len(f.Feeds) == 0
+}
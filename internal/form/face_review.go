@@ -0,0 +1,19 @@
+package form
+
+// FaceConfirmation represents the review result of a single face marker.
+type FaceConfirmation struct {
+	MarkerUID     string `json:"UID"`
+	SubjUID       string `json:"SubjUID"`
+	MarkerName    string `json:"Name"`
+	MarkerInvalid bool   `json:"Invalid"`
+}
+
+// FaceReview represents a batch of face confirmations.
+type FaceReview struct {
+	Faces []FaceConfirmation `json:"Faces"`
+}
+
+// Empty tests if no faces have been reviewed.
+func (f FaceReview) Empty() bool {
+	return len(f.Faces) == 0
+}
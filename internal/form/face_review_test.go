@@ -0,0 +1,18 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaceReview_Empty(t *testing.T) {
+	t.Run("true", func(t *testing.T) {
+		f := FaceReview{}
+		assert.True(t, f.Empty())
+	})
+	t.Run("false", func(t *testing.T) {
+		f := FaceReview{Faces: []FaceConfirmation{{MarkerUID: "mt9k3pw1wowuy444", MarkerName: "Jane Doe"}}}
+		assert.False(t, f.Empty())
+	})
+}
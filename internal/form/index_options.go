@@ -1,6 +1,8 @@
 package form
 
 type IndexOptions struct {
-	Path   string `json:"path"`
-	Rescan bool   `json:"rescan"`
+	Path   string   `json:"path"`
+	Filter string   `json:"filter"`
+	Stages []string `json:"stages"`
+	Rescan bool     `json:"rescan"`
 }
@@ -9,4 +9,7 @@ type Link struct {
 	MaxViews    uint   `json:"MaxViews"`
 	CanComment  bool   `json:"CanComment"`
 	CanEdit     bool   `json:"CanEdit"`
+	CanUpload   bool   `json:"CanUpload"`
+	LinkReview  bool   `json:"Review"`
+	LinkGeo     string `json:"Geo"`
 }
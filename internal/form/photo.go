@@ -41,10 +41,14 @@ type Photo struct {
 	Details          Details   `json:"Details"`
 	PhotoStack       int8      `json:"Stack"`
 	PhotoFavorite    bool      `json:"Favorite"`
+	PhotoRating      int       `json:"Rating"`
+	RatingSrc        string    `json:"RatingSrc"`
 	PhotoPrivate     bool      `json:"Private"`
 	PhotoScan        bool      `json:"Scan"`
 	PhotoPanorama    bool      `json:"Panorama"`
 	PhotoAltitude    int       `json:"Altitude"`
+	PhotoHeading     float32   `json:"Heading"`
+	PhotoSpeed       float32   `json:"Speed"`
 	PhotoLat         float32   `json:"Lat"`
 	PhotoLng         float32   `json:"Lng"`
 	PhotoIso         int       `json:"Iso"`
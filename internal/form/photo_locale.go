@@ -0,0 +1,7 @@
+package form
+
+// PhotoLocale represents a form for editing the title and description of a photo in another language.
+type PhotoLocale struct {
+	Title       string `json:"Title"`
+	Description string `json:"Description"`
+}
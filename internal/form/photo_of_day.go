@@ -0,0 +1,26 @@
+package form
+
+import (
+	"time"
+)
+
+// PhotoOfDayDate is the date format of the day parameter.
+const PhotoOfDayDate = "2006-01-02"
+
+// PhotoOfDay represents request parameters for "/api/v1/photo-of-the-day".
+type PhotoOfDay struct {
+	Query  string `form:"q"`      // Optional search filter, e.g. "favorite:true label:landscape".
+	Date   string `form:"date"`   // Day in the format 2006-01-02, default is today.
+	Seed   string `form:"seed"`   // Selects a different photo per day, e.g. for multiple screens.
+	Size   string `form:"size"`   // Thumbnail size of the image URL, default is fit_1920.
+	Format string `form:"format"` // Response format: json, oembed, or image.
+}
+
+// Day returns the requested day, or the current day if no date was specified.
+func (f PhotoOfDay) Day(now time.Time) (time.Time, error) {
+	if f.Date == "" {
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()), nil
+	}
+
+	return time.ParseInLocation(PhotoOfDayDate, f.Date, now.Location())
+}
@@ -0,0 +1,30 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoOfDay_Day(t *testing.T) {
+	now := time.Date(2026, 10, 14, 17, 30, 0, 0, time.UTC)
+
+	t.Run("Today", func(t *testing.T) {
+		day, err := PhotoOfDay{}.Day(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2026-10-14 00:00:00", day.Format("2006-01-02 15:04:05"))
+	})
+	t.Run("Date", func(t *testing.T) {
+		day, err := PhotoOfDay{Date: "2021-02-28"}.Day(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2021-02-28", day.Format(PhotoOfDayDate))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := PhotoOfDay{Date: "28.02.2021"}.Day(now)
+
+		assert.Error(t, err)
+	})
+}
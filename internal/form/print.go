@@ -0,0 +1,8 @@
+package form
+
+// PrintOrder represents a form for sending photos to an external print service.
+type PrintOrder struct {
+	Photos  []string `json:"photos"`
+	Product string   `json:"product"`
+	Copies  int      `json:"copies"`
+}
@@ -0,0 +1,13 @@
+package form
+
+// PushSubscriptionKeys represents the encryption keys of a browser push subscription.
+type PushSubscriptionKeys struct {
+	P256dh string `json:"p256dh"`
+	Auth   string `json:"auth"`
+}
+
+// PushSubscription represents a browser push subscription as returned by PushSubscription.toJSON().
+type PushSubscription struct {
+	Endpoint string               `json:"endpoint"`
+	Keys     PushSubscriptionKeys `json:"keys"`
+}
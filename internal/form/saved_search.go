@@ -0,0 +1,8 @@
+package form
+
+// SavedSearch represents a saved search form.
+type SavedSearch struct {
+	Name   string `json:"Name"`
+	Query  string `json:"Query"`
+	Notify bool   `json:"Notify"`
+}
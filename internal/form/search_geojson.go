@@ -14,6 +14,7 @@ type SearchGeo struct {
 	Title    string    `form:"title"`
 	Before   time.Time `form:"before" time_format:"2006-01-02"`
 	After    time.Time `form:"after" time_format:"2006-01-02"`
+	Taken    string    `form:"taken"` // Date taken or range with optional start and end, e.g. 2019-06..2019-08.
 	Favorite bool      `form:"favorite"`
 	Unsorted bool      `form:"unsorted"`
 	Video    bool      `form:"video"`
@@ -33,6 +34,9 @@ type SearchGeo struct {
 	S2       string    `form:"s2"`
 	Olc      string    `form:"olc"`
 	Dist     uint      `form:"dist"`
+	Radius   float64   `form:"radius"`   // Exact distance to lat and lng in km, e.g. 5.
+	Latlng   string    `form:"latlng"`   // Viewport bounds as north, east, south, and west, e.g. 52.6,13.6,52.3,13.1.
+	Polygon  string    `form:"polygon"`  // Pairs of latitude and longitude, e.g. 52.6,13.3,52.6,13.5,52.4,13.4.
 	Face     string    `form:"face"`     // UIDs
 	Subject  string    `form:"subject"`  // UIDs
 	Person   string    `form:"person"`   // Alias for Subject
@@ -50,6 +54,8 @@ type SearchGeo struct {
 	Lens     int       `form:"lens"`
 	Count    int       `form:"count" serialize:"-"`
 	Offset   int       `form:"offset" serialize:"-"`
+
+	shared []string // Album UIDs that limit the results to public pictures, see SetShared.
 }
 
 // GetQuery returns the query parameter as string.
@@ -90,6 +96,30 @@ func (f *SearchGeo) ParseQueryString() error {
 	return err
 }
 
+// SetShared limits the results to public pictures in the albums with the given UIDs, e.g. for guests.
+func (f *SearchGeo) SetShared(uids []string) {
+	f.shared = append(make([]string, 0, len(uids)), uids...)
+}
+
+// Shared returns the UIDs of the albums that limit the results, or nil if the search is not limited.
+func (f *SearchGeo) Shared() []string {
+	return f.shared
+}
+
+// ClearLocation removes all filters that reveal where pictures were taken, e.g. for guests.
+func (f *SearchGeo) ClearLocation() {
+	f.Near = ""
+	f.Lat = 0
+	f.Lng = 0
+	f.S2 = ""
+	f.Olc = ""
+	f.Dist = 0
+	f.Radius = 0
+	f.Latlng = ""
+	f.Polygon = ""
+	f.Country = ""
+}
+
 // Serialize returns a string containing non-empty fields and values of a struct.
 func (f *SearchGeo) Serialize() string {
 	return Serialize(f, false)
@@ -0,0 +1,194 @@
+package form
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Not is the prefix of negated search filters, e.g. "-person:alice".
+const Not = "-"
+
+// groupEnd returns the position of the parenthesis that closes the group starting at the
+// given position, or -1 if it isn't closed.
+func groupEnd(q []rune, start int) int {
+	var escaped bool
+	depth := 0
+
+	for i := start; i < len(q); i++ {
+		switch {
+		case q[i] == '"':
+			escaped = !escaped
+		case escaped:
+			continue
+		case q[i] == '(':
+			depth++
+		case q[i] == ')':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// splitUnquoted splits a string at each separator that is not enclosed in quotes.
+func splitUnquoted(s string, sep func(r rune) bool) (result []string) {
+	var escaped bool
+	var part []rune
+
+	for _, r := range s {
+		if r == '"' {
+			escaped = !escaped
+		}
+
+		if !escaped && sep(r) {
+			result = append(result, string(part))
+			part = part[:0]
+		} else {
+			part = append(part, r)
+		}
+	}
+
+	return append(result, string(part))
+}
+
+// groupAlternatives returns the alternatives of a search group like "cat | dog".
+func groupAlternatives(group string) (result []string, err error) {
+	// Remove whitespace around separators.
+	var parts []string
+
+	for _, part := range splitUnquoted(group, func(r rune) bool { return r == '|' }) {
+		parts = append(parts, strings.TrimSpace(part))
+	}
+
+	for _, part := range splitUnquoted(strings.Join(parts, txt.Or), unicode.IsSpace) {
+		if part == "" {
+			continue
+		} else if len(result) > 0 {
+			return result, fmt.Errorf("alternatives in search groups must be separated by %s", txt.Or)
+		}
+
+		for _, alt := range splitUnquoted(part, func(r rune) bool { return r == '|' }) {
+			if alt != "" {
+				result = append(result, alt)
+			}
+		}
+	}
+
+	if len(result) == 0 {
+		return result, fmt.Errorf("empty search group")
+	}
+
+	return result, nil
+}
+
+// expandGroup returns a search group as filter with alternative values, e.g. "label:cat|dog"
+// for "(label:cat | label:dog)". Key is not empty if the filter name precedes the group.
+func expandGroup(key, group string) (string, error) {
+	alternatives, err := groupAlternatives(group)
+
+	if err != nil {
+		return "", err
+	}
+
+	var values []string
+
+	for _, alt := range alternatives {
+		parts := splitUnquoted(alt, func(r rune) bool { return r == ':' })
+
+		switch {
+		case len(parts) == 1:
+			values = append(values, alt)
+		case key == "" && len(values) == 0:
+			key = strings.ToLower(parts[0]) + ":"
+			values = append(values, strings.Join(parts[1:], ":"))
+		case strings.EqualFold(key, parts[0]+":"):
+			values = append(values, strings.Join(parts[1:], ":"))
+		default:
+			return "", fmt.Errorf("search groups may only contain alternatives of the same filter")
+		}
+	}
+
+	// Free-text alternatives are matched if any of the words is found.
+	if key == "" {
+		return strings.Join(values, " "), nil
+	}
+
+	return key + strings.Join(values, txt.Or), nil
+}
+
+// expandGroups rewrites grouped search filters like "(label:cat | label:dog)" or "label:(cat | dog)"
+// to the filter syntax "label:cat|dog", so that they can be parsed by Unserialize.
+func expandGroups(q string) (string, error) {
+	if !strings.ContainsRune(q, '(') {
+		return q, nil
+	}
+
+	var escaped bool
+	var result, token []rune
+
+	runes := []rune(q)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == '"':
+			escaped = !escaped
+		case escaped:
+		case unicode.IsSpace(r):
+			result = append(append(result, token...), r)
+			token = token[:0]
+
+			continue
+		case r == '(':
+			var key string
+
+			if s := string(token); s == "" || s == Not {
+				// Group of filters, optionally negated.
+			} else if strings.HasSuffix(s, ":") && !strings.ContainsAny(s[:len(s)-1], ":\"") {
+				// Group of values.
+				key = strings.TrimPrefix(s, Not)
+			} else {
+				// Parentheses within values are kept.
+				token = append(token, r)
+				continue
+			}
+
+			end := groupEnd(runes, i)
+
+			if end < 0 {
+				return q, fmt.Errorf("missing closing parenthesis")
+			}
+
+			inner, err := expandGroups(string(runes[i+1 : end]))
+
+			if err != nil {
+				return q, err
+			}
+
+			group, err := expandGroup(key, inner)
+
+			if err != nil {
+				return q, err
+			}
+
+			if strings.HasPrefix(string(token), Not) {
+				group = Not + group
+			}
+
+			token = append(token[:0], []rune(group)...)
+			i = end
+
+			continue
+		}
+
+		token = append(token, r)
+	}
+
+	return string(append(result, token...)), nil
+}
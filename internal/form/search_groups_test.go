@@ -0,0 +1,57 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandGroups(t *testing.T) {
+	t.Run("NoGroups", func(t *testing.T) {
+		result, err := expandGroups("label:cat person:alice")
+		assert.NoError(t, err)
+		assert.Equal(t, "label:cat person:alice", result)
+	})
+	t.Run("FilterGroup", func(t *testing.T) {
+		result, err := expandGroups("(label:cat | label:dog) person:alice")
+		assert.NoError(t, err)
+		assert.Equal(t, "label:cat|dog person:alice", result)
+	})
+	t.Run("ValueGroup", func(t *testing.T) {
+		result, err := expandGroups("label:( cat|dog | \"big bird\" )")
+		assert.NoError(t, err)
+		assert.Equal(t, "label:cat|dog|\"big bird\"", result)
+	})
+	t.Run("Negated", func(t *testing.T) {
+		result, err := expandGroups("-(label:cat|label:dog) -person:(alice | bob)")
+		assert.NoError(t, err)
+		assert.Equal(t, "-label:cat|dog -person:alice|bob", result)
+	})
+	t.Run("Nested", func(t *testing.T) {
+		result, err := expandGroups("(label:cat | (label:dog | label:bird))")
+		assert.NoError(t, err)
+		assert.Equal(t, "label:cat|dog|bird", result)
+	})
+	t.Run("Words", func(t *testing.T) {
+		result, err := expandGroups("(cat | dog)")
+		assert.NoError(t, err)
+		assert.Equal(t, "cat dog", result)
+	})
+	t.Run("Quoted", func(t *testing.T) {
+		result, err := expandGroups("title:\"Foo (Bar)\" name:IMG_(1).jpg")
+		assert.NoError(t, err)
+		assert.Equal(t, "title:\"Foo (Bar)\" name:IMG_(1).jpg", result)
+	})
+	t.Run("Unclosed", func(t *testing.T) {
+		_, err := expandGroups("(label:cat | label:dog")
+		assert.EqualError(t, err, "missing closing parenthesis")
+	})
+	t.Run("MissingSeparator", func(t *testing.T) {
+		_, err := expandGroups("(label:cat label:dog)")
+		assert.EqualError(t, err, "alternatives in search groups must be separated by |")
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := expandGroups("label:()")
+		assert.EqualError(t, err, "empty search group")
+	})
+}
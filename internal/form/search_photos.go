@@ -2,75 +2,109 @@ package form
 
 import (
 	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // SearchPhotos represents search form fields for "/api/v1/photos".
 type SearchPhotos struct {
-	Query     string    `form:"q"`
-	Filter    string    `form:"filter"`
-	UID       string    `form:"uid"`
-	Type      string    `form:"type"`
-	Path      string    `form:"path"`
-	Folder    string    `form:"folder"` // Alias for Path
-	Name      string    `form:"name"`
-	Filename  string    `form:"filename"`
-	Original  string    `form:"original"`
-	Title     string    `form:"title"`
-	Hash      string    `form:"hash"`
-	Primary   bool      `form:"primary"`
-	Stack     bool      `form:"stack"`
-	Unstacked bool      `form:"unstacked"`
-	Stackable bool      `form:"stackable"`
-	Video     bool      `form:"video"`
-	Photo     bool      `form:"photo"`
-	Raw       bool      `form:"raw"`
-	Live      bool      `form:"live"`
-	Scan      bool      `form:"scan"`
-	Panorama  bool      `form:"panorama"`
-	Error     bool      `form:"error"`
-	Hidden    bool      `form:"hidden"`
-	Archived  bool      `form:"archived"`
-	Public    bool      `form:"public"`
-	Private   bool      `form:"private"`
-	Favorite  bool      `form:"favorite"`
-	Unsorted  bool      `form:"unsorted"`
-	Lat       float32   `form:"lat"`
-	Lng       float32   `form:"lng"`
-	Dist      uint      `form:"dist"`
-	Fmin      float32   `form:"fmin"`
-	Fmax      float32   `form:"fmax"`
-	Chroma    uint8     `form:"chroma"`
-	Diff      uint32    `form:"diff"`
-	Mono      bool      `form:"mono"`
-	Portrait  bool      `form:"portrait"`
-	Geo       bool      `form:"geo"`
-	Keywords  string    `form:"keywords"`
-	Label     string    `form:"label"`
-	Category  string    `form:"category"` // Moments
-	Country   string    `form:"country"`  // Moments
-	State     string    `form:"state"`    // Moments
-	Year      string    `form:"year"`     // Moments
-	Month     string    `form:"month"`    // Moments
-	Day       string    `form:"day"`      // Moments
-	Face      string    `form:"face"`     // UIDs
-	Subject   string    `form:"subject"`  // UIDs
-	Person    string    `form:"person"`   // Alias for Subject
-	Subjects  string    `form:"subjects"` // Text
-	People    string    `form:"people"`   // Alias for Subjects
-	Album     string    `form:"album"`    // UIDs
-	Albums    string    `form:"albums"`   // Text
-	Color     string    `form:"color"`
-	Faces     string    `form:"faces"` // Find or exclude faces if detected.
-	Quality   int       `form:"quality"`
-	Review    bool      `form:"review"`
-	Camera    int       `form:"camera"`
-	Lens      int       `form:"lens"`
-	Before    time.Time `form:"before" time_format:"2006-01-02"`
-	After     time.Time `form:"after" time_format:"2006-01-02"`
-	Count     int       `form:"count" binding:"required" serialize:"-"`
-	Offset    int       `form:"offset" serialize:"-"`
-	Order     string    `form:"order" serialize:"-"`
-	Merged    bool      `form:"merged" serialize:"-"`
+	Query       string    `form:"q"`
+	Filter      string    `form:"filter"`
+	UID         string    `form:"uid"`
+	Type        string    `form:"type"`
+	Path        string    `form:"path"`
+	Folder      string    `form:"folder"` // Alias for Path
+	Name        string    `form:"name"`
+	Filename    string    `form:"filename"`
+	Original    string    `form:"original"`
+	Title       string    `form:"title"`
+	Caption     string    `form:"caption"` // Generated image description.
+	Hash        string    `form:"hash"`
+	Primary     bool      `form:"primary"`
+	Stack       bool      `form:"stack"`
+	Unstacked   bool      `form:"unstacked"`
+	Stackable   bool      `form:"stackable"`
+	Video       bool      `form:"video"`
+	Photo       bool      `form:"photo"`
+	Raw         bool      `form:"raw"`
+	Live        bool      `form:"live"`
+	Scan        bool      `form:"scan"`
+	Panorama    bool      `form:"panorama"`
+	Projection  string    `form:"projection"` // Panorama projection type, e.g. equirectangular.
+	Error       bool      `form:"error"`
+	Hidden      bool      `form:"hidden"`
+	Archived    bool      `form:"archived"`
+	Public      bool      `form:"public"`
+	Private     bool      `form:"private"`
+	Favorite    bool      `form:"favorite"`
+	Rating      string    `form:"rating"` // Star rating from 0 to 5, e.g. >=4.
+	Unsorted    bool      `form:"unsorted"`
+	Lat         float32   `form:"lat"`
+	Lng         float32   `form:"lng"`
+	Dist        uint      `form:"dist"`
+	Radius      float64   `form:"radius"`  // Exact distance to lat and lng in km, e.g. 5.
+	Latlng      string    `form:"latlng"`  // Viewport bounds as north, east, south, and west, e.g. 52.6,13.6,52.3,13.1.
+	Polygon     string    `form:"polygon"` // Pairs of latitude and longitude, e.g. 52.6,13.3,52.6,13.5,52.4,13.4.
+	Fmin        float32   `form:"fmin"`
+	Fmax        float32   `form:"fmax"`
+	Chroma      uint8     `form:"chroma"`
+	Diff        uint32    `form:"diff"`
+	Mono        bool      `form:"mono"`
+	Portrait    bool      `form:"portrait"`
+	Ratio       string    `form:"ratio"`   // Aspect ratio, e.g. portrait, landscape, square, or >1.5.
+	MP          string    `form:"mp"`      // Resolution in megapixels, e.g. >20.
+	Res         string    `form:"res"`     // Resolution name or longest side in pixels, e.g. 4k or >2000.
+	Size        string    `form:"size"`    // File size, e.g. >50MB.
+	F           string    `form:"f"`       // Aperture f-number, e.g. 1.8 or <=2.8.
+	Iso         string    `form:"iso"`     // ISO sensitivity, e.g. >3200.
+	Mm          string    `form:"mm"`      // Focal length in mm, e.g. 35..50.
+	Shutter     string    `form:"shutter"` // Exposure time, e.g. <1/60.
+	Alt         string    `form:"alt"`     // Altitude in meters above sea level, e.g. >2000.
+	Heading     string    `form:"heading"` // Camera direction in degrees, e.g. 45..135.
+	Speed       string    `form:"speed"`   // Speed in km/h, e.g. >100.
+	Geo         string    `form:"geo"`     // Find or exclude photos with location.
+	Keywords    string    `form:"keywords"`
+	Label       string    `form:"label"`
+	Tag         string    `form:"tag"`      // Personal tag UIDs or names.
+	Category    string    `form:"category"` // Moments
+	Country     string    `form:"country"`  // Moments
+	State       string    `form:"state"`    // Moments
+	Year        string    `form:"year"`     // Moments
+	Month       string    `form:"month"`    // Moments
+	Day         string    `form:"day"`      // Moments
+	Face        string    `form:"face"`     // UIDs
+	Subject     string    `form:"subject"`  // UIDs
+	Person      string    `form:"person"`   // Alias for Subject
+	Subjects    string    `form:"subjects"` // Text
+	People      string    `form:"people"`   // Alias for Subjects
+	Album       string    `form:"album"`    // UIDs
+	Albums      string    `form:"albums"`   // Text
+	Color       string    `form:"color"`
+	Faces       string    `form:"faces"` // Find or exclude faces if detected.
+	Has         string    `form:"has"`   // Find photos with related files, e.g. edits.
+	Quality     int       `form:"quality"`
+	Review      bool      `form:"review"`
+	Pending     bool      `form:"pending"` // Imported photos that have not been approved yet.
+	Camera      int       `form:"camera"`
+	Lens        int       `form:"lens"`
+	Before      time.Time `form:"before" time_format:"2006-01-02"`
+	After       time.Time `form:"after" time_format:"2006-01-02"`
+	Taken       string    `form:"taken"`     // Date taken or range with optional start and end, e.g. 2019-06..2019-08.
+	NotLabel    string    `form:"-label"`    // Excludes photos with any of the labels.
+	NotTag      string    `form:"-tag"`      // Excludes photos with any of the personal tags.
+	NotSubject  string    `form:"-subject"`  // Excludes photos with any of the subjects.
+	NotPerson   string    `form:"-person"`   // Alias for NotSubject
+	NotAlbum    string    `form:"-album"`    // Excludes photos in any of the albums.
+	NotKeywords string    `form:"-keywords"` // Excludes photos with any of the keywords.
+	NotColor    string    `form:"-color"`
+	NotCountry  string    `form:"-country"`
+	NotType     string    `form:"-type"`
+	Count       int       `form:"count" binding:"required" serialize:"-"`
+	Offset      int       `form:"offset" serialize:"-"`
+	Order       string    `form:"order" serialize:"-"`
+	Merged      bool      `form:"merged" serialize:"-"`
+
+	shared []string // Album UIDs that limit the results to public pictures, see SetShared.
 }
 
 func (f *SearchPhotos) GetQuery() string {
@@ -94,11 +128,25 @@ func (f *SearchPhotos) ParseQueryString() error {
 	if f.Subject == "" && f.Person != "" {
 		f.Subject = f.Person
 		f.Person = ""
+	} else if f.Person != "" {
+		f.Subject = f.Subject + txt.And + f.Person
+		f.Person = ""
+	}
+
+	if f.NotSubject == "" && f.NotPerson != "" {
+		f.NotSubject = f.NotPerson
+		f.NotPerson = ""
+	} else if f.NotPerson != "" {
+		f.NotSubject = f.NotSubject + txt.Or + f.NotPerson
+		f.NotPerson = ""
 	}
 
 	if f.Subjects == "" && f.People != "" {
 		f.Subjects = f.People
 		f.People = ""
+	} else if f.People != "" {
+		f.Subjects = f.Subjects + txt.And + f.People
+		f.People = ""
 	}
 
 	if f.Filter != "" {
@@ -110,6 +158,33 @@ func (f *SearchPhotos) ParseQueryString() error {
 	return nil
 }
 
+// SetShared limits the results to public pictures in the albums with the given UIDs, e.g. for guests.
+func (f *SearchPhotos) SetShared(uids []string) {
+	f.shared = append(make([]string, 0, len(uids)), uids...)
+}
+
+// Shared returns the UIDs of the albums that limit the results, or nil if the search is not limited.
+func (f *SearchPhotos) Shared() []string {
+	return f.shared
+}
+
+// ClearLocation removes all filters that reveal where pictures were taken, e.g. for guests.
+func (f *SearchPhotos) ClearLocation() {
+	f.Lat = 0
+	f.Lng = 0
+	f.Dist = 0
+	f.Radius = 0
+	f.Latlng = ""
+	f.Polygon = ""
+	f.Alt = ""
+	f.Heading = ""
+	f.Speed = ""
+	f.Geo = ""
+	f.Country = ""
+	f.NotCountry = ""
+	f.State = ""
+}
+
 // Serialize returns a string containing non-empty fields and values of a struct.
 func (f *SearchPhotos) Serialize() string {
 	return Serialize(f, false)
@@ -40,6 +40,125 @@ func TestParseQueryString(t *testing.T) {
 		assert.Equal(t, "Bar", form.Subject)
 		assert.Equal(t, "Jens & Mander", form.Subjects)
 	})
+	t.Run("multiple people", func(t *testing.T) {
+		form := &SearchPhotos{Query: "person:alice person:bob subject:carol people:Jens people:\"Mander\""}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", form.Person)
+		assert.Equal(t, "", form.People)
+		assert.Equal(t, "carol&alice&bob", form.Subject)
+		assert.Equal(t, "Jens&Mander", form.Subjects)
+	})
+	t.Run("or groups and negation", func(t *testing.T) {
+		form := &SearchPhotos{Query: "label:cat|dog -person:alice (country:de | country:fr) -color:(red|blue) -label:\"cat food\" beach -sunset"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "cat|dog", form.Label)
+		assert.Equal(t, "alice", form.NotSubject)
+		assert.Equal(t, "", form.NotPerson)
+		assert.Equal(t, "de|fr", form.Country)
+		assert.Equal(t, "red|blue", form.NotColor)
+		assert.Equal(t, "cat food", form.NotLabel)
+		assert.Equal(t, "sunset", form.NotKeywords)
+		assert.Equal(t, "beach", form.Query)
+	})
+	t.Run("multiple negations", func(t *testing.T) {
+		form := &SearchPhotos{Query: "-person:alice -person:bob -subject:carol -cat -dog"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "carol|alice|bob", form.NotSubject)
+		assert.Equal(t, "cat|dog", form.NotKeywords)
+		assert.Equal(t, "", form.Query)
+	})
+	t.Run("unsupported negation", func(t *testing.T) {
+		form := &SearchPhotos{Query: "-favorite:true"}
+
+		err := form.ParseQueryString()
+
+		if err == nil {
+			t.Fatal("error expected")
+		}
+
+		assert.Equal(t, "unsupported negation: favorite", err.Error())
+	})
+	t.Run("mixed group", func(t *testing.T) {
+		form := &SearchPhotos{Query: "(label:cat | person:alice)"}
+
+		err := form.ParseQueryString()
+
+		if err == nil {
+			t.Fatal("error expected")
+		}
+
+		assert.Equal(t, "search groups may only contain alternatives of the same filter", err.Error())
+	})
+	t.Run("ratio mp res size", func(t *testing.T) {
+		form := &SearchPhotos{Query: "ratio:portrait mp:>20 res:4k size:<=50MB"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "portrait", form.Ratio)
+		assert.Equal(t, ">20", form.MP)
+		assert.Equal(t, "4k", form.Res)
+		assert.Equal(t, "<=50MB", form.Size)
+	})
+	t.Run("f iso mm shutter", func(t *testing.T) {
+		form := &SearchPhotos{Query: "f:1.8 iso:>3200 mm:35..50 shutter:<1/60"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "1.8", form.F)
+		assert.Equal(t, ">3200", form.Iso)
+		assert.Equal(t, "35..50", form.Mm)
+		assert.Equal(t, "<1/60", form.Shutter)
+	})
+	t.Run("alt heading speed", func(t *testing.T) {
+		form := &SearchPhotos{Query: "alt:>2000 heading:45..135 speed:<=30"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ">2000", form.Alt)
+		assert.Equal(t, "45..135", form.Heading)
+		assert.Equal(t, "<=30", form.Speed)
+	})
+	t.Run("rating", func(t *testing.T) {
+		form := &SearchPhotos{Query: "rating:>=4"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ">=4", form.Rating)
+	})
 	t.Run("keywords", func(t *testing.T) {
 		form := &SearchPhotos{Query: "keywords:\"Foo Bar\""}
 
@@ -110,6 +229,18 @@ func TestParseQueryString(t *testing.T) {
 		assert.Equal(t, uint(0x61a8), form.Dist)
 		assert.Equal(t, float32(33.45343), form.Lat)
 	})
+	t.Run("taken range", func(t *testing.T) {
+		form := &SearchPhotos{Query: "taken:2019-06..2019-08 label:cat"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2019-06..2019-08", form.Taken)
+		assert.Equal(t, "cat", form.Label)
+	})
 	t.Run("valid query 2", func(t *testing.T) {
 		form := &SearchPhotos{Query: "chroma:200 title:\"te:st\" after:2018-01-15 favorite:true lng:33.45343166666667"}
 
@@ -178,6 +309,17 @@ func TestParseQueryString(t *testing.T) {
 
 		assert.True(t, form.Favorite)
 	})
+	t.Run("query for favorites alias", func(t *testing.T) {
+		form := &SearchPhotos{Query: "favorites:true"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, form.Favorite)
+	})
 	t.Run("query for lat with invalid type", func(t *testing.T) {
 		form := &SearchPhotos{Query: "lat:cat"}
 
@@ -276,3 +418,41 @@ func TestPhotoSearch_SerializeAll(t *testing.T) {
 
 	assert.IsType(t, "string", result)
 }
+
+func TestSearchPhotos_ClearLocation(t *testing.T) {
+	f := SearchPhotos{Query: "cat", Lat: 52.5, Lng: 13.4, Dist: 5, Radius: 1.5, Latlng: "52.6,13.6,52.3,13.1", Polygon: "52.6,13.3,52.6,13.5,52.4,13.4", Alt: ">2000", Heading: "45..135", Speed: ">100", Geo: "yes", Country: "de", NotCountry: "us", State: "Berlin"}
+
+	f.ClearLocation()
+
+	assert.Equal(t, "cat", f.Query)
+	assert.Equal(t, float32(0), f.Lat)
+	assert.Equal(t, float32(0), f.Lng)
+	assert.Equal(t, uint(0), f.Dist)
+	assert.Equal(t, float64(0), f.Radius)
+	assert.Equal(t, "", f.Latlng)
+	assert.Equal(t, "", f.Polygon)
+	assert.Equal(t, "", f.Alt)
+	assert.Equal(t, "", f.Heading)
+	assert.Equal(t, "", f.Speed)
+	assert.Equal(t, "", f.Geo)
+	assert.Equal(t, "", f.Country)
+	assert.Equal(t, "", f.NotCountry)
+	assert.Equal(t, "", f.State)
+}
+
+func TestSearchPhotos_SetShared(t *testing.T) {
+	f := SearchPhotos{}
+
+	assert.Nil(t, f.Shared())
+
+	f.SetShared(nil)
+
+	assert.NotNil(t, f.Shared())
+	assert.Empty(t, f.Shared())
+
+	f.SetShared([]string{"at9lxuqxpogaaba9"})
+
+	assert.Equal(t, []string{"at9lxuqxpogaaba9"}, f.Shared())
+	assert.Error(t, Unserialize(&f, "shared:at9lxuqxpogaaba8"))
+	assert.NotContains(t, f.SerializeAll(), "shared")
+}
@@ -9,6 +9,8 @@ type Selection struct {
 	Labels   []string `json:"labels"`
 	Places   []string `json:"places"`
 	Subjects []string `json:"subjects"`
+	Markers  []string `json:"markers"`
+	Tags     []string `json:"tags"` // Personal tag UIDs or names.
 }
 
 func (f Selection) Empty() bool {
@@ -25,6 +27,10 @@ func (f Selection) Empty() bool {
 		return false
 	case len(f.Subjects) > 0:
 		return false
+	case len(f.Markers) > 0:
+		return false
+	case len(f.Tags) > 0:
+		return false
 	}
 
 	return true
@@ -40,6 +46,8 @@ func (f Selection) All() []string {
 	all = append(all, f.Labels...)
 	all = append(all, f.Places...)
 	all = append(all, f.Subjects...)
+	all = append(all, f.Markers...)
+	all = append(all, f.Tags...)
 
 	return all
 }
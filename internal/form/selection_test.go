@@ -32,6 +32,14 @@ func TestSelection_Empty(t *testing.T) {
 		assert.Equal(t, false, sel.Empty())
 		assert.Equal(t, []string{"jqzkpo13j8ngpgv4", "jqzkq8j10hj39sxp"}, sel.Subjects)
 	})
+	t.Run("not empty markers", func(t *testing.T) {
+		sel := Selection{Markers: []string{"mt9k3pw1wowuy3c3"}}
+		assert.Equal(t, false, sel.Empty())
+	})
+	t.Run("not empty tags", func(t *testing.T) {
+		sel := Selection{Tags: []string{"tt9lxuqxpogaaba1"}}
+		assert.Equal(t, false, sel.Empty())
+	})
 	t.Run("empty", func(t *testing.T) {
 		sel := Selection{Photos: []string{}, Albums: []string{}, Labels: []string{}}
 		assert.Equal(t, true, sel.Empty())
@@ -14,6 +14,21 @@ import (
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// andFilters lists search filters that match all values if specified more than once,
+// e.g. "person:alice person:bob" finds photos in which both appear.
+var andFilters = map[string]bool{
+	"subject":  true,
+	"person":   true,
+	"subjects": true,
+	"people":   true,
+}
+
+// filterAliases maps alternative search filter names to their form field names,
+// e.g. "favorites:true" is the same as "favorite:true".
+var filterAliases = map[string]string{
+	"favorites": "favorite",
+}
+
 // Serialize returns a string containing all non-empty fields and values of a struct.
 func Serialize(f interface{}, all bool) string {
 	v := reflect.ValueOf(f)
@@ -85,15 +100,41 @@ func Unserialize(f SearchForm, q string) (result error) {
 	f.SetQuery("")
 
 	formValues := reflect.ValueOf(f).Elem()
+	notKeywords := formValues.FieldByName("NotKeywords")
+
+	// Rewrite grouped filters like "(label:cat | label:dog)" to "label:cat|dog".
+	q, err := expandGroups(strings.TrimSpace(q))
 
-	q = strings.TrimSpace(q) + "\n"
+	if err != nil {
+		log.Warnf("form: %s", err)
+		return err
+	}
+
+	q += "\n"
 
 	var queryStrings []string
 
 	for _, char := range q {
 		if unicode.IsSpace(char) && !escaped {
 			if isKeyValue {
-				fieldName := strings.Title(string(key))
+				filterName := string(key)
+				negated := len(filterName) > len(Not) && strings.HasPrefix(filterName, Not)
+
+				// Negated filters like "-label:cat" are stored in fields like NotLabel.
+				if negated {
+					filterName = strings.TrimPrefix(filterName, Not)
+				}
+
+				if alias, ok := filterAliases[filterName]; ok {
+					filterName = alias
+				}
+
+				fieldName := strings.Title(filterName)
+
+				if negated {
+					fieldName = "Not" + fieldName
+				}
+
 				field := formValues.FieldByNameFunc(func(name string) bool {
 					return strings.EqualFold(name, fieldName)
 				})
@@ -126,15 +167,31 @@ func Unserialize(f SearchForm, q string) (result error) {
 							field.SetUint(uint64(intValue))
 						}
 					case string:
-						field.SetString(sanitize.SearchString(stringValue))
+						if prev := field.String(); prev != "" && negated {
+							// Excludes results matching any of the values.
+							field.SetString(prev + txt.Or + sanitize.SearchString(stringValue))
+						} else if prev != "" && andFilters[strings.ToLower(fieldName)] {
+							field.SetString(prev + txt.And + sanitize.SearchString(stringValue))
+						} else {
+							field.SetString(sanitize.SearchString(stringValue))
+						}
 					case bool:
 						field.SetBool(txt.Bool(stringValue))
 					default:
 						result = fmt.Errorf("unsupported type: %s", fieldName)
 					}
+				} else if negated {
+					result = fmt.Errorf("unsupported negation: %s", filterName)
 				} else {
 					result = fmt.Errorf("unknown filter: %s", fieldName)
 				}
+			} else if word := strings.TrimSpace(string(key)); len(word) > len(Not) && strings.HasPrefix(word, Not) && notKeywords.CanSet() {
+				// Excludes results with keywords like "-cat".
+				if prev := notKeywords.String(); prev != "" {
+					notKeywords.SetString(prev + txt.Or + sanitize.SearchString(strings.TrimPrefix(word, Not)))
+				} else {
+					notKeywords.SetString(sanitize.SearchString(strings.TrimPrefix(word, Not)))
+				}
 			} else if len(strings.TrimSpace(string(key))) > 0 {
 				queryStrings = append(queryStrings, strings.TrimSpace(string(key)))
 			}
@@ -0,0 +1,8 @@
+package form
+
+// Slideshow represents a form for rendering an album as slideshow video.
+type Slideshow struct {
+	Duration   float64 `json:"duration"`
+	Transition string  `json:"transition"`
+	Music      string  `json:"music"`
+}
@@ -0,0 +1,31 @@
+package form
+
+import (
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// SyncHashLength is the length of the SHA1 file hashes used for syncing.
+const SyncHashLength = 40
+
+// SyncFiles represents a list of file hashes sent by sync clients, e.g. to find out which
+// files need to be uploaded, or to confirm uploaded files so that they get imported.
+type SyncFiles struct {
+	Hashes []string `json:"hashes"`
+	Albums []string `json:"albums"`
+}
+
+// FileHashes returns the valid, unique file hashes in lowercase.
+func (f SyncFiles) FileHashes() (result []string) {
+	done := make(map[string]bool, len(f.Hashes))
+
+	for _, s := range f.Hashes {
+		if s = sanitize.Hex(s); len(s) != SyncHashLength || done[s] {
+			continue
+		}
+
+		done[s] = true
+		result = append(result, s)
+	}
+
+	return result
+}
@@ -0,0 +1,22 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncFiles_FileHashes(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		f := SyncFiles{Hashes: []string{
+			"2CAD9168FA6ACC5C5C2965DDF6EC465CA42FD818",
+			"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818",
+			"3cad9168fa6acc5c5c2965ddf6ec465ca42fd818",
+		}}
+		assert.Equal(t, []string{"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", "3cad9168fa6acc5c5c2965ddf6ec465ca42fd818"}, f.FileHashes())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		f := SyncFiles{Hashes: []string{"", "xyz", "2cad9168fa6acc5c5c2965ddf6ec465ca42fd8"}}
+		assert.Empty(t, f.FileHashes())
+	})
+}
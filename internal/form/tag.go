@@ -0,0 +1,14 @@
+package form
+
+import "github.com/ulule/deepcopier"
+
+// Tag represents a personal photo tag edit form.
+type Tag struct {
+	TagName string `json:"Name"`
+}
+
+func NewTag(m interface{}) (f Tag, err error) {
+	err = deepcopier.Copy(m).To(&f)
+
+	return f, err
+}
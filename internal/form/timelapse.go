@@ -0,0 +1,7 @@
+package form
+
+// Timelapse represents a form for assembling photos into a time-lapse video.
+type Timelapse struct {
+	Photos []string `json:"photos"`
+	Fps    int      `json:"fps"`
+}
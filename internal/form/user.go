@@ -8,6 +8,7 @@ type UserCreate struct {
 	FullName string `json:"fullname"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	Role     string `json:"role,omitempty"`
 }
 
 // Username returns the normalized username in lowercase and without whitespace padding.
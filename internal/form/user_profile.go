@@ -0,0 +1,19 @@
+package form
+
+import "github.com/ulule/deepcopier"
+
+// UserProfile represents a user profile update form.
+type UserProfile struct {
+	DisplayName string `json:"DisplayName"`
+	Avatar      string `json:"Avatar"`
+	AvatarUID   string `json:"AvatarUID"`
+	Language    string `json:"Language"`
+	Theme       string `json:"Theme"`
+}
+
+// NewUserProfile creates a new form initialized with the current profile values.
+func NewUserProfile(m interface{}) (f UserProfile, err error) {
+	err = deepcopier.Copy(m).To(&f)
+
+	return f, err
+}
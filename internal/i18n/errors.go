@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ErrorIDs maps error messages to stable, machine-readable identifiers, so that clients do not
+// need to parse translated message strings, which may change over time.
+var ErrorIDs = map[Message]string{
+	ErrUnexpected:         "unexpected",
+	ErrBadRequest:         "bad_request",
+	ErrSaveFailed:         "save_failed",
+	ErrDeleteFailed:       "delete_failed",
+	ErrAlreadyExists:      "already_exists",
+	ErrNotFound:           "not_found",
+	ErrFileNotFound:       "file_not_found",
+	ErrSelectionNotFound:  "selection_not_found",
+	ErrEntityNotFound:     "entity_not_found",
+	ErrAccountNotFound:    "account_not_found",
+	ErrUserNotFound:       "user_not_found",
+	ErrLabelNotFound:      "label_not_found",
+	ErrAlbumNotFound:      "album_not_found",
+	ErrSubjectNotFound:    "subject_not_found",
+	ErrPersonNotFound:     "person_not_found",
+	ErrFaceNotFound:       "face_not_found",
+	ErrPublic:             "public",
+	ErrReadOnly:           "read_only",
+	ErrUnauthorized:       "unauthorized",
+	ErrOffensiveUpload:    "offensive_upload",
+	ErrNoItemsSelected:    "no_items_selected",
+	ErrCreateFile:         "create_file_failed",
+	ErrCreateFolder:       "create_folder_failed",
+	ErrConnectionFailed:   "connection_failed",
+	ErrInvalidPassword:    "invalid_password",
+	ErrFeatureDisabled:    "feature_disabled",
+	ErrNoLabelsSelected:   "no_labels_selected",
+	ErrNoAlbumsSelected:   "no_albums_selected",
+	ErrNoFilesForDownload: "no_files_for_download",
+	ErrZipFailed:          "zip_failed",
+	ErrInvalidCredentials: "invalid_credentials",
+	ErrInvalidLink:        "invalid_link",
+	ErrInvalidName:        "invalid_name",
+	ErrBusy:               "busy",
+	ErrZipTooLarge:        "zip_too_large",
+	ErrNoFacesFound:       "no_faces_found",
+	ErrInvalidRole:        "invalid_role",
+	ErrInvalidArchive:     "invalid_archive",
+	ErrStorageFull:        "storage_full",
+	ErrUploadTooLarge:     "upload_too_large",
+	ErrUnsupportedType:    "unsupported_type",
+	ErrLocked:             "locked",
+	ErrTooManyRequests:    "too_many_requests",
+}
+
+// ErrorID returns the stable identifier of an error message, or an empty string if there is none.
+func ErrorID(id Message) string {
+	return ErrorIDs[id]
+}
+
+// StatusID returns a stable error identifier based on the HTTP status code, e.g. "not_found".
+func StatusID(code int) string {
+	if code < 400 {
+		return ""
+	} else if s := http.StatusText(code); s != "" {
+		return strings.ReplaceAll(strings.ToLower(s), " ", "_")
+	}
+
+	return ErrorIDs[ErrUnexpected]
+}
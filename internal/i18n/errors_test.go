@@ -0,0 +1,38 @@
+package i18n
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorID(t *testing.T) {
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		assert.Equal(t, "album_not_found", ErrorID(ErrAlbumNotFound))
+	})
+	t.Run("Message", func(t *testing.T) {
+		assert.Equal(t, "", ErrorID(MsgChangesSaved))
+	})
+	t.Run("Unique", func(t *testing.T) {
+		found := make(map[string]Message)
+
+		for id := ErrUnexpected; id <= ErrLocked; id++ {
+			s := ErrorID(id)
+			assert.NotEmpty(t, s, Messages[id])
+
+			if other, ok := found[s]; ok {
+				t.Errorf("%s has the same id as %s", Messages[id], Messages[other])
+			}
+
+			found[s] = id
+		}
+	})
+}
+
+func TestStatusID(t *testing.T) {
+	assert.Equal(t, "", StatusID(http.StatusOK))
+	assert.Equal(t, "not_found", StatusID(http.StatusNotFound))
+	assert.Equal(t, "internal_server_error", StatusID(http.StatusInternalServerError))
+	assert.Equal(t, "unexpected", StatusID(599))
+}
@@ -30,15 +30,30 @@ func SetDir(dir string) {
 	localeDir = dir
 }
 
-func SetLocale(loc string) {
+// ParseLocale returns a normalized locale like "de" or "pt_BR", or an empty string if it is invalid.
+func ParseLocale(loc string) Locale {
 	switch len(loc) {
 	case 2:
 		loc = strings.ToLower(loc[:2])
-		locale = Locale(loc)
 	case 5:
 		loc = strings.ToLower(loc[:2]) + "_" + strings.ToUpper(loc[3:5])
-		locale = Locale(loc)
 	default:
+		return ""
+	}
+
+	for i, r := range loc {
+		if i == 2 {
+			continue
+		} else if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return ""
+		}
+	}
+
+	return Locale(loc)
+}
+
+func SetLocale(loc string) {
+	if locale = ParseLocale(loc); locale == "" {
 		locale = Default
 	}
 
@@ -6,6 +6,17 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestParseLocale(t *testing.T) {
+	assert.Equal(t, German, ParseLocale("DE"))
+	assert.Equal(t, BrazilianPortuguese, ParseLocale("pt-br"))
+	assert.Equal(t, ChineseTraditional, ParseLocale("zh_TW"))
+	assert.Equal(t, Locale(""), ParseLocale(""))
+	assert.Equal(t, Locale(""), ParseLocale("d"))
+	assert.Equal(t, Locale(""), ParseLocale("12"))
+	assert.Equal(t, Locale(""), ParseLocale("de/../"))
+	assert.Equal(t, Locale(""), ParseLocale("german"))
+}
+
 func TestSetLocale(t *testing.T) {
 	assert.Equal(t, English, locale)
 	SetLocale("D")
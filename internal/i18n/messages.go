@@ -35,6 +35,15 @@ const (
 	ErrInvalidLink
 	ErrInvalidName
 	ErrBusy
+	ErrZipTooLarge
+	ErrNoFacesFound
+	ErrInvalidRole
+	ErrInvalidArchive
+	ErrStorageFull
+	ErrUploadTooLarge
+	ErrUnsupportedType
+	ErrLocked
+	ErrTooManyRequests
 
 	MsgChangesSaved
 	MsgAlbumCreated
@@ -58,6 +67,7 @@ const (
 	MsgIndexingCompletedIn
 	MsgIndexingOriginals
 	MsgIndexingFiles
+	MsgIndexingFilesMatching
 	MsgIndexingCanceled
 	MsgRemovedFilesAndPhotos
 	MsgMovingFilesFrom
@@ -76,6 +86,26 @@ const (
 	MsgAlbumsDeleted
 	MsgZipCreatedIn
 	MsgPermanentlyDeleted
+	MsgStorageAlmostFull
+	MsgFacesAssignedTo
+	MsgMemberAddedTo
+	MsgMemberRemovedFrom
+	MsgNewSearchMatches
+	MsgAlbumsRestored
+	MsgAlbumsMerged
+	MsgProfileSaved
+	MsgUploadsPending
+	MsgUploadsApproved
+	MsgUploadsRejected
+	MsgPhotosStacked
+	MsgMaintenanceEnabled
+	MsgMaintenanceDisabled
+	MsgLocked
+	MsgUnlocked
+	MsgMonthlySummary
+	MsgPhotosAddedIn
+	MsgNewPeople
+	MsgHighlights
 )
 
 var Messages = MessageMap{
@@ -114,6 +144,15 @@ var Messages = MessageMap{
 	ErrInvalidLink:        gettext("Invalid link"),
 	ErrInvalidName:        gettext("Invalid name"),
 	ErrBusy:               gettext("Busy, please try again later"),
+	ErrZipTooLarge:        gettext("Download exceeds the size limit"),
+	ErrNoFacesFound:       gettext("No faces found"),
+	ErrInvalidRole:        gettext("Invalid role"),
+	ErrInvalidArchive:     gettext("Archive could not be imported"),
+	ErrStorageFull:        gettext("Not enough storage space available"),
+	ErrUploadTooLarge:     gettext("Upload exceeds the size limit"),
+	ErrUnsupportedType:    gettext("Unsupported file type"),
+	ErrLocked:             gettext("Locked, must be unlocked by an admin first"),
+	ErrTooManyRequests:    gettext("Too many requests, please try again later"),
 
 	// Info and confirmation messages:
 	MsgChangesSaved:          gettext("Changes successfully saved"),
@@ -138,6 +177,7 @@ var Messages = MessageMap{
 	MsgIndexingCompletedIn:   gettext("Indexing completed in %d s"),
 	MsgIndexingOriginals:     gettext("Indexing originals..."),
 	MsgIndexingFiles:         gettext("Indexing files in %s"),
+	MsgIndexingFilesMatching: gettext("Indexing files matching %s"),
 	MsgIndexingCanceled:      gettext("Indexing canceled"),
 	MsgRemovedFilesAndPhotos: gettext("Removed %d files and %d photos"),
 	MsgMovingFilesFrom:       gettext("Moving files from %s"),
@@ -156,4 +196,24 @@ var Messages = MessageMap{
 	MsgAlbumsDeleted:         gettext("Albums deleted"),
 	MsgZipCreatedIn:          gettext("Zip created in %d s"),
 	MsgPermanentlyDeleted:    gettext("Permanently deleted"),
+	MsgStorageAlmostFull:     gettext("Storage almost full, %d%% used"),
+	MsgFacesAssignedTo:       gettext("%d faces assigned to %s"),
+	MsgMemberAddedTo:         gettext("%s added to %s"),
+	MsgMemberRemovedFrom:     gettext("%s removed from %s"),
+	MsgNewSearchMatches:      gettext("%d new photos match %s"),
+	MsgAlbumsRestored:        gettext("%d albums restored"),
+	MsgAlbumsMerged:          gettext("%d albums merged into %s"),
+	MsgProfileSaved:          gettext("Profile saved"),
+	MsgUploadsPending:        gettext("%d files uploaded, waiting for review"),
+	MsgUploadsApproved:       gettext("%d uploads added to %s"),
+	MsgUploadsRejected:       gettext("%d uploads rejected"),
+	MsgPhotosStacked:         gettext("%d photos stacked"),
+	MsgMaintenanceEnabled:    gettext("Maintenance mode enabled"),
+	MsgMaintenanceDisabled:   gettext("Maintenance mode disabled"),
+	MsgLocked:                gettext("Locked"),
+	MsgUnlocked:              gettext("Unlocked"),
+	MsgMonthlySummary:        gettext("Your summary for %s"),
+	MsgPhotosAddedIn:         gettext("%d photos added in %s"),
+	MsgNewPeople:             gettext("New people: %s"),
+	MsgHighlights:            gettext("Highlights"),
 }
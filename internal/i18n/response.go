@@ -2,11 +2,15 @@ package i18n
 
 import "strings"
 
+// Response represents a status or error message returned by the API.
 type Response struct {
-	Code    int    `json:"code"`
-	Err     string `json:"error,omitempty"`
-	Msg     string `json:"message,omitempty"`
-	Details string `json:"details,omitempty"`
+	Code    int               `json:"code"`
+	ID      string            `json:"id,omitempty"`
+	Err     string            `json:"error,omitempty"`
+	Msg     string            `json:"message,omitempty"`
+	Details string            `json:"details,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	Trace   string            `json:"trace,omitempty"`
 }
 
 func (r Response) String() string {
@@ -33,6 +37,17 @@ func NewResponse(code int, id Message, params ...interface{}) Response {
 	if code < 400 {
 		return Response{Code: code, Msg: Msg(id, params...)}
 	} else {
-		return Response{Code: code, Err: Msg(id, params...)}
+		return Response{Code: code, ID: ErrorID(id), Err: Msg(id, params...)}
 	}
 }
+
+// NewErrorResponse returns an error response based on the HTTP status code and error.
+func NewErrorResponse(code int, err error) Response {
+	resp := Response{Code: code, ID: StatusID(code)}
+
+	if err != nil {
+		resp.Err = err.Error()
+	}
+
+	return resp
+}
@@ -2,6 +2,7 @@ package i18n
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -12,6 +13,7 @@ func TestNewResponse(t *testing.T) {
 	t.Run("already exists", func(t *testing.T) {
 		resp := NewResponse(http.StatusConflict, ErrAlreadyExists, "A cat")
 		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, "already_exists", resp.ID)
 		assert.Equal(t, "A cat already exists", resp.Err)
 		assert.Equal(t, "", resp.Msg)
 	})
@@ -37,15 +39,23 @@ func TestNewResponse(t *testing.T) {
 	})
 }
 
+func TestNewErrorResponse(t *testing.T) {
+	resp := NewErrorResponse(http.StatusNotFound, errors.New("page not found"))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Equal(t, "not_found", resp.ID)
+	assert.Equal(t, "page not found", resp.Err)
+	assert.Equal(t, "", resp.Msg)
+}
+
 func TestResponse_String(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
-		resp := Response{404, "Not found", "page not found", "xyz"}
+		resp := Response{Code: 404, Err: "Not found", Msg: "page not found", Details: "xyz"}
 		assert.Equal(t, "Not found", resp.String())
 	})
 
 	t.Run("no error", func(t *testing.T) {
 		t.Run("error", func(t *testing.T) {
-			resp := Response{200, "", "Ok", "xyz"}
+			resp := Response{Code: 200, Msg: "Ok", Details: "xyz"}
 			assert.Equal(t, "Ok", resp.String())
 		})
 	})
@@ -53,13 +63,13 @@ func TestResponse_String(t *testing.T) {
 
 func TestResponse_LowerString(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
-		resp := Response{404, "Not found", "page not found", "xyz"}
+		resp := Response{Code: 404, Err: "Not found", Msg: "page not found", Details: "xyz"}
 		assert.Equal(t, "not found", resp.LowerString())
 	})
 
 	t.Run("no error", func(t *testing.T) {
 		t.Run("error", func(t *testing.T) {
-			resp := Response{200, "", "Ok", "xyz"}
+			resp := Response{Code: 200, Msg: "Ok", Details: "xyz"}
 			assert.Equal(t, "ok", resp.LowerString())
 		})
 	})
@@ -67,13 +77,13 @@ func TestResponse_LowerString(t *testing.T) {
 
 func TestResponse_Error(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
-		resp := Response{404, "Not found", "page not found", "xyz"}
+		resp := Response{Code: 404, Err: "Not found", Msg: "page not found", Details: "xyz"}
 		assert.Equal(t, "Not found", resp.Error())
 	})
 
 	t.Run("no error", func(t *testing.T) {
 		t.Run("error", func(t *testing.T) {
-			resp := Response{200, "", "Ok", "xyz"}
+			resp := Response{Code: 200, Msg: "Ok", Details: "xyz"}
 			assert.Equal(t, "", resp.Error())
 		})
 	})
@@ -81,13 +91,13 @@ func TestResponse_Error(t *testing.T) {
 
 func TestResponse_Success(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
-		resp := Response{404, "Not found", "page not found", "xyz"}
+		resp := Response{Code: 404, Err: "Not found", Msg: "page not found", Details: "xyz"}
 		assert.Equal(t, false, resp.Success())
 	})
 
 	t.Run("no error", func(t *testing.T) {
 		t.Run("error", func(t *testing.T) {
-			resp := Response{200, "", "Ok", "xyz"}
+			resp := Response{Code: 200, Msg: "Ok", Details: "xyz"}
 			assert.Equal(t, true, resp.Success())
 		})
 	})
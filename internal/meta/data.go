@@ -49,13 +49,19 @@ type Data struct {
 	GPSLongitude string        `meta:"GPSLongitude"`
 	Lat          float32       `meta:"-"`
 	Lng          float32       `meta:"-"`
-	Altitude     int           `meta:"GlobalAltitude,GPSAltitude"`
+	Altitude     int           `meta:"-"`
+	HasAltitude  bool          `meta:"-"`
+	Heading      float32       `meta:"-"`
+	HasHeading   bool          `meta:"-"`
+	Speed        float32       `meta:"-"`
 	Width        int           `meta:"PixelXDimension,ImageWidth,ExifImageWidth,SourceImageWidth"`
 	Height       int           `meta:"PixelYDimension,ImageHeight,ImageLength,ExifImageHeight,SourceImageHeight"`
 	Orientation  int           `meta:"-"`
 	Rotation     int           `meta:"Rotation"`
+	Rating       int           `meta:"Rating"`
 	Views        int           `meta:"-"`
 	Albums       []string      `meta:"-"`
+	Regions      Regions       `meta:"-"`
 	Error        error         `meta:"-"`
 	All          map[string]string
 }
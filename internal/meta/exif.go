@@ -95,10 +95,27 @@ func (data *Data) Exif(fileName string, fileType fs.FileFormat) (err error) {
 				data.Lat = float32(gi.Latitude.Decimal())
 				data.Lng = float32(gi.Longitude.Decimal())
 				data.Altitude = gi.Altitude
+
+				// The altitude is only parsed if both the value and its reference exist.
+				_, hasAltitude := tags["GPSAltitude"]
+				_, hasAltitudeRef := tags["GPSAltitudeRef"]
+				data.HasAltitude = hasAltitude && hasAltitudeRef
 			}
 		}
 	}
 
+	if value, ok := tags["GPSImgDirection"]; ok {
+		data.Heading, data.HasHeading = GpsHeading(GpsFloat(value))
+	}
+
+	if value, ok := tags["GPSTrack"]; ok && !data.HasHeading {
+		data.Heading, data.HasHeading = GpsHeading(GpsFloat(value))
+	}
+
+	if value, ok := tags["GPSSpeed"]; ok {
+		data.Speed = GpsSpeed(GpsFloat(value), tags["GPSSpeedRef"])
+	}
+
 	if value, ok := tags["Artist"]; ok {
 		data.Artist = SanitizeString(value)
 	}
@@ -191,6 +208,12 @@ func (data *Data) Exif(fileName string, fileType fs.FileFormat) (err error) {
 		}
 	}
 
+	if value, ok := tags["Rating"]; ok {
+		if i, err := strconv.Atoi(value); err == nil {
+			data.Rating = i
+		}
+	}
+
 	if value, ok := tags["ImageUniqueID"]; ok {
 		if id := rnd.SanitizeUUID(value); id != "" {
 			data.DocumentID = id
@@ -506,6 +506,8 @@ func TestExif(t *testing.T) {
 		assert.Equal(t, float32(65.05558), data.Lat)
 		assert.Equal(t, float32(-16.625702), data.Lng)
 		assert.Equal(t, 0, data.Altitude)
+		assert.Equal(t, float32(235.3), data.Heading)
+		assert.Equal(t, float32(1.4), data.Speed)
 		assert.Equal(t, "1/8", data.Exposure)
 		assert.Equal(t, "NIKON CORPORATION", data.CameraMake)
 		assert.Equal(t, "NIKON D800E", data.CameraModel)
@@ -0,0 +1,89 @@
+package meta
+
+import (
+	"errors"
+)
+
+// ErrNotSupported is returned by extractors that can't read metadata from a file.
+var ErrNotSupported = errors.New("metadata: file format not supported")
+
+// Extractor reads metadata from a file and merges it into an existing Data struct.
+type Extractor interface {
+	Name() string
+	Extract(fileName string, data *Data) error
+}
+
+// ExtractorFunc reads metadata from a file.
+type ExtractorFunc func(fileName string, data *Data) error
+
+// extractorFunc adapts a function to the Extractor interface.
+type extractorFunc struct {
+	name string
+	fn   ExtractorFunc
+}
+
+// NewExtractor returns an extractor with the given name that calls the function.
+func NewExtractor(name string, fn ExtractorFunc) Extractor {
+	return &extractorFunc{name: name, fn: fn}
+}
+
+// Name returns the extractor name.
+func (e *extractorFunc) Name() string {
+	return e.name
+}
+
+// Extract reads metadata from a file.
+func (e *extractorFunc) Extract(fileName string, data *Data) error {
+	return e.fn(fileName, data)
+}
+
+// Extractors represents a metadata extraction pipeline. Extractors run in order and
+// merge their results into the same Data struct.
+type Extractors []Extractor
+
+// Add appends extractors to the pipeline, ignoring nil values.
+func (p Extractors) Add(e ...Extractor) Extractors {
+	for _, x := range e {
+		if x != nil {
+			p = append(p, x)
+		}
+	}
+
+	return p
+}
+
+// Extract runs all extractors and returns nil if at least one of them succeeded,
+// otherwise the first error. Extractors that don't support the file are skipped.
+func (p Extractors) Extract(fileName string, data *Data) (err error) {
+	if data.All == nil {
+		data.All = make(map[string]string)
+	}
+
+	found := false
+
+	for _, e := range p {
+		if e == nil {
+			continue
+		}
+
+		if extractErr := e.Extract(fileName, data); extractErr == nil {
+			found = true
+		} else if errors.Is(extractErr, ErrNotSupported) {
+			continue
+		} else {
+			log.Debugf("metadata: %s (%s)", extractErr, e.Name())
+
+			if err == nil {
+				err = extractErr
+			}
+		}
+	}
+
+	if found {
+		return nil
+	} else if err == nil {
+		return ErrNotSupported
+	}
+
+	return err
+}
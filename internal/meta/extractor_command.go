@@ -0,0 +1,129 @@
+package meta
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// CommandTimeout is the default time limit for external metadata commands.
+var CommandTimeout = 30 * time.Second
+
+// Command runs an external program that writes metadata in JSON format to stdout,
+// for example ExifTool with a custom config or a reader for scientific image headers.
+type Command struct {
+	Bin     string
+	Args    []string
+	Ext     []string
+	Timeout time.Duration
+}
+
+// NewCommand returns a command extractor for the command line and a comma-separated list
+// of file extensions, or nil if the command line is empty. The file name is passed as last argument.
+func NewCommand(cmdLine, extList string) *Command {
+	fields := strings.Fields(cmdLine)
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	result := &Command{Bin: fields[0], Args: fields[1:], Timeout: CommandTimeout}
+
+	for _, ext := range strings.Split(extList, ",") {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+
+		if ext == "" {
+			continue
+		} else if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+
+		result.Ext = append(result.Ext, ext)
+	}
+
+	return result
+}
+
+// Name returns the extractor name.
+func (c *Command) Name() string {
+	return filepath.Base(c.Bin)
+}
+
+// Supported tests if the command should be run for the file, based on its extension.
+func (c *Command) Supported(fileName string) bool {
+	if len(c.Ext) == 0 {
+		return true
+	}
+
+	ext := strings.ToLower(filepath.Ext(fileName))
+
+	for _, e := range c.Ext {
+		if e == ext {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Extract runs the command and merges the JSON metadata written to stdout. All values
+// are added to data.All, known tags are also mapped like ExifTool output.
+func (c *Command) Extract(fileName string, data *Data) error {
+	if !c.Supported(fileName) {
+		return ErrNotSupported
+	}
+
+	timeout := c.Timeout
+
+	if timeout <= 0 {
+		timeout = CommandTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	args := append(append([]string{}, c.Args...), fileName)
+	cmd := exec.CommandContext(ctx, c.Bin, args...)
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	baseName := filepath.Base(fileName)
+
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			log.Debug(strings.TrimSpace(stderr.String()))
+		}
+
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("metadata: %s timed out reading %s", c.Name(), sanitize.Log(baseName))
+		}
+
+		return fmt.Errorf("metadata: %s failed reading %s (%s)", c.Name(), sanitize.Log(baseName), strings.TrimSpace(err.Error()))
+	}
+
+	j := gjson.GetBytes(out.Bytes(), "@flatten|@join")
+
+	if !j.IsObject() {
+		return fmt.Errorf("metadata: %s returned no json for %s", c.Name(), sanitize.Log(baseName))
+	}
+
+	if data.All == nil {
+		data.All = make(map[string]string)
+	}
+
+	for key, val := range j.Map() {
+		data.All[key] = val.String()
+	}
+
+	return data.Exiftool(out.Bytes(), baseName)
+}
@@ -0,0 +1,109 @@
+package meta
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractors_Extract(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var p Extractors
+
+		p = p.Add(
+			NewExtractor("fail", func(fileName string, data *Data) error {
+				return errors.New("failed")
+			}),
+			nil,
+			NewExtractor("title", func(fileName string, data *Data) error {
+				data.Title = "Foo"
+				return nil
+			}),
+		)
+
+		assert.Len(t, p, 2)
+
+		data := Data{}
+
+		assert.NoError(t, p.Extract("testdata/foo.jpg", &data))
+		assert.Equal(t, "Foo", data.Title)
+		assert.NotNil(t, data.All)
+	})
+	t.Run("FirstError", func(t *testing.T) {
+		p := Extractors{
+			NewExtractor("unsupported", func(fileName string, data *Data) error {
+				return ErrNotSupported
+			}),
+			NewExtractor("first", func(fileName string, data *Data) error {
+				return errors.New("first")
+			}),
+			NewExtractor("second", func(fileName string, data *Data) error {
+				return errors.New("second")
+			}),
+		}
+
+		data := NewData()
+
+		assert.EqualError(t, p.Extract("testdata/foo.jpg", &data), "first")
+	})
+	t.Run("NotSupported", func(t *testing.T) {
+		var p Extractors
+
+		data := NewData()
+
+		assert.Equal(t, ErrNotSupported, p.Extract("testdata/foo.jpg", &data))
+	})
+}
+
+func TestNewCommand(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Nil(t, NewCommand("  ", "jpg"))
+	})
+	t.Run("Args", func(t *testing.T) {
+		c := NewCommand("exiftool -config custom.cfg -j", "FITS, .fit,")
+
+		assert.Equal(t, "exiftool", c.Bin)
+		assert.Equal(t, []string{"-config", "custom.cfg", "-j"}, c.Args)
+		assert.Equal(t, []string{".fits", ".fit"}, c.Ext)
+		assert.Equal(t, "exiftool", c.Name())
+		assert.True(t, c.Supported("image.FITS"))
+		assert.False(t, c.Supported("image.jpg"))
+	})
+}
+
+func TestCommand_Extract(t *testing.T) {
+	t.Run("Canon", func(t *testing.T) {
+		// The file name is passed as first positional parameter and ignored by the script.
+		c := &Command{Bin: "sh", Args: []string{"-c", "cat testdata/canon_eos_6d.json", "sh"}}
+		data := NewData()
+
+		assert.NoError(t, c.Extract("testdata/canon_eos_6d.xmp", &data))
+		assert.Equal(t, "Canon", data.CameraMake)
+		assert.NotEmpty(t, data.All["ExifToolVersion"])
+	})
+	t.Run("NameMismatch", func(t *testing.T) {
+		c := NewCommand("cat", "json")
+		data := NewData()
+
+		assert.Error(t, c.Extract("testdata/canon_eos_6d.json", &data))
+	})
+	t.Run("NotSupported", func(t *testing.T) {
+		c := NewCommand("cat", "fits")
+		data := NewData()
+
+		assert.Equal(t, ErrNotSupported, c.Extract("testdata/canon_eos_6d.json", &data))
+	})
+	t.Run("NoJson", func(t *testing.T) {
+		c := NewCommand("cat", "")
+		data := NewData()
+
+		assert.Error(t, c.Extract("testdata/README.md", &data))
+	})
+	t.Run("Failed", func(t *testing.T) {
+		c := NewCommand("cat", "")
+		data := NewData()
+
+		assert.Error(t, c.Extract("testdata/missing.json", &data))
+	})
+}
@@ -1,8 +1,10 @@
 package meta
 
 import (
+	"math"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/dsoprea/go-exif/v3"
 )
@@ -87,3 +89,87 @@ func GpsCoord(s string) float64 {
 
 	return result
 }
+
+// GpsFloat returns a GPS value like "2353/10" or "235.3" as floating point number.
+func GpsFloat(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	// Rational number?
+	if values := strings.Split(s, "/"); len(values) == 2 {
+		number, numErr := strconv.ParseFloat(strings.TrimSpace(values[0]), 64)
+		denom, denomErr := strconv.ParseFloat(strings.TrimSpace(values[1]), 64)
+
+		if numErr == nil && denomErr == nil {
+			if denom == 0 {
+				return 0
+			}
+
+			return number / denom
+		}
+	}
+
+	if fl := GpsFloatRegexp.FindString(s); fl != "" {
+		if result, err := strconv.ParseFloat(fl, 64); err == nil {
+			return result
+		}
+	}
+
+	return 0
+}
+
+// GpsHeading returns the direction in degrees from 0 to 360, and false if it is invalid.
+// A direction of 0 degrees is due north and therefore valid.
+func GpsHeading(deg float64) (float32, bool) {
+	if math.IsNaN(deg) || deg < 0 || deg > 360 {
+		return 0, false
+	} else if deg == 360 {
+		return 0, true
+	}
+
+	return float32(math.Round(deg*100) / 100), true
+}
+
+// GpsAltitude returns the altitude in meters, and false if it cannot be parsed. Values like "4 m Below Sea Level"
+// or with reference "1" are returned as negative numbers, so that sea-level and below-sea-level positions are kept.
+func GpsAltitude(s, ref string) (int, bool) {
+	fl := GpsFloatRegexp.FindAllString(s, -1)
+
+	if len(fl) != 1 {
+		return 0, false
+	}
+
+	alt, err := strconv.ParseFloat(fl[0], 64)
+
+	if err != nil || math.IsNaN(alt) {
+		return 0, false
+	}
+
+	ref = strings.ToLower(strings.TrimSpace(ref))
+
+	if alt > 0 && (ref == "1" || strings.Contains(ref, "below") || strings.Contains(strings.ToLower(s), "below")) {
+		alt = -alt
+	}
+
+	return int(alt), true
+}
+
+// GpsSpeed returns the speed in km/h. The unit reference may be "K" or "km/h", "M" or "mph",
+// "N" or "knots", as well as "m/s" for GoPro cameras. The default unit is km/h.
+func GpsSpeed(speed float64, ref string) float32 {
+	if math.IsNaN(speed) || speed <= 0 {
+		return 0
+	}
+
+	switch strings.ToLower(strings.TrimSpace(ref)) {
+	case "m", "mph", "miles":
+		speed = speed * 1.609344
+	case "n", "knots":
+		speed = speed * 1.852
+	case "m/s":
+		speed = speed * 3.6
+	}
+
+	return float32(math.Round(speed*100) / 100)
+}
@@ -1,6 +1,7 @@
 package meta
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -84,3 +85,93 @@ func TestGpsCoord(t *testing.T) {
 		assert.Equal(t, float64(0), r)
 	})
 }
+
+func TestGpsFloat(t *testing.T) {
+	assert.Equal(t, 235.3, GpsFloat("2353/10"))
+	assert.Equal(t, 235.3, GpsFloat("235.3"))
+	assert.Equal(t, 1.4, GpsFloat("1.4 km/h"))
+	assert.Equal(t, float64(0), GpsFloat("1/0"))
+	assert.Equal(t, float64(0), GpsFloat(""))
+	assert.Equal(t, float64(0), GpsFloat("foo"))
+}
+
+func TestGpsHeading(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		heading, ok := GpsHeading(235.3)
+		assert.True(t, ok)
+		assert.Equal(t, float32(235.3), heading)
+
+		heading, ok = GpsHeading(105.7627258)
+		assert.True(t, ok)
+		assert.Equal(t, float32(105.76), heading)
+	})
+	t.Run("North", func(t *testing.T) {
+		heading, ok := GpsHeading(0)
+		assert.True(t, ok)
+		assert.Equal(t, float32(0), heading)
+
+		heading, ok = GpsHeading(360)
+		assert.True(t, ok)
+		assert.Equal(t, float32(0), heading)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, ok := GpsHeading(-1)
+		assert.False(t, ok)
+
+		_, ok = GpsHeading(361)
+		assert.False(t, ok)
+
+		_, ok = GpsHeading(math.NaN())
+		assert.False(t, ok)
+	})
+}
+
+func TestGpsAltitude(t *testing.T) {
+	t.Run("Above", func(t *testing.T) {
+		alt, ok := GpsAltitude("84.4 m Above Sea Level", "Above Sea Level")
+		assert.True(t, ok)
+		assert.Equal(t, 84, alt)
+
+		alt, ok = GpsAltitude("904.1", "0")
+		assert.True(t, ok)
+		assert.Equal(t, 904, alt)
+	})
+	t.Run("SeaLevel", func(t *testing.T) {
+		alt, ok := GpsAltitude("0 m Above Sea Level", "Below Sea Level")
+		assert.True(t, ok)
+		assert.Equal(t, 0, alt)
+	})
+	t.Run("Below", func(t *testing.T) {
+		alt, ok := GpsAltitude("12 m Below Sea Level", "")
+		assert.True(t, ok)
+		assert.Equal(t, -12, alt)
+
+		alt, ok = GpsAltitude("12.5", "1")
+		assert.True(t, ok)
+		assert.Equal(t, -12, alt)
+
+		alt, ok = GpsAltitude("-12.5", "1")
+		assert.True(t, ok)
+		assert.Equal(t, -12, alt)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, ok := GpsAltitude("", "")
+		assert.False(t, ok)
+
+		_, ok = GpsAltitude("foo", "")
+		assert.False(t, ok)
+	})
+}
+
+func TestGpsSpeed(t *testing.T) {
+	assert.Equal(t, float32(1.4), GpsSpeed(1.4, "K"))
+	assert.Equal(t, float32(1.4), GpsSpeed(1.4, "km/h"))
+	assert.Equal(t, float32(1.4), GpsSpeed(1.4, ""))
+	assert.Equal(t, float32(16.09), GpsSpeed(10, "M"))
+	assert.Equal(t, float32(16.09), GpsSpeed(10, "mph"))
+	assert.Equal(t, float32(18.52), GpsSpeed(10, "N"))
+	assert.Equal(t, float32(18.52), GpsSpeed(10, "knots"))
+	assert.Equal(t, float32(36), GpsSpeed(10, "m/s"))
+	assert.Equal(t, float32(0), GpsSpeed(0, "K"))
+	assert.Equal(t, float32(0), GpsSpeed(-5, "K"))
+}
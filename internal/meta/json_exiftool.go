@@ -5,7 +5,6 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime/debug"
-	"strconv"
 	"strings"
 	"time"
 
@@ -145,13 +144,35 @@ func (data *Data) Exiftool(jsonData []byte, originalName string) (err error) {
 		}
 	}
 
-	if data.Altitude == 0 {
-		// Parseable floating point number?
-		if fl := GpsFloatRegexp.FindAllString(jsonStrings["GPSAltitude"], -1); len(fl) != 1 {
-			// Ignore.
-		} else if alt, err := strconv.ParseFloat(fl[0], 64); err == nil && alt != 0 {
-			data.Altitude = int(alt)
+	// Altitude in meters, which may be 0 at sea level or negative below sea level.
+	if data.HasAltitude {
+		// Keep existing value.
+	} else if alt, ok := jsonStrings["GlobalAltitude"]; ok {
+		data.Altitude, data.HasAltitude = GpsAltitude(alt, "")
+	} else if alt, ok := jsonStrings["GPSAltitude"]; ok {
+		data.Altitude, data.HasAltitude = GpsAltitude(alt, jsonStrings["GPSAltitudeRef"])
+	}
+
+	// Camera direction, or the direction of movement if unknown.
+	if !data.HasHeading {
+		if dir, ok := jsonValues["GPSImgDirection"]; ok {
+			data.Heading, data.HasHeading = GpsHeading(dir.Float())
 		}
+
+		if track, ok := jsonValues["GPSTrack"]; ok && !data.HasHeading {
+			data.Heading, data.HasHeading = GpsHeading(track.Float())
+		}
+	}
+
+	if speed, ok := jsonValues["GPSSpeed"]; ok && data.Speed == 0 {
+		ref := jsonStrings["GPSSpeedRef"]
+
+		// GoPro cameras report the speed in meters per second.
+		if ref == "" && strings.HasPrefix(data.CameraMake, "GoPro") {
+			ref = "m/s"
+		}
+
+		data.Speed = GpsSpeed(GpsFloat(speed.String()), ref)
 	}
 
 	hasTimeOffset := false
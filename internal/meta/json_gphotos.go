@@ -130,8 +130,8 @@ func (data *Data) GPhoto(jsonData []byte) (err error) {
 			data.Lng = float32(p.Geo.Lng)
 		}
 
-		if data.Altitude == 0 {
-			data.Altitude = int(p.Geo.Altitude)
+		if !data.HasAltitude && p.Geo.Altitude != 0 {
+			data.Altitude, data.HasAltitude = int(p.Geo.Altitude), true
 		}
 	}
 
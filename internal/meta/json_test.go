@@ -148,6 +148,8 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, "0s", data.Duration.String())
 		assert.Equal(t, float32(52.45969), data.Lat)
 		assert.Equal(t, float32(13.321831), data.Lng)
+		assert.Equal(t, 0, data.Altitude)
+		assert.True(t, data.HasAltitude)
 		assert.Equal(t, "2020-01-01 16:28:23 +0000 UTC", data.TakenAt.String())
 		assert.Equal(t, "2020-01-01 17:28:23 +0000 UTC", data.TakenAtLocal.String())
 		assert.Equal(t, "Europe/Berlin", data.TimeZone)
@@ -912,6 +914,9 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, float32(65.05558), data.Lat)
 		assert.Equal(t, float32(-16.625702), data.Lng)
 		assert.Equal(t, 30, data.Altitude)
+		assert.Equal(t, float32(235.3), data.Heading)
+		assert.True(t, data.HasHeading)
+		assert.Equal(t, float32(1.4), data.Speed)
 		assert.Equal(t, "1/8", data.Exposure)
 		assert.Equal(t, "NIKON CORPORATION", data.CameraMake)
 		assert.Equal(t, "NIKON D800E", data.CameraModel)
@@ -944,6 +949,9 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, float32(65.05558), data.Lat)
 		assert.Equal(t, float32(-16.625702), data.Lng)
 		assert.Equal(t, 30, data.Altitude)
+		assert.Equal(t, float32(235.3), data.Heading)
+		assert.True(t, data.HasHeading)
+		assert.Equal(t, float32(1.4), data.Speed)
 		assert.Equal(t, "0.125", data.Exposure)
 		assert.Equal(t, "NIKON CORPORATION", data.CameraMake)
 		assert.Equal(t, "NIKON D800E", data.CameraModel)
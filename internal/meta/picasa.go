@@ -0,0 +1,128 @@
+package meta
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// PicasaIniNames lists the file names of folder metadata files created by Picasa.
+var PicasaIniNames = []string{".picasa.ini", "Picasa.ini"}
+
+// picasaContacts is the name of the section that maps contact ids to names.
+const picasaContacts = "Contacts2"
+
+// picasaUnknown is the contact id of faces without a name.
+const picasaUnknown = "ffffffffffffffff"
+
+// Picasa parses a Picasa folder metadata file and returns the face regions of the image with the given base name.
+func Picasa(fileName, baseName string) (data Data, err error) {
+	err = data.Picasa(fileName, baseName)
+
+	return data, err
+}
+
+// Picasa parses a Picasa folder metadata file and adds the face regions of the image with the given base name.
+func (data *Data) Picasa(fileName, baseName string) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("metadata: %s in %s (picasa panic)\nstack: %s", e, sanitize.Log(filepath.Base(fileName)), debug.Stack())
+		}
+	}()
+
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		return fmt.Errorf("metadata: cannot read %s (picasa)", sanitize.Log(filepath.Base(fileName)))
+	}
+
+	defer file.Close()
+
+	var section, faces string
+
+	contacts := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = line[1 : len(line)-1]
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+
+		if len(kv) != 2 {
+			continue
+		}
+
+		key, value := kv[0], kv[1]
+
+		switch {
+		case section == picasaContacts:
+			// Values look like "Jane Doe;jane@example.com;".
+			contacts[strings.ToLower(strings.TrimSpace(key))] = strings.SplitN(value, ";", 2)[0]
+		case key == "faces" && strings.EqualFold(section, baseName):
+			faces = value
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("metadata: %s in %s (picasa)", err, sanitize.Log(filepath.Base(fileName)))
+	}
+
+	// Faces look like "rect64(3f845bcb59418507),8e62398ebda8c1a5;rect64(...),ffffffffffffffff".
+	for _, s := range strings.Split(faces, ";") {
+		rect, contact := strings.TrimSpace(s), ""
+
+		if i := strings.Index(rect, ","); i >= 0 {
+			rect, contact = rect[:i], rect[i+1:]
+		}
+
+		if !strings.HasPrefix(rect, "rect64(") || !strings.HasSuffix(rect, ")") {
+			continue
+		}
+
+		region, ok := picasaRect(rect[7 : len(rect)-1])
+
+		if !ok {
+			continue
+		}
+
+		if contact = strings.ToLower(strings.TrimSpace(contact)); contact != picasaUnknown {
+			region.Name = contacts[contact]
+		}
+
+		data.Regions.Add(region)
+	}
+
+	return nil
+}
+
+// picasaRect returns the face region of a Picasa rect64 value, which encodes the relative left, top,
+// right, and bottom coordinates as 16-bit values.
+func picasaRect(s string) (region Region, ok bool) {
+	v, err := strconv.ParseUint(s, 16, 64)
+
+	if err != nil {
+		return region, false
+	}
+
+	left := float32(v>>48&0xFFFF) / 0xFFFF
+	top := float32(v>>32&0xFFFF) / 0xFFFF
+	right := float32(v>>16&0xFFFF) / 0xFFFF
+	bottom := float32(v&0xFFFF) / 0xFFFF
+
+	if right <= left || bottom <= top {
+		return region, false
+	}
+
+	return Region{Type: RegionFace, X: left, Y: top, W: right - left, H: bottom - top}, true
+}
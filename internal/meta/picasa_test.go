@@ -0,0 +1,76 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPicasa(t *testing.T) {
+	t.Run("dinner.jpg", func(t *testing.T) {
+		data, err := Picasa("testdata/picasa.ini", "dinner.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(data.Regions) != 3 {
+			t.Fatalf("expected 3 regions, found %d", len(data.Regions))
+		}
+
+		assert.Equal(t, "Jane Doe", data.Regions[0].Name)
+		assert.Equal(t, RegionFace, data.Regions[0].Type)
+		assert.InDelta(t, 0.248, data.Regions[0].X, 0.001)
+		assert.InDelta(t, 0.359, data.Regions[0].Y, 0.001)
+		assert.Equal(t, "John Doe", data.Regions[1].Name)
+		assert.InDelta(t, 0.625, data.Regions[1].X, 0.001)
+		assert.InDelta(t, 0.0, data.Regions[1].Y, 0.001)
+		assert.InDelta(t, 0.125, data.Regions[1].W, 0.001)
+		assert.InDelta(t, 1.0, data.Regions[1].H, 0.001)
+		assert.Equal(t, "", data.Regions[2].Name)
+	})
+	t.Run("Other.JPG", func(t *testing.T) {
+		data, err := Picasa("testdata/picasa.ini", "Other.JPG")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, data.Regions, 1)
+		assert.Equal(t, "John Doe", data.Regions[0].Name)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		data, err := Picasa("testdata/picasa.ini", "unknown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, data.Regions)
+	})
+	t.Run("FileNotFound", func(t *testing.T) {
+		_, err := Picasa("testdata/missing.ini", "dinner.jpg")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPicasaRect(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		region, ok := picasaRect("a0000000c000ffff")
+
+		assert.True(t, ok)
+		assert.InDelta(t, 0.625, region.X, 0.001)
+		assert.InDelta(t, 1.0, region.H, 0.001)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, ok := picasaRect("c0000000a000ffff")
+
+		assert.False(t, ok)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, ok := picasaRect("xyz")
+
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,143 @@
+package meta
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+)
+
+// previewBufferSize is the read buffer size used when searching files for embedded previews.
+const previewBufferSize = 64 * 1024
+
+// jpegSize returns the size of the JPEG image at the start of r, or -1 if r doesn't start
+// with a complete JPEG image.
+func jpegSize(r io.Reader) int64 {
+	br := bufio.NewReaderSize(r, previewBufferSize)
+	buf := make([]byte, 2)
+
+	if _, err := io.ReadFull(br, buf); err != nil || buf[0] != 0xFF || buf[1] != 0xD8 {
+		return -1
+	}
+
+	n := int64(2)
+
+	for {
+		if _, err := io.ReadFull(br, buf); err != nil || buf[0] != 0xFF {
+			return -1
+		}
+
+		n += 2
+		marker := buf[1]
+
+		switch {
+		case marker == 0xFF:
+			// Skip fill bytes.
+			_ = br.UnreadByte()
+			n--
+			continue
+		case marker == 0xD9:
+			// End of image.
+			return n
+		case marker == 0x01 || marker >= 0xD0 && marker <= 0xD7:
+			// Markers without length.
+			continue
+		}
+
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return -1
+		}
+
+		length := int64(buf[0])<<8 | int64(buf[1])
+
+		if length < 2 {
+			return -1
+		} else if _, err := br.Discard(int(length - 2)); err != nil {
+			return -1
+		}
+
+		n += length
+
+		if marker != 0xDA {
+			continue
+		}
+
+		// Skip entropy-coded data after the start of scan, which ends with the next marker.
+		for {
+			p, err := br.Peek(2)
+
+			if err != nil {
+				return -1
+			} else if p[0] != 0xFF {
+				_, _ = br.Discard(1)
+				n++
+			} else if p[1] == 0x00 || p[1] >= 0xD0 && p[1] <= 0xD7 {
+				_, _ = br.Discard(2)
+				n += 2
+			} else {
+				break
+			}
+		}
+	}
+}
+
+// RawPreview returns the largest JPEG image embedded in a RAW file, which usually is a full-size
+// or large preview created by the camera, as well as its dimensions. The file is read in chunks,
+// so that large RAW files don't need to be loaded into memory.
+func RawPreview(r io.ReaderAt, size int64) (preview *io.SectionReader, config image.Config, err error) {
+	var start int64
+
+	for start < size {
+		br := bufio.NewReaderSize(io.NewSectionReader(r, start, size-start), previewBufferSize)
+		pos := start
+		found := int64(-1)
+
+		// Find the next JPEG start of image marker followed by another marker.
+		var prev [2]byte
+
+		for {
+			b, err := br.ReadByte()
+
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return preview, config, err
+			}
+
+			pos++
+
+			if prev[0] == 0xFF && prev[1] == 0xD8 && b == 0xFF {
+				found = pos - 3
+				break
+			}
+
+			prev[0], prev[1] = prev[1], b
+		}
+
+		if found < 0 {
+			break
+		}
+
+		length := jpegSize(io.NewSectionReader(r, found, size-found))
+
+		if length < 0 {
+			start = found + 1
+			continue
+		}
+
+		// Lossless JPEG, as used for RAW image data, can't be decoded and is skipped.
+		if c, err := jpeg.DecodeConfig(io.NewSectionReader(r, found, length)); err == nil && c.Width*c.Height > config.Width*config.Height {
+			preview, config = io.NewSectionReader(r, found, length), c
+		}
+
+		// Thumbnails embedded in a preview are smaller, so the search continues after its end.
+		start = found + length
+	}
+
+	if preview == nil {
+		return preview, config, fmt.Errorf("metadata: found no preview")
+	}
+
+	return preview, config, nil
+}
@@ -0,0 +1,89 @@
+package meta
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rawPreview returns the largest preview embedded in a test file.
+func rawPreview(t *testing.T, fileName string) (data []byte, width, height int, err error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return data, 0, 0, err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	preview, config, err := RawPreview(f, info.Size())
+
+	if err != nil {
+		return data, 0, 0, err
+	}
+
+	if data, err = io.ReadAll(preview); err != nil {
+		t.Fatal(err)
+	}
+
+	return data, config.Width, config.Height, nil
+}
+
+func TestRawPreview(t *testing.T) {
+	t.Run("canon_eos_6d.dng", func(t *testing.T) {
+		data, width, height, err := rawPreview(t, "testdata/canon_eos_6d.dng")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 57901, len(data))
+		assert.Equal(t, 1024, width)
+		assert.Equal(t, 683, height)
+		assert.Equal(t, []byte{0xFF, 0xD8}, data[:2])
+		assert.Equal(t, []byte{0xFF, 0xD9}, data[len(data)-2:])
+	})
+	t.Run("digikam.jpg", func(t *testing.T) {
+		_, width, _, err := rawPreview(t, "testdata/digikam.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, width, 0)
+	})
+	t.Run("exif-example.tiff", func(t *testing.T) {
+		_, _, _, err := rawPreview(t, "testdata/exif-example.tiff")
+
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, _, _, err := rawPreview(t, "testdata/xxx.dng")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestJpegSize(t *testing.T) {
+	t.Run("Complete", func(t *testing.T) {
+		data := []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x04, 0x01, 0x02, 0xFF, 0xDA, 0x00, 0x02, 0x12, 0xFF, 0x00, 0x34, 0xFF, 0xD0, 0x56, 0xFF, 0xD9, 0x00}
+		assert.Equal(t, int64(21), jpegSize(bytes.NewReader(data)))
+	})
+	t.Run("FillBytes", func(t *testing.T) {
+		data := []byte{0xFF, 0xD8, 0xFF, 0xFF, 0xE0, 0x00, 0x02, 0xFF, 0xD9}
+		assert.Equal(t, int64(9), jpegSize(bytes.NewReader(data)))
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		data := []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10, 0x01}
+		assert.Equal(t, int64(-1), jpegSize(bytes.NewReader(data)))
+	})
+}
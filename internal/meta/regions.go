@@ -0,0 +1,66 @@
+package meta
+
+import (
+	"strings"
+)
+
+// RegionFace is the type of face regions, e.g. as tagged in Lightroom, digiKam, or Picasa.
+const RegionFace = "Face"
+
+// Region represents a named image region with relative coordinates of its top left corner.
+type Region struct {
+	Name string
+	Type string
+	X    float32
+	Y    float32
+	W    float32
+	H    float32
+}
+
+// Regions represents a list of image regions.
+type Regions []Region
+
+// Valid tests if the region has a valid relative area.
+func (r Region) Valid() bool {
+	return r.W > 0 && r.H > 0 && r.X >= 0 && r.Y >= 0 && r.X+r.W <= 1.001 && r.Y+r.H <= 1.001
+}
+
+// IsFace tests if the region is a face.
+func (r Region) IsFace() bool {
+	return strings.EqualFold(r.Type, RegionFace)
+}
+
+// Faces returns the face regions.
+func (r Regions) Faces() (result Regions) {
+	for _, region := range r {
+		if region.IsFace() {
+			result = append(result, region)
+		}
+	}
+
+	return result
+}
+
+// Add adds a region unless it's invalid or already exists.
+func (r *Regions) Add(region Region) {
+	if !region.Valid() {
+		return
+	}
+
+	region.Name = SanitizeString(region.Name)
+
+	for _, other := range *r {
+		if other == region {
+			return
+		}
+	}
+
+	*r = append(*r, region)
+}
+
+// AddRegions appends image regions.
+func (data *Data) AddRegions(regions Regions) {
+	for _, region := range regions {
+		data.Regions.Add(region)
+	}
+}
@@ -0,0 +1,27 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegion_Valid(t *testing.T) {
+	assert.True(t, Region{X: 0.2, Y: 0.3, W: 0.1, H: 0.1}.Valid())
+	assert.False(t, Region{X: 0.2, Y: 0.3}.Valid())
+	assert.False(t, Region{X: -0.1, Y: 0.3, W: 0.2, H: 0.1}.Valid())
+	assert.False(t, Region{X: 0.9, Y: 0.3, W: 0.2, H: 0.1}.Valid())
+}
+
+func TestRegions_Add(t *testing.T) {
+	var regions Regions
+
+	regions.Add(Region{Name: " Jane ", Type: "Face", X: 0.2, Y: 0.3, W: 0.1, H: 0.1})
+	regions.Add(Region{Name: "Jane", Type: "Face", X: 0.2, Y: 0.3, W: 0.1, H: 0.1})
+	regions.Add(Region{Name: "Invalid", Type: "Face"})
+	regions.Add(Region{Name: "Cake", Type: "Focus", X: 0.5, Y: 0.5, W: 0.1, H: 0.1})
+
+	assert.Len(t, regions, 2)
+	assert.Equal(t, "Jane", regions[0].Name)
+	assert.Len(t, regions.Faces(), 1)
+}
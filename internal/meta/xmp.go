@@ -61,9 +61,22 @@ func (data *Data) XMP(fileName string) (err error) {
 		data.TakenAt = takenAt
 	}
 
+	if rating := doc.Rating(); rating != 0 {
+		data.Rating = rating
+	}
+
 	if len(doc.Keywords()) != 0 {
 		data.AddKeywords(doc.Keywords())
 	}
 
+	if regions := doc.Regions(); len(regions) != 0 {
+		data.AddRegions(regions)
+	}
+
+	if projection := doc.Projection(); projection != "" {
+		data.Projection = projection
+		data.AddKeywords(KeywordPanorama)
+	}
+
 	return nil
 }
@@ -3,6 +3,7 @@ package meta
 import (
 	"encoding/xml"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -30,6 +31,9 @@ type XmpDocument struct {
 			XmpRights       string `xml:"xmpRights,attr" json:"xmprights,omitempty"`
 			Iptc4xmpCore    string `xml:"Iptc4xmpCore,attr" json:"iptc4xmpcore,omitempty"`
 			Iptc4xmpExt     string `xml:"Iptc4xmpExt,attr" json:"iptc4xmpext,omitempty"`
+			GPano           string `xml:"GPano,attr" json:"gpano,omitempty"`
+			ProjectionAttr  string `xml:"ProjectionType,attr" json:"projectiontype,omitempty"`
+			RatingAttr      string `xml:"Rating,attr" json:"rating,omitempty"`
 			CreatorTool     string `xml:"CreatorTool"`     // ELE-L29 10.0.0.168(C431E2...
 			ModifyDate      string `xml:"ModifyDate"`      // 2020-01-01T17:28:23.89961...
 			CreateDate      string `xml:"CreateDate"`      // 2020-01-01T17:28:23
@@ -44,6 +48,7 @@ type XmpDocument struct {
 			DocumentID      string `xml:"DocumentID"`      // 2C678C1811D7095FD79CC822B...
 			InstanceID      string `xml:"InstanceID"`      // 2C678C1811D7095FD79CC822B...
 			Format          string `xml:"format"`          // image/jpeg
+			ProjectionType  string `xml:"ProjectionType"`  // equirectangular
 			Title           struct {
 				Text string `xml:",chardata" json:"text,omitempty"`
 				Alt  struct {
@@ -194,10 +199,125 @@ type XmpDocument struct {
 					Li   string `xml:"li"` // Gopher
 				} `xml:"Bag" json:"bag,omitempty"`
 			} `xml:"PersonInImage" json:"personinimage,omitempty"`
+			Regions struct {
+				Text                string        `xml:",chardata" json:"text,omitempty"`
+				ParseType           string        `xml:"parseType,attr" json:"parsetype,omitempty"`
+				AppliedToDimensions XmpDimensions `xml:"AppliedToDimensions" json:"appliedtodimensions,omitempty"`
+				RegionList          struct {
+					Text string `xml:",chardata" json:"text,omitempty"`
+					Bag  struct {
+						Text string      `xml:",chardata" json:"text,omitempty"`
+						Li   []XmpRegion `xml:"li"`
+					} `xml:"Bag" json:"bag,omitempty"`
+				} `xml:"RegionList" json:"regionlist,omitempty"`
+			} `xml:"Regions" json:"regions,omitempty"`
 		} `xml:"Description" json:"description,omitempty"`
 	} `xml:"RDF" json:"rdf,omitempty"`
 }
 
+// XmpRegion represents an image region as specified by the Metadata Working Group (mwg-rs),
+// either with attributes or with nested elements.
+type XmpRegion struct {
+	Name        string  `xml:"Name"`      // Jane Doe
+	Type        string  `xml:"Type"`      // Face
+	NameAttr    string  `xml:"Name,attr"` // Jane Doe
+	TypeAttr    string  `xml:"Type,attr"` // Face
+	Area        XmpArea `xml:"Area" json:"area,omitempty"`
+	Description struct {
+		Name string  `xml:"Name,attr"`
+		Type string  `xml:"Type,attr"`
+		Area XmpArea `xml:"Area" json:"area,omitempty"`
+	} `xml:"Description" json:"description,omitempty"`
+}
+
+// XmpArea represents the area of an image region with normalized center coordinates.
+type XmpArea struct {
+	X     string `xml:"x,attr"`    // 0.5
+	Y     string `xml:"y,attr"`    // 0.5
+	W     string `xml:"w,attr"`    // 0.1
+	H     string `xml:"h,attr"`    // 0.1
+	Unit  string `xml:"unit,attr"` // normalized
+	XElem string `xml:"x"`
+	YElem string `xml:"y"`
+	WElem string `xml:"w"`
+	HElem string `xml:"h"`
+}
+
+// XmpDimensions represents the dimensions of the image that image regions were applied to.
+type XmpDimensions struct {
+	W     string `xml:"w,attr"`    // 4000
+	H     string `xml:"h,attr"`    // 3000
+	Unit  string `xml:"unit,attr"` // pixel
+	WElem string `xml:"w"`
+	HElem string `xml:"h"`
+}
+
+// Size returns the width and height in pixels, or zero if they are unknown.
+func (d XmpDimensions) Size() (w, h float32) {
+	if d.Unit != "" && !strings.EqualFold(d.Unit, "pixel") {
+		return 0, 0
+	}
+
+	return xmpFloat(d.W, d.WElem), xmpFloat(d.H, d.HElem)
+}
+
+// xmpFloat returns the attribute value as float, or the element value if the attribute is empty.
+func xmpFloat(attr, elem string) float32 {
+	s := attr
+
+	if s == "" {
+		s = elem
+	}
+
+	f, _ := strconv.ParseFloat(strings.TrimSpace(s), 32)
+
+	return float32(f)
+}
+
+// Region returns the image region with relative coordinates of its top left corner. Areas in pixels
+// are scaled by the dimensions of the image that the regions were applied to.
+func (r XmpRegion) Region(applied XmpDimensions) (region Region) {
+	area := r.Area
+
+	if r.Description.Area != (XmpArea{}) {
+		area = r.Description.Area
+	}
+
+	// Scale factors for normalized coordinates.
+	scaleW, scaleH := float32(1), float32(1)
+
+	switch {
+	case area.Unit == "" || strings.EqualFold(area.Unit, "normalized"):
+		// Coordinates are relative to the applied dimensions, so they match resized images as well.
+	case strings.EqualFold(area.Unit, "pixel"):
+		if w, h := applied.Size(); w <= 0 || h <= 0 {
+			return region
+		} else {
+			scaleW, scaleH = 1/w, 1/h
+		}
+	default:
+		return region
+	}
+
+	region.Name = r.Name
+	region.Type = r.Type
+
+	if region.Name == "" {
+		region.Name = r.NameAttr + r.Description.Name
+	}
+
+	if region.Type == "" {
+		region.Type = r.TypeAttr + r.Description.Type
+	}
+
+	region.W = xmpFloat(area.W, area.WElem) * scaleW
+	region.H = xmpFloat(area.H, area.HElem) * scaleH
+	region.X = xmpFloat(area.X, area.XElem)*scaleW - region.W/2
+	region.Y = xmpFloat(area.Y, area.YElem)*scaleH - region.H/2
+
+	return region
+}
+
 // Load parses an XMP file and populates document values with its contents.
 func (doc *XmpDocument) Load(filename string) error {
 	data, err := os.ReadFile(filename)
@@ -281,9 +401,50 @@ func (doc *XmpDocument) TakenAt() time.Time {
 	return taken
 }
 
+// Projection returns the XMP document panorama projection type, e.g. from GPano tags.
+func (doc *XmpDocument) Projection() string {
+	if s := SanitizeString(doc.RDF.Description.ProjectionType); s != "" {
+		return strings.ToLower(s)
+	}
+
+	return strings.ToLower(SanitizeString(doc.RDF.Description.ProjectionAttr))
+}
+
+// Rating returns the XMP document star rating from 0 to 5, or -1 if the image has been rejected.
+func (doc *XmpDocument) Rating() int {
+	s := SanitizeString(doc.RDF.Description.Rating)
+
+	if s == "" {
+		s = SanitizeString(doc.RDF.Description.RatingAttr)
+	}
+
+	if s == "" {
+		return 0
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+
+	if err != nil {
+		return 0
+	}
+
+	return int(f)
+}
+
 // Keywords returns the XMP document keywords.
 func (doc *XmpDocument) Keywords() string {
 	s := doc.RDF.Description.Subject.Seq.Li
 
 	return strings.Join(s, ", ")
 }
+
+// Regions returns the XMP document image regions, e.g. faces tagged in Lightroom or digiKam.
+func (doc *XmpDocument) Regions() (regions Regions) {
+	applied := doc.RDF.Description.Regions.AppliedToDimensions
+
+	for _, li := range doc.RDF.Description.Regions.RegionList.Bag.Li {
+		regions.Add(li.Region(applied))
+	}
+
+	return regions
+}
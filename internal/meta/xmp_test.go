@@ -21,6 +21,17 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, Keywords{"blume", "krokus", "schöne", "wiese"}, data.Keywords)
 	})
 
+	t.Run("panorama360", func(t *testing.T) {
+		data, err := XMP("testdata/panorama360.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "equirectangular", data.Projection)
+		assert.Contains(t, data.Keywords, KeywordPanorama)
+	})
+
 	t.Run("photoshop", func(t *testing.T) {
 		data, err := XMP("testdata/photoshop.xmp")
 
@@ -37,6 +48,7 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, "HUAWEI", data.CameraMake)
 		assert.Equal(t, "ELE-L29", data.CameraModel)
 		assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
+		assert.Equal(t, 4, data.Rating)
 	})
 
 	t.Run("canon_eos_6d", func(t *testing.T) {
@@ -71,4 +83,65 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, "iPhone 7 back camera 3.99mm f/1.8", data.LensModel)
 	})
 
+	t.Run("regions", func(t *testing.T) {
+		data, err := XMP("testdata/regions.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Family Dinner", data.Title)
+		assert.Len(t, data.Regions, 4)
+
+		faces := data.Regions.Faces()
+
+		if len(faces) != 3 {
+			t.Fatalf("expected 3 faces, found %d", len(faces))
+		}
+
+		assert.Equal(t, "Jane Doe", faces[0].Name)
+		assert.InDelta(t, 0.2, faces[0].X, 0.001)
+		assert.InDelta(t, 0.25, faces[0].Y, 0.001)
+		assert.InDelta(t, 0.2, faces[0].W, 0.001)
+		assert.InDelta(t, 0.3, faces[0].H, 0.001)
+		assert.Equal(t, "John Doe", faces[1].Name)
+		assert.InDelta(t, 0.65, faces[1].X, 0.001)
+		assert.InDelta(t, 0.4, faces[1].Y, 0.001)
+		assert.Equal(t, "", faces[2].Name)
+	})
+
+	t.Run("regions-pixel", func(t *testing.T) {
+		data, err := XMP("testdata/regions-pixel.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		faces := data.Regions.Faces()
+
+		if len(faces) != 2 {
+			t.Fatalf("expected 2 faces, found %d", len(faces))
+		}
+
+		// Pixel coordinates are scaled by the applied dimensions.
+		assert.Equal(t, "Jane Doe", faces[0].Name)
+		assert.InDelta(t, 0.2, faces[0].X, 0.001)
+		assert.InDelta(t, 0.25, faces[0].Y, 0.001)
+		assert.InDelta(t, 0.2, faces[0].W, 0.001)
+		assert.InDelta(t, 0.3, faces[0].H, 0.001)
+		assert.Equal(t, "John Doe", faces[1].Name)
+		assert.InDelta(t, 0.65, faces[1].X, 0.001)
+		assert.InDelta(t, 0.1, faces[1].W, 0.001)
+	})
+
+	t.Run("rating", func(t *testing.T) {
+		data, err := XMP("testdata/rating.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Sunset", data.Title)
+		assert.Equal(t, 3, data.Rating)
+	})
 }
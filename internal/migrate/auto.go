@@ -2,12 +2,17 @@ package migrate
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/jinzhu/gorm"
 )
 
-// Auto automatically migrates the database provided.
-func Auto(db *gorm.DB, runFailed bool) error {
+// SourceAuto is the source of migrations that record schema changes made by AutoMigrate.
+const SourceAuto = "auto"
+
+// Auto automatically migrates the database provided. The checksum of the schema created
+// by AutoMigrate is recorded first, as its changes are not versioned and cannot be rolled back.
+func Auto(db *gorm.DB, runFailed bool, checksum string) error {
 	if db == nil {
 		return fmt.Errorf("migrate: database connection required")
 	}
@@ -22,10 +27,64 @@ func Auto(db *gorm.DB, runFailed bool) error {
 		return fmt.Errorf("migrate: %s (create migrations table)", err)
 	}
 
+	if err := Schema(db, checksum); err != nil {
+		log.Warn(err)
+	}
+
 	if migrations, ok := Dialects[name]; ok && len(migrations) > 0 {
 		migrations.Start(db, runFailed)
+
+		// Add yearly partitions up to the next year, so that new photos don't end up in the future partition.
+		if err := Partitions(db, time.Now().Year()+1); err != nil {
+			log.Warn(err)
+		}
+
 		return nil
 	} else {
 		return fmt.Errorf("migrate: no migrations found for %s", name)
 	}
 }
+
+// Newer returns the IDs of executed migrations that have been added by a newer version,
+// in which case the schema should not be modified to prevent data loss after a downgrade.
+func Newer(db *gorm.DB) []string {
+	if db == nil || !db.HasTable(&Migration{}) {
+		return nil
+	}
+
+	if migrations, ok := Dialects[db.Dialect().GetName()]; ok {
+		return migrations.Unknown(Existing(db))
+	}
+
+	return nil
+}
+
+// Schema records the schema checksum provided unless it is already known, so that
+// migrations executed before the schema was changed by AutoMigrate are no longer rolled back.
+func Schema(db *gorm.DB, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+
+	id := SourceAuto + "-" + checksum
+
+	if len(id) > 16 {
+		id = id[:16]
+	}
+
+	if _, ok := Existing(db)[id]; ok {
+		return nil
+	}
+
+	now := time.Now().UTC().Round(time.Second)
+
+	m := Migration{ID: id, Dialect: db.Dialect().GetName(), Source: SourceAuto, StartedAt: now, FinishedAt: &now}
+
+	if err := db.Create(&m).Error; err != nil {
+		return fmt.Errorf("migrate: %s (record schema)", err)
+	}
+
+	log.Debugf("migrate: recorded schema %s", id)
+
+	return nil
+}
@@ -6,20 +6,48 @@ var DialectMySQL = Migrations{
 		ID:         "20211121-094727",
 		Dialect:    "mysql",
 		Statements: []string{"DROP INDEX uix_places_place_label ON `places`;"},
+		Down:       []string{"CREATE UNIQUE INDEX IF NOT EXISTS uix_places_place_label ON `places` (place_label);"},
 	},
 	{
 		ID:         "20211124-120008",
 		Dialect:    "mysql",
 		Statements: []string{"DROP INDEX idx_places_place_label ON `places`;", "DROP INDEX uix_places_label ON `places`;"},
+		Down:       []string{"CREATE INDEX IF NOT EXISTS idx_places_place_label ON `places` (place_label);", "CREATE UNIQUE INDEX IF NOT EXISTS uix_places_label ON `places` (place_label);"},
 	},
 	{
 		ID:         "20220103-115400",
 		Dialect:    "mysql",
 		Statements: []string{"ALTER TABLE files MODIFY file_projection VARBINARY(40) NULL;", "ALTER TABLE files MODIFY file_color_profile VARBINARY(40) NULL;"},
+		Down:       []string{"ALTER TABLE files MODIFY file_projection VARBINARY(16) NULL;", "ALTER TABLE files MODIFY file_color_profile VARBINARY(16) NULL;"},
 	},
 	{
 		ID:         "20220118-172400",
 		Dialect:    "mysql",
 		Statements: []string{"ALTER TABLE albums MODIFY album_filter VARBINARY(767) DEFAULT '';", "CREATE INDEX IF NOT EXISTS idx_albums_album_filter ON albums (album_filter);"},
+		Down:       []string{"DROP INDEX idx_albums_album_filter ON albums;"},
+	},
+	{
+		ID:         "20220222-101500",
+		Dialect:    "mysql",
+		Statements: []string{"DROP INDEX IF EXISTS idx_photos_ymd ON photos;", "CREATE INDEX IF NOT EXISTS idx_photos_ymd ON photos (photo_year, photo_month, photo_day);", "CREATE INDEX IF NOT EXISTS idx_photos_deleted_taken ON photos (deleted_at, taken_at, photo_uid);", "CREATE INDEX IF NOT EXISTS idx_files_photo_missing_primary ON files (photo_id, file_missing, file_primary);", "CREATE INDEX IF NOT EXISTS idx_photos_labels_label_uncertainty ON photos_labels (label_id, uncertainty, photo_id);", "CREATE INDEX IF NOT EXISTS idx_photos_albums_album_hidden ON photos_albums (album_uid, hidden, photo_uid);", "CREATE INDEX IF NOT EXISTS idx_markers_subj_invalid_file ON markers (subj_uid, marker_invalid, file_uid);"},
+		Down:       []string{"DROP INDEX IF EXISTS idx_markers_subj_invalid_file ON markers;", "DROP INDEX IF EXISTS idx_photos_albums_album_hidden ON photos_albums;", "DROP INDEX IF EXISTS idx_photos_labels_label_uncertainty ON photos_labels;", "DROP INDEX IF EXISTS idx_files_photo_missing_primary ON files;", "DROP INDEX IF EXISTS idx_photos_deleted_taken ON photos;"},
+	},
+	{
+		ID:         "20220405-101500",
+		Dialect:    "mysql",
+		Statements: []string{"DROP TABLE IF EXISTS photos_keys;", "CREATE TABLE photos_keys (id INT UNSIGNED NOT NULL PRIMARY KEY, photo_uid VARBINARY(42) NOT NULL, UNIQUE KEY uix_photos_keys_photo_uid (photo_uid));", "INSERT IGNORE INTO photos_keys (id, photo_uid) SELECT id, photo_uid FROM photos;", "CREATE TRIGGER IF NOT EXISTS photos_keys_insert AFTER INSERT ON photos FOR EACH ROW INSERT INTO photos_keys (id, photo_uid) VALUES (NEW.id, NEW.photo_uid);", "CREATE TRIGGER IF NOT EXISTS photos_keys_update AFTER UPDATE ON photos FOR EACH ROW UPDATE photos_keys SET id = NEW.id, photo_uid = NEW.photo_uid WHERE id = OLD.id;", "CREATE TRIGGER IF NOT EXISTS photos_keys_delete AFTER DELETE ON photos FOR EACH ROW DELETE FROM photos_keys WHERE id = OLD.id;", "UPDATE photos SET photo_year = -1 WHERE photo_year IS NULL;", "ALTER TABLE photos MODIFY photo_year INT NOT NULL DEFAULT -1;", "ALTER TABLE photos DROP PRIMARY KEY, ADD PRIMARY KEY (id, photo_year);", "DROP INDEX IF EXISTS uix_photos_photo_uid ON photos;", "CREATE UNIQUE INDEX uix_photos_photo_uid ON photos (photo_uid, photo_year);", "ALTER TABLE photos PARTITION BY RANGE (photo_year) (PARTITION p_unknown VALUES LESS THAN (1900), PARTITION p1900 VALUES LESS THAN (2000), PARTITION p2000 VALUES LESS THAN (2010), PARTITION p2010 VALUES LESS THAN (2012), PARTITION p2012 VALUES LESS THAN (2014), PARTITION p2014 VALUES LESS THAN (2016), PARTITION p2016 VALUES LESS THAN (2017), PARTITION p2017 VALUES LESS THAN (2018), PARTITION p2018 VALUES LESS THAN (2019), PARTITION p2019 VALUES LESS THAN (2020), PARTITION p2020 VALUES LESS THAN (2021), PARTITION p2021 VALUES LESS THAN (2022), PARTITION p2022 VALUES LESS THAN (2023), PARTITION p2023 VALUES LESS THAN (2024), PARTITION p2024 VALUES LESS THAN (2025), PARTITION p_future VALUES LESS THAN MAXVALUE);"},
+		Down:       []string{"ALTER TABLE photos REMOVE PARTITIONING;", "DROP INDEX IF EXISTS uix_photos_photo_uid ON photos;", "CREATE UNIQUE INDEX uix_photos_photo_uid ON photos (photo_uid);", "ALTER TABLE photos DROP PRIMARY KEY, ADD PRIMARY KEY (id);", "DROP TRIGGER IF EXISTS photos_keys_delete;", "DROP TRIGGER IF EXISTS photos_keys_update;", "DROP TRIGGER IF EXISTS photos_keys_insert;", "DROP TABLE IF EXISTS photos_keys;"},
+	},
+	{
+		ID:         "20220405-102000",
+		Dialect:    "mysql",
+		Statements: []string{"DROP TABLE IF EXISTS files_keys;", "CREATE TABLE files_keys (id INT UNSIGNED NOT NULL PRIMARY KEY, file_uid VARBINARY(42) NOT NULL, file_name VARBINARY(755) NOT NULL, file_root VARBINARY(16) NOT NULL DEFAULT '/', UNIQUE KEY uix_files_keys_file_uid (file_uid), UNIQUE KEY uix_files_keys_name_root (file_name, file_root));", "INSERT IGNORE INTO files_keys (id, file_uid, file_name, file_root) SELECT id, file_uid, file_name, file_root FROM files;", "CREATE TRIGGER IF NOT EXISTS files_keys_insert AFTER INSERT ON files FOR EACH ROW INSERT INTO files_keys (id, file_uid, file_name, file_root) VALUES (NEW.id, NEW.file_uid, NEW.file_name, NEW.file_root);", "CREATE TRIGGER IF NOT EXISTS files_keys_update AFTER UPDATE ON files FOR EACH ROW UPDATE files_keys SET id = NEW.id, file_uid = NEW.file_uid, file_name = NEW.file_name, file_root = NEW.file_root WHERE id = OLD.id;", "CREATE TRIGGER IF NOT EXISTS files_keys_delete AFTER DELETE ON files FOR EACH ROW DELETE FROM files_keys WHERE id = OLD.id;", "ALTER TABLE files ADD COLUMN IF NOT EXISTS photo_year INT NOT NULL DEFAULT -1;", "UPDATE files JOIN photos ON photos.id = files.photo_id SET files.photo_year = photos.photo_year;", "CREATE TRIGGER IF NOT EXISTS files_year_insert BEFORE INSERT ON files FOR EACH ROW SET NEW.photo_year = COALESCE((SELECT photos.photo_year FROM photos WHERE photos.id = NEW.photo_id), -1);", "CREATE TRIGGER IF NOT EXISTS files_year_update BEFORE UPDATE ON files FOR EACH ROW SET NEW.photo_year = IF(NEW.photo_id = OLD.photo_id, NEW.photo_year, COALESCE((SELECT photos.photo_year FROM photos WHERE photos.id = NEW.photo_id), -1));", "CREATE TRIGGER IF NOT EXISTS photos_files_year AFTER UPDATE ON photos FOR EACH ROW UPDATE files SET photo_year = NEW.photo_year WHERE photo_id = NEW.id AND photo_year <> NEW.photo_year;", "ALTER TABLE files DROP PRIMARY KEY, ADD PRIMARY KEY (id, photo_year);", "DROP INDEX IF EXISTS uix_files_file_uid ON files;", "CREATE UNIQUE INDEX uix_files_file_uid ON files (file_uid, photo_year);", "DROP INDEX IF EXISTS idx_files_name_root ON files;", "CREATE UNIQUE INDEX idx_files_name_root ON files (file_name, file_root, photo_year);", "ALTER TABLE files PARTITION BY RANGE (photo_year) (PARTITION p_unknown VALUES LESS THAN (1900), PARTITION p1900 VALUES LESS THAN (2000), PARTITION p2000 VALUES LESS THAN (2010), PARTITION p2010 VALUES LESS THAN (2012), PARTITION p2012 VALUES LESS THAN (2014), PARTITION p2014 VALUES LESS THAN (2016), PARTITION p2016 VALUES LESS THAN (2017), PARTITION p2017 VALUES LESS THAN (2018), PARTITION p2018 VALUES LESS THAN (2019), PARTITION p2019 VALUES LESS THAN (2020), PARTITION p2020 VALUES LESS THAN (2021), PARTITION p2021 VALUES LESS THAN (2022), PARTITION p2022 VALUES LESS THAN (2023), PARTITION p2023 VALUES LESS THAN (2024), PARTITION p2024 VALUES LESS THAN (2025), PARTITION p_future VALUES LESS THAN MAXVALUE);"},
+		Down:       []string{"ALTER TABLE files REMOVE PARTITIONING;", "DROP INDEX IF EXISTS idx_files_name_root ON files;", "CREATE UNIQUE INDEX idx_files_name_root ON files (file_name, file_root);", "DROP INDEX IF EXISTS uix_files_file_uid ON files;", "CREATE UNIQUE INDEX uix_files_file_uid ON files (file_uid);", "ALTER TABLE files DROP PRIMARY KEY, ADD PRIMARY KEY (id);", "DROP TRIGGER IF EXISTS photos_files_year;", "DROP TRIGGER IF EXISTS files_year_update;", "DROP TRIGGER IF EXISTS files_year_insert;", "ALTER TABLE files DROP COLUMN IF EXISTS photo_year;", "DROP TRIGGER IF EXISTS files_keys_delete;", "DROP TRIGGER IF EXISTS files_keys_update;", "DROP TRIGGER IF EXISTS files_keys_insert;", "DROP TABLE IF EXISTS files_keys;"},
+	},
+	{
+		ID:         "20220420-120000",
+		Dialect:    "mysql",
+		Statements: []string{"DROP INDEX IF EXISTS uix_subjects_subj_name ON subjects;"},
+		Down:       []string{"CREATE UNIQUE INDEX IF NOT EXISTS uix_subjects_subj_name ON subjects (subj_name);", "DROP INDEX IF EXISTS idx_subjects_name_type ON subjects;"},
 	},
 }
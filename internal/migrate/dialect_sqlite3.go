@@ -6,10 +6,24 @@ var DialectSQLite3 = Migrations{
 		ID:         "20211121-094727",
 		Dialect:    "sqlite3",
 		Statements: []string{"DROP INDEX IF EXISTS idx_places_place_label;"},
+		Down:       []string{"CREATE INDEX IF NOT EXISTS idx_places_place_label ON places (place_label);"},
 	},
 	{
 		ID:         "20211124-120008",
 		Dialect:    "sqlite3",
 		Statements: []string{"DROP INDEX IF EXISTS uix_places_place_label;", "DROP INDEX IF EXISTS uix_places_label;"},
+		Down:       []string{"CREATE UNIQUE INDEX IF NOT EXISTS uix_places_place_label ON places (place_label);", "CREATE UNIQUE INDEX IF NOT EXISTS uix_places_label ON places (place_label);"},
+	},
+	{
+		ID:         "20220222-101500",
+		Dialect:    "sqlite3",
+		Statements: []string{"DROP INDEX IF EXISTS idx_photos_ymd;", "CREATE INDEX IF NOT EXISTS idx_photos_ymd ON photos (photo_year, photo_month, photo_day);", "CREATE INDEX IF NOT EXISTS idx_photos_deleted_taken ON photos (deleted_at, taken_at, photo_uid);", "CREATE INDEX IF NOT EXISTS idx_files_photo_missing_primary ON files (photo_id, file_missing, file_primary);", "CREATE INDEX IF NOT EXISTS idx_photos_labels_label_uncertainty ON photos_labels (label_id, uncertainty, photo_id);", "CREATE INDEX IF NOT EXISTS idx_photos_albums_album_hidden ON photos_albums (album_uid, hidden, photo_uid);", "CREATE INDEX IF NOT EXISTS idx_markers_subj_invalid_file ON markers (subj_uid, marker_invalid, file_uid);"},
+		Down:       []string{"DROP INDEX IF EXISTS idx_markers_subj_invalid_file;", "DROP INDEX IF EXISTS idx_photos_albums_album_hidden;", "DROP INDEX IF EXISTS idx_photos_labels_label_uncertainty;", "DROP INDEX IF EXISTS idx_files_photo_missing_primary;", "DROP INDEX IF EXISTS idx_photos_deleted_taken;"},
+	},
+	{
+		ID:         "20220420-120000",
+		Dialect:    "sqlite3",
+		Statements: []string{"DROP INDEX IF EXISTS uix_subjects_subj_name;"},
+		Down:       []string{"CREATE UNIQUE INDEX IF NOT EXISTS uix_subjects_subj_name ON subjects (subj_name);", "DROP INDEX IF EXISTS idx_subjects_name_type;"},
 	},
 }
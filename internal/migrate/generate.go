@@ -24,10 +24,14 @@ func gen_migrations(name string) {
 		ID         string
 		Dialect    string
 		Statements []string
+		Down       []string
 	}
 
 	var migrations []Migration
 
+	// Down migrations by ID, stored in files ending with ".down.sql".
+	down := make(map[string][]string)
+
 	// Folder in which migration files are stored.
 	folder := "./" + dialect
 
@@ -65,6 +69,11 @@ func gen_migrations(name string) {
 		} else if s, err := os.ReadFile(filePath); err == nil && len(s) > 0 {
 			fmt.Printf(".")
 
+			if strings.HasSuffix(file.Name(), ".down.sql") {
+				down[id] = strToStmts(s)
+				continue
+			}
+
 			migrations = append(migrations, Migration{ID: id, Dialect: dialect, Statements: strToStmts(s)})
 		} else {
 			fmt.Printf("f")
@@ -72,6 +81,10 @@ func gen_migrations(name string) {
 		}
 	}
 
+	for i := range migrations {
+		migrations[i].Down = down[migrations[i].ID]
+	}
+
 	fmt.Printf(" found %d migrations\n", len(migrations))
 
 	// Create source file from migrations.
@@ -107,6 +120,9 @@ var Dialect{{ print .Name }} = Migrations{
 		ID:        {{ printf "%q" .ID }},
 		Dialect:   {{ printf "%q" .Dialect }},
 		Statements: []string{ {{ range $index, $s := .Statements}}{{if $index}},{{end}}{{ printf "%q" $s }}{{end}} },
+		{{- if .Down }}
+		Down: []string{ {{ range $index, $s := .Down}}{{if $index}},{{end}}{{ printf "%q" $s }}{{end}} },
+		{{- end }}
 	},	
 {{- end }}
 }`))
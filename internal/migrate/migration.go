@@ -1,6 +1,7 @@
 package migrate
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -14,6 +15,7 @@ type Migration struct {
 	Error      string     `gorm:"size:255;" json:"Error" yaml:"Error,omitempty"`
 	Source     string     `gorm:"size:16;" json:"Source" yaml:"Source,omitempty"`
 	Statements []string   `gorm:"-" json:"Statements" yaml:"Statements,omitempty"`
+	Down       []string   `gorm:"-" json:"Down,omitempty" yaml:"Down,omitempty"`
 	StartedAt  time.Time  `json:"StartedAt" yaml:"StartedAt,omitempty"`
 	FinishedAt *time.Time `json:"FinishedAt" yaml:"FinishedAt,omitempty"`
 }
@@ -39,9 +41,56 @@ func (m *Migration) Finish(db *gorm.DB) error {
 	return db.Model(m).Updates(Values{"FinishedAt": time.Now().UTC()}).Error
 }
 
+// Pending tests if the migration has not been executed yet.
+func (m *Migration) Pending() bool {
+	return m.StartedAt.IsZero()
+}
+
+// Auto tests if the migration records schema changes made by AutoMigrate.
+func (m *Migration) Auto() bool {
+	return m.Source == SourceAuto
+}
+
+// Reversible tests if the migration can be rolled back.
+func (m *Migration) Reversible() bool {
+	return len(m.Down) > 0
+}
+
+// Status returns a human-readable migration status.
+func (m *Migration) Status() string {
+	switch {
+	case m.Pending():
+		return "pending"
+	case m.Error != "":
+		return "failed"
+	case m.FinishedAt == nil:
+		return "running"
+	default:
+		return "ok"
+	}
+}
+
 // Execute runs the migration.
 func (m *Migration) Execute(db *gorm.DB) error {
-	for _, s := range m.Statements {
+	return m.exec(db, m.Statements)
+}
+
+// Rollback reverts the migration and removes it from the list of executed migrations.
+func (m *Migration) Rollback(db *gorm.DB) error {
+	if !m.Reversible() {
+		return fmt.Errorf("migrate: %s cannot be rolled back", m.ID)
+	}
+
+	if err := m.exec(db, m.Down); err != nil {
+		return err
+	}
+
+	return db.Delete(Migration{}, "id = ?", m.ID).Error
+}
+
+// exec executes the statements provided.
+func (m *Migration) exec(db *gorm.DB, statements []string) error {
+	for _, s := range statements {
 		if err := db.Exec(s).Error; err != nil {
 			if strings.HasPrefix(s, "DROP ") && strings.Contains(err.Error(), "DROP") {
 				log.Tracef("migrate: %s (drop statement)", err)
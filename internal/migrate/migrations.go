@@ -2,6 +2,9 @@ package migrate
 
 import (
 	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
@@ -57,6 +60,10 @@ func (m *Migrations) Start(db *gorm.DB, runFailed bool) {
 		log.Debugf("migrate: found %s", english.Plural(len(executed), "previous migration", "previous migrations"))
 	}
 
+	if unknown := m.Unknown(executed); len(unknown) > 0 {
+		log.Warnf("migrate: found %s from a newer version (%s)", english.Plural(len(unknown), "migration", "migrations"), strings.Join(unknown, ", "))
+	}
+
 	for _, migration := range *m {
 		start := time.Now()
 		migration.StartedAt = start.UTC().Round(time.Second)
@@ -85,3 +92,103 @@ func (m *Migrations) Start(db *gorm.DB, runFailed bool) {
 		}
 	}
 }
+
+// Status returns all migrations along with their status, including executed migrations that are unknown
+// to this version, e.g. because they have been added by a newer version.
+func (m Migrations) Status(db *gorm.DB) (result Migrations) {
+	executed := Existing(db)
+
+	for _, migration := range m {
+		if done, ok := executed[migration.ID]; ok {
+			migration.Error = done.Error
+			migration.StartedAt = done.StartedAt
+			migration.FinishedAt = done.FinishedAt
+			delete(executed, migration.ID)
+		}
+
+		result = append(result, migration)
+	}
+
+	for _, done := range executed {
+		result = append(result, done)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+// Unknown returns the IDs of executed migrations that are unknown to this version.
+func (m Migrations) Unknown(executed MigrationMap) (ids []string) {
+	known := make(map[string]bool, len(m))
+
+	for _, migration := range m {
+		known[migration.ID] = true
+	}
+
+	for id, done := range executed {
+		if !known[id] && !done.Auto() {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// AutoSince returns the IDs of schema changes made by AutoMigrate at or after the time provided.
+func (m MigrationMap) AutoSince(t time.Time) (ids []string) {
+	for id, done := range m {
+		if done.Auto() && !done.StartedAt.Before(t) {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// Rollback reverts the executed migration with the given ID, or the most recent one if the ID is empty.
+//
+// Rollbacks are refused if AutoMigrate has changed the schema since the migration was executed, e.g.
+// after upgrading to a version with new tables or columns, as these changes are not versioned and
+// the schema would then not match the version it is rolled back to.
+func (m Migrations) Rollback(db *gorm.DB, id string) (Migration, error) {
+	executed := Existing(db)
+
+	if unknown := m.Unknown(executed); len(unknown) > 0 {
+		return Migration{}, fmt.Errorf("migrate: %s must be rolled back with the version that added it", unknown[len(unknown)-1])
+	}
+
+	for i := len(m) - 1; i >= 0; i-- {
+		migration := m[i]
+
+		done, ok := executed[migration.ID]
+
+		if !ok || id != "" && migration.ID != id {
+			continue
+		}
+
+		if auto := executed.AutoSince(done.StartedAt); len(auto) > 0 {
+			return migration, fmt.Errorf("migrate: %s cannot be rolled back, as the schema has since been changed by AutoMigrate (%s)", migration.ID, strings.Join(auto, ", "))
+		}
+
+		if err := migration.Rollback(db); err != nil {
+			return migration, err
+		}
+
+		log.Infof("migrate: %s rolled back", migration.ID)
+
+		return migration, nil
+	}
+
+	if id == "" {
+		return Migration{}, fmt.Errorf("migrate: found no executed migrations")
+	}
+
+	return Migration{}, fmt.Errorf("migrate: %s has not been executed", id)
+}
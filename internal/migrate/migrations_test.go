@@ -0,0 +1,143 @@
+package migrate
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDb(t *testing.T) *gorm.DB {
+	dbFile := "migrations_test.db"
+
+	_ = os.Remove(dbFile)
+
+	db, err := gorm.Open(SQLite3, dbFile)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = db.Close()
+		_ = os.Remove(dbFile)
+	})
+
+	if err := db.AutoMigrate(&Migration{}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestMigrations_Rollback(t *testing.T) {
+	db := testDb(t)
+
+	migrations := Migrations{
+		{
+			ID:         "20220101-000000",
+			Dialect:    SQLite3,
+			Statements: []string{"CREATE TABLE test_foo (id INTEGER);"},
+			Down:       []string{"DROP TABLE test_foo;"},
+		},
+		{
+			ID:         "20220102-000000",
+			Dialect:    SQLite3,
+			Statements: []string{"CREATE TABLE test_bar (id INTEGER);"},
+		},
+	}
+
+	migrations.Start(db, false)
+
+	t.Run("Status", func(t *testing.T) {
+		status := migrations.Status(db)
+
+		assert.Len(t, status, 2)
+		assert.Equal(t, "ok", status[0].Status())
+		assert.True(t, status[0].Reversible())
+		assert.Equal(t, "ok", status[1].Status())
+		assert.False(t, status[1].Reversible())
+	})
+	t.Run("NotReversible", func(t *testing.T) {
+		_, err := migrations.Rollback(db, "")
+
+		assert.Error(t, err)
+		assert.True(t, db.HasTable("test_bar"))
+	})
+	t.Run("NotExecuted", func(t *testing.T) {
+		_, err := migrations.Rollback(db, "20220103-000000")
+
+		assert.Error(t, err)
+	})
+	t.Run("Success", func(t *testing.T) {
+		m, err := migrations.Rollback(db, "20220101-000000")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "20220101-000000", m.ID)
+		assert.False(t, db.HasTable("test_foo"))
+		assert.Equal(t, "pending", migrations.Status(db)[0].Status())
+	})
+	t.Run("Newer", func(t *testing.T) {
+		older := migrations[:1]
+
+		assert.Equal(t, []string{"20220102-000000"}, older.Unknown(Existing(db)))
+		assert.Len(t, older.Status(db), 2)
+
+		_, err := older.Rollback(db, "")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestMigrations_Rollback_Auto(t *testing.T) {
+	db := testDb(t)
+
+	migrations := Migrations{
+		{
+			ID:         "20220101-000000",
+			Dialect:    SQLite3,
+			Statements: []string{"CREATE TABLE test_foo (id INTEGER);"},
+			Down:       []string{"DROP TABLE test_foo;"},
+		},
+	}
+
+	past := time.Now().UTC().Add(-time.Hour).Round(time.Second)
+
+	if err := db.Create(&Migration{ID: "auto-0000000000a", Dialect: SQLite3, Source: SourceAuto, StartedAt: past, FinishedAt: &past}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	migrations.Start(db, false)
+
+	t.Run("Unknown", func(t *testing.T) {
+		assert.Empty(t, migrations.Unknown(Existing(db)))
+	})
+	t.Run("Changed", func(t *testing.T) {
+		assert.NoError(t, Schema(db, "0123456789abcdef"))
+		assert.NoError(t, Schema(db, "0123456789abcdef"))
+
+		executed := Existing(db)
+		auto := executed["auto-0123456789a"]
+
+		assert.True(t, auto.Auto())
+		assert.Len(t, executed, 3)
+
+		_, err := migrations.Rollback(db, "")
+
+		assert.EqualError(t, err, "migrate: 20220101-000000 cannot be rolled back, as the schema has since been changed by AutoMigrate (auto-0123456789a)")
+		assert.True(t, db.HasTable("test_foo"))
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		if err := db.Delete(Migration{}, "id = ?", "auto-0123456789a").Error; err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := migrations.Rollback(db, "")
+
+		assert.NoError(t, err)
+		assert.False(t, db.HasTable("test_foo"))
+	})
+}
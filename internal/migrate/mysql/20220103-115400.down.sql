@@ -0,0 +1,2 @@
+ALTER TABLE files MODIFY file_projection VARBINARY(16) NULL;
+ALTER TABLE files MODIFY file_color_profile VARBINARY(16) NULL;
@@ -0,0 +1 @@
+DROP INDEX idx_albums_album_filter ON albums;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_markers_subj_invalid_file ON markers;
+DROP INDEX IF EXISTS idx_photos_albums_album_hidden ON photos_albums;
+DROP INDEX IF EXISTS idx_photos_labels_label_uncertainty ON photos_labels;
+DROP INDEX IF EXISTS idx_files_photo_missing_primary ON files;
+DROP INDEX IF EXISTS idx_photos_deleted_taken ON photos;
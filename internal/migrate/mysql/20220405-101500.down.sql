@@ -0,0 +1,8 @@
+ALTER TABLE photos REMOVE PARTITIONING;
+DROP INDEX IF EXISTS uix_photos_photo_uid ON photos;
+CREATE UNIQUE INDEX uix_photos_photo_uid ON photos (photo_uid);
+ALTER TABLE photos DROP PRIMARY KEY, ADD PRIMARY KEY (id);
+DROP TRIGGER IF EXISTS photos_keys_delete;
+DROP TRIGGER IF EXISTS photos_keys_update;
+DROP TRIGGER IF EXISTS photos_keys_insert;
+DROP TABLE IF EXISTS photos_keys;
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS photos_keys;
+CREATE TABLE photos_keys (id INT UNSIGNED NOT NULL PRIMARY KEY, photo_uid VARBINARY(42) NOT NULL, UNIQUE KEY uix_photos_keys_photo_uid (photo_uid));
+INSERT IGNORE INTO photos_keys (id, photo_uid) SELECT id, photo_uid FROM photos;
+CREATE TRIGGER IF NOT EXISTS photos_keys_insert AFTER INSERT ON photos FOR EACH ROW INSERT INTO photos_keys (id, photo_uid) VALUES (NEW.id, NEW.photo_uid);
+CREATE TRIGGER IF NOT EXISTS photos_keys_update AFTER UPDATE ON photos FOR EACH ROW UPDATE photos_keys SET id = NEW.id, photo_uid = NEW.photo_uid WHERE id = OLD.id;
+CREATE TRIGGER IF NOT EXISTS photos_keys_delete AFTER DELETE ON photos FOR EACH ROW DELETE FROM photos_keys WHERE id = OLD.id;
+UPDATE photos SET photo_year = -1 WHERE photo_year IS NULL;
+ALTER TABLE photos MODIFY photo_year INT NOT NULL DEFAULT -1;
+ALTER TABLE photos DROP PRIMARY KEY, ADD PRIMARY KEY (id, photo_year);
+DROP INDEX IF EXISTS uix_photos_photo_uid ON photos;
+CREATE UNIQUE INDEX uix_photos_photo_uid ON photos (photo_uid, photo_year);
+ALTER TABLE photos PARTITION BY RANGE (photo_year) (PARTITION p_unknown VALUES LESS THAN (1900), PARTITION p1900 VALUES LESS THAN (2000), PARTITION p2000 VALUES LESS THAN (2010), PARTITION p2010 VALUES LESS THAN (2012), PARTITION p2012 VALUES LESS THAN (2014), PARTITION p2014 VALUES LESS THAN (2016), PARTITION p2016 VALUES LESS THAN (2017), PARTITION p2017 VALUES LESS THAN (2018), PARTITION p2018 VALUES LESS THAN (2019), PARTITION p2019 VALUES LESS THAN (2020), PARTITION p2020 VALUES LESS THAN (2021), PARTITION p2021 VALUES LESS THAN (2022), PARTITION p2022 VALUES LESS THAN (2023), PARTITION p2023 VALUES LESS THAN (2024), PARTITION p2024 VALUES LESS THAN (2025), PARTITION p_future VALUES LESS THAN MAXVALUE);
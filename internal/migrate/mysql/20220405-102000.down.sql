@@ -0,0 +1,14 @@
+ALTER TABLE files REMOVE PARTITIONING;
+DROP INDEX IF EXISTS idx_files_name_root ON files;
+CREATE UNIQUE INDEX idx_files_name_root ON files (file_name, file_root);
+DROP INDEX IF EXISTS uix_files_file_uid ON files;
+CREATE UNIQUE INDEX uix_files_file_uid ON files (file_uid);
+ALTER TABLE files DROP PRIMARY KEY, ADD PRIMARY KEY (id);
+DROP TRIGGER IF EXISTS photos_files_year;
+DROP TRIGGER IF EXISTS files_year_update;
+DROP TRIGGER IF EXISTS files_year_insert;
+ALTER TABLE files DROP COLUMN IF EXISTS photo_year;
+DROP TRIGGER IF EXISTS files_keys_delete;
+DROP TRIGGER IF EXISTS files_keys_update;
+DROP TRIGGER IF EXISTS files_keys_insert;
+DROP TABLE IF EXISTS files_keys;
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS files_keys;
+CREATE TABLE files_keys (id INT UNSIGNED NOT NULL PRIMARY KEY, file_uid VARBINARY(42) NOT NULL, file_name VARBINARY(755) NOT NULL, file_root VARBINARY(16) NOT NULL DEFAULT '/', UNIQUE KEY uix_files_keys_file_uid (file_uid), UNIQUE KEY uix_files_keys_name_root (file_name, file_root));
+INSERT IGNORE INTO files_keys (id, file_uid, file_name, file_root) SELECT id, file_uid, file_name, file_root FROM files;
+CREATE TRIGGER IF NOT EXISTS files_keys_insert AFTER INSERT ON files FOR EACH ROW INSERT INTO files_keys (id, file_uid, file_name, file_root) VALUES (NEW.id, NEW.file_uid, NEW.file_name, NEW.file_root);
+CREATE TRIGGER IF NOT EXISTS files_keys_update AFTER UPDATE ON files FOR EACH ROW UPDATE files_keys SET id = NEW.id, file_uid = NEW.file_uid, file_name = NEW.file_name, file_root = NEW.file_root WHERE id = OLD.id;
+CREATE TRIGGER IF NOT EXISTS files_keys_delete AFTER DELETE ON files FOR EACH ROW DELETE FROM files_keys WHERE id = OLD.id;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS photo_year INT NOT NULL DEFAULT -1;
+UPDATE files JOIN photos ON photos.id = files.photo_id SET files.photo_year = photos.photo_year;
+CREATE TRIGGER IF NOT EXISTS files_year_insert BEFORE INSERT ON files FOR EACH ROW SET NEW.photo_year = COALESCE((SELECT photos.photo_year FROM photos WHERE photos.id = NEW.photo_id), -1);
+CREATE TRIGGER IF NOT EXISTS files_year_update BEFORE UPDATE ON files FOR EACH ROW SET NEW.photo_year = IF(NEW.photo_id = OLD.photo_id, NEW.photo_year, COALESCE((SELECT photos.photo_year FROM photos WHERE photos.id = NEW.photo_id), -1));
+CREATE TRIGGER IF NOT EXISTS photos_files_year AFTER UPDATE ON photos FOR EACH ROW UPDATE files SET photo_year = NEW.photo_year WHERE photo_id = NEW.id AND photo_year <> NEW.photo_year;
+ALTER TABLE files DROP PRIMARY KEY, ADD PRIMARY KEY (id, photo_year);
+DROP INDEX IF EXISTS uix_files_file_uid ON files;
+CREATE UNIQUE INDEX uix_files_file_uid ON files (file_uid, photo_year);
+DROP INDEX IF EXISTS idx_files_name_root ON files;
+CREATE UNIQUE INDEX idx_files_name_root ON files (file_name, file_root, photo_year);
+ALTER TABLE files PARTITION BY RANGE (photo_year) (PARTITION p_unknown VALUES LESS THAN (1900), PARTITION p1900 VALUES LESS THAN (2000), PARTITION p2000 VALUES LESS THAN (2010), PARTITION p2010 VALUES LESS THAN (2012), PARTITION p2012 VALUES LESS THAN (2014), PARTITION p2014 VALUES LESS THAN (2016), PARTITION p2016 VALUES LESS THAN (2017), PARTITION p2017 VALUES LESS THAN (2018), PARTITION p2018 VALUES LESS THAN (2019), PARTITION p2019 VALUES LESS THAN (2020), PARTITION p2020 VALUES LESS THAN (2021), PARTITION p2021 VALUES LESS THAN (2022), PARTITION p2022 VALUES LESS THAN (2023), PARTITION p2023 VALUES LESS THAN (2024), PARTITION p2024 VALUES LESS THAN (2025), PARTITION p_future VALUES LESS THAN MAXVALUE);
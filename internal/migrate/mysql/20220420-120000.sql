@@ -0,0 +1 @@
+DROP INDEX IF EXISTS uix_subjects_subj_name ON subjects;
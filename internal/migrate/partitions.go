@@ -0,0 +1,75 @@
+package migrate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// PartitionedTables lists the tables that are partitioned by photo year on MariaDB,
+// see migrations 20220405-101500 and 20220405-102000.
+var PartitionedTables = []string{"photos", "files"}
+
+// FuturePartition is the name of the partition that holds all rows with a year after the last yearly partition.
+const FuturePartition = "p_future"
+
+// Partitions adds the missing yearly partitions up to the given year to all partitioned tables by
+// splitting the future partition. It runs after each migration, so that recent years don't end up
+// in the future partition, and can be called again with the next year before it starts.
+func Partitions(db *gorm.DB, year int) error {
+	if db == nil {
+		return fmt.Errorf("migrate: database connection required")
+	} else if db.Dialect().GetName() != MySQL {
+		return nil
+	}
+
+	for _, table := range PartitionedTables {
+		var names []string
+
+		if err := db.Raw("SELECT partition_name FROM information_schema.partitions WHERE table_schema = DATABASE() AND table_name = ? AND partition_name IS NOT NULL", table).Pluck("partition_name", &names).Error; err != nil {
+			return fmt.Errorf("migrate: %s (find %s partitions)", err, table)
+		}
+
+		if stmt := partitionStmt(table, names, year); stmt == "" {
+			continue
+		} else if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("migrate: %s (add %s partitions)", err, table)
+		}
+
+		log.Infof("migrate: added %s partitions up to %d", table, year)
+	}
+
+	return nil
+}
+
+// partitionStmt returns the statement that adds the missing yearly partitions up to the given
+// year to a table with the partitions provided, or an empty string if there are none to add.
+func partitionStmt(table string, names []string, year int) string {
+	last := 0
+	future := false
+
+	for _, name := range names {
+		if name == FuturePartition {
+			future = true
+		} else if y, err := strconv.Atoi(strings.TrimPrefix(name, "p")); err == nil && y > last {
+			last = y
+		}
+	}
+
+	// Not partitioned or nothing to add?
+	if !future || last == 0 || last >= year {
+		return ""
+	}
+
+	parts := make([]string, 0, year-last+1)
+
+	for y := last + 1; y <= year; y++ {
+		parts = append(parts, fmt.Sprintf("PARTITION p%d VALUES LESS THAN (%d)", y, y+1))
+	}
+
+	parts = append(parts, fmt.Sprintf("PARTITION %s VALUES LESS THAN MAXVALUE", FuturePartition))
+
+	return fmt.Sprintf("ALTER TABLE %s REORGANIZE PARTITION %s INTO (%s);", table, FuturePartition, strings.Join(parts, ", "))
+}
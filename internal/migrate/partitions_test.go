@@ -0,0 +1,30 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitions(t *testing.T) {
+	t.Run("SQLite", func(t *testing.T) {
+		assert.NoError(t, Partitions(testDb(t), 2026))
+	})
+	t.Run("NoDatabase", func(t *testing.T) {
+		assert.Error(t, Partitions(nil, 2026))
+	})
+}
+
+func TestPartitionStmt(t *testing.T) {
+	names := []string{"p_unknown", "p1900", "p2000", "p2010", "p2022", "p2023", "p2024", "p_future"}
+
+	t.Run("Missing", func(t *testing.T) {
+		assert.Equal(t, "ALTER TABLE files REORGANIZE PARTITION p_future INTO (PARTITION p2025 VALUES LESS THAN (2026), PARTITION p2026 VALUES LESS THAN (2027), PARTITION p_future VALUES LESS THAN MAXVALUE);", partitionStmt("files", names, 2026))
+	})
+	t.Run("Complete", func(t *testing.T) {
+		assert.Equal(t, "", partitionStmt("photos", names, 2024))
+	})
+	t.Run("NotPartitioned", func(t *testing.T) {
+		assert.Equal(t, "", partitionStmt("photos", nil, 2026))
+	})
+}
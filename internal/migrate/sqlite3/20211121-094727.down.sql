@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_places_place_label ON places (place_label);
@@ -0,0 +1,2 @@
+CREATE UNIQUE INDEX IF NOT EXISTS uix_places_place_label ON places (place_label);
+CREATE UNIQUE INDEX IF NOT EXISTS uix_places_label ON places (place_label);
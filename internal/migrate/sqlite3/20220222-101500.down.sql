@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_markers_subj_invalid_file;
+DROP INDEX IF EXISTS idx_photos_albums_album_hidden;
+DROP INDEX IF EXISTS idx_photos_labels_label_uncertainty;
+DROP INDEX IF EXISTS idx_files_photo_missing_primary;
+DROP INDEX IF EXISTS idx_photos_deleted_taken;
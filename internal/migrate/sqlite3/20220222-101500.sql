@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_photos_ymd;
+CREATE INDEX IF NOT EXISTS idx_photos_ymd ON photos (photo_year, photo_month, photo_day);
+CREATE INDEX IF NOT EXISTS idx_photos_deleted_taken ON photos (deleted_at, taken_at, photo_uid);
+CREATE INDEX IF NOT EXISTS idx_files_photo_missing_primary ON files (photo_id, file_missing, file_primary);
+CREATE INDEX IF NOT EXISTS idx_photos_labels_label_uncertainty ON photos_labels (label_id, uncertainty, photo_id);
+CREATE INDEX IF NOT EXISTS idx_photos_albums_album_hidden ON photos_albums (album_uid, hidden, photo_uid);
+CREATE INDEX IF NOT EXISTS idx_markers_subj_invalid_file ON markers (subj_uid, marker_invalid, file_uid);
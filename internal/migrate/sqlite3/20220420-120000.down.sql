@@ -0,0 +1,2 @@
+CREATE UNIQUE INDEX IF NOT EXISTS uix_subjects_subj_name ON subjects (subj_name);
+DROP INDEX IF EXISTS idx_subjects_name_type;
@@ -26,6 +26,10 @@ func (b *Busy) Start() error {
 		return errors.New("still running")
 	}
 
+	if Maintenance.Enabled() {
+		return errors.New("maintenance mode")
+	}
+
 	if b.busy {
 		return errors.New("already running")
 	}
@@ -53,7 +57,16 @@ func (b *Busy) Cancel() {
 	}
 }
 
+// Canceled tests if the worker should stop. Busy workers are paused here while maintenance mode is enabled.
 func (b *Busy) Canceled() bool {
+	if b.Busy() {
+		Maintenance.Wait(b.canceledFlag)
+	}
+
+	return b.canceledFlag()
+}
+
+func (b *Busy) canceledFlag() bool {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
@@ -0,0 +1,112 @@
+package mutex
+
+import (
+	"sync"
+	"time"
+)
+
+// PauseInterval is the time between checks whether paused workers can resume.
+var PauseInterval = time.Second
+
+// Maintenance pauses busy workers at safe checkpoints while enabled, e.g. before filesystem snapshots and backups.
+var Maintenance = Pause{}
+
+type Pause struct {
+	enabled bool
+	paused  int
+	jobs    int
+	mutex   sync.Mutex
+}
+
+// Enable turns on maintenance mode.
+func (p *Pause) Enable() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.enabled = true
+}
+
+// Disable turns off maintenance mode so that paused workers resume.
+func (p *Pause) Disable() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.enabled = false
+}
+
+// Enabled tests if maintenance mode is enabled.
+func (p *Pause) Enabled() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.enabled
+}
+
+// Paused returns the number of workers waiting at a checkpoint.
+func (p *Pause) Paused() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.paused
+}
+
+// Jobs returns the number of jobs that workers are still processing.
+func (p *Pause) Jobs() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.jobs
+}
+
+// Begin registers a job processed by a worker goroutine, e.g. indexing a file, that must be
+// completed before the instance is idle.
+func (p *Pause) Begin() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.jobs++
+}
+
+// End marks a job registered with Begin as completed.
+func (p *Pause) End() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.jobs > 0 {
+		p.jobs--
+	}
+}
+
+// Idle tests if maintenance mode is enabled, all busy workers have been paused,
+// and no jobs are being processed anymore.
+func (p *Pause) Idle() bool {
+	busy := BusyWorkers()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.enabled && p.paused >= busy && p.jobs == 0
+}
+
+// Wait blocks while maintenance mode is enabled, unless the worker gets canceled.
+func (p *Pause) Wait(canceled func() bool) {
+	p.mutex.Lock()
+
+	if !p.enabled {
+		p.mutex.Unlock()
+		return
+	}
+
+	p.paused++
+	p.mutex.Unlock()
+
+	defer func() {
+		p.mutex.Lock()
+		p.paused--
+		p.mutex.Unlock()
+	}()
+
+	for p.Enabled() && !canceled() {
+		time.Sleep(PauseInterval)
+	}
+}
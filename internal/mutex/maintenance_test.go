@@ -0,0 +1,100 @@
+package mutex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPause(t *testing.T) {
+	interval := PauseInterval
+	PauseInterval = time.Millisecond
+	defer func() { PauseInterval = interval }()
+
+	t.Run("Disabled", func(t *testing.T) {
+		p := Pause{}
+
+		assert.False(t, p.Enabled())
+		assert.False(t, p.Idle())
+
+		p.Wait(func() bool { return false })
+		assert.Equal(t, 0, p.Paused())
+	})
+	t.Run("Resume", func(t *testing.T) {
+		p := Pause{}
+		p.Enable()
+
+		done := make(chan bool)
+
+		go func() {
+			p.Wait(func() bool { return false })
+			done <- true
+		}()
+
+		assert.Eventually(t, func() bool { return p.Paused() == 1 }, time.Second, time.Millisecond)
+
+		p.Disable()
+
+		<-done
+
+		assert.Equal(t, 0, p.Paused())
+	})
+	t.Run("Jobs", func(t *testing.T) {
+		p := Pause{}
+		p.Enable()
+
+		p.Begin()
+		assert.Equal(t, 1, p.Jobs())
+		assert.False(t, p.Idle())
+
+		p.End()
+		assert.Equal(t, 0, p.Jobs())
+		assert.True(t, p.Idle())
+
+		p.End()
+		assert.Equal(t, 0, p.Jobs())
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		p := Pause{}
+		p.Enable()
+		defer p.Disable()
+
+		p.Wait(func() bool { return true })
+		assert.Equal(t, 0, p.Paused())
+	})
+}
+
+func TestMaintenance(t *testing.T) {
+	interval := PauseInterval
+	PauseInterval = time.Millisecond
+	defer func() { PauseInterval = interval }()
+
+	b := &MainWorker
+
+	assert.NoError(t, b.Start())
+
+	Maintenance.Enable()
+
+	assert.Error(t, (&Busy{}).Start(), "maintenance mode")
+	assert.False(t, Maintenance.Idle())
+
+	done := make(chan bool)
+
+	go func() {
+		done <- b.Canceled()
+	}()
+
+	assert.Eventually(t, func() bool { return Maintenance.Paused() == 1 }, time.Second, time.Millisecond)
+
+	assert.True(t, Maintenance.Idle())
+
+	b.Cancel()
+
+	assert.True(t, <-done)
+
+	Maintenance.Disable()
+	b.Stop()
+
+	assert.False(t, Maintenance.Enabled())
+}
@@ -5,17 +5,38 @@ import (
 )
 
 var (
-	Db          = sync.Mutex{}
-	Index       = sync.Mutex{}
-	People      = Busy{}
-	MainWorker  = Busy{}
-	SyncWorker  = Busy{}
-	ShareWorker = Busy{}
-	MetaWorker  = Busy{}
-	FacesWorker = Busy{}
+	Db              = sync.Mutex{}
+	Index           = sync.Mutex{}
+	People          = Busy{}
+	MainWorker      = Busy{}
+	SyncWorker      = Busy{}
+	ShareWorker     = Busy{}
+	MetaWorker      = Busy{}
+	FacesWorker     = Busy{}
+	CaptionsWorker  = Busy{}
+	SearchesWorker  = Busy{}
+	DigestWorker    = Busy{}
+	GCWorker        = Busy{}
+	DownloadWorker  = Busy{}
+	SlideshowWorker = Busy{}
+	TimelapseWorker = Busy{}
 )
 
+// Workers lists the background workers that can be paused in maintenance mode.
+var Workers = []*Busy{&MainWorker, &SyncWorker, &ShareWorker, &MetaWorker, &FacesWorker, &CaptionsWorker, &SearchesWorker, &DigestWorker, &GCWorker, &DownloadWorker, &SlideshowWorker, &TimelapseWorker}
+
+// BusyWorkers returns the number of busy workers.
+func BusyWorkers() (n int) {
+	for _, w := range Workers {
+		if w.Busy() {
+			n++
+		}
+	}
+
+	return n
+}
+
 // WorkersBusy returns true if any worker is busy.
 func WorkersBusy() bool {
-	return MainWorker.Busy() || SyncWorker.Busy() || ShareWorker.Busy() || MetaWorker.Busy() || FacesWorker.Busy()
+	return MainWorker.Busy() || SyncWorker.Busy() || ShareWorker.Busy() || MetaWorker.Busy() || FacesWorker.Busy() || CaptionsWorker.Busy() || SearchesWorker.Busy() || DigestWorker.Busy() || GCWorker.Busy() || DownloadWorker.Busy() || SlideshowWorker.Busy() || TimelapseWorker.Busy()
 }
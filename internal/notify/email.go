@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Email sends notifications via SMTP.
+type Email struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	From     string
+	To       []string
+}
+
+// Name returns the channel name.
+func (c Email) Name() string {
+	return "email"
+}
+
+// Send sends the message as plain text email, with an alternative HTML part if the message has one.
+func (c Email) Send(msg Message) error {
+	if c.Host == "" {
+		return errors.New("smtp host missing")
+	} else if len(c.To) == 0 {
+		return errors.New("no recipients")
+	}
+
+	from := c.From
+
+	if from == "" {
+		from = c.User
+	}
+
+	port := c.Port
+
+	if port <= 0 {
+		port = 587
+	}
+
+	addr := net.JoinHostPort(c.Host, strconv.Itoa(port))
+
+	var auth smtp.Auth
+
+	if c.User != "" {
+		auth = smtp.PlainAuth("", c.User, c.Password, c.Host)
+	}
+
+	return smtp.SendMail(addr, auth, from, c.To, c.message(from, msg))
+}
+
+// message returns the email headers and body.
+func (c Email) message(from string, msg Message) []byte {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("From: %s\r\n", from))
+	b.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(c.To, ", ")))
+	b.WriteString(fmt.Sprintf("Subject: %s\r\n", strings.NewReplacer("\r", "", "\n", " ").Replace(msg.Title)))
+	b.WriteString(fmt.Sprintf("Date: %s\r\n", time.Now().Format(time.RFC1123Z)))
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	if msg.HTML == "" {
+		b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+		b.WriteString("\r\n")
+		b.WriteString(crlf(msg.Text))
+		b.WriteString("\r\n")
+
+		return []byte(b.String())
+	}
+
+	boundary := fmt.Sprintf("photoprism-%x", time.Now().UnixNano())
+
+	b.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=\"%s\"\r\n", boundary))
+	b.WriteString("\r\n")
+	b.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(crlf(msg.Text))
+	b.WriteString("\r\n")
+	b.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+	b.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(crlf(msg.HTML))
+	b.WriteString("\r\n")
+	b.WriteString(fmt.Sprintf("--%s--\r\n", boundary))
+
+	return []byte(b.String())
+}
+
+// crlf returns the text with CRLF line endings, as required by SMTP.
+func crlf(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\n", "\r\n")
+}
@@ -0,0 +1,36 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmail_Send(t *testing.T) {
+	t.Run("NoHost", func(t *testing.T) {
+		assert.Error(t, Email{To: []string{"jane@example.com"}}.Send(Message{Title: "Test"}))
+	})
+	t.Run("NoRecipients", func(t *testing.T) {
+		assert.Error(t, Email{Host: "localhost"}.Send(Message{Title: "Test"}))
+	})
+}
+
+func TestEmail_Message(t *testing.T) {
+	c := Email{To: []string{"jane@example.com", "john@example.com"}}
+	msg := string(c.message("photoprism@example.com", Message{Title: "Import\nfinished", Text: "Line 1\nLine 2"}))
+
+	assert.Contains(t, msg, "From: photoprism@example.com\r\n")
+	assert.Contains(t, msg, "To: jane@example.com, john@example.com\r\n")
+	assert.Contains(t, msg, "Subject: Import finished\r\n")
+	assert.Contains(t, msg, "\r\n\r\nLine 1\r\nLine 2\r\n")
+}
+
+func TestEmail_MessageHTML(t *testing.T) {
+	c := Email{To: []string{"jane@example.com"}}
+	msg := string(c.message("photoprism@example.com", Message{Title: "Summary", Text: "Plain\ntext", HTML: "<p>HTML</p>\n"}))
+
+	assert.Contains(t, msg, "Content-Type: multipart/alternative; boundary=\"photoprism-")
+	assert.Contains(t, msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\nPlain\r\ntext\r\n")
+	assert.Contains(t, msg, "Content-Type: text/html; charset=UTF-8\r\n\r\n<p>HTML</p>\r\n")
+	assert.NotContains(t, msg, "\r\r\n")
+}
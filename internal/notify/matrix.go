@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Matrix sends notifications to a Matrix room.
+type Matrix struct {
+	Server string
+	Token  string
+	RoomID string
+}
+
+// Name returns the channel name.
+func (c Matrix) Name() string {
+	return "matrix"
+}
+
+// Send sends the message as text event to the configured room.
+func (c Matrix) Send(msg Message) error {
+	if c.Server == "" {
+		return errors.New("homeserver missing")
+	} else if c.Token == "" {
+		return errors.New("access token missing")
+	} else if c.RoomID == "" {
+		return errors.New("room id missing")
+	}
+
+	body, err := json.Marshal(map[string]string{"msgtype": "m.text", "body": msg.String()})
+
+	if err != nil {
+		return err
+	}
+
+	// The transaction id makes sure the message is only sent once if the request is retried.
+	txnID := fmt.Sprintf("photoprism%d", time.Now().UnixNano())
+	endpoint := fmt.Sprintf("%s/_matrix/client/r0/rooms/%s/send/m.room.message/%s",
+		strings.TrimRight(c.Server, "/"), url.PathEscape(c.RoomID), txnID)
+
+	req, err := http.NewRequest(http.MethodPut, endpoint, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("matrix: unexpected response (%s)", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrix_Send(t *testing.T) {
+	var body map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.Header.Get("Authorization") != "Bearer secret" ||
+			!strings.HasPrefix(r.URL.EscapedPath(), "/_matrix/client/r0/rooms/%21room:example.com/send/m.room.message/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"event_id":"$1"}`))
+	}))
+
+	defer server.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		err := Matrix{Server: server.URL + "/", Token: "secret", RoomID: "!room:example.com"}.Send(Message{Title: "Disk almost full"})
+		assert.NoError(t, err)
+		assert.Equal(t, "m.text", body["msgtype"])
+		assert.Equal(t, "Disk almost full", body["body"])
+	})
+	t.Run("Forbidden", func(t *testing.T) {
+		err := Matrix{Server: server.URL, Token: "wrong", RoomID: "!room:example.com"}.Send(Message{Title: "Test"})
+		assert.Error(t, err)
+	})
+	t.Run("NoRoom", func(t *testing.T) {
+		assert.Error(t, Matrix{Server: server.URL, Token: "secret"}.Send(Message{Title: "Test"}))
+	})
+}
@@ -0,0 +1,95 @@
+/*
+
+Package notify sends notifications to email, Telegram, Matrix, and Web Push channels.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.app/developer-guide/
+
+*/
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// client is the HTTP client used by channels that send messages through a web API.
+var client = &http.Client{Timeout: 30 * time.Second}
+
+// Message represents a notification message.
+type Message struct {
+	Title string
+	Text  string
+	HTML  string
+	URL   string
+}
+
+// String returns the message as plain text.
+func (m Message) String() string {
+	if m.Title == "" {
+		return m.Text
+	} else if m.Text == "" {
+		return m.Title
+	}
+
+	return fmt.Sprintf("%s\n\n%s", m.Title, m.Text)
+}
+
+// Channel represents a notification channel.
+type Channel interface {
+	Name() string
+	Send(msg Message) error
+}
+
+// Channels represents a list of notification channels.
+type Channels []Channel
+
+// Send sends the message to all channels and returns an error if at least one failed.
+func (c Channels) Send(msg Message) error {
+	var failed []string
+
+	for _, ch := range c {
+		if err := ch.Send(msg); err != nil {
+			log.Errorf("notify: %s (%s)", err, ch.Name())
+			failed = append(failed, ch.Name())
+		} else {
+			log.Debugf("notify: sent %s via %s", msg.Title, ch.Name())
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.New("notify: failed sending via " + strings.Join(failed, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testChannel struct {
+	err  error
+	sent []Message
+}
+
+func (c *testChannel) Name() string {
+	return "test"
+}
+
+func (c *testChannel) Send(msg Message) error {
+	c.sent = append(c.sent, msg)
+	return c.err
+}
+
+func TestMessage_String(t *testing.T) {
+	assert.Equal(t, "Title\n\nText", Message{Title: "Title", Text: "Text"}.String())
+	assert.Equal(t, "Title", Message{Title: "Title"}.String())
+	assert.Equal(t, "Text", Message{Text: "Text"}.String())
+}
+
+func TestChannels_Send(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		ch := &testChannel{}
+		assert.NoError(t, Channels{ch}.Send(Message{Title: "Import finished"}))
+		assert.Len(t, ch.sent, 1)
+	})
+	t.Run("Failed", func(t *testing.T) {
+		ok := &testChannel{}
+		failed := &testChannel{err: errors.New("failed")}
+		assert.Error(t, Channels{failed, ok}.Send(Message{Title: "Import finished"}))
+		assert.Len(t, ok.sent, 1)
+	})
+}
@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// TelegramURL is the default Telegram Bot API endpoint.
+var TelegramURL = "https://api.telegram.org"
+
+// Telegram sends notifications via a Telegram bot.
+type Telegram struct {
+	Token  string
+	ChatID string
+}
+
+// Name returns the channel name.
+func (c Telegram) Name() string {
+	return "telegram"
+}
+
+// Send sends the message to the configured chat.
+func (c Telegram) Send(msg Message) error {
+	if c.Token == "" {
+		return errors.New("bot token missing")
+	} else if c.ChatID == "" {
+		return errors.New("chat id missing")
+	}
+
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", TelegramURL, c.Token)
+
+	resp, err := client.PostForm(endpoint, url.Values{"chat_id": {c.ChatID}, "text": {msg.String()}})
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	var result struct {
+		Ok          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("unexpected response (%s)", resp.Status)
+	} else if !result.Ok {
+		return fmt.Errorf("telegram: %s", result.Description)
+	}
+
+	return nil
+}
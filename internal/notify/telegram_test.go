@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTelegram_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bot123:abc/sendMessage" || r.FormValue("chat_id") != "42" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"ok":false,"description":"Bad Request: chat not found"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+
+	defer server.Close()
+
+	defaultURL := TelegramURL
+	TelegramURL = server.URL
+	defer func() { TelegramURL = defaultURL }()
+
+	t.Run("Success", func(t *testing.T) {
+		assert.NoError(t, Telegram{Token: "123:abc", ChatID: "42"}.Send(Message{Title: "Test"}))
+	})
+	t.Run("WrongChat", func(t *testing.T) {
+		err := Telegram{Token: "123:abc", ChatID: "1"}.Send(Message{Title: "Test"})
+		assert.EqualError(t, err, "telegram: Bad Request: chat not found")
+	})
+	t.Run("NoToken", func(t *testing.T) {
+		assert.Error(t, Telegram{ChatID: "42"}.Send(Message{Title: "Test"}))
+	})
+}
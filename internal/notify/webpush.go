@@ -0,0 +1,322 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/photoprism/photoprism/pkg/dial"
+)
+
+// PushTTL is the default number of seconds a push service keeps undelivered messages.
+const PushTTL = 24 * 3600
+
+// pushClient sends messages to push services, which must not be on a local network.
+var pushClient = dial.Client(30 * time.Second)
+
+// ErrPushGone is returned if a push subscription has expired or was revoked by the browser.
+var ErrPushGone = errors.New("push subscription has expired")
+
+// PushSubscription represents the endpoint and keys of a browser push subscription.
+type PushSubscription struct {
+	Endpoint string
+	P256dh   string
+	Auth     string
+}
+
+// WebPush sends notifications to browsers and installed web apps, see RFC 8030, 8291, and 8292.
+type WebPush struct {
+	Subscriptions []PushSubscription
+	PublicKey     string
+	PrivateKey    string
+	Subject       string
+	TTL           int
+	Gone          func(endpoint string)
+}
+
+// Name returns the channel name.
+func (c WebPush) Name() string {
+	return "webpush"
+}
+
+// Send sends the message to all subscriptions and returns an error if at least one failed.
+// Expired subscriptions are passed to the Gone callback, so that they can be removed.
+func (c WebPush) Send(msg Message) error {
+	if c.PublicKey == "" || c.PrivateKey == "" {
+		return errors.New("vapid keys missing")
+	}
+
+	payload, err := json.Marshal(map[string]string{"title": msg.Title, "body": msg.Text, "url": msg.URL})
+
+	if err != nil {
+		return err
+	}
+
+	var failed int
+
+	for _, sub := range c.Subscriptions {
+		if err := c.Push(sub, payload); err == nil {
+			continue
+		} else if errors.Is(err, ErrPushGone) {
+			log.Debugf("webpush: removing expired subscription")
+
+			if c.Gone != nil {
+				c.Gone(sub.Endpoint)
+			}
+		} else {
+			log.Debugf("webpush: %s", err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed sending %d of %d push messages", failed, len(c.Subscriptions))
+	}
+
+	return nil
+}
+
+// Push encrypts the payload and sends it to the push service of the subscription.
+func (c WebPush) Push(sub PushSubscription, payload []byte) error {
+	body, err := PushEncrypt(sub, payload)
+
+	if err != nil {
+		return err
+	}
+
+	auth, err := VapidAuthorization(sub.Endpoint, c.Subject, c.PublicKey, c.PrivateKey)
+
+	if err != nil {
+		return err
+	}
+
+	ttl := c.TTL
+
+	if ttl <= 0 {
+		ttl = PushTTL
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", auth)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(ttl))
+
+	resp, err := pushClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted:
+		return nil
+	case http.StatusNotFound, http.StatusGone:
+		return ErrPushGone
+	default:
+		return fmt.Errorf("unexpected response (%s)", resp.Status)
+	}
+}
+
+// GenerateVapidKeys returns a new P-256 key pair for identifying the server to push services,
+// encoded as unpadded base64url strings like expected by browsers.
+func GenerateVapidKeys() (publicKey, privateKey string, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	publicKey = base64.RawURLEncoding.EncodeToString(elliptic.Marshal(elliptic.P256(), key.X, key.Y))
+	privateKey = base64.RawURLEncoding.EncodeToString(key.D.FillBytes(make([]byte, 32)))
+
+	return publicKey, privateKey, nil
+}
+
+// vapidKey decodes a VAPID key pair.
+func vapidKey(publicKey, privateKey string) (*ecdsa.PrivateKey, error) {
+	pub, err := decodeBase64(publicKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid public key")
+	}
+
+	priv, err := decodeBase64(privateKey)
+
+	if err != nil || len(priv) != 32 {
+		return nil, fmt.Errorf("invalid vapid private key")
+	}
+
+	x, y := elliptic.Unmarshal(elliptic.P256(), pub)
+
+	if x == nil {
+		return nil, fmt.Errorf("invalid vapid public key")
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y},
+		D:         new(big.Int).SetBytes(priv),
+	}, nil
+}
+
+// VapidAuthorization returns the authorization header value for sending a message to the endpoint.
+func VapidAuthorization(endpoint, subject, publicKey, privateKey string) (string, error) {
+	key, err := vapidKey(publicKey, privateKey)
+
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(endpoint)
+
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid push endpoint")
+	}
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": subject,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+
+	if err != nil {
+		return "", err
+	}
+
+	// ES256 signatures are the concatenated 32 byte values of r and s.
+	sig := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	token := unsigned + "." + base64.RawURLEncoding.EncodeToString(sig)
+
+	return fmt.Sprintf("vapid t=%s, k=%s", token, publicKey), nil
+}
+
+// PushEncrypt encrypts a payload for the subscription using the aes128gcm content encoding.
+func PushEncrypt(sub PushSubscription, payload []byte) ([]byte, error) {
+	uaPublic, err := decodeBase64(sub.P256dh)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key")
+	}
+
+	authSecret, err := decodeBase64(sub.Auth)
+
+	if err != nil || len(authSecret) == 0 {
+		return nil, fmt.Errorf("invalid subscription auth secret")
+	}
+
+	curve := elliptic.P256()
+	uaX, uaY := elliptic.Unmarshal(curve, uaPublic)
+
+	if uaX == nil {
+		return nil, fmt.Errorf("invalid subscription key")
+	}
+
+	// Create an ephemeral key pair for this message.
+	asKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	asPublic := elliptic.Marshal(curve, asKey.X, asKey.Y)
+	sx, _ := curve.ScalarMult(uaX, uaY, asKey.D.Bytes())
+	ecdhSecret := sx.FillBytes(make([]byte, 32))
+
+	keyInfo := append(append([]byte("WebPush: info\x00"), uaPublic...), asPublic...)
+	ikm := make([]byte, 32)
+
+	if _, err := io.ReadFull(hkdf.New(sha256.New, ecdhSecret, authSecret, keyInfo), ikm); err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	cek := make([]byte, 16)
+	nonce := make([]byte, 12)
+
+	if _, err := io.ReadFull(hkdf.New(sha256.New, ikm, salt, []byte("Content-Encoding: aes128gcm\x00")), cek); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(hkdf.New(sha256.New, ikm, salt, []byte("Content-Encoding: nonce\x00")), nonce); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The payload is sent as a single record, terminated by the padding delimiter.
+	record := append(append([]byte{}, payload...), 2)
+
+	var result bytes.Buffer
+
+	rs := make([]byte, 4)
+	binary.BigEndian.PutUint32(rs, 4096)
+
+	result.Write(salt)
+	result.Write(rs)
+	result.WriteByte(byte(len(asPublic)))
+	result.Write(asPublic)
+	result.Write(gcm.Seal(nil, nonce, record, nil))
+
+	return result.Bytes(), nil
+}
+
+// decodeBase64 decodes base64url strings with or without padding, as well as standard base64.
+func decodeBase64(s string) ([]byte, error) {
+	if b, err := base64.RawURLEncoding.DecodeString(s); err == nil {
+		return b, nil
+	} else if b, err := base64.URLEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+
+	return base64.StdEncoding.DecodeString(s)
+}
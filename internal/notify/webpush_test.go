@@ -0,0 +1,216 @@
+package notify
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/photoprism/photoprism/pkg/dial"
+)
+
+// testSubscription returns a browser subscription and its private key for decrypting messages.
+func testSubscription(t *testing.T, endpoint string) (PushSubscription, *ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auth := make([]byte, 16)
+	_, _ = rand.Read(auth)
+
+	return PushSubscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(elliptic.Marshal(elliptic.P256(), key.X, key.Y)),
+		Auth:     base64.RawURLEncoding.EncodeToString(auth),
+	}, key, auth
+}
+
+// testDecrypt decrypts an aes128gcm message like a browser would.
+func testDecrypt(t *testing.T, body []byte, key *ecdsa.PrivateKey, auth []byte) []byte {
+	curve := elliptic.P256()
+	salt := body[:16]
+	assert.Equal(t, uint32(4096), binary.BigEndian.Uint32(body[16:20]))
+	idLen := int(body[20])
+	asPublic := body[21 : 21+idLen]
+	ciphertext := body[21+idLen:]
+
+	asX, asY := elliptic.Unmarshal(curve, asPublic)
+	sx, _ := curve.ScalarMult(asX, asY, key.D.Bytes())
+	uaPublic := elliptic.Marshal(curve, key.X, key.Y)
+
+	ikm := make([]byte, 32)
+	_, _ = io.ReadFull(hkdf.New(sha256.New, sx.FillBytes(make([]byte, 32)), auth, append(append([]byte("WebPush: info\x00"), uaPublic...), asPublic...)), ikm)
+
+	cek := make([]byte, 16)
+	nonce := make([]byte, 12)
+	_, _ = io.ReadFull(hkdf.New(sha256.New, ikm, salt, []byte("Content-Encoding: aes128gcm\x00")), cek)
+	_, _ = io.ReadFull(hkdf.New(sha256.New, ikm, salt, []byte("Content-Encoding: nonce\x00")), nonce)
+
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, byte(2), plain[len(plain)-1])
+
+	return plain[:len(plain)-1]
+}
+
+func TestGenerateVapidKeys(t *testing.T) {
+	publicKey, privateKey, err := GenerateVapidKeys()
+
+	assert.NoError(t, err)
+	assert.Len(t, publicKey, 87)
+	assert.Len(t, privateKey, 43)
+
+	key, err := vapidKey(publicKey, privateKey)
+
+	assert.NoError(t, err)
+
+	x, y := elliptic.P256().ScalarBaseMult(key.D.Bytes())
+	assert.Equal(t, 0, x.Cmp(key.X))
+	assert.Equal(t, 0, y.Cmp(key.Y))
+
+	_, err = vapidKey(publicKey, "invalid")
+	assert.Error(t, err)
+}
+
+func TestVapidAuthorization(t *testing.T) {
+	publicKey, privateKey, _ := GenerateVapidKeys()
+
+	t.Run("Success", func(t *testing.T) {
+		auth, err := VapidAuthorization("https://push.example.com/send/abc", "mailto:admin@example.com", publicKey, privateKey)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, strings.HasPrefix(auth, "vapid t="))
+		assert.True(t, strings.HasSuffix(auth, ", k="+publicKey))
+
+		token := strings.TrimSuffix(strings.TrimPrefix(auth, "vapid t="), ", k="+publicKey)
+		parts := strings.Split(token, ".")
+
+		assert.Len(t, parts, 3)
+
+		claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+
+		var c map[string]interface{}
+		_ = json.Unmarshal(claims, &c)
+
+		assert.Equal(t, "https://push.example.com", c["aud"])
+		assert.Equal(t, "mailto:admin@example.com", c["sub"])
+
+		key, _ := vapidKey(publicKey, privateKey)
+		sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+
+		assert.True(t, ecdsa.Verify(&key.PublicKey, hash[:], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])))
+	})
+	t.Run("InvalidEndpoint", func(t *testing.T) {
+		_, err := VapidAuthorization("push", "", publicKey, privateKey)
+		assert.Error(t, err)
+	})
+}
+
+func TestPushEncrypt(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		sub, key, auth := testSubscription(t, "https://push.example.com/abc")
+
+		body, err := PushEncrypt(sub, []byte("hello"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "hello", string(testDecrypt(t, body, key, auth)))
+	})
+	t.Run("InvalidKey", func(t *testing.T) {
+		_, err := PushEncrypt(PushSubscription{Endpoint: "https://push.example.com/abc", P256dh: "abc", Auth: "abc"}, []byte("hello"))
+		assert.Error(t, err)
+	})
+}
+
+func TestWebPush_Send(t *testing.T) {
+	publicKey, privateKey, _ := GenerateVapidKeys()
+
+	var body []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		} else if r.Header.Get("Content-Encoding") != "aes128gcm" || !strings.HasPrefix(r.Header.Get("Authorization"), "vapid ") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	defer server.Close()
+
+	t.Run("LocalEndpoint", func(t *testing.T) {
+		sub, _, _ := testSubscription(t, server.URL+"/send")
+
+		c := WebPush{Subscriptions: []PushSubscription{sub}, PublicKey: publicKey, PrivateKey: privateKey}
+
+		assert.ErrorIs(t, c.Push(sub, []byte("hello")), dial.ErrPrivate)
+	})
+
+	// The test server runs on a local address.
+	defaultClient := pushClient
+	pushClient = server.Client()
+	defer func() { pushClient = defaultClient }()
+
+	t.Run("Success", func(t *testing.T) {
+		sub, key, auth := testSubscription(t, server.URL+"/send")
+
+		c := WebPush{Subscriptions: []PushSubscription{sub}, PublicKey: publicKey, PrivateKey: privateKey, Subject: "mailto:admin@example.com"}
+
+		assert.NoError(t, c.Send(Message{Title: "PhotoPrism", Text: "3 entries added to Holiday", URL: "https://photos.example.com/"}))
+
+		var payload map[string]string
+		_ = json.Unmarshal(testDecrypt(t, body, key, auth), &payload)
+
+		assert.Equal(t, "PhotoPrism", payload["title"])
+		assert.Equal(t, "3 entries added to Holiday", payload["body"])
+		assert.Equal(t, "https://photos.example.com/", payload["url"])
+	})
+	t.Run("Gone", func(t *testing.T) {
+		sub, _, _ := testSubscription(t, server.URL+"/gone")
+
+		var removed string
+
+		c := WebPush{Subscriptions: []PushSubscription{sub}, PublicKey: publicKey, PrivateKey: privateKey, Gone: func(endpoint string) {
+			removed = endpoint
+		}}
+
+		assert.NoError(t, c.Send(Message{Title: "Test"}))
+		assert.Equal(t, server.URL+"/gone", removed)
+	})
+	t.Run("NoKeys", func(t *testing.T) {
+		assert.Error(t, WebPush{}.Send(Message{Title: "Test"}))
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"path/filepath"
 	"regexp"
 
+	"github.com/dustin/go-humanize/english"
+
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -84,21 +86,45 @@ func RestoreAlbums(backupPath string, force bool) (count int, result error) {
 	}
 
 	for _, fileName := range albums {
-		a := entity.Album{}
-
-		if err := a.LoadFromYaml(fileName); err != nil {
+		if restored, err := RestoreAlbum(fileName); err != nil {
 			log.Errorf("restore: %s in %s", err, sanitize.Log(filepath.Base(fileName)))
 			result = err
-		} else if a.AlbumType == "" || len(a.Photos) == 0 && a.AlbumFilter == "" {
-			log.Debugf("restore: skipping %s", sanitize.Log(filepath.Base(fileName)))
-		} else if err := a.Find(); err == nil {
-			log.Infof("%s: %s already exists", a.AlbumType, sanitize.Log(a.AlbumTitle))
-		} else if err := a.Create(); err != nil {
-			log.Errorf("%s: %s in %s", a.AlbumType, err, sanitize.Log(filepath.Base(fileName)))
-		} else {
+		} else if restored {
 			count++
 		}
 	}
 
 	return count, result
 }
+
+// RestoreAlbum restores an album from a YAML file backup, including its members and share links.
+// Photos are re-linked by UID or file hash. Returns true if a new album was created.
+func RestoreAlbum(fileName string) (restored bool, err error) {
+	a := entity.Album{}
+
+	if err = a.LoadFromYaml(fileName); err != nil {
+		return false, err
+	} else if a.AlbumType == "" || len(a.Photos) == 0 && a.AlbumFilter == "" {
+		log.Debugf("restore: skipping %s", sanitize.Log(filepath.Base(fileName)))
+		return false, nil
+	}
+
+	if existing := a; existing.Find() == nil {
+		log.Infof("%s: %s already exists", a.AlbumType, sanitize.Log(a.AlbumTitle))
+		return false, nil
+	}
+
+	if missing := a.RelinkPhotos(); missing > 0 {
+		log.Infof("%s: %s not indexed yet in %s", a.AlbumType, english.Plural(missing, "photo", "photos"), sanitize.Log(a.AlbumTitle))
+	}
+
+	if err = a.Create(); err != nil {
+		return false, err
+	}
+
+	if err = a.RestoreShares(); err != nil {
+		log.Errorf("%s: %s (restore shares)", a.AlbumType, err)
+	}
+
+	return true, nil
+}
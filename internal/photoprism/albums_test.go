@@ -0,0 +1,79 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestRestoreAlbum(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "album", "at9lxuqxpoaares1.yml")
+
+	data := `UID: at9lxuqxpoaares1
+Slug: restored-album
+Type: album
+Title: Restored Album
+Order: added
+Photos:
+- UID: pt9jtdre2lvl0yh7
+  Order: 2
+- UID: pt9jtdre2lvlrest
+  Hash: ocad9168fa6acc5c5c2965ddf6ec465ca42fd818
+  Order: 1
+Members:
+- UserUID: uqxc08w3d0ej2283
+  Role: viewer
+Links:
+- Share: at9lxuqxpoaares1
+  Token: restoretoken2
+`
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(fileName, []byte(data), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := RestoreAlbum(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, restored)
+
+	a := entity.Album{AlbumUID: "at9lxuqxpoaares1"}
+
+	if err := a.Find(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Restored Album", a.AlbumTitle)
+	assert.Equal(t, "added", a.AlbumOrder)
+
+	var photos entity.PhotoAlbums
+
+	if err := entity.Db().Where("album_uid = ?", a.AlbumUID).Order("photos_albums.`order`").Find(&photos).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, photos, 2) {
+		assert.Equal(t, "pt9jtdre2lvl0yh8", photos[0].PhotoUID)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", photos[1].PhotoUID)
+	}
+
+	assert.NotNil(t, entity.FindAlbumMember(a.AlbumUID, "uqxc08w3d0ej2283"))
+	assert.Len(t, entity.FindLinks("restoretoken2", a.AlbumUID), 1)
+
+	// Existing albums are not restored again.
+	restored, err = RestoreAlbum(fileName)
+
+	assert.NoError(t, err)
+	assert.False(t, restored)
+}
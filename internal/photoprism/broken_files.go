@@ -0,0 +1,70 @@
+package photoprism
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// MoveBrokenFiles moves originals that could not be decoded to a quarantine folder, keeping their
+// relative paths, and removes them from the index. Returns the names of the moved files.
+func MoveBrokenFiles(destPath string, dryRun bool) (moved []string, err error) {
+	if destPath == "" {
+		return moved, fmt.Errorf("quarantine path must not be empty")
+	}
+
+	files, err := query.BrokenFiles(10000, 0)
+
+	if err != nil {
+		return moved, err
+	}
+
+	for _, broken := range files {
+		srcName := FileName(broken.FileRoot, broken.FileName)
+		destName := filepath.Join(destPath, broken.FileName)
+
+		if !fs.FileExists(srcName) {
+			log.Infof("broken: %s does not exist anymore", sanitize.Log(broken.FileName))
+
+			if !dryRun {
+				_ = broken.Purge()
+			}
+
+			continue
+		} else if fs.FileExists(destName) {
+			log.Warnf("broken: %s already exists in quarantine", sanitize.Log(broken.FileName))
+			continue
+		}
+
+		if dryRun {
+			moved = append(moved, broken.FileName)
+			continue
+		}
+
+		if err := fs.Move(srcName, destName); err != nil {
+			log.Errorf("broken: %s while moving %s", err, sanitize.Log(broken.FileName))
+			continue
+		}
+
+		// Remove the file from the index, the metadata worker will hide its photo.
+		var file entity.File
+
+		if err := entity.UnscopedDb().Where("file_name = ? AND file_root = ?", broken.FileName, broken.FileRoot).First(&file).Error; err == nil {
+			if err := file.Purge(); err != nil {
+				log.Errorf("broken: %s while removing %s from index", err, sanitize.Log(broken.FileName))
+			}
+		}
+
+		if err := broken.Purge(); err != nil {
+			return moved, err
+		}
+
+		moved = append(moved, broken.FileName)
+	}
+
+	return moved, nil
+}
@@ -0,0 +1,57 @@
+package photoprism
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestMoveBrokenFiles(t *testing.T) {
+	c := config.TestConfig()
+
+	fileName := "broken/corrupt.jpg"
+	srcName := filepath.Join(c.OriginalsPath(), fileName)
+	destPath := filepath.Join(c.StoragePath(), "quarantine")
+
+	if err := os.MkdirAll(filepath.Dir(srcName), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(srcName, []byte("not a jpeg"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(filepath.Dir(srcName))
+	defer os.RemoveAll(destPath)
+
+	if err := entity.AddBrokenFile(fileName, entity.RootOriginals, 10, errors.New("invalid JPEG format")); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		moved, err := MoveBrokenFiles(destPath, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{fileName}, moved)
+		assert.True(t, fs.FileExists(srcName))
+	})
+	t.Run("Move", func(t *testing.T) {
+		moved, err := MoveBrokenFiles(destPath, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{fileName}, moved)
+		assert.False(t, fs.FileExists(srcName))
+		assert.True(t, fs.FileExists(filepath.Join(destPath, fileName)))
+	})
+	t.Run("NoPath", func(t *testing.T) {
+		_, err := MoveBrokenFiles("", false)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,145 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/caption"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Captions represents a worker that generates image captions with a captioning model.
+type Captions struct {
+	conf  *config.Config
+	model *caption.Model
+}
+
+// NewCaptions returns a new Captions worker.
+func NewCaptions(conf *config.Config) *Captions {
+	instance := &Captions{
+		conf:  conf,
+		model: caption.NewModel(conf.CaptionUri()),
+	}
+
+	return instance
+}
+
+// Disabled tests if generating captions is disabled.
+func (w *Captions) Disabled() bool {
+	return w.conf.DisableCaptions()
+}
+
+// Start generates captions for photos that don't have one yet.
+//
+// Generated captions are stored separately and never overwrite the photo description.
+func (w *Captions) Start() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("captions: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if w.Disabled() {
+		return fmt.Errorf("captions are disabled")
+	}
+
+	if err := mutex.CaptionsWorker.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.CaptionsWorker.Stop()
+
+	start := time.Now()
+
+	limit := 100
+	offset := 0
+	generated := 0
+
+	for {
+		files, err := query.FilesWithoutCaption(limit, offset)
+
+		if err != nil {
+			return err
+		}
+
+		if len(files) == 0 {
+			break
+		}
+
+		for _, file := range files {
+			if mutex.CaptionsWorker.Canceled() {
+				return errors.New("captions: worker canceled")
+			}
+
+			thumbName, err := w.thumbnail(file)
+
+			if err != nil {
+				// Skip files without thumbnail in the next query.
+				log.Warnf("captions: %s in %s", err, sanitize.Log(file.FileName))
+				offset++
+				continue
+			}
+
+			result, err := w.model.File(thumbName)
+
+			// Stop if the captioning service is not available.
+			if err != nil {
+				return err
+			}
+
+			if err := w.Save(file, result.Caption); err != nil {
+				log.Errorf("captions: %s in %s", err, sanitize.Log(file.FileName))
+				offset++
+				continue
+			}
+
+			generated++
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if generated > 0 {
+		log.Infof("captions: generated %d captions [%s]", generated, time.Since(start))
+	} else {
+		log.Debugf("captions: found no photos without caption [%s]", time.Since(start))
+	}
+
+	return nil
+}
+
+// thumbnail returns the name of a thumbnail that can be sent to the captioning model.
+func (w *Captions) thumbnail(file entity.File) (string, error) {
+	size := thumb.Sizes[thumb.Fit720]
+	fileName := FileName(file.FileRoot, file.FileName)
+
+	return thumb.FromFile(fileName, file.FileHash, w.conf.ThumbPath(), size.Width, size.Height, file.FileOrientation, size.Options...)
+}
+
+// Save stores a generated caption and adds its words to the search keywords of the photo.
+func (w *Captions) Save(file entity.File, text string) error {
+	photo, err := query.PhotoByUID(file.PhotoUID)
+
+	if err != nil {
+		return err
+	}
+
+	details := photo.GetDetails()
+	details.SetCaption(text, entity.SrcImage)
+
+	if details.NoCaption() {
+		return errors.New("empty caption")
+	} else if err := details.Save(); err != nil {
+		return err
+	}
+
+	return photo.IndexKeywords()
+}
@@ -0,0 +1,43 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+func TestCaptions_Start(t *testing.T) {
+	c := config.TestConfig()
+
+	w := NewCaptions(c)
+
+	assert.True(t, w.Disabled())
+	assert.Error(t, w.Start())
+}
+
+func TestCaptions_Save(t *testing.T) {
+	c := config.TestConfig()
+
+	w := NewCaptions(c)
+	file := entity.FileFixtures.Get("bridge.jpg")
+
+	if err := w.Save(file, "a bridge over a misty river"); err != nil {
+		t.Fatal(err)
+	}
+
+	photo, err := query.PhotoByUID(file.PhotoUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "a bridge over a misty river", photo.GetDetails().Caption)
+	assert.Equal(t, entity.SrcImage, photo.GetDetails().CaptionSrc)
+	assert.NotEqual(t, "a bridge over a misty river", photo.PhotoDescription)
+
+	assert.Error(t, w.Save(entity.File{PhotoUID: "pt9jtdre2lvl0y99"}, "unknown photo"))
+}
@@ -0,0 +1,325 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+
+	"github.com/karrick/godirwalk"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Index consistency issue types.
+const (
+	IssueUnindexed = "unindexed" // File exists in originals, but not in the index.
+	IssueMissing   = "missing"   // Indexed file does not exist anymore.
+	IssueSize      = "size"      // Indexed file size differs from the file on disk.
+	IssueHash      = "hash"      // Indexed file hash differs from the file on disk.
+	IssueOrphan    = "orphan"    // Indexed file refers to a photo that does not exist.
+	IssuePrimary   = "primary"   // Photo has no existing primary file.
+)
+
+// CheckIssue represents an inconsistency between the index and the files on disk.
+type CheckIssue struct {
+	Type     string
+	Root     string
+	Name     string
+	PhotoUID string
+	Details  string
+	file     *entity.File
+}
+
+// FileName returns the absolute file name, if any.
+func (i CheckIssue) FileName() string {
+	if i.Name == "" {
+		return ""
+	}
+
+	return FileName(i.Root, i.Name)
+}
+
+// CheckIssues represents a list of index consistency issues.
+type CheckIssues []CheckIssue
+
+// CheckOptions represents index consistency check options.
+type CheckOptions struct {
+	Path   string
+	Hashes bool
+	Fix    bool
+}
+
+// Check represents a worker that validates the index against the files on disk.
+type Check struct {
+	conf  *config.Config
+	index *Index
+}
+
+// NewCheck returns a new index consistency check worker.
+func NewCheck(conf *config.Config, index *Index) *Check {
+	instance := &Check{
+		conf:  conf,
+		index: index,
+	}
+
+	return instance
+}
+
+// Start finds index consistency issues and repairs them if the fix option is set.
+func (w *Check) Start(opt CheckOptions) (issues CheckIssues, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("check: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if opt.Fix && w.conf.ReadOnly() {
+		return issues, config.ErrReadOnly
+	}
+
+	if err := mutex.MainWorker.Start(); err != nil {
+		return issues, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	opt.Path = strings.Trim(opt.Path, "/")
+
+	if issues, err = w.indexedFiles(opt, issues); err != nil {
+		return issues, err
+	} else if issues, err = w.unindexedFiles(opt, issues); err != nil {
+		return issues, err
+	} else if issues, err = w.orphanFiles(opt, issues); err != nil {
+		return issues, err
+	} else if issues, err = w.primaryFiles(opt, issues); err != nil {
+		return issues, err
+	}
+
+	if opt.Fix {
+		w.fix(issues)
+	}
+
+	return issues, nil
+}
+
+// indexedFiles finds indexed files that are missing or have changed on disk.
+func (w *Check) indexedFiles(opt CheckOptions, issues CheckIssues) (CheckIssues, error) {
+	limit := 500
+	offset := 0
+
+	for {
+		files, err := query.Files(limit, offset, opt.Path, false)
+
+		if err != nil {
+			return issues, err
+		} else if len(files) == 0 {
+			return issues, nil
+		}
+
+		for i := range files {
+			if mutex.MainWorker.Canceled() {
+				return issues, errors.New("check canceled")
+			}
+
+			file := &files[i]
+			issue := CheckIssue{Root: file.FileRoot, Name: file.FileName, PhotoUID: file.PhotoUID, file: file}
+			info, err := os.Stat(issue.FileName())
+
+			switch {
+			case err != nil || info.IsDir():
+				issue.Type = IssueMissing
+			case info.Size() != file.FileSize:
+				issue.Type = IssueSize
+				issue.Details = fmt.Sprintf("%d bytes indexed, %d bytes on disk", file.FileSize, info.Size())
+			case opt.Hashes && file.FileHash != fs.Hash(issue.FileName()):
+				issue.Type = IssueHash
+			default:
+				continue
+			}
+
+			issues = append(issues, issue)
+		}
+
+		offset += limit
+	}
+}
+
+// unindexedFiles finds media files in originals that are neither indexed nor known duplicates.
+func (w *Check) unindexedFiles(opt CheckOptions, issues CheckIssues) (CheckIssues, error) {
+	indexed, err := query.IndexedFiles()
+
+	if err != nil {
+		return issues, err
+	}
+
+	// Files that could not be indexed are reported separately.
+	limit := 1000
+	offset := 0
+
+	for {
+		broken, err := query.BrokenFiles(limit, offset)
+
+		if err != nil {
+			return issues, err
+		}
+
+		for _, f := range broken {
+			indexed[path.Join(f.FileRoot, f.FileName)] = 0
+		}
+
+		if len(broken) < limit {
+			break
+		}
+
+		offset += limit
+	}
+
+	root := w.conf.OriginalsPath()
+	done := make(fs.Done)
+	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
+
+	if err := ignore.Dir(root); err != nil {
+		log.Infof("check: %s", err)
+	}
+
+	err = godirwalk.Walk(filepath.Join(root, opt.Path), &godirwalk.Options{
+		ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
+			log.Errorf("check: %s", strings.Replace(err.Error(), root, "", 1))
+			return godirwalk.SkipNode
+		},
+		Callback: func(fileName string, info *godirwalk.Dirent) error {
+			if mutex.MainWorker.Canceled() {
+				return errors.New("check canceled")
+			}
+
+			if skip, result := fs.SkipWalk(fileName, info.IsDir(), info.IsSymlink(), done, ignore); skip {
+				return result
+			}
+
+			done[fileName] = fs.Found
+
+			if !fs.IsMedia(fileName) {
+				return nil
+			}
+
+			relName := fs.RelName(fileName, root)
+
+			if _, ok := indexed[path.Join(entity.RootOriginals, relName)]; ok {
+				return nil
+			} else if s, err := os.Stat(fileName); err != nil || s.Size() == 0 {
+				return nil
+			}
+
+			issues = append(issues, CheckIssue{Type: IssueUnindexed, Root: entity.RootOriginals, Name: relName})
+
+			return nil
+		},
+		Unsorted:            false,
+		FollowSymbolicLinks: true,
+	})
+
+	return issues, err
+}
+
+// orphanFiles finds indexed files that refer to a photo that does not exist.
+func (w *Check) orphanFiles(opt CheckOptions, issues CheckIssues) (CheckIssues, error) {
+	files, err := query.OrphanFiles()
+
+	if err != nil {
+		return issues, err
+	}
+
+	for i := range files {
+		if opt.Path != "" && !strings.HasPrefix(files[i].FileName, opt.Path+"/") {
+			continue
+		}
+
+		issues = append(issues, CheckIssue{
+			Type:    IssueOrphan,
+			Root:    files[i].FileRoot,
+			Name:    files[i].FileName,
+			Details: fmt.Sprintf("photo id %d", files[i].PhotoID),
+			file:    &files[i],
+		})
+	}
+
+	return issues, nil
+}
+
+// primaryFiles finds photos without an existing primary file.
+func (w *Check) primaryFiles(opt CheckOptions, issues CheckIssues) (CheckIssues, error) {
+	photos, err := query.PhotosWithoutPrimary(opt.Path)
+
+	if err != nil {
+		return issues, err
+	}
+
+	for _, photoUID := range photos {
+		issues = append(issues, CheckIssue{Type: IssuePrimary, PhotoUID: photoUID})
+	}
+
+	return issues, nil
+}
+
+// fix repairs the issues found.
+func (w *Check) fix(issues CheckIssues) {
+	var purged bool
+
+	for _, issue := range issues {
+		if mutex.MainWorker.Canceled() {
+			return
+		}
+
+		logName := sanitize.Log(issue.Name)
+
+		switch issue.Type {
+		case IssueMissing:
+			wasPrimary := issue.file.FilePrimary
+
+			if err := issue.file.Purge(); err != nil {
+				log.Errorf("check: %s while flagging %s as missing", err, logName)
+			} else if wasPrimary {
+				if err := query.SetPhotoPrimary(issue.PhotoUID, ""); err != nil {
+					log.Infof("check: %s", err)
+				}
+			}
+
+			purged = true
+		case IssueUnindexed, IssueSize, IssueHash:
+			if w.index == nil {
+				log.Errorf("check: index worker required to update %s", logName)
+			} else if res := w.index.FileName(issue.FileName(), IndexOptionsSingle()); res.Failed() {
+				log.Errorf("check: %s while indexing %s", res.Err, logName)
+			}
+		case IssueOrphan:
+			if err := issue.file.DeletePermanently(); err != nil {
+				log.Errorf("check: %s while removing orphan %s", err, logName)
+			}
+		case IssuePrimary:
+			if err := query.SetPhotoPrimary(issue.PhotoUID, ""); err != nil {
+				log.Infof("check: %s", err)
+			}
+		}
+	}
+
+	// Hide photos without existing files.
+	if purged {
+		if err := query.FlagHiddenPhotos(); err != nil {
+			log.Errorf("check: %s (flag hidden photos)", err)
+		}
+	}
+
+	if err := entity.UpdateCounts(); err != nil {
+		log.Warnf("check: %s (update counts)", err)
+	}
+}
@@ -0,0 +1,106 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestCheck_Start(t *testing.T) {
+	c := config.TestConfig()
+	dir := filepath.Join(c.OriginalsPath(), "check")
+
+	defer os.RemoveAll(dir)
+
+	// Creates a file in originals and optionally adds it to the index.
+	create := func(name, content string, onDisk, indexed bool) {
+		fileName := filepath.Join(c.OriginalsPath(), name)
+
+		if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+			t.Fatal(err)
+		} else if err := os.WriteFile(fileName, []byte(content), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		hash := fs.Hash(fileName)
+
+		if !onDisk {
+			_ = os.Remove(fileName)
+		}
+
+		if !indexed {
+			return
+		}
+
+		photo := entity.Photo{PhotoPath: filepath.Dir(name), PhotoName: fs.BasePrefix(name, false)}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: name, FileRoot: entity.RootOriginals, FileType: "jpg", FileHash: hash, FileSize: int64(len(content)), FilePrimary: true}
+
+		if err := entity.Db().Create(&file).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	create("check/ok.jpg", "check-ok", true, true)
+	create("check/missing.jpg", "check-missing", false, true)
+	create("check/new.jpg", "check-new", true, false)
+	create("check/size.jpg", "check-size", true, true)
+
+	// Change the file size after indexing.
+	if err := os.WriteFile(filepath.Join(dir, "size.jpg"), []byte("check-size-changed"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewCheck(c, nil)
+
+	issues, err := w.Start(CheckOptions{Path: "check"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := make(map[string]string)
+
+	for _, i := range issues {
+		found[i.Name] = i.Type
+	}
+
+	assert.Len(t, issues, 3)
+	assert.Equal(t, IssueMissing, found["check/missing.jpg"])
+	assert.Equal(t, IssueUnindexed, found["check/new.jpg"])
+	assert.Equal(t, IssueSize, found["check/size.jpg"])
+
+	t.Run("Fix", func(t *testing.T) {
+		if _, err := w.Start(CheckOptions{Path: "check", Fix: true}); err != nil {
+			t.Fatal(err)
+		}
+
+		var file entity.File
+
+		if err := entity.UnscopedDb().Where("file_name = ?", "check/missing.jpg").First(&file).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, file.FileMissing)
+		assert.False(t, file.FilePrimary)
+
+		issues, err := w.Start(CheckOptions{Path: "check"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Changed and new files can't be indexed without index worker.
+		assert.Len(t, issues, 2)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize/english"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/fastwalk"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/sanitize"
@@ -54,52 +56,8 @@ func (w *CleanUp) Start(opt CleanUpOptions) (thumbs int, orphans int, err error)
 		log.Infof("cleanup: dry run, nothing will actually be removed")
 	}
 
-	var fileHashes, thumbHashes query.HashMap
-
-	// Fetch existing media and thumb file hashes.
-	if fileHashes, err = query.FileHashMap(); err != nil {
-		return thumbs, orphans, err
-	} else if thumbHashes, err = query.ThumbHashMap(); err != nil {
-		return thumbs, orphans, err
-	}
-
-	// Thumbnails storage path.
-	thumbPath := w.conf.ThumbPath()
-
 	// Find and remove orphan thumbnail files.
-	if err := fastwalk.Walk(thumbPath, func(fileName string, info os.FileMode) error {
-		base := filepath.Base(fileName)
-
-		if info.IsDir() || strings.HasPrefix(base, ".") {
-			return nil
-		}
-
-		// Example: 01244519acf35c62a5fea7a5a7dcefdbec4fb2f5_3x3_resize.png
-		i := strings.Index(base, "_")
-
-		if i < 39 {
-			return nil
-		}
-
-		hash := base[:i]
-		logName := sanitize.Log(fs.RelName(fileName, thumbPath))
-
-		if ok := fileHashes[hash]; ok {
-			// Do nothing.
-		} else if ok := thumbHashes[hash]; ok {
-			// Do nothing.
-		} else if opt.Dry {
-			thumbs++
-			log.Debugf("cleanup: thumbnail %s would be removed", logName)
-		} else if err := os.Remove(fileName); err != nil {
-			log.Warnf("cleanup: %s in %s", err, logName)
-		} else {
-			thumbs++
-			log.Debugf("cleanup: removed thumbnail %s", logName)
-		}
-
-		return nil
-	}); err != nil {
+	if thumbs, err = w.Thumbs(opt); err != nil {
 		return thumbs, orphans, err
 	}
 
@@ -166,6 +124,89 @@ func (w *CleanUp) Start(opt CleanUpOptions) (thumbs int, orphans int, err error)
 	return thumbs, orphans, nil
 }
 
+// Thumbs removes thumbnail files that don't belong to an indexed file or cover image.
+func (w *CleanUp) Thumbs(opt CleanUpOptions) (thumbs int, err error) {
+	var fileHashes, thumbHashes query.HashMap
+
+	// Keep thumbnails that were created after the file hashes have been fetched.
+	start := time.Now()
+
+	// Fetch existing media and thumb file hashes.
+	if fileHashes, err = query.FileHashMap(); err != nil {
+		return thumbs, err
+	} else if thumbHashes, err = query.ThumbHashMap(); err != nil {
+		return thumbs, err
+	}
+
+	// Thumbnails storage path.
+	thumbPath := w.conf.ThumbPath()
+
+	// Find and remove orphan thumbnail files.
+	err = fastwalk.Walk(thumbPath, func(fileName string, info os.FileMode) error {
+		base := filepath.Base(fileName)
+
+		// Example: 01244519acf35c62a5fea7a5a7dcefdbec4fb2f5_zoom
+		if info.IsDir() && strings.HasSuffix(base, thumb.ZoomSuffix) {
+			hash := strings.TrimSuffix(base, thumb.ZoomSuffix)
+			logName := sanitize.Log(fs.RelName(fileName, thumbPath))
+
+			if ok := fileHashes[hash]; ok {
+				// Do nothing.
+			} else if ok := thumbHashes[hash]; ok {
+				// Do nothing.
+			} else if thumb.ZoomLocked(hash) {
+				// Do nothing.
+			} else if info, err := os.Stat(fileName); err != nil || info.ModTime().After(start) {
+				// Do nothing.
+			} else if opt.Dry {
+				thumbs++
+				log.Debugf("cleanup: zoom tiles %s would be removed", logName)
+			} else if err := os.RemoveAll(fileName); err != nil {
+				log.Warnf("cleanup: %s in %s", err, logName)
+			} else {
+				thumbs++
+				log.Debugf("cleanup: removed zoom tiles %s", logName)
+			}
+
+			return filepath.SkipDir
+		}
+
+		if info.IsDir() || strings.HasPrefix(base, ".") {
+			return nil
+		}
+
+		// Example: 01244519acf35c62a5fea7a5a7dcefdbec4fb2f5_3x3_resize.png
+		i := strings.Index(base, "_")
+
+		if i < 39 {
+			return nil
+		}
+
+		hash := base[:i]
+		logName := sanitize.Log(fs.RelName(fileName, thumbPath))
+
+		if ok := fileHashes[hash]; ok {
+			// Do nothing.
+		} else if ok := thumbHashes[hash]; ok {
+			// Do nothing.
+		} else if info, err := os.Stat(fileName); err != nil || info.ModTime().After(start) {
+			// Do nothing.
+		} else if opt.Dry {
+			thumbs++
+			log.Debugf("cleanup: thumbnail %s would be removed", logName)
+		} else if err := os.Remove(fileName); err != nil {
+			log.Warnf("cleanup: %s in %s", err, logName)
+		} else {
+			thumbs++
+			log.Debugf("cleanup: removed thumbnail %s", logName)
+		}
+
+		return nil
+	})
+
+	return thumbs, err
+}
+
 // Cancel stops the current operation.
 func (w *CleanUp) Cancel() {
 	mutex.MainWorker.Cancel()
@@ -66,7 +66,7 @@ func (c *Convert) Start(path string) (err error) {
 
 	// Start a fixed number of goroutines to convert files.
 	var wg sync.WaitGroup
-	var numWorkers = c.conf.Workers()
+	var numWorkers = c.conf.TaskWorkers(config.TaskConvert)
 	wg.Add(numWorkers)
 	for i := 0; i < numWorkers; i++ {
 		go func() {
@@ -111,7 +111,7 @@ func (c *Convert) Start(path string) (err error) {
 
 			f, err := NewMediaFile(fileName)
 
-			if err != nil || !(f.IsRaw() || f.IsHEIF() || f.IsImageOther() || f.IsVideo()) {
+			if err != nil || !(f.IsRaw() || f.IsHEIF() || f.IsImageOther() || f.IsDicom() || f.IsVideo()) {
 				return nil
 			}
 
@@ -229,6 +229,9 @@ func (c *Convert) JpegConvertCommand(f *MediaFile, jpegName string, xmpName stri
 		result = exec.Command(c.conf.FFmpegBin(), "-y", "-i", f.FileName(), "-ss", "00:00:00.001", "-vframes", "1", jpegName)
 	} else if f.IsHEIF() && c.conf.HeifConvertEnabled() {
 		result = exec.Command(c.conf.HeifConvertBin(), f.FileName(), jpegName)
+	} else if f.IsDicom() && c.conf.DcmtkEnabled() {
+		// Converts the first frame using the min-max window, as most DICOM files don't contain a VOI window.
+		result = exec.Command(c.conf.DcmtkBin(), "+oj", "+Wm", f.FileName(), jpegName)
 	} else {
 		return nil, useMutex, fmt.Errorf("file type %s not supported", f.FileType())
 	}
@@ -241,6 +244,55 @@ func (c *Convert) JpegConvertCommand(f *MediaFile, jpegName string, xmpName stri
 	return result, useMutex, nil
 }
 
+// TiffPageName returns the JPEG file name of an additional multi-page TIFF page, starting with page 2.
+func (c *Convert) TiffPageName(f *MediaFile, page int) string {
+	return fs.FileName(f.FileName(), f.SidecarPath(), f.OriginalsPath(), fmt.Sprintf(".p%d%s", page, fs.JpegExt))
+}
+
+// ToJpegPages converts the additional pages of a multi-page TIFF file to JPEG, so that they can be
+// indexed as related files of the same photo. The first page is converted by ToJpeg.
+func (c *Convert) ToJpegPages(f *MediaFile) (result []*MediaFile, err error) {
+	if f == nil || !f.IsTiff() {
+		return result, nil
+	}
+
+	pages := f.Pages()
+
+	if pages < 2 {
+		return result, nil
+	}
+
+	fileName := f.RelName(c.conf.OriginalsPath())
+
+	for page := 2; page <= pages; page++ {
+		jpegName := c.TiffPageName(f, page)
+
+		if jpegName == "" {
+			return result, fmt.Errorf("convert: invalid file name for page %d of %s", page, sanitize.Log(fileName))
+		} else if !fs.FileExists(jpegName) {
+			if !c.conf.SidecarWritable() {
+				return result, fmt.Errorf("convert: disabled in read only mode (%s)", fileName)
+			}
+
+			start := time.Now()
+
+			if _, err = thumb.TiffPageJpeg(f.FileName(), jpegName, page, f.Orientation()); err != nil {
+				return result, err
+			}
+
+			log.Infof("%s: created %s from page %d [%s]", f.FileType(), filepath.Base(jpegName), page, time.Since(start))
+		}
+
+		if mediaFile, err := NewMediaFile(jpegName); err != nil {
+			return result, err
+		} else {
+			result = append(result, mediaFile)
+		}
+	}
+
+	return result, nil
+}
+
 // ToJpeg converts a single image file to JPEG if possible.
 func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
 	if f == nil {
@@ -255,7 +307,7 @@ func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
 		return f, nil
 	}
 
-	jpegName := fs.FormatJpeg.FindFirst(f.FileName(), []string{c.conf.SidecarPath(), fs.HiddenPath}, c.conf.OriginalsPath(), false)
+	jpegName := fs.FormatJpeg.FindFirst(f.FileName(), []string{f.SidecarPath(), fs.HiddenPath}, f.OriginalsPath(), false)
 
 	mediaFile, err := NewMediaFile(jpegName)
 
@@ -267,7 +319,7 @@ func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
 		return nil, fmt.Errorf("convert: disabled in read only mode (%s)", f.RelName(c.conf.OriginalsPath()))
 	}
 
-	jpegName = fs.FileName(f.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), fs.JpegExt)
+	jpegName = fs.FileName(f.FileName(), f.SidecarPath(), f.OriginalsPath(), fs.JpegExt)
 	fileName := f.RelName(c.conf.OriginalsPath())
 
 	xmpName := fs.FormatXMP.Find(f.FileName(), false)
@@ -284,6 +336,8 @@ func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
 	if f.IsImageOther() {
 		log.Infof("%s: converting %s to %s", f.FileType(), fileName, fs.FormatJpeg)
 
+		// The first page of multi-page TIFF files is used as preview, see ToJpegPages.
+
 		_, err = thumb.Jpeg(f.FileName(), jpegName, f.Orientation())
 
 		if err != nil {
@@ -295,6 +349,17 @@ func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
 		return NewMediaFile(jpegName)
 	}
 
+	// Use the embedded preview if possible, the RAW converter is only used as fallback.
+	if f.IsRaw() && c.conf.RawPreviews() {
+		if err := c.RawPreview(f, jpegName); err != nil {
+			log.Debugf("%s: %s, converting %s with raw converter", f.FileType(), err, sanitize.Log(fileName))
+		} else {
+			log.Infof("%s: extracted preview %s [%s]", f.FileType(), filepath.Base(jpegName), time.Since(start))
+
+			return NewMediaFile(jpegName)
+		}
+	}
+
 	cmd, useMutex, err := c.JpegConvertCommand(f, jpegName, xmpName)
 
 	if err != nil {
@@ -334,6 +399,45 @@ func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
 	return NewMediaFile(jpegName)
 }
 
+// Keyframes extracts evenly distributed video keyframes as JPEG files to dir and returns their file names.
+func (c *Convert) Keyframes(f *MediaFile, count int, dir string) (fileNames []string, err error) {
+	if f == nil {
+		return fileNames, fmt.Errorf("convert: file is nil - you might have found a bug")
+	} else if !f.IsVideo() {
+		return fileNames, fmt.Errorf("convert: %s is not a video", sanitize.Log(f.BaseName()))
+	} else if !c.conf.FFmpegEnabled() {
+		return fileNames, fmt.Errorf("convert: ffmpeg is disabled")
+	}
+
+	duration := f.MetaData().Duration
+
+	for i := 1; i <= count; i++ {
+		// Skip the very beginning and end, where videos are often blurred or black.
+		pos := duration * time.Duration(i) / time.Duration(count+1)
+		fileName := filepath.Join(dir, fmt.Sprintf("keyframe-%02d%s", i, fs.JpegExt))
+
+		cmd := exec.Command(c.conf.FFmpegBin(), "-y", "-ss", fmt.Sprintf("%.3f", pos.Seconds()), "-i", f.FileName(), "-vframes", "1", fileName)
+
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+
+		log.Tracef("convert: %s", cmd.String())
+
+		if err := cmd.Run(); err != nil {
+			log.Debugf("convert: %s in %s (keyframe %d)", strings.TrimSpace(stderr.String()), sanitize.Log(f.BaseName()), i)
+		} else if fs.FileExists(fileName) {
+			fileNames = append(fileNames, fileName)
+		}
+
+		// Extract a single keyframe only if the duration is unknown.
+		if duration <= 0 {
+			break
+		}
+	}
+
+	return fileNames, nil
+}
+
 // AvcBitrate returns the ideal AVC encoding bitrate in megabits per second.
 func (c *Convert) AvcBitrate(f *MediaFile) string {
 	const defaultBitrate = "8M"
@@ -442,7 +546,7 @@ func (c *Convert) ToAvc(f *MediaFile, encoderName string) (file *MediaFile, err
 		return nil, fmt.Errorf("convert: %s not found", f.RelName(c.conf.OriginalsPath()))
 	}
 
-	avcName := fs.FormatAvc.FindFirst(f.FileName(), []string{c.conf.SidecarPath(), fs.HiddenPath}, c.conf.OriginalsPath(), false)
+	avcName := fs.FormatAvc.FindFirst(f.FileName(), []string{f.SidecarPath(), fs.HiddenPath}, f.OriginalsPath(), false)
 
 	mediaFile, err := NewMediaFile(avcName)
 
@@ -458,7 +562,23 @@ func (c *Convert) ToAvc(f *MediaFile, encoderName string) (file *MediaFile, err
 		return nil, fmt.Errorf("convert: ffmpeg is disabled for transcoding %s", f.RelName(c.conf.OriginalsPath()))
 	}
 
-	avcName = fs.FileName(f.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), fs.AvcExt)
+	// Enforce duration limit for transcoding.
+	if maxDuration := c.conf.FFmpegMaxDuration(); maxDuration <= 0 {
+		// Unlimited.
+	} else if d := f.MetaData().Duration; d > maxDuration {
+		addSkippedFile("transcode", SkippedFile{
+			Root:    f.Root(),
+			Name:    f.RootRelName(),
+			Reason:  SkippedDuration,
+			Details: fmt.Sprintf("%s / %s", d, maxDuration),
+		})
+
+		return nil, fmt.Errorf("convert: %s exceeds duration limit (%s / %s)", sanitize.Log(f.RelName(c.conf.OriginalsPath())), d, maxDuration)
+	} else {
+		removeSkippedFile("transcode", f.Root(), f.RootRelName())
+	}
+
+	avcName = fs.FileName(f.FileName(), f.SidecarPath(), f.OriginalsPath(), fs.AvcExt)
 	fileName := f.RelName(c.conf.OriginalsPath())
 
 	cmd, useMutex, err := c.AvcConvertCommand(f, avcName, encoderName)
@@ -0,0 +1,233 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// HlsMinDuration is the minimum duration of videos that are segmented for adaptive streaming.
+const HlsMinDuration = time.Minute
+
+// HlsSegmentDuration is the target duration of HLS segments in seconds.
+const HlsSegmentDuration = 6
+
+// HlsPlaylist is the file name of HLS playlists.
+const HlsPlaylist = "index.m3u8"
+
+// HlsRendition represents a video quality level for adaptive streaming.
+type HlsRendition struct {
+	Size    int // Length of the shorter side in pixels.
+	Bitrate int // Video bitrate in kbit/s.
+}
+
+// HlsRenditions lists the supported quality levels, from highest to lowest.
+var HlsRenditions = []HlsRendition{
+	{Size: 2160, Bitrate: 16000},
+	{Size: 1080, Bitrate: 5000},
+	{Size: 720, Bitrate: 2800},
+	{Size: 480, Bitrate: 1400},
+}
+
+// HlsPath returns the cache folder for the HLS segments of a video file.
+func HlsPath(videoPath, fileHash string) string {
+	if len(fileHash) < 4 {
+		return ""
+	}
+
+	return filepath.Join(videoPath, "hls", fileHash[0:1], fileHash[1:2], fileHash[2:3], fileHash)
+}
+
+// HlsRenditionsFor returns the quality levels that are suitable for a video with the given dimensions.
+func HlsRenditionsFor(width, height int) (result []HlsRendition) {
+	size := width
+
+	if height < width {
+		size = height
+	}
+
+	// Assume HD if the dimensions are unknown.
+	if size <= 0 {
+		size = 720
+	}
+
+	for _, r := range HlsRenditions {
+		if r.Size <= size {
+			result = append(result, r)
+		}
+	}
+
+	// Keep the original size of small videos.
+	if len(result) == 0 {
+		lowest := HlsRenditions[len(HlsRenditions)-1]
+		result = append(result, HlsRendition{Size: size, Bitrate: lowest.Bitrate})
+	}
+
+	return result
+}
+
+// hlsResolution returns the width and height of a rendition, keeping the aspect ratio.
+func hlsResolution(width, height int, r HlsRendition) (int, int) {
+	even := func(v float64) int {
+		return int(math.Round(v/2) * 2)
+	}
+
+	if width <= 0 || height <= 0 {
+		return 0, r.Size
+	} else if height < width {
+		return even(float64(width) * float64(r.Size) / float64(height)), r.Size
+	}
+
+	return r.Size, even(float64(height) * float64(r.Size) / float64(width))
+}
+
+// HlsMasterPlaylist returns the master playlist that references the playlists of all renditions.
+func HlsMasterPlaylist(width, height int, renditions []HlsRendition) string {
+	var b strings.Builder
+
+	b.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n")
+
+	for _, r := range renditions {
+		w, h := hlsResolution(width, height, r)
+
+		// Bandwidth includes 128 kbit/s for the audio stream.
+		b.WriteString(fmt.Sprintf("#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%dx%d\n", (r.Bitrate+128)*1000, w, h))
+		b.WriteString(fmt.Sprintf("%d/%s\n", r.Size, HlsPlaylist))
+	}
+
+	return b.String()
+}
+
+// NeedsHls tests if a video file should be segmented for adaptive streaming.
+func (c *Convert) NeedsHls(f *MediaFile) bool {
+	if f == nil || !c.conf.FFmpegHls() || !f.IsVideo() {
+		return false
+	}
+
+	return f.MetaData().Duration >= HlsMinDuration
+}
+
+// HlsConvertCommand returns the command for segmenting a video file in the given quality.
+func (c *Convert) HlsConvertCommand(f *MediaFile, dir string, r HlsRendition) *exec.Cmd {
+	scale := fmt.Sprintf("scale=-2:%d", r.Size)
+
+	if f.Height() > f.Width() {
+		scale = fmt.Sprintf("scale=%d:-2", r.Size)
+	}
+
+	return exec.Command(
+		c.conf.FFmpegBin(),
+		"-y",
+		"-i", f.FileName(),
+		"-vf", scale,
+		"-c:v", DefaultAvcEncoder,
+		"-profile:v", "main",
+		"-pix_fmt", "yuv420p",
+		"-b:v", fmt.Sprintf("%dk", r.Bitrate),
+		"-maxrate", fmt.Sprintf("%dk", r.Bitrate),
+		"-bufsize", fmt.Sprintf("%dk", r.Bitrate*2),
+		"-c:a", "aac",
+		"-b:a", "128k",
+		"-ac", "2",
+		"-f", "hls",
+		"-hls_time", strconv.Itoa(HlsSegmentDuration),
+		"-hls_playlist_type", "vod",
+		"-hls_segment_filename", filepath.Join(dir, "segment_%04d.ts"),
+		filepath.Join(dir, HlsPlaylist),
+	)
+}
+
+// ToHls segments a video file for adaptive streaming and returns the file name of the master playlist.
+func (c *Convert) ToHls(f *MediaFile) (masterName string, err error) {
+	if f == nil {
+		return "", fmt.Errorf("convert: file is nil - you might have found a bug")
+	} else if !f.Exists() {
+		return "", fmt.Errorf("convert: %s not found", f.RelName(c.conf.OriginalsPath()))
+	} else if c.conf.DisableFFmpeg() {
+		return "", fmt.Errorf("convert: ffmpeg is disabled for segmenting %s", f.RelName(c.conf.OriginalsPath()))
+	}
+
+	dir := HlsPath(c.conf.VideoPath(), f.Hash())
+	masterName = filepath.Join(dir, HlsPlaylist)
+
+	// The master playlist is written last, so segmenting is complete if it exists.
+	if fs.FileExists(masterName) {
+		return masterName, nil
+	}
+
+	fileName := f.RelName(c.conf.OriginalsPath())
+	renditions := HlsRenditionsFor(f.Width(), f.Height())
+
+	log.Infof("convert: segmenting %s for adaptive streaming", sanitize.Log(fileName))
+
+	start := time.Now()
+
+	for _, r := range renditions {
+		renditionDir := filepath.Join(dir, strconv.Itoa(r.Size))
+
+		if err := os.MkdirAll(renditionDir, os.ModePerm); err != nil {
+			return "", err
+		}
+
+		cmd := c.HlsConvertCommand(f, renditionDir, r)
+
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			_ = os.RemoveAll(dir)
+
+			if stderr.String() != "" {
+				log.Debug(stderr.String())
+			}
+
+			log.Warnf("convert: failed segmenting %s [%s]", sanitize.Log(fileName), time.Since(start))
+
+			return "", errors.New(strings.TrimSpace(err.Error()))
+		}
+	}
+
+	if err := os.WriteFile(masterName, []byte(HlsMasterPlaylist(f.Width(), f.Height(), renditions)), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	log.Infof("convert: created %d hls renditions of %s [%s]", len(renditions), sanitize.Log(fileName), time.Since(start))
+
+	return masterName, nil
+}
+
+// PurgeHls removes cached video segments of files that no longer exist and returns the number of removed videos.
+func PurgeHls(videoPath string, dry bool) (removed int, err error) {
+	dirs, err := filepath.Glob(filepath.Join(videoPath, "hls", "*", "*", "*", "*"))
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, dir := range dirs {
+		if _, err := query.FileByHash(filepath.Base(dir)); err == nil {
+			continue
+		}
+
+		removed++
+
+		if dry {
+			log.Infof("purge: video segments %s would be removed", sanitize.Log(filepath.Base(dir)))
+		} else if err := os.RemoveAll(dir); err != nil {
+			log.Errorf("purge: %s (remove video segments)", err)
+		}
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,92 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestHlsPath(t *testing.T) {
+	assert.Equal(t, "/cache/videos/hls/a/c/a/acad9168fa6acc5c5c2965ddf6ec465ca42fd832", HlsPath("/cache/videos", "acad9168fa6acc5c5c2965ddf6ec465ca42fd832"))
+	assert.Equal(t, "", HlsPath("/cache/videos", "ac"))
+}
+
+func TestHlsRenditionsFor(t *testing.T) {
+	t.Run("4K", func(t *testing.T) {
+		assert.Len(t, HlsRenditionsFor(3840, 2160), 4)
+	})
+	t.Run("Portrait", func(t *testing.T) {
+		result := HlsRenditionsFor(1080, 1920)
+		assert.Len(t, result, 3)
+		assert.Equal(t, 1080, result[0].Size)
+	})
+	t.Run("Small", func(t *testing.T) {
+		result := HlsRenditionsFor(320, 240)
+		assert.Len(t, result, 1)
+		assert.Equal(t, 240, result[0].Size)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		result := HlsRenditionsFor(0, 0)
+		assert.Len(t, result, 2)
+		assert.Equal(t, 720, result[0].Size)
+	})
+}
+
+func TestHlsMasterPlaylist(t *testing.T) {
+	result := HlsMasterPlaylist(1920, 1080, HlsRenditionsFor(1920, 1080))
+
+	assert.Equal(t, "#EXTM3U\n#EXT-X-VERSION:3\n"+
+		"#EXT-X-STREAM-INF:BANDWIDTH=5128000,RESOLUTION=1920x1080\n1080/index.m3u8\n"+
+		"#EXT-X-STREAM-INF:BANDWIDTH=2928000,RESOLUTION=1280x720\n720/index.m3u8\n"+
+		"#EXT-X-STREAM-INF:BANDWIDTH=1528000,RESOLUTION=854x480\n480/index.m3u8\n", result)
+}
+
+func TestConvert_NeedsHls(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	assert.False(t, convert.NeedsHls(nil))
+
+	mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Disabled by default.
+	assert.False(t, convert.NeedsHls(mf))
+}
+
+func TestPurgeHls(t *testing.T) {
+	videoPath := t.TempDir()
+
+	orphan := HlsPath(videoPath, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd819")
+	indexed := HlsPath(videoPath, "acad9168fa6acc5c5c2965ddf6ec465ca42fd832")
+
+	for _, dir := range []string{orphan, indexed} {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("Dry", func(t *testing.T) {
+		removed, err := PurgeHls(videoPath, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		assert.True(t, fs.PathExists(orphan))
+	})
+	t.Run("Remove", func(t *testing.T) {
+		removed, err := PurgeHls(videoPath, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		assert.False(t, fs.PathExists(orphan))
+		assert.True(t, fs.PathExists(indexed))
+	})
+}
@@ -0,0 +1,86 @@
+package photoprism
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+// RawPreviewMinSize is the minimum width or height of embedded RAW previews in pixels,
+// smaller previews are ignored so that large thumbnails don't look blurry.
+const RawPreviewMinSize = 1024
+
+// RawPreview saves the JPEG preview embedded in a RAW file if it is large enough, so that
+// initial thumbnails can be created without running a RAW converter. No file is created if
+// this fails, so that the RAW converter can be used as fallback.
+func (c *Convert) RawPreview(f *MediaFile, jpegName string) error {
+	if f == nil {
+		return fmt.Errorf("convert: file is nil - you might have found a bug")
+	} else if !f.IsRaw() {
+		return fmt.Errorf("convert: %s is not a raw file", f.RelName(c.conf.OriginalsPath()))
+	}
+
+	file, err := os.Open(f.FileName())
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	preview, config, err := meta.RawPreview(file, info.Size())
+
+	if err != nil {
+		return fmt.Errorf("convert: %s in %s", err, f.RelName(c.conf.OriginalsPath()))
+	} else if config.Width < RawPreviewMinSize && config.Height < RawPreviewMinSize {
+		return fmt.Errorf("convert: embedded preview of %s is too small (%dx%d)", f.RelName(c.conf.OriginalsPath()), config.Width, config.Height)
+	}
+
+	// Write to a temporary file first, so that incomplete previews are never used.
+	tmpName := jpegName + ".tmp"
+
+	if err = c.savePreview(preview, tmpName, f.Orientation()); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	return os.Rename(tmpName, jpegName)
+}
+
+// savePreview saves an embedded JPEG preview, and rotates it based on the orientation of the RAW file.
+func (c *Convert) savePreview(preview io.Reader, fileName string, orientation int) error {
+	out, err := os.OpenFile(fileName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.ModePerm)
+
+	if err != nil {
+		return err
+	}
+
+	// Embedded previews don't have their own orientation, so they must be rotated as needed.
+	if orientation > 1 {
+		if img, decodeErr := imaging.Decode(preview); decodeErr != nil {
+			err = decodeErr
+		} else {
+			err = imaging.Encode(out, thumb.Rotate(img, orientation), imaging.JPEG, imaging.JPEGQuality(thumb.JpegQuality))
+		}
+	} else {
+		_, err = io.Copy(out, preview)
+	}
+
+	if err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,82 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestConvert_RawPreview(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	t.Run("Nil", func(t *testing.T) {
+		assert.Error(t, convert.RawPreview(nil, "preview.jpg"))
+	})
+	t.Run("NotRaw", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, convert.RawPreview(mf, "preview.jpg"))
+	})
+	t.Run("Dng", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "canon_eos_6d.dng"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jpegName := filepath.Join(conf.TempPath(), "canon_eos_6d.preview.jpg")
+
+		if err := os.MkdirAll(conf.TempPath(), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(jpegName)
+
+		if err := convert.RawPreview(mf, jpegName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, fs.FileExists(jpegName))
+
+		preview, err := NewMediaFile(jpegName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, preview.IsJpeg())
+		assert.Equal(t, 1024, preview.Width())
+		assert.Equal(t, 683, preview.Height())
+		assert.False(t, fs.FileExists(jpegName+".tmp"))
+	})
+	t.Run("NoPreview", func(t *testing.T) {
+		rawName := filepath.Join(conf.TempPath(), "no-preview.dng")
+		jpegName := filepath.Join(conf.TempPath(), "no-preview.jpg")
+
+		if err := os.WriteFile(rawName, make([]byte, 64*1024), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(rawName)
+
+		mf, err := NewMediaFile(rawName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// No file must be created, so that the RAW converter is used as fallback.
+		assert.Error(t, convert.RawPreview(mf, jpegName))
+		assert.False(t, fs.FileExists(jpegName))
+	})
+}
@@ -0,0 +1,182 @@
+package photoprism
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Sprite sheet file names.
+const (
+	SpriteImage = "sprite.jpg"
+	SpriteInfo  = "sprite.json"
+)
+
+// SpriteColumns is the number of frames in each row of a sprite sheet.
+const SpriteColumns = 10
+
+// SpriteRows is the number of frame rows of a sprite sheet.
+const SpriteRows = 10
+
+// SpriteTileWidth is the width of a single sprite sheet frame in pixels.
+const SpriteTileWidth = 160
+
+// Sprite describes a sprite sheet, so that clients can show the frame matching the position of the pointer.
+type Sprite struct {
+	Columns  int     `json:"Columns"`
+	Rows     int     `json:"Rows"`
+	Frames   int     `json:"Frames"`
+	Width    int     `json:"Width"`
+	Height   int     `json:"Height"`
+	Interval float64 `json:"Interval"`
+}
+
+// NewSprite returns the sprite sheet layout for a video with the given dimensions and duration.
+func NewSprite(width, height int, duration time.Duration) Sprite {
+	result := Sprite{
+		Columns: SpriteColumns,
+		Rows:    SpriteRows,
+		Frames:  SpriteColumns * SpriteRows,
+		Width:   SpriteTileWidth,
+		Height:  SpriteTileWidth * 9 / 16,
+	}
+
+	// Keep the aspect ratio, frames are scaled to an even height.
+	if width > 0 && height > 0 {
+		result.Height = int(math.Round(float64(SpriteTileWidth*height)/float64(width)/2) * 2)
+	}
+
+	if duration > 0 {
+		result.Interval = duration.Seconds() / float64(result.Frames)
+	}
+
+	return result
+}
+
+// SpritePath returns the cache folder for the sprite sheet of a video file.
+func SpritePath(videoPath, fileHash string) string {
+	if len(fileHash) < 4 {
+		return ""
+	}
+
+	return filepath.Join(videoPath, "sprite", fileHash[0:1], fileHash[1:2], fileHash[2:3], fileHash)
+}
+
+// NeedsSprite tests if a sprite sheet should be created for a video file.
+func (c *Convert) NeedsSprite(f *MediaFile) bool {
+	if f == nil || !c.conf.FFmpegSprite() || !f.IsVideo() {
+		return false
+	}
+
+	return f.MetaData().Duration > 0
+}
+
+// SpriteCommand returns the command for creating a sprite sheet of a video file.
+func (c *Convert) SpriteCommand(f *MediaFile, fileName string, sprite Sprite) *exec.Cmd {
+	filter := fmt.Sprintf("fps=1/%.3f,scale=%d:%d,tile=%dx%d", sprite.Interval, sprite.Width, sprite.Height, sprite.Columns, sprite.Rows)
+
+	return exec.Command(
+		c.conf.FFmpegBin(),
+		"-y",
+		"-i", f.FileName(),
+		"-an",
+		"-vf", filter,
+		"-frames:v", "1",
+		"-q:v", "5",
+		fileName,
+	)
+}
+
+// ToSprite creates a sprite sheet of a video file for preview scrubbing and returns its file name.
+func (c *Convert) ToSprite(f *MediaFile) (spriteName string, err error) {
+	if f == nil {
+		return "", fmt.Errorf("convert: file is nil - you might have found a bug")
+	} else if !f.Exists() {
+		return "", fmt.Errorf("convert: %s not found", f.RelName(c.conf.OriginalsPath()))
+	} else if c.conf.DisableFFmpeg() {
+		return "", fmt.Errorf("convert: ffmpeg is disabled for creating a sprite of %s", f.RelName(c.conf.OriginalsPath()))
+	}
+
+	dir := SpritePath(c.conf.VideoPath(), f.Hash())
+	spriteName = filepath.Join(dir, SpriteImage)
+	infoName := filepath.Join(dir, SpriteInfo)
+
+	// The sprite info is written last, so the sprite sheet is complete if it exists.
+	if fs.FileExists(infoName) {
+		return spriteName, nil
+	}
+
+	sprite := NewSprite(f.Width(), f.Height(), f.MetaData().Duration)
+
+	if sprite.Interval <= 0 {
+		return "", fmt.Errorf("convert: unknown duration of %s", f.RelName(c.conf.OriginalsPath()))
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	fileName := f.RelName(c.conf.OriginalsPath())
+	start := time.Now()
+
+	cmd := c.SpriteCommand(f, spriteName, sprite)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		_ = os.RemoveAll(dir)
+
+		if stderr.String() != "" {
+			log.Debug(stderr.String())
+		}
+
+		return "", errors.New(strings.TrimSpace(err.Error()))
+	}
+
+	if data, err := json.Marshal(sprite); err != nil {
+		return "", err
+	} else if err := os.WriteFile(infoName, data, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	log.Infof("convert: created sprite of %s [%s]", sanitize.Log(fileName), time.Since(start))
+
+	return spriteName, nil
+}
+
+// PurgeSprites removes cached sprite sheets of files that no longer exist and returns the number of removed sprites.
+func PurgeSprites(videoPath string, dry bool) (removed int, err error) {
+	dirs, err := filepath.Glob(filepath.Join(videoPath, "sprite", "*", "*", "*", "*"))
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, dir := range dirs {
+		if _, err := query.FileByHash(filepath.Base(dir)); err == nil {
+			continue
+		}
+
+		removed++
+
+		if dry {
+			log.Infof("purge: video sprite %s would be removed", sanitize.Log(filepath.Base(dir)))
+		} else if err := os.RemoveAll(dir); err != nil {
+			log.Errorf("purge: %s (remove video sprite)", err)
+		}
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,91 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestSpritePath(t *testing.T) {
+	assert.Equal(t, "/cache/videos/sprite/a/c/a/acad9168fa6acc5c5c2965ddf6ec465ca42fd832", SpritePath("/cache/videos", "acad9168fa6acc5c5c2965ddf6ec465ca42fd832"))
+	assert.Equal(t, "", SpritePath("/cache/videos", "ac"))
+}
+
+func TestNewSprite(t *testing.T) {
+	t.Run("Landscape", func(t *testing.T) {
+		result := NewSprite(1920, 1080, 100*time.Second)
+		assert.Equal(t, 100, result.Frames)
+		assert.Equal(t, 160, result.Width)
+		assert.Equal(t, 90, result.Height)
+		assert.Equal(t, 1.0, result.Interval)
+	})
+	t.Run("Portrait", func(t *testing.T) {
+		result := NewSprite(1080, 1920, 50*time.Second)
+		assert.Equal(t, 284, result.Height)
+		assert.Equal(t, 0.5, result.Interval)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		result := NewSprite(0, 0, 0)
+		assert.Equal(t, 90, result.Height)
+		assert.Equal(t, 0.0, result.Interval)
+	})
+}
+
+func TestConvert_NeedsSprite(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	assert.False(t, convert.NeedsSprite(nil))
+
+	mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Disabled by default.
+	assert.False(t, convert.NeedsSprite(mf))
+}
+
+func TestConvert_SpriteCommand(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := convert.SpriteCommand(mf, "/tmp/sprite.jpg", NewSprite(1920, 1080, 2*time.Minute))
+
+	assert.True(t, strings.Contains(cmd.String(), "-vf fps=1/1.200,scale=160:90,tile=10x10 -frames:v 1"))
+	assert.True(t, strings.HasSuffix(cmd.String(), "/tmp/sprite.jpg"))
+}
+
+func TestPurgeSprites(t *testing.T) {
+	videoPath := t.TempDir()
+
+	orphan := SpritePath(videoPath, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd819")
+	indexed := SpritePath(videoPath, "acad9168fa6acc5c5c2965ddf6ec465ca42fd832")
+
+	for _, dir := range []string{orphan, indexed} {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed, err := PurgeSprites(videoPath, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.False(t, fs.PathExists(orphan))
+	assert.True(t, fs.PathExists(indexed))
+}
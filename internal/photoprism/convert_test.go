@@ -113,6 +113,52 @@ func TestConvert_ToJpeg(t *testing.T) {
 	})
 }
 
+func TestConvert_ToJpegPages(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	fileName := filepath.Join(conf.OriginalsPath(), "pages", "multipage.tif")
+
+	if err := fs.Copy("../thumb/testdata/multipage.tif", fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(filepath.Dir(fileName))
+	defer os.RemoveAll(filepath.Join(conf.SidecarPath(), "pages"))
+
+	mf, err := NewMediaFile(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pages, err := convert.ToJpegPages(mf)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, pages, 2) {
+		assert.Equal(t, filepath.Join(conf.SidecarPath(), "pages", "multipage.tif.p2.jpg"), pages[0].FileName())
+		assert.Equal(t, filepath.Join(conf.SidecarPath(), "pages", "multipage.tif.p3.jpg"), pages[1].FileName())
+		assert.True(t, pages[0].IsJpeg())
+		assert.Equal(t, 8, pages[0].Width())
+	}
+
+	t.Run("SinglePage", func(t *testing.T) {
+		mf, err := NewMediaFile("../thumb/testdata/example.tif")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pages, err := convert.ToJpegPages(mf)
+
+		assert.NoError(t, err)
+		assert.Empty(t, pages)
+	})
+}
+
 func TestConvert_ToJson(t *testing.T) {
 	conf := config.TestConfig()
 	convert := NewConvert(conf)
@@ -245,6 +291,54 @@ func TestConvert_Start(t *testing.T) {
 	assert.NotEqual(t, oldHash, newHash, "Fingerprint of old and new JPEG file must not be the same")
 }
 
+func TestConvert_Keyframes(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	t.Run("gopher-video.mp4", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "gopher-video.mp4"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		keyframes, err := convert.Keyframes(mf, 3, t.TempDir())
+
+		if !conf.FFmpegEnabled() {
+			assert.Error(t, err)
+			return
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, keyframes, 3)
+
+		for _, fileName := range keyframes {
+			assert.True(t, fs.FileExists(fileName))
+		}
+	})
+
+	t.Run("jpg", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "cat_black.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		keyframes, err := convert.Keyframes(mf, 3, t.TempDir())
+
+		assert.Error(t, err)
+		assert.Empty(t, keyframes)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		keyframes, err := convert.Keyframes(nil, 3, t.TempDir())
+
+		assert.Error(t, err)
+		assert.Empty(t, keyframes)
+	})
+}
+
 func TestConvert_AvcBitrate(t *testing.T) {
 	conf := config.TestConfig()
 	convert := NewConvert(conf)
@@ -3,6 +3,7 @@ package photoprism
 import (
 	"strings"
 
+	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
@@ -12,31 +13,54 @@ type ConvertJob struct {
 }
 
 func ConvertWorker(jobs <-chan ConvertJob) {
-	logError := func(err error, job ConvertJob) {
-		fileName := job.file.RelName(job.convert.conf.OriginalsPath())
-		log.Errorf("convert: %s for %s", strings.TrimSpace(err.Error()), sanitize.Log(fileName))
+	for job := range jobs {
+		mutex.Maintenance.Begin()
+		convertJob(job)
+		mutex.Maintenance.End()
 	}
+}
 
-	for job := range jobs {
-		switch {
-		case job.file == nil:
-			continue
-		case job.convert == nil:
-			continue
-		case job.file.IsVideo():
-			_, _ = job.convert.ToJson(job.file)
-
-			if _, err := job.convert.ToJpeg(job.file); err != nil {
-				logError(err, job)
-			} else if metaData := job.file.MetaData(); metaData.CodecAvc() {
-				continue
-			} else if _, err := job.convert.ToAvc(job.file, job.convert.conf.FFmpegEncoder()); err != nil {
-				logError(err, job)
-			}
-		default:
-			if _, err := job.convert.ToJpeg(job.file); err != nil {
-				logError(err, job)
+// logConvertError logs a conversion error with the relative file name.
+func logConvertError(err error, job ConvertJob) {
+	fileName := job.file.RelName(job.convert.conf.OriginalsPath())
+	log.Errorf("convert: %s for %s", strings.TrimSpace(err.Error()), sanitize.Log(fileName))
+}
+
+// convertJob converts a media file, e.g. to JPEG or AVC.
+func convertJob(job ConvertJob) {
+	switch {
+	case job.file == nil:
+		return
+	case job.convert == nil:
+		return
+	case job.file.IsVideo():
+		_, _ = job.convert.ToJson(job.file)
+
+		if _, err := job.convert.ToJpeg(job.file); err != nil {
+			logConvertError(err, job)
+			return
+		} else if metaData := job.file.MetaData(); metaData.CodecAvc() {
+			// Do nothing.
+		} else if _, err := job.convert.ToAvc(job.file, job.convert.conf.FFmpegEncoder()); err != nil {
+			logConvertError(err, job)
+		}
+
+		// Create a sprite sheet for preview scrubbing if enabled.
+		if job.convert.NeedsSprite(job.file) {
+			if _, err := job.convert.ToSprite(job.file); err != nil {
+				logConvertError(err, job)
 			}
 		}
+
+		// Segment long videos for adaptive streaming if enabled.
+		if !job.convert.NeedsHls(job.file) {
+			return
+		} else if _, err := job.convert.ToHls(job.file); err != nil {
+			logConvertError(err, job)
+		}
+	default:
+		if _, err := job.convert.ToJpeg(job.file); err != nil {
+			logConvertError(err, job)
+		}
 	}
 }
@@ -11,7 +11,7 @@ import (
 
 // Delete permanently removes a photo and all its files.
 func Delete(p entity.Photo) error {
-	yamlFileName := p.YamlFileName(Config().OriginalsPath(), Config().SidecarPath())
+	yamlFileNames := p.YamlFileNames(Config().OriginalsPath(), Config().SidecarPath())
 
 	// Permanently remove photo from index.
 	files, err := p.DeletePermanently()
@@ -41,14 +41,16 @@ func Delete(p entity.Photo) error {
 		}
 
 		if fs.FileExists(fileName) {
-			logWarn("delete", os.Remove(fileName))
+			logWarn("delete", fs.Remove(fileName))
 		}
 	}
 
-	// Remove sidecar backup.
-	if fs.FileExists(yamlFileName) {
-		log.Debugf("delete: removing yaml sidecar %s", sanitize.Log(filepath.Base(yamlFileName)))
-		logWarn("delete", os.Remove(yamlFileName))
+	// Remove sidecar backups in all layouts.
+	for _, yamlFileName := range yamlFileNames {
+		if fs.FileExists(yamlFileName) {
+			log.Debugf("delete: removing yaml sidecar %s", sanitize.Log(filepath.Base(yamlFileName)))
+			logWarn("delete", os.Remove(yamlFileName))
+		}
 	}
 
 	return nil
@@ -0,0 +1,122 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// DirModTimeMinAge is the minimum age of a directory modification time that can be trusted, as changes
+// within the same second may not be detected otherwise.
+const DirModTimeMinAge = 2 * time.Second
+
+// DirModTime returns the directory modification time in seconds, or 0 if it is unknown or too recent.
+func DirModTime(dirName string) int64 {
+	info, err := os.Stat(dirName)
+
+	if err != nil || !info.IsDir() {
+		return 0
+	} else if time.Since(info.ModTime()) < DirModTimeMinAge {
+		return 0
+	}
+
+	return info.ModTime().Unix()
+}
+
+// dirTimes compares directory modification times with those found during the last complete scan. As files can
+// only be added, removed, or renamed if the modification time of their directory changes, files in unchanged
+// directories don't need to be checked again. Changes in subdirectories do not affect the parent directory.
+type dirTimes struct {
+	root     string
+	recorded map[string]int64
+	scanned  map[string]int64
+	cache    map[string]bool
+}
+
+// newDirTimes returns the directory modification times found during the last complete scan of a root.
+func newDirTimes(root string) *dirTimes {
+	recorded, err := query.FolderModTimes(root)
+
+	if err != nil {
+		log.Warnf("folders: %s (find modification times)", err)
+	}
+
+	return &dirTimes{
+		root:     root,
+		recorded: recorded,
+		scanned:  make(map[string]int64),
+		cache:    make(map[string]bool),
+	}
+}
+
+// dirRelName returns the relative directory name as stored in the folders table.
+func dirRelName(relName string) string {
+	relName = strings.Trim(relName, string(os.PathSeparator))
+
+	if relName == "." {
+		return ""
+	}
+
+	return relName
+}
+
+// Unchanged tests if the directory modification time is the same as during the last complete scan.
+func (d *dirTimes) Unchanged(relName string, modTime int64) bool {
+	if modTime <= 0 {
+		return false
+	}
+
+	recorded, ok := d.recorded[dirRelName(relName)]
+
+	return ok && recorded == modTime
+}
+
+// FileUnchanged tests if the directory containing the file is unchanged since the last complete scan.
+func (d *dirTimes) FileUnchanged(fileName, relName string) bool {
+	dirName := dirRelName(filepath.Dir(relName))
+
+	if unchanged, ok := d.cache[dirName]; ok {
+		return unchanged
+	}
+
+	unchanged := d.Unchanged(dirName, DirModTime(filepath.Dir(fileName)))
+	d.cache[dirName] = unchanged
+
+	return unchanged
+}
+
+// Scanned remembers the directory modification time, so that it can be saved once the scan is complete.
+func (d *dirTimes) Scanned(relName string, modTime int64) {
+	if modTime <= 0 {
+		return
+	}
+
+	d.scanned[dirRelName(relName)] = modTime
+}
+
+// Discard forgets the modification times of scanned directories, e.g. if they contain missing files.
+func (d *dirTimes) Discard(dirs map[string]bool) {
+	for relName := range dirs {
+		delete(d.scanned, relName)
+	}
+}
+
+// Save stores the modification times of scanned directories that have changed and returns their number.
+func (d *dirTimes) Save() (updated int) {
+	for relName, modTime := range d.scanned {
+		if d.recorded[relName] == modTime {
+			continue
+		} else if err := entity.UpdateFolderModTime(d.root, relName, modTime); err != nil {
+			log.Warnf("folders: %s (update modification time)", err)
+			continue
+		}
+
+		updated++
+	}
+
+	return updated
+}
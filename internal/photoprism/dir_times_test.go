@@ -0,0 +1,71 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestDirModTime(t *testing.T) {
+	t.Run("Old", func(t *testing.T) {
+		dir := t.TempDir()
+		modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+		if err := os.Chtimes(dir, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, modTime.Unix(), DirModTime(dir))
+	})
+	t.Run("TooRecent", func(t *testing.T) {
+		assert.Equal(t, int64(0), DirModTime(t.TempDir()))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Equal(t, int64(0), DirModTime("testdata/xxx"))
+	})
+}
+
+func TestDirTimes(t *testing.T) {
+	t.Run("Unchanged", func(t *testing.T) {
+		d := &dirTimes{root: entity.RootOriginals, recorded: map[string]int64{"": 100, "2020/01": 200}}
+
+		assert.True(t, d.Unchanged("", 100))
+		assert.True(t, d.Unchanged(".", 100))
+		assert.True(t, d.Unchanged("/2020/01/", 200))
+		assert.False(t, d.Unchanged("2020/01", 201))
+		assert.False(t, d.Unchanged("2020/02", 200))
+		assert.False(t, d.Unchanged("2020/01", 0))
+	})
+	t.Run("FileUnchanged", func(t *testing.T) {
+		dir := t.TempDir()
+		modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+		if err := os.Chtimes(dir, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+
+		d := &dirTimes{
+			root:     entity.RootOriginals,
+			recorded: map[string]int64{"": modTime.Unix()},
+			cache:    make(map[string]bool),
+		}
+
+		assert.True(t, d.FileUnchanged(filepath.Join(dir, "a.jpg"), "a.jpg"))
+		assert.False(t, d.FileUnchanged(filepath.Join(dir, "xxx", "b.jpg"), "xxx/b.jpg"))
+	})
+	t.Run("ScannedAndDiscard", func(t *testing.T) {
+		d := &dirTimes{root: entity.RootOriginals, recorded: map[string]int64{}, scanned: make(map[string]int64)}
+
+		d.Scanned("2020/01", 100)
+		d.Scanned("2020/02", 200)
+		d.Scanned("2020/03", 0)
+		d.Discard(map[string]bool{"2020/02": true})
+
+		assert.Equal(t, map[string]int64{"2020/01": 100}, d.scanned)
+	})
+}
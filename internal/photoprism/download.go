@@ -0,0 +1,279 @@
+package photoprism
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/h2non/filetype"
+
+	"github.com/photoprism/photoprism/pkg/dial"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// DownloadTimeout is the maximum duration of a single remote download.
+var DownloadTimeout = 10 * time.Minute
+
+// DownloadUserAgent is the user agent sent with remote download requests.
+var DownloadUserAgent = "PhotoPrism/1.0"
+
+// ErrUnsupportedDownload is returned if a downloaded file is neither an image nor a video.
+var ErrUnsupportedDownload = errors.New("unsupported file type")
+
+// feedDocument represents the elements of RSS 2.0 and Atom feeds that may reference images.
+type feedDocument struct {
+	XMLName xml.Name
+	Items   []feedItem `xml:"channel>item"`
+	Entries []feedItem `xml:"entry"`
+}
+
+// feedItem represents an RSS item or Atom entry.
+type feedItem struct {
+	Link []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+		Type string `xml:"type,attr"`
+		Text string `xml:",chardata"`
+	} `xml:"link"`
+	Enclosures []feedMedia `xml:"enclosure"`
+	Content    []feedMedia `xml:"content"`
+	Group      []feedMedia `xml:"group>content"`
+}
+
+// feedMedia represents an RSS enclosure or Media RSS content element.
+type feedMedia struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Medium string `xml:"medium,attr"`
+}
+
+// media tests if the enclosure or content element references an image or video.
+func (m feedMedia) media() bool {
+	if m.URL == "" {
+		return false
+	}
+
+	switch {
+	case m.Medium == "image" || m.Medium == "video":
+		return true
+	case strings.HasPrefix(m.Type, "image/") || strings.HasPrefix(m.Type, "video/"):
+		return true
+	case m.Type == "" && m.Medium == "":
+		return downloadMedia(m.URL)
+	}
+
+	return false
+}
+
+// downloadMedia tests if the url path has the file extension of an image or video.
+func downloadMedia(rawUrl string) bool {
+	u, err := url.Parse(rawUrl)
+
+	if err != nil {
+		return false
+	}
+
+	return fs.IsMedia(path.Base(u.Path))
+}
+
+// ParseFeed returns the image and video urls found in an RSS or Atom feed.
+func ParseFeed(data []byte) (urls []string, err error) {
+	doc := feedDocument{}
+
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return urls, fmt.Errorf("invalid feed (%s)", err)
+	}
+
+	switch strings.ToLower(doc.XMLName.Local) {
+	case "rss", "feed":
+	default:
+		return urls, fmt.Errorf("unknown feed format %s", sanitize.Log(doc.XMLName.Local))
+	}
+
+	found := make(map[string]bool)
+
+	add := func(u string) {
+		if u = strings.TrimSpace(u); u != "" && !found[u] {
+			found[u] = true
+			urls = append(urls, u)
+		}
+	}
+
+	for _, item := range append(doc.Items, doc.Entries...) {
+		n := len(urls)
+
+		for _, list := range [][]feedMedia{item.Enclosures, item.Content, item.Group} {
+			for _, m := range list {
+				if m.media() {
+					add(m.URL)
+				}
+			}
+		}
+
+		// Use links to media files if the item has no enclosures.
+		if len(urls) > n {
+			continue
+		}
+
+		for _, l := range item.Link {
+			href := l.Href
+
+			if href == "" {
+				href = l.Text
+			}
+
+			if l.Rel == "enclosure" && (strings.HasPrefix(l.Type, "image/") || strings.HasPrefix(l.Type, "video/")) {
+				add(href)
+			} else if downloadMedia(href) {
+				add(href)
+			}
+		}
+	}
+
+	return urls, nil
+}
+
+// downloadGet performs a GET request and returns the response if the status is OK.
+func downloadGet(rawUrl string) (*http.Response, error) {
+	// Remote downloads must not reach local services, also after redirects.
+	client := dial.Client(DownloadTimeout)
+
+	req, err := http.NewRequest(http.MethodGet, rawUrl, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", DownloadUserAgent)
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// DownloadFeed fetches an RSS or Atom feed and returns the image and video urls it contains.
+func DownloadFeed(rawUrl string, limit int64) (urls []string, err error) {
+	resp, err := downloadGet(rawUrl)
+
+	if err != nil {
+		return urls, err
+	}
+
+	defer resp.Body.Close()
+
+	if limit <= 0 {
+		limit = 10 * 1024 * 1024
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit))
+
+	if err != nil {
+		return urls, err
+	}
+
+	return ParseFeed(data)
+}
+
+// downloadName returns a file name for the downloaded file based on the response headers or url path.
+func downloadName(resp *http.Response, rawUrl string) string {
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		if name := sanitize.FileName(filepath.Base(params["filename"])); name != "" && name != "." {
+			return name
+		}
+	}
+
+	if u, err := url.Parse(rawUrl); err == nil {
+		if name := sanitize.FileName(path.Base(u.Path)); name != "" && name != "." && name != "/" {
+			return name
+		}
+	}
+
+	return "download"
+}
+
+// DownloadFile saves a remote image or video in the given folder and returns its file name.
+// Files larger than limit bytes are rejected, a limit of zero or less means unlimited.
+func DownloadFile(rawUrl, dir string, limit int64) (fileName string, err error) {
+	resp, err := downloadGet(rawUrl)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if limit > 0 && resp.ContentLength > limit {
+		return "", fmt.Errorf("file size exceeds limit")
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	fileName = filepath.Join(dir, downloadName(resp, rawUrl))
+
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		return "", err
+	}
+
+	var body io.Reader = resp.Body
+
+	if limit > 0 {
+		body = io.LimitReader(resp.Body, limit+1)
+	}
+
+	n, err := io.Copy(f, body)
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(fileName)
+		return "", err
+	} else if limit > 0 && n > limit {
+		_ = os.Remove(fileName)
+		return "", fmt.Errorf("file size exceeds limit")
+	}
+
+	if fs.IsMedia(fileName) {
+		return fileName, nil
+	}
+
+	// Add the file extension based on the file content if needed.
+	if kind, err := filetype.MatchFile(fileName); err == nil && kind != filetype.Unknown {
+		newName := fileName + "." + kind.Extension
+
+		if fs.IsMedia(newName) {
+			if err := os.Rename(fileName, newName); err != nil {
+				_ = os.Remove(fileName)
+				return "", err
+			}
+
+			return newName, nil
+		}
+	}
+
+	_ = os.Remove(fileName)
+
+	return "", ErrUnsupportedDownload
+}
@@ -0,0 +1,149 @@
+package photoprism
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/dial"
+)
+
+func TestParseFeed(t *testing.T) {
+	t.Run("RSS", func(t *testing.T) {
+		data := []byte(`<?xml version="1.0"?>
+<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/">
+ <channel>
+  <title>Photos</title>
+  <item>
+   <title>Sunset</title>
+   <link>https://example.com/posts/sunset</link>
+   <enclosure url="https://example.com/sunset.jpg" type="image/jpeg" length="12345"/>
+  </item>
+  <item>
+   <title>Beach</title>
+   <media:content url="https://example.com/media/12345" medium="image"/>
+   <media:content url="https://example.com/beach.mp3" type="audio/mpeg"/>
+  </item>
+  <item>
+   <title>Forest</title>
+   <link>https://example.com/forest.png</link>
+  </item>
+  <item>
+   <title>Duplicate</title>
+   <enclosure url="https://example.com/sunset.jpg" type="image/jpeg"/>
+  </item>
+ </channel>
+</rss>`)
+
+		urls, err := ParseFeed(data)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"https://example.com/sunset.jpg", "https://example.com/media/12345", "https://example.com/forest.png"}, urls)
+	})
+	t.Run("Atom", func(t *testing.T) {
+		data := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+ <title>Photos</title>
+ <entry>
+  <title>Mountains</title>
+  <link rel="alternate" href="https://example.com/posts/mountains"/>
+  <link rel="enclosure" type="image/jpeg" href="https://example.com/download?id=1"/>
+ </entry>
+ <entry>
+  <title>Video</title>
+  <link href="https://example.com/clip.mp4"/>
+ </entry>
+</feed>`)
+
+		urls, err := ParseFeed(data)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"https://example.com/download?id=1", "https://example.com/clip.mp4"}, urls)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseFeed([]byte("<html><body>foo</body></html>"))
+		assert.Error(t, err)
+		_, err = ParseFeed([]byte("foo"))
+		assert.Error(t, err)
+	})
+}
+
+func TestDownloadFile(t *testing.T) {
+	conf := config.TestConfig()
+
+	example := filepath.Join(conf.ExamplesPath(), "elephants.jpg")
+	dir := filepath.Join(conf.TempPath(), "download-test")
+
+	defer os.RemoveAll(dir)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/elephants.jpg", "/photo":
+			http.ServeFile(w, r, example)
+		case "/text.txt":
+			_, _ = w.Write([]byte("hello world"))
+		case "/redirect":
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	defer server.Close()
+
+	t.Run("Local", func(t *testing.T) {
+		_, err := DownloadFile(server.URL+"/elephants.jpg", dir, -1)
+		assert.ErrorIs(t, err, dial.ErrPrivate)
+	})
+
+	// The test server runs on a local address.
+	dial.Allow("127.0.0.1")
+
+	t.Run("Redirect", func(t *testing.T) {
+		_, err := DownloadFile(server.URL+"/redirect", dir, -1)
+		assert.ErrorIs(t, err, dial.ErrPrivate)
+	})
+	t.Run("Success", func(t *testing.T) {
+		fileName, err := DownloadFile(server.URL+"/elephants.jpg", dir, -1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, filepath.Join(dir, "elephants.jpg"), fileName)
+		assert.FileExists(t, fileName)
+	})
+	t.Run("NoExtension", func(t *testing.T) {
+		fileName, err := DownloadFile(server.URL+"/photo", dir, -1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, filepath.Join(dir, "photo.jpg"), fileName)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := DownloadFile(server.URL+"/text.txt", dir, -1)
+		assert.Equal(t, ErrUnsupportedDownload, err)
+		assert.NoFileExists(t, filepath.Join(dir, "text.txt"))
+	})
+	t.Run("TooLarge", func(t *testing.T) {
+		_, err := DownloadFile(server.URL+"/elephants.jpg", filepath.Join(dir, "large"), 100)
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := DownloadFile(server.URL+"/missing.jpg", dir, -1)
+		assert.EqualError(t, err, "download failed with status 404")
+	})
+}
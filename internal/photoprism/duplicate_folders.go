@@ -0,0 +1,256 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// DuplicateFile represents a file in a duplicate folder and the identical copy that is kept.
+type DuplicateFile struct {
+	Name    string `json:"Name"`
+	Copy    string `json:"Copy"`
+	Hash    string `json:"Hash"`
+	Size    int64  `json:"Size"`
+	Indexed bool   `json:"Indexed"`
+}
+
+// DuplicateFolder represents a folder whose files all have identical copies in other folders.
+type DuplicateFolder struct {
+	Path  string          `json:"Path"`
+	Files []DuplicateFile `json:"Files"`
+	Size  int64           `json:"Size"`
+}
+
+// DuplicateFolders represents a list of duplicate folders.
+type DuplicateFolders []DuplicateFolder
+
+// CopyPaths returns the folders containing the copies of the files, sorted by name.
+func (m DuplicateFolder) CopyPaths() (paths []string) {
+	found := make(map[string]bool)
+
+	for _, f := range m.Files {
+		if dir := folderPath(f.Copy); !found[dir] {
+			found[dir] = true
+			paths = append(paths, dir)
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+// folderPath returns the folder of a file name relative to originals, or "" for the root folder.
+func folderPath(fileName string) string {
+	if dir := filepath.Dir(fileName); dir != "." {
+		return dir
+	}
+
+	return ""
+}
+
+// originalsEntry represents an indexed or duplicate file in originals.
+type originalsEntry struct {
+	Name    string
+	Hash    string
+	Size    int64
+	Indexed bool
+}
+
+// originalsEntries returns the files and exact duplicates found in originals while indexing.
+func originalsEntries() (entries []originalsEntry, err error) {
+	var files entity.Files
+	var duplicates entity.Duplicates
+
+	if err = entity.Db().Where("file_root = ? AND file_missing = 0 AND file_hash <> ''", entity.RootOriginals).Find(&files).Error; err != nil {
+		return entries, err
+	} else if err = entity.Db().Where("file_root = ? AND file_hash <> ''", entity.RootOriginals).Find(&duplicates).Error; err != nil {
+		return entries, err
+	}
+
+	for _, f := range files {
+		entries = append(entries, originalsEntry{Name: f.FileName, Hash: f.FileHash, Size: f.FileSize, Indexed: true})
+	}
+
+	for _, d := range duplicates {
+		entries = append(entries, originalsEntry{Name: d.FileName, Hash: d.FileHash, Size: d.FileSize})
+	}
+
+	return entries, nil
+}
+
+// folderFiles returns the number of visible files in a folder, or -1 if it can't be read.
+func folderFiles(dir string) int {
+	list, err := os.ReadDir(dir)
+
+	if err != nil {
+		return -1
+	}
+
+	count := 0
+
+	for _, e := range list {
+		if e.Type().IsRegular() && !fs.FileNameHidden(e.Name()) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// FindDuplicateFolders finds folders in originals whose files all have identical copies in other folders,
+// based on the checksums stored in the index. Folders that contain files which haven't been indexed, and
+// folders containing the only copies of duplicate folders are skipped. The optional path limits the search.
+func FindDuplicateFolders(pathName string) (result DuplicateFolders, err error) {
+	pathName = strings.Trim(pathName, "/")
+
+	entries, err := originalsEntries()
+
+	if err != nil {
+		return result, err
+	}
+
+	byFolder := make(map[string][]originalsEntry)
+	byHash := make(map[string][]originalsEntry)
+
+	for _, e := range entries {
+		byFolder[folderPath(e.Name)] = append(byFolder[folderPath(e.Name)], e)
+		byHash[e.Hash] = append(byHash[e.Hash], e)
+	}
+
+	for hash := range byHash {
+		sort.Slice(byHash[hash], func(i, j int) bool { return byHash[hash][i].Name < byHash[hash][j].Name })
+	}
+
+	folders := make([]string, 0, len(byFolder))
+
+	for dir := range byFolder {
+		if pathName == "" || dir == pathName || strings.HasPrefix(dir, pathName+"/") {
+			folders = append(folders, dir)
+		}
+	}
+
+	// Folders with names like "Photos (Copy)" sort after the original folder and are checked first.
+	sort.Sort(sort.Reverse(sort.StringSlice(folders)))
+
+	duplicate := make(map[string]bool)
+	kept := make(map[string]bool)
+
+	for _, dir := range folders {
+		if kept[dir] {
+			continue
+		} else if n := folderFiles(FileName(entity.RootOriginals, dir)); n != len(byFolder[dir]) {
+			continue
+		}
+
+		folder := DuplicateFolder{Path: dir}
+
+		for _, e := range byFolder[dir] {
+			for _, c := range byHash[e.Hash] {
+				if copyDir := folderPath(c.Name); copyDir == dir || duplicate[copyDir] {
+					continue
+				} else if !fs.FileExists(FileName(entity.RootOriginals, c.Name)) {
+					continue
+				}
+
+				folder.Files = append(folder.Files, DuplicateFile{Name: e.Name, Copy: c.Name, Hash: e.Hash, Size: e.Size, Indexed: e.Indexed})
+				folder.Size += e.Size
+
+				break
+			}
+		}
+
+		if len(folder.Files) < len(byFolder[dir]) {
+			continue
+		}
+
+		duplicate[dir] = true
+
+		for _, copyDir := range folder.CopyPaths() {
+			kept[copyDir] = true
+		}
+
+		result = append(result, folder)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+
+	return result, nil
+}
+
+// MergeDuplicateFolder removes the files of a duplicate folder, or replaces them with symbolic links to their
+// copies, and updates the index so that indexed files refer to the copies that are kept.
+func MergeDuplicateFolder(folder DuplicateFolder, symlinks bool) error {
+	if err := fs.Writable(FileName(entity.RootOriginals, folder.Path)); err != nil {
+		return err
+	}
+
+	for _, f := range folder.Files {
+		srcName := FileName(entity.RootOriginals, f.Name)
+		copyName := FileName(entity.RootOriginals, f.Copy)
+
+		if !fs.FileExists(copyName) {
+			return fmt.Errorf("duplicates: copy %s of %s not found", sanitize.Log(f.Copy), sanitize.Log(f.Name))
+		}
+
+		if f.Indexed {
+			// Update the index so that it refers to the copy.
+			file := entity.File{}
+
+			if err := entity.UnscopedDb().Where("file_name = ? AND file_root = ?", f.Name, entity.RootOriginals).First(&file).Error; err != nil {
+				return err
+			} else if err := file.Rename(f.Copy, entity.RootOriginals, folderPath(f.Copy), fs.BasePrefix(f.Copy, false)); err != nil {
+				return err
+			} else if err := entity.PurgeDuplicate(f.Copy, entity.RootOriginals); err != nil {
+				return err
+			}
+		} else if err := entity.PurgeDuplicate(f.Name, entity.RootOriginals); err != nil {
+			return err
+		}
+
+		if err := os.Remove(srcName); err != nil {
+			return err
+		}
+
+		if !symlinks {
+			log.Infof("duplicates: removed %s, copy of %s", sanitize.Log(f.Name), sanitize.Log(f.Copy))
+			continue
+		}
+
+		if target, err := filepath.Rel(filepath.Dir(srcName), copyName); err != nil {
+			return err
+		} else if err := os.Symlink(target, srcName); err != nil {
+			return err
+		}
+
+		// Links remain known duplicates, so that they are skipped when indexing.
+		if err := entity.AddDuplicate(f.Name, entity.RootOriginals, f.Hash, f.Size, time.Now().Unix()); err != nil {
+			log.Warnf("duplicates: %s", err)
+		}
+
+		log.Infof("duplicates: replaced %s with link to %s", sanitize.Log(f.Name), sanitize.Log(f.Copy))
+	}
+
+	if symlinks || folder.Path == "" {
+		return nil
+	}
+
+	// Remove the folder if it is empty now.
+	if err := os.Remove(FileName(entity.RootOriginals, folder.Path)); err != nil {
+		log.Debugf("duplicates: kept folder %s (%s)", sanitize.Log(folder.Path), err)
+	} else if f := entity.FindFolder(entity.RootOriginals, folder.Path); f != nil {
+		if err := entity.Db().Delete(f).Error; err != nil {
+			log.Warnf("duplicates: %s (remove folder %s from index)", err, sanitize.Log(folder.Path))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestDuplicateFolders(t *testing.T) {
+	c := config.TestConfig()
+	dir := filepath.Join(c.OriginalsPath(), "dupes")
+
+	defer os.RemoveAll(dir)
+
+	// Creates a file in originals and adds it to the index.
+	create := func(name, content string, indexed bool) {
+		fileName := filepath.Join(c.OriginalsPath(), name)
+
+		if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+			t.Fatal(err)
+		} else if err := os.WriteFile(fileName, []byte(content), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		hash := fs.Hash(fileName)
+
+		if !indexed {
+			if err := entity.AddDuplicate(name, entity.RootOriginals, hash, int64(len(content)), 1); err != nil {
+				t.Fatal(err)
+			}
+
+			return
+		}
+
+		photo := entity.Photo{PhotoPath: filepath.Dir(name), PhotoName: fs.BasePrefix(name, false)}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: name, FileRoot: entity.RootOriginals, FileHash: hash, FileSize: int64(len(content))}
+
+		if err := entity.Db().Create(&file).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	create("dupes/album/a.jpg", "dupes-a", true)
+	create("dupes/album/b.jpg", "dupes-b", false)
+	create("dupes/album copy/a.jpg", "dupes-a", false)
+	create("dupes/album copy/b.jpg", "dupes-b", true)
+	create("dupes/partial/a.jpg", "dupes-a", false)
+	create("dupes/partial/d.jpg", "dupes-d", true)
+
+	folders, err := FindDuplicateFolders("dupes")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(folders) != 1 {
+		t.Fatalf("one duplicate folder expected, found %#v", folders)
+	}
+
+	folder := folders[0]
+
+	assert.Equal(t, "dupes/album copy", folder.Path)
+	assert.Equal(t, []string{"dupes/album"}, folder.CopyPaths())
+	assert.Equal(t, int64(14), folder.Size)
+	assert.Len(t, folder.Files, 2)
+
+	t.Run("Merge", func(t *testing.T) {
+		if err := MergeDuplicateFolder(folder, false); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, fs.PathExists(filepath.Join(dir, "album copy")))
+		assert.True(t, fs.FileExists(filepath.Join(dir, "album", "b.jpg")))
+
+		// The index refers to the copy that was kept.
+		file := entity.File{}
+
+		if err := entity.Db().Where("file_name = ?", "dupes/album/b.jpg").First(&file).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "dupes/album", file.RelatedPhoto().PhotoPath)
+
+		var duplicates entity.Duplicates
+
+		if err := entity.Db().Where("file_name LIKE 'dupes/album%'").Find(&duplicates).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, duplicates)
+
+		// The remaining folders aren't duplicates.
+		folders, err := FindDuplicateFolders("dupes")
+
+		assert.NoError(t, err)
+		assert.Empty(t, folders)
+	})
+	t.Run("Symlinks", func(t *testing.T) {
+		create("dupes/links/d.jpg", "dupes-d", false)
+
+		folders, err := FindDuplicateFolders("dupes/links")
+
+		if err != nil {
+			t.Fatal(err)
+		} else if len(folders) != 1 {
+			t.Fatalf("one duplicate folder expected, found %#v", folders)
+		}
+
+		if err := MergeDuplicateFolder(folders[0], true); err != nil {
+			t.Fatal(err)
+		}
+
+		target, err := os.Readlink(filepath.Join(dir, "links", "d.jpg"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "../partial/d.jpg", target)
+	})
+}
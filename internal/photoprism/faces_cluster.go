@@ -5,6 +5,7 @@ import (
 
 	"github.com/dustin/go-humanize/english"
 
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/query"
@@ -25,6 +26,13 @@ func (w *Faces) Cluster(opt FacesOptions) (added entity.Faces, err error) {
 		return added, nil
 	}
 
+	// Add new samples to matching clusters first, so that only the remaining samples are clustered.
+	if res, err := w.Extend(); err != nil {
+		return added, err
+	} else if res.Updated > 0 {
+		log.Infof("faces: updated %s", english.Plural(res.Updated, "cluster", "clusters"))
+	}
+
 	// Fetch unclustered face embeddings.
 	embeddings, err := query.Embeddings(false, true, face.ClusterSizeThreshold, face.ClusterScoreThreshold)
 
@@ -40,7 +48,7 @@ func (w *Faces) Cluster(opt FacesOptions) (added entity.Faces, err error) {
 		var c clusters.HardClusterer
 
 		// See https://dl.photoprism.app/research/ for research on face clustering algorithms.
-		if c, err = clusters.DBSCAN(face.ClusterCore, face.ClusterDist, w.conf.Workers(), clusters.EuclideanDistance); err != nil {
+		if c, err = clusters.DBSCAN(face.ClusterCore, face.ClusterDist, w.conf.TaskWorkers(config.TaskFaces), clusters.EuclideanDistance); err != nil {
 			return added, err
 		} else if err = c.Learn(embeddings.Float64()); err != nil {
 			return added, err
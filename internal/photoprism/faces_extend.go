@@ -0,0 +1,129 @@
+package photoprism
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// FacesExtendResult represents the outcome of Faces.Extend().
+type FacesExtendResult struct {
+	Assigned int
+	Updated  int
+}
+
+// Extend adds unclustered face markers to the closest matching existing cluster and recomputes
+// only the affected clusters, so that the remaining samples can be clustered much faster.
+func (w *Faces) Extend() (result FacesExtendResult, err error) {
+	if w.Disabled() {
+		return result, fmt.Errorf("facial recognition is disabled")
+	}
+
+	faces, err := query.Faces(false, false, false)
+
+	if err != nil {
+		return result, err
+	} else if len(faces) == 0 {
+		return result, nil
+	}
+
+	// Clusters with newly assigned markers.
+	affected := make(map[string]*entity.Face)
+
+	// Assigned markers no longer match the query, so the offset only counts skipped markers.
+	offset := 0
+	limit := 500
+
+	for {
+		markers, err := query.UnclusteredFaceMarkers(limit, offset, face.ClusterSizeThreshold, face.ClusterScoreThreshold)
+
+		if err != nil {
+			return result, err
+		}
+
+		if len(markers) == 0 {
+			break
+		}
+
+		for _, marker := range markers {
+			if w.Canceled() {
+				return result, fmt.Errorf("worker canceled")
+			}
+
+			// Pointer to the matching face.
+			var f *entity.Face
+
+			// Distance to the matching face.
+			var d float64
+
+			// Find the closest face match for marker.
+			for i, m := range faces {
+				if ok, dist := m.Match(marker.Embeddings()); ok && (f == nil || dist < d) {
+					f = &faces[i]
+					d = dist
+				}
+			}
+
+			if f == nil {
+				offset++
+				continue
+			}
+
+			if _, err := marker.SetFace(f, d); err != nil {
+				log.Warnf("faces: %s while assigning marker %s", err, marker.MarkerUID)
+				offset++
+				continue
+			} else if marker.FaceID != f.ID {
+				// Not assigned, e.g. because of ambiguous subjects.
+				offset++
+				continue
+			}
+
+			result.Assigned++
+
+			// Only automatically created clusters are recomputed.
+			if f.FaceSrc == entity.SrcAuto {
+				affected[f.ID] = f
+			}
+		}
+
+		if len(markers) < limit {
+			break
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if result.Assigned > 0 {
+		log.Infof("faces: assigned %s to existing clusters", english.Plural(result.Assigned, "marker", "markers"))
+	}
+
+	// Recompute affected clusters, using confirmed markers and markers within the sample radius only,
+	// so that clusters don't drift with each automatically assigned face.
+	for _, f := range affected {
+		if embeddings, err := query.FaceEmbeddings(f.ID, face.ClusterSizeThreshold, face.ClusterScoreThreshold, f.SampleRadius); err != nil {
+			log.Errorf("faces: %s (recompute cluster %s)", err, f.ID)
+		} else if len(embeddings) == 0 {
+			continue
+		} else if err := f.UpdateEmbeddings(embeddings); err != nil {
+			log.Errorf("faces: %s (recompute cluster %s)", err, f.ID)
+		} else if revised, err := f.ReviseMatches(); err != nil {
+			log.Errorf("faces: %s (revise cluster %s)", err, f.ID)
+		} else {
+			result.Updated++
+
+			if n := len(revised); n > 0 {
+				log.Debugf("faces: cluster %s no longer matches %s", f.ID, english.Plural(n, "marker", "markers"))
+			}
+
+			log.Debugf("faces: updated cluster %s based on %s, radius %f", f.ID, english.Plural(f.Samples, "sample", "samples"), f.SampleRadius)
+		}
+	}
+
+	return result, nil
+}
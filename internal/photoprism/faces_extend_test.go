@@ -0,0 +1,32 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestFaces_Extend(t *testing.T) {
+	c := config.TestConfig()
+
+	m := NewFaces(c)
+
+	r, err := m.Extend()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, r.Assigned, r.Updated)
+
+	t.Log(r)
+
+	// Assigned markers are not assigned again.
+	if again, err := m.Extend(); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.LessOrEqual(t, again.Assigned, r.Assigned)
+	}
+}
@@ -0,0 +1,154 @@
+package photoprism
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// FaceProposal represents an unassigned face marker that matches the reference portraits of a person.
+type FaceProposal struct {
+	MarkerUID string  `json:"UID"`
+	FileUID   string  `json:"FileUID"`
+	Thumb     string  `json:"Thumb"`
+	Dist      float64 `json:"Dist"`
+}
+
+// FaceProposals represents a list of face proposals sorted by distance.
+type FaceProposals []FaceProposal
+
+// References detects the largest face in each reference portrait and returns its embeddings.
+func (w *Faces) References(net *face.Net, fileNames []string) (result face.Embeddings, err error) {
+	if w.Disabled() {
+		return result, fmt.Errorf("facial recognition is disabled")
+	}
+
+	for _, fileName := range fileNames {
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			log.Warnf("faces: %s (reference)", err)
+			continue
+		}
+
+		thumbName, err := mf.Thumbnail(w.conf.ThumbPath(), thumb.Fit720)
+
+		if err != nil {
+			log.Warnf("faces: %s (reference)", err)
+			continue
+		}
+
+		faces, err := net.Detect(thumbName, w.conf.FaceSize(), false, 0)
+
+		if err != nil {
+			log.Warnf("faces: %s in %s (reference)", err, sanitize.Log(mf.BaseName()))
+			continue
+		}
+
+		var best *face.Face
+
+		for i := range faces {
+			if faces[i].NoEmbedding() {
+				continue
+			} else if best == nil || faces[i].Size() > best.Size() {
+				best = &faces[i]
+			}
+		}
+
+		if best == nil {
+			log.Infof("faces: found no face in reference %s", sanitize.Log(mf.BaseName()))
+			continue
+		}
+
+		result = append(result, best.Embeddings...)
+	}
+
+	return result, nil
+}
+
+// Propose returns unassigned face markers within the given distance of the reference embeddings.
+func (w *Faces) Propose(references face.Embeddings, dist float64) (result FaceProposals, err error) {
+	result = FaceProposals{}
+
+	if references.Empty() {
+		return result, nil
+	}
+
+	limit := 500
+	offset := 0
+
+	for {
+		markers, err := query.UnassignedFaceMarkers(limit, offset)
+
+		if err != nil {
+			return result, err
+		}
+
+		if len(markers) == 0 {
+			break
+		}
+
+		for _, m := range markers {
+			best := -1.0
+
+			for _, e := range m.Embeddings() {
+				if d := references.Distance(e); d >= 0 && (d < best || best < 0) {
+					best = d
+				}
+			}
+
+			if best >= 0 && best <= dist {
+				result = append(result, FaceProposal{MarkerUID: m.MarkerUID, FileUID: m.FileUID, Thumb: m.Thumb, Dist: best})
+			}
+		}
+
+		offset += limit
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Dist < result[j].Dist
+	})
+
+	return result, nil
+}
+
+// Assign assigns the face markers with the given uids to a subject, and returns the number of assigned markers.
+func (w *Faces) Assign(subj *entity.Subject, markerUIDs []string) (assigned int, err error) {
+	if subj == nil {
+		return 0, fmt.Errorf("subject not found")
+	}
+
+	for _, uid := range markerUIDs {
+		m, err := query.MarkerByUID(uid)
+
+		if err != nil {
+			log.Warnf("faces: marker %s not found", sanitize.Log(uid))
+			continue
+		} else if m.MarkerType != entity.MarkerFace || m.MarkerInvalid || m.SubjUID != "" && m.SubjSrc == entity.SrcManual {
+			log.Debugf("faces: skipped marker %s", sanitize.Log(uid))
+			continue
+		}
+
+		m.SubjUID = subj.SubjUID
+		m.SubjSrc = entity.SrcManual
+		m.MarkerName = subj.SubjName
+		m.MarkerReview = false
+
+		if err := m.SyncSubject(true); err != nil {
+			return assigned, err
+		} else if err := m.Save(); err != nil {
+			return assigned, err
+		} else if err := m.RefreshPhotos(); err != nil {
+			log.Warnf("faces: %s (refresh photos)", err)
+		}
+
+		assigned++
+	}
+
+	return assigned, nil
+}
@@ -0,0 +1,74 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+)
+
+func TestFaces_References(t *testing.T) {
+	c := config.TestConfig()
+
+	m := NewFaces(c)
+
+	result, err := m.References(face.NewNet(c.FaceNetModelPath(), "", c.DisableFaces()), []string{c.ExamplesPath() + "/beach_sand.jpg", "testdata/missing.jpg"})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Empty())
+}
+
+func TestFaces_Propose(t *testing.T) {
+	c := config.TestConfig()
+
+	m := NewFaces(c)
+
+	t.Run("Empty", func(t *testing.T) {
+		result, err := m.Propose(face.Embeddings{}, face.ClusterDist)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("Matching", func(t *testing.T) {
+		marker := entity.MarkerFixtures.Get("1000003-4")
+		result, err := m.Propose(marker.Embeddings(), 0.01)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(result) == 0 {
+			t.Fatal("at least one proposal expected")
+		}
+
+		assert.Equal(t, marker.MarkerUID, result[0].MarkerUID)
+		assert.InDelta(t, 0, result[0].Dist, 0.0001)
+
+		for i := 1; i < len(result); i++ {
+			assert.GreaterOrEqual(t, result[i].Dist, result[i-1].Dist)
+		}
+	})
+}
+
+func TestFaces_Assign(t *testing.T) {
+	c := config.TestConfig()
+
+	m := NewFaces(c)
+
+	t.Run("NoSubject", func(t *testing.T) {
+		_, err := m.Assign(nil, []string{"mt9k3pw1wowuy222"})
+
+		assert.Error(t, err)
+	})
+	t.Run("Skipped", func(t *testing.T) {
+		subj := entity.SubjectFixtures.Pointer("john-doe")
+
+		assigned, err := m.Assign(subj, []string{"mt9k3pw1wowuy111", "mxxxxxxxxxxxxxxx"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, assigned)
+	})
+}
@@ -0,0 +1,172 @@
+package photoprism
+
+import (
+	"sort"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// FaceSuggestionsLimit is the maximum number of name suggestions per face.
+var FaceSuggestionsLimit = 3
+
+// FaceSuggestion represents a known person that might match an unnamed face.
+type FaceSuggestion struct {
+	SubjUID string  `json:"SubjUID"`
+	Name    string  `json:"Name"`
+	Dist    float64 `json:"Dist"`
+}
+
+// FaceReviewItem represents an unnamed face marker with name suggestions.
+type FaceReviewItem struct {
+	MarkerUID   string           `json:"UID"`
+	FileUID     string           `json:"FileUID"`
+	FaceID      string           `json:"FaceID"`
+	Thumb       string           `json:"Thumb"`
+	Score       int              `json:"Score"`
+	Suggestions []FaceSuggestion `json:"Suggestions"`
+}
+
+// FaceReviewItems represents a list of face markers to review.
+type FaceReviewItems []FaceReviewItem
+
+// FaceReviewResult represents the number of named, rejected, and skipped faces.
+type FaceReviewResult struct {
+	Named    int `json:"Named"`
+	Rejected int `json:"Rejected"`
+	Skipped  int `json:"Skipped"`
+}
+
+// Review returns up to count unnamed faces ordered by cluster confidence, together with suggested names.
+func (w *Faces) Review(count int) (result FaceReviewItems, err error) {
+	result = FaceReviewItems{}
+
+	markers, err := query.ReviewFaceMarkers(count)
+
+	if err != nil {
+		return result, err
+	} else if len(markers) == 0 {
+		return result, nil
+	}
+
+	known, err := query.Faces(true, false, false)
+
+	if err != nil {
+		return result, err
+	}
+
+	subjects, err := query.SubjectMap()
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, m := range markers {
+		item := FaceReviewItem{
+			MarkerUID:   m.MarkerUID,
+			FileUID:     m.FileUID,
+			FaceID:      m.FaceID,
+			Thumb:       m.Thumb,
+			Score:       m.Score,
+			Suggestions: faceSuggestions(m.Embeddings(), known, subjects),
+		}
+
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// faceSuggestions returns the known people closest to the embeddings, closest first.
+func faceSuggestions(embeddings face.Embeddings, known entity.Faces, subjects map[string]entity.Subject) (result []FaceSuggestion) {
+	result = []FaceSuggestion{}
+
+	if embeddings.Empty() {
+		return result
+	}
+
+	best := make(map[string]float64)
+
+	for _, f := range known {
+		if f.SubjUID == "" {
+			continue
+		}
+
+		d := embeddings.Distance(f.Embedding())
+
+		if d < 0 || d > face.ClusterDist+f.SampleRadius {
+			continue
+		} else if prev, ok := best[f.SubjUID]; !ok || d < prev {
+			best[f.SubjUID] = d
+		}
+	}
+
+	for subjUID, d := range best {
+		if subj, ok := subjects[subjUID]; ok && subj.Visible() {
+			result = append(result, FaceSuggestion{SubjUID: subjUID, Name: subj.SubjName, Dist: d})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Dist < result[j].Dist
+	})
+
+	if len(result) > FaceSuggestionsLimit {
+		result = result[:FaceSuggestionsLimit]
+	}
+
+	return result
+}
+
+// Confirm applies a batch of reviewed faces, either naming them or marking them as invalid.
+func (w *Faces) Confirm(f form.FaceReview) (result FaceReviewResult, err error) {
+	for _, c := range f.Faces {
+		m, err := query.MarkerByUID(c.MarkerUID)
+
+		if err != nil {
+			log.Warnf("faces: marker %s not found", sanitize.Log(c.MarkerUID))
+			result.Skipped++
+			continue
+		} else if m.MarkerType != entity.MarkerFace {
+			result.Skipped++
+			continue
+		}
+
+		values := form.Marker{SubjSrc: entity.SrcManual, MarkerName: c.MarkerName, MarkerInvalid: c.MarkerInvalid}
+
+		if c.MarkerInvalid {
+			values.MarkerName = ""
+		} else if c.SubjUID != "" {
+			if subj := entity.FindSubject(c.SubjUID); subj == nil {
+				log.Warnf("faces: subject %s not found", sanitize.Log(c.SubjUID))
+				result.Skipped++
+				continue
+			} else {
+				values.MarkerName = subj.SubjName
+			}
+		} else if sanitize.Name(c.MarkerName) == "" {
+			result.Skipped++
+			continue
+		}
+
+		if changed, err := m.SaveForm(values); err != nil {
+			return result, err
+		} else if !changed {
+			result.Skipped++
+			continue
+		} else if err := m.RefreshPhotos(); err != nil {
+			log.Warnf("faces: %s (refresh photos)", err)
+		}
+
+		if c.MarkerInvalid {
+			result.Rejected++
+		} else {
+			result.Named++
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestFaces_Review(t *testing.T) {
+	c := config.TestConfig()
+
+	m := NewFaces(c)
+
+	result, err := m.Review(5)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, result)
+	assert.LessOrEqual(t, len(result), 5)
+
+	for _, item := range result {
+		assert.NotEmpty(t, item.MarkerUID)
+		assert.LessOrEqual(t, len(item.Suggestions), FaceSuggestionsLimit)
+
+		for i := 1; i < len(item.Suggestions); i++ {
+			assert.GreaterOrEqual(t, item.Suggestions[i].Dist, item.Suggestions[i-1].Dist)
+		}
+	}
+}
+
+func TestFaces_Confirm(t *testing.T) {
+	c := config.TestConfig()
+
+	m := NewFaces(c)
+
+	t.Run("Skipped", func(t *testing.T) {
+		marker := entity.MarkerFixtures.Get("1000003-4")
+
+		result, err := m.Confirm(form.FaceReview{Faces: []form.FaceConfirmation{
+			{MarkerUID: "mt9k3pw1wowuy000"},
+			{MarkerUID: marker.MarkerUID},
+			{MarkerUID: marker.MarkerUID, SubjUID: "jqy3y652h8njw000"},
+		}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, result.Named)
+		assert.Equal(t, 0, result.Rejected)
+		assert.Equal(t, 3, result.Skipped)
+	})
+}
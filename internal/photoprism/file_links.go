@@ -0,0 +1,57 @@
+package photoprism
+
+import (
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// fileLinks remembers the first location of each file found while indexing, so that hard links and
+// symbolic links to the same file are indexed as a single file with multiple locations.
+type fileLinks map[string]string
+
+// First returns the first location of the file if it was found before under a different name, either
+// while indexing or in a previous run, and otherwise remembers the file name as its first location.
+func (l fileLinks) First(fileName string) (first string, found bool) {
+	id := fs.FileID(fileName)
+
+	if id == "" {
+		return "", false
+	} else if first, found = l[id]; found && first != fileName {
+		return first, true
+	} else if found {
+		return "", false
+	}
+
+	// Inode numbers may be reused, so the indexed file must still refer to the same file.
+	if file, err := query.FileByInode(id); err != nil {
+		// Not indexed yet.
+	} else if first = FileName(file.FileRoot, file.FileName); first != fileName && fs.FileID(first) == id {
+		l[id] = first
+		return first, true
+	}
+
+	l[id] = fileName
+
+	return "", false
+}
+
+// addFileLink adds another location of an indexed file to the duplicates table, which is skipped if
+// it has not been modified since it was added.
+func addFileLink(m *MediaFile, first string) error {
+	relName, fileRoot := m.RootRelName(), m.Root()
+	fileSize, modTime := m.FileSize(), m.ModTime().Unix()
+
+	duplicate := entity.Duplicate{FileName: relName, FileRoot: fileRoot}
+
+	if err := duplicate.Find(); err == nil && duplicate.ModTime == modTime && duplicate.FileSize == fileSize {
+		return nil
+	}
+
+	log.Infof("index: %s is a link to %s", sanitize.Log(relName), sanitize.Log(filepath.Base(first)))
+
+	return entity.AddDuplicate(relName, fileRoot, fs.Hash(first), fileSize, modTime)
+}
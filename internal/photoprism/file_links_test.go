@@ -0,0 +1,111 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestFileLinks(t *testing.T) {
+	c := config.TestConfig()
+	dir := filepath.Join(c.OriginalsPath(), "links")
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "cat_brown.jpg")
+	hardLink := filepath.Join(dir, "hardlink.jpg")
+	symLink := filepath.Join(dir, "symlink.jpg")
+	copyName := filepath.Join(dir, "copy.jpg")
+
+	if err := fs.Copy(filepath.Join(c.ExamplesPath(), "cat_brown.jpg"), fileName); err != nil {
+		t.Fatal(err)
+	} else if err := fs.Copy(fileName, copyName); err != nil {
+		t.Fatal(err)
+	} else if err := os.Link(fileName, hardLink); err != nil {
+		t.Skip(err)
+	} else if err := os.Symlink(fileName, symLink); err != nil {
+		t.Skip(err)
+	}
+
+	t.Run("First", func(t *testing.T) {
+		links := make(fileLinks)
+
+		_, found := links.First(fileName)
+		assert.False(t, found)
+
+		_, found = links.First(fileName)
+		assert.False(t, found)
+
+		first, found := links.First(hardLink)
+		assert.True(t, found)
+		assert.Equal(t, fileName, first)
+
+		first, found = links.First(symLink)
+		assert.True(t, found)
+		assert.Equal(t, fileName, first)
+
+		_, found = links.First(copyName)
+		assert.False(t, found)
+	})
+	t.Run("Indexed", func(t *testing.T) {
+		file := entity.File{FileUID: "fs6sg6bwlnk0000a", FileRoot: entity.RootOriginals, FileName: "links/cat_brown.jpg", FileInode: fs.FileID(fileName)}
+
+		if err := entity.Db().Create(&file).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.UnscopedDb().Delete(&file)
+
+		links := make(fileLinks)
+
+		_, found := links.First(fileName)
+		assert.False(t, found)
+
+		links = make(fileLinks)
+
+		first, found := links.First(hardLink)
+		assert.True(t, found)
+		assert.Equal(t, fileName, first)
+
+		_, found = links.First(copyName)
+		assert.False(t, found)
+	})
+	t.Run("AddFileLink", func(t *testing.T) {
+		mf, err := NewMediaFile(hardLink)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := addFileLink(mf, fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		duplicate := entity.Duplicate{FileName: "links/hardlink.jpg", FileRoot: entity.RootOriginals}
+
+		if err := duplicate.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, fs.Hash(fileName), duplicate.FileHash)
+
+		// Unchanged links are skipped.
+		if err := addFileLink(mf, fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := duplicate.Purge(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
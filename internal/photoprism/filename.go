@@ -12,6 +12,14 @@ import (
 
 // FileName returns the full file name based on the root folder type.
 func FileName(fileRoot, fileName string) string {
+	if root, sidecar, ok := Config().OriginalsRoots().Find(fileRoot); !ok {
+		// Not an additional originals root.
+	} else if sidecar {
+		return path.Join(root.SidecarPath, fileName)
+	} else {
+		return path.Join(root.Path, fileName)
+	}
+
 	switch fileRoot {
 	case entity.RootSidecar:
 		return path.Join(Config().SidecarPath(), fileName)
@@ -53,6 +61,14 @@ func RelName(fileName, directory string) string {
 
 // RootPath returns the file root path based on the configuration.
 func RootPath(fileName string) string {
+	if root, sidecar, ok := Config().OriginalsRoots().Match(fileName); !ok {
+		// Not in an additional originals root.
+	} else if sidecar {
+		return root.SidecarPath
+	} else {
+		return root.Path
+	}
+
 	switch Root(fileName) {
 	case entity.RootSidecar:
 		return Config().SidecarPath()
@@ -67,6 +83,15 @@ func RootPath(fileName string) string {
 
 // Root returns the file root directory.
 func Root(fileName string) string {
+	// Additional roots may be located in the default sidecar folder, so they are matched first.
+	if root, sidecar, ok := Config().OriginalsRoots().Match(fileName); !ok {
+		// Not in an additional originals root.
+	} else if sidecar {
+		return root.SidecarRoot()
+	} else {
+		return root.Name
+	}
+
 	originalsPath := Config().OriginalsPath()
 
 	if originalsPath != "" && strings.HasPrefix(fileName, originalsPath) {
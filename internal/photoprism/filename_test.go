@@ -69,3 +69,30 @@ func TestRootRelName(t *testing.T) {
 		assert.Equal(t, "foo/test.jpg", RootRelName(FileName("sidecar", "foo/test.jpg")))
 	})
 }
+
+func TestOriginalsRoots(t *testing.T) {
+	c := config.TestConfig()
+
+	c.Options().OriginalsRoots = "nas:/mnt/nas/photos"
+	defer func() { c.Options().OriginalsRoots = "" }()
+
+	sidecarPath := c.SidecarPath() + "/nas"
+
+	t.Run("FileName", func(t *testing.T) {
+		assert.Equal(t, "/mnt/nas/photos/2021/test.jpg", FileName("nas", "2021/test.jpg"))
+		assert.Equal(t, sidecarPath+"/2021/test.jpg.json", FileName("sidecar-nas", "2021/test.jpg.json"))
+	})
+	t.Run("RootPath", func(t *testing.T) {
+		assert.Equal(t, "/mnt/nas/photos", RootPath("/mnt/nas/photos/2021/test.jpg"))
+		assert.Equal(t, sidecarPath, RootPath(sidecarPath+"/2021/test.jpg.json"))
+		assert.Equal(t, c.SidecarPath(), RootPath(FileName("sidecar", "test.jpg")))
+	})
+	t.Run("Root", func(t *testing.T) {
+		assert.Equal(t, "nas", Root("/mnt/nas/photos/2021/test.jpg"))
+		assert.Equal(t, "sidecar-nas", Root(sidecarPath+"/2021/test.jpg.json"))
+		assert.Equal(t, entity.RootSidecar, Root(FileName("sidecar", "test.jpg")))
+	})
+	t.Run("RootRelName", func(t *testing.T) {
+		assert.Equal(t, "2021/test.jpg", RootRelName("/mnt/nas/photos/2021/test.jpg"))
+	})
+}
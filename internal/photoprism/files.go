@@ -3,6 +3,7 @@ package photoprism
 import (
 	"fmt"
 	"path"
+	"strings"
 	"sync"
 	"time"
 
@@ -115,3 +116,23 @@ func (m *Files) Indexed(fileName, fileRoot string, modTime time.Time, rescan boo
 		return false
 	}
 }
+
+// MissingDirs returns the directories of indexed files in a root that have not been found, e.g. because
+// they were deleted. Directory names are relative to the root path.
+func (m *Files) MissingDirs(fileRoot string, found func(fileName string) bool) map[string]bool {
+	result := make(map[string]bool)
+	prefix := strings.TrimSuffix(fileRoot, "/") + "/"
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for key := range m.files {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		} else if fileName := key[len(prefix):]; !found(fileName) {
+			result[dirRelName(path.Dir(fileName))] = true
+		}
+	}
+
+	return result
+}
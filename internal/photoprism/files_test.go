@@ -34,3 +34,20 @@ func TestFiles_Ignore(t *testing.T) {
 	assert.True(t, files.Ignore("new-file.jpg", entity.RootSidecar, time.Unix(1583460001, 2), false))
 	assert.False(t, files.Ignore("new-file.jpg", entity.RootSidecar, time.Unix(501, 0), false))
 }
+
+func TestFiles_MissingDirs(t *testing.T) {
+	files := NewFiles()
+
+	if err := files.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("AllFound", func(t *testing.T) {
+		result := files.MissingDirs(entity.RootOriginals, func(fileName string) bool { return true })
+		assert.Empty(t, result)
+	})
+	t.Run("NoneFound", func(t *testing.T) {
+		result := files.MissingDirs(entity.RootOriginals, func(fileName string) bool { return false })
+		assert.True(t, result["2790/07"])
+	})
+}
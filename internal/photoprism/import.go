@@ -1,6 +1,7 @@
 package photoprism
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -12,11 +13,13 @@ import (
 	"sync"
 
 	"github.com/karrick/godirwalk"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/tracing"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
@@ -49,6 +52,22 @@ func (imp *Import) thumbPath() string {
 	return imp.conf.ThumbPath()
 }
 
+// CheckStorage returns an error if importing files would use up the storage reserve of the originals folder.
+func (imp *Import) CheckStorage(opt ImportOptions) error {
+	var size int64
+
+	// Moved files usually don't need additional space.
+	if !opt.Move {
+		var err error
+
+		if size, err = fs.DirSize(opt.Path); err != nil {
+			return err
+		}
+	}
+
+	return imp.conf.CheckStorage(imp.conf.OriginalsPath(), size)
+}
+
 // Start imports media files from a directory and converts/indexes them as needed.
 func (imp *Import) Start(opt ImportOptions) fs.Done {
 	defer func() {
@@ -73,6 +92,11 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		return done
 	}
 
+	if err := imp.CheckStorage(opt); err != nil {
+		event.Error(fmt.Sprintf("import: %s", err))
+		return done
+	}
+
 	if err := mutex.MainWorker.Start(); err != nil {
 		event.Error(fmt.Sprintf("import: %s", err.Error()))
 		return done
@@ -80,6 +104,10 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 
 	defer mutex.MainWorker.Stop()
 
+	ctx, span := tracing.Start(context.Background(), "import", attribute.Bool("import.move", opt.Move))
+
+	defer span.End()
+
 	if err := ind.tensorFlow.Init(); err != nil {
 		log.Errorf("import: %s", err.Error())
 		return done
@@ -89,7 +117,7 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 
 	// Start a fixed number of goroutines to import files.
 	var wg sync.WaitGroup
-	var numWorkers = imp.conf.Workers()
+	var numWorkers = imp.conf.TaskWorkers(config.TaskIndex)
 	wg.Add(numWorkers)
 	for i := 0; i < numWorkers; i++ {
 		go func() {
@@ -105,8 +133,11 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		Rescan:  true,
 		Stack:   true,
 		Convert: imp.conf.Settings().Index.Convert && imp.conf.SidecarWritable(),
+		Pending: imp.conf.ImportReview(),
 	}
 
+	resetSkipped("import")
+
 	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
 
 	if err := ignore.Dir(importPath); err != nil {
@@ -142,10 +173,13 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 						directories = append(directories, fileName)
 					}
 
-					folder := entity.NewFolder(entity.RootImport, fs.RelName(fileName, imp.conf.ImportPath()), fs.BirthTime(fileName))
+					// Folders outside the import path, e.g. with approved guest uploads, are not added.
+					if strings.HasPrefix(fileName, imp.conf.ImportPath()) {
+						folder := entity.NewFolder(entity.RootImport, fs.RelName(fileName, imp.conf.ImportPath()), fs.BirthTime(fileName))
 
-					if err := folder.Create(); err == nil {
-						log.Infof("import: added folder /%s", folder.Path)
+						if err := folder.Create(); err == nil {
+							log.Infof("import: added folder /%s", folder.Path)
+						}
 					}
 				}
 
@@ -210,6 +244,8 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 	close(jobs)
 	wg.Wait()
 
+	span.SetAttributes(attribute.Int("import.files", filesImported))
+
 	sort.Slice(directories, func(i, j int) bool {
 		return len(directories[i]) > len(directories[j])
 	})
@@ -227,9 +263,11 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		}
 	}
 
+	addSkipped("import", entity.RootImport, importPath, ignore)
+
 	if opt.RemoveDotFiles {
-		// Remove hidden .files if option is enabled.
-		for _, file := range ignore.Hidden() {
+		// Remove hidden .files and junk files like Thumbs.db if option is enabled.
+		for _, file := range append(ignore.Hidden(), ignore.Junk()...) {
 			if !fs.FileExists(file) {
 				continue
 			}
@@ -248,8 +286,14 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		// Run facial recognition if enabled.
 		if w := NewFaces(imp.conf); w.Disabled() {
 			log.Debugf("import: skipping facial recognition")
-		} else if err := w.Start(FacesOptionsDefault()); err != nil {
-			log.Errorf("import: %s", err)
+		} else {
+			_, facesSpan := tracing.Start(ctx, "import.faces")
+			err := w.Start(FacesOptionsDefault())
+			tracing.End(facesSpan, err)
+
+			if err != nil {
+				log.Errorf("import: %s", err)
+			}
 		}
 
 		// Update photo counts and visibilities.
@@ -270,9 +314,50 @@ func (imp *Import) Cancel() {
 
 // DestinationFilename returns the destination filename of a MediaFile to be imported.
 func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile) (string, error) {
-	fileName := mainFile.CanonicalName()
+	return imp.destinationFilename(mainFile, mediaFile, "", imp.destinationCounter(mainFile, ""))
+}
+
+// destinationPath returns the originals folder specified, or a folder based on the creation date if it is empty.
+func (imp *Import) destinationPath(mainFile *MediaFile, folder string) string {
+	if folder != "" {
+		return filepath.Join(imp.originalsPath(), folder)
+	}
+
+	//	Mon Jan 2 15:04:05 -0700 MST 2006
+	return filepath.Join(imp.originalsPath(), mainFile.DateCreated().Format("2006/01"))
+}
+
+// destinationCounter returns the first counter for which no file with the same base name exists in the
+// destination folder, so that related files, e.g. a JPEG and its RAW sibling, get the same number.
+func (imp *Import) destinationCounter(mainFile *MediaFile, folder string) int {
+	template := imp.conf.ImportName()
+
+	if folder != "" || !ImportNameHasCounter(template) {
+		return 1
+	}
+
+	pathName := imp.destinationPath(mainFile, folder)
+
+	for counter := 1; counter < 10000; counter++ {
+		pattern := fs.GlobEscape(filepath.Join(pathName, ImportName(template, mainFile, counter))) + ".*"
+
+		if matches, err := filepath.Glob(pattern); err != nil {
+			log.Warnf("import: %s", err)
+			return counter
+		} else if len(matches) == 0 {
+			return counter
+		}
+	}
+
+	return 1
+}
+
+// destinationFilename returns the destination filename of a MediaFile to be imported into the
+// originals folder specified, or a folder based on the creation date if it is empty. The original
+// file name is kept if a folder is specified, e.g. to preserve the structure of imported archives.
+func (imp *Import) destinationFilename(mainFile *MediaFile, mediaFile *MediaFile, folder string, counter int) (string, error) {
+	fileName := ImportName(imp.conf.ImportName(), mainFile, counter)
 	fileExtension := mediaFile.Extension()
-	dateCreated := mainFile.DateCreated()
 
 	if !mediaFile.IsSidecar() {
 		if f, err := entity.FirstFileByHash(mediaFile.Hash()); err == nil {
@@ -285,8 +370,11 @@ func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile
 		}
 	}
 
-	//	Mon Jan 2 15:04:05 -0700 MST 2006
-	pathName := filepath.Join(imp.originalsPath(), dateCreated.Format("2006/01"))
+	if folder != "" {
+		fileName = mainFile.BasePrefix(false)
+	}
+
+	pathName := imp.destinationPath(mainFile, folder)
 
 	iteration := 0
 
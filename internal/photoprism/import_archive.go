@@ -0,0 +1,109 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Archive file import status.
+const (
+	ArchiveImported  = "imported"
+	ArchiveDuplicate = "duplicate"
+	ArchiveSkipped   = "skipped"
+	ArchiveFailed    = "failed"
+)
+
+// ArchiveResult reports the import status of a file extracted from an archive.
+type ArchiveResult struct {
+	FileName string `json:"FileName"`
+	Status   string `json:"Status"`
+	Original string `json:"Original,omitempty"`
+}
+
+// ArchiveResults represents the import results of all files in an archive.
+type ArchiveResults []ArchiveResult
+
+// Count returns the number of files with the given import status.
+func (r ArchiveResults) Count(status string) (n int) {
+	for _, result := range r {
+		if result.Status == status {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Archive extracts a ZIP or TAR archive to a temporary folder and imports the files, keeping
+// their folder structure. Returns the import status of each extracted file.
+func (imp *Import) Archive(archiveName string, albums []string) (results ArchiveResults, err error) {
+	if !fs.IsArchive(archiveName) {
+		return results, fmt.Errorf("%s is not a supported archive", sanitize.Log(filepath.Base(archiveName)))
+	} else if mutex.MainWorker.Busy() {
+		return results, fmt.Errorf("another index or import is in progress")
+	}
+
+	tmpPath := filepath.Join(imp.conf.TempPath(), "archives", rnd.UUID())
+
+	defer func() {
+		if err := os.RemoveAll(tmpPath); err != nil {
+			log.Warnf("import: failed removing %s (%s)", sanitize.Log(tmpPath), err)
+		}
+	}()
+
+	fileNames, err := fs.Extract(archiveName, tmpPath, imp.conf.ArchiveLimit())
+
+	if err != nil {
+		return results, err
+	}
+
+	log.Infof("import: extracted %d files from %s", len(fileNames), sanitize.Log(filepath.Base(archiveName)))
+
+	// Extracted files may be on a different volume than originals.
+	if size, err := fs.DirSize(tmpPath); err != nil {
+		return results, err
+	} else if err := imp.conf.CheckStorage(imp.conf.OriginalsPath(), size); err != nil {
+		return results, err
+	}
+
+	// Remember the file hashes, as extracted files are moved to originals.
+	hashes := make(map[string]string, len(fileNames))
+
+	for _, fileName := range fileNames {
+		relName := fs.RelName(fileName, tmpPath)
+		hashes[relName] = fs.Hash(fileName)
+
+		if f, err := entity.FirstFileByHash(hashes[relName]); err == nil {
+			results = append(results, ArchiveResult{FileName: relName, Status: ArchiveDuplicate, Original: f.FileName})
+		} else {
+			results = append(results, ArchiveResult{FileName: relName})
+		}
+	}
+
+	opt := ImportOptionsArchive(tmpPath)
+	opt.Albums = albums
+
+	imp.Start(opt)
+
+	for i, result := range results {
+		if result.Status != "" {
+			continue
+		} else if f, err := entity.FirstFileByHash(hashes[result.FileName]); err == nil {
+			results[i].Status = ArchiveImported
+			results[i].Original = f.FileName
+		} else if fs.IsMedia(result.FileName) {
+			results[i].Status = ArchiveFailed
+		} else {
+			results[i].Status = ArchiveSkipped
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,96 @@
+package photoprism
+
+import (
+	"archive/zip"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/nsfw"
+)
+
+func TestImport_Archive(t *testing.T) {
+	conf := config.TestConfig()
+
+	// Image classification is not needed to test the import.
+	tf := classify.New(conf.AssetsPath(), true)
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), "", true)
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	imp := NewImport(conf, ind, convert)
+
+	t.Run("Zip", func(t *testing.T) {
+		archiveName := filepath.Join(t.TempDir(), "holiday.zip")
+
+		f, err := os.Create(archiveName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w := zip.NewWriter(f)
+
+		// Create a unique image, so that it is not a duplicate of a file indexed by other tests.
+		img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+		c := color.RGBA{R: uint8(time.Now().UnixNano()), G: uint8(time.Now().Unix()), B: 128, A: 255}
+
+		for x := 0; x < 64; x++ {
+			for y := 0; y < 64; y++ {
+				img.Set(x, y, c)
+			}
+		}
+
+		if jw, err := w.Create("Holiday/Beach/archive.jpg"); err != nil {
+			t.Fatal(err)
+		} else if err := jpeg.Encode(jw, img, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		if tw, err := w.Create("notes.txt"); err != nil {
+			t.Fatal(err)
+		} else if _, err := tw.Write([]byte("Beach photos")); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		f.Close()
+
+		results, err := imp.Archive(archiveName, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(filepath.Join(conf.OriginalsPath(), "Holiday"))
+
+		assert.Len(t, results, 2)
+		assert.Equal(t, 1, results.Count(ArchiveImported))
+		assert.Equal(t, 1, results.Count(ArchiveSkipped))
+
+		for _, r := range results {
+			if r.FileName == "Holiday/Beach/archive.jpg" {
+				assert.Equal(t, ArchiveImported, r.Status)
+				assert.Equal(t, "Holiday/Beach/archive.jpg", r.Original)
+			}
+		}
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := imp.Archive("testdata/foo.jpg", nil)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,79 @@
+package photoprism
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// File name template tokens for imported originals.
+const (
+	NameTokenDate     = "{date}"     // Creation date, e.g. 20190705.
+	NameTokenTime     = "{time}"     // Creation time, e.g. 153230.
+	NameTokenYear     = "{year}"     // Creation year, e.g. 2019.
+	NameTokenMonth    = "{month}"    // Creation month, e.g. 07.
+	NameTokenDay      = "{day}"      // Creation day, e.g. 05.
+	NameTokenCamera   = "{camera}"   // Camera model, e.g. iPhone-SE.
+	NameTokenName     = "{name}"     // Original file name without extension.
+	NameTokenChecksum = "{checksum}" // CRC32 checksum, e.g. C167C6FD.
+	NameTokenCounter  = "{counter}"  // Counter to avoid name collisions, e.g. 0001.
+)
+
+// ImportNameHasCounter tests if the file name template contains a counter token.
+func ImportNameHasCounter(template string) bool {
+	return strings.Contains(template, NameTokenCounter)
+}
+
+// ImportName returns the file name without extension based on the template, or the canonical name if the
+// template is empty. The counter replaces the counter token and is ignored if there is none.
+func ImportName(template string, m *MediaFile, counter int) string {
+	if template == "" {
+		return m.CanonicalName()
+	}
+
+	date := m.DateCreated()
+
+	r := strings.NewReplacer(
+		NameTokenDate, date.Format("20060102"),
+		NameTokenTime, date.Format("150405"),
+		NameTokenYear, date.Format("2006"),
+		NameTokenMonth, date.Format("01"),
+		NameTokenDay, date.Format("02"),
+		NameTokenCamera, nameToken(m.CameraModel()),
+		NameTokenName, nameToken(m.BasePrefix(false)),
+		NameTokenChecksum, strings.ToUpper(m.Checksum()),
+		NameTokenCounter, fmt.Sprintf("%04d", counter),
+	)
+
+	result := nameToken(r.Replace(template))
+
+	if result == "" {
+		return m.CanonicalName()
+	}
+
+	return result
+}
+
+// nameToken removes characters that are not allowed in file names, and separators left over by empty values.
+func nameToken(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return r
+		case r == '-' || r == '_' || r == '.':
+			return r
+		case unicode.IsSpace(r):
+			return '-'
+		}
+
+		return -1
+	}, s)
+
+	for _, sep := range []string{"__", "--", ".."} {
+		for strings.Contains(s, sep) {
+			s = strings.ReplaceAll(s, sep, sep[:1])
+		}
+	}
+
+	return strings.Trim(s, "-_.")
+}
@@ -0,0 +1,52 @@
+package photoprism
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestImportName(t *testing.T) {
+	conf := config.TestConfig()
+
+	m, err := NewMediaFile(conf.ExamplesPath() + "/IMG_4120.JPG")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	date := m.DateCreated()
+
+	t.Run("Canonical", func(t *testing.T) {
+		assert.Equal(t, m.CanonicalName(), ImportName("", m, 1))
+	})
+	t.Run("DateTimeCounter", func(t *testing.T) {
+		assert.Equal(t, date.Format("20060102_150405")+"_0001", ImportName("{date}_{time}_{counter}", m, 1))
+		assert.Equal(t, date.Format("20060102_150405")+"_0012", ImportName("{date}_{time}_{counter}", m, 12))
+	})
+	t.Run("Name", func(t *testing.T) {
+		assert.Equal(t, date.Format("2006-01-02")+"_IMG_4120", ImportName("{year}-{month}-{day}_{name}", m, 1))
+	})
+	t.Run("Camera", func(t *testing.T) {
+		assert.Equal(t, "iPhone-SE_"+strings.ToUpper(m.Checksum()), ImportName("{camera}_{checksum}", m, 1))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, m.CanonicalName(), ImportName("//", m, 1))
+		assert.Equal(t, date.Format("20060102"), ImportName("../{date}/", m, 1))
+	})
+}
+
+func TestImportNameHasCounter(t *testing.T) {
+	assert.True(t, ImportNameHasCounter("{date}_{counter}"))
+	assert.False(t, ImportNameHasCounter("{date}_{time}"))
+	assert.False(t, ImportNameHasCounter(""))
+}
+
+func TestNameToken(t *testing.T) {
+	assert.Equal(t, "Canon-EOS-6D", nameToken("Canon EOS 6D"))
+	assert.Equal(t, "a_b", nameToken("_a__/b_"))
+	assert.Equal(t, "", nameToken("{}"))
+}
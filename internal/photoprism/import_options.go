@@ -7,6 +7,7 @@ type ImportOptions struct {
 	RemoveDotFiles         bool
 	RemoveExistingFiles    bool
 	RemoveEmptyDirectories bool
+	KeepFolders            bool
 }
 
 // ImportOptionsCopy returns import options for copying files to originals (read-only).
@@ -34,3 +35,12 @@ func ImportOptionsMove(path string) ImportOptions {
 
 	return result
 }
+
+// ImportOptionsArchive returns import options for moving files extracted from an archive to
+// originals, keeping their folder structure.
+func ImportOptionsArchive(path string) ImportOptions {
+	result := ImportOptionsMove(path)
+	result.KeepFolders = true
+
+	return result
+}
@@ -23,3 +23,13 @@ func TestImportOptionsMove(t *testing.T) {
 	assert.Equal(t, true, result.RemoveExistingFiles)
 	assert.Equal(t, true, result.RemoveEmptyDirectories)
 }
+
+func TestImportOptionsArchive(t *testing.T) {
+	result := ImportOptionsArchive("xxx")
+	assert.Equal(t, "xxx", result.Path)
+	assert.Equal(t, true, result.Move)
+	assert.Equal(t, true, result.RemoveDotFiles)
+	assert.Equal(t, true, result.RemoveExistingFiles)
+	assert.Equal(t, true, result.RemoveEmptyDirectories)
+	assert.Equal(t, true, result.KeepFolders)
+}
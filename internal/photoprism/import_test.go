@@ -1,6 +1,8 @@
 package photoprism
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/classify"
@@ -24,6 +26,27 @@ func TestNewImport(t *testing.T) {
 	assert.IsType(t, &Import{}, imp)
 }
 
+func TestImport_CheckStorage(t *testing.T) {
+	conf := config.TestConfig()
+
+	tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	imp := NewImport(conf, ind, convert)
+
+	opt := ImportOptionsCopy(conf.ExamplesPath())
+
+	assert.NoError(t, imp.CheckStorage(opt))
+
+	conf.Options().StorageReserve = 1 << 40
+	defer func() { conf.Options().StorageReserve = 0 }()
+
+	assert.Equal(t, config.ErrStorageFull, imp.CheckStorage(opt))
+}
+
 func TestImport_DestinationFilename(t *testing.T) {
 	conf := config.TestConfig()
 
@@ -51,6 +74,44 @@ func TestImport_DestinationFilename(t *testing.T) {
 	}
 
 	assert.Equal(t, conf.OriginalsPath()+"/2019/07/20190705_153230_C167C6FD.cr2", fileName)
+
+	t.Run("Template", func(t *testing.T) {
+		conf.Options().ImportName = "{date}_{name}_{counter}"
+		defer func() { conf.Options().ImportName = "" }()
+
+		fileName, err := imp.DestinationFilename(rawFile, rawFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, conf.OriginalsPath()+"/2019/07/20190705_IMG_2567_0001.cr2", fileName)
+	})
+	t.Run("CounterPerBaseName", func(t *testing.T) {
+		conf.Options().ImportName = "{date}_{name}_{counter}"
+		defer func() { conf.Options().ImportName = "" }()
+
+		existing := conf.OriginalsPath() + "/2019/07/20190705_IMG_2567_0001.jpg"
+
+		if err := os.MkdirAll(filepath.Dir(existing), os.ModePerm); err != nil {
+			t.Fatal(err)
+		} else if err := os.WriteFile(existing, []byte("{}"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(existing)
+
+		// The base name is already used by a file with another extension.
+		assert.Equal(t, 2, imp.destinationCounter(rawFile, ""))
+
+		fileName, err := imp.DestinationFilename(rawFile, rawFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, conf.OriginalsPath()+"/2019/07/20190705_IMG_2567_0002.cr2", fileName)
+	})
 }
 
 func TestImport_Start(t *testing.T) {
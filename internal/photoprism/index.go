@@ -1,15 +1,19 @@
 package photoprism
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
 	"strings"
 	"sync"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/karrick/godirwalk"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
@@ -18,6 +22,7 @@ import (
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/tracing"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
@@ -61,6 +66,41 @@ func (ind *Index) originalsPath() string {
 	return ind.conf.OriginalsPath()
 }
 
+// indexRoot represents an originals folder to be indexed.
+type indexRoot struct {
+	Name string
+	Path string
+	Dir  string
+}
+
+// roots returns the originals folders to be indexed. Additional roots are indexed together with
+// the main originals folder, unless a sub folder or a specific root is selected.
+func (ind *Index) roots(opt IndexOptions) (result []indexRoot, err error) {
+	extra := ind.conf.OriginalsRoots()
+
+	if opt.Root == "" || opt.Root == entity.RootOriginals {
+		result = append(result, indexRoot{Name: entity.RootOriginals, Path: ind.originalsPath(), Dir: opt.Path})
+
+		if opt.Path == "" || opt.Path == "/" {
+			for _, root := range extra {
+				result = append(result, indexRoot{Name: root.Name, Path: root.Path, Dir: "/"})
+			}
+		}
+	} else if root, sidecar, ok := extra.Find(opt.Root); ok && !sidecar {
+		result = append(result, indexRoot{Name: root.Name, Path: root.Path, Dir: opt.Path})
+	} else {
+		return result, fmt.Errorf("unknown originals root %s", sanitize.Log(opt.Root))
+	}
+
+	for _, root := range result {
+		if dir := filepath.Join(root.Path, root.Dir); !fs.PathExists(dir) {
+			return result, fmt.Errorf("%s does not exist", sanitize.Log(dir))
+		}
+	}
+
+	return result, nil
+}
+
 func (ind *Index) thumbPath() string {
 	return ind.conf.ThumbPath()
 }
@@ -70,6 +110,15 @@ func (ind *Index) Cancel() {
 	mutex.MainWorker.Cancel()
 }
 
+// folderDepth returns the depth of a folder relative to its originals root, which has a depth of 0.
+func folderDepth(relName string) int {
+	if relName == "" {
+		return 0
+	}
+
+	return strings.Count(relName, string(os.PathSeparator)) + 1
+}
+
 // Start indexes media files in the "originals" folder.
 func (ind *Index) Start(opt IndexOptions) fs.Done {
 	defer func() {
@@ -85,11 +134,10 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 		return done
 	}
 
-	originalsPath := ind.originalsPath()
-	optionsPath := filepath.Join(originalsPath, opt.Path)
+	roots, err := ind.roots(opt)
 
-	if !fs.PathExists(optionsPath) {
-		event.Error(fmt.Sprintf("index: %s does not exist", sanitize.Log(optionsPath)))
+	if err != nil {
+		event.Error(fmt.Sprintf("index: %s", err.Error()))
 		return done
 	}
 
@@ -100,6 +148,37 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 
 	defer mutex.MainWorker.Stop()
 
+	// Index jobs start a new trace, paths are not added as they may contain personal data.
+	ctx, span := tracing.Start(context.Background(), "index", attribute.Bool("index.rescan", opt.Rescan))
+
+	defer span.End()
+
+	// Limit scope to files of photos matching a search filter?
+	var filtered map[string]bool
+
+	if opt.Filter != "" {
+		var err error
+
+		if filtered, err = IndexFilterFiles(opt.Filter); err != nil {
+			event.Error(fmt.Sprintf("index: %s", err.Error()))
+			return done
+		}
+
+		log.Infof("index: filter %s matches %s", sanitize.Log(opt.Filter), english.Plural(len(filtered), "file", "files"))
+
+		// Matching files must be indexed again, even if unchanged.
+		opt.Rescan = true
+
+		// Re-run only the pipeline stages that are relevant for the filter, unless stages were selected.
+		if len(opt.Stages) == 0 {
+			opt.Stages = IndexFilterStages(opt.Filter)
+		}
+	}
+
+	if len(opt.Stages) > 0 {
+		log.Infof("index: running %s stages only", strings.Join(opt.Stages, ", "))
+	}
+
 	if err := ind.tensorFlow.Init(); err != nil {
 		log.Errorf("index: %s", err.Error())
 
@@ -110,7 +189,7 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 
 	// Start a fixed number of goroutines to index files.
 	var wg sync.WaitGroup
-	var numWorkers = ind.conf.Workers()
+	var numWorkers = ind.conf.TaskWorkers(config.TaskIndex)
 	wg.Add(numWorkers)
 	for i := 0; i < numWorkers; i++ {
 		go func() {
@@ -126,122 +205,205 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 	defer ind.files.Done()
 
 	filesIndexed := 0
-	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
 
-	if err := ignore.Dir(originalsPath); err != nil {
-		log.Infof("index: %s", err)
-	}
+	resetSkipped("index")
 
-	ignore.Log = func(fileName string) {
-		log.Infof(`index: ignored "%s"`, fs.RelName(fileName, originalsPath))
-	}
+	// Directory modification times are recorded so that purge can skip unchanged folders.
+	scanned := make([]*dirTimes, 0, len(roots))
 
-	err := godirwalk.Walk(optionsPath, &godirwalk.Options{
-		ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
-			log.Errorf("index: %s", strings.Replace(err.Error(), originalsPath, "", 1))
-			return godirwalk.SkipNode
-		},
-		Callback: func(fileName string, info *godirwalk.Dirent) error {
-			if mutex.MainWorker.Canceled() {
-				return errors.New("indexing canceled")
-			}
+	// Hard links and symbolic links to files that were already found are added as duplicates.
+	links := make(fileLinks)
 
-			isDir := info.IsDir()
-			isSymlink := info.IsSymlink()
-			relName := fs.RelName(fileName, originalsPath)
+	for _, root := range roots {
+		dirs := newDirTimes(root.Name)
 
-			if skip, result := fs.SkipWalk(fileName, isDir, isSymlink, done, ignore); skip {
-				if (isSymlink || isDir) && result != filepath.SkipDir {
-					folder := entity.NewFolder(entity.RootOriginals, relName, fs.BirthTime(fileName))
+		ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
 
-					if err := folder.Create(); err == nil {
-						log.Infof("index: added folder /%s", folder.Path)
-					}
+		if err := ignore.Dir(root.Path); err != nil {
+			log.Infof("index: %s", err)
+		}
+
+		ignore.Log = func(fileName string) {
+			log.Infof(`index: ignored "%s"`, fs.RelName(fileName, root.Path))
+		}
+
+		followSymlinks := ind.conf.FollowSymlinks(root.Name)
+		maxDepth := ind.conf.OriginalsDepth()
+
+		err := godirwalk.Walk(filepath.Join(root.Path, root.Dir), &godirwalk.Options{
+			ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
+				log.Errorf("index: %s", strings.Replace(err.Error(), root.Path, "", 1))
+				return godirwalk.SkipNode
+			},
+			Callback: func(fileName string, info *godirwalk.Dirent) error {
+				if mutex.MainWorker.Canceled() {
+					return errors.New("indexing canceled")
+				}
+
+				isDir := info.IsDir()
+				isSymlink := info.IsSymlink()
+				relName := fs.RelName(fileName, root.Path)
+
+				if !isSymlink {
+					// Regular file or folder.
+				} else if !followSymlinks {
+					done[fileName] = fs.Found
+					return godirwalk.SkipThis
+				} else if link, err := os.Stat(fileName); err == nil && link.Mode().IsRegular() {
+					// Symbolic links to files are indexed like regular files.
+					isSymlink = false
 				}
 
-				if isDir {
-					event.Publish("index.folder", event.Data{
-						"filePath": relName,
+				// Skip folders below the configured maximum depth.
+				if depth := folderDepth(relName); maxDepth > 0 && (isDir || isSymlink) && depth > maxDepth {
+					addSkippedFile("index", SkippedFile{
+						Root:    root.Name,
+						Name:    relName,
+						Reason:  SkippedDepth,
+						Details: fmt.Sprintf("%d / %d", depth, maxDepth),
 					})
+
+					log.Infof("index: skipped %s, exceeds folder depth limit", sanitize.Log(relName))
+					done[fileName] = fs.Found
+
+					return godirwalk.SkipThis
 				}
 
-				return result
-			}
+				if skip, result := fs.SkipWalk(fileName, isDir, isSymlink, done, ignore); skip {
+					if (isSymlink || isDir) && result != filepath.SkipDir {
+						folder := entity.NewFolder(root.Name, relName, fs.BirthTime(fileName))
 
-			done[fileName] = fs.Found
+						if err := folder.Create(); err == nil {
+							log.Infof("index: added folder /%s", folder.Path)
+						}
 
-			if !fs.IsMedia(fileName) {
-				return nil
-			}
+						dirs.Scanned(relName, DirModTime(fileName))
+					}
 
-			mf, err := NewMediaFile(fileName)
+					if isDir {
+						event.Publish("index.folder", event.Data{
+							"filePath": relName,
+						})
+					}
 
-			if err != nil {
-				log.Error(err)
-				return nil
-			}
+					return result
+				}
 
-			if mf.FileSize() == 0 {
-				log.Infof("index: skipped empty file %s", sanitize.Log(mf.BaseName()))
-				return nil
-			}
+				done[fileName] = fs.Found
 
-			if ind.files.Indexed(relName, entity.RootOriginals, mf.modTime, opt.Rescan) {
-				return nil
-			}
+				if !fs.IsMedia(fileName) {
+					return nil
+				} else if filtered != nil && !filtered[relName] {
+					return nil
+				}
 
-			related, err := mf.RelatedFiles(ind.conf.Settings().StackSequences())
+				mf, err := NewMediaFile(fileName)
 
-			if err != nil {
-				log.Warnf("index: %s", err.Error())
+				if err != nil {
+					log.Error(err)
+					return nil
+				}
 
-				return nil
-			}
+				if mf.FileSize() == 0 {
+					log.Infof("index: skipped empty file %s", sanitize.Log(mf.BaseName()))
+					return nil
+				}
+
+				if mf.IsSidecar() {
+					// Sidecar files are indexed with their related media files.
+				} else if first, found := links.First(fileName); found {
+					if err := addFileLink(mf, first); err != nil {
+						log.Warnf("index: %s in %s (add link)", err, sanitize.Log(relName))
+					}
 
-			var files MediaFiles
+					done[fileName] = fs.Processed
 
-			for _, f := range related.Files {
-				if done[f.FileName()].Processed() {
-					continue
+					return nil
 				}
 
-				if f.FileSize() == 0 || ind.files.Indexed(f.RootRelName(), f.Root(), f.ModTime(), opt.Rescan) {
-					done[f.FileName()] = fs.Found
-					continue
+				if ind.files.Indexed(relName, root.Name, mf.modTime, opt.Rescan) {
+					return nil
 				}
 
-				files = append(files, f)
-				filesIndexed++
-				done[f.FileName()] = fs.Processed
-			}
+				related, err := mf.RelatedFiles(ind.conf.Settings().StackSequences())
+
+				if err != nil {
+					log.Warnf("index: %s", err.Error())
+
+					return nil
+				}
+
+				var files MediaFiles
+
+				for _, f := range related.Files {
+					if done[f.FileName()].Processed() {
+						continue
+					}
+
+					if f.FileSize() == 0 || ind.files.Indexed(f.RootRelName(), f.Root(), f.ModTime(), opt.Rescan) {
+						done[f.FileName()] = fs.Found
+						continue
+					}
+
+					files = append(files, f)
+					filesIndexed++
+					done[f.FileName()] = fs.Processed
+				}
+
+				done[fileName] = fs.Processed
 
-			done[fileName] = fs.Processed
+				if len(files) == 0 || related.Main == nil {
+					// Nothing to do.
+					return nil
+				}
+
+				related.Files = files
+
+				jobs <- IndexJob{
+					FileName: mf.FileName(),
+					Related:  related,
+					IndexOpt: opt,
+					Ind:      ind,
+					Ctx:      ctx,
+				}
 
-			if len(files) == 0 || related.Main == nil {
-				// Nothing to do.
 				return nil
-			}
+			},
+			Unsorted:            false,
+			FollowSymbolicLinks: true,
+		})
 
-			related.Files = files
+		if err != nil {
+			log.Error(err.Error())
+		} else if filtered == nil {
+			// Directory modification times may only be saved if all files have been checked, folders with
+			// missing files must be checked by the purge worker first.
+			dirs.Discard(ind.files.MissingDirs(root.Name, func(fileName string) bool {
+				return done[filepath.Join(root.Path, fileName)].Exists()
+			}))
 
-			jobs <- IndexJob{
-				FileName: mf.FileName(),
-				Related:  related,
-				IndexOpt: opt,
-				Ind:      ind,
-			}
+			scanned = append(scanned, dirs)
+		}
+
+		addSkipped("index", root.Name, root.Path, ignore)
 
-			return nil
-		},
-		Unsorted:            false,
-		FollowSymbolicLinks: true,
-	})
+		if mutex.MainWorker.Canceled() {
+			break
+		}
+	}
 
 	close(jobs)
 	wg.Wait()
 
-	if err != nil {
-		log.Error(err.Error())
+	span.SetAttributes(attribute.Int("index.files", filesIndexed))
+
+	// Save directory modification times after all files have been indexed.
+	if !mutex.MainWorker.Canceled() {
+		for _, dirs := range scanned {
+			if n := dirs.Save(); n > 0 {
+				log.Debugf("index: updated modification time of %s", english.Plural(n, "folder", "folders"))
+			}
+		}
 	}
 
 	if filesIndexed > 0 {
@@ -252,8 +414,14 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 		// Run facial recognition if enabled.
 		if w := NewFaces(ind.conf); w.Disabled() {
 			log.Debugf("index: skipping facial recognition")
-		} else if err := w.Start(FacesOptionsDefault()); err != nil {
-			log.Errorf("index: %s", err)
+		} else {
+			_, facesSpan := tracing.Start(ctx, "index.faces")
+			err := w.Start(FacesOptionsDefault())
+			tracing.End(facesSpan, err)
+
+			if err != nil {
+				log.Errorf("index: %s", err)
+			}
 		}
 
 		event.Publish("index.updating", event.Data{
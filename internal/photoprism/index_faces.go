@@ -1,11 +1,16 @@
 package photoprism
 
 import (
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
 
+	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
@@ -51,3 +56,124 @@ func (ind *Index) Faces(jpeg *MediaFile, expected int) face.Faces {
 
 	return faces
 }
+
+// Regions adds face markers for regions that were tagged in other applications, e.g. Lightroom or Picasa.
+func (ind *Index) Regions(jpeg *MediaFile, file *entity.File) {
+	if jpeg == nil || file == nil {
+		return
+	}
+
+	add := func(regions meta.Regions, src string) {
+		for _, r := range regions {
+			area := crop.NewArea("face", r.X, r.Y, r.W, r.H)
+
+			if err := file.AddRegion(area, r.Name, src, int(r.W*float32(jpeg.Width()))); err != nil {
+				log.Warnf("index: %s in %s (add face region)", err, sanitize.Log(jpeg.BaseName()))
+			}
+		}
+	}
+
+	add(jpeg.XmpRegions(), entity.SrcXmp)
+	add(jpeg.PicasaRegions(), entity.SrcMeta)
+}
+
+// KeyframesDir is the hidden sidecar folder for video keyframes, so that they are not indexed as related files.
+const KeyframesDir = ".keyframes"
+
+// VideoFaces finds faces in several keyframes of a video and adds them as markers to the keyframe files,
+// so that the marker coordinates match the image the faces were found in. Returns the number of valid faces.
+func (ind *Index) VideoFaces(video *MediaFile, photo entity.Photo) (count int) {
+	keyframes := Config().FaceKeyframes()
+
+	if video == nil || photo.ID == 0 || keyframes < 1 {
+		return 0
+	}
+
+	dir := filepath.Join(Config().SidecarPath(), KeyframesDir, video.Hash())
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		log.Debugf("index: %s in %s (video faces)", err, sanitize.Log(video.BaseName()))
+		return 0
+	}
+
+	fileNames, err := ind.convert.Keyframes(video, keyframes, dir)
+
+	if err != nil {
+		log.Debugf("index: %s in %s (video faces)", err, sanitize.Log(video.BaseName()))
+		return 0
+	}
+
+	var found face.Faces
+
+	for _, fileName := range fileNames {
+		jpeg, err := NewMediaFile(fileName)
+
+		if err != nil {
+			log.Debugf("index: %s in %s (video faces)", err, sanitize.Log(video.BaseName()))
+			continue
+		}
+
+		// Skip faces that have already been found in a previous keyframe.
+		var faces face.Faces
+
+		for _, f := range ind.Faces(jpeg, 0) {
+			if !found.Similar(f, Config().FaceMatchDist()) {
+				faces.Append(f)
+				found.Append(f)
+			}
+		}
+
+		if len(faces) == 0 {
+			logWarn("index", os.Remove(fileName))
+			continue
+		}
+
+		file, err := keyframeFile(jpeg, photo)
+
+		if err != nil {
+			log.Errorf("index: %s in %s (add keyframe)", err, sanitize.Log(video.BaseName()))
+			continue
+		}
+
+		file.AddFaces(faces)
+
+		if err = file.Save(); err != nil {
+			log.Errorf("index: %s in %s (save keyframe)", err, sanitize.Log(video.BaseName()))
+			continue
+		}
+
+		count += file.ValidFaceCount()
+	}
+
+	if l := len(found); l > 0 {
+		log.Infof("index: found %s in %s keyframes", english.Plural(l, "face", "faces"), sanitize.Log(video.BaseName()))
+	}
+
+	return count
+}
+
+// keyframeFile returns the sidecar file entity of a video keyframe, and creates it if it doesn't exist yet.
+func keyframeFile(jpeg *MediaFile, photo entity.Photo) (*entity.File, error) {
+	if file, err := entity.FirstFileByHash(jpeg.Hash()); err == nil && file.PhotoID == photo.ID {
+		return &file, nil
+	}
+
+	file := &entity.File{
+		PhotoID:         photo.ID,
+		PhotoUID:        photo.PhotoUID,
+		FileName:        jpeg.RelName(Config().SidecarPath()),
+		FileRoot:        entity.RootSidecar,
+		FileHash:        jpeg.Hash(),
+		FileSize:        jpeg.FileSize(),
+		FileType:        string(jpeg.FileType()),
+		FileMime:        jpeg.MimeType(),
+		FileSidecar:     true,
+		FileWidth:       jpeg.Width(),
+		FileHeight:      jpeg.Height(),
+		FileAspectRatio: jpeg.AspectRatio(),
+		FileOrientation: jpeg.Orientation(),
+		ModTime:         jpeg.ModTime().Unix(),
+	}
+
+	return file, file.Create()
+}
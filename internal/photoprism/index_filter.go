@@ -0,0 +1,103 @@
+package photoprism
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// IndexFilterAliases maps shorthand index filters to search filters.
+var IndexFilterAliases = map[string]string{
+	"missing-location": "geo:no",
+	"low-quality":      "review:true",
+}
+
+// IndexFilterAliasStages maps shorthand index filters to the pipeline stages that are relevant for them.
+var IndexFilterAliasStages = map[string][]string{
+	"missing-location": {IndexStageMeta},
+	"low-quality":      {IndexStageMeta, IndexStageLabels},
+}
+
+// IndexFilterStages returns the pipeline stages that are relevant for an index filter, or nil if
+// the filter contains other terms, in which case all stages should run.
+func IndexFilterStages(filter string) (stages []string) {
+	selected := make(map[string]bool)
+
+	for _, term := range strings.Fields(filter) {
+		alias, ok := IndexFilterAliasStages[strings.ToLower(term)]
+
+		if !ok {
+			return nil
+		}
+
+		for _, stage := range alias {
+			selected[stage] = true
+		}
+	}
+
+	for _, stage := range IndexStages {
+		if selected[stage] {
+			stages = append(stages, stage)
+		}
+	}
+
+	return stages
+}
+
+// IndexFilterQuery returns the search query for an index filter, expanding shorthand aliases.
+func IndexFilterQuery(filter string) string {
+	terms := strings.Fields(filter)
+
+	for i, term := range terms {
+		if alias, ok := IndexFilterAliases[strings.ToLower(term)]; ok {
+			terms[i] = alias
+		}
+	}
+
+	return strings.Join(terms, " ")
+}
+
+// IndexFilterFiles returns the original file names of all photos matching the filter, e.g. "camera:2 quality:3".
+func IndexFilterFiles(filter string) (result map[string]bool, err error) {
+	result = make(map[string]bool)
+
+	f := form.SearchPhotos{
+		Query:  IndexFilterQuery(filter),
+		Count:  search.MaxResults,
+		Offset: 0,
+	}
+
+	var uids []string
+
+	for {
+		photos, count, err := search.Photos(f)
+
+		if err != nil {
+			return result, err
+		}
+
+		for _, p := range photos {
+			uids = append(uids, p.PhotoUID)
+		}
+
+		if count < f.Count {
+			break
+		}
+
+		f.Offset += count
+	}
+
+	fileNames, err := query.OriginalFileNames(uids)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, fileName := range fileNames {
+		result[fileName] = true
+	}
+
+	return result, nil
+}
@@ -0,0 +1,144 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestIndexFilterQuery(t *testing.T) {
+	t.Run("Alias", func(t *testing.T) {
+		assert.Equal(t, "geo:no", IndexFilterQuery("missing-location"))
+	})
+	t.Run("AliasAndFilter", func(t *testing.T) {
+		assert.Equal(t, "camera:2 review:true", IndexFilterQuery(" camera:2  low-quality "))
+	})
+	t.Run("NoAlias", func(t *testing.T) {
+		assert.Equal(t, "quality:3", IndexFilterQuery("quality:3"))
+	})
+}
+
+func TestIndexFilterStages(t *testing.T) {
+	t.Run("Alias", func(t *testing.T) {
+		assert.Equal(t, []string{IndexStageMeta}, IndexFilterStages("missing-location"))
+	})
+	t.Run("Aliases", func(t *testing.T) {
+		assert.Equal(t, []string{IndexStageMeta, IndexStageLabels}, IndexFilterStages("low-quality missing-location"))
+	})
+	t.Run("Filter", func(t *testing.T) {
+		assert.Nil(t, IndexFilterStages("missing-location camera:2"))
+	})
+}
+
+func TestIndexFilterFiles(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		files, err := IndexFilterFiles("uid:pt9jtdre2lvl0yh7")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, files["2790/07/27900704_070228_D6D51B6C.jpg"])
+		assert.False(t, files["2790/02/Photo01.dng"])
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		files, err := IndexFilterFiles("uid:pt9jtdre2lvl0y99")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, files)
+	})
+}
+
+func TestIndex_StartFilter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	conf := config.TestConfig()
+
+	// Stages are tested with metadata only, so that no models are required.
+	conf.Options().DisableTensorFlow = true
+	defer func() { conf.Options().DisableTensorFlow = false }()
+
+	dir := filepath.Join(conf.OriginalsPath(), "index-filter")
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"beach_colorfilter.jpg", "fern_green.jpg"} {
+		if err := fs.Copy(filepath.Join(conf.ExamplesPath(), name), filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+
+	opt := IndexOptionsAll()
+	opt.Path = "index-filter"
+
+	ind.Start(opt)
+
+	photo := func(name string) (result entity.Photo) {
+		var file entity.File
+
+		if err := entity.UnscopedDb().Where("file_name = ?", "index-filter/"+name).First(&file).Error; err != nil {
+			t.Fatal(err)
+		} else if err = entity.UnscopedDb().Preload("Camera").First(&result, file.PhotoID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		return result
+	}
+
+	// Remove the camera of both photos, so that it is restored from metadata when the meta stage runs.
+	reset := func() {
+		if err := entity.UnscopedDb().Model(entity.Photo{}).
+			Where("photo_uid IN (?)", []string{photo("beach_colorfilter.jpg").PhotoUID, photo("fern_green.jpg").PhotoUID}).
+			UpdateColumns(entity.Values{"camera_id": entity.UnknownCamera.ID, "camera_src": entity.SrcAuto}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filter := "uid:" + photo("beach_colorfilter.jpg").PhotoUID
+
+	t.Run("OtherStage", func(t *testing.T) {
+		reset()
+
+		matched, other := photo("beach_colorfilter.jpg").UpdatedAt, photo("fern_green.jpg").UpdatedAt
+
+		ind.Start(IndexOptions{Path: "/", Filter: filter, Stages: []string{IndexStageLabels}})
+
+		assert.True(t, photo("beach_colorfilter.jpg").UpdatedAt.After(matched))
+		assert.Equal(t, other, photo("fern_green.jpg").UpdatedAt)
+		assert.Equal(t, entity.UnknownCamera.ID, photo("beach_colorfilter.jpg").CameraID)
+		assert.Equal(t, entity.UnknownCamera.ID, photo("fern_green.jpg").CameraID)
+	})
+	t.Run("MetaStage", func(t *testing.T) {
+		reset()
+
+		ind.Start(IndexOptions{Path: "/", Filter: filter, Stages: []string{IndexStageMeta}})
+
+		assert.Equal(t, "EOS 5D", photo("beach_colorfilter.jpg").Camera.CameraModel)
+		assert.Equal(t, entity.UnknownCamera.ID, photo("fern_green.jpg").CameraID)
+	})
+}
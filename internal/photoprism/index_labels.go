@@ -9,8 +9,8 @@ import (
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
-// Labels classifies a JPEG image and returns matching labels.
-func (ind *Index) Labels(jpeg *MediaFile) (results classify.Labels) {
+// Labels classifies a JPEG image and returns matching labels as well as the image embedding.
+func (ind *Index) Labels(jpeg *MediaFile) (results classify.Labels, embedding classify.Embedding) {
 	start := time.Now()
 
 	var sizes []thumb.Name
@@ -31,7 +31,7 @@ func (ind *Index) Labels(jpeg *MediaFile) (results classify.Labels) {
 			continue
 		}
 
-		imageLabels, err := ind.tensorFlow.File(filename)
+		imageLabels, imageEmbedding, err := ind.tensorFlow.ClassifyFile(filename)
 
 		if err != nil {
 			log.Debugf("%s in %s", err, sanitize.Log(jpeg.BaseName()))
@@ -39,6 +39,7 @@ func (ind *Index) Labels(jpeg *MediaFile) (results classify.Labels) {
 		}
 
 		labels = append(labels, imageLabels...)
+		embedding = embedding.Add(imageEmbedding)
 	}
 
 	// Sort by priority and uncertainty
@@ -62,5 +63,5 @@ func (ind *Index) Labels(jpeg *MediaFile) (results classify.Labels) {
 		log.Infof("index: matched %d labels with %s [%s]", l, sanitize.Log(jpeg.BaseName()), time.Since(start))
 	}
 
-	return results
+	return results, embedding
 }
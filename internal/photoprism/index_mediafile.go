@@ -3,6 +3,7 @@ package photoprism
 import (
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -51,10 +52,16 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 	photo := entity.NewPhoto(o.Stack)
 	metaData := meta.NewData()
 	labels := classify.Labels{}
+	embedding := classify.Embedding{}
 	stripSequence := Config().Settings().StackSequences() && o.Stack
 
 	fileRoot, fileBase, filePath, fileName := m.PathNameInfo(stripSequence)
 	fullBase := m.BasePrefix(false)
+
+	// Edited versions like IMG_1234-edit.jpg are stacked with their originals, even if sequences are not.
+	if o.Stack && !stripSequence {
+		fileBase = fs.StripEditSuffix(fileBase)
+	}
 	logName := sanitize.Log(fileName)
 	fileSize, modTime, err := m.Stat()
 
@@ -234,6 +241,11 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 		log.Error(err)
 	}
 
+	// Remove file from broken files table if exists, it will be added again if decoding still fails.
+	if err := entity.PurgeBrokenFile(m.RootRelName(), m.Root()); err != nil {
+		log.Error(err)
+	}
+
 	// Fetch photo details such as keywords, subject, and artist.
 	details := photo.GetDetails()
 
@@ -241,11 +253,14 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 	if !photoExists {
 		photo.PhotoQuality = -1
 
+		// Imported photos must be approved in review first?
+		photo.PhotoPending = o.Pending
+
 		if o.Stack {
 			photo.PhotoStack = entity.IsStackable
 		}
 
-		if yamlName := fs.FormatYaml.FindFirst(m.FileName(), []string{Config().SidecarPath(), fs.HiddenPath}, Config().OriginalsPath(), stripSequence); yamlName != "" {
+		if yamlName := m.YamlSidecarName(stripSequence); yamlName != "" {
 			if err := photo.LoadFromYaml(yamlName); err != nil {
 				log.Errorf("index: %s in %s (restore from yaml)", err.Error(), logName)
 			} else if err := photo.Find(); err != nil {
@@ -287,7 +302,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 	if !fileStacked && (file.FilePrimary || photo.PhotoName == "") {
 		photo.PhotoPath = filePath
 
-		if !o.Stack || !stripSequence || photo.PhotoStack == entity.IsUnstacked {
+		if !o.Stack || photo.PhotoStack == entity.IsUnstacked {
 			photo.PhotoName = fullBase
 		} else {
 			photo.PhotoName = fileBase
@@ -318,27 +333,37 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 	// Extra labels to ba added when new files have a photo id.
 	extraLabels := classify.Labels{}
 
+	// Run only the selected pipeline stages for files that have already been indexed.
+	runMeta := !fileExists || o.Stage(IndexStageMeta)
+	runLabels := !fileExists || o.Stage(IndexStageLabels)
+	runFaces := !fileExists || o.Stage(IndexStageFaces)
+
 	// Detect faces in images?
 	if o.FacesOnly && (!photoExists || !fileExists || !file.FilePrimary || file.FileError != "") {
 		// New and non-primary files can be skipped when updating faces only.
 		result.Status = IndexSkipped
 		return result
-	} else if ind.findFaces && file.FilePrimary {
+	} else if file.FilePrimary {
 		if markers := file.Markers(); markers != nil {
 			// Detect faces.
-			faces := ind.Faces(m, markers.DetectedFaceCount())
+			if ind.findFaces && runFaces {
+				faces := ind.Faces(m, markers.DetectedFaceCount())
 
-			// Create markers from faces and add them.
-			if len(faces) > 0 {
-				file.AddFaces(faces)
+				// Create markers from faces and add them.
+				if len(faces) > 0 {
+					file.AddFaces(faces)
+				}
 			}
 
+			// Add face regions tagged in other applications, e.g. Lightroom or Picasa, even if face detection is disabled.
+			ind.Regions(m, &file)
+
 			// Any new markers?
 			if file.UnsavedMarkers() {
 				// Add matching labels.
 				extraLabels = append(extraLabels, file.Markers().Labels()...)
-			} else if o.FacesOnly {
-				// Skip when indexing faces only.
+			} else if o.FacesOnly && photo.PhotoType != entity.TypeVideo {
+				// Skip when indexing faces only, videos may still have new faces in their keyframes.
 				result.Status = IndexSkipped
 				return result
 			}
@@ -358,6 +383,10 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			log.Debugf("%s while detecting colors", err.Error())
 			file.FileError = err.Error()
 			file.FilePrimary = false
+
+			if err := entity.AddBrokenFile(m.RootRelName(), m.Root(), m.FileSize(), err); err != nil {
+				log.Errorf("index: %s in %s (add broken file)", err, logName)
+			}
 		} else {
 			file.FileMainColor = p.MainColor.Name()
 			file.FileColors = p.Colors.Hex()
@@ -403,12 +432,16 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			}
 		}
 	case m.IsXMP():
-		if metaData, err := meta.XMP(m.FileName()); err == nil {
+		if !runMeta {
+			// Keep existing metadata.
+		} else if metaData, err := meta.XMP(m.FileName()); err == nil {
 			// Update basic metadata.
 			photo.SetTitle(metaData.Title, entity.SrcXmp)
 			photo.SetDescription(metaData.Description, entity.SrcXmp)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcXmp)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcXmp)
+			photo.SetMotion(metaData.Heading, metaData.Speed, metaData.HasHeading, entity.SrcXmp)
+			photo.SetRating(metaData.Rating, entity.SrcXmp)
 
 			// Update metadata details.
 			details.SetKeywords(metaData.Keywords.String(), entity.SrcXmp)
@@ -416,17 +449,24 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			details.SetSubject(metaData.Subject, entity.SrcXmp)
 			details.SetArtist(metaData.Artist, entity.SrcXmp)
 			details.SetCopyright(metaData.Copyright, entity.SrcXmp)
+
+			// Panorama projection, e.g. from GPano tags.
+			photo.SetProjection(metaData.Projection)
 		} else {
 			log.Warn(err.Error())
 			file.FileError = err.Error()
 		}
-	case m.IsRaw(), m.IsHEIF(), m.IsImageOther():
-		if metaData := m.MetaData(); metaData.Error == nil {
+	case m.IsRaw(), m.IsHEIF(), m.IsImageOther(), m.IsDicom():
+		if !runMeta {
+			// Keep existing metadata.
+		} else if metaData := m.MetaData(); metaData.Error == nil {
 			// Update basic metadata.
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
+			photo.SetMotion(metaData.Heading, metaData.Speed, metaData.HasHeading, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
 
 			// Update metadata details.
@@ -460,6 +500,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			file.FileHeight = m.Height()
 			file.FileAspectRatio = m.AspectRatio()
 			file.FilePortrait = m.Portrait()
+			file.FilePages = m.Pages()
 			file.SetProjection(metaData.Projection)
 			file.SetHDR(metaData.IsHDR())
 			file.SetColorProfile(metaData.ColorProfile)
@@ -482,11 +523,15 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			}
 		}
 	case m.IsVideo():
-		if metaData := m.MetaData(); metaData.Error == nil {
+		if !runMeta {
+			// Keep existing metadata.
+		} else if metaData := m.MetaData(); metaData.Error == nil {
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
+			photo.SetMotion(metaData.Heading, metaData.Speed, metaData.HasHeading, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
 
 			// Update metadata details.
@@ -580,8 +625,11 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 		primaryFile = file
 
 		// Classify images with TensorFlow?
-		if ind.findLabels {
-			labels = ind.Labels(m)
+		if ind.findLabels && runLabels {
+			labels, embedding = ind.Labels(m)
+
+			// Add a pet marker so that pets can be named like people.
+			ind.Pet(&file, labels, embedding)
 
 			// Append labels from other sources such as face detection.
 			if len(extraLabels) > 0 {
@@ -594,12 +642,16 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 		}
 
 		// Read metadata from embedded Exif and JSON sidecar file, if exists.
-		if metaData := m.MetaData(); metaData.Error == nil {
+		if !runMeta {
+			// Keep existing metadata.
+		} else if metaData := m.MetaData(); metaData.Error == nil {
 			// Update basic metadata.
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
+			photo.SetMotion(metaData.Heading, metaData.Speed, metaData.HasHeading, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
 
 			// Update metadata details.
@@ -616,9 +668,11 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			}
 		}
 
-		photo.SetCamera(entity.FirstOrCreateCamera(entity.NewCamera(m.CameraModel(), m.CameraMake())), entity.SrcMeta)
-		photo.SetLens(entity.FirstOrCreateLens(entity.NewLens(m.LensModel(), m.LensMake())), entity.SrcMeta)
-		photo.SetExposure(m.FocalLength(), m.FNumber(), m.Iso(), m.Exposure(), entity.SrcMeta)
+		if runMeta {
+			photo.SetCamera(entity.FirstOrCreateCamera(entity.NewCamera(m.CameraModel(), m.CameraMake())), entity.SrcMeta)
+			photo.SetLens(entity.FirstOrCreateLens(entity.NewLens(m.LensModel(), m.LensMake())), entity.SrcMeta)
+			photo.SetExposure(m.FocalLength(), m.FNumber(), m.Iso(), m.Exposure(), entity.SrcMeta)
+		}
 
 		var locLabels classify.Labels
 
@@ -641,15 +695,18 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 	// Panorama?
 	if file.Panorama() {
 		photo.PhotoPanorama = true
+		photo.SetProjection(file.Projection())
 	}
 
 	// Set remaining file properties.
 	file.FileSidecar = m.IsSidecar()
+	file.FileEdited = m.IsEdited()
 	file.FileVideo = m.IsVideo()
 	file.FileType = string(m.FileType())
 	file.FileMime = m.MimeType()
 	file.FileOrientation = m.Orientation()
 	file.ModTime = modTime.Unix()
+	file.FileInode = fs.FileID(m.FileName())
 
 	// Detect ICC color profile for JPEGs if still unknown at this point.
 	if file.FileColorProfile == "" && file.FileType == string(fs.FormatJpeg) {
@@ -751,6 +808,12 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			log.Errorf("index: %s in %s (sync keywords and labels)", err, logName)
 		}
 
+		if !embedding.Empty() {
+			if err := entity.SavePhotoEmbedding(photo.ID, photo.PhotoUID, embedding); err != nil {
+				log.Errorf("index: %s in %s (save embedding)", err, logName)
+			}
+		}
+
 		if err := photo.IndexKeywords(); err != nil {
 			log.Errorf("index: %s in %s (save keywords)", err, logName)
 		}
@@ -803,6 +866,19 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 		}
 	}
 
+	// Detect faces in video keyframes so that people search also finds videos.
+	if ind.findFaces && runFaces && file.FilePrimary && photo.PhotoType == entity.TypeVideo && o.KeyframeFaces(fileExists) {
+		if video, err := query.VideoByPhotoUID(photo.PhotoUID); err != nil {
+			log.Debugf("index: %s in %s (find video)", err, logName)
+		} else if videoFile, err := NewMediaFile(FileName(video.FileRoot, video.FileName)); err != nil {
+			log.Debugf("index: %s in %s (find video)", err, logName)
+		} else if n := ind.VideoFaces(videoFile, photo); n > 0 {
+			if err := photo.Update("PhotoFaces", photo.PhotoFaces+n); err != nil {
+				log.Errorf("index: %s in %s (update face count)", err, logName)
+			}
+		}
+	}
+
 	result.FileID = file.ID
 	result.FileUID = file.FileUID
 
@@ -832,12 +908,20 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 
 	if file.FilePrimary && Config().BackupYaml() {
 		// Write YAML sidecar file (optional).
-		yamlFile := photo.YamlFileName(Config().OriginalsPath(), Config().SidecarPath())
+		yamlFile := photo.YamlFileName(m.OriginalsPath(), m.SidecarPath())
 
 		if err := photo.SaveAsYaml(yamlFile); err != nil {
 			log.Errorf("index: %s in %s (update yaml)", err.Error(), logName)
 		} else {
 			log.Debugf("index: updated yaml file %s", sanitize.Log(filepath.Base(yamlFile)))
+
+			// Remove files in a previous layout or with a different compression.
+			for _, fileName := range photo.YamlFileNames(m.OriginalsPath(), m.SidecarPath()) {
+				if fileName != yamlFile && fs.FileExists(fileName) {
+					log.Debugf("index: removing outdated yaml file %s", sanitize.Log(filepath.Base(fileName)))
+					logWarn("index", os.Remove(fileName))
+				}
+			}
 		}
 	}
 
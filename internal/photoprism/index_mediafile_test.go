@@ -1,14 +1,18 @@
 package photoprism
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 func TestIndex_MediaFile(t *testing.T) {
@@ -88,6 +92,80 @@ func TestIndex_MediaFile(t *testing.T) {
 	})
 }
 
+func TestIndex_MediaFile_Edited(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	conf := config.TestConfig()
+
+	// Edited versions must be stacked with the default settings.
+	assert.False(t, conf.Settings().StackSequences())
+
+	// Stacking is tested with metadata only, so that no models are required.
+	conf.Options().DisableTensorFlow = true
+	defer func() { conf.Options().DisableTensorFlow = false }()
+
+	dir := filepath.Join(conf.OriginalsPath(), "edited")
+
+	defer os.RemoveAll(dir)
+
+	tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+
+	// Copies an example to the originals folder and indexes it with the default options.
+	index := func(src, name string) IndexResult {
+		fileName := filepath.Join(dir, name)
+
+		if err := fs.Copy(filepath.Join(conf.ExamplesPath(), src), fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		mediaFile, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result := ind.MediaFile(mediaFile, IndexOptionsAll(), "", "")
+
+		assert.True(t, result.Indexed())
+
+		return result
+	}
+
+	t.Run("OriginalFirst", func(t *testing.T) {
+		original := index("cat_black.jpg", "cat_1234.jpg")
+		edited := index("cat_brown.jpg", "cat_1234_edited.jpg")
+
+		assert.Equal(t, original.PhotoUID, edited.PhotoUID)
+	})
+	t.Run("EditedFirst", func(t *testing.T) {
+		edited := index("dog_orange.jpg", "dog_1234-edit.jpg")
+		original := index("dog_toshi_red.jpg", "dog_1234.jpg")
+
+		assert.Equal(t, edited.PhotoUID, original.PhotoUID)
+
+		photo := entity.Photo{PhotoUID: original.PhotoUID}
+
+		if err := photo.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "dog_1234", photo.PhotoName)
+	})
+	t.Run("NotEdited", func(t *testing.T) {
+		first := index("elephants.jpg", "elephant_1234.jpg")
+		second := index("elephant_mono.jpg", "elephant_1234 (2).jpg")
+
+		assert.NotEqual(t, first.PhotoUID, second.PhotoUID)
+	})
+}
+
 func TestIndexResult_Archived(t *testing.T) {
 	t.Run("true", func(t *testing.T) {
 		r := &IndexResult{IndexArchived, nil, 5, "", 5, ""}
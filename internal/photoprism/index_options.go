@@ -1,17 +1,87 @@
 package photoprism
 
+import (
+	"fmt"
+	"strings"
+)
+
+// Index pipeline stages that can be re-run separately for files that have already been indexed.
+const (
+	IndexStageMeta   = "meta"   // Metadata such as time, title, camera, and location.
+	IndexStageLabels = "labels" // Image classification and NSFW detection.
+	IndexStageFaces  = "faces"  // Face detection, including video keyframes.
+)
+
+// IndexStages lists all pipeline stages that can be selected.
+var IndexStages = []string{IndexStageMeta, IndexStageLabels, IndexStageFaces}
+
 type IndexOptions struct {
+	Root      string
 	Path      string
+	Filter    string
+	Stages    []string
 	Rescan    bool
 	Convert   bool
 	Stack     bool
 	FacesOnly bool
+	Pending   bool
 }
 
 func (o *IndexOptions) SkipUnchanged() bool {
 	return !o.Rescan
 }
 
+// Stage tests if the pipeline stage should run for files that have already been indexed,
+// which is the case for all stages if none were selected.
+func (o *IndexOptions) Stage(name string) bool {
+	return len(o.Stages) == 0 || o.Selected(name)
+}
+
+// Selected tests if the pipeline stage has been selected explicitly.
+func (o *IndexOptions) Selected(name string) bool {
+	for _, s := range o.Stages {
+		if s == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// KeyframeFaces tests if faces should be detected in video keyframes, which is skipped for files that
+// have already been indexed unless faces are updated, or the faces stage has been selected explicitly.
+func (o *IndexOptions) KeyframeFaces(indexed bool) bool {
+	return !indexed || o.FacesOnly || o.Selected(IndexStageFaces)
+}
+
+// ParseIndexStages parses a comma-separated list of pipeline stages, e.g. "meta,labels".
+func ParseIndexStages(s string) (stages []string, err error) {
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if name == "" {
+			continue
+		}
+
+		found := false
+
+		for _, stage := range IndexStages {
+			if name == stage {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return stages, fmt.Errorf("unknown index stage %s, use %s", name, strings.Join(IndexStages, ", "))
+		}
+
+		stages = append(stages, name)
+	}
+
+	return stages, nil
+}
+
 // IndexOptionsAll returns new index options with all options set to true.
 func IndexOptionsAll() IndexOptions {
 	result := IndexOptions{
@@ -43,3 +43,71 @@ func TestIndexOptionsFacesOnly(t *testing.T) {
 	assert.Equal(t, true, opt.Stack)
 	assert.Equal(t, true, opt.FacesOnly)
 }
+
+func TestIndexOptions_Stage(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		opt := IndexOptionsAll()
+
+		assert.True(t, opt.Stage(IndexStageMeta))
+		assert.True(t, opt.Stage(IndexStageLabels))
+		assert.True(t, opt.Stage(IndexStageFaces))
+	})
+	t.Run("Selected", func(t *testing.T) {
+		opt := IndexOptionsAll()
+		opt.Stages = []string{IndexStageLabels}
+
+		assert.False(t, opt.Stage(IndexStageMeta))
+		assert.True(t, opt.Stage(IndexStageLabels))
+		assert.False(t, opt.Stage(IndexStageFaces))
+	})
+}
+
+func TestIndexOptions_KeyframeFaces(t *testing.T) {
+	t.Run("New", func(t *testing.T) {
+		opt := IndexOptionsAll()
+
+		assert.True(t, opt.KeyframeFaces(false))
+	})
+	t.Run("Rescan", func(t *testing.T) {
+		opt := IndexOptionsAll()
+
+		assert.False(t, opt.KeyframeFaces(true))
+	})
+	t.Run("FacesOnly", func(t *testing.T) {
+		opt := IndexOptionsFacesOnly()
+
+		assert.True(t, opt.KeyframeFaces(true))
+	})
+	t.Run("StageFaces", func(t *testing.T) {
+		opt := IndexOptionsAll()
+		opt.Stages = []string{IndexStageFaces}
+
+		assert.True(t, opt.KeyframeFaces(true))
+	})
+	t.Run("StageMeta", func(t *testing.T) {
+		opt := IndexOptionsAll()
+		opt.Stages = []string{IndexStageMeta}
+
+		assert.False(t, opt.KeyframeFaces(true))
+	})
+}
+
+func TestParseIndexStages(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		stages, err := ParseIndexStages("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, stages)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		stages, err := ParseIndexStages(" Meta, faces ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{IndexStageMeta, IndexStageFaces}, stages)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := ParseIndexStages("meta,thumbs")
+
+		assert.Error(t, err)
+	})
+}
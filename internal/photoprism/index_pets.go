@@ -0,0 +1,64 @@
+package photoprism
+
+import (
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// PetSimilarity is the minimum similarity of photo embeddings to assume that they show the same pet.
+const PetSimilarity = 0.95
+
+// PetCandidates is the maximum number of pet markers compared with a photo.
+const PetCandidates = 1000
+
+// Pet adds a pet marker to the file if the labels contain a pet. Pets are clustered by the image embedding:
+// The marker gets the subject of the most similar photo with a named pet, and no marker is added if the
+// most similar pet has not been named yet, so that the same unknown pet is not marked in every file.
+func (ind *Index) Pet(file *entity.File, labels classify.Labels, embedding classify.Embedding) {
+	if _, ok := labels.Pet(); !ok {
+		return
+	} else if file.Markers().Pet() != nil {
+		return
+	}
+
+	match, found := petCluster(file.PhotoID, embedding)
+
+	if !found {
+		file.AddPet(labels, nil)
+	} else if match.SubjUID == "" {
+		log.Debugf("index: %s shows the same pet as unnamed marker %s", sanitize.Log(file.FileName), sanitize.Log(match.MarkerUID))
+	} else if subj := entity.FindSubject(match.SubjUID); subj != nil && !subj.Deleted() {
+		file.AddPet(labels, subj)
+	} else {
+		file.AddPet(labels, nil)
+	}
+}
+
+// petCluster returns the pet marker of the most similar photo, if any.
+func petCluster(photoID uint, embedding classify.Embedding) (match query.PetMarker, found bool) {
+	if embedding.Empty() {
+		return match, false
+	}
+
+	candidates, err := query.PetMarkers(embedding.Buckets(), photoID, PetCandidates)
+
+	if err != nil {
+		log.Warnf("index: %s (find similar pets)", err)
+		return match, false
+	}
+
+	best := PetSimilarity
+
+	for _, c := range candidates {
+		if similarity := embedding.Similarity(c.Vector()); similarity < best {
+			continue
+		} else if !found || similarity > best || match.SubjUID == "" && c.SubjUID != "" {
+			// Named pets are preferred if multiple markers are equally similar.
+			match, best, found = c, similarity, true
+		}
+	}
+
+	return match, found
+}
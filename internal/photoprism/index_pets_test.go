@@ -0,0 +1,34 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestIndex_Pet(t *testing.T) {
+	conf := config.TestConfig()
+	ind := NewIndex(conf, nil, nil, nil, NewConvert(conf), NewFiles(), NewPhotos())
+
+	t.Run("NewPet", func(t *testing.T) {
+		file := &entity.File{FileUID: "fqzuh65p4sjk3pe3", FileHash: "746b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileHeight: 480, FileName: "PetTest", PhotoID: 1000003, FilePrimary: true}
+
+		ind.Pet(file, classify.Labels{{Name: "cat", Source: classify.SrcImage, Uncertainty: 10}}, nil)
+
+		if assert.Equal(t, 1, len(*file.Markers())) {
+			assert.Equal(t, entity.MarkerPet, (*file.Markers())[0].MarkerType)
+			assert.Equal(t, "", (*file.Markers())[0].SubjUID)
+		}
+	})
+	t.Run("NoPet", func(t *testing.T) {
+		file := &entity.File{FileUID: "fqzuh65p4sjk3pe4", FileHash: "846b3897eec9ef75e35fbf0bbc4c83c55ca41e31", FileType: "jpg", FileWidth: 720, FileHeight: 480, FileName: "PetTest", PhotoID: 1000003, FilePrimary: true}
+
+		ind.Pet(file, classify.Labels{{Name: "snow", Source: classify.SrcImage, Uncertainty: 10}}, nil)
+
+		assert.Equal(t, 0, len(*file.Markers()))
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/dustin/go-humanize/english"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/query"
 
 	"github.com/photoprism/photoprism/pkg/sanitize"
@@ -25,6 +26,7 @@ func IndexMain(related *RelatedFiles, ind *Index, opt IndexOptions) (result Inde
 
 	// Enforce file size limit for originals.
 	if sizeLimit > 0 && f.FileSize() > sizeLimit {
+		skipSize("index", f, sizeLimit)
 		result.Err = fmt.Errorf("index: %s exceeds file size limit (%d / %d MB)", sanitize.Log(f.BaseName()), f.FileSize()/(1024*1024), sizeLimit/(1024*1024))
 		result.Status = IndexFailed
 		return result
@@ -48,6 +50,7 @@ func IndexMain(related *RelatedFiles, ind *Index, opt IndexOptions) (result Inde
 			log.Debugf("index: created %s", sanitize.Log(jpegFile.BaseName()))
 
 			if err := jpegFile.ResampleDefault(ind.thumbPath(), false); err != nil {
+				AddBrokenFile(f, err)
 				result.Err = fmt.Errorf("index: failed creating thumbnails for %s (%s)", sanitize.Log(f.BaseName()), err.Error())
 				result.Status = IndexFailed
 
@@ -58,13 +61,25 @@ func IndexMain(related *RelatedFiles, ind *Index, opt IndexOptions) (result Inde
 		}
 	}
 
+	// Additional pages of multi-page TIFF files are stacked with the first page.
+	if opt.Convert && f.IsTiff() {
+		if pages, err := ind.convert.ToJpegPages(f); err != nil {
+			log.Warnf("index: %s in %s (convert pages)", err, sanitize.Log(f.BaseName()))
+		} else {
+			related.Files = append(related.Files, pages...)
+		}
+	}
+
 	result = ind.MediaFile(f, opt, "", "")
 
 	if result.Indexed() && f.IsJpeg() {
 		if err := f.ResampleDefault(ind.thumbPath(), false); err != nil {
 			log.Errorf("index: failed creating thumbnails for %s (%s)", sanitize.Log(f.BaseName()), err.Error())
 			query.SetFileError(result.FileUID, err.Error())
+			AddBrokenFile(f, err)
 		}
+	} else if result.Indexed() && opt.Convert {
+		ind.sprite(f)
 	}
 
 	log.Infof("index: %s main %s file %s", result, f.FileType(), sanitize.Log(f.RelName(ind.originalsPath())))
@@ -123,6 +138,7 @@ func IndexRelated(related RelatedFiles, ind *Index, opt IndexOptions) (result In
 
 		// Enforce file size limit for originals.
 		if sizeLimit > 0 && f.FileSize() > sizeLimit {
+			skipSize("index", f, sizeLimit)
 			log.Warnf("index: %s exceeds file size limit (%d / %d MB)", sanitize.Log(f.BaseName()), f.FileSize()/(1024*1024), sizeLimit/(1024*1024))
 			continue
 		}
@@ -145,6 +161,7 @@ func IndexRelated(related RelatedFiles, ind *Index, opt IndexOptions) (result In
 				log.Debugf("index: created %s", sanitize.Log(jpegFile.BaseName()))
 
 				if err := jpegFile.ResampleDefault(ind.thumbPath(), false); err != nil {
+					AddBrokenFile(f, err)
 					result.Err = fmt.Errorf("index: failed creating thumbnails for %s (%s)", sanitize.Log(f.BaseName()), err.Error())
 					result.Status = IndexFailed
 
@@ -161,7 +178,10 @@ func IndexRelated(related RelatedFiles, ind *Index, opt IndexOptions) (result In
 			if err := f.ResampleDefault(ind.thumbPath(), false); err != nil {
 				log.Errorf("index: failed creating thumbnails for %s (%s)", sanitize.Log(f.BaseName()), err.Error())
 				query.SetFileError(res.FileUID, err.Error())
+				AddBrokenFile(f, err)
 			}
+		} else if res.Indexed() && opt.Convert {
+			ind.sprite(f)
 		}
 
 		log.Infof("index: %s related %s file %s", res, f.FileType(), sanitize.Log(f.BaseName()))
@@ -169,3 +189,23 @@ func IndexRelated(related RelatedFiles, ind *Index, opt IndexOptions) (result In
 
 	return result
 }
+
+// sprite creates a sprite sheet of a video for preview scrubbing if enabled.
+func (ind *Index) sprite(f *MediaFile) {
+	if !ind.convert.NeedsSprite(f) {
+		return
+	} else if _, err := ind.convert.ToSprite(f); err != nil {
+		log.Warnf("index: %s in %s (create sprite)", err, sanitize.Log(f.BaseName()))
+	}
+}
+
+// AddBrokenFile records a media file that could not be decoded, so that it can be reviewed later.
+func AddBrokenFile(f *MediaFile, err error) {
+	if f == nil || err == nil {
+		return
+	}
+
+	if err := entity.AddBrokenFile(f.RootRelName(), f.Root(), f.FileSize(), err); err != nil {
+		log.Errorf("index: %s in %s (add broken file)", err, sanitize.Log(f.BaseName()))
+	}
+}
@@ -58,3 +58,9 @@ func TestIndex_File(t *testing.T) {
 
 	assert.Equal(t, IndexFailed, err.Status)
 }
+
+func TestFolderDepth(t *testing.T) {
+	assert.Equal(t, 0, folderDepth(""))
+	assert.Equal(t, 1, folderDepth("2020"))
+	assert.Equal(t, 3, folderDepth("2020/01/Holiday"))
+}
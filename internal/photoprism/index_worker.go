@@ -1,14 +1,34 @@
 package photoprism
 
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/tracing"
+)
+
 type IndexJob struct {
 	FileName string
 	Related  RelatedFiles
 	IndexOpt IndexOptions
 	Ind      *Index
+	Ctx      context.Context // Parent of the file span, optional.
 }
 
 func IndexWorker(jobs <-chan IndexJob) {
 	for job := range jobs {
-		IndexRelated(job.Related, job.Ind, job.IndexOpt)
+		mutex.Maintenance.Begin()
+		// File names are not added to the span, as they may contain personal data.
+		_, span := tracing.Start(job.Ctx, "index.file")
+		result := IndexRelated(job.Related, job.Ind, job.IndexOpt)
+		span.SetAttributes(
+			attribute.String("index.status", result.String()),
+			attribute.String("file.uid", result.FileUID),
+			attribute.String("photo.uid", result.PhotoUID),
+		)
+		tracing.End(span, result.Err)
+		mutex.Maintenance.End()
 	}
 }
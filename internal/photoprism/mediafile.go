@@ -276,14 +276,25 @@ func (m *MediaFile) EditedName() string {
 	return ""
 }
 
+// IsEdited returns true if the file name indicates an edited version, e.g. IMG_1234-edit.jpg or IMG_E1234.JPG.
+func (m *MediaFile) IsEdited() bool {
+	if fs.IsEdited(m.fileName) {
+		return true
+	}
+
+	basename := filepath.Base(m.fileName)
+
+	return len(basename) > 5 && strings.ToUpper(basename[:5]) == "IMG_E" && basename[5] >= '0' && basename[5] <= '9'
+}
+
 // RelatedFiles returns files which are related to this file.
 func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err error) {
 	// File path and name without any extensions.
 	prefix := m.AbsPrefix(stripSequence)
 
 	// Storage folder path prefixes.
-	sidecarPrefix := Config().SidecarPath() + "/"
-	originalsPrefix := Config().OriginalsPath() + "/"
+	sidecarPrefix := m.SidecarPath() + "/"
+	originalsPrefix := m.OriginalsPath() + "/"
 
 	// Replace sidecar with originals path in search prefix.
 	if len(sidecarPrefix) > 1 && sidecarPrefix != originalsPrefix && strings.HasPrefix(prefix, sidecarPrefix) {
@@ -333,7 +344,7 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 		} else if f.IsHEIF() {
 			isHEIF = true
 			result.Main = f
-		} else if f.IsImageOther() {
+		} else if f.IsImageOther() || f.IsDicom() {
 			result.Main = f
 		} else if f.IsVideo() && !isHEIF {
 			result.Main = f
@@ -358,7 +369,7 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 
 	// Add hidden JPEG if exists.
 	if !result.ContainsJpeg() {
-		if jpegName := fs.FormatJpeg.FindFirst(result.Main.FileName(), []string{Config().SidecarPath(), fs.HiddenPath}, Config().OriginalsPath(), stripSequence); jpegName != "" {
+		if jpegName := fs.FormatJpeg.FindFirst(result.Main.FileName(), []string{m.SidecarPath(), fs.HiddenPath}, m.OriginalsPath(), stripSequence); jpegName != "" {
 			if resultFile, err := NewMediaFile(jpegName); err == nil {
 				result.Files = append(result.Files, resultFile)
 			}
@@ -373,21 +384,7 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 // PathNameInfo returns file name infos for indexing.
 func (m *MediaFile) PathNameInfo(stripSequence bool) (fileRoot, fileBase, relativePath, relativeName string) {
 	fileRoot = m.Root()
-
-	var rootPath string
-
-	switch fileRoot {
-	case entity.RootSidecar:
-		rootPath = Config().SidecarPath()
-	case entity.RootImport:
-		rootPath = Config().ImportPath()
-	case entity.RootExamples:
-		rootPath = Config().ExamplesPath()
-	case entity.RootOriginals:
-		rootPath = Config().OriginalsPath()
-	default:
-		rootPath = Config().OriginalsPath()
-	}
+	rootPath := m.RootPath()
 
 	fileBase = m.BasePrefix(stripSequence)
 	relativePath = m.RelPath(rootPath)
@@ -455,6 +452,14 @@ func (m *MediaFile) RelPath(directory string) string {
 
 // RootPath returns the file root path based on the configuration.
 func (m *MediaFile) RootPath() string {
+	if root, sidecar, ok := Config().OriginalsRoots().Find(m.Root()); !ok {
+		// Not in an additional originals root.
+	} else if sidecar {
+		return root.SidecarPath
+	} else {
+		return root.Path
+	}
+
 	switch m.Root() {
 	case entity.RootSidecar:
 		return Config().SidecarPath()
@@ -467,6 +472,24 @@ func (m *MediaFile) RootPath() string {
 	}
 }
 
+// OriginalsPath returns the originals folder the file belongs to, which may be an additional originals root.
+func (m *MediaFile) OriginalsPath() string {
+	if root, _, ok := Config().OriginalsRoots().Find(m.Root()); ok {
+		return root.Path
+	}
+
+	return Config().OriginalsPath()
+}
+
+// SidecarPath returns the sidecar folder the file belongs to, which may be the sidecar path of an additional originals root.
+func (m *MediaFile) SidecarPath() string {
+	if root, _, ok := Config().OriginalsRoots().Find(m.Root()); ok {
+		return root.SidecarPath
+	}
+
+	return Config().SidecarPath()
+}
+
 // RootRelPath returns the relative path and automatically detects the root path.
 func (m *MediaFile) RootRelPath() string {
 	return m.RelPath(m.RootPath())
@@ -502,6 +525,17 @@ func (m *MediaFile) Root() string {
 		return m.fileRoot
 	}
 
+	// Additional roots may be located in the default sidecar folder, so they are matched first.
+	if root, sidecar, ok := Config().OriginalsRoots().Match(m.FileName()); !ok {
+		// Not in an additional originals root.
+	} else if sidecar {
+		m.fileRoot = root.SidecarRoot()
+		return m.fileRoot
+	} else {
+		m.fileRoot = root.Name
+		return m.fileRoot
+	}
+
 	if strings.HasPrefix(m.FileName(), Config().OriginalsPath()) {
 		m.fileRoot = entity.RootOriginals
 		return m.fileRoot
@@ -563,7 +597,7 @@ func (m *MediaFile) Exists() bool {
 
 // Remove permanently removes a media file.
 func (m *MediaFile) Remove() error {
-	return os.Remove(m.FileName())
+	return fs.Remove(m.FileName())
 }
 
 // HasSameName compares a media file with another media file and returns if
@@ -578,6 +612,12 @@ func (m *MediaFile) HasSameName(f *MediaFile) bool {
 
 // Move file to a new destination with the filename provided in parameter.
 func (m *MediaFile) Move(dest string) error {
+	if err := fs.Writable(m.fileName); err != nil {
+		return err
+	} else if err := fs.Writable(dest); err != nil {
+		return err
+	}
+
 	if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
 		return err
 	}
@@ -605,6 +645,10 @@ func (m *MediaFile) Move(dest string) error {
 
 // Copy a MediaFile to another file by destinationFilename.
 func (m *MediaFile) Copy(dest string) error {
+	if err := fs.Writable(dest); err != nil {
+		return err
+	}
+
 	if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
 		return err
 	}
@@ -672,6 +716,11 @@ func (m *MediaFile) IsHEIF() bool {
 	return m.MimeType() == fs.MimeTypeHEIF
 }
 
+// IsDicom returns true if this is a DICOM medical or scientific image file.
+func (m *MediaFile) IsDicom() bool {
+	return m.MimeType() == fs.MimeTypeDicom
+}
+
 // IsBitmap returns true if this is a bitmap file.
 func (m *MediaFile) IsBitmap() bool {
 	return m.MimeType() == fs.MimeTypeBitmap
@@ -700,6 +749,8 @@ func (m *MediaFile) FileType() fs.FileFormat {
 		return fs.FormatHEIF
 	case m.IsBitmap():
 		return fs.FormatBitmap
+	case m.IsDicom():
+		return fs.FormatDicom
 	default:
 		return fs.GetFileFormat(m.fileName)
 	}
@@ -751,17 +802,17 @@ func (m *MediaFile) IsPlayableVideo() bool {
 
 // IsPhoto returns true if this file is a photo / image.
 func (m *MediaFile) IsPhoto() bool {
-	return m.IsJpeg() || m.IsRaw() || m.IsHEIF() || m.IsImageOther()
+	return m.IsJpeg() || m.IsRaw() || m.IsHEIF() || m.IsImageOther() || m.IsDicom()
 }
 
 // IsLive returns true if this is a live photo.
 func (m *MediaFile) IsLive() bool {
 	if m.IsHEIF() {
-		return fs.FormatMov.FindFirst(m.FileName(), []string{}, Config().OriginalsPath(), false) != ""
+		return fs.FormatMov.FindFirst(m.FileName(), []string{}, m.OriginalsPath(), false) != ""
 	}
 
 	if m.IsVideo() {
-		return fs.FormatHEIF.FindFirst(m.FileName(), []string{}, Config().OriginalsPath(), false) != ""
+		return fs.FormatHEIF.FindFirst(m.FileName(), []string{}, m.OriginalsPath(), false) != ""
 	}
 
 	return false
@@ -774,7 +825,7 @@ func (m *MediaFile) ExifSupported() bool {
 
 // IsMedia returns true if this is a media file (photo or video, not sidecar or other).
 func (m *MediaFile) IsMedia() bool {
-	return m.IsJpeg() || m.IsVideo() || m.IsRaw() || m.IsHEIF() || m.IsImageOther()
+	return m.IsJpeg() || m.IsVideo() || m.IsRaw() || m.IsHEIF() || m.IsImageOther() || m.IsDicom()
 }
 
 // Jpeg returns the JPEG version of the media file (if exists).
@@ -787,7 +838,7 @@ func (m *MediaFile) Jpeg() (*MediaFile, error) {
 		return m, nil
 	}
 
-	jpegFilename := fs.FormatJpeg.FindFirst(m.FileName(), []string{Config().SidecarPath(), fs.HiddenPath}, Config().OriginalsPath(), false)
+	jpegFilename := fs.FormatJpeg.FindFirst(m.FileName(), []string{m.SidecarPath(), fs.HiddenPath}, m.OriginalsPath(), false)
 
 	if jpegFilename == "" {
 		return nil, fmt.Errorf("no jpeg found for %s", m.FileName())
@@ -807,7 +858,7 @@ func (m *MediaFile) HasJpeg() bool {
 		return true
 	}
 
-	jpegName := fs.FormatJpeg.FindFirst(m.FileName(), []string{Config().SidecarPath(), fs.HiddenPath}, Config().OriginalsPath(), false)
+	jpegName := fs.FormatJpeg.FindFirst(m.FileName(), []string{m.SidecarPath(), fs.HiddenPath}, m.OriginalsPath(), false)
 
 	if jpegName == "" {
 		m.hasJpeg = false
@@ -911,6 +962,21 @@ func (m *MediaFile) Megapixels() int {
 	return int(math.Round(float64(m.Width()*m.Height()) / 1000000))
 }
 
+// Pages returns the number of pages, e.g. of a multi-page TIFF scan.
+func (m *MediaFile) Pages() int {
+	if !m.IsTiff() {
+		return 1
+	}
+
+	if pages, err := thumb.TiffPages(m.FileName()); err != nil {
+		log.Debugf("media: %s in %s (count pages)", err, sanitize.Log(m.BaseName()))
+	} else if pages > 1 {
+		return pages
+	}
+
+	return 1
+}
+
 // Orientation returns the Exif orientation of the media file.
 func (m *MediaFile) Orientation() int {
 	if data := m.MetaData(); data.Error == nil {
@@ -970,6 +1036,7 @@ func (m *MediaFile) ResampleDefault(thumbPath string, force bool) (err error) {
 	var originalImg image.Image
 	var sourceImg image.Image
 	var sourceName thumb.Name
+	var sourceFile string
 
 	for _, name := range thumb.DefaultSizes {
 		size := thumb.Sizes[name]
@@ -988,6 +1055,27 @@ func (m *MediaFile) ResampleDefault(thumbPath string, force bool) (err error) {
 				continue
 			}
 
+			// Use libvips if enabled, smaller sizes are created from the source thumbnail if possible.
+			if thumb.VipsEnabled() {
+				srcName, orientation := m.FileName(), m.Orientation()
+
+				if size.Source != "" && size.Source == sourceName && sourceFile != "" {
+					srcName, orientation = sourceFile, 1
+				}
+
+				if err := thumb.Vips(srcName, fileName, size.Width, size.Height, orientation, size.Options...); err != nil {
+					log.Debugf("media: %s in %s, using imaging library", err, sanitize.Log(m.BaseName()))
+				} else {
+					if size.Source == "" {
+						sourceFile = fileName
+						sourceName = name
+					}
+
+					count++
+					continue
+				}
+			}
+
 			if originalImg == nil {
 				img, err := thumb.Open(m.FileName(), m.Orientation())
 
@@ -1008,6 +1096,7 @@ func (m *MediaFile) ResampleDefault(thumbPath string, force bool) (err error) {
 			} else {
 				sourceImg, err = thumb.Create(originalImg, fileName, size.Width, size.Height, size.Options...)
 				sourceName = name
+				sourceFile = fileName
 			}
 
 			if err != nil {
@@ -1026,8 +1115,8 @@ func (m *MediaFile) ResampleDefault(thumbPath string, force bool) (err error) {
 func (m *MediaFile) RenameSidecars(oldFileName string) (renamed map[string]string, err error) {
 	renamed = make(map[string]string)
 
-	sidecarPath := Config().SidecarPath()
-	originalsPath := Config().OriginalsPath()
+	sidecarPath := m.SidecarPath()
+	originalsPath := m.OriginalsPath()
 
 	newName := m.RelPrefix(originalsPath, false)
 	oldPrefix := fs.RelPrefix(oldFileName, originalsPath, false)
@@ -1068,8 +1157,8 @@ func (m *MediaFile) RenameSidecars(oldFileName string) (renamed map[string]strin
 // RemoveSidecars permanently removes related sidecar files.
 func (m *MediaFile) RemoveSidecars() (err error) {
 	fileName := m.FileName()
-	sidecarPath := Config().SidecarPath()
-	originalsPath := Config().OriginalsPath()
+	sidecarPath := m.SidecarPath()
+	originalsPath := m.OriginalsPath()
 
 	prefix := fs.RelPrefix(fileName, originalsPath, false)
 	globPrefix := filepath.Join(sidecarPath, prefix) + "."
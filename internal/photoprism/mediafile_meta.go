@@ -17,7 +17,7 @@ func (m *MediaFile) HasSidecarJson() bool {
 		return true
 	}
 
-	return fs.FormatJson.FindFirst(m.FileName(), []string{Config().SidecarPath(), fs.HiddenPath}, Config().OriginalsPath(), false) != ""
+	return fs.FormatJson.FindFirst(m.FileName(), []string{m.SidecarPath(), fs.HiddenPath}, m.OriginalsPath(), false) != ""
 }
 
 // SidecarJsonName returns the corresponding JSON sidecar file name as used by Google Photos (and potentially other apps).
@@ -31,6 +31,55 @@ func (m *MediaFile) SidecarJsonName() string {
 	return ""
 }
 
+// YamlSidecarName returns the YAML sidecar file name to restore photo metadata from, or an empty string if none exists.
+func (m *MediaFile) YamlSidecarName(stripSequence bool) string {
+	// Hash-prefixed sidecar folders are searched first, so that metadata can be restored after files have been moved.
+	if entity.SidecarLayout == entity.SidecarLayoutHash {
+		for _, yamlName := range entity.YamlHashNames(m.Hash(), m.SidecarPath()) {
+			if fs.FileExists(yamlName) {
+				return yamlName
+			}
+		}
+	}
+
+	if yamlName := fs.FormatYaml.FindFirst(m.FileName(), []string{m.SidecarPath(), fs.HiddenPath}, m.OriginalsPath(), stripSequence); yamlName != "" {
+		return yamlName
+	}
+
+	// Search for compressed files with any YAML file extension.
+	fileDir := filepath.Dir(m.FileName())
+	prefixes := []string{fs.BasePrefix(m.FileName(), false), fs.BasePrefix(m.FileName(), stripSequence)}
+
+	for _, dir := range []string{m.SidecarPath(), fs.HiddenPath} {
+		if dir == "" {
+			continue
+		} else if filepath.IsAbs(dir) {
+			dir = filepath.Join(dir, fs.RelName(fileDir, m.OriginalsPath()))
+		} else {
+			dir = filepath.Join(fileDir, dir)
+		}
+
+		for _, prefix := range prefixes {
+			for _, ext := range fs.TypeExt[fs.FormatYaml] {
+				if yamlName := filepath.Join(dir, prefix) + ext + entity.GzipExt; fs.FileExists(yamlName) {
+					return yamlName
+				}
+			}
+		}
+	}
+
+	// Files in hash-prefixed folders are found even if the layout has been changed back.
+	if entity.SidecarLayout != entity.SidecarLayoutHash {
+		for _, yamlName := range entity.YamlHashNames(m.Hash(), m.SidecarPath()) {
+			if fs.FileExists(yamlName) {
+				return yamlName
+			}
+		}
+	}
+
+	return ""
+}
+
 // ExifToolJsonName returns the cached ExifTool metadata file name.
 func (m *MediaFile) ExifToolJsonName() (string, error) {
 	if Config().DisableExifTool() {
@@ -66,41 +115,64 @@ func (m *MediaFile) ReadExifToolJson() error {
 	return m.metaData.JSON(jsonName, "")
 }
 
-// MetaData returns exif meta data of a media file.
-func (m *MediaFile) MetaData() (result meta.Data) {
-	m.metaDataOnce.Do(func() {
-		var err error
+// MetaExtractors returns the metadata extraction pipeline for this file.
+func (m *MediaFile) MetaExtractors() (result meta.Extractors) {
+	result = result.Add(meta.NewExtractor("exif", func(fileName string, data *meta.Data) error {
+		if !m.ExifSupported() {
+			return fmt.Errorf("exif not supported")
+		}
 
-		if m.ExifSupported() {
-			err = m.metaData.Exif(m.FileName(), m.FileType())
-		} else {
-			err = fmt.Errorf("exif not supported")
+		return data.Exif(fileName, m.FileType())
+	}))
+
+	if m.IsSidecar() {
+		return result
+	}
+
+	// Parse regular JSON sidecar files ("img_1234.json").
+	result = result.Add(meta.NewExtractor("sidecar", func(fileName string, data *meta.Data) (err error) {
+		jsonFiles := fs.FormatJson.FindAll(fileName, []string{m.SidecarPath(), fs.HiddenPath}, m.OriginalsPath(), false)
+
+		if len(jsonFiles) == 0 {
+			log.Tracef("metadata: found no additional sidecar file for %s", sanitize.Log(filepath.Base(fileName)))
+			return meta.ErrNotSupported
 		}
 
-		// Parse regular JSON sidecar files ("img_1234.json")
-		if !m.IsSidecar() {
-			if jsonFiles := fs.FormatJson.FindAll(m.FileName(), []string{Config().SidecarPath(), fs.HiddenPath}, Config().OriginalsPath(), false); len(jsonFiles) == 0 {
-				log.Tracef("metadata: found no additional sidecar file for %s", sanitize.Log(filepath.Base(m.FileName())))
-			} else {
-				for _, jsonFile := range jsonFiles {
-					jsonErr := m.metaData.JSON(jsonFile, m.BaseName())
-
-					if jsonErr != nil {
-						log.Debug(jsonErr)
-					} else {
-						err = nil
-					}
-				}
-			}
+		err = meta.ErrNotSupported
 
-			if jsonErr := m.ReadExifToolJson(); jsonErr != nil {
+		for _, jsonFile := range jsonFiles {
+			if jsonErr := data.JSON(jsonFile, m.BaseName()); jsonErr != nil {
 				log.Debug(jsonErr)
 			} else {
 				err = nil
 			}
 		}
 
+		return err
+	}))
+
+	result = result.Add(meta.NewExtractor("exiftool", func(fileName string, data *meta.Data) error {
+		jsonName, err := m.ExifToolJsonName()
+
 		if err != nil {
+			return meta.ErrNotSupported
+		}
+
+		return data.JSON(jsonName, "")
+	}))
+
+	// Optional external command, e.g. for custom ExifTool configs or scientific image headers.
+	if cmd := meta.NewCommand(Config().MetadataCmd(), Config().MetadataExt()); cmd != nil {
+		result = result.Add(cmd)
+	}
+
+	return result
+}
+
+// MetaData returns exif meta data of a media file.
+func (m *MediaFile) MetaData() (result meta.Data) {
+	m.metaDataOnce.Do(func() {
+		if err := m.MetaExtractors().Extract(m.FileName(), &m.metaData); err != nil {
 			m.metaData.Error = err
 			log.Debugf("metadata: %s in %s", err, sanitize.Log(m.BaseName()))
 		}
@@ -108,3 +180,43 @@ func (m *MediaFile) MetaData() (result meta.Data) {
 
 	return m.metaData
 }
+
+// XmpRegions returns the face regions found in XMP sidecar files, e.g. as tagged in Lightroom or digiKam.
+func (m *MediaFile) XmpRegions() (regions meta.Regions) {
+	if m.IsSidecar() {
+		return regions
+	}
+
+	for _, xmpName := range fs.FormatXMP.FindAll(m.FileName(), []string{m.SidecarPath(), fs.HiddenPath}, m.OriginalsPath(), false) {
+		if data, err := meta.XMP(xmpName); err != nil {
+			log.Debug(err)
+		} else {
+			for _, r := range data.Regions.Faces() {
+				regions.Add(r)
+			}
+		}
+	}
+
+	return regions
+}
+
+// PicasaRegions returns the face regions found in Picasa folder metadata files.
+func (m *MediaFile) PicasaRegions() (regions meta.Regions) {
+	if m.IsSidecar() {
+		return regions
+	}
+
+	for _, iniName := range meta.PicasaIniNames {
+		if fileName := filepath.Join(m.Dir(), iniName); !fs.FileExists(fileName) {
+			continue
+		} else if data, err := meta.Picasa(fileName, m.BaseName()); err != nil {
+			log.Debug(err)
+		} else {
+			for _, r := range data.Regions.Faces() {
+				regions.Add(r)
+			}
+		}
+	}
+
+	return regions
+}
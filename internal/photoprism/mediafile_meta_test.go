@@ -7,6 +7,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -107,6 +108,37 @@ func TestMediaFile_NeedsExifToolJson(t *testing.T) {
 	})
 }
 
+func TestMediaFile_MetaExtractors(t *testing.T) {
+	t.Run("Image", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/beach_sand.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		names := make([]string, 0, 3)
+
+		for _, e := range mediaFile.MetaExtractors() {
+			names = append(names, e.Name())
+		}
+
+		assert.Equal(t, []string{"exif", "sidecar", "exiftool"}, names)
+	})
+	t.Run("Sidecar", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/blue-go-video.mp4.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, mediaFile.MetaExtractors(), 1)
+	})
+}
+
 func TestMediaFile_Exif_JPEG(t *testing.T) {
 	conf := config.TestConfig()
 
@@ -367,3 +399,35 @@ func TestMediaFile_Exif_HEIF(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestMediaFile_Regions(t *testing.T) {
+	dir := t.TempDir()
+	jpegName := filepath.Join(dir, "dinner.jpg")
+
+	if err := fs.Copy("testdata/2015-02-04.jpg", jpegName); err != nil {
+		t.Fatal(err)
+	} else if err := fs.Copy("../meta/testdata/regions.xmp", filepath.Join(dir, "dinner.xmp")); err != nil {
+		t.Fatal(err)
+	} else if err := fs.Copy("../meta/testdata/picasa.ini", filepath.Join(dir, ".picasa.ini")); err != nil {
+		t.Fatal(err)
+	}
+
+	mediaFile, err := NewMediaFile(jpegName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Xmp", func(t *testing.T) {
+		regions := mediaFile.XmpRegions()
+
+		assert.Len(t, regions, 3)
+		assert.Equal(t, "Jane Doe", regions[0].Name)
+	})
+	t.Run("Picasa", func(t *testing.T) {
+		regions := mediaFile.PicasaRegions()
+
+		assert.Len(t, regions, 3)
+		assert.Equal(t, "John Doe", regions[1].Name)
+	})
+}
@@ -401,6 +401,26 @@ func TestMediaFile_EditedFilename(t *testing.T) {
 	})
 }
 
+func TestMediaFile_IsEdited(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("IMG_E4120.JPG", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/IMG_E4120.JPG")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, mediaFile.IsEdited())
+	})
+
+	t.Run("IMG_4120.JPG", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/IMG_4120.JPG")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.False(t, mediaFile.IsEdited())
+	})
+}
+
 func TestMediaFile_RelatedFiles(t *testing.T) {
 	conf := config.TestConfig()
 
@@ -952,6 +972,44 @@ func TestMediaFile_Move(t *testing.T) {
 	assert.Equal(t, destName, m.FileName())
 }
 
+func TestMediaFile_Protected(t *testing.T) {
+	conf := config.TestConfig()
+
+	tmpPath := conf.CachePath() + "/_tmp/TestMediaFile_Protected"
+	origName := tmpPath + "/original.jpg"
+
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(tmpPath)
+
+	f, err := NewMediaFile(conf.ExamplesPath() + "/table_white.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Copy(origName); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewMediaFile(origName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs.SetProtected(tmpPath)
+	defer fs.SetProtected()
+
+	assert.Equal(t, fs.ErrProtected, m.Move(conf.CachePath()+"/_tmp/moved.jpg"))
+	assert.Equal(t, fs.ErrProtected, f.Copy(tmpPath+"/copy.jpg"))
+	assert.Equal(t, fs.ErrProtected, m.Remove())
+	assert.FileExists(t, origName)
+	assert.NoFileExists(t, tmpPath+"/copy.jpg")
+}
+
 func TestMediaFile_Copy(t *testing.T) {
 	conf := config.TestConfig()
 
@@ -2256,3 +2314,26 @@ func TestMediaFile_ColorProfile(t *testing.T) {
 		assert.Equal(t, "sRGB IEC61966-2.1", mediaFile.ColorProfile())
 	})
 }
+
+func TestMediaFile_OriginalsPath(t *testing.T) {
+	conf := config.TestConfig()
+
+	conf.Options().OriginalsRoots = "ex:" + conf.ExamplesPath()
+	defer func() { conf.Options().OriginalsRoots = "" }()
+
+	mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/tree_white.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "ex", mediaFile.Root())
+	assert.Equal(t, conf.ExamplesPath(), mediaFile.OriginalsPath())
+	assert.Equal(t, conf.SidecarPath()+"/ex", mediaFile.SidecarPath())
+	assert.Equal(t, conf.ExamplesPath(), mediaFile.RootPath())
+
+	fileRoot, _, _, relName := mediaFile.PathNameInfo(false)
+
+	assert.Equal(t, "ex", fileRoot)
+	assert.Equal(t, "tree_white.jpg", relName)
+}
@@ -0,0 +1,90 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// MigrateYamlResult represents the number of YAML sidecar files that have been moved to the configured layout.
+type MigrateYamlResult struct {
+	Moved    int
+	Removed  int
+	NotFound int
+	Failed   int
+}
+
+// MigrateYaml moves existing YAML sidecar files to the configured layout and compression, e.g. after
+// switching to hash-prefixed folders, so that no files remain orphaned at their previous location.
+func MigrateYaml(originalsPath, sidecarPath string) (result MigrateYamlResult, err error) {
+	if sidecarPath == "" || !fs.PathExists(sidecarPath) {
+		return result, nil
+	}
+
+	err = filepath.WalkDir(sidecarPath, func(fileName string, d os.DirEntry, err error) error {
+		if err != nil {
+			log.Debugf("migrate: %s", err)
+			return nil
+		}
+
+		// Skip hidden folders.
+		if d.IsDir() {
+			if fileName != sidecarPath && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		relName := strings.TrimSuffix(fs.RelName(fileName, sidecarPath), entity.GzipExt)
+
+		if fs.GetFileFormat(relName) != fs.FormatYaml {
+			return nil
+		}
+
+		_, photo, err := findYamlPhoto(fileName, entity.RootOriginals, relName)
+
+		if err != nil {
+			log.Debugf("migrate: found no photo for %s", sanitize.Log(relName))
+			result.NotFound++
+			return nil
+		}
+
+		destName := photo.YamlFileName(originalsPath, sidecarPath)
+
+		if destName == fileName {
+			return nil
+		}
+
+		// Keep the more recent file if both exist.
+		if dest, err := os.Stat(destName); err == nil {
+			if info, err := d.Info(); err == nil && !info.ModTime().After(dest.ModTime()) {
+				if err = os.Remove(fileName); err != nil {
+					log.Errorf("migrate: %s", err)
+					result.Failed++
+				} else {
+					log.Debugf("migrate: removed outdated %s", sanitize.Log(relName))
+					result.Removed++
+				}
+
+				return nil
+			}
+		}
+
+		if err = entity.MoveYaml(fileName, destName); err != nil {
+			log.Errorf("migrate: %s in %s", err, sanitize.Log(relName))
+			result.Failed++
+		} else {
+			log.Debugf("migrate: moved %s to %s", sanitize.Log(relName), sanitize.Log(fs.RelName(destName, sidecarPath)))
+			result.Moved++
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,73 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestMigrateYaml(t *testing.T) {
+	conf := config.TestConfig()
+
+	sidecarPath := conf.SidecarPath()
+	mirrorName := filepath.Join(sidecarPath, "Germany", "bridge.yml")
+	hashName := filepath.Join(sidecarPath, "p", "c", "a", "pcad9168fa6acc5c5c2965ddf6ec465ca42fd818.yml.gz")
+	unknownName := filepath.Join(sidecarPath, "Germany", "migrate-unknown.yml")
+
+	if err := os.MkdirAll(filepath.Dir(mirrorName), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(mirrorName)
+	defer os.Remove(hashName)
+	defer os.Remove(unknownName)
+
+	entity.SidecarLayout = entity.SidecarLayoutHash
+	entity.SidecarGzip = true
+
+	defer func() {
+		entity.SidecarLayout = entity.SidecarLayoutMirror
+		entity.SidecarGzip = false
+	}()
+
+	if err := os.WriteFile(mirrorName, []byte("UID: pt9jtdre2lvl0y11\nTitle: Migrated\n"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	} else if err := os.WriteFile(unknownName, []byte("Title: Unknown\n"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := MigrateYaml(conf.OriginalsPath(), sidecarPath)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, res.Moved, 1)
+	assert.Equal(t, 0, res.Failed)
+	assert.NoFileExists(t, mirrorName)
+	assert.FileExists(t, unknownName)
+
+	photo := entity.Photo{}
+
+	if err := photo.LoadFromYaml(hashName); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Migrated", photo.PhotoTitle)
+
+	t.Run("Unchanged", func(t *testing.T) {
+		res, err := MigrateYaml(conf.OriginalsPath(), sidecarPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, res.Moved)
+		assert.FileExists(t, hashName)
+	})
+}
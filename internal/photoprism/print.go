@@ -0,0 +1,248 @@
+package photoprism
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// PrintDpi is the resolution used to calculate the export size of print products.
+const PrintDpi = 300
+
+// PrintTimeout is the maximum duration of a request to the print service.
+var PrintTimeout = 5 * time.Minute
+
+// PrintProduct represents a print format with its size in pixels, a size of zero means full resolution.
+type PrintProduct struct {
+	Name  string `json:"Name"`
+	Long  int    `json:"Long"`
+	Short int    `json:"Short"`
+}
+
+// PrintProducts maps product names to print formats, sizes are in inches or ISO 216 paper sizes.
+var PrintProducts = map[string]PrintProduct{
+	"4x6":   {Name: "4x6", Long: 6 * PrintDpi, Short: 4 * PrintDpi},
+	"5x7":   {Name: "5x7", Long: 7 * PrintDpi, Short: 5 * PrintDpi},
+	"8x10":  {Name: "8x10", Long: 10 * PrintDpi, Short: 8 * PrintDpi},
+	"11x14": {Name: "11x14", Long: 14 * PrintDpi, Short: 11 * PrintDpi},
+	"a4":    {Name: "a4", Long: 3508, Short: 2480},
+	"a3":    {Name: "a3", Long: 4961, Short: 3508},
+	"full":  {Name: "full"},
+}
+
+// PrintProductNames returns the sorted names of all print products.
+func PrintProductNames() (names []string) {
+	for name := range PrintProducts {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// FindPrintProduct returns the print product with the given name.
+func FindPrintProduct(name string) (PrintProduct, bool) {
+	p, ok := PrintProducts[strings.ToLower(strings.TrimSpace(name))]
+	return p, ok
+}
+
+// Size returns the maximum export size for an image with the given dimensions.
+func (p PrintProduct) Size(width, height int) (w, h int) {
+	if width >= height {
+		return p.Long, p.Short
+	}
+
+	return p.Short, p.Long
+}
+
+// PrintOrder represents photos that are sent to the print service.
+type PrintOrder struct {
+	Product string      `json:"Product"`
+	Copies  int         `json:"Copies"`
+	Photos  []PrintFile `json:"Photos"`
+}
+
+// PrintFile represents an exported photo in a print order.
+type PrintFile struct {
+	PhotoUID string `json:"PhotoUID"`
+	Title    string `json:"Title"`
+	Name     string `json:"Name"`
+	Width    int    `json:"Width"`
+	Height   int    `json:"Height"`
+	fileName string
+}
+
+// PrintExports creates full-resolution JPEG exports of the photos, sized for the print product, in the given folder.
+func PrintExports(photos entity.Photos, product PrintProduct, dir string) (files []PrintFile, err error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return files, err
+	}
+
+	for _, p := range photos {
+		f, err := query.FileByPhotoUID(p.PhotoUID)
+
+		if err != nil {
+			return files, fmt.Errorf("print: no file found for %s", sanitize.Log(p.PhotoUID))
+		}
+
+		srcName := FileName(f.FileRoot, f.FileName)
+
+		if !fs.FileExists(srcName) {
+			return files, fmt.Errorf("print: %s is missing", sanitize.Log(f.FileName))
+		}
+
+		img, err := thumb.Open(srcName, f.FileOrientation)
+
+		if err != nil {
+			return files, fmt.Errorf("print: %s in %s", err, sanitize.Log(f.FileName))
+		}
+
+		if product.Long > 0 && product.Short > 0 {
+			bounds := img.Bounds()
+			w, h := product.Size(bounds.Dx(), bounds.Dy())
+			img = imaging.Fit(img, w, h, imaging.Lanczos)
+		}
+
+		result := PrintFile{
+			PhotoUID: p.PhotoUID,
+			Title:    p.PhotoTitle,
+			Name:     fmt.Sprintf("%s_%s.jpg", p.PhotoUID, product.Name),
+			Width:    img.Bounds().Dx(),
+			Height:   img.Bounds().Dy(),
+		}
+
+		result.fileName = filepath.Join(dir, result.Name)
+
+		if err := imaging.Save(img, result.fileName, imaging.JPEGQuality(95)); err != nil {
+			return files, fmt.Errorf("print: %s while saving %s", err, sanitize.Log(result.Name))
+		}
+
+		files = append(files, result)
+	}
+
+	return files, nil
+}
+
+// PrintClient sends print orders to an external print service.
+type PrintClient struct {
+	Url   string
+	Token string
+}
+
+// NewPrintClient returns a new print service client.
+func NewPrintClient(url, token string) *PrintClient {
+	return &PrintClient{Url: url, Token: token}
+}
+
+// Send uploads the order details and exported files as multipart form, and returns the response of the service.
+func (c *PrintClient) Send(order PrintOrder) (result json.RawMessage, err error) {
+	if c.Url == "" {
+		return result, errors.New("print: service url is empty")
+	} else if len(order.Photos) == 0 {
+		return result, errors.New("print: order contains no photos")
+	}
+
+	orderJson, err := json.Marshal(order)
+
+	if err != nil {
+		return result, err
+	}
+
+	// Stream the form, so that exported files don't need to be kept in memory.
+	body, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+
+	go func() {
+		err := w.WriteField("order", string(orderJson))
+
+		for _, p := range order.Photos {
+			if err != nil {
+				break
+			}
+
+			err = printAddFile(w, p)
+		}
+
+		if err == nil {
+			err = w.Close()
+		}
+
+		_ = pw.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequest(http.MethodPost, c.Url, body)
+
+	if err != nil {
+		_ = body.Close()
+		return result, err
+	}
+
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("User-Agent", DownloadUserAgent)
+
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	client := &http.Client{Timeout: PrintTimeout}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return result, err
+	}
+
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+
+	if err != nil {
+		return result, err
+	} else if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return result, fmt.Errorf("print: service responded with status %d", resp.StatusCode)
+	}
+
+	if len(data) > 0 && json.Valid(data) {
+		result = data
+	}
+
+	return result, nil
+}
+
+// printAddFile adds an exported file to the multipart form.
+func printAddFile(w *multipart.Writer, p PrintFile) error {
+	f, err := os.Open(p.fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	part, err := w.CreateFormFile("files", p.Name)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, f)
+
+	return err
+}
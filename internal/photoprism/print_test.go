@@ -0,0 +1,108 @@
+package photoprism
+
+import (
+	"encoding/json"
+	"image"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestFindPrintProduct(t *testing.T) {
+	p, ok := FindPrintProduct(" 4X6 ")
+	assert.True(t, ok)
+	assert.Equal(t, "4x6", p.Name)
+
+	_, ok = FindPrintProduct("poster")
+	assert.False(t, ok)
+}
+
+func TestPrintProductNames(t *testing.T) {
+	names := PrintProductNames()
+	assert.Len(t, names, len(PrintProducts))
+	assert.Contains(t, names, "a4")
+	assert.Contains(t, names, "full")
+}
+
+func TestPrintProduct_Size(t *testing.T) {
+	p := PrintProducts["4x6"]
+
+	w, h := p.Size(4000, 3000)
+	assert.Equal(t, 1800, w)
+	assert.Equal(t, 1200, h)
+
+	w, h = p.Size(3000, 4000)
+	assert.Equal(t, 1200, w)
+	assert.Equal(t, 1800, h)
+}
+
+func TestPrintExports(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		photos := entity.Photos{{PhotoUID: "pt9jtdre2lvl0xxx"}}
+
+		_, err := PrintExports(photos, PrintProducts["4x6"], t.TempDir())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPrintClient_Send(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "pt9jtdre2lvl0y11_4x6.jpg")
+
+	if err := imaging.Save(image.NewRGBA(image.Rect(0, 0, 30, 20)), fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	order := PrintOrder{
+		Product: "4x6",
+		Copies:  2,
+		Photos:  []PrintFile{{PhotoUID: "pt9jtdre2lvl0y11", Name: filepath.Base(fileName), Width: 30, Height: 20, fileName: fileName}},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+			if err := r.ParseMultipartForm(1024 * 1024); err != nil {
+				t.Error(err)
+			}
+
+			var received PrintOrder
+
+			assert.NoError(t, json.Unmarshal([]byte(r.FormValue("order")), &received))
+			assert.Equal(t, 2, received.Copies)
+			assert.Len(t, r.MultipartForm.File["files"], 1)
+
+			_, _ = w.Write([]byte(`{"OrderID": "1234"}`))
+		}))
+
+		defer srv.Close()
+
+		resp, err := NewPrintClient(srv.URL, "secret").Send(order)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"OrderID": "1234"}`, string(resp))
+	})
+	t.Run("Unauthorized", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+
+		defer srv.Close()
+
+		_, err := NewPrintClient(srv.URL, "").Send(order)
+
+		assert.Error(t, err)
+	})
+	t.Run("NoPhotos", func(t *testing.T) {
+		_, err := NewPrintClient("http://127.0.0.1:1/", "").Send(PrintOrder{Product: "4x6"})
+		assert.Error(t, err)
+	})
+}
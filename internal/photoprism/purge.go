@@ -59,6 +59,22 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 
 	defer mutex.MainWorker.Stop()
 
+	// Files in directories that have not changed since the last complete scan cannot be missing,
+	// unless a full scan is requested.
+	dirs := make(map[string]*dirTimes)
+
+	unchanged := func(fileRoot, relName, fileName string) bool {
+		if opt.Full {
+			return false
+		}
+
+		if dirs[fileRoot] == nil {
+			dirs[fileRoot] = newDirTimes(fileRoot)
+		}
+
+		return dirs[fileRoot].FileUnchanged(fileName, relName)
+	}
+
 	limit := 500
 	offset := 0
 
@@ -97,6 +113,8 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 						log.Infof("purge: found %s", sanitize.Log(file.FileName))
 					}
 				}
+			} else if unchanged(file.FileRoot, file.FileName, fileName) {
+				continue
 			} else if !fs.FileExists(fileName) {
 				if opt.Dry {
 					purgedFiles[fileName] = true
@@ -155,7 +173,7 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 
 			fileName := FileName(file.FileRoot, file.FileName)
 
-			if ignore[fileName].Exists() || purgedFiles[fileName] {
+			if ignore[fileName].Exists() || purgedFiles[fileName] || unchanged(file.FileRoot, file.FileName, fileName) {
 				continue
 			}
 
@@ -275,6 +293,21 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 		log.Errorf("index: %s (purge places)", err)
 	}
 
+	// Remove cached video segments of deleted files.
+	if opt.Path == "" {
+		if n, err := PurgeHls(w.conf.VideoPath(), opt.Dry); err != nil {
+			log.Errorf("purge: %s (video segments)", err)
+		} else if n > 0 && !opt.Dry {
+			log.Infof("purge: removed %s", english.Plural(n, "segmented video", "segmented videos"))
+		}
+
+		if n, err := PurgeSprites(w.conf.VideoPath(), opt.Dry); err != nil {
+			log.Errorf("purge: %s (video sprites)", err)
+		} else if n > 0 && !opt.Dry {
+			log.Infof("purge: removed %s", english.Plural(n, "video sprite", "video sprites"))
+		}
+	}
+
 	// Update precalculated photo and file counts.
 	if err := entity.UpdateCounts(); err != nil {
 		log.Warnf("index: %s (update counts)", err)
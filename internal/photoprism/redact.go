@@ -0,0 +1,118 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Redact represents a worker that removes embedded metadata from copies of shared files with ExifTool.
+type Redact struct {
+	conf  *config.Config
+	extra []string
+}
+
+// NewRedact returns a new metadata redaction worker.
+func NewRedact(conf *config.Config) *Redact {
+	instance := &Redact{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Add removes the metadata fields in addition to the configured fields, e.g. GPS for links without exact locations.
+func (w *Redact) Add(fields ...string) *Redact {
+	w.extra = append(w.extra, fields...)
+	return w
+}
+
+// Fields returns the metadata fields to remove without duplicates.
+func (w *Redact) Fields() (result []string) {
+	found := make(map[string]bool)
+
+	for _, field := range append(w.conf.ShareRedact(), w.extra...) {
+		if !found[field] {
+			found[field] = true
+			result = append(result, field)
+		}
+	}
+
+	return result
+}
+
+// Enabled tests if metadata is removed from shared files.
+func (w *Redact) Enabled() bool {
+	return len(w.Args()) > 0
+}
+
+// Args returns the ExifTool arguments for removing the configured metadata.
+func (w *Redact) Args() (args []string) {
+	for _, field := range w.Fields() {
+		switch field {
+		case entity.RedactSerial:
+			args = append(args, "-SerialNumber=", "-InternalSerialNumber=", "-BodySerialNumber=",
+				"-LensSerialNumber=", "-XMP-aux:SerialNumber=", "-XMP-aux:LensSerialNumber=")
+		case entity.RedactOwner:
+			args = append(args, "-OwnerName=", "-CameraOwnerName=", "-Artist=", "-Copyright=",
+				"-XMP-dc:Creator=", "-XMP-dc:Rights=", "-IPTC:By-line=", "-IPTC:CopyrightNotice=")
+		case entity.RedactGps:
+			args = append(args, "-gps:all=", "-XMP-exif:GPSLatitude=", "-XMP-exif:GPSLongitude=",
+				"-XMP-exif:GPSAltitude=")
+		case entity.RedactFilenames:
+			args = append(args, "-DocumentName=", "-RawFileName=", "-OriginalRawFileName=",
+				"-XMP-xmpMM:PreservedFileName=")
+		}
+	}
+
+	return args
+}
+
+// File creates a copy of the file without the configured metadata in the temp folder and returns
+// its name. The caller is responsible for removing the copy once it has been served.
+func (w *Redact) File(fileName string) (string, error) {
+	args := w.Args()
+
+	if len(args) == 0 {
+		return fileName, nil
+	} else if w.conf.ExifToolBin() == "" {
+		return "", fmt.Errorf("redact: exiftool is required to remove metadata from %s", sanitize.Log(filepath.Base(fileName)))
+	}
+
+	tmpPath := filepath.Join(w.conf.TempPath(), "redact")
+
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	destName := filepath.Join(tmpPath, rnd.UUID()+strings.ToLower(filepath.Ext(fileName)))
+
+	args = append([]string{"-m", "-q", "-o", destName}, args...)
+	cmd := exec.Command(w.conf.ExifToolBin(), append(args, fileName)...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		_ = os.Remove(destName)
+
+		if stderr.String() != "" {
+			return "", errors.New(strings.TrimSpace(stderr.String()))
+		}
+
+		return "", err
+	}
+
+	log.Debugf("redact: removed metadata from %s", sanitize.Log(filepath.Base(fileName)))
+
+	return destName, nil
+}
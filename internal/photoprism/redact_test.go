@@ -0,0 +1,88 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestRedact(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewRedact(conf)
+	fileName := filepath.Join(conf.ExamplesPath(), "tree_white.jpg")
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert.Empty(t, w.Args())
+
+		result, err := w.File(fileName)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fileName, result)
+	})
+
+	// Use a fake ExifTool command that copies the file to the output file name.
+	binName := filepath.Join(conf.TempPath(), "exiftool-redact")
+
+	if err := os.WriteFile(binName, []byte("#!/bin/sh\nfor last; do :; done\ncp \"$last\" \"$4\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	conf.Options().ShareRedact = "serial,gps"
+	conf.Options().ExifToolBin = binName
+
+	defer func() {
+		conf.Options().ShareRedact = ""
+		conf.Options().ExifToolBin = ""
+		_ = os.Remove(binName)
+	}()
+
+	t.Run("Args", func(t *testing.T) {
+		args := w.Args()
+
+		assert.Contains(t, args, "-SerialNumber=")
+		assert.Contains(t, args, "-gps:all=")
+		assert.NotContains(t, args, "-Artist=")
+	})
+	t.Run("File", func(t *testing.T) {
+		result, err := w.File(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(result)
+
+		assert.NotEqual(t, fileName, result)
+		assert.True(t, fs.FileExists(result))
+		assert.Equal(t, fs.Hash(fileName), fs.Hash(result))
+	})
+	t.Run("NoExifTool", func(t *testing.T) {
+		conf.Options().ExifToolBin = ""
+
+		_, err := w.File(fileName)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRedact_Add(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewRedact(conf)
+
+	assert.False(t, w.Enabled())
+
+	w.Add(entity.RedactGps, entity.RedactGps)
+
+	assert.True(t, w.Enabled())
+	assert.Equal(t, []string{entity.RedactGps}, w.Fields())
+	assert.Contains(t, w.Args(), "-gps:all=")
+	assert.NotContains(t, w.Args(), "-SerialNumber=")
+}
@@ -0,0 +1,253 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// RenameOptions represents options for renaming originals.
+type RenameOptions struct {
+	Path   string
+	DryRun bool
+}
+
+// RenamedFile represents an original file that has been renamed.
+type RenamedFile struct {
+	From string
+	To   string
+}
+
+// RenamedFiles represents a list of renamed originals.
+type RenamedFiles []RenamedFile
+
+// Rename represents a worker that renames indexed originals based on the configured file name template.
+type Rename struct {
+	conf *config.Config
+}
+
+// NewRename returns a new rename worker.
+func NewRename(conf *config.Config) *Rename {
+	instance := &Rename{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start renames the indexed originals in the given path and updates the index accordingly.
+func (w *Rename) Start(opt RenameOptions) (renamed RenamedFiles, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("rename: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if !opt.DryRun && w.conf.ReadOnly() {
+		return renamed, config.ErrReadOnly
+	}
+
+	if err := mutex.MainWorker.Start(); err != nil {
+		return renamed, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	opt.Path = strings.Trim(opt.Path, "/")
+
+	// New file name prefixes by photo uid, so that related files keep the same name.
+	prefixes := make(map[string]renamePrefix)
+
+	limit := 500
+	offset := 0
+
+	for {
+		files, err := query.Files(limit, offset, opt.Path, false)
+
+		if err != nil {
+			return renamed, err
+		} else if len(files) == 0 {
+			return renamed, nil
+		}
+
+		for i := range files {
+			if mutex.MainWorker.Canceled() {
+				return renamed, errors.New("rename canceled")
+			}
+
+			file := &files[i]
+
+			if file.FileRoot != entity.RootOriginals || file.PhotoUID == "" {
+				continue
+			}
+
+			prefix, ok := prefixes[file.PhotoUID]
+
+			if !ok {
+				prefix = w.prefix(file.PhotoUID)
+				prefixes[file.PhotoUID] = prefix
+			}
+
+			if prefix.Name == "" {
+				continue
+			}
+
+			if result, err := w.rename(file, prefix, opt.DryRun); err != nil {
+				log.Errorf("rename: %s", err)
+			} else if result.To != "" {
+				renamed = append(renamed, result)
+			}
+		}
+
+		offset += limit
+	}
+}
+
+// renamePrefix represents the new file name prefix of a photo and the original it is based on.
+type renamePrefix struct {
+	Name    string
+	MainUID string
+}
+
+// prefix returns the new file name prefix of a photo, or an empty name if it should not be renamed. It is
+// based on the primary file if it is an original, and the first original otherwise, e.g. if the primary
+// file is a RAW conversion in the sidecar folder.
+func (w *Rename) prefix(photoUID string) (result renamePrefix) {
+	files, err := query.FilesByPhotoUID(photoUID)
+
+	if err != nil {
+		log.Warnf("rename: %s", err)
+		return result
+	}
+
+	var main *entity.File
+
+	// Names must not be used by other files in any folder that contains files of the photo.
+	dirs := make(map[string]bool)
+
+	for i := range files {
+		f := &files[i]
+		dirs[filepath.Dir(FileName(f.FileRoot, f.FileName))] = true
+
+		if main == nil && f.FileRoot == entity.RootOriginals && !f.FileSidecar {
+			main = f
+		}
+	}
+
+	if main == nil {
+		log.Debugf("rename: no original found for %s", sanitize.Log(photoUID))
+		return result
+	}
+
+	mf, err := NewMediaFile(FileName(main.FileRoot, main.FileName))
+
+	if err != nil {
+		log.Warnf("rename: %s", err)
+		return result
+	}
+
+	template := w.conf.ImportName()
+	current := mf.BasePrefix(false)
+	counter := ImportNameHasCounter(template)
+
+	for i := 1; i < 100000; i++ {
+		var prefix string
+
+		if counter {
+			prefix = ImportName(template, mf, i)
+		} else if i == 1 {
+			prefix = ImportName(template, mf, i)
+		} else {
+			prefix = ImportName(template, mf, i) + "." + fmt.Sprintf("%05d", i-1)
+		}
+
+		if prefix == current {
+			return result
+		}
+
+		// Skip names that are already used by other files.
+		used := false
+
+		for dir := range dirs {
+			if matches, err := filepath.Glob(fs.GlobEscape(filepath.Join(dir, prefix)) + ".*"); err != nil {
+				log.Warnf("rename: %s", err)
+				return result
+			} else if len(matches) > 0 {
+				used = true
+				break
+			}
+		}
+
+		if !used {
+			return renamePrefix{Name: prefix, MainUID: main.FileUID}
+		}
+	}
+
+	return result
+}
+
+// rename moves a single file and its sidecar files so that it has the new prefix.
+func (w *Rename) rename(file *entity.File, prefix renamePrefix, dryRun bool) (result RenamedFile, err error) {
+	base := filepath.Base(file.FileName)
+	relName := filepath.Join(filepath.Dir(file.FileName), prefix.Name+strings.TrimPrefix(base, fs.BasePrefix(base, false)))
+	srcName := FileName(file.FileRoot, file.FileName)
+	destName := FileName(file.FileRoot, relName)
+
+	if relName == file.FileName {
+		return result, nil
+	} else if fs.FileExists(destName) {
+		return result, fmt.Errorf("%s already exists", sanitize.Log(relName))
+	}
+
+	result = RenamedFile{From: file.FileName, To: relName}
+
+	if dryRun {
+		return result, nil
+	}
+
+	mf, err := NewMediaFile(srcName)
+
+	if err != nil {
+		return RenamedFile{}, err
+	} else if err := mf.Move(destName); err != nil {
+		return RenamedFile{}, err
+	}
+
+	log.Infof("rename: moved %s to %s", sanitize.Log(file.FileName), sanitize.Log(relName))
+
+	// Update the photo path and name based on the original the new prefix has been derived from.
+	if file.FileUID == prefix.MainUID {
+		err = file.Rename(relName, file.FileRoot, mf.RootRelPath(), mf.BasePrefix(false))
+	} else {
+		err = file.Update("FileName", relName)
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	sidecars, err := mf.RenameSidecars(srcName)
+
+	if err != nil {
+		log.Warnf("rename: %s (sidecars)", err)
+	}
+
+	// Indexed files in the sidecar folder, e.g. RAW conversions, must keep pointing to the renamed files.
+	for srcRel, destRel := range sidecars {
+		if err := query.RenameFile(entity.RootSidecar, srcRel, entity.RootSidecar, destRel); err != nil {
+			log.Warnf("rename: %s (update sidecar %s)", err, sanitize.Log(srcRel))
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,147 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestRename_Start(t *testing.T) {
+	c := config.TestConfig()
+	dir := filepath.Join(c.OriginalsPath(), "rename")
+
+	defer os.RemoveAll(dir)
+
+	c.Options().ImportName = "{name}_renamed"
+	defer func() { c.Options().ImportName = "" }()
+
+	name := "rename/IMG_0001.jpg"
+	fileName := filepath.Join(c.OriginalsPath(), name)
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	} else if err := os.WriteFile(fileName, []byte("rename"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	photo := entity.Photo{PhotoPath: "rename", PhotoName: "IMG_0001"}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: name, FileRoot: entity.RootOriginals, FileType: "jpg", FileHash: fs.Hash(fileName), FilePrimary: true}
+
+	if err := entity.Db().Create(&file).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewRename(c)
+
+	t.Run("DryRun", func(t *testing.T) {
+		renamed, err := w.Start(RenameOptions{Path: "rename", DryRun: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, RenamedFiles{{From: name, To: "rename/IMG_0001_renamed.jpg"}}, renamed)
+		assert.FileExists(t, fileName)
+	})
+	t.Run("Rename", func(t *testing.T) {
+		renamed, err := w.Start(RenameOptions{Path: "rename"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, renamed, 1)
+		assert.NoFileExists(t, fileName)
+		assert.FileExists(t, filepath.Join(dir, "IMG_0001_renamed.jpg"))
+
+		var result entity.File
+
+		if err := entity.Db().Where("id = ?", file.ID).First(&result).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "rename/IMG_0001_renamed.jpg", result.FileName)
+		assert.Equal(t, "IMG_0001_renamed", result.RelatedPhoto().PhotoName)
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		c.Options().ImportName = "{name}"
+
+		renamed, err := w.Start(RenameOptions{Path: "rename"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, renamed)
+	})
+}
+
+func TestRename_Sidecar(t *testing.T) {
+	c := config.TestConfig()
+	dir := filepath.Join(c.OriginalsPath(), "rename-raw")
+	sidecarDir := filepath.Join(c.SidecarPath(), "rename-raw")
+
+	defer os.RemoveAll(dir)
+	defer os.RemoveAll(sidecarDir)
+
+	c.Options().ImportName = "{name}_renamed"
+	defer func() { c.Options().ImportName = "" }()
+
+	rawName := filepath.Join(dir, "IMG_0002.cr2")
+	jpegName := filepath.Join(sidecarDir, "IMG_0002.jpg")
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	} else if err := os.MkdirAll(sidecarDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	} else if err := os.WriteFile(rawName, []byte("raw"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	} else if err := os.WriteFile(jpegName, []byte("jpeg"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	photo := entity.Photo{PhotoPath: "rename-raw", PhotoName: "IMG_0002"}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	raw := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "rename-raw/IMG_0002.cr2", FileRoot: entity.RootOriginals, FileType: "raw", FileHash: fs.Hash(rawName)}
+	jpeg := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "rename-raw/IMG_0002.jpg", FileRoot: entity.RootSidecar, FileType: "jpg", FileHash: fs.Hash(jpegName), FilePrimary: true}
+
+	if err := entity.Db().Create(&raw).Error; err != nil {
+		t.Fatal(err)
+	} else if err := entity.Db().Create(&jpeg).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	renamed, err := NewRename(c).Start(RenameOptions{Path: "rename-raw"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, RenamedFiles{{From: "rename-raw/IMG_0002.cr2", To: "rename-raw/IMG_0002_renamed.cr2"}}, renamed)
+	assert.FileExists(t, filepath.Join(dir, "IMG_0002_renamed.cr2"))
+	assert.FileExists(t, filepath.Join(sidecarDir, "IMG_0002_renamed.jpg"))
+
+	var result entity.File
+
+	if err := entity.Db().Where("id = ?", jpeg.ID).First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "rename-raw/IMG_0002_renamed.jpg", result.FileName)
+	assert.Equal(t, "IMG_0002_renamed", result.RelatedPhoto().PhotoName)
+}
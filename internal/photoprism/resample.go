@@ -47,7 +47,7 @@ func (w *Resample) Start(force bool) (err error) {
 
 	// Start a fixed number of goroutines to read and digest files.
 	var wg sync.WaitGroup
-	var numWorkers = w.conf.Workers()
+	var numWorkers = w.conf.TaskWorkers(config.TaskThumbs)
 	wg.Add(numWorkers)
 	for i := 0; i < numWorkers; i++ {
 		go func() {
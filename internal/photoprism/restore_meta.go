@@ -0,0 +1,228 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// RestoreMetaResult represents the outcome of RestoreMeta().
+type RestoreMetaResult struct {
+	Restored int
+	Skipped  int
+	NotFound int
+	Failed   int
+}
+
+// RestoreMeta applies metadata from YAML and JSON sidecar files to matching photos in the index.
+//
+// Photos are matched by the sidecar file path first, then by the UID stored in YAML files, by the
+// file hash if the file name starts with it, and by the original name stored in YAML files. Photos that
+// have been edited after the YAML file was saved are skipped unless force is true.
+func RestoreMeta(sidecarPath string, force, dryRun bool) (result RestoreMetaResult, err error) {
+	if sidecarPath == "" || !fs.PathExists(sidecarPath) {
+		return result, fmt.Errorf("sidecar path %s not found", sanitize.Log(sidecarPath))
+	}
+
+	err = filepath.Walk(sidecarPath, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		} else if info.IsDir() {
+			return nil
+		}
+
+		relName := fs.RelName(fileName, sidecarPath)
+		format := fs.GetFileFormat(fileName)
+
+		// YAML files may be compressed with gzip.
+		if strings.HasSuffix(fileName, entity.GzipExt) {
+			format = fs.GetFileFormat(strings.TrimSuffix(fileName, entity.GzipExt))
+
+			if format != fs.FormatYaml {
+				return nil
+			}
+		}
+
+		var restored bool
+
+		switch format {
+		case fs.FormatYaml:
+			restored, err = restoreYamlMeta(fileName, strings.TrimSuffix(relName, entity.GzipExt), force, dryRun)
+		case fs.FormatJson:
+			restored, err = restoreJsonMeta(fileName, relName, dryRun)
+		default:
+			return nil
+		}
+
+		switch {
+		case err == errMetaNotFound:
+			log.Debugf("restore: found no photo for %s", sanitize.Log(relName))
+			result.NotFound++
+		case err != nil:
+			log.Errorf("restore: %s in %s", err, sanitize.Log(relName))
+			result.Failed++
+		case restored:
+			log.Infof("restore: applied metadata from %s", sanitize.Log(relName))
+			result.Restored++
+		default:
+			result.Skipped++
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// errMetaNotFound is returned if no photo matches a sidecar file.
+var errMetaNotFound = errors.New("photo not found")
+
+// findSidecarPhoto returns the indexed photo with the path and name of a sidecar file. Photos in
+// additional originals roots must have a file in the given root, so that equal paths don't match.
+func findSidecarPhoto(rootName, relName string) (photo entity.Photo, err error) {
+	photoPath := filepath.Dir(relName)
+	photoName := fs.StripKnownExt(filepath.Base(relName))
+
+	if photoPath == "." {
+		photoPath = ""
+	}
+
+	stmt := entity.UnscopedDb().Where("photo_path = ? AND photo_name = ?", photoPath, photoName)
+
+	if rootName != "" && rootName != entity.RootOriginals {
+		stmt = stmt.Where("id IN (SELECT photo_id FROM files WHERE file_root = ?)", rootName)
+	}
+
+	if err := stmt.First(&photo).Error; err != nil {
+		return photo, err
+	}
+
+	return query.PhotoByUID(photo.PhotoUID)
+}
+
+// restoreYamlMeta applies the metadata in a YAML sidecar file to the matching photo.
+func restoreYamlMeta(fileName, relName string, force, dryRun bool) (restored bool, err error) {
+	backup, photo, err := findYamlPhoto(fileName, entity.RootOriginals, relName)
+
+	if err != nil {
+		return false, err
+	}
+
+	return applyYamlMeta(fileName, relName, backup, photo, force, dryRun)
+}
+
+// findYamlPhoto loads a YAML sidecar file and returns its data along with the matching photo.
+func findYamlPhoto(fileName, rootName, relName string) (backup, photo entity.Photo, err error) {
+	if err := backup.LoadFromYaml(fileName); err != nil {
+		return backup, photo, err
+	}
+
+	photo, err = findSidecarPhoto(rootName, relName)
+
+	if err != nil && backup.PhotoUID != "" {
+		photo, err = query.PhotoByUID(backup.PhotoUID)
+	}
+
+	// Try to find the photo by file hash, e.g. for "a/b/c/<hash>.yml".
+	if hash := fs.StripKnownExt(filepath.Base(relName)); err != nil && fs.IsHash(hash) {
+		var file entity.File
+
+		if file, err = query.FileByHash(hash); err == nil {
+			photo, err = query.PhotoByUID(file.PhotoUID)
+		}
+	}
+
+	if err != nil && backup.OriginalName != "" {
+		var found entity.Photo
+
+		if err = entity.UnscopedDb().Where("original_name = ?", backup.OriginalName).First(&found).Error; err == nil {
+			photo, err = query.PhotoByUID(found.PhotoUID)
+		}
+	}
+
+	if err != nil {
+		return backup, photo, errMetaNotFound
+	}
+
+	return backup, photo, nil
+}
+
+// applyYamlMeta applies the metadata in a YAML sidecar file to the photo.
+func applyYamlMeta(fileName, relName string, backup, photo entity.Photo, force, dryRun bool) (restored bool, err error) {
+	// Don't overwrite edits that are newer than the sidecar file.
+	if !force && photo.EditedAt != nil && (backup.EditedAt == nil || photo.EditedAt.After(*backup.EditedAt)) {
+		log.Infof("restore: %s has been edited after %s was saved", photo.String(), sanitize.Log(relName))
+		return false, nil
+	}
+
+	if dryRun {
+		return true, nil
+	}
+
+	// Keep the identity of the indexed photo.
+	photoID, photoUID := photo.ID, photo.PhotoUID
+
+	if err := photo.LoadFromYaml(fileName); err != nil {
+		return false, err
+	}
+
+	photo.ID, photo.PhotoUID = photoID, photoUID
+
+	if photo.Details != nil {
+		photo.Details.PhotoID = photoID
+	}
+
+	return true, photo.Save()
+}
+
+// restoreJsonMeta applies the metadata in a JSON sidecar file to the matching photo.
+func restoreJsonMeta(fileName, relName string, dryRun bool) (restored bool, err error) {
+	photo, err := findSidecarPhoto(entity.RootOriginals, relName)
+
+	// Try to find the photo by file hash, e.g. for "<hash>_exiftool.json".
+	if hash := strings.SplitN(filepath.Base(relName), "_", 2)[0]; err != nil && fs.IsHash(hash) {
+		var file entity.File
+
+		if file, err = query.FileByHash(hash); err == nil {
+			photo, err = query.PhotoByUID(file.PhotoUID)
+		}
+	}
+
+	if err != nil {
+		return false, errMetaNotFound
+	}
+
+	data, err := meta.JSON(fileName, photo.OriginalName)
+
+	if err != nil {
+		return false, err
+	}
+
+	if dryRun {
+		return true, nil
+	}
+
+	photo.SetTitle(data.Title, entity.SrcMeta)
+	photo.SetDescription(data.Description, entity.SrcMeta)
+	photo.SetTakenAt(data.TakenAt, data.TakenAtLocal, data.TimeZone, entity.SrcMeta)
+	photo.SetCoordinates(data.Lat, data.Lng, data.Altitude, entity.SrcMeta)
+	photo.SetMotion(data.Heading, data.Speed, data.HasHeading, entity.SrcMeta)
+
+	details := photo.GetDetails()
+
+	details.SetKeywords(data.Keywords.String(), entity.SrcMeta)
+	details.SetNotes(data.Notes, entity.SrcMeta)
+	details.SetSubject(data.Subject, entity.SrcMeta)
+	details.SetArtist(data.Artist, entity.SrcMeta)
+	details.SetCopyright(data.Copyright, entity.SrcMeta)
+
+	return true, photo.Save()
+}
@@ -0,0 +1,118 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+func TestRestoreMeta(t *testing.T) {
+	sidecarPath := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(sidecarPath, "2016", "11"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	yamlData := []byte("UID: pt9jtdre2lvl0y14\nTitle: Restored Title\nTitleSrc: manual\n")
+
+	if err := os.WriteFile(filepath.Join(sidecarPath, "2016", "11", "Photo07.yml"), yamlData, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(sidecarPath, "unknown.yml"), []byte("Title: Unknown\n"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("InvalidPath", func(t *testing.T) {
+		_, err := RestoreMeta(filepath.Join(sidecarPath, "missing"), false, false)
+
+		assert.Error(t, err)
+	})
+	t.Run("DryRun", func(t *testing.T) {
+		res, err := RestoreMeta(sidecarPath, true, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, res.Restored)
+		assert.Equal(t, 1, res.NotFound)
+
+		photo, err := query.PhotoByUID("pt9jtdre2lvl0y14")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, "Restored Title", photo.PhotoTitle)
+	})
+	t.Run("EditedAfterSave", func(t *testing.T) {
+		res, err := RestoreMeta(sidecarPath, false, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, res.Restored)
+		assert.Equal(t, 1, res.Skipped)
+	})
+	t.Run("Force", func(t *testing.T) {
+		original, err := query.PhotoByUID("pt9jtdre2lvl0y14")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := RestoreMeta(sidecarPath, true, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, res.Restored)
+
+		photo, err := query.PhotoByUID("pt9jtdre2lvl0y14")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Restored Title", photo.PhotoTitle)
+		assert.Equal(t, entity.SrcManual, photo.TitleSrc)
+		assert.Equal(t, original.ID, photo.ID)
+		assert.Equal(t, original.PhotoPath, photo.PhotoPath)
+
+		if err := photo.Updates(entity.Values{"PhotoTitle": original.PhotoTitle, "TitleSrc": original.TitleSrc}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestRestoreMeta_Hash(t *testing.T) {
+	sidecarPath := t.TempDir()
+
+	entity.SidecarGzip = true
+
+	defer func() { entity.SidecarGzip = false }()
+
+	backup := entity.Photo{PhotoTitle: "Hashed Title", TitleSrc: entity.SrcManual}
+
+	if err := backup.SaveAsYaml(entity.YamlHashName("2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", sidecarPath)); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := RestoreMeta(sidecarPath, true, true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, res.Restored)
+	assert.Equal(t, 0, res.NotFound)
+	assert.Equal(t, 0, res.Failed)
+}
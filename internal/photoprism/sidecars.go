@@ -0,0 +1,253 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// sidecarSaveTolerance is the time after a photo update in which a YAML sidecar file is assumed
+// to have been written by PhotoPrism itself rather than by another application.
+const sidecarSaveTolerance = 10 * time.Second
+
+// SidecarsResult represents the number of changed sidecar files that have been applied to the index.
+type SidecarsResult struct {
+	Xmp    int
+	Yaml   int
+	Failed int
+}
+
+// Updated returns the number of photos updated from sidecar files.
+func (r SidecarsResult) Updated() int {
+	return r.Xmp + r.Yaml
+}
+
+// Sidecars represents a worker that applies metadata from XMP and YAML sidecar files that have been
+// edited by other applications such as Lightroom or darktable, so that no full rescan is required.
+type Sidecars struct {
+	conf  *config.Config
+	index *Index
+}
+
+// NewSidecars returns a new sidecar files worker.
+func NewSidecars(conf *config.Config, index *Index) *Sidecars {
+	return &Sidecars{conf: conf, index: index}
+}
+
+// sidecarRoot represents a folder that may contain sidecar files, and the file root name of the originals they belong to.
+type sidecarRoot struct {
+	Name    string
+	Path    string
+	Sidecar bool
+}
+
+// sidecarFile represents a sidecar file that has been changed by another application.
+type sidecarFile struct {
+	Root     sidecarRoot
+	FileName string
+	RelName  string
+	Format   fs.FileFormat
+	ModTime  time.Time
+}
+
+// Start applies the metadata of sidecar files that have been modified after the given time. Folders are
+// searched without blocking other workers, so that the index is only locked if changed files were found.
+func (w *Sidecars) Start(since time.Time) (result SidecarsResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("sidecars: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	files, err := w.changed(since)
+
+	if err != nil || len(files) == 0 {
+		return result, err
+	}
+
+	if err = mutex.MainWorker.Start(); err != nil {
+		return result, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	for _, f := range files {
+		if mutex.MainWorker.Canceled() {
+			return result, fmt.Errorf("sidecars: canceled")
+		}
+
+		var updated bool
+
+		switch f.Format {
+		case fs.FormatXMP:
+			updated, err = w.xmp(f)
+		case fs.FormatYaml:
+			updated, err = w.yaml(f)
+		}
+
+		if err != nil {
+			log.Errorf("sidecars: %s in %s", err, sanitize.Log(f.RelName))
+			result.Failed++
+		} else if !updated {
+			continue
+		} else if f.Format == fs.FormatXMP {
+			result.Xmp++
+		} else {
+			result.Yaml++
+		}
+	}
+
+	if n := result.Updated(); n > 0 {
+		log.Infof("sidecars: updated %d photos from changed sidecar files", n)
+	}
+
+	return result, nil
+}
+
+// roots returns the folders that may contain sidecar files, including additional originals roots.
+// XMP files may be stored next to the originals, YAML files are stored in sidecar folders only.
+func (w *Sidecars) roots() (result []sidecarRoot) {
+	result = []sidecarRoot{
+		{Name: entity.RootOriginals, Path: w.conf.OriginalsPath()},
+		{Name: entity.RootOriginals, Path: w.conf.SidecarPath(), Sidecar: true},
+	}
+
+	for _, root := range w.conf.OriginalsRoots() {
+		result = append(result,
+			sidecarRoot{Name: root.Name, Path: root.Path},
+			sidecarRoot{Name: root.Name, Path: root.SidecarPath, Sidecar: true})
+	}
+
+	return result
+}
+
+// changed returns the sidecar files that have been modified after the given time. Only files in folders
+// that have been modified as well are checked, which is the case when applications save a file by
+// replacing it, so that files edited in place are found by the next regular index run instead.
+func (w *Sidecars) changed(since time.Time) (files []sidecarFile, err error) {
+	roots := w.roots()
+
+	isRoot := make(map[string]bool, len(roots))
+	walked := make(map[string]bool, len(roots))
+
+	for _, root := range roots {
+		isRoot[root.Path] = true
+	}
+
+	for _, root := range roots {
+		if root.Path == "" || walked[root.Path] || !fs.PathExists(root.Path) {
+			continue
+		}
+
+		walked[root.Path] = true
+		changedDirs := make(map[string]bool)
+
+		err = filepath.WalkDir(root.Path, func(fileName string, d os.DirEntry, err error) error {
+			if err != nil {
+				log.Debugf("sidecars: %s", err)
+				return nil
+			}
+
+			// Skip hidden folders and other roots, e.g. a sidecar folder inside originals.
+			if d.IsDir() {
+				if fileName != root.Path && (strings.HasPrefix(d.Name(), ".") || isRoot[fileName]) {
+					return filepath.SkipDir
+				} else if info, err := d.Info(); err == nil && info.ModTime().After(since) {
+					changedDirs[fileName] = true
+				}
+
+				return nil
+			} else if !changedDirs[filepath.Dir(fileName)] {
+				return nil
+			}
+
+			format := fs.GetFileFormat(fileName)
+
+			// YAML files may be compressed with gzip.
+			if root.Sidecar && strings.HasSuffix(fileName, entity.GzipExt) {
+				format = fs.GetFileFormat(strings.TrimSuffix(fileName, entity.GzipExt))
+			}
+
+			if format != fs.FormatXMP && (format != fs.FormatYaml || !root.Sidecar) {
+				return nil
+			}
+
+			if info, err := d.Info(); err != nil {
+				log.Debugf("sidecars: %s", err)
+			} else if info.ModTime().After(since) {
+				files = append(files, sidecarFile{
+					Root:     root,
+					FileName: fileName,
+					RelName:  fs.RelName(fileName, root.Path),
+					Format:   format,
+					ModTime:  info.ModTime(),
+				})
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return files, err
+		}
+	}
+
+	return files, nil
+}
+
+// xmp indexes a changed XMP file and its related files if the photo already exists.
+func (w *Sidecars) xmp(f sidecarFile) (updated bool, err error) {
+	if _, err := findSidecarPhoto(f.Root.Name, f.RelName); err != nil {
+		log.Debugf("sidecars: found no photo for %s", sanitize.Log(f.RelName))
+		return false, nil
+	}
+
+	opt := IndexOptions{
+		Path:    "/",
+		Rescan:  false,
+		Convert: false,
+		Stack:   true,
+	}
+
+	res := w.index.FileName(f.FileName, opt)
+
+	if res.Failed() {
+		return false, res.Err
+	} else if res.Indexed() {
+		log.Infof("sidecars: applied metadata from %s", sanitize.Log(f.RelName))
+	}
+
+	return res.Indexed(), nil
+}
+
+// yaml applies a changed YAML sidecar file unless it has been written by PhotoPrism.
+func (w *Sidecars) yaml(f sidecarFile) (updated bool, err error) {
+	backup, photo, err := findYamlPhoto(f.FileName, f.Root.Name, strings.TrimSuffix(f.RelName, entity.GzipExt))
+
+	if err == errMetaNotFound {
+		log.Debugf("sidecars: found no photo for %s", sanitize.Log(f.RelName))
+		return false, nil
+	} else if err != nil {
+		return false, err
+	} else if f.ModTime.Before(photo.UpdatedAt.Add(sidecarSaveTolerance)) {
+		return false, nil
+	}
+
+	if updated, err = applyYamlMeta(f.FileName, f.RelName, backup, photo, false, false); err != nil {
+		return false, err
+	} else if updated {
+		log.Infof("sidecars: applied metadata from %s", sanitize.Log(f.RelName))
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,92 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+func TestSidecarsResult_Updated(t *testing.T) {
+	assert.Equal(t, 3, SidecarsResult{Xmp: 1, Yaml: 2, Failed: 4}.Updated())
+}
+
+func TestSidecars_Start(t *testing.T) {
+	conf := config.TestConfig()
+	w := NewSidecars(conf, NewIndex(conf, nil, nil, nil, NewConvert(conf), NewFiles(), NewPhotos()))
+
+	dir := filepath.Join(conf.SidecarPath(), "2016", "11")
+	yamlName := filepath.Join(dir, "Photo08.yml")
+	xmpName := filepath.Join(dir, "sidecars-unknown.xmp")
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(yamlName)
+	defer os.Remove(xmpName)
+
+	since := time.Now().Add(-time.Second)
+
+	yamlData := []byte("UID: pt9jtdre2lvl0y15\nTitle: Edited Elsewhere\nTitleSrc: manual\n")
+
+	if err := os.WriteFile(yamlName, yamlData, os.ModePerm); err != nil {
+		t.Fatal(err)
+	} else if err := os.WriteFile(xmpName, []byte("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\"></x:xmpmeta>"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Changed", func(t *testing.T) {
+		// Edited by another application after the last photo update.
+		editedAt := time.Now().Add(time.Minute)
+
+		if err := os.Chtimes(yamlName, editedAt, editedAt); err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := w.Start(since)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, res.Yaml)
+		assert.Equal(t, 0, res.Xmp)
+
+		photo, err := query.PhotoByUID("pt9jtdre2lvl0y15")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Edited Elsewhere", photo.PhotoTitle)
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		res, err := w.Start(time.Now().Add(time.Minute))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, res.Updated())
+	})
+	t.Run("SavedByIndex", func(t *testing.T) {
+		// The file modification time is close to the last photo update.
+		if err := os.Chtimes(yamlName, time.Now(), time.Now()); err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := w.Start(since)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, res.Yaml)
+	})
+}
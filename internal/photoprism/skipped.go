@@ -0,0 +1,131 @@
+package photoprism
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// SkippedLimit is the maximum number of skipped files that are remembered per worker.
+const SkippedLimit = 10000
+
+// Reasons why files and folders are skipped.
+const (
+	SkippedHidden   = "hidden"
+	SkippedIgnored  = "ignored"
+	SkippedJunk     = "junk"
+	SkippedSize     = "size"
+	SkippedDepth    = "depth"
+	SkippedDuration = "duration"
+)
+
+// SkippedFile represents a file or folder that was skipped by the last index or import run,
+// or a video that was not transcoded. Details contain the exceeded limit, if any.
+type SkippedFile struct {
+	Root    string `json:"Root"`
+	Name    string `json:"Name"`
+	Reason  string `json:"Reason"`
+	Details string `json:"Details,omitempty"`
+}
+
+// SkippedFiles represents a list of skipped files and folders.
+type SkippedFiles []SkippedFile
+
+var skipped = struct {
+	sync.RWMutex
+	files map[string]SkippedFiles
+}{files: make(map[string]SkippedFiles)}
+
+// Skipped returns the files and folders that were skipped by the last run of a worker, e.g. "index" or "import".
+func Skipped(worker string) SkippedFiles {
+	skipped.RLock()
+	defer skipped.RUnlock()
+
+	result := make(SkippedFiles, len(skipped.files[worker]))
+	copy(result, skipped.files[worker])
+
+	return result
+}
+
+// resetSkipped removes the skipped files of a previous run.
+func resetSkipped(worker string) {
+	skipped.Lock()
+	defer skipped.Unlock()
+
+	delete(skipped.files, worker)
+}
+
+// addSkipped remembers the files and folders skipped while walking a root folder, names are relative to its path.
+func addSkipped(worker, rootName, rootPath string, ignore *fs.IgnoreList) {
+	if ignore == nil {
+		return
+	}
+
+	skipped.Lock()
+	defer skipped.Unlock()
+
+	files := skipped.files[worker]
+
+	add := func(names []string, reason string) {
+		for _, name := range names {
+			if len(files) >= SkippedLimit {
+				return
+			}
+
+			files = append(files, SkippedFile{Root: rootName, Name: fs.RelName(name, rootPath), Reason: reason})
+		}
+	}
+
+	add(ignore.Junk(), SkippedJunk)
+	add(ignore.Ignored(), SkippedIgnored)
+	add(ignore.Hidden(), SkippedHidden)
+
+	skipped.files[worker] = files
+}
+
+// addSkippedFile remembers a single file or folder that exceeds a configured limit, replacing a previous entry.
+func addSkippedFile(worker string, file SkippedFile) {
+	skipped.Lock()
+	defer skipped.Unlock()
+
+	files := skipped.files[worker]
+
+	for i := range files {
+		if files[i].Root == file.Root && files[i].Name == file.Name {
+			files[i] = file
+			return
+		}
+	}
+
+	if len(files) >= SkippedLimit {
+		return
+	}
+
+	skipped.files[worker] = append(files, file)
+}
+
+// removeSkippedFile forgets a skipped file, e.g. after the limit has been raised.
+func removeSkippedFile(worker, rootName, relName string) {
+	skipped.Lock()
+	defer skipped.Unlock()
+
+	files := skipped.files[worker]
+
+	for i := range files {
+		if files[i].Root == rootName && files[i].Name == relName {
+			skipped.files[worker] = append(files[:i], files[i+1:]...)
+			return
+		}
+	}
+}
+
+// skipSize remembers a file that exceeds the originals file size limit.
+func skipSize(worker string, f *MediaFile, sizeLimit int64) {
+	addSkippedFile(worker, SkippedFile{
+		Root:    f.Root(),
+		Name:    f.RootRelName(),
+		Reason:  SkippedSize,
+		Details: fmt.Sprintf("%d / %d MB", f.FileSize()/(1024*1024), sizeLimit/(1024*1024)),
+	})
+}
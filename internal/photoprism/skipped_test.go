@@ -0,0 +1,54 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestSkipped(t *testing.T) {
+	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
+
+	assert.True(t, ignore.Ignore("/photos/2020/.DS_Store"))
+	assert.True(t, ignore.Ignore("/photos/2020/.hidden.jpg"))
+	assert.False(t, ignore.Ignore("/photos/2020/IMG_1234.jpg"))
+
+	resetSkipped("test")
+	addSkipped("test", "/", "/photos", ignore)
+
+	result := Skipped("test")
+
+	assert.Equal(t, SkippedFiles{
+		{Root: "/", Name: "2020/.DS_Store", Reason: SkippedJunk},
+		{Root: "/", Name: "2020/.hidden.jpg", Reason: SkippedHidden},
+	}, result)
+
+	resetSkipped("test")
+
+	assert.Empty(t, Skipped("test"))
+	assert.Empty(t, Skipped("unknown"))
+}
+
+func TestAddSkippedFile(t *testing.T) {
+	resetSkipped("test")
+
+	addSkippedFile("test", SkippedFile{Root: "/", Name: "2020/video.mp4", Reason: SkippedDuration, Details: "1h0m0s / 30m0s"})
+	addSkippedFile("test", SkippedFile{Root: "/", Name: "2020/image.jpg", Reason: SkippedSize, Details: "1200 / 1000 MB"})
+	addSkippedFile("test", SkippedFile{Root: "/", Name: "2020/video.mp4", Reason: SkippedDuration, Details: "2h0m0s / 30m0s"})
+
+	assert.Equal(t, SkippedFiles{
+		{Root: "/", Name: "2020/video.mp4", Reason: SkippedDuration, Details: "2h0m0s / 30m0s"},
+		{Root: "/", Name: "2020/image.jpg", Reason: SkippedSize, Details: "1200 / 1000 MB"},
+	}, Skipped("test"))
+
+	removeSkippedFile("test", "/", "2020/video.mp4")
+	removeSkippedFile("test", "/", "2020/unknown.mp4")
+
+	assert.Equal(t, SkippedFiles{
+		{Root: "/", Name: "2020/image.jpg", Reason: SkippedSize, Details: "1200 / 1000 MB"},
+	}, Skipped("test"))
+
+	resetSkipped("test")
+}
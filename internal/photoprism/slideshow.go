@@ -0,0 +1,343 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// SlideshowLimit is the maximum number of photos in a slideshow video.
+const SlideshowLimit = 200
+
+// SlideshowFps is the frame rate of slideshow videos.
+const SlideshowFps = 25
+
+// Slideshow durations in seconds.
+const (
+	SlideshowDuration       = 4.0
+	SlideshowDurationMin    = 1.0
+	SlideshowDurationMax    = 60.0
+	SlideshowTransitionTime = 1.0
+)
+
+// SlideshowTransitions maps transition names to ffmpeg xfade transitions, an empty value means a hard cut.
+var SlideshowTransitions = map[string]string{
+	"none":     "",
+	"fade":     "fade",
+	"dissolve": "dissolve",
+	"slide":    "slideleft",
+	"wipe":     "wipeleft",
+}
+
+// SlideshowMusicTypes contains the file extensions of supported background music files.
+var SlideshowMusicTypes = map[string]bool{".mp3": true, ".m4a": true, ".aac": true, ".ogg": true, ".wav": true, ".flac": true}
+
+// SlideshowOptions represents slideshow video rendering options.
+type SlideshowOptions struct {
+	Duration   float64
+	Transition string
+	Music      string
+	Width      int
+	Height     int
+}
+
+// NewSlideshowOptions returns valid slideshow options, unknown transitions default to a fade.
+func NewSlideshowOptions(duration float64, transition, music string) SlideshowOptions {
+	if duration <= 0 {
+		duration = SlideshowDuration
+	} else if duration < SlideshowDurationMin {
+		duration = SlideshowDurationMin
+	} else if duration > SlideshowDurationMax {
+		duration = SlideshowDurationMax
+	}
+
+	transition = strings.ToLower(strings.TrimSpace(transition))
+
+	if _, ok := SlideshowTransitions[transition]; !ok {
+		transition = "fade"
+	}
+
+	return SlideshowOptions{
+		Duration:   duration,
+		Transition: transition,
+		Music:      music,
+		Width:      1920,
+		Height:     1080,
+	}
+}
+
+// xfade returns the ffmpeg transition, or an empty string if photos are shown without transition.
+func (opt SlideshowOptions) xfade() string {
+	return SlideshowTransitions[opt.Transition]
+}
+
+// Length returns the length of a slideshow video with the given number of photos.
+func (opt SlideshowOptions) Length(photos int) time.Duration {
+	if photos <= 0 {
+		return 0
+	}
+
+	seconds := float64(photos) * opt.Duration
+
+	if opt.xfade() != "" {
+		seconds += SlideshowTransitionTime
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// SlideshowPath returns the cache file name of the slideshow video of an album.
+func SlideshowPath(videoPath, albumUID string) string {
+	albumUID = sanitize.IdString(albumUID)
+
+	if albumUID == "" {
+		return ""
+	}
+
+	return filepath.Join(videoPath, "slideshow", albumUID+".mp4")
+}
+
+// SlideshowMusic returns the absolute file name of a background music file in the originals folder.
+func SlideshowMusic(originalsPath, name string) (string, error) {
+	if name = strings.TrimSpace(name); name == "" {
+		return "", nil
+	}
+
+	ext := strings.ToLower(filepath.Ext(name))
+
+	if !SlideshowMusicTypes[ext] {
+		return "", fmt.Errorf("slideshow: unsupported music file type %s", sanitize.Log(ext))
+	}
+
+	fileName := filepath.Join(originalsPath, filepath.Clean("/"+name))
+
+	if !fs.FileExists(fileName) {
+		return "", fmt.Errorf("slideshow: music file %s not found", sanitize.Log(name))
+	}
+
+	return fileName, nil
+}
+
+// Slideshow represents a worker that renders albums as slideshow videos.
+type Slideshow struct {
+	conf *config.Config
+}
+
+// NewSlideshow returns a new slideshow video worker.
+func NewSlideshow(conf *config.Config) *Slideshow {
+	return &Slideshow{conf: conf}
+}
+
+// Command returns the ffmpeg command for rendering the images as slideshow video.
+func (w *Slideshow) Command(images []string, music, fileName string, opt SlideshowOptions) *exec.Cmd {
+	transition := opt.xfade()
+	length := opt.Duration
+
+	// Images overlap during transitions, so each is shown longer.
+	if transition != "" {
+		length += SlideshowTransitionTime
+	}
+
+	args := []string{"-y"}
+
+	for _, img := range images {
+		args = append(args, "-loop", "1", "-t", formatSeconds(length), "-i", img)
+	}
+
+	var filter []string
+
+	for i := range images {
+		filter = append(filter, fmt.Sprintf(
+			"[%d:v]scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2,setsar=1,fps=%d,format=yuv420p[v%d]",
+			i, opt.Width, opt.Height, opt.Width, opt.Height, SlideshowFps, i))
+	}
+
+	out := "[v0]"
+
+	if len(images) > 1 && transition != "" {
+		for i := 1; i < len(images); i++ {
+			next := fmt.Sprintf("[x%d]", i)
+			offset := float64(i) * opt.Duration
+			filter = append(filter, fmt.Sprintf("%s[v%d]xfade=transition=%s:duration=%s:offset=%s%s",
+				out, i, transition, formatSeconds(SlideshowTransitionTime), formatSeconds(offset), next))
+			out = next
+		}
+	} else if len(images) > 1 {
+		var inputs string
+
+		for i := range images {
+			inputs += fmt.Sprintf("[v%d]", i)
+		}
+
+		filter = append(filter, fmt.Sprintf("%sconcat=n=%d:v=1:a=0[out]", inputs, len(images)))
+		out = "[out]"
+	}
+
+	total := opt.Length(len(images)).Seconds()
+
+	if music != "" {
+		args = append(args, "-stream_loop", "-1", "-i", music)
+		fadeStart := total - 2
+
+		if fadeStart < 0 {
+			fadeStart = 0
+		}
+
+		filter = append(filter, fmt.Sprintf("[%d:a]afade=t=out:st=%s:d=2[aout]", len(images), formatSeconds(fadeStart)))
+	}
+
+	args = append(args, "-filter_complex", strings.Join(filter, ";"), "-map", out)
+
+	if music != "" {
+		args = append(args, "-map", "[aout]", "-c:a", "aac", "-b:a", "192k", "-shortest")
+	}
+
+	args = append(args,
+		"-t", formatSeconds(total),
+		"-c:v", "libx264",
+		"-pix_fmt", "yuv420p",
+		"-r", strconv.Itoa(SlideshowFps),
+		"-movflags", "+faststart",
+		"-f", "mp4",
+		fileName,
+	)
+
+	return exec.Command(w.conf.FFmpegBin(), args...)
+}
+
+// formatSeconds returns the number of seconds as string for use in ffmpeg arguments.
+func formatSeconds(s float64) string {
+	return strconv.FormatFloat(s, 'f', 3, 64)
+}
+
+// images returns the file names of the album photo thumbnails to be shown in the slideshow.
+func (w *Slideshow) images(a entity.Album) (images []string, err error) {
+	photos, err := search.AlbumPhotos(a, SlideshowLimit, false)
+
+	if err != nil {
+		return images, err
+	}
+
+	size := thumb.Sizes[thumb.Fit1920]
+
+	for _, p := range photos {
+		if p.FileVideo || p.FileHash == "" || p.FileMissing {
+			continue
+		}
+
+		fileName := FileName(p.FileRoot, p.FileName)
+
+		if img, err := thumb.FromFile(fileName, p.FileHash, w.conf.ThumbPath(), size.Width, size.Height, p.FileOrientation, size.Options...); err != nil {
+			log.Warnf("slideshow: %s in %s", err, sanitize.Log(p.FileName))
+		} else {
+			images = append(images, img)
+		}
+	}
+
+	return images, nil
+}
+
+// Render creates a slideshow video of the album and returns its file name.
+func (w *Slideshow) Render(a entity.Album, opt SlideshowOptions) (fileName string, err error) {
+	if err := mutex.SlideshowWorker.Start(); err != nil {
+		return "", err
+	}
+
+	defer mutex.SlideshowWorker.Stop()
+
+	return w.render(a, opt)
+}
+
+// Start creates a slideshow video of the album in the background and calls done with the result. It returns
+// an error without starting if another slideshow is being rendered, so that callers can report it right away.
+func (w *Slideshow) Start(a entity.Album, opt SlideshowOptions, done func(fileName string, err error)) error {
+	if err := mutex.SlideshowWorker.Start(); err != nil {
+		return err
+	}
+
+	go func() {
+		defer mutex.SlideshowWorker.Stop()
+
+		fileName, err := w.render(a, opt)
+
+		if done != nil {
+			done(fileName, err)
+		}
+	}()
+
+	return nil
+}
+
+// render creates a slideshow video of the album, the caller must hold the worker lock.
+func (w *Slideshow) render(a entity.Album, opt SlideshowOptions) (fileName string, err error) {
+	if w.conf.DisableFFmpeg() {
+		return "", fmt.Errorf("slideshow: ffmpeg is disabled")
+	} else if a.AlbumUID == "" {
+		return "", fmt.Errorf("slideshow: album has no id - you might have found a bug")
+	}
+
+	start := time.Now()
+
+	music, err := SlideshowMusic(w.conf.OriginalsPath(), opt.Music)
+
+	if err != nil {
+		return "", err
+	}
+
+	images, err := w.images(a)
+
+	if err != nil {
+		return "", err
+	} else if len(images) == 0 {
+		return "", fmt.Errorf("slideshow: no photos found in %s", sanitize.Log(a.Title()))
+	}
+
+	fileName = SlideshowPath(w.conf.VideoPath(), a.AlbumUID)
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	// Render to a temporary file, so that an incomplete video is never downloaded.
+	tmpName := fileName + ".tmp"
+
+	cmd := w.Command(images, music, tmpName, opt)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	log.Infof("slideshow: rendering %d photos of %s", len(images), sanitize.Log(a.Title()))
+
+	if err := cmd.Run(); err != nil {
+		_ = os.Remove(tmpName)
+
+		if stderr.String() != "" {
+			log.Debug(stderr.String())
+		}
+
+		return "", errors.New(strings.TrimSpace(err.Error()))
+	}
+
+	if err := os.Rename(tmpName, fileName); err != nil {
+		_ = os.Remove(tmpName)
+		return "", err
+	}
+
+	log.Infof("slideshow: created %s [%s]", sanitize.Log(filepath.Base(fileName)), time.Since(start))
+
+	return fileName, nil
+}
@@ -0,0 +1,140 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+func TestNewSlideshowOptions(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		opt := NewSlideshowOptions(0, "", "")
+		assert.Equal(t, SlideshowDuration, opt.Duration)
+		assert.Equal(t, "fade", opt.Transition)
+		assert.Equal(t, 1920, opt.Width)
+		assert.Equal(t, 1080, opt.Height)
+	})
+	t.Run("Limits", func(t *testing.T) {
+		assert.Equal(t, SlideshowDurationMin, NewSlideshowOptions(0.2, "none", "").Duration)
+		assert.Equal(t, SlideshowDurationMax, NewSlideshowOptions(600, "none", "").Duration)
+	})
+	t.Run("Transition", func(t *testing.T) {
+		assert.Equal(t, "slide", NewSlideshowOptions(5, " Slide ", "").Transition)
+		assert.Equal(t, "none", NewSlideshowOptions(5, "none", "").Transition)
+		assert.Equal(t, "fade", NewSlideshowOptions(5, "spin", "").Transition)
+	})
+}
+
+func TestSlideshowOptions_Length(t *testing.T) {
+	assert.Equal(t, 13*time.Second, NewSlideshowOptions(4, "fade", "").Length(3))
+	assert.Equal(t, 12*time.Second, NewSlideshowOptions(4, "none", "").Length(3))
+	assert.Equal(t, time.Duration(0), NewSlideshowOptions(4, "fade", "").Length(0))
+}
+
+func TestSlideshowPath(t *testing.T) {
+	assert.Equal(t, "/cache/videos/slideshow/at9lxuqxpogaaba8.mp4", SlideshowPath("/cache/videos", "at9lxuqxpogaaba8"))
+	assert.Equal(t, "", SlideshowPath("/cache/videos", ""))
+}
+
+func TestSlideshowMusic(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "song.mp3"), []byte("ID3"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		fileName, err := SlideshowMusic(dir, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "", fileName)
+	})
+	t.Run("Found", func(t *testing.T) {
+		fileName, err := SlideshowMusic(dir, "song.mp3")
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "song.mp3"), fileName)
+	})
+	t.Run("Outside", func(t *testing.T) {
+		_, err := SlideshowMusic(dir, "../song.mp3")
+		assert.NoError(t, err)
+		_, err = SlideshowMusic(dir, "../../etc/song.mp3")
+		assert.Error(t, err)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := SlideshowMusic(dir, "notes.txt")
+		assert.Error(t, err)
+	})
+}
+
+func TestSlideshow_Start(t *testing.T) {
+	w := NewSlideshow(config.TestConfig())
+
+	t.Run("Busy", func(t *testing.T) {
+		if err := mutex.SlideshowWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.SlideshowWorker.Stop()
+
+		assert.Error(t, w.Start(entity.Album{AlbumUID: "at9lxuqxpogaaba8"}, NewSlideshowOptions(3, "fade", ""), nil))
+	})
+	t.Run("NoAlbum", func(t *testing.T) {
+		result := make(chan error)
+
+		err := w.Start(entity.Album{}, NewSlideshowOptions(3, "fade", ""), func(fileName string, err error) {
+			result <- err
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, mutex.SlideshowWorker.Busy())
+		assert.Error(t, <-result)
+
+		// The worker is released after rendering.
+		assert.Eventually(t, func() bool { return !mutex.SlideshowWorker.Busy() }, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestSlideshow_Command(t *testing.T) {
+	conf := config.TestConfig()
+	w := NewSlideshow(conf)
+	images := []string{"/tmp/a.jpg", "/tmp/b.jpg", "/tmp/c.jpg"}
+
+	t.Run("Fade", func(t *testing.T) {
+		cmd := w.Command(images, "", "/tmp/slideshow.mp4", NewSlideshowOptions(4, "fade", ""))
+		s := cmd.String()
+
+		assert.True(t, strings.Contains(s, "-loop 1 -t 5.000 -i /tmp/a.jpg"))
+		assert.True(t, strings.Contains(s, "[v0][v1]xfade=transition=fade:duration=1.000:offset=4.000[x1]"))
+		assert.True(t, strings.Contains(s, "[x1][v2]xfade=transition=fade:duration=1.000:offset=8.000[x2]"))
+		assert.True(t, strings.Contains(s, "-map [x2] -t 13.000"))
+		assert.False(t, strings.Contains(s, "-c:a"))
+		assert.True(t, strings.HasSuffix(s, "/tmp/slideshow.mp4"))
+	})
+	t.Run("NoTransition", func(t *testing.T) {
+		cmd := w.Command(images, "", "/tmp/slideshow.mp4", NewSlideshowOptions(4, "none", ""))
+		s := cmd.String()
+
+		assert.True(t, strings.Contains(s, "-loop 1 -t 4.000 -i /tmp/a.jpg"))
+		assert.True(t, strings.Contains(s, "[v0][v1][v2]concat=n=3:v=1:a=0[out]"))
+		assert.False(t, strings.Contains(s, "xfade"))
+	})
+	t.Run("Music", func(t *testing.T) {
+		cmd := w.Command(images, "/music/song.mp3", "/tmp/slideshow.mp4", NewSlideshowOptions(4, "wipe", ""))
+		s := cmd.String()
+
+		assert.True(t, strings.Contains(s, "-stream_loop -1 -i /music/song.mp3"))
+		assert.True(t, strings.Contains(s, "[3:a]afade=t=out:st=11.000:d=2[aout]"))
+		assert.True(t, strings.Contains(s, "-map [aout] -c:a aac"))
+		assert.True(t, strings.Contains(s, "transition=wipeleft"))
+	})
+}
@@ -0,0 +1,394 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// TimelapseMinFrames is the default minimum number of photos in a time-lapse sequence.
+const TimelapseMinFrames = 24
+
+// TimelapseMaxFrames is the maximum number of photos in a time-lapse video.
+const TimelapseMaxFrames = 5000
+
+// TimelapseMaxInterval is the maximum time between two photos of a time-lapse sequence.
+const TimelapseMaxInterval = time.Hour
+
+// TimelapseWindow is the default time window searched for time-lapse sequences.
+const TimelapseWindow = 90 * 24 * time.Hour
+
+// TimelapseMaxWindow is the maximum time window searched for time-lapse sequences at once.
+const TimelapseMaxWindow = 366 * 24 * time.Hour
+
+// TimelapseMaxCandidates is the maximum number of photos checked for time-lapse sequences at once.
+const TimelapseMaxCandidates = 100000
+
+// TimelapseTolerance is the relative deviation from the sequence interval that is still considered fixed,
+// with a minimum of one second because capture times are stored in seconds.
+const TimelapseTolerance = 0.2
+
+// Time-lapse video frame rates.
+const (
+	TimelapseFps    = 25
+	TimelapseFpsMin = 1
+	TimelapseFpsMax = 60
+)
+
+// TimelapseSequence represents photos from the same camera taken at a fixed interval.
+type TimelapseSequence struct {
+	Photos   []string  `json:"Photos"`
+	CameraID uint      `json:"CameraID"`
+	Start    time.Time `json:"Start"`
+	End      time.Time `json:"End"`
+	Interval float64   `json:"Interval"`
+	interval time.Duration
+}
+
+// newTimelapseSequence returns a new sequence starting with the photo.
+func newTimelapseSequence(p entity.Photo) *TimelapseSequence {
+	return &TimelapseSequence{
+		Photos:   []string{p.PhotoUID},
+		CameraID: p.CameraID,
+		Start:    p.TakenAt,
+		End:      p.TakenAt,
+	}
+}
+
+// Frames returns the number of photos in the sequence.
+func (s *TimelapseSequence) Frames() int {
+	return len(s.Photos)
+}
+
+// add appends the photo if it was taken at the sequence interval after the last photo.
+func (s *TimelapseSequence) add(p entity.Photo) bool {
+	d := p.TakenAt.Sub(s.End)
+
+	if d < time.Second || d > TimelapseMaxInterval {
+		return false
+	}
+
+	// The first two photos define the interval.
+	if s.interval == 0 {
+		s.interval = d
+		s.Interval = d.Seconds()
+	} else {
+		tolerance := time.Duration(float64(s.interval) * TimelapseTolerance)
+
+		if tolerance < time.Second {
+			tolerance = time.Second
+		}
+
+		if diff := d - s.interval; diff > tolerance || diff < -tolerance {
+			return false
+		}
+	}
+
+	s.Photos = append(s.Photos, p.PhotoUID)
+	s.End = p.TakenAt
+
+	return true
+}
+
+// TimelapseSequences returns the sequences of at least minFrames photos taken at a fixed interval,
+// the photos must be ordered by camera and capture time.
+func TimelapseSequences(photos entity.Photos, minFrames int) (result []TimelapseSequence) {
+	result = []TimelapseSequence{}
+
+	if minFrames < 2 {
+		minFrames = 2
+	}
+
+	var seq *TimelapseSequence
+	var last entity.Photo
+
+	// flush adds the current sequence to the result if it is long enough.
+	flush := func() bool {
+		if seq == nil || seq.Frames() < minFrames {
+			return false
+		}
+
+		result = append(result, *seq)
+
+		return true
+	}
+
+	for _, p := range photos {
+		if seq != nil && p.CameraID == last.CameraID && p.CameraSerial == last.CameraSerial {
+			if seq.add(p) {
+				last = p
+				continue
+			}
+
+			// The last photo may start a new sequence with a different interval.
+			if !flush() {
+				if seq = newTimelapseSequence(last); seq.add(p) {
+					last = p
+					continue
+				}
+			}
+		} else {
+			flush()
+		}
+
+		seq = newTimelapseSequence(p)
+		last = p
+	}
+
+	flush()
+
+	return result
+}
+
+// TimelapseOptions represents time-lapse video rendering options.
+type TimelapseOptions struct {
+	Fps    int
+	Width  int
+	Height int
+}
+
+// NewTimelapseOptions returns valid time-lapse options.
+func NewTimelapseOptions(fps int) TimelapseOptions {
+	if fps <= 0 {
+		fps = TimelapseFps
+	} else if fps < TimelapseFpsMin {
+		fps = TimelapseFpsMin
+	} else if fps > TimelapseFpsMax {
+		fps = TimelapseFpsMax
+	}
+
+	return TimelapseOptions{
+		Fps:    fps,
+		Width:  1920,
+		Height: 1080,
+	}
+}
+
+// TimelapsePath returns the originals file name of a time-lapse video starting at the given time.
+func TimelapsePath(originalsPath string, takenAt time.Time, photoUID string) string {
+	photoUID = sanitize.IdString(photoUID)
+
+	if photoUID == "" {
+		return ""
+	}
+
+	takenAt = takenAt.UTC()
+
+	return filepath.Join(originalsPath, "timelapse", takenAt.Format("2006/01"), fmt.Sprintf("%s_%s.mp4", takenAt.Format("20060102_150405"), photoUID))
+}
+
+// Timelapse represents a worker that detects time-lapse sequences and assembles them into videos.
+type Timelapse struct {
+	conf  *config.Config
+	index *Index
+}
+
+// NewTimelapse returns a new time-lapse worker.
+func NewTimelapse(conf *config.Config, index *Index) *Timelapse {
+	return &Timelapse{conf: conf, index: index}
+}
+
+// Sequences returns the time-lapse sequences of at least minFrames photos taken in the given
+// time window, which must not be longer than TimelapseMaxWindow.
+func (w *Timelapse) Sequences(minFrames int, after, before time.Time) ([]TimelapseSequence, error) {
+	if !before.After(after) || before.Sub(after) > TimelapseMaxWindow {
+		return []TimelapseSequence{}, fmt.Errorf("invalid time window")
+	}
+
+	photos, err := query.TimelapseCandidates(after, before, TimelapseMaxCandidates)
+
+	if err != nil {
+		return []TimelapseSequence{}, err
+	}
+
+	return TimelapseSequences(photos, minFrames), nil
+}
+
+// Command returns the ffmpeg command for rendering numbered frame images as time-lapse video.
+func (w *Timelapse) Command(framePattern, fileName string, takenAt time.Time, opt TimelapseOptions) *exec.Cmd {
+	filter := fmt.Sprintf(
+		"scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2,setsar=1,format=yuv420p",
+		opt.Width, opt.Height, opt.Width, opt.Height)
+
+	return exec.Command(
+		w.conf.FFmpegBin(),
+		"-y",
+		"-framerate", strconv.Itoa(opt.Fps),
+		"-i", framePattern,
+		"-vf", filter,
+		"-c:v", "libx264",
+		"-pix_fmt", "yuv420p",
+		"-r", strconv.Itoa(opt.Fps),
+		"-metadata", "creation_time="+takenAt.UTC().Format(time.RFC3339),
+		"-movflags", "+faststart",
+		"-f", "mp4",
+		fileName,
+	)
+}
+
+// frames creates numbered links to the photo thumbnails in dir and returns the UIDs of the photos used.
+func (w *Timelapse) frames(photoUIDs []string, dir string) (used []string, takenAt time.Time, err error) {
+	files, err := query.TimelapseFiles(photoUIDs)
+
+	if err != nil {
+		return used, takenAt, err
+	}
+
+	size := thumb.Sizes[thumb.Fit1920]
+
+	for _, f := range files {
+		if f.FileVideo || f.FileHash == "" || f.PhotoUID == "" {
+			continue
+		}
+
+		fileName := FileName(f.FileRoot, f.FileName)
+
+		img, err := thumb.FromFile(fileName, f.FileHash, w.conf.ThumbPath(), size.Width, size.Height, f.FileOrientation, size.Options...)
+
+		if err != nil {
+			log.Warnf("timelapse: %s in %s", err, sanitize.Log(f.FileName))
+			continue
+		}
+
+		frameName := filepath.Join(dir, fmt.Sprintf("%06d.jpg", len(used)+1))
+
+		// Copy the thumbnail if links are not supported.
+		if err := os.Symlink(img, frameName); err != nil {
+			if err := fs.Copy(img, frameName); err != nil {
+				return used, takenAt, err
+			}
+		}
+
+		if len(used) == 0 {
+			if p, err := query.PhotoByUID(f.PhotoUID); err == nil {
+				takenAt = p.TakenAt
+			}
+		}
+
+		used = append(used, f.PhotoUID)
+	}
+
+	return used, takenAt, nil
+}
+
+// Create renders the photos as time-lapse video, adds it to the originals folder,
+// and returns the UID of the new video, which is linked to its source photos.
+func (w *Timelapse) Create(photoUIDs []string, opt TimelapseOptions) (videoUID string, err error) {
+	if err := mutex.TimelapseWorker.Start(); err != nil {
+		return "", err
+	}
+
+	defer mutex.TimelapseWorker.Stop()
+
+	return w.create(photoUIDs, opt)
+}
+
+// Start creates a time-lapse video in the background and calls done with the result. It returns an error
+// without starting if another time-lapse video is being created, so that callers can report it right away.
+func (w *Timelapse) Start(photoUIDs []string, opt TimelapseOptions, done func(videoUID string, err error)) error {
+	if err := mutex.TimelapseWorker.Start(); err != nil {
+		return err
+	}
+
+	go func() {
+		defer mutex.TimelapseWorker.Stop()
+
+		videoUID, err := w.create(photoUIDs, opt)
+
+		if done != nil {
+			done(videoUID, err)
+		}
+	}()
+
+	return nil
+}
+
+// create renders the photos as time-lapse video, the caller must hold the worker lock.
+func (w *Timelapse) create(photoUIDs []string, opt TimelapseOptions) (videoUID string, err error) {
+	if w.conf.DisableFFmpeg() {
+		return "", fmt.Errorf("timelapse: ffmpeg is disabled")
+	} else if w.conf.ReadOnly() {
+		return "", fmt.Errorf("timelapse: originals folder is read-only")
+	} else if len(photoUIDs) < 2 {
+		return "", fmt.Errorf("timelapse: at least two photos required")
+	} else if len(photoUIDs) > TimelapseMaxFrames {
+		return "", fmt.Errorf("timelapse: too many photos (%d / %d)", len(photoUIDs), TimelapseMaxFrames)
+	}
+
+	start := time.Now()
+
+	dir := filepath.Join(w.conf.TempPath(), "timelapse", rnd.UUID())
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	defer os.RemoveAll(dir)
+
+	used, takenAt, err := w.frames(photoUIDs, dir)
+
+	if err != nil {
+		return "", err
+	} else if len(used) < 2 {
+		return "", fmt.Errorf("timelapse: not enough frames found")
+	}
+
+	fileName := TimelapsePath(w.conf.OriginalsPath(), takenAt, used[0])
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	// Render to a temporary file, so that an incomplete video is never indexed.
+	tmpName := filepath.Join(dir, "timelapse.mp4")
+
+	cmd := w.Command(filepath.Join(dir, "%06d.jpg"), tmpName, takenAt, opt)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	log.Infof("timelapse: rendering %d photos", len(used))
+
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			log.Debug(stderr.String())
+		}
+
+		return "", errors.New(strings.TrimSpace(err.Error()))
+	}
+
+	if err := fs.Move(tmpName, fileName); err != nil {
+		return "", err
+	}
+
+	res := w.index.FileName(fileName, IndexOptionsSingle())
+
+	if res.Failed() {
+		return "", res.Err
+	} else if res.PhotoUID == "" {
+		return "", fmt.Errorf("timelapse: %s could not be indexed", sanitize.Log(filepath.Base(fileName)))
+	}
+
+	if _, err := entity.AddPhotoTimelapse(res.PhotoUID, used); err != nil {
+		return res.PhotoUID, err
+	}
+
+	log.Infof("timelapse: created %s [%s]", sanitize.Log(filepath.Base(fileName)), time.Since(start))
+
+	return res.PhotoUID, nil
+}
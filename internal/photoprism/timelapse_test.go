@@ -0,0 +1,191 @@
+package photoprism
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+// timelapsePhotos returns n photos of the same camera taken at the given interval.
+func timelapsePhotos(prefix string, start time.Time, n int, interval time.Duration) (photos entity.Photos) {
+	for i := 0; i < n; i++ {
+		photos = append(photos, entity.Photo{
+			PhotoUID: fmt.Sprintf("%s%03d", prefix, i),
+			TakenAt:  start.Add(time.Duration(i) * interval),
+			CameraID: 2,
+		})
+	}
+
+	return photos
+}
+
+func TestTimelapseSequences(t *testing.T) {
+	start := time.Date(2021, 6, 1, 5, 0, 0, 0, time.UTC)
+
+	t.Run("FixedInterval", func(t *testing.T) {
+		result := TimelapseSequences(timelapsePhotos("a", start, 30, 10*time.Second), 24)
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, 30, result[0].Frames())
+			assert.Equal(t, 10.0, result[0].Interval)
+			assert.Equal(t, uint(2), result[0].CameraID)
+			assert.Equal(t, start, result[0].Start)
+			assert.Equal(t, start.Add(290*time.Second), result[0].End)
+		}
+	})
+	t.Run("TooShort", func(t *testing.T) {
+		result := TimelapseSequences(timelapsePhotos("a", start, 10, 10*time.Second), 24)
+		assert.Empty(t, result)
+	})
+	t.Run("Jitter", func(t *testing.T) {
+		photos := timelapsePhotos("a", start, 5, time.Minute)
+		photos[2].TakenAt = photos[2].TakenAt.Add(5 * time.Second)
+
+		result := TimelapseSequences(photos, 5)
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, 5, result[0].Frames())
+		}
+	})
+	t.Run("IntervalChange", func(t *testing.T) {
+		photos := timelapsePhotos("a", start, 5, 10*time.Second)
+		photos = append(photos, timelapsePhotos("b", photos[4].TakenAt.Add(time.Minute), 5, time.Minute)...)
+
+		result := TimelapseSequences(photos, 5)
+
+		if assert.Len(t, result, 2) {
+			assert.Equal(t, "a000", result[0].Photos[0])
+			assert.Equal(t, 10.0, result[0].Interval)
+			assert.Equal(t, "b000", result[1].Photos[0])
+			assert.Equal(t, 60.0, result[1].Interval)
+		}
+	})
+	t.Run("RestartWithLastPhoto", func(t *testing.T) {
+		photos := timelapsePhotos("a", start, 2, 10*time.Second)
+		photos = append(photos, timelapsePhotos("b", photos[1].TakenAt.Add(time.Minute), 4, time.Minute)...)
+
+		result := TimelapseSequences(photos, 5)
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, []string{"a001", "b000", "b001", "b002", "b003"}, result[0].Photos)
+		}
+	})
+	t.Run("OtherCamera", func(t *testing.T) {
+		photos := timelapsePhotos("a", start, 6, 10*time.Second)
+		photos[3].CameraID = 3
+
+		result := TimelapseSequences(photos, 4)
+
+		assert.Empty(t, result)
+	})
+	t.Run("Burst", func(t *testing.T) {
+		photos := timelapsePhotos("a", start, 30, 0)
+
+		result := TimelapseSequences(photos, 2)
+
+		assert.Empty(t, result)
+	})
+}
+
+func TestNewTimelapseOptions(t *testing.T) {
+	assert.Equal(t, TimelapseFps, NewTimelapseOptions(0).Fps)
+	assert.Equal(t, 12, NewTimelapseOptions(12).Fps)
+	assert.Equal(t, TimelapseFpsMax, NewTimelapseOptions(240).Fps)
+	assert.Equal(t, 1920, NewTimelapseOptions(0).Width)
+	assert.Equal(t, 1080, NewTimelapseOptions(0).Height)
+}
+
+func TestTimelapsePath(t *testing.T) {
+	takenAt := time.Date(2021, 6, 1, 5, 4, 3, 0, time.UTC)
+
+	assert.Equal(t, "/originals/timelapse/2021/06/20210601_050403_pt9jtdre2lvl0yh7.mp4", TimelapsePath("/originals", takenAt, "pt9jtdre2lvl0yh7"))
+	assert.Equal(t, "", TimelapsePath("/originals", takenAt, ""))
+}
+
+func TestTimelapse_Command(t *testing.T) {
+	conf := config.TestConfig()
+	w := NewTimelapse(conf, nil)
+
+	cmd := w.Command("/tmp/frames/%06d.jpg", "/tmp/timelapse.mp4", time.Date(2021, 6, 1, 5, 4, 3, 0, time.UTC), NewTimelapseOptions(30))
+	s := cmd.String()
+
+	assert.True(t, strings.Contains(s, "-framerate 30 -i /tmp/frames/%06d.jpg"))
+	assert.True(t, strings.Contains(s, "scale=1920:1080:force_original_aspect_ratio=decrease"))
+	assert.True(t, strings.Contains(s, "-metadata creation_time=2021-06-01T05:04:03Z"))
+	assert.True(t, strings.HasSuffix(s, "/tmp/timelapse.mp4"))
+}
+
+func TestTimelapse_Create(t *testing.T) {
+	conf := config.TestConfig()
+	w := NewTimelapse(conf, nil)
+
+	t.Run("NotEnoughPhotos", func(t *testing.T) {
+		_, err := w.Create([]string{"pt9jtdre2lvl0yh7"}, NewTimelapseOptions(0))
+		assert.Error(t, err)
+	})
+}
+
+func TestTimelapse_Start(t *testing.T) {
+	conf := config.TestConfig()
+	w := NewTimelapse(conf, nil)
+
+	t.Run("Busy", func(t *testing.T) {
+		if err := mutex.TimelapseWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.TimelapseWorker.Stop()
+
+		assert.Error(t, w.Start([]string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}, NewTimelapseOptions(0), nil))
+	})
+	t.Run("NotEnoughPhotos", func(t *testing.T) {
+		result := make(chan error)
+
+		err := w.Start([]string{"pt9jtdre2lvl0yh7"}, NewTimelapseOptions(0), func(videoUID string, err error) {
+			result <- err
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, mutex.TimelapseWorker.Busy())
+		assert.Error(t, <-result)
+
+		// The worker is released after rendering.
+		assert.Eventually(t, func() bool { return !mutex.TimelapseWorker.Busy() }, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestTimelapse_Sequences(t *testing.T) {
+	conf := config.TestConfig()
+	w := NewTimelapse(conf, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		before := time.Now()
+
+		result, err := w.Sequences(TimelapseMinFrames, before.Add(-1*TimelapseWindow), before)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotNil(t, result)
+	})
+	t.Run("InvalidWindow", func(t *testing.T) {
+		before := time.Now()
+
+		_, err := w.Sequences(TimelapseMinFrames, before, before)
+		assert.Error(t, err)
+
+		_, err = w.Sequences(TimelapseMinFrames, before.Add(-2*TimelapseMaxWindow), before)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,99 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// VideoCacheDir represents a cached video directory with HLS segments or a sprite sheet.
+type VideoCacheDir struct {
+	Path   string
+	Size   int64
+	UsedAt time.Time
+}
+
+// VideoCacheDirs returns all cached video directories, least recently used first.
+func VideoCacheDirs(videoPath string) (result []VideoCacheDir, err error) {
+	for _, kind := range []string{"hls", "sprite"} {
+		dirs, err := filepath.Glob(filepath.Join(videoPath, kind, "*", "*", "*", "*"))
+
+		if err != nil {
+			return result, err
+		}
+
+		for _, dir := range dirs {
+			info, err := os.Stat(dir)
+
+			if err != nil || !info.IsDir() {
+				continue
+			}
+
+			entry := VideoCacheDir{Path: dir, UsedAt: info.ModTime()}
+
+			_ = filepath.Walk(dir, func(fileName string, info os.FileInfo, err error) error {
+				if err == nil && info.Mode().IsRegular() {
+					entry.Size += info.Size()
+				}
+
+				return nil
+			})
+
+			result = append(result, entry)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].UsedAt.Before(result[j].UsedAt)
+	})
+
+	return result, nil
+}
+
+// TouchVideoCache marks a cached video directory as recently used.
+func TouchVideoCache(dir string) error {
+	now := time.Now()
+
+	return os.Chtimes(dir, now, now)
+}
+
+// TrimVideoCache removes the least recently used video segments and sprite sheets until
+// the cache size does not exceed the limit in bytes.
+func TrimVideoCache(videoPath string, limit int64) (removed int, err error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	dirs, err := VideoCacheDirs(videoPath)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+
+	for _, dir := range dirs {
+		size += dir.Size
+	}
+
+	for _, dir := range dirs {
+		if size <= limit {
+			break
+		}
+
+		if err := os.RemoveAll(dir.Path); err != nil {
+			log.Errorf("gc: %s (trim video cache)", err)
+			continue
+		}
+
+		log.Debugf("gc: removed cached video %s", sanitize.Log(filepath.Base(dir.Path)))
+
+		size -= dir.Size
+		removed++
+	}
+
+	return removed, nil
+}
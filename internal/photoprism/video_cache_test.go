@@ -0,0 +1,58 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestTrimVideoCache(t *testing.T) {
+	videoPath := t.TempDir()
+
+	older := HlsPath(videoPath, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd819")
+	newer := SpritePath(videoPath, "acad9168fa6acc5c5c2965ddf6ec465ca42fd832")
+
+	for i, dir := range []string{older, newer} {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		} else if err := os.WriteFile(filepath.Join(dir, "data"), make([]byte, 1000), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		usedAt := time.Now().Add(time.Duration(i-2) * time.Hour)
+
+		if err := os.Chtimes(dir, usedAt, usedAt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("Dirs", func(t *testing.T) {
+		dirs, err := VideoCacheDirs(videoPath)
+
+		assert.NoError(t, err)
+		assert.Len(t, dirs, 2)
+		assert.Equal(t, older, dirs[0].Path)
+		assert.Equal(t, int64(1000), dirs[0].Size)
+	})
+	t.Run("Unlimited", func(t *testing.T) {
+		removed, err := TrimVideoCache(videoPath, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, removed)
+	})
+	t.Run("Touch", func(t *testing.T) {
+		assert.NoError(t, TouchVideoCache(older))
+
+		removed, err := TrimVideoCache(videoPath, 1500)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		assert.True(t, fs.PathExists(older))
+		assert.False(t, fs.PathExists(newer))
+	})
+}
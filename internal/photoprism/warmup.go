@@ -0,0 +1,142 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+// WarmupOptions represents thumbnail cache warm-up options.
+type WarmupOptions struct {
+	Filter  string       // Search filter, e.g. "favorite:true".
+	Sizes   []thumb.Name // Thumbnail sizes, the pre-cached default sizes if empty.
+	Workers int          // Number of concurrent workers, the thumbs task workers if 0.
+	Force   bool         // Replace existing thumbnails.
+}
+
+// Warmup represents a worker that pre-generates thumbnails for photos matching a search filter,
+// e.g. after the cache was reset or the server was moved.
+type Warmup struct {
+	conf *config.Config
+}
+
+// NewWarmup returns a new thumbnail cache warm-up worker.
+func NewWarmup(conf *config.Config) *Warmup {
+	return &Warmup{conf: conf}
+}
+
+// Sizes returns the thumbnail sizes to generate, skipping sizes that are never served from the cache.
+func (w *Warmup) Sizes(names []thumb.Name) (result []thumb.Name) {
+	if len(names) == 0 {
+		names = thumb.DefaultSizes
+	}
+
+	for _, name := range names {
+		size, ok := thumb.Sizes[name]
+
+		switch {
+		case !ok:
+			log.Warnf("warmup: invalid size %s", name)
+		case size.ExceedsLimit():
+			log.Warnf("warmup: skipping %s, exceeds size limit", name)
+		case size.Uncached() && !w.conf.ThumbUncached():
+			log.Warnf("warmup: skipping %s, exceeds pre-cached size limit", name)
+		default:
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+// Start generates thumbnails for the primary files of matching photos and returns the number of files.
+func (w *Warmup) Start(opt WarmupOptions) (files int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("warmup: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	sizes := w.Sizes(opt.Sizes)
+
+	if len(sizes) == 0 {
+		return 0, fmt.Errorf("warmup: no thumbnail sizes")
+	}
+
+	if err = mutex.MainWorker.Start(); err != nil {
+		return 0, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	numWorkers := opt.Workers
+
+	if numWorkers < 1 {
+		numWorkers = w.conf.TaskWorkers(config.TaskThumbs)
+	}
+
+	jobs := make(chan WarmupJob)
+
+	// Start a fixed number of goroutines to create thumbnails.
+	var wg sync.WaitGroup
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			WarmupWorker(jobs)
+			wg.Done()
+		}()
+	}
+
+	defer wg.Wait()
+	defer close(jobs)
+
+	f := form.SearchPhotos{
+		Query:   opt.Filter,
+		Primary: true,
+		Count:   search.MaxResults,
+		Offset:  0,
+	}
+
+	for {
+		photos, count, err := search.Photos(f)
+
+		if err != nil {
+			return files, err
+		}
+
+		for _, p := range photos {
+			if mutex.MainWorker.Canceled() {
+				return files, errors.New("warmup: canceled")
+			} else if p.FileHash == "" {
+				continue
+			}
+
+			jobs <- WarmupJob{
+				fileName:    FileName(p.FileRoot, p.FileName),
+				hash:        p.FileHash,
+				orientation: p.FileOrientation,
+				thumbPath:   w.conf.ThumbPath(),
+				sizes:       sizes,
+				force:       opt.Force,
+			}
+
+			files++
+		}
+
+		if count < f.Count {
+			break
+		}
+
+		f.Offset += count
+	}
+
+	return files, nil
+}
@@ -0,0 +1,72 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+func TestNewWarmup(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewWarmup(conf)
+
+	assert.IsType(t, &Warmup{}, w)
+}
+
+func TestWarmup_Sizes(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewWarmup(conf)
+
+	t.Run("Default", func(t *testing.T) {
+		sizes := w.Sizes(nil)
+
+		assert.NotEmpty(t, sizes)
+		assert.Contains(t, sizes, thumb.Tile224)
+	})
+	t.Run("Custom", func(t *testing.T) {
+		sizes := w.Sizes([]thumb.Name{thumb.Tile224, thumb.Fit720})
+
+		assert.Equal(t, []thumb.Name{thumb.Tile224, thumb.Fit720}, sizes)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		sizes := w.Sizes([]thumb.Name{"invalid"})
+
+		assert.Empty(t, sizes)
+	})
+}
+
+func TestWarmup_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewWarmup(conf)
+
+	t.Run("NoSizes", func(t *testing.T) {
+		files, err := w.Start(WarmupOptions{Sizes: []thumb.Name{"invalid"}})
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, files)
+	})
+	t.Run("NoMatches", func(t *testing.T) {
+		files, err := w.Start(WarmupOptions{Filter: "title:\"xxx-no-match\"", Sizes: []thumb.Name{thumb.Tile50}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, files)
+	})
+	t.Run("Favorites", func(t *testing.T) {
+		files, err := w.Start(WarmupOptions{Filter: "favorites:true", Sizes: []thumb.Name{thumb.Tile50}, Workers: 2})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, files, 1)
+	})
+}
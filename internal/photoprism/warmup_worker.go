@@ -0,0 +1,60 @@
+package photoprism
+
+import (
+	"os"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+type WarmupJob struct {
+	fileName    string
+	hash        string
+	orientation int
+	thumbPath   string
+	sizes       []thumb.Name
+	force       bool
+}
+
+func WarmupWorker(jobs <-chan WarmupJob) {
+	for job := range jobs {
+		if !fs.FileExists(job.fileName) {
+			log.Warnf("warmup: %s not found", sanitize.Log(job.fileName))
+			continue
+		}
+
+		created := 0
+
+		for _, name := range job.sizes {
+			size := thumb.Sizes[name]
+
+			fileName, err := thumb.FileName(job.hash, job.thumbPath, size.Width, size.Height, size.Options...)
+
+			if err != nil {
+				log.Errorf("warmup: %s", err)
+				continue
+			}
+
+			// Replace existing thumbnails only if forced.
+			if fs.FileExists(fileName) {
+				if !job.force {
+					continue
+				} else if err := os.Remove(fileName); err != nil {
+					log.Errorf("warmup: %s", err)
+					continue
+				}
+			}
+
+			if _, err := thumb.FromFile(job.fileName, job.hash, job.thumbPath, size.Width, size.Height, job.orientation, size.Options...); err != nil {
+				log.Errorf("warmup: %s in %s", err, sanitize.Log(job.fileName))
+			} else {
+				created++
+			}
+		}
+
+		if created > 0 {
+			log.Debugf("warmup: created %d thumbnails for %s", created, sanitize.Log(job.fileName))
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// ExifBackupExt is the file extension of original file backups created before writing metadata.
+const ExifBackupExt = ".original"
+
+// Writeback represents a worker that writes manually edited metadata back to originals with ExifTool.
+type Writeback struct {
+	conf *config.Config
+}
+
+// NewWriteback returns a new metadata writeback worker.
+func NewWriteback(conf *config.Config) *Writeback {
+	instance := &Writeback{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Disabled tests if writing metadata back to originals is disabled.
+func (w *Writeback) Disabled() bool {
+	return len(w.conf.ExifWriteback()) == 0
+}
+
+// File returns the original file of a photo that the metadata should be written to.
+func (w *Writeback) File(p entity.Photo) (*MediaFile, error) {
+	files := p.Files
+
+	if f, err := p.PrimaryFile(); err == nil {
+		files = append([]entity.File{*f}, files...)
+	}
+
+	for _, f := range files {
+		if f.FileSidecar || f.FileMissing || f.FileVideo || f.FileRoot == entity.RootSidecar {
+			continue
+		}
+
+		mf, err := NewMediaFile(FileName(f.FileRoot, f.FileName))
+
+		if err != nil || mf.IsSidecar() || !mf.ExifSupported() {
+			continue
+		}
+
+		return mf, nil
+	}
+
+	return nil, fmt.Errorf("found no original file that supports metadata writeback")
+}
+
+// Args returns the ExifTool arguments for writing the manually edited metadata fields that
+// differ from the metadata found in the file.
+func (w *Writeback) Args(p entity.Photo, mf *MediaFile) (args []string) {
+	data := mf.MetaData()
+
+	for _, field := range w.conf.ExifWriteback() {
+		switch field {
+		case config.ExifWritebackTitle:
+			if p.TitleSrc != entity.SrcManual || p.PhotoTitle == data.Title {
+				continue
+			}
+
+			args = append(args, "-XMP-dc:Title="+p.PhotoTitle, "-IPTC:ObjectName="+p.PhotoTitle)
+		case config.ExifWritebackKeywords:
+			details := p.GetDetails()
+
+			if details.KeywordsSrc != entity.SrcManual {
+				continue
+			}
+
+			var keywords []string
+
+			for _, kw := range strings.Split(details.Keywords, ",") {
+				if kw = strings.TrimSpace(kw); kw != "" {
+					keywords = append(keywords, kw)
+				}
+			}
+
+			if strings.Join(keywords, ", ") == data.Keywords.String() {
+				continue
+			}
+
+			// Empty values remove existing keywords first.
+			args = append(args, "-XMP-dc:Subject=", "-IPTC:Keywords=")
+
+			for _, kw := range keywords {
+				args = append(args, "-XMP-dc:Subject="+kw, "-IPTC:Keywords="+kw)
+			}
+		case config.ExifWritebackGps:
+			if p.PlaceSrc != entity.SrcManual || !p.HasLatLng() || p.PhotoLat == data.Lat && p.PhotoLng == data.Lng {
+				continue
+			}
+
+			// The sign of signed values determines the N/S and E/W references.
+			lat := fmt.Sprintf("%f", p.PhotoLat)
+			lng := fmt.Sprintf("%f", p.PhotoLng)
+
+			args = append(args, "-GPSLatitude="+lat, "-GPSLatitudeRef="+lat, "-GPSLongitude="+lng, "-GPSLongitudeRef="+lng)
+
+			if p.PhotoAltitude != 0 {
+				alt, ref := p.PhotoAltitude, 0
+
+				if alt < 0 {
+					alt, ref = -alt, 1
+				}
+
+				args = append(args, fmt.Sprintf("-GPSAltitude=%d", alt), fmt.Sprintf("-GPSAltitudeRef#=%d", ref))
+			}
+		case config.ExifWritebackRating:
+			if p.RatingSrc != entity.SrcManual || p.PhotoRating == data.Rating {
+				continue
+			}
+
+			args = append(args, fmt.Sprintf("-XMP-xmp:Rating=%d", p.PhotoRating))
+		}
+	}
+
+	return args
+}
+
+// BackupName returns the file name of the backup that is created before the file is modified.
+func (w *Writeback) BackupName(mf *MediaFile) string {
+	return fs.FileName(mf.FileName(), mf.SidecarPath(), mf.OriginalsPath(), ExifBackupExt)
+}
+
+// Start writes the manually edited metadata of a photo back to its original file. A backup of the
+// unmodified file is kept in the sidecar folder, existing backups are never replaced.
+func (w *Writeback) Start(p entity.Photo) error {
+	if w.Disabled() {
+		return nil
+	}
+
+	mf, err := w.File(p)
+
+	if err != nil {
+		return err
+	}
+
+	args := w.Args(p, mf)
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	relName := mf.RootRelName()
+	backupName := w.BackupName(mf)
+
+	if fs.FileExists(backupName) {
+		log.Debugf("writeback: backup of %s already exists", sanitize.Log(relName))
+	} else if err := fs.Copy(mf.FileName(), backupName); err != nil {
+		return fmt.Errorf("writeback: failed creating backup of %s (%s)", sanitize.Log(relName), err)
+	}
+
+	start := time.Now()
+
+	args = append([]string{"-m", "-overwrite_original", "-charset", "iptc=UTF8", "-codedcharacterset=UTF8"}, args...)
+	cmd := exec.Command(w.conf.ExifToolBin(), append(args, mf.FileName())...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// ExifTool only replaces the original if the modified file was written successfully.
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return errors.New(strings.TrimSpace(stderr.String()))
+		}
+
+		return err
+	}
+
+	log.Infof("writeback: updated metadata of %s [%s]", sanitize.Log(relName), time.Since(start))
+
+	return nil
+}
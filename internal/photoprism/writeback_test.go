@@ -0,0 +1,118 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestWriteback(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewWriteback(conf)
+
+	assert.True(t, w.Disabled())
+
+	// Use a fake ExifTool command that accepts all arguments.
+	binName := filepath.Join(conf.TempPath(), "exiftool-writeback")
+
+	if err := os.WriteFile(binName, []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	conf.Options().ExifWriteback = "title,keywords,gps,rating"
+	conf.Options().ExifToolBin = binName
+
+	defer func() {
+		conf.Options().ExifWriteback = ""
+		conf.Options().ExifToolBin = ""
+		_ = os.Remove(binName)
+	}()
+
+	assert.False(t, w.Disabled())
+
+	fileName := filepath.Join(conf.OriginalsPath(), "writeback", "tree_white.jpg")
+
+	if err := fs.Copy(filepath.Join(conf.ExamplesPath(), "tree_white.jpg"), fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(filepath.Dir(fileName))
+
+	photo := entity.Photo{
+		PhotoTitle:    "Tree in Winter",
+		TitleSrc:      entity.SrcManual,
+		PhotoLat:      -33.45,
+		PhotoLng:      151.2,
+		PhotoAltitude: -5,
+		PlaceSrc:      entity.SrcManual,
+		PhotoRating:   4,
+		RatingSrc:     entity.SrcManual,
+		Details:       &entity.Details{Keywords: "tree, winter", KeywordsSrc: entity.SrcManual},
+		Files:         []entity.File{{FileRoot: entity.RootOriginals, FileName: "writeback/tree_white.jpg"}},
+	}
+
+	t.Run("File", func(t *testing.T) {
+		mf, err := w.File(photo)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, fileName, mf.FileName())
+		assert.Equal(t, filepath.Join(conf.SidecarPath(), "writeback", "tree_white.jpg.original"), w.BackupName(mf))
+	})
+	t.Run("Args", func(t *testing.T) {
+		mf, err := w.File(photo)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{
+			"-XMP-dc:Title=Tree in Winter", "-IPTC:ObjectName=Tree in Winter",
+			"-XMP-dc:Subject=", "-IPTC:Keywords=",
+			"-XMP-dc:Subject=tree", "-IPTC:Keywords=tree",
+			"-XMP-dc:Subject=winter", "-IPTC:Keywords=winter",
+			"-GPSLatitude=-33.450001", "-GPSLatitudeRef=-33.450001",
+			"-GPSLongitude=151.199997", "-GPSLongitudeRef=151.199997",
+			"-GPSAltitude=5", "-GPSAltitudeRef#=1",
+			"-XMP-xmp:Rating=4",
+		}, w.Args(photo, mf))
+	})
+	t.Run("Estimated", func(t *testing.T) {
+		mf, err := w.File(photo)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		estimated := photo
+		estimated.TitleSrc = entity.SrcAuto
+		estimated.PlaceSrc = entity.SrcEstimate
+		estimated.RatingSrc = entity.SrcXmp
+		estimated.Details = &entity.Details{Keywords: "tree", KeywordsSrc: entity.SrcMeta}
+
+		assert.Empty(t, w.Args(estimated, mf))
+	})
+	t.Run("Start", func(t *testing.T) {
+		mf, err := w.File(photo)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		backupName := w.BackupName(mf)
+
+		defer os.RemoveAll(filepath.Dir(backupName))
+
+		assert.NoError(t, w.Start(photo))
+		assert.FileExists(t, backupName)
+	})
+}
@@ -0,0 +1,20 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Activities returns the activity feed, optionally filtered by activity type.
+func Activities(limit, offset int, activityType string) (results entity.Activities, err error) {
+	stmt := Db()
+
+	if activityType = strings.TrimSpace(activityType); activityType != "" {
+		stmt = stmt.Where("activity_type = ?", activityType)
+	}
+
+	err = stmt.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&results).Error
+
+	return results, err
+}
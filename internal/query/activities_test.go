@@ -0,0 +1,47 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestActivities(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		results, err := Activities(1000, 0, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 2)
+
+		for i := 1; i < len(results); i++ {
+			assert.False(t, results[i].CreatedAt.After(results[i-1].CreatedAt))
+		}
+	})
+	t.Run("Type", func(t *testing.T) {
+		results, err := Activities(1000, 0, entity.ActivityAlbum)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+
+		for _, m := range results {
+			assert.Equal(t, entity.ActivityAlbum, m.ActivityType)
+		}
+	})
+	t.Run("Offset", func(t *testing.T) {
+		results, err := Activities(1, 1000, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
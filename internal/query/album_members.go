@@ -0,0 +1,49 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// AlbumMemberResult represents an album member together with the user's name.
+type AlbumMemberResult struct {
+	AlbumUID   string     `json:"AlbumUID"`
+	UserUID    string     `json:"UserUID"`
+	UserName   string     `json:"UserName"`
+	FullName   string     `json:"FullName"`
+	MemberRole string     `json:"Role"`
+	InvitedBy  string     `json:"InvitedBy"`
+	PhotoCount int        `json:"PhotoCount"`
+	AddedAt    *time.Time `json:"AddedAt"`
+	CreatedAt  time.Time  `json:"CreatedAt"`
+}
+
+// AlbumMembers returns the members of an album and their activity, sorted by role and user name.
+func AlbumMembers(albumUID string) (results []AlbumMemberResult, err error) {
+	err = Db().Table(entity.AlbumMember{}.TableName()).
+		Select("albums_members.*, users.user_name, users.full_name").
+		Joins("JOIN users ON users.user_uid = albums_members.user_uid AND users.deleted_at IS NULL").
+		Where("albums_members.album_uid = ?", albumUID).
+		Order("albums_members.member_role, users.user_name").
+		Scan(&results).Error
+
+	return results, err
+}
+
+// MemberPhotoUIDs returns the UIDs of the given photos that the user can see as a member of at least one album.
+func MemberPhotoUIDs(userUID string, photoUIDs []string) (results []string, err error) {
+	if userUID == "" || len(photoUIDs) == 0 {
+		return results, nil
+	}
+
+	err = Db().Table(entity.PhotoAlbum{}.TableName()).
+		Joins("JOIN albums_members ON albums_members.album_uid = photos_albums.album_uid").
+		Joins("JOIN photos ON photos.photo_uid = photos_albums.photo_uid").
+		Where("albums_members.user_uid = ? AND photos_albums.hidden = 0", userUID).
+		Where("photos.photo_private = 0 AND photos.deleted_at IS NULL").
+		Where("photos_albums.photo_uid IN (?)", photoUIDs).
+		Pluck("DISTINCT photos_albums.photo_uid", &results).Error
+
+	return results, err
+}
@@ -0,0 +1,56 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestAlbumMembers(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		results, err := AlbumMembers("at9lxuqxpogaaba8")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, "bob", results[0].UserName)
+		assert.Equal(t, entity.MemberContributor, results[0].MemberRole)
+		assert.Equal(t, 1, results[0].PhotoCount)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		results, err := AlbumMembers("at9lxuqxpogaaxxx")
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func TestMemberPhotoUIDs(t *testing.T) {
+	t.Run("Member", func(t *testing.T) {
+		uid := "pt9jtdre2lvl0yh8"
+		results, err := MemberPhotoUIDs("uqxc08w3d0ej2283", []string{uid, "pt9jtdre2lvl0yxx"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{uid}, results)
+	})
+	t.Run("NoMember", func(t *testing.T) {
+		uid := "pt9jtdre2lvl0yh8"
+		results, err := MemberPhotoUIDs("uqxetse3cy5eo9z2", []string{uid})
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		results, err := MemberPhotoUIDs("uqxc08w3d0ej2283", nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
@@ -25,6 +25,28 @@ func AlbumByUID(albumUID string) (album entity.Album, err error) {
 	return album, nil
 }
 
+// UnlockedAlbumUIDs returns the UIDs of the selected albums that are not locked.
+func UnlockedAlbumUIDs(uids []string) (result []string, err error) {
+	if len(uids) == 0 {
+		return result, nil
+	}
+
+	err = UnscopedDb().Model(&entity.Album{}).Where("album_uid IN (?) AND album_locked = 0", uids).Pluck("album_uid", &result).Error
+	return result, err
+}
+
+// GuestAlbumUIDs returns the UIDs of all albums that are visible to registered guests.
+func GuestAlbumUIDs() (result []string, err error) {
+	err = Db().Model(&entity.Album{}).Where("album_guests = 1").Pluck("album_uid", &result).Error
+	return result, err
+}
+
+// PhotoAlbumUIDs returns the UIDs of all albums that contain the photo, e.g. to find the links it has been shared with.
+func PhotoAlbumUIDs(photoUID string) (result []string, err error) {
+	err = Db().Model(&entity.PhotoAlbum{}).Where("photo_uid = ?", photoUID).Pluck("album_uid", &result).Error
+	return result, err
+}
+
 // AlbumCoverByUID returns an album cover file based on the uid.
 func AlbumCoverByUID(uid string) (file entity.File, err error) {
 	a := entity.Album{}
@@ -79,7 +101,7 @@ func UpdateAlbumDates() error {
 
 	switch DbDialect() {
 	case MySQL:
-		return UnscopedDb().Exec(`UPDATE albums
+		return entity.Exec(`UPDATE albums
 		INNER JOIN
 			(SELECT photo_path, MAX(taken_at_local) AS taken_max
 			 FROM photos WHERE taken_src = 'meta' AND photos.photo_quality >= 3 AND photos.deleted_at IS NULL
@@ -98,7 +120,7 @@ func UpdateMissingAlbumEntries() error {
 
 	switch DbDialect() {
 	default:
-		return UnscopedDb().Exec(`UPDATE photos_albums SET missing = 1 WHERE photo_uid IN
+		return entity.Exec(`UPDATE photos_albums SET missing = 1 WHERE photo_uid IN
 		(SELECT photo_uid FROM photos WHERE deleted_at IS NOT NULL OR photo_quality < 0)`).Error
 	}
 }
@@ -107,6 +129,6 @@ func UpdateMissingAlbumEntries() error {
 func AlbumEntryFound(uid string) error {
 	switch DbDialect() {
 	default:
-		return UnscopedDb().Exec(`UPDATE photos_albums SET missing = 0 WHERE photo_uid = ?`, uid).Error
+		return entity.Exec(`UPDATE photos_albums SET missing = 0 WHERE photo_uid = ?`, uid).Error
 	}
 }
@@ -24,6 +24,28 @@ func TestAlbumByUID(t *testing.T) {
 	})
 }
 
+func TestGuestAlbumUIDs(t *testing.T) {
+	result, err := GuestAlbumUIDs()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, result, "at9lxuqxpogaaba8")
+	assert.NotContains(t, result, "at9lxuqxpogaaba9")
+}
+
+func TestPhotoAlbumUIDs(t *testing.T) {
+	result, err := PhotoAlbumUIDs("pt9jtdre2lvl0yh7")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, result, "at9lxuqxpogaaba8")
+	assert.NotContains(t, result, "at9lxuqxpogaaba7")
+}
+
 func TestAlbumCoverByUID(t *testing.T) {
 	t.Run("existing uid default album", func(t *testing.T) {
 		file, err := AlbumCoverByUID("at9lxuqxpogaaba8")
@@ -0,0 +1,18 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// CameraAliases returns camera and lens aliases sorted by type and name, an empty type returns all aliases.
+func CameraAliases(aliasType string) (results entity.CameraAliases, err error) {
+	stmt := UnscopedDb().Order("alias_type, alias_slug")
+
+	if aliasType != "" {
+		stmt = stmt.Where("alias_type = ?", aliasType)
+	}
+
+	err = stmt.Find(&results).Error
+
+	return results, err
+}
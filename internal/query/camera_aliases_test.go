@@ -0,0 +1,34 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestCameraAliases(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		results, err := CameraAliases("")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 2)
+	})
+	t.Run("Camera", func(t *testing.T) {
+		results, err := CameraAliases(entity.AliasCamera)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 1)
+
+		for _, r := range results {
+			assert.Equal(t, entity.AliasCamera, r.AliasType)
+		}
+	})
+}
@@ -0,0 +1,202 @@
+package query
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// ChangedUIDs represents the UIDs of entities that have been created, updated, or deleted.
+type ChangedUIDs struct {
+	Created []string `json:"Created"`
+	Updated []string `json:"Updated"`
+	Deleted []string `json:"Deleted"`
+}
+
+// Changes represents the photos and albums that have changed since a sync token.
+type Changes struct {
+	Token  string      `json:"Token"`
+	Photos ChangedUIDs `json:"Photos"`
+	Albums ChangedUIDs `json:"Albums"`
+}
+
+// SyncToken returns the token that can be used to find changes after the given time.
+func SyncToken(t time.Time) string {
+	return strconv.FormatInt(t.UTC().Unix(), 10)
+}
+
+// ParseSyncToken returns the time encoded in a sync token, or the zero time if the token is empty.
+func ParseSyncToken(token string) (time.Time, error) {
+	if token == "" {
+		return time.Time{}, nil
+	}
+
+	sec, err := strconv.ParseInt(token, 10, 64)
+
+	if err != nil || sec < 0 {
+		return time.Time{}, errors.New("invalid sync token")
+	}
+
+	return time.Unix(sec, 0).UTC(), nil
+}
+
+// ChangesSince finds photos and albums that have been created, updated, or deleted since the given time.
+// If shares is not nil, only changes of public photos in these albums and of the albums themselves are
+// returned, e.g. for guests. Photos that were removed from the albums or made private are reported as deleted.
+//
+// Timestamps are compared inclusively, so clients may receive the same UID again
+// with the next sync, but will never miss a change made within the same second.
+func ChangesSince(since time.Time, shares []string) (result Changes, err error) {
+	result.Token = SyncToken(time.Now())
+
+	if result.Photos, err = changedPhotos(since, shares); err != nil {
+		return result, err
+	}
+
+	if result.Albums, err = changedAlbums(since, shares); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// changedPhotos finds the UIDs of photos that have changed since the given time.
+func changedPhotos(since time.Time, shares []string) (result ChangedUIDs, err error) {
+	photos := func() *gorm.DB {
+		q := UnscopedDb().Table(entity.Photo{}.TableName())
+
+		if shares != nil {
+			q = q.Where("photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND album_uid IN (?))", shares)
+		}
+
+		return q
+	}
+
+	visible := "deleted_at IS NULL"
+
+	if shares != nil {
+		visible = "deleted_at IS NULL AND photo_private = 0"
+	}
+
+	if result.Created, err = pluckUIDs(photos().
+		Where("created_at >= ?", since).Where(visible), "photo_uid"); err != nil {
+		return result, err
+	}
+
+	if result.Updated, err = pluckUIDs(photos().
+		Where("updated_at >= ? AND created_at < ?", since, since).Where(visible), "photo_uid"); err != nil {
+		return result, err
+	}
+
+	// Photos that were created and deleted since the last sync are unknown to the client.
+	if shares == nil {
+		result.Deleted, err = pluckUIDs(photos().
+			Where("deleted_at >= ? AND created_at < ?", since, since), "photo_uid")
+	} else {
+		result.Deleted, err = pluckUIDs(photos().
+			Where("created_at < ?", since).
+			Where("deleted_at >= ? OR updated_at >= ? AND photo_private = 1", since, since), "photo_uid")
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	if shares != nil {
+		var removed []string
+
+		if removed, err = pluckUIDs(UnscopedDb().Table(entity.PhotoAlbum{}.TableName()).
+			Where("hidden = 1 AND updated_at >= ? AND album_uid IN (?)", since, shares), "photo_uid"); err != nil {
+			return result, err
+		}
+
+		result.Deleted = append(result.Deleted, removed...)
+	}
+
+	if result.Deleted, err = addTombstones(result.Deleted, entity.TombPhoto, since, shares); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// changedAlbums finds the UIDs of albums that have changed since the given time.
+func changedAlbums(since time.Time, shares []string) (result ChangedUIDs, err error) {
+	albums := func() *gorm.DB {
+		q := UnscopedDb().Table(entity.Album{}.TableName())
+
+		if shares != nil {
+			q = q.Where("album_uid IN (?)", shares)
+		}
+
+		return q
+	}
+
+	if result.Created, err = pluckUIDs(albums().
+		Where("created_at >= ? AND deleted_at IS NULL", since), "album_uid"); err != nil {
+		return result, err
+	}
+
+	if result.Updated, err = pluckUIDs(albums().
+		Where("updated_at >= ? AND created_at < ? AND deleted_at IS NULL", since, since), "album_uid"); err != nil {
+		return result, err
+	}
+
+	// Albums that were created and deleted since the last sync are unknown to the client.
+	if result.Deleted, err = pluckUIDs(albums().
+		Where("deleted_at >= ? AND created_at < ?", since, since), "album_uid"); err != nil {
+		return result, err
+	}
+
+	if result.Deleted, err = addTombstones(result.Deleted, entity.TombAlbum, since, shares); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// addTombstones adds the UIDs of permanently deleted entities to the list, without duplicates.
+func addTombstones(deleted []string, tombType string, since time.Time, shares []string) ([]string, error) {
+	q := UnscopedDb().Table(entity.Tombstone{}.TableName()).
+		Where("tomb_type = ? AND created_at >= ?", tombType, since)
+
+	if shares == nil {
+		q = q.Where("album_uid = ''")
+	} else {
+		q = q.Where("album_uid IN (?)", shares)
+	}
+
+	tombstones, err := pluckUIDs(q, "tomb_uid")
+
+	if err != nil {
+		return deleted, err
+	}
+
+	result := make([]string, 0, len(deleted)+len(tombstones))
+	found := make(map[string]bool, len(deleted)+len(tombstones))
+
+	for _, uid := range append(deleted, tombstones...) {
+		if !found[uid] {
+			found[uid] = true
+			result = append(result, uid)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// pluckUIDs returns the sorted values of a UID column, or an empty slice if there are none.
+func pluckUIDs(q *gorm.DB, uidCol string) (result []string, err error) {
+	result = []string{}
+
+	err = q.Order(uidCol).Pluck(uidCol, &result).Error
+
+	return result, err
+}
@@ -0,0 +1,104 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestParseSyncToken(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		since, err := ParseSyncToken("")
+		assert.NoError(t, err)
+		assert.True(t, since.IsZero())
+	})
+	t.Run("Valid", func(t *testing.T) {
+		now := time.Now()
+		since, err := ParseSyncToken(SyncToken(now))
+		assert.NoError(t, err)
+		assert.Equal(t, now.Unix(), since.Unix())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseSyncToken("-1")
+		assert.Error(t, err)
+		_, err = ParseSyncToken("abc")
+		assert.Error(t, err)
+	})
+}
+
+func TestChangesSince(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		result, err := ChangesSince(time.Time{}, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, result.Token)
+		assert.Contains(t, result.Photos.Created, "pt9jtdre2lvl0yh7")
+		assert.NotContains(t, result.Photos.Deleted, "pt9jtdre2lvl0yh7")
+		assert.NotEmpty(t, result.Albums.Created)
+		assert.Empty(t, result.Photos.Updated)
+	})
+	t.Run("Future", func(t *testing.T) {
+		result, err := ChangesSince(time.Now().Add(time.Hour), nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result.Photos.Created)
+		assert.Empty(t, result.Photos.Updated)
+		assert.Empty(t, result.Photos.Deleted)
+		assert.Empty(t, result.Albums.Created)
+	})
+	t.Run("Shared", func(t *testing.T) {
+		result, err := ChangesSince(time.Time{}, []string{"at9lxuqxpogaaba9"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, result.Photos.Created, "pt9jtdre2lvl0yh8")
+		assert.NotContains(t, result.Photos.Created, "pt9jtdre2lvl0yh7")
+		assert.Equal(t, []string{"at9lxuqxpogaaba9"}, result.Albums.Created)
+	})
+	t.Run("NoShares", func(t *testing.T) {
+		result, err := ChangesSince(time.Time{}, []string{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result.Photos.Created)
+		assert.Empty(t, result.Albums.Created)
+	})
+	t.Run("Tombstones", func(t *testing.T) {
+		since := time.Now().Add(-time.Second)
+
+		if err := entity.AddTombstones(entity.TombPhoto, "pt9jtdrtomb00001", []string{"at9lxuqxpogaaba9"}); err != nil {
+			t.Fatal(err)
+		}
+
+		if result, err := ChangesSince(since, nil); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, []string{"pt9jtdrtomb00001"}, result.Photos.Deleted)
+		}
+
+		if result, err := ChangesSince(since, []string{"at9lxuqxpogaaba9"}); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, []string{"pt9jtdrtomb00001"}, result.Photos.Deleted)
+		}
+
+		if result, err := ChangesSince(since, []string{"at9lxuqxpogaaba8"}); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Empty(t, result.Photos.Deleted)
+		}
+	})
+}
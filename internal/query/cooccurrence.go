@@ -0,0 +1,49 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// SubjectCooccurrence represents the number of photos in which two people appear together.
+type SubjectCooccurrence struct {
+	SubjUID     string `json:"SubjUID"`
+	SubjName    string `json:"SubjName"`
+	RelatedUID  string `json:"RelatedUID"`
+	RelatedName string `json:"RelatedName"`
+	PhotoCount  int    `json:"PhotoCount"`
+}
+
+// SubjectCooccurrences returns how often people appear in the same photos, sorted by photo count.
+// If a subject UID is passed, only people who appear together with this subject are returned,
+// otherwise each pair of people is returned once.
+func SubjectCooccurrences(subjUID string, limit int) (results []SubjectCooccurrence, err error) {
+	stmt := UnscopedDb().
+		Table(entity.Marker{}.TableName()+" m1").
+		Select("m1.subj_uid, s1.subj_name, m2.subj_uid AS related_uid, s2.subj_name AS related_name, COUNT(DISTINCT f1.photo_id) AS photo_count").
+		Joins(fmt.Sprintf("JOIN %s f1 ON f1.file_uid = m1.file_uid", entity.File{}.TableName())).
+		Joins(fmt.Sprintf("JOIN %s p ON p.id = f1.photo_id AND p.deleted_at IS NULL", entity.Photo{}.TableName())).
+		Joins(fmt.Sprintf("JOIN %s f2 ON f2.photo_id = f1.photo_id", entity.File{}.TableName())).
+		Joins(fmt.Sprintf("JOIN %s m2 ON m2.file_uid = f2.file_uid AND m2.marker_invalid = 0", entity.Marker{}.TableName())).
+		Joins(fmt.Sprintf("JOIN %s s1 ON s1.subj_uid = m1.subj_uid AND s1.deleted_at IS NULL AND s1.subj_type = ?", entity.Subject{}.TableName()), entity.SubjPerson).
+		Joins(fmt.Sprintf("JOIN %s s2 ON s2.subj_uid = m2.subj_uid AND s2.deleted_at IS NULL AND s2.subj_type = ?", entity.Subject{}.TableName()), entity.SubjPerson).
+		Where("m1.marker_invalid = 0")
+
+	if subjUID != "" {
+		stmt = stmt.Where("m1.subj_uid = ? AND m2.subj_uid <> ?", subjUID, subjUID)
+	} else {
+		stmt = stmt.Where("m1.subj_uid < m2.subj_uid")
+	}
+
+	if limit > 0 {
+		stmt = stmt.Limit(limit)
+	}
+
+	err = stmt.
+		Group("m1.subj_uid, s1.subj_name, m2.subj_uid, s2.subj_name").
+		Order("photo_count DESC, s1.subj_name, s2.subj_name").
+		Scan(&results).Error
+
+	return results, err
+}
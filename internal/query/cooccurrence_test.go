@@ -0,0 +1,60 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestSubjectCooccurrences(t *testing.T) {
+	actress := entity.SubjectFixtures.Get("actress-1")
+	actor := entity.SubjectFixtures.Get("actor-1")
+
+	t.Run("All", func(t *testing.T) {
+		results, err := SubjectCooccurrences("", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+
+		for _, r := range results {
+			assert.Less(t, r.SubjUID, r.RelatedUID)
+			assert.GreaterOrEqual(t, r.PhotoCount, 1)
+		}
+	})
+	t.Run("Subject", func(t *testing.T) {
+		results, err := SubjectCooccurrences(actress.SubjUID, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+
+		for _, r := range results {
+			assert.Equal(t, actress.SubjUID, r.SubjUID)
+			assert.Equal(t, "Actress A", r.SubjName)
+
+			if r.RelatedUID == actor.SubjUID {
+				found = true
+				assert.Equal(t, "Actor A", r.RelatedName)
+				assert.GreaterOrEqual(t, r.PhotoCount, 1)
+			}
+		}
+
+		assert.True(t, found)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		results, err := SubjectCooccurrences("jqy1y111h1njxxxx", 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
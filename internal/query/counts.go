@@ -1,6 +1,10 @@
 package query
 
-import "github.com/photoprism/photoprism/internal/entity"
+import (
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
 
 type Counts struct {
 	Cameras        int `json:"cameras"`
@@ -72,3 +76,32 @@ func (c *Counts) Refresh() {
 		Where("deleted_at IS NULL").
 		Take(c)
 }
+
+// PhotoCounts returns the precalculated photo counts of the given type by key, see entity.UpdateCounts().
+func PhotoCounts(countType string) (result map[string]int, err error) {
+	return photoCounts(Db().Where("count_type = ?", countType))
+}
+
+// MemberAlbumCounts returns the precalculated photo counts of the albums the user is a member of.
+func MemberAlbumCounts(userUID string) (result map[string]int, err error) {
+	return photoCounts(Db().
+		Where("count_type = ?", entity.CountAlbum).
+		Where("count_key IN (SELECT album_uid FROM albums_members WHERE user_uid = ?)", userUID))
+}
+
+// photoCounts returns the precalculated photo counts matching the query by key.
+func photoCounts(q *gorm.DB) (result map[string]int, err error) {
+	var counts entity.PhotoCounts
+
+	if err = q.Find(&counts).Error; err != nil {
+		return result, err
+	}
+
+	result = make(map[string]int, len(counts))
+
+	for _, c := range counts {
+		result[c.CountKey] = c.PhotoCount
+	}
+
+	return result, nil
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestCounts_Refresh(t *testing.T) {
@@ -16,3 +18,49 @@ func TestCounts_Refresh(t *testing.T) {
 	assert.Greater(t, counts.Albums, 0)
 
 }
+
+func TestPhotoCounts(t *testing.T) {
+	if err := entity.UpdateCounts(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Total", func(t *testing.T) {
+		result, err := PhotoCounts(entity.CountTotal)
+		assert.NoError(t, err)
+		assert.Greater(t, result["photos"], 0)
+		assert.Equal(t, result["photos"]+result["live"]+result["videos"], result["all"])
+	})
+	t.Run("Album", func(t *testing.T) {
+		result, err := PhotoCounts(entity.CountAlbum)
+		assert.NoError(t, err)
+		assert.Greater(t, result["at9lxuqxpogaaba8"], 0)
+	})
+	t.Run("Year", func(t *testing.T) {
+		result, err := PhotoCounts(entity.CountYear)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		result, err := PhotoCounts("xxx")
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestMemberAlbumCounts(t *testing.T) {
+	if err := entity.UpdatePhotoCounts(entity.CountAlbum); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Bob", func(t *testing.T) {
+		result, err := MemberAlbumCounts("uqxc08w3d0ej2283")
+		assert.NoError(t, err)
+		assert.Contains(t, result, "at9lxuqxpogaaba8")
+		assert.NotContains(t, result, "at9lxuqxpogaaba7")
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		result, err := MemberAlbumCounts("uqxc08w3d0ej0000")
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
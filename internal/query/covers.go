@@ -12,6 +12,22 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 )
 
+// albumCoverOrder ranks the photos of an album as cover candidates by quality score,
+// the presence of people, and recency.
+const albumCoverOrder = "p.photo_quality + CASE WHEN p.photo_faces > 0 THEN 2 ELSE 0 END DESC, p.taken_at DESC, p.id DESC"
+
+// updateAlbumDefaultCovers updates the cover thumbs of all default albums matching the condition.
+func updateAlbumDefaultCovers(condition interface{}) (res *gorm.DB) {
+	return Db().Table(entity.Album{}.TableName()).
+		UpdateColumn("thumb", gorm.Expr(`(
+		SELECT f.file_hash FROM files f 
+			JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.photo_uid = f.photo_uid AND pa.hidden = 0 AND pa.missing = 0
+			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.photo_pending = 0 AND p.deleted_at IS NULL AND p.photo_quality > 0
+			WHERE f.deleted_at IS NULL AND f.file_missing = 0 AND f.file_hash <> '' AND f.file_primary = 1 AND f.file_error = '' AND f.file_type = 'jpg' 
+			ORDER BY `+albumCoverOrder+` LIMIT 1
+		) WHERE ?`, condition))
+}
+
 // UpdateAlbumDefaultCovers updates default album cover thumbs.
 func UpdateAlbumDefaultCovers() (err error) {
 	mutex.Index.Lock()
@@ -24,24 +40,8 @@ func UpdateAlbumDefaultCovers() (err error) {
 	condition := gorm.Expr("album_type = ? AND thumb_src = ?", entity.AlbumDefault, entity.SrcAuto)
 
 	switch DbDialect() {
-	case MySQL:
-		res = Db().Exec(`UPDATE albums LEFT JOIN (
-    	SELECT p2.album_uid, f.file_hash FROM files f, (
-        	SELECT pa.album_uid, max(p.id) AS photo_id FROM photos p
-            JOIN photos_albums pa ON pa.photo_uid = p.photo_uid AND pa.hidden = 0 AND pa.missing = 0
-        	WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
-        	GROUP BY pa.album_uid) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type = 'jpg'
-			) b ON b.album_uid = albums.album_uid
-		SET thumb = b.file_hash WHERE ?`, condition)
-	case SQLite3:
-		res = Db().Table(entity.Album{}.TableName()).
-			UpdateColumn("thumb", gorm.Expr(`(
-		SELECT f.file_hash FROM files f 
-			JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.photo_uid = f.photo_uid AND pa.hidden = 0 AND pa.missing = 0
-			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.deleted_at IS NULL AND p.photo_quality > 0
-			WHERE f.deleted_at IS NULL AND f.file_missing = 0 AND f.file_hash <> '' AND f.file_primary = 1 AND f.file_error = '' AND f.file_type = 'jpg' 
-			ORDER BY p.taken_at DESC LIMIT 1
-		) WHERE ?`, condition))
+	case MySQL, SQLite3:
+		res = updateAlbumDefaultCovers(condition)
 	default:
 		log.Warnf("sql: unsupported dialect %s", DbDialect())
 		return nil
@@ -60,6 +60,23 @@ func UpdateAlbumDefaultCovers() (err error) {
 	return err
 }
 
+// UpdateAlbumCover updates the cover thumb of a default album after photos have been added or removed,
+// unless the cover was selected manually.
+func UpdateAlbumCover(albumUID string) (err error) {
+	mutex.Index.Lock()
+	defer mutex.Index.Unlock()
+
+	condition := gorm.Expr("album_uid = ? AND album_type = ? AND thumb_src = ?", albumUID, entity.AlbumDefault, entity.SrcAuto)
+
+	switch DbDialect() {
+	case MySQL, SQLite3:
+		return updateAlbumDefaultCovers(condition).Error
+	default:
+		log.Warnf("sql: unsupported dialect %s", DbDialect())
+		return nil
+	}
+}
+
 // UpdateAlbumFolderCovers updates folder album cover thumbs.
 func UpdateAlbumFolderCovers() (err error) {
 	mutex.Index.Lock()
@@ -73,10 +90,10 @@ func UpdateAlbumFolderCovers() (err error) {
 
 	switch DbDialect() {
 	case MySQL:
-		res = Db().Exec(`UPDATE albums LEFT JOIN (
+		res = entity.Exec(`UPDATE albums LEFT JOIN (
 		SELECT p2.photo_path, f.file_hash FROM files f, (
 			SELECT p.photo_path, max(p.id) AS photo_id FROM photos p
-			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.photo_pending = 0 AND p.deleted_at IS NULL
 			GROUP BY p.photo_path) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type = 'jpg'
 			) b ON b.photo_path = albums.album_path
 		SET thumb = b.file_hash WHERE ?`, condition)
@@ -84,7 +101,7 @@ func UpdateAlbumFolderCovers() (err error) {
 		res = Db().Table(entity.Album{}.TableName()).UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f,(
 			SELECT p.photo_path, max(p.id) AS photo_id FROM photos p
-			  WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			  WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.photo_pending = 0 AND p.deleted_at IS NULL
 			  GROUP BY p.photo_path
 			) b
 		WHERE f.photo_id = b.photo_id  AND f.file_primary = 1 AND f.file_error = '' AND f.file_type = 'jpg'
@@ -121,10 +138,10 @@ func UpdateAlbumMonthCovers() (err error) {
 
 	switch DbDialect() {
 	case MySQL:
-		res = Db().Exec(`UPDATE albums LEFT JOIN (
+		res = entity.Exec(`UPDATE albums LEFT JOIN (
 		SELECT p2.photo_year, p2.photo_month, f.file_hash FROM files f, (
 			SELECT p.photo_year, p.photo_month, max(p.id) AS photo_id FROM photos p
-			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.photo_pending = 0 AND p.deleted_at IS NULL
 			GROUP BY p.photo_year, p.photo_month) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type = 'jpg'
 			) b ON b.photo_year = albums.album_year AND b.photo_month = albums.album_month
 		SET thumb = b.file_hash WHERE ?`, condition)
@@ -132,7 +149,7 @@ func UpdateAlbumMonthCovers() (err error) {
 		res = Db().Table(entity.Album{}.TableName()).UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f,(
 			SELECT p.photo_year, p.photo_month, max(p.id) AS photo_id FROM photos p
-			  WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			  WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.photo_pending = 0 AND p.deleted_at IS NULL
 			  GROUP BY p.photo_year, p.photo_month
 			) b
 		WHERE f.photo_id = b.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type = 'jpg'
@@ -189,17 +206,17 @@ func UpdateLabelCovers() (err error) {
 
 	switch DbDialect() {
 	case MySQL:
-		res = Db().Exec(`UPDATE labels LEFT JOIN (
+		res = entity.Exec(`UPDATE labels LEFT JOIN (
 		SELECT p2.label_id, f.file_hash FROM files f, (
 			SELECT pl.label_id as label_id, max(p.id) AS photo_id FROM photos p
 				JOIN photos_labels pl ON pl.photo_id = p.id AND pl.uncertainty < 100
-			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.photo_pending = 0 AND p.deleted_at IS NULL
 			GROUP BY pl.label_id
 			UNION
 			SELECT c.category_id as label_id, max(p.id) AS photo_id FROM photos p
 				JOIN photos_labels pl ON pl.photo_id = p.id AND pl.uncertainty < 100
 				JOIN categories c ON c.label_id = pl.label_id
-			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			WHERE p.photo_quality > 0 AND p.photo_private = 0 AND p.photo_pending = 0 AND p.deleted_at IS NULL
 			GROUP BY c.category_id
 			) p2 WHERE p2.photo_id = f.photo_id AND f.file_primary = 1 AND f.file_error = '' AND f.file_type = 'jpg' AND f.file_missing = 0
 		) b ON b.label_id = labels.id
@@ -208,7 +225,7 @@ func UpdateLabelCovers() (err error) {
 		res = Db().Table(entity.Label{}.TableName()).UpdateColumn("thumb", gorm.Expr(`(
 		SELECT f.file_hash FROM files f 
 			JOIN photos_labels pl ON pl.label_id = labels.id AND pl.photo_id = f.photo_id AND pl.uncertainty < 100
-			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.deleted_at IS NULL AND p.photo_quality > 0
+			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.photo_pending = 0 AND p.deleted_at IS NULL AND p.photo_quality > 0
 			WHERE f.deleted_at IS NULL AND f.file_hash <> '' AND f.file_missing = 0 AND f.file_primary = 1 AND f.file_error = '' AND f.file_type = 'jpg' 
 			ORDER BY p.photo_quality DESC, pl.uncertainty ASC, p.taken_at DESC LIMIT 1
 		) WHERE ?`, condition))
@@ -218,7 +235,7 @@ func UpdateLabelCovers() (err error) {
 			SELECT f.file_hash FROM files f 
 			JOIN photos_labels pl ON pl.photo_id = f.photo_id AND pl.uncertainty < 100
 			JOIN categories c ON c.label_id = pl.label_id AND c.category_id = labels.id
-			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.deleted_at IS NULL AND p.photo_quality > 0
+			JOIN photos p ON p.id = f.photo_id AND p.photo_private = 0 AND p.photo_pending = 0 AND p.deleted_at IS NULL AND p.photo_quality > 0
 			WHERE f.deleted_at IS NULL AND f.file_hash <> '' AND f.file_missing = 0 AND f.file_primary = 1 AND f.file_error = '' AND f.file_type = 'jpg' 
 			ORDER BY p.photo_quality DESC, pl.uncertainty ASC, p.taken_at DESC LIMIT 1
 			) WHERE thumb IS NULL`))
@@ -256,13 +273,13 @@ func UpdateSubjectCovers() (err error) {
 	markerTable := entity.Marker{}.TableName()
 
 	condition := gorm.Expr(
-		fmt.Sprintf("%s.subj_type = ? AND thumb_src = ?", subjTable),
-		entity.SubjPerson, entity.SrcAuto)
+		fmt.Sprintf("%s.subj_type IN (?, ?) AND thumb_src = ?", subjTable),
+		entity.SubjPerson, entity.SubjPet, entity.SrcAuto)
 
 	// TODO: Avoid using private photos as subject covers.
 	switch DbDialect() {
 	case MySQL:
-		res = Db().Exec(`UPDATE ? LEFT JOIN (
+		res = entity.Exec(`UPDATE ? LEFT JOIN (
     	SELECT m.subj_uid, m.q, MAX(m.thumb) AS marker_thumb FROM ? m
 			WHERE m.subj_uid <> '' AND m.subj_uid IS NOT NULL
 			  AND m.marker_invalid = 0 AND m.thumb IS NOT NULL AND m.thumb <> ''
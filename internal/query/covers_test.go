@@ -4,12 +4,47 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestUpdateAlbumDefaultCovers(t *testing.T) {
 	assert.NoError(t, UpdateAlbumDefaultCovers())
 }
 
+func TestUpdateAlbumCover(t *testing.T) {
+	album := entity.AlbumFixtures.Get("berlin-2019")
+
+	t.Run("Auto", func(t *testing.T) {
+		assert.NoError(t, UpdateAlbumCover(album.AlbumUID))
+
+		if result, err := AlbumByUID(album.AlbumUID); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Contains(t, []string{"pcad9168fa6acc5c5c2965ddf6ec465ca42fd818", "pcad9168fa6acc5c5c2965adf6ec465ca42fd818"}, result.Thumb)
+		}
+	})
+	t.Run("Manual", func(t *testing.T) {
+		if err := album.Updates(entity.Values{"Thumb": "manualcover", "ThumbSrc": entity.SrcManual}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, UpdateAlbumCover(album.AlbumUID))
+
+		if result, err := AlbumByUID(album.AlbumUID); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, "manualcover", result.Thumb)
+		}
+
+		if err := album.Updates(entity.Values{"ThumbSrc": entity.SrcAuto}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, UpdateAlbumCover(album.AlbumUID))
+	})
+}
+
 func TestUpdateAlbumFolderCovers(t *testing.T) {
 	assert.NoError(t, UpdateAlbumFolderCovers())
 }
@@ -0,0 +1,54 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// DigestPhoto represents a photo highlighted in the monthly summary.
+type DigestPhoto struct {
+	PhotoUID      string
+	PhotoTitle    string
+	TakenAt       time.Time
+	PhotoRating   int
+	PhotoFavorite bool
+	FileHash      string
+}
+
+// DigestPhotos returns the best-rated public photos added in the time range, starting with the highest rating.
+func DigestPhotos(since, before time.Time, limit int) (result []DigestPhoto, err error) {
+	err = Db().
+		Table(entity.Photo{}.TableName()).
+		Select("photos.photo_uid, photos.photo_title, photos.taken_at, photos.photo_rating, photos.photo_favorite, files.file_hash").
+		Joins("JOIN files ON files.photo_id = photos.id AND files.file_primary = 1 AND files.deleted_at IS NULL").
+		Where("photos.deleted_at IS NULL AND photos.photo_private = 0 AND photos.photo_quality >= 0").
+		Where("photos.created_at >= ? AND photos.created_at < ?", since, before).
+		Order("photos.photo_rating DESC, photos.photo_favorite DESC, photos.photo_quality DESC, photos.taken_at DESC").
+		Limit(limit).
+		Scan(&result).Error
+
+	return result, err
+}
+
+// DigestPhotoCount returns the number of public photos added in the time range.
+func DigestPhotoCount(since, before time.Time) (count int, err error) {
+	err = Db().
+		Table(entity.Photo{}.TableName()).
+		Where("deleted_at IS NULL AND photo_private = 0 AND photo_quality >= 0").
+		Where("created_at >= ? AND created_at < ?", since, before).
+		Count(&count).Error
+
+	return count, err
+}
+
+// DigestPeople returns the people added in the time range, sorted by name.
+func DigestPeople(since, before time.Time) (result entity.Subjects, err error) {
+	err = Db().
+		Where("subj_type = ? AND subj_hidden = 0 AND subj_name <> ''", entity.SubjPerson).
+		Where("created_at >= ? AND created_at < ?", since, before).
+		Order("subj_name").
+		Find(&result).Error
+
+	return result, err
+}
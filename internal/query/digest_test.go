@@ -0,0 +1,65 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigestPhotos(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		results, err := DigestPhotos(time.Time{}, time.Now().Add(time.Hour), 5)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(results))
+		assert.GreaterOrEqual(t, 5, len(results))
+
+		for i, r := range results {
+			assert.NotEmpty(t, r.PhotoUID)
+			assert.NotEmpty(t, r.FileHash)
+
+			if i > 0 {
+				assert.LessOrEqual(t, r.PhotoRating, results[i-1].PhotoRating)
+			}
+		}
+	})
+	t.Run("None", func(t *testing.T) {
+		results, err := DigestPhotos(time.Now().Add(time.Hour), time.Now().Add(2*time.Hour), 5)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
+
+func TestDigestPhotoCount(t *testing.T) {
+	if count, err := DigestPhotoCount(time.Time{}, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.LessOrEqual(t, 1, count)
+	}
+
+	if count, err := DigestPhotoCount(time.Now().Add(time.Hour), time.Now().Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Equal(t, 0, count)
+	}
+}
+
+func TestDigestPeople(t *testing.T) {
+	if results, err := DigestPeople(time.Time{}, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.LessOrEqual(t, 1, len(results))
+
+		for _, r := range results {
+			assert.NotEmpty(t, r.SubjName)
+		}
+	}
+}
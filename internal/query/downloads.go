@@ -0,0 +1,39 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Downloads returns the remote downloads of a user, newest first, or all downloads if the user uid is empty.
+func Downloads(userUID string, limit, offset int) (results entity.Downloads, err error) {
+	stmt := Db()
+
+	if userUID != "" {
+		stmt = stmt.Where("user_uid = ?", userUID)
+	}
+
+	err = stmt.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&results).Error
+
+	return results, err
+}
+
+// PendingDownloads returns remote files that have not been downloaded yet, oldest first.
+func PendingDownloads(limit int) (results entity.Downloads, err error) {
+	err = Db().
+		Where("download_type = ? AND download_status = ?", entity.DownloadFile, entity.DownloadPending).
+		Order("id").Limit(limit).Find(&results).Error
+
+	return results, err
+}
+
+// DueFeeds returns the RSS and Atom feeds that have not been checked within the given interval.
+func DueFeeds(interval time.Duration) (results entity.Downloads, err error) {
+	err = Db().
+		Where("download_type = ?", entity.DownloadFeed).
+		Where("checked_at IS NULL OR checked_at < ?", entity.TimeStamp().Add(-1*interval)).
+		Order("id").Find(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,60 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestDownloads(t *testing.T) {
+	t.Run("Admin", func(t *testing.T) {
+		results, err := Downloads("uqxetse3cy5eo9z2", 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 2)
+
+		for _, r := range results {
+			assert.Equal(t, "uqxetse3cy5eo9z2", r.UserUID)
+		}
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		results, err := Downloads("uqxetse3cy5eoxxx", 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
+
+func TestPendingDownloads(t *testing.T) {
+	results, err := PendingDownloads(10)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range results {
+		assert.Equal(t, entity.DownloadFile, r.DownloadType)
+		assert.Equal(t, entity.DownloadPending, r.DownloadStatus)
+	}
+}
+
+func TestDueFeeds(t *testing.T) {
+	results, err := DueFeeds(time.Hour)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range results {
+		assert.Equal(t, entity.DownloadFeed, r.DownloadType)
+	}
+}
@@ -47,6 +47,31 @@ func Files(limit, offset int, pathName string, includeMissing bool) (files entit
 	return files, err
 }
 
+// FilesWithoutCaption returns primary files of photos that do not have a generated caption yet.
+func FilesWithoutCaption(limit, offset int) (files entity.Files, err error) {
+	err = Db().
+		Table("files").Select("files.*").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Joins("LEFT JOIN details ON details.photo_id = files.photo_id").
+		Where("files.file_primary = 1 AND files.file_missing = 0 AND files.deleted_at IS NULL").
+		Where("details.caption IS NULL OR details.caption = ''").
+		Order("files.id").
+		Limit(limit).Offset(offset).
+		Find(&files).Error
+
+	return files, err
+}
+
+// BrokenFiles returns originals that could not be decoded while indexing, sorted by name.
+func BrokenFiles(limit, offset int) (files entity.BrokenFiles, err error) {
+	err = UnscopedDb().
+		Order("file_root, file_name").
+		Limit(limit).Offset(offset).
+		Find(&files).Error
+
+	return files, err
+}
+
 // FilesByUID finds files for the given UIDs.
 func FilesByUID(u []string, limit int, offset int) (files entity.Files, err error) {
 	if err := Db().Where("(photo_uid IN (?) AND file_primary = 1) OR file_uid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
@@ -65,6 +90,13 @@ func FileByPhotoUID(u string) (file entity.File, err error) {
 	return file, nil
 }
 
+// FilesByPhotoUID returns all indexed files of a photo, starting with the primary file.
+func FilesByPhotoUID(u string) (files entity.Files, err error) {
+	err = Db().Where("photo_uid = ?", u).Order("file_primary DESC, id").Find(&files).Error
+
+	return files, err
+}
+
 // VideoByPhotoUID finds a video for the given photo UID.
 func VideoByPhotoUID(u string) (file entity.File, err error) {
 	if err := Db().Where("photo_uid = ? AND file_video = 1", u).Preload("Photo").First(&file).Error; err != nil {
@@ -83,6 +115,19 @@ func FileByUID(uid string) (file entity.File, err error) {
 	return file, nil
 }
 
+// OriginalFileNames returns the names of all original files belonging to the given photo UIDs.
+func OriginalFileNames(photoUIDs []string) (fileNames []string, err error) {
+	if len(photoUIDs) == 0 {
+		return fileNames, nil
+	}
+
+	err = Db().Model(entity.File{}).
+		Where("photo_uid IN (?) AND file_root = ? AND file_missing = 0", photoUIDs, entity.RootOriginals).
+		Pluck("file_name", &fileNames).Error
+
+	return fileNames, err
+}
+
 // FileByHash finds a file with a given hash string.
 func FileByHash(fileHash string) (file entity.File, err error) {
 	if err := Db().Where("file_hash = ?", fileHash).Preload("Photo").First(&file).Error; err != nil {
@@ -92,13 +137,59 @@ func FileByHash(fileHash string) (file entity.File, err error) {
 	return file, nil
 }
 
+// FileByInode finds an existing file with the given device and inode number, see fs.FileID.
+func FileByInode(fileInode string) (file entity.File, err error) {
+	if fileInode == "" {
+		return file, fmt.Errorf("file inode required")
+	}
+
+	err = Db().Where("file_inode = ? AND file_missing = 0", fileInode).First(&file).Error
+
+	return file, err
+}
+
+// KnownFileHashes returns the hashes of existing originals, including duplicates and file links.
+func KnownFileHashes(hashes []string) (result []string, err error) {
+	if len(hashes) == 0 {
+		return result, nil
+	}
+
+	var files, duplicates []string
+
+	if err = Db().Model(&entity.File{}).
+		Where("file_hash IN (?) AND file_missing = 0", hashes).
+		Pluck("DISTINCT file_hash", &files).Error; err != nil {
+		return result, err
+	}
+
+	if err = Db().Model(&entity.Duplicate{}).
+		Where("file_hash IN (?)", hashes).
+		Pluck("DISTINCT file_hash", &duplicates).Error; err != nil {
+		return result, err
+	}
+
+	found := make(map[string]bool, len(files)+len(duplicates))
+
+	for _, h := range append(files, duplicates...) {
+		found[h] = true
+	}
+
+	for _, h := range hashes {
+		if found[h] {
+			result = append(result, h)
+		}
+	}
+
+	return result, nil
+}
+
 // RenameFile renames an indexed file.
 func RenameFile(srcRoot, srcName, destRoot, destName string) error {
 	if srcRoot == "" || srcName == "" || destRoot == "" || destName == "" {
 		return fmt.Errorf("cannot rename %s/%s to %s/%s", srcRoot, srcName, destRoot, destName)
 	}
 
-	return Db().Exec("UPDATE files SET file_root = ?, file_name = ?, file_missing = 0, deleted_at = NULL WHERE file_root = ? AND file_name = ?", destRoot, destName, srcRoot, srcName).Error
+	return entity.Exec("UPDATE files SET file_root = ?, file_name = ?, file_missing = 0, deleted_at = NULL WHERE file_root = ? AND file_name = ?", destRoot, destName, srcRoot, srcName).Error
 }
 
 // SetPhotoPrimary sets a new primary image file for a photo.
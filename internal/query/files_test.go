@@ -1,6 +1,7 @@
 package query
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -33,6 +34,38 @@ func TestFilesByPath(t *testing.T) {
 	})
 }
 
+func TestFilesWithoutCaption(t *testing.T) {
+	files, err := FilesWithoutCaption(10, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, 1, len(files))
+
+	for _, f := range files {
+		assert.True(t, f.FilePrimary)
+		assert.False(t, f.FileMissing)
+	}
+}
+
+func TestBrokenFiles(t *testing.T) {
+	if err := entity.AddBrokenFile("2022/02/corrupt.jpg", entity.RootOriginals, 512, errors.New("invalid JPEG format")); err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.PurgeBrokenFile("2022/02/corrupt.jpg", entity.RootOriginals)
+
+	files, err := BrokenFiles(10, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, files, 1)
+	assert.Equal(t, "invalid JPEG format", files[0].FileError)
+}
+
 func TestExistingFiles(t *testing.T) {
 	t.Run("files found", func(t *testing.T) {
 		files, err := Files(1000, 0, "/", true)
@@ -111,6 +144,26 @@ func TestFileByPhotoUID(t *testing.T) {
 	})
 }
 
+func TestFilesByPhotoUID(t *testing.T) {
+	t.Run("files found", func(t *testing.T) {
+		files, err := FilesByPhotoUID("pt9jtdre2lvl0yh0")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(files), 2)
+		assert.True(t, files[0].FilePrimary)
+	})
+
+	t.Run("no files found", func(t *testing.T) {
+		files, err := FilesByPhotoUID("111")
+
+		assert.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
+
 func TestVideoByPhotoUID(t *testing.T) {
 	t.Run("files found", func(t *testing.T) {
 		file, err := VideoByPhotoUID("pt9jtdre2lvl0yh0")
@@ -170,6 +223,72 @@ func TestFileByHash(t *testing.T) {
 	})
 }
 
+func TestFileByInode(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := FileByInode("1:1")
+
+		assert.Error(t, err)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := FileByInode("")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestKnownFileHashes(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		hashes, err := KnownFileHashes([]string{"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", "0000000000000000000000000000000000000000"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"}, hashes)
+	})
+	t.Run("Duplicate", func(t *testing.T) {
+		if err := entity.AddDuplicate("sync/duplicate.jpg", entity.RootOriginals, "dcad9168fa6acc5c5c2965ddf6ec465ca42fd818", 100, 1); err != nil {
+			t.Fatal(err)
+		}
+
+		hashes, err := KnownFileHashes([]string{"dcad9168fa6acc5c5c2965ddf6ec465ca42fd818"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"dcad9168fa6acc5c5c2965ddf6ec465ca42fd818"}, hashes)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		hashes, err := KnownFileHashes(nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, hashes)
+	})
+}
+
+func TestOriginalFileNames(t *testing.T) {
+	t.Run("files found", func(t *testing.T) {
+		fileNames, err := OriginalFileNames([]string{entity.PhotoFixtures.Pointer("19800101_000002_D640C559").PhotoUID})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, fileNames, "2790/07/27900704_070228_D6D51B6C.jpg")
+	})
+
+	t.Run("no uids", func(t *testing.T) {
+		fileNames, err := OriginalFileNames(nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, fileNames)
+	})
+}
+
 func TestSetPhotoPrimary(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		assert.Equal(t, false, entity.FileFixturesExampleXMP.FilePrimary)
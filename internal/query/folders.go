@@ -62,6 +62,23 @@ func AlbumFolders(threshold int) (folders entity.Folders, err error) {
 	return folders, nil
 }
 
+// FolderModTimes returns the directory modification times found during the last complete scan by path.
+func FolderModTimes(root string) (result map[string]int64, err error) {
+	var folders entity.Folders
+
+	if err := Db().Select("path, mod_time").Where("root = ? AND mod_time > 0", root).Find(&folders).Error; err != nil {
+		return result, err
+	}
+
+	result = make(map[string]int64, len(folders))
+
+	for _, f := range folders {
+		result[f.Path] = f.ModTime
+	}
+
+	return result, nil
+}
+
 // UpdateFolderDates updates folder year, month and day based on indexed photo metadata.
 func UpdateFolderDates() error {
 	mutex.Index.Lock()
@@ -69,7 +86,7 @@ func UpdateFolderDates() error {
 
 	switch DbDialect() {
 	case MySQL:
-		return UnscopedDb().Exec(`UPDATE folders
+		return entity.Exec(`UPDATE folders
 		INNER JOIN
 			(SELECT photo_path, MAX(taken_at_local) AS taken_max
 			FROM photos WHERE taken_src = 'meta' AND photos.photo_quality >= 3 AND photos.deleted_at IS NULL
@@ -74,3 +74,33 @@ func TestUpdateFolderDates(t *testing.T) {
 		}
 	})
 }
+
+func TestFolderModTimes(t *testing.T) {
+	t.Run("Originals", func(t *testing.T) {
+		if err := entity.UpdateFolderModTime(entity.RootOriginals, "1990/04", 1608926400); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := FolderModTimes(entity.RootOriginals)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(1608926400), result["1990/04"])
+		assert.NotContains(t, result, "2007/12")
+
+		if err := entity.UpdateFolderModTime(entity.RootOriginals, "1990/04", 0); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Import", func(t *testing.T) {
+		result, err := FolderModTimes(entity.RootImport)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
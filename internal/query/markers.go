@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/face"
 )
@@ -64,6 +65,57 @@ func UnmatchedFaceMarkers(limit, offset int, matchedBefore *time.Time) (result e
 	return result, err
 }
 
+// UnassignedFaceMarkers finds valid face markers with embeddings that are not assigned to a subject.
+func UnassignedFaceMarkers(limit, offset int) (result entity.Markers, err error) {
+	err = Db().
+		Where("marker_type = ?", entity.MarkerFace).
+		Where("marker_invalid = 0").
+		Where("embeddings_json <> ''").
+		Where("subj_uid = '' OR subj_uid IS NULL").
+		Order("marker_uid").Limit(limit).Offset(offset).
+		Find(&result).Error
+
+	return result, err
+}
+
+// UnclusteredFaceMarkers finds valid face markers with embeddings that are not assigned to a face cluster.
+func UnclusteredFaceMarkers(limit, offset, size, score int) (result entity.Markers, err error) {
+	stmt := Db().
+		Where("marker_type = ?", entity.MarkerFace).
+		Where("marker_invalid = 0").
+		Where("embeddings_json <> ''").
+		Where("face_id = ''")
+
+	if size > 0 {
+		stmt = stmt.Where("size >= ?", size)
+	}
+
+	if score > 0 {
+		stmt = stmt.Where("score >= ?", score)
+	}
+
+	err = stmt.Order("marker_uid").Limit(limit).Offset(offset).Find(&result).Error
+
+	return result, err
+}
+
+// ReviewFaceMarkers returns unassigned face markers for review, markers in larger and closer clusters first.
+func ReviewFaceMarkers(limit int) (result entity.Markers, err error) {
+	err = Db().
+		Table(entity.Marker{}.TableName()).Select("markers.*").
+		Joins("LEFT JOIN faces ON faces.id = markers.face_id").
+		Where("markers.marker_type = ?", entity.MarkerFace).
+		Where("markers.marker_invalid = 0").
+		Where("markers.embeddings_json <> ''").
+		Where("markers.subj_uid = '' OR markers.subj_uid IS NULL").
+		Where("faces.id IS NULL OR faces.face_hidden = ?", false).
+		Order("faces.samples DESC, markers.face_dist, markers.score DESC, markers.marker_uid").
+		Limit(limit).
+		Find(&result).Error
+
+	return result, err
+}
+
 // FaceMarkers returns all face markers sorted by id.
 func FaceMarkers(limit, offset int) (result entity.Markers, err error) {
 	err = Db().
@@ -116,6 +168,41 @@ func Embeddings(single, unclustered bool, size, score int) (result face.Embeddin
 	return result, nil
 }
 
+// FaceEmbeddings returns the embeddings of valid markers assigned to a face cluster, which have either been
+// confirmed by a user or are not farther from the cluster midpoint than the given distance.
+func FaceEmbeddings(faceId string, size, score int, dist float64) (result face.Embeddings, err error) {
+	var col []string
+
+	stmt := Db().
+		Model(&entity.Marker{}).
+		Where("marker_type = ?", entity.MarkerFace).
+		Where("marker_invalid = 0").
+		Where("embeddings_json <> ''").
+		Where("face_id = ?", faceId).
+		Where("subj_src = ? OR marker_review = 0 AND face_dist >= 0 AND face_dist <= ?", entity.SrcManual, dist).
+		Order("marker_uid")
+
+	if size > 0 {
+		stmt = stmt.Where("size >= ?", size)
+	}
+
+	if score > 0 {
+		stmt = stmt.Where("score >= ?", score)
+	}
+
+	if err := stmt.Pluck("embeddings_json", &col).Error; err != nil {
+		return result, err
+	}
+
+	for _, embeddingsJson := range col {
+		if embeddings := face.UnmarshalEmbeddings(embeddingsJson); !embeddings.Empty() {
+			result = append(result, embeddings...)
+		}
+	}
+
+	return result, nil
+}
+
 // RemoveInvalidMarkerReferences removes face and subject references from invalid markers.
 func RemoveInvalidMarkerReferences() (removed int64, err error) {
 	res := Db().
@@ -251,3 +338,33 @@ func RemoveOrphanMarkers() (removed int64, err error) {
 
 	return removed, nil
 }
+
+// PetMarker represents a pet marker with the image embedding of its photo.
+type PetMarker struct {
+	MarkerUID string
+	SubjUID   string
+	Embedding []byte
+}
+
+// Vector returns the decoded image embedding of the photo.
+func (m PetMarker) Vector() classify.Embedding {
+	return classify.EmbeddingFromBytes(m.Embedding)
+}
+
+// PetMarkers returns pet markers of other photos that share at least one embedding hash bucket.
+func PetMarkers(buckets []uint32, photoID uint, limit int) (result []PetMarker, err error) {
+	if len(buckets) == 0 {
+		return result, nil
+	}
+
+	err = UnscopedDb().Table(entity.Marker{}.TableName()+" m").
+		Select("m.marker_uid, m.subj_uid, e.embedding").
+		Joins("JOIN files f ON f.file_uid = m.file_uid AND f.deleted_at IS NULL").
+		Joins("JOIN photos_embeddings e ON e.photo_id = f.photo_id").
+		Where("m.marker_type = ? AND m.marker_invalid = 0 AND f.photo_id <> ?", entity.MarkerPet, photoID).
+		Where("f.photo_id IN (SELECT photo_id FROM photos_embeddings_buckets WHERE bucket IN (?))", buckets).
+		Limit(limit).
+		Scan(&result).Error
+
+	return result, err
+}
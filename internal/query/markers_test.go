@@ -97,6 +97,70 @@ func TestUnmatchedFaceMarkers(t *testing.T) {
 	})
 }
 
+func TestUnassignedFaceMarkers(t *testing.T) {
+	results, err := UnassignedFaceMarkers(100, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, results)
+
+	for _, m := range results {
+		assert.Equal(t, "", m.SubjUID)
+		assert.False(t, m.MarkerInvalid)
+		assert.NotEmpty(t, m.EmbeddingsJSON)
+	}
+}
+
+func TestUnclusteredFaceMarkers(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		results, err := UnclusteredFaceMarkers(100, 0, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+
+		for _, m := range results {
+			assert.Equal(t, "", m.FaceID)
+			assert.False(t, m.MarkerInvalid)
+			assert.NotEmpty(t, m.EmbeddingsJSON)
+		}
+	})
+	t.Run("size and score", func(t *testing.T) {
+		results, err := UnclusteredFaceMarkers(100, 0, 200, 100)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, m := range results {
+			assert.Equal(t, "", m.FaceID)
+			assert.GreaterOrEqual(t, m.Size, 200)
+			assert.GreaterOrEqual(t, m.Score, 100)
+		}
+	})
+}
+
+func TestReviewFaceMarkers(t *testing.T) {
+	results, err := ReviewFaceMarkers(3)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, results)
+	assert.LessOrEqual(t, len(results), 3)
+
+	for _, m := range results {
+		assert.Equal(t, entity.MarkerFace, m.MarkerType)
+		assert.Equal(t, "", m.SubjUID)
+		assert.False(t, m.MarkerInvalid)
+	}
+}
+
 func TestFaceMarkers(t *testing.T) {
 	t.Run("all", func(t *testing.T) {
 		results, err := FaceMarkers(3, 0)
@@ -151,6 +215,48 @@ func TestEmbeddings(t *testing.T) {
 	})
 }
 
+func TestFaceEmbeddings(t *testing.T) {
+	t.Run("actress", func(t *testing.T) {
+		results, err := FaceEmbeddings(entity.FaceFixtures.Get("actress-1").ID, 0, 0, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 1)
+
+		for _, val := range results {
+			assert.IsType(t, face.Embedding{}, val)
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		results, err := FaceEmbeddings("XXX", 0, 0, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+	t.Run("confirmed", func(t *testing.T) {
+		all, err := FaceEmbeddings(entity.FaceFixtures.Get("actress-1").ID, 0, 0, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		confirmed, err := FaceEmbeddings(entity.FaceFixtures.Get("actress-1").ID, 0, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Only the marker that was named manually is used.
+		assert.Len(t, confirmed, 1)
+		assert.Greater(t, len(all), len(confirmed))
+	})
+}
+
 func TestRemoveInvalidMarkerReferences(t *testing.T) {
 	affected, err := RemoveInvalidMarkerReferences()
 
@@ -207,3 +313,17 @@ func TestCountMarkers(t *testing.T) {
 
 	assert.GreaterOrEqual(t, n, 1)
 }
+
+func TestPetMarkers(t *testing.T) {
+	t.Run("NoBuckets", func(t *testing.T) {
+		results, err := PetMarkers(nil, 0, 10)
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+	t.Run("Buckets", func(t *testing.T) {
+		_, err := PetMarkers([]uint32{1, 2, 3}, 1000000, 10)
+
+		assert.NoError(t, err)
+	})
+}
@@ -267,14 +267,22 @@ func MomentsLabels(threshold int) (results Moments, err error) {
 
 // RemoveDuplicateMoments deletes generated albums with duplicate slug or filter.
 func RemoveDuplicateMoments() (removed int, err error) {
-	if res := UnscopedDb().Exec(`DELETE FROM links WHERE share_uid 
+	if res := entity.Exec(`DELETE FROM links WHERE share_uid 
 		IN (SELECT a.album_uid FROM albums a JOIN albums b ON a.album_type = b.album_type 
 		AND a.album_type <> ? AND a.id > b.id WHERE (a.album_slug = b.album_slug 
 		OR a.album_filter = b.album_filter) GROUP BY a.album_uid)`, entity.AlbumDefault); res.Error != nil {
 		return removed, res.Error
 	}
 
-	if res := UnscopedDb().Exec(`DELETE FROM albums WHERE id 
+	if res := entity.Exec(`INSERT INTO tombstones (tomb_type, tomb_uid, album_uid, created_at) 
+		SELECT ?, album_uid, album_uid, ? FROM albums WHERE id 
+		IN (SELECT a.id FROM albums a JOIN albums b ON a.album_type = b.album_type 
+		AND a.album_type <> ? AND a.id > b.id WHERE (a.album_slug = b.album_slug 
+		OR a.album_filter = b.album_filter) GROUP BY a.album_uid)`, entity.TombAlbum, entity.TimeStamp(), entity.AlbumDefault); res.Error != nil {
+		return removed, res.Error
+	}
+
+	if res := entity.Exec(`DELETE FROM albums WHERE id 
 		IN (SELECT a.id FROM albums a JOIN albums b ON a.album_type = b.album_type 
 		AND a.album_type <> ? AND a.id > b.id WHERE (a.album_slug = b.album_slug 
 		OR a.album_filter = b.album_filter) GROUP BY a.album_uid)`, entity.AlbumDefault); res.Error != nil {
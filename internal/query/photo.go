@@ -1,6 +1,7 @@
 package query
 
 import (
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
@@ -30,6 +31,16 @@ func PhotoByID(photoID uint64) (photo entity.Photo, err error) {
 	return photo, nil
 }
 
+// UnlockedPhotoUIDs returns the UIDs of the selected photos that are not locked.
+func UnlockedPhotoUIDs(uids []string) (result []string, err error) {
+	if len(uids) == 0 {
+		return result, nil
+	}
+
+	err = UnscopedDb().Model(&entity.Photo{}).Where("photo_uid IN (?) AND photo_locked = 0", uids).Pluck("photo_uid", &result).Error
+	return result, err
+}
+
 // PhotoByUID returns a Photo based on the UID.
 func PhotoByUID(photoUID string) (photo entity.Photo, err error) {
 	if err := UnscopedDb().Where("photo_uid = ?", photoUID).
@@ -160,6 +171,23 @@ func FixPrimaries() error {
 	return nil
 }
 
+// PhotosWithoutPrimary returns the uids of photos that have no existing primary file although
+// they have a valid jpeg file, optionally limited to an originals folder.
+func PhotosWithoutPrimary(pathName string) (photoUIDs []string, err error) {
+	stmt := UnscopedDb().Table(entity.Photo{}.TableName()).
+		Where("deleted_at IS NULL").
+		Where("id IN (SELECT photo_id FROM files WHERE file_type = 'jpg' AND file_missing = 0 AND file_error = '' AND deleted_at IS NULL)").
+		Where("id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND file_missing = 0 AND file_error = '' AND deleted_at IS NULL)")
+
+	if pathName = strings.Trim(pathName, "/"); pathName != "" {
+		stmt = stmt.Where("photo_path = ? OR photo_path LIKE ?", pathName, pathName+"/%")
+	}
+
+	err = stmt.Order("id").Pluck("photo_uid", &photoUIDs).Error
+
+	return photoUIDs, err
+}
+
 // FlagHiddenPhotos sets the quality score of photos without valid primary file to -1.
 func FlagHiddenPhotos() error {
 	mutex.Index.Lock()
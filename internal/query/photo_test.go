@@ -104,3 +104,39 @@ func TestFlagHiddenPhotos(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestPhotosWithoutPrimary(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		result, err := PhotosWithoutPrimary("")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, []string{}, result)
+	})
+	t.Run("Path", func(t *testing.T) {
+		result, err := PhotosWithoutPrimary("/foo/bar/")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
+
+func TestUnlockedPhotoUIDs(t *testing.T) {
+	result, err := UnlockedPhotoUIDs([]string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8", "xxx"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.ElementsMatch(t, []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}, result)
+
+	result, err = UnlockedPhotoUIDs(nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
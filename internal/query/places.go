@@ -33,5 +33,5 @@ func PurgePlaces() error {
 	query := "DELETE FROM places WHERE id NOT IN (SELECT DISTINCT place_id FROM cells)" +
 		" AND id NOT IN (SELECT DISTINCT place_id FROM photos)"
 
-	return Db().Exec(query).Error
+	return entity.Exec(query).Error
 }
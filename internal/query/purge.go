@@ -82,7 +82,7 @@ func PurgeOrphanCountries() error {
 	entity.FlushCountryCache()
 	switch DbDialect() {
 	default:
-		return UnscopedDb().Exec(`DELETE FROM countries WHERE country_slug <> ? AND id NOT IN (SELECT photo_country FROM photos)`, entity.UnknownCountry.CountrySlug).Error
+		return entity.Exec(`DELETE FROM countries WHERE country_slug <> ? AND id NOT IN (SELECT photo_country FROM photos)`, entity.UnknownCountry.CountrySlug).Error
 	}
 }
 
@@ -94,7 +94,7 @@ func PurgeOrphanCameras() error {
 	entity.FlushCameraCache()
 	switch DbDialect() {
 	default:
-		return UnscopedDb().Exec(`DELETE FROM cameras WHERE camera_slug <> ? AND id NOT IN (SELECT camera_id FROM photos)`, entity.UnknownCamera.CameraSlug).Error
+		return entity.Exec(`DELETE FROM cameras WHERE camera_slug <> ? AND id NOT IN (SELECT camera_id FROM photos)`, entity.UnknownCamera.CameraSlug).Error
 	}
 }
 
@@ -106,6 +106,6 @@ func PurgeOrphanLenses() error {
 	entity.FlushLensCache()
 	switch DbDialect() {
 	default:
-		return UnscopedDb().Exec(`DELETE FROM lenses WHERE lens_slug <> ? AND id NOT IN (SELECT lens_id FROM photos)`, entity.UnknownLens.LensSlug).Error
+		return entity.Exec(`DELETE FROM lenses WHERE lens_slug <> ? AND id NOT IN (SELECT lens_id FROM photos)`, entity.UnknownLens.LensSlug).Error
 	}
 }
@@ -0,0 +1,16 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// PushSubscriptions returns the push subscriptions of the given users.
+func PushSubscriptions(userUIDs ...string) (results entity.PushSubscriptions, err error) {
+	if len(userUIDs) == 0 {
+		return results, nil
+	}
+
+	err = Db().Where("user_uid IN (?)", userUIDs).Order("user_uid, id").Find(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,26 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushSubscriptions(t *testing.T) {
+	t.Run("Bob", func(t *testing.T) {
+		results, err := PushSubscriptions("uqxc08w3d0ej2283", "uqxetse3cy5eo9z3")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, "https://push.example.com/send/bob-phone", results[0].Endpoint)
+	})
+	t.Run("NoUsers", func(t *testing.T) {
+		results, err := PushSubscriptions()
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
@@ -0,0 +1,19 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Searches returns the saved searches of a user sorted by name.
+func Searches(userUID string) (results entity.Searches, err error) {
+	err = Db().Where("user_uid = ?", userUID).Order("search_name, search_uid").Find(&results).Error
+
+	return results, err
+}
+
+// NotifySearches returns the saved searches for which notifications about new matches are enabled.
+func NotifySearches() (results entity.Searches, err error) {
+	err = Db().Where("search_notify = 1").Order("user_uid, search_uid").Find(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,46 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearches(t *testing.T) {
+	t.Run("Admin", func(t *testing.T) {
+		results, err := Searches("uqxetse3cy5eo9z2")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 1)
+
+		for _, r := range results {
+			assert.Equal(t, "uqxetse3cy5eo9z2", r.UserUID)
+		}
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		results, err := Searches("uqxetse3cy5eoxxx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
+
+func TestNotifySearches(t *testing.T) {
+	results, err := NotifySearches()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(results), 1)
+
+	for _, r := range results {
+		assert.True(t, r.SearchNotify)
+	}
+}
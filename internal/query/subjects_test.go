@@ -26,6 +26,15 @@ func TestPeopleCount(t *testing.T) {
 	}
 }
 
+func TestPetsCount(t *testing.T) {
+	if result, err := PetsCount(); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.LessOrEqual(t, 0, result)
+		t.Logf("there are %d pets", result)
+	}
+}
+
 func TestSubjects(t *testing.T) {
 	results, err := Subjects(3, 0)
 
@@ -0,0 +1,30 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// TagResult represents a personal tag with the number of photos it has been added to.
+type TagResult struct {
+	TagUID     string `json:"UID"`
+	TagSlug    string `json:"Slug"`
+	TagName    string `json:"Name"`
+	PhotoCount int    `json:"PhotoCount"`
+}
+
+// TagResults represents a list of personal tags.
+type TagResults []TagResult
+
+// Tags returns all personal tags sorted by name, including the number of photos that are not deleted.
+func Tags() (results TagResults, err error) {
+	err = Db().
+		Table(entity.Tag{}.TableName()).
+		Select("tags.tag_uid, tags.tag_slug, tags.tag_name, COUNT(photos.id) AS photo_count").
+		Joins("LEFT JOIN photos_tags ON photos_tags.tag_uid = tags.tag_uid").
+		Joins("LEFT JOIN photos ON photos.photo_uid = photos_tags.photo_uid AND photos.deleted_at IS NULL").
+		Group("tags.tag_uid, tags.tag_slug, tags.tag_name").
+		Order("tags.tag_name").
+		Scan(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,29 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestTags(t *testing.T) {
+	results, err := Tags()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(results), 3)
+
+	counts := make(map[string]int)
+
+	for _, r := range results {
+		counts[r.TagUID] = r.PhotoCount
+	}
+
+	assert.Equal(t, 2, counts[entity.TagFixtures.Get("to-print").TagUID])
+	assert.Equal(t, 1, counts[entity.TagFixtures.Get("for-blog").TagUID])
+	assert.Equal(t, 0, counts[entity.TagFixtures.Get("needs-edit").TagUID])
+}
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// TimelapseCandidates returns up to limit images taken in the given time window with a capture time from
+// metadata, ordered by camera and time, so that photos taken at fixed intervals can be detected as time-lapse
+// sequences. Private and archived photos are excluded.
+func TimelapseCandidates(after, before time.Time, limit int) (photos entity.Photos, err error) {
+	err = Db().
+		Select("id, photo_uid, taken_at, camera_id, camera_serial").
+		Where("deleted_at IS NULL AND photo_private = 0 AND photo_type = ? AND taken_src = ?", entity.TypeImage, entity.SrcMeta).
+		Where("taken_at >= ? AND taken_at < ?", after.UTC(), before.UTC()).
+		Where(search.YearAfter(after)).
+		Where(search.YearBefore(before)).
+		Order("camera_id, camera_serial, taken_at, photo_uid").
+		Limit(limit).
+		Find(&photos).Error
+
+	return photos, err
+}
+
+// TimelapseFiles returns the primary files of the given photos ordered by capture time.
+func TimelapseFiles(photoUIDs []string) (files entity.Files, err error) {
+	if len(photoUIDs) == 0 {
+		return files, nil
+	}
+
+	err = Db().
+		Table(entity.File{}.TableName()).
+		Select("files.*").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL AND photos.photo_private = 0").
+		Where("files.photo_uid IN (?) AND files.file_primary = 1 AND files.file_missing = 0", photoUIDs).
+		Order("photos.taken_at, files.photo_uid").
+		Find(&files).Error
+
+	return files, err
+}
+
+// TimelapsePhotoUIDs returns the UIDs of the photos a time-lapse video was assembled from in frame order.
+func TimelapsePhotoUIDs(videoUID string) (photoUIDs []string, err error) {
+	err = Db().Model(entity.PhotoTimelapse{}).
+		Where("video_uid = ?", videoUID).
+		Order("frame").
+		Pluck("photo_uid", &photoUIDs).Error
+
+	return photoUIDs, err
+}
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestTimelapseCandidates(t *testing.T) {
+	after := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	results, err := TimelapseCandidates(after, before, 1000)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, p := range results {
+		assert.NotEmpty(t, p.PhotoUID)
+		assert.False(t, p.TakenAt.Before(after))
+		assert.True(t, p.TakenAt.Before(before))
+
+		if i > 0 && p.CameraID == results[i-1].CameraID && p.CameraSerial == results[i-1].CameraSerial {
+			assert.False(t, p.TakenAt.Before(results[i-1].TakenAt))
+		}
+	}
+
+	t.Run("Limit", func(t *testing.T) {
+		limited, err := TimelapseCandidates(after, before, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, len(limited), 1)
+	})
+}
+
+func TestTimelapseFiles(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		first, second := entity.PhotoFixtures.Get("Photo04"), entity.PhotoFixtures.Get("Photo17")
+
+		if second.TakenAt.Before(first.TakenAt) {
+			first, second = second, first
+		}
+
+		files, err := TimelapseFiles([]string{second.PhotoUID, first.PhotoUID})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, files, 2) {
+			assert.Equal(t, first.PhotoUID, files[0].PhotoUID)
+			assert.Equal(t, second.PhotoUID, files[1].PhotoUID)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		files, err := TimelapseFiles(nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, files)
+	})
+}
+
+func TestTimelapsePhotoUIDs(t *testing.T) {
+	videoUID := "pt9jtdre2lvl0y90"
+
+	if _, err := entity.AddPhotoTimelapse(videoUID, []string{"pt9jtdre2lvl0yh8", "pt9jtdre2lvl0yh7"}); err != nil {
+		t.Fatal(err)
+	}
+
+	photoUIDs, err := TimelapsePhotoUIDs(videoUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"pt9jtdre2lvl0yh8", "pt9jtdre2lvl0yh7"}, photoUIDs)
+
+	// Existing links of the video are replaced.
+	if _, err := entity.AddPhotoTimelapse(videoUID, []string{"pt9jtdre2lvl0yh7"}); err != nil {
+		t.Fatal(err)
+	}
+
+	photoUIDs, err = TimelapsePhotoUIDs(videoUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"pt9jtdre2lvl0yh7"}, photoUIDs)
+}
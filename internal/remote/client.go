@@ -0,0 +1,56 @@
+package remote
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/remote/ftp"
+	"github.com/photoprism/photoprism/internal/remote/sftp"
+	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Client represents a connection to a remote service that files can be downloaded from.
+type Client interface {
+	Files(dir string) (fs.FileInfos, error)
+	Directories(root string, recursive bool, timeout time.Duration) (fs.FileInfos, error)
+	Download(from, to string, force bool) error
+	Close() error
+}
+
+// NewClient connects to a remote service, only WebDAV, SFTP, and FTP are supported at the moment.
+func NewClient(serviceType, serviceUrl, user, pass string) (Client, error) {
+	switch serviceType {
+	case ServiceWebDAV:
+		return webdav.New(serviceUrl, user, pass), nil
+	case ServiceSFTP:
+		if c, err := sftp.New(serviceUrl, user, pass); err != nil {
+			return nil, err
+		} else {
+			return c, nil
+		}
+	case ServiceFTP:
+		if c, err := ftp.New(serviceUrl, user, pass); err != nil {
+			return nil, err
+		} else {
+			return c, nil
+		}
+	default:
+		return nil, fmt.Errorf("remote: %s not supported", serviceType)
+	}
+}
+
+// CanSync tests if files can be downloaded from accounts of the service type.
+func CanSync(serviceType string) bool {
+	switch serviceType {
+	case ServiceWebDAV, ServiceSFTP, ServiceFTP:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanUpload tests if files can be uploaded to accounts of the service type.
+func CanUpload(serviceType string) bool {
+	return serviceType == ServiceWebDAV
+}
@@ -0,0 +1,49 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Run("webdav", func(t *testing.T) {
+		c, err := NewClient(ServiceWebDAV, "http://dummy-webdav/", "admin", "photoprism")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, c)
+		assert.NoError(t, c.Close())
+	})
+	t.Run("sftp", func(t *testing.T) {
+		c, err := NewClient(ServiceSFTP, "sftp://127.0.0.1:1/", "admin", "photoprism")
+
+		assert.Error(t, err)
+		assert.Nil(t, c)
+	})
+	t.Run("ftp", func(t *testing.T) {
+		c, err := NewClient(ServiceFTP, "ftp://127.0.0.1:1/", "admin", "photoprism")
+
+		assert.Error(t, err)
+		assert.Nil(t, c)
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		c, err := NewClient(ServiceFacebook, "https://www.facebook.com/", "", "")
+
+		assert.Error(t, err)
+		assert.Nil(t, c)
+	})
+}
+
+func TestCanSync(t *testing.T) {
+	assert.True(t, CanSync(ServiceWebDAV))
+	assert.True(t, CanSync(ServiceSFTP))
+	assert.True(t, CanSync(ServiceFTP))
+	assert.False(t, CanSync(ServiceFacebook))
+	assert.False(t, CanSync(""))
+}
+
+func TestCanUpload(t *testing.T) {
+	assert.True(t, CanUpload(ServiceWebDAV))
+	assert.False(t, CanUpload(ServiceSFTP))
+	assert.False(t, CanUpload(ServiceFTP))
+}
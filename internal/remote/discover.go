@@ -2,6 +2,7 @@ package remote
 
 import (
 	"errors"
+	"net"
 	"net/url"
 	"strings"
 
@@ -51,6 +52,12 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 		u.Scheme = "https"
 	}
 
+	// File transfer services are identified by their URL scheme.
+	switch u.Scheme {
+	case "sftp", "ftp", "ftps":
+		return discoverFileTransfer(u, result)
+	}
+
 	for _, h := range Heuristics {
 		if !h.MatchDomain(u.Host) {
 			continue
@@ -74,3 +81,39 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 
 	return result, errors.New("could not connect")
 }
+
+// discoverFileTransfer tests if an SFTP or FTP server is reachable and returns the account information.
+func discoverFileTransfer(u *url.URL, result Account) (Account, error) {
+	result.AccType = ServiceFTP
+
+	port := "21"
+
+	if u.Scheme == "sftp" {
+		result.AccType = ServiceSFTP
+		port = "22"
+	}
+
+	if u.Port() != "" {
+		port = u.Port()
+	}
+
+	if !TcpOk(net.JoinHostPort(u.Hostname(), port)) {
+		return result, errors.New("could not connect")
+	}
+
+	u.User = nil
+
+	if u.Path == "" {
+		u.Path = "/"
+	}
+
+	if w := txt.Keywords(u.Hostname()); len(w) > 0 {
+		result.AccName = strings.Title(w[0])
+	} else {
+		result.AccName = u.Hostname()
+	}
+
+	result.AccURL = u.String()
+
+	return result, nil
+}
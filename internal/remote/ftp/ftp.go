@@ -0,0 +1,280 @@
+/*
+
+Package ftp implements downloading files from FTP and FTPS servers.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.app/developer-guide/
+
+*/
+package ftp
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/jlaffaye/ftp"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+var log = event.Log
+
+const SyncTimeout = time.Second * 45
+const AsyncTimeout = time.Minute * 20
+const DialTimeout = time.Second * 30
+const DefaultPort = "21"
+
+type Client struct {
+	mu   sync.Mutex
+	conn *ftp.ServerConn
+}
+
+// fileInfo implements os.FileInfo for remote files.
+type fileInfo struct {
+	entry *ftp.Entry
+}
+
+func (f fileInfo) Name() string       { return f.entry.Name }
+func (f fileInfo) Size() int64        { return int64(f.entry.Size) }
+func (f fileInfo) ModTime() time.Time { return f.entry.Time }
+func (f fileInfo) IsDir() bool        { return f.entry.Type == ftp.EntryTypeFolder }
+func (f fileInfo) Sys() interface{}   { return f.entry }
+
+func (f fileInfo) Mode() os.FileMode {
+	switch f.entry.Type {
+	case ftp.EntryTypeFolder:
+		return os.ModeDir | 0755
+	case ftp.EntryTypeLink:
+		return os.ModeSymlink | 0777
+	default:
+		return 0644
+	}
+}
+
+// New connects to an FTP server, e.g. "ftp://example.com/". The connection is upgraded with
+// explicit TLS if the URL scheme is "ftps".
+func New(rawUrl, user, pass string) (*Client, error) {
+	u, err := url.Parse(rawUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	host := u.Hostname()
+	addr := u.Host
+
+	if u.Port() == "" {
+		addr = net.JoinHostPort(host, DefaultPort)
+	}
+
+	if user == "" {
+		user = "anonymous"
+	}
+
+	options := []ftp.DialOption{ftp.DialWithTimeout(DialTimeout)}
+
+	if u.Scheme == "ftps" {
+		// Data connections must resume the control connection session with many servers.
+		options = append(options, ftp.DialWithExplicitTLS(&tls.Config{ServerName: host, ClientSessionCache: tls.NewLRUClientSessionCache(0)}))
+	}
+
+	conn, err := ftp.Dial(addr, options...)
+
+	if err != nil {
+		return nil, fmt.Errorf("ftp: %s", err)
+	}
+
+	if err = conn.Login(user, pass); err != nil {
+		_ = conn.Quit()
+		return nil, fmt.Errorf("ftp: %s (login)", err)
+	}
+
+	return &Client{conn: conn}, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.conn.Quit()
+}
+
+// readDir returns the directory contents without "." and "..".
+func (c *Client) readDir(dir string) (result []os.FileInfo, err error) {
+	if dir == "" {
+		dir = "/"
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.conn.List(dir)
+
+	if err != nil {
+		return result, fmt.Errorf("ftp: %s (list %s)", err, dir)
+	}
+
+	for _, entry := range entries {
+		if entry.Name == "" || entry.Name == "." || entry.Name == ".." {
+			continue
+		}
+
+		result = append(result, fileInfo{entry: entry})
+	}
+
+	return result, nil
+}
+// Files returns all files in a directory.
+func (c *Client) Files(dir string) (result fs.FileInfos, err error) {
+	files, err := c.readDir(dir)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, file := range files {
+		if !file.Mode().IsRegular() {
+			continue
+		} else if fs.FileNameJunk(file.Name(), fs.JunkPatterns) {
+			log.Debugf("ftp: skipped junk file %s", sanitize.Log(file.Name()))
+			continue
+		}
+
+		result = append(result, fs.NewFileInfo(file, dir))
+	}
+
+	return result, nil
+}
+
+// Directories returns all sub directories in path.
+func (c *Client) Directories(root string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	start := time.Now()
+
+	result, err = c.fetchDirs(root, recursive, start, timeout)
+
+	if time.Now().Sub(start) >= timeout {
+		log.Warnf("ftp: read dir timeout reached")
+	}
+
+	return result, err
+}
+
+// fetchDirs recursively fetches all directories until the timeout is reached.
+func (c *Client) fetchDirs(root string, recursive bool, start time.Time, timeout time.Duration) (result fs.FileInfos, err error) {
+	files, err := c.readDir(root)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, file := range files {
+		if !file.Mode().IsDir() {
+			continue
+		} else if fs.FileNameJunk(file.Name(), fs.JunkPatterns) {
+			log.Debugf("ftp: skipped junk folder %s", sanitize.Log(file.Name()))
+			continue
+		}
+
+		info := fs.NewFileInfo(file, root)
+
+		result = append(result, info)
+
+		if recursive && time.Now().Sub(start) < timeout {
+			subDirs, err := c.fetchDirs(info.Abs, true, start, timeout)
+
+			if err != nil {
+				return result, err
+			}
+
+			result = append(result, subDirs...)
+		}
+	}
+
+	return result, nil
+}
+
+// Download downloads a single file to the given location.
+func (c *Client) Download(from, to string, force bool) (err error) {
+	if _, err := os.Stat(to); err == nil && !force {
+		return fmt.Errorf("ftp: download skipped, %s already exists", to)
+	}
+
+	dir := path.Dir(to)
+	dirInfo, err := os.Stat(dir)
+
+	if err != nil {
+		// Create directory
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return fmt.Errorf("ftp: cannot create %s (%s)", dir, err)
+		}
+	} else if !dirInfo.IsDir() {
+		return fmt.Errorf("ftp: %s is not a folder", dir)
+	}
+
+	f, err := os.OpenFile(to, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	if err = c.retr(from, f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(to)
+		return fmt.Errorf("ftp: %s (download %s)", err, from)
+	}
+
+	return f.Close()
+}
+
+// retr copies the contents of a remote file to w.
+func (c *Client) retr(from string, w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, err := c.conn.Retr(from)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, r)
+
+	if closeErr := r.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
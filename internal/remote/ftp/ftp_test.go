@@ -0,0 +1,230 @@
+package ftp
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testServer starts an FTP server on localhost that serves the listings and files provided.
+func testServer(t *testing.T, mlsd bool, listings, files map[string]string) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		conn, err := l.Accept()
+
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(format string, args ...interface{}) {
+			_, _ = fmt.Fprintf(conn, format+"\r\n", args...)
+		}
+
+		var data net.Listener
+
+		send := func(content string) {
+			if data == nil {
+				reply("425 No data connection")
+				return
+			}
+
+			reply("150 Opening data connection")
+
+			if dc, err := data.Accept(); err == nil {
+				_, _ = dc.Write([]byte(content))
+				_ = dc.Close()
+			}
+
+			_ = data.Close()
+			data = nil
+
+			reply("226 Transfer complete")
+		}
+
+		reply("220 Welcome")
+
+		for {
+			line, err := r.ReadString('\n')
+
+			if err != nil {
+				return
+			}
+
+			cmd, arg := strings.TrimSpace(line), ""
+
+			if i := strings.Index(cmd, " "); i > 0 {
+				cmd, arg = cmd[:i], cmd[i+1:]
+			}
+
+			switch cmd {
+			case "USER":
+				reply("331 Password required")
+			case "PASS":
+				if arg == "secret" {
+					reply("230 Logged in")
+				} else {
+					reply("530 Login incorrect")
+				}
+			case "FEAT":
+				if mlsd {
+					reply("211-Features:\r\n MLST type*;size*;modify*;\r\n211 End")
+				} else {
+					reply("211 No features")
+				}
+			case "TYPE":
+				reply("200 Type set to I")
+			case "EPSV":
+				if data, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+					reply("425 Cannot open data connection")
+				} else {
+					reply("229 Entering Extended Passive Mode (|||%d|)", data.Addr().(*net.TCPAddr).Port)
+				}
+			case "MLSD":
+				if listing, ok := listings[arg]; ok {
+					send(listing)
+				} else {
+					reply("550 No such directory")
+				}
+			case "LIST":
+				send(listings[arg])
+			case "RETR":
+				if content, ok := files[arg]; ok {
+					send(content)
+				} else {
+					reply("550 No such file")
+				}
+			case "QUIT":
+				reply("221 Goodbye")
+				return
+			default:
+				reply("502 Command not implemented")
+			}
+		}
+	}()
+
+	return "ftp://" + l.Addr().String() + "/"
+}
+
+func TestClient(t *testing.T) {
+	listings := map[string]string{
+		"/": "type=cdir;modify=20210102150405; .\r\n" +
+			"type=dir;modify=20210102150405; Photos\r\n",
+		"/Photos": "type=file;size=4;modify=20210102150405; example.jpg\r\n" +
+			"type=file;size=4;modify=20210102150405; .DS_Store\r\n" +
+			"type=dir;modify=20210102150405; 2021\r\n",
+		"/Photos/2021": "",
+	}
+
+	files := map[string]string{"/Photos/example.jpg": "test"}
+
+	t.Run("Login", func(t *testing.T) {
+		_, err := New(testServer(t, true, listings, files), "admin", "wrong")
+		assert.Error(t, err)
+	})
+	t.Run("Files", func(t *testing.T) {
+		c, err := New(testServer(t, true, listings, files), "admin", "secret")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer c.Close()
+
+		result, err := c.Files("/Photos")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "/Photos/example.jpg", result[0].Abs)
+		assert.Equal(t, int64(4), result[0].Size)
+		assert.Equal(t, time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC), result[0].Date)
+
+		_, err = c.Files("/xxx")
+		assert.Error(t, err)
+	})
+	t.Run("Directories", func(t *testing.T) {
+		c, err := New(testServer(t, true, listings, files), "admin", "secret")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer c.Close()
+
+		dirs, err := c.Directories("/", true, SyncTimeout)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos", "/Photos/2021"}, dirs.Abs())
+	})
+	t.Run("List", func(t *testing.T) {
+		c, err := New(testServer(t, false, map[string]string{
+			"/": "drwxr-xr-x 2 user group 4096 Jan 02  2021 Photos\r\n" +
+				"-rw-r--r-- 1 user group    4 Jan 02  2021 my photo.jpg\r\n",
+		}, files), "admin", "secret")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer c.Close()
+
+		result, err := c.Files("/")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "/my photo.jpg", result[0].Abs)
+	})
+	t.Run("Download", func(t *testing.T) {
+		c, err := New(testServer(t, true, listings, files), "admin", "secret")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer c.Close()
+
+		fileName := filepath.Join(t.TempDir(), "Photos", "example.jpg")
+
+		if err := c.Download("/Photos/example.jpg", fileName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "test", string(data))
+		assert.Error(t, c.Download("/Photos/example.jpg", fileName, false))
+
+		missing := filepath.Join(filepath.Dir(fileName), "missing.jpg")
+		assert.Error(t, c.Download("/Photos/missing.jpg", missing, false))
+		assert.NoFileExists(t, missing)
+	})
+}
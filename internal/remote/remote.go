@@ -35,6 +35,7 @@ https://docs.photoprism.app/developer-guide/
 package remote
 
 import (
+	"net"
 	"net/http"
 	"time"
 )
@@ -43,6 +44,8 @@ var client = &http.Client{Timeout: 30 * time.Second} // TODO: Change timeout if
 
 const (
 	ServiceWebDAV    = "webdav"
+	ServiceSFTP      = "sftp"
+	ServiceFTP       = "ftp"
 	ServiceFacebook  = "facebook"
 	ServiceTwitter   = "twitter"
 	ServiceFlickr    = "flickr"
@@ -70,3 +73,16 @@ func HttpOk(method, rawUrl string) bool {
 
 	return false
 }
+
+// TcpOk tests if a TCP connection can be established.
+func TcpOk(address string) bool {
+	conn, err := net.DialTimeout("tcp", address, client.Timeout)
+
+	if err != nil {
+		return false
+	}
+
+	_ = conn.Close()
+
+	return true
+}
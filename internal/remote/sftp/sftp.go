@@ -0,0 +1,267 @@
+/*
+
+Package sftp implements downloading files from SFTP servers.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.app/developer-guide/
+
+*/
+package sftp
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"time"
+
+	pkgsftp "github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+var log = event.Log
+
+const SyncTimeout = time.Second * 45
+const AsyncTimeout = time.Minute * 20
+const DialTimeout = time.Second * 30
+const DefaultPort = "22"
+
+type Client struct {
+	ssh  *ssh.Client
+	sftp *pkgsftp.Client
+}
+
+// New connects to an SFTP server, e.g. "sftp://example.com:22/?fingerprint=SHA256:...". The URL must contain
+// the SHA256 fingerprint of the server host key as shown by "ssh-keygen -l", otherwise the connection is refused.
+func New(rawUrl, user, pass string) (*Client, error) {
+	u, err := url.Parse(rawUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	host := u.Host
+
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), DefaultPort)
+	}
+
+	config := &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.Password(pass), ssh.KeyboardInteractive(keyboardInteractive(pass))},
+		HostKeyCallback: hostKeyCallback(u.Query().Get("fingerprint")),
+		Timeout:         DialTimeout,
+	}
+
+	sshClient, err := ssh.Dial("tcp", host, config)
+
+	if err != nil {
+		return nil, fmt.Errorf("sftp: %s", err)
+	}
+
+	c, err := pkgsftp.NewClient(sshClient)
+
+	if err != nil {
+		_ = sshClient.Close()
+		return nil, fmt.Errorf("sftp: %s", err)
+	}
+
+	return &Client{ssh: sshClient, sftp: c}, nil
+}
+
+// keyboardInteractive answers all keyboard interactive questions with the password.
+func keyboardInteractive(pass string) ssh.KeyboardInteractiveChallenge {
+	return func(user, instruction string, questions []string, echos []bool) ([]string, error) {
+		answers := make([]string, len(questions))
+
+		for i := range answers {
+			answers[i] = pass
+		}
+
+		return answers, nil
+	}
+}
+
+// hostKeyCallback verifies the server host key. Unknown keys are refused, so that users can check the
+// fingerprint, e.g. with "ssh-keyscan", before adding it to the service URL.
+func hostKeyCallback(fingerprint string) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		found := ssh.FingerprintSHA256(key)
+
+		if fingerprint == "" {
+			log.Warnf("sftp: %s has unknown host key fingerprint %s", sanitize.Log(hostname), found)
+			return fmt.Errorf("unknown host key, add fingerprint=%s to the service url if it can be trusted", url.QueryEscape(found))
+		} else if found != fingerprint {
+			return fmt.Errorf("host key fingerprint %s does not match", found)
+		}
+
+		return nil
+	}
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	err := c.sftp.Close()
+
+	if c.ssh != nil {
+		return c.ssh.Close()
+	}
+
+	return err
+}
+
+// Files returns all files in a directory.
+func (c *Client) Files(dir string) (result fs.FileInfos, err error) {
+	if dir == "" {
+		dir = "/"
+	}
+
+	files, err := c.sftp.ReadDir(dir)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, file := range files {
+		if !file.Mode().IsRegular() {
+			continue
+		} else if fs.FileNameJunk(file.Name(), fs.JunkPatterns) {
+			log.Debugf("sftp: skipped junk file %s", sanitize.Log(file.Name()))
+			continue
+		}
+
+		result = append(result, fs.NewFileInfo(file, dir))
+	}
+
+	return result, nil
+}
+
+// Directories returns all sub directories in path.
+func (c *Client) Directories(root string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	start := time.Now()
+
+	result, err = c.fetchDirs(root, recursive, start, timeout)
+
+	if time.Now().Sub(start) >= timeout {
+		log.Warnf("sftp: read dir timeout reached")
+	}
+
+	return result, err
+}
+
+// fetchDirs recursively fetches all directories until the timeout is reached.
+func (c *Client) fetchDirs(root string, recursive bool, start time.Time, timeout time.Duration) (result fs.FileInfos, err error) {
+	if root == "" {
+		root = "/"
+	}
+
+	files, err := c.sftp.ReadDir(root)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, file := range files {
+		if !file.Mode().IsDir() {
+			continue
+		} else if fs.FileNameJunk(file.Name(), fs.JunkPatterns) {
+			log.Debugf("sftp: skipped junk folder %s", sanitize.Log(file.Name()))
+			continue
+		}
+
+		info := fs.NewFileInfo(file, root)
+
+		result = append(result, info)
+
+		if recursive && time.Now().Sub(start) < timeout {
+			subDirs, err := c.fetchDirs(info.Abs, true, start, timeout)
+
+			if err != nil {
+				return result, err
+			}
+
+			result = append(result, subDirs...)
+		}
+	}
+
+	return result, nil
+}
+
+// Download downloads a single file to the given location.
+func (c *Client) Download(from, to string, force bool) (err error) {
+	if _, err := os.Stat(to); err == nil && !force {
+		return fmt.Errorf("sftp: download skipped, %s already exists", to)
+	}
+
+	dir := path.Dir(to)
+	dirInfo, err := os.Stat(dir)
+
+	if err != nil {
+		// Create directory
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return fmt.Errorf("sftp: cannot create %s (%s)", dir, err)
+		}
+	} else if !dirInfo.IsDir() {
+		return fmt.Errorf("sftp: %s is not a folder", dir)
+	}
+
+	f, err := os.OpenFile(to, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	if err = c.readFile(from, f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(to)
+		return fmt.Errorf("sftp: %s (download %s)", err, from)
+	}
+
+	return f.Close()
+}
+
+// readFile copies the contents of a remote file to w.
+func (c *Client) readFile(from string, w io.Writer) error {
+	f, err := c.sftp.Open(from)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, f)
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
@@ -0,0 +1,176 @@
+package sftp
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pkgsftp "github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+// testPipe connects both ends of an in-memory SFTP session.
+type testPipe struct {
+	io.Reader
+	io.WriteCloser
+}
+
+// testServer returns a client connected to an in-memory SFTP server with the files provided.
+func testServer(t *testing.T, files map[string]string) *Client {
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+
+	server := pkgsftp.NewRequestServer(testPipe{serverR, serverW}, pkgsftp.InMemHandler())
+
+	go func() { _ = server.Serve() }()
+
+	c, err := pkgsftp.NewClientPipe(clientR, clientW)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, data := range files {
+		if err := c.MkdirAll(filepath.Dir(name)); err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.HasSuffix(name, "/") {
+			continue
+		}
+
+		f, err := c.Create(name)
+
+		if err != nil {
+			t.Fatal(err)
+		} else if _, err := f.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		} else if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Closing the server first ends the client's receive loop.
+	t.Cleanup(func() {
+		_ = server.Close()
+		_ = c.Close()
+	})
+
+	return &Client{sftp: c}
+}
+
+var testFiles = map[string]string{
+	"/Photos/2021/":         "",
+	"/Photos/example.jpg":   strings.Repeat("x", 40000),
+	"/Photos/.DS_Store":     "junk",
+	"/Photos/2021/test.jpg": "test",
+}
+
+func TestClient_Files(t *testing.T) {
+	c := testServer(t, testFiles)
+
+	t.Run("Photos", func(t *testing.T) {
+		files, err := c.Files("/Photos")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, files, 1)
+		assert.Equal(t, "/Photos/example.jpg", files[0].Abs)
+		assert.Equal(t, int64(40000), files[0].Size)
+		assert.False(t, files[0].Date.IsZero())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := c.Files("/xxx")
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_Directories(t *testing.T) {
+	c := testServer(t, testFiles)
+
+	t.Run("Recursive", func(t *testing.T) {
+		dirs, err := c.Directories("/", true, SyncTimeout)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos", "/Photos/2021"}, dirs.Abs())
+	})
+	t.Run("NotRecursive", func(t *testing.T) {
+		dirs, err := c.Directories("", false, SyncTimeout)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos"}, dirs.Abs())
+	})
+}
+
+func TestClient_Download(t *testing.T) {
+	c := testServer(t, testFiles)
+	dir := t.TempDir()
+
+	t.Run("Success", func(t *testing.T) {
+		fileName := filepath.Join(dir, "Photos", "example.jpg")
+
+		if err := c.Download("/Photos/example.jpg", fileName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, testFiles["/Photos/example.jpg"], string(data))
+	})
+	t.Run("Exists", func(t *testing.T) {
+		assert.Error(t, c.Download("/Photos/example.jpg", filepath.Join(dir, "Photos", "example.jpg"), false))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		fileName := filepath.Join(dir, "missing.jpg")
+
+		assert.Error(t, c.Download("/Photos/missing.jpg", fileName, false))
+		assert.NoFileExists(t, fileName)
+	})
+}
+
+func TestHostKeyCallback(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ssh.NewPublicKey(pub)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fingerprint := ssh.FingerprintSHA256(key)
+
+	t.Run("Match", func(t *testing.T) {
+		assert.NoError(t, hostKeyCallback(fingerprint)("example.com:22", nil, key))
+	})
+	t.Run("Mismatch", func(t *testing.T) {
+		assert.Error(t, hostKeyCallback("SHA256:xxx")("example.com:22", nil, key))
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		err := hostKeyCallback("")("example.com:22", nil, key)
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "fingerprint=SHA256")
+		}
+	})
+}
@@ -40,6 +40,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
 	"github.com/studio-b12/gowebdav"
 )
 
@@ -65,6 +66,11 @@ func New(url, user, pass string) Client {
 	return result
 }
 
+// Close closes idle connections, WebDAV requests don't require a persistent connection.
+func (c Client) Close() error {
+	return nil
+}
+
 func (c Client) readDir(path string) ([]os.FileInfo, error) {
 	if path == "" {
 		path = "/"
@@ -90,6 +96,9 @@ func (c Client) Files(dir string) (result fs.FileInfos, err error) {
 	for _, file := range files {
 		if !file.Mode().IsRegular() {
 			continue
+		} else if fs.FileNameJunk(file.Name(), fs.JunkPatterns) {
+			log.Debugf("webdav: skipped junk file %s", sanitize.Log(file.Name()))
+			continue
 		}
 
 		info := fs.NewFileInfo(file, dir)
@@ -128,6 +137,9 @@ func (c Client) fetchDirs(root string, recursive bool, start time.Time, timeout
 	for _, file := range files {
 		if !file.Mode().IsDir() {
 			continue
+		} else if fs.FileNameJunk(file.Name(), fs.JunkPatterns) {
+			log.Debugf("webdav: skipped junk folder %s", sanitize.Log(file.Name()))
+			continue
 		}
 
 		info := fs.NewFileInfo(file, root)
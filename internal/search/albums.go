@@ -1,6 +1,7 @@
 package search
 
 import (
+	"context"
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -10,12 +11,17 @@ import (
 
 // Albums searches albums based on their name.
 func Albums(f form.SearchAlbums) (results AlbumResults, err error) {
+	return AlbumsContext(context.Background(), f)
+}
+
+// AlbumsContext searches albums like Albums, and adds the database queries to the trace in the context.
+func AlbumsContext(ctx context.Context, f form.SearchAlbums) (results AlbumResults, err error) {
 	if err := f.ParseQueryString(); err != nil {
 		return results, err
 	}
 
 	// Base query.
-	s := UnscopedDb().Table("albums").
+	s := DbContext(ctx).Unscoped().Table("albums").
 		Select("albums.*, cp.photo_count, cl.link_count, CASE WHEN albums.album_year = 0 THEN 0 ELSE 1 END AS has_year").
 		Joins("LEFT JOIN (SELECT album_uid, count(photo_uid) AS photo_count FROM photos_albums WHERE hidden = 0 AND missing = 0 GROUP BY album_uid) AS cp ON cp.album_uid = albums.album_uid").
 		Joins("LEFT JOIN (SELECT share_uid, count(share_uid) AS link_count FROM links GROUP BY share_uid) AS cl ON cl.share_uid = albums.album_uid").
@@ -0,0 +1,226 @@
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+)
+
+// Resolutions maps video and display resolution names to the minimum length of the longest side in pixels.
+var Resolutions = map[string]int{
+	"sd":    640,
+	"480p":  640,
+	"hd":    1280,
+	"720p":  1280,
+	"fhd":   1920,
+	"1080p": 1920,
+	"2k":    2048,
+	"qhd":   2560,
+	"1440p": 2560,
+	"4k":    3840,
+	"uhd":   3840,
+	"2160p": 3840,
+	"5k":    5120,
+	"6k":    6144,
+	"8k":    7680,
+}
+
+// ParseCompare splits a comparison like ">=20" into operator and value, the default operator is "=".
+func ParseCompare(s string) (op, value string) {
+	s = strings.TrimSpace(s)
+
+	for _, op = range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(s, op) {
+			return op, strings.TrimSpace(s[len(op):])
+		}
+	}
+
+	return "=", s
+}
+
+// CompareFloat returns a where condition comparing a column with a number, e.g. ">20", or an empty string if invalid.
+func CompareFloat(col, s string) (where string) {
+	op, value := ParseCompare(s)
+
+	f, err := strconv.ParseFloat(value, 64)
+
+	if err != nil || f < 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s %s %s", col, op, strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// CompareBytes returns a where condition comparing a column with a data size, e.g. ">50MB", or an empty string if invalid.
+func CompareBytes(col, s string) (where string) {
+	op, value := ParseCompare(s)
+
+	n, err := humanize.ParseBytes(value)
+
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s %s %d", col, op, n)
+}
+
+// CompareResolution returns a where condition comparing the longest side of an image with a resolution,
+// e.g. "4k" or ">2000", or an empty string if invalid.
+func CompareResolution(widthCol, heightCol, s string) (where string) {
+	op, value := ParseCompare(s)
+
+	px, ok := Resolutions[strings.ToLower(value)]
+
+	if !ok {
+		var err error
+
+		if px, err = strconv.Atoi(value); err != nil || px < 0 {
+			return ""
+		}
+	}
+
+	switch op {
+	case "<", "<=":
+		return fmt.Sprintf("(%s %s %d AND %s %s %d)", widthCol, op, px, heightCol, op, px)
+	case ">":
+		return fmt.Sprintf("(%s > %d OR %s > %d)", widthCol, px, heightCol, px)
+	default:
+		// Resolutions without operator are minimum sizes.
+		return fmt.Sprintf("(%s >= %d OR %s >= %d)", widthCol, px, heightCol, px)
+	}
+}
+
+// RangeSeparator separates the start and end of a range, e.g. mm:35..50.
+const RangeSeparator = ".."
+
+// ParseNumber returns a positive number, e.g. an ISO value or focal length.
+func ParseNumber(s string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+
+	if err != nil {
+		return 0, err
+	} else if f < 0 {
+		return 0, fmt.Errorf("negative number %s", s)
+	}
+
+	return f, nil
+}
+
+// ParseExposure returns the exposure time in seconds, e.g. for "1/60", "0.5", or "2s".
+func ParseExposure(s string) (float64, error) {
+	s = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "s")
+
+	if i := strings.Index(s, "/"); i > 0 {
+		n, err := ParseNumber(s[:i])
+
+		if err != nil {
+			return 0, err
+		}
+
+		d, err := ParseNumber(s[i+1:])
+
+		if err != nil {
+			return 0, err
+		} else if d == 0 {
+			return 0, fmt.Errorf("invalid exposure time %s", s)
+		}
+
+		return n / d, nil
+	}
+
+	return ParseNumber(s)
+}
+
+// ExposureSeconds returns an SQL expression that converts an exposure time column like "1/60" to seconds.
+func ExposureSeconds(col string) string {
+	return fmt.Sprintf("(CASE WHEN INSTR(%[1]s, '/') > 0 THEN (SUBSTR(%[1]s, 1, INSTR(%[1]s, '/') - 1) + 0.0) / (SUBSTR(%[1]s, INSTR(%[1]s, '/') + 1) + 0.0) ELSE %[1]s + 0.0 END)", col)
+}
+
+// CompareRange returns a where condition comparing a column with a number or range, e.g. ">3200" or "35..50",
+// or an empty string if invalid. Zero values are excluded, as they indicate that the value is unknown.
+// The relative tolerance is used to match floating point values, e.g. 0.01 for f-numbers.
+func CompareRange(col, s string, tolerance float64) (where string) {
+	return compareRange(col, s, tolerance, ParseNumber)
+}
+
+// CompareExposure returns a where condition comparing an exposure time column with a time or range in seconds,
+// e.g. "<1/60" or "1/250..1/60", or an empty string if invalid.
+func CompareExposure(col, s string) (where string) {
+	if where = compareRange(ExposureSeconds(col), s, 0.01, ParseExposure); where == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("(%s <> '' AND %s)", col, where)
+}
+
+// compareRange returns a where condition comparing an expression with values returned by the parse function.
+func compareRange(expr, s string, tolerance float64, parse func(string) (float64, error)) (where string) {
+	format := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	s = strings.TrimSpace(s)
+
+	if i := strings.Index(s, RangeSeparator); i >= 0 {
+		start, end := s[:i], s[i+len(RangeSeparator):]
+
+		var cond []string
+
+		if start != "" {
+			f, err := parse(start)
+
+			if err != nil {
+				return ""
+			}
+
+			cond = append(cond, fmt.Sprintf("%s >= %s", expr, format(f*(1-tolerance))))
+		}
+
+		if end != "" {
+			f, err := parse(end)
+
+			if err != nil {
+				return ""
+			}
+
+			cond = append(cond, fmt.Sprintf("%s <= %s", expr, format(f*(1+tolerance))))
+		}
+
+		if len(cond) == 0 {
+			return ""
+		}
+
+		return fmt.Sprintf("(%s > 0 AND %s)", expr, strings.Join(cond, " AND "))
+	}
+
+	op, value := ParseCompare(s)
+
+	f, err := parse(value)
+
+	if err != nil {
+		return ""
+	}
+
+	lo, hi := format(f*(1-tolerance)), format(f*(1+tolerance))
+
+	switch op {
+	case ">=":
+		where = fmt.Sprintf("%s >= %s", expr, lo)
+	case "<=":
+		where = fmt.Sprintf("%s <= %s", expr, hi)
+	case ">":
+		where = fmt.Sprintf("%s > %s", expr, hi)
+	case "<":
+		where = fmt.Sprintf("%s < %s", expr, lo)
+	default:
+		if tolerance == 0 {
+			where = fmt.Sprintf("%s = %s", expr, lo)
+		} else {
+			where = fmt.Sprintf("%s BETWEEN %s AND %s", expr, lo, hi)
+		}
+	}
+
+	return fmt.Sprintf("(%s > 0 AND %s)", expr, where)
+}
@@ -0,0 +1,85 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCompare(t *testing.T) {
+	t.Run("Greater", func(t *testing.T) {
+		op, value := ParseCompare(">20")
+		assert.Equal(t, ">", op)
+		assert.Equal(t, "20", value)
+	})
+	t.Run("LessOrEqual", func(t *testing.T) {
+		op, value := ParseCompare("<= 1.5")
+		assert.Equal(t, "<=", op)
+		assert.Equal(t, "1.5", value)
+	})
+	t.Run("Default", func(t *testing.T) {
+		op, value := ParseCompare("12")
+		assert.Equal(t, "=", op)
+		assert.Equal(t, "12", value)
+	})
+}
+
+func TestCompareFloat(t *testing.T) {
+	assert.Equal(t, "photos.photo_resolution > 20", CompareFloat("photos.photo_resolution", ">20"))
+	assert.Equal(t, "files.file_aspect_ratio <= 1.5", CompareFloat("files.file_aspect_ratio", "<=1.5"))
+	assert.Equal(t, "", CompareFloat("photos.photo_resolution", ">foo"))
+	assert.Equal(t, "", CompareFloat("photos.photo_resolution", ""))
+}
+
+func TestCompareBytes(t *testing.T) {
+	assert.Equal(t, "files.file_size > 50000000", CompareBytes("files.file_size", ">50MB"))
+	assert.Equal(t, "files.file_size < 2097152", CompareBytes("files.file_size", "<2MiB"))
+	assert.Equal(t, "", CompareBytes("files.file_size", ">foo"))
+}
+
+func TestCompareResolution(t *testing.T) {
+	assert.Equal(t, "(w >= 3840 OR h >= 3840)", CompareResolution("w", "h", "4k"))
+	assert.Equal(t, "(w > 2000 OR h > 2000)", CompareResolution("w", "h", ">2000"))
+	assert.Equal(t, "(w < 1280 AND h < 1280)", CompareResolution("w", "h", "<HD"))
+	assert.Equal(t, "", CompareResolution("w", "h", "foo"))
+}
+
+func TestParseExposure(t *testing.T) {
+	t.Run("Fraction", func(t *testing.T) {
+		f, err := ParseExposure("1/60")
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.016666, f, 0.00001)
+	})
+	t.Run("Seconds", func(t *testing.T) {
+		f, err := ParseExposure("2s")
+		assert.NoError(t, err)
+		assert.Equal(t, 2.0, f)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseExposure("1/0")
+		assert.Error(t, err)
+		_, err = ParseExposure("fast")
+		assert.Error(t, err)
+	})
+}
+
+func TestCompareRange(t *testing.T) {
+	assert.Equal(t, "(photos.photo_iso > 0 AND photos.photo_iso > 3200)", CompareRange("photos.photo_iso", ">3200", 0))
+	assert.Equal(t, "(photos.photo_focal_length > 0 AND photos.photo_focal_length >= 35 AND photos.photo_focal_length <= 50)", CompareRange("photos.photo_focal_length", "35..50", 0))
+	assert.Equal(t, "(photos.photo_focal_length > 0 AND photos.photo_focal_length <= 24)", CompareRange("photos.photo_focal_length", "..24", 0))
+	assert.Equal(t, "(photos.photo_iso > 0 AND photos.photo_iso = 100)", CompareRange("photos.photo_iso", "100", 0))
+	assert.Equal(t, "(photos.photo_f_number > 0 AND photos.photo_f_number BETWEEN 1.782 AND 1.818)", CompareRange("photos.photo_f_number", "1.8", 0.01))
+	assert.Equal(t, "(photos.photo_f_number > 0 AND photos.photo_f_number <= 2.828)", CompareRange("photos.photo_f_number", "<=2.8", 0.01))
+	assert.Equal(t, "", CompareRange("photos.photo_iso", ">foo", 0))
+	assert.Equal(t, "", CompareRange("photos.photo_iso", "..", 0))
+	assert.Equal(t, "", CompareRange("photos.photo_iso", "-100", 0))
+}
+
+func TestCompareExposure(t *testing.T) {
+	where := CompareExposure("photos.photo_exposure", "<1/60")
+
+	assert.True(t, strings.HasPrefix(where, "(photos.photo_exposure <> '' AND ("))
+	assert.True(t, strings.HasSuffix(where, " < 0.0165))"))
+	assert.Equal(t, "", CompareExposure("photos.photo_exposure", "<foo"))
+}
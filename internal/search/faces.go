@@ -1,6 +1,7 @@
 package search
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -11,6 +12,11 @@ import (
 
 // Faces searches faces and returns them.
 func Faces(f form.SearchFaces) (results FaceResults, err error) {
+	return FacesContext(context.Background(), f)
+}
+
+// FacesContext searches faces like Faces, and adds the database queries to the trace in the context.
+func FacesContext(ctx context.Context, f form.SearchFaces) (results FaceResults, err error) {
 	if err := f.ParseQueryString(); err != nil {
 		return results, err
 	}
@@ -18,7 +24,7 @@ func Faces(f form.SearchFaces) (results FaceResults, err error) {
 	facesTable := entity.Face{}.TableName()
 
 	// Base query.
-	s := UnscopedDb().Table(facesTable)
+	s := DbContext(ctx).Unscoped().Table(facesTable)
 
 	if f.Markers {
 		s = s.Select(fmt.Sprintf(`%s.*, m.marker_uid, m.file_uid, m.marker_name, m.subj_src, m.marker_src, 
@@ -0,0 +1,103 @@
+package search
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// MaxRadius is the maximum search radius in km.
+const MaxRadius = 5000
+
+// GeoFilter represents geographic search constraints.
+type GeoFilter struct {
+	Latlng  string  // Viewport bounds: north, east, south, west.
+	Polygon string  // Pairs of latitude and longitude.
+	Lat     float32 // Center latitude for searching by radius.
+	Lng     float32 // Center longitude for searching by radius.
+	Radius  float64 // Distance from the center in km.
+}
+
+// Exact tests if the search is limited by exact distance, so that approximate filters can be skipped.
+func (f GeoFilter) Exact() bool {
+	return f.Radius > 0 && (f.Lat != 0 || f.Lng != 0)
+}
+
+// whereBounds adds a filter for the bounds to the search query, which can use the index on latitude and longitude.
+// Bounds crossing the antimeridian are split into two longitude ranges.
+func whereBounds(s *gorm.DB, b geo.Bounds) *gorm.DB {
+	if !b.Antimeridian() {
+		return s.Where("photos.photo_lat BETWEEN ? AND ? AND photos.photo_lng BETWEEN ? AND ?", b.South, b.North, b.West, b.East)
+	}
+
+	r := b.LngRanges()
+
+	return s.Where("photos.photo_lat BETWEEN ? AND ? AND (photos.photo_lng BETWEEN ? AND ? OR photos.photo_lng BETWEEN ? AND ?)",
+		b.South, b.North, r[0][0], r[0][1], r[1][0], r[1][1])
+}
+
+// filterGeo limits the results to photos within the viewport bounds, polygon, and radius, if specified.
+func filterGeo(s *gorm.DB, f GeoFilter) (*gorm.DB, error) {
+	if f.Latlng != "" {
+		if b, err := geo.ParseBounds(f.Latlng); err != nil {
+			return s, fmt.Errorf("invalid latlng: %s", err)
+		} else {
+			s = whereBounds(s, b)
+		}
+	}
+
+	if f.Polygon != "" {
+		p, err := geo.ParsePolygon(f.Polygon)
+
+		if err != nil {
+			return s, fmt.Errorf("invalid polygon: %s", err)
+		}
+
+		s = whereBounds(s, p.Bounds())
+
+		// Count edges crossed by a ray from each position, an odd number means it is inside.
+		var sql string
+		var values []interface{}
+
+		for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+			a, b := p[i], p[j]
+
+			// Horizontal edges are never crossed.
+			if a.Lat == b.Lat {
+				continue
+			}
+
+			if sql != "" {
+				sql += " + "
+			}
+
+			sql += "CASE WHEN (? > photos.photo_lat) <> (? > photos.photo_lat) AND photos.photo_lng < ? + (photos.photo_lat - ?) * ? THEN 1 ELSE 0 END"
+			values = append(values, a.Lat, b.Lat, a.Lng, a.Lat, (b.Lng-a.Lng)/(b.Lat-a.Lat))
+		}
+
+		if sql == "" {
+			return s, fmt.Errorf("invalid polygon: no area")
+		}
+
+		s = s.Where(fmt.Sprintf("(%s) %% 2 = 1", sql), values...)
+	}
+
+	if f.Exact() {
+		center := geo.Position{Lat: float64(f.Lat), Lng: float64(f.Lng)}
+		radius := math.Min(f.Radius, MaxRadius)
+
+		s = whereBounds(s, center.Bounds(radius))
+
+		// Approximate the distance on a plane, which is accurate enough for the radius of local searches.
+		latKm := geo.KmPerDegree
+		lngKm := geo.KmPerDegree * math.Cos(geo.DegToRad(center.Lat))
+
+		s = s.Where("(photos.photo_lat - ?) * (photos.photo_lat - ?) * ? + (photos.photo_lng - ?) * (photos.photo_lng - ?) * ? <= ?",
+			center.Lat, center.Lat, latKm*latKm, center.Lng, center.Lng, lngKm*lngKm, radius*radius)
+	}
+
+	return s, nil
+}
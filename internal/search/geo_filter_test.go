@@ -0,0 +1,125 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestGeoFilter_Exact(t *testing.T) {
+	assert.True(t, GeoFilter{Lat: 19.681944, Lng: -98.84659, Radius: 5}.Exact())
+	assert.False(t, GeoFilter{Lat: 19.681944, Lng: -98.84659}.Exact())
+	assert.False(t, GeoFilter{Radius: 5}.Exact())
+}
+
+func TestPhotos_GeoFilter(t *testing.T) {
+	search := func(t *testing.T, f form.SearchPhotos) PhotoResults {
+		f.Count = 100
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return photos
+	}
+
+	t.Run("Radius", func(t *testing.T) {
+		near := search(t, form.SearchPhotos{Lat: 19.681944, Lng: -98.84659, Radius: 0.1})
+		far := search(t, form.SearchPhotos{Lat: 19.681944, Lng: -98.84659, Radius: 1})
+
+		assert.LessOrEqual(t, 1, len(near))
+		assert.Less(t, len(near), len(far))
+
+		for _, p := range near {
+			assert.InDelta(t, 19.681944, p.PhotoLat, 0.001)
+			assert.InDelta(t, -98.84659, p.PhotoLng, 0.001)
+		}
+	})
+	t.Run("RadiusQuery", func(t *testing.T) {
+		photos := search(t, form.SearchPhotos{Query: "lat:19.681944 lng:-98.84659 radius:1"})
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.InDelta(t, 19.681944, p.PhotoLat, 0.01)
+			assert.InDelta(t, -98.84659, p.PhotoLng, 0.01)
+		}
+	})
+	t.Run("Latlng", func(t *testing.T) {
+		photos := search(t, form.SearchPhotos{Latlng: "19.7,-98.8,19.6,-98.9"})
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.InDelta(t, 19.65, p.PhotoLat, 0.05)
+			assert.InDelta(t, -98.85, p.PhotoLng, 0.05)
+		}
+	})
+	t.Run("Antimeridian", func(t *testing.T) {
+		inside := search(t, form.SearchPhotos{Latlng: "19.7,-98.8,19.6,170"})
+		outside := search(t, form.SearchPhotos{Latlng: "19.7,-100,19.6,170"})
+
+		assert.LessOrEqual(t, 1, len(inside))
+
+		for _, p := range inside {
+			assert.True(t, p.PhotoLng >= 170 || p.PhotoLng <= -98.8)
+		}
+
+		for _, p := range outside {
+			assert.True(t, p.PhotoLng >= 170 || p.PhotoLng <= -100)
+		}
+	})
+	t.Run("Polygon", func(t *testing.T) {
+		inside := search(t, form.SearchPhotos{Polygon: "48.6,9.0,48.6,9.1,48.4,9.1"})
+		outside := search(t, form.SearchPhotos{Polygon: "48.6,9.0,48.4,9.0,48.4,9.1"})
+
+		assert.LessOrEqual(t, 1, len(inside))
+		assert.Empty(t, outside)
+
+		for _, p := range inside {
+			assert.InDelta(t, 48.519234, p.PhotoLat, 0.001)
+			assert.InDelta(t, 9.057997, p.PhotoLng, 0.001)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, _, err := Photos(form.SearchPhotos{Count: 10, Latlng: "foo"})
+		assert.Error(t, err)
+
+		_, _, err = Photos(form.SearchPhotos{Count: 10, Polygon: "48.6,9.0,48.6,9.1"})
+		assert.Error(t, err)
+	})
+}
+
+func TestGeo_GeoFilter(t *testing.T) {
+	t.Run("Radius", func(t *testing.T) {
+		photos, err := Geo(form.SearchGeo{Lat: 19.681944, Lng: -98.84659, Radius: 0.1})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.InDelta(t, 19.681944, p.PhotoLat, 0.001)
+			assert.InDelta(t, -98.84659, p.PhotoLng, 0.001)
+		}
+	})
+	t.Run("Polygon", func(t *testing.T) {
+		photos, err := Geo(form.SearchGeo{Polygon: "48.6,9.0,48.6,9.1,48.4,9.1"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Geo(form.SearchGeo{Latlng: "1,2,3"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// GeoRoundPrecision is the number of decimal places of rounded coordinates, about 11 km.
+const GeoRoundPrecision = entity.GeoRoundPrecision
+
+// geoRound rounds a coordinate to GeoRoundPrecision decimal places.
+func geoRound(v float32) float32 {
+	return entity.GeoRoundCoordinate(v)
+}
+
+// SetGeoPrivacy rounds or removes coordinates and location details depending on the privacy level.
+func (photo *Photo) SetGeoPrivacy(level string) {
+	switch level {
+	case entity.GeoRound:
+		photo.PhotoLat = geoRound(photo.PhotoLat)
+		photo.PhotoLng = geoRound(photo.PhotoLng)
+		photo.PhotoAltitude = 0
+		photo.PhotoHeading = 0
+		photo.PhotoSpeed = 0
+		photo.CellID = entity.UnknownID
+		photo.CellAccuracy = 0
+	case entity.GeoStrip:
+		photo.PhotoLat = 0
+		photo.PhotoLng = 0
+		photo.PhotoAltitude = 0
+		photo.PhotoHeading = 0
+		photo.PhotoSpeed = 0
+		photo.PhotoCountry = entity.UnknownID
+		photo.CellID = entity.UnknownID
+		photo.CellAccuracy = 0
+		photo.PlaceID = entity.UnknownID
+		photo.PlaceSrc = ""
+		photo.PlaceLabel = ""
+		photo.PlaceCity = ""
+		photo.PlaceState = ""
+		photo.PlaceCountry = entity.UnknownID
+	}
+}
+
+// SetGeoPrivacy rounds or removes coordinates and location details of all results.
+func (m PhotoResults) SetGeoPrivacy(level string) {
+	for i := range m {
+		m[i].SetGeoPrivacy(level)
+	}
+}
+
+// SetGeoPrivacy rounds coordinates depending on the privacy level, or returns no results if they must be removed.
+func (m GeoResults) SetGeoPrivacy(level string) GeoResults {
+	switch level {
+	case entity.GeoRound:
+		for i := range m {
+			m[i].PhotoLat = geoRound(m[i].PhotoLat)
+			m[i].PhotoLng = geoRound(m[i].PhotoLng)
+		}
+	case entity.GeoStrip:
+		return GeoResults{}
+	}
+
+	return m
+}
+
+// SetGeoPrivacy removes location details if required by the privacy level.
+func (album *Album) SetGeoPrivacy(level string) {
+	if level == entity.GeoStrip {
+		album.AlbumLocation = ""
+		album.AlbumState = ""
+		album.AlbumCountry = entity.UnknownID
+	}
+}
@@ -0,0 +1,63 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestPhoto_SetGeoPrivacy(t *testing.T) {
+	newPhoto := func() Photo {
+		return Photo{PhotoLat: 52.51634, PhotoLng: 13.37789, PhotoAltitude: 40, CellID: "s2:47a85a63f764",
+			PlaceID: "de:HFqPHxa2Hsol", PlaceLabel: "Berlin, Germany", PlaceCity: "Berlin", PlaceCountry: "de", PhotoCountry: "de"}
+	}
+
+	t.Run("Exact", func(t *testing.T) {
+		photo := newPhoto()
+		photo.SetGeoPrivacy(entity.GeoExact)
+		assert.Equal(t, float32(52.51634), photo.PhotoLat)
+		assert.Equal(t, "s2:47a85a63f764", photo.CellID)
+	})
+	t.Run("Round", func(t *testing.T) {
+		photo := newPhoto()
+		photo.SetGeoPrivacy(entity.GeoRound)
+		assert.Equal(t, float32(52.5), photo.PhotoLat)
+		assert.Equal(t, float32(13.4), photo.PhotoLng)
+		assert.Equal(t, 0, photo.PhotoAltitude)
+		assert.Equal(t, entity.UnknownID, photo.CellID)
+		assert.Equal(t, "Berlin", photo.PlaceCity)
+	})
+	t.Run("Strip", func(t *testing.T) {
+		results := PhotoResults{newPhoto()}
+		results.SetGeoPrivacy(entity.GeoStrip)
+		assert.Equal(t, float32(0), results[0].PhotoLat)
+		assert.Equal(t, float32(0), results[0].PhotoLng)
+		assert.Equal(t, "", results[0].PlaceLabel)
+		assert.Equal(t, "", results[0].PlaceCity)
+		assert.Equal(t, entity.UnknownID, results[0].PlaceID)
+		assert.Equal(t, entity.UnknownID, results[0].PhotoCountry)
+	})
+}
+
+func TestGeoResults_SetGeoPrivacy(t *testing.T) {
+	results := GeoResults{{PhotoLat: 52.51634, PhotoLng: 13.37789}}
+
+	assert.Empty(t, results.SetGeoPrivacy(entity.GeoStrip))
+
+	rounded := results.SetGeoPrivacy(entity.GeoRound)
+	assert.Len(t, rounded, 1)
+	assert.Equal(t, float32(52.5), rounded[0].PhotoLat)
+}
+
+func TestAlbum_SetGeoPrivacy(t *testing.T) {
+	album := Album{AlbumLocation: "Berlin", AlbumState: "Berlin", AlbumCountry: "de"}
+
+	album.SetGeoPrivacy(entity.GeoRound)
+	assert.Equal(t, "Berlin", album.AlbumLocation)
+
+	album.SetGeoPrivacy(entity.GeoStrip)
+	assert.Equal(t, "", album.AlbumLocation)
+	assert.Equal(t, entity.UnknownID, album.AlbumCountry)
+}
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/dustin/go-humanize/english"
 	"github.com/jinzhu/gorm"
 
+	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -20,12 +22,31 @@ import (
 
 // Geo searches for photos based on Form values and returns GeoResults ([]GeoResult).
 func Geo(f form.SearchGeo) (results GeoResults, err error) {
+	return GeoContext(context.Background(), f)
+}
+
+// GeoContext searches photos like Geo, and adds the database queries to the trace in the context.
+func GeoContext(ctx context.Context, f form.SearchGeo) (results GeoResults, err error) {
 	start := time.Now()
 
 	if err := f.ParseQueryString(); err != nil {
 		return GeoResults{}, err
 	}
 
+	// Limited searches, e.g. by guests, only find public pictures in one of the shared albums.
+	if shared := f.Shared(); shared != nil {
+		if !sharedAlbum(shared, f.Album) {
+			return GeoResults{}, ErrNotShared
+		}
+
+		f.Albums = ""
+		f.Public = true
+		f.Private = false
+		f.Archived = false
+		f.Review = false
+		f.ClearLocation()
+	}
+
 	S2Levels := 7
 
 	// Search for nearby photos?
@@ -43,7 +64,7 @@ func Geo(f form.SearchGeo) (results GeoResults, err error) {
 		S2Levels = 12
 	}
 
-	s := UnscopedDb()
+	s := DbContext(ctx).Unscoped()
 
 	// s.LogMode(true)
 
@@ -54,6 +75,7 @@ func Geo(f form.SearchGeo) (results GeoResults, err error) {
 		Joins(`JOIN files ON files.photo_id = photos.id AND 
 		files.file_missing = 0 AND files.file_primary AND files.deleted_at IS NULL`).
 		Where("photos.deleted_at IS NULL").
+		Where("photos.photo_pending = 0").
 		Where("photos.photo_lat <> 0")
 
 	// Set search filters based on search terms.
@@ -100,7 +122,7 @@ func Geo(f form.SearchGeo) (results GeoResults, err error) {
 		var labels []entity.Label
 		var labelIds []uint
 
-		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
+		if err := Db().Where(AnySlug("custom_slug", classify.CanonicalList(f.Query, " "), " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
 			log.Debugf("search: label %s not found, using fuzzy search", txt.LogParamLower(f.Query))
 
 			for _, where := range LikeAnyKeyword("k.keyword", f.Query) {
@@ -321,13 +343,15 @@ func Geo(f form.SearchGeo) (results GeoResults, err error) {
 		}
 	}
 
+	geoFilter := GeoFilter{Latlng: f.Latlng, Polygon: f.Polygon, Lat: f.Lat, Lng: f.Lng, Radius: f.Radius}
+
 	if f.S2 != "" {
 		s2Min, s2Max := s2.PrefixedRange(f.S2, S2Levels)
 		s = s.Where("photos.cell_id BETWEEN ? AND ?", s2Min, s2Max)
 	} else if f.Olc != "" {
 		s2Min, s2Max := s2.PrefixedRange(pluscode.S2(f.Olc), S2Levels)
 		s = s.Where("photos.cell_id BETWEEN ? AND ?", s2Min, s2Max)
-	} else {
+	} else if !geoFilter.Exact() {
 		// Filter by approx distance to coordinate:
 		if f.Lat != 0 {
 			latMin := f.Lat - Radius*float32(f.Dist)
@@ -341,14 +365,34 @@ func Geo(f form.SearchGeo) (results GeoResults, err error) {
 		}
 	}
 
+	// Filter by viewport bounds, polygon, or exact distance to coordinates:
+	if s, err = filterGeo(s, geoFilter); err != nil {
+		return GeoResults{}, err
+	}
+
 	// Find photos taken before date?
 	if !f.Before.IsZero() {
 		s = s.Where("photos.taken_at <= ?", f.Before.Format("2006-01-02"))
+		s = s.Where(YearBefore(f.Before))
+
+		if where := FileYearBefore(f.Before); where != "" {
+			s = s.Where(where)
+		}
 	}
 
 	// Find photos taken after date?
 	if !f.After.IsZero() {
 		s = s.Where("photos.taken_at >= ?", f.After.Format("2006-01-02"))
+		s = s.Where(YearAfter(f.After))
+
+		if where := FileYearAfter(f.After); where != "" {
+			s = s.Where(where)
+		}
+	}
+
+	// Find photos taken on a date or within a date range?
+	if where := TakenRange(f.Taken); where != "" {
+		s = s.Where(where)
 	}
 
 	if f.Near == "" {
@@ -801,4 +801,34 @@ func TestGeo(t *testing.T) {
 			assert.NotEmpty(t, r.ID)
 		}
 	})
+	t.Run("Shared", func(t *testing.T) {
+		frm := form.SearchGeo{Album: "at9lxuqxpogaaba9", Public: true}
+
+		expected, err := Geo(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(expected))
+
+		frm = form.SearchGeo{Query: "private:true public:false archived:true near:pt9jtdre2lvl0y11", Album: "at9lxuqxpogaaba9"}
+		frm.SetShared([]string{"at9lxuqxpogaaba9"})
+
+		photos, err := Geo(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, len(expected), len(photos))
+	})
+	t.Run("NotShared", func(t *testing.T) {
+		frm := form.SearchGeo{Query: "album:at9lxuqxpogaaba8", Album: "at9lxuqxpogaaba9"}
+		frm.SetShared([]string{"at9lxuqxpogaaba9"})
+
+		_, err := Geo(frm)
+
+		assert.ErrorIs(t, err, ErrNotShared)
+	})
 }
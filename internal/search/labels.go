@@ -1,9 +1,11 @@
 package search
 
 import (
+	"context"
 	"strings"
 
 	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/sanitize"
@@ -12,11 +14,16 @@ import (
 
 // Labels searches labels based on their name.
 func Labels(f form.SearchLabels) (results []Label, err error) {
+	return LabelsContext(context.Background(), f)
+}
+
+// LabelsContext searches labels like Labels, and adds the database queries to the trace in the context.
+func LabelsContext(ctx context.Context, f form.SearchLabels) (results []Label, err error) {
 	if err := f.ParseQueryString(); err != nil {
 		return results, err
 	}
 
-	s := UnscopedDb()
+	s := DbContext(ctx).Unscoped()
 	// s.LogMode(true)
 
 	// Base query.
@@ -48,7 +55,7 @@ func Labels(f form.SearchLabels) (results []Label, err error) {
 			return results, result.Error
 		}
 
-		return results, nil
+		return translateLabels(results), nil
 	}
 
 	if f.Query != "" {
@@ -56,7 +63,7 @@ func Labels(f form.SearchLabels) (results []Label, err error) {
 		var categories []entity.Category
 		var label entity.Label
 
-		slugString := slug.Make(f.Query)
+		slugString := slug.Make(classify.Canonical(f.Query))
 		likeString := "%" + f.Query + "%"
 
 		if result := Db().First(&label, "label_slug = ? OR custom_slug = ?", slugString, slugString); result.Error != nil {
@@ -90,5 +97,14 @@ func Labels(f form.SearchLabels) (results []Label, err error) {
 		return results, result.Error
 	}
 
-	return results, nil
+	return translateLabels(results), nil
+}
+
+// translateLabels sets the label names in the user interface language, if enabled.
+func translateLabels(results []Label) []Label {
+	for i := range results {
+		results[i].LabelName = classify.Translate(results[i].LabelName)
+	}
+
+	return results
 }
@@ -3,6 +3,7 @@ package search
 import (
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/stretchr/testify/assert"
@@ -143,4 +144,19 @@ func TestLabels(t *testing.T) {
 
 		assert.Equal(t, "flower", result[0].LabelSlug)
 	})
+	t.Run("translated", func(t *testing.T) {
+		classify.SetLocale("de")
+		defer classify.SetLocale("")
+
+		query := form.NewLabelSearch("Query:blume")
+		result, err := Labels(query)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "Blume", result[0].LabelName)
+		assert.Equal(t, "flower", result[0].LabelSlug)
+	})
 }
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/dustin/go-humanize/english"
 	"github.com/jinzhu/gorm"
 
+	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -18,20 +20,41 @@ import (
 
 // Photos searches for photos based on a Form and returns PhotoResults ([]Photo).
 func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
+	return PhotosContext(context.Background(), f)
+}
+
+// PhotosContext searches photos like Photos, and adds the database queries to the trace in the context.
+func PhotosContext(ctx context.Context, f form.SearchPhotos) (results PhotoResults, count int, err error) {
 	start := time.Now()
 
 	if err := f.ParseQueryString(); err != nil {
 		return PhotoResults{}, 0, err
 	}
 
-	s := UnscopedDb()
+	// Limited searches, e.g. by guests, only find public pictures in one of the shared albums.
+	if shared := f.Shared(); shared != nil {
+		if !sharedAlbum(shared, f.Album) {
+			return PhotoResults{}, 0, ErrNotShared
+		}
+
+		f.UID = ""
+		f.Albums = ""
+		f.Public = true
+		f.Private = false
+		f.Hidden = false
+		f.Archived = false
+		f.Review = false
+		f.ClearLocation()
+	}
+
+	s := DbContext(ctx).Unscoped()
 	// s = s.LogMode(true)
 
 	// Base query.
 	s = s.Table("photos").
 		Select(`photos.*, photos.id AS composite_id,
 		files.id AS file_id, files.file_uid, files.instance_id, files.file_primary, files.file_sidecar, 
-		files.file_portrait,files.file_video, files.file_missing, files.file_name, files.file_root, files.file_hash, 
+		files.file_portrait,files.file_video, files.file_missing, files.file_name, files.file_root, files.file_hash, files.file_edit_hash, 
 		files.file_codec, files.file_type, files.file_mime, files.file_width, files.file_height, 
 		files.file_aspect_ratio, files.file_orientation, files.file_main_color, files.file_colors, files.file_luminance, 
 		files.file_chroma, files.file_projection, files.file_diff, files.file_duration, files.file_size,
@@ -118,7 +141,9 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 	var labelIds []uint
 
 	if f.Label != "" {
-		if err := Db().Where(AnySlug("label_slug", f.Label, txt.Or)).Or(AnySlug("custom_slug", f.Label, txt.Or)).Find(&labels).Error; len(labels) == 0 || err != nil {
+		labelSlugs := classify.CanonicalList(f.Label, txt.Or)
+
+		if err := Db().Where(AnySlug("label_slug", labelSlugs, txt.Or)).Or(AnySlug("custom_slug", labelSlugs, txt.Or)).Find(&labels).Error; len(labels) == 0 || err != nil {
 			log.Debugf("search: label %s not found", txt.LogParamLower(f.Label))
 			return PhotoResults{}, 0, nil
 		} else {
@@ -187,14 +212,18 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 	}
 
 	// Filter by location?
-	if f.Geo == true {
+	if txt.No(f.Geo) {
+		s = s.Where("photos.cell_id = 'zz'")
+	}
+
+	if txt.Yes(f.Geo) {
 		s = s.Where("photos.cell_id <> 'zz'")
 
 		for _, where := range LikeAnyKeyword("k.keyword", f.Query) {
 			s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
 		}
 	} else if f.Query != "" {
-		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
+		if err := Db().Where(AnySlug("custom_slug", classify.CanonicalList(f.Query, " "), " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
 			log.Debugf("search: label %s not found, using fuzzy search", txt.LogParamLower(f.Query))
 
 			for _, where := range LikeAnyKeyword("k.keyword", f.Query) {
@@ -231,6 +260,19 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		}
 	}
 
+	// Filter by personal tags?
+	if f.Tag != "" {
+		for _, tag := range strings.Split(strings.ToLower(f.Tag), txt.And) {
+			if tags := strings.Split(tag, txt.Or); rnd.ContainsUIDs(tags, 't') {
+				s = s.Where(fmt.Sprintf("photos.photo_uid IN (SELECT photo_uid FROM %s WHERE tag_uid IN (?))",
+					entity.PhotoTag{}.TableName()), tags)
+			} else {
+				s = s.Where(fmt.Sprintf("photos.photo_uid IN (SELECT pt.photo_uid FROM %s pt JOIN %s t ON t.tag_uid = pt.tag_uid WHERE (?))",
+					entity.PhotoTag{}.TableName(), entity.Tag{}.TableName()), gorm.Expr(AnySlug("t.tag_slug", tag, txt.Or)))
+			}
+		}
+	}
+
 	// Filter by number of faces?
 	if txt.IsUInt(f.Faces) {
 		s = s.Where("photos.photo_faces >= ?", txt.Int(f.Faces))
@@ -295,9 +337,16 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		}
 
 		if f.Review {
-			s = s.Where("photos.photo_quality < 3")
-		} else if f.Quality != 0 && f.Private == false {
-			s = s.Where("photos.photo_quality >= ?", f.Quality)
+			s = s.Where("photos.photo_quality < 3 OR photos.photo_pending = 1")
+		} else if f.Pending {
+			s = s.Where("photos.photo_pending = 1")
+		} else {
+			// Pending photos are only visible in review until they have been approved.
+			s = s.Where("photos.photo_pending = 0")
+
+			if f.Quality != 0 && f.Private == false {
+				s = s.Where("photos.photo_quality >= ?", f.Quality)
+			}
 		}
 	}
 
@@ -346,11 +395,94 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		s = s.Where("photos.photo_panorama = 1")
 	}
 
+	// Filter by panorama projection type?
+	if f.Projection != "" {
+		s = s.Where("photos.photo_projection IN (?)", strings.Split(strings.ToLower(f.Projection), txt.Or))
+	}
+
+	// Find photos with edited versions only?
+	switch strings.ToLower(strings.TrimSpace(f.Has)) {
+	case "edits", "edit", "edited":
+		s = s.Where("photos.id IN (SELECT e.photo_id FROM files e WHERE e.file_edited = 1 AND e.deleted_at IS NULL)")
+	}
+
 	// Find portraits only?
 	if f.Portrait {
 		s = s.Where("files.file_portrait = 1")
 	}
 
+	// Filter by aspect ratio?
+	switch strings.ToLower(f.Ratio) {
+	case "":
+	case "portrait":
+		s = s.Where("files.file_aspect_ratio < 0.99")
+	case "landscape":
+		s = s.Where("files.file_aspect_ratio > 1.01")
+	case "square":
+		s = s.Where("files.file_aspect_ratio BETWEEN 0.99 AND 1.01")
+	case "panorama", "panoramas":
+		s = s.Where("photos.photo_panorama = 1")
+	default:
+		if where := CompareFloat("files.file_aspect_ratio", f.Ratio); where != "" {
+			s = s.Where(where)
+		}
+	}
+
+	// Filter by resolution in megapixels?
+	if where := CompareFloat("photos.photo_resolution", f.MP); f.MP != "" && where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by image resolution?
+	if where := CompareResolution("files.file_width", "files.file_height", f.Res); f.Res != "" && where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by star rating?
+	if where := CompareFloat("photos.photo_rating", f.Rating); f.Rating != "" && where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by file size?
+	if where := CompareBytes("files.file_size", f.Size); f.Size != "" && where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by aperture f-number?
+	if where := CompareRange("photos.photo_f_number", f.F, 0.01); f.F != "" && where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by ISO sensitivity?
+	if where := CompareRange("photos.photo_iso", f.Iso, 0); f.Iso != "" && where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by focal length in mm?
+	if where := CompareRange("photos.photo_focal_length", f.Mm, 0); f.Mm != "" && where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by exposure time?
+	if where := CompareExposure("photos.photo_exposure", f.Shutter); f.Shutter != "" && where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by altitude in meters?
+	if where := CompareRange("photos.photo_altitude", f.Alt, 0); f.Alt != "" && where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by camera direction in degrees?
+	if where := CompareRange("photos.photo_heading", f.Heading, 0.001); f.Heading != "" && where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by speed in km/h?
+	if where := CompareRange("photos.photo_speed", f.Speed, 0.001); f.Speed != "" && where != "" {
+		s = s.Where(where)
+	}
+
 	if f.Stackable {
 		s = s.Where("photos.photo_stack > -1")
 	} else if f.Unstacked {
@@ -432,6 +564,12 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		s = s.Where(where, values...)
 	}
 
+	// Filter by generated caption?
+	if f.Caption != "" {
+		where, values := OrLike("d.caption", f.Caption)
+		s = s.Where("photos.id IN (SELECT d.photo_id FROM details d WHERE "+where+")", values...)
+	}
+
 	// Filter by file hash?
 	if f.Hash != "" {
 		s = s.Where("files.file_hash IN (?)", strings.Split(strings.ToLower(f.Hash), txt.Or))
@@ -463,24 +601,48 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		f.Dist = 5000
 	}
 
-	// Filter by approx distance to coordinates:
-	if f.Lat != 0 {
-		latMin := f.Lat - Radius*float32(f.Dist)
-		latMax := f.Lat + Radius*float32(f.Dist)
-		s = s.Where("photos.photo_lat BETWEEN ? AND ?", latMin, latMax)
+	geoFilter := GeoFilter{Latlng: f.Latlng, Polygon: f.Polygon, Lat: f.Lat, Lng: f.Lng, Radius: f.Radius}
+
+	// Filter by viewport bounds, polygon, or exact distance to coordinates:
+	if s, err = filterGeo(s, geoFilter); err != nil {
+		return PhotoResults{}, 0, err
 	}
-	if f.Lng != 0 {
-		lngMin := f.Lng - Radius*float32(f.Dist)
-		lngMax := f.Lng + Radius*float32(f.Dist)
-		s = s.Where("photos.photo_lng BETWEEN ? AND ?", lngMin, lngMax)
+
+	// Filter by approx distance to coordinates, unless the exact distance is limited by radius:
+	if !geoFilter.Exact() {
+		if f.Lat != 0 {
+			latMin := f.Lat - Radius*float32(f.Dist)
+			latMax := f.Lat + Radius*float32(f.Dist)
+			s = s.Where("photos.photo_lat BETWEEN ? AND ?", latMin, latMax)
+		}
+		if f.Lng != 0 {
+			lngMin := f.Lng - Radius*float32(f.Dist)
+			lngMax := f.Lng + Radius*float32(f.Dist)
+			s = s.Where("photos.photo_lng BETWEEN ? AND ?", lngMin, lngMax)
+		}
 	}
 
 	if !f.Before.IsZero() {
 		s = s.Where("photos.taken_at <= ?", f.Before.Format("2006-01-02"))
+		s = s.Where(YearBefore(f.Before))
+
+		if where := FileYearBefore(f.Before); where != "" {
+			s = s.Where(where)
+		}
 	}
 
 	if !f.After.IsZero() {
 		s = s.Where("photos.taken_at >= ?", f.After.Format("2006-01-02"))
+		s = s.Where(YearAfter(f.After))
+
+		if where := FileYearAfter(f.After); where != "" {
+			s = s.Where(where)
+		}
+	}
+
+	// Find photos taken on a date or within a date range?
+	if where := TakenRange(f.Taken); where != "" {
+		s = s.Where(where)
 	}
 
 	// Find stacks only?
@@ -508,6 +670,78 @@ func Photos(f form.SearchPhotos) (results PhotoResults, count int, err error) {
 		}
 	}
 
+	// Exclude photos matching negated filters like "-label:cat"?
+	if f.NotLabel != "" {
+		var excludedLabels []entity.Label
+		var excludedIds []uint
+
+		if err := Db().Where(AnySlug("label_slug", f.NotLabel, txt.Or)).Or(AnySlug("custom_slug", f.NotLabel, txt.Or)).Find(&excludedLabels).Error; err != nil {
+			return PhotoResults{}, 0, err
+		}
+
+		for _, l := range excludedLabels {
+			excludedIds = append(excludedIds, l.ID)
+
+			Db().Where("category_id = ?", l.ID).Find(&categories)
+
+			for _, category := range categories {
+				excludedIds = append(excludedIds, category.LabelID)
+			}
+		}
+
+		if len(excludedIds) > 0 {
+			s = s.Where("photos.id NOT IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", excludedIds)
+		}
+	}
+
+	if f.NotTag != "" {
+		if tags := strings.Split(strings.ToLower(f.NotTag), txt.Or); rnd.ContainsUIDs(tags, 't') {
+			s = s.Where(fmt.Sprintf("photos.photo_uid NOT IN (SELECT photo_uid FROM %s WHERE tag_uid IN (?))",
+				entity.PhotoTag{}.TableName()), tags)
+		} else {
+			s = s.Where(fmt.Sprintf("photos.photo_uid NOT IN (SELECT pt.photo_uid FROM %s pt JOIN %s t ON t.tag_uid = pt.tag_uid WHERE (?))",
+				entity.PhotoTag{}.TableName(), entity.Tag{}.TableName()), gorm.Expr(AnySlug("t.tag_slug", f.NotTag, txt.Or)))
+		}
+	}
+
+	if f.NotSubject != "" {
+		if subjects := strings.Split(strings.ToLower(f.NotSubject), txt.Or); rnd.ContainsUIDs(subjects, 'j') {
+			s = s.Where(fmt.Sprintf("photos.id NOT IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 WHERE subj_uid IN (?))",
+				entity.Marker{}.TableName()), subjects)
+		} else {
+			s = s.Where(fmt.Sprintf("photos.id NOT IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 JOIN %s s ON s.subj_uid = m.subj_uid WHERE (?))",
+				entity.Marker{}.TableName(), entity.Subject{}.TableName()), gorm.Expr(AnySlug("s.subj_slug", strings.ToLower(f.NotSubject), txt.Or)))
+		}
+	}
+
+	if f.NotAlbum != "" {
+		if albums := strings.Split(f.NotAlbum, txt.Or); rnd.ContainsUIDs(albums, 'a') {
+			s = s.Where("photos.photo_uid NOT IN (SELECT pa.photo_uid FROM photos_albums pa WHERE pa.hidden = 0 AND pa.album_uid IN (?))", albums)
+		} else {
+			for _, where := range LikeAnyWord("a.album_title", f.NotAlbum) {
+				s = s.Where("photos.photo_uid NOT IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = 0 WHERE (?))", gorm.Expr(where))
+			}
+		}
+	}
+
+	if f.NotKeywords != "" {
+		for _, where := range LikeAnyWord("k.keyword", f.NotKeywords) {
+			s = s.Where("photos.id NOT IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
+		}
+	}
+
+	if f.NotColor != "" {
+		s = s.Where("files.file_main_color NOT IN (?)", strings.Split(strings.ToLower(f.NotColor), txt.Or))
+	}
+
+	if f.NotCountry != "" {
+		s = s.Where("photos.photo_country NOT IN (?)", strings.Split(strings.ToLower(f.NotCountry), txt.Or))
+	}
+
+	if f.NotType != "" {
+		s = s.Where("photos.photo_type NOT IN (?)", strings.Split(strings.ToLower(f.NotType), txt.Or))
+	}
+
 	if err := s.Scan(&results).Error; err != nil {
 		return results, 0, err
 	}
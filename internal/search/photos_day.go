@@ -0,0 +1,76 @@
+package search
+
+import (
+	"errors"
+	"hash/fnv"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// ErrNoPhotoOfDay is returned if no photo matches the search filter.
+var ErrNoPhotoOfDay = errors.New("no photos found")
+
+// photoOfDayPageSize is the number of results per query when counting the matching photos.
+var photoOfDayPageSize = MaxResults
+
+// PhotoOfDayIndex returns the result index of the featured photo for a day and seed.
+// The index is the same for the same arguments, so that every client shows the same photo.
+func PhotoOfDayIndex(day time.Time, seed string, count int) int {
+	if count < 1 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(day.Format(form.PhotoOfDayDate) + "/" + seed))
+
+	return int(h.Sum64() % uint64(count))
+}
+
+// PhotoOfDay returns the featured photo for a day, selected deterministically from all photos
+// matching the search form. Private photos and videos are excluded.
+func PhotoOfDay(f form.SearchPhotos, day time.Time, seed string) (result Photo, err error) {
+	f.Primary = true
+	f.Public = true
+	f.Private = false
+	f.Photo = true
+	f.Merged = false
+
+	// A stable sort order is required for selecting the same photo again.
+	f.Order = entity.SortOrderOldest
+
+	// Count all matching photos, since the number of results per query is limited.
+	var count int
+
+	for f.Count, f.Offset = photoOfDayPageSize, 0; ; f.Offset += photoOfDayPageSize {
+		_, n, err := Photos(f)
+
+		if err != nil {
+			return result, err
+		}
+
+		count += n
+
+		if n < photoOfDayPageSize {
+			break
+		}
+	}
+
+	if count == 0 {
+		return result, ErrNoPhotoOfDay
+	}
+
+	// Fetch the selected photo only.
+	f.Count, f.Offset = 1, PhotoOfDayIndex(day, seed, count)
+
+	photos, n, err := Photos(f)
+
+	if err != nil {
+		return result, err
+	} else if n == 0 {
+		return result, ErrNoPhotoOfDay
+	}
+
+	return photos[0], nil
+}
@@ -0,0 +1,92 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotoOfDayIndex(t *testing.T) {
+	day := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 0, PhotoOfDayIndex(day, "", 0))
+	assert.Equal(t, 0, PhotoOfDayIndex(day, "", 1))
+	assert.Equal(t, PhotoOfDayIndex(day, "", 100), PhotoOfDayIndex(day, "", 100))
+
+	for i := 0; i < 30; i++ {
+		n := PhotoOfDayIndex(day.AddDate(0, 0, i), "kitchen", 7)
+		assert.GreaterOrEqual(t, n, 0)
+		assert.Less(t, n, 7)
+	}
+
+	// Different days and seeds should not always select the same photo.
+	indexes := make(map[int]bool)
+
+	for i := 0; i < 10; i++ {
+		indexes[PhotoOfDayIndex(day.AddDate(0, 0, i), "", 1000)] = true
+		indexes[PhotoOfDayIndex(day, string(rune('a'+i)), 1000)] = true
+	}
+
+	assert.Greater(t, len(indexes), 10)
+}
+
+func TestPhotoOfDay(t *testing.T) {
+	day := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Default", func(t *testing.T) {
+		photo, err := PhotoOfDay(form.SearchPhotos{}, day, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photo.PhotoUID)
+		assert.False(t, photo.PhotoPrivate)
+		assert.NotEqual(t, entity.TypeVideo, photo.PhotoType)
+
+		again, err := PhotoOfDay(form.SearchPhotos{}, day, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, photo.PhotoUID, again.PhotoUID)
+	})
+	t.Run("Filter", func(t *testing.T) {
+		photo, err := PhotoOfDay(form.SearchPhotos{Query: "favorite:true"}, day, "seed")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, photo.PhotoFavorite)
+	})
+	t.Run("Paginated", func(t *testing.T) {
+		expected, err := PhotoOfDay(form.SearchPhotos{}, day, "pages")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		photoOfDayPageSize = 2
+
+		defer func() { photoOfDayPageSize = MaxResults }()
+
+		photo, err := PhotoOfDay(form.SearchPhotos{}, day, "pages")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, expected.PhotoUID, photo.PhotoUID)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := PhotoOfDay(form.SearchPhotos{Query: "title:xxxnotfoundxxx"}, day, "")
+
+		assert.Equal(t, ErrNoPhotoOfDay, err)
+	})
+}
@@ -0,0 +1,94 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotos_Exclude(t *testing.T) {
+	search := func(q string) PhotoResults {
+		f := form.SearchPhotos{Query: q, Count: 5000}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return photos
+	}
+
+	uids := func(photos PhotoResults) map[string]bool {
+		result := make(map[string]bool, len(photos))
+
+		for _, p := range photos {
+			result[p.PhotoUID] = true
+		}
+
+		return result
+	}
+
+	all := search("")
+
+	t.Run("Label", func(t *testing.T) {
+		excluded := uids(search("label:landscape"))
+		photos := search("-label:landscape")
+
+		assert.NotEmpty(t, excluded)
+		assert.Less(t, len(photos), len(all))
+
+		for _, p := range photos {
+			assert.False(t, excluded[p.PhotoUID], p.PhotoUID)
+		}
+	})
+	t.Run("LabelGroup", func(t *testing.T) {
+		photos := search("-(label:landscape | label:flower)")
+
+		assert.LessOrEqual(t, len(photos), len(search("-label:landscape")))
+	})
+	t.Run("Subject", func(t *testing.T) {
+		excluded := uids(search("person:john-doe"))
+		photos := search("-person:john-doe|jane-doe")
+
+		for _, p := range photos {
+			assert.False(t, excluded[p.PhotoUID], p.PhotoUID)
+		}
+
+		assert.Equal(t, len(photos), len(search("-person:jqu0xs11qekk9jx8|jqy1y111h1njaaab")))
+	})
+	t.Run("Album", func(t *testing.T) {
+		excluded := uids(search("album:at9lxuqxpogaaba9"))
+		photos := search("-album:at9lxuqxpogaaba9")
+
+		assert.NotEmpty(t, excluded)
+		assert.Equal(t, len(uids(all)), len(uids(photos))+len(excluded))
+
+		for _, p := range photos {
+			assert.False(t, excluded[p.PhotoUID], p.PhotoUID)
+		}
+
+		assert.NotEmpty(t, search("-album:\"Christmas 2030\""))
+	})
+	t.Run("Keywords", func(t *testing.T) {
+		excluded := uids(search("keywords:bridge"))
+		photos := search("-bridge")
+
+		assert.NotEmpty(t, excluded)
+
+		for _, p := range photos {
+			assert.False(t, excluded[p.PhotoUID], p.PhotoUID)
+		}
+	})
+	t.Run("ColorCountryType", func(t *testing.T) {
+		excluded := uids(search("color:red|blue"))
+
+		for _, p := range search("-color:(red|blue) -country:de -type:video") {
+			assert.False(t, excluded[p.PhotoUID], p.PhotoUID)
+			assert.NotEqual(t, "de", p.PhotoCountry)
+			assert.NotEqual(t, "video", p.PhotoType)
+		}
+	})
+}
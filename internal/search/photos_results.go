@@ -33,7 +33,9 @@ type Photo struct {
 	PhotoCountry     string        `json:"Country"`
 	PhotoStack       int8          `json:"Stack"`
 	PhotoFavorite    bool          `json:"Favorite"`
+	PhotoRating      int           `json:"Rating"`
 	PhotoPrivate     bool          `json:"Private"`
+	PhotoPending     bool          `json:"Pending"`
 	PhotoIso         int           `json:"Iso"`
 	PhotoFocalLength int           `json:"FocalLength"`
 	PhotoFNumber     float32       `json:"FNumber"`
@@ -44,6 +46,7 @@ type Photo struct {
 	PhotoColor       uint8         `json:"Color"`
 	PhotoScan        bool          `json:"Scan"`
 	PhotoPanorama    bool          `json:"Panorama"`
+	PhotoProjection  string        `json:"Projection,omitempty"`
 	CameraID         uint          `json:"CameraID"` // Camera
 	CameraSerial     string        `json:"CameraSerial,omitempty"`
 	CameraSrc        string        `json:"CameraSrc,omitempty"`
@@ -53,6 +56,8 @@ type Photo struct {
 	LensModel        string        `json:"LensModel"`
 	LensMake         string        `json:"LensMake"`
 	PhotoAltitude    int           `json:"Altitude,omitempty"`
+	PhotoHeading     float32       `json:"Heading,omitempty"`
+	PhotoSpeed       float32       `json:"Speed,omitempty"`
 	PhotoLat         float32       `json:"Lat"`
 	PhotoLng         float32       `json:"Lng"`
 	CellID           string        `json:"CellID"` // Cell
@@ -69,6 +74,7 @@ type Photo struct {
 	FileRoot         string        `json:"FileRoot"`
 	FileName         string        `json:"FileName"`
 	FileHash         string        `json:"Hash"`
+	FileEditHash     string        `json:"EditHash,omitempty"`
 	FileWidth        int           `json:"Width"`
 	FileHeight       int           `json:"Height"`
 	FilePortrait     bool          `json:"Portrait"`
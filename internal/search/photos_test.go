@@ -4,6 +4,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/entity"
 
 	"github.com/stretchr/testify/assert"
@@ -140,7 +141,7 @@ func TestPhotos(t *testing.T) {
 		frm.Query = ""
 		frm.Count = 10
 		frm.Offset = 0
-		frm.Geo = true
+		frm.Geo = "true"
 
 		photos, _, err := Photos(frm)
 
@@ -150,13 +151,282 @@ func TestPhotos(t *testing.T) {
 
 		assert.LessOrEqual(t, 2, len(photos))
 	})
+	t.Run("form.location false", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = ""
+		frm.Count = 10
+		frm.Offset = 0
+		frm.Geo = "false"
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.Equal(t, "zz", p.CellID)
+		}
+	})
+	t.Run("form.ratio mp res size", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = ""
+		frm.Count = 10
+		frm.Offset = 0
+		frm.Ratio = "landscape"
+		frm.MP = ">0"
+		frm.Res = ">100"
+		frm.Size = ">1KB"
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.Greater(t, p.FileAspectRatio, float32(1))
+			assert.Greater(t, p.FileSize, int64(1000))
+		}
+	})
+	t.Run("form.f iso mm shutter", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "f:4..5.6 iso:<=200 mm:35..50 shutter:<1/60"
+		frm.Count = 10
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.GreaterOrEqual(t, p.PhotoFNumber, float32(4))
+			assert.LessOrEqual(t, p.PhotoIso, 200)
+			assert.GreaterOrEqual(t, p.PhotoFocalLength, 35)
+			assert.LessOrEqual(t, p.PhotoFocalLength, 50)
+		}
+	})
+	t.Run("form.shutter", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "shutter:1/80"
+		frm.Count = 10
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Equal(t, "1/80", p.PhotoExposure)
+		}
+	})
+	t.Run("form.alt", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "alt:>2"
+		frm.Count = 10
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Greater(t, p.PhotoAltitude, 2)
+		}
+	})
+	t.Run("form.heading speed", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "heading:180..270 speed:1.4"
+		frm.Count = 10
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Equal(t, float32(235.3), p.PhotoHeading)
+			assert.Equal(t, float32(1.4), p.PhotoSpeed)
+		}
+	})
+	t.Run("form.rating", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "rating:>=3"
+		frm.Count = 10
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.GreaterOrEqual(t, p.PhotoRating, 3)
+		}
+	})
+	t.Run("form.tag", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "tag:to-print"
+		frm.Count = 10
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Contains(t, []string{"pt9jtdre2lvl0y11", "pt9jtdre2lvl0y12"}, p.PhotoUID)
+		}
+	})
+	t.Run("form.tag and uid", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Tag = "to-print&tt9lxuqxpogaaba2"
+		frm.Count = 10
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, len(photos))
+		assert.Equal(t, "pt9jtdre2lvl0y11", photos[0].PhotoUID)
+	})
+	t.Run("form.-tag", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "-tag:for-blog"
+		frm.Count = 5000
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.NotEqual(t, "pt9jtdre2lvl0y11", p.PhotoUID)
+		}
+	})
+	t.Run("form.taken range", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "taken:2016-11..2016-12"
+		frm.Count = 10
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Equal(t, 2016, p.PhotoYear)
+			assert.GreaterOrEqual(t, p.PhotoMonth, 11)
+		}
+	})
+	t.Run("form.taken month", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "taken:2016-11"
+		frm.Count = 10
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Equal(t, 2016, p.PhotoYear)
+			assert.Equal(t, 11, p.PhotoMonth)
+		}
+	})
+	t.Run("form.taken before", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "taken:..2000"
+		frm.Count = 10
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Greater(t, p.PhotoYear, 0)
+			assert.LessOrEqual(t, p.PhotoYear, 2000)
+		}
+	})
+	t.Run("form.has edits", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = ""
+		frm.Count = 10
+		frm.Offset = 0
+		frm.Has = "edits"
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
 	t.Run("form.location true and keyword", func(t *testing.T) {
 		var frm form.SearchPhotos
 
 		frm.Query = "bridge"
 		frm.Count = 10
 		frm.Offset = 0
-		frm.Geo = true
+		frm.Geo = "true"
 		frm.Error = false
 
 		photos, _, err := Photos(frm)
@@ -258,6 +528,24 @@ func TestPhotos(t *testing.T) {
 		}
 		assert.LessOrEqual(t, 1, len(photos))
 	})
+	t.Run("search for pending", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = ""
+		f.Count = 5000
+		f.Offset = 0
+		f.Pending = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.True(t, p.PhotoPending)
+		}
+	})
 	t.Run("search for quality", func(t *testing.T) {
 		var f form.SearchPhotos
 
@@ -410,6 +698,29 @@ func TestPhotos(t *testing.T) {
 		//t.Logf("results: %+v", photos)
 		assert.Equal(t, 1, len(photos))
 	})
+	t.Run("form.person multiple", func(t *testing.T) {
+		var f form.SearchPhotos
+		f.Query = "person:jqy1y111h1njaaac"
+		f.Count = 10
+		f.Offset = 0
+
+		single, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f.Query = "person:jqy1y111h1njaaac person:jqy1y111h1njaaad"
+
+		both, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(both), 1)
+		assert.Less(t, len(both), len(single))
+	})
 	t.Run("form.subjects", func(t *testing.T) {
 		var f form.SearchPhotos
 		f.Query = "subjects:John"
@@ -682,6 +993,22 @@ func TestPhotos(t *testing.T) {
 		assert.LessOrEqual(t, 1, len(photos))
 	})
 
+	t.Run("search for translated labels", func(t *testing.T) {
+		classify.SetLocale("de")
+		defer classify.SetLocale("")
+
+		var f form.SearchPhotos
+		f.Label = "landschaft|blume"
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+	})
+
 	t.Run("search for primary files", func(t *testing.T) {
 		var f form.SearchPhotos
 		f.Primary = true
@@ -779,6 +1106,70 @@ func TestPhotos(t *testing.T) {
 			}
 		}
 	})
+	t.Run("search caption", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		details := entity.Details{PhotoID: 1000000}
+		details.SetCaption("a frog sitting in a lake", entity.SrcImage)
+
+		if err := details.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.Db().Delete(&details)
+
+		frm.Query = "caption:\"a frog*\""
+		frm.Count = 10
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, len(photos))
+		assert.Equal(t, uint(1000000), photos[0].ID)
+	})
+	t.Run("search projection", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "projection:equirectangular"
+		frm.Count = 10
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, r := range photos {
+			assert.Equal(t, entity.ProjEquirectangular, r.PhotoProjection)
+			assert.True(t, r.PhotoPanorama)
+		}
+	})
+	t.Run("search panorama:true", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "panorama:true"
+		frm.Count = 10
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, r := range photos {
+			assert.True(t, r.PhotoPanorama)
+		}
+	})
 	t.Run("search unstacked panoramas", func(t *testing.T) {
 		var frm form.SearchPhotos
 
@@ -1493,4 +1884,41 @@ func TestPhotos(t *testing.T) {
 		assert.Equal(t, 1, len(photos))
 		assert.Equal(t, photos[0].PhotoTitle, "Neckarbrücke")
 	})
+	t.Run("Shared", func(t *testing.T) {
+		frm := form.SearchPhotos{Album: "at9lxuqxpogaaba9", Public: true, Count: 10}
+
+		expected, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(expected))
+
+		frm = form.SearchPhotos{Query: "private:true public:false archived:true hidden:true review:true", Album: "at9lxuqxpogaaba9", Count: 10}
+		frm.SetShared([]string{"at9lxuqxpogaaba9"})
+
+		results, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, expected.UIDs(), results.UIDs())
+	})
+	t.Run("NotShared", func(t *testing.T) {
+		frm := form.SearchPhotos{Query: "album:at9lxuqxpogaaba8", Album: "at9lxuqxpogaaba9", Count: 10}
+		frm.SetShared([]string{"at9lxuqxpogaaba9"})
+
+		_, _, err := Photos(frm)
+
+		assert.ErrorIs(t, err, ErrNotShared)
+
+		frm = form.SearchPhotos{Count: 10}
+		frm.SetShared(nil)
+
+		_, _, err = Photos(frm)
+
+		assert.ErrorIs(t, err, ErrNotShared)
+	})
 }
@@ -0,0 +1,44 @@
+package search
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Redact removes the given metadata from a search result before it is shared.
+func (photo *Photo) Redact(fields []string) {
+	for _, field := range fields {
+		switch field {
+		case entity.RedactSerial:
+			photo.CameraSerial = ""
+		case entity.RedactGps:
+			photo.SetGeoPrivacy(entity.GeoStrip)
+		case entity.RedactFilenames:
+			photo.PhotoPath = ""
+			photo.PhotoName = ""
+			photo.OriginalName = ""
+			photo.FileName = ""
+		}
+	}
+
+	for i := range photo.Files {
+		photo.Files[i].Redact(fields)
+	}
+}
+
+// Redact removes the given metadata from all results before they are shared.
+func (m PhotoResults) Redact(fields []string) {
+	for i := range m {
+		m[i].Redact(fields)
+	}
+}
+
+// Redact returns no results if coordinates must be removed before they are shared.
+func (m GeoResults) Redact(fields []string) GeoResults {
+	for _, field := range fields {
+		if field == entity.RedactGps {
+			return GeoResults{}
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,49 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestPhoto_Redact(t *testing.T) {
+	newPhoto := func() Photo {
+		return Photo{PhotoPath: "2020/berlin", PhotoName: "IMG_1234", OriginalName: "Berlin/IMG_1234.jpg",
+			FileName: "2020/berlin/IMG_1234.jpg", CameraSerial: "123456", PhotoLat: 52.51634, PhotoLng: 13.37789,
+			PlaceCity: "Berlin", Files: []entity.File{{FileName: "2020/berlin/IMG_1234.jpg", FileHash: "abc123", FileType: "jpg"}}}
+	}
+
+	t.Run("Serial", func(t *testing.T) {
+		photo := newPhoto()
+		photo.Redact([]string{entity.RedactSerial})
+		assert.Equal(t, "", photo.CameraSerial)
+		assert.Equal(t, "2020/berlin/IMG_1234.jpg", photo.FileName)
+		assert.Equal(t, float32(52.51634), photo.PhotoLat)
+	})
+	t.Run("Gps", func(t *testing.T) {
+		photo := newPhoto()
+		photo.Redact([]string{entity.RedactGps})
+		assert.Equal(t, float32(0), photo.PhotoLat)
+		assert.Equal(t, float32(0), photo.PhotoLng)
+		assert.Equal(t, "", photo.PlaceCity)
+		assert.Equal(t, "123456", photo.CameraSerial)
+	})
+	t.Run("Filenames", func(t *testing.T) {
+		results := PhotoResults{newPhoto()}
+		results.Redact([]string{entity.RedactFilenames})
+		assert.Equal(t, "", results[0].PhotoPath)
+		assert.Equal(t, "", results[0].PhotoName)
+		assert.Equal(t, "", results[0].OriginalName)
+		assert.Equal(t, "", results[0].FileName)
+		assert.Equal(t, "abc123.jpg", results[0].Files[0].FileName)
+	})
+}
+
+func TestGeoResults_Redact(t *testing.T) {
+	results := GeoResults{{PhotoUID: "pt9jtdre2lvl0yh7", PhotoLat: 52.51634, PhotoLng: 13.37789}}
+
+	assert.Len(t, results.Redact([]string{entity.RedactSerial}), 1)
+	assert.Len(t, results.Redact([]string{entity.RedactSerial, entity.RedactGps}), 0)
+}
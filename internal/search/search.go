@@ -32,6 +32,8 @@ https://docs.photoprism.app/developer-guide/
 package search
 
 import (
+	"context"
+
 	"github.com/jinzhu/gorm"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -64,6 +66,11 @@ func Db() *gorm.DB {
 	return entity.Db()
 }
 
+// DbContext returns a database connection instance that adds queries to the trace in the context.
+func DbContext(ctx context.Context) *gorm.DB {
+	return entity.DbContext(ctx)
+}
+
 // UnscopedDb returns an unscoped database connection instance.
 func UnscopedDb() *gorm.DB {
 	return entity.Db().Unscoped()
@@ -0,0 +1,23 @@
+package search
+
+import (
+	"errors"
+)
+
+// ErrNotShared is returned if a limited search does not select one of the albums it is limited to.
+var ErrNotShared = errors.New("album not shared")
+
+// sharedAlbum tests if the album uid is one of the shared album uids.
+func sharedAlbum(shared []string, uid string) bool {
+	if uid == "" {
+		return false
+	}
+
+	for _, s := range shared {
+		if s == uid {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package search
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SimilarCandidates is the maximum number of embeddings compared with the photo.
+const SimilarCandidates = 1000
+
+// Similar returns up to count photos that look similar to the photo with the UID, in descending order of similarity.
+func Similar(uid string, count int, public bool) (results PhotoResults, err error) {
+	start := time.Now()
+
+	if uid == "" {
+		return results, fmt.Errorf("missing photo uid")
+	}
+
+	if count <= 0 || count > MaxResults {
+		count = MaxResults
+	}
+
+	var embedding entity.PhotoEmbedding
+
+	if err = Db().Where("photo_uid = ?", uid).First(&embedding).Error; err != nil {
+		return results, fmt.Errorf("photo has no embedding")
+	}
+
+	vector := embedding.Vector()
+
+	// Find candidates sharing at least one hash bucket, most matches first.
+	s := UnscopedDb().Table(entity.PhotoEmbeddingBucket{}.TableName()+" b").
+		Select("b.photo_id").
+		Joins("JOIN photos ON photos.id = b.photo_id AND photos.deleted_at IS NULL").
+		Where("b.bucket IN (?) AND b.photo_id <> ?", vector.Buckets(), embedding.PhotoID)
+
+	if public {
+		s = s.Where("photos.photo_private = 0")
+	}
+
+	var photoIds []uint
+
+	if err = s.Group("b.photo_id").Order("COUNT(*) DESC, b.photo_id").Limit(SimilarCandidates).Pluck("b.photo_id", &photoIds).Error; err != nil {
+		return results, err
+	} else if len(photoIds) == 0 {
+		return PhotoResults{}, nil
+	}
+
+	var candidates []entity.PhotoEmbedding
+
+	if err = Db().Where("photo_id IN (?)", photoIds).Find(&candidates).Error; err != nil {
+		return results, err
+	}
+
+	// Rank candidates by their actual similarity.
+	similarity := make(map[string]float64, len(candidates))
+
+	for _, c := range candidates {
+		similarity[c.PhotoUID] = vector.Similarity(c.Vector())
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return similarity[candidates[i].PhotoUID] > similarity[candidates[j].PhotoUID]
+	})
+
+	if len(candidates) > count {
+		candidates = candidates[:count]
+	}
+
+	uids := make([]string, len(candidates))
+
+	for i, c := range candidates {
+		uids[i] = c.PhotoUID
+	}
+
+	f := form.SearchPhotos{UID: strings.Join(uids, txt.Or), Primary: true, Count: len(uids)}
+
+	if results, _, err = Photos(f); err != nil {
+		return results, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return similarity[results[i].PhotoUID] > similarity[results[j].PhotoUID]
+	})
+
+	log.Debugf("photos: found %s similar to %s [%s]", english.Plural(len(results), "result", "results"), uid, time.Since(start))
+
+	return results, nil
+}
@@ -0,0 +1,78 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestSimilar(t *testing.T) {
+	probabilities := func(values ...float32) []float32 {
+		result := make([]float32, 100)
+		copy(result, values)
+		return result
+	}
+
+	photo := entity.PhotoFixtures.Get("19800101_000002_D640C559")
+	near := entity.PhotoFixtures.Get("Photo04")
+	private := entity.PhotoFixtures.Get("Photo06")
+
+	embeddings := map[*entity.Photo]classify.Embedding{
+		&photo:   classify.NewEmbedding(probabilities(0.7, 0.2, 0.1)),
+		&near:    classify.NewEmbedding(probabilities(0.68, 0.22, 0.1)),
+		&private: classify.NewEmbedding(probabilities(0.7, 0.2, 0.1)),
+	}
+
+	for p, e := range embeddings {
+		if err := entity.SavePhotoEmbedding(p.ID, p.PhotoUID, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer func() {
+		for p := range embeddings {
+			_ = entity.DeletePhotoEmbedding(p.ID)
+		}
+	}()
+
+	t.Run("All", func(t *testing.T) {
+		results, err := Similar(photo.PhotoUID, 10, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 2)
+		assert.Equal(t, private.PhotoUID, results[0].PhotoUID)
+		assert.Equal(t, near.PhotoUID, results[1].PhotoUID)
+	})
+	t.Run("Public", func(t *testing.T) {
+		results, err := Similar(photo.PhotoUID, 10, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, near.PhotoUID, results[0].PhotoUID)
+	})
+	t.Run("Count", func(t *testing.T) {
+		results, err := Similar(photo.PhotoUID, 1, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+	})
+	t.Run("NoEmbedding", func(t *testing.T) {
+		_, err := Similar("pt9jtdre2lvl0y25", 10, false)
+		assert.Error(t, err)
+
+		_, err = Similar("", 10, false)
+		assert.Error(t, err)
+	})
+}
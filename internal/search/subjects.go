@@ -1,6 +1,7 @@
 package search
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -15,6 +16,11 @@ import (
 
 // Subjects searches subjects and returns them.
 func Subjects(f form.SearchSubjects) (results SubjectResults, err error) {
+	return SubjectsContext(context.Background(), f)
+}
+
+// SubjectsContext searches subjects like Subjects, and adds the database queries to the trace in the context.
+func SubjectsContext(ctx context.Context, f form.SearchSubjects) (results SubjectResults, err error) {
 	if err := f.ParseQueryString(); err != nil {
 		return results, err
 	}
@@ -22,7 +28,7 @@ func Subjects(f form.SearchSubjects) (results SubjectResults, err error) {
 	subjTable := entity.Subject{}.TableName()
 
 	// Base query.
-	s := UnscopedDb().Table(subjTable).
+	s := DbContext(ctx).Unscoped().Table(subjTable).
 		Select(fmt.Sprintf("%s.*", subjTable))
 
 	// Limit result count.
@@ -0,0 +1,261 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Suggestion types.
+const (
+	SuggestLabel  = "label"
+	SuggestPerson = "person"
+	SuggestAlbum  = "album"
+	SuggestPlace  = "place"
+	SuggestCamera = "camera"
+)
+
+// SuggestTypes lists the supported suggestion types.
+var SuggestTypes = []string{SuggestLabel, SuggestPerson, SuggestAlbum, SuggestPlace, SuggestCamera}
+
+// Suggestion represents a search box completion.
+type Suggestion struct {
+	Type  string `json:"Type"`
+	UID   string `json:"UID,omitempty"`
+	Name  string `json:"Name"`
+	Count int    `json:"Count"`
+}
+
+// Suggestions represents a list of search box completions.
+type Suggestions []Suggestion
+
+// suggestNode represents a node of an in-memory suggestion trie.
+type suggestNode struct {
+	children map[rune]*suggestNode
+	items    []*Suggestion
+}
+
+// suggestTrie is a prefix tree that finds suggestions by the beginning of any word in their name.
+type suggestTrie struct {
+	root suggestNode
+}
+
+// Add adds a suggestion for each word of its name, so that "york" also finds "New York".
+func (t *suggestTrie) Add(s *Suggestion) {
+	name := strings.ToLower(s.Name)
+	start := true
+
+	for i, r := range name {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			if start {
+				t.insert(name[i:], s)
+			}
+
+			start = false
+		} else {
+			start = true
+		}
+	}
+}
+
+// insert adds a suggestion for the given key.
+func (t *suggestTrie) insert(key string, s *Suggestion) {
+	node := &t.root
+
+	for _, r := range key {
+		if node.children == nil {
+			node.children = make(map[rune]*suggestNode)
+		}
+
+		next, ok := node.children[r]
+
+		if !ok {
+			next = &suggestNode{}
+			node.children[r] = next
+		}
+
+		node = next
+	}
+
+	node.items = append(node.items, s)
+}
+
+// Find returns all suggestions with a word that starts with the prefix.
+func (t *suggestTrie) Find(prefix string) (results []*Suggestion) {
+	node := &t.root
+
+	for _, r := range strings.ToLower(prefix) {
+		if node = node.children[r]; node == nil {
+			return results
+		}
+	}
+
+	found := make(map[*Suggestion]bool)
+	stack := []*suggestNode{node}
+
+	for len(stack) > 0 {
+		node, stack = stack[len(stack)-1], stack[:len(stack)-1]
+
+		for _, s := range node.items {
+			if !found[s] {
+				found[s] = true
+				results = append(results, s)
+			}
+		}
+
+		for _, child := range node.children {
+			stack = append(stack, child)
+		}
+	}
+
+	return results
+}
+
+// suggestCache holds the suggestion tries, which are rebuilt on demand after the index has changed.
+var suggestCache = struct {
+	tries map[string]*suggestTrie
+	mutex sync.Mutex
+}{}
+
+// FlushSuggestions marks the search suggestions as outdated, so they are rebuilt when needed.
+func FlushSuggestions() {
+	suggestCache.mutex.Lock()
+	defer suggestCache.mutex.Unlock()
+
+	suggestCache.tries = nil
+}
+
+// suggestRow represents a suggestion query result.
+type suggestRow struct {
+	UID   string
+	Name  string
+	Make  string
+	Model string
+	Count int
+}
+
+// suggestRows returns the names and photo counts of all suggestions with the given type.
+func suggestRows(suggestType string) (rows []suggestRow, err error) {
+	switch suggestType {
+	case SuggestLabel:
+		err = UnscopedDb().Table(entity.Label{}.TableName()).
+			Select("label_uid AS uid, label_name AS name, photo_count AS count").
+			Where("deleted_at IS NULL AND photo_count > 0 AND label_priority >= 0").
+			Scan(&rows).Error
+	case SuggestPerson:
+		err = UnscopedDb().Table(entity.Subject{}.TableName()).
+			Select("subj_uid AS uid, subj_name AS name, photo_count AS count").
+			Where("deleted_at IS NULL AND subj_hidden = 0 AND subj_type = ? AND subj_name <> ''", entity.SubjPerson).
+			Scan(&rows).Error
+	case SuggestAlbum:
+		err = UnscopedDb().Table(entity.Album{}.TableName()).
+			Select("albums.album_uid AS uid, albums.album_title AS name, COUNT(pa.photo_uid) AS count").
+			Joins("LEFT JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.hidden = 0").
+			Where("albums.deleted_at IS NULL AND albums.album_type = ?", entity.AlbumDefault).
+			Group("albums.album_uid, albums.album_title").
+			Scan(&rows).Error
+	case SuggestPlace:
+		err = UnscopedDb().Table(entity.Place{}.TableName()).
+			Select("place_city AS name, SUM(photo_count) AS count").
+			Where("place_city <> '' AND id <> ?", entity.UnknownPlace.ID).
+			Group("place_city").
+			Scan(&rows).Error
+	case SuggestCamera:
+		err = UnscopedDb().Table("cameras").
+			Select("cameras.camera_slug AS uid, cameras.camera_name AS name, cameras.camera_make AS make, cameras.camera_model AS model, COUNT(photos.id) AS count").
+			Joins("JOIN photos ON photos.camera_id = cameras.id AND photos.deleted_at IS NULL").
+			Where("cameras.deleted_at IS NULL AND cameras.id <> ?", entity.UnknownCamera.ID).
+			Group("cameras.camera_slug, cameras.camera_name, cameras.camera_make, cameras.camera_model").
+			Scan(&rows).Error
+	}
+
+	return rows, err
+}
+
+// suggestTries returns the suggestion tries and builds them if needed.
+func suggestTries() (map[string]*suggestTrie, error) {
+	suggestCache.mutex.Lock()
+	defer suggestCache.mutex.Unlock()
+
+	if suggestCache.tries != nil {
+		return suggestCache.tries, nil
+	}
+
+	start := time.Now()
+	tries := make(map[string]*suggestTrie, len(SuggestTypes))
+
+	for _, t := range SuggestTypes {
+		rows, err := suggestRows(t)
+
+		if err != nil {
+			return tries, err
+		}
+
+		trie := &suggestTrie{}
+
+		for _, row := range rows {
+			name := row.Name
+
+			// Some cameras only have a make and model.
+			if name == "" {
+				name = strings.TrimSpace(row.Make + " " + row.Model)
+			}
+
+			if name != "" {
+				trie.Add(&Suggestion{Type: t, UID: row.UID, Name: name, Count: row.Count})
+			}
+		}
+
+		tries[t] = trie
+	}
+
+	suggestCache.tries = tries
+
+	log.Debugf("search: updated suggestions [%s]", time.Since(start))
+
+	return tries, nil
+}
+
+// Suggest returns completions for the search box that start with the prefix, sorted by photo count.
+// Results can optionally be limited to the given suggestion types.
+func Suggest(prefix string, limit int, types ...string) (results Suggestions, err error) {
+	if prefix = strings.TrimSpace(prefix); prefix == "" {
+		return results, nil
+	}
+
+	if len(types) == 0 {
+		types = SuggestTypes
+	}
+
+	tries, err := suggestTries()
+
+	if err != nil {
+		return results, err
+	}
+
+	for _, t := range types {
+		if trie, ok := tries[t]; ok {
+			for _, s := range trie.Find(prefix) {
+				results = append(results, *s)
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Count != results[j].Count {
+			return results[i].Count > results[j].Count
+		}
+
+		return strings.ToLower(results[i].Name) < strings.ToLower(results[j].Name)
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
@@ -0,0 +1,90 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestTrie(t *testing.T) {
+	trie := &suggestTrie{}
+
+	ny := &Suggestion{Type: SuggestPlace, Name: "New York", Count: 3}
+	newcastle := &Suggestion{Type: SuggestPlace, Name: "Newcastle", Count: 1}
+
+	trie.Add(ny)
+	trie.Add(newcastle)
+
+	assert.ElementsMatch(t, []*Suggestion{ny, newcastle}, trie.Find("new"))
+	assert.Equal(t, []*Suggestion{ny}, trie.Find("YOR"))
+	assert.Equal(t, []*Suggestion{ny}, trie.Find("new y"))
+	assert.Empty(t, trie.Find("castle"))
+	assert.Empty(t, trie.Find("x"))
+}
+
+func TestSuggest(t *testing.T) {
+	FlushSuggestions()
+
+	t.Run("Empty", func(t *testing.T) {
+		results, err := Suggest(" ", 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+	t.Run("Label", func(t *testing.T) {
+		results, err := Suggest("flow", 10, SuggestLabel)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+
+		for _, r := range results {
+			assert.Equal(t, SuggestLabel, r.Type)
+			assert.NotEmpty(t, r.UID)
+		}
+	})
+	t.Run("Album", func(t *testing.T) {
+		results, err := Suggest("berlin", 10, SuggestAlbum)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+		assert.Equal(t, "Berlin 2019", results[0].Name)
+	})
+	t.Run("Camera", func(t *testing.T) {
+		results, err := Suggest("canon", 10, SuggestCamera)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+	})
+	t.Run("Place", func(t *testing.T) {
+		results, err := Suggest("york", 10, SuggestPlace)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+		assert.Equal(t, "New york", results[0].Name)
+	})
+	t.Run("Limit", func(t *testing.T) {
+		results, err := Suggest("a", 2)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 2)
+		assert.GreaterOrEqual(t, results[0].Count, results[1].Count)
+	})
+}
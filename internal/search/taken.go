@@ -0,0 +1,107 @@
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TakenRangeSep separates the start and end date of a taken date range, e.g. "2019-06..2019-08".
+const TakenRangeSep = ".."
+
+// Photo dates as sortable numbers like 20190601, unknown months and days are expanded to the whole year or month.
+const (
+	takenMinSQL = "(photos.photo_year * 10000 + CASE WHEN photos.photo_month > 0 THEN photos.photo_month * 100 + " +
+		"CASE WHEN photos.photo_day > 0 THEN photos.photo_day ELSE 1 END ELSE 101 END)"
+	takenMaxSQL = "(photos.photo_year * 10000 + CASE WHEN photos.photo_month > 0 THEN photos.photo_month * 100 + " +
+		"CASE WHEN photos.photo_day > 0 THEN photos.photo_day ELSE 31 END ELSE 1231 END)"
+)
+
+// ParseTakenDate parses a partial date like "2020", "2020-07", or "2020-07-15" and returns it as sortable
+// number. Missing months and days are filled in so that the number marks the start or end of the period.
+func ParseTakenDate(s string, end bool) (date int, ok bool) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return 0, false
+	}
+
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '/' || r == '.' })
+
+	if len(parts) == 0 || len(parts) > 3 {
+		return 0, false
+	}
+
+	values := []int{0, 0, 0}
+	limits := [][2]int{{1, 9999}, {1, 12}, {1, 31}}
+
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+
+		if err != nil || n < limits[i][0] || n > limits[i][1] {
+			return 0, false
+		}
+
+		values[i] = n
+	}
+
+	if len(parts) < 2 {
+		if end {
+			values[1] = 12
+		} else {
+			values[1] = 1
+		}
+	}
+
+	if len(parts) < 3 {
+		if end {
+			values[2] = 31
+		} else {
+			values[2] = 1
+		}
+	}
+
+	return values[0]*10000 + values[1]*100 + values[2], true
+}
+
+// TakenRange returns a where condition that matches photos taken in a date range like "2019-06..2019-08",
+// or an empty string if invalid. Both ends are optional and may be partial dates, so that "2020-07" matches
+// the whole month and "2019-06.." all photos taken since June 2019. The condition is based on the local date,
+// so it does not depend on the time zone. Photos with unknown day or month are found if their period overlaps.
+func TakenRange(s string) (where string) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return ""
+	}
+
+	from, to := s, s
+
+	if i := strings.Index(s, TakenRangeSep); i >= 0 {
+		from, to = s[:i], s[i+len(TakenRangeSep):]
+	}
+
+	start, hasStart := ParseTakenDate(from, false)
+	end, hasEnd := ParseTakenDate(to, true)
+
+	// Return if a given date could not be parsed, or no date was given at all.
+	if !hasStart && strings.TrimSpace(from) != "" || !hasEnd && strings.TrimSpace(to) != "" || !hasStart && !hasEnd {
+		return ""
+	}
+
+	var cond []string
+
+	if hasStart {
+		cond = append(cond, fmt.Sprintf("photos.photo_year >= %d", start/10000))
+		cond = append(cond, fmt.Sprintf("%s >= %d", takenMaxSQL, start))
+	} else {
+		cond = append(cond, "photos.photo_year > 0")
+	}
+
+	if hasEnd {
+		cond = append(cond, fmt.Sprintf("photos.photo_year <= %d", end/10000))
+		cond = append(cond, fmt.Sprintf("%s <= %d", takenMinSQL, end))
+	}
+
+	return strings.Join(cond, " AND ")
+}
@@ -0,0 +1,58 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTakenDate(t *testing.T) {
+	t.Run("Year", func(t *testing.T) {
+		date, ok := ParseTakenDate("2020", false)
+		assert.True(t, ok)
+		assert.Equal(t, 20200101, date)
+		date, ok = ParseTakenDate("2020", true)
+		assert.True(t, ok)
+		assert.Equal(t, 20201231, date)
+	})
+	t.Run("Month", func(t *testing.T) {
+		date, ok := ParseTakenDate("2020-07", false)
+		assert.True(t, ok)
+		assert.Equal(t, 20200701, date)
+		date, ok = ParseTakenDate("2020/7", true)
+		assert.True(t, ok)
+		assert.Equal(t, 20200731, date)
+	})
+	t.Run("Day", func(t *testing.T) {
+		date, ok := ParseTakenDate("2020-07-15", true)
+		assert.True(t, ok)
+		assert.Equal(t, 20200715, date)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, s := range []string{"", "cat", "2020-13", "2020-07-32", "2020-07-15-01", "0"} {
+			_, ok := ParseTakenDate(s, false)
+			assert.False(t, ok, s)
+		}
+	})
+}
+
+func TestTakenRange(t *testing.T) {
+	t.Run("Range", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_year >= 2019 AND "+takenMaxSQL+" >= 20190601 AND photos.photo_year <= 2019 AND "+takenMinSQL+" <= 20190831", TakenRange("2019-06..2019-08"))
+	})
+	t.Run("Month", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_year >= 2020 AND "+takenMaxSQL+" >= 20200701 AND photos.photo_year <= 2020 AND "+takenMinSQL+" <= 20200731", TakenRange("2020-07"))
+	})
+	t.Run("OpenEnd", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_year >= 2019 AND "+takenMaxSQL+" >= 20190601", TakenRange("2019-06.."))
+	})
+	t.Run("OpenStart", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_year > 0 AND photos.photo_year <= 2010 AND "+takenMinSQL+" <= 20101231", TakenRange("..2010"))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, "", TakenRange(""))
+		assert.Equal(t, "", TakenRange(".."))
+		assert.Equal(t, "", TakenRange("cat"))
+		assert.Equal(t, "", TakenRange("2019..cat"))
+	})
+}
@@ -0,0 +1,51 @@
+package search
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// YearBefore returns a where condition on the photo year that matches all photos taken before the
+// given date. It allows the database to skip year partitions, the condition itself is less strict than
+// the date, since the year is based on the local time.
+func YearBefore(t time.Time) string {
+	return yearBefore("photos", t)
+}
+
+// YearAfter returns a where condition on the photo year that matches all photos taken after the given
+// date, including photos with an unknown year.
+func YearAfter(t time.Time) string {
+	return yearAfter("photos", t)
+}
+
+// FileYearBefore returns the same condition as YearBefore for the files table. Since only MariaDB keeps a
+// copy of the photo year in the files table to partition it, the result is empty for other dialects.
+func FileYearBefore(t time.Time) string {
+	if !entity.IsDialect(entity.MySQL) {
+		return ""
+	}
+
+	return yearBefore("files", t)
+}
+
+// FileYearAfter returns the same condition as YearAfter for the files table, or an empty string
+// if the dialect is not MariaDB.
+func FileYearAfter(t time.Time) string {
+	if !entity.IsDialect(entity.MySQL) {
+		return ""
+	}
+
+	return yearAfter("files", t)
+}
+
+// yearBefore returns the YearBefore condition for the given table.
+func yearBefore(table string, t time.Time) string {
+	return fmt.Sprintf("%s.photo_year <= %d", table, t.Year()+1)
+}
+
+// yearAfter returns the YearAfter condition for the given table.
+func yearAfter(table string, t time.Time) string {
+	return fmt.Sprintf("(%s.photo_year >= %d OR %s.photo_year = %d)", table, t.Year()-1, table, entity.UnknownYear)
+}
@@ -0,0 +1,34 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYearBefore(t *testing.T) {
+	assert.Equal(t, "photos.photo_year <= 2017", YearBefore(time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestYearAfter(t *testing.T) {
+	assert.Equal(t, "(photos.photo_year >= 2012 OR photos.photo_year = -1)", YearAfter(time.Date(2013, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestFileYearBefore(t *testing.T) {
+	t.Run("SQLite", func(t *testing.T) {
+		assert.Equal(t, "", FileYearBefore(time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)))
+	})
+	t.Run("MySQL", func(t *testing.T) {
+		assert.Equal(t, "files.photo_year <= 2017", yearBefore("files", time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)))
+	})
+}
+
+func TestFileYearAfter(t *testing.T) {
+	t.Run("SQLite", func(t *testing.T) {
+		assert.Equal(t, "", FileYearAfter(time.Date(2013, 1, 1, 0, 0, 0, 0, time.UTC)))
+	})
+	t.Run("MySQL", func(t *testing.T) {
+		assert.Equal(t, "(files.photo_year >= 2012 OR files.photo_year = -1)", yearAfter("files", time.Date(2013, 1, 1, 0, 0, 0, 0, time.UTC)))
+	})
+}
@@ -6,15 +6,23 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
 )
 
+// BasicAuthExpires is the time after which cached credentials are checked again, so that disabled or
+// removed users and changed passwords take effect without restarting the server.
+const BasicAuthExpires = time.Minute
+
 var basicAuth = struct {
-	user  map[string]entity.User
+	user  *gc.Cache
 	mutex sync.RWMutex
-}{user: make(map[string]entity.User)}
+}{user: gc.New(BasicAuthExpires, 10*time.Minute)}
 
 func GetCredentials(c *gin.Context) (username, password, raw string) {
 	data := c.GetHeader("Authorization")
@@ -52,8 +60,8 @@ func BasicAuth() gin.HandlerFunc {
 		basicAuth.mutex.Lock()
 		defer basicAuth.mutex.Unlock()
 
-		if user, ok := basicAuth.user[raw]; ok {
-			c.Set(gin.AuthUserKey, user.UserUID)
+		if hit, ok := basicAuth.user.Get(raw); ok {
+			c.Set(gin.AuthUserKey, hit.(entity.User).UserUID)
 			return
 		}
 
@@ -63,13 +71,14 @@ func BasicAuth() gin.HandlerFunc {
 			invalid = user.InvalidPassword(password)
 		}
 
-		if user == nil || invalid {
+		// Guests may not manage files, as they must only see the albums that have been shared with them.
+		if user == nil || invalid || acl.Permissions.Deny(acl.ResourceWebDAV, user.Role(), acl.ActionDefault) {
 			c.Header("WWW-Authenticate", realm)
 			c.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 
-		basicAuth.user[raw] = *user
+		basicAuth.user.SetDefault(raw, *user)
 
 		c.Set(gin.AuthUserKey, user.UserUID)
 	}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestBasicAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	app := gin.New()
+	app.Group(WebDAVOriginals, BasicAuth()).GET("/*path", func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(gin.AuthUserKey))
+	})
+
+	request := func(username, password string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", WebDAVOriginals+"/", nil)
+		req.SetBasicAuth(username, password)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Admin", func(t *testing.T) {
+		r := request("alice", "Alice123!")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "uqxetse3cy5eo9z2", r.Body.String())
+	})
+	t.Run("InvalidPassword", func(t *testing.T) {
+		r := request("alice", "wrong")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		assert.NotEmpty(t, r.Header().Get("WWW-Authenticate"))
+	})
+	t.Run("Guest", func(t *testing.T) {
+		guest := entity.FindUserByName("grandma")
+
+		if guest == nil {
+			t.Fatal("guest not found")
+		} else if err := guest.SetPassword("Grandma123!"); err != nil {
+			t.Fatal(err)
+		}
+
+		r := request("grandma", "Grandma123!")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		assert.NotEmpty(t, r.Header().Get("WWW-Authenticate"))
+	})
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/api"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/dlna"
+)
+
+// StartDLNA starts the DLNA media server on a separate port, so that it is not exposed
+// through the web server and only accepts requests from the local network.
+func StartDLNA(ctx context.Context, conf *config.Config) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Error(err)
+		}
+	}()
+
+	router := gin.New()
+	router.Use(Logger(), Recovery(), LocalNetwork())
+
+	registerDLNARoutes(router, conf)
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", conf.DLNAPort()),
+		Handler: router,
+	}
+
+	go func() {
+		log.Infof("dlna: starting media server at %s", server.Addr)
+
+		if err := server.ListenAndServe(); err != nil {
+			if err == http.ErrServerClosed {
+				log.Info("dlna: media server shutdown complete")
+			} else {
+				log.Errorf("dlna: media server closed unexpect: %s", err)
+			}
+		}
+	}()
+
+	<-ctx.Done()
+	log.Info("dlna: shutting down media server")
+
+	if err := server.Close(); err != nil {
+		log.Errorf("dlna: media server shutdown failed: %v", err)
+	}
+}
+
+// registerDLNARoutes registers the device description, control endpoints, and media routes.
+func registerDLNARoutes(router *gin.Engine, conf *config.Config) {
+	dlna.Register(router.Group(dlna.Uri), conf)
+
+	media := router.Group(config.ApiUri)
+	{
+		api.GetThumb(media)
+		api.GetVideo(media)
+	}
+}
+
+// LocalNetwork rejects requests from outside the local network, and marks the others
+// so that only the DLNA token is accepted for loading photos and videos.
+func LocalNetwork() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !dlna.LocalClient(c.Request.RemoteAddr) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		c.Set(api.DLNAKey, true)
+		c.Next()
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
@@ -30,8 +33,21 @@ func Logger() gin.HandlerFunc {
 			path = path + "?" + raw
 		}
 
+		entry := logrus.NewEntry(log)
+
+		// Add request details as separate fields to structured logs.
+		if event.JsonLog() {
+			entry = entry.WithFields(logrus.Fields{
+				event.FieldComponent: "http",
+				event.FieldDuration:  latency.Seconds(),
+				"method":             method,
+				"path":               sanitize.Log(path),
+				"status":             statusCode,
+			})
+		}
+
 		// Use debug level to keep production logs clean.
-		log.Debugf("http: %s %s (%3d) [%v]",
+		entry.Debugf("http: %s %s (%3d) [%v]",
 			method,
 			sanitize.Log(path),
 			statusCode,
@@ -44,15 +44,22 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	{
 		// Config options.
 		api.GetConfig(v1)
+		api.GetCounts(v1)
 		api.GetConfigOptions(v1)
 		api.SaveConfigOptions(v1)
+		api.RotateTokens(v1)
+		api.GetWorkers(v1)
+		api.UpdateWorkers(v1)
 
 		// User profile and settings.
 		api.GetSettings(v1)
 		api.SaveSettings(v1)
 		api.ChangePassword(v1)
+		api.GetUserProfile(v1)
+		api.UpdateUserProfile(v1)
 		api.CreateSession(v1)
 		api.DeleteSession(v1)
+		api.RotateSessionTokens(v1)
 
 		// External account management.
 		api.SearchAccounts(v1)
@@ -65,16 +72,29 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 
 		// Thumbnails and downloads.
 		api.GetThumb(v1)
+		api.GetZoomImage(v1)
+		api.GetZoomTile(v1)
 		api.GetDownload(v1)
 		api.GetVideo(v1)
+		api.GetVideoHls(v1)
+		api.GetVideoSprite(v1)
 		api.CreateZip(v1)
+		api.GetPrintProducts(v1)
+		api.SendPrintOrder(v1)
 		api.DownloadZip(v1)
 
 		// Photos.
 		api.SearchPhotos(v1)
 		api.SearchGeo(v1)
+		api.GetTile(v1)
+		api.SearchSuggestions(v1)
 		api.GetPhoto(v1)
 		api.GetPhotoYaml(v1)
+		api.GetSimilarPhotos(v1)
+		api.GetPhotoOfDay(v1)
+		api.GetTimelapseSequences(v1)
+		api.CreateTimelapse(v1)
+		api.GetTimelapsePhotos(v1)
 		api.UpdatePhoto(v1)
 		api.GetPhotoDownload(v1)
 		api.GetPhotoLinks(v1)
@@ -84,16 +104,47 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.ApprovePhoto(v1)
 		api.LikePhoto(v1)
 		api.DislikePhoto(v1)
+		api.LockPhoto(v1)
+		api.UnlockPhoto(v1)
 		api.AddPhotoLabel(v1)
 		api.RemovePhotoLabel(v1)
+		api.UpdatePhotoLocale(v1)
+		api.DeletePhotoLocale(v1)
 		api.UpdatePhotoLabel(v1)
 		api.GetMomentsTime(v1)
 		api.GetFile(v1)
 		api.DeleteFile(v1)
+		api.GetBrokenFiles(v1)
+		api.GetSkippedFiles(v1)
+		api.DismissBrokenFile(v1)
+		api.GetCameraAliases(v1)
+		api.SaveCameraAlias(v1)
+		api.DeleteCameraAlias(v1)
+		api.GetSearches(v1)
+		api.GetSearch(v1)
+		api.CreateSearch(v1)
+		api.UpdateSearch(v1)
+		api.DeleteSearch(v1)
+		api.GetTags(v1)
+		api.CreateTag(v1)
+		api.UpdateTag(v1)
+		api.DeleteTag(v1)
+		api.BatchTagsAdd(v1)
+		api.BatchTagsRemove(v1)
+		api.GetDownloads(v1)
+		api.AddDownloads(v1)
+		api.DeleteDownload(v1)
+		api.GetPushKey(v1)
+		api.SubscribePush(v1)
+		api.UnsubscribePush(v1)
 		api.UpdateMarker(v1)
 		api.ClearMarkerSubject(v1)
 		api.PhotoPrimary(v1)
 		api.PhotoUnstack(v1)
+		api.GetPhotoEdit(v1)
+		api.UpdatePhotoEdit(v1)
+		api.DeletePhotoEdit(v1)
+		api.GetPhotoEditDownload(v1)
 
 		// Albums.
 		api.SearchAlbums(v1)
@@ -101,17 +152,31 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.AlbumCover(v1)
 		api.CreateAlbum(v1)
 		api.UpdateAlbum(v1)
+		api.SetAlbumCover(v1)
+		api.ResetAlbumCover(v1)
 		api.DeleteAlbum(v1)
 		api.DownloadAlbum(v1)
+		api.CreateAlbumSlideshow(v1)
+		api.DownloadAlbumSlideshow(v1)
 		api.GetAlbumLinks(v1)
 		api.CreateAlbumLink(v1)
 		api.UpdateAlbumLink(v1)
 		api.DeleteAlbumLink(v1)
 		api.LikeAlbum(v1)
 		api.DislikeAlbum(v1)
+		api.LockAlbum(v1)
+		api.UnlockAlbum(v1)
 		api.CloneAlbums(v1)
+		api.RestoreAlbums(v1)
 		api.AddPhotosToAlbum(v1)
 		api.RemovePhotosFromAlbum(v1)
+		api.GetAlbumMembers(v1)
+		api.AddAlbumMember(v1)
+		api.RemoveAlbumMember(v1)
+		api.UploadToAlbum(v1)
+		api.GetShareUploads(v1)
+		api.ApproveShareUploads(v1)
+		api.RejectShareUploads(v1)
 
 		// Labels.
 		api.SearchLabels(v1)
@@ -131,20 +196,32 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 
 		// People and other subjects.
 		api.SearchSubjects(v1)
+		api.SearchPets(v1)
 		api.GetSubject(v1)
 		api.UpdateSubject(v1)
 		api.LikeSubject(v1)
 		api.DislikeSubject(v1)
+		api.GetSubjectCooccurrence(v1)
+		api.GetPeopleCooccurrence(v1)
+		api.ProposeSubjectFaces(v1)
+		api.AssignSubjectFaces(v1)
 
 		// Faces.
 		api.SearchFaces(v1)
 		api.GetFace(v1)
 		api.UpdateFace(v1)
+		api.GetFaceReview(v1)
+		api.ConfirmFaceReview(v1)
 
 		// Indexing and importing.
 		api.Upload(v1)
+		api.SyncCheck(v1)
+		api.SyncUpload(v1)
+		api.SyncConfirm(v1)
+		api.ShareUpload(v1)
 		api.StartImport(v1)
 		api.CancelImport(v1)
+		api.ImportArchives(v1)
 		api.StartIndexing(v1)
 		api.CancelIndexing(v1)
 
@@ -154,13 +231,24 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.BatchPhotosRestore(v1)
 		api.BatchPhotosPrivate(v1)
 		api.BatchPhotosDelete(v1)
+		api.BatchPhotosStack(v1)
 		api.BatchAlbumsDelete(v1)
+		api.BatchAlbumsAdd(v1)
+		api.BatchAlbumsRemove(v1)
+		api.BatchAlbumsClone(v1)
+		api.BatchAlbumsMerge(v1)
 		api.BatchLabelsDelete(v1)
 
+		// Delta sync.
+		api.GetChanges(v1)
+
 		// Other.
 		api.GetSvg(v1)
 		api.GetStatus(v1)
+		api.EnableMaintenance(v1)
+		api.DisableMaintenance(v1)
 		api.GetErrors(v1)
+		api.GetActivities(v1)
 		api.SendFeedback(v1)
 		api.Websocket(v1)
 	}
@@ -182,6 +270,11 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		if conf.ImportPath() != "" {
 			WebDAV(conf.ImportPath(), router.Group(conf.BaseUri(WebDAVImport), BasicAuth()), conf)
 			log.Infof("webdav: %s/ enabled, waiting for requests", conf.BaseUri(WebDAVImport))
+
+			if !conf.ReadOnly() {
+				WebDAVUserUpload(router.Group(conf.BaseUri(WebDAVUpload), BasicAuth()), conf)
+				log.Infof("webdav: %s/ enabled, waiting for requests", conf.BaseUri(WebDAVUpload))
+			}
 		}
 	}
 
@@ -37,6 +37,11 @@ func Start(ctx context.Context, conf *config.Config) {
 	// Register logger middleware.
 	router.Use(Logger(), Recovery())
 
+	// Register tracing middleware?
+	if conf.Tracing() {
+		router.Use(Tracing())
+	}
+
 	// Register security middleware.
 	router.Use(Security(SecurityOptions{
 		IsDevelopment:         gin.Mode() != gin.ReleaseMode || conf.Test(),
@@ -108,16 +108,23 @@ func WebDAV(path string, router *gin.RouterGroup, conf *config.Config) {
 
 	router.Handle(MethodHead, "/*path", handler)
 	router.Handle(MethodGet, "/*path", handler)
+	router.Handle(MethodOptions, "/*path", handler)
+	router.Handle(MethodPropfind, "/*path", handler)
+
+	// Files in protected folders can only be read.
+	if fs.Protected(path) {
+		log.Infof("webdav: %s is read-only", sanitize.Log(filepath.Base(path)))
+		return
+	}
+
 	router.Handle(MethodPut, "/*path", handler)
 	router.Handle(MethodPost, "/*path", handler)
 	router.Handle(MethodPatch, "/*path", handler)
 	router.Handle(MethodDelete, "/*path", handler)
-	router.Handle(MethodOptions, "/*path", handler)
 	router.Handle(MethodMkcol, "/*path", handler)
 	router.Handle(MethodCopy, "/*path", handler)
 	router.Handle(MethodMove, "/*path", handler)
 	router.Handle(MethodLock, "/*path", handler)
 	router.Handle(MethodUnlock, "/*path", handler)
-	router.Handle(MethodPropfind, "/*path", handler)
 	router.Handle(MethodProppatch, "/*path", handler)
 }
@@ -0,0 +1,250 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/webdav"
+
+	"github.com/photoprism/photoprism/internal/auto"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+const WebDAVUpload = "/upload"
+
+// ErrQuotaExceeded is returned when writing to a file would exceed the user upload quota.
+var ErrQuotaExceeded = errors.New("upload quota exceeded")
+
+// webdavLocks contains a separate lock system for each user upload folder.
+var webdavLocks = struct {
+	user  map[string]webdav.LockSystem
+	mutex sync.Mutex
+}{user: make(map[string]webdav.LockSystem)}
+
+// webdavQuotas contains the number of bytes currently being written to each user upload folder.
+var webdavQuotas = struct {
+	user  map[string]*quotaUsage
+	mutex sync.Mutex
+}{user: make(map[string]*quotaUsage)}
+
+// quotaUsage counts the bytes written to files that are still open.
+type quotaUsage struct {
+	writing int64
+	mutex   sync.Mutex
+}
+
+// userQuotaUsage returns the quota usage counter for the given user upload folder.
+func userQuotaUsage(dir string) *quotaUsage {
+	webdavQuotas.mutex.Lock()
+	defer webdavQuotas.mutex.Unlock()
+
+	if u, ok := webdavQuotas.user[dir]; ok {
+		return u
+	}
+
+	u := &quotaUsage{}
+	webdavQuotas.user[dir] = u
+
+	return u
+}
+
+// QuotaDir is a WebDAV file system that refuses writes exceeding a quota, regardless of the request headers.
+type QuotaDir struct {
+	webdav.Dir
+	Quota int64
+	usage *quotaUsage
+}
+
+// NewQuotaDir returns a WebDAV file system for dir with the given quota in bytes, or no limit if quota <= 0.
+func NewQuotaDir(dir string, quota int64) *QuotaDir {
+	return &QuotaDir{Dir: webdav.Dir(dir), Quota: quota, usage: userQuotaUsage(dir)}
+}
+
+// OpenFile opens a file and limits the number of bytes that can be written to it.
+func (d *QuotaDir) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	f, err := d.Dir.OpenFile(ctx, name, flag, perm)
+
+	if err != nil || d.Quota <= 0 || flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		return f, err
+	}
+
+	// Bytes of concurrent uploads may be counted twice, so the quota is never exceeded.
+	return &quotaFile{File: f, dir: d, name: name, used: UploadSize(string(d.Dir))}, nil
+}
+
+// quotaFile is a WebDAV file that counts the bytes written to it.
+type quotaFile struct {
+	webdav.File
+	dir      *QuotaDir
+	used     int64
+	written  int64
+	exceeded bool
+	name     string
+}
+
+// Write writes p to the file unless this would exceed the quota.
+func (f *quotaFile) Write(p []byte) (n int, err error) {
+	u := f.dir.usage
+
+	u.mutex.Lock()
+
+	if f.used+u.writing+int64(len(p)) > f.dir.Quota {
+		u.mutex.Unlock()
+		f.exceeded = true
+		return 0, ErrQuotaExceeded
+	}
+
+	u.writing += int64(len(p))
+	u.mutex.Unlock()
+
+	n, err = f.File.Write(p)
+
+	u.mutex.Lock()
+	u.writing -= int64(len(p) - n)
+	u.mutex.Unlock()
+
+	f.written += int64(n)
+
+	return n, err
+}
+
+// Close closes the file and removes it if the quota has been exceeded.
+func (f *quotaFile) Close() error {
+	u := f.dir.usage
+
+	u.mutex.Lock()
+	u.writing -= f.written
+	u.mutex.Unlock()
+
+	err := f.File.Close()
+
+	// Incomplete files must not use up the quota.
+	if f.exceeded {
+		if removeErr := f.dir.RemoveAll(context.Background(), f.name); removeErr != nil {
+			log.Debugf("webdav: %s", removeErr)
+		}
+	}
+
+	return err
+}
+
+// userLockSystem returns the WebDAV lock system for the given user.
+func userLockSystem(userUID string) webdav.LockSystem {
+	webdavLocks.mutex.Lock()
+	defer webdavLocks.mutex.Unlock()
+
+	if ls, ok := webdavLocks.user[userUID]; ok {
+		return ls
+	}
+
+	ls := webdav.NewMemLS()
+	webdavLocks.user[userUID] = ls
+
+	return ls
+}
+
+// UploadSize returns the total size of all files in a folder in bytes.
+func UploadSize(dir string) (size int64) {
+	_ = filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size
+}
+
+// QuotaExceeded tests if uploading length bytes to dir would exceed the quota.
+func QuotaExceeded(dir string, length, quota int64) bool {
+	if quota <= 0 {
+		return false
+	}
+
+	return UploadSize(dir)+length > quota
+}
+
+// WebDAVUserUpload handles requests to /upload/* with a separate, private upload folder for each user.
+func WebDAVUserUpload(router *gin.RouterGroup, conf *config.Config) {
+	if router == nil {
+		log.Error("webdav: router is nil")
+		return
+	}
+
+	if conf == nil {
+		log.Error("webdav: conf is nil")
+		return
+	}
+
+	handler := func(c *gin.Context) {
+		w := c.Writer
+		r := c.Request
+
+		userUID := c.GetString(gin.AuthUserKey)
+		userPath := conf.UserUploadPath(userUID)
+
+		if userPath == "" {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		if err := os.MkdirAll(userPath, os.ModePerm); err != nil {
+			log.Errorf("webdav: failed creating upload folder for %s (%s)", sanitize.Log(userUID), err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Refuse uploads that would exceed the user quota.
+		if r.Method == MethodPut && QuotaExceeded(userPath, r.ContentLength, conf.UploadQuota()) {
+			log.Warnf("webdav: upload quota of %s exceeded", sanitize.Log(userUID))
+			c.AbortWithStatus(http.StatusInsufficientStorage)
+			return
+		}
+
+		srv := &webdav.Handler{
+			Prefix:     router.BasePath(),
+			FileSystem: NewQuotaDir(userPath, conf.UploadQuota()),
+			LockSystem: userLockSystem(userUID),
+			Logger: func(r *http.Request, err error) {
+				if err != nil {
+					log.Debugf("webdav: %s in %s %s", sanitize.Log(err.Error()), sanitize.Log(r.Method), sanitize.Log(r.URL.String()))
+					return
+				}
+
+				switch r.Method {
+				case MethodPut, MethodPost, MethodPatch, MethodCopy, MethodMove:
+					log.Infof("webdav: %s %s by %s", sanitize.Log(r.Method), sanitize.Log(r.URL.String()), sanitize.Log(userUID))
+
+					// Import completed uploads.
+					auto.ShouldImport()
+				default:
+					log.Tracef("webdav: %s %s", sanitize.Log(r.Method), sanitize.Log(r.URL.String()))
+				}
+			},
+		}
+
+		srv.ServeHTTP(w, r)
+	}
+
+	router.Handle(MethodHead, "/*path", handler)
+	router.Handle(MethodGet, "/*path", handler)
+	router.Handle(MethodPut, "/*path", handler)
+	router.Handle(MethodPost, "/*path", handler)
+	router.Handle(MethodPatch, "/*path", handler)
+	router.Handle(MethodDelete, "/*path", handler)
+	router.Handle(MethodOptions, "/*path", handler)
+	router.Handle(MethodMkcol, "/*path", handler)
+	router.Handle(MethodCopy, "/*path", handler)
+	router.Handle(MethodMove, "/*path", handler)
+	router.Handle(MethodLock, "/*path", handler)
+	router.Handle(MethodUnlock, "/*path", handler)
+	router.Handle(MethodPropfind, "/*path", handler)
+	router.Handle(MethodProppatch, "/*path", handler)
+}
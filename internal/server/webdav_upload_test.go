@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaExceeded(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "a.jpg"), make([]byte, 100), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, int64(100), UploadSize(dir))
+	assert.False(t, QuotaExceeded(dir, 100, 200))
+	assert.True(t, QuotaExceeded(dir, 101, 200))
+	assert.False(t, QuotaExceeded(dir, 1000, -1))
+}
+
+func TestQuotaDir_OpenFile(t *testing.T) {
+	ctx := context.Background()
+	flag := os.O_RDWR | os.O_CREATE | os.O_TRUNC
+
+	t.Run("WithinQuota", func(t *testing.T) {
+		d := NewQuotaDir(t.TempDir(), 100)
+
+		f, err := d.OpenFile(ctx, "/a.jpg", flag, 0666)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		n, err := f.Write(make([]byte, 100))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 100, n)
+		assert.NoError(t, f.Close())
+		assert.FileExists(t, filepath.Join(string(d.Dir), "a.jpg"))
+	})
+	t.Run("Exceeded", func(t *testing.T) {
+		d := NewQuotaDir(t.TempDir(), 100)
+
+		if err := d.Mkdir(ctx, "/sub", os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := d.OpenFile(ctx, "/sub/b.jpg", flag, 0666)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = f.Write(make([]byte, 60))
+		assert.NoError(t, err)
+
+		// Writes are refused even if no content length was sent.
+		_, err = f.Write(make([]byte, 60))
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+		assert.NoError(t, f.Close())
+
+		// Incomplete uploads are removed.
+		assert.NoFileExists(t, filepath.Join(string(d.Dir), "sub", "b.jpg"))
+	})
+	t.Run("Concurrent", func(t *testing.T) {
+		d := NewQuotaDir(t.TempDir(), 100)
+
+		a, err := d.OpenFile(ctx, "/a.jpg", flag, 0666)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := d.OpenFile(ctx, "/b.jpg", flag, 0666)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = a.Write(make([]byte, 60))
+		assert.NoError(t, err)
+		_, err = b.Write(make([]byte, 60))
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+		assert.NoError(t, a.Close())
+		assert.NoError(t, b.Close())
+		assert.Equal(t, int64(60), UploadSize(string(d.Dir)))
+	})
+	t.Run("Unlimited", func(t *testing.T) {
+		d := NewQuotaDir(t.TempDir(), -1)
+
+		f, err := d.OpenFile(ctx, "/a.jpg", flag, 0666)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = f.Write(make([]byte, 1000))
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+	})
+}
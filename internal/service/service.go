@@ -18,6 +18,8 @@ var services struct {
 	FolderCache *gc.Cache
 	CoverCache  *gc.Cache
 	ThumbCache  *gc.Cache
+	ShareCache  *gc.Cache
+	UserCache   *gc.Cache
 	Classify    *classify.TensorFlow
 	Convert     *photoprism.Convert
 	Files       *photoprism.Files
@@ -33,6 +35,7 @@ var services struct {
 	FaceNet     *face.Net
 	Query       *query.Query
 	Resample    *photoprism.Resample
+	Warmup      *photoprism.Warmup
 	Session     *session.Session
 }
 
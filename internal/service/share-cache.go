@@ -0,0 +1,21 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+)
+
+var onceShareCache sync.Once
+
+func initShareCache() {
+	services.ShareCache = gc.New(time.Minute, 10*time.Minute)
+}
+
+// ShareCache returns the cache of albums that are visible to registered guests by session id.
+func ShareCache() *gc.Cache {
+	onceShareCache.Do(initShareCache)
+
+	return services.ShareCache
+}
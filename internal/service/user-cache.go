@@ -0,0 +1,21 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+)
+
+var onceUserCache sync.Once
+
+func initUserCache() {
+	services.UserCache = gc.New(time.Minute, 10*time.Minute)
+}
+
+// UserCache returns the cache of registered users that may still log in by user uid.
+func UserCache() *gc.Cache {
+	onceUserCache.Do(initUserCache)
+
+	return services.UserCache
+}
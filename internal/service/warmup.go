@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceWarmup sync.Once
+
+func initWarmup() {
+	services.Warmup = photoprism.NewWarmup(Config())
+}
+
+func Warmup() *photoprism.Warmup {
+	onceWarmup.Do(initWarmup)
+
+	return services.Warmup
+}
@@ -0,0 +1,196 @@
+package session
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Access represents the download and preview tokens of a session, which expire after a configurable lifetime.
+type Access struct {
+	DownloadToken string `json:"downloadToken"`
+	PreviewToken  string `json:"previewToken"`
+	Expires       int64  `json:"expires"`
+}
+
+// accessToken references the session a download or preview token belongs to.
+type accessToken struct {
+	ID       string
+	Download bool
+}
+
+// NewAccess returns new random download and preview tokens that expire after the lifetime.
+func NewAccess(lifetime time.Duration) Access {
+	return Access{
+		DownloadToken: rnd.Token(10),
+		PreviewToken:  rnd.Token(10),
+		Expires:       time.Now().Add(lifetime).Unix(),
+	}
+}
+
+// Empty tests if no tokens have been created yet.
+func (a Access) Empty() bool {
+	return a.DownloadToken == "" || a.PreviewToken == ""
+}
+
+// Remaining returns the time until the tokens expire.
+func (a Access) Remaining() time.Duration {
+	if a.Empty() {
+		return 0
+	}
+
+	return time.Until(time.Unix(a.Expires, 0))
+}
+
+// addAccess adds the tokens to the index, so that they can be validated without knowing the session id.
+func (s *Session) addAccess(id string, a Access) {
+	remaining := a.Remaining()
+
+	if remaining <= 0 {
+		return
+	}
+
+	s.tokens.Set(a.DownloadToken, accessToken{ID: id, Download: true}, remaining)
+	s.tokens.Set(a.PreviewToken, accessToken{ID: id}, remaining)
+}
+
+// removeAccess removes the tokens from the index, so that they become invalid immediately.
+func (s *Session) removeAccess(a Access) {
+	if a.Empty() {
+		return
+	}
+
+	s.tokens.Delete(a.DownloadToken)
+	s.tokens.Delete(a.PreviewToken)
+}
+
+// indexed tests if the tokens are still in the index, which is not the case after they have been revoked.
+func (s *Session) indexed(a Access) bool {
+	_, found := s.tokens.Get(a.PreviewToken)
+
+	return found
+}
+
+// Access returns the download and preview tokens of a session. New tokens are created when less than half
+// of the lifetime remains, previous tokens stay valid until they expire so that loaded pages keep working.
+func (s *Session) Access(id string, lifetime time.Duration) (Access, error) {
+	if id == "" {
+		return Access{}, fmt.Errorf("session: empty id")
+	}
+
+	data := s.Get(id)
+
+	if data.Invalid() {
+		return Access{}, fmt.Errorf("session: %s not found (access)", id)
+	}
+
+	if data.Access.Remaining() > lifetime/2 && s.indexed(data.Access) {
+		return data.Access, nil
+	}
+
+	data.Access = NewAccess(lifetime)
+
+	if err := s.Update(id, data); err != nil {
+		return Access{}, err
+	}
+
+	s.addAccess(id, data.Access)
+
+	return data.Access, nil
+}
+
+// RotateAccess replaces the download and preview tokens of a session, the previous tokens become invalid immediately.
+func (s *Session) RotateAccess(id string, lifetime time.Duration) (Access, error) {
+	if id == "" {
+		return Access{}, fmt.Errorf("session: empty id")
+	}
+
+	data := s.Get(id)
+
+	if data.Invalid() {
+		return Access{}, fmt.Errorf("session: %s not found (rotate access)", id)
+	}
+
+	s.removeAccess(data.Access)
+
+	data.Access = NewAccess(lifetime)
+
+	if err := s.Update(id, data); err != nil {
+		return Access{}, err
+	}
+
+	s.addAccess(id, data.Access)
+
+	log.Debugf("session: rotated access tokens")
+
+	return data.Access, nil
+}
+
+// RevokeAccess invalidates the download and preview tokens of all sessions.
+func (s *Session) RevokeAccess() {
+	s.tokens.Flush()
+
+	log.Debugf("session: revoked all access tokens")
+}
+
+// token returns the session data for a download or preview token, if it is valid.
+func (s *Session) token(t string) (result accessToken, data Data, ok bool) {
+	if t == "" {
+		return result, data, false
+	}
+
+	hit, found := s.tokens.Get(t)
+
+	if !found {
+		return result, data, false
+	}
+
+	result = hit.(accessToken)
+
+	if data = s.Get(result.ID); data.Invalid() {
+		return result, data, false
+	}
+
+	return result, data, true
+}
+
+// ValidPreviewToken tests if the token is a valid preview or download token of an existing session.
+func (s *Session) ValidPreviewToken(t string) bool {
+	_, _, ok := s.token(t)
+
+	return ok
+}
+
+// PreviewToken returns the session data for a preview or download token and true if it is valid.
+func (s *Session) PreviewToken(t string) (Data, bool) {
+	_, data, ok := s.token(t)
+
+	if !ok {
+		return Data{}, false
+	}
+
+	return data, true
+}
+
+// DownloadToken returns the session data for a download token and true if it is valid.
+func (s *Session) DownloadToken(t string) (Data, bool) {
+	result, data, ok := s.token(t)
+
+	if !ok || !result.Download {
+		return Data{}, false
+	}
+
+	return data, true
+}
+
+// DownloadSessionID returns the id of the session a download token belongs to and true if it is valid.
+func (s *Session) DownloadSessionID(t string) (string, bool) {
+	result, _, ok := s.token(t)
+
+	if !ok || !result.Download {
+		return "", false
+	}
+
+	return result.ID, true
+}
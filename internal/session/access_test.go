@@ -0,0 +1,106 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAccess(t *testing.T) {
+	a := NewAccess(time.Hour)
+
+	assert.False(t, a.Empty())
+	assert.Equal(t, 10, len(a.DownloadToken))
+	assert.Equal(t, 10, len(a.PreviewToken))
+	assert.NotEqual(t, a.DownloadToken, a.PreviewToken)
+	assert.InDelta(t, time.Hour.Seconds(), a.Remaining().Seconds(), 5)
+	assert.Equal(t, time.Duration(0), Access{}.Remaining())
+}
+
+func TestSession_Access(t *testing.T) {
+	s := New(time.Hour, "testdata")
+	id := s.Create(Data{User: entity.Admin})
+
+	a, err := s.Access(id, time.Hour)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, a.Empty())
+	assert.True(t, s.ValidPreviewToken(a.PreviewToken))
+	assert.True(t, s.ValidPreviewToken(a.DownloadToken))
+	assert.False(t, s.ValidPreviewToken("xxx"))
+	assert.False(t, s.ValidPreviewToken(""))
+
+	if data, ok := s.DownloadToken(a.DownloadToken); !ok {
+		t.Fatal("download token should be valid")
+	} else {
+		assert.Equal(t, entity.Admin.UserUID, data.User.UserUID)
+	}
+
+	_, ok := s.DownloadToken(a.PreviewToken)
+	assert.False(t, ok)
+
+	if data, ok := s.PreviewToken(a.PreviewToken); !ok {
+		t.Fatal("preview token should be valid")
+	} else {
+		assert.Equal(t, entity.Admin.UserUID, data.User.UserUID)
+	}
+
+	_, ok = s.PreviewToken("xxx")
+	assert.False(t, ok)
+
+	t.Run("Unchanged", func(t *testing.T) {
+		b, err := s.Access(id, time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, a, b)
+	})
+	t.Run("Renewed", func(t *testing.T) {
+		b, err := s.Access(id, 4*time.Hour)
+		assert.NoError(t, err)
+		assert.NotEqual(t, a.PreviewToken, b.PreviewToken)
+		assert.True(t, s.ValidPreviewToken(b.PreviewToken))
+
+		// Previous tokens remain valid until they expire.
+		assert.True(t, s.ValidPreviewToken(a.PreviewToken))
+	})
+	t.Run("Rotated", func(t *testing.T) {
+		b, err := s.Access(id, 4*time.Hour)
+		assert.NoError(t, err)
+
+		c, err := s.RotateAccess(id, 4*time.Hour)
+		assert.NoError(t, err)
+		assert.NotEqual(t, b.PreviewToken, c.PreviewToken)
+		assert.False(t, s.ValidPreviewToken(b.PreviewToken))
+		assert.True(t, s.ValidPreviewToken(c.PreviewToken))
+	})
+	t.Run("Revoked", func(t *testing.T) {
+		b, err := s.Access(id, 4*time.Hour)
+		assert.NoError(t, err)
+
+		s.RevokeAccess()
+		assert.False(t, s.ValidPreviewToken(b.PreviewToken))
+
+		c, err := s.Access(id, 4*time.Hour)
+		assert.NoError(t, err)
+		assert.NotEqual(t, b.PreviewToken, c.PreviewToken)
+	})
+	t.Run("Deleted", func(t *testing.T) {
+		b, err := s.Access(id, 4*time.Hour)
+		assert.NoError(t, err)
+
+		s.Delete(id)
+		assert.False(t, s.ValidPreviewToken(b.PreviewToken))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := s.Access("", time.Hour)
+		assert.Error(t, err)
+		_, err = s.Access(NewID(), time.Hour)
+		assert.Error(t, err)
+		_, err = s.RotateAccess(NewID(), time.Hour)
+		assert.Error(t, err)
+	})
+}
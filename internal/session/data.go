@@ -9,6 +9,7 @@ import (
 type Saved struct {
 	User       string   `json:"user"`
 	Tokens     []string `json:"tokens"`
+	Access     Access   `json:"access"`
 	Expiration int64    `json:"expiration"`
 }
 
@@ -29,14 +30,15 @@ type Data struct {
 	User   entity.User `json:"user"`   // Session user, guest or anonymous person.
 	Tokens []string    `json:"tokens"` // Slice of secret share tokens.
 	Shares UIDs        `json:"shares"` // Slice of shared entity UIDs.
+	Access Access      `json:"access"` // Download and preview tokens if they expire.
 }
 
 func (s Data) Saved() Saved {
-	return Saved{User: s.User.UserUID, Tokens: s.Tokens}
+	return Saved{User: s.User.UserUID, Tokens: s.Tokens, Access: s.Access}
 }
 
 func (s Data) Invalid() bool {
-	return s.User.ID == 0 || s.User.UserUID == "" || (s.Guest() && s.NoShares())
+	return s.User.ID == 0 || s.User.UserUID == "" || (s.Guest() && s.NoShares() && !s.User.Registered())
 }
 
 func (s Data) Valid() bool {
@@ -51,6 +53,30 @@ func (s Data) NoShares() bool {
 	return len(s.Shares) == 0
 }
 
+// GeoPrivacy returns the strictest geo privacy level of the links used to access the shared entity.
+func (s Data) GeoPrivacy(shareUID string) (level string) {
+	if !s.Guest() {
+		return entity.GeoExact
+	}
+
+	for _, token := range s.Tokens {
+		for _, link := range entity.FindValidLinks(token, shareUID) {
+			level = entity.GeoStricter(level, link.LinkGeo)
+		}
+	}
+
+	return level
+}
+
+// Owner tests if the session user owns the entity created by the given user, or is an admin.
+func (s Data) Owner(userUID string) bool {
+	if s.User.Admin() {
+		return true
+	}
+
+	return userUID != "" && s.User.UserUID == userUID
+}
+
 func (s Data) HasShare(uid string) bool {
 	for _, share := range s.Shares {
 		if share == uid {
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestUIDs_String(t *testing.T) {
@@ -16,8 +18,42 @@ func TestUIDs_Join(t *testing.T) {
 	assert.Equal(t, "dghjkfd|dfgehrih", uid.Join("|"))
 }
 
+func TestData_Invalid(t *testing.T) {
+	t.Run("Guest", func(t *testing.T) {
+		assert.True(t, Data{User: entity.Guest}.Invalid())
+		assert.False(t, Data{User: entity.Guest, Shares: UIDs{"at9lxuqxpogaaba8"}}.Invalid())
+	})
+	t.Run("RegisteredGuest", func(t *testing.T) {
+		user := entity.User{ID: 99, UserUID: "uqxqg7i1kperxvu9", UserName: "grandma", RoleGuest: true}
+		assert.False(t, Data{User: user}.Invalid())
+	})
+}
+
 func TestData_HasShare(t *testing.T) {
 	data := Data{Shares: []string{"abc123", "def444"}}
 	assert.True(t, data.HasShare("def444"))
 	assert.False(t, data.HasShare("xxx"))
 }
+
+func TestData_Owner(t *testing.T) {
+	user := entity.User{ID: 99, UserUID: "uqxqg7i1kperxvu9", UserName: "grandma", RoleGuest: true}
+
+	assert.True(t, Data{User: user}.Owner("uqxqg7i1kperxvu9"))
+	assert.False(t, Data{User: user}.Owner("uqxqg7i1kperxvu8"))
+	assert.False(t, Data{User: entity.UnknownUser}.Owner(""))
+
+	admin := entity.User{ID: 98, UserUID: "uqxqg7i1kperxvu7", UserName: "admin", RoleAdmin: true}
+	assert.True(t, Data{User: admin}.Owner("uqxqg7i1kperxvu8"))
+}
+
+func TestData_GeoPrivacy(t *testing.T) {
+	t.Run("Guest", func(t *testing.T) {
+		data := Data{User: entity.Guest, Tokens: []string{"1jxf3jfn2k", "4jxf3jfn2k"}}
+		assert.Equal(t, entity.GeoRound, data.GeoPrivacy("at9lxuqxpogaaba7"))
+		assert.Equal(t, entity.GeoExact, data.GeoPrivacy("at9lxuqxpogaaba8"))
+	})
+	t.Run("Admin", func(t *testing.T) {
+		data := Data{User: entity.Admin, Tokens: []string{"4jxf3jfn2k"}}
+		assert.Equal(t, entity.GeoExact, data.GeoPrivacy("at9lxuqxpogaaba7"))
+	})
+}
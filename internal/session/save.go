@@ -17,7 +17,7 @@ var fileMutex sync.RWMutex
 
 // New returns a new session store with an optional cachePath.
 func New(expiration time.Duration, cachePath string) *Session {
-	s := &Session{}
+	s := &Session{tokens: gc.New(gc.NoExpiration, 15*time.Minute)}
 
 	cleanupInterval := 15 * time.Minute
 
@@ -38,7 +38,8 @@ func New(expiration time.Duration, cachePath string) *Session {
 			for key, saved := range savedItems {
 				user := entity.FindUserByUID(saved.User)
 
-				if user == nil {
+				// Skip sessions of deleted and disabled users.
+				if user == nil || user.UserDisabled {
 					continue
 				}
 
@@ -57,8 +58,10 @@ func New(expiration time.Duration, cachePath string) *Session {
 					}
 				}
 
-				data := Data{User: *user, Tokens: tokens, Shares: shared}
+				data := Data{User: *user, Tokens: tokens, Shares: shared, Access: saved.Access}
 				items[key] = gc.Item{Expiration: saved.Expiration, Object: data}
+
+				s.addAccess(key, saved.Access)
 			}
 
 			s.cache = gc.NewFrom(expiration, cleanupInterval, items)
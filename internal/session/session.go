@@ -42,4 +42,5 @@ var log = event.Log
 type Session struct {
 	cacheFile string
 	cache     *gc.Cache
+	tokens    *gc.Cache
 }
@@ -42,6 +42,7 @@ func (s *Session) Update(id string, data Data) error {
 
 // Delete deletes an existing user session.
 func (s *Session) Delete(id string) {
+	s.removeAccess(s.Get(id).Access)
 	s.cache.Delete(id)
 	log.Debugf("session: deleted")
 
@@ -50,6 +51,33 @@ func (s *Session) Delete(id string) {
 	}
 }
 
+// DeleteUser deletes all sessions of the specified user and returns the number of deleted sessions.
+func (s *Session) DeleteUser(userUID string) (deleted int) {
+	if userUID == "" {
+		return 0
+	}
+
+	for id, item := range s.cache.Items() {
+		if data, ok := item.Object.(Data); ok && data.User.UserUID == userUID {
+			s.removeAccess(data.Access)
+			s.cache.Delete(id)
+			deleted++
+		}
+	}
+
+	if deleted <= 0 {
+		return 0
+	}
+
+	log.Debugf("session: deleted %d of user %s", deleted, userUID)
+
+	if err := s.Save(); err != nil {
+		log.Errorf("session: %s (delete user)", err)
+	}
+
+	return deleted
+}
+
 // Get returns the data of an existing user session.
 func (s *Session) Get(id string) Data {
 	if id == "" {
@@ -69,3 +97,22 @@ func (s *Session) Exists(id string) bool {
 
 	return found
 }
+
+// DeleteExpired deletes all expired user sessions and returns the number of deleted sessions.
+func (s *Session) DeleteExpired() (deleted int) {
+	count := s.cache.ItemCount()
+	s.cache.DeleteExpired()
+	deleted = count - s.cache.ItemCount()
+
+	if deleted <= 0 {
+		return 0
+	}
+
+	log.Debugf("session: deleted %d expired", deleted)
+
+	if err := s.Save(); err != nil {
+		log.Errorf("session: %s (delete expired)", err)
+	}
+
+	return deleted
+}
@@ -127,3 +127,32 @@ func TestSession_Exists(t *testing.T) {
 	s.Delete(id)
 	assert.False(t, s.Exists(id))
 }
+
+func TestSession_DeleteUser(t *testing.T) {
+	s := New(time.Hour, "")
+
+	bob := entity.UserFixtures.Get("bob")
+	first := s.Create(Data{User: bob})
+	second := s.Create(Data{User: bob})
+	admin := s.Create(Data{User: entity.Admin})
+
+	assert.Equal(t, 2, s.DeleteUser(bob.UserUID))
+	assert.False(t, s.Exists(first))
+	assert.False(t, s.Exists(second))
+	assert.True(t, s.Exists(admin))
+	assert.Equal(t, 0, s.DeleteUser(bob.UserUID))
+	assert.Equal(t, 0, s.DeleteUser(""))
+}
+
+func TestSession_DeleteExpired(t *testing.T) {
+	s := New(time.Millisecond, "")
+
+	id := s.Create(Data{User: entity.Admin})
+	assert.True(t, s.Exists(id))
+
+	time.Sleep(5 * time.Millisecond)
+
+	assert.Equal(t, 1, s.DeleteExpired())
+	assert.False(t, s.Exists(id))
+	assert.Equal(t, 0, s.DeleteExpired())
+}
@@ -94,6 +94,15 @@ func FromFile(imageFilename, hash, thumbPath string, width, height, orientation
 		return "", err
 	}
 
+	// Use libvips if enabled, and fall back to the imaging library in case of errors.
+	if VipsEnabled() {
+		if err := Vips(imageFilename, fileName, width, height, orientation, opts...); err != nil {
+			log.Debugf("%s, using imaging library", err)
+		} else {
+			return fileName, nil
+		}
+	}
+
 	// Load image from storage.
 	img, err := Open(imageFilename, orientation)
 
@@ -126,10 +135,8 @@ func Create(img image.Image, fileName string, width, height int, opts ...Resampl
 
 	if filepath.Ext(fileName) == "."+string(fs.FormatPng) {
 		saveOption = imaging.PNGCompressionLevel(png.DefaultCompression)
-	} else if width <= 150 && height <= 150 {
-		saveOption = imaging.JPEGQuality(JpegQualitySmall)
 	} else {
-		saveOption = imaging.JPEGQuality(JpegQuality)
+		saveOption = imaging.JPEGQuality(JpegQualityFor(width, height, opts...))
 	}
 
 	err = imaging.Save(result, fileName, saveOption)
@@ -0,0 +1,215 @@
+package thumb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/disintegration/imaging"
+)
+
+const (
+	// StraightenMax is the maximum straighten angle in degrees.
+	StraightenMax = 45.0
+
+	// EditSep separates the file hash from the edit key in thumbnail hashes.
+	EditSep = "_e"
+)
+
+// Edit represents non-destructive editing operations that are applied when rendering an image.
+//
+// Operations are applied in the following order: rotate, straighten, crop. The crop area
+// is relative to the rotated and straightened image, with values between 0 and 1.
+type Edit struct {
+	Rotate     int     `json:"Rotate,omitempty"`
+	Straighten float64 `json:"Straighten,omitempty"`
+	CropX      float64 `json:"CropX,omitempty"`
+	CropY      float64 `json:"CropY,omitempty"`
+	CropW      float64 `json:"CropW,omitempty"`
+	CropH      float64 `json:"CropH,omitempty"`
+}
+
+// ParseEdit parses a JSON encoded edit, an empty string returns an empty edit.
+func ParseEdit(s string) (e Edit, err error) {
+	if s == "" {
+		return e, nil
+	}
+
+	err = json.Unmarshal([]byte(s), &e)
+
+	return e, err
+}
+
+// JSON returns the edit as JSON string, or an empty string if there are no operations.
+func (e Edit) JSON() string {
+	if e.Empty() {
+		return ""
+	}
+
+	b, _ := json.Marshal(e)
+
+	return string(b)
+}
+
+// Normalize converts the rotation to clockwise degrees between 0 and 270 and removes a full-size crop area.
+func (e *Edit) Normalize() {
+	e.Rotate = ((e.Rotate % 360) + 360) % 360
+
+	if e.CropX == 0 && e.CropY == 0 && (e.CropW == 0 || e.CropW == 1) && (e.CropH == 0 || e.CropH == 1) {
+		e.CropW, e.CropH = 0, 0
+	}
+}
+
+// Validate returns an error if the edit contains invalid values.
+func (e Edit) Validate() error {
+	if e.Rotate%90 != 0 {
+		return errors.New("edit: rotation must be a multiple of 90 degrees")
+	}
+
+	if math.Abs(e.Straighten) > StraightenMax {
+		return fmt.Errorf("edit: straighten angle must be between -%g and %g degrees", StraightenMax, StraightenMax)
+	}
+
+	if !e.Cropped() {
+		if e.CropX != 0 || e.CropY != 0 {
+			return errors.New("edit: crop area has no size")
+		}
+
+		return nil
+	}
+
+	if e.CropX < 0 || e.CropY < 0 || e.CropW <= 0 || e.CropH <= 0 || e.CropX+e.CropW > 1.0001 || e.CropY+e.CropH > 1.0001 {
+		return errors.New("edit: crop area is out of bounds")
+	}
+
+	return nil
+}
+
+// Cropped tests if the edit has a crop area.
+func (e Edit) Cropped() bool {
+	return e.CropW != 0 || e.CropH != 0
+}
+
+// Empty tests if the edit has no operations.
+func (e Edit) Empty() bool {
+	return e.Rotate%360 == 0 && e.Straighten == 0 && !e.Cropped()
+}
+
+// Key returns a short key that changes with the operations.
+func (e Edit) Key() string {
+	if e.Empty() {
+		return ""
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(e.JSON()))
+
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// Hash returns the thumbnail cache hash for an edited version of the file with the given hash.
+func (e Edit) Hash(fileHash string) string {
+	if key := e.Key(); key == "" {
+		return fileHash
+	} else {
+		return fileHash + EditSep + key
+	}
+}
+
+// ParseEditHash splits an edited thumbnail hash into the file hash and edit key.
+func ParseEditHash(s string) (fileHash, key string) {
+	if i := strings.LastIndex(s, EditSep); i > 0 && len(s)-i-len(EditSep) == 8 {
+		return s[:i], s[i+len(EditSep):]
+	}
+
+	return s, ""
+}
+
+// Apply returns the edited image.
+func (e Edit) Apply(img image.Image) image.Image {
+	switch ((e.Rotate % 360) + 360) % 360 {
+	case 90:
+		img = imaging.Rotate270(img)
+	case 180:
+		img = imaging.Rotate180(img)
+	case 270:
+		img = imaging.Rotate90(img)
+	}
+
+	if e.Straighten != 0 {
+		img = straighten(img, e.Straighten)
+	}
+
+	if e.Cropped() {
+		b := img.Bounds()
+		w, h := float64(b.Dx()), float64(b.Dy())
+
+		x0 := b.Min.X + int(math.Round(e.CropX*w))
+		y0 := b.Min.Y + int(math.Round(e.CropY*h))
+		x1 := x0 + int(math.Max(1, math.Round(e.CropW*w)))
+		y1 := y0 + int(math.Max(1, math.Round(e.CropH*h)))
+
+		img = imaging.Crop(img, image.Rect(x0, y0, x1, y1))
+	}
+
+	return img
+}
+
+// straighten rotates the image clockwise by the given angle and crops it to the largest
+// rectangle with the original aspect ratio, so that no empty corners remain.
+func straighten(img image.Image, angle float64) image.Image {
+	b := img.Bounds()
+	w, h := float64(b.Dx()), float64(b.Dy())
+
+	rad := math.Abs(angle) * math.Pi / 180
+	sin, cos := math.Sin(rad), math.Cos(rad)
+
+	scale := math.Min(w/(w*cos+h*sin), h/(w*sin+h*cos))
+
+	rotated := imaging.Rotate(img, -angle, color.Black)
+
+	return imaging.CropCenter(rotated, int(w*scale), int(h*scale))
+}
+
+// EditFromFile returns the thumb cache file name for the edited version of an image, and creates it if needed.
+func EditFromFile(imageFilename, hash, thumbPath string, e Edit, width, height, orientation int, opts ...ResampleOption) (fileName string, err error) {
+	if e.Empty() {
+		return FromFile(imageFilename, hash, thumbPath, width, height, orientation, opts...)
+	}
+
+	editHash := e.Hash(hash)
+
+	if fileName, err := FromCache(imageFilename, editHash, thumbPath, width, height, opts...); err == nil {
+		return fileName, err
+	} else if err != ErrThumbNotCached {
+		return "", err
+	}
+
+	// Generate thumb cache filename.
+	fileName, err = FileName(editHash, thumbPath, width, height, opts...)
+
+	if err != nil {
+		log.Error(err)
+		return "", err
+	}
+
+	// Load image from storage.
+	img, err := Open(imageFilename, orientation)
+
+	if err != nil {
+		log.Error(err)
+		return "", err
+	}
+
+	// Create thumb from edited image.
+	if _, err := Create(e.Apply(img), fileName, width, height, opts...); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
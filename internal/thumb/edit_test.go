@@ -0,0 +1,108 @@
+package thumb
+
+import (
+	"image"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestEdit_Validate(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.NoError(t, Edit{}.Validate())
+		assert.True(t, Edit{}.Empty())
+		assert.Equal(t, "", Edit{}.Key())
+		assert.Equal(t, "", Edit{}.JSON())
+	})
+	t.Run("Valid", func(t *testing.T) {
+		e := Edit{Rotate: 90, Straighten: -2.5, CropX: 0.1, CropY: 0.2, CropW: 0.5, CropH: 0.5}
+		assert.NoError(t, e.Validate())
+		assert.False(t, e.Empty())
+		assert.Len(t, e.Key(), 8)
+		assert.Equal(t, "abcd_e"+e.Key(), e.Hash("abcd"))
+
+		fileHash, key := ParseEditHash(e.Hash("abcd"))
+		assert.Equal(t, "abcd", fileHash)
+		assert.Equal(t, e.Key(), key)
+	})
+	t.Run("InvalidRotation", func(t *testing.T) {
+		assert.Error(t, Edit{Rotate: 45}.Validate())
+	})
+	t.Run("InvalidStraighten", func(t *testing.T) {
+		assert.Error(t, Edit{Straighten: 50}.Validate())
+	})
+	t.Run("InvalidCrop", func(t *testing.T) {
+		assert.Error(t, Edit{CropX: 0.6, CropW: 0.5, CropH: 0.5}.Validate())
+		assert.Error(t, Edit{CropX: 0.5}.Validate())
+	})
+}
+
+func TestParseEditHash(t *testing.T) {
+	fileHash, key := ParseEditHash("1234567890abcdef1234567890abcdef12345678")
+	assert.Equal(t, "1234567890abcdef1234567890abcdef12345678", fileHash)
+	assert.Equal(t, "", key)
+}
+
+func TestEdit_Normalize(t *testing.T) {
+	e := Edit{Rotate: -90, CropW: 1, CropH: 1}
+	e.Normalize()
+	assert.Equal(t, 270, e.Rotate)
+	assert.False(t, e.Cropped())
+}
+
+func TestParseEdit(t *testing.T) {
+	e := Edit{Rotate: 180, CropX: 0.25, CropY: 0.25, CropW: 0.5, CropH: 0.5}
+
+	result, err := ParseEdit(e.JSON())
+	assert.NoError(t, err)
+	assert.Equal(t, e, result)
+
+	result, err = ParseEdit("")
+	assert.NoError(t, err)
+	assert.True(t, result.Empty())
+
+	_, err = ParseEdit("{")
+	assert.Error(t, err)
+}
+
+func TestEdit_Apply(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 400, 200))
+
+	t.Run("Rotate", func(t *testing.T) {
+		result := Edit{Rotate: 90}.Apply(img)
+		assert.Equal(t, 200, result.Bounds().Dx())
+		assert.Equal(t, 400, result.Bounds().Dy())
+	})
+	t.Run("Straighten", func(t *testing.T) {
+		result := Edit{Straighten: 10}.Apply(img)
+		assert.Less(t, result.Bounds().Dx(), 400)
+		assert.InDelta(t, 2.0, float64(result.Bounds().Dx())/float64(result.Bounds().Dy()), 0.02)
+	})
+	t.Run("Crop", func(t *testing.T) {
+		result := Edit{CropX: 0.5, CropY: 0.5, CropW: 0.5, CropH: 0.5}.Apply(img)
+		assert.Equal(t, 200, result.Bounds().Dx())
+		assert.Equal(t, 100, result.Bounds().Dy())
+	})
+}
+
+func TestEditFromFile(t *testing.T) {
+	thumbsPath := "testdata/cache"
+	defer os.RemoveAll(thumbsPath)
+
+	src := "testdata/example.jpg"
+	hash := "1234567890abcdef1234567890abcdef12345678"
+
+	e := Edit{Rotate: 90, CropW: 0.5, CropH: 0.5}
+
+	fileName, err := EditFromFile(src, hash, thumbsPath, e, 224, 224, OrientationNormal)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, fs.FileExists(fileName))
+	assert.Contains(t, fileName, e.Hash(hash))
+}
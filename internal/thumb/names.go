@@ -1,6 +1,11 @@
 package thumb
 
-import "github.com/photoprism/photoprism/pkg/fs"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
 
 // Name represents a thumbnail size name.
 type Name string
@@ -33,3 +38,23 @@ const (
 	Fit4096  Name = "fit_4096"
 	Fit7680  Name = "fit_7680"
 )
+
+// ParseNames parses a comma-separated list of thumbnail size names, e.g. "tile_224,fit_1280".
+func ParseNames(s string) (result []Name, err error) {
+	done := make(map[Name]bool)
+
+	for _, v := range strings.Split(s, ",") {
+		name := Name(strings.ToLower(strings.TrimSpace(v)))
+
+		if name == "" || done[name] {
+			continue
+		} else if _, ok := Sizes[name]; !ok {
+			return result, fmt.Errorf("invalid thumbnail size %s", name)
+		}
+
+		done[name] = true
+		result = append(result, name)
+	}
+
+	return result, nil
+}
@@ -11,3 +11,28 @@ func TestName_Jpeg(t *testing.T) {
 		assert.Equal(t, "tile_50.jpg", Tile50.Jpeg())
 	})
 }
+
+func TestParseNames(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		names, err := ParseNames("tile_224, FIT_1280,tile_224,")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []Name{Tile224, Fit1280}, names)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		names, err := ParseNames("")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, names)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseNames("tile_224,fit_1234")
+		assert.EqualError(t, err, "invalid thumbnail size fit_1234")
+	})
+}
@@ -0,0 +1,81 @@
+package thumb
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Quality maps thumbnail size names like "fit_2048" or size classes like "tile" to a JPEG quality.
+type Quality map[string]int
+
+// SizeQuality contains custom JPEG qualities that override the defaults for matching sizes.
+var SizeQuality = Quality{}
+
+// Class returns the thumbnail size class, e.g. "tile" for "tile_500".
+func (n Name) Class() string {
+	if i := strings.Index(string(n), "_"); i > 0 {
+		return string(n)[:i]
+	}
+
+	return string(n)
+}
+
+// Quality returns the JPEG quality of the thumbnail size.
+func (s Size) Quality() int {
+	if q, ok := SizeQuality[s.Name.String()]; ok {
+		return q
+	} else if q, ok = SizeQuality[s.Name.Class()]; ok {
+		return q
+	} else if s.Width <= 150 && s.Height <= 150 {
+		return JpegQualitySmall
+	}
+
+	return JpegQuality
+}
+
+// JpegQualityFor returns the JPEG quality of thumbnails with the given dimensions and resample options.
+func JpegQualityFor(width, height int, opts ...ResampleOption) int {
+	method, _, _ := ResampleOptions(opts...)
+
+	for _, size := range Sizes {
+		if size.Width != width || size.Height != height {
+			continue
+		} else if m, _, _ := ResampleOptions(size.Options...); m == method {
+			return size.Quality()
+		}
+	}
+
+	if width <= 150 && height <= 150 {
+		return JpegQualitySmall
+	}
+
+	return JpegQuality
+}
+
+// Valid returns the qualities of known thumbnail sizes or size classes that are between 25 and 100.
+func (q Quality) Valid() Quality {
+	result := make(Quality, len(q))
+
+	for key, value := range q {
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		if value < 25 || value > 100 {
+			log.Warnf("thumb: jpeg quality of %s must be between 25 and 100", sanitize.Log(key))
+			continue
+		}
+
+		for name := range Sizes {
+			if key == name.String() || key == name.Class() {
+				result[key] = value
+				break
+			}
+		}
+
+		if _, ok := result[key]; !ok {
+			log.Warnf("thumb: unknown size %s", sanitize.Log(key))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,49 @@
+package thumb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestName_Class(t *testing.T) {
+	assert.Equal(t, "tile", Tile500.Class())
+	assert.Equal(t, "fit", Fit2048.Class())
+	assert.Equal(t, "colors", Colors.Class())
+}
+
+func TestSize_Quality(t *testing.T) {
+	SizeQuality = Quality{"tile": 70, "fit_2048": 85}
+
+	defer func() { SizeQuality = Quality{} }()
+
+	assert.Equal(t, 70, Sizes[Tile500].Quality())
+	assert.Equal(t, 70, Sizes[Tile50].Quality())
+	assert.Equal(t, 85, Sizes[Fit2048].Quality())
+	assert.Equal(t, JpegQuality, Sizes[Fit1280].Quality())
+	assert.Equal(t, JpegQuality, Sizes[Left224].Quality())
+}
+
+func TestJpegQualityFor(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		assert.Equal(t, JpegQuality, JpegQualityFor(500, 500, ResampleFillCenter, ResampleDefault))
+		assert.Equal(t, JpegQualitySmall, JpegQualityFor(100, 100, ResampleFillCenter, ResampleDefault))
+		assert.Equal(t, JpegQualitySmall, JpegQualityFor(120, 80))
+		assert.Equal(t, JpegQuality, JpegQualityFor(800, 600))
+	})
+	t.Run("Custom", func(t *testing.T) {
+		SizeQuality = Quality{"tile": 70, "right_224": 60}
+
+		defer func() { SizeQuality = Quality{} }()
+
+		assert.Equal(t, 70, JpegQualityFor(224, 224, ResampleFillCenter, ResampleDefault))
+		assert.Equal(t, 60, JpegQualityFor(224, 224, ResampleFillBottomRight, ResampleDefault))
+		assert.Equal(t, JpegQuality, JpegQualityFor(224, 224, ResampleFillTopLeft, ResampleDefault))
+	})
+}
+
+func TestQuality_Valid(t *testing.T) {
+	q := Quality{"Tile": 70, "fit_2048": 85, "fit_9999": 80, "left_224": 20, "colors": 101}
+
+	assert.Equal(t, Quality{"tile": 70, "fit_2048": 85}, q.Valid())
+}
@@ -0,0 +1,151 @@
+package thumb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+	"os"
+
+	"github.com/disintegration/imaging"
+	"golang.org/x/image/tiff"
+)
+
+// TiffPagesLimit is the maximum number of TIFF pages that will be read.
+const TiffPagesLimit = 1000
+
+// TiffPages returns the number of pages (image file directories) in a TIFF file.
+func TiffPages(fileName string) (int, error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer f.Close()
+
+	_, offsets, err := tiffPageOffsets(f)
+
+	return len(offsets), err
+}
+
+// OpenTiffPage decodes a single page of a multi-page TIFF file, starting with page 1.
+func OpenTiffPage(fileName string, page int, orientation int) (result image.Image, err error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return result, err
+	}
+
+	order, offsets, err := tiffPageOffsets(f)
+
+	if err != nil {
+		return result, err
+	} else if page < 1 || page > len(offsets) {
+		return result, fmt.Errorf("tiff: page %d not found", page)
+	}
+
+	// Point the header to the requested page, as the decoder only reads the first image file directory.
+	r := &tiffPageReader{r: f}
+
+	if _, err = f.ReadAt(r.header[:4], 0); err != nil {
+		return result, err
+	}
+
+	order.PutUint32(r.header[4:], offsets[page-1])
+
+	if result, err = tiff.Decode(io.NewSectionReader(r, 0, info.Size())); err != nil {
+		return result, err
+	}
+
+	if orientation > 1 {
+		result = Rotate(result, orientation)
+	}
+
+	return result, nil
+}
+
+// TiffPageJpeg saves a single page of a multi-page TIFF file as JPEG, starting with page 1.
+func TiffPageJpeg(srcFilename, jpgFilename string, page int, orientation int) (img image.Image, err error) {
+	if img, err = OpenTiffPage(srcFilename, page, orientation); err != nil {
+		return img, err
+	}
+
+	if err = imaging.Save(img, jpgFilename, imaging.JPEGQuality(JpegQuality)); err != nil {
+		return img, err
+	}
+
+	return img, nil
+}
+
+// tiffPageReader replaces the TIFF file header so that the first image file directory can be changed.
+type tiffPageReader struct {
+	r      io.ReaderAt
+	header [8]byte
+}
+
+// ReadAt implements io.ReaderAt.
+func (t *tiffPageReader) ReadAt(p []byte, off int64) (n int, err error) {
+	n, err = t.r.ReadAt(p, off)
+
+	for i := 0; i < n && off+int64(i) < int64(len(t.header)); i++ {
+		p[i] = t.header[off+int64(i)]
+	}
+
+	return n, err
+}
+
+// tiffPageOffsets returns the byte order and the offsets of all image file directories in a TIFF file.
+func tiffPageOffsets(r io.ReaderAt) (order binary.ByteOrder, offsets []uint32, err error) {
+	header := make([]byte, 8)
+
+	if _, err = r.ReadAt(header, 0); err != nil {
+		return order, offsets, fmt.Errorf("tiff: %s", err)
+	}
+
+	switch string(header[0:4]) {
+	case "II\x2A\x00":
+		order = binary.LittleEndian
+	case "MM\x00\x2A":
+		order = binary.BigEndian
+	default:
+		return order, offsets, fmt.Errorf("tiff: invalid header")
+	}
+
+	seen := make(map[uint32]bool)
+	buf := make([]byte, 4)
+
+	for offset := order.Uint32(header[4:]); offset != 0; {
+		if seen[offset] {
+			return order, offsets, fmt.Errorf("tiff: circular page offsets")
+		} else if len(offsets) >= TiffPagesLimit {
+			break
+		}
+
+		seen[offset] = true
+		offsets = append(offsets, offset)
+
+		// Each directory starts with the number of 12-byte entries followed by the next directory offset.
+		if _, err = r.ReadAt(buf[:2], int64(offset)); err != nil {
+			return order, offsets, fmt.Errorf("tiff: %s", err)
+		}
+
+		next := int64(offset) + 2 + int64(order.Uint16(buf[:2]))*12
+
+		if _, err = r.ReadAt(buf, next); err != nil {
+			return order, offsets, fmt.Errorf("tiff: %s", err)
+		}
+
+		offset = order.Uint32(buf)
+	}
+
+	return order, offsets, nil
+}
@@ -0,0 +1,94 @@
+package thumb
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTiffPages(t *testing.T) {
+	t.Run("multipage.tif", func(t *testing.T) {
+		pages, err := TiffPages("testdata/multipage.tif")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 3, pages)
+	})
+	t.Run("example.tif", func(t *testing.T) {
+		pages, err := TiffPages("testdata/example.tif")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, pages)
+	})
+	t.Run("example.png", func(t *testing.T) {
+		pages, err := TiffPages("testdata/example.png")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, pages)
+	})
+	t.Run("not existing", func(t *testing.T) {
+		_, err := TiffPages("testdata/xxx.tif")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestOpenTiffPage(t *testing.T) {
+	t.Run("first page", func(t *testing.T) {
+		img, err := OpenTiffPage("testdata/multipage.tif", 1, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 16, img.Bounds().Dx())
+		assert.Equal(t, 8, img.Bounds().Dy())
+	})
+	t.Run("second page", func(t *testing.T) {
+		img, err := OpenTiffPage("testdata/multipage.tif", 2, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 8, img.Bounds().Dx())
+		assert.Equal(t, 16, img.Bounds().Dy())
+	})
+	t.Run("rotate", func(t *testing.T) {
+		img, err := OpenTiffPage("testdata/multipage.tif", 3, OrientationRotate90)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 12, img.Bounds().Dx())
+	})
+	t.Run("invalid page", func(t *testing.T) {
+		_, err := OpenTiffPage("testdata/multipage.tif", 4, 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestTiffPageJpeg(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "multipage.tif.p2.jpg")
+
+	img, err := TiffPageJpeg("testdata/multipage.tif", dest, 2, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 8, img.Bounds().Dx())
+	assert.FileExists(t, dest)
+
+	_, err = TiffPageJpeg("testdata/multipage.tif", dest, 4, 0)
+
+	assert.Error(t, err)
+}
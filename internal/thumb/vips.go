@@ -0,0 +1,108 @@
+package thumb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Thumbnail library names.
+const (
+	LibImaging = "imaging"
+	LibVips    = "vips"
+)
+
+// Library is the thumbnail library, either the pure Go imaging package or libvips.
+var Library = LibImaging
+
+// VipsBin is the vipsthumbnail executable file name.
+var VipsBin = ""
+
+// VipsEnabled tests if thumbnails should be created with libvips.
+func VipsEnabled() bool {
+	return Library == LibVips && VipsBin != ""
+}
+
+// vipsArgs returns the vipsthumbnail command arguments.
+func vipsArgs(imageFilename, fileName string, width, height, orientation int, opts ...ResampleOption) (args []string) {
+	method, _, format := ResampleOptions(opts...)
+
+	args = []string{imageFilename}
+
+	switch method {
+	case ResampleFillCenter:
+		args = append(args, "--size", fmt.Sprintf("%dx%d", width, height), "--smartcrop", "centre")
+	case ResampleFillTopLeft:
+		args = append(args, "--size", fmt.Sprintf("%dx%d", width, height), "--smartcrop", "low")
+	case ResampleFillBottomRight:
+		args = append(args, "--size", fmt.Sprintf("%dx%d", width, height), "--smartcrop", "high")
+	case ResampleResize:
+		args = append(args, "--size", fmt.Sprintf("%dx%d!", width, height))
+	default:
+		// Like imaging.Fit, smaller images are not enlarged.
+		args = append(args, "--size", fmt.Sprintf("%dx%d>", width, height))
+	}
+
+	// Libvips rotates images based on their Exif orientation, so this must be disabled if they don't need rotation.
+	if orientation <= 1 {
+		args = append(args, "--no-rotate")
+	}
+
+	args = append(args, "--eprofile", "srgb")
+
+	if format == fs.FormatPng {
+		args = append(args, "-o", fileName+"[strip]")
+	} else {
+		args = append(args, "-o", fmt.Sprintf("%s[Q=%d,strip]", fileName, JpegQualityFor(width, height, opts...)))
+	}
+
+	return args
+}
+
+// Vips creates a thumbnail with vipsthumbnail, this is much faster and uses less memory than decoding
+// the image in Go, as large JPEGs are shrunk while loading.
+func Vips(imageFilename, fileName string, width, height, orientation int, opts ...ResampleOption) error {
+	if VipsBin == "" {
+		return errors.New("resample: vipsthumbnail not found")
+	}
+
+	if InvalidSize(width) {
+		return fmt.Errorf("resample: width has an invalid value (%d)", width)
+	}
+
+	if InvalidSize(height) {
+		return fmt.Errorf("resample: height has an invalid value (%d)", height)
+	}
+
+	if !fs.FileExists(imageFilename) {
+		return fmt.Errorf("resample: %s not found", sanitize.Log(filepath.Base(imageFilename)))
+	}
+
+	cmd := exec.Command(VipsBin, vipsArgs(imageFilename, fileName, width, height, orientation, opts...)...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		_ = os.Remove(fileName)
+
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("resample: %s", msg)
+		}
+
+		return err
+	}
+
+	if !fs.FileExists(fileName) {
+		return fmt.Errorf("resample: failed to create %s", sanitize.Log(filepath.Base(fileName)))
+	}
+
+	return nil
+}
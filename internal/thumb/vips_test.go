@@ -0,0 +1,74 @@
+package thumb
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVipsEnabled(t *testing.T) {
+	lib, bin := Library, VipsBin
+
+	defer func() {
+		Library, VipsBin = lib, bin
+	}()
+
+	Library, VipsBin = LibImaging, "/usr/bin/vipsthumbnail"
+	assert.False(t, VipsEnabled())
+	Library, VipsBin = LibVips, ""
+	assert.False(t, VipsEnabled())
+	Library, VipsBin = LibVips, "/usr/bin/vipsthumbnail"
+	assert.True(t, VipsEnabled())
+}
+
+func TestVipsArgs(t *testing.T) {
+	t.Run("Fit", func(t *testing.T) {
+		args := vipsArgs("in.jpg", "out.jpg", 720, 720, 1, ResampleFit)
+		assert.Equal(t, []string{"in.jpg", "--size", "720x720>", "--no-rotate", "--eprofile", "srgb", "-o", fmt.Sprintf("out.jpg[Q=%d,strip]", JpegQualityFor(720, 720, ResampleFit))}, args)
+	})
+	t.Run("FillCenter", func(t *testing.T) {
+		args := vipsArgs("in.jpg", "out.jpg", 224, 224, 6, ResampleFillCenter, ResampleDefault)
+		assert.Equal(t, []string{"in.jpg", "--size", "224x224", "--smartcrop", "centre", "--eprofile", "srgb", "-o", fmt.Sprintf("out.jpg[Q=%d,strip]", JpegQualityFor(224, 224, ResampleFillCenter, ResampleDefault))}, args)
+	})
+	t.Run("FillTopLeft", func(t *testing.T) {
+		args := vipsArgs("in.jpg", "out.jpg", 100, 100, 0, ResampleFillTopLeft)
+		assert.Contains(t, args, "low")
+	})
+	t.Run("FillBottomRight", func(t *testing.T) {
+		args := vipsArgs("in.jpg", "out.jpg", 100, 100, 0, ResampleFillBottomRight)
+		assert.Contains(t, args, "high")
+	})
+	t.Run("ResizePng", func(t *testing.T) {
+		args := vipsArgs("in.jpg", "out.png", 50, 50, 0, ResampleResize, ResamplePng)
+		assert.Equal(t, []string{"in.jpg", "--size", "50x50!", "--no-rotate", "--eprofile", "srgb", "-o", "out.png[strip]"}, args)
+	})
+}
+
+func TestVips(t *testing.T) {
+	bin := VipsBin
+
+	defer func() {
+		VipsBin = bin
+	}()
+
+	fileName := filepath.Join(t.TempDir(), "out.jpg")
+
+	t.Run("NotFound", func(t *testing.T) {
+		VipsBin = ""
+		assert.Error(t, Vips("testdata/example.jpg", fileName, 100, 100, 1, ResampleFit))
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		VipsBin = "/bin/true"
+		assert.Error(t, Vips("testdata/example.jpg", fileName, 100000, 100, 1, ResampleFit))
+	})
+	t.Run("NoOutput", func(t *testing.T) {
+		VipsBin = "/bin/true"
+		assert.Error(t, Vips("testdata/example.jpg", fileName, 100, 100, 1, ResampleFit))
+	})
+	t.Run("CommandFailed", func(t *testing.T) {
+		VipsBin = "/bin/false"
+		assert.Error(t, Vips("testdata/example.jpg", fileName, 100, 100, 1, ResampleFit))
+	})
+}
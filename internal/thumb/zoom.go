@@ -0,0 +1,321 @@
+package thumb
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Deep Zoom tile pyramid settings, see https://docs.microsoft.com/en-us/previous-versions/windows/silverlight/dotnet-windows-silverlight/cc645077(v=vs.95).
+const (
+	ZoomTileSize   = 254
+	ZoomOverlap    = 1
+	ZoomFormat     = fs.FormatJpeg
+	ZoomDescriptor = "image.dzi"
+	ZoomXmlns      = "http://schemas.microsoft.com/deepzoom/2008"
+)
+
+// ZoomSuffix is appended to the file hash to get the name of the tile pyramid cache folder, so that
+// it can be cleaned up like other thumbnails.
+const ZoomSuffix = "_zoom"
+
+var ErrZoomTileNotFound = errors.New("zoom: tile not found")
+var ErrZoomPending = errors.New("zoom: tiles are being created")
+
+// zoomLock prevents the same tile pyramid from being created multiple times in parallel.
+type zoomLock struct {
+	sync.Mutex
+	refs int
+}
+
+// zoomLocks contains the locks of tile pyramids that are being created, entries are removed when done.
+var zoomLocks = struct {
+	locks map[string]*zoomLock
+	mutex sync.Mutex
+}{locks: make(map[string]*zoomLock)}
+
+// zoomWorker limits the number of tile pyramids that are created in the background at the same time,
+// as decoding very large images requires a lot of memory.
+var zoomWorker = make(chan struct{}, 1)
+
+// lockZoom locks the tile pyramid of a file hash and returns a function that releases the lock.
+func lockZoom(hash string) (unlock func()) {
+	zoomLocks.mutex.Lock()
+
+	m, ok := zoomLocks.locks[hash]
+
+	if !ok {
+		m = &zoomLock{}
+		zoomLocks.locks[hash] = m
+	}
+
+	m.refs++
+	zoomLocks.mutex.Unlock()
+
+	m.Lock()
+
+	return func() {
+		m.Unlock()
+
+		zoomLocks.mutex.Lock()
+		defer zoomLocks.mutex.Unlock()
+
+		if m.refs--; m.refs < 1 {
+			delete(zoomLocks.locks, hash)
+		}
+	}
+}
+
+// ZoomLocked tests if the tile pyramid of a file hash is being created.
+func ZoomLocked(hash string) bool {
+	zoomLocks.mutex.Lock()
+	defer zoomLocks.mutex.Unlock()
+
+	_, ok := zoomLocks.locks[hash]
+
+	return ok
+}
+
+// ZoomImage represents a Deep Zoom image descriptor.
+type ZoomImage struct {
+	XMLName  xml.Name `xml:"Image"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Format   string   `xml:"Format,attr"`
+	Overlap  int      `xml:"Overlap,attr"`
+	TileSize int      `xml:"TileSize,attr"`
+	Size     ZoomSize `xml:"Size"`
+}
+
+// ZoomSize represents the full resolution of a Deep Zoom image.
+type ZoomSize struct {
+	Width  int `xml:"Width,attr"`
+	Height int `xml:"Height,attr"`
+}
+
+// NewZoomImage returns a new Deep Zoom image descriptor for the given resolution.
+func NewZoomImage(width, height int) ZoomImage {
+	return ZoomImage{
+		Xmlns:    ZoomXmlns,
+		Format:   string(ZoomFormat),
+		Overlap:  ZoomOverlap,
+		TileSize: ZoomTileSize,
+		Size:     ZoomSize{Width: width, Height: height},
+	}
+}
+
+// Levels returns the number of pyramid levels, the last level having the full resolution.
+func (m ZoomImage) Levels() int {
+	return ZoomLevels(m.Size.Width, m.Size.Height)
+}
+
+// Bytes returns the descriptor as XML document.
+func (m ZoomImage) Bytes() ([]byte, error) {
+	data, err := xml.Marshal(m)
+
+	if err != nil {
+		return data, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// ZoomLevels returns the number of pyramid levels for an image with the given resolution.
+func ZoomLevels(width, height int) int {
+	if width < 1 || height < 1 {
+		return 0
+	}
+
+	return int(math.Ceil(math.Log2(math.Max(float64(width), float64(height))))) + 1
+}
+
+// ZoomLevelSize returns the image resolution at the given pyramid level.
+func ZoomLevelSize(width, height, level int) (w, h int) {
+	scale := math.Pow(2, float64(ZoomLevels(width, height)-1-level))
+
+	w = int(math.Ceil(float64(width) / scale))
+	h = int(math.Ceil(float64(height) / scale))
+
+	return w, h
+}
+
+// ZoomPath returns the tile pyramid cache folder for a file hash.
+func ZoomPath(hash string, thumbPath string) (string, error) {
+	if len(hash) < 4 {
+		return "", fmt.Errorf("zoom: file hash is empty or too short (%s)", sanitize.Log(hash))
+	}
+
+	if len(thumbPath) == 0 {
+		return "", errors.New("zoom: folder is empty")
+	}
+
+	return path.Join(thumbPath, hash[0:1], hash[1:2], hash[2:3], hash+ZoomSuffix), nil
+}
+
+// ZoomTileName returns the file name of a tile in the pyramid cache folder.
+func ZoomTileName(zoomPath string, level, col, row int) string {
+	return filepath.Join(zoomPath, fmt.Sprintf("%d", level), fmt.Sprintf("%d_%d.%s", col, row, ZoomFormat))
+}
+
+// ZoomTile returns the cached file name of a tile, and creates the tile pyramid if needed.
+func ZoomTile(imageFilename, hash, thumbPath string, orientation, level, col, row int) (fileName string, err error) {
+	zoomPath, err := ZoomFromFile(imageFilename, hash, thumbPath, orientation)
+
+	if err != nil {
+		return "", err
+	}
+
+	fileName = ZoomTileName(zoomPath, level, col, row)
+
+	if !fs.FileExists(fileName) {
+		return "", ErrZoomTileNotFound
+	}
+
+	return fileName, nil
+}
+
+// ZoomCached returns the tile pyramid cache folder for a file hash if the pyramid exists.
+func ZoomCached(hash, thumbPath string) (zoomPath string, ok bool) {
+	zoomPath, err := ZoomPath(hash, thumbPath)
+
+	if err != nil {
+		return "", false
+	}
+
+	return zoomPath, fs.FileExists(filepath.Join(zoomPath, ZoomDescriptor))
+}
+
+// ZoomStart creates the tile pyramid for an image in the background and returns ErrZoomPending
+// until it is done, or nil if it already exists.
+func ZoomStart(imageFilename, hash, thumbPath string, orientation int) error {
+	if _, err := ZoomPath(hash, thumbPath); err != nil {
+		return err
+	} else if _, ok := ZoomCached(hash, thumbPath); ok {
+		return nil
+	} else if ZoomLocked(hash) {
+		return ErrZoomPending
+	}
+
+	unlock := lockZoom(hash)
+
+	go func() {
+		defer unlock()
+
+		zoomWorker <- struct{}{}
+		defer func() { <-zoomWorker }()
+
+		if _, err := createZoom(imageFilename, hash, thumbPath, orientation); err != nil {
+			log.Errorf("zoom: %s", err)
+		}
+	}()
+
+	return ErrZoomPending
+}
+
+// ZoomFromFile returns the tile pyramid cache folder for an image, and creates the pyramid if needed.
+// Tiles of all levels are created at once, so that the image is only decoded a single time.
+func ZoomFromFile(imageFilename, hash, thumbPath string, orientation int) (zoomPath string, err error) {
+	if zoomPath, ok := ZoomCached(hash, thumbPath); ok {
+		return zoomPath, nil
+	}
+
+	defer lockZoom(hash)()
+
+	return createZoom(imageFilename, hash, thumbPath, orientation)
+}
+
+// createZoom creates the tile pyramid for an image unless it exists, the caller must hold the lock.
+func createZoom(imageFilename, hash, thumbPath string, orientation int) (zoomPath string, err error) {
+	if zoomPath, err = ZoomPath(hash, thumbPath); err != nil {
+		return "", err
+	}
+
+	// Created while waiting for the lock?
+	if fs.FileExists(filepath.Join(zoomPath, ZoomDescriptor)) {
+		return zoomPath, nil
+	}
+
+	img, err := Open(imageFilename, orientation)
+
+	if err != nil {
+		log.Error(err)
+		return "", err
+	}
+
+	if err = CreateZoom(img, zoomPath); err != nil {
+		return "", err
+	}
+
+	return zoomPath, nil
+}
+
+// CreateZoom creates the tiles of all pyramid levels and writes the descriptor when done.
+func CreateZoom(img image.Image, zoomPath string) error {
+	bounds := img.Bounds()
+	desc := NewZoomImage(bounds.Dx(), bounds.Dy())
+	levels := desc.Levels()
+
+	if levels < 1 {
+		return fmt.Errorf("zoom: invalid image size %dx%d", bounds.Dx(), bounds.Dy())
+	}
+
+	// Tiles are cropped relative to the origin.
+	if bounds.Min != (image.Point{}) {
+		img = imaging.Clone(img)
+	}
+
+	quality := imaging.JPEGQuality(JpegQuality)
+
+	// Each level is created by scaling down the next higher level.
+	for level := levels - 1; level >= 0; level-- {
+		w, h := ZoomLevelSize(desc.Size.Width, desc.Size.Height, level)
+
+		if b := img.Bounds(); b.Dx() != w || b.Dy() != h {
+			img = imaging.Resize(img, w, h, imaging.Lanczos)
+		}
+
+		levelPath := filepath.Join(zoomPath, fmt.Sprintf("%d", level))
+
+		if err := os.MkdirAll(levelPath, os.ModePerm); err != nil {
+			return err
+		}
+
+		for col := 0; col*ZoomTileSize < w; col++ {
+			for row := 0; row*ZoomTileSize < h; row++ {
+				// Tiles overlap their neighbors, the crop rectangle is clipped to the image bounds.
+				rect := image.Rect(
+					col*ZoomTileSize-ZoomOverlap,
+					row*ZoomTileSize-ZoomOverlap,
+					(col+1)*ZoomTileSize+ZoomOverlap,
+					(row+1)*ZoomTileSize+ZoomOverlap,
+				)
+
+				tile := imaging.Crop(img, rect)
+				fileName := ZoomTileName(zoomPath, level, col, row)
+
+				if err := imaging.Save(tile, fileName, quality); err != nil {
+					log.Errorf("zoom: failed to save %s", sanitize.Log(filepath.Base(fileName)))
+					return err
+				}
+			}
+		}
+	}
+
+	data, err := desc.Bytes()
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(zoomPath, ZoomDescriptor), data, os.ModePerm)
+}
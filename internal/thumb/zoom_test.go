@@ -0,0 +1,126 @@
+package thumb
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZoomLevels(t *testing.T) {
+	assert.Equal(t, 0, ZoomLevels(0, 100))
+	assert.Equal(t, 1, ZoomLevels(1, 1))
+	assert.Equal(t, 11, ZoomLevels(1024, 768))
+	assert.Equal(t, 12, ZoomLevels(1025, 768))
+	assert.Equal(t, 16, ZoomLevels(20000, 15000))
+}
+
+func TestZoomLevelSize(t *testing.T) {
+	w, h := ZoomLevelSize(1025, 768, 11)
+	assert.Equal(t, 1025, w)
+	assert.Equal(t, 768, h)
+
+	w, h = ZoomLevelSize(1025, 768, 10)
+	assert.Equal(t, 513, w)
+	assert.Equal(t, 384, h)
+
+	w, h = ZoomLevelSize(1025, 768, 0)
+	assert.Equal(t, 1, w)
+	assert.Equal(t, 1, h)
+}
+
+func TestZoomImage_Bytes(t *testing.T) {
+	data, err := NewZoomImage(750, 500).Bytes()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := string(data)
+
+	assert.True(t, strings.HasPrefix(s, "<?xml"))
+	assert.Contains(t, s, `<Image xmlns="http://schemas.microsoft.com/deepzoom/2008" Format="jpg" Overlap="1" TileSize="254">`)
+	assert.Contains(t, s, `<Size Width="750" Height="500"></Size>`)
+}
+
+func TestZoomPath(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		result, err := ZoomPath("193456789098765432", "/storage/thumbs")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/storage/thumbs/1/9/3/193456789098765432_zoom", result)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		_, err := ZoomPath("19", "/storage/thumbs")
+
+		assert.Error(t, err)
+	})
+	t.Run("EmptyPath", func(t *testing.T) {
+		_, err := ZoomPath("193456789098765432", "")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestZoomTile(t *testing.T) {
+	thumbsPath := "./testdata/zoom"
+	hash := "1234567890abcdef"
+
+	defer os.RemoveAll(thumbsPath)
+
+	t.Run("Created", func(t *testing.T) {
+		fileName, err := ZoomTile("testdata/example.jpg", hash, thumbsPath, 1, 10, 1, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, filepath.Join(thumbsPath, "1/2/3", hash+"_zoom", "10", "1_0.jpg"), fileName)
+		assert.FileExists(t, fileName)
+		assert.FileExists(t, filepath.Join(thumbsPath, "1/2/3", hash+"_zoom", ZoomDescriptor))
+		assert.FileExists(t, filepath.Join(thumbsPath, "1/2/3", hash+"_zoom", "0", "0_0.jpg"))
+
+		img, err := Open(fileName, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ZoomTileSize+2*ZoomOverlap, img.Bounds().Dx())
+		assert.False(t, ZoomLocked(hash))
+		assert.Empty(t, zoomLocks.locks)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := ZoomTile("testdata/example.jpg", hash, thumbsPath, 1, 10, 100, 100)
+
+		assert.Equal(t, ErrZoomTileNotFound, err)
+	})
+	t.Run("InvalidFile", func(t *testing.T) {
+		_, err := ZoomTile("testdata/missing.jpg", "abcdef1234567890", thumbsPath, 1, 10, 0, 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestZoomStart(t *testing.T) {
+	thumbsPath := "./testdata/zoom-start"
+	hash := "234567890abcdef1"
+
+	defer os.RemoveAll(thumbsPath)
+
+	assert.Equal(t, ErrZoomPending, ZoomStart("testdata/example.jpg", hash, thumbsPath, 1))
+
+	for i := 0; ZoomLocked(hash) && i < 100; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	zoomPath, ok := ZoomCached(hash, thumbsPath)
+
+	assert.True(t, ok)
+	assert.FileExists(t, filepath.Join(zoomPath, ZoomDescriptor))
+	assert.NoError(t, ZoomStart("testdata/example.jpg", hash, thumbsPath, 1))
+	assert.Empty(t, zoomLocks.locks)
+}
@@ -0,0 +1,169 @@
+package tiles
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Tile formats, see https://github.com/mapbox/mbtiles-spec/blob/master/1.3/spec.md.
+const (
+	FormatPng  = "png"
+	FormatJpeg = "jpg"
+	FormatWebp = "webp"
+	FormatPbf  = "pbf"
+)
+
+// ContentTypes maps tile formats to their content type.
+var ContentTypes = map[string]string{
+	FormatPng:  "image/png",
+	FormatJpeg: "image/jpeg",
+	FormatWebp: "image/webp",
+	FormatPbf:  "application/x-protobuf",
+}
+
+// MBTiles represents a read-only MBTiles file, which is an SQLite database containing map tiles.
+type MBTiles struct {
+	fileName string
+	format   string
+	db       *gorm.DB
+}
+
+// Open opens an MBTiles file for reading.
+func Open(fileName string) (*MBTiles, error) {
+	db, err := gorm.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro", fileName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := &MBTiles{fileName: fileName, db: db}
+
+	if meta, err := m.Metadata(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("tiles: %s is not a valid mbtiles file (%s)", sanitize.Log(filepath.Base(fileName)), err)
+	} else if _, ok := ContentTypes[meta["format"]]; ok {
+		m.format = meta["format"]
+	} else {
+		m.format = FormatPng
+	}
+
+	return m, nil
+}
+
+// Metadata returns the name and value pairs of the metadata table.
+func (m *MBTiles) Metadata() (map[string]string, error) {
+	result := make(map[string]string)
+
+	rows, err := m.db.Raw("SELECT name, value FROM metadata").Rows()
+
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, value string
+
+		if err := rows.Scan(&name, &value); err != nil {
+			return result, err
+		}
+
+		result[name] = value
+	}
+
+	return result, rows.Err()
+}
+
+// Format returns the tile format, e.g. png or pbf for vector tiles.
+func (m *MBTiles) Format() string {
+	return m.format
+}
+
+// ContentType returns the content type of the tiles.
+func (m *MBTiles) ContentType() string {
+	return ContentTypes[m.format]
+}
+
+// Tile returns the tile data for the given zoom level and XYZ coordinates, or nil if it doesn't exist.
+func (m *MBTiles) Tile(z, x, y int) (data []byte, err error) {
+	if z < 0 || z > 30 || x < 0 || y < 0 || x >= 1<<z || y >= 1<<z {
+		return nil, fmt.Errorf("tiles: invalid coordinates %d/%d/%d", z, x, y)
+	}
+
+	// MBTiles use the TMS scheme, so the row numbers start at the bottom.
+	row := (1 << z) - 1 - y
+
+	rows, err := m.db.Raw("SELECT tile_data FROM tiles WHERE zoom_level = ? AND tile_column = ? AND tile_row = ?", z, x, row).Rows()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	if rows.Next() {
+		err = rows.Scan(&data)
+	}
+
+	return data, err
+}
+
+// Gzipped tests if tile data is compressed with gzip, as is common for vector tiles.
+func Gzipped(data []byte) bool {
+	return bytes.HasPrefix(data, []byte{0x1f, 0x8b})
+}
+
+// Close closes the MBTiles file.
+func (m *MBTiles) Close() error {
+	return m.db.Close()
+}
+
+// files caches opened MBTiles files by name.
+var files = struct {
+	open  map[string]*MBTiles
+	mutex sync.Mutex
+}{open: make(map[string]*MBTiles)}
+
+// Get returns an opened MBTiles file, so that it doesn't need to be opened for each tile.
+func Get(fileName string) (*MBTiles, error) {
+	files.mutex.Lock()
+	defer files.mutex.Unlock()
+
+	if m, ok := files.open[fileName]; ok {
+		return m, nil
+	}
+
+	m, err := Open(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("tiles: opened %s", sanitize.Log(filepath.Base(fileName)))
+
+	files.open[fileName] = m
+
+	return m, nil
+}
+
+// CloseAll closes all cached MBTiles files.
+func CloseAll() {
+	files.mutex.Lock()
+	defer files.mutex.Unlock()
+
+	for fileName, m := range files.open {
+		if err := m.Close(); err != nil {
+			log.Warnf("tiles: %s", err)
+		}
+
+		delete(files.open, fileName)
+	}
+}
@@ -0,0 +1,145 @@
+package tiles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+// createTestFile creates an MBTiles file with a single tile at zoom level 1.
+func createTestFile(t *testing.T, format string) string {
+	fileName := filepath.Join(t.TempDir(), "test.mbtiles")
+
+	db, err := gorm.Open("sqlite3", fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	for _, stmt := range []string{
+		"CREATE TABLE metadata (name TEXT, value TEXT)",
+		"CREATE TABLE tiles (zoom_level INTEGER, tile_column INTEGER, tile_row INTEGER, tile_data BLOB)",
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := db.Exec("INSERT INTO metadata (name, value) VALUES ('name', 'Test'), ('format', ?)", format).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Row 0 is the bottom row in the TMS scheme.
+	if err := db.Exec("INSERT INTO tiles VALUES (1, 1, 0, ?)", []byte{0x1f, 0x8b, 0x08}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("Pbf", func(t *testing.T) {
+		m, err := Open(createTestFile(t, FormatPbf))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.Close()
+
+		assert.Equal(t, FormatPbf, m.Format())
+		assert.Equal(t, "application/x-protobuf", m.ContentType())
+
+		meta, err := m.Metadata()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Test", meta["name"])
+	})
+	t.Run("UnknownFormat", func(t *testing.T) {
+		m, err := Open(createTestFile(t, "xxx"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.Close()
+
+		assert.Equal(t, FormatPng, m.Format())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Open(filepath.Join(t.TempDir(), "missing.mbtiles"))
+		assert.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "invalid.mbtiles")
+
+		if err := os.WriteFile(fileName, []byte("foo"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := Open(fileName)
+		assert.Error(t, err)
+	})
+}
+
+func TestMBTiles_Tile(t *testing.T) {
+	m, err := Open(createTestFile(t, FormatPbf))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Close()
+
+	t.Run("Found", func(t *testing.T) {
+		data, err := m.Tile(1, 1, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x1f, 0x8b, 0x08}, data)
+		assert.True(t, Gzipped(data))
+	})
+	t.Run("Missing", func(t *testing.T) {
+		data, err := m.Tile(1, 1, 0)
+
+		assert.NoError(t, err)
+		assert.Nil(t, data)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := m.Tile(1, 2, 0)
+		assert.Error(t, err)
+
+		_, err = m.Tile(-1, 0, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestGet(t *testing.T) {
+	fileName := createTestFile(t, FormatPng)
+
+	m, err := Get(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cached, err := Get(fileName)
+
+	assert.NoError(t, err)
+	assert.Same(t, m, cached)
+
+	CloseAll()
+
+	_, err = Get(filepath.Join(t.TempDir(), "missing.mbtiles"))
+	assert.Error(t, err)
+}
+
+func TestGzipped(t *testing.T) {
+	assert.True(t, Gzipped([]byte{0x1f, 0x8b}))
+	assert.False(t, Gzipped([]byte{0x89, 'P', 'N', 'G'}))
+	assert.False(t, Gzipped(nil))
+}
@@ -0,0 +1,202 @@
+/*
+
+Package tracing records OpenTelemetry spans for API requests, database queries, and background workers.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.app/developer-guide/
+
+*/
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// Name is the instrumentation name of the tracer.
+const Name = "github.com/photoprism/photoprism"
+
+// ShutdownTimeout is the maximum duration for exporting remaining spans on shutdown.
+var ShutdownTimeout = 5 * time.Second
+
+var (
+	provider *sdktrace.TracerProvider
+	mu       sync.Mutex
+)
+
+// Options represents the tracing exporter settings.
+type Options struct {
+	Endpoint   string  // OTLP/HTTP collector host and port or url, e.g. localhost:4318.
+	Insecure   bool    // Use HTTP instead of HTTPS.
+	SampleRate float64 // Fraction of traces that are recorded, from 0 to 1.
+	Service    string
+	Version    string
+}
+
+// Init sets up the OTLP exporter, tracing stays disabled if no endpoint is configured.
+func Init(opt Options) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if opt.Endpoint == "" || provider != nil {
+		return nil
+	}
+
+	clientOpt, err := exporterOptions(opt.Endpoint, opt.Insecure)
+
+	if err != nil {
+		return err
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), clientOpt...)
+
+	if err != nil {
+		return fmt.Errorf("tracing: %s", err)
+	}
+
+	res := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String(opt.Service),
+		semconv.ServiceVersionKey.String(opt.Version),
+	)
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opt.SampleRate))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	log.Infof("tracing: sending spans to %s", opt.Endpoint)
+
+	return nil
+}
+
+// exporterOptions returns the exporter client options for the given endpoint.
+func exporterOptions(endpoint string, insecure bool) (opt []otlptracehttp.Option, err error) {
+	endpoint = strings.TrimSpace(endpoint)
+
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+
+		if err != nil || u.Host == "" {
+			return opt, fmt.Errorf("tracing: invalid endpoint %s", endpoint)
+		}
+
+		switch u.Scheme {
+		case "http":
+			insecure = true
+		case "https":
+		default:
+			return opt, fmt.Errorf("tracing: unsupported endpoint scheme %s", u.Scheme)
+		}
+
+		endpoint = u.Host
+
+		if u.Path != "" && u.Path != "/" {
+			opt = append(opt, otlptracehttp.WithURLPath(u.Path))
+		}
+	}
+
+	opt = append(opt, otlptracehttp.WithEndpoint(endpoint))
+
+	if insecure {
+		opt = append(opt, otlptracehttp.WithInsecure())
+	}
+
+	return opt, nil
+}
+
+// Enabled tests if spans are exported.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return provider != nil
+}
+
+// Shutdown exports remaining spans and stops the exporter.
+func Shutdown() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if provider == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+
+	if err := provider.Shutdown(ctx); err != nil {
+		log.Warnf("tracing: %s (shutdown)", err)
+	}
+
+	provider = nil
+}
+
+// Tracer returns the application tracer, spans are not recorded if tracing is disabled.
+func Tracer() trace.Tracer {
+	return otel.Tracer(Name)
+}
+
+// Start creates a new span and returns it with a context that contains the span.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error, if any, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,89 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInit(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		assert.NoError(t, Init(Options{}))
+		assert.False(t, Enabled())
+		Shutdown()
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		tp := otel.GetTracerProvider()
+		defer otel.SetTracerProvider(tp)
+
+		assert.NoError(t, Init(Options{Endpoint: "http://127.0.0.1:4318", SampleRate: 1, Service: "photoprism", Version: "test"}))
+		assert.True(t, Enabled())
+		Shutdown()
+		assert.False(t, Enabled())
+	})
+	t.Run("InvalidEndpoint", func(t *testing.T) {
+		assert.Error(t, Init(Options{Endpoint: "ftp://127.0.0.1:4318"}))
+		assert.False(t, Enabled())
+	})
+}
+
+func TestExporterOptions(t *testing.T) {
+	t.Run("HostPort", func(t *testing.T) {
+		opt, err := exporterOptions("localhost:4318", false)
+		assert.NoError(t, err)
+		assert.Len(t, opt, 1)
+	})
+	t.Run("Insecure", func(t *testing.T) {
+		opt, err := exporterOptions("localhost:4318", true)
+		assert.NoError(t, err)
+		assert.Len(t, opt, 2)
+	})
+	t.Run("HttpUrl", func(t *testing.T) {
+		opt, err := exporterOptions("http://tempo:4318/otlp/v1/traces", false)
+		assert.NoError(t, err)
+		assert.Len(t, opt, 3)
+	})
+	t.Run("HttpsUrl", func(t *testing.T) {
+		opt, err := exporterOptions("https://tempo.example.com/", false)
+		assert.NoError(t, err)
+		assert.Len(t, opt, 1)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := exporterOptions("http://", false)
+		assert.Error(t, err)
+		_, err = exporterOptions("grpc://tempo:4317", false)
+		assert.Error(t, err)
+	})
+}
+
+func TestStart(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(tp)
+
+	ctx, parent := Start(context.Background(), "parent", attribute.String("foo", "bar"))
+	_, child := Start(ctx, "child")
+	End(child, errors.New("failed"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "child", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, "failed", spans[0].Status().Description)
+		assert.Len(t, spans[0].Events(), 1)
+		assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, "parent", spans[1].Name())
+		assert.Equal(t, codes.Unset, spans[1].Status().Code)
+		assert.Equal(t, []attribute.KeyValue{attribute.String("foo", "bar")}, spans[1].Attributes())
+	}
+}
@@ -0,0 +1,267 @@
+package workers
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/notify"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// DigestPhotosLimit is the maximum number of photos highlighted in the monthly summary.
+const DigestPhotosLimit = 12
+
+// DigestLinkExpires is the number of seconds after which the thumbnail links in a summary expire.
+const DigestLinkExpires = 90 * 24 * 3600
+
+// digestMonth remembers the month of the last summary in case the state could not be saved.
+var digestMonth string
+
+// DigestState represents the month of the last summary that was sent.
+type DigestState struct {
+	Month string `yaml:"Month"`
+}
+
+// DigestPhoto represents a highlighted photo with its link and thumbnail URL.
+type DigestPhoto struct {
+	Title string
+	URL   string
+	Thumb string
+}
+
+// DigestSummary represents the template data of the monthly summary email.
+type DigestSummary struct {
+	SiteTitle  string
+	SiteUrl    string
+	Heading    string
+	PhotoCount string
+	People     string
+	Highlights string
+	Photos     []DigestPhoto
+}
+
+// digestTemplate renders the monthly summary as HTML email body.
+var digestTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{ .Heading }}</title></head>
+<body style="font-family: sans-serif; color: #333;">
+<h1 style="font-size: 20px;">{{ .Heading }}</h1>
+<p>{{ .PhotoCount }}</p>
+{{- if .People }}
+<p>{{ .People }}</p>
+{{- end }}
+{{- if .Photos }}
+<h2 style="font-size: 16px;">{{ .Highlights }}</h2>
+<p>
+{{- range .Photos }}
+<a href="{{ .URL }}"><img src="{{ .Thumb }}" alt="{{ .Title }}" title="{{ .Title }}" width="224" style="margin: 0 4px 4px 0;"></a>
+{{- end }}
+</p>
+{{- end }}
+<p><a href="{{ .SiteUrl }}">{{ .SiteTitle }}</a></p>
+</body>
+</html>
+`))
+
+// Digest represents a worker that sends a monthly summary email.
+type Digest struct {
+	conf *config.Config
+}
+
+// NewDigest returns a new monthly summary worker.
+func NewDigest(conf *config.Config) *Digest {
+	return &Digest{conf: conf}
+}
+
+// DigestPeriod returns the start and end of the calendar month before the given time.
+func DigestPeriod(now time.Time) (since, before time.Time) {
+	before = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	since = before.AddDate(0, -1, 0)
+
+	return since, before
+}
+
+// Start sends the summary of the month before now, if enabled in the settings and not sent yet.
+func (worker *Digest) Start(now time.Time) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("digest: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if err := mutex.DigestWorker.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.DigestWorker.Stop()
+
+	ch := worker.conf.Settings().Notify.DigestChannel()
+
+	if ch == nil {
+		return nil
+	}
+
+	since, before := DigestPeriod(now)
+	month := since.Format("2006-01")
+
+	if digestMonth == month || worker.State().Month == month {
+		return nil
+	}
+
+	msg, err := worker.Message(since, before)
+
+	if err != nil {
+		return err
+	} else if err = ch.Send(msg); err != nil {
+		return err
+	}
+
+	log.Infof("digest: sent summary for %s", month)
+
+	// Don't send the summary again if the state can't be saved, e.g. because the config folder is read-only.
+	digestMonth = month
+
+	if err = worker.SaveState(DigestState{Month: month}); err != nil {
+		log.Errorf("digest: %s (save state)", err)
+	}
+
+	return nil
+}
+
+// Message returns the summary of the photos and people added in the time range.
+func (worker *Digest) Message(since, before time.Time) (msg notify.Message, err error) {
+	conf := worker.conf
+	monthName := since.Format("January 2006")
+
+	count, err := query.DigestPhotoCount(since, before)
+
+	if err != nil {
+		return msg, err
+	}
+
+	photos, err := query.DigestPhotos(since, before, DigestPhotosLimit)
+
+	if err != nil {
+		return msg, err
+	}
+
+	people, err := query.DigestPeople(since, before)
+
+	if err != nil {
+		return msg, err
+	}
+
+	data := DigestSummary{
+		SiteTitle:  conf.SiteTitle(),
+		SiteUrl:    conf.SiteUrl(),
+		Heading:    i18n.Msg(i18n.MsgMonthlySummary, monthName),
+		PhotoCount: i18n.Msg(i18n.MsgPhotosAddedIn, count, monthName),
+		Highlights: i18n.Msg(i18n.MsgHighlights),
+	}
+
+	if len(people) > 0 {
+		names := make([]string, len(people))
+
+		for i, p := range people {
+			names[i] = p.SubjName
+		}
+
+		data.People = i18n.Msg(i18n.MsgNewPeople, strings.Join(names, ", "))
+	}
+
+	// Thumbnails are shared with a new token for each summary, so that the preview token is not disclosed.
+	token := rnd.Token(10)
+	shared := make(map[string]bool, len(photos))
+
+	for _, p := range photos {
+		// Photos with multiple primary files are only highlighted once.
+		if shared[p.PhotoUID] {
+			continue
+		}
+
+		shared[p.PhotoUID] = true
+
+		link := entity.NewLink(p.PhotoUID, false, false)
+		link.LinkToken = token
+		link.LinkExpires = DigestLinkExpires
+
+		if err = link.Save(); err != nil {
+			return msg, err
+		}
+
+		data.Photos = append(data.Photos, DigestPhoto{
+			Title: p.PhotoTitle,
+			URL:   fmt.Sprintf("%sbrowse?q=uid:%s", conf.SiteUrl(), p.PhotoUID),
+			Thumb: fmt.Sprintf("%ss/%s/%s/preview", conf.SiteUrl(), token, p.PhotoUID),
+		})
+	}
+
+	var html bytes.Buffer
+
+	if err = digestTemplate.Execute(&html, data); err != nil {
+		return msg, err
+	}
+
+	text := []string{data.PhotoCount}
+
+	if data.People != "" {
+		text = append(text, data.People)
+	}
+
+	text = append(text, conf.SiteUrl())
+
+	msg = notify.Message{
+		Title: fmt.Sprintf("%s: %s", conf.SiteTitle(), data.Heading),
+		Text:  strings.Join(text, "\n\n"),
+		HTML:  html.String(),
+		URL:   conf.SiteUrl(),
+	}
+
+	return msg, nil
+}
+
+// FileName returns the name of the file that stores the month of the last summary.
+func (worker *Digest) FileName() string {
+	return filepath.Join(worker.conf.ConfigPath(), "digest.yml")
+}
+
+// State returns the month of the last summary that was sent.
+func (worker *Digest) State() (state DigestState) {
+	fileName := worker.FileName()
+
+	if !fs.FileExists(fileName) {
+		return state
+	} else if data, err := os.ReadFile(fileName); err != nil {
+		log.Errorf("digest: %s (read state)", err)
+	} else if err = yaml.Unmarshal(data, &state); err != nil {
+		log.Errorf("digest: %s (parse state)", err)
+	}
+
+	return state
+}
+
+// SaveState stores the month of the last summary, so that it is not sent again after a restart.
+func (worker *Digest) SaveState(state DigestState) error {
+	if data, err := yaml.Marshal(state); err != nil {
+		return err
+	} else if err = os.WriteFile(worker.FileName(), data, 0o644); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package workers
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+func TestNewDigest(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewDigest(conf)
+
+	assert.IsType(t, &Digest{}, worker)
+}
+
+func TestDigestPeriod(t *testing.T) {
+	t.Run("October", func(t *testing.T) {
+		since, before := DigestPeriod(time.Date(2026, 10, 14, 12, 30, 0, 0, time.UTC))
+
+		assert.Equal(t, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), since)
+		assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), before)
+	})
+	t.Run("January", func(t *testing.T) {
+		since, before := DigestPeriod(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Equal(t, time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), since)
+		assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), before)
+	})
+}
+
+func TestDigest_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewDigest(conf)
+
+	if err := mutex.DigestWorker.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := worker.Start(time.Now()); err == nil {
+		t.Fatal("error expected")
+	}
+
+	mutex.DigestWorker.Stop()
+
+	// Disabled by default.
+	if err := worker.Start(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDigest_Message(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewDigest(conf)
+
+	msg, err := worker.Message(time.Time{}, time.Now().Add(time.Hour))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, msg.Title, conf.SiteTitle())
+	assert.Contains(t, msg.Text, "photos added in")
+	assert.Contains(t, msg.Text, conf.SiteUrl())
+	assert.Contains(t, msg.HTML, "<h1")
+	assert.Contains(t, msg.HTML, "/preview")
+	assert.NotContains(t, msg.HTML, conf.PreviewToken())
+	assert.Contains(t, msg.HTML, "browse?q=uid:")
+	assert.Equal(t, conf.SiteUrl(), msg.URL)
+}
+
+func TestDigest_State(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewDigest(conf)
+
+	defer os.Remove(worker.FileName())
+
+	assert.Equal(t, "", worker.State().Month)
+
+	if err := worker.SaveState(DigestState{Month: "2026-09"}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "2026-09", worker.State().Month)
+}
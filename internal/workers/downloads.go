@@ -0,0 +1,200 @@
+package workers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// DownloadFeedInterval is the minimum time between two checks of the same feed.
+const DownloadFeedInterval = time.Hour
+
+// DownloadBatchSize is the maximum number of files downloaded per run.
+const DownloadBatchSize = 100
+
+// ErrOffensiveDownload is returned if a downloaded file might be offensive and NSFW uploads are disabled.
+var ErrOffensiveDownload = errors.New("file might be offensive")
+
+// Downloader represents a worker that downloads and imports remote files and feeds.
+type Downloader struct {
+	conf *config.Config
+}
+
+// NewDownloader returns a new remote download worker.
+func NewDownloader(conf *config.Config) *Downloader {
+	return &Downloader{conf: conf}
+}
+
+// Start adds new items of due feeds to the download queue, then downloads and imports pending files.
+func (worker *Downloader) Start() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("download: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if worker.conf.ReadOnly() {
+		return config.ErrReadOnly
+	}
+
+	// Files are imported by the main worker, so try again later if it is busy.
+	if mutex.MainWorker.Busy() {
+		return nil
+	}
+
+	if err := mutex.DownloadWorker.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.DownloadWorker.Stop()
+
+	feeds, err := query.DueFeeds(DownloadFeedInterval)
+
+	if err != nil {
+		return err
+	}
+
+	for _, feed := range feeds {
+		if mutex.DownloadWorker.Canceled() {
+			return nil
+		}
+
+		worker.Feed(feed)
+	}
+
+	pending, err := query.PendingDownloads(DownloadBatchSize)
+
+	if err != nil {
+		return err
+	} else if len(pending) == 0 {
+		return nil
+	}
+
+	dir := filepath.Join(worker.conf.TempPath(), "downloads", rnd.Token(8))
+
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Warnf("download: %s (remove temp folder)", err)
+		}
+	}()
+
+	// Downloaded files are imported by album, so that they are added to the right one.
+	fileNames := make(map[uint]string)
+	albums := make(map[string]entity.Downloads)
+
+	for _, d := range pending {
+		if mutex.DownloadWorker.Canceled() {
+			return nil
+		}
+
+		albumDir := filepath.Join(dir, "album-"+sanitize.IdString(d.AlbumUID), strconv.Itoa(int(d.ID)))
+
+		fileName, err := photoprism.DownloadFile(d.DownloadURL, albumDir, worker.conf.OriginalsLimit())
+
+		if err != nil {
+			log.Warnf("download: %s in %s", err, sanitize.Log(d.DownloadURL))
+			entity.Log("download", "update status", d.Failed(err))
+			continue
+		}
+
+		// Downloads are subject to the same content restrictions as uploads.
+		if !worker.conf.UploadNSFW() && worker.Offensive(fileName) {
+			_ = os.Remove(fileName)
+			log.Warnf("download: %s might be offensive", sanitize.Log(d.DownloadURL))
+			entity.Log("download", "update status", d.Failed(ErrOffensiveDownload))
+			continue
+		}
+
+		log.Debugf("download: saved %s", sanitize.Log(d.DownloadURL))
+
+		fileNames[d.ID] = fileName
+		albums[d.AlbumUID] = append(albums[d.AlbumUID], d)
+	}
+
+	for albumUID, downloads := range albums {
+		opt := photoprism.ImportOptionsMove(filepath.Join(dir, "album-"+sanitize.IdString(albumUID)))
+
+		if albumUID != "" {
+			opt.Albums = []string{albumUID}
+		}
+
+		service.Import().Start(opt)
+
+		// Files that are still there could not be imported, duplicates are removed.
+		for i := range downloads {
+			d := &downloads[i]
+
+			if fs.FileExists(fileNames[d.ID]) {
+				entity.Log("download", "update status", d.Failed(fmt.Errorf("import failed")))
+			} else {
+				entity.Log("download", "update status", d.Done())
+			}
+		}
+
+		log.Infof("download: imported %d remote files", len(downloads))
+	}
+
+	// Update album, label, and subject cover thumbs.
+	if err := query.UpdateCovers(); err != nil {
+		log.Warnf("download: %s (update covers)", err)
+	}
+
+	return nil
+}
+
+// Offensive tests if the downloaded file might be offensive.
+func (worker *Downloader) Offensive(fileName string) bool {
+	labels, err := service.NsfwDetector().File(fileName)
+
+	if err != nil {
+		log.Debugf("download: %s (nsfw)", err)
+		return false
+	}
+
+	return !labels.IsSafe()
+}
+
+// Feed adds new image and video urls found in a feed to the download queue.
+func (worker *Downloader) Feed(feed entity.Download) (added int) {
+	urls, err := photoprism.DownloadFeed(feed.DownloadURL, 0)
+
+	if err != nil {
+		log.Warnf("download: %s in feed %s", err, sanitize.Log(feed.DownloadURL))
+		entity.Log("download", "update status", feed.Failed(err))
+		return 0
+	}
+
+	for _, u := range urls {
+		d := entity.NewDownload(u, entity.DownloadFile, feed.AlbumUID, feed.UserUID)
+		d.FeedID = feed.ID
+
+		if _, created, err := d.FirstOrCreate(); err != nil {
+			log.Debugf("download: %s in feed %s", err, sanitize.Log(feed.DownloadURL))
+		} else if created {
+			added++
+		}
+	}
+
+	if added > 0 {
+		log.Infof("download: found %d new files in feed %s", added, sanitize.Log(feed.DownloadURL))
+	}
+
+	entity.Log("download", "update status", feed.Done())
+
+	return added
+}
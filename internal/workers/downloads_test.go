@@ -0,0 +1,88 @@
+package workers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/dial"
+)
+
+func TestNewDownloader(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewDownloader(conf)
+
+	assert.IsType(t, &Downloader{}, worker)
+}
+
+func TestDownloader_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewDownloader(conf)
+
+	if err := mutex.DownloadWorker.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := worker.Start(); err == nil {
+		t.Fatal("error expected")
+	}
+
+	mutex.DownloadWorker.Stop()
+
+	if err := worker.Start(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDownloader_Feed(t *testing.T) {
+	conf := config.TestConfig()
+
+	// The test server runs on a local address.
+	dial.Allow("127.0.0.1")
+
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = fmt.Fprintf(w, `<?xml version="1.0"?><rss version="2.0"><channel>
+<item><enclosure url="%[1]s/one.jpg" type="image/jpeg"/></item>
+<item><enclosure url="%[1]s/two.jpg" type="image/jpeg"/></item>
+</channel></rss>`, server.URL)
+	}))
+
+	defer server.Close()
+
+	feed, created, err := entity.NewDownload(server.URL+"/feed.xml", entity.DownloadFeed, "", "uqxetse3cy5eo9z2").FirstOrCreate()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, created)
+
+	worker := NewDownloader(conf)
+
+	assert.Equal(t, 2, worker.Feed(*feed))
+	assert.Equal(t, 0, worker.Feed(*feed))
+
+	for _, name := range []string{"feed.xml", "one.jpg", "two.jpg"} {
+		if d, _, err := entity.NewDownload(server.URL+"/"+name, entity.DownloadFile, "", "uqxetse3cy5eo9z2").FirstOrCreate(); err != nil {
+			t.Fatal(err)
+		} else {
+			if name != "feed.xml" {
+				assert.Equal(t, feed.ID, d.FeedID)
+				assert.Equal(t, entity.DownloadPending, d.DownloadStatus)
+			}
+
+			assert.NoError(t, d.Delete())
+		}
+	}
+}
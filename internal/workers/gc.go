@@ -0,0 +1,121 @@
+package workers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// GCInterval is the minimum time between two garbage collection runs.
+const GCInterval = 24 * time.Hour
+
+// SyncUploadExpires is the time after which files uploaded by sync clients are removed if they have not been confirmed.
+const SyncUploadExpires = 24 * time.Hour
+
+// ActivityExpires is the time after which activity feed entries are removed.
+const ActivityExpires = 90 * 24 * time.Hour
+
+// gcLastRun is the time of the last garbage collection run, guarded by mutex.GCWorker.
+var gcLastRun time.Time
+
+// GC represents a worker that removes expired sessions, activities, orphaned thumbnails and cached videos.
+type GC struct {
+	conf *config.Config
+}
+
+// NewGC returns a new garbage collection worker.
+func NewGC(conf *config.Config) *GC {
+	return &GC{conf: conf}
+}
+
+// Start runs the garbage collection if it has not been run within the given interval.
+func (worker *GC) Start(interval time.Duration) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("gc: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if err := mutex.GCWorker.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.GCWorker.Stop()
+
+	if time.Since(gcLastRun) < interval {
+		return nil
+	}
+
+	gcLastRun = time.Now()
+
+	// Remove expired user sessions.
+	if n := service.Session().DeleteExpired(); n > 0 {
+		log.Infof("gc: removed %d expired sessions", n)
+	}
+
+	// Remove activity feed entries that have expired.
+	if n, err := entity.DeleteActivities(time.Now().Add(-1 * ActivityExpires)); err != nil {
+		log.Warnf("gc: %s (remove expired activities)", err)
+	} else if n > 0 {
+		log.Infof("gc: removed %d expired activities", n)
+	}
+
+	// Thumbnails of files that are being indexed may not be in the database yet.
+	if mutex.MainWorker.Busy() {
+		log.Debugf("gc: skipping thumbnails while indexing")
+	} else if n, err := photoprism.NewCleanUp(worker.conf).Thumbs(photoprism.CleanUpOptions{}); err != nil {
+		log.Warnf("gc: %s (remove orphaned thumbnails)", err)
+	} else if n > 0 {
+		log.Infof("gc: removed %d orphaned thumbnails", n)
+	}
+
+	if mutex.GCWorker.Canceled() {
+		return nil
+	}
+
+	// Remove files uploaded by sync clients that have never been confirmed, unless they are being imported.
+	if mutex.MainWorker.Busy() {
+		log.Debugf("gc: skipping sync uploads while indexing")
+	} else if n := removeExpiredUploads(worker.conf.SyncUploadPath(), SyncUploadExpires); n > 0 {
+		log.Infof("gc: removed %d expired sync uploads", n)
+	}
+
+	// Trim the video cache to the configured size, least recently used first.
+	if n, err := photoprism.TrimVideoCache(worker.conf.VideoPath(), worker.conf.VideoCacheLimit()); err != nil {
+		log.Warnf("gc: %s (trim video cache)", err)
+	} else if n > 0 {
+		log.Infof("gc: removed %d cached videos", n)
+	}
+
+	return nil
+}
+
+// removeExpiredUploads removes upload folders in the user folders of dir that have not been modified within expires.
+func removeExpiredUploads(dir string, expires time.Duration) (removed int) {
+	folders, err := filepath.Glob(filepath.Join(dir, "*", "*"))
+
+	if err != nil {
+		return 0
+	}
+
+	for _, folder := range folders {
+		if info, err := os.Stat(folder); err != nil || time.Since(info.ModTime()) < expires {
+			continue
+		} else if err := os.RemoveAll(folder); err != nil {
+			log.Warnf("gc: %s (remove expired upload)", err)
+		} else {
+			removed++
+		}
+	}
+
+	return removed
+}
@@ -0,0 +1,78 @@
+package workers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+func TestNewGC(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewGC(conf)
+
+	assert.IsType(t, &GC{}, worker)
+}
+
+func TestGC_Start(t *testing.T) {
+	conf := config.TestConfig()
+	service.SetConfig(conf)
+
+	worker := NewGC(conf)
+
+	if err := mutex.GCWorker.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := worker.Start(time.Second); err == nil {
+		t.Fatal("error expected")
+	}
+
+	mutex.GCWorker.Stop()
+
+	if err := worker.Start(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	lastRun := gcLastRun
+	assert.False(t, lastRun.IsZero())
+
+	// Skipped if run within the interval.
+	if err := worker.Start(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, lastRun, gcLastRun)
+}
+
+func TestRemoveExpiredUploads(t *testing.T) {
+	dir := filepath.Join(config.TestConfig().TempPath(), "gc-uploads")
+	expired := filepath.Join(dir, "user", "expired")
+	recent := filepath.Join(dir, "user", "recent")
+
+	for _, folder := range []string{expired, recent} {
+		if err := os.MkdirAll(folder, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer os.RemoveAll(dir)
+
+	old := time.Now().Add(-2 * SyncUploadExpires)
+
+	if err := os.Chtimes(expired, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, removeExpiredUploads(dir, SyncUploadExpires))
+	assert.NoDirExists(t, expired)
+	assert.DirExists(t, recent)
+	assert.Equal(t, 0, removeExpiredUploads(filepath.Join(dir, "xxx"), SyncUploadExpires))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"runtime"
 	"runtime/debug"
+	"sync"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
@@ -53,6 +54,13 @@ func (m *Meta) Start(delay, interval time.Duration, force bool) (err error) {
 		log.Warn(err)
 	}
 
+	// Run captions worker.
+	if w := photoprism.NewCaptions(m.conf); w.Disabled() {
+		log.Debugf("metadata: skipping caption generation")
+	} else if err := w.Start(); err != nil {
+		log.Warn(err)
+	}
+
 	log.Debugf("metadata: starting routine check")
 
 	settings := m.conf.Settings()
@@ -62,6 +70,10 @@ func (m *Meta) Start(delay, interval time.Duration, force bool) (err error) {
 	offset := 0
 	optimized := 0
 
+	// Photos are optimized concurrently if more than one metadata worker is configured.
+	numWorkers := m.conf.TaskWorkers(config.TaskMeta)
+	var doneMutex sync.Mutex
+
 	// Run index optimization.
 	for {
 		photos, err := query.PhotosMetadataUpdate(limit, offset, delay, interval)
@@ -72,36 +84,56 @@ func (m *Meta) Start(delay, interval time.Duration, force bool) (err error) {
 
 		if len(photos) == 0 {
 			break
-		} else if offset == 0 {
+		}
+
+		jobs := make(chan entity.Photo)
+
+		var wg sync.WaitGroup
+		wg.Add(numWorkers)
+		for i := 0; i < numWorkers; i++ {
+			go func() {
+				defer wg.Done()
+
+				for photo := range jobs {
+					updated, merged, err := photo.Optimize(settings.StackMeta(), settings.StackUUID(), settings.Features.Estimates, force)
 
+					doneMutex.Lock()
+
+					if err != nil {
+						log.Errorf("metadata: %s (optimize photo)", err)
+					} else if updated {
+						optimized++
+						log.Debugf("metadata: updated photo %s", photo.String())
+					}
+
+					for _, p := range merged {
+						log.Infof("metadata: merged %s", p.PhotoUID)
+						done[p.PhotoUID] = true
+					}
+
+					doneMutex.Unlock()
+				}
+			}()
 		}
 
 		for _, photo := range photos {
 			if mutex.MetaWorker.Canceled() {
-				return errors.New("metadata: check canceled")
-			}
-
-			if done[photo.PhotoUID] {
-				continue
+				break
 			}
 
+			doneMutex.Lock()
+			skip := done[photo.PhotoUID]
 			done[photo.PhotoUID] = true
+			doneMutex.Unlock()
 
-			updated, merged, err := photo.Optimize(settings.StackMeta(), settings.StackUUID(), settings.Features.Estimates, force)
-
-			if err != nil {
-				log.Errorf("metadata: %s (optimize photo)", err)
-			} else if updated {
-				optimized++
-				log.Debugf("metadata: updated photo %s", photo.String())
-			}
-
-			for _, p := range merged {
-				log.Infof("metadata: merged %s", p.PhotoUID)
-				done[p.PhotoUID] = true
+			if !skip {
+				jobs <- photo
 			}
 		}
 
+		close(jobs)
+		wg.Wait()
+
 		if mutex.MetaWorker.Canceled() {
 			return errors.New("metadata: check canceled")
 		}
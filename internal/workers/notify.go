@@ -0,0 +1,156 @@
+package workers
+
+import (
+	"sync"
+
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/notify"
+)
+
+// StorageUsageLimit is the percentage of used storage above which a notification is sent.
+const StorageUsageLimit = 90
+
+// notifyTopics contains the events that may trigger a notification.
+var notifyTopics = []string{"import.completed", "notify.*"}
+
+var notifyState = struct {
+	sub          *hub.Subscription
+	storageAlert bool
+	mutex        sync.Mutex
+}{}
+
+// StartNotify sends notifications for important events to the channels configured in the settings.
+func StartNotify(conf *config.Config) {
+	notifyState.mutex.Lock()
+	defer notifyState.mutex.Unlock()
+
+	if notifyState.sub != nil {
+		return
+	}
+
+	s := event.Subscribe(notifyTopics...)
+	notifyState.sub = &s
+
+	go func() {
+		for msg := range s.Receiver {
+			if m, ok := NotifyMessage(conf.Settings().Notify, msg); ok {
+				if channels := conf.Settings().Notify.Channels(); len(channels) > 0 && SiteNotify(msg) {
+					m.Title = conf.SiteTitle()
+
+					if err := channels.Send(m); err != nil {
+						log.Warn(err)
+					}
+				}
+
+				// Send push notifications to the web apps of affected users.
+				if ch := PushChannel(conf, msg); ch != nil {
+					m.Title = conf.SiteTitle()
+					m.URL = PushURL(conf, msg)
+
+					if err := ch.Send(m); err != nil {
+						log.Warnf("push: %s", err)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// StopNotify stops sending notifications.
+func StopNotify() {
+	notifyState.mutex.Lock()
+	defer notifyState.mutex.Unlock()
+
+	if notifyState.sub != nil {
+		event.Unsubscribe(*notifyState.sub)
+		notifyState.sub = nil
+	}
+}
+
+// SiteNotify tests if the event may be sent to the channels configured in the settings, which are shared
+// by all users. Saved search notifications are only sent there if the search belongs to an admin.
+func SiteNotify(msg event.Message) bool {
+	if msg.Name != "notify.search" {
+		return true
+	}
+
+	uid, _ := msg.Fields["user"].(string)
+
+	if u := entity.FindUserByUID(uid); u != nil {
+		return u.Admin()
+	}
+
+	return false
+}
+
+// NotifyMessage returns the notification message for an event, if enabled in the settings.
+func NotifyMessage(s config.NotifySettings, msg event.Message) (m notify.Message, ok bool) {
+	switch msg.Name {
+	case "import.completed":
+		if !s.Import {
+			return m, false
+		}
+
+		m.Text = i18n.Msg(i18n.MsgImportCompletedIn, msg.Fields["seconds"])
+	case "notify.share":
+		if !s.Share {
+			return m, false
+		}
+
+		m.Text = i18n.Msg(i18n.MsgEntriesAddedTo, msg.Fields["count"], msg.Fields["album"])
+	case "notify.storage":
+		if !s.Storage {
+			return m, false
+		}
+
+		m.Text = i18n.Msg(i18n.MsgStorageAlmostFull, msg.Fields["percent"])
+	case "notify.search":
+		if !s.Search {
+			return m, false
+		}
+
+		m.Text = i18n.Msg(i18n.MsgNewSearchMatches, msg.Fields["count"], msg.Fields["name"])
+	default:
+		return m, false
+	}
+
+	return m, true
+}
+
+// CheckStorage publishes a notification event once a storage volume is almost full, i.e. more than
+// StorageUsageLimit percent are used or less free space is left than configured as storage reserve.
+func CheckStorage(conf *config.Config) {
+	var full *config.StorageVolume
+
+	reserve := conf.StorageReserve()
+	volumes := conf.StorageVolumes()
+
+	for i, v := range volumes {
+		if v.Percent < StorageUsageLimit && (reserve < 0 || v.Free >= uint64(reserve)) {
+			continue
+		} else if full == nil || v.Percent > full.Percent {
+			full = &volumes[i]
+		}
+	}
+
+	notifyState.mutex.Lock()
+	defer notifyState.mutex.Unlock()
+
+	if full == nil {
+		notifyState.storageAlert = false
+		return
+	} else if notifyState.storageAlert {
+		return
+	}
+
+	notifyState.storageAlert = true
+
+	log.Warnf("notify: %s storage almost full, %d%% used", full.Name, full.Percent)
+
+	event.Publish("notify.storage", event.Data{"volume": full.Name, "percent": full.Percent})
+}
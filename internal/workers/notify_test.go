@@ -0,0 +1,80 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+func TestNotifyMessage(t *testing.T) {
+	s := config.NotifySettings{Import: true, Share: true, Storage: false, Search: true}
+
+	t.Run("Import", func(t *testing.T) {
+		m, ok := NotifyMessage(s, event.Message{Name: "import.completed", Fields: event.Data{"seconds": 5}})
+		assert.True(t, ok)
+		assert.Equal(t, "Import completed in 5 s", m.Text)
+	})
+	t.Run("Share", func(t *testing.T) {
+		m, ok := NotifyMessage(s, event.Message{Name: "notify.share", Fields: event.Data{"album": "Holiday", "count": 3}})
+		assert.True(t, ok)
+		assert.Equal(t, "3 entries added to Holiday", m.Text)
+	})
+	t.Run("StorageDisabled", func(t *testing.T) {
+		_, ok := NotifyMessage(s, event.Message{Name: "notify.storage", Fields: event.Data{"percent": 95}})
+		assert.False(t, ok)
+	})
+	t.Run("Search", func(t *testing.T) {
+		m, ok := NotifyMessage(s, event.Message{Name: "notify.search", Fields: event.Data{"name": "Kids", "count": 2}})
+		assert.True(t, ok)
+		assert.Equal(t, "2 new photos match Kids", m.Text)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, ok := NotifyMessage(s, event.Message{Name: "index.completed"})
+		assert.False(t, ok)
+	})
+}
+
+func TestSiteNotify(t *testing.T) {
+	t.Run("Import", func(t *testing.T) {
+		assert.True(t, SiteNotify(event.Message{Name: "import.completed"}))
+	})
+	t.Run("AdminSearch", func(t *testing.T) {
+		assert.True(t, SiteNotify(event.Message{Name: "notify.search", Fields: event.Data{"user": entity.Admin.UserUID}}))
+	})
+	t.Run("UserSearch", func(t *testing.T) {
+		assert.False(t, SiteNotify(event.Message{Name: "notify.search", Fields: event.Data{"user": "uqxc08w3d0ej2283"}}))
+	})
+	t.Run("UnknownUser", func(t *testing.T) {
+		assert.False(t, SiteNotify(event.Message{Name: "notify.search", Fields: event.Data{"user": "uqxxxxxxxxxxxxxx"}}))
+	})
+}
+
+func TestCheckStorage(t *testing.T) {
+	conf := config.TestConfig()
+
+	CheckStorage(conf)
+	assert.False(t, notifyState.storageAlert)
+
+	// Volumes are almost full if less free space is left than reserved.
+	conf.Options().StorageReserve = 1 << 40
+	defer func() { conf.Options().StorageReserve = 0 }()
+
+	s := event.Subscribe("notify.storage")
+	defer event.Unsubscribe(s)
+
+	CheckStorage(conf)
+	assert.True(t, notifyState.storageAlert)
+
+	msg := <-s.Receiver
+	assert.Equal(t, "notify.storage", msg.Name)
+	assert.NotEmpty(t, msg.Fields["volume"])
+
+	conf.Options().StorageReserve = 0
+
+	CheckStorage(conf)
+	assert.False(t, notifyState.storageAlert)
+}
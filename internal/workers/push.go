@@ -0,0 +1,96 @@
+package workers
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/notify"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// PushRecipients returns the users who should receive push notifications for an event, e.g.
+// the other members of a shared album that photos were added to.
+func PushRecipients(msg event.Message) (userUIDs []string) {
+	user, _ := msg.Fields["user"].(string)
+
+	switch msg.Name {
+	case "notify.share":
+		uid, _ := msg.Fields["uid"].(string)
+
+		if uid == "" {
+			return userUIDs
+		}
+
+		members, err := query.AlbumMembers(uid)
+
+		if err != nil {
+			log.Warnf("push: %s (find album members)", err)
+			return userUIDs
+		}
+
+		for _, m := range members {
+			if m.UserUID != user {
+				userUIDs = append(userUIDs, m.UserUID)
+			}
+		}
+	case "notify.search":
+		if user != "" {
+			userUIDs = append(userUIDs, user)
+		}
+	}
+
+	return userUIDs
+}
+
+// PushURL returns the page that web apps should open when a push notification is clicked.
+func PushURL(conf *config.Config, msg event.Message) string {
+	if msg.Name == "notify.share" {
+		if uid, _ := msg.Fields["uid"].(string); uid != "" {
+			slug, _ := msg.Fields["slug"].(string)
+			return fmt.Sprintf("%salbums/%s/%s", conf.SiteUrl(), uid, slug)
+		}
+	}
+
+	return conf.SiteUrl()
+}
+
+// PushChannel returns the web push channel for the recipients of an event, or nil if there are none.
+func PushChannel(conf *config.Config, msg event.Message) notify.Channel {
+	if !conf.PushEnabled() {
+		return nil
+	}
+
+	recipients := PushRecipients(msg)
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	subscriptions, err := query.PushSubscriptions(recipients...)
+
+	if err != nil {
+		log.Warnf("push: %s (find subscriptions)", err)
+		return nil
+	} else if len(subscriptions) == 0 {
+		return nil
+	}
+
+	result := notify.WebPush{
+		PublicKey:  conf.PushPublicKey(),
+		PrivateKey: conf.PushPrivateKey(),
+		Subject:    conf.PushSubject(),
+		Gone: func(endpoint string) {
+			if err := entity.DeletePushSubscription(endpoint); err != nil {
+				log.Warnf("push: %s (remove expired subscription)", err)
+			}
+		},
+	}
+
+	for _, s := range subscriptions {
+		result.Subscriptions = append(result.Subscriptions, notify.PushSubscription{Endpoint: s.Endpoint, P256dh: s.P256dh, Auth: s.Auth})
+	}
+
+	return result
+}
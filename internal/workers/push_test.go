@@ -0,0 +1,61 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/notify"
+)
+
+func TestPushRecipients(t *testing.T) {
+	t.Run("AddedByOwner", func(t *testing.T) {
+		msg := event.Message{Name: "notify.share", Fields: event.Data{"uid": "at9lxuqxpogaaba9", "user": "uqxetse3cy5eo9z2"}}
+		assert.Equal(t, []string{"uqxc08w3d0ej2283"}, PushRecipients(msg))
+	})
+	t.Run("AddedByMember", func(t *testing.T) {
+		msg := event.Message{Name: "notify.share", Fields: event.Data{"uid": "at9lxuqxpogaaba9", "user": "uqxc08w3d0ej2283"}}
+		assert.Empty(t, PushRecipients(msg))
+	})
+	t.Run("Search", func(t *testing.T) {
+		msg := event.Message{Name: "notify.search", Fields: event.Data{"uid": "qt9lxuqxpogaaba1", "user": "uqxetse3cy5eo9z2"}}
+		assert.Equal(t, []string{"uqxetse3cy5eo9z2"}, PushRecipients(msg))
+	})
+	t.Run("Storage", func(t *testing.T) {
+		assert.Empty(t, PushRecipients(event.Message{Name: "notify.storage", Fields: event.Data{"percent": 95}}))
+	})
+}
+
+func TestPushURL(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("Album", func(t *testing.T) {
+		msg := event.Message{Name: "notify.share", Fields: event.Data{"uid": "at9lxuqxpogaaba9", "slug": "berlin-2019"}}
+		assert.Equal(t, conf.SiteUrl()+"albums/at9lxuqxpogaaba9/berlin-2019", PushURL(conf, msg))
+	})
+	t.Run("Default", func(t *testing.T) {
+		assert.Equal(t, conf.SiteUrl(), PushURL(conf, event.Message{Name: "notify.search"}))
+	})
+}
+
+func TestPushChannel(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("Member", func(t *testing.T) {
+		msg := event.Message{Name: "notify.share", Fields: event.Data{"uid": "at9lxuqxpogaaba9", "user": "uqxetse3cy5eo9z2"}}
+		ch := PushChannel(conf, msg)
+
+		if ch == nil {
+			t.Fatal("channel must not be nil")
+		}
+
+		assert.Equal(t, "webpush", ch.Name())
+		assert.Len(t, ch.(notify.WebPush).Subscriptions, 1)
+	})
+	t.Run("NoSubscriptions", func(t *testing.T) {
+		msg := event.Message{Name: "notify.search", Fields: event.Data{"user": "uqxetse3cy5eo9z2"}}
+		assert.Nil(t, PushChannel(conf, msg))
+	})
+}
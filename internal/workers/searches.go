@@ -0,0 +1,159 @@
+package workers
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sort"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// SearchesLimit is the maximum number of new matches counted per saved search.
+const SearchesLimit = 1000
+
+// Searches represents a worker that checks saved searches for new matches.
+type Searches struct {
+	conf *config.Config
+}
+
+// NewSearches returns a new saved searches worker.
+func NewSearches(conf *config.Config) *Searches {
+	return &Searches{conf: conf}
+}
+
+// Start checks saved searches with notifications enabled and publishes an event for each search
+// that matches newly added photos.
+func (worker *Searches) Start() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("searches: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if err := mutex.SearchesWorker.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.SearchesWorker.Stop()
+
+	searches, err := query.NotifySearches()
+
+	if err != nil {
+		return err
+	}
+
+	for _, s := range searches {
+		if mutex.SearchesWorker.Canceled() {
+			return nil
+		}
+
+		n, err := worker.Check(s)
+
+		if err != nil {
+			log.Warnf("searches: %s in %s", err, sanitize.Log(s.SearchName))
+		} else if n > 0 {
+			log.Infof("searches: %d new matches for %s", n, sanitize.Log(s.SearchName))
+
+			event.Publish("notify.search", event.Data{
+				"uid":   s.SearchUID,
+				"name":  s.SearchName,
+				"user":  s.UserUID,
+				"count": n,
+			})
+		}
+	}
+
+	return nil
+}
+
+// Check returns the number of photos added since the saved search was last checked.
+func (worker *Searches) Check(s entity.Search) (n int, err error) {
+	since := s.Since()
+	checkedAt := entity.TimeStamp()
+
+	owner := entity.FindUserByUID(s.UserUID)
+
+	if owner == nil {
+		return 0, fmt.Errorf("owner not found")
+	}
+
+	f := form.SearchPhotos{
+		Query: s.SearchQuery,
+		Count: SearchesLimit,
+		Order: entity.SortOrderAdded,
+	}
+
+	var results search.PhotoResults
+
+	// Guests may only be notified about public pictures in albums that are visible to them.
+	if acl.Permissions.Deny(acl.ResourcePhotos, owner.Role(), acl.ActionSearchAll) {
+		results, err = worker.shared(f)
+	} else {
+		results, _, err = search.Photos(f)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	seen := make(map[string]bool, len(results))
+
+	// Results are sorted by the time they were added, newest first.
+	for _, r := range results {
+		if !r.CreatedAt.After(since) {
+			break
+		} else if seen[r.PhotoUID] {
+			continue
+		}
+
+		seen[r.PhotoUID] = true
+		n++
+	}
+
+	return n, s.Checked(checkedAt, n)
+}
+
+// shared returns the public pictures matching the search in albums that are visible to guests,
+// newest first.
+func (worker *Searches) shared(f form.SearchPhotos) (results search.PhotoResults, err error) {
+	albums, err := query.GuestAlbumUIDs()
+
+	if err != nil {
+		return results, err
+	}
+
+	for _, uid := range albums {
+		af := f
+
+		if err := af.ParseQueryString(); err != nil {
+			return results, err
+		}
+
+		af.Filter = ""
+		af.Album = uid
+		af.SetShared([]string{uid})
+
+		found, _, err := search.Photos(af)
+
+		if err != nil {
+			return results, err
+		}
+
+		results = append(results, found...)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+
+	return results, nil
+}
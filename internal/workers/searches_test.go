@@ -0,0 +1,125 @@
+package workers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+func TestNewSearches(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewSearches(conf)
+
+	assert.IsType(t, &Searches{}, worker)
+}
+
+func TestSearches_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewSearches(conf)
+
+	if err := mutex.SearchesWorker.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := worker.Start(); err == nil {
+		t.Fatal("error expected")
+	}
+
+	mutex.SearchesWorker.Stop()
+
+	if err := worker.Start(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSearches_Check(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewSearches(conf)
+
+	m := entity.NewSearch("uqxetse3cy5eo9z2", "Everything", "type:image", true)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Delete()
+
+	if err := m.Checked(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := worker.Check(*entity.FindSearch(m.SearchUID))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Greater(t, n, 0)
+
+	n, err = worker.Check(*entity.FindSearch(m.SearchUID))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, n)
+}
+
+func TestSearches_CheckGuest(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewSearches(conf)
+
+	guest := entity.User{AddressID: 1, UserName: "search-guest", FullName: "Search Guest", RoleGuest: true}
+
+	if err := guest.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer guest.Delete()
+
+	m := entity.NewSearch(guest.UserUID, "Everything", "type:image", true)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Delete()
+
+	if err := m.Checked(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := worker.Check(*entity.FindSearch(m.SearchUID))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only public pictures in the album that is visible to guests may match.
+	shared, _, err := search.Photos(form.SearchPhotos{Query: "type:image", Album: "at9lxuqxpogaaba8", Public: true, Count: SearchesLimit, Merged: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all, _, err := search.Photos(form.SearchPhotos{Query: "type:image", Count: SearchesLimit, Merged: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Greater(t, n, 0)
+	assert.Equal(t, len(shared), n)
+	assert.Less(t, n, len(all))
+}
@@ -0,0 +1,114 @@
+package workers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// shareUploadsMutex prevents approved guest uploads from being imported twice at the same time.
+var shareUploadsMutex = sync.Mutex{}
+
+// ShareUploads represents a worker that imports approved guest uploads into shared albums.
+type ShareUploads struct {
+	conf *config.Config
+}
+
+// NewShareUploads returns a new guest uploads worker.
+func NewShareUploads(conf *config.Config) *ShareUploads {
+	return &ShareUploads{conf: conf}
+}
+
+// Start imports approved guest uploads. Files that could not be imported are kept and retried on the next run.
+func (worker *ShareUploads) Start() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("share: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if worker.conf.ReadOnly() {
+		return config.ErrReadOnly
+	}
+
+	// Files are imported by the main worker, so try again later if it is busy.
+	if mutex.MainWorker.Busy() {
+		return nil
+	}
+
+	shareUploadsMutex.Lock()
+	defer shareUploadsMutex.Unlock()
+
+	dirs, err := filepath.Glob(filepath.Join(worker.conf.ShareApprovedPath(""), "*"))
+
+	if err != nil {
+		return err
+	}
+
+	imported := 0
+
+	for _, dir := range dirs {
+		if mutex.MainWorker.Busy() {
+			return nil
+		}
+
+		albumUID := filepath.Base(dir)
+		pending := shareUploadsCount(dir)
+
+		if pending == 0 {
+			_ = os.RemoveAll(dir)
+			continue
+		}
+
+		opt := photoprism.ImportOptionsMove(dir)
+		opt.Albums = []string{albumUID}
+
+		service.Import().Start(opt)
+
+		// Imported files and duplicates have been removed by the import, the others are kept.
+		if remaining := shareUploadsCount(dir); remaining > 0 {
+			log.Warnf("share: %d approved uploads to %s not imported yet", remaining, sanitize.Log(albumUID))
+			imported += pending - remaining
+		} else {
+			imported += pending
+			_ = os.RemoveAll(dir)
+		}
+	}
+
+	if imported == 0 {
+		return nil
+	}
+
+	log.Infof("share: imported %d approved guest uploads", imported)
+
+	// Update album, label, and subject cover thumbs.
+	if err := query.UpdateCovers(); err != nil {
+		log.Warnf("share: %s (update covers)", err)
+	}
+
+	return nil
+}
+
+// shareUploadsCount returns the number of files in the approved uploads folder of an album.
+func shareUploadsCount(dir string) (count int) {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*", "*"))
+
+	for _, fileName := range matches {
+		if fs.FileExists(fileName) {
+			count++
+		}
+	}
+
+	return count
+}
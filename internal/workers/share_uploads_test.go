@@ -0,0 +1,62 @@
+package workers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestNewShareUploads(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewShareUploads(conf)
+
+	assert.IsType(t, &ShareUploads{}, worker)
+}
+
+func TestShareUploads_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewShareUploads(conf)
+	dir := filepath.Join(conf.ShareApprovedPath("as6sg6bxpogaaba7"), "test")
+	fileName := filepath.Join(dir, "example.txt")
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	} else if err := os.WriteFile(fileName, []byte("approved"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(conf.ShareApprovedPath(""))
+
+	t.Run("MainWorkerBusy", func(t *testing.T) {
+		if err := mutex.MainWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		err := worker.Start()
+
+		mutex.MainWorker.Stop()
+
+		assert.NoError(t, err)
+		assert.True(t, fs.FileExists(fileName))
+	})
+	t.Run("Count", func(t *testing.T) {
+		assert.Equal(t, 1, shareUploadsCount(conf.ShareApprovedPath("as6sg6bxpogaaba7")))
+		assert.Equal(t, 0, shareUploadsCount(conf.ShareApprovedPath("xxx")))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		if err := os.Remove(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, worker.Start())
+		assert.False(t, fs.PathExists(conf.ShareApprovedPath("as6sg6bxpogaaba7")))
+	})
+}
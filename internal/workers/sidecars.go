@@ -0,0 +1,85 @@
+package workers
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// sidecarsMutex prevents sidecar checks from running concurrently.
+var sidecarsMutex = sync.Mutex{}
+
+// SidecarsState contains the time of the last successful sidecar check, so that
+// only files changed by other applications since then are applied to the index.
+type SidecarsState struct {
+	LastRun time.Time `yaml:"LastRun"`
+}
+
+// Sidecars represents a worker that applies metadata from externally edited XMP and YAML sidecar files.
+type Sidecars struct {
+	conf *config.Config
+}
+
+// NewSidecars returns a new sidecar files worker.
+func NewSidecars(conf *config.Config) *Sidecars {
+	return &Sidecars{conf: conf}
+}
+
+// Start applies the metadata of sidecar files that have been changed since the last run.
+func (worker *Sidecars) Start() (err error) {
+	sidecarsMutex.Lock()
+	defer sidecarsMutex.Unlock()
+
+	start := time.Now()
+	state := worker.State()
+
+	// Only files changed after the first run are applied, existing files are up to date after indexing.
+	if state.LastRun.IsZero() {
+		return worker.SaveState(SidecarsState{LastRun: start})
+	}
+
+	if _, err = photoprism.NewSidecars(worker.conf, service.Index()).Start(state.LastRun); err != nil {
+		return err
+	}
+
+	return worker.SaveState(SidecarsState{LastRun: start})
+}
+
+// FileName returns the name of the file that stores the time of the last sidecar check.
+func (worker *Sidecars) FileName() string {
+	return filepath.Join(worker.conf.ConfigPath(), "sidecars.yml")
+}
+
+// State returns the time of the last successful sidecar check.
+func (worker *Sidecars) State() (state SidecarsState) {
+	fileName := worker.FileName()
+
+	if !fs.FileExists(fileName) {
+		return state
+	} else if data, err := os.ReadFile(fileName); err != nil {
+		log.Errorf("sidecars: %s (read state)", err)
+	} else if err = yaml.Unmarshal(data, &state); err != nil {
+		log.Errorf("sidecars: %s (parse state)", err)
+	}
+
+	return state
+}
+
+// SaveState stores the time of the last sidecar check, so that changes are not missed after a restart.
+func (worker *Sidecars) SaveState(state SidecarsState) error {
+	if data, err := yaml.Marshal(state); err != nil {
+		return err
+	} else if err = os.WriteFile(worker.FileName(), data, 0o644); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package workers
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestSidecars_State(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewSidecars(conf)
+
+	_ = os.Remove(worker.FileName())
+	defer os.Remove(worker.FileName())
+
+	assert.True(t, worker.State().LastRun.IsZero())
+
+	lastRun := time.Date(2026, 10, 14, 12, 30, 0, 0, time.UTC)
+
+	if err := worker.SaveState(SidecarsState{LastRun: lastRun}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, lastRun.Equal(worker.State().LastRun))
+}
@@ -0,0 +1,48 @@
+package workers
+
+import (
+	"sync"
+
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// suggestTopics contains the events after which search suggestions may be outdated.
+var suggestTopics = []string{"index.completed", "import.completed", "photos.*", "albums.*", "labels.*", "subjects.*"}
+
+var suggestState = struct {
+	sub   *hub.Subscription
+	mutex sync.Mutex
+}{}
+
+// StartSuggest flushes the search suggestions whenever the index has changed.
+func StartSuggest() {
+	suggestState.mutex.Lock()
+	defer suggestState.mutex.Unlock()
+
+	if suggestState.sub != nil {
+		return
+	}
+
+	s := event.Subscribe(suggestTopics...)
+	suggestState.sub = &s
+
+	go func() {
+		for range s.Receiver {
+			search.FlushSuggestions()
+		}
+	}()
+}
+
+// StopSuggest stops flushing the search suggestions.
+func StopSuggest() {
+	suggestState.mutex.Lock()
+	defer suggestState.mutex.Unlock()
+
+	if suggestState.sub != nil {
+		event.Unsubscribe(*suggestState.sub)
+		suggestState.sub = nil
+	}
+}
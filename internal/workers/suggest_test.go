@@ -0,0 +1,22 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+func TestStartSuggest(t *testing.T) {
+	StartSuggest()
+	StartSuggest()
+
+	assert.NotNil(t, suggestState.sub)
+
+	event.Publish("index.completed", event.Data{})
+
+	StopSuggest()
+
+	assert.Nil(t, suggestState.sub)
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/internal/remote"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -76,7 +76,14 @@ func (worker *Sync) download(a entity.Account) (complete bool, err error) {
 
 	log.Infof("sync: downloading from %s", a.AccName)
 
-	client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+	client, err := remote.NewClient(a.AccType, a.AccURL, a.AccUser, a.AccPass)
+
+	if err != nil {
+		worker.logError(err)
+		return false, err
+	}
+
+	defer client.Close()
 
 	var baseDir string
 
@@ -10,11 +10,18 @@ import (
 
 // Updates the local list of remote files so that they can be downloaded in batches
 func (worker *Sync) refresh(a entity.Account) (complete bool, err error) {
-	if a.AccType != remote.ServiceWebDAV {
+	if !remote.CanSync(a.AccType) {
 		return false, nil
 	}
 
-	client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+	client, err := remote.NewClient(a.AccType, a.AccURL, a.AccUser, a.AccPass)
+
+	if err != nil {
+		log.Error(err)
+		return false, err
+	}
+
+	defer client.Close()
 
 	subDirs, err := client.Directories(a.SyncPath, true, webdav.AsyncTimeout)
 
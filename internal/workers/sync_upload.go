@@ -10,12 +10,17 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/remote"
 	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
 // Uploads local files to a remote account
 func (worker *Sync) upload(a entity.Account) (complete bool, err error) {
+	if !remote.CanUpload(a.AccType) {
+		return true, nil
+	}
+
 	maxResults := 250
 
 	// Get upload file list from database
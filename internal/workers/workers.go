@@ -32,19 +32,24 @@ https://docs.photoprism.app/developer-guide/
 package workers
 
 import (
+	"context"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/tracing"
 )
 
 var log = event.Log
 var stop = make(chan bool, 1)
 
-// Start runs the metadata, share & sync background workers at regular intervals.
+// Start runs the metadata, share, sync & gc background workers at regular intervals and enables notifications.
 func Start(conf *config.Config) {
+	StartNotify(conf)
+	StartSuggest()
+
 	interval := conf.WakeupInterval()
 
 	// Disabled in safe mode?
@@ -62,13 +67,30 @@ func Start(conf *config.Config) {
 				log.Info("shutting down workers")
 				ticker.Stop()
 				mutex.MetaWorker.Cancel()
+				mutex.CaptionsWorker.Cancel()
 				mutex.ShareWorker.Cancel()
 				mutex.SyncWorker.Cancel()
+				mutex.SearchesWorker.Cancel()
+				mutex.DigestWorker.Cancel()
+				mutex.GCWorker.Cancel()
+				mutex.DownloadWorker.Cancel()
 				return
 			case <-ticker.C:
+				// Don't start workers in maintenance mode.
+				if mutex.Maintenance.Enabled() {
+					continue
+				}
+
 				StartMeta(conf)
+				StartSidecars(conf)
 				StartShare(conf)
+				StartShareUploads(conf)
 				StartSync(conf)
+				StartSearches(conf)
+				StartDigest(conf)
+				StartGC(conf)
+				StartDownloads(conf)
+				CheckStorage(conf)
 			}
 		}
 	}()
@@ -76,6 +98,8 @@ func Start(conf *config.Config) {
 
 // Stop shuts down all service workers.
 func Stop() {
+	StopNotify()
+	StopSuggest()
 	stop <- true
 }
 
@@ -88,19 +112,59 @@ func StartMeta(conf *config.Config) {
 			delay := time.Minute
 			interval := entity.MetadataUpdateInterval
 
-			if err := worker.Start(delay, interval, false); err != nil {
+			_, span := tracing.Start(context.Background(), "workers.meta")
+			err := worker.Start(delay, interval, false)
+			tracing.End(span, err)
+
+			if err != nil {
 				log.Warnf("metadata: %s", err)
 			}
 		}()
 	}
 }
 
+// StartSidecars applies the metadata of changed sidecar files once, unless the index is being updated.
+func StartSidecars(conf *config.Config) {
+	if !mutex.MainWorker.Busy() {
+		go func() {
+			worker := NewSidecars(conf)
+			_, span := tracing.Start(context.Background(), "workers.sidecars")
+			err := worker.Start()
+			tracing.End(span, err)
+
+			if err != nil {
+				log.Warnf("sidecars: %s", err)
+			}
+		}()
+	}
+}
+
 // StartShare runs the share worker once.
 func StartShare(conf *config.Config) {
 	if !mutex.ShareWorker.Busy() {
 		go func() {
 			worker := NewShare(conf)
-			if err := worker.Start(); err != nil {
+			_, span := tracing.Start(context.Background(), "workers.share")
+			err := worker.Start()
+			tracing.End(span, err)
+
+			if err != nil {
+				log.Warnf("share: %s", err)
+			}
+		}()
+	}
+}
+
+// StartShareUploads imports approved guest uploads once, unless the index is being updated.
+func StartShareUploads(conf *config.Config) {
+	if !mutex.MainWorker.Busy() {
+		go func() {
+			worker := NewShareUploads(conf)
+			_, span := tracing.Start(context.Background(), "workers.share_uploads")
+			err := worker.Start()
+			tracing.End(span, err)
+
+			if err != nil {
 				log.Warnf("share: %s", err)
 			}
 		}()
@@ -112,9 +176,77 @@ func StartSync(conf *config.Config) {
 	if !mutex.SyncWorker.Busy() {
 		go func() {
 			worker := NewSync(conf)
-			if err := worker.Start(); err != nil {
+			_, span := tracing.Start(context.Background(), "workers.sync")
+			err := worker.Start()
+			tracing.End(span, err)
+
+			if err != nil {
 				log.Warnf("sync: %s", err)
 			}
 		}()
 	}
 }
+
+// StartSearches runs the saved searches worker once.
+func StartSearches(conf *config.Config) {
+	if !mutex.SearchesWorker.Busy() {
+		go func() {
+			worker := NewSearches(conf)
+			_, span := tracing.Start(context.Background(), "workers.searches")
+			err := worker.Start()
+			tracing.End(span, err)
+
+			if err != nil {
+				log.Warnf("searches: %s", err)
+			}
+		}()
+	}
+}
+
+// StartDigest runs the monthly summary worker once.
+func StartDigest(conf *config.Config) {
+	if !mutex.DigestWorker.Busy() {
+		go func() {
+			worker := NewDigest(conf)
+			_, span := tracing.Start(context.Background(), "workers.digest")
+			err := worker.Start(time.Now())
+			tracing.End(span, err)
+
+			if err != nil {
+				log.Warnf("digest: %s", err)
+			}
+		}()
+	}
+}
+
+// StartDownloads runs the remote download worker once.
+func StartDownloads(conf *config.Config) {
+	if !mutex.DownloadWorker.Busy() {
+		go func() {
+			worker := NewDownloader(conf)
+			_, span := tracing.Start(context.Background(), "workers.download")
+			err := worker.Start()
+			tracing.End(span, err)
+
+			if err != nil {
+				log.Warnf("download: %s", err)
+			}
+		}()
+	}
+}
+
+// StartGC runs the garbage collection worker once, if it has not been run within GCInterval.
+func StartGC(conf *config.Config) {
+	if !mutex.GCWorker.Busy() {
+		go func() {
+			worker := NewGC(conf)
+			_, span := tracing.Start(context.Background(), "workers.gc")
+			err := worker.Start(GCInterval)
+			tracing.End(span, err)
+
+			if err != nil {
+				log.Warnf("gc: %s", err)
+			}
+		}()
+	}
+}
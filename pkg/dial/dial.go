@@ -0,0 +1,134 @@
+/*
+
+Package dial provides network connections and HTTP clients that cannot reach local or private addresses.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+*/
+package dial
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrPrivate is returned when a connection to a local or private network address is refused.
+var ErrPrivate = errors.New("local and private network addresses are not allowed")
+
+// sharedAddressSpace is the carrier-grade NAT range, see RFC 6598.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// allowList contains local addresses that may be reached anyway.
+var allowList = struct {
+	hosts map[string]bool
+	mutex sync.RWMutex
+}{hosts: make(map[string]bool)}
+
+// Allow adds host names or addresses that may be reached even if they are local, e.g. for testing.
+func Allow(hosts ...string) {
+	allowList.mutex.Lock()
+	defer allowList.mutex.Unlock()
+
+	for _, host := range hosts {
+		allowList.hosts[normalizeHost(host)] = true
+	}
+}
+
+// allowed tests if the host name or address has been added with Allow.
+func allowed(host string) bool {
+	allowList.mutex.RLock()
+	defer allowList.mutex.RUnlock()
+
+	return allowList.hosts[host]
+}
+
+// normalizeHost returns the host name or address in lowercase and without brackets or trailing dot.
+func normalizeHost(host string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.Trim(host, "[]"), "."))
+}
+
+// Public tests if the ip address can be reached through the internet.
+func Public(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		sharedAddressSpace.Contains(ip) || ip.To4() != nil && ip.To4()[0] == 0)
+}
+
+// CheckHost returns an error if the host name or address is local or private, without resolving it.
+func CheckHost(host string) error {
+	host = normalizeHost(host)
+
+	if host == "" {
+		return fmt.Errorf("host must not be empty")
+	} else if allowed(host) {
+		return nil
+	} else if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrPrivate
+	} else if ip := net.ParseIP(host); ip != nil && !Public(ip) {
+		return ErrPrivate
+	}
+
+	return nil
+}
+
+// control refuses connections to addresses that are not public after the host name has been resolved.
+func control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+
+	if err != nil {
+		return err
+	} else if !Public(net.ParseIP(host)) && !allowed(normalizeHost(host)) {
+		return ErrPrivate
+	}
+
+	return nil
+}
+
+// Dialer returns a network dialer that only connects to public addresses.
+func Dialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second, Control: control}
+}
+
+// Client returns an HTTP client that only connects to public addresses. Since every connection
+// is checked after DNS resolution, this also applies to redirects.
+func Client(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	// A proxy would connect on behalf of the client and bypass the address check.
+	transport.Proxy = nil
+	transport.DialContext = Dialer(timeout).DialContext
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
@@ -0,0 +1,69 @@
+package dial
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublic(t *testing.T) {
+	assert.True(t, Public(net.ParseIP("8.8.8.8")))
+	assert.True(t, Public(net.ParseIP("2001:4860:4860::8888")))
+	assert.False(t, Public(nil))
+	assert.False(t, Public(net.ParseIP("127.0.0.1")))
+	assert.False(t, Public(net.ParseIP("::1")))
+	assert.False(t, Public(net.ParseIP("10.1.2.3")))
+	assert.False(t, Public(net.ParseIP("172.16.0.1")))
+	assert.False(t, Public(net.ParseIP("192.168.1.1")))
+	assert.False(t, Public(net.ParseIP("169.254.169.254")))
+	assert.False(t, Public(net.ParseIP("fe80::1")))
+	assert.False(t, Public(net.ParseIP("fd00::1")))
+	assert.False(t, Public(net.ParseIP("100.64.0.1")))
+	assert.False(t, Public(net.ParseIP("0.0.0.0")))
+	assert.False(t, Public(net.ParseIP("::ffff:127.0.0.1")))
+}
+
+func TestCheckHost(t *testing.T) {
+	assert.NoError(t, CheckHost("push.example.com"))
+	assert.NoError(t, CheckHost("8.8.8.8"))
+	assert.Error(t, CheckHost(""))
+	assert.ErrorIs(t, CheckHost("localhost"), ErrPrivate)
+	assert.ErrorIs(t, CheckHost("api.localhost."), ErrPrivate)
+	assert.ErrorIs(t, CheckHost("127.0.0.1"), ErrPrivate)
+	assert.ErrorIs(t, CheckHost("[::1]"), ErrPrivate)
+	assert.ErrorIs(t, CheckHost("169.254.169.254"), ErrPrivate)
+}
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	t.Run("Loopback", func(t *testing.T) {
+		_, err := Client(5 * time.Second).Get(server.URL)
+
+		assert.True(t, errors.Is(err, ErrPrivate))
+	})
+	t.Run("Allowed", func(t *testing.T) {
+		Allow("127.0.0.1")
+		defer func() { allowList.hosts = make(map[string]bool) }()
+
+		assert.NoError(t, CheckHost("127.0.0.1"))
+
+		resp, err := Client(5 * time.Second).Get(server.URL)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
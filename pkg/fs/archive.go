@@ -0,0 +1,217 @@
+package fs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveExt lists the supported archive file extensions.
+var ArchiveExt = []string{".zip", ".tar", ".tar.gz", ".tgz"}
+
+// ErrArchiveLimit is returned if the extracted files exceed the size limit.
+var ErrArchiveLimit = fmt.Errorf("archive exceeds size limit")
+
+// IsArchive tests if the file name has a supported archive extension.
+func IsArchive(fileName string) bool {
+	name := strings.ToLower(fileName)
+
+	for _, ext := range ArchiveExt {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Extract extracts a ZIP or TAR archive to the destination directory and returns the names of the
+// extracted files. Entry names that would end up outside the destination are rejected, and
+// extraction stops once the total size exceeds the limit in bytes, unless it is negative.
+func Extract(src, dest string, limit int64) (fileNames []string, err error) {
+	if dest, err = filepath.Abs(dest); err != nil {
+		return fileNames, err
+	}
+
+	name := strings.ToLower(src)
+
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return extractZip(src, dest, limit)
+	case strings.HasSuffix(name, ".tar"):
+		return extractTar(src, dest, limit, false)
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return extractTar(src, dest, limit, true)
+	default:
+		return fileNames, fmt.Errorf("unsupported archive type %s", filepath.Ext(src))
+	}
+}
+
+// archiveTarget returns the file name of an archive entry in the destination directory, or an
+// empty string if the entry should be skipped.
+func archiveTarget(dest, entryName string) (string, error) {
+	entryName = filepath.FromSlash(entryName)
+
+	// Skip directories like __MACOSX and hidden files.
+	for _, elem := range strings.Split(entryName, string(os.PathSeparator)) {
+		if strings.HasPrefix(elem, "__") || strings.HasPrefix(elem, ".") && elem != "." && elem != ".." {
+			return "", nil
+		}
+	}
+
+	target := filepath.Join(dest, entryName)
+
+	if target == dest {
+		return "", nil
+	} else if !strings.HasPrefix(target, dest+string(os.PathSeparator)) {
+		return "", fmt.Errorf("illegal file path %s in archive", entryName)
+	}
+
+	return target, nil
+}
+
+// extractFile writes an archive entry to the target file name and returns the number of bytes written.
+func extractFile(r io.Reader, target string, limit int64) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return 0, err
+	}
+
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer f.Close()
+
+	if limit < 0 {
+		return io.Copy(f, r)
+	}
+
+	// Read one more byte than allowed to detect if the limit has been exceeded.
+	n, err := io.Copy(f, io.LimitReader(r, limit+1))
+
+	if err != nil {
+		return n, err
+	} else if n > limit {
+		return n, ErrArchiveLimit
+	}
+
+	return n, nil
+}
+
+// extractZip extracts a ZIP archive.
+func extractZip(src, dest string, limit int64) (fileNames []string, err error) {
+	r, err := zip.OpenReader(src)
+
+	if err != nil {
+		return fileNames, err
+	}
+
+	defer r.Close()
+
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() || !f.Mode().IsRegular() {
+			continue
+		}
+
+		target, err := archiveTarget(dest, f.Name)
+
+		if err != nil {
+			return fileNames, err
+		} else if target == "" {
+			continue
+		}
+
+		rc, err := f.Open()
+
+		if err != nil {
+			return fileNames, err
+		}
+
+		n, err := extractFile(rc, target, limit)
+
+		rc.Close()
+
+		if err != nil {
+			return fileNames, err
+		}
+
+		if limit >= 0 {
+			limit -= n
+		}
+
+		fileNames = append(fileNames, target)
+	}
+
+	return fileNames, nil
+}
+
+// extractTar extracts a TAR archive, which may be compressed with gzip.
+func extractTar(src, dest string, limit int64, compressed bool) (fileNames []string, err error) {
+	file, err := os.Open(src)
+
+	if err != nil {
+		return fileNames, err
+	}
+
+	defer file.Close()
+
+	var r io.Reader = file
+
+	if compressed {
+		gz, err := gzip.NewReader(file)
+
+		if err != nil {
+			return fileNames, err
+		}
+
+		defer gz.Close()
+
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+
+	for {
+		header, err := tr.Next()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fileNames, err
+		}
+
+		// Only extract regular files, links are skipped.
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			continue
+		}
+
+		target, err := archiveTarget(dest, header.Name)
+
+		if err != nil {
+			return fileNames, err
+		} else if target == "" {
+			continue
+		}
+
+		n, err := extractFile(tr, target, limit)
+
+		if err != nil {
+			return fileNames, err
+		}
+
+		if limit >= 0 {
+			limit -= n
+		}
+
+		fileNames = append(fileNames, target)
+	}
+
+	return fileNames, nil
+}
@@ -0,0 +1,157 @@
+package fs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestZip(t *testing.T, fileName string, files map[string]string) {
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+
+	for name, content := range files {
+		fw, err := w.Create(name)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := fw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestTarGz(t *testing.T, fileName string, files map[string]string) {
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	w := tar.NewWriter(gz)
+
+	for name, content := range files {
+		if err := w.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIsArchive(t *testing.T) {
+	assert.True(t, IsArchive("photos.zip"))
+	assert.True(t, IsArchive("Photos.TAR.GZ"))
+	assert.True(t, IsArchive("photos.tgz"))
+	assert.True(t, IsArchive("photos.tar"))
+	assert.False(t, IsArchive("photo.jpg"))
+	assert.False(t, IsArchive(""))
+}
+
+func TestExtract(t *testing.T) {
+	files := map[string]string{
+		"Holiday/Beach/IMG_1.jpg":    "beach",
+		"Holiday/IMG_2.jpg":          "holiday",
+		"__MACOSX/Holiday/IMG_1.jpg": "resource fork",
+		"Holiday/.DS_Store":          "hidden",
+	}
+
+	t.Run("Zip", func(t *testing.T) {
+		dir := t.TempDir()
+		src := filepath.Join(dir, "test.zip")
+		dest := filepath.Join(dir, "dest")
+
+		writeTestZip(t, src, files)
+
+		fileNames, err := Extract(src, dest, -1)
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{filepath.Join(dest, "Holiday/Beach/IMG_1.jpg"), filepath.Join(dest, "Holiday/IMG_2.jpg")}, fileNames)
+		assert.True(t, FileExists(filepath.Join(dest, "Holiday/Beach/IMG_1.jpg")))
+		assert.False(t, PathExists(filepath.Join(dest, "__MACOSX")))
+	})
+	t.Run("TarGz", func(t *testing.T) {
+		dir := t.TempDir()
+		src := filepath.Join(dir, "test.tar.gz")
+		dest := filepath.Join(dir, "dest")
+
+		writeTestTarGz(t, src, files)
+
+		fileNames, err := Extract(src, dest, -1)
+
+		assert.NoError(t, err)
+		assert.Len(t, fileNames, 2)
+		assert.True(t, FileExists(filepath.Join(dest, "Holiday/IMG_2.jpg")))
+	})
+	t.Run("ZipSlip", func(t *testing.T) {
+		dir := t.TempDir()
+		src := filepath.Join(dir, "test.zip")
+		dest := filepath.Join(dir, "dest")
+
+		writeTestZip(t, src, map[string]string{"../evil.jpg": "evil"})
+
+		_, err := Extract(src, dest, -1)
+
+		assert.Error(t, err)
+		assert.False(t, FileExists(filepath.Join(dir, "evil.jpg")))
+	})
+	t.Run("TarSlip", func(t *testing.T) {
+		dir := t.TempDir()
+		src := filepath.Join(dir, "test.tgz")
+		dest := filepath.Join(dir, "dest")
+
+		writeTestTarGz(t, src, map[string]string{"Holiday/../../evil.jpg": "evil"})
+
+		_, err := Extract(src, dest, -1)
+
+		assert.Error(t, err)
+		assert.False(t, FileExists(filepath.Join(dir, "evil.jpg")))
+	})
+	t.Run("Limit", func(t *testing.T) {
+		dir := t.TempDir()
+		src := filepath.Join(dir, "test.zip")
+		dest := filepath.Join(dir, "dest")
+
+		writeTestZip(t, src, files)
+
+		_, err := Extract(src, dest, 8)
+
+		assert.Equal(t, ErrArchiveLimit, err)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := Extract("testdata/test.jpg", t.TempDir(), -1)
+
+		assert.Error(t, err)
+	})
+}
@@ -54,11 +54,40 @@ func StripSequence(name string) string {
 		name = name[:end]
 	}
 
-	name = strings.TrimSpace(name)
+	// Edited versions exported by image editors, example: IMG_1234-edit.
+	return StripEditSuffix(strings.TrimSpace(name))
+}
+
+// EditSuffixes contains common file name suffixes of edited versions.
+var EditSuffixes = []string{"-edited", "-edit", "_edited", "_edit", " edited", " edit"}
+
+// EditSuffix returns the edited version suffix of a file name without extension, if any.
+func EditSuffix(name string) string {
+	lower := strings.ToLower(name)
+
+	for _, suffix := range EditSuffixes {
+		if len(lower) > len(suffix) && strings.HasSuffix(lower, suffix) {
+			return name[len(name)-len(suffix):]
+		}
+	}
+
+	return ""
+}
+
+// StripEditSuffix removes the edited version suffix from a file name without extension, if any.
+func StripEditSuffix(name string) string {
+	if suffix := EditSuffix(name); suffix != "" {
+		name = strings.TrimSpace(name[:len(name)-len(suffix)])
+	}
 
 	return name
 }
 
+// IsEdited tests if the file name indicates an edited version, e.g. IMG_1234-edit.jpg.
+func IsEdited(fileName string) bool {
+	return EditSuffix(BasePrefix(fileName, false)) != ""
+}
+
 // BasePrefix returns the filename base without any extensions and path.
 func BasePrefix(fileName string, stripSequence bool) string {
 	name := StripKnownExt(StripExt(filepath.Base(fileName)))
@@ -105,6 +105,14 @@ func TestBase(t *testing.T) {
 		assert.Equal(t, "Test", result)
 	})
 
+	t.Run("IMG_1234-edit.jpg", func(t *testing.T) {
+		regular := BasePrefix("/testdata/IMG_1234-edit.jpg", false)
+		assert.Equal(t, "IMG_1234-edit", regular)
+
+		stripped := BasePrefix("/testdata/IMG_1234-Edited.jpg", true)
+		assert.Equal(t, "IMG_1234", stripped)
+	})
+
 	t.Run("Test.3453453.jpg", func(t *testing.T) {
 		regular := BasePrefix("/testdata/Test.3453453.jpg", false)
 		assert.Equal(t, "Test.3453453", regular)
@@ -164,6 +172,23 @@ func TestBase(t *testing.T) {
 	})
 }
 
+func TestIsEdited(t *testing.T) {
+	assert.True(t, IsEdited("/testdata/IMG_1234-edit.jpg"))
+	assert.True(t, IsEdited("/testdata/IMG_1234_Edited.jpg.json"))
+	assert.True(t, IsEdited("IMG_1234 edit.jpg"))
+	assert.False(t, IsEdited("/testdata/IMG_1234.jpg"))
+	assert.False(t, IsEdited("/testdata/edit.jpg"))
+	assert.False(t, IsEdited("/testdata/-edit.jpg"))
+}
+
+func TestStripEditSuffix(t *testing.T) {
+	assert.Equal(t, "IMG_1234", StripEditSuffix("IMG_1234-edit"))
+	assert.Equal(t, "IMG_1234", StripEditSuffix("IMG_1234_Edited"))
+	assert.Equal(t, "IMG_1234 (2)", StripEditSuffix("IMG_1234 (2) edit"))
+	assert.Equal(t, "IMG_1234", StripEditSuffix("IMG_1234"))
+	assert.Equal(t, "edit", StripEditSuffix("edit"))
+}
+
 func TestRelBase(t *testing.T) {
 	t.Run("/foo/bar.0000.ZIP", func(t *testing.T) {
 		regular := RelPrefix("/foo/bar.0000.ZIP", "/bar", false)
@@ -15,6 +15,10 @@ func Copy(src, dest string) (err error) {
 		}
 	}()
 
+	if err := Writable(dest); err != nil {
+		return err
+	}
+
 	if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
 		return err
 	}
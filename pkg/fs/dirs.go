@@ -184,3 +184,18 @@ func FindDir(dirs []string) string {
 
 	return ""
 }
+
+// DirSize returns the total size in bytes of all regular files in a directory and its subdirectories.
+func DirSize(dir string) (size int64, err error) {
+	err = filepath.Walk(dir, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		} else if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size, err
+}
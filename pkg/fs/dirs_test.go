@@ -90,3 +90,19 @@ func TestFindDirs(t *testing.T) {
 		assert.True(t, strings.HasSuffix(result, "/pkg/fs/testdata"))
 	})
 }
+
+func TestDirSize(t *testing.T) {
+	t.Run("Testdata", func(t *testing.T) {
+		size, err := DirSize("testdata/directory")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, size, int64(0))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := DirSize("testdata/foo/bar")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,20 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package fs
+
+import "syscall"
+
+// DiskUsage returns the used and total size in bytes of the file system that contains path.
+func DiskUsage(path string) (used, total uint64, err error) {
+	var stat syscall.Statfs_t
+
+	if err = syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+
+	total = stat.Blocks * uint64(stat.Bsize)
+	used = total - stat.Bavail*uint64(stat.Bsize)
+
+	return used, total, nil
+}
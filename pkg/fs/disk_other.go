@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package fs
+
+import "errors"
+
+// DiskUsage returns the used and total size in bytes of the file system that contains path.
+func DiskUsage(path string) (used, total uint64, err error) {
+	return 0, 0, errors.New("disk usage not supported on this platform")
+}
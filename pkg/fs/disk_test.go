@@ -0,0 +1,24 @@
+package fs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskUsage(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		used, total, err := DiskUsage("testdata")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, total, uint64(0))
+		assert.LessOrEqual(t, used, total)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, _, err := DiskUsage("testdata/foo/bar")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,26 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package fs
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// FileID returns a unique identifier of the file a path refers to, so that hard links and symbolic links
+// to the same file can be detected. Symbolic links are followed, an empty string is returned on error.
+func FileID(fileName string) string {
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		return ""
+	}
+
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return fmt.Sprintf("%d:%d", stat.Dev, stat.Ino)
+	}
+
+	return ""
+}
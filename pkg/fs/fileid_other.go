@@ -0,0 +1,24 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package fs
+
+import (
+	"path/filepath"
+)
+
+// FileID returns a unique identifier of the file a path refers to, so that symbolic links to the same
+// file can be detected. Hard links are not detected on this platform, an empty string is returned on error.
+func FileID(fileName string) string {
+	resolved, err := filepath.EvalSymlinks(fileName)
+
+	if err != nil {
+		return ""
+	}
+
+	if resolved, err = filepath.Abs(resolved); err != nil {
+		return ""
+	}
+
+	return resolved
+}
@@ -0,0 +1,58 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileID(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "test.jpg")
+
+	if err := Copy("testdata/test.jpg", fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	id := FileID(fileName)
+
+	assert.NotEmpty(t, id)
+
+	t.Run("Symlink", func(t *testing.T) {
+		linkName := filepath.Join(dir, "symlink.jpg")
+
+		if err := os.Symlink(fileName, linkName); err != nil {
+			t.Skip(err)
+		}
+
+		assert.Equal(t, id, FileID(linkName))
+	})
+	t.Run("Hardlink", func(t *testing.T) {
+		if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+			t.Skip("hard links are not detected on this platform")
+		}
+
+		linkName := filepath.Join(dir, "hardlink.jpg")
+
+		if err := os.Link(fileName, linkName); err != nil {
+			t.Skip(err)
+		}
+
+		assert.Equal(t, id, FileID(linkName))
+	})
+	t.Run("Copy", func(t *testing.T) {
+		copyName := filepath.Join(dir, "copy.jpg")
+
+		if err := Copy(fileName, copyName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, id, FileID(copyName))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Equal(t, "", FileID(filepath.Join(dir, "missing.jpg")))
+	})
+}
@@ -19,6 +19,7 @@ const (
 	FormatBitmap   FileFormat = "bmp"  // BMP image file.
 	FormatRaw      FileFormat = "raw"  // RAW image file.
 	FormatHEIF     FileFormat = "heif" // High Efficiency Image File Format
+	FormatDicom    FileFormat = "dcm"  // DICOM medical and scientific image file.
 	FormatHEVC     FileFormat = "hevc"
 	FormatMov      FileFormat = "mov" // Video files.
 	FormatMp4      FileFormat = "mp4"
@@ -90,6 +91,7 @@ var FileExt = FileExtensions{
 	".aae":  FormatAAE,
 	".heif": FormatHEIF,
 	".heic": FormatHEIF,
+	".dcm":  FormatDicom,
 	".3fr":  FormatRaw,
 	".ari":  FormatRaw,
 	".bay":  FormatRaw,
@@ -82,7 +82,7 @@ func PathExists(path string) bool {
 
 // PathWritable tests if a path exists and is writable.
 func PathWritable(path string) bool {
-	if !PathExists(path) {
+	if !PathExists(path) || Protected(path) {
 		return false
 	}
 
@@ -101,6 +101,10 @@ func PathWritable(path string) bool {
 
 // Overwrite overwrites the file with data. Creates file if not present.
 func Overwrite(fileName string, data []byte) bool {
+	if Protected(fileName) {
+		return false
+	}
+
 	f, err := os.Create(fileName)
 	if err != nil {
 		return false
@@ -0,0 +1,11 @@
+package fs
+
+import "strings"
+
+// globMeta replaces the meta characters of a file name pattern with escaped versions.
+var globMeta = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`)
+
+// GlobEscape escapes the meta characters *?[\ in a file name, so that it can be used as a literal Glob pattern.
+func GlobEscape(name string) string {
+	return globMeta.Replace(name)
+}
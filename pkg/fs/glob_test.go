@@ -0,0 +1,32 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobEscape(t *testing.T) {
+	t.Run("Literal", func(t *testing.T) {
+		assert.Equal(t, "/photos/2019/IMG_4120 (1).jpg", GlobEscape("/photos/2019/IMG_4120 (1).jpg"))
+		assert.Equal(t, "/photos/a+b$^.jpg", GlobEscape("/photos/a+b$^.jpg"))
+	})
+	t.Run("Meta", func(t *testing.T) {
+		assert.Equal(t, `\[1]\*\?\\.jpg`, GlobEscape(`[1]*?\.jpg`))
+	})
+	t.Run("Glob", func(t *testing.T) {
+		dir := t.TempDir()
+		name := filepath.Join(dir, "IMG [1] (copy).jpg")
+
+		if err := os.WriteFile(name, []byte("{}"), 0666); err != nil {
+			t.Fatal(err)
+		}
+
+		matches, err := filepath.Glob(GlobEscape(filepath.Join(dir, "IMG [1] (copy)")) + ".*")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{name}, matches)
+	})
+}
@@ -51,6 +51,8 @@ type IgnoreList struct {
 	items         []IgnoreItem
 	hiddenFiles   []string
 	ignoredFiles  []string
+	junkFiles     []string
+	junkPatterns  []string
 	configFiles   map[string][]string
 	configFile    string
 	ignoreHidden  bool
@@ -64,6 +66,7 @@ func NewIgnoreList(configFile string, ignoreHidden bool, caseSensitive bool) *Ig
 		ignoreHidden:  ignoreHidden,
 		caseSensitive: caseSensitive,
 		configFiles:   make(map[string][]string),
+		junkPatterns:  JunkPatterns,
 	}
 }
 
@@ -77,6 +80,16 @@ func (l *IgnoreList) Ignored() []string {
 	return l.ignoredFiles
 }
 
+// Junk returns junk files and folders that were ignored, like .DS_Store or Thumbs.db.
+func (l *IgnoreList) Junk() []string {
+	return l.junkFiles
+}
+
+// SetJunkPatterns replaces the junk name patterns, an empty list disables junk filtering.
+func (l *IgnoreList) SetJunkPatterns(patterns []string) {
+	l.junkPatterns = patterns
+}
+
 // AppendItems adds items to the list of ignored items.
 func (l *IgnoreList) AppendItems(dir string, patterns []string) error {
 	if dir == "" {
@@ -156,6 +169,11 @@ func (l *IgnoreList) Ignore(fileName string) bool {
 		}
 	}
 
+	if FileNameJunk(base, l.junkPatterns) {
+		l.junkFiles = append(l.junkFiles, fileName)
+		return true
+	}
+
 	if l.ignoreHidden && FileNameHidden(fileName) {
 		l.hiddenFiles = append(l.hiddenFiles, fileName)
 		return true
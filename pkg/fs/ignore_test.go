@@ -73,6 +73,36 @@ func TestIgnoreList_Hidden(t *testing.T) {
 	})
 }
 
+func TestIgnoreList_Junk(t *testing.T) {
+	t.Run("ignore junk", func(t *testing.T) {
+		ignore := NewIgnoreList(".ppignore", false, false)
+
+		assert.True(t, ignore.Ignore("testdata/directory/.DS_Store"))
+		assert.True(t, ignore.Ignore("testdata/directory/Thumbs.db"))
+		assert.True(t, ignore.Ignore("testdata/directory/@eaDir"))
+		assert.False(t, ignore.Ignore("testdata/directory/.hiddenfile"))
+		assert.False(t, ignore.Ignore("testdata/directory/example.bmp"))
+
+		expectJunk := []string{
+			"testdata/directory/.DS_Store",
+			"testdata/directory/Thumbs.db",
+			"testdata/directory/@eaDir",
+		}
+
+		assert.Equal(t, expectJunk, ignore.Junk())
+		assert.Equal(t, 0, len(ignore.Hidden()))
+	})
+
+	t.Run("custom patterns", func(t *testing.T) {
+		ignore := NewIgnoreList(".ppignore", false, false)
+		ignore.SetJunkPatterns([]string{"*.ini"})
+
+		assert.True(t, ignore.Ignore("testdata/directory/desktop.ini"))
+		assert.False(t, ignore.Ignore("testdata/directory/Thumbs.db"))
+		assert.Equal(t, []string{"testdata/directory/desktop.ini"}, ignore.Junk())
+	})
+}
+
 func TestIgnoreList_Ignored(t *testing.T) {
 	t.Run("has ignored", func(t *testing.T) {
 		testPath := "testdata/directory"
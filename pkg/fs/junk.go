@@ -0,0 +1,55 @@
+package fs
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// DefaultJunkPatterns are the default name patterns of files and folders created by operating systems, NAS devices,
+// and trash folders, which should neither be indexed nor imported.
+var DefaultJunkPatterns = []string{
+	".DS_Store",
+	"._*",
+	".AppleDouble",
+	".AppleDB",
+	".Spotlight-V100",
+	".fseventsd",
+	".Trashes",
+	".Trash",
+	".Trash-*",
+	"Thumbs.db",
+	"ehthumbs.db",
+	"desktop.ini",
+	"$RECYCLE.BIN",
+	"System Volume Information",
+	"@eaDir",
+	"@Recycle",
+	"#recycle",
+	"#snapshot",
+}
+
+// JunkPatterns are the junk name patterns currently in use, they can be changed in the config.
+var JunkPatterns = DefaultJunkPatterns
+
+// FileNameJunk tests if the base name of a file or folder matches one of the junk name patterns, ignoring the case.
+func FileNameJunk(name string, patterns []string) bool {
+	if name == "" || len(patterns) == 0 {
+		return false
+	}
+
+	base := strings.ToLower(filepath.Base(name))
+
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+		if pattern == "" {
+			continue
+		} else if pattern == base {
+			return true
+		} else if match, err := filepath.Match(pattern, base); match && err == nil {
+			return true
+		}
+	}
+
+	return false
+}
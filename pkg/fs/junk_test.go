@@ -0,0 +1,31 @@
+package fs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileNameJunk(t *testing.T) {
+	t.Run("Junk", func(t *testing.T) {
+		assert.True(t, FileNameJunk("/photos/2020/.DS_Store", JunkPatterns))
+		assert.True(t, FileNameJunk("/photos/2020/._IMG_1234.jpg", JunkPatterns))
+		assert.True(t, FileNameJunk("/photos/2020/Thumbs.db", JunkPatterns))
+		assert.True(t, FileNameJunk("/photos/2020/thumbs.DB", JunkPatterns))
+		assert.True(t, FileNameJunk("/volume1/photo/@eaDir", JunkPatterns))
+		assert.True(t, FileNameJunk("/volume1/photo/#recycle", JunkPatterns))
+		assert.True(t, FileNameJunk("/media/usb/$RECYCLE.BIN", JunkPatterns))
+		assert.True(t, FileNameJunk("/media/usb/.Trash-1000", JunkPatterns))
+	})
+	t.Run("NoJunk", func(t *testing.T) {
+		assert.False(t, FileNameJunk("/photos/2020/IMG_1234.jpg", JunkPatterns))
+		assert.False(t, FileNameJunk("/photos/2020/.hidden.jpg", JunkPatterns))
+		assert.False(t, FileNameJunk("/photos/recycle", JunkPatterns))
+		assert.False(t, FileNameJunk("", JunkPatterns))
+	})
+	t.Run("CustomPatterns", func(t *testing.T) {
+		assert.True(t, FileNameJunk("/photos/picasa.ini", []string{"", "*.ini"}))
+		assert.False(t, FileNameJunk("/photos/.DS_Store", []string{"*.ini"}))
+		assert.False(t, FileNameJunk("/photos/.DS_Store", nil))
+	})
+}
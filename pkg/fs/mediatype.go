@@ -18,6 +18,7 @@ var MediaTypes = map[FileFormat]MediaType{
 	FormatTiff:     MediaImage,
 	FormatBitmap:   MediaImage,
 	FormatHEIF:     MediaImage,
+	FormatDicom:    MediaImage,
 	FormatMpo:      MediaImage,
 	FormatAvi:      MediaVideo,
 	FormatHEVC:     MediaVideo,
@@ -13,6 +13,7 @@ const (
 	MimeTypeBitmap = "image/bmp"
 	MimeTypeTiff   = "image/tiff"
 	MimeTypeHEIF   = "image/heif"
+	MimeTypeDicom  = "application/dicom"
 )
 
 // MimeType returns the mime type of a file, empty string if unknown.
@@ -30,6 +31,8 @@ func MimeType(filename string) string {
 
 	if _, err := handle.Read(buffer); err != nil {
 		return ""
+	} else if IsDicom(buffer) {
+		return MimeTypeDicom
 	} else if t, err := filetype.Get(buffer); err == nil && t != filetype.Unknown {
 		return t.MIME.Value
 	} else if t := filetype.GetType(NormalizeExt(filename)); t != filetype.Unknown {
@@ -38,3 +41,8 @@ func MimeType(filename string) string {
 		return ""
 	}
 }
+
+// IsDicom tests if the buffer starts with a DICOM file preamble followed by the "DICM" prefix.
+func IsDicom(buffer []byte) bool {
+	return len(buffer) >= 132 && string(buffer[128:132]) == "DICM"
+}
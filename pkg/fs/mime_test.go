@@ -18,3 +18,19 @@ func TestMimeType(t *testing.T) {
 		assert.Equal(t, "", mimeType)
 	})
 }
+
+func TestIsDicom(t *testing.T) {
+	t.Run("dicom", func(t *testing.T) {
+		buffer := make([]byte, 261)
+		copy(buffer[128:], "DICM")
+		assert.True(t, IsDicom(buffer))
+	})
+	t.Run("jpeg", func(t *testing.T) {
+		buffer := make([]byte, 261)
+		copy(buffer, []byte{0xFF, 0xD8, 0xFF})
+		assert.False(t, IsDicom(buffer))
+	})
+	t.Run("too short", func(t *testing.T) {
+		assert.False(t, IsDicom([]byte("DICM")))
+	})
+}
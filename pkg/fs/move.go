@@ -14,6 +14,12 @@ func Move(src, dest string) (err error) {
 		}
 	}()
 
+	if err := Writable(src); err != nil {
+		return err
+	} else if err := Writable(dest); err != nil {
+		return err
+	}
+
 	if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
 		return err
 	}
@@ -0,0 +1,85 @@
+package fs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrProtected is returned when trying to change files in a protected path.
+var ErrProtected = errors.New("path is read-only")
+
+var protectedPaths []string
+var protectedMutex = sync.RWMutex{}
+
+// SetProtected sets the paths in which no files may be created, changed, or deleted,
+// e.g. for originals on a snapshotting network share. Calling it without arguments removes the protection.
+func SetProtected(paths ...string) {
+	protectedMutex.Lock()
+	defer protectedMutex.Unlock()
+
+	protectedPaths = nil
+
+	for _, p := range paths {
+		if p = Abs(p); p != "" && p != string(os.PathSeparator) {
+			protectedPaths = append(protectedPaths, p)
+		}
+	}
+}
+
+// Protected tests if a file or folder is inside a protected path.
+func Protected(fileName string) bool {
+	if fileName == "" {
+		return false
+	}
+
+	protectedMutex.RLock()
+	defer protectedMutex.RUnlock()
+
+	if len(protectedPaths) == 0 {
+		return false
+	}
+
+	fileName = Abs(fileName)
+
+	for _, p := range protectedPaths {
+		if fileName == p || strings.HasPrefix(fileName, p+string(os.PathSeparator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Writable returns ErrProtected if files in the path must not be created, changed, or deleted.
+func Writable(fileName string) error {
+	if Protected(fileName) {
+		return ErrProtected
+	}
+
+	return nil
+}
+
+// Remove deletes a file unless it is inside a protected path.
+func Remove(fileName string) error {
+	if err := Writable(fileName); err != nil {
+		return err
+	}
+
+	return os.Remove(fileName)
+}
+
+// WriteFile writes data to a file unless it is inside a protected path.
+func WriteFile(fileName string, data []byte, perm os.FileMode) error {
+	if err := Writable(fileName); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, data, perm)
+}
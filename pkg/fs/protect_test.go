@@ -0,0 +1,101 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtected(t *testing.T) {
+	dir := t.TempDir()
+
+	SetProtected(dir)
+	defer SetProtected()
+
+	assert.True(t, Protected(dir))
+	assert.True(t, Protected(filepath.Join(dir, "2021/photo.jpg")))
+	assert.False(t, Protected(dir+"-sidecar/photo.jpg"))
+	assert.False(t, Protected(filepath.Dir(dir)))
+	assert.False(t, Protected(""))
+
+	SetProtected()
+
+	assert.False(t, Protected(dir))
+}
+
+func TestSetProtected(t *testing.T) {
+	t.Run("Root", func(t *testing.T) {
+		SetProtected("/", "")
+		defer SetProtected()
+
+		assert.False(t, Protected("/tmp/photo.jpg"))
+	})
+}
+
+func TestWritable(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, Writable(filepath.Join(dir, "photo.jpg")))
+
+	SetProtected(dir)
+	defer SetProtected()
+
+	assert.Equal(t, ErrProtected, Writable(filepath.Join(dir, "photo.jpg")))
+	assert.NoError(t, Writable(filepath.Join(os.TempDir(), "photo.jpg")))
+}
+
+func TestProtectedWrites(t *testing.T) {
+	originals := t.TempDir()
+	storage := t.TempDir()
+
+	fileName := filepath.Join(originals, "photo.jpg")
+
+	if err := os.WriteFile(fileName, []byte("original"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	SetProtected(originals)
+	defer SetProtected()
+
+	t.Run("WriteFile", func(t *testing.T) {
+		assert.Equal(t, ErrProtected, WriteFile(filepath.Join(originals, "photo.yml"), []byte("Title: Test"), os.ModePerm))
+		assert.NoFileExists(t, filepath.Join(originals, "photo.yml"))
+		assert.NoError(t, WriteFile(filepath.Join(storage, "sidecar/photo.yml"), []byte("Title: Test"), os.ModePerm))
+		assert.FileExists(t, filepath.Join(storage, "sidecar/photo.yml"))
+	})
+	t.Run("Overwrite", func(t *testing.T) {
+		assert.False(t, Overwrite(fileName, []byte("changed")))
+	})
+	t.Run("Copy", func(t *testing.T) {
+		assert.Equal(t, ErrProtected, Copy(fileName, filepath.Join(originals, "copy.jpg")))
+		assert.NoError(t, Copy(fileName, filepath.Join(storage, "copy.jpg")))
+	})
+	t.Run("Move", func(t *testing.T) {
+		assert.Equal(t, ErrProtected, Move(fileName, filepath.Join(storage, "moved.jpg")))
+		assert.Equal(t, ErrProtected, Move(filepath.Join(storage, "copy.jpg"), filepath.Join(originals, "moved.jpg")))
+	})
+	t.Run("Remove", func(t *testing.T) {
+		assert.Equal(t, ErrProtected, Remove(fileName))
+	})
+	t.Run("PathWritable", func(t *testing.T) {
+		assert.False(t, PathWritable(originals))
+		assert.True(t, PathWritable(storage))
+	})
+
+	// The original must not have been changed.
+	if data, err := os.ReadFile(fileName); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Equal(t, "original", string(data))
+	}
+
+	entries, err := os.ReadDir(originals)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, entries, 1)
+}
@@ -0,0 +1,107 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// KmPerDegree is the distance in km between two latitudes that are one degree apart.
+const KmPerDegree = 2 * math.Pi * EarthRadiusKm / 360
+
+// Bounds represents a rectangular area, e.g. the map viewport. If East is less than West,
+// the area crosses the antimeridian.
+type Bounds struct {
+	North float64
+	East  float64
+	South float64
+	West  float64
+}
+
+// ParseBounds parses comma-separated north, east, south, and west coordinates in degrees.
+// East and west are not swapped, so that viewports crossing the antimeridian keep their meaning.
+func ParseBounds(s string) (b Bounds, err error) {
+	values, err := parseFloats(s)
+
+	if err != nil {
+		return b, err
+	} else if len(values) != 4 {
+		return b, fmt.Errorf("bounds must have 4 coordinates")
+	}
+
+	b = Bounds{North: values[0], East: values[1], South: values[2], West: values[3]}
+
+	if b.North < b.South {
+		b.North, b.South = b.South, b.North
+	}
+
+	if b.North > 90 || b.South < -90 || b.East > 180 || b.East < -180 || b.West > 180 || b.West < -180 {
+		return b, fmt.Errorf("bounds out of range")
+	}
+
+	return b, nil
+}
+
+// Antimeridian tests if the bounds cross the antimeridian.
+func (b Bounds) Antimeridian() bool {
+	return b.East < b.West
+}
+
+// LngRanges returns the longitude ranges as west and east pairs, which are two if the bounds cross the antimeridian.
+func (b Bounds) LngRanges() [][2]float64 {
+	if b.Antimeridian() {
+		return [][2]float64{{b.West, 180}, {-180, b.East}}
+	}
+
+	return [][2]float64{{b.West, b.East}}
+}
+
+// Contains tests if the position is within the bounds.
+func (b Bounds) Contains(p Position) bool {
+	if p.Lat > b.North || p.Lat < b.South {
+		return false
+	}
+
+	for _, r := range b.LngRanges() {
+		if p.Lng >= r[0] && p.Lng <= r[1] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Bounds returns the bounds of a circle around the position with a radius in km.
+func (p Position) Bounds(km float64) Bounds {
+	lat := km / KmPerDegree
+	lng := 180.0
+
+	if c := math.Cos(DegToRad(p.Lat)); c > 0 {
+		lng = math.Min(lat/c, 180)
+	}
+
+	return Bounds{
+		North: math.Min(p.Lat+lat, 90),
+		East:  math.Min(p.Lng+lng, 180),
+		South: math.Max(p.Lat-lat, -90),
+		West:  math.Max(p.Lng-lng, -180),
+	}
+}
+
+// parseFloats parses a list of comma-separated numbers.
+func parseFloats(s string) (result []float64, err error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return result, fmt.Errorf("no coordinates")
+	}
+
+	for _, v := range strings.Split(s, ",") {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err != nil {
+			return result, fmt.Errorf("invalid coordinate %s", strconv.Quote(v))
+		} else {
+			result = append(result, f)
+		}
+	}
+
+	return result, nil
+}
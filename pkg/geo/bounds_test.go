@@ -0,0 +1,70 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBounds(t *testing.T) {
+	t.Run("Berlin", func(t *testing.T) {
+		b, err := ParseBounds("52.6, 13.6, 52.3, 13.1")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Bounds{North: 52.6, East: 13.6, South: 52.3, West: 13.1}, b)
+		assert.True(t, b.Contains(Position{Lat: 52.52437, Lng: 13.41053}))
+		assert.False(t, b.Contains(Position{Lat: 31.22222, Lng: 121.45806}))
+	})
+	t.Run("Swapped", func(t *testing.T) {
+		b, err := ParseBounds("52.3,13.6,52.6,13.1")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Bounds{North: 52.6, East: 13.6, South: 52.3, West: 13.1}, b)
+		assert.False(t, b.Antimeridian())
+		assert.Equal(t, [][2]float64{{13.1, 13.6}}, b.LngRanges())
+	})
+	t.Run("Antimeridian", func(t *testing.T) {
+		b, err := ParseBounds("-15,-175,-20,175")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Bounds{North: -15, East: -175, South: -20, West: 175}, b)
+		assert.True(t, b.Antimeridian())
+		assert.Equal(t, [][2]float64{{175, 180}, {-180, -175}}, b.LngRanges())
+		assert.True(t, b.Contains(Position{Lat: -18.1416, Lng: 178.4419}))
+		assert.True(t, b.Contains(Position{Lat: -18.1416, Lng: -178.4419}))
+		assert.False(t, b.Contains(Position{Lat: -18.1416, Lng: 0}))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseBounds("52.6,13.6,52.3")
+		assert.Error(t, err)
+		_, err = ParseBounds("52.6,13.6,52.3,foo")
+		assert.Error(t, err)
+		_, err = ParseBounds("95,13.6,52.3,13.1")
+		assert.Error(t, err)
+		_, err = ParseBounds("52.6,13.6,52.3,-190")
+		assert.Error(t, err)
+		_, err = ParseBounds("")
+		assert.Error(t, err)
+	})
+}
+
+func TestPosition_Bounds(t *testing.T) {
+	berlin := Position{Lat: 52.52437, Lng: 13.41053}
+	b := berlin.Bounds(5)
+
+	assert.InDelta(t, 52.56934, b.North, 0.0001)
+	assert.InDelta(t, 52.47940, b.South, 0.0001)
+	assert.InDelta(t, 13.48441, b.East, 0.0001)
+	assert.InDelta(t, 13.33665, b.West, 0.0001)
+	assert.InDelta(t, 5, Km(berlin, Position{Lat: b.North, Lng: berlin.Lng}), 0.01)
+	assert.InDelta(t, 5, Km(berlin, Position{Lat: berlin.Lat, Lng: b.East}), 0.01)
+}
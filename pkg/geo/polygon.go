@@ -0,0 +1,72 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// PolygonMaxVertices is the maximum number of polygon vertices in search filters.
+const PolygonMaxVertices = 100
+
+// Polygon represents an area enclosed by straight lines between its vertices.
+type Polygon []Position
+
+// ParsePolygon parses comma-separated pairs of latitude and longitude in degrees.
+func ParsePolygon(s string) (p Polygon, err error) {
+	values, err := parseFloats(s)
+
+	if err != nil {
+		return p, err
+	} else if len(values)%2 != 0 {
+		return p, fmt.Errorf("polygon coordinates must be pairs of latitude and longitude")
+	} else if n := len(values) / 2; n < 3 {
+		return p, fmt.Errorf("polygon must have at least 3 vertices")
+	} else if n > PolygonMaxVertices {
+		return p, fmt.Errorf("polygon must not have more than %d vertices", PolygonMaxVertices)
+	}
+
+	for i := 0; i < len(values); i += 2 {
+		lat, lng := values[i], values[i+1]
+
+		if lat > 90 || lat < -90 || lng > 180 || lng < -180 {
+			return p, fmt.Errorf("polygon coordinates out of range")
+		}
+
+		p = append(p, Position{Lat: lat, Lng: lng})
+	}
+
+	return p, nil
+}
+
+// Bounds returns the smallest bounds that contain the polygon.
+func (p Polygon) Bounds() (b Bounds) {
+	if len(p) == 0 {
+		return b
+	}
+
+	b = Bounds{North: p[0].Lat, East: p[0].Lng, South: p[0].Lat, West: p[0].Lng}
+
+	for _, v := range p[1:] {
+		b.North = math.Max(b.North, v.Lat)
+		b.East = math.Max(b.East, v.Lng)
+		b.South = math.Min(b.South, v.Lat)
+		b.West = math.Min(b.West, v.Lng)
+	}
+
+	return b
+}
+
+// Contains tests if the position is inside the polygon using the even-odd rule.
+func (p Polygon) Contains(pos Position) bool {
+	inside := false
+
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[i], p[j]
+
+		if (a.Lat > pos.Lat) != (b.Lat > pos.Lat) && pos.Lng < a.Lng+(pos.Lat-a.Lat)*(b.Lng-a.Lng)/(b.Lat-a.Lat) {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
@@ -0,0 +1,36 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePolygon(t *testing.T) {
+	t.Run("Triangle", func(t *testing.T) {
+		p, err := ParsePolygon("52.6,13.3,52.6,13.5,52.4,13.4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, p, 3)
+		assert.Equal(t, Bounds{North: 52.6, East: 13.5, South: 52.4, West: 13.3}, p.Bounds())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParsePolygon("52.6,13.3,52.6,13.5")
+		assert.Error(t, err)
+		_, err = ParsePolygon("52.6,13.3,52.6,13.5,52.4")
+		assert.Error(t, err)
+		_, err = ParsePolygon("52.6,13.3,52.6,193.5,52.4,13.4")
+		assert.Error(t, err)
+	})
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	p := Polygon{{Lat: 52.6, Lng: 13.3}, {Lat: 52.6, Lng: 13.5}, {Lat: 52.4, Lng: 13.4}}
+
+	assert.True(t, p.Contains(Position{Lat: 52.55, Lng: 13.4}))
+	assert.False(t, p.Contains(Position{Lat: 52.45, Lng: 13.3}))
+	assert.False(t, p.Contains(Position{Lat: 52.7, Lng: 13.4}))
+}
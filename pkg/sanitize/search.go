@@ -26,8 +26,15 @@ func SearchString(s string) string {
 	s = strings.ReplaceAll(s, "%", "*")
 	s = strings.ReplaceAll(s, "**", "*")
 
+	// Keep comparison operators in front of numbers, e.g. >20 or <=50MB.
+	op := ""
+
+	if i := strings.IndexFunc(s, func(r rune) bool { return r != '<' && r != '>' && r != '=' }); i > 0 && i <= 2 && s[i] >= '0' && s[i] <= '9' {
+		op, s = s[:i], s[i:]
+	}
+
 	// Trim.
-	return strings.Trim(s, "&|\\<>\n\r\t")
+	return op + strings.Trim(s, "&|\\<>\n\r\t")
 }
 
 // SearchQuery replaces search operator with default symbols.
@@ -19,6 +19,12 @@ func TestSearchString(t *testing.T) {
 		q := SearchString(" Flowers in the Park ")
 		assert.Equal(t, " Flowers in the Park ", q)
 	})
+	t.Run("Comparison", func(t *testing.T) {
+		assert.Equal(t, ">20", SearchString(">20"))
+		assert.Equal(t, "<=50MB", SearchString("<=50MB"))
+		assert.Equal(t, "foo", SearchString("<foo>"))
+		assert.Equal(t, "20", SearchString(">>>20"))
+	})
 }
 
 func TestSearchQuery(t *testing.T) {